@@ -6,18 +6,17 @@
 package cmd
 
 import (
-	"bufio"
+	"context"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
-	"strconv"
 	"strings"
 
 	"github.com/connerohnesorge/spectr/internal/archive"
+	"github.com/connerohnesorge/spectr/internal/audit"
 	"github.com/connerohnesorge/spectr/internal/config"
 	"github.com/connerohnesorge/spectr/internal/discovery"
-	"github.com/connerohnesorge/spectr/internal/markdown"
 	"github.com/connerohnesorge/spectr/internal/parsers"
 	"github.com/connerohnesorge/spectr/internal/specterrs"
 	"github.com/connerohnesorge/spectr/internal/validation"
@@ -69,7 +68,19 @@ func (c *AcceptCmd) Run() error {
 		return err
 	}
 
-	return c.processChange(projectRoot, changeID)
+	if err := c.processChange(projectRoot, changeID); err != nil {
+		return err
+	}
+	if c.DryRun {
+		return nil
+	}
+
+	return recordAudit(
+		projectRoot,
+		audit.OpAccept,
+		[]string{"changes/" + changeID},
+		nil,
+	)
 }
 
 // processChange handles the conversion of tasks.md to tasks.jsonc.
@@ -86,6 +97,11 @@ func (c *AcceptCmd) processChange(
 		return err
 	}
 
+	// Refuse to overwrite a tasks.jsonc written by a newer spectr
+	if err := checkExistingTasksVersion(changeDir); err != nil {
+		return err
+	}
+
 	// Load project configuration (optional)
 	cfg, err := config.LoadConfig(projectRoot)
 	if err != nil {
@@ -109,6 +125,12 @@ func (c *AcceptCmd) processChange(
 		return depErr
 	}
 
+	if !c.DryRun {
+		if err := captureSnapshotIfMissing(projectRoot, changeDir); err != nil {
+			return err
+		}
+	}
+
 	tasks, err := parseTasksMd(tasksMdPath)
 	if err != nil {
 		return fmt.Errorf(
@@ -333,6 +355,7 @@ func (*AcceptCmd) runValidation(
 	fmt.Println("Validating change...")
 
 	report, err := archive.ValidatePreArchive(
+		context.Background(),
 		changeDir,
 	)
 	if err != nil {
@@ -403,6 +426,11 @@ func (c *AcceptCmd) resolveChangeID(
 		return result.ChangeID, nil
 	}
 
+	detected, err := detectChangeID(projectRoot)
+	if err != nil || detected != "" {
+		return detected, err
+	}
+
 	if c.NoInteractive {
 		return "", &specterrs.MissingChangeIDError{}
 	}
@@ -410,138 +438,12 @@ func (c *AcceptCmd) resolveChangeID(
 	return selectChangeInteractive(projectRoot)
 }
 
-// taskParseState tracks state during tasks.md parsing.
-type taskParseState struct {
-	lastSectionNum   int    // Track for section numbering continuity
-	sectionNum       string // Current section number as string
-	sectionName      string // Current section name
-	taskSeqInSection int    // Task sequence within section
-	globalTaskSeq    int    // For tasks with no section
-}
-
-// handleSection processes a section header line and updates state.
-func (s *taskParseState) handleSection(
-	name, number string,
-) {
-	if number != "" {
-		// Numbered section - use explicit number
-		num, _ := strconv.Atoi(number)
-		s.lastSectionNum = num
-		s.sectionNum = number
-	} else {
-		// Unnumbered section - increment from last
-		s.lastSectionNum++
-		s.sectionNum = strconv.Itoa(s.lastSectionNum)
-	}
-	s.sectionName = strings.TrimSpace(name)
-	s.taskSeqInSection = 0
-}
-
-// generateTaskID creates a task ID based on current state and match.
-func (s *taskParseState) generateTaskID(
-	matchNumber string,
-) string {
-	if s.sectionNum == "" {
-		// No section context - global sequential
-		s.globalTaskSeq++
-
-		return strconv.Itoa(s.globalTaskSeq)
-	}
-
-	s.taskSeqInSection++
-	expectedID := fmt.Sprintf(
-		"%s.%d",
-		s.sectionNum,
-		s.taskSeqInSection,
-	)
-
-	if matchNumber != "" &&
-		matchNumber == expectedID {
-		return matchNumber // Explicit matches expected
-	}
-
-	return expectedID // Auto-generate or override
-}
-
-// createTask builds a Task from parsed match and current state.
-func (s *taskParseState) createTask(
-	match *markdown.FlexibleTaskMatch,
-) parsers.Task {
-	taskID := s.generateTaskID(match.Number)
-
-	var status parsers.TaskStatusValue
-	if match.Status == ' ' {
-		status = parsers.TaskStatusPending
-	} else {
-		status = parsers.TaskStatusCompleted
-	}
-
-	return parsers.Task{
-		ID:      taskID,
-		Section: s.sectionName,
-		Description: strings.TrimSpace(
-			match.Content,
-		),
-		Status: status,
-	}
-}
-
-// parseTasksMd parses tasks.md and returns a slice of Task structs.
-// It extracts section headers (## lines), task IDs, descriptions, and status
-// from the markdown structure. Supports flexible task formats:
-//   - "- [ ] 1.1 Task" (decimal ID)
-//   - "- [ ] 1. Task" (simple dot ID)
-//   - "- [ ] 1 Task" (number only ID)
-//   - "- [ ] Task" (no ID - auto-generated)
+// parseTasksMd parses tasks.md and returns a slice of Task structs (see
+// parsers.ParseTasksMarkdown).
 func parseTasksMd(
 	path string,
 ) ([]parsers.Task, error) {
-	file, err := os.Open(path)
-	if err != nil {
-		return nil, fmt.Errorf(
-			"failed to open file: %w",
-			err,
-		)
-	}
-	defer func() { _ = file.Close() }()
-
-	var tasks []parsers.Task
-	state := &taskParseState{}
-
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := scanner.Text()
-
-		// Check for section header (numbered or unnumbered)
-		if name, number, ok := markdown.MatchAnySection(line); ok {
-			state.handleSection(name, number)
-
-			continue
-		}
-
-		// Check for task line using flexible matching
-		match, ok := markdown.MatchFlexibleTask(
-			line,
-		)
-		if !ok {
-			continue
-		}
-
-		tasks = append(
-			tasks,
-			state.createTask(match),
-		)
-	}
-
-	err = scanner.Err()
-	if err != nil {
-		return nil, fmt.Errorf(
-			"error reading file: %w",
-			err,
-		)
-	}
-
-	return tasks, nil
+	return parsers.ParseTasksMarkdown(path)
 }
 
 // sectionGroup represents a group of tasks under a common section
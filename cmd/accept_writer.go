@@ -5,7 +5,9 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -14,56 +16,16 @@ import (
 
 	"github.com/connerohnesorge/spectr/internal/config"
 	"github.com/connerohnesorge/spectr/internal/parsers"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+	"github.com/connerohnesorge/spectr/internal/txn"
 )
 
-// tasksJSONHeader is the comprehensive JSONC comment header prepended to
-// tasks.jsonc files. It explains status values, transitions, and workflow.
-const tasksJSONHeader = `// Spectr Tasks File (JSONC)
-//
-// This file contains machine-readable task definitions for a Spectr change.
-// JSONC format allows comments while maintaining JSON compatibility.
-//
-// Status Values:
-//   - "pending"     : Task has not been started yet
-//   - "in_progress" : Task is currently being worked on
-//   - "completed"   : Task has been finished and verified
-//
-// Status Transitions:
-//   pending -> in_progress -> completed
-//
-//   Tasks should only move forward through these states.
-//   Do not skip states or move backward.
-//
-// Workflow:
-//   1. BEFORE starting work on a task, mark it as "in_progress"
-//   2. Complete the implementation for the task
-//   3. Verify the work is correct and complete
-//   4. IMMEDIATELY mark the task as "completed" after verification
-//   5. Move to the next task and repeat
-//
-// IMPORTANT - Update Status Immediately:
-//   - Update each task's status IMMEDIATELY after it transitions
-//   - Do NOT batch status updates at the end of all work
-//   - Do NOT wait until all tasks are done to update statuses
-//   - This file should reflect accurate progress at any point in time
-//   - Using a single edit to mark a task completed AND the next task
-//     in_progress is allowed (this is a single transition, not batching)
-//
-// Note: This file is auto-generated by 'spectr accept'. Manual edits to
-// task status are expected, but structure changes may be overwritten.
-// The original tasks.md file is preserved alongside tasks.jsonc to retain
-// human-readable formatting, links, and context.
-//
-
-`
+// tasksJSONHeader is the JSONC comment header prepended to tasks.jsonc.
+const tasksJSONHeader = parsers.TasksJSONHeader
 
 // buildChildTasksHeader generates the JSONC header for child task files.
 func buildChildTasksHeader(changeID, parentTaskID string) string {
-	return fmt.Sprintf(`// Generated by: spectr accept %s
-// Parent change: %s
-// Parent task: %s
-
-`, changeID, changeID, parentTaskID)
+	return parsers.ChildTasksHeader(changeID, parentTaskID)
 }
 
 // writeTasksFile is a shared helper for writing TasksFile structures to disk.
@@ -73,30 +35,41 @@ func writeTasksFile(
 	tasksFile *parsers.TasksFile,
 	header string,
 ) error {
+	output, err := encodeTasksFile(tasksFile, header)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(path, output, filePerm); err != nil {
+		return fmt.Errorf(
+			"failed to write file %s: %w",
+			path,
+			err,
+		)
+	}
+
+	return nil
+}
+
+// encodeTasksFile marshals tasksFile to indented JSON behind header.
+func encodeTasksFile(
+	tasksFile *parsers.TasksFile,
+	header string,
+) ([]byte, error) {
 	jsonData, err := json.MarshalIndent(
 		tasksFile,
 		"",
 		"  ",
 	)
 	if err != nil {
-		return fmt.Errorf(
+		return nil, fmt.Errorf(
 			"failed to marshal tasks to JSON: %w",
 			err,
 		)
 	}
 
 	// Prepend the header to the JSON data
-	output := header + string(jsonData)
-
-	if err := os.WriteFile(path, []byte(output), filePerm); err != nil {
-		return fmt.Errorf(
-			"failed to write file %s: %w",
-			path,
-			err,
-		)
-	}
-
-	return nil
+	return append([]byte(header), jsonData...), nil
 }
 
 // writeTasksJSONC writes tasks to a tasks.jsonc file with header.
@@ -252,6 +225,20 @@ func buildTaskStatusMap(changeDir string) map[string]parsers.TaskStatusValue {
 	return statusMap
 }
 
+// checkExistingTasksVersion returns an error when the change already has a
+// tasks.jsonc in a format version newer than this build supports. Missing
+// or unreadable files are left to the normal accept flow.
+func checkExistingTasksVersion(changeDir string) error {
+	_, err := parsers.ReadTasksJson(filepath.Join(changeDir, "tasks.jsonc"))
+
+	var versionErr *specterrs.UnsupportedTasksVersionError
+	if errors.As(err, &versionErr) {
+		return err
+	}
+
+	return nil
+}
+
 // readRootTaskStatus reads the root tasks.jsonc and extracts statuses.
 func readRootTaskStatus(changeDir string, statusMap map[string]parsers.TaskStatusValue) error {
 	tasksJSONPath := filepath.Join(changeDir, "tasks.jsonc")
@@ -266,6 +253,9 @@ func readRootTaskStatus(changeDir string, statusMap map[string]parsers.TaskStatu
 	if err := json.Unmarshal(jsonData, &tasksFile); err != nil {
 		return err
 	}
+	if err := tasksFile.CheckVersion(tasksJSONPath); err != nil {
+		return err
+	}
 
 	for _, task := range tasksFile.Tasks {
 		statusMap[task.ID] = task.Status
@@ -439,6 +429,8 @@ func aggregateSectionStatus(
 // child tasks-{N}.jsonc files containing the actual task details.
 // If prependCfg or appendCfg are provided, those tasks are injected into
 // each child file with IDs like "N.0.X" (prepended) and "N.99.X" (appended).
+// All files are written in one transaction, so a failed write leaves the
+// previous task files in place.
 func writeHierarchicalTasksJSONC(
 	changeDir, changeID string,
 	sections []sectionGroup,
@@ -447,6 +439,7 @@ func writeHierarchicalTasksJSONC(
 ) error {
 	// Build root tasks with children references
 	rootTasks := make([]parsers.Task, 0, len(sections))
+	tx := txn.New()
 
 	for _, section := range sections {
 		// Skip empty sections
@@ -509,9 +502,11 @@ func writeHierarchicalTasksJSONC(
 		}
 
 		header := buildChildTasksHeader(changeID, section.sectionNum)
-		if err := writeTasksFile(childPath, &childFile, header); err != nil {
-			return fmt.Errorf("failed to write child file %s: %w", childFileName, err)
+		data, err := encodeTasksFile(&childFile, header)
+		if err != nil {
+			return fmt.Errorf("failed to encode child file %s: %w", childFileName, err)
 		}
+		tx.WriteFile(childPath, data, filePerm)
 	}
 
 	// Write root tasks.jsonc
@@ -522,6 +517,11 @@ func writeHierarchicalTasksJSONC(
 	}
 
 	rootPath := filepath.Join(changeDir, "tasks.jsonc")
+	data, err := encodeTasksFile(&rootFile, tasksJSONHeader)
+	if err != nil {
+		return err
+	}
+	tx.WriteFile(rootPath, data, filePerm)
 
-	return writeTasksFile(rootPath, &rootFile, tasksJSONHeader)
+	return tx.Commit(context.Background())
 }
@@ -0,0 +1,122 @@
+// Package cmd provides command-line interface implementations.
+// This file resolves old IDs of renamed specs and changes in command
+// arguments.
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/alecthomas/kong"
+	"github.com/connerohnesorge/spectr/internal/alias"
+	"github.com/connerohnesorge/spectr/internal/discovery"
+)
+
+// resolveAliasArgs replaces old IDs in the spec, change and item
+// arguments of the selected command, found by their completion
+// predictor, with the IDs they were renamed to, and warns about each.
+// Arguments naming an existing spec or change are left alone.
+func resolveAliasArgs(ctx *kong.Context) {
+	node := ctx.Selected()
+	if node == nil {
+		return
+	}
+	root, err := GetSingleRoot()
+	if err != nil {
+		return
+	}
+	aliases, err := alias.Load(filepath.Join(root.Path, "spectr"))
+	if err != nil || aliases.Empty() {
+		return
+	}
+
+	values := append([]*kong.Value{}, node.Positional...)
+	for _, flag := range node.Flags {
+		values = append(values, flag.Value)
+	}
+	for _, value := range values {
+		var kinds []alias.Kind
+		switch value.Tag.Get("predictor") {
+		case "specID":
+			kinds = []alias.Kind{alias.Spec}
+		case "changeID":
+			kinds = []alias.Kind{alias.Change}
+		case "item":
+			kinds = []alias.Kind{alias.Spec, alias.Change}
+		default:
+			continue
+		}
+		resolveAliasValue(value.Target, func(arg string) string {
+			return resolveAliasArg(root.Path, aliases, kinds, arg)
+		})
+	}
+}
+
+// resolveAliasValue applies resolve to a string, *string or []string
+// argument.
+func resolveAliasValue(target reflect.Value, resolve func(string) string) {
+	switch {
+	case target.Kind() == reflect.Pointer && !target.IsNil():
+		resolveAliasValue(target.Elem(), resolve)
+	case target.Kind() == reflect.String && target.CanSet():
+		target.SetString(resolve(target.String()))
+	case target.Kind() == reflect.Slice && target.Type().Elem().Kind() == reflect.String:
+		for i := range target.Len() {
+			if elem := target.Index(i); elem.CanSet() {
+				elem.SetString(resolve(elem.String()))
+			}
+		}
+	}
+}
+
+// resolveAliasArg returns arg with an old ID replaced by its new one. arg
+// may be an ID or a path such as spectr/specs/<id>/spec.md.
+func resolveAliasArg(
+	projectRoot string,
+	aliases *alias.Map,
+	kinds []alias.Kind,
+	arg string,
+) string {
+	id, inferred := discovery.NormalizeItemPath(arg)
+	for _, kind := range kinds {
+		if inferred != "" && inferred != string(kind) {
+			continue
+		}
+		if itemExists(projectRoot, kind, id) {
+			return arg
+		}
+	}
+
+	for _, kind := range kinds {
+		if inferred != "" && inferred != string(kind) {
+			continue
+		}
+		target, ok := aliases.Resolve(kind, id)
+		if !ok {
+			continue
+		}
+		fmt.Fprintf(os.Stderr, "warning: %s\n", alias.Warning(kind, id, target))
+		if id == arg {
+			return target
+		}
+		dir := "/" + string(kind) + "s/"
+
+		return strings.Replace(filepath.ToSlash(arg), dir+id, dir+target, 1)
+	}
+
+	return arg
+}
+
+// itemExists reports whether the project has a spec or active change id.
+func itemExists(projectRoot string, kind alias.Kind, id string) bool {
+	path := filepath.Join(projectRoot, "spectr", "changes", id, "proposal.md")
+	if kind == alias.Spec {
+		path = filepath.Join(projectRoot, "spectr", "specs", filepath.FromSlash(id), "spec.md")
+	}
+	_, err := os.Stat(path)
+
+	return err == nil
+}
@@ -0,0 +1,125 @@
+// Package cmd provides command-line interface implementations.
+// This file contains the attest command for signing and verifying the
+// approved requirement text of specs.
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/connerohnesorge/spectr/internal/attest"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+	"github.com/connerohnesorge/spectr/internal/utils"
+)
+
+// AttestCmd signs attestations of spec text and verifies them. Without a
+// subcommand, `spectr attest <spec>` signs.
+type AttestCmd struct {
+	Sign   AttestSignCmd   `cmd:"" default:"withargs" help:"Sign the requirement text of a spec"` //nolint:lll,revive // Kong struct tag with alignment
+	Verify AttestVerifyCmd `cmd:""                    help:"Verify a spec's attestation"`         //nolint:lll,revive // Kong struct tag with alignment
+}
+
+// AttestSignCmd writes and signs the attestation manifest of a spec.
+type AttestSignCmd struct {
+	SpecID  string        `arg:"" predictor:"specID" help:"Spec ID to attest"`                                                //nolint:lll,revive // Kong struct tag with alignment
+	Tool    string        `help:"Signing tool"                            name:"tool" enum:"minisign,ssh" default:"minisign"` //nolint:lll,revive // Kong struct tag with alignment
+	Key     string        `help:"Secret key (default: minisign's own key)" name:"key" type:"path"`                            //nolint:lll,revive // Kong struct tag with alignment
+	Signer  string        `help:"Signer identity (default: git user)"      name:"signer"`                                     //nolint:lll,revive // Kong struct tag with alignment
+	JSON    bool          `help:"Output as JSON"                           name:"json"`                                       //nolint:lll,revive // Kong struct tag with alignment
+	Timeout time.Duration `help:"Abort after duration (e.g. 30s)"          name:"timeout"`                                    //nolint:lll,revive // Kong struct tag with alignment
+}
+
+// Run executes the attest command.
+func (c *AttestSignCmd) Run() error {
+	root, err := GetSingleRoot()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := utils.CommandContext(c.Timeout)
+	defer cancel()
+
+	m, err := attest.Attest(ctx, root.Path, c.SpecID, attest.Options{
+		Tool:   c.Tool,
+		Key:    c.Key,
+		Signer: c.Signer,
+	}, time.Now())
+	if err != nil {
+		return utils.CommandError(ctx, "attest", c.Timeout, err)
+	}
+
+	if c.JSON {
+		data, err := json.MarshalIndent(m, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode attestation: %w", err)
+		}
+		fmt.Println(string(data))
+
+		return nil
+	}
+	fmt.Printf(
+		"Attested %d requirement(s) of %s as %s (%s)\n",
+		len(m.Requirements),
+		m.Spec,
+		m.Signer,
+		m.Tool,
+	)
+
+	return nil
+}
+
+// AttestVerifyCmd verifies the signature of a spec's attestation and
+// compares it with the spec.
+type AttestVerifyCmd struct {
+	SpecID  string        `arg:"" predictor:"specID" help:"Spec ID to verify"`                             //nolint:lll,revive // Kong struct tag with alignment
+	Key     string        `help:"minisign public key, or SSH allowed signers file" name:"key" required:""` //nolint:lll,revive // Kong struct tag with alignment
+	JSON    bool          `help:"Output as JSON"                                   name:"json"`            //nolint:lll,revive // Kong struct tag with alignment
+	Timeout time.Duration `help:"Abort after duration (e.g. 30s)"                  name:"timeout"`         //nolint:lll,revive // Kong struct tag with alignment
+}
+
+// Run executes the attest verify command.
+func (c *AttestVerifyCmd) Run() error {
+	root, err := GetSingleRoot()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := utils.CommandContext(c.Timeout)
+	defer cancel()
+
+	res, err := attest.Verify(ctx, root.Path, c.SpecID, c.Key)
+	if err != nil {
+		return utils.CommandError(ctx, "attest verify", c.Timeout, err)
+	}
+
+	if c.JSON {
+		data, err := json.MarshalIndent(res, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode verification: %w", err)
+		}
+		fmt.Println(string(data))
+	} else {
+		printVerification(res)
+	}
+
+	if count := len(res.Drift) + len(res.Unattested); count > 0 {
+		return &specterrs.AttestationDriftError{Spec: res.Spec, Count: count}
+	}
+
+	return nil
+}
+
+// printVerification renders a verified attestation and its drift.
+func printVerification(res *attest.Verification) {
+	fmt.Printf("Good signature by %s on %s (%s)\n", res.Signer, res.Signed, res.Tool)
+	for _, drift := range res.Drift {
+		fmt.Printf("%s (%s)\n", drift.Requirement, drift.Kind)
+		if drift.Diff != "" {
+			fmt.Println(drift.Diff)
+		}
+	}
+	for _, name := range res.Unattested {
+		fmt.Printf("%s (unattested)\n", name)
+	}
+}
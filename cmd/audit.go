@@ -0,0 +1,134 @@
+// Package cmd provides command-line interface implementations.
+// This file contains the audit command for reviewing the operation log.
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/connerohnesorge/spectr/internal/audit"
+)
+
+// shortHashLen is how many hex digits of the head hash are printed.
+const shortHashLen = 12
+
+// AuditCmd represents the audit command with subcommands.
+type AuditCmd struct {
+	Show AuditShowCmd `cmd:"" help:"Show recorded operations"`
+}
+
+// AuditShowCmd prints the audit log after verifying its hash chain.
+type AuditShowCmd struct {
+	Since string `help:"Only entries since a date, time or duration (e.g. 2025-01-31, 7d)" name:"since"` //nolint:lll,revive // Kong struct tag with alignment
+	JSON  bool   `help:"Output as JSON"                                                     name:"json"` //nolint:lll,revive // Kong struct tag with alignment
+}
+
+// Run executes the audit show command.
+func (c *AuditShowCmd) Run() error {
+	root, err := GetSingleRoot()
+	if err != nil {
+		return err
+	}
+
+	entries, err := audit.Read(
+		filepath.Join(root.SpectrDir(), audit.LogFileName),
+	)
+	if err != nil {
+		return err
+	}
+
+	head := ""
+	if len(entries) > 0 {
+		head = entries[len(entries)-1].Hash
+	}
+
+	if c.Since != "" {
+		since, err := audit.ParseSince(c.Since, time.Now())
+		if err != nil {
+			return err
+		}
+		entries = audit.Since(entries, since)
+	}
+
+	if c.JSON {
+		if entries == nil {
+			entries = []audit.Entry{}
+		}
+		data, err := json.MarshalIndent(entries, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode audit entries: %w", err)
+		}
+		fmt.Println(string(data))
+
+		return nil
+	}
+
+	if len(entries) == 0 {
+		fmt.Println("No audit entries")
+	} else {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "SEQ\tTIME\tACTOR\tOPERATION\tITEMS\tDETAILS")
+		for _, entry := range entries {
+			fmt.Fprintf(
+				w,
+				"%d\t%s\t%s\t%s\t%s\t%s\n",
+				entry.Seq,
+				entry.Time.Format(time.RFC3339),
+				entry.Actor,
+				entry.Operation,
+				strings.Join(entry.Items, ", "),
+				formatAuditDetails(entry.Details),
+			)
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	}
+
+	if head != "" {
+		fmt.Printf("\nChain verified; head %s\n", head[:shortHashLen])
+	}
+
+	return nil
+}
+
+// formatAuditDetails renders details as sorted key=value pairs.
+func formatAuditDetails(details map[string]string) string {
+	keys := make([]string, 0, len(details))
+	for key := range details {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+	for i, key := range keys {
+		pairs[i] = key + "=" + details[key]
+	}
+
+	return strings.Join(pairs, " ")
+}
+
+// recordAudit appends an entry to the project's audit log.
+func recordAudit(
+	projectRoot, op string,
+	items []string,
+	details map[string]string,
+) error {
+	err := audit.Record(
+		filepath.Join(projectRoot, "spectr"),
+		op,
+		items,
+		details,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record audit entry: %w", err)
+	}
+
+	return nil
+}
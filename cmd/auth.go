@@ -0,0 +1,200 @@
+// Package cmd provides command-line interface implementations.
+// This file contains the auth command for managing hosting tokens.
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/credentials"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+	"github.com/connerohnesorge/spectr/internal/tui"
+)
+
+// AuthCmd represents the auth command with subcommands.
+type AuthCmd struct {
+	Login  AuthLoginCmd  `cmd:"" help:"Store a token for a provider"`
+	Logout AuthLogoutCmd `cmd:"" help:"Remove a stored token"`
+	Status AuthStatusCmd `cmd:"" help:"Show where tokens resolve from"`
+}
+
+// AuthLoginCmd stores a token in the OS keychain or the encrypted file.
+type AuthLoginCmd struct {
+	Provider  string `arg:"" enum:"github,gitlab,gitea,jira" help:"Provider (github, gitlab, gitea, jira)"`                   //nolint:lll,revive // Kong struct tag with alignment
+	Host      string `                                       help:"Host (default: github.com, gitlab.com)" name:"host"`       //nolint:lll,revive // Kong struct tag with alignment
+	WithToken bool   `                                       help:"Read the token from stdin"              name:"with-token"` //nolint:lll,revive // Kong struct tag with alignment
+}
+
+// AuthLogoutCmd removes a stored token.
+type AuthLogoutCmd struct {
+	Provider string `arg:"" enum:"github,gitlab,gitea,jira" help:"Provider (github, gitlab, gitea, jira)"`             //nolint:lll,revive // Kong struct tag with alignment
+	Host     string `                                       help:"Host (default: github.com, gitlab.com)" name:"host"` //nolint:lll,revive // Kong struct tag with alignment
+}
+
+// AuthStatusCmd shows which source each provider's token resolves from.
+type AuthStatusCmd struct {
+	Host string `help:"Host for self-hosted providers" name:"host"` //nolint:lll,revive // Kong struct tag with alignment
+}
+
+// Run executes the auth login command.
+func (c *AuthLoginCmd) Run() error {
+	provider, host, err := providerAndHost(c.Provider, c.Host)
+	if err != nil {
+		return err
+	}
+
+	token, err := readToken(os.Stdin, provider, c.WithToken)
+	if err != nil {
+		return err
+	}
+
+	store, err := credentials.Save(
+		context.Background(),
+		credentials.DefaultStores(),
+		provider,
+		host,
+		token,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to store token: %w", err)
+	}
+
+	fmt.Printf("Stored %s token for %s in %s\n", provider, host, store)
+
+	return nil
+}
+
+// Run executes the auth logout command.
+func (c *AuthLogoutCmd) Run() error {
+	provider, host, err := providerAndHost(c.Provider, c.Host)
+	if err != nil {
+		return err
+	}
+
+	err = credentials.Forget(
+		context.Background(),
+		credentials.DefaultStores(),
+		provider,
+		host,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to remove token: %w", err)
+	}
+
+	fmt.Printf("Removed stored %s token for %s\n", provider, host)
+
+	return nil
+}
+
+// Run executes the auth status command.
+func (c *AuthStatusCmd) Run() error {
+	resolver := credentials.NewResolver()
+
+	for _, provider := range credentials.Providers {
+		host := c.Host
+		if provider.DefaultHost() != "" {
+			host = provider.DefaultHost()
+		}
+
+		cred, err := resolver.Resolve(
+			context.Background(),
+			provider,
+			host,
+			"",
+		)
+
+		var notFound *specterrs.CredentialNotFoundError
+		var hostRequired *specterrs.ProviderHostRequiredError
+		switch {
+		case err == nil:
+			fmt.Printf(
+				"%-7s %-20s %s (%s)\n",
+				provider,
+				cred.Host,
+				maskToken(cred.Token),
+				cred.Source,
+			)
+		case errors.As(err, &notFound):
+			fmt.Printf("%-7s %-20s not configured\n", provider, host)
+		case errors.As(err, &hostRequired):
+			fmt.Printf("%-7s %-20s pass --host to check\n", provider, "-")
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
+// providerAndHost parses the provider argument and applies its default
+// host.
+func providerAndHost(
+	name, host string,
+) (credentials.Provider, string, error) {
+	provider, err := credentials.ParseProvider(name)
+	if err != nil {
+		return "", "", err
+	}
+
+	if host == "" {
+		host = provider.DefaultHost()
+	}
+	if host == "" {
+		return "", "", &specterrs.ProviderHostRequiredError{
+			Provider: string(provider),
+		}
+	}
+
+	return provider, host, nil
+}
+
+// readToken reads a token from in. Without --with-token on a terminal it
+// prompts first, unless --no-input is set; input is not hidden, so prefer
+// piping the token in.
+func readToken(
+	in *os.File,
+	provider credentials.Provider,
+	withToken bool,
+) (string, error) {
+	var reader io.Reader = in
+	if !withToken && tui.IsTerminal(in) {
+		if tui.NoInput() {
+			return "", &specterrs.MissingArgumentError{
+				Argument: "token (pipe it in with --with-token)",
+			}
+		}
+		fmt.Printf("Paste your %s token: ", provider)
+		line, err := bufio.NewReader(in).ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return "", err
+		}
+		reader = strings.NewReader(line)
+	}
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return "", fmt.Errorf("failed to read token: %w", err)
+	}
+
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return "", errors.New("no token provided")
+	}
+
+	return token, nil
+}
+
+// maskToken shows only the first four characters of a token.
+func maskToken(token string) string {
+	const visible = 4
+	if len(token) <= visible {
+		return strings.Repeat("*", len(token))
+	}
+
+	return token[:visible] + strings.Repeat("*", 8)
+}
@@ -0,0 +1,125 @@
+// Package cmd provides command-line interface implementations.
+// This file contains the bench command for catching performance
+// regressions.
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+
+	"github.com/connerohnesorge/spectr/internal/bench"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+// BenchCmd benchmarks parsing, validating and listing the current project
+// and compares the results with a saved baseline.
+type BenchCmd struct {
+	Save      bool    `help:"Save the results as the new baseline"       name:"save"`                   //nolint:lll,revive // Kong struct tag with alignment
+	Baseline  string  `help:"Baseline file (default: spectr/bench.json)" name:"baseline"  type:"path"`  //nolint:lll,revive // Kong struct tag with alignment
+	Threshold float64 `help:"Allowed slowdown or extra allocations (%)"  name:"threshold" default:"10"` //nolint:lll,revive // Kong struct tag with alignment
+	Count     int     `help:"Runs per benchmark; the fastest is kept"    name:"count"     default:"3"`  //nolint:lll,revive // Kong struct tag with alignment
+	JSON      bool    `help:"Output as JSON"                             name:"json"`                   //nolint:lll,revive // Kong struct tag with alignment
+}
+
+// Run executes the bench command.
+func (c *BenchCmd) Run() error {
+	if c.Threshold < 0 {
+		return &specterrs.InvalidRegressionThresholdError{Value: c.Threshold}
+	}
+
+	root, err := GetSingleRoot()
+	if err != nil {
+		return err
+	}
+
+	baselinePath := c.Baseline
+	if baselinePath == "" {
+		baselinePath = filepath.Join(root.SpectrDir(), bench.BaselineFileName)
+	}
+	baseline, err := bench.LoadBaseline(baselinePath)
+	if err != nil {
+		return err
+	}
+
+	benchmarks, err := bench.Benchmarks(root.Path)
+	if err != nil {
+		return err
+	}
+	results, err := bench.Run(benchmarks, c.Count)
+	if err != nil {
+		return err
+	}
+
+	if c.Save {
+		if err := bench.SaveBaseline(baselinePath, results); err != nil {
+			return err
+		}
+		// The new baseline is what later runs compare against, not this one
+		baseline = nil
+	}
+
+	comparisons := bench.Compare(results, baseline, c.Threshold)
+
+	if c.JSON {
+		data, err := json.MarshalIndent(comparisons, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode benchmark results: %w", err)
+		}
+		fmt.Println(string(data))
+	} else if err := printComparisons(comparisons); err != nil {
+		return err
+	}
+
+	if c.Save {
+		if !c.JSON {
+			fmt.Printf("\nSaved baseline to %s\n", baselinePath)
+		}
+
+		return nil
+	}
+
+	var regressed []string
+	for _, comparison := range comparisons {
+		if comparison.Regressed {
+			regressed = append(regressed, comparison.Current.Name)
+		}
+	}
+	if len(regressed) > 0 {
+		return &specterrs.BenchRegressionError{
+			Names:     regressed,
+			Threshold: c.Threshold,
+		}
+	}
+
+	return nil
+}
+
+// printComparisons prints the results as a table, with the change from
+// the baseline when there is one.
+func printComparisons(comparisons []bench.Comparison) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "BENCHMARK\tTIME/OP\tALLOCS/OP\tCHANGE")
+	for _, c := range comparisons {
+		change := "no baseline"
+		if c.Baseline != nil {
+			change = fmt.Sprintf("%+.1f%% time, %+.1f%% allocs", c.TimeDelta, c.AllocsDelta)
+			if c.Regressed {
+				change += "  REGRESSED"
+			}
+		}
+		fmt.Fprintf(
+			w,
+			"%s\t%s\t%d\t%s\n",
+			c.Current.Name,
+			time.Duration(c.Current.NsPerOp),
+			c.Current.AllocsPerOp,
+			change,
+		)
+	}
+
+	return w.Flush()
+}
@@ -0,0 +1,74 @@
+// Package cmd provides command-line interface implementations.
+// This file contains the bot command, which runs spectr in CI as a review
+// assistant for pull requests.
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/connerohnesorge/spectr/internal/pr"
+	"github.com/connerohnesorge/spectr/internal/utils"
+)
+
+// BotCmd groups the commands meant for CI.
+type BotCmd struct {
+	Review BotReviewCmd `cmd:"" help:"Summarize a PR's requirement changes"`
+}
+
+// BotReviewCmd comments a PR's requirement-level spec diffs and sets a
+// commit status from their validation.
+type BotReviewCmd struct {
+	PR      int           `help:"Pull request number"                                     name:"pr"      required:""` //nolint:lll,revive // Kong struct tag with alignment
+	Base    string        `help:"Commit to diff against (default: the PR's base)"         name:"base"`                //nolint:lll,revive // Kong struct tag with alignment
+	DryRun  bool          `help:"Print the comment and status without posting"            name:"dry-run"`             //nolint:lll,revive // Kong struct tag with alignment
+	Token   string        `help:"Hosting token (overrides env and keychain)"              name:"token"`               //nolint:lll,revive // Kong struct tag with alignment
+	Timeout time.Duration `help:"Abort after duration (e.g. 5m)"                          name:"timeout"`             //nolint:lll,revive // Kong struct tag with alignment
+}
+
+// Run executes the bot review command.
+func (c *BotReviewCmd) Run() error {
+	root, err := GetSingleRoot()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := utils.CommandContext(c.Timeout)
+	defer cancel()
+
+	result, err := pr.BotReview(ctx, pr.BotReviewConfig{
+		ProjectRoot: root.Path,
+		Number:      c.PR,
+		Base:        c.Base,
+		DryRun:      c.DryRun,
+		Token:       c.Token,
+	})
+	if err != nil {
+		return fmt.Errorf(
+			"bot review failed: %w",
+			utils.CommandError(ctx, "bot review", c.Timeout, err),
+		)
+	}
+
+	status := fmt.Sprintf(
+		"%s: %s (%s)",
+		pr.BotStatusContext,
+		result.State,
+		pr.BotStatusDescription(result.Review),
+	)
+	if c.DryRun {
+		fmt.Print(result.Comment)
+		fmt.Printf("\nStatus %s would be set on %s\n", status, result.HeadSHA)
+
+		return nil
+	}
+
+	action := "Posted"
+	if result.Updated {
+		action = "Updated"
+	}
+	fmt.Printf("%s review comment on PR #%d\n", action, c.PR)
+	fmt.Printf("Set status %s on %s\n", status, result.HeadSHA)
+
+	return nil
+}
@@ -0,0 +1,94 @@
+// Package cmd provides command-line interface implementations.
+// This file detects the current change from the git worktree or branch.
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/connerohnesorge/spectr/internal/config"
+	"github.com/connerohnesorge/spectr/internal/discovery"
+	"github.com/connerohnesorge/spectr/internal/git"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+// detectChangeID returns the change to use when none is given on the
+// command line, or "" when there is no such default. The change recorded
+// by spectr worktree wins; otherwise the checked-out branch is matched
+// against the git.change_branches patterns. A branch naming several
+// changes is reported as *specterrs.AmbiguousBranchChangeError, and one
+// naming only a prefix of a change ID as
+// *specterrs.UnknownBranchChangeError. Without git there is no default.
+func detectChangeID(dir string) (string, error) {
+	// Without git there is no worktree or branch to detect from
+	if git.Available() != nil {
+		return "", nil
+	}
+
+	ctx := context.Background()
+
+	changeID, err := git.WorktreeChangeID(ctx, dir)
+	if err == nil && changeID != "" {
+		result, err := discovery.ResolveChangeID(changeID, dir)
+		if err != nil {
+			return "", fmt.Errorf(
+				"this worktree is for change %s: %w",
+				changeID,
+				err,
+			)
+		}
+		fmt.Fprintf(
+			os.Stderr,
+			"Using change '%s' from this worktree\n\n",
+			result.ChangeID,
+		)
+
+		return result.ChangeID, nil
+	}
+
+	branch, err := git.CurrentBranch(ctx, dir)
+	if err != nil {
+		// Detection is best effort; outside git there is no branch
+		branch = ""
+	}
+	if branch == "" {
+		return "", nil
+	}
+
+	cfg, err := config.LoadConfig(dir)
+	if err != nil {
+		return "", err
+	}
+	active, err := discovery.GetActiveChangeIDs(dir)
+	if err != nil {
+		return "", err
+	}
+	candidates, err := discovery.ChangeIDsForBranch(
+		branch,
+		cfg.ChangeBranchPatterns(),
+		active,
+	)
+	if err != nil {
+		return "", err
+	}
+
+	switch len(candidates) {
+	case 0:
+		return "", nil
+	case 1:
+		fmt.Fprintf(
+			os.Stderr,
+			"Using change '%s' from branch %s\n\n",
+			candidates[0],
+			branch,
+		)
+
+		return candidates[0], nil
+	default:
+		return "", &specterrs.AmbiguousBranchChangeError{
+			Branch:     branch,
+			Candidates: candidates,
+		}
+	}
+}
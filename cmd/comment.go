@@ -0,0 +1,153 @@
+// Package cmd provides command-line interface implementations.
+// This file contains the comment command for review discussion of
+// requirements stored alongside specs.
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/connerohnesorge/spectr/internal/comment"
+)
+
+// CommentCmd adds, lists and resolves review comments on requirements.
+type CommentCmd struct {
+	Add     CommentAddCmd     `cmd:"" help:"Comment on a requirement"`
+	List    CommentListCmd    `cmd:"" help:"List a spec's comments"`
+	Resolve CommentResolveCmd `cmd:"" help:"Resolve a comment"`
+}
+
+// CommentAddCmd records a comment on a requirement.
+type CommentAddCmd struct {
+	SpecID      string `arg:"" predictor:"specID" help:"Spec ID"`                               //nolint:lll,revive // Kong struct tag with alignment
+	Requirement string `arg:"" help:"Requirement name or ID (e.g. AUTH-R1)"`                    //nolint:lll,revive // Kong struct tag with alignment
+	Text        string `arg:"" help:"Comment text"`                                             //nolint:lll,revive // Kong struct tag with alignment
+	JSON        bool   `help:"Output as JSON"                                      name:"json"` //nolint:lll,revive // Kong struct tag with alignment
+}
+
+// Run executes the comment add command.
+func (c *CommentAddCmd) Run() error {
+	root, err := GetSingleRoot()
+	if err != nil {
+		return err
+	}
+
+	thread, err := comment.Add(root.Path, c.SpecID, c.Requirement, c.Text, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if c.JSON {
+		return printCommentJSON(thread)
+	}
+	fmt.Printf("Added comment #%d on %s (%s)\n", thread.ID, thread.Current, thread.CurrentName)
+
+	return nil
+}
+
+// CommentListCmd lists the comments of a spec.
+type CommentListCmd struct {
+	SpecID string `arg:"" predictor:"specID" help:"Spec ID"`            //nolint:lll,revive // Kong struct tag with alignment
+	All    bool   `help:"Include resolved comments"        name:"all"`  //nolint:lll,revive // Kong struct tag with alignment
+	JSON   bool   `help:"Output as JSON"                   name:"json"` //nolint:lll,revive // Kong struct tag with alignment
+}
+
+// Run executes the comment list command.
+func (c *CommentListCmd) Run() error {
+	root, err := GetSingleRoot()
+	if err != nil {
+		return err
+	}
+
+	threads, err := comment.List(root.Path, c.SpecID, c.All)
+	if err != nil {
+		return err
+	}
+
+	if c.JSON {
+		return printCommentJSON(threads)
+	}
+	if len(threads) == 0 {
+		fmt.Println("No comments")
+
+		return nil
+	}
+	for i, thread := range threads {
+		if i > 0 {
+			fmt.Println()
+		}
+		printThread(thread)
+	}
+
+	return nil
+}
+
+// printThread renders one comment: a header with its requirement, author
+// and state, then the indented text.
+func printThread(thread comment.Thread) {
+	location := "(requirement removed: " + thread.Name + ")"
+	if thread.Current != "" {
+		location = thread.Current + " " + thread.CurrentName
+	}
+
+	var tags []string
+	if thread.Outdated {
+		tags = append(tags, "outdated")
+	}
+	if thread.Resolved != "" {
+		tags = append(tags, "resolved by "+thread.ResolvedBy)
+	}
+	suffix := ""
+	if len(tags) > 0 {
+		suffix = " [" + strings.Join(tags, ", ") + "]"
+	}
+
+	created := thread.Created
+	if t, err := time.Parse(time.RFC3339, created); err == nil {
+		created = t.Format(time.DateOnly)
+	}
+	fmt.Printf("#%d %s - %s, %s%s\n", thread.ID, location, thread.Author, created, suffix)
+	for line := range strings.SplitSeq(thread.Body, "\n") {
+		fmt.Println("    " + line)
+	}
+}
+
+// CommentResolveCmd marks a comment resolved.
+type CommentResolveCmd struct {
+	SpecID string `arg:"" predictor:"specID" help:"Spec ID"`                //nolint:lll,revive // Kong struct tag with alignment
+	ID     int    `arg:"" help:"Comment number from 'spectr comment list'"` //nolint:lll,revive // Kong struct tag with alignment
+	JSON   bool   `help:"Output as JSON"                       name:"json"` //nolint:lll,revive // Kong struct tag with alignment
+}
+
+// Run executes the comment resolve command.
+func (c *CommentResolveCmd) Run() error {
+	root, err := GetSingleRoot()
+	if err != nil {
+		return err
+	}
+
+	thread, err := comment.Resolve(root.Path, c.SpecID, c.ID, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if c.JSON {
+		return printCommentJSON(thread)
+	}
+	fmt.Printf("Resolved comment #%d on %s\n", thread.ID, thread.Name)
+
+	return nil
+}
+
+// printCommentJSON prints v as indented JSON.
+func printCommentJSON(v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode comments: %w", err)
+	}
+	fmt.Println(string(data))
+
+	return nil
+}
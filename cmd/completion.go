@@ -6,8 +6,13 @@ package cmd
 
 import (
 	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/connerohnesorge/spectr/internal/discovery"
+	"github.com/connerohnesorge/spectr/internal/parsers"
+	"github.com/connerohnesorge/spectr/internal/taskexec"
 	"github.com/posener/complete"
 )
 
@@ -96,3 +101,99 @@ func PredictItems() complete.Predictor {
 		},
 	)
 }
+
+// PredictTaskIDs returns a predictor that suggests the pending task IDs of
+// the change named earlier on the command line, reading child task files
+// of the hierarchical layout. Shells that can show hints get each task's
+// description alongside its ID. Returns nil on error.
+func PredictTaskIDs() complete.Predictor {
+	return complete.PredictFunc(
+		func(a complete.Args) []string {
+			projectPath, err := os.Getwd()
+			if err != nil {
+				return nil
+			}
+
+			changeIDs, err := discovery.GetActiveChangeIDs(
+				projectPath,
+			)
+			if err != nil {
+				return nil
+			}
+
+			changeID := ""
+			for _, arg := range slices.Backward(a.Completed) {
+				if slices.Contains(changeIDs, arg) {
+					changeID = arg
+
+					break
+				}
+			}
+			if changeID == "" {
+				return nil
+			}
+
+			tasks, err := taskexec.PendingTasks(filepath.Join(
+				projectPath,
+				"spectr",
+				"changes",
+				changeID,
+			))
+			if err != nil {
+				return nil
+			}
+
+			return taskCompletions(tasks, a.Last, completionShell())
+		},
+	)
+}
+
+// Shells that run spectr for completions, as far as taskCompletions needs
+// to tell them apart.
+const (
+	shellPlain = iota // no descriptions: zsh, or anything unrecognized
+	shellBash
+	shellFish
+)
+
+// completionShell guesses the shell asking for completions. Bash exports
+// COMP_TYPE to completion commands; the fish script generated by
+// "spectr completion" sets COMP_LINE but not COMP_POINT.
+func completionShell() int {
+	switch {
+	case os.Getenv("COMP_TYPE") != "":
+		return shellBash
+	case os.Getenv("COMP_POINT") == "":
+		return shellFish
+	default:
+		return shellPlain
+	}
+}
+
+// taskCompletions formats the tasks matching the word being completed.
+// Fish shows text after a tab as a description. Bash inserts a lone match
+// as typed and only lists several, so descriptions are added only when
+// more than one task matches; the common prefix bash inserts then never
+// reaches a description.
+func taskCompletions(tasks []parsers.Task, last string, shell int) []string {
+	var matches []parsers.Task
+	for _, task := range tasks {
+		if strings.HasPrefix(task.ID, last) {
+			matches = append(matches, task)
+		}
+	}
+
+	completions := make([]string, 0, len(matches))
+	for _, task := range matches {
+		switch {
+		case shell == shellFish:
+			completions = append(completions, task.ID+"\t"+task.Description)
+		case shell == shellBash && len(matches) > 1:
+			completions = append(completions, task.ID+" -- "+task.Description)
+		default:
+			completions = append(completions, task.ID)
+		}
+	}
+
+	return completions
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/connerohnesorge/spectr/internal/parsers"
+	"github.com/posener/complete"
+)
+
+func TestTaskCompletions(t *testing.T) {
+	tasks := []parsers.Task{
+		{ID: "1.1", Description: "Add parser"},
+		{ID: "1.10", Description: "Document parser"},
+		{ID: "2.1", Description: "Write tests"},
+	}
+
+	tests := []struct {
+		name  string
+		last  string
+		shell int
+		want  []string
+	}{
+		{
+			name:  "fish",
+			last:  "1.",
+			shell: shellFish,
+			want:  []string{"1.1\tAdd parser", "1.10\tDocument parser"},
+		},
+		{
+			name:  "bash with several matches",
+			last:  "1.1",
+			shell: shellBash,
+			want:  []string{"1.1 -- Add parser", "1.10 -- Document parser"},
+		},
+		{
+			name:  "bash with one match",
+			last:  "2",
+			shell: shellBash,
+			want:  []string{"2.1"},
+		},
+		{
+			name:  "plain",
+			last:  "",
+			shell: shellPlain,
+			want:  []string{"1.1", "1.10", "2.1"},
+		},
+		{
+			name:  "no match",
+			last:  "3",
+			shell: shellFish,
+			want:  []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := taskCompletions(tasks, tt.last, tt.shell)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("taskCompletions = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPredictTaskIDs(t *testing.T) {
+	projectDir := t.TempDir()
+	changeDir := filepath.Join(projectDir, "spectr", "changes", "add-auth")
+	if err := os.MkdirAll(changeDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	tasks := `{"version": 1, "tasks": [
+  {"id": "1.1", "section": "Impl", "description": "Done", "status": "completed"},
+  {"id": "1.2", "section": "Impl", "description": "Login", "status": "pending"}
+]}`
+	files := map[string]string{
+		"proposal.md": "# Change: Add auth\n",
+		"tasks.jsonc": tasks,
+	}
+	for name, content := range files {
+		err := os.WriteFile(filepath.Join(changeDir, name), []byte(content), 0o644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Chdir(projectDir)
+	t.Setenv("COMP_TYPE", "")
+	t.Setenv("COMP_POINT", "20")
+
+	got := PredictTaskIDs().Predict(complete.Args{
+		Completed: []string{"tasks", "complete", "add-auth"},
+	})
+	if want := []string{"1.2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Predict = %q, want %q", got, want)
+	}
+
+	got = PredictTaskIDs().Predict(complete.Args{
+		Completed: []string{"tasks", "complete", "unknown"},
+	})
+	if got != nil {
+		t.Errorf("Predict for unknown change = %q, want nil", got)
+	}
+}
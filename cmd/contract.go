@@ -0,0 +1,161 @@
+// Package cmd provides command-line interface implementations.
+// This file contains the contract command for pinning requirements a
+// project depends on.
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"github.com/connerohnesorge/spectr/internal/contract"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+// ContractCmd represents the contract command with subcommands.
+type ContractCmd struct {
+	Freeze ContractFreezeCmd `cmd:"" help:"Pin requirements of a spec"`
+	Check  ContractCheckCmd  `cmd:"" help:"Fail when pinned requirements changed"`
+}
+
+// ContractFreezeCmd pins requirements of a provider's spec into
+// spectr/contracts/ of the current project.
+type ContractFreezeCmd struct {
+	SpecID       string   `arg:"" predictor:"specID" help:"Spec ID to pin"`                                       //nolint:lll,revive // Kong struct tag with alignment
+	Requirements []string `help:"Requirement to pin (repeatable, default: all)" name:"requirement" short:"r"`     //nolint:lll,revive // Kong struct tag with alignment
+	Provider     string   `help:"Provider project directory (default: this project)" name:"provider" type:"path"` //nolint:lll,revive // Kong struct tag with alignment
+}
+
+// Run executes the contract freeze command.
+func (c *ContractFreezeCmd) Run() error {
+	root, err := GetSingleRoot()
+	if err != nil {
+		return err
+	}
+
+	providerDir := root.Path
+	relProvider := ""
+	if c.Provider != "" {
+		providerDir = c.Provider
+		relProvider, err = filepath.Rel(root.Path, c.Provider)
+		if err != nil {
+			relProvider = c.Provider
+		}
+		relProvider = filepath.ToSlash(relProvider)
+	}
+
+	pinned, err := contract.Freeze(
+		filepath.Join(providerDir, "spectr", "specs"),
+		c.SpecID,
+		c.Requirements,
+	)
+	if err != nil {
+		return err
+	}
+	pinned.Provider = relProvider
+
+	path, err := contract.Save(root.SpectrDir(), pinned)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf(
+		"Pinned %d requirement(s) of %s in %s\n",
+		len(pinned.Requirements),
+		c.SpecID,
+		path,
+	)
+
+	return nil
+}
+
+// ContractCheckCmd compares every contract of the current project with
+// its provider's spec.
+type ContractCheckCmd struct {
+	SpecIDs  []string `arg:"" optional:"" help:"Contracts to check (default: all)" name:"spec-ids"`             //nolint:lll,revive // Kong struct tag with alignment
+	Provider string   `help:"Provider project directory (overrides the contract)"  name:"provider" type:"path"` //nolint:lll,revive // Kong struct tag with alignment
+	JSON     bool     `help:"Output as JSON"                                       name:"json"`                 //nolint:lll,revive // Kong struct tag with alignment
+}
+
+// Run executes the contract check command.
+func (c *ContractCheckCmd) Run() error {
+	root, err := GetSingleRoot()
+	if err != nil {
+		return err
+	}
+
+	contracts, err := c.load(root.SpectrDir())
+	if err != nil {
+		return err
+	}
+
+	var drifts []contract.Drift
+	for _, pinned := range contracts {
+		providerDir := c.Provider
+		if providerDir == "" {
+			providerDir = filepath.Join(root.Path, filepath.FromSlash(pinned.Provider))
+		}
+
+		found, err := contract.Check(
+			pinned,
+			filepath.Join(providerDir, "spectr", "specs"),
+		)
+		if err != nil {
+			return err
+		}
+		drifts = append(drifts, found...)
+	}
+
+	if c.JSON {
+		if drifts == nil {
+			drifts = []contract.Drift{}
+		}
+		data, err := json.MarshalIndent(drifts, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode drift: %w", err)
+		}
+		fmt.Println(string(data))
+	} else {
+		printDrifts(drifts, len(contracts))
+	}
+
+	if len(drifts) > 0 {
+		return &specterrs.ContractDriftError{Count: len(drifts)}
+	}
+
+	return nil
+}
+
+// load reads the requested contracts, or all of them.
+func (c *ContractCheckCmd) load(spectrDir string) ([]*contract.Contract, error) {
+	if len(c.SpecIDs) == 0 {
+		return contract.LoadAll(spectrDir)
+	}
+
+	contracts := make([]*contract.Contract, 0, len(c.SpecIDs))
+	for _, specID := range c.SpecIDs {
+		pinned, err := contract.Load(contract.Path(spectrDir, specID))
+		if err != nil {
+			return nil, fmt.Errorf("no contract for '%s': %w", specID, err)
+		}
+		contracts = append(contracts, pinned)
+	}
+
+	return contracts, nil
+}
+
+// printDrifts prints drifted requirements with their word diffs.
+func printDrifts(drifts []contract.Drift, contracts int) {
+	if len(drifts) == 0 {
+		fmt.Printf("All pinned requirements match (%d contract(s))\n", contracts)
+
+		return
+	}
+
+	for _, drift := range drifts {
+		fmt.Printf("%s: %s (%s)\n", drift.Spec, drift.Requirement, drift.Kind)
+		if drift.Diff != "" {
+			fmt.Println(drift.Diff)
+		}
+	}
+}
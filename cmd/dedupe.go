@@ -0,0 +1,85 @@
+// Package cmd provides command-line interface implementations.
+// This file contains the dedupe command for finding near-duplicate
+// requirements.
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/connerohnesorge/spectr/internal/dedupe"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+// DedupeCmd reports pairs of requirements, in the same or different specs,
+// whose body text is similar.
+type DedupeCmd struct {
+	Threshold float64 `help:"Minimum similarity to report (0-1)" name:"threshold" default:"0.5"` //nolint:lll,revive // Kong struct tag with alignment
+	JSON      bool    `help:"Output as JSON"                      name:"json"`                   //nolint:lll,revive // Kong struct tag with alignment
+}
+
+// Run executes the dedupe command.
+func (c *DedupeCmd) Run() error {
+	if c.Threshold <= 0 || c.Threshold > 1 {
+		return &specterrs.InvalidThresholdError{Value: c.Threshold}
+	}
+
+	root, err := GetSingleRoot()
+	if err != nil {
+		return err
+	}
+
+	reqs, err := dedupe.Load(root.SpecsDir())
+	if err != nil {
+		return err
+	}
+	pairs := dedupe.FindPairs(reqs, c.Threshold)
+
+	if c.JSON {
+		if pairs == nil {
+			pairs = []dedupe.Pair{}
+		}
+		data, err := json.MarshalIndent(pairs, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode duplicate pairs: %w", err)
+		}
+		fmt.Println(string(data))
+
+		return nil
+	}
+
+	if len(pairs) == 0 {
+		fmt.Printf(
+			"No requirement pairs at or above %.0f%% similarity (%d requirements)\n",
+			c.Threshold*100,
+			len(reqs),
+		)
+
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SIMILARITY\tREQUIREMENT\tSIMILAR TO")
+	for _, pair := range pairs {
+		fmt.Fprintf(
+			w,
+			"%.0f%%\t%s\t%s\n",
+			pair.Similarity*100,
+			pair.A,
+			pair.B,
+		)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Printf(
+		"\n%d pair(s) among %d requirements\n",
+		len(pairs),
+		len(reqs),
+	)
+
+	return nil
+}
@@ -0,0 +1,45 @@
+// Package cmd provides command-line interface implementations.
+// This file contains the demo command that generates a sample project.
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/connerohnesorge/spectr/internal/demo"
+)
+
+// DemoCmd generates a throwaway project with realistic specs, in-flight
+// changes, partially completed tasks and an archived change.
+type DemoCmd struct {
+	Dir string `arg:"" optional:"" help:"Directory to create the project in (default: new temp dir)" type:"path"` //nolint:lll,revive // Kong struct tag with alignment
+}
+
+// Run executes the demo command.
+func (c *DemoCmd) Run() error {
+	dir := c.Dir
+	if dir == "" {
+		created, err := demo.Create("")
+		if err != nil {
+			return err
+		}
+		dir = created
+	} else if err := demo.Write(dir); err != nil {
+		return err
+	}
+
+	fmt.Printf("Created demo project at:\n\n  %s\n\n", dir)
+	fmt.Println("It contains:")
+	fmt.Printf("  specs:    %s, %s\n", demo.SpecAuth, demo.SpecNotifications)
+	fmt.Printf("  changes:  %s, %s\n", demo.ChangeTwoFactor, demo.ChangeDigest)
+	fmt.Printf("  archived: %s\n", demo.ArchivedChange)
+	fmt.Println()
+	fmt.Println("Try:")
+	fmt.Println()
+	fmt.Printf("  cd %s\n", dir)
+	fmt.Println("  spectr list --long")
+	fmt.Println("  spectr validate --all")
+	fmt.Printf("  spectr diff %s\n", demo.ChangeTwoFactor)
+	fmt.Println("  spectr view")
+
+	return nil
+}
@@ -0,0 +1,236 @@
+// Package cmd provides command-line interface implementations.
+// This file contains the diff command for reviewing a change's delta specs
+// against the requirements they modify.
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/connerohnesorge/spectr/internal/diff"
+	"github.com/connerohnesorge/spectr/internal/parsers"
+	"github.com/connerohnesorge/spectr/internal/snapshot"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+	"github.com/mattn/go-isatty"
+)
+
+var (
+	// diffDeleteStyle styles removed words in red
+	diffDeleteStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("1")).
+			Strikethrough(true)
+	// diffInsertStyle styles added words in green
+	diffInsertStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("2")).
+			Underline(true)
+)
+
+// DiffCmd shows what a change does to the specs. MODIFIED requirements are
+// shown as word-level diffs against their base version.
+type DiffCmd struct {
+	ChangeID string `arg:"" optional:"" predictor:"changeID" help:"Change ID"`
+	Plain    bool   `                                        help:"Mark changes as [-old-]{+new+}" name:"plain"` //nolint:lll,revive // Kong struct tag with alignment
+}
+
+// Run executes the diff command.
+func (c *DiffCmd) Run() error {
+	root, err := GetSingleRoot()
+	if err != nil {
+		return err
+	}
+
+	changeID, err := resolveOrSelectChangeID(c.ChangeID, root.Path)
+	if err != nil {
+		var userCancelledErr *specterrs.UserCancelledError
+		if errors.As(err, &userCancelledErr) {
+			return nil
+		}
+
+		return err
+	}
+
+	style := diff.PlainStyler
+	if !c.Plain && isatty.IsTerminal(os.Stdout.Fd()) {
+		style = colorStyler
+	}
+
+	out, err := renderChangeDiff(
+		root.SpecsDir(),
+		filepath.Join(root.ChangesDir(), changeID),
+		style,
+	)
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(out)
+
+	return nil
+}
+
+// colorStyler renders deleted and inserted words with terminal colors.
+func colorStyler(op diff.Op, text string) string {
+	switch op {
+	case diff.Delete:
+		return diffDeleteStyle.Render(text)
+	case diff.Insert:
+		return diffInsertStyle.Render(text)
+	case diff.Equal:
+		return text
+	}
+
+	return text
+}
+
+// renderChangeDiff renders the delta specs of a change, one spec at a time
+// in name order.
+func renderChangeDiff(
+	specsDir, changeDir string,
+	style diff.Styler,
+) (string, error) {
+	deltaDir := filepath.Join(changeDir, "specs")
+	entries, err := os.ReadDir(deltaDir)
+	if err != nil && !os.IsNotExist(err) {
+		return "", fmt.Errorf("failed to read %s: %w", deltaDir, err)
+	}
+
+	var specIDs []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			specIDs = append(specIDs, entry.Name())
+		}
+	}
+	sort.Strings(specIDs)
+
+	store := snapshot.Open(changeDir)
+	var sb strings.Builder
+	for _, specID := range specIDs {
+		deltaPath := filepath.Join(deltaDir, specID, "spec.md")
+		if _, err := os.Stat(deltaPath); err != nil {
+			continue
+		}
+
+		plan, err := parsers.ParseDeltaSpec(deltaPath)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse %s: %w", deltaPath, err)
+		}
+
+		if sb.Len() > 0 {
+			sb.WriteString("\n")
+		}
+		fmt.Fprintf(&sb, "%s\n", specID)
+
+		err = writeSpecDiff(
+			&sb,
+			plan,
+			specBase{
+				store:  store,
+				specID: specID,
+				path:   filepath.Join(specsDir, specID, "spec.md"),
+			},
+			style,
+		)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return sb.String(), nil
+}
+
+// writeSpecDiff writes the operations of one delta spec.
+func writeSpecDiff(
+	sb *strings.Builder,
+	plan *parsers.DeltaPlan,
+	base specBase,
+	style diff.Styler,
+) error {
+	for _, req := range plan.Added {
+		fmt.Fprintf(sb, "  + Requirement: %s\n", req.Name)
+	}
+	for _, name := range plan.Removed {
+		fmt.Fprintf(sb, "  - Requirement: %s\n", name)
+	}
+	for _, op := range plan.Renamed {
+		fmt.Fprintf(sb, "  → Requirement: %s → %s\n", op.From, op.To)
+	}
+
+	for _, req := range plan.Modified {
+		fmt.Fprintf(sb, "  ~ Requirement: %s\n", req.Name)
+
+		baseContent, ok, err := base.lookup(req.Name)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			sb.WriteString("      (no base version found)\n")
+
+			continue
+		}
+
+		segments := diff.Words(
+			requirementBody(baseContent),
+			requirementBody(req.Raw),
+		)
+		if !diff.Changed(segments) {
+			sb.WriteString("      (no textual changes)\n")
+
+			continue
+		}
+		for _, line := range strings.Split(
+			strings.TrimSuffix(diff.Render(segments, style), "\n"),
+			"\n",
+		) {
+			fmt.Fprintf(sb, "      %s\n", line)
+		}
+	}
+
+	return nil
+}
+
+// specBase finds the base version of requirements in one spec: the
+// change's snapshot if it captured the requirement, otherwise the current
+// spec.
+type specBase struct {
+	store  *snapshot.Store
+	specID string
+	path   string
+}
+
+// lookup returns the base content of a requirement.
+func (b specBase) lookup(name string) (string, bool, error) {
+	content, ok, err := b.store.Lookup(b.specID, name)
+	if err != nil || ok {
+		return content, ok, err
+	}
+
+	if _, statErr := os.Stat(b.path); statErr != nil {
+		return "", false, nil
+	}
+
+	reqs, err := parsers.ParseRequirements(b.path)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to parse %s: %w", b.path, err)
+	}
+
+	normalized := parsers.NormalizeRequirementName(name)
+	for _, req := range reqs {
+		if parsers.NormalizeRequirementName(req.Name) == normalized {
+			return req.Raw, true, nil
+		}
+	}
+
+	return "", false, nil
+}
+
+// requirementBody returns requirement content without its header line.
+func requirementBody(raw string) string {
+	_, body, _ := strings.Cut(raw, "\n")
+
+	return body
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/connerohnesorge/spectr/internal/diff"
+	"github.com/connerohnesorge/spectr/internal/snapshot"
+)
+
+const diffBaseSpec = `# Auth Specification
+
+## Requirements
+
+### Requirement: Login
+The system SHALL log users in
+using their password.
+
+#### Scenario: Valid login
+- **WHEN** credentials are valid
+- **THEN** a session is created
+`
+
+const diffDeltaSpec = `## MODIFIED Requirements
+
+### Requirement: Login
+The system SHALL log users in using their password and a code.
+
+#### Scenario: Valid login
+- **WHEN** credentials are valid
+- **THEN** a session is created
+`
+
+func writeDiffFixture(t *testing.T) (specsDir, changeDir string) {
+	t.Helper()
+
+	root := t.TempDir()
+	specsDir = filepath.Join(root, "specs")
+	changeDir = filepath.Join(root, "changes", "add-mfa")
+
+	for path, content := range map[string]string{
+		filepath.Join(specsDir, "auth", "spec.md"):           diffBaseSpec,
+		filepath.Join(changeDir, "specs", "auth", "spec.md"): diffDeltaSpec,
+	} {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return specsDir, changeDir
+}
+
+func TestRenderChangeDiff(t *testing.T) {
+	specsDir, changeDir := writeDiffFixture(t)
+
+	got, err := renderChangeDiff(specsDir, changeDir, diff.PlainStyler)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `auth
+  ~ Requirement: Login
+      The system SHALL log users in using their [-password.-] {+password and a code.+}
+      #### Scenario: Valid login
+      - **WHEN** credentials are valid
+      - **THEN** a session is created
+`
+	if got != want {
+		t.Errorf("renderChangeDiff() =\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestRenderChangeDiff_PrefersSnapshotBase(t *testing.T) {
+	specsDir, changeDir := writeDiffFixture(t)
+	if _, err := snapshot.Capture(specsDir, changeDir); err != nil {
+		t.Fatal(err)
+	}
+
+	// The main spec moves on after the snapshot was taken
+	moved := strings.Replace(diffBaseSpec, "their password", "their passkey", 1)
+	if err := os.WriteFile(filepath.Join(specsDir, "auth", "spec.md"), []byte(moved), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := renderChangeDiff(specsDir, changeDir, diff.PlainStyler)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(got, "passkey") {
+		t.Errorf("diff should use the snapshot base, got:\n%s", got)
+	}
+}
@@ -1,12 +1,14 @@
 package cmd
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
 	"sync"
 
 	"github.com/connerohnesorge/spectr/internal/discovery"
+	"github.com/connerohnesorge/spectr/internal/fileio"
 )
 
 // discoveryCache caches the results of GetDiscoveredRoots to avoid
@@ -72,8 +74,9 @@ func ResetDiscoveryCache() {
 	cachedCwd = ""
 }
 
-// GetSingleRoot returns the first discovered root, or an error if no roots
-// are found. This is useful for commands that operate on a single root.
+// GetSingleRoot returns the root a single-root command works on, or an
+// error if no roots are found or several are found below the working
+// directory and none above (see discovery.SingleRoot).
 func GetSingleRoot() (discovery.SpectrRoot, error) {
 	roots, err := GetDiscoveredRoots()
 	if err != nil {
@@ -86,10 +89,53 @@ func GetSingleRoot() (discovery.SpectrRoot, error) {
 		)
 	}
 
-	return roots[0], nil
+	return discovery.SingleRoot(roots)
 }
 
 // HasMultipleRoots returns true if there are multiple discovered roots.
 func HasMultipleRoots(roots []discovery.SpectrRoot) bool {
 	return len(roots) > 1
 }
+
+// prefetchRoots starts reading the spectr/ trees of roots ahead of a scan.
+// The returned function drops whatever the scan did not read.
+func prefetchRoots(
+	ctx context.Context,
+	roots []discovery.SpectrRoot,
+) (release func()) {
+	releases := make([]func(), 0, len(roots))
+	for _, root := range roots {
+		releases = append(releases, fileio.PrefetchTree(ctx, root.SpectrDir()))
+	}
+
+	return func() {
+		for _, release := range releases {
+			release()
+		}
+	}
+}
+
+// useProjectDir implements --project-dir: it makes dir, which must contain
+// spectr/, the working directory and the only discovered root, so commands
+// that use the working directory directly see the same project.
+func useProjectDir(dir string) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	root, err := discovery.FindSpectrRootAt(dir, cwd)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Chdir(root.Path); err != nil {
+		return fmt.Errorf("failed to enter project directory: %w", err)
+	}
+	if err := os.Setenv("SPECTR_ROOT", root.Path); err != nil {
+		return fmt.Errorf("failed to set SPECTR_ROOT: %w", err)
+	}
+	ResetDiscoveryCache()
+
+	return nil
+}
@@ -0,0 +1,62 @@
+// Package cmd provides command-line interface implementations.
+// This file contains the evidence command for attaching acceptance
+// evidence to spec scenarios.
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/connerohnesorge/spectr/internal/evidence"
+	"github.com/connerohnesorge/spectr/internal/utils"
+)
+
+// EvidenceCmd manages the acceptance evidence of spec scenarios.
+type EvidenceCmd struct {
+	Attach EvidenceAttachCmd `cmd:"" help:"Attach evidence to a scenario"`
+}
+
+// EvidenceAttachCmd records a test run URL, screenshot or other file as
+// evidence for a scenario.
+type EvidenceAttachCmd struct {
+	SpecID   string `arg:"" predictor:"specID" help:"Spec ID"`                         //nolint:lll,revive // Kong struct tag with alignment
+	Scenario string `arg:"" help:"Scenario name or ID (e.g. AUTH-R1-S2)"`              //nolint:lll,revive // Kong struct tag with alignment
+	Ref      string `arg:"" help:"Evidence URL or file"`                               //nolint:lll,revive // Kong struct tag with alignment
+	JSON     bool   `help:"Output as JSON"                                name:"json"` //nolint:lll,revive // Kong struct tag with alignment
+}
+
+// Run executes the evidence attach command.
+func (c *EvidenceAttachCmd) Run() error {
+	root, err := GetSingleRoot()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := utils.CommandContext(0)
+	defer cancel()
+
+	res, err := evidence.Attach(ctx, root.Path, c.SpecID, c.Scenario, c.Ref, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if c.JSON {
+		data, err := json.MarshalIndent(res, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode result: %w", err)
+		}
+		fmt.Println(string(data))
+
+		return nil
+	}
+
+	if res.Duplicate {
+		fmt.Printf("%s already has evidence %s\n", res.ScenarioID, res.Ref)
+
+		return nil
+	}
+	fmt.Printf("Attached %s to %s (%s / %s)\n", res.Ref, res.ScenarioID, res.Requirement, res.Scenario)
+
+	return nil
+}
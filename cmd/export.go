@@ -0,0 +1,409 @@
+// Package cmd provides command-line interface implementations.
+// This file contains the export command for converting specs to
+// other formats, one at a time or all into a directory, the project to one
+// YAML document and specs to Backstage catalog entities.
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/connerohnesorge/spectr/internal/backstage"
+	"github.com/connerohnesorge/spectr/internal/config"
+	"github.com/connerohnesorge/spectr/internal/discovery"
+	"github.com/connerohnesorge/spectr/internal/events"
+	"github.com/connerohnesorge/spectr/internal/evidence"
+	"github.com/connerohnesorge/spectr/internal/export"
+	"github.com/connerohnesorge/spectr/internal/fileio"
+	"github.com/connerohnesorge/spectr/internal/heatmap"
+	"github.com/connerohnesorge/spectr/internal/inherit"
+	"github.com/connerohnesorge/spectr/internal/parsers"
+	"github.com/connerohnesorge/spectr/internal/quality"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+	"github.com/connerohnesorge/spectr/internal/utils"
+	"github.com/connerohnesorge/spectr/internal/yamldoc"
+)
+
+// ExportCmd represents the export command which renders specs in formats
+// consumed by other tools. Without a subcommand, `spectr export <spec>`
+// exports one spec.
+type ExportCmd struct {
+	Spec      ExportSpecCmd      `cmd:"" default:"withargs" help:"Export a spec"`                              //nolint:lll,revive // Kong struct tag with alignment
+	YAML      ExportYAMLCmd      `cmd:"" name:"yaml"        help:"Export the project as one YAML file"`        //nolint:lll,revive // Kong struct tag with alignment
+	Backstage ExportBackstageCmd `cmd:"" name:"backstage"   help:"Export specs as Backstage catalog entities"` //nolint:lll,revive // Kong struct tag with alignment
+	All       ExportAllCmd       `cmd:"" name:"all"         help:"Export every spec to a directory"`           //nolint:lll,revive // Kong struct tag with alignment
+}
+
+// ExportSpecCmd renders a spec as Gherkin, markdown or HTML.
+type ExportSpecCmd struct {
+	// SpecID is the spec to export
+	SpecID string `arg:"" predictor:"specID" help:"Spec ID to export"` //nolint:lll,revive // Kong struct tag with alignment
+
+	// Format selects the output format
+	Format string `name:"format" short:"f" help:"Output format" enum:"gherkin,markdown,html" default:"gherkin"` //nolint:lll,revive // Kong struct tag with alignment
+
+	// Output writes to a file instead of stdout
+	Output string `name:"output" short:"o" help:"Write output to file" type:"path"` //nolint:lll,revive // Kong struct tag with alignment
+
+	// Quality adds the spec's quality score as a leading comment
+	Quality bool `name:"quality" help:"Include the spec quality score as a comment"` //nolint:lll,revive // Kong struct tag with alignment
+
+	// Flags keeps only the content enabled in an environment's flag set
+	Flags string `name:"flags" help:"Keep only content enabled in this JSON flag set" type:"path"` //nolint:lll,revive // Kong struct tag with alignment
+
+	// Heatmap colors requirements by recent edits in HTML output
+	Heatmap bool `name:"heatmap" help:"Color requirements by edit frequency (html only)"` //nolint:lll,revive // Kong struct tag with alignment
+
+	// Days is the heatmap window
+	Days int `name:"days" help:"Heatmap window in days" default:"90"` //nolint:lll,revive // Kong struct tag with alignment
+
+	// Timeout bounds reading the git history for --heatmap
+	Timeout time.Duration `name:"timeout" help:"Abort after duration (e.g. 30s)"` //nolint:lll,revive // Kong struct tag with alignment
+}
+
+// exportFormatMarkdown is the --format value that exports the spec as
+// markdown.
+const exportFormatMarkdown = "markdown"
+
+// exportFormatHTML is the --format value that exports the spec as a
+// standalone HTML page.
+const exportFormatHTML = "html"
+
+// exportSteps is the number of export steps reported as progress events:
+// parse, render and write.
+const exportSteps = 3
+
+// Run executes the export spec command.
+func (c *ExportSpecCmd) Run() error {
+	if err := c.checkFlags(); err != nil {
+		return err
+	}
+
+	root, err := GetSingleRoot()
+	if err != nil {
+		return err
+	}
+
+	specPath := filepath.Join(root.SpecsDir(), c.SpecID, "spec.md")
+	if _, statErr := os.Stat(specPath); statErr != nil {
+		return fmt.Errorf("spec '%s' not found", c.SpecID)
+	}
+
+	output, err := c.render(root.Path, specPath, c.SpecID, func(phase string, step int) {
+		events.Emit(phase, c.SpecID, step, exportSteps)
+	})
+	if err != nil {
+		return err
+	}
+
+	events.Emit("write", c.SpecID, 2, exportSteps)
+	if c.Output == "" {
+		fmt.Print(output)
+	} else if err := os.WriteFile(c.Output, []byte(output), filePerm); err != nil {
+		return fmt.Errorf("failed to write %s: %w", c.Output, err)
+	}
+	events.Emit("done", c.SpecID, exportSteps, exportSteps)
+
+	return nil
+}
+
+// checkFlags rejects flag combinations the export cannot honor.
+func (c *ExportSpecCmd) checkFlags() error {
+	if c.Heatmap && c.Format != exportFormatHTML {
+		return &specterrs.RequiresFlagError{Flag: "--heatmap", RequiredFlag: "--format html"}
+	}
+	if c.Heatmap && c.Days < 1 {
+		return &specterrs.InvalidHeatmapDaysError{Days: c.Days}
+	}
+
+	return nil
+}
+
+// render exports the spec specID, read from specPath, in c.Format with
+// the options of c. onStep is called as the parse and render steps start.
+func (c *ExportSpecCmd) render(
+	projectRoot, specPath, specID string,
+	onStep func(phase string, step int),
+) (string, error) {
+	onStep("parse", 0)
+	title, err := parsers.ExtractTitle(specPath)
+	if err != nil || title == "" {
+		title = specID
+	}
+
+	resolved, err := inherit.ResolveFile(specPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse spec: %w", err)
+	}
+	var flags export.Flags
+	if c.Flags != "" {
+		if flags, err = export.LoadFlags(c.Flags); err != nil {
+			return "", err
+		}
+	}
+	reqs := make([]parsers.RequirementBlock, 0, len(resolved))
+	for _, req := range resolved {
+		if flags != nil {
+			req.Raw = string(export.FilterFlags([]byte(req.Raw), flags))
+			if strings.TrimSpace(req.Raw) == "" {
+				continue
+			}
+		}
+		reqs = append(reqs, req.RequirementBlock)
+	}
+
+	ev, err := evidence.Load(filepath.Join(filepath.Dir(specPath), evidence.FileName))
+	if err != nil {
+		return "", err
+	}
+
+	onStep("render", 1)
+	var output string
+	switch c.Format {
+	case exportFormatMarkdown:
+		source, err := fileio.ReadFile(specPath)
+		if err != nil {
+			return "", fmt.Errorf("failed to read spec: %w", err)
+		}
+		if base, _ := inherit.Base(specPath); base != "" {
+			source = []byte(inherit.Render(string(source), resolved))
+		}
+		if flags != nil {
+			source = export.FilterFlags(source, flags)
+		}
+		output = export.AppendEvidence(export.FormatMarkdown(source), ev)
+	case exportFormatHTML:
+		var hm *heatmap.Heatmap
+		if c.Heatmap {
+			if hm, err = c.heatmap(projectRoot, specID); err != nil {
+				return "", err
+			}
+		}
+		output = export.FormatHTML(title, reqs, hm)
+	default:
+		output = export.FormatGherkinWithEvidence(title, reqs, ev)
+	}
+
+	if c.Quality {
+		report, err := scoreSpec(projectRoot, specID)
+		if err != nil {
+			return "", err
+		}
+		switch c.Format {
+		case exportFormatMarkdown:
+			output = fmt.Sprintf("<!-- Quality: %s -->\n", report) + output
+		case exportFormatHTML:
+			comment := fmt.Sprintf("<!-- Quality: %s -->\n", report)
+			output = strings.Replace(output, "<body>\n", "<body>\n"+comment, 1)
+		default:
+			output = fmt.Sprintf("# Quality: %s\n", report) + output
+		}
+	}
+
+	return output, nil
+}
+
+// heatmap computes the edit heatmap of spec specID over the last c.Days
+// days.
+func (c *ExportSpecCmd) heatmap(projectRoot, specID string) (*heatmap.Heatmap, error) {
+	ctx, cancel := utils.CommandContext(c.Timeout)
+	defer cancel()
+
+	now := time.Now()
+	hm, err := heatmap.Compute(
+		ctx,
+		projectRoot,
+		heatmap.Options{Since: now.AddDate(0, 0, -c.Days), Spec: specID},
+		now,
+	)
+	if err != nil {
+		return nil, utils.CommandError(ctx, "export", c.Timeout, err)
+	}
+
+	return hm, nil
+}
+
+// ExportAllCmd renders every spec to its own file in a directory. Specs
+// are rendered in parallel, and an interrupted export resumes from the
+// specs it already wrote.
+type ExportAllCmd struct {
+	Format  string        `name:"format" short:"f"  help:"Output format" enum:"gherkin,markdown,html" default:"html"`       //nolint:lll,revive // Kong struct tag with alignment
+	OutDir  string        `name:"out-dir" short:"o" help:"Directory to write one file per spec to" type:"path" required:""` //nolint:lll,revive // Kong struct tag with alignment
+	Jobs    int           `name:"jobs" short:"j"    help:"Specs rendered in parallel (default: one per CPU)"`               //nolint:lll,revive // Kong struct tag with alignment
+	Restart bool          `name:"restart"           help:"Export every spec again instead of resuming"`                     //nolint:lll,revive // Kong struct tag with alignment
+	Quality bool          `name:"quality"           help:"Include each spec's quality score as a comment"`                  //nolint:lll,revive // Kong struct tag with alignment
+	Flags   string        `name:"flags"             help:"Keep only content enabled in this JSON flag set" type:"path"`     //nolint:lll,revive // Kong struct tag with alignment
+	Heatmap bool          `name:"heatmap"           help:"Color requirements by edit frequency (html only)"`                //nolint:lll,revive // Kong struct tag with alignment
+	Days    int           `name:"days"              help:"Heatmap window in days" default:"90"`                             //nolint:lll,revive // Kong struct tag with alignment
+	Timeout time.Duration `name:"timeout"           help:"Abort after duration (e.g. 10m)"`                                 //nolint:lll,revive // Kong struct tag with alignment
+}
+
+// exportExtensions maps export formats to output file extensions.
+var exportExtensions = map[string]string{
+	"gherkin":            ".feature",
+	exportFormatMarkdown: ".md",
+	exportFormatHTML:     ".html",
+}
+
+// Run executes the export all command.
+func (c *ExportAllCmd) Run() error {
+	spec := ExportSpecCmd{
+		Format:  c.Format,
+		Quality: c.Quality,
+		Flags:   c.Flags,
+		Heatmap: c.Heatmap,
+		Days:    c.Days,
+	}
+	if err := spec.checkFlags(); err != nil {
+		return err
+	}
+	if c.Jobs < 0 {
+		return &specterrs.InvalidJobsError{Jobs: c.Jobs}
+	}
+	jobs := c.Jobs
+	if jobs == 0 {
+		jobs = runtime.NumCPU()
+	}
+
+	root, err := GetSingleRoot()
+	if err != nil {
+		return err
+	}
+	ids, err := discovery.GetSpecIDs(root.Path)
+	if err != nil {
+		return err
+	}
+	specs := make([]export.BatchSpec, 0, len(ids))
+	for _, id := range ids {
+		specs = append(specs, export.BatchSpec{
+			ID:   id,
+			Path: filepath.Join(root.SpecsDir(), filepath.FromSlash(id), "spec.md"),
+		})
+	}
+
+	ctx, cancel := utils.CommandContext(c.Timeout)
+	defer cancel()
+
+	result, err := export.Batch(ctx, specs, export.BatchOptions{
+		OutputDir: c.OutDir,
+		Ext:       exportExtensions[c.Format],
+		Settings: fmt.Sprintf(
+			"format=%s quality=%t flags=%s heatmap=%t days=%d",
+			c.Format, c.Quality, c.Flags, c.Heatmap, c.Days,
+		),
+		Jobs:    jobs,
+		Restart: c.Restart,
+	}, func(s export.BatchSpec) (string, error) {
+		return spec.render(root.Path, s.Path, s.ID, func(string, int) {})
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Exported %d of %d specs; run again to resume\n",
+			result.Resumed+result.Written, len(specs))
+
+		return utils.CommandError(ctx, "export all", c.Timeout, err)
+	}
+
+	if result.Resumed > 0 {
+		fmt.Printf("Exported %d specs to %s (%d resumed from an earlier run)\n",
+			len(specs), c.OutDir, result.Resumed)
+	} else {
+		fmt.Printf("Exported %d specs to %s\n", len(specs), c.OutDir)
+	}
+
+	return nil
+}
+
+// ExportYAMLCmd serializes specs, and with --all the active changes and
+// their tasks, as one YAML document for `spectr import yaml`.
+type ExportYAMLCmd struct {
+	SpecIDs []string `name:"spec-ids" arg:"" optional:"" predictor:"specID" help:"Specs to export"`                     //nolint:lll,revive // Kong struct tag with alignment
+	All     bool     `name:"all"                                            help:"Export every spec and active change"` //nolint:lll,revive // Kong struct tag with alignment
+	Output  string   `name:"output" short:"o"                               help:"Write output to file" type:"path"`    //nolint:lll,revive // Kong struct tag with alignment
+}
+
+// Run executes the export yaml command.
+func (c *ExportYAMLCmd) Run() error {
+	switch {
+	case c.All && len(c.SpecIDs) > 0:
+		return &specterrs.IncompatibleFlagsError{Flag1: "--all", Flag2: "<spec-ids>"}
+	case !c.All && len(c.SpecIDs) == 0:
+		return &specterrs.NoExportSelectionError{}
+	}
+
+	root, err := GetSingleRoot()
+	if err != nil {
+		return err
+	}
+	doc, err := yamldoc.Build(root.Path, yamldoc.Options{Specs: c.SpecIDs, Changes: c.All})
+	if err != nil {
+		return err
+	}
+	output, err := yamldoc.Encode(doc)
+	if err != nil {
+		return err
+	}
+
+	return writeExportOutput(c.Output, output)
+}
+
+// ExportBackstageCmd generates Backstage catalog-info entities from specs.
+type ExportBackstageCmd struct {
+	SpecIDs []string `name:"spec-ids" arg:"" optional:"" predictor:"specID" help:"Specs to export (default: all)"`                            //nolint:lll,revive // Kong struct tag with alignment
+	BaseURL string   `name:"base-url"                                       help:"URL prefix of links to spec files"`                         //nolint:lll,revive // Kong struct tag with alignment
+	Output  string   `name:"output" short:"o"                               help:"Write output to file (e.g. catalog-info.yaml)" type:"path"` //nolint:lll,revive // Kong struct tag with alignment
+}
+
+// Run executes the export backstage command.
+func (c *ExportBackstageCmd) Run() error {
+	root, err := GetSingleRoot()
+	if err != nil {
+		return err
+	}
+	entities, err := backstage.Build(root.Path, backstage.Options{
+		Specs:   c.SpecIDs,
+		BaseURL: c.BaseURL,
+		Now:     time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+	output, err := backstage.Encode(entities)
+	if err != nil {
+		return err
+	}
+
+	return writeExportOutput(c.Output, output)
+}
+
+// writeExportOutput writes output to path, or to stdout when path is
+// empty.
+func writeExportOutput(path string, output []byte) error {
+	if path == "" {
+		_, err := os.Stdout.Write(output)
+
+		return err
+	}
+	if err := os.WriteFile(path, output, filePerm); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// scoreSpec scores one spec with the weights configured for projectRoot.
+func scoreSpec(projectRoot, specID string) (*quality.Report, error) {
+	cfg, err := config.LoadConfig(projectRoot)
+	if err != nil {
+		return nil, err
+	}
+	scorer, err := quality.NewScorer(projectRoot, quality.ConfiguredWeights(cfg))
+	if err != nil {
+		return nil, err
+	}
+
+	return scorer.Score(specID)
+}
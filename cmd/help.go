@@ -0,0 +1,129 @@
+// Package cmd provides command-line interface implementations.
+// This file contains the help command for the built-in topics and examples.
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/connerohnesorge/spectr/internal/help"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+// HelpCmd shows built-in help topics with worked examples. The topics are
+// compiled into the binary, so they work offline.
+type HelpCmd struct {
+	Topic   string `arg:"" optional:"" help:"Topic name or search words"`                              //nolint:lll,revive // Kong struct tag with alignment
+	Example bool   `                   help:"Scaffold a sandbox project for the topic" name:"example"` //nolint:lll,revive // Kong struct tag with alignment
+	Search  string `                   help:"Search topics and examples"               name:"search"`  //nolint:lll,revive // Kong struct tag with alignment
+}
+
+// Run executes the help command.
+func (c *HelpCmd) Run() error {
+	if c.Search != "" {
+		return c.search(c.Search)
+	}
+
+	if c.Topic == "" {
+		if c.Example {
+			return &specterrs.RequiresFlagError{
+				Flag:         "--example",
+				RequiredFlag: "a topic",
+			}
+		}
+		printTopicList()
+
+		return nil
+	}
+
+	topic, ok := help.Lookup(c.Topic)
+	if !ok {
+		return c.search(c.Topic)
+	}
+
+	if c.Example {
+		return scaffoldExample(topic)
+	}
+
+	fmt.Print(help.Render(topic.Markdown()))
+
+	return nil
+}
+
+// search prints topics matching query, or renders the topic directly when
+// there is exactly one match.
+func (c *HelpCmd) search(query string) error {
+	matches := help.Search(query)
+	switch len(matches) {
+	case 0:
+		return &specterrs.UnknownHelpTopicError{
+			Topic:     query,
+			Available: topicNames(),
+		}
+	case 1:
+		if c.Example {
+			return scaffoldExample(matches[0].Topic)
+		}
+		fmt.Print(help.Render(matches[0].Topic.Markdown()))
+
+		return nil
+	}
+
+	fmt.Printf("Topics matching '%s':\n\n", query)
+	for _, match := range matches {
+		fmt.Printf(
+			"  %-10s %s (%s)\n",
+			match.Topic.Name,
+			match.Topic.Summary,
+			match.Where,
+		)
+	}
+
+	return nil
+}
+
+// scaffoldExample writes the topic's sandbox project and explains how to
+// use it.
+func scaffoldExample(topic help.Topic) error {
+	dir, err := help.Scaffold(topic, "")
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Created sandbox project for '%s' at:\n\n  %s\n\n", topic.Name, dir)
+	fmt.Println("Try:")
+	fmt.Println()
+	fmt.Printf("  cd %s\n", dir)
+	for _, example := range topic.Examples {
+		for _, command := range example.Commands {
+			fmt.Printf("  %s\n", command)
+		}
+	}
+	fmt.Println()
+	fmt.Println("Delete the directory when you are done.")
+
+	return nil
+}
+
+// printTopicList prints the available topics.
+func printTopicList() {
+	fmt.Println("Help topics:")
+	fmt.Println()
+	for _, topic := range help.Topics() {
+		fmt.Printf("  %-10s %s\n", topic.Name, topic.Summary)
+	}
+	fmt.Println()
+	fmt.Println("Run 'spectr help <topic>' to read a topic,")
+	fmt.Println("'spectr help <topic> --example' to scaffold a sandbox project,")
+	fmt.Println("or 'spectr help --search <words>' to search the examples.")
+}
+
+// topicNames returns the names of all help topics.
+func topicNames() []string {
+	topics := help.Topics()
+	names := make([]string, len(topics))
+	for i, topic := range topics {
+		names[i] = topic.Name
+	}
+
+	return names
+}
@@ -0,0 +1,77 @@
+// Package cmd provides command-line interface implementations.
+// This file contains the hooks command for installing git integration.
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/connerohnesorge/spectr/internal/git"
+)
+
+// spectrMergeDrivers lists the merge drivers installed by
+// "spectr hooks install". Drivers run with --no-sync so that a merge never
+// rewrites tasks.md files as a side effect.
+var spectrMergeDrivers = []git.MergeDriver{
+	{
+		Name:        "spectr-tasks",
+		Description: "Spectr tasks.jsonc merge driver",
+		Command:     "spectr --no-sync merge-tasks %O %A %B",
+		Patterns:    []string{"tasks*.jsonc"},
+	},
+	{
+		Name:        "spectr-spec",
+		Description: "Spectr spec.md merge driver",
+		Command:     "spectr --no-sync merge-spec %O %A %B",
+		Patterns:    []string{"spec.md"},
+	},
+	{
+		Name:        "spectr-audit",
+		Description: "Spectr audit log merge driver",
+		Command:     "spectr --no-sync merge-audit %O %A %B",
+		Patterns:    []string{"audit.log.jsonl"},
+	},
+}
+
+// HooksCmd represents the hooks command with subcommands.
+type HooksCmd struct {
+	Install HooksInstallCmd `cmd:"" help:"Install git merge drivers"`
+}
+
+// HooksInstallCmd registers spectr's git merge drivers in the current
+// repository.
+type HooksInstallCmd struct{}
+
+// Run executes the hooks install command.
+func (*HooksInstallCmd) Run() error {
+	if err := git.Require("spectr hooks install"); err != nil {
+		return err
+	}
+
+	repoRoot, err := git.GetRepoRoot()
+	if err != nil {
+		return err
+	}
+
+	for _, driver := range spectrMergeDrivers {
+		added, err := git.InstallMergeDriver(repoRoot, driver)
+		if err != nil {
+			return fmt.Errorf(
+				"failed to install %s merge driver: %w",
+				driver.Name,
+				err,
+			)
+		}
+
+		fmt.Printf("Registered merge driver %s\n", driver.Name)
+		for _, line := range added {
+			fmt.Printf("  .gitattributes: %s\n", line)
+		}
+	}
+
+	fmt.Println(
+		"\nCommit .gitattributes so collaborators use the drivers;" +
+			" each clone must run 'spectr hooks install'.",
+	)
+
+	return nil
+}
@@ -0,0 +1,90 @@
+// Package cmd provides command-line interface implementations.
+// This file contains the ide command for generating editor integration.
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/connerohnesorge/spectr/internal/discovery"
+	"github.com/connerohnesorge/spectr/internal/ide"
+)
+
+// IDECmd represents the ide command with subcommands.
+type IDECmd struct {
+	VSCode IDEVSCodeCmd `cmd:"" name:"vscode" help:"Generate .vscode tasks and settings"`
+}
+
+// IDEVSCodeCmd writes .vscode/tasks.json and .vscode/settings.json for the
+// spectr projects in the workspace.
+type IDEVSCodeCmd struct {
+	Dir     string `arg:"" optional:"" help:"Workspace folder (default: current directory)" type:"path"`               //nolint:lll,revive // Kong struct tag with alignment
+	Command string `                   help:"spectr executable used by tasks"      name:"command"    default:"spectr"` //nolint:lll,revive // Kong struct tag with alignment
+	DryRun  bool   `                   help:"Print the files instead of writing them" name:"dry-run"`                  //nolint:lll,revive // Kong struct tag with alignment
+	Force   bool   `                   help:"Overwrite existing files instead of merging" name:"force"`                //nolint:lll,revive // Kong struct tag with alignment
+}
+
+// Run executes the ide vscode command.
+func (c *IDEVSCodeCmd) Run() error {
+	workspace := c.Dir
+	if workspace == "" {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("failed to get current directory: %w", err)
+		}
+		workspace = cwd
+	}
+
+	roots, err := discovery.FindSpectrRoots(workspace)
+	if err != nil {
+		return fmt.Errorf("failed to discover spectr roots: %w", err)
+	}
+	if len(roots) == 0 {
+		return errors.New(
+			"no spectr directory found\nHint: Run 'spectr init' to initialize Spectr",
+		)
+	}
+
+	projects, err := ide.Projects(workspace, roots)
+	if err != nil {
+		return err
+	}
+
+	files, err := ide.VSCode(workspace, projects, ide.VSCodeOptions{
+		Command: c.Command,
+		Force:   c.Force,
+	})
+	if err != nil {
+		return err
+	}
+
+	if c.DryRun {
+		for _, f := range files {
+			fmt.Printf("// %s\n%s\n", f.Path, f.Data)
+		}
+
+		return nil
+	}
+
+	if err := ide.WriteFiles(workspace, files); err != nil {
+		return err
+	}
+
+	for _, f := range files {
+		status := "unchanged"
+		if f.Changed {
+			status = "wrote"
+		}
+		fmt.Printf("%-9s %s\n", status, f.Path)
+	}
+	for _, p := range projects {
+		fmt.Printf(
+			"project   %s (tasks.jsonc v%d)\n",
+			p.Dir,
+			p.TasksVersion,
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+// Package cmd provides command-line interface implementations.
+// This file contains the import command for writing a project YAML
+// document back as specs and changes.
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/connerohnesorge/spectr/internal/fileio"
+	"github.com/connerohnesorge/spectr/internal/utils"
+	"github.com/connerohnesorge/spectr/internal/yamldoc"
+)
+
+// ImportCmd represents the import command.
+type ImportCmd struct {
+	YAML ImportYAMLCmd `cmd:"" name:"yaml" help:"Import a project YAML file"` //nolint:lll,revive // Kong struct tag with alignment
+}
+
+// ImportYAMLCmd writes the specs and changes of a document made by
+// `spectr export yaml` into the project.
+type ImportYAMLCmd struct {
+	File  string `arg:"" help:"YAML file from spectr export yaml" type:"existingfile"` //nolint:lll,revive // Kong struct tag with alignment
+	Force bool   `       help:"Replace existing files"            name:"force"`        //nolint:lll,revive // Kong struct tag with alignment
+	JSON  bool   `       help:"Output as JSON"                    name:"json"`         //nolint:lll,revive // Kong struct tag with alignment
+}
+
+// Run executes the import yaml command.
+func (c *ImportYAMLCmd) Run() error {
+	root, err := GetSingleRoot()
+	if err != nil {
+		return err
+	}
+	data, err := fileio.ReadFile(c.File)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", c.File, err)
+	}
+	doc, err := yamldoc.Decode(c.File, data)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := utils.CommandContext(0)
+	defer cancel()
+
+	result, err := yamldoc.Import(ctx, root.Path, c.File, doc, yamldoc.ImportOptions{Force: c.Force})
+	if err != nil {
+		return err
+	}
+
+	if c.JSON {
+		out, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode result: %w", err)
+		}
+		fmt.Println(string(out))
+
+		return nil
+	}
+	fmt.Printf(
+		"Imported %d spec(s) and %d change(s) into %d file(s)\n",
+		len(doc.Specs),
+		len(doc.Changes),
+		len(result.Written),
+	)
+	for _, path := range result.Written {
+		fmt.Printf("  %s\n", path)
+	}
+
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/connerohnesorge/spectr/internal/initialize"
 	"github.com/connerohnesorge/spectr/internal/initialize/providers"
 	"github.com/connerohnesorge/spectr/internal/specterrs"
+	"github.com/connerohnesorge/spectr/internal/tui"
 )
 
 // InitCmd wraps the initialize package's InitCmd type to add Run method
@@ -106,6 +107,13 @@ func isTTYError(err error) bool {
 }
 
 func runInteractiveInit(c *InitCmd) error {
+	if !tui.Interactive() {
+		return &specterrs.NoTerminalError{
+			Command:     "the init wizard",
+			Alternative: "spectr init --non-interactive --tools <tool1,tool2>",
+		}
+	}
+
 	model, err := initialize.NewWizardModel(
 		&c.InitCmd,
 	)
@@ -145,6 +153,9 @@ func runInteractiveInit(c *InitCmd) error {
 	if err != nil {
 		return err
 	}
+	if msg := wizardModel.GetCopyMessage(); msg != "" {
+		fmt.Print(msg)
+	}
 
 	return nil
 }
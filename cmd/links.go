@@ -0,0 +1,120 @@
+// Package cmd provides command-line interface implementations.
+// This file contains the links command for inspecting wikilink
+// resolution across projects.
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/connerohnesorge/spectr/internal/links"
+)
+
+// LinksCmd shows where wikilinks resolve. Without --resolve it lists the
+// namespaces in resolution order; with it, it explains how one target
+// resolves.
+type LinksCmd struct {
+	Resolve string `help:"Explain how a wikilink target resolves" name:"resolve"` //nolint:lll,revive // Kong struct tag with alignment
+	JSON    bool   `help:"Output as JSON"                         name:"json"`    //nolint:lll,revive // Kong struct tag with alignment
+}
+
+// Run executes the links command.
+func (c *LinksCmd) Run() error {
+	root, err := GetSingleRoot()
+	if err != nil {
+		return err
+	}
+
+	resolver, err := links.NewResolver(root.Path)
+	if err != nil {
+		return err
+	}
+
+	if c.Resolve == "" {
+		return c.printNamespaces(root.Path, resolver.Namespaces())
+	}
+
+	res, resolveErr := resolver.Resolve(c.Resolve)
+	if c.JSON && res != nil {
+		data, err := json.MarshalIndent(res, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode resolution: %w", err)
+		}
+		fmt.Println(string(data))
+	} else if res != nil {
+		printAttempts(root.Path, res)
+	}
+	if resolveErr != nil {
+		return resolveErr
+	}
+	if !res.Found() {
+		return fmt.Errorf("wikilink target not found: %s", c.Resolve)
+	}
+
+	return nil
+}
+
+// printNamespaces lists the namespaces in resolution order.
+func (c *LinksCmd) printNamespaces(
+	projectRoot string,
+	namespaces []links.Namespace,
+) error {
+	if c.JSON {
+		data, err := json.MarshalIndent(namespaces, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode namespaces: %w", err)
+		}
+		fmt.Println(string(data))
+
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAMESPACE\tKIND\tSPECTR DIR")
+	for _, ns := range namespaces {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", ns.Name, ns.Kind, relativePath(projectRoot, ns.SpectrDir))
+	}
+
+	return w.Flush()
+}
+
+// printAttempts prints every place a target was looked for, marking the
+// one it resolved to.
+func printAttempts(projectRoot string, res *links.Resolution) {
+	for _, attempt := range res.Attempts {
+		mark := " "
+		switch {
+		case attempt.Path == res.Path && res.Found():
+			mark = "→"
+		case attempt.Exists:
+			mark = "!"
+		}
+		fmt.Printf(
+			"%s %-8s %-20s %s\n",
+			mark,
+			attempt.Kind,
+			attempt.Namespace,
+			relativePath(projectRoot, attempt.Path),
+		)
+	}
+
+	if res.Renamed != "" {
+		fmt.Fprintf(os.Stderr, "warning: [[%s]] names a renamed item; use [[%s]]\n", res.Target, res.Renamed)
+	}
+	if res.Found() {
+		fmt.Printf("\n[[%s]] resolves to %s (%s)\n", res.Target, relativePath(projectRoot, res.Path), res.Namespace)
+	}
+}
+
+// relativePath shortens path relative to base when possible.
+func relativePath(base, path string) string {
+	rel, err := filepath.Rel(base, path)
+	if err != nil {
+		return path
+	}
+
+	return rel
+}
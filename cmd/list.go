@@ -1,13 +1,34 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"slices"
+	"time"
 
 	"github.com/connerohnesorge/spectr/internal/archive"
+	"github.com/connerohnesorge/spectr/internal/config"
+	"github.com/connerohnesorge/spectr/internal/kinds"
 	"github.com/connerohnesorge/spectr/internal/list"
 	"github.com/connerohnesorge/spectr/internal/pr"
+	"github.com/connerohnesorge/spectr/internal/quality"
+	"github.com/connerohnesorge/spectr/internal/review"
 	"github.com/connerohnesorge/spectr/internal/specterrs"
+	"github.com/connerohnesorge/spectr/internal/stale"
+	"github.com/connerohnesorge/spectr/internal/tui"
+	"github.com/connerohnesorge/spectr/internal/utils"
+)
+
+// sortByActivity is the --sort value that orders changes stalest first.
+const sortByActivity = "activity"
+
+// List --format values.
+const (
+	listFormatText = "text"
+	listFormatJSON = "json"
+	listFormatCSV  = "csv"
+	listFormatTSV  = "tsv"
 )
 
 // ListCmd represents the list command which displays changes or specs.
@@ -19,24 +40,61 @@ type ListCmd struct {
 	// All determines whether to list both changes and specs in unified mode
 	All bool `name:"all"   help:"List both changes and specs in unified mode"` //nolint:lll,revive // Kong struct tag with alignment
 
+	// Kind lists the items of a custom kind declared in spectr.yaml
+	Kind string `name:"kind" help:"List items of a custom kind"` //nolint:lll,revive // Kong struct tag exceeds line length
+
+	// Tree groups specs by directory hierarchy with aggregate counts
+	Tree bool `name:"tree" help:"Group specs by directory (implies --specs)"` //nolint:lll,revive // Kong struct tag exceeds line length
+
+	// Columns selects the fields to render, overriding the configured
+	// list.columns / list.spec_columns defaults
+	Columns []string `name:"columns" help:"Columns to show (e.g. id,title,tasks)" sep:","` //nolint:lll,revive // Kong struct tag exceeds line length
+
 	// Long enables detailed output with titles and counts
 	Long bool `name:"long" help:"Show detailed output with titles and counts"` //nolint:lll,revive // Kong struct tag exceeds line length
 
 	// JSON enables JSON output format
 	JSON bool `name:"json" help:"Output as JSON"` //nolint:lll,revive // Kong struct tag with alignment
 
+	// Format selects the output format; --json is shorthand for json
+	Format string `name:"format" help:"Output format (text, json, csv, tsv)" enum:"text,json,csv,tsv" default:"text"` //nolint:lll,revive // Kong struct tag exceeds line length
+
 	// Interactive enables interactive table mode with clipboard
 	Interactive bool `name:"interactive" help:"Interactive mode" short:"I"` //nolint:lll,revive // Kong struct tag exceeds line length
 
 	// Stdout prints selected ID to stdout instead of clipboard.
 	// Requires -I (interactive mode).
 	Stdout bool `name:"stdout" help:"Print ID to stdout (requires -I)"` //nolint:lll,revive // Kong struct tag exceeds line length
+
+	// Sort orders changes by ID or by last activity (stalest first)
+	Sort string `name:"sort" help:"Order changes by id or activity" enum:"id,activity" default:"id"` //nolint:lll,revive // Kong struct tag exceeds line length
+
+	// Timeout aborts listing if it takes longer than this
+	Timeout time.Duration `name:"timeout" help:"Abort after duration (e.g. 30s)"` //nolint:lll,revive // Kong struct tag exceeds line length
 }
 
 // Run executes the list command.
 // It validates flags, determines the project path, and delegates to
 // either listSpecs, listChanges, or listAll based on the flags.
 func (c *ListCmd) Run() error {
+	// Validate flags - --json only agrees with --format json
+	if c.JSON && c.Format != listFormatText && c.Format != listFormatJSON {
+		return &specterrs.IncompatibleFlagsError{
+			Flag1: "--json",
+			Flag2: "--format " + c.Format,
+		}
+	}
+	if c.Format == listFormatJSON {
+		c.JSON = true
+	}
+
+	// Validate flags - CSV and TSV are flat tables of changes or specs
+	if c.delimiter() != 0 {
+		if err := c.checkFlatOutput("--format " + c.Format); err != nil {
+			return err
+		}
+	}
+
 	// Validate flags - interactive and JSON are mutually exclusive
 	if c.Interactive && c.JSON {
 		return &specterrs.IncompatibleFlagsError{
@@ -53,6 +111,21 @@ func (c *ListCmd) Run() error {
 		}
 	}
 
+	// Validate flags - tree view only applies to specs
+	if c.All && c.Tree {
+		return &specterrs.IncompatibleFlagsError{
+			Flag1: "--all",
+			Flag2: "--tree",
+		}
+	}
+
+	// Validate flags - columns only apply to flat, non-interactive output
+	if len(c.Columns) > 0 {
+		if err := c.checkFlatOutput("--columns"); err != nil {
+			return err
+		}
+	}
+
 	// Validate flags - stdout requires interactive mode
 	if c.Stdout && !c.Interactive {
 		return &specterrs.RequiresFlagError{
@@ -69,6 +142,23 @@ func (c *ListCmd) Run() error {
 		}
 	}
 
+	// Without a terminal the table is printed instead of the TUI, unless
+	// the caller wanted a selection back on stdout
+	if c.Interactive && !tui.Interactive() {
+		if c.Stdout {
+			return &specterrs.NoTerminalError{
+				Command:     "spectr list --interactive --stdout",
+				Alternative: "spectr list --json",
+			}
+		}
+		fmt.Fprintln(os.Stderr, "spectr: no terminal for --interactive; printing the list")
+		c.Interactive = false
+	}
+
+	if c.Kind != "" {
+		return c.listKind()
+	}
+
 	// Discover all spectr roots
 	roots, err := GetDiscoveredRoots()
 	if err != nil {
@@ -97,26 +187,95 @@ func (c *ListCmd) Run() error {
 	multiLister := list.NewMultiRootLister(roots)
 	hasMultipleRoots := multiLister.HasMultipleRoots()
 
+	ctx, cancel := utils.CommandContext(c.Timeout)
+	defer cancel()
+	defer prefetchRoots(ctx, roots)()
+
 	// Route to appropriate listing function
-	if c.All {
-		return c.listAllMulti(multiLister, projectPath, hasMultipleRoots)
+	switch {
+	case c.All:
+		err = c.listAllMulti(ctx, multiLister, projectPath, hasMultipleRoots)
+	case c.Specs || c.Tree:
+		err = c.listSpecsMulti(ctx, multiLister, projectPath, hasMultipleRoots)
+	default:
+		err = c.listChangesMulti(ctx, multiLister, projectPath, hasMultipleRoots)
+	}
+
+	return utils.CommandError(ctx, "list", c.Timeout, err)
+}
+
+// listKind displays the items of the custom kind named by --kind.
+func (c *ListCmd) listKind() error {
+	conflicts := []struct {
+		flag string
+		set  bool
+	}{
+		{"--specs", c.Specs},
+		{"--all", c.All},
+		{"--tree", c.Tree},
+		{"--columns", len(c.Columns) > 0},
+		{"--format " + c.Format, c.delimiter() != 0},
+	}
+	for _, conflict := range conflicts {
+		if conflict.set {
+			return &specterrs.IncompatibleFlagsError{
+				Flag1: "--kind",
+				Flag2: conflict.flag,
+			}
+		}
+	}
+
+	root, err := GetSingleRoot()
+	if err != nil {
+		return err
+	}
+	registry, err := kinds.Load(root.Path)
+	if err != nil {
+		return err
 	}
-	if c.Specs {
-		return c.listSpecsMulti(multiLister, projectPath, hasMultipleRoots)
+	kind, err := registry.Get(c.Kind)
+	if err != nil {
+		return err
+	}
+	items, err := kind.Items()
+	if err != nil {
+		return err
+	}
+
+	var output string
+	switch {
+	case c.Interactive:
+		if len(items) == 0 {
+			fmt.Printf("No %s found.\n", kind.Label())
+
+			return nil
+		}
+
+		return list.RunInteractiveKind(kind, items, root.Path, c.Stdout)
+	case c.JSON:
+		if output, err = list.FormatKindItemsJSON(items); err != nil {
+			return err
+		}
+	case c.Long:
+		output = list.FormatKindItemsLong(items)
+	default:
+		output = list.FormatKindItemsText(items)
 	}
+	fmt.Println(output)
 
-	return c.listChangesMulti(multiLister, projectPath, hasMultipleRoots)
+	return nil
 }
 
 // listChangesMulti retrieves and displays changes from all discovered roots.
 // It handles interactive mode, JSON, long, and default text formats.
 func (c *ListCmd) listChangesMulti(
+	ctx context.Context,
 	multiLister *list.MultiRootLister,
 	projectPath string,
 	hasMultipleRoots bool,
 ) error {
 	// Retrieve all changes from all roots
-	changes, err := multiLister.ListChanges()
+	changes, err := multiLister.ListChangesContext(ctx)
 	if err != nil {
 		return fmt.Errorf(
 			"failed to list changes: %w",
@@ -124,14 +283,63 @@ func (c *ListCmd) listChangesMulti(
 		)
 	}
 
+	formatMode := list.NewFormatMode(hasMultipleRoots)
+	if c.Sort == sortByActivity {
+		formatMode |= list.FormatSortByActivity
+		list.SortChangesByActivity(changes)
+	}
+
 	// Handle interactive mode - shows a navigable table
 	if c.Interactive {
+		stale.Mark(
+			ctx,
+			changes,
+			stale.Threshold(stale.DefaultDays),
+			time.Now(),
+		)
+
 		return c.handleInteractiveChanges(changes, projectPath)
 	}
 
+	columns, err := c.resolveColumns(
+		projectPath,
+		list.ChangeColumns,
+		(*config.Config).ListColumns,
+	)
+	if err != nil {
+		return err
+	}
+
 	// Format output based on flags
 	var output string
 	switch {
+	case c.delimiter() != 0:
+		// CSV/TSV for spreadsheets, all columns unless chosen
+		if len(columns) == 0 {
+			columns = list.ChangeColumns
+		}
+		output, err = list.FormatChangesDelimited(
+			changes,
+			columns,
+			formatMode,
+			c.delimiter(),
+		)
+		if err != nil {
+			return err
+		}
+	case len(columns) > 0 && c.JSON:
+		// JSON objects holding only the selected columns
+		var jsonErr error
+		output, jsonErr = list.FormatChangesColumnsJSON(changes, columns)
+		if jsonErr != nil {
+			return fmt.Errorf(
+				"failed to format JSON: %w",
+				jsonErr,
+			)
+		}
+	case len(columns) > 0:
+		// Table of the selected columns
+		output = list.FormatChangesColumns(changes, columns, formatMode)
 	case c.JSON:
 		// JSON format for machine consumption
 		var jsonErr error
@@ -146,10 +354,10 @@ func (c *ListCmd) listChangesMulti(
 		}
 	case c.Long:
 		// Long format with detailed information
-		output = list.FormatChangesLongMulti(changes, list.NewFormatMode(hasMultipleRoots))
+		output = list.FormatChangesLongMulti(changes, formatMode)
 	default:
 		// Default text format - simple ID list (with root prefix if multi-root)
-		output = list.FormatChangesTextMulti(changes, list.NewFormatMode(hasMultipleRoots))
+		output = list.FormatChangesTextMulti(changes, formatMode)
 	}
 
 	// Display the formatted output
@@ -215,9 +423,12 @@ func (*ListCmd) runArchiveWorkflow(
 		Yes: true,
 	}
 
-	// Run the archive workflow
+	// Run the archive workflow. It gets its own context: --timeout bounds
+	// listing, not the time spent in the interactive table.
 	// Result is discarded for interactive usage - already prints to terminal
-	if _, err := archive.Archive(archiveCmd, projectPath); err != nil {
+	ctx, cancel := utils.CommandContext(0)
+	defer cancel()
+	if _, err := archive.Archive(ctx, archiveCmd, projectPath); err != nil {
 		return fmt.Errorf(
 			"archive workflow failed: %w",
 			err,
@@ -237,7 +448,10 @@ func (*ListCmd) runPRWorkflow(
 		ProjectRoot: projectPath,
 	}
 
-	result, err := pr.ExecutePR(config)
+	ctx, cancel := utils.CommandContext(0)
+	defer cancel()
+
+	result, err := pr.ExecutePR(ctx, config)
 	if err != nil {
 		return fmt.Errorf(
 			"pr workflow failed: %w",
@@ -261,21 +475,85 @@ func (*ListCmd) runPRWorkflow(
 	return nil
 }
 
+// checkFlatOutput rejects the flags that conflict with flag, which needs a
+// flat, non-interactive table of changes or specs.
+func (c *ListCmd) checkFlatOutput(flag string) error {
+	conflicts := []struct {
+		flag string
+		set  bool
+	}{
+		{"--all", c.All},
+		{"--tree", c.Tree},
+		{"--long", c.Long},
+		{"--interactive", c.Interactive},
+	}
+	for _, conflict := range conflicts {
+		if conflict.set {
+			return &specterrs.IncompatibleFlagsError{
+				Flag1: flag,
+				Flag2: conflict.flag,
+			}
+		}
+	}
+
+	return nil
+}
+
+// delimiter returns the field separator for --format csv or tsv, or 0 for
+// the other formats.
+func (c *ListCmd) delimiter() rune {
+	switch c.Format {
+	case listFormatCSV:
+		return ','
+	case listFormatTSV:
+		return '\t'
+	default:
+		return 0
+	}
+}
+
+// resolveColumns returns the columns to render: --columns when given,
+// otherwise the configured default for plain text output. --long and --json
+// keep their built-in formats unless --columns is explicit. An empty result
+// selects the built-in formats.
+func (c *ListCmd) resolveColumns(
+	projectPath string,
+	supported []string,
+	configured func(*config.Config) []string,
+) ([]string, error) {
+	names := c.Columns
+	if len(names) == 0 {
+		if c.Long || c.JSON || c.Tree {
+			return nil, nil
+		}
+
+		cfg, err := config.LoadConfig(projectPath)
+		if err != nil {
+			return nil, err
+		}
+		names = configured(cfg)
+	}
+
+	return list.ParseColumns(names, supported)
+}
+
 // listSpecsMulti retrieves and displays specifications from all discovered roots.
 // It handles interactive mode, JSON, long, and default text formats.
 func (c *ListCmd) listSpecsMulti(
+	ctx context.Context,
 	multiLister *list.MultiRootLister,
 	projectPath string,
 	hasMultipleRoots bool,
 ) error {
 	// Retrieve all specifications from all roots
-	specs, err := multiLister.ListSpecs()
+	specs, err := multiLister.ListSpecsContext(ctx)
 	if err != nil {
 		return fmt.Errorf(
 			"failed to list specs: %w",
 			err,
 		)
 	}
+	review.FlagDue(ctx, specs, time.Now())
 
 	// Handle interactive mode - shows a navigable table
 	if c.Interactive {
@@ -289,12 +567,71 @@ func (c *ListCmd) listSpecsMulti(
 			specs,
 			projectPath,
 			c.Stdout,
+			c.Tree,
 		)
 	}
 
+	columns, err := c.resolveColumns(
+		projectPath,
+		slices.Concat(list.SpecColumns, list.OptionalSpecColumns),
+		(*config.Config).ListSpecColumns,
+	)
+	if err != nil {
+		return err
+	}
+	if slices.Contains(columns, list.ColumnQuality) {
+		if err := scoreSpecs(specs); err != nil {
+			return err
+		}
+	}
+
 	// Format output based on flags
 	var output string
 	switch {
+	case c.Tree && c.JSON:
+		// Nested JSON mirroring the directory hierarchy
+		var jsonErr error
+		output, jsonErr = list.FormatSpecsTreeJSON(specs)
+		if jsonErr != nil {
+			return fmt.Errorf(
+				"failed to format JSON: %w",
+				jsonErr,
+			)
+		}
+	case c.Tree:
+		// Tree grouped by directory with aggregate counts
+		output = list.FormatSpecsTree(specs, c.Long)
+	case c.delimiter() != 0:
+		// CSV/TSV for spreadsheets, all columns unless chosen
+		if len(columns) == 0 {
+			columns = list.SpecColumns
+		}
+		output, err = list.FormatSpecsDelimited(
+			specs,
+			columns,
+			list.NewFormatMode(hasMultipleRoots),
+			c.delimiter(),
+		)
+		if err != nil {
+			return err
+		}
+	case len(columns) > 0 && c.JSON:
+		// JSON objects holding only the selected columns
+		var jsonErr error
+		output, jsonErr = list.FormatSpecsColumnsJSON(specs, columns)
+		if jsonErr != nil {
+			return fmt.Errorf(
+				"failed to format JSON: %w",
+				jsonErr,
+			)
+		}
+	case len(columns) > 0:
+		// Table of the selected columns
+		output = list.FormatSpecsColumns(
+			specs,
+			columns,
+			list.NewFormatMode(hasMultipleRoots),
+		)
 	case c.JSON:
 		// JSON format for machine consumption
 		var jsonErr error
@@ -322,12 +659,13 @@ func (c *ListCmd) listSpecsMulti(
 // listAllMulti retrieves and displays both changes and specs from all roots.
 // It handles interactive mode, JSON, long, and default text formats.
 func (c *ListCmd) listAllMulti(
+	ctx context.Context,
 	multiLister *list.MultiRootLister,
 	projectPath string,
 	hasMultipleRoots bool,
 ) error {
 	// Retrieve all items (changes and specs) from all roots
-	items, err := multiLister.ListAll(nil)
+	items, err := multiLister.ListAllContext(ctx, nil)
 	if err != nil {
 		return fmt.Errorf(
 			"failed to list all items: %w",
@@ -376,3 +714,32 @@ func (c *ListCmd) listAllMulti(
 
 	return nil
 }
+
+// scoreSpecs sets the quality score of each spec, scoring the specs of
+// each root with that root's configured weights.
+func scoreSpecs(specs []list.SpecInfo) error {
+	scorers := make(map[string]*quality.Scorer)
+	for i := range specs {
+		root := specs[i].RootAbsPath
+		scorer, ok := scorers[root]
+		if !ok {
+			cfg, err := config.LoadConfig(root)
+			if err != nil {
+				return err
+			}
+			scorer, err = quality.NewScorer(root, quality.ConfiguredWeights(cfg))
+			if err != nil {
+				return err
+			}
+			scorers[root] = scorer
+		}
+
+		report, err := scorer.Score(specs[i].ID)
+		if err != nil {
+			return fmt.Errorf("failed to score spec %s: %w", specs[i].ID, err)
+		}
+		specs[i].Quality = report
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+// Package cmd provides command-line interface implementations.
+// This file contains the git merge driver commands.
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/connerohnesorge/spectr/internal/merge"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+// MergeTasksCmd is a git merge driver for tasks.jsonc files. Git invokes it
+// as "spectr merge-tasks %O %A %B" and expects the result in the %A file.
+type MergeTasksCmd struct {
+	Base   string `arg:"" help:"Common ancestor version (%O)" type:"path"`
+	Ours   string `arg:"" help:"Current version (%A), overwritten with the result" type:"path"` //nolint:lll,revive // Kong struct tag with alignment
+	Theirs string `arg:"" help:"Other branch version (%B)" type:"path"`
+}
+
+// Run executes the merge-tasks command.
+func (c *MergeTasksCmd) Run() error {
+	return runMergeDriver(c.Base, c.Ours, c.Theirs, merge.MergeTasks)
+}
+
+// MergeSpecCmd is a git merge driver for spec.md files. Git invokes it as
+// "spectr merge-spec %O %A %B" and expects the result in the %A file.
+type MergeSpecCmd struct {
+	Base   string `arg:"" help:"Common ancestor version (%O)" type:"path"`
+	Ours   string `arg:"" help:"Current version (%A), overwritten with the result" type:"path"` //nolint:lll,revive // Kong struct tag with alignment
+	Theirs string `arg:"" help:"Other branch version (%B)" type:"path"`
+}
+
+// Run executes the merge-spec command.
+func (c *MergeSpecCmd) Run() error {
+	return runMergeDriver(c.Base, c.Ours, c.Theirs, merge.MergeSpec)
+}
+
+// MergeAuditCmd is a git merge driver for the audit log. Git invokes it
+// as "spectr merge-audit %O %A %B" and expects the result in the %A file.
+type MergeAuditCmd struct {
+	Base   string `arg:"" help:"Common ancestor version (%O)" type:"path"`
+	Ours   string `arg:"" help:"Current version (%A), overwritten with the result" type:"path"` //nolint:lll,revive // Kong struct tag with alignment
+	Theirs string `arg:"" help:"Other branch version (%B)" type:"path"`
+}
+
+// Run executes the merge-audit command.
+func (c *MergeAuditCmd) Run() error {
+	return runMergeDriver(c.Base, c.Ours, c.Theirs, merge.MergeAuditLog)
+}
+
+// mergeFunc is a three-way merge implementation used by a merge driver.
+type mergeFunc func(base, ours, theirs []byte) ([]byte, []merge.Conflict, error)
+
+// runMergeDriver reads the three versions, merges them with fn and writes
+// the result over ours. Conflicts are listed on stderr and reported as an
+// error so git marks the file as conflicted.
+func runMergeDriver(basePath, oursPath, theirsPath string, fn mergeFunc) error {
+	base, err := os.ReadFile(basePath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read base: %w", err)
+	}
+	ours, err := os.ReadFile(oursPath)
+	if err != nil {
+		return fmt.Errorf("failed to read ours: %w", err)
+	}
+	theirs, err := os.ReadFile(theirsPath)
+	if err != nil {
+		return fmt.Errorf("failed to read theirs: %w", err)
+	}
+
+	merged, conflicts, err := fn(base, ours, theirs)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(oursPath, merged, filePerm); err != nil {
+		return fmt.Errorf("failed to write merge result: %w", err)
+	}
+
+	if len(conflicts) == 0 {
+		return nil
+	}
+
+	descriptions := make([]string, 0, len(conflicts))
+	for _, conflict := range conflicts {
+		fmt.Fprintf(os.Stderr, "conflict: %s\n", conflict)
+		descriptions = append(descriptions, conflict.String())
+	}
+
+	return &specterrs.MergeConflictError{
+		Path:      oursPath,
+		Conflicts: descriptions,
+	}
+}
@@ -0,0 +1,126 @@
+// Package cmd provides command-line interface implementations.
+// This file contains the migrate command for upgrading file formats.
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/discovery"
+	"github.com/connerohnesorge/spectr/internal/migrate"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+// MigrateCmd represents the migrate command with subcommands.
+type MigrateCmd struct {
+	Tasks MigrateTasksCmd `cmd:"" help:"Upgrade tasks.jsonc to the latest version"`
+}
+
+// MigrateTasksCmd upgrades tasks.jsonc files from version 1 to version 2.
+type MigrateTasksCmd struct {
+	ChangeIDs []string `arg:"" optional:"" predictor:"changeID" help:"Change IDs (default: all active changes)" name:"change-ids"` //nolint:lll,revive // Kong struct tag with alignment
+	DryRun    bool     `                                        help:"Show what would change without writing" name:"dry-run"`      //nolint:lll,revive // Kong struct tag with alignment
+	Split     bool     `                                        help:"Split every section into a child file"  name:"split"`        //nolint:lll,revive // Kong struct tag with alignment
+	Check     bool     `                                        help:"Fail if any file needs migration"       name:"check"`        //nolint:lll,revive // Kong struct tag with alignment
+}
+
+// Run executes the migrate tasks command.
+func (c *MigrateTasksCmd) Run() error {
+	if c.Check && c.Split {
+		return &specterrs.IncompatibleFlagsError{
+			Flag1: "--check",
+			Flag2: "--split",
+		}
+	}
+
+	root, err := GetSingleRoot()
+	if err != nil {
+		return err
+	}
+
+	changeIDs, err := c.resolveChangeIDs(root.Path)
+	if err != nil {
+		return err
+	}
+
+	opts := migrate.TasksOptions{
+		DryRun: c.DryRun || c.Check,
+		Split:  c.Split,
+	}
+
+	var (
+		failed  int
+		pending []string
+	)
+	for _, changeID := range changeIDs {
+		result, err := migrate.Tasks(
+			filepath.Join(root.ChangesDir(), changeID),
+			opts,
+		)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", changeID, err)
+			failed++
+
+			continue
+		}
+
+		if !result.UpToDate() {
+			pending = append(pending, changeID)
+		}
+		fmt.Println(c.describe(result))
+	}
+
+	if failed > 0 {
+		return &specterrs.TasksMigrationFailedError{Failed: failed}
+	}
+	if c.Check && len(pending) > 0 {
+		return &specterrs.TasksMigrationNeededError{ChangeIDs: pending}
+	}
+
+	return nil
+}
+
+// resolveChangeIDs returns the requested change IDs, resolving partial
+// IDs, or every active change when none were given.
+func (c *MigrateTasksCmd) resolveChangeIDs(projectRoot string) ([]string, error) {
+	if len(c.ChangeIDs) == 0 {
+		return discovery.GetActiveChangeIDs(projectRoot)
+	}
+
+	ids := make([]string, 0, len(c.ChangeIDs))
+	for _, partial := range c.ChangeIDs {
+		result, err := discovery.ResolveChangeID(partial, projectRoot)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, result.ChangeID)
+	}
+
+	return ids, nil
+}
+
+// describe formats one result line.
+func (c *MigrateTasksCmd) describe(result *migrate.TasksResult) string {
+	switch {
+	case result.Missing:
+		return fmt.Sprintf("%s: no tasks.jsonc", result.ChangeID)
+	case result.UpToDate():
+		return fmt.Sprintf("%s: up to date (v%d)", result.ChangeID, result.To)
+	}
+
+	verb := "migrated"
+	if c.DryRun || c.Check {
+		verb = "would migrate"
+	}
+
+	return fmt.Sprintf(
+		"%s: %s v%d -> v%d (%s)",
+		result.ChangeID,
+		verb,
+		result.From,
+		result.To,
+		strings.Join(result.Files, ", "),
+	)
+}
@@ -0,0 +1,109 @@
+// Package cmd provides command-line interface implementations.
+// This file contains the new command for creating changes and items of
+// custom kinds.
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/connerohnesorge/spectr/internal/changeid"
+	"github.com/connerohnesorge/spectr/internal/kinds"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+// changeKind is the kind argument that creates a change.
+const changeKind = "change"
+
+// NewCmd creates a change, or an item of a custom kind declared in
+// spectr.yaml from the kind's template or its required sections.
+type NewCmd struct {
+	Kind  string `arg:"" help:"change, or a kind declared in spectr.yaml"`                       //nolint:lll,revive // Kong struct tag with alignment
+	ID    string `arg:"" optional:"" help:"ID of the item (changes: generated from --title)"`    //nolint:lll,revive // Kong struct tag with alignment
+	Title string `       help:"Title of the item (default: the ID)"                name:"title"` //nolint:lll,revive // Kong struct tag with alignment
+}
+
+// Run executes the new command.
+func (c *NewCmd) Run() error {
+	root, err := GetSingleRoot()
+	if err != nil {
+		return err
+	}
+	if c.Kind == changeKind {
+		return c.newChange(root.Path)
+	}
+	if c.ID == "" {
+		return &specterrs.MissingItemIDError{Kind: c.Kind}
+	}
+
+	registry, err := kinds.Load(root.Path)
+	if err != nil {
+		return err
+	}
+	kind, err := registry.Get(c.Kind)
+	if err != nil {
+		return err
+	}
+
+	path, err := kind.New(c.ID, c.Title)
+	if err != nil {
+		return err
+	}
+	if rel, err := filepath.Rel(root.Path, path); err == nil {
+		path = rel
+	}
+	fmt.Printf("Created %s\n", path)
+
+	return nil
+}
+
+// newChange creates spectr/changes/<id>/proposal.md. The ID is checked
+// against the change_ids policy, or generated from the title.
+func (c *NewCmd) newChange(projectRoot string) error {
+	policy, err := changeid.Load(projectRoot)
+	if err != nil {
+		return err
+	}
+	taken, err := changeid.Taken(projectRoot)
+	if err != nil {
+		return err
+	}
+
+	id := c.ID
+	switch {
+	case id != "":
+		if err := policy.Check(id); err != nil {
+			return err
+		}
+		if taken(id) {
+			return &specterrs.ChangeExistsError{ChangeID: id}
+		}
+	case c.Title != "":
+		if id, err = policy.Generate(c.Title, taken); err != nil {
+			return err
+		}
+	default:
+		return &specterrs.ChangeTitleRequiredError{}
+	}
+
+	title := c.Title
+	if title == "" {
+		title = id
+	}
+	dir := filepath.Join(projectRoot, "spectr", "changes", id)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	proposal := fmt.Sprintf("# Change: %s\n\n## Why\n\n## What Changes\n\n## Impact\n", title)
+	path := filepath.Join(dir, "proposal.md")
+	if err := os.WriteFile(path, []byte(proposal), filePerm); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	fmt.Printf("Created change %s\n", id)
+	fmt.Printf("Next: write %s, then add delta specs under specs/ and tasks.md\n",
+		filepath.Join("spectr", "changes", id, "proposal.md"))
+
+	return nil
+}
@@ -0,0 +1,139 @@
+// Package cmd provides command-line interface implementations.
+// This file contains the notify command for telling subscribers about
+// spec changes.
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/connerohnesorge/spectr/internal/hostapi"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+	"github.com/connerohnesorge/spectr/internal/subscription"
+	"github.com/connerohnesorge/spectr/internal/utils"
+)
+
+// SMTP credential environment variables read by notify.
+const (
+	smtpUsernameEnv = "SPECTR_SMTP_USERNAME"
+	smtpPasswordEnv = "SPECTR_SMTP_PASSWORD"
+)
+
+// NotifyCmd detects requirement changes to subscribed specs since a git
+// ref and tells the subscribers by email and/or webhook. Meant to run in
+// CI after merges; without --smtp or --webhook it only prints the
+// notices.
+type NotifyCmd struct {
+	Since   string        `help:"Git ref to compare the specs with (e.g. HEAD~1)"        name:"since"   required:""` //nolint:lll,revive // Kong struct tag with alignment
+	Webhook string        `help:"Post a summary to this webhook URL (Slack-compatible)"  name:"webhook"`             //nolint:lll,revive // Kong struct tag with alignment
+	SMTP    string        `help:"Email subscribers through this SMTP server (host:port)" name:"smtp"`                //nolint:lll,revive // Kong struct tag with alignment
+	From    string        `help:"Sender address for emails"                              name:"from"`                //nolint:lll,revive // Kong struct tag with alignment
+	JSON    bool          `help:"Output as JSON"                                         name:"json"`                //nolint:lll,revive // Kong struct tag with alignment
+	Timeout time.Duration `help:"Abort after duration (e.g. 30s)"                        name:"timeout"`             //nolint:lll,revive // Kong struct tag with alignment
+}
+
+// Run executes the notify command.
+func (c *NotifyCmd) Run() error {
+	if c.SMTP != "" && c.From == "" {
+		return &specterrs.RequiresFlagError{Flag: "--smtp", RequiredFlag: "--from"}
+	}
+
+	root, err := GetSingleRoot()
+	if err != nil {
+		return err
+	}
+
+	subs, err := subscription.Load(root.SpectrDir())
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := utils.CommandContext(c.Timeout)
+	defer cancel()
+
+	var changes []subscription.SpecChange
+	if len(subs) > 0 {
+		changes, err = subscription.DetectSince(ctx, root.Path, c.Since, subs)
+		if err != nil {
+			return utils.CommandError(ctx, "notify", c.Timeout, err)
+		}
+	}
+	notices := subscription.Notices(subs, changes)
+
+	if len(notices) > 0 {
+		if err := c.send(ctx, changes, notices); err != nil {
+			return utils.CommandError(ctx, "notify", c.Timeout, err)
+		}
+	}
+
+	if c.JSON {
+		if notices == nil {
+			notices = []subscription.Notice{}
+		}
+		data, err := json.MarshalIndent(notices, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode notices: %w", err)
+		}
+		fmt.Println(string(data))
+
+		return nil
+	}
+
+	if len(notices) == 0 {
+		fmt.Printf("No watched specs changed since %s\n", c.Since)
+
+		return nil
+	}
+
+	fmt.Println(subscription.Summary(changes))
+	fmt.Println()
+	for _, notice := range notices {
+		fmt.Printf("%s: %d spec(s)\n", notice.Email, len(notice.Specs))
+	}
+	if c.Webhook != "" {
+		fmt.Println("Posted the summary to the webhook")
+	}
+	if c.SMTP != "" {
+		fmt.Printf("Emailed %d subscriber(s)\n", len(notices))
+	}
+
+	return nil
+}
+
+// send delivers the notices through the configured channels.
+func (c *NotifyCmd) send(
+	ctx context.Context,
+	changes []subscription.SpecChange,
+	notices []subscription.Notice,
+) error {
+	if c.Webhook != "" {
+		err := subscription.Post(
+			ctx,
+			hostapi.NewClient(),
+			c.Webhook,
+			subscription.NewMessage(c.Since, changes, notices),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	if c.SMTP != "" {
+		mailer := &subscription.Mailer{
+			Addr:     c.SMTP,
+			From:     c.From,
+			Username: os.Getenv(smtpUsernameEnv),
+			Password: os.Getenv(smtpPasswordEnv),
+		}
+		for _, notice := range notices {
+			if err := mailer.Send(c.Since, notice); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
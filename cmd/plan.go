@@ -0,0 +1,292 @@
+// Package cmd provides command-line interface implementations.
+// This file contains the plan command for suggesting an archive order
+// and forecasting when changes are done.
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/connerohnesorge/spectr/internal/plan"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+// PlanCmd plans the active changes. Without a subcommand, `spectr plan`
+// suggests an archive order.
+type PlanCmd struct {
+	Order    PlanOrderCmd    `cmd:"" default:"withargs" help:"Suggest an archive order"`           //nolint:lll,revive // Kong struct tag with alignment
+	Capacity PlanCapacityCmd `cmd:""                    help:"Forecast completion from estimates"` //nolint:lll,revive // Kong struct tag with alignment
+}
+
+// PlanOrderCmd suggests the order in which to merge and archive the active
+// changes, grouped into phases by dependencies and conflicting deltas.
+type PlanOrderCmd struct {
+	JSON    bool `help:"Output as JSON"                 name:"json"`    //nolint:lll,revive // Kong struct tag with alignment
+	Mermaid bool `help:"Output as a Mermaid flowchart"  name:"mermaid"` //nolint:lll,revive // Kong struct tag with alignment
+	Gantt   bool `help:"Output as a Mermaid gantt chart" name:"gantt"`  //nolint:lll,revive // Kong struct tag with alignment
+}
+
+// Run executes the plan order command.
+func (c *PlanOrderCmd) Run() error {
+	if err := c.checkFlags(); err != nil {
+		return err
+	}
+
+	root, err := GetSingleRoot()
+	if err != nil {
+		return err
+	}
+
+	p, err := plan.Build(root.Path)
+	if err != nil {
+		return fmt.Errorf("failed to build plan: %w", err)
+	}
+
+	switch {
+	case c.JSON:
+		data, err := json.MarshalIndent(p, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode plan: %w", err)
+		}
+		fmt.Println(string(data))
+
+		return nil
+	case c.Mermaid:
+		return plan.WriteMermaid(os.Stdout, p)
+	case c.Gantt:
+		return plan.WriteGantt(os.Stdout, p)
+	}
+
+	printPlan(p)
+
+	return nil
+}
+
+// checkFlags rejects more than one output format.
+func (c *PlanOrderCmd) checkFlags() error {
+	var set []string
+	for _, flag := range []struct {
+		name string
+		on   bool
+	}{
+		{"--json", c.JSON},
+		{"--mermaid", c.Mermaid},
+		{"--gantt", c.Gantt},
+	} {
+		if flag.on {
+			set = append(set, flag.name)
+		}
+	}
+	if len(set) > 1 {
+		return &specterrs.IncompatibleFlagsError{Flag1: set[0], Flag2: set[1]}
+	}
+
+	return nil
+}
+
+// printPlan prints the phases and blocked changes as text.
+func printPlan(p *plan.Plan) {
+	if len(p.Phases) == 0 && len(p.Blocked) == 0 {
+		fmt.Println("No active changes")
+
+		return
+	}
+
+	for i, phase := range p.Phases {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("Phase %d\n", phase.Number)
+		for _, change := range phase.Changes {
+			fmt.Printf("  %s\n", change.ID)
+			if len(change.After) > 0 {
+				fmt.Printf("    after: %s\n", strings.Join(change.After, ", "))
+			}
+			for _, conflict := range change.Conflicts {
+				fmt.Printf(
+					"    conflicts with %s on %s\n",
+					conflict.Change,
+					strings.Join(conflict.Requirements, ", "),
+				)
+			}
+		}
+	}
+
+	if len(p.Blocked) > 0 {
+		if len(p.Phases) > 0 {
+			fmt.Println()
+		}
+		fmt.Println("Blocked")
+		for _, blocked := range p.Blocked {
+			fmt.Printf("  %s: %s\n", blocked.ID, blocked.Reason)
+		}
+	}
+}
+
+// PlanCapacityCmd forecasts when each active change and release is done,
+// from the task estimates and a team's size and weekly hours.
+type PlanCapacityCmd struct {
+	TeamSize    int     `help:"People working on the changes"         name:"team-size"    default:"1"`  //nolint:lll,revive // Kong struct tag with alignment
+	WeeklyHours float64 `help:"Working hours per person per week"     name:"weekly-hours" default:"40"` //nolint:lll,revive // Kong struct tag with alignment
+	Start       string  `help:"First day of work, YYYY-MM-DD (today)" name:"start"`                     //nolint:lll,revive // Kong struct tag with alignment
+	JSON        bool    `help:"Output as JSON"                        name:"json"`                      //nolint:lll,revive // Kong struct tag with alignment
+	Gantt       bool    `help:"Output as a Mermaid gantt chart"       name:"gantt"`                     //nolint:lll,revive // Kong struct tag with alignment
+}
+
+// Run executes the plan capacity command.
+func (c *PlanCapacityCmd) Run() error {
+	opts, err := c.options()
+	if err != nil {
+		return err
+	}
+	if c.JSON && c.Gantt {
+		return &specterrs.IncompatibleFlagsError{Flag1: "--json", Flag2: "--gantt"}
+	}
+
+	root, err := GetSingleRoot()
+	if err != nil {
+		return err
+	}
+
+	forecast, err := plan.BuildCapacity(root.Path, opts)
+	if err != nil {
+		return fmt.Errorf("failed to build forecast: %w", err)
+	}
+
+	switch {
+	case c.JSON:
+		data, err := json.MarshalIndent(forecast, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode forecast: %w", err)
+		}
+		fmt.Println(string(data))
+
+		return nil
+	case c.Gantt:
+		return plan.WriteCapacityGantt(os.Stdout, forecast)
+	}
+
+	return printCapacity(forecast)
+}
+
+// options checks the flags and returns them as capacity options.
+func (c *PlanCapacityCmd) options() (plan.CapacityOptions, error) {
+	opts := plan.CapacityOptions{
+		TeamSize:    c.TeamSize,
+		WeeklyHours: c.WeeklyHours,
+		Start:       time.Now(),
+	}
+	if c.TeamSize < 1 {
+		return opts, &specterrs.InvalidCapacityFlagError{
+			Flag:   "--team-size",
+			Value:  strconv.Itoa(c.TeamSize),
+			Reason: "must be at least 1",
+		}
+	}
+	if c.WeeklyHours <= 0 {
+		return opts, &specterrs.InvalidCapacityFlagError{
+			Flag:   "--weekly-hours",
+			Value:  formatHours(c.WeeklyHours),
+			Reason: "must be positive",
+		}
+	}
+	if c.Start != "" {
+		start, err := time.ParseInLocation(time.DateOnly, c.Start, time.Local)
+		if err != nil {
+			return opts, &specterrs.InvalidCapacityFlagError{
+				Flag:   "--start",
+				Value:  c.Start,
+				Reason: "use YYYY-MM-DD",
+			}
+		}
+		opts.Start = start
+	}
+
+	return opts, nil
+}
+
+// printCapacity prints the change and release forecasts as tables.
+func printCapacity(forecast *plan.Capacity) error {
+	if len(forecast.Changes) == 0 && len(forecast.Blocked) == 0 {
+		fmt.Println("No active changes")
+
+		return nil
+	}
+
+	unestimated := 0
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "CHANGE\tRELEASE\tREMAINING\tSTART\tFINISH\tPEOPLE")
+	for _, change := range forecast.Changes {
+		remaining := formatHours(change.RemainingHours)
+		if change.Unestimated > 0 {
+			remaining += fmt.Sprintf(" (+%d unestimated)", change.Unestimated)
+		}
+		unestimated += change.Unestimated
+		fmt.Fprintf(
+			w,
+			"%s\t%s\t%s\t%s\t%s\t%s\n",
+			change.ID,
+			orDash(change.Release),
+			remaining,
+			change.Start.Format(time.DateOnly),
+			change.Finish.Format(time.DateOnly),
+			orDash(strings.Join(change.People, ", ")),
+		)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if len(forecast.Releases) > 0 {
+		fmt.Println()
+		w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "RELEASE\tCHANGES\tREMAINING\tFINISH")
+		for _, release := range forecast.Releases {
+			fmt.Fprintf(
+				w,
+				"%s\t%d\t%s\t%s\n",
+				release.Name,
+				len(release.Changes),
+				formatHours(release.RemainingHours),
+				release.Finish.Format(time.DateOnly),
+			)
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf(
+		"\nTeam of %d at %sh/week, starting %s\n",
+		forecast.TeamSize,
+		strconv.FormatFloat(forecast.WeeklyHours, 'f', -1, 64),
+		forecast.Start.Format(time.DateOnly),
+	)
+	if unestimated > 0 {
+		fmt.Printf("%d remaining task(s) have no estimate and are not counted\n", unestimated)
+	}
+	for _, blocked := range forecast.Blocked {
+		fmt.Printf("Blocked: %s: %s\n", blocked.ID, blocked.Reason)
+	}
+
+	return nil
+}
+
+// formatHours renders a number of hours, e.g. "12.5h".
+func formatHours(hours float64) string {
+	return strconv.FormatFloat(hours, 'f', -1, 64) + "h"
+}
+
+// orDash returns s, or "-" when it is empty.
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+
+	return s
+}
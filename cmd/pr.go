@@ -2,15 +2,19 @@
 package cmd
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/connerohnesorge/spectr/internal/discovery"
 	"github.com/connerohnesorge/spectr/internal/git"
 	"github.com/connerohnesorge/spectr/internal/list"
 	"github.com/connerohnesorge/spectr/internal/pr"
 	"github.com/connerohnesorge/spectr/internal/specterrs"
+	"github.com/connerohnesorge/spectr/internal/tui"
+	"github.com/connerohnesorge/spectr/internal/utils"
 )
 
 // PRCmd represents the pr command with subcommands.
@@ -22,30 +26,41 @@ type PRCmd struct {
 
 // PRArchiveCmd represents the pr archive subcommand.
 type PRArchiveCmd struct {
-	ChangeID  string `arg:"" optional:"" predictor:"changeID" help:"Change ID"`
-	Base      string `                                        help:"Target branch for PR"      name:"base"       short:"b"`
-	Draft     bool   `                                        help:"Create as draft PR"        name:"draft"      short:"d"`
-	Force     bool   `                                        help:"Delete existing branch"    name:"force"      short:"f"`
-	DryRun    bool   `                                        help:"Preview without executing" name:"dry-run"`
-	SkipSpecs bool   `                                        help:"Skip spec merging"         name:"skip-specs"`
+	ChangeID       string        `arg:"" optional:"" predictor:"changeID" help:"Change ID"`
+	Base           string        `                                        help:"Target branch for PR"                         name:"base"            short:"b"`
+	Draft          bool          `                                        help:"Create as draft PR"                           name:"draft"           short:"d"`
+	Force          bool          `                                        help:"Delete existing branch"                       name:"force"           short:"f"`
+	DryRun         bool          `                                        help:"Preview without executing"                    name:"dry-run"`
+	SkipSpecs      bool          `                                        help:"Skip spec merging"                            name:"skip-specs"`
+	ReviewComments bool          `                                        help:"Comment on each MODIFIED/REMOVED requirement" name:"review-comments"`
+	OwnerReview    bool          `                                        help:"Request review from owners of touched specs"  name:"owner-review"`
+	Token          string        `                                        help:"Hosting token (overrides env and keychain)"   name:"token"`
+	Timeout        time.Duration `                                        help:"Abort after duration (e.g. 5m)"               name:"timeout"`
 }
 
 // PRProposalCmd represents the pr proposal subcommand.
 type PRProposalCmd struct {
-	ChangeID string `arg:"" optional:"" predictor:"changeID" help:"Change ID"`
-	Base     string `                                        help:"Target branch for PR"      name:"base"    short:"b"`
-	Draft    bool   `                                        help:"Create as draft PR"        name:"draft"   short:"d"`
-	Force    bool   `                                        help:"Delete existing branch"    name:"force"   short:"f"`
-	DryRun   bool   `                                        help:"Preview without executing" name:"dry-run"`
+	ChangeID       string        `arg:"" optional:"" predictor:"changeID" help:"Change ID"`
+	Base           string        `                                        help:"Target branch for PR"                         name:"base"            short:"b"`
+	Draft          bool          `                                        help:"Create as draft PR"                           name:"draft"           short:"d"`
+	Force          bool          `                                        help:"Delete existing branch"                       name:"force"           short:"f"`
+	DryRun         bool          `                                        help:"Preview without executing"                    name:"dry-run"`
+	Update         bool          `                                        help:"Regenerate the body of the open PR"           name:"update"`
+	ReviewComments bool          `                                        help:"Comment on each MODIFIED/REMOVED requirement" name:"review-comments"`
+	OwnerReview    bool          `                                        help:"Request review from owners of touched specs"  name:"owner-review"`
+	Token          string        `                                        help:"Hosting token (overrides env and keychain)"   name:"token"`
+	Timeout        time.Duration `                                        help:"Abort after duration (e.g. 5m)"               name:"timeout"`
 }
 
 // PRRemoveCmd represents the pr remove subcommand.
 type PRRemoveCmd struct {
-	ChangeID string `arg:"" optional:"" predictor:"changeID" help:"Change ID"`
-	Base     string `                                        help:"Target branch for PR"      name:"base"    short:"b"`
-	Draft    bool   `                                        help:"Create as draft PR"        name:"draft"   short:"d"`
-	Force    bool   `                                        help:"Delete existing branch"    name:"force"   short:"f"`
-	DryRun   bool   `                                        help:"Preview without executing" name:"dry-run"`
+	ChangeID string        `arg:"" optional:"" predictor:"changeID" help:"Change ID"`
+	Base     string        `                                        help:"Target branch for PR"                       name:"base"    short:"b"`
+	Draft    bool          `                                        help:"Create as draft PR"                         name:"draft"   short:"d"`
+	Force    bool          `                                        help:"Delete existing branch"                     name:"force"   short:"f"`
+	DryRun   bool          `                                        help:"Preview without executing"                  name:"dry-run"`
+	Token    string        `                                        help:"Hosting token (overrides env and keychain)" name:"token"`
+	Timeout  time.Duration `                                        help:"Abort after duration (e.g. 5m)"             name:"timeout"`
 }
 
 // Run executes the pr remove command.
@@ -79,13 +94,17 @@ func (c *PRRemoveCmd) Run() error {
 		Force:       c.Force,
 		DryRun:      c.DryRun,
 		ProjectRoot: projectRoot,
+		Token:       c.Token,
 	}
 
-	result, err := pr.ExecutePR(config)
+	ctx, cancel := utils.CommandContext(c.Timeout)
+	defer cancel()
+
+	result, err := pr.ExecutePR(ctx, config)
 	if err != nil {
 		return fmt.Errorf(
 			"pr remove failed: %w",
-			err,
+			utils.CommandError(ctx, "pr rm", c.Timeout, err),
 		)
 	}
 
@@ -118,21 +137,27 @@ func (c *PRArchiveCmd) Run() error {
 	}
 
 	config := pr.PRConfig{
-		ChangeID:    changeID,
-		Mode:        pr.ModeArchive,
-		BaseBranch:  c.Base,
-		Draft:       c.Draft,
-		Force:       c.Force,
-		DryRun:      c.DryRun,
-		SkipSpecs:   c.SkipSpecs,
-		ProjectRoot: projectRoot,
+		ChangeID:       changeID,
+		Mode:           pr.ModeArchive,
+		BaseBranch:     c.Base,
+		Draft:          c.Draft,
+		Force:          c.Force,
+		DryRun:         c.DryRun,
+		SkipSpecs:      c.SkipSpecs,
+		ProjectRoot:    projectRoot,
+		ReviewComments: c.ReviewComments,
+		OwnerReview:    c.OwnerReview,
+		Token:          c.Token,
 	}
 
-	result, err := pr.ExecutePR(config)
+	ctx, cancel := utils.CommandContext(c.Timeout)
+	defer cancel()
+
+	result, err := pr.ExecutePR(ctx, config)
 	if err != nil {
 		return fmt.Errorf(
 			"pr archive failed: %w",
-			err,
+			utils.CommandError(ctx, "pr archive", c.Timeout, err),
 		)
 	}
 
@@ -153,12 +178,20 @@ func (c *PRProposalCmd) Run() error {
 
 	var changeID string
 
-	// For proposal command without explicit ID, filter to unmerged changes only
+	// For proposal command without explicit ID, use the change detected
+	// from the worktree or branch, or select among unmerged changes only
 	if c.ChangeID == "" {
-		changeID, err = selectChangeForProposal(
-			projectRoot,
-			c.Base,
-		)
+		changeID, err = detectChangeID(projectRoot)
+		if err == nil && changeID == "" {
+			// Selection waits on the user, so --timeout does not apply to it
+			selectCtx, cancelSelect := utils.CommandContext(0)
+			changeID, err = selectChangeForProposal(
+				selectCtx,
+				projectRoot,
+				c.Base,
+			)
+			cancelSelect()
+		}
 	} else {
 		// Explicit ID provided - resolve without filtering
 		changeID, err = resolveOrSelectChangeID(c.ChangeID, projectRoot)
@@ -173,21 +206,46 @@ func (c *PRProposalCmd) Run() error {
 		return err
 	}
 
+	ctx, cancel := utils.CommandContext(c.Timeout)
+	defer cancel()
+
 	config := pr.PRConfig{
-		ChangeID:    changeID,
-		Mode:        pr.ModeProposal,
-		BaseBranch:  c.Base,
-		Draft:       c.Draft,
-		Force:       c.Force,
-		DryRun:      c.DryRun,
-		ProjectRoot: projectRoot,
+		ChangeID:       changeID,
+		Mode:           pr.ModeProposal,
+		BaseBranch:     c.Base,
+		Draft:          c.Draft,
+		Force:          c.Force,
+		DryRun:         c.DryRun,
+		ProjectRoot:    projectRoot,
+		ReviewComments: c.ReviewComments,
+		OwnerReview:    c.OwnerReview,
+		Token:          c.Token,
 	}
 
-	result, err := pr.ExecutePR(config)
+	if c.Update {
+		result, err := pr.UpdatePR(ctx, config)
+		if err != nil {
+			return fmt.Errorf(
+				"pr proposal --update failed: %w",
+				utils.CommandError(ctx, "pr proposal", c.Timeout, err),
+			)
+		}
+		switch {
+		case c.DryRun:
+		case result.Unchanged:
+			fmt.Printf("PR body already up to date: %s\n", result.PRURL)
+		default:
+			fmt.Printf("PR body updated: %s\n", result.PRURL)
+		}
+
+		return nil
+	}
+
+	result, err := pr.ExecutePR(ctx, config)
 	if err != nil {
 		return fmt.Errorf(
 			"pr proposal failed: %w",
-			err,
+			utils.CommandError(ctx, "pr proposal", c.Timeout, err),
 		)
 	}
 
@@ -202,6 +260,11 @@ func resolveOrSelectChangeID(
 	changeID, projectRoot string,
 ) (string, error) {
 	if changeID == "" {
+		detected, err := detectChangeID(projectRoot)
+		if err != nil || detected != "" {
+			return detected, err
+		}
+
 		return selectChangeInteractive(
 			projectRoot,
 		)
@@ -215,8 +278,10 @@ func resolveOrSelectChangeID(
 		return "", err
 	}
 
+	// Report on stderr so output meant for piping (diff, prompt) stays clean
 	if result.PartialMatch {
-		fmt.Printf(
+		fmt.Fprintf(
+			os.Stderr,
 			"Resolved '%s' -> '%s'\n\n",
 			changeID,
 			result.ChangeID,
@@ -230,6 +295,10 @@ func resolveOrSelectChangeID(
 func selectChangeInteractive(
 	projectRoot string,
 ) (string, error) {
+	if !tui.Interactive() {
+		return "", &specterrs.MissingArgumentError{Argument: "change ID"}
+	}
+
 	lister := list.NewLister(projectRoot)
 
 	changes, err := lister.ListChanges()
@@ -272,11 +341,16 @@ func selectChangeInteractive(
 // filtering out changes that already exist on the base branch.
 // This ensures only unmerged proposals are shown for PR creation.
 func selectChangeForProposal(
+	ctx context.Context,
 	projectRoot, baseBranch string,
 ) (string, error) {
+	if !tui.Interactive() {
+		return "", &specterrs.MissingArgumentError{Argument: "change ID"}
+	}
+
 	lister := list.NewLister(projectRoot)
 
-	changes, err := lister.ListChanges()
+	changes, err := lister.ListChangesContext(ctx)
 	if err != nil {
 		return "", fmt.Errorf(
 			"list changes: %w",
@@ -293,7 +367,7 @@ func selectChangeForProposal(
 	}
 
 	// Fetch origin to ensure refs are current
-	err = git.FetchOrigin()
+	err = git.FetchOrigin(ctx)
 	if err != nil {
 		return "", fmt.Errorf(
 			"fetch origin: %w",
@@ -302,7 +376,7 @@ func selectChangeForProposal(
 	}
 
 	// Determine the base branch ref
-	ref, err := git.GetBaseBranch(baseBranch)
+	ref, err := git.GetBaseBranch(ctx, baseBranch)
 	if err != nil {
 		return "", fmt.Errorf(
 			"get base branch: %w",
@@ -312,6 +386,7 @@ func selectChangeForProposal(
 
 	// Filter to only show changes not already on the base branch
 	unmergedChanges, err := list.FilterChangesNotOnRef(
+		ctx,
 		changes,
 		ref,
 	)
@@ -0,0 +1,127 @@
+// Package cmd provides command-line interface implementations.
+// This file contains the profile command for fetching and inspecting the
+// shared profile a spectr.yaml extends.
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/connerohnesorge/spectr/internal/config"
+	"github.com/connerohnesorge/spectr/internal/profile"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+	"github.com/connerohnesorge/spectr/internal/utils"
+)
+
+// ProfileCmd fetches and shows the shared profile named by extends.
+type ProfileCmd struct {
+	Fetch ProfileFetchCmd `cmd:"" help:"Fetch and verify the profile, bypassing the cache"`
+	Show  ProfileShowCmd  `cmd:"" help:"Print the profile this project extends"`
+}
+
+// ProfileFetchCmd refetches the profile and refreshes the cache.
+type ProfileFetchCmd struct {
+	JSON    bool          `help:"Output as JSON"                  name:"json"`    //nolint:lll,revive // Kong struct tag with alignment
+	Timeout time.Duration `help:"Abort after duration (e.g. 30s)" name:"timeout"` //nolint:lll,revive // Kong struct tag with alignment
+}
+
+// profileFetchResult is the JSON output of profile fetch.
+type profileFetchResult struct {
+	URL       string `json:"url"`
+	Ref       string `json:"ref,omitempty"`
+	SHA256    string `json:"sha256"`
+	CachePath string `json:"cachePath"`
+	Bytes     int    `json:"bytes"`
+}
+
+// Run executes the profile fetch command.
+func (c *ProfileFetchCmd) Run() error {
+	src, err := profileSource()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := utils.CommandContext(c.Timeout)
+	defer cancel()
+
+	data, err := profile.Fetch(ctx, src)
+	if err != nil {
+		return utils.CommandError(ctx, "profile fetch", c.Timeout, err)
+	}
+	cachePath, err := profile.CachePath(src)
+	if err != nil {
+		return err
+	}
+
+	res := profileFetchResult{
+		URL:       src.URL,
+		Ref:       src.Ref,
+		SHA256:    src.SHA256,
+		CachePath: cachePath,
+		Bytes:     len(data),
+	}
+	if c.JSON {
+		out, err := json.MarshalIndent(res, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode result: %w", err)
+		}
+		fmt.Println(string(out))
+
+		return nil
+	}
+
+	fmt.Printf("Fetched %s (sha256 %s)\n", res.URL, res.SHA256)
+	fmt.Printf("Cached at %s\n", res.CachePath)
+
+	return nil
+}
+
+// ProfileShowCmd prints the verified profile, fetching it when it is not
+// cached.
+type ProfileShowCmd struct {
+	Timeout time.Duration `help:"Abort after duration (e.g. 30s)" name:"timeout"` //nolint:lll,revive // Kong struct tag with alignment
+}
+
+// Run executes the profile show command.
+func (c *ProfileShowCmd) Run() error {
+	src, err := profileSource()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := utils.CommandContext(c.Timeout)
+	defer cancel()
+
+	data, err := profile.Load(ctx, src)
+	if err != nil {
+		return utils.CommandError(ctx, "profile show", c.Timeout, err)
+	}
+	_, err = os.Stdout.Write(data)
+
+	return err
+}
+
+// profileSource returns the extends entry of the current project.
+func profileSource() (profile.Source, error) {
+	root, err := GetSingleRoot()
+	if err != nil {
+		return profile.Source{}, err
+	}
+
+	ext, err := config.LoadExtends(root.Path)
+	if err != nil {
+		return profile.Source{}, err
+	}
+	if ext == nil {
+		return profile.Source{}, &specterrs.NoProfileError{}
+	}
+
+	return profile.Source{
+		URL:    ext.URL,
+		Ref:    ext.Ref,
+		Path:   ext.Path,
+		SHA256: ext.SHA256,
+	}, nil
+}
@@ -0,0 +1,61 @@
+// Package cmd provides command-line interface implementations.
+// This file contains the prompt command for assembling change context.
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/connerohnesorge/spectr/internal/prompt"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+	"github.com/connerohnesorge/spectr/internal/tokens"
+)
+
+// promptFormatJSON is the --format value for JSON output.
+const promptFormatJSON = "json"
+
+// PromptCmd prints a change's proposal, pending tasks, deltas and relevant
+// requirements as one document sized to a token limit, for use as LLM
+// context.
+type PromptCmd struct {
+	ChangeID  string `arg:"" optional:"" predictor:"changeID" help:"Change ID"`
+	Format    string `                                        help:"Output format"                                          name:"format"     enum:"markdown,json" default:"markdown"` //nolint:lll,revive // Kong struct tag with alignment
+	MaxTokens int    `                                        help:"Token limit (0 for no limit)"                           name:"max-tokens" default:"8000"`                          //nolint:lll,revive // Kong struct tag with alignment
+	Model     string `                                        help:"Tokenizer preset (default, gpt, claude, gemini, llama)" name:"model"      default:"default"`                       //nolint:lll,revive // Kong struct tag with alignment
+}
+
+// Run executes the prompt command.
+func (c *PromptCmd) Run() error {
+	root, err := GetSingleRoot()
+	if err != nil {
+		return err
+	}
+
+	changeID, err := resolveOrSelectChangeID(c.ChangeID, root.Path)
+	if err != nil {
+		var userCancelledErr *specterrs.UserCancelledError
+		if errors.As(err, &userCancelledErr) {
+			return nil
+		}
+
+		return err
+	}
+
+	model, err := tokens.Lookup(c.Model)
+	if err != nil {
+		return err
+	}
+
+	doc, err := prompt.Build(root.Path, changeID)
+	if err != nil {
+		return err
+	}
+	render := prompt.Markdown
+	if c.Format == promptFormatJSON {
+		render = prompt.JSON
+	}
+	prompt.Fit(doc, c.MaxTokens, model, render)
+	fmt.Print(render(doc))
+
+	return nil
+}
@@ -0,0 +1,76 @@
+// Package cmd provides command-line interface implementations.
+// This file contains the read command for paging through a spec.
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/fileio"
+	"github.com/connerohnesorge/spectr/internal/inherit"
+	"github.com/connerohnesorge/spectr/internal/reader"
+	"github.com/connerohnesorge/spectr/internal/tui"
+)
+
+// ReadCmd shows a spec with terminal styling in a built-in pager with
+// search, heading jumps, an outline and requirement folding. When stdout
+// is not a terminal the spec is printed unchanged instead; without a
+// terminal on stdin, or with --no-input, it is printed styled. A spec that
+// extends a base spec is shown with the inherited requirements in place.
+type ReadCmd struct {
+	// SpecID is the spec to read
+	SpecID string `arg:"" predictor:"specID" help:"Spec ID to read"` //nolint:lll,revive // Kong struct tag with alignment
+
+	// Fold starts with every requirement folded to its header
+	Fold bool `name:"fold" help:"Start with requirements folded"` //nolint:lll,revive // Kong struct tag with alignment
+
+	// NoPager prints the styled spec instead of opening the pager
+	NoPager bool `name:"no-pager" help:"Print the styled spec instead of paging it"` //nolint:lll,revive // Kong struct tag with alignment
+}
+
+// Run executes the read command.
+func (c *ReadCmd) Run() error {
+	root, err := GetSingleRoot()
+	if err != nil {
+		return err
+	}
+
+	specPath := filepath.Join(root.SpecsDir(), c.SpecID, "spec.md")
+	source, err := fileio.ReadFile(specPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("spec '%s' not found", c.SpecID)
+		}
+
+		return fmt.Errorf("failed to read %s: %w", specPath, err)
+	}
+
+	if base, _ := inherit.Base(specPath); base != "" {
+		reqs, err := inherit.ResolveFile(specPath)
+		if err != nil {
+			return err
+		}
+		source = []byte(inherit.Render(string(source), reqs))
+	}
+
+	if !tui.IsTerminal(os.Stdout) {
+		fmt.Print(string(source))
+
+		return nil
+	}
+
+	doc := reader.NewDocument(source)
+	if c.NoPager || !tui.Interactive() {
+		fmt.Println(strings.Join(reader.StyleLines(doc), "\n"))
+
+		return nil
+	}
+
+	return reader.NewPager(reader.PagerConfig{
+		Title:    c.SpecID,
+		Document: doc,
+		Folded:   c.Fold,
+	}).Run()
+}
@@ -0,0 +1,55 @@
+// Package cmd provides command-line interface implementations.
+// This file contains the rename command for renaming specs and changes
+// while keeping their old IDs as aliases.
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/connerohnesorge/spectr/internal/alias"
+	"github.com/connerohnesorge/spectr/internal/utils"
+)
+
+// RenameCmd renames a spec or an active change. The old ID is recorded in
+// spectr/aliases.yaml, so references to it still resolve.
+type RenameCmd struct {
+	Kind string `arg:"" enum:"spec,change" help:"What to rename: spec or change"` //nolint:lll,revive // Kong struct tag with alignment
+	From string `arg:""                    help:"Current ID"`                     //nolint:lll,revive // Kong struct tag with alignment
+	To   string `arg:""                    help:"New ID"`                         //nolint:lll,revive // Kong struct tag with alignment
+	JSON bool   `help:"Output as JSON"     name:"json"`                           //nolint:lll,revive // Kong struct tag with alignment
+}
+
+// Run executes the rename command.
+func (c *RenameCmd) Run() error {
+	root, err := GetSingleRoot()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := utils.CommandContext(0)
+	defer cancel()
+
+	res, err := alias.Rename(ctx, root.Path, alias.Kind(c.Kind), c.From, c.To)
+	if err != nil {
+		return err
+	}
+
+	if c.JSON {
+		data, err := json.MarshalIndent(res, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode result: %w", err)
+		}
+		fmt.Println(string(data))
+
+		return nil
+	}
+
+	fmt.Printf("Renamed %s %s to %s\n", res.Kind, res.From, res.To)
+	for _, changeID := range res.Deltas {
+		fmt.Printf("  moved the delta spec of change %s\n", changeID)
+	}
+	fmt.Printf("'%s' is kept as an alias in spectr/%s\n", res.From, alias.FileName)
+
+	return nil
+}
@@ -0,0 +1,116 @@
+// Package cmd provides command-line interface implementations.
+// This file contains the replace command for scoped find-and-replace
+// across specs.
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/diff"
+	"github.com/connerohnesorge/spectr/internal/replace"
+	"github.com/connerohnesorge/spectr/internal/utils"
+	"github.com/mattn/go-isatty"
+)
+
+// ReplaceCmd replaces a term in the chosen kinds of spec text, leaving
+// code, links and URLs alone. It previews every changed line and writes
+// all files in one transaction.
+type ReplaceCmd struct {
+	Old    string   `help:"Term to replace"                                                         arg:""`                            //nolint:lll,revive // Kong struct tag with alignment
+	New    string   `help:"Replacement"                                                             arg:""`                            //nolint:lll,revive // Kong struct tag with alignment
+	In     []string `help:"Text to change: requirement-body, scenario, heading, prose (repeatable)" name:"in"      required:""`        //nolint:lll,revive // Kong struct tag with alignment
+	Specs  []string `help:"Only change these specs (repeatable, default: all)"                      name:"spec"    predictor:"specID"` //nolint:lll,revive // Kong struct tag with alignment
+	DryRun bool     `help:"Preview without writing"                                                 name:"dry-run"`                    //nolint:lll,revive // Kong struct tag with alignment
+	Plain  bool     `help:"Mark changes as [-old-]{+new+} instead of colors"                        name:"plain"`                      //nolint:lll,revive // Kong struct tag with alignment
+	JSON   bool     `help:"Output as JSON"                                                          name:"json"`                       //nolint:lll,revive // Kong struct tag with alignment
+}
+
+// Run executes the replace command.
+func (c *ReplaceCmd) Run() error {
+	root, err := GetSingleRoot()
+	if err != nil {
+		return err
+	}
+
+	scopes := make([]replace.Scope, 0, len(c.In))
+	for _, value := range c.In {
+		for name := range strings.SplitSeq(value, ",") {
+			scope, err := replace.ParseScope(strings.TrimSpace(name))
+			if err != nil {
+				return err
+			}
+			scopes = append(scopes, scope)
+		}
+	}
+
+	changes, err := replace.Plan(root.Path, replace.Options{
+		Old:     c.Old,
+		New:     c.New,
+		Scopes:  scopes,
+		SpecIDs: c.Specs,
+	})
+	if err != nil {
+		return err
+	}
+
+	if c.JSON {
+		data, err := json.MarshalIndent(changes, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode replacements: %w", err)
+		}
+		fmt.Println(string(data))
+	} else {
+		c.printPreview(root.Path, changes)
+	}
+
+	if c.DryRun || len(changes) == 0 {
+		return nil
+	}
+
+	ctx, cancel := utils.CommandContext(0)
+	defer cancel()
+
+	if err := replace.Apply(ctx, changes); err != nil {
+		return err
+	}
+	if !c.JSON {
+		fmt.Printf("Updated %d spec(s)\n", len(changes))
+	}
+
+	return nil
+}
+
+// printPreview prints each changed line as a word diff.
+func (c *ReplaceCmd) printPreview(
+	projectRoot string,
+	changes []replace.FileChange,
+) {
+	if len(changes) == 0 {
+		fmt.Printf("No matches for %q\n", c.Old)
+
+		return
+	}
+
+	style := diff.PlainStyler
+	if !c.Plain && isatty.IsTerminal(os.Stdout.Fd()) {
+		style = colorStyler
+	}
+
+	total := 0
+	for _, change := range changes {
+		path, err := filepath.Rel(projectRoot, change.Path)
+		if err != nil {
+			path = change.Path
+		}
+		for _, line := range change.Lines {
+			rendered := diff.Render(diff.Words(line.Before, line.After), style)
+			fmt.Printf("%s:%d: %s", path, line.Number, rendered)
+		}
+		total += change.Replacements
+	}
+	fmt.Printf("\n%d replacement(s) in %d spec(s)\n", total, len(changes))
+}
@@ -0,0 +1,83 @@
+// Package cmd provides command-line interface implementations.
+// This file contains --repo/--ref support for running read-only commands
+// against a git repository without a working tree.
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/git"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+// repoReadOnlyCommands lists the commands that may run with --repo/--ref.
+// They only read the spectr/ tree, so they work on an exported snapshot.
+var repoReadOnlyCommands = map[string]bool{
+	"list":     true,
+	"validate": true,
+	"export":   true,
+	"show":     true,
+	"read":     true,
+	"diff":     true,
+}
+
+// prepareRepoSnapshot exports the spectr/ tree of --repo at --ref into a
+// temporary directory and makes it the working directory and the only
+// discovery root for the command. Task sync is skipped because the snapshot
+// is discarded after the command runs.
+func (c *CLI) prepareRepoSnapshot(command string) error {
+	name, _, _ := strings.Cut(command, " ")
+	if !repoReadOnlyCommands[name] {
+		return &specterrs.IncompatibleFlagsError{
+			Flag1: "--repo/--ref",
+			Flag2: name,
+		}
+	}
+
+	if err := git.Require("--repo/--ref"); err != nil {
+		return err
+	}
+
+	repo := c.Repo
+	if repo == "" {
+		repo = "."
+	}
+	ref := c.Ref
+	if ref == "" {
+		ref = "HEAD"
+	}
+
+	dir, err := os.MkdirTemp("", "spectr-repo-")
+	if err != nil {
+		return fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+	c.snapshotDir = dir
+
+	if err := git.ExportTree(context.Background(), repo, ref, "spectr", dir); err != nil {
+		return err
+	}
+
+	if err := os.Chdir(dir); err != nil {
+		return fmt.Errorf("failed to enter snapshot directory: %w", err)
+	}
+	if err := os.Setenv("SPECTR_ROOT", dir); err != nil {
+		return fmt.Errorf("failed to set SPECTR_ROOT: %w", err)
+	}
+	ResetDiscoveryCache()
+
+	return nil
+}
+
+// Cleanup removes the temporary snapshot created for --repo/--ref, if any.
+// It is safe to call when no snapshot was created.
+func (c *CLI) Cleanup() {
+	if c.snapshotDir == "" {
+		return
+	}
+
+	_ = os.RemoveAll(c.snapshotDir)
+	c.snapshotDir = ""
+}
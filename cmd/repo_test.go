@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+func TestPrepareRepoSnapshot_RejectsWriteCommands(t *testing.T) {
+	for _, command := range []string{"accept <change-id>", "archive", "init", "pr archive"} {
+		t.Run(command, func(t *testing.T) {
+			cli := &CLI{Repo: t.TempDir()}
+			defer cli.Cleanup()
+
+			err := cli.prepareRepoSnapshot(command)
+
+			var flagsErr *specterrs.IncompatibleFlagsError
+			if !errors.As(err, &flagsErr) {
+				t.Fatalf("prepareRepoSnapshot(%q) error = %v, want IncompatibleFlagsError", command, err)
+			}
+			if cli.snapshotDir != "" {
+				t.Error("no snapshot should be created for rejected commands")
+			}
+		})
+	}
+}
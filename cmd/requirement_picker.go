@@ -0,0 +1,76 @@
+// Package cmd provides command-line interface implementations.
+// This file contains the interactive requirement picker shared by commands
+// that take a requirement argument.
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/connerohnesorge/spectr/internal/discovery"
+	"github.com/connerohnesorge/spectr/internal/parsers"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+	"github.com/connerohnesorge/spectr/internal/tui"
+)
+
+// pickRequirement asks the user to pick a spec and then one of its
+// requirements, with a fuzzy filter at each step. Commands call it when
+// their requirement argument is omitted; when it cannot prompt it returns
+// a MissingArgumentError naming the omitted argument instead.
+func pickRequirement(
+	projectRoot, argument string,
+) (specID, requirement string, err error) {
+	if !tui.Interactive() {
+		return "", "", &specterrs.MissingArgumentError{Argument: argument}
+	}
+
+	roots, err := requirementTree(projectRoot)
+	if err != nil {
+		return "", "", err
+	}
+	if len(roots) == 0 {
+		return "", "", fmt.Errorf("no specs with requirements found")
+	}
+
+	picked, err := tui.NewTreePicker(tui.TreePickerConfig{
+		Titles: []string{"Select a spec", "Select a requirement"},
+		Roots:  roots,
+	}).Run()
+	if err != nil {
+		return "", "", err
+	}
+	if picked == nil {
+		return "", "", &specterrs.UserCancelledError{
+			Operation: "requirement selection",
+		}
+	}
+
+	return picked[0], picked[1], nil
+}
+
+// requirementTree lists the specs of a project with their requirements,
+// leaving out specs that have none.
+func requirementTree(projectRoot string) ([]tui.PickerNode, error) {
+	specIDs, err := discovery.GetSpecIDs(projectRoot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list specs: %w", err)
+	}
+
+	var roots []tui.PickerNode
+	for _, specID := range specIDs {
+		reqs, err := parsers.ParseRequirements(filepath.Join(
+			projectRoot, "spectr", "specs", specID, "spec.md",
+		))
+		if err != nil || len(reqs) == 0 {
+			continue
+		}
+
+		node := tui.PickerNode{Label: specID}
+		for _, req := range reqs {
+			node.Children = append(node.Children, tui.PickerNode{Label: req.Name})
+		}
+		roots = append(roots, node)
+	}
+
+	return roots, nil
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+func TestRequirementTree(t *testing.T) {
+	projectRoot := t.TempDir()
+	specs := map[string]string{
+		"auth": "# Auth\n\n## Requirements\n\n" +
+			"### Requirement: Login\nThe system SHALL log users in.\n\n" +
+			"#### Scenario: Valid password\n- **WHEN** ok\n- **THEN** in\n\n" +
+			"### Requirement: Logout\nThe system SHALL log users out.\n\n" +
+			"#### Scenario: Click logout\n- **WHEN** click\n- **THEN** out\n",
+		"empty": "# Empty\n\nNothing here yet.\n",
+	}
+	for id, content := range specs {
+		dir := filepath.Join(projectRoot, "spectr", "specs", id)
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "spec.md"), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	roots, err := requirementTree(projectRoot)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(roots) != 1 || roots[0].Label != "auth" {
+		t.Fatalf("roots = %+v, want only auth", roots)
+	}
+	children := roots[0].Children
+	if len(children) != 2 || children[0].Label != "Login" || children[1].Label != "Logout" {
+		t.Errorf("requirements = %+v, want Login and Logout", children)
+	}
+}
+
+func TestPickRequirement_NoTerminal(t *testing.T) {
+	stdin, err := os.Open(os.DevNull)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = stdin.Close() }()
+	saved := os.Stdin
+	os.Stdin = stdin
+	t.Cleanup(func() { os.Stdin = saved })
+
+	_, _, err = pickRequirement(t.TempDir(), "spec ID")
+
+	var missing *specterrs.MissingArgumentError
+	if !errors.As(err, &missing) || missing.Argument != "spec ID" {
+		t.Errorf("err = %v, want MissingArgumentError for spec ID", err)
+	}
+}
+
+func TestShowOutput_KeepRequirement(t *testing.T) {
+	output := &ShowOutput{
+		ID: "auth",
+		Requirements: []ShowRequirement{
+			{Name: "Login"},
+			{Name: "Two-Factor Login"},
+		},
+	}
+
+	if err := output.keepRequirement(" two-factor login"); err != nil {
+		t.Fatal(err)
+	}
+	if len(output.Requirements) != 1 || output.Requirements[0].Name != "Two-Factor Login" {
+		t.Errorf("requirements = %+v, want Two-Factor Login", output.Requirements)
+	}
+
+	if err := output.keepRequirement("Logout"); err == nil {
+		t.Error("expected an error for a missing requirement")
+	}
+}
@@ -0,0 +1,70 @@
+// Package cmd provides command-line interface implementations.
+// This file contains the retire command for moving obsolete specs into
+// spectr/specs/archive/.
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/connerohnesorge/spectr/internal/retire"
+	"github.com/connerohnesorge/spectr/internal/utils"
+)
+
+// RetireCmd archives a spec that no longer describes the system. It
+// refuses while active changes still modify or link to the spec.
+type RetireCmd struct {
+	SpecID       string `arg:"" predictor:"specID" help:"Spec ID to retire"`                              //nolint:lll,revive // Kong struct tag with alignment
+	Reason       string `help:"Why the spec is retired (recorded in the changelog)" name:"reason"`        //nolint:lll,revive // Kong struct tag with alignment
+	RewriteLinks bool   `help:"Point inbound wikilinks at the archived spec"        name:"rewrite-links"` //nolint:lll,revive // Kong struct tag with alignment
+	JSON         bool   `help:"Output as JSON"                                      name:"json"`          //nolint:lll,revive // Kong struct tag with alignment
+}
+
+// Run executes the retire command.
+func (c *RetireCmd) Run() error {
+	root, err := GetSingleRoot()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := utils.CommandContext(0)
+	defer cancel()
+
+	res, err := retire.Retire(ctx, root.Path, c.SpecID, retire.Options{
+		Reason:       c.Reason,
+		RewriteLinks: c.RewriteLinks,
+	})
+	if err != nil {
+		return err
+	}
+
+	if c.JSON {
+		data, err := json.MarshalIndent(res, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode result: %w", err)
+		}
+		fmt.Println(string(data))
+
+		return nil
+	}
+
+	fmt.Printf("Retired %s to %s\n", res.SpecID, res.ArchivePath)
+	if len(res.Links) == 0 {
+		return nil
+	}
+
+	if res.Rewritten {
+		fmt.Printf("Rewrote %d inbound wikilink(s):\n", len(res.Links))
+	} else {
+		fmt.Printf(
+			"%d inbound wikilink(s) still point at %s (use --rewrite-links to update them):\n",
+			len(res.Links),
+			res.SpecID,
+		)
+	}
+	for _, link := range res.Links {
+		fmt.Printf("  %s:%d [[%s]]\n", link.File, link.Line, link.Target)
+	}
+
+	return nil
+}
@@ -0,0 +1,154 @@
+// Package cmd provides command-line interface implementations.
+// This file contains the review command for recording spec reviews and
+// listing specs due for one.
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+
+	"github.com/connerohnesorge/spectr/internal/review"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+	"github.com/connerohnesorge/spectr/internal/utils"
+)
+
+// ReviewCmd records spec reviews and reports specs past their review
+// interval.
+type ReviewCmd struct {
+	Mark ReviewMarkCmd `cmd:"" help:"Record that a spec was reviewed"`
+	Due  ReviewDueCmd  `cmd:"" help:"List specs due for review"`
+}
+
+// ReviewMarkCmd sets last_reviewed in a spec's frontmatter.
+type ReviewMarkCmd struct {
+	SpecID string `arg:"" predictor:"specID" help:"Spec ID to mark reviewed"`               //nolint:lll,revive // Kong struct tag with alignment
+	Date   string `help:"Review date (YYYY-MM-DD, default: today)"             name:"date"` //nolint:lll,revive // Kong struct tag with alignment
+	JSON   bool   `help:"Output as JSON"                                       name:"json"` //nolint:lll,revive // Kong struct tag with alignment
+}
+
+// Run executes the review mark command.
+func (c *ReviewMarkCmd) Run() error {
+	date := time.Now()
+	if c.Date != "" {
+		var err error
+		if date, err = review.ParseDate(c.Date); err != nil {
+			return err
+		}
+	}
+
+	root, err := GetSingleRoot()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := utils.CommandContext(0)
+	defer cancel()
+
+	res, err := review.Mark(ctx, root.Path, c.SpecID, date)
+	if err != nil {
+		return err
+	}
+
+	if c.JSON {
+		data, err := json.MarshalIndent(res, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode result: %w", err)
+		}
+		fmt.Println(string(data))
+
+		return nil
+	}
+
+	if res.Previous != "" {
+		fmt.Printf("Marked %s reviewed on %s (was %s)\n", res.SpecID, res.LastReviewed, res.Previous)
+
+		return nil
+	}
+	fmt.Printf("Marked %s reviewed on %s\n", res.SpecID, res.LastReviewed)
+
+	return nil
+}
+
+// ReviewDueCmd lists specs past the review interval set in spectr.yaml.
+type ReviewDueCmd struct {
+	JSON    bool          `help:"Output as JSON"                        name:"json"`    //nolint:lll,revive // Kong struct tag with alignment
+	Check   bool          `help:"Fail when any spec is due for review"  name:"check"`   //nolint:lll,revive // Kong struct tag with alignment
+	Timeout time.Duration `help:"Abort after duration (e.g. 30s)"       name:"timeout"` //nolint:lll,revive // Kong struct tag with alignment
+}
+
+// Run executes the review due command.
+func (c *ReviewDueCmd) Run() error {
+	roots, err := GetDiscoveredRoots()
+	if err != nil {
+		return fmt.Errorf(
+			"failed to discover spectr roots: %w",
+			err,
+		)
+	}
+
+	ctx, cancel := utils.CommandContext(c.Timeout)
+	defer cancel()
+
+	var due []review.Spec
+	for _, root := range roots {
+		specs, err := review.Find(ctx, root.Path, time.Now())
+		if err != nil {
+			return utils.CommandError(ctx, "review due", c.Timeout, err)
+		}
+		for i := range specs {
+			if len(roots) > 1 {
+				specs[i].RootPath = root.RelativeTo
+			}
+		}
+		due = append(due, specs...)
+	}
+
+	if c.JSON {
+		if due == nil {
+			due = []review.Spec{}
+		}
+		data, err := json.MarshalIndent(due, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode due specs: %w", err)
+		}
+		fmt.Println(string(data))
+	} else if err := printDueSpecs(due); err != nil {
+		return err
+	}
+
+	if c.Check && len(due) > 0 {
+		return &specterrs.ReviewDueError{Count: len(due)}
+	}
+
+	return nil
+}
+
+// printDueSpecs renders the review due table.
+func printDueSpecs(due []review.Spec) error {
+	if len(due) == 0 {
+		fmt.Println("No specs due for review")
+
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SPEC\tLAST REVIEWED\tINTERVAL\tOVERDUE")
+	for _, spec := range due {
+		id := spec.ID
+		if spec.RootPath != "" {
+			id = filepath.ToSlash(filepath.Join(spec.RootPath, id))
+		}
+		lastReviewed, overdue := "never", "-"
+		if spec.DueDate != "" {
+			lastReviewed = spec.LastReviewed
+			overdue = fmt.Sprintf("%dd", spec.OverdueDays)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%dd\t%s\n", id, lastReviewed, spec.IntervalDays, overdue)
+	}
+
+	return w.Flush()
+}
@@ -1,38 +1,144 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 
+	"github.com/alecthomas/kong"
 	"github.com/connerohnesorge/spectr/internal/archive"
+	"github.com/connerohnesorge/spectr/internal/config"
+	"github.com/connerohnesorge/spectr/internal/events"
+	"github.com/connerohnesorge/spectr/internal/fileio"
+	"github.com/connerohnesorge/spectr/internal/git"
+	"github.com/connerohnesorge/spectr/internal/markdown"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
 	"github.com/connerohnesorge/spectr/internal/sync"
+	"github.com/connerohnesorge/spectr/internal/tui"
+	"github.com/connerohnesorge/spectr/internal/version"
 	kongcompletion "github.com/jotaen/kong-completion"
 )
 
 // CLI represents the root command structure for Kong
 type CLI struct {
 	// Global flags (apply to all commands)
-	NoSync  bool `help:"Skip automatic task sync" name:"no-sync" short:"S"` //nolint:lll,revive // Kong struct tag
-	Verbose bool `help:"Enable verbose output"    name:"verbose" short:"v"` //nolint:lll,revive // Kong struct tag
+	NoSync     bool   `help:"Skip automatic task sync"             name:"no-sync"     short:"S"`                       //nolint:lll,revive // Kong struct tag
+	Verbose    bool   `help:"Enable verbose output"                name:"verbose"     short:"v"`                       //nolint:lll,revive // Kong struct tag
+	Repo       string `help:"Read from a git repository (bare ok)" name:"repo"        type:"path"`                     //nolint:lll,revive // Kong struct tag
+	Ref        string `help:"Git ref to read (implies --repo .)"   name:"ref"`                                         //nolint:lll,revive // Kong struct tag
+	ProjectDir string `help:"Run in this project directory"        name:"project-dir" type:"path"`                     //nolint:lll,revive // Kong struct tag
+	Progress   string `help:"Emit progress events on stderr"       name:"progress"    enum:"none,json" default:"none"` //nolint:lll,revive // Kong struct tag
+	NoInput    bool   `help:"Never prompt or open a TUI"           name:"no-input"    env:"SPECTR_NO_INPUT"`           //nolint:lll,revive // Kong struct tag
+
+	// snapshotDir holds the spectr/ tree exported for --repo/--ref
+	snapshotDir string
 
 	// Commands
-	Init       InitCmd                   `cmd:"" help:"Initialize Spectr"`                 //nolint:lll,revive // Kong struct tag with alignment
-	List       ListCmd                   `cmd:"" help:"List items"           aliases:"ls"` //nolint:lll,revive // Kong struct tag with alignment
-	Validate   ValidateCmd               `cmd:"" help:"Validate items"`                    //nolint:lll,revive // Kong struct tag with alignment
-	Accept     AcceptCmd                 `cmd:"" help:"Accept tasks.md"`                   //nolint:lll,revive // Kong struct tag with alignment
-	Archive    archive.ArchiveCmd        `cmd:"" help:"Archive a change"`                  //nolint:lll,revive // Kong struct tag with alignment
-	Graph      GraphCmd                  `cmd:"" help:"Show dependency graph"`             //nolint:lll,revive // Kong struct tag with alignment
-	PR         PRCmd                     `cmd:"" help:"Create pull requests"`              //nolint:lll,revive // Kong struct tag with alignment
-	View       ViewCmd                   `cmd:"" help:"Display dashboard"`                 //nolint:lll,revive // Kong struct tag with alignment
-	Version    VersionCmd                `cmd:"" help:"Show version info"`                 //nolint:lll,revive // Kong struct tag with alignment
-	Completion kongcompletion.Completion `cmd:"" help:"Generate completions"`              //nolint:lll,revive // Kong struct tag with alignment
+	Init        InitCmd                   `cmd:"" help:"Initialize Spectr"`                     //nolint:lll,revive // Kong struct tag with alignment
+	List        ListCmd                   `cmd:"" help:"List items"           aliases:"ls"`     //nolint:lll,revive // Kong struct tag with alignment
+	Validate    ValidateCmd               `cmd:"" help:"Validate items"`                        //nolint:lll,revive // Kong struct tag with alignment
+	New         NewCmd                    `cmd:"" help:"Create a change or custom kind item"`   //nolint:lll,revive // Kong struct tag with alignment
+	Accept      AcceptCmd                 `cmd:"" help:"Accept tasks.md"`                       //nolint:lll,revive // Kong struct tag with alignment
+	Archive     archive.ArchiveCmd        `cmd:"" help:"Archive a change"`                      //nolint:lll,revive // Kong struct tag with alignment
+	Graph       GraphCmd                  `cmd:"" help:"Show dependency graph"`                 //nolint:lll,revive // Kong struct tag with alignment
+	Plan        PlanCmd                   `cmd:"" help:"Suggest an archive order"`              //nolint:lll,revive // Kong struct tag with alignment
+	PR          PRCmd                     `cmd:"" help:"Create pull requests"`                  //nolint:lll,revive // Kong struct tag with alignment
+	Bot         BotCmd                    `cmd:"" help:"Review pull requests in CI"`            //nolint:lll,revive // Kong struct tag with alignment
+	View        ViewCmd                   `cmd:"" help:"Display dashboard"`                     //nolint:lll,revive // Kong struct tag with alignment
+	Show        ShowCmd                   `cmd:"" help:"Show a spec"`                           //nolint:lll,revive // Kong struct tag with alignment
+	Read        ReadCmd                   `cmd:"" help:"Read a spec in a pager"`                //nolint:lll,revive // Kong struct tag with alignment
+	Diff        DiffCmd                   `cmd:"" help:"Show a change's spec diff"`             //nolint:lll,revive // Kong struct tag with alignment
+	Export      ExportCmd                 `cmd:"" help:"Export specs"`                          //nolint:lll,revive // Kong struct tag with alignment
+	Import      ImportCmd                 `cmd:"" help:"Import an exported project"`            //nolint:lll,revive // Kong struct tag with alignment
+	Fmt         FmtCmd                    `cmd:"" help:"Format markdown files"`                 //nolint:lll,revive // Kong struct tag with alignment
+	Replace     ReplaceCmd                `cmd:"" help:"Replace text across specs"`             //nolint:lll,revive // Kong struct tag with alignment
+	Tasks       TasksCmd                  `cmd:"" help:"Manage change tasks"`                   //nolint:lll,revive // Kong struct tag with alignment
+	Scaffold    ScaffoldCmd               `cmd:"" help:"Generate test skeletons"`               //nolint:lll,revive // Kong struct tag with alignment
+	Snapshot    SnapshotCmd               `cmd:"" help:"Snapshot affected requirements"`        //nolint:lll,revive // Kong struct tag with alignment
+	Hooks       HooksCmd                  `cmd:"" help:"Manage git integration"`                //nolint:lll,revive // Kong struct tag with alignment
+	Auth        AuthCmd                   `cmd:"" help:"Manage hosting credentials"`            //nolint:lll,revive // Kong struct tag with alignment
+	Help        HelpCmd                   `cmd:"" help:"Show topics and examples"`              //nolint:lll,revive // Kong struct tag with alignment
+	Demo        DemoCmd                   `cmd:"" help:"Create a sample project"`               //nolint:lll,revive // Kong struct tag with alignment
+	Migrate     MigrateCmd                `cmd:"" help:"Upgrade file formats"`                  //nolint:lll,revive // Kong struct tag with alignment
+	Schema      SchemaCmd                 `cmd:"" help:"Print JSON Schemas"`                    //nolint:lll,revive // Kong struct tag with alignment
+	IDE         IDECmd                    `cmd:"" name:"ide" help:"Editor integration"`         //nolint:lll,revive // Kong struct tag with alignment
+	Audit       AuditCmd                  `cmd:"" help:"Review the operation audit log"`        //nolint:lll,revive // Kong struct tag with alignment
+	Stale       StaleCmd                  `cmd:"" help:"List idle changes"`                     //nolint:lll,revive // Kong struct tag with alignment
+	Stats       StatsCmd                  `cmd:"" help:"Show spec edit activity"`               //nolint:lll,revive // Kong struct tag with alignment
+	Subscribe   SubscribeCmd              `cmd:"" help:"Watch a spec for changes"`              //nolint:lll,revive // Kong struct tag with alignment
+	Notify      NotifyCmd                 `cmd:"" help:"Notify spec subscribers"`               //nolint:lll,revive // Kong struct tag with alignment
+	Contract    ContractCmd               `cmd:"" help:"Pin requirements you depend on"`        //nolint:lll,revive // Kong struct tag with alignment
+	Review      ReviewCmd                 `cmd:"" help:"Track spec reviews"`                    //nolint:lll,revive // Kong struct tag with alignment
+	Profile     ProfileCmd                `cmd:"" help:"Fetch the shared profile"`              //nolint:lll,revive // Kong struct tag with alignment
+	Evidence    EvidenceCmd               `cmd:"" help:"Attach acceptance evidence"`            //nolint:lll,revive // Kong struct tag with alignment
+	Comment     CommentCmd                `cmd:"" help:"Discuss requirements in review"`        //nolint:lll,revive // Kong struct tag with alignment
+	Attest      AttestCmd                 `cmd:"" help:"Sign and verify approved spec text"`    //nolint:lll,revive // Kong struct tag with alignment
+	Rename      RenameCmd                 `cmd:"" help:"Rename a spec or change"`               //nolint:lll,revive // Kong struct tag with alignment
+	Retire      RetireCmd                 `cmd:"" help:"Retire an obsolete spec"`               //nolint:lll,revive // Kong struct tag with alignment
+	SplitChange SplitChangeCmd            `cmd:"" help:"Move deltas and tasks to a new change"` //nolint:lll,revive // Kong struct tag with alignment
+	Worktree    WorktreeCmd               `cmd:"" help:"Create a worktree for a change"`        //nolint:lll,revive // Kong struct tag with alignment
+	Dedupe      DedupeCmd                 `cmd:"" help:"Find near-duplicate requirements"`      //nolint:lll,revive // Kong struct tag with alignment
+	Links       LinksCmd                  `cmd:"" help:"Show wikilink resolution"`              //nolint:lll,revive // Kong struct tag with alignment
+	Bench       BenchCmd                  `cmd:"" help:"Benchmark the current project"`         //nolint:lll,revive // Kong struct tag with alignment
+	Prompt      PromptCmd                 `cmd:"" help:"Assemble change context for LLMs"`      //nolint:lll,revive // Kong struct tag with alignment
+	MergeTasks  MergeTasksCmd             `cmd:"" help:"Git merge driver for tasks"`            //nolint:lll,revive // Kong struct tag with alignment
+	MergeSpec   MergeSpecCmd              `cmd:"" help:"Git merge driver for specs"`            //nolint:lll,revive // Kong struct tag with alignment
+	MergeAudit  MergeAuditCmd             `cmd:"" help:"Git merge driver for the audit log"`    //nolint:lll,revive // Kong struct tag with alignment
+	Version     VersionCmd                `cmd:"" help:"Show version info"`                     //nolint:lll,revive // Kong struct tag with alignment
+	Completion  kongcompletion.Completion `cmd:"" help:"Generate completions"`                  //nolint:lll,revive // Kong struct tag with alignment
 }
 
 // AfterApply is called by Kong after parsing flags but before running the command.
+// With --project-dir it first moves into that project, like git -C.
 // It synchronizes task statuses from tasks.jsonc to tasks.md for all active changes
-// across all discovered spectr roots.
-func (c *CLI) AfterApply() error {
+// across all discovered spectr roots. With --repo or --ref, it instead exports
+// the spectr/ tree from git and runs the (read-only) command against it.
+// The project's version requirements, git backend, file reading settings
+// and scenario keywords in spectr.yaml are applied first. --progress=json turns on progress
+// events on stderr, and --no-input turns off every prompt and TUI. Old IDs
+// of renamed specs and changes in arguments are replaced by their new ones.
+func (c *CLI) AfterApply(ctx *kong.Context) error {
+	events.Configure(c.Progress, ctx.Command(), os.Stderr)
+	tui.SetNoInput(c.NoInput)
+
+	if c.ProjectDir != "" {
+		if err := useProjectDir(c.ProjectDir); err != nil {
+			return err
+		}
+	}
+
+	// A missing or unreadable config keeps the defaults
+	cfg, err := config.LoadConfig(".")
+	if err != nil {
+		cfg = nil
+	}
+	if err := checkProjectVersion(cfg, ctx.Command()); err != nil {
+		return err
+	}
+	if err := selectGitBackend(cfg); err != nil {
+		return err
+	}
+	configureFileIO(cfg)
+	if err := markdown.SetKeywordAliases(cfg.ScenarioKeywords()); err != nil {
+		return err
+	}
+	if err := markdown.SetDeltaAliases(cfg.DeltaAliases()); err != nil {
+		return err
+	}
+
+	if c.Repo != "" || c.Ref != "" {
+		if err := c.prepareRepoSnapshot(ctx.Command()); err != nil {
+			return err
+		}
+		resolveAliasArgs(ctx)
+
+		return nil
+	}
+	resolveAliasArgs(ctx)
+
 	if c.NoSync {
 		return nil
 	}
@@ -81,3 +187,53 @@ func (c *CLI) AfterApply() error {
 
 	return nil
 }
+
+// versionCheckExempt are the commands that never read the project, so they
+// keep working against a project that needs a newer spectr.
+var versionCheckExempt = []string{"version", "help", "completion", "schema"}
+
+// checkProjectVersion refuses to run against a project that requires a
+// newer spectr and prints warnings for projects created by one. Setting
+// SPECTR_IGNORE_VERSION=1 turns the refusal into a warning.
+func checkProjectVersion(cfg *config.Config, command string) error {
+	name, _, _ := strings.Cut(command, " ")
+	if slices.Contains(versionCheckExempt, name) {
+		return nil
+	}
+
+	warnings, err := cfg.CheckVersion(version.Version)
+	var versionErr *specterrs.IncompatibleVersionError
+	if errors.As(err, &versionErr) && os.Getenv("SPECTR_IGNORE_VERSION") == "1" {
+		warnings = append([]string{fmt.Sprintf(
+			"%s requires spectr %s or newer; running anyway",
+			versionErr.Path,
+			versionErr.Required,
+		)}, warnings...)
+	} else if err != nil {
+		return err
+	}
+	for _, warning := range warnings {
+		fmt.Fprintf(os.Stderr, "warning: %s\n", warning)
+	}
+
+	return nil
+}
+
+// configureFileIO applies the io settings from spectr.yaml.
+func configureFileIO(cfg *config.Config) {
+	opts := cfg.IOOptions()
+	fileio.Configure(fileio.Options{
+		Mmap:            opts.Mmap,
+		PrefetchWorkers: opts.PrefetchWorkers,
+	})
+}
+
+// selectGitBackend applies the git.backend setting from spectr.yaml. No
+// setting keeps the default exec backend.
+func selectGitBackend(cfg *config.Config) error {
+	if cfg.GitBackend() == "" {
+		return nil
+	}
+
+	return git.UseBackend(cfg.GitBackend())
+}
@@ -0,0 +1,71 @@
+// Package cmd provides command-line interface implementations.
+// This file contains the scaffold command for generating test skeletons
+// from spec scenarios.
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/connerohnesorge/spectr/internal/scaffold"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+// ScaffoldCmd generates files from specs.
+type ScaffoldCmd struct {
+	Tests ScaffoldTestsCmd `cmd:"" help:"Generate test skeletons from scenarios"`
+}
+
+// ScaffoldTestsCmd generates a test file with one test per requirement and
+// one subtest per scenario, named by scenario ID.
+type ScaffoldTestsCmd struct {
+	SpecID  string `arg:"" predictor:"specID" help:"Spec ID"`                                  //nolint:lll,revive // Kong struct tag with alignment
+	Lang    string `name:"lang"    help:"Test language (go)"                   default:"go"`   //nolint:lll,revive // Kong struct tag with alignment
+	Output  string `name:"output"  help:"Write tests to file"       short:"o"  type:"path"`    //nolint:lll,revive // Kong struct tag with alignment
+	Package string `name:"package" help:"Go package (default: output directory or spec name)"` //nolint:lll,revive // Kong struct tag with alignment
+	Force   bool   `name:"force"   help:"Overwrite an existing output file"`                   //nolint:lll,revive // Kong struct tag with alignment
+}
+
+// Run executes the scaffold tests command.
+func (c *ScaffoldTestsCmd) Run() error {
+	root, err := GetSingleRoot()
+	if err != nil {
+		return err
+	}
+
+	if c.Output != "" && !c.Force {
+		if _, err := os.Stat(c.Output); !errors.Is(err, fs.ErrNotExist) {
+			return &specterrs.ScaffoldFileExistsError{Path: c.Output}
+		}
+	}
+
+	pkg := c.Package
+	if pkg == "" && c.Output != "" {
+		if abs, err := filepath.Abs(c.Output); err == nil {
+			pkg = scaffold.PackageName(filepath.Base(filepath.Dir(abs)))
+		}
+	}
+
+	src, err := scaffold.Tests(root.Path, c.SpecID, scaffold.Options{
+		Lang:    c.Lang,
+		Package: pkg,
+	})
+	if err != nil {
+		return err
+	}
+
+	if c.Output == "" {
+		fmt.Print(string(src))
+
+		return nil
+	}
+	if err := os.WriteFile(c.Output, src, filePerm); err != nil {
+		return fmt.Errorf("failed to write %s: %w", c.Output, err)
+	}
+	fmt.Printf("Wrote %s\n", c.Output)
+
+	return nil
+}
@@ -0,0 +1,78 @@
+// Package cmd provides command-line interface implementations.
+// This file contains the schema command for printing JSON Schemas.
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/connerohnesorge/spectr/internal/jsonschema"
+)
+
+// SchemaCmd represents the schema command with subcommands.
+type SchemaCmd struct {
+	Print SchemaPrintCmd `cmd:"" help:"Print the JSON Schema for an artifact"`
+	List  SchemaListCmd  `cmd:"" help:"List artifacts with schemas"`
+}
+
+// SchemaPrintCmd writes an artifact's JSON Schema to stdout so editors
+// can use it for completion and validation.
+type SchemaPrintCmd struct {
+	Artifact string `arg:"" help:"Artifact name or file (tasks, tasks.jsonc, config, spectr.yaml)"`         //nolint:lll,revive // Kong struct tag with alignment
+	Version  int    `       help:"Schema version for versioned artifacts (default: latest)" name:"version"` //nolint:lll,revive // Kong struct tag with alignment
+}
+
+// Run executes the schema print command.
+func (c *SchemaPrintCmd) Run() error {
+	artifact, err := jsonschema.Lookup(c.Artifact)
+	if err != nil {
+		return err
+	}
+
+	data, err := artifact.Schema(c.Version)
+	if err != nil {
+		return err
+	}
+
+	_, err = os.Stdout.Write(data)
+
+	return err
+}
+
+// SchemaListCmd lists the artifacts that have schemas.
+type SchemaListCmd struct{}
+
+// Run executes the schema list command.
+func (*SchemaListCmd) Run() error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ARTIFACT\tFILE\tVERSIONS\tDESCRIPTION")
+	for _, a := range jsonschema.Artifacts() {
+		fmt.Fprintf(
+			w,
+			"%s\t%s\t%s\t%s\n",
+			a.Name,
+			a.FileName,
+			schemaVersions(a),
+			a.Description,
+		)
+	}
+
+	return w.Flush()
+}
+
+// schemaVersions formats an artifact's versions, or "-" if unversioned.
+func schemaVersions(a jsonschema.Artifact) string {
+	if a.Latest() == 0 {
+		return "-"
+	}
+
+	versions := make([]string, len(a.Versions))
+	for i, v := range a.Versions {
+		versions[i] = strconv.Itoa(v)
+	}
+
+	return strings.Join(versions, ", ")
+}
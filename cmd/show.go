@@ -0,0 +1,240 @@
+// Package cmd provides command-line interface implementations.
+// This file contains the show command for displaying a single spec.
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/implindex"
+	"github.com/connerohnesorge/spectr/internal/inherit"
+	"github.com/connerohnesorge/spectr/internal/parsers"
+	"github.com/connerohnesorge/spectr/internal/progress"
+)
+
+// ShowCmd represents the show command which displays a spec's requirements
+// together with the source locations that implement them (found via
+// `spectr:impl` marker comments) and the progress of each scenario, from
+// the tasks that cover it. Without arguments it lets the user pick a
+// requirement interactively.
+type ShowCmd struct {
+	// SpecID is the spec to display
+	SpecID string `arg:"" optional:"" predictor:"specID" help:"Spec ID to show"` //nolint:lll,revive // Kong struct tag with alignment
+
+	// Requirement limits the output to one requirement
+	Requirement string `arg:"" optional:"" help:"Requirement to show (default: all)"` //nolint:lll,revive // Kong struct tag with alignment
+
+	// JSON enables JSON output format
+	JSON bool `name:"json" help:"Output as JSON"` //nolint:lll,revive // Kong struct tag with alignment
+}
+
+// ShowRequirement is a requirement entry in the show output.
+type ShowRequirement struct {
+	Name             string              `json:"name"`
+	Scenarios        []string            `json:"scenarios"`
+	ScenarioProgress []progress.Scenario `json:"scenarioProgress"`
+	Implementations  []implindex.Marker  `json:"implementations"`
+	// InheritedFrom is the base spec of an inherited requirement
+	InheritedFrom string `json:"inheritedFrom,omitempty"`
+	// Overrides lists the scenarios the spec overrides in an inherited
+	// requirement
+	Overrides []string `json:"overrides,omitempty"`
+}
+
+// ShowOutput is the JSON output structure for the show command.
+type ShowOutput struct {
+	ID           string            `json:"id"`
+	Title        string            `json:"title"`
+	Requirements []ShowRequirement `json:"requirements"`
+}
+
+// Run executes the show command.
+func (c *ShowCmd) Run() error {
+	root, err := GetSingleRoot()
+	if err != nil {
+		return err
+	}
+
+	specID, requirement := c.SpecID, c.Requirement
+	if specID == "" {
+		specID, requirement, err = pickRequirement(root.Path, "spec ID")
+		if err != nil {
+			return err
+		}
+	}
+
+	specPath := filepath.Join(root.SpecsDir(), specID, "spec.md")
+	if _, statErr := os.Stat(specPath); statErr != nil {
+		return fmt.Errorf("spec '%s' not found", specID)
+	}
+
+	output, err := buildShowOutput(root.Path, specID, specPath)
+	if err != nil {
+		return err
+	}
+	if requirement != "" {
+		if err := output.keepRequirement(requirement); err != nil {
+			return err
+		}
+	}
+
+	if c.JSON {
+		data, jsonErr := json.MarshalIndent(output, "", "  ")
+		if jsonErr != nil {
+			return fmt.Errorf("failed to format JSON: %w", jsonErr)
+		}
+		fmt.Println(string(data))
+
+		return nil
+	}
+
+	fmt.Print(formatShowText(output))
+
+	return nil
+}
+
+// buildShowOutput collects the requirements of a spec and the
+// implementation markers found under projectRoot.
+func buildShowOutput(
+	projectRoot, specID, specPath string,
+) (*ShowOutput, error) {
+	title, err := parsers.ExtractTitle(specPath)
+	if err != nil || title == "" {
+		title = specID
+	}
+
+	reqs, err := inherit.ResolveFile(specPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse spec: %w", err)
+	}
+
+	idx, err := implindex.Scan(projectRoot)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to scan implementation markers: %w",
+			err,
+		)
+	}
+
+	covers, err := progress.Load(filepath.Join(projectRoot, "spectr", "changes"))
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ShowOutput{
+		ID:           specID,
+		Title:        title,
+		Requirements: make([]ShowRequirement, 0, len(reqs)),
+	}
+	for _, req := range reqs {
+		scenarios := parsers.ParseScenarios(req.Raw)
+		if scenarios == nil {
+			scenarios = make([]string, 0)
+		}
+		impls := idx.Lookup(specID, req.Name)
+		if impls == nil && req.Inherited {
+			impls = idx.Lookup(req.Spec, req.Name)
+		}
+		if impls == nil {
+			impls = make([]implindex.Marker, 0)
+		}
+		shown := ShowRequirement{
+			Name:             req.Name,
+			Scenarios:        scenarios,
+			ScenarioProgress: covers.Requirement(req.Spec, req.Index, scenarios),
+			Implementations:  impls,
+			Overrides:        req.Overrides,
+		}
+		if req.Inherited {
+			shown.InheritedFrom = req.Spec
+		}
+		output.Requirements = append(output.Requirements, shown)
+	}
+
+	return output, nil
+}
+
+// keepRequirement drops every requirement but the named one, matched
+// ignoring case and surrounding whitespace.
+func (o *ShowOutput) keepRequirement(name string) error {
+	for _, req := range o.Requirements {
+		if strings.EqualFold(strings.TrimSpace(req.Name), strings.TrimSpace(name)) {
+			o.Requirements = []ShowRequirement{req}
+
+			return nil
+		}
+	}
+
+	return fmt.Errorf("requirement '%s' not found in spec '%s'", name, o.ID)
+}
+
+// formatShowText renders the show output for terminal display.
+func formatShowText(output *ShowOutput) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "%s (%s)\n", output.Title, output.ID)
+	fmt.Fprintf(&sb, "%d requirements\n", len(output.Requirements))
+
+	for _, req := range output.Requirements {
+		fmt.Fprintf(&sb, "\n### %s\n", req.Name)
+		if req.InheritedFrom != "" {
+			writeInheritance(&sb, req)
+		}
+		writeScenarioProgress(&sb, req.ScenarioProgress)
+
+		if len(req.Implementations) == 0 {
+			sb.WriteString("  Implemented by: (none)\n")
+
+			continue
+		}
+
+		sb.WriteString("  Implemented by:\n")
+		for _, m := range req.Implementations {
+			location := fmt.Sprintf("%s:%d", m.File, m.Line)
+			if m.Function != "" {
+				location += " (" + m.Function + ")"
+			}
+			fmt.Fprintf(&sb, "    - %s\n", location)
+		}
+	}
+
+	return sb.String()
+}
+
+// writeInheritance renders where an inherited requirement comes from.
+func writeInheritance(sb *strings.Builder, req ShowRequirement) {
+	if len(req.Overrides) == 0 {
+		fmt.Fprintf(sb, "  Inherited from: %s (read-only)\n", req.InheritedFrom)
+
+		return
+	}
+	fmt.Fprintf(
+		sb,
+		"  Inherited from: %s (overrides: %s)\n",
+		req.InheritedFrom,
+		strings.Join(req.Overrides, ", "),
+	)
+}
+
+// writeScenarioProgress renders the scenarios of a requirement with their
+// IDs and the completed share of the tasks covering each.
+func writeScenarioProgress(sb *strings.Builder, scenarios []progress.Scenario) {
+	done := 0
+	for _, s := range scenarios {
+		if s.Done() {
+			done++
+		}
+	}
+	fmt.Fprintf(sb, "  Scenarios: %d (%d done)\n", len(scenarios), done)
+
+	for _, s := range scenarios {
+		status := "no tasks"
+		if len(s.Tasks) > 0 {
+			status = fmt.Sprintf("%d/%d tasks", s.Completed, len(s.Tasks))
+		}
+		fmt.Fprintf(sb, "    %s %s (%s)\n", s.ID, s.Name, status)
+	}
+}
@@ -0,0 +1,87 @@
+// Package cmd provides command-line interface implementations.
+// This file contains the snapshot command for capturing base requirement
+// versions into a change directory.
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/connerohnesorge/spectr/internal/snapshot"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+// SnapshotCmd captures the current version of the requirements a change
+// affects, so later tooling has a reliable base version.
+type SnapshotCmd struct {
+	ChangeID string `arg:"" optional:"" predictor:"changeID" help:"Change ID"`
+	Force    bool   `                                        help:"Recapture an existing snapshot" name:"force" short:"f"` //nolint:lll,revive // Kong struct tag with alignment
+}
+
+// Run executes the snapshot command.
+func (c *SnapshotCmd) Run() error {
+	root, err := GetSingleRoot()
+	if err != nil {
+		return err
+	}
+
+	changeID, err := resolveOrSelectChangeID(c.ChangeID, root.Path)
+	if err != nil {
+		var userCancelledErr *specterrs.UserCancelledError
+		if errors.As(err, &userCancelledErr) {
+			return nil
+		}
+
+		return err
+	}
+
+	changeDir := filepath.Join(root.ChangesDir(), changeID)
+	if snapshot.Open(changeDir).Exists() && !c.Force {
+		fmt.Printf(
+			"Snapshot already exists for %s (use --force to recapture)\n",
+			changeID,
+		)
+
+		return nil
+	}
+
+	index, err := snapshot.Capture(root.SpecsDir(), changeDir)
+	if err != nil {
+		return fmt.Errorf("failed to capture snapshot: %w", err)
+	}
+
+	fmt.Printf(
+		"Captured %d requirement(s) into %s\n",
+		len(index.Requirements),
+		filepath.Join(changeDir, snapshot.DirName),
+	)
+
+	return nil
+}
+
+// captureSnapshotIfMissing captures the change's base requirements the
+// first time a change is accepted. Later accepts keep the original
+// snapshot, since the base specs may have moved on since then.
+func captureSnapshotIfMissing(projectRoot, changeDir string) error {
+	if snapshot.Open(changeDir).Exists() {
+		return nil
+	}
+
+	index, err := snapshot.Capture(
+		filepath.Join(projectRoot, "spectr", "specs"),
+		changeDir,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to capture snapshot: %w", err)
+	}
+
+	if len(index.Requirements) > 0 {
+		fmt.Printf(
+			"Captured base version of %d requirement(s)\n",
+			len(index.Requirements),
+		)
+	}
+
+	return nil
+}
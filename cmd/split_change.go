@@ -0,0 +1,68 @@
+// Package cmd provides command-line interface implementations.
+// This file contains the split-change command for moving part of an
+// oversized change into a new one.
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/split"
+	"github.com/connerohnesorge/spectr/internal/utils"
+)
+
+// SplitChangeCmd moves selected delta specs, requirements and tasks of a
+// change into a new change with a stub proposal.
+type SplitChangeCmd struct {
+	ChangeID     string   `arg:"" predictor:"changeID" help:"Change to split"`                                           //nolint:lll,revive // Kong struct tag with alignment
+	NewID        string   `arg:""                      help:"ID of the new change"`                                      //nolint:lll,revive // Kong struct tag with alignment
+	Specs        []string `help:"Delta spec to move (repeatable)"                        name:"spec" predictor:"specID"` //nolint:lll,revive // Kong struct tag with alignment
+	Requirements []string `help:"Requirement delta to move, as spec#Name (repeatable)" name:"requirement"`               //nolint:lll,revive // Kong struct tag with alignment
+	Tasks        []string `help:"tasks.md task number to move (repeatable)"              name:"task"`                    //nolint:lll,revive // Kong struct tag with alignment
+	JSON         bool     `help:"Output as JSON"                                         name:"json"`                    //nolint:lll,revive // Kong struct tag with alignment
+}
+
+// Run executes the split-change command.
+func (c *SplitChangeCmd) Run() error {
+	root, err := GetSingleRoot()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := utils.CommandContext(0)
+	defer cancel()
+
+	res, err := split.Split(ctx, root.Path, c.ChangeID, c.NewID, split.Options{
+		Specs:        c.Specs,
+		Requirements: c.Requirements,
+		Tasks:        c.Tasks,
+	})
+	if err != nil {
+		return err
+	}
+
+	if c.JSON {
+		data, err := json.MarshalIndent(res, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode result: %w", err)
+		}
+		fmt.Println(string(data))
+
+		return nil
+	}
+
+	fmt.Printf("Split %s into %s\n", res.ChangeID, res.Path)
+	if len(res.Specs) > 0 {
+		fmt.Printf("  Moved delta specs: %s\n", strings.Join(res.Specs, ", "))
+	}
+	if len(res.Requirements) > 0 {
+		fmt.Printf("  Moved requirements: %s\n", strings.Join(res.Requirements, ", "))
+	}
+	if len(res.Tasks) > 0 {
+		fmt.Printf("  Moved tasks (IDs unchanged): %s\n", strings.Join(res.Tasks, ", "))
+	}
+	fmt.Printf("Fill in %sproposal.md, then run 'spectr validate %s'\n", res.Path, res.NewID)
+
+	return nil
+}
@@ -0,0 +1,138 @@
+// Package cmd provides command-line interface implementations.
+// This file contains the stale command for finding idle changes.
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/connerohnesorge/spectr/internal/hostapi"
+	"github.com/connerohnesorge/spectr/internal/list"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+	"github.com/connerohnesorge/spectr/internal/stale"
+	"github.com/connerohnesorge/spectr/internal/utils"
+)
+
+// StaleCmd lists changes with no task progress or commits in the last
+// Days days and can post a reminder about them to a webhook.
+type StaleCmd struct {
+	Days    int           `help:"Idle days before a change is stale"                     name:"days"    default:"30"` //nolint:lll,revive // Kong struct tag with alignment
+	JSON    bool          `help:"Output as JSON"                                         name:"json"`                 //nolint:lll,revive // Kong struct tag with alignment
+	Notify  string        `help:"Post a reminder to this webhook URL (Slack-compatible)" name:"notify"`               //nolint:lll,revive // Kong struct tag with alignment
+	Timeout time.Duration `help:"Abort after duration (e.g. 30s)"                        name:"timeout"`              //nolint:lll,revive // Kong struct tag with alignment
+}
+
+// Run executes the stale command.
+func (c *StaleCmd) Run() error {
+	if c.Days < 1 {
+		return &specterrs.InvalidStaleDaysError{Days: c.Days}
+	}
+
+	roots, err := GetDiscoveredRoots()
+	if err != nil {
+		return fmt.Errorf(
+			"failed to discover spectr roots: %w",
+			err,
+		)
+	}
+	if len(roots) == 0 {
+		fmt.Println("No spectr directories found.")
+
+		return nil
+	}
+
+	ctx, cancel := utils.CommandContext(c.Timeout)
+	defer cancel()
+
+	changes, err := list.NewMultiRootLister(roots).ListChangesContext(ctx)
+	if err != nil {
+		return utils.CommandError(
+			ctx,
+			"stale",
+			c.Timeout,
+			fmt.Errorf("failed to list changes: %w", err),
+		)
+	}
+
+	idle := stale.Find(ctx, changes, stale.Threshold(c.Days), time.Now())
+
+	if c.Notify != "" && len(idle) > 0 {
+		err := stale.Notify(
+			ctx,
+			hostapi.NewClient(),
+			c.Notify,
+			stale.NewReminder(idle, c.Days),
+		)
+		if err != nil {
+			return utils.CommandError(ctx, "stale", c.Timeout, err)
+		}
+	}
+
+	if c.JSON {
+		if idle == nil {
+			idle = []stale.Change{}
+		}
+		data, err := json.MarshalIndent(idle, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode stale changes: %w", err)
+		}
+		fmt.Println(string(data))
+
+		return nil
+	}
+
+	if len(idle) == 0 {
+		fmt.Printf("No changes idle for %d+ days\n", c.Days)
+
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "CHANGE\tIDLE\tLAST ACTIVITY\tTASKS")
+	for _, change := range idle {
+		fmt.Fprintf(
+			w,
+			"%s\t%s\t%s\t%d/%d\n",
+			change.ID,
+			formatIdleDays(change),
+			formatLastActivity(change),
+			change.Completed,
+			change.Total,
+		)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if c.Notify != "" {
+		fmt.Printf("Posted reminder for %d change(s)\n", len(idle))
+	}
+
+	return nil
+}
+
+// formatIdleDays renders a change's idle period for the stale table.
+func formatIdleDays(change stale.Change) string {
+	if change.LastActivity.IsZero() {
+		return "-"
+	}
+
+	return fmt.Sprintf("%dd", change.IdleDays)
+}
+
+// formatLastActivity renders the date of a change's last activity and
+// where it came from.
+func formatLastActivity(change stale.Change) string {
+	if change.LastActivity.IsZero() {
+		return "never"
+	}
+
+	return fmt.Sprintf(
+		"%s (%s)",
+		change.LastActivity.Format(time.DateOnly),
+		change.Source,
+	)
+}
@@ -0,0 +1,135 @@
+// Package cmd provides command-line interface implementations.
+// This file contains the stats command for reporting spec edit activity.
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/connerohnesorge/spectr/internal/heatmap"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+	"github.com/connerohnesorge/spectr/internal/utils"
+)
+
+// heatGlyphs draws heatmap levels 0 through heatmap.MaxLevel.
+var heatGlyphs = []rune(" ░▒▓█")
+
+// StatsCmd reports how often specs and their requirements were edited
+// over the last Days days, from the git history.
+type StatsCmd struct {
+	Spec    string        `help:"Only this spec"                         name:"spec"    predictor:"specID"` //nolint:lll,revive // Kong struct tag with alignment
+	Heatmap bool          `help:"Show edit frequency per requirement"    name:"heatmap"`                    //nolint:lll,revive // Kong struct tag with alignment
+	Days    int           `help:"Window in days"                         name:"days"    default:"90"`       //nolint:lll,revive // Kong struct tag with alignment
+	JSON    bool          `help:"Output as JSON"                         name:"json"`                       //nolint:lll,revive // Kong struct tag with alignment
+	Timeout time.Duration `help:"Abort after duration (e.g. 30s)"        name:"timeout"`                    //nolint:lll,revive // Kong struct tag with alignment
+}
+
+// Run executes the stats command.
+func (c *StatsCmd) Run() error {
+	if c.Days < 1 {
+		return &specterrs.InvalidHeatmapDaysError{Days: c.Days}
+	}
+
+	root, err := GetSingleRoot()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := utils.CommandContext(c.Timeout)
+	defer cancel()
+
+	now := time.Now()
+	hm, err := heatmap.Compute(
+		ctx,
+		root.Path,
+		heatmap.Options{Since: now.AddDate(0, 0, -c.Days), Spec: c.Spec},
+		now,
+	)
+	if err != nil {
+		return utils.CommandError(ctx, "stats", c.Timeout, err)
+	}
+
+	if c.JSON {
+		if hm.Requirements == nil {
+			hm.Requirements = []heatmap.Entry{}
+		}
+		data, err := json.MarshalIndent(hm, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode stats: %w", err)
+		}
+		fmt.Println(string(data))
+
+		return nil
+	}
+
+	if len(hm.Requirements) == 0 {
+		fmt.Println("No requirements found")
+
+		return nil
+	}
+
+	if c.Heatmap {
+		err = printRequirementHeat(hm)
+	} else {
+		err = printSpecHeat(hm)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Printf(
+		"\n%d commit(s) edited specs from %s to %s\n",
+		hm.Commits,
+		hm.Since.Format(time.DateOnly),
+		hm.Until.Format(time.DateOnly),
+	)
+
+	return nil
+}
+
+// printSpecHeat prints one row per spec with its requirement count, its
+// edits and a strip of its requirement levels in document order.
+func printSpecHeat(hm *heatmap.Heatmap) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SPEC\tREQUIREMENTS\tEDITS\tHEAT")
+	for start := 0; start < len(hm.Requirements); {
+		spec := hm.Requirements[start].Spec
+		end, edits := start, 0
+		var strip strings.Builder
+		for ; end < len(hm.Requirements) && hm.Requirements[end].Spec == spec; end++ {
+			edits += hm.Requirements[end].Edits
+			strip.WriteRune(heatGlyphs[hm.Requirements[end].Level])
+		}
+		fmt.Fprintf(w, "%s\t%d\t%d\t[%s]\n", spec, end-start, edits, strip.String())
+		start = end
+	}
+
+	return w.Flush()
+}
+
+// printRequirementHeat prints one row per requirement with its level,
+// edits and last edit date.
+func printRequirementHeat(hm *heatmap.Heatmap) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "HEAT\tID\tREQUIREMENT\tEDITS\tLAST EDITED")
+	for _, entry := range hm.Requirements {
+		last := "-"
+		if !entry.LastEdited.IsZero() {
+			last = entry.LastEdited.Format(time.DateOnly)
+		}
+		fmt.Fprintf(
+			w,
+			"%s\t%s\t%s\t%d\t%s\n",
+			strings.Repeat(string(heatGlyphs[entry.Level]), 2),
+			entry.ID,
+			entry.Requirement,
+			entry.Edits,
+			last,
+		)
+	}
+
+	return w.Flush()
+}
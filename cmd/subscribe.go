@@ -0,0 +1,133 @@
+// Package cmd provides command-line interface implementations.
+// This file contains the subscribe command for watching specs.
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/connerohnesorge/spectr/internal/git"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+	"github.com/connerohnesorge/spectr/internal/subscription"
+)
+
+// SubscribeCmd records that the current user watches a spec, so that
+// `spectr notify` tells them when its requirements change. Without a spec
+// ID it lists the project's subscriptions.
+type SubscribeCmd struct {
+	// SpecID is the spec, or directory of specs, to watch
+	SpecID string `arg:"" optional:"" predictor:"specID" help:"Spec ID or spec directory to watch"` //nolint:lll,revive // Kong struct tag with alignment
+
+	// Email identifies the subscriber; defaults to git's user.email
+	Email string `name:"email" help:"Subscriber email (default: git user.email)"` //nolint:lll,revive // Kong struct tag with alignment
+
+	// Slack is a handle mentioned in webhook notifications
+	Slack string `name:"slack" help:"Slack handle to mention, e.g. @ada"` //nolint:lll,revive // Kong struct tag with alignment
+
+	// Remove unsubscribes instead
+	Remove bool `name:"remove" help:"Stop watching the spec"` //nolint:lll,revive // Kong struct tag with alignment
+}
+
+// Run executes the subscribe command.
+func (c *SubscribeCmd) Run() error {
+	root, err := GetSingleRoot()
+	if err != nil {
+		return err
+	}
+
+	subs, err := subscription.Load(root.SpectrDir())
+	if err != nil {
+		return err
+	}
+
+	if c.SpecID == "" {
+		if c.Remove {
+			return &specterrs.RequiresFlagError{Flag: "--remove", RequiredFlag: "a spec ID"}
+		}
+
+		return printSubscriptions(subs)
+	}
+
+	spec := strings.Trim(c.SpecID, "/")
+	email := c.Email
+	if email == "" {
+		email = gitUserEmail(root.Path)
+	}
+	if email == "" {
+		return &specterrs.MissingSubscriberEmailError{}
+	}
+
+	if c.Remove {
+		var removed bool
+		subs, removed = subscription.Remove(subs, spec, email)
+		if !removed {
+			fmt.Printf("%s is not subscribed to %s\n", email, spec)
+
+			return nil
+		}
+		if err := subscription.Save(root.SpectrDir(), subs); err != nil {
+			return err
+		}
+		fmt.Printf("Unsubscribed %s from %s\n", email, spec)
+
+		return nil
+	}
+
+	info, err := os.Stat(filepath.Join(root.SpecsDir(), spec))
+	if err != nil || !info.IsDir() {
+		return fmt.Errorf("spec '%s' not found", spec)
+	}
+
+	subs, added := subscription.Add(subs, subscription.Subscription{
+		Spec:  spec,
+		Email: email,
+		Slack: c.Slack,
+	})
+	if err := subscription.Save(root.SpectrDir(), subs); err != nil {
+		return err
+	}
+
+	if added {
+		fmt.Printf("Subscribed %s to %s\n", email, spec)
+	} else {
+		fmt.Printf("Updated the subscription of %s to %s\n", email, spec)
+	}
+	fmt.Printf("Commit spectr/%s to share it\n", subscription.FileName)
+
+	return nil
+}
+
+// printSubscriptions prints subscriptions as a table.
+func printSubscriptions(subs []subscription.Subscription) error {
+	if len(subs) == 0 {
+		fmt.Println("No subscriptions")
+
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SPEC\tEMAIL\tSLACK")
+	for _, sub := range subs {
+		slack := sub.Slack
+		if slack == "" {
+			slack = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", sub.Spec, sub.Email, slack)
+	}
+
+	return w.Flush()
+}
+
+// gitUserEmail returns git's user.email as seen from dir, or "".
+func gitUserEmail(dir string) string {
+	out, err := git.Run(context.Background(), dir, "config", "--get", "user.email")
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimSpace(string(out))
+}
@@ -0,0 +1,235 @@
+// Package cmd provides command-line interface implementations.
+// This file contains the tasks command for managing change tasks.
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/audit"
+	"github.com/connerohnesorge/spectr/internal/git"
+	"github.com/connerohnesorge/spectr/internal/parsers"
+	"github.com/connerohnesorge/spectr/internal/pr"
+	"github.com/connerohnesorge/spectr/internal/sync"
+	"github.com/connerohnesorge/spectr/internal/taskexec"
+)
+
+// reviewFeedbackSection is the tasks.jsonc section that receives tasks
+// imported from pull request review comments.
+const reviewFeedbackSection = "Review feedback"
+
+// maxReviewSummaryLen caps the comment excerpt used in task descriptions.
+const maxReviewSummaryLen = 120
+
+// TasksCmd represents the tasks command with subcommands.
+type TasksCmd struct {
+	Import   TasksImportCmd   `cmd:"" help:"Import tasks from review comments"` //nolint:lll,revive // Kong struct tag with alignment
+	Complete TasksCompleteCmd `cmd:"" help:"Mark a task completed"`             //nolint:lll,revive // Kong struct tag with alignment
+}
+
+// TasksCompleteCmd represents the tasks complete subcommand.
+type TasksCompleteCmd struct {
+	ChangeID string `arg:"" predictor:"changeID" help:"Change ID"` //nolint:lll,revive // Kong struct tag with alignment
+	TaskID   string `arg:"" predictor:"taskID"   help:"Task ID"`   //nolint:lll,revive // Kong struct tag with alignment
+}
+
+// Run executes the tasks complete command.
+func (c *TasksCompleteCmd) Run() error {
+	root, err := GetSingleRoot()
+	if err != nil {
+		return err
+	}
+
+	changeID, err := resolveOrSelectChangeID(c.ChangeID, root.Path)
+	if err != nil {
+		return err
+	}
+
+	changeDir := filepath.Join(root.ChangesDir(), changeID)
+	if _, err := os.Stat(filepath.Join(changeDir, "tasks.jsonc")); err != nil {
+		return fmt.Errorf(
+			"change '%s' has no tasks.jsonc; run 'spectr accept %s' first",
+			changeID,
+			changeID,
+		)
+	}
+
+	err = taskexec.NewStatusUpdater(changeDir).UpdateTaskStatus(
+		c.TaskID,
+		parsers.TaskStatusCompleted,
+	)
+	if err != nil {
+		return err
+	}
+	// Keep the tasks.md checkboxes in step, as the next command would
+	if _, err := sync.SyncTasksToMarkdown(changeDir); err != nil {
+		return err
+	}
+
+	fmt.Printf("Completed task %s in %s\n", c.TaskID, changeID)
+
+	return nil
+}
+
+// TasksImportCmd represents the tasks import subcommand.
+type TasksImportCmd struct {
+	ChangeID string `arg:"" optional:"" predictor:"changeID" help:"Change ID"`
+	FromPR   string `                                        help:"Pull request URL to import unresolved review comments from" name:"from-pr" required:""` //nolint:lll,revive // Kong struct tag with alignment
+}
+
+// Run executes the tasks import command.
+func (c *TasksImportCmd) Run() error {
+	root, err := GetSingleRoot()
+	if err != nil {
+		return err
+	}
+
+	changeID, err := resolveOrSelectChangeID(c.ChangeID, root.Path)
+	if err != nil {
+		return err
+	}
+
+	tasksPath := filepath.Join(root.ChangesDir(), changeID, "tasks.jsonc")
+	tasksFile, err := parsers.ReadTasksJson(tasksPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf(
+				"change '%s' has no tasks.jsonc; run 'spectr accept %s' first",
+				changeID,
+				changeID,
+			)
+		}
+
+		return fmt.Errorf("failed to read tasks file: %w", err)
+	}
+
+	ref, err := git.ParsePullRequestURL(c.FromPR)
+	if err != nil {
+		return err
+	}
+
+	comments, err := pr.FetchUnresolvedReviewComments(context.Background(), ref)
+	if err != nil {
+		return err
+	}
+
+	descriptions := reviewTaskDescriptions(comments, tasksFile.Tasks)
+	if len(descriptions) == 0 {
+		fmt.Println("No new unresolved review comments to import")
+
+		return nil
+	}
+
+	added, err := taskexec.AppendTasks(
+		tasksPath,
+		reviewFeedbackSection,
+		descriptions,
+	)
+	if err != nil {
+		return err
+	}
+
+	err = recordAudit(
+		root.Path,
+		audit.OpTasksImport,
+		[]string{"changes/" + changeID},
+		map[string]string{
+			"from_pr": c.FromPR,
+			"tasks":   strconv.Itoa(len(added)),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf(
+		"Imported %d review comment(s) into %s\n",
+		len(added),
+		tasksPath,
+	)
+	for _, task := range added {
+		fmt.Printf("  %s %s\n", task.ID, task.Description)
+	}
+
+	return nil
+}
+
+// reviewTaskDescriptions builds task descriptions for review comments.
+// Comments whose URL is already referenced by an existing task are
+// skipped, so importing the same pull request twice is idempotent.
+func reviewTaskDescriptions(
+	comments []pr.ReviewComment,
+	existing []parsers.Task,
+) []string {
+	descriptions := make([]string, 0, len(comments))
+
+	for _, comment := range comments {
+		if comment.URL != "" && taskReferencesURL(existing, comment.URL) {
+			continue
+		}
+		descriptions = append(
+			descriptions,
+			formatReviewTask(comment),
+		)
+	}
+
+	return descriptions
+}
+
+// taskReferencesURL reports whether any task description contains url.
+func taskReferencesURL(tasks []parsers.Task, url string) bool {
+	for _, task := range tasks {
+		if strings.Contains(task.Description, url) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// formatReviewTask renders a review comment as a task description, e.g.
+// "Address review from @alice on cmd/root.go:12: Add a test (<url>)".
+func formatReviewTask(comment pr.ReviewComment) string {
+	var sb strings.Builder
+
+	sb.WriteString("Address review")
+	if comment.Author != "" {
+		sb.WriteString(" from @" + comment.Author)
+	}
+	if comment.Path != "" {
+		sb.WriteString(" on " + comment.Path)
+		if comment.Line > 0 {
+			sb.WriteString(":" + strconv.Itoa(comment.Line))
+		}
+	}
+	sb.WriteString(": " + summarizeComment(comment.Body))
+	if comment.URL != "" {
+		sb.WriteString(" (" + comment.URL + ")")
+	}
+
+	return sb.String()
+}
+
+// summarizeComment returns the first non-empty line of a comment body,
+// truncated to maxReviewSummaryLen characters.
+func summarizeComment(body string) string {
+	summary := ""
+	for _, line := range strings.Split(body, "\n") {
+		if trimmed := strings.TrimSpace(line); trimmed != "" {
+			summary = trimmed
+
+			break
+		}
+	}
+
+	runes := []rune(summary)
+	if len(runes) > maxReviewSummaryLen {
+		summary = string(runes[:maxReviewSummaryLen-3]) + "..."
+	}
+
+	return summary
+}
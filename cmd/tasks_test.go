@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/connerohnesorge/spectr/internal/parsers"
+	"github.com/connerohnesorge/spectr/internal/pr"
+)
+
+func TestFormatReviewTask(t *testing.T) {
+	tests := []struct {
+		name    string
+		comment pr.ReviewComment
+		want    string
+	}{
+		{
+			name: "full comment",
+			comment: pr.ReviewComment{
+				Author: "alice",
+				Body:   "Please add a test\n\nMore detail here",
+				Path:   "cmd/root.go",
+				Line:   12,
+				URL:    "https://github.com/o/r/pull/1#discussion_r1",
+			},
+			want: "Address review from @alice on cmd/root.go:12: " +
+				"Please add a test (https://github.com/o/r/pull/1#discussion_r1)",
+		},
+		{
+			name: "no path or line",
+			comment: pr.ReviewComment{
+				Author: "bob",
+				Body:   "  Rename this  ",
+				URL:    "https://example.com/c/2",
+			},
+			want: "Address review from @bob: Rename this (https://example.com/c/2)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatReviewTask(tt.comment); got != tt.want {
+				t.Errorf("formatReviewTask() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSummarizeComment_Truncates(t *testing.T) {
+	got := summarizeComment(strings.Repeat("x", maxReviewSummaryLen+20))
+	if len([]rune(got)) != maxReviewSummaryLen {
+		t.Errorf("summary length = %d, want %d", len([]rune(got)), maxReviewSummaryLen)
+	}
+	if !strings.HasSuffix(got, "...") {
+		t.Errorf("summary %q should end with ellipsis", got)
+	}
+}
+
+func TestReviewTaskDescriptions_SkipsImported(t *testing.T) {
+	existing := []parsers.Task{
+		{
+			ID:          "3.1",
+			Section:     reviewFeedbackSection,
+			Description: "Address review from @alice: Old (https://example.com/c/1)",
+			Status:      parsers.TaskStatusPending,
+		},
+	}
+	comments := []pr.ReviewComment{
+		{Author: "alice", Body: "Old", URL: "https://example.com/c/1"},
+		{Author: "bob", Body: "New", URL: "https://example.com/c/2"},
+	}
+
+	got := reviewTaskDescriptions(comments, existing)
+	if len(got) != 1 {
+		t.Fatalf("got %d descriptions, want 1: %v", len(got), got)
+	}
+	if !strings.Contains(got[0], "https://example.com/c/2") {
+		t.Errorf("unexpected description %q", got[0])
+	}
+}
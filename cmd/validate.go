@@ -2,12 +2,22 @@
 package cmd
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"time"
 
+	"github.com/connerohnesorge/spectr/internal/config"
 	"github.com/connerohnesorge/spectr/internal/discovery"
+	"github.com/connerohnesorge/spectr/internal/events"
+	"github.com/connerohnesorge/spectr/internal/implindex"
+	"github.com/connerohnesorge/spectr/internal/kinds"
+	"github.com/connerohnesorge/spectr/internal/markdown"
 	"github.com/connerohnesorge/spectr/internal/specterrs"
+	"github.com/connerohnesorge/spectr/internal/tui"
+	"github.com/connerohnesorge/spectr/internal/utils"
 	"github.com/connerohnesorge/spectr/internal/validation"
 )
 
@@ -15,13 +25,24 @@ import (
 
 // ValidateCmd represents the validate command
 type ValidateCmd struct {
-	ItemName      *string `arg:"" optional:"" predictor:"item"`
-	JSON          bool    `                                        name:"json"           help:"Output as JSON"`                      //nolint:lll,revive // Kong struct tag with alignment
-	All           bool    `                                        name:"all"            help:"Validate all"`                        //nolint:lll,revive // Kong struct tag with alignment
-	Changes       bool    `                                        name:"changes"        help:"Validate changes"`                    //nolint:lll,revive // Kong struct tag with alignment
-	Specs         bool    `                                        name:"specs"          help:"Validate specs"`                      //nolint:lll,revive // Kong struct tag with alignment
-	Type          *string `                   predictor:"itemType" name:"type"                                   enum:"change,spec"` //nolint:lll,revive // Kong struct tag with alignment
-	NoInteractive bool    `                                        name:"no-interactive" help:"No prompts"`                          //nolint:lll,revive // Kong struct tag with alignment
+	ItemName      *string       `arg:"" optional:"" predictor:"item"`
+	Selectors     []string      `arg:"" optional:"" help:"More items, or requirement:<name> selectors"`                                                                           //nolint:lll,revive // Kong struct tag with alignment
+	JSON          bool          `                                        name:"json"           help:"Output as JSON"`                                                             //nolint:lll,revive // Kong struct tag with alignment
+	Format        string        `                                        name:"format"         help:"Output format (human, json, jsonl)" enum:"human,json,jsonl" default:"human"` //nolint:lll,revive // Kong struct tag with alignment
+	All           bool          `                                        name:"all"            help:"Validate all"`                                                               //nolint:lll,revive // Kong struct tag with alignment
+	Changes       bool          `                                        name:"changes"        help:"Validate changes"`                                                           //nolint:lll,revive // Kong struct tag with alignment
+	Specs         bool          `                                        name:"specs"          help:"Validate specs"`                                                             //nolint:lll,revive // Kong struct tag with alignment
+	Type          *string       `                   predictor:"itemType" name:"type"                                   enum:"change,spec"`                                        //nolint:lll,revive // Kong struct tag with alignment
+	NoInteractive bool          `                                        name:"no-interactive" help:"No prompts"`                                                                 //nolint:lll,revive // Kong struct tag with alignment
+	Impl          bool          `                                        name:"impl"           help:"Check spectr:impl markers"`                                                  //nolint:lll,revive // Kong struct tag with alignment
+	Tests         bool          `                                        name:"tests"          help:"Check spectr:req test markers"`                                              //nolint:lll,revive // Kong struct tag with alignment
+	FailOn        string        `                                        name:"fail-on"        help:"Fail on error, or on warning too"   enum:"error,warning"    default:"error"` //nolint:lll,revive // Kong struct tag with alignment
+	Fix           bool          `                                        name:"fix"            help:"Apply quick fixes before validating"`                                        //nolint:lll,revive // Kong struct tag with alignment
+	MaxErrors     *int          `                                        name:"max-errors"     help:"Issues shown per item (0 = all)"`                                            //nolint:lll,revive // Kong struct tag with alignment
+	Timeout       time.Duration `                                        name:"timeout"        help:"Abort after duration (e.g. 30s)"`                                            //nolint:lll,revive // Kong struct tag with alignment
+
+	// implIndexes caches implementation indexes per project root
+	implIndexes map[string]*implindex.Index
 }
 
 // Run executes the validate command
@@ -40,15 +61,41 @@ func (c *ValidateCmd) Run() error {
 		return c.runBulkValidation(projectPath)
 	}
 
+	// Several items or a selector validate just the selection
+	if len(c.Selectors) > 0 ||
+		(c.ItemName != nil && validation.IsSelector(*c.ItemName)) {
+		return c.runSelectedValidation(
+			projectPath,
+			append([]string{*c.ItemName}, c.Selectors...),
+		)
+	}
+
+	// Default to the change named by the worktree or branch
+	if (c.ItemName == nil || *c.ItemName == "") &&
+		(c.Type == nil || *c.Type == validation.ItemTypeChange) {
+		changeID, err := detectChangeID(projectPath)
+		if err != nil {
+			return err
+		}
+		if changeID != "" {
+			c.ItemName = &changeID
+		}
+	}
+
 	// If no item name provided
 	if c.ItemName == nil || *c.ItemName == "" {
 		if c.NoInteractive {
 			return getUsageError()
 		}
+		if !tui.Interactive() {
+			return &specterrs.MissingArgumentError{
+				Argument: "item name (or --all, --changes, --specs)",
+			}
+		}
 		// Launch interactive mode
 		return validation.RunInteractiveValidation(
 			projectPath,
-			c.JSON,
+			c.format() == formatJSON,
 		)
 	}
 
@@ -59,10 +106,25 @@ func (c *ValidateCmd) Run() error {
 	)
 }
 
-// runDirectValidation validates a single item (change or spec)
+// runDirectValidation validates a single item (change, spec or custom
+// kind item)
 func (c *ValidateCmd) runDirectValidation(
 	projectPath, itemName string,
 ) error {
+	// Items of custom kinds are named "<kind>/<id>" or by their path
+	registry, err := kinds.Load(projectPath)
+	if err != nil {
+		return err
+	}
+	if item, ok := registry.Find(itemName); ok {
+		kind, err := registry.Get(item.Kind)
+		if err != nil {
+			return err
+		}
+
+		return c.runKindValidation(projectPath, validation.KindItem(&kind, item))
+	}
+
 	// Normalize the item path to extract ID and infer type
 	normalizedID, inferredType := discovery.NormalizeItemPath(
 		itemName,
@@ -82,8 +144,29 @@ func (c *ValidateCmd) runDirectValidation(
 		return err
 	}
 
+	if c.Fix {
+		path := filepath.Join(projectPath, validation.SpectrDir, "specs", normalizedID, "spec.md")
+		if info.ItemType == validation.ItemTypeChange {
+			path = filepath.Join(projectPath, validation.SpectrDir, "changes", normalizedID)
+		}
+		err := applyFixes([]validation.ValidationItem{{
+			Name:     normalizedID,
+			ItemType: info.ItemType,
+			Path:     path,
+		}})
+		if err != nil {
+			return err
+		}
+	}
+
+	ctx, cancel := utils.CommandContext(c.Timeout)
+	defer cancel()
+
 	// Create validator and validate
-	validator := validation.NewValidator()
+	validator, err := c.newValidator(projectPath)
+	if err != nil {
+		return err
+	}
 	report, err := validation.ValidateItemByType(
 		validator,
 		projectPath,
@@ -97,11 +180,75 @@ func (c *ValidateCmd) runDirectValidation(
 		)
 	}
 
+	if (c.Impl || c.Tests) && info.ItemType == validation.ItemTypeSpec {
+		report, err = c.addImplIssues(
+			ctx,
+			report,
+			projectPath,
+			normalizedID,
+			filepath.Join(
+				projectPath,
+				validation.SpectrDir,
+				"specs",
+				normalizedID,
+				"spec.md",
+			),
+		)
+		if err != nil {
+			return utils.CommandError(ctx, "validate", c.Timeout, err)
+		}
+	}
+
+	return c.printReport(projectPath, normalizedID, report)
+}
+
+// runKindValidation validates an item of a custom kind.
+func (c *ValidateCmd) runKindValidation(
+	projectPath string,
+	item validation.ValidationItem,
+) error {
+	if c.Fix {
+		if err := applyFixes([]validation.ValidationItem{item}); err != nil {
+			return err
+		}
+	}
+
+	ctx, cancel := utils.CommandContext(c.Timeout)
+	defer cancel()
+
+	validator, err := c.newValidator(projectPath)
+	if err != nil {
+		return err
+	}
+	report, err := validator.ValidateKindContext(ctx, *item.Kind, item.Path)
+	if err != nil {
+		return utils.CommandError(ctx, "validate", c.Timeout, err)
+	}
+
+	return c.printReport(projectPath, item.Name, report)
+}
+
+// printReport prints the report of a single item and returns an error when
+// the item failed validation.
+func (c *ValidateCmd) printReport(
+	projectPath, name string,
+	report *validation.ValidationReport,
+) error {
 	// Print report
-	if c.JSON {
+	switch c.format() {
+	case formatJSON:
 		validation.PrintJSONReport(report)
-	} else {
-		validation.PrintHumanReport(normalizedID, report)
+	case formatJSONLines:
+		validation.PrintJSONLinesReport(name, report)
+	default:
+		maxErrors, err := c.maxErrors(projectPath)
+		if err != nil {
+			return err
+		}
+		validation.PrintHumanReport(
+			name,
+			validation.LimitReport(report, maxErrors),
+		)
 	}
 
 	// Return error if validation failed
@@ -111,6 +258,12 @@ func (c *ValidateCmd) runDirectValidation(
 			WarningCount: report.Summary.Warnings,
 		}
 	}
+	if c.FailOn == failOnWarning && report.Summary.Warnings > 0 {
+		return &specterrs.ValidationFailedError{
+			WarningCount: report.Summary.Warnings,
+			WarningsOnly: true,
+		}
+	}
 
 	return nil
 }
@@ -119,8 +272,6 @@ func (c *ValidateCmd) runDirectValidation(
 func (c *ValidateCmd) runBulkValidation(
 	_ string,
 ) error {
-	validator := validation.NewValidator()
-
 	// Discover all spectr roots
 	roots, err := GetDiscoveredRoots()
 	if err != nil {
@@ -144,18 +295,74 @@ func (c *ValidateCmd) runBulkValidation(
 		return c.handleNoItems()
 	}
 
+	return c.validateItems(roots, items, len(roots) > 1)
+}
+
+// runSelectedValidation validates the items named by selectors, such as
+// "spec/auth" or requirement:"Token refresh", in the current project.
+func (c *ValidateCmd) runSelectedValidation(
+	projectPath string,
+	selectors []string,
+) error {
+	items, err := validation.SelectItems(projectPath, selectors, c.Type)
+	if err != nil {
+		return err
+	}
+
+	return c.validateItems(
+		[]discovery.SpectrRoot{{Path: projectPath}},
+		items,
+		false,
+	)
+}
+
+// validateItems validates items from roots, prints the results and
+// returns an error when any item failed.
+func (c *ValidateCmd) validateItems(
+	roots []discovery.SpectrRoot,
+	items []validation.ValidationItem,
+	hasMultipleRoots bool,
+) error {
+	if c.Fix {
+		if err := applyFixes(items); err != nil {
+			return err
+		}
+	}
+
+	// Stop between items on Ctrl-C or timeout rather than mid-report
+	ctx, cancel := utils.CommandContext(c.Timeout)
+	defer cancel()
+	defer prefetchRoots(ctx, roots)()
+
 	// Validate all items
-	results, hasFailures := c.validateAllItems(
+	validator, err := c.newValidator(roots[0].Path)
+	if err != nil {
+		return err
+	}
+	results, hasFailures, err := c.validateAllItems(
+		ctx,
 		validator,
 		items,
 	)
+	if err != nil {
+		return utils.CommandError(ctx, "validate", c.Timeout, err)
+	}
 
 	// Print results
-	hasMultipleRoots := len(roots) > 1
-	if c.JSON {
+	switch c.format() {
+	case formatJSON:
 		validation.PrintBulkJSONResults(results)
-	} else {
-		validation.PrintBulkHumanResultsMulti(results, hasMultipleRoots)
+	case formatJSONLines:
+		validation.PrintBulkJSONLinesResults(items, results)
+	default:
+		maxErrors, err := c.maxErrors(roots[0].Path)
+		if err != nil {
+			return err
+		}
+		validation.PrintBulkHumanResultsMulti(
+			validation.LimitResults(results, maxErrors),
+			hasMultipleRoots,
+		)
 	}
 
 	if hasFailures {
@@ -163,10 +370,43 @@ func (c *ValidateCmd) runBulkValidation(
 			ItemCount: len(items),
 		}
 	}
+	if c.FailOn == failOnWarning && hasWarnings(results) {
+		return &specterrs.MultiValidationFailedError{
+			ItemCount:    len(items),
+			WarningsOnly: true,
+		}
+	}
 
 	return nil
 }
 
+// applyFixes applies the quick fixes of each item's markdown files and
+// reports what changed on stderr, keeping JSON output clean.
+func applyFixes(items []validation.ValidationItem) error {
+	for _, item := range items {
+		n, err := validation.FixItem(item)
+		if err != nil {
+			return fmt.Errorf("failed to fix %s: %w", item.Name, err)
+		}
+		if n > 0 {
+			fmt.Fprintf(os.Stderr, "Fixed %d issue(s) in %s\n", n, item.Name)
+		}
+	}
+
+	return nil
+}
+
+// hasWarnings reports whether any result has a warning.
+func hasWarnings(results []validation.BulkResult) bool {
+	for _, result := range results {
+		if result.Report != nil && result.Report.Summary.Warnings > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
 // getItemsToValidateMultiRoot returns the items to validate from all roots.
 func (c *ValidateCmd) getItemsToValidateMultiRoot(
 	roots []discovery.SpectrRoot,
@@ -185,20 +425,80 @@ func (c *ValidateCmd) getItemsToValidateMultiRoot(
 
 // handleNoItems handles the case when there are no items to validate
 func (c *ValidateCmd) handleNoItems() error {
-	if c.JSON {
+	switch c.format() {
+	case formatJSON:
 		fmt.Println("[]")
-	} else {
+	case formatJSONLines:
+		// No issues means no lines
+	default:
 		fmt.Println("No items to validate")
 	}
 
 	return nil
 }
 
-// validateAllItems validates all items and returns results
-func (*ValidateCmd) validateAllItems(
+// failOnWarning is the --fail-on value that fails validation on warnings.
+const failOnWarning = "warning"
+
+// Output formats for --format. --json is shorthand for --format=json.
+const (
+	formatHuman     = "human"
+	formatJSON      = "json"
+	formatJSONLines = "jsonl"
+)
+
+// maxErrors returns the number of issues printed per item in human
+// output: --max-errors, else validation.max_errors from the spectr.yaml
+// found from dir, else the default. JSON output always has every issue.
+func (c *ValidateCmd) maxErrors(dir string) (int, error) {
+	if c.MaxErrors != nil {
+		return *c.MaxErrors, nil
+	}
+	cfg, err := config.LoadConfig(dir)
+	if err != nil {
+		return 0, err
+	}
+
+	return cfg.MaxErrors(validation.DefaultMaxErrors), nil
+}
+
+// newValidator returns a validator whose markdown diagnostics stop after
+// the issues printed per item (see maxErrors). JSON output collects them
+// all.
+func (c *ValidateCmd) newValidator(dir string) (*validation.Validator, error) {
+	limit := 0
+	if c.format() == formatHuman {
+		var err error
+		if limit, err = c.maxErrors(dir); err != nil {
+			return nil, err
+		}
+	}
+
+	validator := validation.NewValidator()
+	validator.ParseOptions = []markdown.ParseOption{markdown.WithMaxErrors(limit)}
+
+	return validator, nil
+}
+
+// format returns the effective output format.
+func (c *ValidateCmd) format() string {
+	if c.JSON {
+		return formatJSON
+	}
+	if c.Format == "" {
+		return formatHuman
+	}
+
+	return c.Format
+}
+
+// validateAllItems validates all items and returns results. It returns
+// ctx.Err() if ctx is done before all items are validated.
+func (c *ValidateCmd) validateAllItems(
+	ctx context.Context,
 	validator *validation.Validator,
 	items []validation.ValidationItem,
-) ([]validation.BulkResult, bool) {
+) ([]validation.BulkResult, bool, error) {
 	results := make(
 		[]validation.BulkResult,
 		0,
@@ -206,19 +506,96 @@ func (*ValidateCmd) validateAllItems(
 	)
 	hasFailures := false
 
-	for _, item := range items {
-		result, err := validation.ValidateSingleItem(
+	events.Emit("validate", "", 0, len(items))
+	for i, item := range items {
+		result, err := validation.ValidateSingleItemContext(
+			ctx,
 			validator,
 			item,
 		)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, false, ctxErr
+		}
+		if err == nil && (c.Impl || c.Tests) &&
+			item.ItemType == validation.ItemTypeSpec {
+			result.Report, err = c.addImplIssues(
+				ctx,
+				result.Report,
+				specProjectRoot(item.Path),
+				item.Name,
+				item.Path,
+			)
+			if err == nil {
+				result.Report, err = validation.ScopeReport(item, result.Report)
+			}
+			if err != nil {
+				result.Valid = false
+				result.Error = err.Error()
+			} else {
+				result.Valid = result.Report.Valid
+			}
+		}
 		results = append(results, result)
+		events.Emit("validate", item.Name, i+1, len(items))
 
 		if err != nil || !result.Valid {
 			hasFailures = true
 		}
 	}
 
-	return results, hasFailures
+	return results, hasFailures, ctx.Err()
+}
+
+// addImplIssues merges implementation marker issues (--impl) and test
+// marker issues (--tests) for a spec into report. The implementation index
+// for each project root is scanned once and cached.
+func (c *ValidateCmd) addImplIssues(
+	ctx context.Context,
+	report *validation.ValidationReport,
+	projectRoot, specID, specPath string,
+) (*validation.ValidationReport, error) {
+	if c.implIndexes == nil {
+		c.implIndexes = make(map[string]*implindex.Index)
+	}
+
+	idx, ok := c.implIndexes[projectRoot]
+	if !ok {
+		var err error
+		idx, err = implindex.ScanContext(ctx, projectRoot)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"failed to scan implementation markers: %w",
+				err,
+			)
+		}
+		c.implIndexes[projectRoot] = idx
+	}
+
+	issues := report.Issues
+	for _, check := range []struct {
+		enabled  bool
+		validate func(specPath, specID string, idx *implindex.Index) ([]validation.ValidationIssue, error)
+	}{
+		{c.Impl, validation.ValidateImplementationMarkers},
+		{c.Tests, validation.ValidateTestMarkers},
+	} {
+		if !check.enabled {
+			continue
+		}
+		found, err := check.validate(specPath, specID, idx)
+		if err != nil {
+			return nil, err
+		}
+		issues = append(issues, found...)
+	}
+
+	return validation.NewValidationReport(issues), nil
+}
+
+// specProjectRoot derives the project root from a spec.md path
+// (<root>/spectr/specs/<id>/spec.md).
+func specProjectRoot(specPath string) string {
+	return filepath.Dir(filepath.Dir(filepath.Dir(filepath.Dir(specPath))))
 }
 
 // getUsageError returns the usage error message
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+	"github.com/connerohnesorge/spectr/internal/validation"
+)
+
+func TestHasWarnings(t *testing.T) {
+	warning := validation.NewValidationReport([]validation.ValidationIssue{
+		{Level: validation.LevelWarning, Path: "spec.md", Message: "no scenarios"},
+	})
+	clean := validation.NewValidationReport(nil)
+
+	tests := []struct {
+		name    string
+		results []validation.BulkResult
+		want    bool
+	}{
+		{"none", nil, false},
+		{"clean", []validation.BulkResult{{Report: clean}}, false},
+		{"error without report", []validation.BulkResult{{Error: "boom"}}, false},
+		{"warning", []validation.BulkResult{{Report: clean}, {Report: warning}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hasWarnings(tt.results); got != tt.want {
+				t.Errorf("hasWarnings() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidationExitCodes(t *testing.T) {
+	tests := []struct {
+		name string
+		err  interface{ ExitCode() int }
+		want int
+	}{
+		{"errors", &specterrs.ValidationFailedError{ErrorCount: 1}, specterrs.ExitValidation},
+		{"warnings only", &specterrs.ValidationFailedError{WarningCount: 2, WarningsOnly: true}, specterrs.ExitWarnings},
+		{"bulk errors", &specterrs.MultiValidationFailedError{ItemCount: 3}, specterrs.ExitValidation},
+		{"bulk warnings only", &specterrs.MultiValidationFailedError{ItemCount: 3, WarningsOnly: true}, specterrs.ExitWarnings},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err.ExitCode(); got != tt.want {
+				t.Errorf("ExitCode() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateNewValidator_MaxErrors(t *testing.T) {
+	dir := t.TempDir()
+	specPath := filepath.Join(dir, "spec.md")
+	content := "# Auth\n\n## Purpose\n\nAuth.\n\n## Requirements\n\n" +
+		strings.Repeat("**Requirement: Login**\n\n", 150)
+	if err := os.WriteFile(specPath, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	three, all := 3, 0
+	tests := []struct {
+		name      string
+		cmd       ValidateCmd
+		wantFixes int
+	}{
+		{"default limit", ValidateCmd{}, 100},
+		{"max errors", ValidateCmd{MaxErrors: &three}, 3},
+		{"max errors zero collects all", ValidateCmd{MaxErrors: &all}, 150},
+		{"json collects all", ValidateCmd{Format: formatJSON}, 150},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator, err := tt.cmd.newValidator(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			report, err := validator.ValidateSpec(specPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			fixes := 0
+			for _, issue := range report.Issues {
+				if issue.Fix != nil {
+					fixes++
+				}
+			}
+			if fixes != tt.wantFixes {
+				t.Errorf("%d issues with a fix, want %d", fixes, tt.wantFixes)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+// Package cmd provides command-line interface implementations.
+// This file contains the worktree command for per-change working
+// directories.
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/connerohnesorge/spectr/internal/git"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+	"github.com/connerohnesorge/spectr/internal/utils"
+)
+
+// WorktreeCmd creates a git worktree and branch for a single change, so
+// several changes can be worked on side by side from one clone.
+type WorktreeCmd struct {
+	ChangeID string `arg:"" optional:"" predictor:"changeID" help:"Change ID"`
+	Path     string `                                        help:"Worktree directory (default: <repo>-<change-id> next to the repo)" name:"path"` //nolint:lll,revive // Kong struct tag with alignment
+	Base     string `                                        help:"Commit or branch to start from (default: HEAD)"                    name:"base"` //nolint:lll,revive // Kong struct tag with alignment
+}
+
+// Run executes the worktree command.
+func (c *WorktreeCmd) Run() error {
+	if err := git.Require("spectr worktree"); err != nil {
+		return err
+	}
+
+	root, err := GetSingleRoot()
+	if err != nil {
+		return err
+	}
+
+	changeID, err := resolveOrSelectChangeID(c.ChangeID, root.Path)
+	if err != nil {
+		var userCancelledErr *specterrs.UserCancelledError
+		if errors.As(err, &userCancelledErr) {
+			return nil
+		}
+
+		return err
+	}
+
+	repoRoot, err := git.GetRepoRoot()
+	if err != nil {
+		return err
+	}
+
+	path := c.Path
+	if path == "" {
+		path = filepath.Join(
+			filepath.Dir(repoRoot),
+			filepath.Base(repoRoot)+"-"+changeID,
+		)
+	}
+	path, err = filepath.Abs(path)
+	if err != nil {
+		return fmt.Errorf("resolve worktree path: %w", err)
+	}
+
+	ctx, cancel := utils.CommandContext(0)
+	defer cancel()
+
+	branch, err := git.CreateChangeWorktree(ctx, git.ChangeWorktreeConfig{
+		RepoDir:  repoRoot,
+		Path:     path,
+		ChangeID: changeID,
+		Base:     c.Base,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Created worktree for %s on branch %s\n", changeID, branch)
+	fmt.Printf("  cd %s\n", path)
+	fmt.Println(
+		"Commands run there that take a change ID default to " + changeID,
+	)
+
+	return nil
+}
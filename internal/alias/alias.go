@@ -0,0 +1,145 @@
+// Package alias keeps the old IDs of renamed specs and changes, so
+// references to them keep working while they are updated.
+//
+// spectr rename records every rename in spectr/aliases.yaml:
+//
+//	specs:
+//	  auth: identity/auth
+//	changes:
+//	  add-sso: add-single-sign-on
+//
+// Command arguments and wikilinks that use an old ID resolve to the new
+// one with a deprecation warning. An ID that exists again as a spec or
+// change is no longer treated as an alias.
+package alias
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+
+	"github.com/connerohnesorge/spectr/internal/fileio"
+	"gopkg.in/yaml.v3"
+)
+
+// FileName is the alias file inside spectr/.
+const FileName = "aliases.yaml"
+
+// Kind is the kind of item an alias names.
+type Kind string
+
+// Item kinds with aliases.
+const (
+	Spec   Kind = "spec"
+	Change Kind = "change"
+)
+
+// Map holds the aliases of a project, old ID to new ID.
+type Map struct {
+	Specs   map[string]string `yaml:"specs,omitempty"`
+	Changes map[string]string `yaml:"changes,omitempty"`
+}
+
+// Load reads the aliases of the spectr/ directory spectrDir. A missing
+// file gives an empty map.
+func Load(spectrDir string) (*Map, error) {
+	path := filepath.Join(spectrDir, FileName)
+	data, err := fileio.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &Map{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var m Map
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	return &m, nil
+}
+
+// Encode returns the map as the content of aliases.yaml.
+func (m *Map) Encode() ([]byte, error) {
+	data, err := yaml.Marshal(m)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode aliases: %w", err)
+	}
+
+	return data, nil
+}
+
+// Empty reports whether the map has no aliases.
+func (m *Map) Empty() bool {
+	return len(m.Specs) == 0 && len(m.Changes) == 0
+}
+
+// Resolve returns the current ID of id when it is an alias, following
+// renames of renamed items.
+func (m *Map) Resolve(kind Kind, id string) (string, bool) {
+	entries := m.entries(kind)
+	target, ok := entries[id]
+	if !ok {
+		return "", false
+	}
+	// Add keeps chains flat; the bound guards hand-edited cycles
+	for range entries {
+		next, ok := entries[target]
+		if !ok || next == id {
+			break
+		}
+		target = next
+	}
+
+	return target, true
+}
+
+// Add records that from was renamed to, pointing aliases of from at to as
+// well. An alias named to is dropped, as that ID is in use again.
+func (m *Map) Add(kind Kind, from, to string) {
+	if kind == Spec && m.Specs == nil {
+		m.Specs = make(map[string]string)
+	}
+	if kind == Change && m.Changes == nil {
+		m.Changes = make(map[string]string)
+	}
+
+	entries := m.entries(kind)
+	for old, target := range entries {
+		if target == from {
+			entries[old] = to
+		}
+	}
+	delete(entries, to)
+	entries[from] = to
+}
+
+// Aliases returns the old IDs of kind that resolve to id, sorted.
+func (m *Map) Aliases(kind Kind, id string) []string {
+	var old []string
+	for from := range m.entries(kind) {
+		if target, _ := m.Resolve(kind, from); target == id {
+			old = append(old, from)
+		}
+	}
+	sort.Strings(old)
+
+	return old
+}
+
+// entries returns the aliases of kind.
+func (m *Map) entries(kind Kind) map[string]string {
+	if kind == Change {
+		return m.Changes
+	}
+
+	return m.Specs
+}
+
+// Warning is the deprecation warning for a reference to an old ID.
+func Warning(kind Kind, from, to string) string {
+	return fmt.Sprintf("%s '%s' was renamed to '%s'; update references to the new ID", kind, from, to)
+}
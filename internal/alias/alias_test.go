@@ -0,0 +1,148 @@
+package alias
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+	"github.com/connerohnesorge/spectr/internal/testutil"
+)
+
+func TestMap_Add(t *testing.T) {
+	m := &Map{}
+	m.Add(Spec, "auth", "login")
+	m.Add(Spec, "login", "identity/login")
+	m.Add(Change, "add-sso", "add-single-sign-on")
+
+	want := map[string]string{"auth": "identity/login", "login": "identity/login"}
+	if !reflect.DeepEqual(m.Specs, want) {
+		t.Errorf("Specs = %v, want %v", m.Specs, want)
+	}
+
+	tests := []struct {
+		kind Kind
+		id   string
+		want string
+		ok   bool
+	}{
+		{Spec, "auth", "identity/login", true},
+		{Spec, "login", "identity/login", true},
+		{Spec, "identity/login", "", false},
+		{Change, "add-sso", "add-single-sign-on", true},
+		{Change, "auth", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := m.Resolve(tt.kind, tt.id)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("Resolve(%s, %q) = %q, %v; want %q, %v", tt.kind, tt.id, got, ok, tt.want, tt.ok)
+		}
+	}
+
+	if got := m.Aliases(Spec, "identity/login"); !reflect.DeepEqual(got, []string{"auth", "login"}) {
+		t.Errorf("Aliases() = %v", got)
+	}
+
+	// Renaming back to an old ID drops that alias
+	m.Add(Spec, "identity/login", "auth")
+	if _, ok := m.Resolve(Spec, "auth"); ok {
+		t.Error("auth still resolves after being reused")
+	}
+	if got, _ := m.Resolve(Spec, "login"); got != "auth" {
+		t.Errorf("Resolve(login) = %q, want auth", got)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	m, err := Load(dir)
+	if err != nil || !m.Empty() {
+		t.Fatalf("Load() of a missing file = %+v, %v", m, err)
+	}
+
+	testutil.WriteFile(t, filepath.Join(dir, FileName), "specs:\n  auth: login\n")
+	m, err = Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := m.Resolve(Spec, "auth"); got != "login" {
+		t.Errorf("Resolve(auth) = %q, want login", got)
+	}
+
+	testutil.WriteFile(t, filepath.Join(dir, FileName), "specs: [\n")
+	if _, err := Load(dir); err == nil {
+		t.Error("Load() of invalid YAML succeeded")
+	}
+}
+
+func TestRename_Spec(t *testing.T) {
+	root := t.TempDir()
+	spectrDir := filepath.Join(root, "spectr")
+	testutil.WriteFile(t, filepath.Join(spectrDir, "specs", "auth", "spec.md"), "# Auth\n")
+	testutil.WriteFile(t, filepath.Join(spectrDir, "changes", "add-sso", "proposal.md"), "# Change\n")
+	testutil.WriteFile(t, filepath.Join(spectrDir, "changes", "add-sso", "specs", "auth", "spec.md"), "## ADDED Requirements\n")
+
+	result, err := Rename(context.Background(), root, Spec, "auth", "identity/auth")
+	if err != nil {
+		t.Fatalf("Rename() error = %v", err)
+	}
+	if !reflect.DeepEqual(result.Deltas, []string{"add-sso"}) {
+		t.Errorf("Deltas = %v, want [add-sso]", result.Deltas)
+	}
+
+	for _, path := range []string{
+		filepath.Join(spectrDir, "specs", "identity", "auth", "spec.md"),
+		filepath.Join(spectrDir, "changes", "add-sso", "specs", "identity", "auth", "spec.md"),
+	} {
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("%s not moved: %v", path, err)
+		}
+	}
+
+	m, err := Load(spectrDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := m.Resolve(Spec, "auth"); got != "identity/auth" {
+		t.Errorf("Resolve(auth) = %q, want identity/auth", got)
+	}
+}
+
+func TestRename_Errors(t *testing.T) {
+	root := t.TempDir()
+	spectrDir := filepath.Join(root, "spectr")
+	testutil.WriteFile(t, filepath.Join(spectrDir, "specs", "auth", "spec.md"), "# Auth\n")
+	testutil.WriteFile(t, filepath.Join(spectrDir, "specs", "login", "spec.md"), "# Login\n")
+	testutil.WriteFile(t, filepath.Join(spectrDir, "changes", "add-sso", "proposal.md"), "# Change\n")
+	testutil.WriteFile(t, filepath.Join(spectrDir, "changes", "fix-login", "proposal.md"), "# Change\n")
+
+	tests := []struct {
+		name     string
+		kind     Kind
+		from, to string
+		want     any
+	}{
+		{"spec target exists", Spec, "auth", "login", new(*specterrs.RenameTargetExistsError)},
+		{"same ID", Spec, "auth", "auth", new(*specterrs.InvalidRenameError)},
+		{"outside the project", Spec, "auth", "../auth", new(*specterrs.InvalidRenameError)},
+		{"into the archive", Spec, "auth", "archive/auth", new(*specterrs.InvalidRenameError)},
+		{"change with a slash", Change, "add-sso", "sso/add", new(*specterrs.InvalidRenameError)},
+		{"change against policy", Change, "add-sso", "Add_SSO", new(*specterrs.InvalidChangeIDError)},
+		{"change target exists", Change, "add-sso", "fix-login", new(*specterrs.ChangeExistsError)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Rename(context.Background(), root, tt.kind, tt.from, tt.to)
+			if !errors.As(err, tt.want) {
+				t.Errorf("Rename() error = %v, want %T", err, tt.want)
+			}
+		})
+	}
+
+	if _, err := os.Stat(filepath.Join(spectrDir, FileName)); !os.IsNotExist(err) {
+		t.Errorf("failed renames wrote %s: %v", FileName, err)
+	}
+}
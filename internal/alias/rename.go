@@ -0,0 +1,183 @@
+package alias
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/audit"
+	"github.com/connerohnesorge/spectr/internal/changeid"
+	"github.com/connerohnesorge/spectr/internal/discovery"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+	"github.com/connerohnesorge/spectr/internal/txn"
+)
+
+// filePerm is the permission of aliases.yaml.
+const filePerm = 0o644
+
+// Result describes a completed rename.
+type Result struct {
+	Kind Kind   `json:"kind"`
+	From string `json:"from"`
+	To   string `json:"to"`
+	// Deltas lists the active changes whose delta spec moved with a
+	// renamed spec
+	Deltas []string `json:"deltas,omitempty"`
+}
+
+// Rename renames the spec or active change from in the project at
+// projectRoot to to and records from as an alias. A renamed spec takes
+// the delta specs of active changes along. The moves, aliases.yaml and the
+// audit entry are applied as one transaction.
+func Rename(
+	ctx context.Context,
+	projectRoot string,
+	kind Kind,
+	from, to string,
+) (*Result, error) {
+	from = strings.Trim(filepath.ToSlash(from), "/")
+	to = strings.Trim(filepath.ToSlash(to), "/")
+	if err := checkIDs(kind, from, to); err != nil {
+		return nil, err
+	}
+
+	spectrDir := filepath.Join(projectRoot, "spectr")
+	aliases, err := Load(spectrDir)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := txn.New()
+	result := &Result{Kind: kind, From: from, To: to}
+	switch kind {
+	case Spec:
+		err = renameSpec(tx, projectRoot, result)
+	case Change:
+		err = renameChange(tx, projectRoot, result)
+	default:
+		err = fmt.Errorf("unknown item kind %q", kind)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	aliases.Add(kind, from, to)
+	data, err := aliases.Encode()
+	if err != nil {
+		return nil, err
+	}
+	tx.WriteFile(filepath.Join(spectrDir, FileName), data, filePerm)
+
+	audit.RecordLast(
+		tx,
+		spectrDir,
+		audit.OpRename,
+		[]string{string(kind) + "s/" + from},
+		map[string]string{"renamed_to": to},
+	)
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("apply rename: %w", err)
+	}
+
+	return result, nil
+}
+
+// checkIDs rejects renames to the same ID, into the archive or out of
+// the project.
+func checkIDs(kind Kind, from, to string) error {
+	invalid := func(reason string) error {
+		return &specterrs.InvalidRenameError{From: from, To: to, Reason: reason}
+	}
+	for _, id := range []string{from, to} {
+		clean := path.Clean(id)
+		if id == "" || clean != id || clean == ".." || strings.HasPrefix(clean, "../") {
+			return invalid(fmt.Sprintf("'%s' is not a %s ID", id, kind))
+		}
+		first, _, _ := strings.Cut(id, "/")
+		if first == discovery.SpecArchiveDir {
+			return invalid("archived items cannot be renamed")
+		}
+	}
+	if from == to {
+		return invalid("the IDs are the same")
+	}
+	if kind == Change && strings.Contains(to, "/") {
+		return invalid("change IDs cannot contain '/'")
+	}
+
+	return nil
+}
+
+// renameSpec registers moving a spec and the matching delta specs of
+// active changes.
+func renameSpec(tx *txn.Tx, projectRoot string, result *Result) error {
+	specsDir := filepath.Join(projectRoot, "spectr", "specs")
+	fromDir := filepath.Join(specsDir, filepath.FromSlash(result.From))
+	toDir := filepath.Join(specsDir, filepath.FromSlash(result.To))
+	if !exists(filepath.Join(fromDir, "spec.md")) {
+		return fmt.Errorf("spec '%s' not found", result.From)
+	}
+	if exists(toDir) {
+		return &specterrs.RenameTargetExistsError{Kind: string(Spec), ID: result.To, Path: toDir}
+	}
+	tx.Move(fromDir, toDir)
+
+	changeIDs, err := discovery.GetActiveChangeIDs(projectRoot)
+	if err != nil {
+		return err
+	}
+	for _, changeID := range changeIDs {
+		deltaSpecs := filepath.Join(projectRoot, "spectr", "changes", changeID, "specs")
+		deltaFrom := filepath.Join(deltaSpecs, filepath.FromSlash(result.From))
+		if !exists(filepath.Join(deltaFrom, "spec.md")) {
+			continue
+		}
+		deltaTo := filepath.Join(deltaSpecs, filepath.FromSlash(result.To))
+		if exists(deltaTo) {
+			return &specterrs.RenameTargetExistsError{Kind: string(Spec), ID: result.To, Path: deltaTo}
+		}
+		tx.Move(deltaFrom, deltaTo)
+		result.Deltas = append(result.Deltas, changeID)
+	}
+
+	return nil
+}
+
+// renameChange registers moving an active change. The new ID must follow
+// the change_ids policy of spectr.yaml.
+func renameChange(tx *txn.Tx, projectRoot string, result *Result) error {
+	changesDir := filepath.Join(projectRoot, "spectr", "changes")
+	fromDir := filepath.Join(changesDir, result.From)
+	if !exists(filepath.Join(fromDir, "proposal.md")) {
+		return fmt.Errorf("change '%s' not found", result.From)
+	}
+
+	policy, err := changeid.Load(projectRoot)
+	if err != nil {
+		return err
+	}
+	if err := policy.Check(result.To); err != nil {
+		return err
+	}
+	taken, err := changeid.Taken(projectRoot)
+	if err != nil {
+		return err
+	}
+	if taken(result.To) {
+		return &specterrs.ChangeExistsError{ChangeID: result.To}
+	}
+	tx.Move(fromDir, filepath.Join(changesDir, result.To))
+
+	return nil
+}
+
+// exists reports whether path exists.
+func exists(path string) bool {
+	_, err := os.Stat(path)
+
+	return err == nil
+}
@@ -16,16 +16,25 @@ package archive
 
 import (
 	"bufio"
+	"context"
 	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/connerohnesorge/spectr/internal/audit"
 	"github.com/connerohnesorge/spectr/internal/discovery"
+	"github.com/connerohnesorge/spectr/internal/events"
+	"github.com/connerohnesorge/spectr/internal/fileio"
 	"github.com/connerohnesorge/spectr/internal/parsers"
 	"github.com/connerohnesorge/spectr/internal/specterrs"
+	"github.com/connerohnesorge/spectr/internal/tui"
+	"github.com/connerohnesorge/spectr/internal/txn"
 )
 
 // Archive archives a change by validating, applying specs, and moving to archive directory
@@ -37,8 +46,14 @@ import (
 // Returns ArchiveResult containing the archive path, operation counts, and
 // updated capabilities.
 //
+// Spec writes, the move into the archive and the audit entry are applied as
+// one transaction: if any of them fails, or ctx is cancelled part-way, the
+// files already changed are restored so the tree is never left
+// half-archived.
+//
 //nolint:revive // cmd.ChangeID field needs to be reassigned when empty
 func Archive(
+	ctx context.Context,
 	cmd *ArchiveCmd,
 	workingDir string,
 ) (ArchiveResult, error) {
@@ -126,8 +141,9 @@ func Archive(
 	)
 
 	// Validation workflow
+	emitStep(stepValidate, changeID)
 	if !cmd.NoValidate {
-		err = runValidation(changeDir)
+		err = runValidation(ctx, changeDir)
 		if err != nil {
 			return ArchiveResult{}, fmt.Errorf(
 				"validation failed: %w",
@@ -144,6 +160,7 @@ func Archive(
 	}
 
 	// Task checking
+	emitStep(stepTasks, changeID)
 	err = checkTasks(cmd.Yes, changeDir)
 	if err != nil {
 		return ArchiveResult{}, fmt.Errorf(
@@ -152,11 +169,21 @@ func Archive(
 		)
 	}
 
+	// Last point at which cancellation leaves nothing to undo
+	if err := ctx.Err(); err != nil {
+		return ArchiveResult{}, err
+	}
+
+	// Every file change below is registered on tx and applied at the end
+	tx := txn.New()
+
 	// Spec update workflow - capture counts and capabilities
+	emitStep(stepSpecs, changeID)
 	var counts OperationCounts
 	var capabilities []string
 	if !cmd.SkipSpecs {
-		counts, capabilities, err = updateSpecsWithTracking(
+		counts, capabilities, err = planSpecUpdates(
+			tx,
 			cmd.Yes,
 			changeDir,
 			projectRoot,
@@ -172,7 +199,9 @@ func Archive(
 	}
 
 	// Archive operation - capture archive name
-	archiveName, err := moveToArchive(
+	emitStep(stepMove, changeID)
+	archiveName, err := planMoveToArchive(
+		tx,
 		changeDir,
 		changeID,
 		projectRoot,
@@ -184,17 +213,41 @@ func Archive(
 		)
 	}
 
-	fmt.Printf(
-		"\n✓ Successfully archived: %s\n",
-		changeID,
-	)
-
 	// Build relative archive path
 	archivePath := fmt.Sprintf(
 		"spectr/changes/archive/%s/",
 		archiveName,
 	)
 
+	audit.RecordLast(
+		tx,
+		spectrRoot,
+		audit.OpArchive,
+		archiveAuditItems(changeID, capabilities),
+		map[string]string{"archived_to": archivePath},
+	)
+
+	emitStep(stepApply, changeID)
+	if err := tx.Commit(ctx); err != nil {
+		return ArchiveResult{}, fmt.Errorf(
+			"apply archive: %w",
+			err,
+		)
+	}
+	emitStep(stepDone, changeID)
+
+	if len(capabilities) > 0 {
+		displaySummary(counts)
+	}
+	fmt.Printf(
+		"\nMoved to: changes/archive/%s\n",
+		archiveName,
+	)
+	fmt.Printf(
+		"\n✓ Successfully archived: %s\n",
+		changeID,
+	)
+
 	return ArchiveResult{
 		ArchivePath:  archivePath,
 		Counts:       counts,
@@ -202,11 +255,51 @@ func Archive(
 	}, nil
 }
 
+// Archive steps reported as progress events, in order
+const (
+	stepValidate = "validate"
+	stepTasks    = "tasks"
+	stepSpecs    = "specs"
+	stepMove     = "move"
+	stepApply    = "apply"
+	stepDone     = "done"
+)
+
+// archiveSteps lists the steps in the order they run.
+var archiveSteps = []string{stepValidate, stepTasks, stepSpecs, stepMove, stepApply, stepDone}
+
+// emitStep reports the start of an archive step. The step's position
+// gives the progress, so "done" is 100%.
+func emitStep(step, changeID string) {
+	events.Emit(
+		step,
+		changeID,
+		slices.Index(archiveSteps, step),
+		len(archiveSteps)-1,
+	)
+}
+
+// archiveAuditItems lists the items of an archive's audit entry: the
+// change and every spec its deltas were merged into.
+func archiveAuditItems(changeID string, capabilities []string) []string {
+	items := make([]string, 0, len(capabilities)+1)
+	items = append(items, "changes/"+changeID)
+	for _, capability := range capabilities {
+		items = append(items, "specs/"+capability)
+	}
+
+	return items
+}
+
 // selectChangeInteractive uses the interactive table for change selection.
 // Returns ErrUserCancelled if the user cancels the selection.
 func selectChangeInteractive(
 	projectRoot string,
 ) (string, error) {
+	if !tui.Interactive() {
+		return "", &specterrs.MissingArgumentError{Argument: "change ID"}
+	}
+
 	// Import list package functions
 	// Note: This will be done at the package level
 	lister := newListerForArchive(projectRoot)
@@ -247,10 +340,11 @@ func selectChangeInteractive(
 }
 
 // runValidation validates the change before archiving
-func runValidation(changeDir string) error {
+func runValidation(ctx context.Context, changeDir string) error {
 	fmt.Println("Validating change...")
 
 	report, err := ValidatePreArchive(
+		ctx,
 		changeDir,
 	)
 	if err != nil {
@@ -334,8 +428,10 @@ func checkTasks(
 	return nil
 }
 
-// updateSpecsWithTracking applies delta specs and tracks operation counts and capabilities
-func updateSpecsWithTracking(
+// planSpecUpdates merges delta specs and registers the resulting spec
+// writes on tx. It returns the operation counts and updated capabilities.
+func planSpecUpdates(
+	tx *txn.Tx,
 	yes bool,
 	changeDir, workingDir string,
 ) (OperationCounts, []string, error) {
@@ -381,12 +477,7 @@ func updateSpecsWithTracking(
 		return OperationCounts{}, nil, err
 	}
 
-	err = writeSpecs(mergedSpecs)
-	if err != nil {
-		return OperationCounts{}, nil, err
-	}
-
-	displaySummary(totalCounts)
+	planSpecWrites(tx, mergedSpecs)
 
 	// Extract capability names from update targets
 	capabilities := make(
@@ -505,13 +596,19 @@ func processMerges(
 	totalCounts := OperationCounts{}
 	mergedSpecs := make(map[string]string)
 
-	for _, update := range updates {
+	for i, update := range updates {
 		merged, counts, err := processOneMerge(
 			update,
 		)
 		if err != nil {
 			return totalCounts, nil, err
 		}
+		events.Emit(
+			"merge",
+			filepath.Base(filepath.Dir(update.Target)),
+			i+1,
+			len(updates),
+		)
 
 		mergedSpecs[update.Target] = merged
 		totalCounts.Added += counts.Added
@@ -589,35 +686,24 @@ func processOneMerge(
 	return merged, counts, nil
 }
 
-// writeSpecs writes all merged specs to disk
-func writeSpecs(
+// planSpecWrites registers a write for each merged spec, in path order
+func planSpecWrites(
+	tx *txn.Tx,
 	mergedSpecs map[string]string,
-) error {
-	for targetPath, content := range mergedSpecs {
-		if err := os.MkdirAll(
-			filepath.Dir(targetPath),
-			dirPerm,
-		); err != nil {
-			return fmt.Errorf(
-				"create spec directory: %w",
-				err,
-			)
-		}
+) {
+	targets := make([]string, 0, len(mergedSpecs))
+	for targetPath := range mergedSpecs {
+		targets = append(targets, targetPath)
+	}
+	sort.Strings(targets)
 
-		if err := os.WriteFile(
+	for _, targetPath := range targets {
+		tx.WriteFile(
 			targetPath,
-			[]byte(content),
+			[]byte(mergedSpecs[targetPath]),
 			filePerm,
-		); err != nil {
-			return fmt.Errorf(
-				"write spec %s: %w",
-				targetPath,
-				err,
-			)
-		}
+		)
 	}
-
-	return nil
 }
 
 // displaySummary prints operation summary to console
@@ -659,14 +745,14 @@ func findDeltaSpecs(
 ) ([]string, error) {
 	var specs []string
 
-	err := filepath.Walk(
+	err := fileio.WalkDir(
 		dir,
-		func(path string, info os.FileInfo, err error) error {
+		func(path string, entry fs.DirEntry, err error) error {
 			if err != nil {
 				return err
 			}
-			if !info.IsDir() &&
-				info.Name() == "spec.md" {
+			if !entry.IsDir() &&
+				entry.Name() == "spec.md" {
 				specs = append(specs, path)
 			}
 
@@ -677,23 +763,18 @@ func findDeltaSpecs(
 	return specs, err
 }
 
-// moveToArchive moves the change directory to archive with date prefix
-func moveToArchive(
+// planMoveToArchive registers moving the change into the dated archive
+// directory on tx and returns the archive name.
+func planMoveToArchive(
+	tx *txn.Tx,
 	changeDir, changeID, workingDir string,
 ) (string, error) {
-	// Create archive directory if it doesn't exist
 	archiveDir := filepath.Join(
 		workingDir,
 		"spectr",
 		"changes",
 		"archive",
 	)
-	if err := os.MkdirAll(archiveDir, dirPerm); err != nil {
-		return "", fmt.Errorf(
-			"create archive directory: %w",
-			err,
-		)
-	}
 
 	// Generate archive name with date
 	date := time.Now().Format("2006-01-02")
@@ -715,25 +796,20 @@ func moveToArchive(
 		)
 	}
 
-	// Move change to archive
-	if err := os.Rename(changeDir, archivePath); err != nil {
-		return "", fmt.Errorf(
-			"move to archive: %w",
-			err,
-		)
-	}
-
-	fmt.Printf(
-		"\nMoved to: changes/archive/%s\n",
-		archiveName,
-	)
+	tx.Move(changeDir, archivePath)
 
 	return archiveName, nil
 }
 
-// confirm prompts user for yes/no confirmation
+// confirm prompts user for yes/no confirmation. Without a terminal, or
+// with --no-input, the answer is no.
 func confirm(message string) bool {
 	fmt.Printf("%s [y/N]: ", message)
+	if !tui.Interactive() {
+		fmt.Println("N (no terminal for input; pass --yes to confirm)")
+
+		return false
+	}
 	reader := bufio.NewReader(os.Stdin)
 	response, err := reader.ReadString('\n')
 	if err != nil {
@@ -2,11 +2,15 @@ package archive
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"io"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/connerohnesorge/spectr/internal/specterrs"
 )
 
 const (
@@ -141,7 +145,7 @@ func TestArchive_PartialIDPrefix(t *testing.T) {
 		Yes:      true,       // Skip confirmations
 	}
 
-	_, err := Archive(cmd, tmpDir)
+	_, err := Archive(context.Background(), cmd, tmpDir)
 	if err != nil {
 		t.Fatalf("Archive failed: %v", err)
 	}
@@ -231,7 +235,7 @@ func TestArchive_PartialIDSubstring(
 		Yes:      true,
 	}
 
-	_, err := Archive(cmd, tmpDir)
+	_, err := Archive(context.Background(), cmd, tmpDir)
 	if err != nil {
 		t.Fatalf("Archive failed: %v", err)
 	}
@@ -261,6 +265,55 @@ func TestArchive_PartialIDSubstring(
 	}
 }
 
+func TestArchive_CancelledContextLeavesChange(t *testing.T) {
+	tests := []struct {
+		name       string
+		noValidate bool
+	}{
+		{"cancelled during validation", false},
+		{"cancelled before spec updates", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			setupTestProject(t, tmpDir, []string{"add-feature"})
+
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			_, err := Archive(ctx, &ArchiveCmd{
+				ChangeID:   "add-feature",
+				Yes:        true,
+				NoValidate: tt.noValidate,
+			}, tmpDir)
+			if !errors.Is(err, context.Canceled) {
+				t.Fatalf("Archive error = %v, want context.Canceled", err)
+			}
+
+			changeDir := filepath.Join(
+				tmpDir,
+				"spectr",
+				"changes",
+				"add-feature",
+			)
+			if _, err := os.Stat(changeDir); err != nil {
+				t.Errorf("change directory missing after cancel: %v", err)
+			}
+			specPath := filepath.Join(
+				tmpDir,
+				"spectr",
+				"specs",
+				"test-feature",
+				"spec.md",
+			)
+			if _, err := os.Stat(specPath); !os.IsNotExist(err) {
+				t.Errorf("spec written after cancel: %v", err)
+			}
+		})
+	}
+}
+
 func TestArchive_PartialIDAmbiguous(
 	t *testing.T,
 ) {
@@ -278,7 +331,7 @@ func TestArchive_PartialIDAmbiguous(
 		Yes:      true,
 	}
 
-	_, err := Archive(cmd, tmpDir)
+	_, err := Archive(context.Background(), cmd, tmpDir)
 
 	if err == nil {
 		t.Fatal(
@@ -312,7 +365,7 @@ func TestArchive_PartialIDNoMatch(t *testing.T) {
 		Yes:      true,
 	}
 
-	_, err := Archive(cmd, tmpDir)
+	_, err := Archive(context.Background(), cmd, tmpDir)
 
 	if err == nil {
 		t.Fatal(
@@ -355,7 +408,7 @@ func TestArchive_ExactIDNoResolutionMessage(
 		Yes:      true,
 	}
 
-	_, err := Archive(cmd, tmpDir)
+	_, err := Archive(context.Background(), cmd, tmpDir)
 	if err != nil {
 		t.Fatalf("Archive failed: %v", err)
 	}
@@ -405,7 +458,7 @@ func TestArchive_CaseInsensitiveMatch(
 		Yes:      true,
 	}
 
-	_, err := Archive(cmd, tmpDir)
+	_, err := Archive(context.Background(), cmd, tmpDir)
 	if err != nil {
 		t.Fatalf("Archive failed: %v", err)
 	}
@@ -435,3 +488,37 @@ func TestArchive_CaseInsensitiveMatch(
 		)
 	}
 }
+
+func TestArchive_FailedStepRollsBack(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupTestProject(t, tmpDir, []string{"add-feature"})
+
+	// A log that fails verification makes the final audit step fail
+	// after the spec write and the move have been applied.
+	auditPath := filepath.Join(tmpDir, "spectr", "audit.log.jsonl")
+	if err := os.WriteFile(auditPath, []byte("tampered\n"), testFilePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := Archive(context.Background(), &ArchiveCmd{
+		ChangeID: "add-feature",
+		Yes:      true,
+	}, tmpDir)
+	var txErr *specterrs.TransactionError
+	if !errors.As(err, &txErr) {
+		t.Fatalf("Archive error = %v, want TransactionError", err)
+	}
+
+	changeDir := filepath.Join(tmpDir, "spectr", "changes", "add-feature")
+	if _, err := os.Stat(changeDir); err != nil {
+		t.Errorf("change directory not restored: %v", err)
+	}
+	archiveDir := filepath.Join(tmpDir, "spectr", "changes", "archive")
+	if _, err := os.Stat(archiveDir); !os.IsNotExist(err) {
+		t.Errorf("archive directory left behind: %v", err)
+	}
+	specPath := filepath.Join(tmpDir, "spectr", "specs", "test-feature", "spec.md")
+	if _, err := os.Stat(specPath); !os.IsNotExist(err) {
+		t.Errorf("spec left behind after rollback: %v", err)
+	}
+}
@@ -2,25 +2,34 @@
 // for archiving completed changes.
 package archive
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+
+	"github.com/connerohnesorge/spectr/internal/utils"
+)
 
 // ArchiveCmd represents the archive command configuration
 type ArchiveCmd struct {
-	ChangeID   string `arg:"" optional:"" predictor:"changeID"`
-	Yes        bool   `                                        name:"yes"         short:"y" help:"Skip confirmation"` //nolint:lll,revive // Kong struct tag with alignment
-	SkipSpecs  bool   `                                        name:"skip-specs"            help:"Skip spec updates"` //nolint:lll,revive // Kong struct tag with alignment
-	NoValidate bool   `                                        name:"no-validate"           help:"Skip validation"`   //nolint:lll,revive // Kong struct tag with alignment
+	ChangeID   string        `arg:"" optional:"" predictor:"changeID"`
+	Yes        bool          `                                        name:"yes"         short:"y" help:"Skip confirmation"`              //nolint:lll,revive // Kong struct tag with alignment
+	SkipSpecs  bool          `                                        name:"skip-specs"            help:"Skip spec updates"`              //nolint:lll,revive // Kong struct tag with alignment
+	NoValidate bool          `                                        name:"no-validate"           help:"Skip validation"`                //nolint:lll,revive // Kong struct tag with alignment
+	Timeout    time.Duration `                                        name:"timeout"               help:"Abort after duration (e.g. 2m)"` //nolint:lll,revive // Kong struct tag with alignment
 }
 
 // Run executes the archive command
 func (c *ArchiveCmd) Run() error {
+	ctx, cancel := utils.CommandContext(c.Timeout)
+	defer cancel()
+
 	// Pass empty string to use current working directory
 	// Result is discarded for CLI usage - already prints to terminal
-	_, err := Archive(c, "")
+	_, err := Archive(ctx, c, "")
 	if err != nil {
 		return fmt.Errorf(
 			"archive failed: %w",
-			err,
+			utils.CommandError(ctx, "archive", c.Timeout, err),
 		)
 	}
 
@@ -4,6 +4,5 @@ package archive
 
 const (
 	// File permission constants
-	dirPerm  = 0o755
 	filePerm = 0o644
 )
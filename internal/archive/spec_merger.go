@@ -3,9 +3,9 @@ package archive
 
 import (
 	"fmt"
-	"os"
 	"strings"
 
+	"github.com/connerohnesorge/spectr/internal/fileio"
 	"github.com/connerohnesorge/spectr/internal/markdown"
 	"github.com/connerohnesorge/spectr/internal/parsers"
 )
@@ -63,7 +63,7 @@ func MergeSpec(
 	}
 
 	// Load existing spec
-	baseContent, err := os.ReadFile(baseSpecPath)
+	baseContent, err := fileio.ReadFile(baseSpecPath)
 	if err != nil {
 		return "", counts, fmt.Errorf(
 			"read base spec: %w",
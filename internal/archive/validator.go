@@ -2,9 +2,9 @@
 package archive
 
 import (
+	"context"
 	"fmt"
 	"os"
-	"path/filepath"
 
 	"github.com/connerohnesorge/spectr/internal/parsers"
 	"github.com/connerohnesorge/spectr/internal/specterrs"
@@ -16,19 +16,13 @@ import (
 // Returns validation report and error (error is for filesystem issues,
 // not validation failures)
 func ValidatePreArchive(
+	ctx context.Context,
 	changeDir string,
 ) (*validation.ValidationReport, error) {
-	// Derive spectrRoot from changeDir
-	// changeDir format: /path/to/project/spectr/changes/<change-id>
-	// spectrRoot should be: /path/to/project/spectr
-	spectrRoot := filepath.Dir(
-		filepath.Dir(changeDir),
-	)
-
 	// Use existing change validation from validation package
-	report, err := validation.ValidateChangeDeltaSpecs(
+	report, err := validation.NewValidator().ValidateChangeContext(
+		ctx,
 		changeDir,
-		spectrRoot,
 	)
 	if err != nil {
 		return nil, fmt.Errorf(
@@ -0,0 +1,345 @@
+package attest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/connerohnesorge/spectr/internal/audit"
+	"github.com/connerohnesorge/spectr/internal/contract"
+	"github.com/connerohnesorge/spectr/internal/parsers"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+	"github.com/connerohnesorge/spectr/internal/txn"
+)
+
+// Signing tools.
+const (
+	ToolMinisign = "minisign"
+	ToolSSH      = "ssh"
+)
+
+// File layout constants
+const (
+	// DirName is the attestations directory inside spectr/.
+	DirName  = "attestations"
+	fileExt  = ".json"
+	filePerm = 0o644
+)
+
+// manifestVersion is the current manifest format version.
+const manifestVersion = 1
+
+// sshNamespace scopes SSH signatures to spectr attestations, so a
+// signature made for a git commit or another tool does not verify here.
+const sshNamespace = "spectr-attest"
+
+// Manifest is the signed record of a spec's requirement text.
+type Manifest struct {
+	Version int    `json:"version"`
+	Spec    string `json:"spec"`
+	Signer  string `json:"signer"`
+	Signed  string `json:"signed"` // RFC 3339 timestamp
+	// Tool is the signing tool, ToolMinisign or ToolSSH
+	Tool         string         `json:"tool"`
+	Requirements []contract.Pin `json:"requirements"`
+}
+
+// Options configure signing.
+type Options struct {
+	// Tool is ToolMinisign or ToolSSH; empty means ToolMinisign.
+	Tool string
+	// Key is the secret key file; minisign falls back to its default key.
+	Key string
+	// Signer is the identity recorded in the manifest; empty means the
+	// git user. SSH verification looks it up in the allowed signers file.
+	Signer string
+}
+
+// Verification is the result of verifying an attestation.
+type Verification struct {
+	Spec   string `json:"spec"`
+	Signer string `json:"signer"`
+	Signed string `json:"signed"`
+	Tool   string `json:"tool"`
+	// Drift lists attested requirements that changed or were removed
+	Drift []contract.Drift `json:"drift,omitempty"`
+	// Unattested lists requirements added since the attestation
+	Unattested []string `json:"unattested,omitempty"`
+}
+
+// Path returns the manifest of specID in spectrDir.
+func Path(spectrDir, specID string) string {
+	return filepath.Join(spectrDir, DirName, filepath.FromSlash(specID)+fileExt)
+}
+
+// SignaturePath returns the signature file of a manifest signed by tool.
+func SignaturePath(manifestPath, tool string) string {
+	if tool == ToolSSH {
+		return manifestPath + ".sig"
+	}
+
+	return manifestPath + ".minisig"
+}
+
+// Load reads a manifest.
+func Load(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return parse(path, data)
+}
+
+// parse decodes manifest data read from path.
+func parse(path string, data []byte) (*Manifest, error) {
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if m.Version > manifestVersion {
+		return nil, &specterrs.AttestationVersionError{Path: path, Version: m.Version}
+	}
+
+	return &m, nil
+}
+
+// Attest writes and signs the manifest of specID in the project at
+// projectRoot, replacing an earlier attestation, then records it in the
+// audit log. Nothing is written when signing fails.
+func Attest(
+	ctx context.Context,
+	projectRoot, specID string,
+	opts Options,
+	now time.Time,
+) (*Manifest, error) {
+	if opts.Tool == "" {
+		opts.Tool = ToolMinisign
+	}
+	if opts.Tool == ToolSSH && opts.Key == "" {
+		return nil, &specterrs.SigningKeyRequiredError{Tool: opts.Tool}
+	}
+	if opts.Signer == "" {
+		opts.Signer = audit.Actor(projectRoot)
+	}
+
+	specID = strings.Trim(filepath.ToSlash(specID), "/")
+	spectrDir := filepath.Join(projectRoot, "spectr")
+	frozen, err := contract.Freeze(filepath.Join(spectrDir, "specs"), specID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Manifest{
+		Version:      manifestVersion,
+		Spec:         specID,
+		Signer:       opts.Signer,
+		Signed:       now.UTC().Format(time.RFC3339),
+		Tool:         opts.Tool,
+		Requirements: frozen.Requirements,
+	}
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+	data = append(data, '\n')
+
+	sig, err := sign(ctx, data, opts, fmt.Sprintf("spectr attest %s by %s", specID, opts.Signer))
+	if err != nil {
+		return nil, err
+	}
+
+	path := Path(spectrDir, specID)
+	tx := txn.New()
+	tx.WriteFile(path, data, filePerm)
+	tx.WriteFile(SignaturePath(path, opts.Tool), sig, filePerm)
+
+	audit.RecordLast(
+		tx,
+		spectrDir,
+		audit.OpAttest,
+		[]string{"specs/" + specID},
+		map[string]string{"signer": opts.Signer, "tool": opts.Tool},
+	)
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("attest %s: %w", specID, err)
+	}
+
+	return m, nil
+}
+
+// sign signs data in a temporary directory and returns the signature.
+// The tool may prompt for the key's password on the terminal.
+func sign(ctx context.Context, data []byte, opts Options, comment string) ([]byte, error) {
+	dir, err := os.MkdirTemp("", "spectr-attest-")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	manifest := filepath.Join(dir, "manifest"+fileExt)
+	if err := os.WriteFile(manifest, data, filePerm); err != nil {
+		return nil, err
+	}
+	sigPath := SignaturePath(manifest, opts.Tool)
+
+	var name string
+	var args []string
+	switch opts.Tool {
+	case ToolSSH:
+		name = "ssh-keygen"
+		args = []string{"-Y", "sign", "-q", "-f", opts.Key, "-n", sshNamespace, manifest}
+	default:
+		name = "minisign"
+		args = []string{"-S", "-m", manifest, "-x", sigPath, "-t", comment}
+		if opts.Key != "" {
+			args = append(args, "-s", opts.Key)
+		}
+	}
+	if err := run(ctx, nil, name, args...); err != nil {
+		return nil, err
+	}
+
+	return os.ReadFile(sigPath)
+}
+
+// Verify checks the signature of specID's attestation in the project at
+// projectRoot against key, then compares the attested requirements with
+// the spec. Key is a minisign public key (a file or the key itself) or,
+// for SSH signatures, an allowed signers file. A signature that does not
+// verify is an error; requirement drift is reported in the result.
+func Verify(
+	ctx context.Context,
+	projectRoot, specID, key string,
+) (*Verification, error) {
+	specID = strings.Trim(filepath.ToSlash(specID), "/")
+	spectrDir := filepath.Join(projectRoot, "spectr")
+	path := Path(spectrDir, specID)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, &specterrs.AttestationNotFoundError{Spec: specID}
+	}
+	if err != nil {
+		return nil, err
+	}
+	m, err := parse(path, data)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := verifySignature(ctx, path, data, m, key); err != nil {
+		return nil, err
+	}
+	if m.Spec != specID {
+		// A validly signed manifest copied over from another spec
+		return nil, &specterrs.SignatureInvalidError{
+			Path:   path,
+			Reason: fmt.Sprintf("manifest attests spec '%s'", m.Spec),
+		}
+	}
+
+	specsDir := filepath.Join(spectrDir, "specs")
+	drift, err := contract.Check(&contract.Contract{
+		Spec:         m.Spec,
+		Requirements: m.Requirements,
+	}, specsDir)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &Verification{
+		Spec:   m.Spec,
+		Signer: m.Signer,
+		Signed: m.Signed,
+		Tool:   m.Tool,
+		Drift:  drift,
+	}
+	attested := make(map[string]bool, len(m.Requirements))
+	for _, pin := range m.Requirements {
+		attested[parsers.NormalizeRequirementName(pin.Requirement)] = true
+	}
+	reqs, err := parsers.ParseRequirements(filepath.Join(specsDir, filepath.FromSlash(specID), "spec.md"))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	for _, req := range reqs {
+		if !attested[parsers.NormalizeRequirementName(req.Name)] {
+			res.Unattested = append(res.Unattested, req.Name)
+		}
+	}
+
+	return res, nil
+}
+
+// verifySignature checks the signature of the manifest data at path with
+// the tool the manifest names.
+func verifySignature(
+	ctx context.Context,
+	path string,
+	data []byte,
+	m *Manifest,
+	key string,
+) error {
+	sigPath := SignaturePath(path, m.Tool)
+	if _, err := os.Stat(sigPath); err != nil {
+		return &specterrs.SignatureInvalidError{Path: path, Reason: "no signature file " + sigPath}
+	}
+
+	var err error
+	switch m.Tool {
+	case ToolSSH:
+		err = run(ctx, bytes.NewReader(data), "ssh-keygen",
+			"-Y", "verify", "-f", key, "-I", m.Signer, "-n", sshNamespace, "-s", sigPath)
+	case ToolMinisign:
+		keyFlag := "-P"
+		if _, statErr := os.Stat(key); statErr == nil {
+			keyFlag = "-p"
+		}
+		err = run(ctx, bytes.NewReader(nil), "minisign", "-V", "-q", "-m", path, "-x", sigPath, keyFlag, key)
+	default:
+		return &specterrs.SignatureInvalidError{Path: path, Reason: "unknown signing tool " + m.Tool}
+	}
+
+	var notFound *specterrs.SigningToolNotFoundError
+	if err != nil && !errors.As(err, &notFound) {
+		return &specterrs.SignatureInvalidError{Path: path, Reason: err.Error()}
+	}
+
+	return err
+}
+
+// run runs a signing tool, with stdin as its input and its output
+// discarded. A nil stdin runs it on the terminal instead, so it can prompt
+// for a key password. A failure includes the tool's stderr.
+func run(ctx context.Context, stdin io.Reader, name string, args ...string) error {
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = stdin, io.Discard, &stderr
+	if stdin == nil {
+		cmd.Stdin, cmd.Stdout = os.Stdin, os.Stderr
+		cmd.Stderr = io.MultiWriter(os.Stderr, &stderr)
+	}
+
+	err := cmd.Run()
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, exec.ErrNotFound):
+		return &specterrs.SigningToolNotFoundError{Tool: name}
+	}
+	if msg := strings.TrimSpace(stderr.String()); msg != "" {
+		return fmt.Errorf("%s: %w: %s", name, err, msg)
+	}
+
+	return fmt.Errorf("%s: %w", name, err)
+}
@@ -0,0 +1,143 @@
+package attest
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/connerohnesorge/spectr/internal/audit"
+	"github.com/connerohnesorge/spectr/internal/contract"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+	"github.com/connerohnesorge/spectr/internal/testutil"
+)
+
+const authSpec = `# Auth
+
+## Requirements
+
+### Requirement: Login
+The system SHALL authenticate users.
+
+#### Scenario: Valid password
+- **WHEN** a user signs in
+- **THEN** a session starts
+`
+
+const signer = "ada@acme.example"
+
+// sshKey generates an SSH key pair and an allowed signers file trusting
+// it for signer. It returns the secret key and allowed signers paths.
+func sshKey(t *testing.T, dir string) (string, string) {
+	t.Helper()
+
+	if _, err := exec.LookPath("ssh-keygen"); err != nil {
+		t.Skip("ssh-keygen not installed")
+	}
+	key := filepath.Join(dir, "id_ed25519")
+	out, err := exec.Command("ssh-keygen", "-q", "-t", "ed25519", "-N", "", "-f", key).CombinedOutput()
+	if err != nil {
+		t.Fatalf("ssh-keygen: %v: %s", err, out)
+	}
+	pub, err := os.ReadFile(key + ".pub")
+	if err != nil {
+		t.Fatal(err)
+	}
+	allowed := filepath.Join(dir, "allowed_signers")
+	testutil.WriteFile(t, allowed, signer+" "+string(pub))
+
+	return key, allowed
+}
+
+func TestAttestAndVerify(t *testing.T) {
+	root := t.TempDir()
+	specPath := filepath.Join(root, "spectr", "specs", "auth", "spec.md")
+	testutil.WriteFile(t, specPath, authSpec)
+	key, allowed := sshKey(t, t.TempDir())
+	ctx := context.Background()
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	m, err := Attest(ctx, root, "auth", Options{Tool: ToolSSH, Key: key, Signer: signer}, now)
+	if err != nil {
+		t.Fatalf("Attest() error = %v", err)
+	}
+	if len(m.Requirements) != 1 || m.Requirements[0].Hash != contract.Hash(
+		strings.TrimPrefix(authSpec, "# Auth\n\n## Requirements\n\n")) {
+		t.Errorf("Attest() requirements = %+v", m.Requirements)
+	}
+	if m.Signed != "2026-10-16T12:00:00Z" || m.Signer != signer {
+		t.Errorf("Attest() = %+v", m)
+	}
+
+	res, err := Verify(ctx, root, "auth", allowed)
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if len(res.Drift) != 0 || len(res.Unattested) != 0 {
+		t.Errorf("Verify() = %+v, want no drift", res)
+	}
+
+	// Changed and added requirements are drift, not signature failures
+	testutil.WriteFile(t, specPath, strings.Replace(authSpec, "authenticate", "log in", 1)+
+		"\n### Requirement: Logout\nThe system SHALL end sessions.\n")
+	res, err = Verify(ctx, root, "auth", allowed)
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if len(res.Drift) != 1 || res.Drift[0].Kind != contract.Changed ||
+		len(res.Unattested) != 1 || res.Unattested[0] != "Logout" {
+		t.Errorf("Verify() = %+v, want Login changed and Logout unattested", res)
+	}
+
+	entries, err := audit.Read(filepath.Join(root, "spectr", audit.LogFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Operation != audit.OpAttest {
+		t.Errorf("audit entries = %+v, want one attest entry", entries)
+	}
+}
+
+func TestVerify_Tampered(t *testing.T) {
+	root := t.TempDir()
+	testutil.WriteFile(t, filepath.Join(root, "spectr", "specs", "auth", "spec.md"), authSpec)
+	key, allowed := sshKey(t, t.TempDir())
+	ctx := context.Background()
+
+	if _, err := Attest(ctx, root, "auth", Options{Tool: ToolSSH, Key: key, Signer: signer}, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	path := Path(filepath.Join(root, "spectr"), "auth")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutil.WriteFile(t, path, strings.Replace(string(data), "authenticate", "log in", 1))
+
+	_, err = Verify(ctx, root, "auth", allowed)
+	var target *specterrs.SignatureInvalidError
+	if !errors.As(err, &target) {
+		t.Errorf("Verify() of a tampered manifest error = %v, want SignatureInvalidError", err)
+	}
+}
+
+func TestVerify_Errors(t *testing.T) {
+	root := t.TempDir()
+	testutil.WriteFile(t, filepath.Join(root, "spectr", "specs", "auth", "spec.md"), authSpec)
+
+	_, err := Verify(context.Background(), root, "auth", "key.pub")
+	var notFound *specterrs.AttestationNotFoundError
+	if !errors.As(err, &notFound) {
+		t.Errorf("Verify() error = %v, want AttestationNotFoundError", err)
+	}
+
+	_, err = Attest(context.Background(), root, "auth", Options{Tool: ToolSSH}, time.Now())
+	var keyRequired *specterrs.SigningKeyRequiredError
+	if !errors.As(err, &keyRequired) {
+		t.Errorf("Attest() error = %v, want SigningKeyRequiredError", err)
+	}
+}
@@ -0,0 +1,16 @@
+// Package attest produces signed attestations of spec text, so audits in
+// regulated environments can prove which requirement text was approved.
+//
+// An attestation is a JSON manifest of a spec's requirements, each with
+// its contract.Hash and full text, plus the signer and a timestamp. It is
+// written to spectr/attestations/<spec>.json and signed with an external
+// tool:
+//
+//   - minisign (the default) writes <spec>.json.minisig, using the key
+//     given with --key or minisign's own default secret key.
+//   - ssh signs with an SSH key through ssh-keygen -Y sign, the same
+//     mechanism git uses for SSH-signed commits, and writes <spec>.json.sig.
+//
+// Verification checks the signature with the tool the manifest names, then
+// compares the attested hashes with the spec as it is now.
+package attest
@@ -0,0 +1,10 @@
+// Package audit records mutating spectr operations in an append-only,
+// hash-chained log at spectr/audit.log.jsonl.
+//
+// Each line is one JSON Entry. An entry's hash covers its own fields and
+// the previous entry's hash, so editing, deleting or reordering any entry
+// breaks verification of every entry after it. Truncating the newest
+// entries cannot be detected from the file alone; reviewers who need that
+// guarantee should note the head hash printed by spectr audit show (or
+// rely on the log being committed to git).
+package audit
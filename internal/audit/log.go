@@ -0,0 +1,246 @@
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+// LogFileName is the audit log's file name inside the spectr/ directory.
+const LogFileName = "audit.log.jsonl"
+
+// logPerm is the permission mode for a newly created audit log.
+const logPerm = 0o644
+
+// Entry is one recorded operation.
+type Entry struct {
+	// Seq numbers entries from 1 in the order they were written.
+	Seq int `json:"seq"`
+	// Time is when the operation completed, in UTC.
+	Time time.Time `json:"time"`
+	// Actor identifies who ran the operation, normally the git user.
+	Actor string `json:"actor"`
+	// Operation names what was done, e.g. "accept".
+	Operation string `json:"operation"`
+	// Items are the affected paths relative to spectr/, e.g.
+	// "changes/add-2fa" or "specs/auth".
+	Items []string `json:"items"`
+	// Details holds operation-specific values such as a task's new status.
+	Details map[string]string `json:"details,omitempty"`
+	// PrevHash is the previous entry's Hash, empty for the first entry.
+	PrevHash string `json:"prev_hash,omitempty"`
+	// Hash is the hex SHA-256 of the entry encoded without this field.
+	Hash string `json:"hash"`
+}
+
+// computeHash returns the hash the entry should carry.
+func (e Entry) computeHash() (string, error) {
+	e.Hash = ""
+	data, err := json.Marshal(e)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Read loads and verifies the audit log at path. A missing log has no
+// entries. A log whose chain does not verify is reported as
+// *specterrs.AuditChainError.
+func Read(path string) ([]Entry, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read audit log: %w", err)
+	}
+
+	return parseLog(path, data)
+}
+
+// parseLog decodes and verifies the audit log data read from path.
+func parseLog(path string, data []byte) ([]Entry, error) {
+	var entries []Entry
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, len(data)+1)
+	prevHash := ""
+	for line := 1; scanner.Scan(); line++ {
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, &specterrs.AuditChainError{
+				Path:   path,
+				Line:   line,
+				Reason: "not a JSON entry",
+			}
+		}
+		if reason := verifyEntry(entry, line, prevHash); reason != "" {
+			return nil, &specterrs.AuditChainError{
+				Path:   path,
+				Line:   line,
+				Reason: reason,
+			}
+		}
+		entries = append(entries, entry)
+		prevHash = entry.Hash
+	}
+
+	return entries, scanner.Err()
+}
+
+// verifyEntry checks entry against its position in the chain and returns
+// why it does not fit, or "" when it does.
+func verifyEntry(entry Entry, seq int, prevHash string) string {
+	if entry.Seq != seq {
+		return fmt.Sprintf("sequence %d, expected %d", entry.Seq, seq)
+	}
+	if entry.PrevHash != prevHash {
+		return "prev_hash does not match the previous entry"
+	}
+	hash, err := entry.computeHash()
+	if err != nil || hash != entry.Hash {
+		return "hash does not match the entry's contents"
+	}
+
+	return ""
+}
+
+// Append chains entry onto the log at path and writes it, creating the
+// log if needed. Seq, PrevHash and Hash are filled in; the written entry
+// is returned. Append refuses to extend a log that does not verify.
+func Append(path string, entry Entry) (Entry, error) {
+	entries, err := Read(path)
+	if err != nil {
+		return Entry{}, err
+	}
+
+	entry, err = chain(entries, entry)
+	if err != nil {
+		return Entry{}, err
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return Entry{}, fmt.Errorf("failed to encode audit entry: %w", err)
+	}
+
+	file, err := os.OpenFile(
+		path,
+		os.O_WRONLY|os.O_APPEND|os.O_CREATE,
+		logPerm,
+	)
+	if err != nil {
+		return Entry{}, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		_ = file.Close()
+
+		return Entry{}, fmt.Errorf("failed to write audit log: %w", err)
+	}
+
+	return entry, file.Close()
+}
+
+// chain fills in the Seq, PrevHash and Hash that put entry after entries.
+func chain(entries []Entry, entry Entry) (Entry, error) {
+	entry.Seq = len(entries) + 1
+	entry.PrevHash = ""
+	if len(entries) > 0 {
+		entry.PrevHash = entries[len(entries)-1].Hash
+	}
+	if entry.Items == nil {
+		entry.Items = []string{}
+	}
+	hash, err := entry.computeHash()
+	if err != nil {
+		return Entry{}, fmt.Errorf("failed to hash audit entry: %w", err)
+	}
+	entry.Hash = hash
+
+	return entry, nil
+}
+
+// contentKey identifies an entry by its contents, without the chain
+// fields that change when it is rechained.
+func (e Entry) contentKey() (string, error) {
+	e.Seq, e.PrevHash, e.Hash = 0, "", ""
+	data, err := json.Marshal(e)
+
+	return string(data), err
+}
+
+// Merge joins two versions of an audit log that branched from a common
+// history, for the spectr merge-audit git merge driver. The entries of
+// ours are kept as they are; the entries only theirs has are chained after
+// them in their order, with new Seq, PrevHash and Hash values, so the
+// merged log verifies and Append can extend it. Entries are matched by
+// their contents, so entries rechained by an earlier merge are not
+// duplicated. Both versions must verify.
+func Merge(ours, theirs []byte) ([]byte, error) {
+	oursEntries, err := parseLog("ours", ours)
+	if err != nil {
+		return nil, err
+	}
+	theirsEntries, err := parseLog("theirs", theirs)
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[string]bool, len(oursEntries))
+	for _, entry := range oursEntries {
+		key, err := entry.contentKey()
+		if err != nil {
+			return nil, err
+		}
+		known[key] = true
+	}
+
+	merged := append([]byte(nil), ours...)
+	if len(merged) > 0 && merged[len(merged)-1] != '\n' {
+		merged = append(merged, '\n')
+	}
+	for _, entry := range theirsEntries {
+		key, err := entry.contentKey()
+		if err != nil {
+			return nil, err
+		}
+		if known[key] {
+			continue
+		}
+		known[key] = true
+
+		entry, err = chain(oursEntries, entry)
+		if err != nil {
+			return nil, err
+		}
+		oursEntries = append(oursEntries, entry)
+		line, err := json.Marshal(entry)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode audit entry: %w", err)
+		}
+		merged = append(merged, line...)
+		merged = append(merged, '\n')
+	}
+
+	return merged, nil
+}
+
+// Since returns the entries recorded at or after t.
+func Since(entries []Entry, t time.Time) []Entry {
+	var result []Entry
+	for _, entry := range entries {
+		if !entry.Time.Before(t) {
+			result = append(result, entry)
+		}
+	}
+
+	return result
+}
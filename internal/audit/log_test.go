@@ -0,0 +1,144 @@
+package audit
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+func writeTestLog(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), LogFileName)
+	base := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	ops := []string{OpAccept, OpTaskStatus, OpArchive}
+	for i, op := range ops {
+		_, err := Append(path, Entry{
+			Time:      base.Add(time.Duration(i) * time.Hour),
+			Actor:     "Ada <ada@example.com>",
+			Operation: op,
+			Items:     []string{"changes/add-2fa"},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return path
+}
+
+func TestAppendChainsEntries(t *testing.T) {
+	path := writeTestLog(t)
+
+	entries, err := Read(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("got %d entries, want 3", len(entries))
+	}
+	if entries[0].PrevHash != "" {
+		t.Errorf("first prev_hash = %q, want empty", entries[0].PrevHash)
+	}
+	for i := 1; i < len(entries); i++ {
+		if entries[i].Seq != i+1 {
+			t.Errorf("entry %d seq = %d", i, entries[i].Seq)
+		}
+		if entries[i].PrevHash != entries[i-1].Hash {
+			t.Errorf("entry %d is not chained to entry %d", i+1, i)
+		}
+	}
+}
+
+func TestReadMissingLog(t *testing.T) {
+	entries, err := Read(filepath.Join(t.TempDir(), LogFileName))
+	if err != nil || entries != nil {
+		t.Fatalf("Read() = %v, %v; want nil, nil", entries, err)
+	}
+}
+
+func TestReadDetectsTampering(t *testing.T) {
+	tests := []struct {
+		name     string
+		tamper   func(lines []string) []string
+		wantLine int
+	}{
+		{
+			name: "edited actor",
+			tamper: func(lines []string) []string {
+				lines[1] = strings.Replace(lines[1], "Ada", "Eve", 1)
+
+				return lines
+			},
+			wantLine: 2,
+		},
+		{
+			name: "deleted entry",
+			tamper: func(lines []string) []string {
+				return append(lines[:1], lines[2:]...)
+			},
+			wantLine: 2,
+		},
+		{
+			name: "reordered entries",
+			tamper: func(lines []string) []string {
+				lines[1], lines[2] = lines[2], lines[1]
+
+				return lines
+			},
+			wantLine: 2,
+		},
+		{
+			name: "garbage line",
+			tamper: func(lines []string) []string {
+				return append(lines, "not json")
+			},
+			wantLine: 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeTestLog(t)
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+			lines = tt.tamper(lines)
+			content := strings.Join(lines, "\n") + "\n"
+			if err := os.WriteFile(path, []byte(content), logPerm); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = Read(path)
+			var chainErr *specterrs.AuditChainError
+			if !errors.As(err, &chainErr) {
+				t.Fatalf("Read() error = %v, want AuditChainError", err)
+			}
+			if chainErr.Line != tt.wantLine {
+				t.Errorf("line = %d, want %d", chainErr.Line, tt.wantLine)
+			}
+
+			if _, err := Append(path, Entry{Operation: OpAccept}); err == nil {
+				t.Error("Append() extended a broken chain")
+			}
+		})
+	}
+}
+
+func TestSince(t *testing.T) {
+	entries, err := Read(writeTestLog(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := Since(entries, time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC))
+	if len(got) != 2 || got[0].Seq != 2 {
+		t.Errorf("Since() = %+v, want entries 2 and 3", got)
+	}
+}
@@ -0,0 +1,95 @@
+package audit
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/connerohnesorge/spectr/internal/git"
+	"github.com/connerohnesorge/spectr/internal/txn"
+)
+
+// Operation names recorded in the audit log.
+const (
+	OpAccept      = "accept"
+	OpArchive     = "archive"
+	OpAttest      = "attest"
+	OpEvidence    = "evidence"
+	OpRename      = "rename"
+	OpRetire      = "retire"
+	OpReview      = "review"
+	OpSplit       = "split"
+	OpTaskStatus  = "task-status"
+	OpTasksImport = "tasks-import"
+)
+
+// unknownActor is recorded when neither git nor the environment names
+// the user.
+const unknownActor = "unknown"
+
+// Record appends an entry for op to spectrDir's audit log, stamped with
+// the current time and the git user of the enclosing project.
+func Record(
+	spectrDir, op string,
+	items []string,
+	details map[string]string,
+) error {
+	_, err := Append(filepath.Join(spectrDir, LogFileName), Entry{
+		Time:      time.Now().UTC().Truncate(time.Second),
+		Actor:     Actor(filepath.Dir(spectrDir)),
+		Operation: op,
+		Items:     items,
+		Details:   details,
+	})
+
+	return err
+}
+
+// RecordLast registers recording an entry for op as the next step of tx.
+// It must be the last step: an appended entry cannot be undone, so it may
+// only run once every other step has succeeded.
+func RecordLast(
+	tx *txn.Tx,
+	spectrDir, op string,
+	items []string,
+	details map[string]string,
+) {
+	tx.Do("record audit entry", func() error {
+		return Record(spectrDir, op, items, details)
+	}, nil)
+}
+
+// Actor returns "Name <email>" from the git config seen in dir, falling
+// back to $USER when git has no identity.
+func Actor(dir string) string {
+	name := gitConfig(dir, "user.name")
+	email := gitConfig(dir, "user.email")
+
+	switch {
+	case name != "" && email != "":
+		return name + " <" + email + ">"
+	case name != "":
+		return name
+	case email != "":
+		return "<" + email + ">"
+	}
+
+	if user := os.Getenv("USER"); user != "" {
+		return user
+	}
+
+	return unknownActor
+}
+
+// gitConfig reads a git config value, returning "" when it is unset or
+// git is unavailable.
+func gitConfig(dir, key string) string {
+	out, err := git.Run(context.Background(), dir, "config", "--get", key)
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimSpace(string(out))
+}
@@ -0,0 +1,50 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/connerohnesorge/spectr/internal/txn"
+)
+
+func TestRecordLast(t *testing.T) {
+	tests := []struct {
+		name        string
+		failBefore  bool
+		wantEntries int
+	}{
+		{name: "recorded after the other steps", wantEntries: 1},
+		{name: "skipped when an earlier step fails", failBefore: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spectrDir := filepath.Join(t.TempDir(), "spectr")
+
+			tx := txn.New()
+			tx.WriteFile(filepath.Join(spectrDir, "specs", "auth", "spec.md"), []byte("# Auth\n"), 0o644)
+			if tt.failBefore {
+				tx.Do("fail", func() error { return errors.New("boom") }, nil)
+			}
+			RecordLast(tx, spectrDir, OpReview, []string{"specs/auth"}, map[string]string{"k": "v"})
+
+			err := tx.Commit(context.Background())
+			if tt.failBefore != (err != nil) {
+				t.Fatalf("Commit() error = %v", err)
+			}
+
+			entries, err := Read(filepath.Join(spectrDir, LogFileName))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(entries) != tt.wantEntries {
+				t.Fatalf("got %d entries, want %d", len(entries), tt.wantEntries)
+			}
+			if tt.wantEntries > 0 && entries[0].Operation != OpReview {
+				t.Errorf("Operation = %q, want %q", entries[0].Operation, OpReview)
+			}
+		})
+	}
+}
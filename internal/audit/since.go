@@ -0,0 +1,36 @@
+package audit
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+// hoursPerDay converts the "d" suffix accepted by ParseSince.
+const hoursPerDay = 24
+
+// ParseSince parses a --since value relative to now. It accepts a date
+// (2006-01-02, local time), an RFC 3339 timestamp, a Go duration such as
+// 36h, or a whole number of days such as 7d.
+func ParseSince(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+
+	if t, err := time.ParseInLocation(time.DateOnly, value, time.Local); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n >= 0 {
+			return now.Add(-time.Duration(n) * hoursPerDay * time.Hour), nil
+		}
+	}
+	if d, err := time.ParseDuration(value); err == nil && d >= 0 {
+		return now.Add(-d), nil
+	}
+
+	return time.Time{}, &specterrs.InvalidSinceError{Value: value}
+}
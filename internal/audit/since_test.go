@@ -0,0 +1,46 @@
+package audit
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{value: "7d", want: now.Add(-7 * 24 * time.Hour)},
+		{value: "36h", want: now.Add(-36 * time.Hour)},
+		{value: "2025-03-01", want: time.Date(2025, 3, 1, 0, 0, 0, 0, time.Local)},
+		{value: "2025-03-01T08:30:00Z", want: time.Date(2025, 3, 1, 8, 30, 0, 0, time.UTC)},
+		{value: "last week", wantErr: true},
+		{value: "-3d", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseSince(tt.value, now)
+			if tt.wantErr {
+				var sinceErr *specterrs.InvalidSinceError
+				if !errors.As(err, &sinceErr) {
+					t.Fatalf("ParseSince() error = %v, want InvalidSinceError", err)
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("ParseSince() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,330 @@
+// Package backstage generates Backstage catalog entities from specs, so a
+// platform catalog lists each spec with its owner and status.
+//
+// Every spec becomes one entity: a Component unless its backstage
+// frontmatter names API or Resource. The owner comes from the owners list
+// in spectr.yaml, the description from the Purpose section, and each
+// requirement becomes a link to its heading in spec.md. Review state and
+// the requirement count are recorded as spectr.dev annotations.
+package backstage
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/connerohnesorge/spectr/internal/config"
+	"github.com/connerohnesorge/spectr/internal/discovery"
+	"github.com/connerohnesorge/spectr/internal/domain"
+	"github.com/connerohnesorge/spectr/internal/fileio"
+	"github.com/connerohnesorge/spectr/internal/markdown"
+	"github.com/connerohnesorge/spectr/internal/parsers"
+	"github.com/connerohnesorge/spectr/internal/review"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+	"gopkg.in/yaml.v3"
+)
+
+// APIVersion is the apiVersion of generated entities.
+const APIVersion = "backstage.io/v1alpha1"
+
+// Defaults of entities whose spec does not override them.
+const (
+	DefaultLifecycle = "production"
+	DefaultOwner     = "unknown"
+)
+
+// maxNameLength is the longest entity name Backstage accepts.
+const maxNameLength = 63
+
+// Annotations of generated entities.
+const (
+	AnnotationSpecID       = "spectr.dev/spec-id"
+	AnnotationRequirements = "spectr.dev/requirements"
+	AnnotationLastReviewed = "spectr.dev/last-reviewed"
+	AnnotationReviewDue    = "spectr.dev/review-due"
+	AnnotationSource       = "backstage.io/source-location"
+)
+
+// defaultTypes is the spec.type of each kind when the spec sets none.
+var defaultTypes = map[string]string{
+	"Component": "service",
+	"API":       "spectr",
+	"Resource":  "spectr",
+}
+
+// invalidName matches runs of characters Backstage names cannot contain.
+var invalidName = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// invalidTag matches runs of characters Backstage tags cannot contain.
+var invalidTag = regexp.MustCompile(`[^a-z0-9+#-]+`)
+
+// Options control Build.
+type Options struct {
+	// Specs limits the entities to these spec IDs; empty means all
+	Specs []string
+	// BaseURL prefixes links to spec files, e.g.
+	// https://github.com/acme/app/blob/main. Without it links are paths
+	// relative to the project root.
+	BaseURL string
+	// Now is the date review state is computed for
+	Now time.Time
+}
+
+// Entity is a Backstage catalog entity.
+type Entity struct {
+	APIVersion string   `yaml:"apiVersion"`
+	Kind       string   `yaml:"kind"`
+	Metadata   Metadata `yaml:"metadata"`
+	Spec       Spec     `yaml:"spec"`
+}
+
+// Metadata is the metadata of an entity.
+type Metadata struct {
+	Name        string            `yaml:"name"`
+	Title       string            `yaml:"title,omitempty"`
+	Description string            `yaml:"description,omitempty"`
+	Annotations map[string]string `yaml:"annotations,omitempty"`
+	Tags        []string          `yaml:"tags,omitempty"`
+	Links       []Link            `yaml:"links,omitempty"`
+}
+
+// Link is an entry of metadata.links.
+type Link struct {
+	URL   string `yaml:"url"`
+	Title string `yaml:"title"`
+}
+
+// Spec is the spec of an entity.
+type Spec struct {
+	Type      string `yaml:"type"`
+	Lifecycle string `yaml:"lifecycle"`
+	Owner     string `yaml:"owner"`
+	System    string `yaml:"system,omitempty"`
+	// Definition points API entities at their spec.md
+	Definition map[string]string `yaml:"definition,omitempty"`
+}
+
+// Build returns the entities of the specs of the project at projectRoot,
+// in spec ID order.
+func Build(projectRoot string, opts Options) ([]Entity, error) {
+	cfg, err := config.LoadConfig(projectRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	specIDs := opts.Specs
+	if len(specIDs) == 0 {
+		if specIDs, err = discovery.GetSpecIDs(projectRoot); err != nil {
+			return nil, err
+		}
+	}
+
+	entities := make([]Entity, 0, len(specIDs))
+	for _, id := range specIDs {
+		entity, err := buildEntity(projectRoot, cfg, id, opts)
+		if err != nil {
+			return nil, err
+		}
+		entities = append(entities, entity)
+	}
+
+	return entities, nil
+}
+
+// Encode writes entities as a multi-document YAML catalog-info file.
+func Encode(entities []Entity) ([]byte, error) {
+	var sb strings.Builder
+	enc := yaml.NewEncoder(&sb)
+	enc.SetIndent(2)
+	for _, entity := range entities {
+		if err := enc.Encode(entity); err != nil {
+			return nil, fmt.Errorf("failed to encode entity %s: %w", entity.Metadata.Name, err)
+		}
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode entities: %w", err)
+	}
+
+	return []byte(sb.String()), nil
+}
+
+// buildEntity returns the entity of one spec.
+func buildEntity(
+	projectRoot string,
+	cfg *config.Config,
+	id string,
+	opts Options,
+) (Entity, error) {
+	specPath := filepath.Join(projectRoot, "spectr", "specs", filepath.FromSlash(id), "spec.md")
+	content, err := fileio.ReadFile(specPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return Entity{}, fmt.Errorf("spec '%s' not found", id)
+	}
+	if err != nil {
+		return Entity{}, err
+	}
+	meta, err := domain.ParseSpecFrontmatter(content)
+	if err != nil {
+		return Entity{}, fmt.Errorf("parse %s frontmatter: %w", id, err)
+	}
+	overrides := domain.BackstageMetadata{}
+	if meta.Backstage != nil {
+		overrides = *meta.Backstage
+	}
+
+	kind, err := entityKind(id, overrides.Kind)
+	if err != nil {
+		return Entity{}, err
+	}
+	title, err := parsers.ExtractTitle(specPath)
+	if err != nil {
+		return Entity{}, err
+	}
+	blocks, err := parsers.ParseRequirementsContent(string(content))
+	if err != nil {
+		return Entity{}, fmt.Errorf("failed to parse %s: %w", id, err)
+	}
+
+	specURL := joinURL(opts.BaseURL, "spectr/specs/"+id+"/spec.md")
+	entity := Entity{
+		APIVersion: APIVersion,
+		Kind:       kind,
+		Metadata: Metadata{
+			Name:        EntityName(id),
+			Title:       title,
+			Description: purpose(string(content)),
+			Annotations: map[string]string{
+				AnnotationSpecID:       id,
+				AnnotationRequirements: strconv.Itoa(len(blocks)),
+			},
+			Tags:  tags(meta.Tags),
+			Links: []Link{{URL: specURL, Title: "Spec"}},
+		},
+		Spec: Spec{
+			Type:      firstNonEmpty(overrides.Type, defaultTypes[kind]),
+			Lifecycle: firstNonEmpty(overrides.Lifecycle, DefaultLifecycle),
+			Owner:     firstNonEmpty(overrides.Owner, ownerRef(cfg.SpecOwner(id)), DefaultOwner),
+			System:    overrides.System,
+		},
+	}
+	if opts.BaseURL != "" {
+		entity.Metadata.Annotations[AnnotationSource] =
+			"url:" + joinURL(opts.BaseURL, "spectr/specs/"+id+"/")
+	}
+	if kind == "API" {
+		entity.Spec.Definition = map[string]string{"$text": specURL}
+	}
+	for _, block := range blocks {
+		heading := "Requirement: " + block.Name
+		entity.Metadata.Links = append(entity.Metadata.Links, Link{
+			URL:   specURL + "#" + markdown.HeadingAnchor(heading),
+			Title: heading,
+		})
+	}
+
+	if meta.LastReviewed != "" {
+		entity.Metadata.Annotations[AnnotationLastReviewed] = meta.LastReviewed
+	}
+	status, ok, err := review.Status(cfg, id, specPath, opts.Now)
+	if err != nil {
+		return Entity{}, err
+	}
+	if ok {
+		entity.Metadata.Annotations[AnnotationReviewDue] = strconv.FormatBool(status.Due)
+	}
+
+	return entity, nil
+}
+
+// purpose returns the text of the Purpose section of a spec as one line.
+func purpose(content string) string {
+	var lines []string
+	inPurpose := false
+	for line := range strings.SplitSeq(content, "\n") {
+		if name, ok := markdown.MatchH2SectionHeader(strings.TrimSpace(line)); ok {
+			if inPurpose {
+				break
+			}
+			inPurpose = name == "Purpose"
+
+			continue
+		}
+		if inPurpose {
+			lines = append(lines, line)
+		}
+	}
+
+	return strings.Join(strings.Fields(strings.Join(lines, " ")), " ")
+}
+
+// entityKind returns the canonical spelling of a backstage.kind value,
+// Component when it is empty.
+func entityKind(specID, kind string) (string, error) {
+	if kind == "" {
+		return "Component", nil
+	}
+	for known := range defaultTypes {
+		if strings.EqualFold(kind, known) {
+			return known, nil
+		}
+	}
+
+	return "", &specterrs.InvalidBackstageKindError{Spec: specID, Kind: kind}
+}
+
+// EntityName converts a spec ID to a Backstage entity name, e.g.
+// "payments/refunds" -> "payments-refunds".
+func EntityName(specID string) string {
+	name := invalidName.ReplaceAllString(specID, "-")
+	if len(name) > maxNameLength {
+		name = name[:maxNameLength]
+	}
+
+	return strings.Trim(name, "-_.")
+}
+
+// ownerRef converts a spectr.yaml owner to a Backstage entity reference:
+// "@acme/payments" becomes "acme/payments".
+func ownerRef(owner string) string {
+	return strings.TrimPrefix(strings.TrimSpace(owner), "@")
+}
+
+// tags converts spec tags to Backstage tags, which are lowercase and
+// limited to letters, digits and +#-.
+func tags(specTags []string) []string {
+	var out []string
+	for _, tag := range specTags {
+		tag = strings.Trim(invalidTag.ReplaceAllString(strings.ToLower(tag), "-"), "-")
+		if tag != "" {
+			out = append(out, tag)
+		}
+	}
+
+	return out
+}
+
+// joinURL joins a base URL and a slash-separated path; an empty base
+// leaves the path relative.
+func joinURL(base, rel string) string {
+	if base == "" {
+		return rel
+	}
+
+	return strings.TrimSuffix(base, "/") + "/" + rel
+}
+
+// firstNonEmpty returns the first value that is not empty.
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
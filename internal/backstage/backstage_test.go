@@ -0,0 +1,202 @@
+package backstage
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+const testConfig = `owners:
+  - spec: payments
+    owner: "@acme/payments"
+review:
+  interval_days: 90
+`
+
+const refundsSpec = `---
+last_reviewed: 2026-01-02
+tags: [Compliance, PCI DSS]
+backstage:
+  kind: api
+  system: billing
+---
+# Refunds
+
+## Purpose
+
+Refund captured
+payments.
+
+## Requirements
+
+### Requirement: Refund Window
+The system SHALL refund within 30 days.
+
+#### Scenario: Late refund
+- **WHEN** a refund is requested after 30 days
+- **THEN** it is rejected
+`
+
+const searchSpec = `# Search
+
+## Purpose
+
+Find things.
+
+## Requirements
+
+### Requirement: Query
+The system SHALL search.
+
+#### Scenario: Match
+- **WHEN** a user searches
+- **THEN** results are shown
+`
+
+func testProject(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	files := map[string]string{
+		"spectr.yaml":                           testConfig,
+		"spectr/specs/payments/refunds/spec.md": refundsSpec,
+		"spectr/specs/search/spec.md":           searchSpec,
+	}
+	for rel, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return root
+}
+
+func TestBuild(t *testing.T) {
+	now := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	entities, err := Build(testProject(t), Options{
+		BaseURL: "https://example.com/repo/blob/main/",
+		Now:     now,
+	})
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	if len(entities) != 2 {
+		t.Fatalf("got %d entities, want 2", len(entities))
+	}
+
+	specURL := "https://example.com/repo/blob/main/spectr/specs/payments/refunds/spec.md"
+	want := Entity{
+		APIVersion: APIVersion,
+		Kind:       "API",
+		Metadata: Metadata{
+			Name:        "payments-refunds",
+			Title:       "Refunds",
+			Description: "Refund captured payments.",
+			Annotations: map[string]string{
+				AnnotationSpecID:       "payments/refunds",
+				AnnotationRequirements: "1",
+				AnnotationLastReviewed: "2026-01-02",
+				AnnotationReviewDue:    "true",
+				AnnotationSource:       "url:https://example.com/repo/blob/main/spectr/specs/payments/refunds/",
+			},
+			Tags: []string{"compliance", "pci-dss"},
+			Links: []Link{
+				{URL: specURL, Title: "Spec"},
+				{URL: specURL + "#requirement-refund-window", Title: "Requirement: Refund Window"},
+			},
+		},
+		Spec: Spec{
+			Type:       "spectr",
+			Lifecycle:  DefaultLifecycle,
+			Owner:      "acme/payments",
+			System:     "billing",
+			Definition: map[string]string{"$text": specURL},
+		},
+	}
+	if !reflect.DeepEqual(entities[0], want) {
+		t.Errorf("entity = %+v\nwant %+v", entities[0], want)
+	}
+
+	search := entities[1]
+	if search.Kind != "Component" || search.Spec.Type != "service" ||
+		search.Spec.Owner != DefaultOwner || search.Spec.Definition != nil {
+		t.Errorf("unexpected defaults %+v", search)
+	}
+	if search.Metadata.Annotations[AnnotationReviewDue] != "true" {
+		t.Errorf("never-reviewed spec should be due: %v", search.Metadata.Annotations)
+	}
+}
+
+func TestBuild_RelativeLinks(t *testing.T) {
+	entities, err := Build(testProject(t), Options{Specs: []string{"search"}})
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	if len(entities) != 1 {
+		t.Fatalf("got %d entities, want 1", len(entities))
+	}
+	if got := entities[0].Metadata.Links[1].URL; got != "spectr/specs/search/spec.md#requirement-query" {
+		t.Errorf("link = %q", got)
+	}
+	if _, ok := entities[0].Metadata.Annotations[AnnotationSource]; ok {
+		t.Error("source location set without a base URL")
+	}
+}
+
+func TestBuild_InvalidKind(t *testing.T) {
+	root := testProject(t)
+	path := filepath.Join(root, "spectr", "specs", "search", "spec.md")
+	content := "---\nbackstage:\n  kind: Domain\n---\n" + searchSpec
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := Build(root, Options{Specs: []string{"search"}})
+	var kindErr *specterrs.InvalidBackstageKindError
+	if !errors.As(err, &kindErr) || kindErr.Kind != "Domain" {
+		t.Fatalf("expected InvalidBackstageKindError, got %v", err)
+	}
+}
+
+func TestEntityName(t *testing.T) {
+	tests := []struct {
+		specID string
+		want   string
+	}{
+		{"auth", "auth"},
+		{"payments/refunds", "payments-refunds"},
+		{"a b//c", "a-b-c"},
+		{strings.Repeat("x", 70), strings.Repeat("x", 63)},
+	}
+
+	for _, tt := range tests {
+		if got := EntityName(tt.specID); got != tt.want {
+			t.Errorf("EntityName(%q) = %q, want %q", tt.specID, got, tt.want)
+		}
+	}
+}
+
+func TestEncode(t *testing.T) {
+	entities, err := Build(testProject(t), Options{})
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	data, err := Encode(entities)
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	output := string(data)
+	if strings.Count(output, "apiVersion: backstage.io/v1alpha1") != 2 ||
+		strings.Count(output, "\n---\n") != 1 {
+		t.Errorf("expected two YAML documents:\n%s", output)
+	}
+}
@@ -0,0 +1,119 @@
+package bench
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+// BaselineFileName is the default baseline file inside the spectr/
+// directory.
+const BaselineFileName = "bench.json"
+
+// baselineVersion is the current baseline file format version.
+const baselineVersion = 1
+
+// Baseline is a saved set of results to compare later runs against.
+type Baseline struct {
+	Version int      `json:"version"`
+	Results []Result `json:"results"`
+}
+
+// Comparison is a result next to its baseline.
+type Comparison struct {
+	Current  Result  `json:"current"`
+	Baseline *Result `json:"baseline,omitempty"`
+	// TimeDelta and AllocsDelta are the changes from the baseline in
+	// percent; positive means slower or more allocations
+	TimeDelta   float64 `json:"timeDelta"`
+	AllocsDelta float64 `json:"allocsDelta"`
+	Regressed   bool    `json:"regressed"`
+}
+
+// LoadBaseline reads a baseline file. A missing file returns nil and no
+// error.
+func LoadBaseline(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read baseline: %w", err)
+	}
+
+	var baseline Baseline
+	if err := json.Unmarshal(data, &baseline); err != nil {
+		return nil, fmt.Errorf("failed to parse baseline %s: %w", path, err)
+	}
+	if baseline.Version != baselineVersion {
+		return nil, &specterrs.BenchBaselineVersionError{
+			Path:    path,
+			Version: baseline.Version,
+		}
+	}
+
+	return &baseline, nil
+}
+
+// SaveBaseline writes results as the baseline at path.
+func SaveBaseline(path string, results []Result) error {
+	data, err := json.MarshalIndent(
+		Baseline{Version: baselineVersion, Results: results},
+		"",
+		"  ",
+	)
+	if err != nil {
+		return fmt.Errorf("failed to encode baseline: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create baseline directory: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write baseline: %w", err)
+	}
+
+	return nil
+}
+
+// Compare compares results with a baseline. A result regressed when its
+// time or allocations per operation grew by more than threshold percent.
+// Results without a baseline entry never regress.
+func Compare(
+	results []Result,
+	baseline *Baseline,
+	threshold float64,
+) []Comparison {
+	base := make(map[string]Result)
+	if baseline != nil {
+		for _, r := range baseline.Results {
+			base[r.Name] = r
+		}
+	}
+
+	comparisons := make([]Comparison, 0, len(results))
+	for _, current := range results {
+		c := Comparison{Current: current}
+		if prev, ok := base[current.Name]; ok {
+			c.Baseline = &prev
+			c.TimeDelta = percentChange(prev.NsPerOp, current.NsPerOp)
+			c.AllocsDelta = percentChange(prev.AllocsPerOp, current.AllocsPerOp)
+			c.Regressed = c.TimeDelta > threshold || c.AllocsDelta > threshold
+		}
+		comparisons = append(comparisons, c)
+	}
+
+	return comparisons
+}
+
+// percentChange returns the change from before to after in percent.
+func percentChange(before, after int64) float64 {
+	if before == 0 {
+		return 0
+	}
+
+	return float64(after-before) / float64(before) * 100
+}
@@ -0,0 +1,87 @@
+package bench
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+func TestCompare(t *testing.T) {
+	baseline := &Baseline{
+		Version: baselineVersion,
+		Results: []Result{
+			{Name: "parse", NsPerOp: 1000, AllocsPerOp: 100},
+			{Name: "list", NsPerOp: 1000, AllocsPerOp: 100},
+			{Name: "validate-all", NsPerOp: 1000, AllocsPerOp: 100},
+		},
+	}
+	results := []Result{
+		{Name: "parse", NsPerOp: 1090, AllocsPerOp: 100},
+		{Name: "list", NsPerOp: 1200, AllocsPerOp: 90},
+		{Name: "validate-all", NsPerOp: 900, AllocsPerOp: 150},
+		{Name: "parse-incremental", NsPerOp: 5000, AllocsPerOp: 10},
+	}
+
+	comparisons := Compare(results, baseline, 10)
+
+	want := []struct {
+		timeDelta float64
+		regressed bool
+		hasBase   bool
+	}{
+		{9, false, true},
+		{20, true, true},
+		{-10, true, true},
+		{0, false, false},
+	}
+	for i, w := range want {
+		c := comparisons[i]
+		if c.Current != results[i] ||
+			c.TimeDelta != w.timeDelta ||
+			c.Regressed != w.regressed ||
+			(c.Baseline != nil) != w.hasBase {
+			t.Errorf("comparison %d = %+v, want %+v", i, c, w)
+		}
+	}
+}
+
+func TestCompare_NoBaseline(t *testing.T) {
+	comparisons := Compare([]Result{{Name: "parse", NsPerOp: 1}}, nil, 0)
+	if len(comparisons) != 1 || comparisons[0].Regressed {
+		t.Errorf("comparisons = %+v, want one non-regressed", comparisons)
+	}
+}
+
+func TestSaveAndLoadBaseline(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "spectr", BaselineFileName)
+
+	missing, err := LoadBaseline(path)
+	if err != nil || missing != nil {
+		t.Fatalf("LoadBaseline(missing) = %v, %v; want nil, nil", missing, err)
+	}
+
+	results := []Result{{Name: "parse", NsPerOp: 10, AllocsPerOp: 2, BytesPerOp: 64}}
+	if err := SaveBaseline(path, results); err != nil {
+		t.Fatal(err)
+	}
+	baseline, err := LoadBaseline(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(baseline.Results, results) {
+		t.Errorf("results = %+v, want %+v", baseline.Results, results)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"version": 9}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	_, err = LoadBaseline(path)
+	var versionErr *specterrs.BenchBaselineVersionError
+	if !errors.As(err, &versionErr) || versionErr.Version != 9 {
+		t.Errorf("err = %v, want BenchBaselineVersionError for version 9", err)
+	}
+}
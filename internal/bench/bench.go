@@ -0,0 +1,172 @@
+package bench
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/connerohnesorge/spectr/internal/fileio"
+	"github.com/connerohnesorge/spectr/internal/list"
+	"github.com/connerohnesorge/spectr/internal/markdown"
+	"github.com/connerohnesorge/spectr/internal/validation"
+)
+
+// Benchmark names, in the order they run.
+const (
+	NameParse            = "parse"
+	NameParseIncremental = "parse-incremental"
+	NameValidateAll      = "validate-all"
+	NameList             = "list"
+)
+
+// Benchmark is an operation measured against one project.
+type Benchmark struct {
+	Name string
+	Func func(b *testing.B)
+}
+
+// Result is the measurement of one benchmark.
+type Result struct {
+	Name        string `json:"name"`
+	NsPerOp     int64  `json:"nsPerOp"`
+	AllocsPerOp int64  `json:"allocsPerOp"`
+	BytesPerOp  int64  `json:"bytesPerOp"`
+}
+
+// Benchmarks returns the benchmarks for the project at projectRoot:
+//   - parse: parse every markdown file under spectr/
+//   - parse-incremental: reparse the largest file after a one-word edit
+//   - validate-all: validate every change and spec
+//   - list: list every change and spec
+func Benchmarks(projectRoot string) ([]Benchmark, error) {
+	docs, err := readMarkdown(filepath.Join(projectRoot, "spectr"))
+	if err != nil {
+		return nil, err
+	}
+
+	var largest []byte
+	for _, doc := range docs {
+		if len(doc) > len(largest) {
+			largest = doc
+		}
+	}
+	oldTree, _ := markdown.Parse(largest)
+	edited := insertWord(largest)
+
+	return []Benchmark{
+		{Name: NameParse, Func: func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				for _, doc := range docs {
+					_, _ = markdown.Parse(doc)
+				}
+			}
+		}},
+		{Name: NameParseIncremental, Func: func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				_, _ = markdown.ParseIncremental(oldTree, largest, edited)
+			}
+		}},
+		{Name: NameValidateAll, Func: func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				items, err := validation.GetAllItems(projectRoot)
+				if err != nil {
+					b.Fatal(err)
+				}
+				_, err = validation.NewValidator().ValidateItems(
+					context.Background(),
+					items,
+				)
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		}},
+		{Name: NameList, Func: func(b *testing.B) {
+			b.ReportAllocs()
+			lister := list.NewLister(projectRoot)
+			for range b.N {
+				if _, err := lister.ListChanges(); err != nil {
+					b.Fatal(err)
+				}
+				if _, err := lister.ListSpecs(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}},
+	}, nil
+}
+
+// Run runs each benchmark count times and keeps its fastest run, which is
+// the least disturbed by other load on the machine.
+func Run(benchmarks []Benchmark, count int) ([]Result, error) {
+	results := make([]Result, 0, len(benchmarks))
+	for _, bm := range benchmarks {
+		var best Result
+		for i := range max(count, 1) {
+			r := testing.Benchmark(bm.Func)
+			if r.N == 0 {
+				return nil, fmt.Errorf("benchmark %s failed", bm.Name)
+			}
+			if i == 0 || r.NsPerOp() < best.NsPerOp {
+				best = Result{
+					Name:        bm.Name,
+					NsPerOp:     r.NsPerOp(),
+					AllocsPerOp: r.AllocsPerOp(),
+					BytesPerOp:  r.AllocedBytesPerOp(),
+				}
+			}
+		}
+		results = append(results, best)
+	}
+
+	return results, nil
+}
+
+// readMarkdown reads every markdown file under dir, skipping archived
+// changes.
+func readMarkdown(dir string) ([][]byte, error) {
+	var docs [][]byte
+	err := fileio.WalkDir(
+		dir,
+		func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if entry.IsDir() && entry.Name() == "archive" {
+				return filepath.SkipDir
+			}
+			if entry.IsDir() || filepath.Ext(path) != ".md" {
+				return nil
+			}
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			docs = append(docs, content)
+
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read markdown files: %w", err)
+	}
+
+	return docs, nil
+}
+
+// insertWord returns doc with a word inserted halfway through, the kind of
+// edit an editor sends while typing.
+func insertWord(doc []byte) []byte {
+	mid := len(doc) / 2
+	edited := make([]byte, 0, len(doc)+5)
+	edited = append(edited, doc[:mid]...)
+	edited = append(edited, "word "...)
+
+	return append(edited, doc[mid:]...)
+}
@@ -0,0 +1,48 @@
+package bench
+
+import (
+	"testing"
+)
+
+// benchmarksFor generates a project of the given size and returns its
+// benchmarks by name.
+func benchmarksFor(b *testing.B, size Size) map[string]func(*testing.B) {
+	b.Helper()
+	root := b.TempDir()
+	if err := Generate(root, size); err != nil {
+		b.Fatal(err)
+	}
+	benchmarks, err := Benchmarks(root)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	byName := make(map[string]func(*testing.B), len(benchmarks))
+	for _, bm := range benchmarks {
+		byName[bm.Name] = bm.Func
+	}
+
+	return byName
+}
+
+func runOverSizes(b *testing.B, name string) {
+	for _, size := range Sizes {
+		b.Run(size.Name, benchmarksFor(b, size)[name])
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	runOverSizes(b, NameParse)
+}
+
+func BenchmarkParseIncremental(b *testing.B) {
+	runOverSizes(b, NameParseIncremental)
+}
+
+func BenchmarkValidateAll(b *testing.B) {
+	runOverSizes(b, NameValidateAll)
+}
+
+func BenchmarkList(b *testing.B) {
+	runOverSizes(b, NameList)
+}
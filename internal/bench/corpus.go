@@ -0,0 +1,113 @@
+package bench
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Size describes a generated corpus.
+type Size struct {
+	Name string
+	// Specs is the number of specs, each with Requirements requirements
+	Specs        int
+	Requirements int
+	// Changes is the number of active changes, each modifying one
+	// requirement of one spec
+	Changes int
+}
+
+// Sizes are the corpora the go benchmarks run over.
+var Sizes = []Size{
+	{Name: "small", Specs: 5, Requirements: 5, Changes: 2},
+	{Name: "medium", Specs: 25, Requirements: 10, Changes: 10},
+	{Name: "large", Specs: 100, Requirements: 20, Changes: 40},
+}
+
+// Generate writes a valid spectr project of the given size under root.
+func Generate(root string, size Size) error {
+	for i := range size.Specs {
+		path := filepath.Join(root, "spectr", "specs", specID(i), "spec.md")
+		if err := writeFile(path, specContent(i, size.Requirements)); err != nil {
+			return err
+		}
+	}
+
+	for i := range size.Changes {
+		changeDir := filepath.Join(root, "spectr", "changes", fmt.Sprintf("change-%03d", i))
+		spec := i % max(size.Specs, 1)
+		files := map[string]string{
+			"proposal.md": fmt.Sprintf(
+				"# Change %d\n\n## Why\nImprove %s.\n\n## What Changes\n- Update %s\n\n"+
+					"## Impact\n- Affected specs: %s\n",
+				i,
+				specID(spec),
+				requirementName(spec, 0),
+				specID(spec),
+			),
+			"tasks.md": fmt.Sprintf(
+				"## 1. Implementation\n- [ ] 1.1 Update %s\n- [x] 1.2 Write tests\n",
+				requirementName(spec, 0),
+			),
+			filepath.Join("specs", specID(spec), "spec.md"): "## MODIFIED Requirements\n\n" +
+				requirementContent(spec, 0, "changed"),
+		}
+		for name, content := range files {
+			if err := writeFile(filepath.Join(changeDir, name), content); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func specID(i int) string {
+	return fmt.Sprintf("spec-%03d", i)
+}
+
+func requirementName(spec, req int) string {
+	return fmt.Sprintf("Requirement %d-%d", spec, req)
+}
+
+func specContent(spec, requirements int) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s Specification\n\n", specID(spec))
+	fmt.Fprintf(
+		&b,
+		"## Purpose\nGenerated spec %d for benchmarking spectr on larger projects.\n\n",
+		spec,
+	)
+	b.WriteString("## Requirements\n\n")
+	for req := range requirements {
+		b.WriteString(requirementContent(spec, req, "handled"))
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+func requirementContent(spec, req int, verb string) string {
+	return fmt.Sprintf(
+		"### Requirement: %s\n"+
+			"The system SHALL ensure that case %d of spec %d is %s.\n\n"+
+			"#### Scenario: Case %d succeeds\n"+
+			"- **WHEN** case %d occurs\n"+
+			"- **THEN** the system reports success\n\n"+
+			"#### Scenario: Case %d fails\n"+
+			"- **WHEN** case %d fails\n"+
+			"- **THEN** the system reports the error\n",
+		requirementName(spec, req),
+		req, spec, verb,
+		req, req, req, req,
+	)
+}
+
+func writeFile(path, content string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, []byte(content), 0o644)
+}
@@ -0,0 +1,49 @@
+package bench
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/connerohnesorge/spectr/internal/validation"
+)
+
+func TestGenerate_IsValid(t *testing.T) {
+	root := t.TempDir()
+	if err := Generate(root, Sizes[0]); err != nil {
+		t.Fatal(err)
+	}
+
+	benchmarks, err := Benchmarks(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, bm := range benchmarks {
+		names = append(names, bm.Name)
+	}
+	want := []string{NameParse, NameParseIncremental, NameValidateAll, NameList}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("names = %v, want %v", names, want)
+	}
+
+	items, err := validation.GetAllItems(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != Sizes[0].Specs+Sizes[0].Changes {
+		t.Errorf("got %d items, want %d", len(items), Sizes[0].Specs+Sizes[0].Changes)
+	}
+	results, err := validation.NewValidator().ValidateItems(
+		context.Background(),
+		items,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, result := range results {
+		if !result.Valid {
+			t.Errorf("%s is invalid: %+v", result.Name, result.Report)
+		}
+	}
+}
@@ -0,0 +1,9 @@
+// Package bench measures how long spectr takes to parse, validate and list
+// a project, and compares the measurements with a saved baseline.
+//
+// The same benchmarks back the go benchmarks of this package, which run
+// over generated projects of several sizes, and `spectr bench`, which runs
+// them against the current project. Each benchmark is run a few times and
+// its fastest run kept, since noise on a shared machine only ever makes
+// a run slower.
+package bench
@@ -0,0 +1,197 @@
+// Package changeid enforces the naming policy of change IDs and generates
+// IDs from change titles.
+//
+// The policy comes from change_ids in spectr.yaml: a regular expression
+// every ID must match (kebab-case by default) and prefixes IDs must not
+// start with. Generated IDs are verb-noun slugs such as
+// "fix-login-redirect", numbered when the slug is already taken by an
+// active or archived change.
+package changeid
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/config"
+	"github.com/connerohnesorge/spectr/internal/discovery"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+// DefaultPattern is the kebab-case pattern used when spectr.yaml sets
+// none.
+const DefaultPattern = `^[a-z][a-z0-9]*(-[a-z0-9]+)*$`
+
+// DefaultVerb starts generated IDs whose title does not start with a
+// known verb.
+const DefaultVerb = "add"
+
+// maxSlugWords caps the words of a generated ID, the verb included.
+const maxSlugWords = 5
+
+// verbs are the words a generated ID may start with.
+var verbs = []string{
+	"add", "allow", "change", "clean", "deprecate", "disable", "document",
+	"drop", "enable", "extend", "fix", "implement", "improve", "introduce",
+	"migrate", "move", "refactor", "remove", "rename", "replace", "revert",
+	"simplify", "split", "support", "update", "upgrade", "use",
+}
+
+// stopWords are left out of generated IDs.
+var stopWords = []string{
+	"a", "an", "and", "as", "at", "by", "for", "from", "in", "into", "of",
+	"on", "or", "the", "to", "with",
+}
+
+// wordPattern matches the words of a title.
+var wordPattern = regexp.MustCompile(`[a-z0-9]+`)
+
+// Policy is the naming policy of change IDs.
+type Policy struct {
+	pattern  *regexp.Regexp
+	reserved []string
+}
+
+// FromConfig returns the policy of cfg, the default policy when cfg sets
+// none.
+func FromConfig(cfg *config.Config) (Policy, error) {
+	var settings config.ChangeIDsConfig
+	if cfg != nil && cfg.ChangeIDs != nil {
+		settings = *cfg.ChangeIDs
+	}
+
+	source := settings.Pattern
+	if source == "" {
+		source = DefaultPattern
+	}
+	pattern, err := regexp.Compile(source)
+	if err != nil {
+		return Policy{}, &specterrs.InvalidChangeIDPatternError{Pattern: source, Err: err}
+	}
+
+	return Policy{pattern: pattern, reserved: settings.ReservedPrefixes}, nil
+}
+
+// Load returns the policy of the project at projectRoot.
+func Load(projectRoot string) (Policy, error) {
+	cfg, err := config.LoadConfig(projectRoot)
+	if err != nil {
+		return Policy{}, err
+	}
+
+	return FromConfig(cfg)
+}
+
+// Check returns a *specterrs.InvalidChangeIDError when id breaks the
+// policy.
+func (p Policy) Check(id string) error {
+	for _, prefix := range p.reserved {
+		if strings.HasPrefix(id, prefix) {
+			return &specterrs.InvalidChangeIDError{
+				ID:     id,
+				Reason: fmt.Sprintf("the prefix %q is reserved", prefix),
+			}
+		}
+	}
+	if !p.pattern.MatchString(id) {
+		return &specterrs.InvalidChangeIDError{
+			ID:     id,
+			Reason: fmt.Sprintf("it does not match %s", p.pattern),
+		}
+	}
+
+	return nil
+}
+
+// Generate returns an ID for a change titled title: a verb-noun slug of
+// its words, suffixed with -2, -3, ... while taken reports the ID as in
+// use. The ID is checked against the policy, so a project whose pattern
+// needs more than a slug, such as a ticket number, gets an error.
+func (p Policy) Generate(title string, taken func(string) bool) (string, error) {
+	slug := Slug(title)
+	if slug == "" {
+		return "", &specterrs.InvalidChangeIDError{
+			ID:     title,
+			Reason: "the title has no letters or digits to build an ID from",
+		}
+	}
+
+	id := slug
+	for n := 2; taken(id); n++ {
+		id = fmt.Sprintf("%s-%d", slug, n)
+	}
+
+	return id, p.Check(id)
+}
+
+// Slug turns a title into a verb-noun slug, e.g. "Fixed the login
+// redirect" -> "fix-login-redirect". Stop words are dropped, and titles
+// that do not start with a known verb get DefaultVerb.
+func Slug(title string) string {
+	var words []string
+	for _, word := range wordPattern.FindAllString(strings.ToLower(title), -1) {
+		if !slices.Contains(stopWords, word) {
+			words = append(words, word)
+		}
+	}
+	if len(words) == 0 {
+		return ""
+	}
+
+	if verb, ok := baseVerb(words[0]); ok {
+		words[0] = verb
+	} else {
+		words = append([]string{DefaultVerb}, words...)
+	}
+	if len(words) > maxSlugWords {
+		words = words[:maxSlugWords]
+	}
+
+	return strings.Join(words, "-")
+}
+
+// baseVerb returns the known verb word is a form of: "fixes", "fixed" and
+// "fixing" all give "fix".
+func baseVerb(word string) (string, bool) {
+	candidates := []string{word}
+	for _, suffix := range []string{"s", "es", "d", "ed", "ing"} {
+		stem, ok := strings.CutSuffix(word, suffix)
+		if !ok || stem == "" {
+			continue
+		}
+		candidates = append(candidates, stem, stem+"e")
+		// A doubled final consonant: "dropped" -> "drop"
+		if n := len(stem); n > 1 && stem[n-1] == stem[n-2] {
+			candidates = append(candidates, stem[:n-1])
+		}
+	}
+	for _, candidate := range candidates {
+		if slices.Contains(verbs, candidate) {
+			return candidate, true
+		}
+	}
+
+	return "", false
+}
+
+// Taken returns a function reporting whether an ID is used by an active
+// or archived change of the project at projectRoot.
+func Taken(projectRoot string) (func(string) bool, error) {
+	archived, err := discovery.GetArchivedChangeIDs(projectRoot)
+	if err != nil {
+		return nil, err
+	}
+	changesDir := filepath.Join(projectRoot, "spectr", "changes")
+
+	return func(id string) bool {
+		if slices.Contains(archived, id) {
+			return true
+		}
+		_, err := os.Stat(filepath.Join(changesDir, id))
+
+		return err == nil
+	}, nil
+}
@@ -0,0 +1,118 @@
+package changeid
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/connerohnesorge/spectr/internal/config"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+func TestSlug(t *testing.T) {
+	tests := []struct {
+		title string
+		want  string
+	}{
+		{"Fix_Stuff2", "fix-stuff2"},
+		{"Add two-factor login", "add-two-factor-login"},
+		{"Fixed the login redirect", "fix-login-redirect"},
+		{"Dropping support for IE11", "drop-support-ie11"},
+		{"Updates the README", "update-readme"},
+		{"Session timeout", "add-session-timeout"},
+		{"Refactor the parser into smaller visitor types", "refactor-parser-smaller-visitor-types"},
+		{"Remove a b c d e f g", "remove-b-c-d-e"},
+		{"  !!  ", ""},
+	}
+
+	for _, tt := range tests {
+		if got := Slug(tt.title); got != tt.want {
+			t.Errorf("Slug(%q) = %q, want %q", tt.title, got, tt.want)
+		}
+	}
+}
+
+func TestPolicy_Check(t *testing.T) {
+	policy, err := FromConfig(&config.Config{ChangeIDs: &config.ChangeIDsConfig{
+		ReservedPrefixes: []string{"tmp-"},
+	}})
+	if err != nil {
+		t.Fatalf("FromConfig: %v", err)
+	}
+
+	tests := []struct {
+		id    string
+		valid bool
+	}{
+		{"add-login", true},
+		{"add-2fa", true},
+		{"Fix_Stuff2", false},
+		{"add--login", false},
+		{"tmp-login", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		err := policy.Check(tt.id)
+		var invalid *specterrs.InvalidChangeIDError
+		if tt.valid != (err == nil) || (err != nil && !errors.As(err, &invalid)) {
+			t.Errorf("Check(%q) = %v, want valid %v", tt.id, err, tt.valid)
+		}
+	}
+
+	_, err = FromConfig(&config.Config{ChangeIDs: &config.ChangeIDsConfig{Pattern: "("}})
+	var patternErr *specterrs.InvalidChangeIDPatternError
+	if !errors.As(err, &patternErr) {
+		t.Errorf("expected InvalidChangeIDPatternError, got %v", err)
+	}
+}
+
+func TestPolicy_Generate(t *testing.T) {
+	policy, err := FromConfig(nil)
+	if err != nil {
+		t.Fatalf("FromConfig: %v", err)
+	}
+	used := map[string]bool{"fix-login": true, "fix-login-2": true}
+	taken := func(id string) bool { return used[id] }
+
+	id, err := policy.Generate("Fix login", taken)
+	if err != nil || id != "fix-login-3" {
+		t.Errorf("Generate = %q, %v; want fix-login-3", id, err)
+	}
+
+	strict, err := FromConfig(&config.Config{ChangeIDs: &config.ChangeIDsConfig{
+		Pattern: `^[a-z]+-[0-9]+-`,
+	}})
+	if err != nil {
+		t.Fatalf("FromConfig: %v", err)
+	}
+	var invalid *specterrs.InvalidChangeIDError
+	if _, err := strict.Generate("Fix login", taken); !errors.As(err, &invalid) {
+		t.Errorf("expected InvalidChangeIDError for a slug the pattern rejects, got %v", err)
+	}
+	if _, err := policy.Generate("???", taken); !errors.As(err, &invalid) {
+		t.Errorf("expected InvalidChangeIDError for an empty slug, got %v", err)
+	}
+}
+
+func TestTaken(t *testing.T) {
+	root := t.TempDir()
+	for _, dir := range []string{
+		"spectr/changes/add-login",
+		"spectr/changes/archive/2026-01-02-fix-logout",
+	} {
+		if err := os.MkdirAll(filepath.Join(root, filepath.FromSlash(dir)), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	taken, err := Taken(root)
+	if err != nil {
+		t.Fatalf("Taken: %v", err)
+	}
+	for id, want := range map[string]bool{"add-login": true, "fix-logout": true, "add-signup": false} {
+		if got := taken(id); got != want {
+			t.Errorf("taken(%q) = %v, want %v", id, got, want)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package clipboard
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+
+	nativeclip "github.com/atotto/clipboard"
+	"github.com/mattn/go-isatty"
+)
+
+// Method is how a value was copied.
+type Method int
+
+const (
+	// Native means the value is on the system clipboard. It is the zero
+	// value, so results that predate Method read as a plain copy.
+	Native Method = iota
+	// OSC52 means the value was sent to the terminal, which copies it if
+	// it supports OSC 52; there is no way to confirm it did.
+	OSC52
+	// Printed means no clipboard was reachable and the caller must print
+	// the value for the user to copy.
+	Printed
+)
+
+// Hooks replaced by tests.
+var (
+	writeNative           = nativeclip.WriteAll
+	terminal    io.Writer = os.Stderr
+	isTerminal            = func() bool {
+		return isatty.IsTerminal(os.Stderr.Fd()) && os.Getenv("TERM") != "dumb"
+	}
+)
+
+// Copy copies text to the system clipboard, falling back to OSC 52 when
+// stderr is a terminal, and reports which method was used. Printed means
+// nothing was copied.
+func Copy(text string) Method {
+	if err := writeNative(text); err == nil {
+		return Native
+	}
+	if !isTerminal() {
+		return Printed
+	}
+	if _, err := io.WriteString(terminal, osc52(text)); err != nil {
+		return Printed
+	}
+
+	return OSC52
+}
+
+// osc52 returns the escape sequence that sets the clipboard to text,
+// wrapped so tmux and screen pass it through to the outer terminal.
+func osc52(text string) string {
+	seq := "\x1b]52;c;" + base64.StdEncoding.EncodeToString([]byte(text)) + "\x07"
+
+	switch {
+	case os.Getenv("TMUX") != "":
+		return "\x1bPtmux;\x1b" + seq + "\x1b\\"
+	case os.Getenv("STY") != "":
+		return "\x1bP" + seq + "\x1b\\"
+	default:
+		return seq
+	}
+}
+
+// Message returns the line shown after copying value, which the user
+// knows as label (usually an ID).
+func (m Method) Message(label, value string) string {
+	switch m {
+	case OSC52:
+		return fmt.Sprintf("✓ Copied via terminal (OSC 52): %s\n", label)
+	case Printed:
+		return fmt.Sprintf(
+			"Clipboard unavailable; copy %s from here:\n%s\n",
+			label,
+			value,
+		)
+	default:
+		return fmt.Sprintf("✓ Copied: %s\n", label)
+	}
+}
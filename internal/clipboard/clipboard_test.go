@@ -0,0 +1,77 @@
+package clipboard
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCopy(t *testing.T) {
+	errNoClipboard := errors.New("no clipboard utilities available")
+
+	tests := []struct {
+		name      string
+		nativeErr error
+		tty       bool
+		tmux      string
+		want      Method
+		wantOut   string
+	}{
+		{name: "native", tty: true, want: Native},
+		{
+			name:      "osc52 fallback",
+			nativeErr: errNoClipboard,
+			tty:       true,
+			want:      OSC52,
+			wantOut:   "\x1b]52;c;aGk=\x07",
+		},
+		{
+			name:      "osc52 inside tmux",
+			nativeErr: errNoClipboard,
+			tty:       true,
+			tmux:      "/tmp/tmux-1000/default,1,0",
+			want:      OSC52,
+			wantOut:   "\x1bPtmux;\x1b\x1b]52;c;aGk=\x07\x1b\\",
+		},
+		{name: "no terminal", nativeErr: errNoClipboard, want: Printed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out strings.Builder
+			savedNative, savedTerminal, savedIsTerminal := writeNative, terminal, isTerminal
+			t.Cleanup(func() {
+				writeNative, terminal, isTerminal = savedNative, savedTerminal, savedIsTerminal
+			})
+			writeNative = func(string) error { return tt.nativeErr }
+			terminal = &out
+			isTerminal = func() bool { return tt.tty }
+			t.Setenv("TMUX", tt.tmux)
+			t.Setenv("STY", "")
+
+			if got := Copy("hi"); got != tt.want {
+				t.Errorf("Copy = %v, want %v", got, tt.want)
+			}
+			if out.String() != tt.wantOut {
+				t.Errorf("terminal output = %q, want %q", out.String(), tt.wantOut)
+			}
+		})
+	}
+}
+
+func TestMethod_Message(t *testing.T) {
+	tests := []struct {
+		method Method
+		want   string
+	}{
+		{Native, "✓ Copied: add-auth\n"},
+		{OSC52, "✓ Copied via terminal (OSC 52): add-auth\n"},
+		{Printed, "Clipboard unavailable; copy add-auth from here:\nspectr/changes/add-auth/proposal.md\n"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.method.Message("add-auth", "spectr/changes/add-auth/proposal.md"); got != tt.want {
+			t.Errorf("Message = %q, want %q", got, tt.want)
+		}
+	}
+}
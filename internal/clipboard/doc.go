@@ -0,0 +1,9 @@
+// Package clipboard copies values for the interactive TUIs.
+//
+// The system clipboard is tried first. Over SSH, or anywhere no clipboard
+// tool is installed, the value is sent to the terminal as an OSC 52 escape
+// sequence, which most modern terminals (and tmux, with set-clipboard on)
+// copy to the local clipboard. When neither is possible the caller is told
+// to print the value so it can be copied by hand. Copy reports which of
+// these happened so quit messages never claim a copy that did not occur.
+package clipboard
@@ -0,0 +1,282 @@
+package comment
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/connerohnesorge/spectr/internal/audit"
+	"github.com/connerohnesorge/spectr/internal/contract"
+	"github.com/connerohnesorge/spectr/internal/parsers"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+// FileName is the comment file in a spec directory.
+const FileName = "comments.jsonc"
+
+// fileVersion is the current comment file format version.
+const fileVersion = 1
+
+// filePerm is the permission of comment files.
+const filePerm = 0o644
+
+// fileHeader starts every comment file written by Save.
+const fileHeader = "// Requirement review comments, written by spectr comment.\n"
+
+// Comment is one review comment on a requirement.
+type Comment struct {
+	ID int `json:"id"`
+	// Requirement is the requirement ID the comment was written on
+	Requirement string `json:"requirement"`
+	// Name is the requirement name the comment was written on
+	Name string `json:"name"`
+	// Hash is contract.Hash of the requirement content when written
+	Hash    string `json:"hash"`
+	Author  string `json:"author"`
+	Body    string `json:"body"`
+	Created string `json:"created"` // RFC 3339 timestamp
+	// Resolved is when the comment was resolved, empty while open
+	Resolved   string `json:"resolved,omitempty"`
+	ResolvedBy string `json:"resolvedBy,omitempty"`
+}
+
+// File is the comments of one spec.
+type File struct {
+	Version  int       `json:"version"`
+	Comments []Comment `json:"comments"`
+}
+
+// Thread is a comment located in the spec as it is now.
+type Thread struct {
+	Comment
+	// Current is the ID of the requirement now, empty when it is gone
+	Current string `json:"current,omitempty"`
+	// CurrentName is the name of the requirement now
+	CurrentName string `json:"currentName,omitempty"`
+	// Outdated reports a requirement that changed since the comment
+	Outdated bool `json:"outdated,omitempty"`
+}
+
+// requirement is a requirement of a spec as comments key it.
+type requirement struct {
+	id   string
+	name string
+	hash string
+}
+
+// Path returns the comment file of specID in the project at projectRoot.
+func Path(projectRoot, specID string) string {
+	return filepath.Join(projectRoot, "spectr", "specs", filepath.FromSlash(specID), FileName)
+}
+
+// Load reads a comment file. A missing file has no comments.
+func Load(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &File{Version: fileVersion}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var f File
+	if err := json.Unmarshal(parsers.StripJSONComments(data), &f); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if f.Version > fileVersion {
+		return nil, &specterrs.CommentVersionError{Path: path, Version: f.Version}
+	}
+
+	return &f, nil
+}
+
+// Save writes f to path with a comment header.
+func Save(path string, f *File) error {
+	f.Version = fileVersion
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal comments: %w", err)
+	}
+	data = append([]byte(fileHeader), append(data, '\n')...)
+	if err := os.WriteFile(path, data, filePerm); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// Add records body as a comment on the requirement of specID named by
+// query: a requirement ID such as AUTH-R1, or a requirement name matched
+// ignoring case. The author is the git user of the project.
+func Add(projectRoot, specID, query, body string, now time.Time) (*Thread, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return nil, &specterrs.EmptyCommentError{}
+	}
+	specID = strings.Trim(filepath.ToSlash(specID), "/")
+	reqs, err := requirements(projectRoot, specID)
+	if err != nil {
+		return nil, err
+	}
+	req, ok := find(reqs, specID, query)
+	if !ok {
+		return nil, &specterrs.RequirementNotFoundError{Spec: specID, Name: query}
+	}
+
+	path := Path(projectRoot, specID)
+	f, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+	next := 1
+	for _, c := range f.Comments {
+		next = max(next, c.ID+1)
+	}
+	c := Comment{
+		ID:          next,
+		Requirement: req.id,
+		Name:        req.name,
+		Hash:        req.hash,
+		Author:      audit.Actor(projectRoot),
+		Body:        body,
+		Created:     now.UTC().Format(time.RFC3339),
+	}
+	f.Comments = append(f.Comments, c)
+	if err := Save(path, f); err != nil {
+		return nil, err
+	}
+
+	return &Thread{Comment: c, Current: req.id, CurrentName: req.name}, nil
+}
+
+// List returns the comments of specID located in the spec as it is now,
+// in the order they were written. Resolved comments are left out unless
+// all is set.
+func List(projectRoot, specID string, all bool) ([]Thread, error) {
+	specID = strings.Trim(filepath.ToSlash(specID), "/")
+	reqs, err := requirements(projectRoot, specID)
+	if err != nil {
+		return nil, err
+	}
+	f, err := Load(Path(projectRoot, specID))
+	if err != nil {
+		return nil, err
+	}
+
+	threads := make([]Thread, 0, len(f.Comments))
+	for _, c := range f.Comments {
+		if c.Resolved != "" && !all {
+			continue
+		}
+		threads = append(threads, locate(reqs, c))
+	}
+
+	return threads, nil
+}
+
+// Resolve marks comment id of specID resolved. A comment that is already
+// resolved is returned unchanged.
+func Resolve(projectRoot, specID string, id int, now time.Time) (*Thread, error) {
+	specID = strings.Trim(filepath.ToSlash(specID), "/")
+	reqs, err := requirements(projectRoot, specID)
+	if err != nil {
+		return nil, err
+	}
+	path := Path(projectRoot, specID)
+	f, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range f.Comments {
+		c := &f.Comments[i]
+		if c.ID != id {
+			continue
+		}
+		if c.Resolved == "" {
+			c.Resolved = now.UTC().Format(time.RFC3339)
+			c.ResolvedBy = audit.Actor(projectRoot)
+			if err := Save(path, f); err != nil {
+				return nil, err
+			}
+		}
+		thread := locate(reqs, *c)
+
+		return &thread, nil
+	}
+
+	return nil, &specterrs.CommentNotFoundError{Spec: specID, ID: id}
+}
+
+// requirements returns the requirements of specID with their IDs and
+// content hashes.
+func requirements(projectRoot, specID string) ([]requirement, error) {
+	specPath := filepath.Join(projectRoot, "spectr", "specs", filepath.FromSlash(specID), "spec.md")
+	blocks, err := parsers.ParseRequirements(specPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("spec '%s' not found", specID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", specPath, err)
+	}
+
+	reqs := make([]requirement, len(blocks))
+	for i, block := range blocks {
+		reqs[i] = requirement{
+			id:   parsers.RequirementID(specID, i+1),
+			name: block.Name,
+			hash: contract.Hash(block.Raw),
+		}
+	}
+
+	return reqs, nil
+}
+
+// find returns the requirement named by query: its ID, or its name
+// matched ignoring case.
+func find(reqs []requirement, specID, query string) (requirement, bool) {
+	key, num, isID := parsers.ParseRequirementID(query)
+	name := parsers.NormalizeRequirementName(query)
+	for i, req := range reqs {
+		if isID && key == parsers.SpecKey(specID) && num == i+1 {
+			return req, true
+		}
+		if parsers.NormalizeRequirementName(req.name) == name {
+			return req, true
+		}
+	}
+
+	return requirement{}, false
+}
+
+// locate finds the requirement c was written on: the one with the same
+// content, else the one with the same name, else the one at the same ID.
+// Only the first keeps the comment current.
+func locate(reqs []requirement, c Comment) Thread {
+	thread := Thread{Comment: c, Outdated: true}
+	matchers := []func(requirement) bool{
+		func(req requirement) bool { return req.hash == c.Hash },
+		func(req requirement) bool {
+			return parsers.NormalizeRequirementName(req.name) ==
+				parsers.NormalizeRequirementName(c.Name)
+		},
+		func(req requirement) bool { return req.id == c.Requirement },
+	}
+	for i, match := range matchers {
+		for _, req := range reqs {
+			if match(req) {
+				thread.Current, thread.CurrentName = req.id, req.name
+				thread.Outdated = i > 0
+
+				return thread
+			}
+		}
+	}
+
+	return thread
+}
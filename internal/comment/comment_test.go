@@ -0,0 +1,161 @@
+package comment
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+const authSpec = `# Auth
+
+## Requirements
+
+### Requirement: Login
+The system SHALL authenticate users.
+
+#### Scenario: Valid password
+- **WHEN** a user signs in
+- **THEN** a session starts
+
+### Requirement: Logout
+The system SHALL end sessions.
+
+#### Scenario: Sign out
+- **WHEN** a user signs out
+- **THEN** the session ends
+`
+
+// writeSpec writes the spec.md of auth in the project at root.
+func writeSpec(t *testing.T, root, content string) {
+	t.Helper()
+
+	path := filepath.Join(root, "spectr", "specs", "auth", "spec.md")
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAdd(t *testing.T) {
+	root := t.TempDir()
+	writeSpec(t, root, authSpec)
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		query   string
+		body    string
+		want    string // Requirement ID, empty for an error
+		wantErr any
+	}{
+		{name: "by ID", query: "auth-r2", body: "Cover idle timeouts?", want: "AUTH-R2"},
+		{name: "by name", query: "login", body: "What about SSO?", want: "AUTH-R1"},
+		{
+			name:    "unknown requirement",
+			query:   "Signup",
+			body:    "?",
+			wantErr: new(*specterrs.RequirementNotFoundError),
+		},
+		{
+			name:    "empty body",
+			query:   "Login",
+			body:    "  ",
+			wantErr: new(*specterrs.EmptyCommentError),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Add(root, "auth", tt.query, tt.body, now)
+			if tt.wantErr != nil {
+				if !errors.As(err, tt.wantErr) {
+					t.Fatalf("Add() error = %v, want %T", err, tt.wantErr)
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("Add() error = %v", err)
+			}
+			if got.Requirement != tt.want || got.Current != tt.want || got.Body != tt.body {
+				t.Errorf("Add() = %+v", got)
+			}
+		})
+	}
+
+	threads, err := List(root, "auth", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(threads) != 2 || threads[0].ID != 1 || threads[1].ID != 2 {
+		t.Errorf("List() = %+v, want comments 1 and 2", threads)
+	}
+}
+
+func TestList_Locate(t *testing.T) {
+	root := t.TempDir()
+	writeSpec(t, root, authSpec)
+	now := time.Now()
+
+	for _, query := range []string{"Login", "Logout"} {
+		if _, err := Add(root, "auth", query, "Looks good", now); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Swap the requirements and reword Logout
+	login, logout, _ := strings.Cut(
+		strings.TrimPrefix(authSpec, "# Auth\n\n## Requirements\n\n"),
+		"### Requirement: Logout",
+	)
+	logout = strings.Replace("### Requirement: Logout"+logout, "end sessions", "end all sessions", 1)
+	writeSpec(t, root, "# Auth\n\n## Requirements\n\n"+logout+"\n"+login)
+
+	threads, err := List(root, "auth", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(threads) != 2 {
+		t.Fatalf("List() = %+v", threads)
+	}
+	if threads[0].Current != "AUTH-R2" || threads[0].Outdated {
+		t.Errorf("moved Login comment = %+v, want current at AUTH-R2", threads[0])
+	}
+	if threads[1].Current != "AUTH-R1" || !threads[1].Outdated {
+		t.Errorf("changed Logout comment = %+v, want outdated at AUTH-R1", threads[1])
+	}
+}
+
+func TestResolve(t *testing.T) {
+	root := t.TempDir()
+	writeSpec(t, root, authSpec)
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	if _, err := Add(root, "auth", "Login", "What about SSO?", now); err != nil {
+		t.Fatal(err)
+	}
+	got, err := Resolve(root, "auth", 1, now)
+	if err != nil || got.Resolved != "2026-10-16T12:00:00Z" {
+		t.Fatalf("Resolve() = %+v, %v", got, err)
+	}
+
+	if threads, _ := List(root, "auth", false); len(threads) != 0 {
+		t.Errorf("List() = %+v, want resolved comments left out", threads)
+	}
+	if threads, _ := List(root, "auth", true); len(threads) != 1 {
+		t.Errorf("List(all) = %+v, want the resolved comment", threads)
+	}
+
+	_, err = Resolve(root, "auth", 7, now)
+	var target *specterrs.CommentNotFoundError
+	if !errors.As(err, &target) {
+		t.Errorf("Resolve() error = %v, want CommentNotFoundError", err)
+	}
+}
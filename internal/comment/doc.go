@@ -0,0 +1,9 @@
+// Package comment stores review discussion of requirements next to the
+// specs, so it survives outside the hosting platform.
+//
+// Comments live in spectr/specs/<id>/comments.jsonc. Each is keyed by the
+// requirement ID it was written on (AUTH-R1) and a hash of the
+// requirement's content at the time. The hash finds the requirement again
+// after it moved, and marks a comment outdated once the requirement
+// changed, like review comments on a stale diff.
+package comment
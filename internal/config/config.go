@@ -3,11 +3,16 @@
 package config
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
 
+	"github.com/connerohnesorge/spectr/internal/profile"
 	"gopkg.in/yaml.v3"
 )
 
@@ -22,12 +27,406 @@ var ErrConfigMalformed = errors.New(
 
 // Config represents the root configuration structure for spectr.yaml.
 type Config struct {
+	// SpectrVersion is the spectr release that created the project.
+	SpectrVersion string `yaml:"spectr_version"`
+	// MinSpectrVersion is the oldest spectr release that can read the
+	// project; older releases refuse to run against it.
+	MinSpectrVersion string `yaml:"min_spectr_version"`
 	// AppendTasks defines tasks to automatically append during accept.
 	AppendTasks *AppendTasksConfig `yaml:"append_tasks"`
 	// RefsAlwaysPrepend defines tasks to prepend to each child task file (v2 format).
 	RefsAlwaysPrepend *RefsTasksConfig `yaml:"refs_always_prepend"`
 	// RefsAlwaysAppend defines tasks to append to each child task file (v2 format).
 	RefsAlwaysAppend *RefsTasksConfig `yaml:"refs_always_append"`
+	// Git configures how spectr talks to git.
+	Git *GitConfig `yaml:"git"`
+	// Frozen lists requirements that changes may not modify or remove
+	// without an override annotation in proposal.md.
+	Frozen []FrozenRequirement `yaml:"frozen"`
+	// List configures the default output of spectr list.
+	List *ListConfig `yaml:"list"`
+	// Owners assigns specs to the teams that own them.
+	Owners []SpecOwner `yaml:"owners"`
+	// IO configures how project files are read during scans.
+	IO *IOConfig `yaml:"io"`
+	// Quality configures the spec quality score.
+	Quality *QualityConfig `yaml:"quality"`
+	// Templates configures user templates such as the pull request body.
+	Templates *TemplatesConfig `yaml:"templates"`
+	// Scenarios configures how scenario steps are written.
+	Scenarios *ScenariosConfig `yaml:"scenarios"`
+	// Deltas configures how delta spec sections are titled.
+	Deltas *DeltasConfig `yaml:"deltas"`
+	// Budgets caps the size of a change before validation suggests
+	// splitting it.
+	Budgets *BudgetsConfig `yaml:"budgets"`
+	// Review sets how often specs are due for re-review.
+	Review *ReviewConfig `yaml:"review"`
+	// Extends pulls in a shared profile whose settings this file
+	// overrides.
+	Extends *ProfileSource `yaml:"extends"`
+	// Evidence sets what acceptance evidence (spectr evidence attach)
+	// requirements need.
+	Evidence *EvidenceConfig `yaml:"evidence"`
+	// Validation configures how spectr validate reports issues.
+	Validation *ValidationConfig `yaml:"validation"`
+	// Kinds declares custom item kinds, such as ADRs or runbooks, managed
+	// alongside specs and changes.
+	Kinds []KindConfig `yaml:"kinds"`
+	// ChangeIDs is the naming policy change IDs are validated against.
+	ChangeIDs *ChangeIDsConfig `yaml:"change_ids"`
+
+	// path is the file the config was loaded from.
+	path string
+	// unknownKeys are top-level keys this release does not understand,
+	// usually settings added by a newer release.
+	unknownKeys []string
+}
+
+// IOConfig defines how spectr reads project files.
+type IOConfig struct {
+	// Mmap memory-maps large files instead of reading them.
+	Mmap bool `yaml:"mmap"`
+	// PrefetchWorkers is the number of parallel reads used to prefetch a
+	// project before a scan. Zero uses the default.
+	PrefetchWorkers int `yaml:"prefetch_workers"`
+}
+
+// TemplatesConfig defines where user templates are found and the
+// variables they can use.
+type TemplatesConfig struct {
+	// Dirs are extra template directories, searched after
+	// spectr/templates/ so a project overrides a shared base. Relative
+	// paths are resolved against the directory of spectr.yaml, and
+	// ${VAR} references are expanded from the environment.
+	Dirs []string `yaml:"dirs"`
+	// Vars are values templates read with {{var "name"}}. ${VAR}
+	// references are expanded from the environment.
+	Vars map[string]string `yaml:"vars"`
+	// Strict makes undefined variables and environment variables an
+	// error instead of an empty string.
+	Strict bool `yaml:"strict"`
+}
+
+// ScenariosConfig defines how scenario steps are written.
+type ScenariosConfig struct {
+	// Keywords maps step keywords (GIVEN, WHEN, THEN, AND, BUT) to the
+	// words a team writes instead, e.g. WHEN: [QUAND, 当]. Steps written
+	// with them are read as the keyword they stand for.
+	Keywords map[string][]string `yaml:"keywords"`
+}
+
+// DeltasConfig defines how delta spec sections are titled.
+type DeltasConfig struct {
+	// Aliases maps delta operations (ADDED, MODIFIED, REMOVED, RENAMED)
+	// to other section titles a team writes, e.g. ADDED: [Additions].
+	// Sections titled with them are read as that operation.
+	Aliases map[string][]string `yaml:"aliases"`
+}
+
+// BudgetsConfig defines the size limits of a single change. Zero leaves a
+// limit off.
+type BudgetsConfig struct {
+	// MaxDeltas is the most requirement deltas (ADDED, MODIFIED, REMOVED
+	// and RENAMED requirements) a change should have.
+	MaxDeltas int `yaml:"max_deltas"`
+	// MaxTasks is the most tasks a change should have.
+	MaxTasks int `yaml:"max_tasks"`
+	// MaxSpecs is the most specs a change should touch.
+	MaxSpecs int `yaml:"max_specs"`
+}
+
+// ReviewConfig sets how many days a spec may go without a review, as
+// recorded by last_reviewed in its frontmatter. Zero means no scheduled
+// review.
+type ReviewConfig struct {
+	// IntervalDays applies to specs without a tag listed in Tags.
+	IntervalDays int `yaml:"interval_days"`
+	// Tags maps a spec tag to its interval; a spec with several listed
+	// tags uses the shortest.
+	Tags map[string]int `yaml:"tags"`
+}
+
+// EvidenceConfig holds the evidence policy of a project.
+type EvidenceConfig struct {
+	// RequireForImplemented makes validation reject a requirement marked
+	// status: implemented while one of its scenarios has no evidence.
+	RequireForImplemented bool `yaml:"require_for_implemented"`
+}
+
+// ValidationConfig configures validation output.
+type ValidationConfig struct {
+	// MaxErrors is the number of issues printed per item before the rest
+	// are summarized. Unset uses the default; zero prints them all.
+	MaxErrors *int `yaml:"max_errors"`
+}
+
+// KindConfig declares a custom item kind. Each item is a markdown file
+// in Dir.
+type KindConfig struct {
+	// Name identifies the kind on the command line, e.g. "adr".
+	Name string `yaml:"name"`
+	// Dir is the directory holding the items, relative to the project
+	// root, e.g. "spectr/adrs".
+	Dir string `yaml:"dir"`
+	// Title is the display name, e.g. "Architecture decision records".
+	Title string `yaml:"title"`
+	// Sections are the ## sections every item must have.
+	Sections []string `yaml:"sections"`
+	// Template is a markdown file, relative to the project root, that new
+	// items start from.
+	Template string `yaml:"template"`
+	// Validation is the validation profile: "sections" (the default),
+	// "spec" or "none".
+	Validation string `yaml:"validation"`
+}
+
+// ChangeIDsConfig is the naming policy of change IDs, enforced by spectr
+// validate and spectr new change.
+type ChangeIDsConfig struct {
+	// Pattern is a regular expression every change ID must match. Empty
+	// means kebab-case.
+	Pattern string `yaml:"pattern"`
+	// ReservedPrefixes are prefixes change IDs must not start with, e.g.
+	// "tmp-" or "release-".
+	ReservedPrefixes []string `yaml:"reserved_prefixes"`
+}
+
+// ProfileSource locates a shared profile: a spectr.yaml published in a
+// git repository or an HTTPS tarball and pinned by its SHA-256.
+type ProfileSource struct {
+	// URL is an HTTPS tarball or a git repository.
+	URL string `yaml:"url"`
+	// Ref is the branch, tag or commit of a git repository.
+	Ref string `yaml:"ref"`
+	// Path is the profile file in the bundle, spectr.yaml by default.
+	Path string `yaml:"path"`
+	// SHA256 is the hex SHA-256 of the profile file.
+	SHA256 string `yaml:"sha256"`
+}
+
+// QualityConfig defines how the spec quality score is computed.
+type QualityConfig struct {
+	// Weights override the default weight of each part of the score.
+	Weights *QualityWeights `yaml:"weights"`
+}
+
+// QualityWeights are the relative weights of the parts of the spec quality
+// score. Unset weights keep their defaults; zero leaves a part out.
+type QualityWeights struct {
+	// Lint weighs the validation errors and warnings of the spec.
+	Lint *float64 `yaml:"lint"`
+	// Coverage weighs the share of requirements with implementation
+	// markers.
+	Coverage *float64 `yaml:"coverage"`
+	// Scenarios weighs the number of scenarios per requirement.
+	Scenarios *float64 `yaml:"scenarios"`
+	// Links weighs the share of wikilinks that resolve.
+	Links *float64 `yaml:"links"`
+}
+
+// SpecOwner assigns a spec, or a directory of nested specs, to an owner.
+type SpecOwner struct {
+	// Spec is a spec ID such as "payments/refunds", or a directory such as
+	// "payments" that covers every spec nested beneath it.
+	Spec string `yaml:"spec"`
+	// Owner is the owning team or person, e.g. "@acme/payments".
+	Owner string `yaml:"owner"`
+}
+
+// ListConfig defines the defaults for spectr list.
+type ListConfig struct {
+	// Columns are the change columns shown when --columns is not given.
+	Columns []string `yaml:"columns"`
+	// SpecColumns are the spec columns shown when --columns is not given.
+	SpecColumns []string `yaml:"spec_columns"`
+}
+
+// FrozenRequirement pins a single requirement of a spec.
+type FrozenRequirement struct {
+	// Spec is the capability ID, e.g. "auth".
+	Spec string `yaml:"spec"`
+	// Requirement is the requirement name as written after "### Requirement:".
+	Requirement string `yaml:"requirement"`
+	// Reason explains why the requirement is frozen (e.g. a compliance control).
+	Reason string `yaml:"reason"`
+}
+
+// GitConfig defines the git integration settings.
+type GitConfig struct {
+	// Backend selects the git implementation ("exec" or "go-git").
+	// Defaults to "exec", which shells out to the git binary.
+	Backend string `yaml:"backend"`
+	// ChangeBranches are branch name patterns containing {change}, used to
+	// detect the current change from the checked-out branch. Defaults to
+	// DefaultChangeBranches.
+	ChangeBranches []string `yaml:"change_branches"`
+}
+
+// DefaultChangeBranches are the branch patterns that name a change when
+// git.change_branches is not set. The second matches the branches created
+// by spectr worktree.
+var DefaultChangeBranches = []string{
+	"spectr/{change}",
+	"spectr/change/{change}",
+}
+
+// GitBackend returns the configured git backend, or "" for the default.
+func (c *Config) GitBackend() string {
+	if c == nil || c.Git == nil {
+		return ""
+	}
+
+	return c.Git.Backend
+}
+
+// ChangeBranchPatterns returns the configured change branch patterns, or
+// DefaultChangeBranches when none are set.
+func (c *Config) ChangeBranchPatterns() []string {
+	if c == nil || c.Git == nil || len(c.Git.ChangeBranches) == 0 {
+		return DefaultChangeBranches
+	}
+
+	return c.Git.ChangeBranches
+}
+
+// ListColumns returns the configured default change columns, or nil to
+// use the built-in list output.
+func (c *Config) ListColumns() []string {
+	if c == nil || c.List == nil {
+		return nil
+	}
+
+	return c.List.Columns
+}
+
+// ListSpecColumns returns the configured default spec columns, or nil to
+// use the built-in list output.
+func (c *Config) ListSpecColumns() []string {
+	if c == nil || c.List == nil {
+		return nil
+	}
+
+	return c.List.SpecColumns
+}
+
+// IOOptions returns the configured file reading settings, or the zero
+// value for the defaults.
+func (c *Config) IOOptions() IOConfig {
+	if c == nil || c.IO == nil {
+		return IOConfig{}
+	}
+
+	return *c.IO
+}
+
+// TemplateSettings returns the template configuration, or the zero value
+// when none is set.
+func (c *Config) TemplateSettings() TemplatesConfig {
+	if c == nil || c.Templates == nil {
+		return TemplatesConfig{}
+	}
+
+	return *c.Templates
+}
+
+// ScenarioKeywords returns the configured scenario keyword aliases, or nil
+// when none are set.
+func (c *Config) ScenarioKeywords() map[string][]string {
+	if c == nil || c.Scenarios == nil {
+		return nil
+	}
+
+	return c.Scenarios.Keywords
+}
+
+// DeltaAliases returns the configured delta section title aliases, or nil
+// when none are set.
+func (c *Config) DeltaAliases() map[string][]string {
+	if c == nil || c.Deltas == nil {
+		return nil
+	}
+
+	return c.Deltas.Aliases
+}
+
+// ChangeBudgets returns the configured change size limits, or the zero
+// value (no limits) when none are set.
+func (c *Config) ChangeBudgets() BudgetsConfig {
+	if c == nil || c.Budgets == nil {
+		return BudgetsConfig{}
+	}
+
+	return *c.Budgets
+}
+
+// MaxErrors returns the configured number of issues printed per item,
+// or def when spectr.yaml does not set one.
+func (c *Config) MaxErrors(def int) int {
+	if c == nil || c.Validation == nil || c.Validation.MaxErrors == nil {
+		return def
+	}
+
+	return *c.Validation.MaxErrors
+}
+
+// ReviewInterval returns the review interval in days for a spec with
+// tags, or 0 when the spec needs no scheduled review.
+func (c *Config) ReviewInterval(tags []string) int {
+	if c == nil || c.Review == nil {
+		return 0
+	}
+
+	interval := 0
+	for _, tag := range tags {
+		days := c.Review.Tags[tag]
+		if days > 0 && (interval == 0 || days < interval) {
+			interval = days
+		}
+	}
+	if interval > 0 {
+		return interval
+	}
+
+	return c.Review.IntervalDays
+}
+
+// Dir returns the directory containing the loaded spectr.yaml.
+func (c *Config) Dir() string {
+	if c == nil {
+		return ""
+	}
+
+	return filepath.Dir(c.path)
+}
+
+// QualityWeights returns the configured quality score weights, or the
+// zero value when none are set.
+func (c *Config) QualityWeights() QualityWeights {
+	if c == nil || c.Quality == nil || c.Quality.Weights == nil {
+		return QualityWeights{}
+	}
+
+	return *c.Quality.Weights
+}
+
+// SpecOwner returns the owner of specID, or "" if no entry covers it. The
+// most specific entry wins, so "payments/refunds" overrides "payments".
+func (c *Config) SpecOwner(specID string) string {
+	if c == nil {
+		return ""
+	}
+
+	owner, matched := "", ""
+	for _, entry := range c.Owners {
+		spec := strings.Trim(entry.Spec, "/")
+		covers := specID == spec || strings.HasPrefix(specID, spec+"/")
+		if covers && len(spec) > len(matched) {
+			owner, matched = entry.Owner, spec
+		}
+	}
+
+	return owner
 }
 
 // AppendTasksConfig defines the configuration for auto-appending tasks.
@@ -91,6 +490,29 @@ func LoadConfig(
 	return parseConfigFile(configPath)
 }
 
+// LoadExtends returns the extends entry of the spectr.yaml found from
+// startDir without fetching the profile, or nil when there is no config
+// or it extends nothing.
+func LoadExtends(startDir string) (*ProfileSource, error) {
+	configPath, err := findConfigFile(startDir)
+	if err != nil || configPath == "" {
+		return nil, err
+	}
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var cfg struct {
+		Extends *ProfileSource `yaml:"extends"`
+	}
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrConfigMalformed, configPath, err)
+	}
+
+	return cfg.Extends, nil
+}
+
 // findConfigFile walks up from startDir to find spectr.yaml.
 // Returns empty string if not found (not an error).
 func findConfigFile(
@@ -146,6 +568,119 @@ func parseConfigFile(
 			err,
 		)
 	}
+	if cfg.Extends != nil {
+		if data, err = extendProfile(cfg.Extends, data); err != nil {
+			return nil, fmt.Errorf("%s: extends: %w", path, err)
+		}
+		cfg = Config{}
+		if err := yaml.Unmarshal(data, &cfg); err != nil {
+			return nil, fmt.Errorf(
+				"%w: %s: %v",
+				ErrConfigMalformed,
+				path,
+				err,
+			)
+		}
+	}
+	cfg.path = path
+	cfg.unknownKeys = unknownTopLevelKeys(data)
 
 	return &cfg, nil
 }
+
+// extendProfile loads the profile src points at and returns data merged
+// over it. Mappings merge key by key, so a project can override a single
+// setting of a profile section; any other value in data replaces the
+// profile's. A profile's own extends is ignored.
+func extendProfile(src *ProfileSource, data []byte) ([]byte, error) {
+	base, err := profile.Load(context.Background(), profile.Source{
+		URL:    src.URL,
+		Ref:    src.Ref,
+		Path:   src.Path,
+		SHA256: src.SHA256,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var baseDoc, localDoc yaml.Node
+	if err := yaml.Unmarshal(base, &baseDoc); err != nil {
+		return nil, fmt.Errorf("%w: profile %s: %v", ErrConfigMalformed, src.URL, err)
+	}
+	if err := yaml.Unmarshal(data, &localDoc); err != nil {
+		return nil, err
+	}
+	if len(baseDoc.Content) == 0 || baseDoc.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("%w: profile %s is not a mapping", ErrConfigMalformed, src.URL)
+	}
+
+	merged := baseDoc.Content[0]
+	deleteKey(merged, "extends")
+	mergeNodes(merged, localDoc.Content[0])
+
+	return yaml.Marshal(merged)
+}
+
+// mergeNodes merges the mapping overlay into base in place.
+func mergeNodes(base, overlay *yaml.Node) {
+	for i := 0; i < len(overlay.Content); i += 2 {
+		key, value := overlay.Content[i], overlay.Content[i+1]
+		j := keyIndex(base, key.Value)
+		switch {
+		case j < 0:
+			base.Content = append(base.Content, key, value)
+		case base.Content[j+1].Kind == yaml.MappingNode && value.Kind == yaml.MappingNode:
+			mergeNodes(base.Content[j+1], value)
+		default:
+			base.Content[j+1] = value
+		}
+	}
+}
+
+// keyIndex returns the index of key in the Content of the mapping node,
+// or -1. Only keys are searched, not values equal to key.
+func keyIndex(mapping *yaml.Node, key string) int {
+	for i := 0; i < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// deleteKey removes key from the mapping node.
+func deleteKey(mapping *yaml.Node, key string) {
+	if i := keyIndex(mapping, key); i >= 0 {
+		mapping.Content = append(mapping.Content[:i], mapping.Content[i+2:]...)
+	}
+}
+
+// unknownTopLevelKeys returns the top-level keys of a spectr.yaml document
+// that do not map to a Config field, in document order.
+func unknownTopLevelKeys(data []byte) []string {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil ||
+		len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil
+	}
+
+	var known []string
+	configType := reflect.TypeFor[Config]()
+	for i := range configType.NumField() {
+		tag, _, _ := strings.Cut(configType.Field(i).Tag.Get("yaml"), ",")
+		if tag != "" {
+			known = append(known, tag)
+		}
+	}
+
+	var unknown []string
+	mapping := doc.Content[0]
+	for i := 0; i < len(mapping.Content); i += 2 {
+		if key := mapping.Content[i].Value; !slices.Contains(known, key) {
+			unknown = append(unknown, key)
+		}
+	}
+
+	return unknown
+}
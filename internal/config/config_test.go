@@ -1,11 +1,16 @@
 package config
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
 
 	"github.com/alecthomas/assert/v2"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+	"gopkg.in/yaml.v3"
 )
 
 func TestLoadConfig_ValidConfig(t *testing.T) {
@@ -424,3 +429,260 @@ func TestRefsTasksConfig_NilReceiver(t *testing.T) {
 	var cfg *RefsTasksConfig
 	assert.False(t, cfg.HasTasks())
 }
+
+func TestLoadConfig_GitBackend(t *testing.T) {
+	tmpDir := t.TempDir()
+	err := os.WriteFile(
+		filepath.Join(tmpDir, "spectr.yaml"),
+		[]byte("git:\n  backend: go-git\n"),
+		0o644,
+	)
+	assert.NoError(t, err)
+
+	cfg, err := LoadConfig(tmpDir)
+	assert.NoError(t, err)
+	assert.Equal(t, "go-git", cfg.GitBackend())
+}
+
+func TestConfig_GitBackendDefault(t *testing.T) {
+	var nilCfg *Config
+	assert.Equal(t, "", nilCfg.GitBackend())
+	assert.Equal(t, "", (&Config{}).GitBackend())
+}
+
+func TestLoadConfig_ChangeBranches(t *testing.T) {
+	tmpDir := t.TempDir()
+	err := os.WriteFile(
+		filepath.Join(tmpDir, "spectr.yaml"),
+		[]byte("git:\n  change_branches:\n    - feature/{change}\n"),
+		0o644,
+	)
+	assert.NoError(t, err)
+
+	cfg, err := LoadConfig(tmpDir)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"feature/{change}"}, cfg.ChangeBranchPatterns())
+}
+
+func TestConfig_ChangeBranchesDefault(t *testing.T) {
+	var nilCfg *Config
+	assert.Equal(t, DefaultChangeBranches, nilCfg.ChangeBranchPatterns())
+	assert.Equal(t, DefaultChangeBranches, (&Config{Git: &GitConfig{}}).ChangeBranchPatterns())
+}
+
+func TestLoadConfig_ListColumns(t *testing.T) {
+	tmpDir := t.TempDir()
+	err := os.WriteFile(
+		filepath.Join(tmpDir, "spectr.yaml"),
+		[]byte("list:\n  columns: [id, owner]\n  spec_columns: [id, requirements]\n"),
+		0o644,
+	)
+	assert.NoError(t, err)
+
+	cfg, err := LoadConfig(tmpDir)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"id", "owner"}, cfg.ListColumns())
+	assert.Equal(t, []string{"id", "requirements"}, cfg.ListSpecColumns())
+
+	var nilCfg *Config
+	assert.Equal(t, 0, len(nilCfg.ListColumns()))
+	assert.Equal(t, 0, len((&Config{}).ListSpecColumns()))
+}
+
+func TestLoadConfig_IO(t *testing.T) {
+	tmpDir := t.TempDir()
+	err := os.WriteFile(
+		filepath.Join(tmpDir, "spectr.yaml"),
+		[]byte("io:\n  mmap: true\n  prefetch_workers: 32\n"),
+		0o644,
+	)
+	assert.NoError(t, err)
+
+	cfg, err := LoadConfig(tmpDir)
+	assert.NoError(t, err)
+	assert.Equal(t, IOConfig{Mmap: true, PrefetchWorkers: 32}, cfg.IOOptions())
+
+	var nilCfg *Config
+	assert.Equal(t, IOConfig{}, nilCfg.IOOptions())
+}
+
+func TestConfig_SpecOwner(t *testing.T) {
+	cfg := &Config{Owners: []SpecOwner{
+		{Spec: "payments", Owner: "@acme/payments"},
+		{Spec: "payments/refunds/", Owner: "@acme/refunds"},
+		{Spec: "auth", Owner: "@acme/identity"},
+	}}
+
+	tests := []struct {
+		spec string
+		want string
+	}{
+		{"payments", "@acme/payments"},
+		{"payments/payouts", "@acme/payments"},
+		{"payments/refunds", "@acme/refunds"},
+		{"payments/refunds/disputes", "@acme/refunds"},
+		{"payments-legacy", ""},
+		{"auth", "@acme/identity"},
+		{"notifications", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			assert.Equal(t, tt.want, cfg.SpecOwner(tt.spec))
+		})
+	}
+
+	var nilCfg *Config
+	assert.Equal(t, "", nilCfg.SpecOwner("auth"))
+}
+
+func TestConfig_ReviewInterval(t *testing.T) {
+	cfg := &Config{Review: &ReviewConfig{
+		IntervalDays: 365,
+		Tags:         map[string]int{"compliance": 90, "security": 30, "ui": 0},
+	}}
+
+	tests := []struct {
+		name string
+		tags []string
+		want int
+	}{
+		{"untagged", nil, 365},
+		{"unknown tag", []string{"search"}, 365},
+		{"tagged", []string{"compliance"}, 90},
+		{"shortest tag wins", []string{"compliance", "security"}, 30},
+		{"zero tag interval ignored", []string{"ui"}, 365},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, cfg.ReviewInterval(tt.tags))
+		})
+	}
+
+	var nilCfg *Config
+	assert.Equal(t, 0, nilCfg.ReviewInterval([]string{"compliance"}))
+}
+
+func TestConfig_MaxErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+		want int
+	}{
+		{"unset", "", 100},
+		{"section without limit", "validation: {}\n", 100},
+		{"limit", "validation:\n  max_errors: 20\n", 20},
+		{"zero shows all", "validation:\n  max_errors: 0\n", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			err := os.WriteFile(filepath.Join(dir, "spectr.yaml"), []byte(tt.yaml), 0o644)
+			assert.NoError(t, err)
+
+			cfg, err := LoadConfig(dir)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, cfg.MaxErrors(100))
+		})
+	}
+}
+
+func TestLoadConfig_Extends(t *testing.T) {
+	base := "budgets:\n  max_tasks: 20\n  max_specs: 3\nreview:\n  interval_days: 180\n" +
+		"extends:\n  url: https://ignored.example/p.tar.gz\n  sha256: x\n"
+	sum := sha256.Sum256([]byte(base))
+	checksum := hex.EncodeToString(sum[:])
+
+	// A verified profile in the cache is used without fetching
+	cacheDir := t.TempDir()
+	t.Setenv("SPECTR_CACHE_DIR", cacheDir)
+	assert.NoError(t, os.MkdirAll(filepath.Join(cacheDir, "profiles"), 0o755))
+	assert.NoError(t, os.WriteFile(
+		filepath.Join(cacheDir, "profiles", checksum+".yaml"),
+		[]byte(base),
+		0o644,
+	))
+
+	tmpDir := t.TempDir()
+	err := os.WriteFile(
+		filepath.Join(tmpDir, "spectr.yaml"),
+		[]byte("extends:\n  url: https://platform.example/profile.tar.gz\n  sha256: "+checksum+
+			"\nbudgets:\n  max_tasks: 40\n"),
+		0o644,
+	)
+	assert.NoError(t, err)
+
+	cfg, err := LoadConfig(tmpDir)
+	assert.NoError(t, err)
+	assert.Equal(t, &BudgetsConfig{MaxTasks: 40, MaxSpecs: 3}, cfg.Budgets)
+	assert.Equal(t, 180, cfg.ReviewInterval(nil))
+	assert.Equal(t, "https://platform.example/profile.tar.gz", cfg.Extends.URL)
+	assert.Equal(t, 0, len(cfg.unknownKeys))
+
+	ext, err := LoadExtends(tmpDir)
+	assert.NoError(t, err)
+	assert.Equal(t, checksum, ext.SHA256)
+}
+
+func TestLoadConfig_ExtendsChecksumMismatch(t *testing.T) {
+	tmpDir := t.TempDir()
+	err := os.WriteFile(
+		filepath.Join(tmpDir, "spectr.yaml"),
+		[]byte("extends:\n  url: https://platform.example/profile.tar.gz\n  sha256: nothex\n"),
+		0o644,
+	)
+	assert.NoError(t, err)
+
+	_, err = LoadConfig(tmpDir)
+	var target *specterrs.InvalidProfileSourceError
+	assert.True(t, errors.As(err, &target))
+}
+
+func TestMergeNodes(t *testing.T) {
+	tests := []struct {
+		name    string
+		base    string
+		overlay string
+		want    string
+	}{
+		{
+			name:    "overrides a key",
+			base:    "a: 1\nb: 2\n",
+			overlay: "b: 3\n",
+			want:    "a: 1\nb: 3\n",
+		},
+		{
+			name:    "key equal to an earlier value",
+			base:    "mode: strict\nstrict: false\n",
+			overlay: "strict: true\n",
+			want:    "mode: strict\nstrict: true\n",
+		},
+		{
+			name:    "nested mappings merge",
+			base:    "git:\n  backend: exec\n",
+			overlay: "git:\n  change_branches: [\"{change}\"]\n",
+			want:    "git:\n    backend: exec\n    change_branches: [\"{change}\"]\n",
+		},
+		{
+			name:    "new key only present as a value",
+			base:    "owner: docs\n",
+			overlay: "docs: true\n",
+			want:    "owner: docs\ndocs: true\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var base, overlay yaml.Node
+			assert.NoError(t, yaml.Unmarshal([]byte(tt.base), &base))
+			assert.NoError(t, yaml.Unmarshal([]byte(tt.overlay), &overlay))
+
+			mergeNodes(base.Content[0], overlay.Content[0])
+			got, err := yaml.Marshal(base.Content[0])
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
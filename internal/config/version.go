@@ -0,0 +1,47 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+	"github.com/connerohnesorge/spectr/internal/version"
+)
+
+// CheckVersion checks the project against the running spectr version. It
+// returns an IncompatibleVersionError when spectr.yaml requires a newer
+// release, and warnings when the project was created by a newer release or
+// sets keys this release would silently ignore; warnings are returned
+// alongside the error for callers that run anyway. Local builds whose version
+// is not a release skip the version comparisons.
+func (c *Config) CheckVersion(running string) (warnings []string, err error) {
+	if c == nil {
+		return nil, nil
+	}
+
+	if cmp, ok := version.Compare(running, c.MinSpectrVersion); ok && cmp < 0 {
+		err = &specterrs.IncompatibleVersionError{
+			Path:     c.path,
+			Required: c.MinSpectrVersion,
+			Running:  running,
+		}
+	} else if cmp, ok := version.Compare(running, c.SpectrVersion); ok && cmp < 0 {
+		warnings = append(warnings, fmt.Sprintf(
+			"%s was created by spectr %s, newer than this %s; "+
+				"upgrade spectr if results look wrong",
+			c.path,
+			c.SpectrVersion,
+			running,
+		))
+	}
+
+	if len(c.unknownKeys) > 0 {
+		warnings = append(warnings, fmt.Sprintf(
+			"%s: ignoring settings this spectr does not understand: %s",
+			c.path,
+			strings.Join(c.unknownKeys, ", "),
+		))
+	}
+
+	return warnings, err
+}
@@ -0,0 +1,108 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+func TestCheckVersion(t *testing.T) {
+	tests := []struct {
+		name         string
+		yaml         string
+		running      string
+		wantErr      bool
+		wantWarnings []string // substrings, one per warning
+	}{
+		{
+			name:    "same version",
+			yaml:    "spectr_version: v1.2.0\nmin_spectr_version: v1.0.0\n",
+			running: "v1.2.0",
+		},
+		{
+			name:    "no stamp",
+			yaml:    "list:\n  columns: [id]\n",
+			running: "v1.2.0",
+		},
+		{
+			name:         "requires newer",
+			yaml:         "spectr_version: v2.0.0\nmin_spectr_version: v2.0.0\nlints: {}\n",
+			running:      "v1.9.3",
+			wantErr:      true,
+			wantWarnings: []string{"does not understand: lints"},
+		},
+		{
+			name:         "created by newer",
+			yaml:         "spectr_version: v1.4.0\nmin_spectr_version: v1.0.0\n",
+			running:      "v1.2.0",
+			wantWarnings: []string{"created by spectr v1.4.0"},
+		},
+		{
+			name:         "unknown settings",
+			yaml:         "spectr_version: v1.4.0\nlints:\n  strict: true\nio:\n  mmap: true\n",
+			running:      "v1.4.0",
+			wantWarnings: []string{"does not understand: lints"},
+		},
+		{
+			name:         "local build",
+			yaml:         "spectr_version: v9.0.0\nmin_spectr_version: v9.0.0\nlints: {}\n",
+			running:      "dev",
+			wantWarnings: []string{"does not understand: lints"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, "spectr.yaml")
+			if err := os.WriteFile(path, []byte(tt.yaml), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			cfg, err := LoadConfig(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			warnings, err := cfg.CheckVersion(tt.running)
+
+			var versionErr *specterrs.IncompatibleVersionError
+			if tt.wantErr != errors.As(err, &versionErr) {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && versionErr.Path != path {
+				t.Errorf("error path = %q, want %q", versionErr.Path, path)
+			}
+			if len(warnings) != len(tt.wantWarnings) {
+				t.Fatalf("warnings = %q, want %d", warnings, len(tt.wantWarnings))
+			}
+			for i, want := range tt.wantWarnings {
+				if !strings.Contains(warnings[i], want) {
+					t.Errorf("warning %q does not contain %q", warnings[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckVersion_NilConfig(t *testing.T) {
+	var cfg *Config
+	warnings, err := cfg.CheckVersion("v1.0.0")
+	if warnings != nil || err != nil {
+		t.Errorf("CheckVersion = %v, %v; want nil, nil", warnings, err)
+	}
+}
+
+func TestUnknownTopLevelKeys(t *testing.T) {
+	got := unknownTopLevelKeys([]byte("git:\n  backend: exec\nfuture: 1\nother: [a]\n"))
+	if want := []string{"future", "other"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unknownTopLevelKeys = %v, want %v", got, want)
+	}
+	if got := unknownTopLevelKeys([]byte("")); got != nil {
+		t.Errorf("unknownTopLevelKeys(empty) = %v, want nil", got)
+	}
+}
@@ -0,0 +1,257 @@
+// Package contract pins the requirements a consumer depends on. A consumer
+// project freezes requirements of a provider's spec into a contract file,
+// and later checks fail when any pinned requirement changed or went away.
+//
+// Contracts live in the consumer's spectr/contracts/<spec>.json, so they
+// are committed and reviewed next to the consumer's own specs. Each pin
+// stores a hash of the requirement with whitespace collapsed, so
+// re-wrapping text is not a change, and the frozen text itself so a
+// failed check can show what changed.
+package contract
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/connerohnesorge/spectr/internal/diff"
+	"github.com/connerohnesorge/spectr/internal/parsers"
+	"github.com/connerohnesorge/spectr/internal/snapshot"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+// File layout constants
+const (
+	// DirName is the contracts directory inside spectr/.
+	DirName  = "contracts"
+	fileExt  = ".json"
+	dirPerm  = 0o755
+	filePerm = 0o644
+)
+
+// contractVersion is the current contract file format version.
+const contractVersion = 1
+
+// Pin is one frozen requirement.
+type Pin struct {
+	Requirement string `json:"requirement"`
+	Hash        string `json:"hash"`
+	Content     string `json:"content"`
+}
+
+// Contract pins requirements of one provider spec.
+type Contract struct {
+	Version int    `json:"version"`
+	Spec    string `json:"spec"`
+	// Provider is the provider project directory, relative to the
+	// consumer project; empty when the consumer pins its own specs.
+	Provider     string `json:"provider,omitempty"`
+	Frozen       string `json:"frozen"` // RFC 3339 timestamp
+	Requirements []Pin  `json:"requirements"`
+}
+
+// DriftKind says how a pinned requirement drifted.
+type DriftKind string
+
+// Drift kinds.
+const (
+	Changed DriftKind = "changed"
+	Removed DriftKind = "removed"
+)
+
+// Drift is a pinned requirement that no longer matches the provider.
+type Drift struct {
+	Spec        string    `json:"spec"`
+	Requirement string    `json:"requirement"`
+	Kind        DriftKind `json:"kind"`
+	// Diff is the word diff from the frozen to the current text
+	Diff string `json:"diff,omitempty"`
+}
+
+// Hash returns the pin hash of requirement content. Whitespace is
+// collapsed first, so re-wrapped text hashes the same.
+func Hash(content string) string {
+	return snapshot.Hash(strings.Join(strings.Fields(content), " "))
+}
+
+// Freeze pins the requirements of specID in specsDir. With names, only
+// those requirements (matched case-insensitively) are pinned; otherwise
+// every requirement of the spec is.
+func Freeze(specsDir, specID string, names []string) (*Contract, error) {
+	path := filepath.Join(specsDir, filepath.FromSlash(specID), "spec.md")
+	reqs, err := parsers.ParseRequirements(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("spec '%s' not found", specID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	byName := make(map[string]parsers.RequirementBlock, len(reqs))
+	for _, req := range reqs {
+		byName[parsers.NormalizeRequirementName(req.Name)] = req
+	}
+
+	selected := reqs
+	if len(names) > 0 {
+		selected = make([]parsers.RequirementBlock, 0, len(names))
+		for _, name := range names {
+			req, ok := byName[parsers.NormalizeRequirementName(name)]
+			if !ok {
+				return nil, fmt.Errorf(
+					"requirement '%s' not found in spec '%s'",
+					name,
+					specID,
+				)
+			}
+			selected = append(selected, req)
+		}
+	}
+
+	c := &Contract{
+		Version: contractVersion,
+		Spec:    specID,
+		Frozen:  time.Now().UTC().Format(time.RFC3339),
+	}
+	for _, req := range selected {
+		c.Requirements = append(c.Requirements, Pin{
+			Requirement: req.Name,
+			Hash:        Hash(req.Raw),
+			Content:     req.Raw,
+		})
+	}
+
+	return c, nil
+}
+
+// Check compares the pins of c with the spec in specsDir and returns the
+// requirements that changed or were removed. A missing spec removes every
+// pinned requirement.
+func Check(c *Contract, specsDir string) ([]Drift, error) {
+	path := filepath.Join(specsDir, filepath.FromSlash(c.Spec), "spec.md")
+	reqs, err := parsers.ParseRequirements(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	current := make(map[string]parsers.RequirementBlock, len(reqs))
+	for _, req := range reqs {
+		current[parsers.NormalizeRequirementName(req.Name)] = req
+	}
+
+	var drifts []Drift
+	for _, pin := range c.Requirements {
+		req, ok := current[parsers.NormalizeRequirementName(pin.Requirement)]
+		switch {
+		case !ok:
+			drifts = append(drifts, Drift{
+				Spec:        c.Spec,
+				Requirement: pin.Requirement,
+				Kind:        Removed,
+			})
+		case Hash(req.Raw) != pin.Hash:
+			drifts = append(drifts, Drift{
+				Spec:        c.Spec,
+				Requirement: pin.Requirement,
+				Kind:        Changed,
+				Diff: diff.Render(
+					diff.Words(pin.Content, req.Raw),
+					diff.PlainStyler,
+				),
+			})
+		}
+	}
+
+	return drifts, nil
+}
+
+// Path returns the contract file of specID in spectrDir.
+func Path(spectrDir, specID string) string {
+	return filepath.Join(
+		spectrDir,
+		DirName,
+		filepath.FromSlash(specID)+fileExt,
+	)
+}
+
+// Load reads a contract file.
+func Load(path string) (*Contract, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var c Contract
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if c.Version > contractVersion {
+		return nil, &specterrs.ContractVersionError{
+			Path:    path,
+			Version: c.Version,
+		}
+	}
+
+	return &c, nil
+}
+
+// LoadAll reads every contract in spectrDir, sorted by spec. A missing
+// contracts directory yields no contracts.
+func LoadAll(spectrDir string) ([]*Contract, error) {
+	dir := filepath.Join(spectrDir, DirName)
+
+	var contracts []*Contract
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return fs.SkipAll
+			}
+
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != fileExt {
+			return nil
+		}
+
+		c, err := Load(path)
+		if err != nil {
+			return err
+		}
+		contracts = append(contracts, c)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(contracts, func(i, j int) bool {
+		return contracts[i].Spec < contracts[j].Spec
+	})
+
+	return contracts, nil
+}
+
+// Save writes c to its contract file in spectrDir and returns the path.
+func Save(spectrDir string, c *Contract) (string, error) {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal contract: %w", err)
+	}
+
+	path := Path(spectrDir, c.Spec)
+	if err := os.MkdirAll(filepath.Dir(path), dirPerm); err != nil {
+		return "", fmt.Errorf("failed to create contracts directory: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), filePerm); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	return path, nil
+}
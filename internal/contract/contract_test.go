@@ -0,0 +1,158 @@
+package contract
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+	"github.com/connerohnesorge/spectr/internal/testutil"
+)
+
+const providerSpec = `# Payments Specification
+
+## Requirements
+
+### Requirement: Refunds
+The system SHALL refund captured payments within 30 days.
+
+#### Scenario: Refund
+- **WHEN** a refund is requested
+- **THEN** the payment is refunded
+
+### Requirement: Receipts
+The system SHALL email a receipt.
+
+#### Scenario: Receipt
+- **WHEN** a payment succeeds
+- **THEN** a receipt is sent
+`
+
+func TestFreezeAndCheck(t *testing.T) {
+	specsDir := filepath.Join(t.TempDir(), "specs")
+	specPath := filepath.Join(specsDir, "payments", "spec.md")
+	testutil.WriteFile(t, specPath, providerSpec)
+
+	c, err := Freeze(specsDir, "payments", []string{"refunds"})
+	if err != nil {
+		t.Fatalf("Freeze() error = %v", err)
+	}
+	if len(c.Requirements) != 1 || c.Requirements[0].Requirement != "Refunds" {
+		t.Fatalf("pinned = %+v, want only Refunds", c.Requirements)
+	}
+
+	t.Run("unchanged", func(t *testing.T) {
+		drifts, err := Check(c, specsDir)
+		if err != nil || len(drifts) != 0 {
+			t.Errorf("Check() = %+v, %v; want no drift", drifts, err)
+		}
+	})
+
+	t.Run("rewrapped", func(t *testing.T) {
+		testutil.WriteFile(t, specPath, strings.Replace(
+			providerSpec,
+			"captured payments within",
+			"captured payments\nwithin",
+			1,
+		))
+		drifts, err := Check(c, specsDir)
+		if err != nil || len(drifts) != 0 {
+			t.Errorf("Check() = %+v, %v; re-wrapping is not a change", drifts, err)
+		}
+	})
+
+	t.Run("unpinned requirement changed", func(t *testing.T) {
+		testutil.WriteFile(t, specPath, strings.Replace(providerSpec, "email a receipt", "text a receipt", 1))
+		drifts, err := Check(c, specsDir)
+		if err != nil || len(drifts) != 0 {
+			t.Errorf("Check() = %+v, %v; want no drift", drifts, err)
+		}
+	})
+
+	t.Run("changed", func(t *testing.T) {
+		testutil.WriteFile(t, specPath, strings.Replace(providerSpec, "30 days", "14 days", 1))
+		drifts, err := Check(c, specsDir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(drifts) != 1 || drifts[0].Kind != Changed {
+			t.Fatalf("Check() = %+v, want one changed requirement", drifts)
+		}
+		if !strings.Contains(drifts[0].Diff, "[-30-] {+14+}") {
+			t.Errorf("diff = %q", drifts[0].Diff)
+		}
+	})
+
+	t.Run("removed", func(t *testing.T) {
+		if err := os.Remove(specPath); err != nil {
+			t.Fatal(err)
+		}
+		drifts, err := Check(c, specsDir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(drifts) != 1 || drifts[0].Kind != Removed {
+			t.Errorf("Check() = %+v, want one removed requirement", drifts)
+		}
+	})
+}
+
+func TestFreeze_Errors(t *testing.T) {
+	specsDir := filepath.Join(t.TempDir(), "specs")
+	testutil.WriteFile(t, filepath.Join(specsDir, "payments", "spec.md"), providerSpec)
+
+	if _, err := Freeze(specsDir, "billing", nil); err == nil {
+		t.Error("expected an error for a missing spec")
+	}
+	if _, err := Freeze(specsDir, "payments", []string{"Disputes"}); err == nil {
+		t.Error("expected an error for a missing requirement")
+	}
+}
+
+func TestSaveAndLoadAll(t *testing.T) {
+	specsDir := filepath.Join(t.TempDir(), "specs")
+	testutil.WriteFile(t, filepath.Join(specsDir, "payments", "spec.md"), providerSpec)
+	testutil.WriteFile(t, filepath.Join(specsDir, "billing", "invoices", "spec.md"), providerSpec)
+	spectrDir := t.TempDir()
+
+	for _, spec := range []string{"payments", "billing/invoices"} {
+		c, err := Freeze(specsDir, spec, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Save(spectrDir, c); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+
+	contracts, err := LoadAll(spectrDir)
+	if err != nil {
+		t.Fatalf("LoadAll() error = %v", err)
+	}
+	if len(contracts) != 2 ||
+		contracts[0].Spec != "billing/invoices" ||
+		contracts[1].Spec != "payments" {
+		t.Fatalf("LoadAll() = %+v", contracts)
+	}
+	if len(contracts[1].Requirements) != 2 {
+		t.Errorf("pins = %d, want 2", len(contracts[1].Requirements))
+	}
+
+	empty, err := LoadAll(t.TempDir())
+	if err != nil || len(empty) != 0 {
+		t.Errorf("LoadAll() without contracts = %v, %v", empty, err)
+	}
+}
+
+func TestLoad_NewerVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "payments.json")
+	testutil.WriteFile(t, path, `{"version": 99, "spec": "payments"}`)
+
+	_, err := Load(path)
+	var versionErr *specterrs.ContractVersionError
+	if !errors.As(err, &versionErr) {
+		t.Errorf("Load() error = %v, want ContractVersionError", err)
+	}
+}
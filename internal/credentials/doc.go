@@ -0,0 +1,12 @@
+// Package credentials stores and resolves hosting platform tokens.
+//
+// Every integration asks a Resolver for its token instead of reading its
+// own environment variable. Sources are tried in order:
+//
+//  1. an explicit --token flag
+//  2. provider environment variables (e.g. SPECTR_GITHUB_TOKEN, GH_TOKEN)
+//  3. tokens saved by "spectr auth login": the OS keychain (macOS security,
+//     Linux secret-tool) or, where none is available, an encrypted file in
+//     the user config directory
+//  4. git's credential helpers ("git credential fill")
+package credentials
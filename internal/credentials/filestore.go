@@ -0,0 +1,194 @@
+package credentials
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// File store names and permissions.
+const (
+	credentialsFile = "credentials.enc"
+	keyFile         = "credentials.key"
+	keySize         = 32
+	secretDirPerm   = 0o700
+	secretFilePerm  = 0o600
+)
+
+// FileStore keeps tokens in an AES-GCM encrypted file. The key lives in a
+// separate owner-only file, so the token file alone (e.g. in a backup or
+// a dotfiles repository) does not reveal tokens. It is the fallback for
+// systems without a usable keychain.
+type FileStore struct {
+	dir string
+}
+
+// NewFileStore creates a file store in dir, or in the spectr user config
+// directory ($SPECTR_CONFIG_DIR or e.g. ~/.config/spectr) when dir is
+// empty.
+func NewFileStore(dir string) (*FileStore, error) {
+	if dir == "" {
+		var err error
+		dir, err = userConfigDir()
+		if err != nil {
+			return nil, fmt.Errorf(
+				"locate config directory: %w",
+				err,
+			)
+		}
+	}
+
+	return &FileStore{dir: dir}, nil
+}
+
+// Name implements Store.
+func (s *FileStore) Name() string {
+	return "encrypted file " + filepath.Join(s.dir, credentialsFile)
+}
+
+// Get implements Store.
+func (s *FileStore) Get(
+	_ context.Context,
+	account string,
+) (string, error) {
+	tokens, err := s.load()
+	if err != nil {
+		return "", err
+	}
+
+	return tokens[account], nil
+}
+
+// Set implements Store.
+func (s *FileStore) Set(
+	_ context.Context,
+	account, token string,
+) error {
+	tokens, err := s.load()
+	if err != nil {
+		return err
+	}
+	tokens[account] = token
+
+	return s.save(tokens)
+}
+
+// Delete implements Store.
+func (s *FileStore) Delete(
+	_ context.Context,
+	account string,
+) error {
+	tokens, err := s.load()
+	if err != nil {
+		return err
+	}
+	if _, ok := tokens[account]; !ok {
+		return nil
+	}
+	delete(tokens, account)
+
+	return s.save(tokens)
+}
+
+// load decrypts the token file. A missing file yields no tokens.
+func (s *FileStore) load() (map[string]string, error) {
+	tokens := make(map[string]string)
+
+	sealed, err := os.ReadFile(filepath.Join(s.dir, credentialsFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return tokens, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read credentials: %w", err)
+	}
+
+	aead, err := s.cipher(false)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return nil, fmt.Errorf("credentials file is corrupt")
+	}
+
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt credentials: %w", err)
+	}
+	if err := json.Unmarshal(plaintext, &tokens); err != nil {
+		return nil, fmt.Errorf("parse credentials: %w", err)
+	}
+
+	return tokens, nil
+}
+
+// save encrypts tokens and replaces the token file.
+func (s *FileStore) save(tokens map[string]string) error {
+	if err := os.MkdirAll(s.dir, secretDirPerm); err != nil {
+		return fmt.Errorf("create config directory: %w", err)
+	}
+
+	aead, err := s.cipher(true)
+	if err != nil {
+		return err
+	}
+
+	plaintext, err := json.Marshal(tokens)
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return fmt.Errorf("generate nonce: %w", err)
+	}
+	sealed := aead.Seal(nonce, nonce, plaintext, nil)
+
+	path := filepath.Join(s.dir, credentialsFile)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, sealed, secretFilePerm); err != nil {
+		return fmt.Errorf("write credentials: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+
+		return fmt.Errorf("write credentials: %w", err)
+	}
+
+	return nil
+}
+
+// cipher loads the encryption key, generating it first when create is
+// set and no key exists yet.
+func (s *FileStore) cipher(create bool) (cipher.AEAD, error) {
+	path := filepath.Join(s.dir, keyFile)
+
+	key, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) && create {
+		key = make([]byte, keySize)
+		if _, err := rand.Read(key); err != nil {
+			return nil, fmt.Errorf("generate key: %w", err)
+		}
+		if err := os.WriteFile(path, key, secretFilePerm); err != nil {
+			return nil, fmt.Errorf("write key: %w", err)
+		}
+	} else if err != nil {
+		return nil, fmt.Errorf("read credentials key: %w", err)
+	}
+	if len(key) != keySize {
+		return nil, fmt.Errorf("credentials key %s is corrupt", path)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
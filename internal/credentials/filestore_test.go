@@ -0,0 +1,84 @@
+package credentials
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestFileStore_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	dir := filepath.Join(t.TempDir(), "spectr")
+	store, err := NewFileStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if token, err := store.Get(ctx, "github:github.com"); err != nil || token != "" {
+		t.Fatalf("Get on empty store = %q, %v", token, err)
+	}
+
+	if err := store.Set(ctx, "github:github.com", "secret-token"); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if err := store.Set(ctx, "gitlab:gitlab.com", "other"); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	reopened, _ := NewFileStore(dir)
+	token, err := reopened.Get(ctx, "github:github.com")
+	if err != nil || token != "secret-token" {
+		t.Fatalf("Get = %q, %v", token, err)
+	}
+
+	sealed, err := os.ReadFile(filepath.Join(dir, credentialsFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(sealed), "secret-token") {
+		t.Error("credentials file contains the plaintext token")
+	}
+
+	if runtime.GOOS != "windows" {
+		for _, name := range []string{credentialsFile, keyFile} {
+			info, err := os.Stat(filepath.Join(dir, name))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if perm := info.Mode().Perm(); perm != secretFilePerm {
+				t.Errorf("%s mode = %o, want %o", name, perm, secretFilePerm)
+			}
+		}
+	}
+
+	if err := store.Delete(ctx, "github:github.com"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if token, _ := store.Get(ctx, "github:github.com"); token != "" {
+		t.Errorf("Get after Delete = %q", token)
+	}
+	if token, _ := store.Get(ctx, "gitlab:gitlab.com"); token != "other" {
+		t.Errorf("other token = %q, want kept", token)
+	}
+}
+
+func TestFileStore_WrongKey(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	store, _ := NewFileStore(dir)
+	if err := store.Set(ctx, "github:github.com", "tok"); err != nil {
+		t.Fatal(err)
+	}
+
+	other := make([]byte, keySize)
+	if err := os.WriteFile(filepath.Join(dir, keyFile), other, secretFilePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := store.Get(ctx, "github:github.com"); err == nil {
+		t.Error("Get with wrong key succeeded")
+	}
+}
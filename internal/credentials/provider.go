@@ -0,0 +1,106 @@
+package credentials
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/git"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+// Provider identifies a service spectr authenticates against.
+type Provider string
+
+// Supported providers.
+const (
+	ProviderGitHub Provider = "github"
+	ProviderGitLab Provider = "gitlab"
+	ProviderGitea  Provider = "gitea"
+	ProviderJira   Provider = "jira"
+)
+
+// Providers lists the supported providers in display order.
+var Providers = []Provider{
+	ProviderGitHub,
+	ProviderGitLab,
+	ProviderGitea,
+	ProviderJira,
+}
+
+// envVars lists the environment variables consulted for each provider, in
+// order. The SPECTR_ variable wins over the platform CLI's own.
+var envVars = map[Provider][]string{
+	ProviderGitHub: {"SPECTR_GITHUB_TOKEN", "GH_TOKEN", "GITHUB_TOKEN"},
+	ProviderGitLab: {"SPECTR_GITLAB_TOKEN", "GITLAB_TOKEN"},
+	ProviderGitea:  {"SPECTR_GITEA_TOKEN", "GITEA_TOKEN"},
+	ProviderJira:   {"SPECTR_JIRA_TOKEN", "JIRA_API_TOKEN"},
+}
+
+// cliTokenVars are the variables each platform CLI reads its token from.
+// tea only supports its own login configuration.
+var cliTokenVars = map[Provider]string{
+	ProviderGitHub: "GH_TOKEN",
+	ProviderGitLab: "GITLAB_TOKEN",
+}
+
+// ParseProvider converts a provider name to a Provider.
+func ParseProvider(name string) (Provider, error) {
+	provider := Provider(strings.ToLower(name))
+	if !slices.Contains(Providers, provider) {
+		return "", &specterrs.UnknownProviderError{Provider: name}
+	}
+
+	return provider, nil
+}
+
+// ProviderForPlatform returns the provider for a detected git platform.
+func ProviderForPlatform(platform git.Platform) (Provider, bool) {
+	switch platform {
+	case git.PlatformGitHub:
+		return ProviderGitHub, true
+	case git.PlatformGitLab:
+		return ProviderGitLab, true
+	case git.PlatformGitea:
+		return ProviderGitea, true
+	case git.PlatformBitbucket, git.PlatformUnknown:
+		return "", false
+	}
+
+	return "", false
+}
+
+// DefaultHost returns the public host of a provider, or "" for providers
+// that are always self-hosted.
+func (p Provider) DefaultHost() string {
+	switch p {
+	case ProviderGitHub:
+		return "github.com"
+	case ProviderGitLab:
+		return "gitlab.com"
+	case ProviderGitea, ProviderJira:
+		return ""
+	}
+
+	return ""
+}
+
+// EnvVars returns the environment variables consulted for the provider.
+func (p Provider) EnvVars() []string {
+	return envVars[p]
+}
+
+// CLIEnv returns the environment entries that hand token to the
+// provider's CLI, or nil if the CLI cannot take a token that way.
+func (p Provider) CLIEnv(token string) []string {
+	name, ok := cliTokenVars[p]
+	if !ok || token == "" {
+		return nil
+	}
+
+	return []string{name + "=" + token}
+}
+
+// account is the key a token is stored under.
+func account(provider Provider, host string) string {
+	return string(provider) + ":" + host
+}
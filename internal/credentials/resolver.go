@@ -0,0 +1,197 @@
+package credentials
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/git"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+// Credential sources reported in Credential.Source.
+const (
+	SourceFlag          = "--token flag"
+	SourceGitCredential = "git credential helper"
+)
+
+// Credential is a resolved token and where it came from.
+type Credential struct {
+	Provider Provider
+	Host     string
+	Token    string
+	// Source names the source, e.g. "--token flag", "env GH_TOKEN" or a
+	// store name.
+	Source string
+}
+
+// Resolver finds tokens by walking the credential sources in order.
+type Resolver struct {
+	// Getenv reads environment variables.
+	Getenv func(key string) string
+	// Stores are consulted in order after the environment.
+	Stores []Store
+	// GitCredential asks git's credential helpers for a host's password.
+	// Nil skips the git credential helper.
+	GitCredential func(ctx context.Context, host string) (string, error)
+}
+
+// NewResolver creates a Resolver over the process environment, the
+// default stores and git's credential helpers.
+func NewResolver() *Resolver {
+	return &Resolver{
+		Getenv:        os.Getenv,
+		Stores:        DefaultStores(),
+		GitCredential: GitCredentialFill,
+	}
+}
+
+// Resolve returns the token for provider at host. flagToken is the value
+// of an explicit --token flag and wins when non-empty. An empty host uses
+// the provider's default host. Returns *specterrs.CredentialNotFoundError
+// when no source has a token.
+func (r *Resolver) Resolve(
+	ctx context.Context,
+	provider Provider,
+	host, flagToken string,
+) (Credential, error) {
+	if host == "" {
+		host = provider.DefaultHost()
+	}
+	cred := Credential{Provider: provider, Host: host}
+
+	if flagToken != "" {
+		cred.Token, cred.Source = flagToken, SourceFlag
+
+		return cred, nil
+	}
+
+	sources := make([]string, 0, len(provider.EnvVars())+len(r.Stores)+1)
+	for _, name := range provider.EnvVars() {
+		if token := r.Getenv(name); token != "" {
+			cred.Token, cred.Source = token, "env "+name
+
+			return cred, nil
+		}
+		sources = append(sources, "$"+name)
+	}
+
+	if host == "" {
+		return cred, &specterrs.ProviderHostRequiredError{
+			Provider: string(provider),
+		}
+	}
+
+	for _, store := range r.Stores {
+		token, err := store.Get(ctx, account(provider, host))
+		if err != nil {
+			return cred, fmt.Errorf("%s: %w", store.Name(), err)
+		}
+		if token != "" {
+			cred.Token, cred.Source = token, store.Name()
+
+			return cred, nil
+		}
+		sources = append(sources, store.Name())
+	}
+
+	if r.GitCredential != nil {
+		token, err := r.GitCredential(ctx, host)
+		if err != nil {
+			return cred, err
+		}
+		if token != "" {
+			cred.Token, cred.Source = token, SourceGitCredential
+
+			return cred, nil
+		}
+		sources = append(sources, SourceGitCredential)
+	}
+
+	return cred, &specterrs.CredentialNotFoundError{
+		Provider: string(provider),
+		Host:     host,
+		Sources:  sources,
+	}
+}
+
+// Save stores token for provider at host in the first store that accepts
+// it and returns that store's name. A keychain that cannot be reached
+// (e.g. no D-Bus session) falls through to the encrypted file.
+func Save(
+	ctx context.Context,
+	stores []Store,
+	provider Provider,
+	host, token string,
+) (string, error) {
+	var errs []error
+	for _, store := range stores {
+		err := store.Set(ctx, account(provider, host), token)
+		if err == nil {
+			return store.Name(), nil
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", store.Name(), err))
+	}
+	if len(errs) == 0 {
+		return "", errors.New("no credential store available")
+	}
+
+	return "", errors.Join(errs...)
+}
+
+// Forget removes the token for provider at host from every store.
+func Forget(
+	ctx context.Context,
+	stores []Store,
+	provider Provider,
+	host string,
+) error {
+	var errs []error
+	for _, store := range stores {
+		err := store.Delete(ctx, account(provider, host))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", store.Name(), err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// GitCredentialFill asks git's configured credential helpers for the
+// HTTPS password of host without prompting. Returns "" when no helper
+// knows the host.
+func GitCredentialFill(
+	ctx context.Context,
+	host string,
+) (string, error) {
+	output, err := git.DefaultExecutor().Run(ctx, git.Command{
+		Args:  []string{"credential", "fill"},
+		Stdin: strings.NewReader("protocol=https\nhost=" + host + "\n\n"),
+		Env: []string{
+			"GIT_TERMINAL_PROMPT=0",
+			"GIT_ASKPASS=",
+			"SSH_ASKPASS=",
+		},
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+
+		// git exits non-zero when no helper has the credential and
+		// prompting is disabled
+		return "", nil
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(string(output)))
+	for scanner.Scan() {
+		if password, ok := strings.CutPrefix(scanner.Text(), "password="); ok {
+			return password, nil
+		}
+	}
+
+	return "", nil
+}
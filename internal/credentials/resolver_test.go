@@ -0,0 +1,187 @@
+package credentials
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+// memoryStore is an in-memory Store.
+type memoryStore struct {
+	name   string
+	tokens map[string]string
+	err    error
+}
+
+func (m *memoryStore) Name() string { return m.name }
+
+func (m *memoryStore) Get(_ context.Context, account string) (string, error) {
+	return m.tokens[account], m.err
+}
+
+func (m *memoryStore) Set(_ context.Context, account, token string) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.tokens[account] = token
+
+	return nil
+}
+
+func (m *memoryStore) Delete(_ context.Context, account string) error {
+	delete(m.tokens, account)
+
+	return nil
+}
+
+func TestResolver_Resolve(t *testing.T) {
+	tests := []struct {
+		name       string
+		flag       string
+		env        map[string]string
+		stored     map[string]string
+		gitToken   string
+		wantToken  string
+		wantSource string
+	}{
+		{
+			name:       "flag wins",
+			flag:       "flag-token",
+			env:        map[string]string{"GH_TOKEN": "env-token"},
+			wantToken:  "flag-token",
+			wantSource: SourceFlag,
+		},
+		{
+			name: "spectr env var before CLI env var",
+			env: map[string]string{
+				"SPECTR_GITHUB_TOKEN": "spectr-token",
+				"GH_TOKEN":            "gh-token",
+			},
+			wantToken:  "spectr-token",
+			wantSource: "env SPECTR_GITHUB_TOKEN",
+		},
+		{
+			name:       "env before store",
+			env:        map[string]string{"GITHUB_TOKEN": "env-token"},
+			stored:     map[string]string{"github:github.com": "stored"},
+			wantToken:  "env-token",
+			wantSource: "env GITHUB_TOKEN",
+		},
+		{
+			name:       "store before git credential",
+			stored:     map[string]string{"github:github.com": "stored"},
+			gitToken:   "git-token",
+			wantToken:  "stored",
+			wantSource: "memory",
+		},
+		{
+			name:       "git credential last",
+			gitToken:   "git-token",
+			wantToken:  "git-token",
+			wantSource: SourceGitCredential,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stored := tt.stored
+			if stored == nil {
+				stored = map[string]string{}
+			}
+			resolver := &Resolver{
+				Getenv: func(key string) string { return tt.env[key] },
+				Stores: []Store{&memoryStore{name: "memory", tokens: stored}},
+				GitCredential: func(_ context.Context, host string) (string, error) {
+					if host != "github.com" {
+						t.Errorf("git credential host = %q", host)
+					}
+
+					return tt.gitToken, nil
+				},
+			}
+
+			cred, err := resolver.Resolve(context.Background(), ProviderGitHub, "", tt.flag)
+			if err != nil {
+				t.Fatalf("Resolve: %v", err)
+			}
+			if cred.Token != tt.wantToken || cred.Source != tt.wantSource {
+				t.Errorf(
+					"Resolve = %q from %q, want %q from %q",
+					cred.Token, cred.Source, tt.wantToken, tt.wantSource,
+				)
+			}
+		})
+	}
+}
+
+func TestResolver_NotFound(t *testing.T) {
+	resolver := &Resolver{
+		Getenv: func(string) string { return "" },
+		Stores: []Store{&memoryStore{name: "memory", tokens: map[string]string{}}},
+	}
+
+	_, err := resolver.Resolve(context.Background(), ProviderGitLab, "", "")
+
+	var notFound *specterrs.CredentialNotFoundError
+	if !errors.As(err, &notFound) {
+		t.Fatalf("err = %v, want CredentialNotFoundError", err)
+	}
+	if notFound.Host != "gitlab.com" {
+		t.Errorf("Host = %q, want gitlab.com", notFound.Host)
+	}
+	want := []string{"$SPECTR_GITLAB_TOKEN", "$GITLAB_TOKEN", "memory"}
+	if len(notFound.Sources) != len(want) {
+		t.Fatalf("Sources = %v, want %v", notFound.Sources, want)
+	}
+	for i := range want {
+		if notFound.Sources[i] != want[i] {
+			t.Errorf("Sources = %v, want %v", notFound.Sources, want)
+		}
+	}
+}
+
+func TestResolver_SelfHostedNeedsHost(t *testing.T) {
+	resolver := &Resolver{Getenv: func(string) string { return "" }}
+
+	_, err := resolver.Resolve(context.Background(), ProviderJira, "", "")
+
+	var hostRequired *specterrs.ProviderHostRequiredError
+	if !errors.As(err, &hostRequired) {
+		t.Errorf("err = %v, want ProviderHostRequiredError", err)
+	}
+}
+
+func TestSave_FallsThroughFailingStore(t *testing.T) {
+	broken := &memoryStore{name: "keychain", tokens: map[string]string{}, err: errors.New("no dbus")}
+	file := &memoryStore{name: "file", tokens: map[string]string{}}
+
+	name, err := Save(context.Background(), []Store{broken, file}, ProviderGitHub, "github.com", "tok")
+	if err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if name != "file" || file.tokens["github:github.com"] != "tok" {
+		t.Errorf("saved to %q with %v", name, file.tokens)
+	}
+}
+
+func TestParseProvider(t *testing.T) {
+	if p, err := ParseProvider("GitHub"); err != nil || p != ProviderGitHub {
+		t.Errorf("ParseProvider(GitHub) = %q, %v", p, err)
+	}
+
+	var unknown *specterrs.UnknownProviderError
+	if _, err := ParseProvider("bitbucket"); !errors.As(err, &unknown) {
+		t.Errorf("ParseProvider(bitbucket) err = %v", err)
+	}
+}
+
+func TestProvider_CLIEnv(t *testing.T) {
+	if got := ProviderGitHub.CLIEnv("tok"); len(got) != 1 || got[0] != "GH_TOKEN=tok" {
+		t.Errorf("github CLIEnv = %v", got)
+	}
+	if got := ProviderGitea.CLIEnv("tok"); got != nil {
+		t.Errorf("gitea CLIEnv = %v, want nil", got)
+	}
+}
@@ -0,0 +1,203 @@
+package credentials
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// keychainService is the service name tokens are stored under.
+const keychainService = "spectr"
+
+// Store persists tokens keyed by "<provider>:<host>".
+type Store interface {
+	// Name describes the store in user-facing messages.
+	Name() string
+	// Get returns the stored token, or "" if none is stored.
+	Get(ctx context.Context, account string) (string, error)
+	// Set stores token, replacing any previous value.
+	Set(ctx context.Context, account, token string) error
+	// Delete removes the token. Deleting a missing token is not an error.
+	Delete(ctx context.Context, account string) error
+}
+
+// DefaultStores returns the stores consulted by NewResolver: the OS
+// keychain when one is usable on this system, then the encrypted file.
+func DefaultStores() []Store {
+	var stores []Store
+	if keychain := newKeychainStore(); keychain != nil {
+		stores = append(stores, keychain)
+	}
+
+	file, err := NewFileStore("")
+	if err == nil {
+		stores = append(stores, file)
+	}
+
+	return stores
+}
+
+// keychainStore stores tokens in the OS keychain through its CLI:
+// "security" on macOS and "secret-tool" (libsecret) on Linux.
+type keychainStore struct {
+	tool string
+}
+
+// newKeychainStore returns a keychain store, or nil when the platform has
+// no supported keychain CLI.
+func newKeychainStore() *keychainStore {
+	var tool string
+	switch runtime.GOOS {
+	case "darwin":
+		tool = "security"
+	case "linux", "freebsd", "openbsd":
+		tool = "secret-tool"
+	default:
+		return nil
+	}
+
+	if _, err := exec.LookPath(tool); err != nil {
+		return nil
+	}
+
+	return &keychainStore{tool: tool}
+}
+
+// Name implements Store.
+func (*keychainStore) Name() string {
+	return "OS keychain"
+}
+
+// Get implements Store.
+func (k *keychainStore) Get(
+	ctx context.Context,
+	account string,
+) (string, error) {
+	var args []string
+	if k.tool == "security" {
+		args = []string{
+			"find-generic-password",
+			"-s", keychainService,
+			"-a", account,
+			"-w",
+		}
+	} else {
+		args = []string{
+			"lookup",
+			"service", keychainService,
+			"account", account,
+		}
+	}
+
+	output, err := exec.CommandContext(ctx, k.tool, args...).Output()
+	if err != nil {
+		// Both tools exit non-zero when the item does not exist
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return "", nil
+		}
+
+		return "", fmt.Errorf("%s: %w", k.tool, err)
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
+
+// Set implements Store.
+func (k *keychainStore) Set(
+	ctx context.Context,
+	account, token string,
+) error {
+	cmd := k.setCommand(ctx, account, token)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf(
+			"%s failed: %s",
+			k.tool,
+			strings.TrimSpace(string(output)),
+		)
+	}
+
+	return nil
+}
+
+// setCommand returns the command storing token. The token is written to
+// the command's stdin, never passed as an argument, where other users
+// could read it from the process list.
+func (k *keychainStore) setCommand(
+	ctx context.Context,
+	account, token string,
+) *exec.Cmd {
+	if k.tool == "security" {
+		// -w as the last option, without a value, makes security read
+		// the password, then its confirmation, from stdin
+		cmd := exec.CommandContext(
+			ctx,
+			k.tool,
+			"add-generic-password",
+			"-U",
+			"-s", keychainService,
+			"-a", account,
+			"-w",
+		)
+		cmd.Stdin = strings.NewReader(token + "\n" + token + "\n")
+
+		return cmd
+	}
+
+	cmd := exec.CommandContext(
+		ctx,
+		k.tool,
+		"store",
+		"--label", "spectr "+account,
+		"service", keychainService,
+		"account", account,
+	)
+	cmd.Stdin = strings.NewReader(token)
+
+	return cmd
+}
+
+// Delete implements Store.
+func (k *keychainStore) Delete(
+	ctx context.Context,
+	account string,
+) error {
+	var args []string
+	if k.tool == "security" {
+		args = []string{
+			"delete-generic-password",
+			"-s", keychainService,
+			"-a", account,
+		}
+	} else {
+		args = []string{
+			"clear",
+			"service", keychainService,
+			"account", account,
+		}
+	}
+
+	// Missing items make both tools fail; that is the desired end state
+	_ = exec.CommandContext(ctx, k.tool, args...).Run()
+
+	return nil
+}
+
+// userConfigDir returns spectr's directory in the user config directory.
+func userConfigDir() (string, error) {
+	if dir := os.Getenv("SPECTR_CONFIG_DIR"); dir != "" {
+		return dir, nil
+	}
+
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(base, "spectr"), nil
+}
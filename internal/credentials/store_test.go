@@ -0,0 +1,36 @@
+package credentials
+
+import (
+	"context"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestKeychainSetCommand_TokenOnStdin(t *testing.T) {
+	const token = "ghp_secret"
+
+	for _, tool := range []string{"security", "secret-tool"} {
+		t.Run(tool, func(t *testing.T) {
+			k := &keychainStore{tool: tool}
+			cmd := k.setCommand(context.Background(), "github:github.com", token)
+
+			if slices.ContainsFunc(cmd.Args, func(arg string) bool {
+				return strings.Contains(arg, token)
+			}) {
+				t.Errorf("Args = %v, want the token kept off the command line", cmd.Args)
+			}
+			if cmd.Stdin == nil {
+				t.Fatal("Stdin = nil, want the token")
+			}
+			stdin, err := io.ReadAll(cmd.Stdin)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.HasPrefix(string(stdin), token) {
+				t.Errorf("Stdin = %q, want it to start with the token", stdin)
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package dedupe
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/discovery"
+	"github.com/connerohnesorge/spectr/internal/fileio"
+	"github.com/connerohnesorge/spectr/internal/parsers"
+)
+
+// DefaultThreshold is the similarity at or above which pairs are reported.
+const DefaultThreshold = 0.5
+
+// estimateSlack widens the MinHash prefilter so pairs whose estimate falls
+// just short of the threshold are still scored exactly. It is about 1.6
+// standard errors of the estimate.
+const estimateSlack = 0.15
+
+// Requirement is a requirement prepared for comparison.
+type Requirement struct {
+	Spec     string
+	Name     string
+	shingles map[uint64]struct{}
+	sig      Signature
+}
+
+// NewRequirement prepares the requirement block raw of spec for
+// comparison.
+func NewRequirement(spec, name, raw string) Requirement {
+	shingles := Shingles(BodyText(raw))
+
+	return Requirement{
+		Spec:     spec,
+		Name:     name,
+		shingles: shingles,
+		sig:      NewSignature(shingles),
+	}
+}
+
+// Ref identifies a requirement in a report.
+type Ref struct {
+	Spec        string `json:"spec"`
+	Requirement string `json:"requirement"`
+}
+
+// String formats the reference as spec/Requirement Name.
+func (r Ref) String() string {
+	return r.Spec + "/" + r.Requirement
+}
+
+// Pair is two requirements with similar bodies.
+type Pair struct {
+	A          Ref     `json:"a"`
+	B          Ref     `json:"b"`
+	Similarity float64 `json:"similarity"`
+}
+
+// Load reads every requirement of every spec under specsDir. Spec IDs are
+// the directory paths relative to specsDir. Retired specs are skipped.
+func Load(specsDir string) ([]Requirement, error) {
+	var reqs []Requirement
+	err := fileio.WalkDir(
+		specsDir,
+		func(path string, d os.DirEntry, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) && path == specsDir {
+					return filepath.SkipDir
+				}
+
+				return err
+			}
+			if d.IsDir() && path == filepath.Join(specsDir, discovery.SpecArchiveDir) {
+				return filepath.SkipDir
+			}
+			if d.IsDir() || d.Name() != "spec.md" {
+				return nil
+			}
+
+			rel, err := filepath.Rel(specsDir, filepath.Dir(path))
+			if err != nil {
+				return err
+			}
+			blocks, err := parsers.ParseRequirements(path)
+			if err != nil {
+				return fmt.Errorf("failed to parse %s: %w", path, err)
+			}
+			for _, block := range blocks {
+				reqs = append(
+					reqs,
+					NewRequirement(filepath.ToSlash(rel), block.Name, block.Raw),
+				)
+			}
+
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return reqs, nil
+}
+
+// FindPairs returns the pairs of requirements whose bodies have a Jaccard
+// similarity of at least threshold, most similar first. Requirements with
+// no body text are never paired.
+func FindPairs(reqs []Requirement, threshold float64) []Pair {
+	var pairs []Pair
+	for i := range reqs {
+		if len(reqs[i].shingles) == 0 {
+			continue
+		}
+		for j := i + 1; j < len(reqs); j++ {
+			if len(reqs[j].shingles) == 0 {
+				continue
+			}
+			if reqs[i].sig.Estimate(&reqs[j].sig) < threshold-estimateSlack {
+				continue
+			}
+
+			similarity := Jaccard(reqs[i].shingles, reqs[j].shingles)
+			if similarity < threshold {
+				continue
+			}
+			pairs = append(pairs, newPair(reqs[i], reqs[j], similarity))
+		}
+	}
+
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].Similarity != pairs[j].Similarity {
+			return pairs[i].Similarity > pairs[j].Similarity
+		}
+		if pairs[i].A != pairs[j].A {
+			return pairs[i].A.String() < pairs[j].A.String()
+		}
+
+		return pairs[i].B.String() < pairs[j].B.String()
+	})
+
+	return pairs
+}
+
+// newPair orders the two requirements of a pair by reference.
+func newPair(a, b Requirement, similarity float64) Pair {
+	refA := Ref{Spec: a.Spec, Requirement: a.Name}
+	refB := Ref{Spec: b.Spec, Requirement: b.Name}
+	if strings.Compare(refB.String(), refA.String()) < 0 {
+		refA, refB = refB, refA
+	}
+
+	return Pair{A: refA, B: refB, Similarity: similarity}
+}
@@ -0,0 +1,162 @@
+package dedupe
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBodyText(t *testing.T) {
+	raw := "### Requirement: Login\n\nUsers SHALL sign in.\n\n" +
+		"#### Scenario: Valid\n\n- **WHEN** a user signs in\n"
+
+	got := BodyText(raw)
+	want := "Users SHALL sign in. WHEN  a user signs in"
+	if got != want {
+		t.Errorf("BodyText() = %q, want %q", got, want)
+	}
+}
+
+func TestShingles(t *testing.T) {
+	tests := []struct {
+		text string
+		want int
+	}{
+		{"", 0},
+		{"Hello", 1},
+		{"one two", 1},
+		{"one two three", 1},
+		{"One, two; three four!", 2},
+		{"a b c a b c", 3},
+	}
+
+	for _, tt := range tests {
+		if got := len(Shingles(tt.text)); got != tt.want {
+			t.Errorf("len(Shingles(%q)) = %d, want %d", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestJaccard(t *testing.T) {
+	a := Shingles("the system shall lock the account after five failures")
+	b := Shingles("the system shall lock the account after ten failures")
+
+	if got := Jaccard(a, a); got != 1 {
+		t.Errorf("Jaccard(a, a) = %v, want 1", got)
+	}
+	// 7 shingles each, 5 shared
+	if got, want := Jaccard(a, b), 5.0/9.0; math.Abs(got-want) > 1e-9 {
+		t.Errorf("Jaccard(a, b) = %v, want %v", got, want)
+	}
+	if got := Jaccard(nil, nil); got != 0 {
+		t.Errorf("Jaccard(nil, nil) = %v, want 0", got)
+	}
+}
+
+func TestSignatureEstimate(t *testing.T) {
+	a := Shingles(longText(0, 200))
+	b := Shingles(longText(50, 250))
+	exact := Jaccard(a, b)
+
+	sigA, sigB := NewSignature(a), NewSignature(b)
+	if got := sigA.Estimate(&sigB); math.Abs(got-exact) > estimateSlack {
+		t.Errorf("Estimate() = %v, exact %v", got, exact)
+	}
+	if got := sigA.Estimate(&sigA); got != 1 {
+		t.Errorf("Estimate() of itself = %v, want 1", got)
+	}
+}
+
+func TestLoadAndFindPairs(t *testing.T) {
+	specsDir := t.TempDir()
+	writeSpec(t, specsDir, "auth", `# Auth
+
+## Requirements
+
+### Requirement: Account Lockout
+
+The system SHALL lock an account after five consecutive failed login
+attempts and notify the account owner by email.
+
+#### Scenario: Too many failures
+
+- **WHEN** a user fails to log in five times in a row
+- **THEN** the account SHALL be locked
+
+### Requirement: Session Expiry
+
+Sessions SHALL expire after 14 days of inactivity.
+`)
+	writeSpec(t, specsDir, "platform/security", `# Security
+
+## Requirements
+
+### Requirement: Lock Accounts
+
+The system SHALL lock an account after five consecutive failed login
+attempts and notify the account owner by SMS.
+
+#### Scenario: Repeated failures
+
+- **WHEN** a user fails to log in five times in a row
+- **THEN** the account SHALL be locked
+
+### Requirement: Empty
+`)
+
+	reqs, err := Load(specsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reqs) != 4 {
+		t.Fatalf("loaded %d requirements, want 4", len(reqs))
+	}
+
+	pairs := FindPairs(reqs, DefaultThreshold)
+	if len(pairs) != 1 {
+		t.Fatalf("expected 1 pair, got %+v", pairs)
+	}
+	got := pairs[0]
+	if got.A.String() != "auth/Account Lockout" ||
+		got.B.String() != "platform/security/Lock Accounts" {
+		t.Errorf("pair = %s, %s", got.A, got.B)
+	}
+	if got.Similarity < 0.8 || got.Similarity >= 1 {
+		t.Errorf("similarity = %v, want high but below 1", got.Similarity)
+	}
+
+	if pairs := FindPairs(reqs, 1); len(pairs) != 0 {
+		t.Errorf("threshold 1 should only match identical bodies, got %+v", pairs)
+	}
+}
+
+func TestLoad_MissingDir(t *testing.T) {
+	reqs, err := Load(filepath.Join(t.TempDir(), "specs"))
+	if err != nil || len(reqs) != 0 {
+		t.Fatalf("Load() = %v, %v; want no requirements", reqs, err)
+	}
+}
+
+// longText returns the words w<from> .. w<to-1> separated by spaces.
+func longText(from, to int) string {
+	text := ""
+	for i := from; i < to; i++ {
+		text += "w" + string(rune('a'+i%26)) + string(rune('a'+i/26)) + " "
+	}
+
+	return text
+}
+
+// writeSpec writes specsDir/<id>/spec.md.
+func writeSpec(t *testing.T, specsDir, id, content string) {
+	t.Helper()
+
+	path := filepath.Join(specsDir, filepath.FromSlash(id), "spec.md")
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,9 @@
+// Package dedupe finds near-duplicate requirements across specs.
+//
+// Each requirement is reduced to the text nodes of its body and scenarios
+// (headers are ignored), split into lowercase words and turned into a set
+// of overlapping word shingles. MinHash signatures of the shingle sets
+// cheaply estimate the Jaccard similarity of every pair; pairs whose
+// estimate comes close to the threshold are then scored exactly, so the
+// reported similarity is the true Jaccard similarity of the shingle sets.
+package dedupe
@@ -0,0 +1,61 @@
+package dedupe
+
+import "math"
+
+// numHashes is the MinHash signature length. The standard error of the
+// similarity estimate is about 1/sqrt(numHashes), roughly 0.09.
+const numHashes = 128
+
+// Signature is a MinHash signature of a shingle set.
+type Signature [numHashes]uint64
+
+// hashSeeds are fixed so signatures are reproducible between runs.
+var hashSeeds = func() [numHashes]uint64 {
+	var seeds [numHashes]uint64
+	state := uint64(0x5bd1e995)
+	for i := range seeds {
+		state = splitmix64(state)
+		seeds[i] = state
+	}
+
+	return seeds
+}()
+
+// NewSignature computes the MinHash signature of shingles.
+func NewSignature(shingles map[uint64]struct{}) Signature {
+	var sig Signature
+	for i := range sig {
+		sig[i] = math.MaxUint64
+	}
+	for shingle := range shingles {
+		for i, seed := range hashSeeds {
+			if h := splitmix64(shingle ^ seed); h < sig[i] {
+				sig[i] = h
+			}
+		}
+	}
+
+	return sig
+}
+
+// Estimate returns the fraction of matching signature slots, an estimate
+// of the Jaccard similarity of the underlying sets.
+func (s *Signature) Estimate(other *Signature) float64 {
+	matches := 0
+	for i := range s {
+		if s[i] == other[i] {
+			matches++
+		}
+	}
+
+	return float64(matches) / numHashes
+}
+
+// splitmix64 is a fast, well-mixing 64-bit hash.
+func splitmix64(x uint64) uint64 {
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+
+	return x ^ (x >> 31)
+}
@@ -0,0 +1,94 @@
+package dedupe
+
+import (
+	"hash/fnv"
+	"strings"
+	"unicode"
+
+	"github.com/connerohnesorge/spectr/internal/markdown"
+)
+
+// shingleSize is the number of consecutive words in a shingle.
+const shingleSize = 3
+
+// BodyText returns the text nodes of a requirement block joined by spaces.
+// Headers, including the requirement and scenario names, are not text
+// nodes and are left out.
+func BodyText(raw string) string {
+	root, _ := markdown.Parse([]byte(raw))
+	if root == nil {
+		return ""
+	}
+
+	collector := &textCollector{}
+	_ = markdown.Walk(root, collector)
+
+	return strings.Join(collector.texts, " ")
+}
+
+// textCollector gathers the content of text nodes.
+type textCollector struct {
+	markdown.BaseVisitor
+	texts []string
+}
+
+// VisitText records the text node's content.
+func (c *textCollector) VisitText(n *markdown.NodeText) error {
+	c.texts = append(c.texts, n.Text())
+
+	return nil
+}
+
+// Shingles returns the set of hashed word shingles of text. Words are
+// lowercased runs of letters and digits. Text shorter than a shingle
+// yields a single shingle of all its words, and empty text yields none.
+func Shingles(text string) map[uint64]struct{} {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	set := make(map[uint64]struct{})
+	if len(words) == 0 {
+		return set
+	}
+	if len(words) < shingleSize {
+		set[hashWords(words)] = struct{}{}
+
+		return set
+	}
+	for i := 0; i+shingleSize <= len(words); i++ {
+		set[hashWords(words[i:i+shingleSize])] = struct{}{}
+	}
+
+	return set
+}
+
+// hashWords hashes a word sequence.
+func hashWords(words []string) uint64 {
+	h := fnv.New64a()
+	for _, word := range words {
+		_, _ = h.Write([]byte(word))
+		_, _ = h.Write([]byte{0})
+	}
+
+	return h.Sum64()
+}
+
+// Jaccard returns the Jaccard similarity of two shingle sets.
+func Jaccard(a, b map[uint64]struct{}) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 0
+	}
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+
+	shared := 0
+	for shingle := range a {
+		if _, ok := b[shingle]; ok {
+			shared++
+		}
+	}
+
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
@@ -0,0 +1,319 @@
+package demo
+
+import "sort"
+
+// sortedPaths returns the keys of files in sorted order so output and
+// errors are deterministic.
+func sortedPaths() []string {
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	return paths
+}
+
+// files maps project-relative paths to their contents.
+var files = map[string]string{
+	"spectr/project.md": `# Project Context
+
+## Purpose
+
+Acme Notes is a small note-taking web service. This project was generated
+by ` + "`spectr demo`" + ` to show how specs and changes fit together.
+
+## Tech Stack
+
+- Go HTTP API backed by PostgreSQL
+- Email delivery through an SMTP relay
+
+## Conventions
+
+- Change IDs are kebab-case and start with a verb
+- Every requirement has at least one scenario
+`,
+
+	"spectr/specs/auth/spec.md": `# Auth Specification
+
+## Purpose
+
+Describes how users sign in to Acme Notes and recover their accounts.
+
+## Requirements
+
+### Requirement: User Login
+
+The system SHALL authenticate users with an email address and password.
+
+#### Scenario: Valid credentials
+
+- **WHEN** a user submits a registered email and the correct password
+- **THEN** the system SHALL start a session for that user
+
+#### Scenario: Invalid credentials
+
+- **WHEN** a user submits an incorrect password
+- **THEN** the system SHALL reject the login without revealing which field
+  was wrong
+
+### Requirement: Session Expiry
+
+Sessions SHALL expire after 14 days of inactivity.
+
+#### Scenario: Idle session
+
+- **WHEN** a session has not been used for 14 days
+- **THEN** the next request SHALL require a new login
+
+### Requirement: Password Reset
+
+The system SHALL let users reset a forgotten password through a link sent
+to their email address.
+
+#### Scenario: Reset link sent
+
+- **WHEN** a user requests a reset for a registered email
+- **THEN** the system SHALL email a single-use link valid for one hour
+
+#### Scenario: Expired link
+
+- **WHEN** a user opens a reset link older than one hour
+- **THEN** the system SHALL reject it and offer to send a new one
+`,
+
+	"spectr/specs/notifications/spec.md": `# Notifications Specification
+
+## Purpose
+
+Describes the emails Acme Notes sends and how users control them.
+
+## Requirements
+
+### Requirement: Email Notifications
+
+The system SHALL email a user when a note is shared with them.
+
+#### Scenario: Note shared
+
+- **WHEN** another user shares a note
+- **THEN** the recipient SHALL receive an email within five minutes
+
+### Requirement: Notification Preferences
+
+Users SHALL be able to turn each notification type on or off.
+
+#### Scenario: Sharing emails disabled
+
+- **WHEN** a user disables sharing emails
+- **THEN** the system SHALL NOT email them when notes are shared
+`,
+
+	// In-flight change with accepted tasks (tasks.jsonc) and some progress.
+	twoFactorChangeDir + "/proposal.md": `# Change: Add two-factor authentication
+
+## Why
+
+Password-only accounts are being taken over through reused passwords.
+
+## What Changes
+
+- Add optional TOTP-based two-factor authentication
+- Require the second factor at login once it is enabled
+
+## Impact
+
+- Affected specs: ` + "`auth`" + `
+- Affected code: login handler, account settings
+`,
+
+	twoFactorChangeDir + "/tasks.md": `## 1. Implementation
+
+- [x] 1.1 Store TOTP secrets encrypted per user
+- [x] 1.2 Add Two-Factor Authentication enrollment page with QR code
+- [ ] 1.3 Ask for the code at login when enrolled
+
+## 2. Testing
+
+- [ ] 2.1 Test enrollment and login with valid and invalid codes
+- [ ] 2.2 Test recovery codes
+`,
+
+	twoFactorChangeDir + "/tasks.jsonc": `// Generated by: spectr accept ` + ChangeTwoFactor + `
+{
+  "version": 1,
+  "tasks": [
+    {
+      "id": "1.1",
+      "section": "Implementation",
+      "description": "Store TOTP secrets encrypted per user",
+      "status": "completed"
+    },
+    {
+      "id": "1.2",
+      "section": "Implementation",
+      "description": "Add Two-Factor Authentication enrollment page with QR code",
+      "status": "completed"
+    },
+    {
+      "id": "1.3",
+      "section": "Implementation",
+      "description": "Ask for the code at login when enrolled",
+      "status": "pending"
+    },
+    {
+      "id": "2.1",
+      "section": "Testing",
+      "description": "Test enrollment and login with valid and invalid codes",
+      "status": "pending"
+    },
+    {
+      "id": "2.2",
+      "section": "Testing",
+      "description": "Test recovery codes",
+      "status": "pending"
+    }
+  ]
+}
+`,
+
+	twoFactorChangeDir + "/specs/auth/spec.md": `## ADDED Requirements
+
+### Requirement: Two-Factor Authentication
+
+The system SHALL let users enable TOTP two-factor authentication and
+SHALL issue ten single-use recovery codes on enrollment.
+
+#### Scenario: Enrollment
+
+- **WHEN** a user scans the QR code and enters a valid code
+- **THEN** two-factor authentication SHALL be enabled for the account
+- **AND** the user SHALL be shown ten recovery codes
+
+## MODIFIED Requirements
+
+### Requirement: User Login
+
+The system SHALL authenticate users with an email address and password,
+and SHALL additionally require a TOTP code when two-factor authentication
+is enabled.
+
+#### Scenario: Valid credentials
+
+- **WHEN** a user submits a registered email and the correct password
+- **THEN** the system SHALL start a session for that user
+
+#### Scenario: Second factor required
+
+- **WHEN** a user with two-factor authentication submits a correct password
+- **THEN** the system SHALL ask for a TOTP code before starting a session
+
+#### Scenario: Invalid credentials
+
+- **WHEN** a user submits an incorrect password
+- **THEN** the system SHALL reject the login without revealing which field
+  was wrong
+`,
+
+	// In-flight change that has not been accepted yet (tasks.md only).
+	digestChangeDir + "/proposal.md": `# Change: Update notifications to a daily digest
+
+## Why
+
+Users who share many notes receive dozens of emails a day.
+
+## What Changes
+
+- Batch sharing emails into one daily digest by default
+- Keep immediate emails as an opt-in preference
+
+## Impact
+
+- Affected specs: ` + "`notifications`" + `
+`,
+
+	digestChangeDir + "/tasks.md": `## 1. Implementation
+
+- [x] 1.1 Queue sharing events instead of sending immediately
+- [ ] 1.2 Send the digest at 08:00 local time (` + "`notifications#Empty Digest Suppression`" + `)
+- [ ] 1.3 Add the immediate email preference
+
+## 2. Documentation
+
+- [ ] 2.1 Update the notification settings help page
+`,
+
+	digestChangeDir + "/specs/notifications/spec.md": `## MODIFIED Requirements
+
+### Requirement: Email Notifications
+
+The system SHALL email a user once a day with a digest of the notes shared
+with them, unless they opted into immediate emails.
+
+#### Scenario: Digest sent
+
+- **WHEN** notes were shared with a user during the previous day
+- **THEN** the user SHALL receive one digest email listing them
+
+#### Scenario: Immediate emails
+
+- **WHEN** a user has opted into immediate emails and a note is shared
+- **THEN** the recipient SHALL receive an email within five minutes
+
+## ADDED Requirements
+
+### Requirement: Empty Digest Suppression
+
+The system SHALL NOT send a digest when nothing was shared.
+
+#### Scenario: Quiet day
+
+- **WHEN** no notes were shared with a user during the previous day
+- **THEN** the system SHALL NOT send that user a digest
+`,
+
+	// Archived change; its requirement is already merged into specs/auth.
+	archivedChangeDir + "/proposal.md": `# Change: Add password reset
+
+## Why
+
+Users who forget their password have to contact support.
+
+## What Changes
+
+- Add a self-service password reset flow by email
+
+## Impact
+
+- Affected specs: ` + "`auth`" + `
+`,
+
+	archivedChangeDir + "/tasks.md": `## 1. Implementation
+
+- [x] 1.1 Add the reset request form
+- [x] 1.2 Email single-use reset links
+- [x] 1.3 Expire links after one hour
+
+## 2. Testing
+
+- [x] 2.1 Test valid and expired links
+`,
+
+	archivedChangeDir + "/specs/auth/spec.md": `## ADDED Requirements
+
+### Requirement: Password Reset
+
+The system SHALL let users reset a forgotten password through a link sent
+to their email address.
+
+#### Scenario: Reset link sent
+
+- **WHEN** a user requests a reset for a registered email
+- **THEN** the system SHALL email a single-use link valid for one hour
+
+#### Scenario: Expired link
+
+- **WHEN** a user opens a reset link older than one hour
+- **THEN** the system SHALL reject it and offer to send a new one
+`,
+}
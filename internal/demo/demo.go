@@ -0,0 +1,73 @@
+package demo
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+const (
+	dirPerm  = 0o755
+	filePerm = 0o644
+)
+
+// Change IDs and spec IDs in the generated project, exported so tests can
+// refer to them without repeating string literals.
+const (
+	ChangeTwoFactor   = "add-two-factor-auth"
+	ChangeDigest      = "update-notification-digest"
+	ArchivedChange    = "2026-01-15-add-password-reset"
+	SpecAuth          = "auth"
+	SpecNotifications = "notifications"
+)
+
+const (
+	archivedChangeDir  = "spectr/changes/archive/" + ArchivedChange
+	twoFactorChangeDir = "spectr/changes/" + ChangeTwoFactor
+	digestChangeDir    = "spectr/changes/" + ChangeDigest
+)
+
+// Create writes the demo project into a new directory under parent
+// (os.TempDir() when empty) and returns its path.
+func Create(parent string) (string, error) {
+	dir, err := os.MkdirTemp(parent, "spectr-demo-*")
+	if err != nil {
+		return "", fmt.Errorf("create demo directory: %w", err)
+	}
+
+	if err := Write(dir); err != nil {
+		_ = os.RemoveAll(dir)
+
+		return "", err
+	}
+
+	return dir, nil
+}
+
+// Write writes the demo project into dir, creating it if needed. dir must
+// not already contain a spectr/ directory.
+func Write(dir string) error {
+	spectrDir := filepath.Join(dir, "spectr")
+	if _, err := os.Stat(spectrDir); err == nil {
+		return &specterrs.DemoExistsError{Path: spectrDir}
+	}
+
+	for _, path := range sortedPaths() {
+		target := filepath.Join(dir, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(target), dirPerm); err != nil {
+			return fmt.Errorf("create directory for %s: %w", path, err)
+		}
+		if err := os.WriteFile(target, []byte(files[path]), filePerm); err != nil {
+			return fmt.Errorf("write %s: %w", path, err)
+		}
+	}
+
+	return nil
+}
+
+// Files returns the relative paths of all generated files in sorted order.
+func Files() []string {
+	return sortedPaths()
+}
@@ -0,0 +1,122 @@
+package demo
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/connerohnesorge/spectr/internal/discovery"
+	"github.com/connerohnesorge/spectr/internal/parsers"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+	"github.com/connerohnesorge/spectr/internal/validation"
+)
+
+func TestCreate(t *testing.T) {
+	dir, err := Create(t.TempDir())
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	for _, path := range Files() {
+		if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(path))); err != nil {
+			t.Errorf("missing %s: %v", path, err)
+		}
+	}
+}
+
+func TestWrite_Existing(t *testing.T) {
+	dir := t.TempDir()
+	if err := Write(dir); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	var existsErr *specterrs.DemoExistsError
+	if err := Write(dir); !errors.As(err, &existsErr) {
+		t.Errorf("second Write error = %v, want DemoExistsError", err)
+	}
+}
+
+func TestWrite_Contents(t *testing.T) {
+	dir := t.TempDir()
+	if err := Write(dir); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	changes, err := discovery.GetActiveChanges(dir)
+	if err != nil {
+		t.Fatalf("GetActiveChanges: %v", err)
+	}
+	if len(changes) != 2 || changes[0] != ChangeTwoFactor || changes[1] != ChangeDigest {
+		t.Errorf("active changes = %v, want [%s %s]", changes, ChangeTwoFactor, ChangeDigest)
+	}
+
+	specs, err := discovery.GetSpecs(dir)
+	if err != nil {
+		t.Fatalf("GetSpecs: %v", err)
+	}
+	if len(specs) != 2 {
+		t.Errorf("specs = %v, want %s and %s", specs, SpecAuth, SpecNotifications)
+	}
+
+	archived := filepath.Join(dir, "spectr", "changes", "archive", ArchivedChange)
+	if _, err := os.Stat(filepath.Join(archived, "proposal.md")); err != nil {
+		t.Errorf("archived change missing: %v", err)
+	}
+
+	tests := []struct {
+		change        string
+		wantTotal     int
+		wantCompleted int
+	}{
+		{ChangeTwoFactor, 5, 2},
+		{ChangeDigest, 4, 1},
+	}
+	for _, tt := range tests {
+		status, err := parsers.CountTasks(filepath.Join(dir, "spectr", "changes", tt.change))
+		if err != nil {
+			t.Fatalf("CountTasks(%s): %v", tt.change, err)
+		}
+		if status.Total != tt.wantTotal || status.Completed != tt.wantCompleted {
+			t.Errorf(
+				"%s tasks = %d/%d, want %d/%d",
+				tt.change, status.Completed, status.Total,
+				tt.wantCompleted, tt.wantTotal,
+			)
+		}
+	}
+}
+
+func TestWrite_Validates(t *testing.T) {
+	dir := t.TempDir()
+	if err := Write(dir); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	spectrRoot := filepath.Join(dir, "spectr")
+
+	for _, spec := range []string{SpecAuth, SpecNotifications} {
+		report, err := validation.ValidateSpecFile(
+			filepath.Join(spectrRoot, "specs", spec, "spec.md"),
+		)
+		assertValid(t, report, err)
+	}
+
+	for _, change := range []string{ChangeTwoFactor, ChangeDigest} {
+		report, err := validation.ValidateChangeDeltaSpecs(
+			filepath.Join(spectrRoot, "changes", change),
+			spectrRoot,
+		)
+		assertValid(t, report, err)
+	}
+}
+
+func assertValid(t *testing.T, report *validation.ValidationReport, err error) {
+	t.Helper()
+
+	if err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	for _, issue := range report.Issues {
+		t.Errorf("%s: %s: %s", issue.Level, issue.Path, issue.Message)
+	}
+}
@@ -0,0 +1,12 @@
+// Package demo generates a throwaway spectr project for onboarding and
+// tests. The project has specs for two capabilities, two in-flight changes
+// with partially completed tasks, and one archived change whose deltas are
+// already merged into the specs.
+//
+// Tests can call Write with t.TempDir() instead of copying testdata:
+//
+//	root := t.TempDir()
+//	if err := demo.Write(root); err != nil {
+//		t.Fatal(err)
+//	}
+package demo
@@ -0,0 +1,344 @@
+// Package diff computes word-level diffs of requirement text. Text is
+// compared block by block (paragraphs, list items, headers, table rows), so
+// re-flowing a paragraph across lines does not show up as a change; only
+// the words that actually differ do.
+package diff
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Op classifies a diff segment.
+type Op int
+
+// Diff operations
+const (
+	Equal Op = iota
+	Delete
+	Insert
+)
+
+// blockBreak is the token that separates blocks. Breaks are only matched
+// against other breaks, and rendered as newlines.
+const blockBreak = "\n"
+
+// maxCells bounds the LCS table of two blocks. Larger blocks are reported
+// as a full replacement instead of a word diff.
+const maxCells = 4_000_000
+
+// Segment is a run of words with the same operation.
+type Segment struct {
+	Op   Op
+	Text string
+}
+
+// blockStartPattern matches lines that start a new markdown block rather
+// than continuing the previous paragraph.
+var blockStartPattern = regexp.MustCompile(`^(?:[-*+] |#|\||>|\d+\. |` + "```" + `)`)
+
+// Words returns the word-level diff between oldText and newText.
+// Whitespace differences within a block are ignored.
+//
+// Blocks are aligned first: unchanged blocks are matched whole, and among
+// the blocks changed between two matches, those that still share most of
+// their words are paired and diffed word by word. Other blocks are deleted
+// or inserted whole, so a new scenario never has its words spliced into an
+// existing one.
+func Words(oldText, newText string) []Segment {
+	a, b := splitBlocks(oldText), splitBlocks(newText)
+
+	var tokens []Segment
+	addBlock := func(op Op, words []Segment) {
+		if len(tokens) > 0 {
+			tokens = append(tokens, Segment{op, blockBreak})
+		}
+		tokens = append(tokens, words...)
+	}
+	// addHunk adds the blocks changed between two unchanged ones
+	addHunk := func(dels, ins [][]string) {
+		steps := align(len(dels), len(ins), func(i, j int) bool {
+			return similar(dels[i], ins[j])
+		})
+		for _, step := range steps {
+			switch {
+			case step.old < 0:
+				addBlock(Insert, withOp(Insert, ins[step.new]))
+			case step.new < 0:
+				addBlock(Delete, withOp(Delete, dels[step.old]))
+			default:
+				addBlock(Equal, diffWords(dels[step.old], ins[step.new]))
+			}
+		}
+	}
+
+	var dels, ins [][]string
+	steps := align(len(a), len(b), func(i, j int) bool {
+		return slices.Equal(a[i], b[j])
+	})
+	for _, step := range steps {
+		switch {
+		case step.old < 0:
+			ins = append(ins, b[step.new])
+		case step.new < 0:
+			dels = append(dels, a[step.old])
+		default:
+			addHunk(dels, ins)
+			dels, ins = nil, nil
+			addBlock(Equal, withOp(Equal, a[step.old]))
+		}
+	}
+	addHunk(dels, ins)
+
+	return group(tokens)
+}
+
+// Changed reports whether a diff contains any insertions or deletions.
+func Changed(segments []Segment) bool {
+	for _, seg := range segments {
+		if seg.Op != Equal {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Styler renders a deleted or inserted run of words.
+type Styler func(op Op, text string) string
+
+// PlainStyler marks deletions as [-old-] and insertions as {+new+}, like
+// git diff --word-diff=plain.
+func PlainStyler(op Op, text string) string {
+	switch op {
+	case Delete:
+		return "[-" + text + "-]"
+	case Insert:
+		return "{+" + text + "+}"
+	case Equal:
+		return text
+	}
+
+	return text
+}
+
+// Render formats segments as text, one block per line, with the layout of
+// the new version. Changed runs are rendered with style.
+func Render(segments []Segment, style Styler) string {
+	var sb strings.Builder
+	lineStart := true
+	for _, seg := range segments {
+		if seg.Text == blockBreak {
+			// Blocks merged in the new version are not split again
+			if seg.Op != Delete && !lineStart {
+				sb.WriteString("\n")
+				lineStart = true
+			}
+
+			continue
+		}
+
+		if !lineStart {
+			sb.WriteString(" ")
+		}
+		sb.WriteString(style(seg.Op, seg.Text))
+		lineStart = false
+	}
+	if !lineStart {
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// splitBlocks splits text into blocks of words.
+func splitBlocks(text string) [][]string {
+	var blocks [][]string
+	inFence := false
+	prevBlank := true
+
+	for _, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			prevBlank = true
+
+			continue
+		}
+
+		startsBlock := prevBlank || inFence ||
+			blockStartPattern.MatchString(trimmed)
+		if strings.HasPrefix(trimmed, "```") {
+			inFence = !inFence
+		}
+		if startsBlock || len(blocks) == 0 {
+			blocks = append(blocks, nil)
+		}
+
+		last := len(blocks) - 1
+		blocks[last] = append(blocks[last], strings.Fields(trimmed)...)
+		prevBlank = false
+	}
+
+	return blocks
+}
+
+// step is one entry of an alignment: an old index matched with a new one,
+// or -1 on the side the entry is missing from.
+type step struct {
+	old, new int
+}
+
+// align matches n old entries with m new ones in order, pairing as many
+// as possible for which match holds, by longest common subsequence.
+func align(n, m int, match func(i, j int) bool) []step {
+	// lcs[i][j] is the LCS length of old[i:] and new[j:]
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	matched := make([][]bool, n)
+	for i := n - 1; i >= 0; i-- {
+		matched[i] = make([]bool, m)
+		for j := m - 1; j >= 0; j-- {
+			matched[i][j] = match(i, j)
+			if matched[i][j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var steps []step
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case matched[i][j] && lcs[i][j] == lcs[i+1][j+1]+1:
+			steps = append(steps, step{i, j})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			steps = append(steps, step{i, -1})
+			i++
+		default:
+			steps = append(steps, step{-1, j})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		steps = append(steps, step{i, -1})
+	}
+	for ; j < m; j++ {
+		steps = append(steps, step{-1, j})
+	}
+
+	return steps
+}
+
+// similar reports whether two changed blocks still share at least half of
+// their words, so they are shown as one edited block.
+func similar(a, b []string) bool {
+	if len(a)*len(b) > maxCells {
+		return false
+	}
+	common := 0
+	for _, seg := range lcsDiff(a, b) {
+		if seg.Op == Equal {
+			common++
+		}
+	}
+
+	// The common words are at least half of the average block length
+	return 4*common >= len(a)+len(b)
+}
+
+// diffWords diffs the words of two paired blocks.
+func diffWords(a, b []string) []Segment {
+	if len(a)*len(b) > maxCells {
+		return append(withOp(Delete, a), withOp(Insert, b)...)
+	}
+
+	return lcsDiff(a, b)
+}
+
+// withOp returns words as segments with operation op.
+func withOp(op Op, words []string) []Segment {
+	segments := make([]Segment, len(words))
+	for i, word := range words {
+		segments[i] = Segment{op, word}
+	}
+
+	return segments
+}
+
+// lcsDiff diffs two token slices using their longest common subsequence.
+func lcsDiff(a, b []string) []Segment {
+	steps := align(len(a), len(b), func(i, j int) bool {
+		return a[i] == b[j]
+	})
+	segments := make([]Segment, len(steps))
+	for k, step := range steps {
+		switch {
+		case step.old < 0:
+			segments[k] = Segment{Insert, b[step.new]}
+		case step.new < 0:
+			segments[k] = Segment{Delete, a[step.old]}
+		default:
+			segments[k] = Segment{Equal, a[step.old]}
+		}
+	}
+
+	return segments
+}
+
+// group merges adjacent words with the same operation into one segment and
+// orders each changed run as deletions before insertions. Block breaks stay
+// separate segments.
+func group(tokens []Segment) []Segment {
+	var (
+		out             []Segment
+		equal, del, ins []string
+	)
+	flushChange := func() {
+		if len(del) > 0 {
+			out = append(out, Segment{Delete, strings.Join(del, " ")})
+		}
+		if len(ins) > 0 {
+			out = append(out, Segment{Insert, strings.Join(ins, " ")})
+		}
+		del, ins = nil, nil
+	}
+	flushEqual := func() {
+		if len(equal) > 0 {
+			out = append(out, Segment{Equal, strings.Join(equal, " ")})
+		}
+		equal = nil
+	}
+
+	for _, token := range tokens {
+		if token.Text == blockBreak {
+			flushEqual()
+			flushChange()
+			out = append(out, token)
+
+			continue
+		}
+
+		switch token.Op {
+		case Equal:
+			flushChange()
+			equal = append(equal, token.Text)
+		case Delete:
+			flushEqual()
+			del = append(del, token.Text)
+		case Insert:
+			flushEqual()
+			ins = append(ins, token.Text)
+		}
+	}
+	flushEqual()
+	flushChange()
+
+	return out
+}
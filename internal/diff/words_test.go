@@ -0,0 +1,77 @@
+package diff
+
+import "testing"
+
+func TestWords(t *testing.T) {
+	tests := []struct {
+		name    string
+		oldText string
+		newText string
+		want    string
+	}{
+		{
+			name:    "changed clause",
+			oldText: "The system SHALL log users in.",
+			newText: "The system SHALL log users in with MFA.",
+			want:    "The system SHALL log users [-in.-] {+in with MFA.+}\n",
+		},
+		{
+			name:    "re-flowed paragraph is unchanged",
+			oldText: "The system SHALL log\nusers in.",
+			newText: "The system SHALL log users\nin.",
+			want:    "The system SHALL log users in.\n",
+		},
+		{
+			name:    "list items stay on their own lines",
+			oldText: "- **WHEN** credentials are valid\n- **THEN** a session is created",
+			newText: "- **WHEN** credentials and code are valid\n- **THEN** a session is created",
+			want:    "- **WHEN** credentials {+and code+} are valid\n- **THEN** a session is created\n",
+		},
+		{
+			name:    "added block",
+			oldText: "Paragraph one.",
+			newText: "Paragraph one.\n\nParagraph two.",
+			want:    "Paragraph one.\n{+Paragraph two.+}\n",
+		},
+		{
+			name:    "inserted scenario is not spliced into an existing one",
+			oldText: "Users SHALL log in.\n\n#### Scenario: Valid login\n- **WHEN** the password is correct\n- **THEN** a session starts",
+			newText: "Users SHALL log in.\n\n#### Scenario: Locked account\n- **WHEN** the account is locked\n- **THEN** login fails\n\n" +
+				"#### Scenario: Valid login\n- **WHEN** the password is correct\n- **THEN** a session starts",
+			want: "Users SHALL log in.\n" +
+				"{+#### Scenario: Locked account+}\n{+- **WHEN** the account is locked+}\n{+- **THEN** login fails+}\n" +
+				"#### Scenario: Valid login\n- **WHEN** the password is correct\n- **THEN** a session starts\n",
+		},
+		{
+			name:    "edited and inserted scenarios",
+			oldText: "#### Scenario: Valid login\n- **THEN** a session starts",
+			newText: "#### Scenario: Valid login with MFA\n- **THEN** a session starts\n\n#### Scenario: Logout\n- **THEN** the session ends",
+			want: "#### Scenario: Valid login {+with MFA+}\n- **THEN** a session starts\n" +
+				"{+#### Scenario: Logout+}\n{+- **THEN** the session ends+}\n",
+		},
+		{
+			name:    "removed block",
+			oldText: "Paragraph one.\n\nParagraph two.",
+			newText: "Paragraph one.",
+			want:    "Paragraph one. [-Paragraph two.-]\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Render(Words(tt.oldText, tt.newText), PlainStyler)
+			if got != tt.want {
+				t.Errorf("Render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChanged(t *testing.T) {
+	if Changed(Words("same\ntext", "same text")) {
+		t.Error("Changed() = true for re-flowed text")
+	}
+	if !Changed(Words("old", "new")) {
+		t.Error("Changed() = false for different text")
+	}
+}
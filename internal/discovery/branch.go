@@ -0,0 +1,95 @@
+package discovery
+
+import (
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+// ChangeBranchPlaceholder marks where the change ID sits in a change
+// branch pattern such as "spectr/{change}".
+const ChangeBranchPlaceholder = "{change}"
+
+// ChangeIDsForBranch returns the changes among changeIDs that branch names
+// under any of patterns. The text matched by {change} never spans a "/" and
+// must be a change ID exactly. The result is sorted and has no duplicates;
+// more than one entry means the branch is ambiguous. A branch that names no
+// change but is a prefix of some, such as "spectr/add-two" for
+// add-two-factor-auth, is reported as *specterrs.UnknownBranchChangeError
+// rather than guessed.
+func ChangeIDsForBranch(
+	branch string,
+	patterns, changeIDs []string,
+) ([]string, error) {
+	found := make(map[string]bool)
+	var unknown string
+	for _, pattern := range patterns {
+		id, ok, err := matchBranchPattern(pattern, branch)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		if slices.Contains(changeIDs, id) {
+			found[id] = true
+		} else if unknown == "" {
+			unknown = id
+		}
+	}
+
+	if len(found) == 0 && unknown != "" {
+		if candidates := changesPrefixed(unknown, changeIDs); len(candidates) > 0 {
+			return nil, &specterrs.UnknownBranchChangeError{
+				Branch:     branch,
+				ChangeID:   unknown,
+				Candidates: candidates,
+			}
+		}
+	}
+
+	result := make([]string, 0, len(found))
+	for id := range found {
+		result = append(result, id)
+	}
+	sort.Strings(result)
+
+	return result, nil
+}
+
+// matchBranchPattern returns the text that {change} matches when branch
+// fits pattern.
+func matchBranchPattern(pattern, branch string) (string, bool, error) {
+	prefix, suffix, ok := strings.Cut(pattern, ChangeBranchPlaceholder)
+	if !ok || strings.Contains(suffix, ChangeBranchPlaceholder) {
+		return "", false, &specterrs.InvalidBranchPatternError{Pattern: pattern}
+	}
+
+	rest, ok := strings.CutPrefix(branch, prefix)
+	if !ok {
+		return "", false, nil
+	}
+	id, ok := strings.CutSuffix(rest, suffix)
+	if !ok || id == "" || strings.Contains(id, "/") {
+		return "", false, nil
+	}
+
+	return id, true, nil
+}
+
+// changesPrefixed returns the changes among changeIDs that id is a
+// case-insensitive prefix of, sorted.
+func changesPrefixed(id string, changeIDs []string) []string {
+	var matches []string
+	idLower := strings.ToLower(id)
+	for _, change := range changeIDs {
+		if strings.HasPrefix(strings.ToLower(change), idLower) {
+			matches = append(matches, change)
+		}
+	}
+	sort.Strings(matches)
+
+	return matches
+}
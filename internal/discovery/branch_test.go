@@ -0,0 +1,83 @@
+package discovery
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+func TestChangeIDsForBranch(t *testing.T) {
+	defaults := []string{"spectr/{change}", "spectr/change/{change}"}
+	changes := []string{"add-2fa", "add-2fa-sms", "add-2fa-totp", "fix-login"}
+
+	tests := []struct {
+		name     string
+		branch   string
+		patterns []string
+		want     []string
+	}{
+		{"exact", "spectr/fix-login", defaults, []string{"fix-login"}},
+		{"exact wins over prefix", "spectr/add-2fa", defaults, []string{"add-2fa"}},
+		{"worktree branch", "spectr/change/fix-login", defaults, []string{"fix-login"}},
+		{"exact under two patterns", "spectr/add-2fa-sms", []string{"spectr/{change}", "{change}"}, []string{"add-2fa-sms"}},
+		{"exact under one of two patterns", "fix-login-v2", []string{"{change}", "{change}-v2"}, []string{"fix-login"}},
+		{"ambiguous patterns", "add-2fa-sms", []string{"{change}", "{change}-sms"}, []string{"add-2fa", "add-2fa-sms"}},
+		{"no pattern", "main", defaults, []string{}},
+		{"unknown change", "spectr/refactor", defaults, []string{}},
+		{"nested segment", "spectr/archive/fix-login", defaults, []string{}},
+		{"custom pattern", "feature/fix-login-v2", []string{"feature/{change}-v2"}, []string{"fix-login"}},
+		{"custom excludes default", "spectr/fix-login", []string{"feature/{change}"}, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ChangeIDsForBranch(tt.branch, tt.patterns, changes)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ChangeIDsForBranch(%q) = %v, want %v", tt.branch, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChangeIDsForBranch_PrefixOnly(t *testing.T) {
+	defaults := []string{"spectr/{change}", "spectr/change/{change}"}
+	changes := []string{"add-2fa-sms", "add-2fa-totp", "add-two-factor-auth", "fix-login"}
+
+	tests := []struct {
+		name       string
+		branch     string
+		candidates []string
+	}{
+		{"one prefix", "spectr/add-two", []string{"add-two-factor-auth"}},
+		{"several prefixes", "spectr/add-2fa", []string{"add-2fa-sms", "add-2fa-totp"}},
+		{"case-insensitive prefix", "spectr/FIX", []string{"fix-login"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ChangeIDsForBranch(tt.branch, defaults, changes)
+			var unknownErr *specterrs.UnknownBranchChangeError
+			if !errors.As(err, &unknownErr) {
+				t.Fatalf("ChangeIDsForBranch(%q) = %v, %v; want UnknownBranchChangeError", tt.branch, got, err)
+			}
+			if !slices.Equal(unknownErr.Candidates, tt.candidates) {
+				t.Errorf("Candidates = %v, want %v", unknownErr.Candidates, tt.candidates)
+			}
+		})
+	}
+}
+
+func TestChangeIDsForBranch_InvalidPattern(t *testing.T) {
+	for _, pattern := range []string{"spectr/", "{change}/{change}"} {
+		_, err := ChangeIDsForBranch("spectr/x", []string{pattern}, nil)
+		var patternErr *specterrs.InvalidBranchPatternError
+		if !errors.As(err, &patternErr) {
+			t.Errorf("pattern %q: expected InvalidBranchPatternError, got %v", pattern, err)
+		}
+	}
+}
@@ -5,6 +5,9 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/specterrs"
 )
 
 const (
@@ -79,31 +82,40 @@ func FindSpectrRoots(cwd string) ([]SpectrRoot, error) {
 
 // findSpectrRootFromEnv handles the SPECTR_ROOT environment variable case.
 func findSpectrRootFromEnv(envRoot, cwd string) ([]SpectrRoot, error) {
+	root, err := findSpectrRootAt(envRoot, cwd, "SPECTR_ROOT")
+	if err != nil {
+		return nil, err
+	}
+
+	return []SpectrRoot{root}, nil
+}
+
+// FindSpectrRootAt returns the project at dir, which must contain a
+// spectr/ directory (or a symlink to one). It backs --project-dir. A
+// relative dir is resolved against cwd.
+func FindSpectrRootAt(dir, cwd string) (SpectrRoot, error) {
+	return findSpectrRootAt(dir, cwd, "--project-dir")
+}
+
+// findSpectrRootAt builds the root for an explicitly named directory;
+// source names where the directory came from in errors.
+func findSpectrRootAt(dir, cwd, source string) (SpectrRoot, error) {
 	// Make path absolute if relative
-	absPath := envRoot
-	if !filepath.IsAbs(envRoot) {
-		absPath = filepath.Join(cwd, envRoot)
+	absPath := dir
+	if !filepath.IsAbs(dir) {
+		absPath = filepath.Join(cwd, dir)
 	}
 	absPath = filepath.Clean(absPath)
 
 	// Validate spectr/ directory exists
-	spectrDir := filepath.Join(absPath, spectrDirName)
-	info, err := os.Stat(spectrDir)
-	if os.IsNotExist(err) {
-		return nil, fmt.Errorf(
-			"SPECTR_ROOT path does not contain spectr/ directory: %s",
-			absPath,
-		)
-	}
+	found, err := hasSpectrDir(absPath)
 	if err != nil {
-		return nil, fmt.Errorf(
-			"failed to check SPECTR_ROOT path: %w",
-			err,
-		)
+		return SpectrRoot{}, err
 	}
-	if !info.IsDir() {
-		return nil, fmt.Errorf(
-			"SPECTR_ROOT path does not contain spectr/ directory: %s",
+	if !found {
+		return SpectrRoot{}, fmt.Errorf(
+			"%s path does not contain spectr/ directory: %s",
+			source,
 			absPath,
 		)
 	}
@@ -114,18 +126,38 @@ func findSpectrRootFromEnv(envRoot, cwd string) ([]SpectrRoot, error) {
 		relPath = absPath // Fallback to absolute if rel fails
 	}
 
-	// Find git root for this path
-	gitRoot := findGitRoot(absPath)
-
-	return []SpectrRoot{
-		{
-			Path:       absPath,
-			RelativeTo: relPath,
-			GitRoot:    gitRoot,
-		},
+	return SpectrRoot{
+		Path:       absPath,
+		RelativeTo: relPath,
+		GitRoot:    findGitRoot(absPath),
 	}, nil
 }
 
+// hasSpectrDir reports whether dir contains a spectr/ directory. A
+// spectr/ symlink counts when it leads to a directory; one that cannot be
+// followed, because its target is missing or it loops, is an error rather
+// than silently ignored.
+func hasSpectrDir(dir string) (bool, error) {
+	spectrDir := filepath.Join(dir, spectrDirName)
+	linfo, err := os.Lstat(spectrDir)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to check %s: %w", spectrDir, err)
+	}
+	if linfo.Mode()&os.ModeSymlink == 0 {
+		return linfo.IsDir(), nil
+	}
+
+	info, err := os.Stat(spectrDir)
+	if err != nil {
+		return false, &specterrs.SpectrLinkError{Path: spectrDir, Err: err}
+	}
+
+	return info.IsDir(), nil
+}
+
 // findSpectrRootsFromCwd walks up from cwd to find all spectr/ directories,
 // stopping at git boundaries, and also searches downward from cwd (or git root)
 // to find nested spectr/ directories in subdirectories.
@@ -145,9 +177,11 @@ func findSpectrRootsFromCwd(cwd string) ([]SpectrRoot, error) {
 	current := absCwd
 	for {
 		// Check if spectr/ directory exists at this level
-		spectrDir := filepath.Join(current, spectrDirName)
-		info, err := os.Stat(spectrDir)
-		if err == nil && info.IsDir() {
+		found, err := hasSpectrDir(current)
+		if err != nil {
+			return nil, err
+		}
+		if found {
 			// Calculate relative path from original cwd
 			relPath, relErr := filepath.Rel(absCwd, current)
 			if relErr != nil {
@@ -184,9 +218,74 @@ func findSpectrRootsFromCwd(cwd string) ([]SpectrRoot, error) {
 	// 4. Sort by distance from cwd (closest first)
 	roots = sortRootsByDistance(roots, absCwd)
 
+	// 5. Drop roots whose spectr/ is a symlink to one already found
+	roots = deduplicateRealRoots(roots)
+
 	return roots, nil
 }
 
+// deduplicateRealRoots removes roots whose spectr/ directory resolves,
+// through symlinks, to the same directory as an earlier root. Roots must
+// be sorted closest first, so the closest path to a project is kept.
+func deduplicateRealRoots(roots []SpectrRoot) []SpectrRoot {
+	seen := make(map[string]bool, len(roots))
+	result := make([]SpectrRoot, 0, len(roots))
+
+	for _, root := range roots {
+		real, err := filepath.EvalSymlinks(root.SpectrDir())
+		if err != nil {
+			real = root.SpectrDir()
+		}
+		if seen[real] {
+			continue
+		}
+		seen[real] = true
+		result = append(result, root)
+	}
+
+	return result
+}
+
+// SingleRoot picks the project a single-project command works on: the
+// closest root at or above the working directory, or the only root found
+// below it. Several roots below and none above is ambiguous.
+func SingleRoot(roots []SpectrRoot) (SpectrRoot, error) {
+	for _, root := range roots {
+		if isAncestorRel(root.RelativeTo) {
+			return root, nil
+		}
+	}
+
+	switch len(roots) {
+	case 0:
+		return SpectrRoot{}, nil
+	case 1:
+		return roots[0], nil
+	}
+
+	candidates := make([]string, len(roots))
+	for i, root := range roots {
+		candidates[i] = root.RelativeTo
+	}
+
+	return SpectrRoot{}, &specterrs.AmbiguousRootError{Candidates: candidates}
+}
+
+// isAncestorRel reports whether a path relative to the working directory
+// names the working directory or one of its parents.
+func isAncestorRel(rel string) bool {
+	if rel == "." {
+		return true
+	}
+	for _, part := range strings.Split(filepath.ToSlash(rel), "/") {
+		if part != ".." {
+			return false
+		}
+	}
+
+	return true
+}
+
 // deduplicateRoots removes duplicate SpectrRoot entries based on their Path field.
 // Preserves the order of first occurrence.
 func deduplicateRoots(roots []SpectrRoot) []SpectrRoot {
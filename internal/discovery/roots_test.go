@@ -1,9 +1,13 @@
 package discovery
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/connerohnesorge/spectr/internal/specterrs"
 )
 
 func TestFindSpectrRoots_SingleRoot(t *testing.T) {
@@ -1399,3 +1403,107 @@ func containsAt(s, substr string, start int) bool {
 
 	return false
 }
+
+func TestFindSpectrRoots_SymlinkedSpectrDir(t *testing.T) {
+	tmpDir := t.TempDir()
+	project := filepath.Join(tmpDir, "project")
+	shared := filepath.Join(tmpDir, "shared-spectr")
+	deep := filepath.Join(project, "src", "pkg")
+	for _, dir := range []string{filepath.Join(project, ".git"), shared, deep} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Symlink(shared, filepath.Join(project, "spectr")); err != nil {
+		t.Fatal(err)
+	}
+	// A second link to the same tree deeper in the project
+	if err := os.Symlink("../spectr", filepath.Join(project, "src", "spectr")); err != nil {
+		t.Fatal(err)
+	}
+
+	roots, err := FindSpectrRoots(deep)
+	if err != nil {
+		t.Fatalf("FindSpectrRoots returned error: %v", err)
+	}
+	if len(roots) != 1 || roots[0].Path != filepath.Join(project, "src") {
+		t.Errorf("roots = %+v, want only the closest path to the shared tree", roots)
+	}
+}
+
+func TestFindSpectrRoots_BrokenSpectrSymlink(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(tmpDir, ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	link := filepath.Join(tmpDir, "spectr")
+	if err := os.Symlink(filepath.Join(tmpDir, "missing"), link); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := FindSpectrRoots(tmpDir)
+	var linkErr *specterrs.SpectrLinkError
+	if !errors.As(err, &linkErr) || linkErr.Path != link {
+		t.Errorf("err = %v, want SpectrLinkError for %s", err, link)
+	}
+}
+
+func TestFindSpectrRootAt(t *testing.T) {
+	tmpDir := t.TempDir()
+	project := filepath.Join(tmpDir, "project")
+	if err := os.MkdirAll(filepath.Join(project, "spectr"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	root, err := FindSpectrRootAt("project", tmpDir)
+	if err != nil {
+		t.Fatalf("FindSpectrRootAt returned error: %v", err)
+	}
+	if root.Path != project || root.RelativeTo != "project" {
+		t.Errorf("root = %+v, want %s relative to project", root, project)
+	}
+
+	_, err = FindSpectrRootAt(tmpDir, tmpDir)
+	if err == nil || !strings.Contains(err.Error(), "--project-dir path does not contain spectr/") {
+		t.Errorf("err = %v, want missing spectr/ error", err)
+	}
+}
+
+func TestSingleRoot(t *testing.T) {
+	tests := []struct {
+		name      string
+		roots     []string // RelativeTo of each root
+		want      string
+		ambiguous bool
+	}{
+		{name: "none", roots: nil, want: ""},
+		{name: "only below", roots: []string{"api"}, want: "api"},
+		{name: "self before children", roots: []string{".", "api", "web"}, want: "."},
+		{name: "closest ancestor", roots: []string{"..", "../..", "sub"}, want: ".."},
+		{name: "several below", roots: []string{"api", "web"}, ambiguous: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			roots := make([]SpectrRoot, len(tt.roots))
+			for i, rel := range tt.roots {
+				roots[i] = SpectrRoot{RelativeTo: rel}
+			}
+
+			root, err := SingleRoot(roots)
+
+			var ambiguousErr *specterrs.AmbiguousRootError
+			if tt.ambiguous {
+				if !errors.As(err, &ambiguousErr) ||
+					len(ambiguousErr.Candidates) != len(tt.roots) {
+					t.Errorf("err = %v, want AmbiguousRootError", err)
+				}
+
+				return
+			}
+			if err != nil || root.RelativeTo != tt.want {
+				t.Errorf("SingleRoot = %q, %v; want %q", root.RelativeTo, err, tt.want)
+			}
+		})
+	}
+}
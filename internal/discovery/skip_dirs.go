@@ -73,3 +73,10 @@ func shouldSkipDirectory(dirName string) bool {
 
 	return false
 }
+
+// ShouldSkipDirectory reports whether a directory with the given name should
+// be skipped when walking a project tree (dependency caches, build output,
+// hidden directories, and so on).
+func ShouldSkipDirectory(dirName string) bool {
+	return shouldSkipDirectory(dirName)
+}
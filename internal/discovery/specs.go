@@ -1,14 +1,25 @@
 package discovery
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"sort"
 	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/fileio"
 )
 
-// GetSpecs finds all specs in spectr/specs/ that contain spec.md
+// SpecArchiveDir is the directory under spectr/specs/ holding retired
+// specs. GetSpecs does not list them.
+const SpecArchiveDir = "archive"
+
+// GetSpecs finds all specs under spectr/specs/ that contain spec.md.
+// Specs may be nested in capability directories; a nested spec's ID is its
+// slash-separated path relative to spectr/specs/ (e.g. "payments/refunds").
+// Retired specs under spectr/specs/archive/ are skipped.
 func GetSpecs(
 	projectPath string,
 ) ([]string, error) {
@@ -25,7 +36,40 @@ func GetSpecs(
 		return make([]string, 0), nil
 	}
 
-	entries, err := os.ReadDir(specsDir)
+	var specs []string
+	err := fileio.WalkDir(
+		specsDir,
+		func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			// Skip non-directories and the specs directory itself
+			if !entry.IsDir() || path == specsDir {
+				return nil
+			}
+
+			// Skip hidden directories and retired specs
+			if strings.HasPrefix(entry.Name(), ".") ||
+				path == filepath.Join(specsDir, SpecArchiveDir) {
+				return filepath.SkipDir
+			}
+
+			// Check if spec.md exists
+			_, statErr := os.Stat(filepath.Join(path, "spec.md"))
+			if errors.Is(statErr, fs.ErrNotExist) {
+				return nil
+			}
+
+			rel, relErr := filepath.Rel(specsDir, path)
+			if relErr != nil {
+				return relErr
+			}
+			specs = append(specs, filepath.ToSlash(rel))
+
+			return nil
+		},
+	)
 	if err != nil {
 		return nil, fmt.Errorf(
 			"failed to read specs directory: %w",
@@ -33,29 +77,6 @@ func GetSpecs(
 		)
 	}
 
-	var specs []string
-	for _, entry := range entries {
-		// Skip non-directories
-		if !entry.IsDir() {
-			continue
-		}
-
-		// Skip hidden directories
-		if strings.HasPrefix(entry.Name(), ".") {
-			continue
-		}
-
-		// Check if spec.md exists
-		specPath := filepath.Join(
-			specsDir,
-			entry.Name(),
-			"spec.md",
-		)
-		if _, err := os.Stat(specPath); err == nil {
-			specs = append(specs, entry.Name())
-		}
-	}
-
 	// Sort alphabetically for consistency
 	sort.Strings(specs)
 
@@ -115,6 +115,52 @@ func TestGetSpecs_EmptyDirectory(t *testing.T) {
 	}
 }
 
+func TestGetSpecs_Nested(t *testing.T) {
+	tmpDir := t.TempDir()
+	specsDir := filepath.Join(tmpDir, "spectr", "specs")
+
+	// "payments" is both a spec and a group; "billing" is only a group
+	for _, id := range []string{
+		"payments",
+		"payments/refunds",
+		"payments/payouts/instant",
+		"billing/invoices",
+		"billing/.drafts/tax",
+	} {
+		specDir := filepath.Join(specsDir, filepath.FromSlash(id))
+		if err := os.MkdirAll(specDir, testDirPerm); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(
+			filepath.Join(specDir, "spec.md"),
+			[]byte("# Test Spec"),
+			testFilePerm,
+		); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	specs, err := GetSpecs(tmpDir)
+	if err != nil {
+		t.Fatalf("GetSpecs failed: %v", err)
+	}
+
+	expected := []string{
+		"billing/invoices",
+		"payments",
+		"payments/payouts/instant",
+		"payments/refunds",
+	}
+	if len(specs) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, specs)
+	}
+	for i, id := range expected {
+		if specs[i] != id {
+			t.Errorf("specs[%d] = %q, want %q", i, specs[i], id)
+		}
+	}
+}
+
 func TestGetSpecIDs(t *testing.T) {
 	// Create temporary test directory
 	tmpDir := t.TempDir()
@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/connerohnesorge/spectr/internal/fileio"
 	"github.com/connerohnesorge/spectr/internal/parsers"
 	"github.com/connerohnesorge/spectr/internal/utils"
 )
@@ -49,7 +50,7 @@ func (td *TaskDiscovery) findNextPendingTaskInFile(filePath string) (*parsers.Ta
 	td.visited[absPath] = true
 
 	// Read the tasks file
-	data, err := os.ReadFile(filePath)
+	data, err := fileio.ReadFile(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read tasks file %s: %w", filePath, err)
 	}
@@ -62,6 +63,9 @@ func (td *TaskDiscovery) findNextPendingTaskInFile(filePath string) (*parsers.Ta
 	if err := json.Unmarshal(jsonData, &tasksFileData); err != nil {
 		return nil, fmt.Errorf("failed to parse tasks file %s: %w", filePath, err)
 	}
+	if err := tasksFileData.CheckVersion(filePath); err != nil {
+		return nil, err
+	}
 
 	// Find the first pending task, recursively checking children
 	for _, task := range tasksFileData.Tasks {
@@ -6,10 +6,11 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
-	"os"
 	"strings"
 
 	"gopkg.in/yaml.v3"
+
+	"github.com/connerohnesorge/spectr/internal/fileio"
 )
 
 // Dependency represents a dependency on another proposal.
@@ -26,6 +27,15 @@ type ProposalMetadata struct {
 	Requires []Dependency `yaml:"requires,omitempty"`
 	// Enables lists proposals that this proposal unlocks (informational only)
 	Enables []Dependency `yaml:"enables,omitempty"`
+	// Override references the ticket that approves touching frozen requirements
+	Override string `yaml:"override,omitempty"`
+	// Owner names the person or team responsible for the change
+	Owner string `yaml:"owner,omitempty"`
+	// SplitFrom names the change this one was split out of by
+	// spectr split-change
+	SplitFrom string `yaml:"split_from,omitempty"`
+	// Release names the release the change ships in, e.g. "v1.4"
+	Release string `yaml:"release,omitempty"`
 }
 
 // HasDependencies returns true if the proposal has any requires dependencies.
@@ -77,7 +87,7 @@ func ParseProposalFrontmatter(content []byte) (*ProposalMetadata, error) {
 
 // ParseProposalFrontmatterFromFile reads a proposal.md file and parses its frontmatter.
 func ParseProposalFrontmatterFromFile(path string) (*ProposalMetadata, error) {
-	content, err := os.ReadFile(path)
+	content, err := fileio.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read file: %w", err)
 	}
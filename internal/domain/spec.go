@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"fmt"
+
+	"github.com/connerohnesorge/spectr/internal/fileio"
+	"gopkg.in/yaml.v3"
+)
+
+// SpecMetadata is the optional YAML frontmatter of a spec.md.
+type SpecMetadata struct {
+	// LastReviewed is the date (YYYY-MM-DD) the spec was last reviewed,
+	// set by spectr review mark.
+	LastReviewed string `yaml:"last_reviewed,omitempty"`
+	// Tags classify the spec, e.g. compliance. Review intervals can be
+	// configured per tag.
+	Tags []string `yaml:"tags,omitempty"`
+	// Extends is the ID of a base spec whose requirements this spec
+	// inherits read-only; a requirement of the same name here overrides
+	// individual scenarios.
+	Extends string `yaml:"extends,omitempty"`
+	// Backstage describes the catalog entity spectr export backstage
+	// generates for the spec.
+	Backstage *BackstageMetadata `yaml:"backstage,omitempty"`
+}
+
+// BackstageMetadata overrides the defaults of a spec's Backstage catalog
+// entity. Empty fields keep the defaults.
+type BackstageMetadata struct {
+	// Kind is Component (the default), API or Resource
+	Kind string `yaml:"kind,omitempty"`
+	// Type is the entity's spec.type, e.g. service, openapi or database
+	Type string `yaml:"type,omitempty"`
+	// Lifecycle is the entity's spec.lifecycle, e.g. experimental
+	Lifecycle string `yaml:"lifecycle,omitempty"`
+	// Owner replaces the owner spectr.yaml assigns to the spec
+	Owner string `yaml:"owner,omitempty"`
+	// System is the system the entity belongs to
+	System string `yaml:"system,omitempty"`
+}
+
+// ParseSpecFrontmatter extracts and parses YAML frontmatter from spec.md
+// content. Returns empty SpecMetadata if no frontmatter is present.
+func ParseSpecFrontmatter(content []byte) (*SpecMetadata, error) {
+	fm, err := ExtractFrontmatter(content)
+	if err != nil {
+		return nil, err
+	}
+	if fm == nil {
+		return &SpecMetadata{}, nil
+	}
+
+	var meta SpecMetadata
+	if err := yaml.Unmarshal(fm, &meta); err != nil {
+		return nil, fmt.Errorf("invalid YAML in frontmatter: %w", err)
+	}
+
+	return &meta, nil
+}
+
+// ParseSpecFrontmatterFromFile reads a spec.md file and parses its
+// frontmatter.
+func ParseSpecFrontmatterFromFile(path string) (*SpecMetadata, error) {
+	content, err := fileio.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	return ParseSpecFrontmatter(content)
+}
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseSpecFrontmatter(t *testing.T) {
+	tests := []struct {
+		name         string
+		content      string
+		wantReviewed string
+		wantTags     []string
+		wantErr      bool
+	}{
+		{
+			name:         "review date and tags",
+			content:      "---\nlast_reviewed: 2026-10-16\ntags: [compliance, auth]\n---\n\n# Auth\n",
+			wantReviewed: "2026-10-16",
+			wantTags:     []string{"compliance", "auth"},
+		},
+		{
+			name:    "no frontmatter",
+			content: "# Auth\n",
+		},
+		{
+			name:    "malformed YAML",
+			content: "---\ntags: [unclosed\n---\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			meta, err := ParseSpecFrontmatter([]byte(tt.content))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if meta.LastReviewed != tt.wantReviewed || !slices.Equal(meta.Tags, tt.wantTags) {
+				t.Errorf("ParseSpecFrontmatter() = %+v", meta)
+			}
+		})
+	}
+}
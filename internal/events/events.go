@@ -0,0 +1,124 @@
+// Package events emits machine-readable progress events for tools that
+// wrap spectr, such as CI steps and editor plugins.
+//
+// With --progress=json, long operations write one JSON object per line to
+// stderr as they advance:
+//
+//	{"time":"...","command":"validate","phase":"validate","item":"auth","current":3,"total":12,"percent":25}
+//
+// Events never go to stdout, so they do not mix with command output. When
+// progress output is off, Emit does nothing.
+package events
+
+import (
+	"encoding/json"
+	"io"
+	"math"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Progress output formats
+const (
+	FormatNone = "none"
+	FormatJSON = "json"
+)
+
+// Event reports how far a phase of a command has progressed.
+type Event struct {
+	Time    time.Time `json:"time"`
+	Command string    `json:"command"`
+	Phase   string    `json:"phase"`
+	Item    string    `json:"item,omitempty"`
+	Current int       `json:"current"`
+	Total   int       `json:"total"`
+	Percent float64   `json:"percent"`
+}
+
+// Reporter writes events to a writer.
+type Reporter struct {
+	mu      sync.Mutex
+	enc     *json.Encoder
+	command string
+	now     func() time.Time
+}
+
+// NewReporter returns a reporter writing JSON lines to w for command. A nil
+// w discards events.
+func NewReporter(w io.Writer, command string) *Reporter {
+	r := &Reporter{command: command, now: time.Now}
+	if w != nil {
+		r.enc = json.NewEncoder(w)
+	}
+
+	return r
+}
+
+// Emit reports that current of total steps of phase are done, the latest
+// being item. Write errors are ignored: progress must never fail a command.
+func (r *Reporter) Emit(phase, item string, current, total int) {
+	if r == nil || r.enc == nil {
+		return
+	}
+
+	percent := 100.0
+	if total > 0 {
+		percent = math.Round(float64(current)/float64(total)*1000) / 10
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_ = r.enc.Encode(Event{
+		Time:    r.now().UTC(),
+		Command: r.command,
+		Phase:   phase,
+		Item:    item,
+		Current: current,
+		Total:   total,
+		Percent: percent,
+	})
+}
+
+// defaultReporter is the process-wide reporter set by Configure.
+var (
+	defaultMu       sync.RWMutex
+	defaultReporter *Reporter
+)
+
+// Configure sets the process-wide progress output. Format FormatJSON writes
+// events to w; anything else turns progress output off. command is the
+// command path as Kong reports it; argument placeholders are dropped.
+func Configure(format, command string, w io.Writer) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+
+	if format != FormatJSON {
+		defaultReporter = nil
+
+		return
+	}
+	defaultReporter = NewReporter(w, commandName(command))
+}
+
+// Emit reports progress on the process-wide reporter.
+func Emit(phase, item string, current, total int) {
+	defaultMu.RLock()
+	r := defaultReporter
+	defaultMu.RUnlock()
+
+	r.Emit(phase, item, current, total)
+}
+
+// commandName turns "archive <change-id>" into "archive".
+func commandName(command string) string {
+	words := make([]string, 0, 2)
+	for word := range strings.FieldsSeq(command) {
+		if strings.HasPrefix(word, "<") {
+			break
+		}
+		words = append(words, word)
+	}
+
+	return strings.Join(words, " ")
+}
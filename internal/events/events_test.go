@@ -0,0 +1,57 @@
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReporter_Emit(t *testing.T) {
+	var buf bytes.Buffer
+	r := NewReporter(&buf, "validate")
+	r.now = func() time.Time { return time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC) }
+
+	r.Emit("validate", "", 0, 3)
+	r.Emit("validate", "auth", 1, 3)
+	r.Emit("apply", "", 0, 0)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines, want 3:\n%s", len(lines), buf.String())
+	}
+
+	want := []Event{
+		{Command: "validate", Phase: "validate", Current: 0, Total: 3, Percent: 0},
+		{Command: "validate", Phase: "validate", Item: "auth", Current: 1, Total: 3, Percent: 33.3},
+		{Command: "validate", Phase: "apply", Percent: 100},
+	}
+	for i, line := range lines {
+		var got Event
+		if err := json.Unmarshal([]byte(line), &got); err != nil {
+			t.Fatalf("line %d is not JSON: %q", i, line)
+		}
+		got.Time = time.Time{}
+		if got != want[i] {
+			t.Errorf("event %d = %+v, want %+v", i, got, want[i])
+		}
+	}
+}
+
+func TestConfigure(t *testing.T) {
+	var buf bytes.Buffer
+	t.Cleanup(func() { Configure(FormatNone, "", nil) })
+
+	Configure(FormatNone, "validate", &buf)
+	Emit("validate", "auth", 1, 1)
+	if buf.Len() != 0 {
+		t.Errorf("events written with progress off: %q", buf.String())
+	}
+
+	Configure(FormatJSON, "archive <change-id>", &buf)
+	Emit("move", "add-mfa", 3, 5)
+	if !strings.Contains(buf.String(), `"command":"archive"`) {
+		t.Errorf("event = %q, want command archive", buf.String())
+	}
+}
@@ -0,0 +1,9 @@
+// Package evidence records the acceptance evidence of spec scenarios:
+// links to test runs, screenshots and other proof that a scenario holds.
+//
+// Evidence lives next to the spec in spectr/specs/<id>/evidence.jsonc and
+// is keyed by requirement and scenario name, so reordering the spec does
+// not detach it. Entries are URLs or files referenced by their path from
+// the project root. The file is rewritten by spectr evidence attach, which
+// does not keep comments added by hand.
+package evidence
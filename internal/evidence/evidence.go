@@ -0,0 +1,267 @@
+package evidence
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/connerohnesorge/spectr/internal/audit"
+	"github.com/connerohnesorge/spectr/internal/inherit"
+	"github.com/connerohnesorge/spectr/internal/parsers"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+	"github.com/connerohnesorge/spectr/internal/txn"
+)
+
+// FileName is the evidence file in a spec directory.
+const FileName = "evidence.jsonc"
+
+// fileVersion is the current evidence file format version.
+const fileVersion = 1
+
+// filePerm is the permission of evidence files.
+const filePerm = 0o644
+
+// fileHeader starts every evidence file written by Attach.
+const fileHeader = "// Acceptance evidence per scenario, written by spectr evidence attach.\n"
+
+// Entry is one piece of evidence for a scenario.
+type Entry struct {
+	Requirement string `json:"requirement"`
+	Scenario    string `json:"scenario"`
+	// Ref is a URL, or a file path relative to the project root
+	Ref   string `json:"ref"`
+	Added string `json:"added"` // RFC 3339 timestamp
+}
+
+// File is the evidence of one spec.
+type File struct {
+	Version  int     `json:"version"`
+	Evidence []Entry `json:"evidence"`
+}
+
+// Result describes attached evidence.
+type Result struct {
+	Spec        string `json:"spec"`
+	Requirement string `json:"requirement"`
+	Scenario    string `json:"scenario"`
+	ScenarioID  string `json:"scenarioId"`
+	Ref         string `json:"ref"`
+	// Duplicate reports evidence that was already attached
+	Duplicate bool `json:"duplicate,omitempty"`
+}
+
+// Target is a scenario of a spec, with inheritance resolved.
+type Target struct {
+	Requirement string
+	Scenario    string
+	ID          string
+}
+
+// Path returns the evidence file of specID in the project at projectRoot.
+func Path(projectRoot, specID string) string {
+	return filepath.Join(projectRoot, "spectr", "specs", filepath.FromSlash(specID), FileName)
+}
+
+// Load reads an evidence file. A missing file has no evidence.
+func Load(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &File{Version: fileVersion}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var f File
+	if err := json.Unmarshal(parsers.StripJSONComments(data), &f); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if f.Version > fileVersion {
+		return nil, &specterrs.EvidenceVersionError{Path: path, Version: f.Version}
+	}
+
+	return &f, nil
+}
+
+// For returns the evidence of a scenario, matched ignoring case.
+func (f *File) For(requirement, scenario string) []Entry {
+	var entries []Entry
+	for _, entry := range f.Evidence {
+		if matches(entry, requirement, scenario) {
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries
+}
+
+// Missing returns the scenarios of a requirement that have no evidence.
+func (f *File) Missing(requirement string, scenarios []string) []string {
+	var missing []string
+	for _, scenario := range scenarios {
+		if len(f.For(requirement, scenario)) == 0 {
+			missing = append(missing, scenario)
+		}
+	}
+
+	return missing
+}
+
+// matches reports whether entry belongs to the scenario.
+func matches(entry Entry, requirement, scenario string) bool {
+	return parsers.NormalizeRequirementName(entry.Requirement) ==
+		parsers.NormalizeRequirementName(requirement) &&
+		strings.EqualFold(strings.TrimSpace(entry.Scenario), strings.TrimSpace(scenario))
+}
+
+// FindScenario returns the scenario of specID named by query: a scenario
+// ID such as AUTH-R1-S2, or a scenario name matched ignoring case.
+func FindScenario(projectRoot, specID, query string) (Target, error) {
+	specPath := filepath.Join(projectRoot, "spectr", "specs", filepath.FromSlash(specID), "spec.md")
+	if _, err := os.Stat(specPath); err != nil {
+		return Target{}, fmt.Errorf("spec '%s' not found", specID)
+	}
+	reqs, err := inherit.ResolveFile(specPath)
+	if err != nil {
+		return Target{}, fmt.Errorf("failed to parse spec: %w", err)
+	}
+
+	key, reqNum, scenarioNum, isID := parsers.ParseScenarioID(query)
+	var found []Target
+	for _, req := range reqs {
+		for i, scenario := range parsers.ParseScenarios(req.Raw) {
+			target := Target{
+				Requirement: req.Name,
+				Scenario:    scenario,
+				ID:          parsers.ScenarioID(req.Spec, req.Index, i+1),
+			}
+			if isID && parsers.SpecKey(req.Spec) == key && req.Index == reqNum && i+1 == scenarioNum {
+				return target, nil
+			}
+			if strings.EqualFold(scenario, strings.TrimSpace(query)) {
+				found = append(found, target)
+			}
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return Target{}, &specterrs.ScenarioNotFoundError{Spec: specID, Scenario: query}
+	case 1:
+		return found[0], nil
+	}
+	names := make([]string, 0, len(found))
+	for _, target := range found {
+		names = append(names, target.Requirement)
+	}
+
+	return Target{}, &specterrs.AmbiguousScenarioError{
+		Spec:         specID,
+		Scenario:     query,
+		Requirements: names,
+	}
+}
+
+// NormalizeRef returns ref as stored: URLs unchanged, files as their slash
+// path from projectRoot. Paths are resolved against the working directory.
+func NormalizeRef(projectRoot, ref string) (string, error) {
+	if strings.HasPrefix(ref, "https://") || strings.HasPrefix(ref, "http://") {
+		return ref, nil
+	}
+
+	abs, err := filepath.Abs(ref)
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(abs); err != nil {
+		return "", &specterrs.EvidenceFileNotFoundError{Path: ref}
+	}
+	root, err := filepath.Abs(projectRoot)
+	if err != nil {
+		return "", err
+	}
+	rel, err := filepath.Rel(root, abs)
+	if err != nil {
+		return "", err
+	}
+	if rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		// Files outside the project are kept by absolute path
+		return filepath.ToSlash(abs), nil
+	}
+
+	return filepath.ToSlash(rel), nil
+}
+
+// Attach records ref as evidence for the scenario of specID named by
+// query, then records the attachment in the audit log. Evidence that is
+// already attached is reported as a duplicate and not written again.
+func Attach(
+	ctx context.Context,
+	projectRoot, specID, query, ref string,
+	now time.Time,
+) (*Result, error) {
+	specID = strings.Trim(filepath.ToSlash(specID), "/")
+	target, err := FindScenario(projectRoot, specID, query)
+	if err != nil {
+		return nil, err
+	}
+	if ref, err = NormalizeRef(projectRoot, ref); err != nil {
+		return nil, err
+	}
+
+	res := &Result{
+		Spec:        specID,
+		Requirement: target.Requirement,
+		Scenario:    target.Scenario,
+		ScenarioID:  target.ID,
+		Ref:         ref,
+	}
+
+	path := Path(projectRoot, specID)
+	f, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range f.For(target.Requirement, target.Scenario) {
+		if entry.Ref == ref {
+			res.Duplicate = true
+
+			return res, nil
+		}
+	}
+
+	f.Version = fileVersion
+	f.Evidence = append(f.Evidence, Entry{
+		Requirement: target.Requirement,
+		Scenario:    target.Scenario,
+		Ref:         ref,
+		Added:       now.UTC().Format(time.RFC3339),
+	})
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal evidence: %w", err)
+	}
+
+	tx := txn.New()
+	tx.WriteFile(path, append([]byte(fileHeader), append(data, '\n')...), filePerm)
+
+	audit.RecordLast(
+		tx,
+		filepath.Join(projectRoot, "spectr"),
+		audit.OpEvidence,
+		[]string{"specs/" + specID},
+		map[string]string{"scenario": target.ID, "ref": ref},
+	)
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("attach evidence: %w", err)
+	}
+
+	return res, nil
+}
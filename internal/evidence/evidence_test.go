@@ -0,0 +1,183 @@
+package evidence
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/connerohnesorge/spectr/internal/audit"
+	"github.com/connerohnesorge/spectr/internal/parsers"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+	"github.com/connerohnesorge/spectr/internal/testutil"
+)
+
+const authSpec = `# Auth
+
+## Requirements
+
+### Requirement: Login
+The system SHALL authenticate users.
+
+#### Scenario: Valid password
+- **WHEN** a user signs in
+- **THEN** a session starts
+
+#### Scenario: Expired session
+- **WHEN** a session expires
+- **THEN** the user signs in again
+
+### Requirement: Logout
+The system SHALL end sessions.
+
+#### Scenario: Expired session
+- **WHEN** a session expires
+- **THEN** nothing happens
+`
+
+// setupProject creates a project with the auth spec.
+func setupProject(t *testing.T) string {
+	t.Helper()
+
+	root := t.TempDir()
+	testutil.WriteFile(t, filepath.Join(root, "spectr", "specs", "auth", "spec.md"), authSpec)
+
+	return root
+}
+
+func TestFindScenario(t *testing.T) {
+	root := setupProject(t)
+
+	tests := []struct {
+		name    string
+		query   string
+		want    Target
+		wantErr any
+	}{
+		{
+			name:  "by name ignoring case",
+			query: "valid PASSWORD",
+			want: Target{
+				Requirement: "Login",
+				Scenario:    "Valid password",
+				ID:          parsers.ScenarioID("auth", 1, 1),
+			},
+		},
+		{
+			name:  "by ID",
+			query: parsers.ScenarioID("auth", 2, 1),
+			want: Target{
+				Requirement: "Logout",
+				Scenario:    "Expired session",
+				ID:          parsers.ScenarioID("auth", 2, 1),
+			},
+		},
+		{
+			name:    "ambiguous name",
+			query:   "Expired session",
+			wantErr: new(*specterrs.AmbiguousScenarioError),
+		},
+		{
+			name:    "unknown scenario",
+			query:   "Forgot password",
+			wantErr: new(*specterrs.ScenarioNotFoundError),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FindScenario(root, "auth", tt.query)
+			if tt.wantErr != nil {
+				if !errors.As(err, tt.wantErr) {
+					t.Fatalf("FindScenario() error = %v, want %T", err, tt.wantErr)
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("FindScenario() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("FindScenario() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeRef(t *testing.T) {
+	root := setupProject(t)
+	shot := filepath.Join(root, "shots", "login.png")
+	testutil.WriteFile(t, shot, "png")
+
+	tests := []struct {
+		name    string
+		ref     string
+		want    string
+		wantErr bool
+	}{
+		{name: "URL", ref: "https://ci.example/runs/7", want: "https://ci.example/runs/7"},
+		{name: "file in project", ref: shot, want: "shots/login.png"},
+		{name: "missing file", ref: filepath.Join(root, "nope.png"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeRef(root, tt.ref)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NormalizeRef() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeRef() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAttach(t *testing.T) {
+	root := setupProject(t)
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	ctx := context.Background()
+	url := "https://ci.example/runs/7"
+
+	res, err := Attach(ctx, root, "auth", "Valid password", url, now)
+	if err != nil {
+		t.Fatalf("Attach() error = %v", err)
+	}
+	if res.Duplicate || res.Requirement != "Login" || res.Ref != url {
+		t.Errorf("Attach() = %+v", res)
+	}
+
+	res, err = Attach(ctx, root, "auth", res.ScenarioID, url, now)
+	if err != nil || !res.Duplicate {
+		t.Errorf("second Attach() = %+v, %v; want a duplicate", res, err)
+	}
+
+	f, err := Load(Path(root, "auth"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(f.Evidence) != 1 || f.Evidence[0].Added != "2026-10-16T12:00:00Z" {
+		t.Errorf("evidence = %+v, want one entry", f.Evidence)
+	}
+	if missing := f.Missing("login", []string{"Valid password", "Expired session"}); len(missing) != 1 ||
+		missing[0] != "Expired session" {
+		t.Errorf("Missing() = %v", missing)
+	}
+
+	entries, err := audit.Read(filepath.Join(root, "spectr", audit.LogFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Operation != audit.OpEvidence ||
+		entries[0].Details["ref"] != url {
+		t.Errorf("audit entries = %+v, want one evidence entry", entries)
+	}
+}
+
+func TestLoad_Missing(t *testing.T) {
+	f, err := Load(filepath.Join(t.TempDir(), FileName))
+	if err != nil || len(f.Evidence) != 0 {
+		t.Errorf("Load() = %+v, %v; want no evidence", f, err)
+	}
+}
@@ -0,0 +1,273 @@
+package export
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/connerohnesorge/spectr/internal/events"
+)
+
+// CheckpointFile is the file in a batch export's output directory that
+// records the specs already written, so an interrupted export resumes
+// where it stopped. It is removed once every spec is written.
+const CheckpointFile = ".spectr-export.json"
+
+// BatchSpec is a spec exported by Batch.
+type BatchSpec struct {
+	// ID is the spec ID, which names the output file
+	ID string
+	// Path is the spec.md file; a spec whose file changed since it was
+	// written is exported again on resume
+	Path string
+}
+
+// BatchOptions configures Batch.
+type BatchOptions struct {
+	// OutputDir receives one file per spec, <OutputDir>/<spec ID><Ext>
+	OutputDir string
+	// Ext is the extension of the output files, such as ".html"
+	Ext string
+	// Settings identifies the export settings (format, flags). A
+	// checkpoint written with other settings is not resumed.
+	Settings string
+	// Jobs is the number of specs rendered in parallel
+	Jobs int
+	// Restart ignores an existing checkpoint
+	Restart bool
+}
+
+// BatchResult reports what Batch did.
+type BatchResult struct {
+	// Written is the number of specs rendered and written
+	Written int
+	// Resumed is the number of specs skipped because a checkpoint
+	// recorded them as written
+	Resumed int
+}
+
+// checkpoint is the content of CheckpointFile.
+type checkpoint struct {
+	Settings string `json:"settings"`
+	// Done maps written spec IDs to the SHA-256 of their spec.md
+	Done map[string]string `json:"done"`
+}
+
+// rendered is the outcome of rendering one spec.
+type rendered struct {
+	id   string
+	hash string
+	err  error
+}
+
+// Batch renders specs with render and writes each to its own file under
+// opts.OutputDir, rendering opts.Jobs specs at a time. Progress is saved
+// to CheckpointFile after every spec, so after an interruption or an
+// error the next Batch with the same settings skips the specs already
+// written. Batch stops at the first error or when ctx is done.
+func Batch(
+	ctx context.Context,
+	specs []BatchSpec,
+	opts BatchOptions,
+	render func(spec BatchSpec) (string, error),
+) (BatchResult, error) {
+	var result BatchResult
+	if err := os.MkdirAll(opts.OutputDir, 0o755); err != nil {
+		return result, fmt.Errorf("failed to create %s: %w", opts.OutputDir, err)
+	}
+
+	checkpointPath := filepath.Join(opts.OutputDir, CheckpointFile)
+	state := checkpoint{Settings: opts.Settings, Done: make(map[string]string)}
+	if !opts.Restart {
+		if saved, ok := loadCheckpoint(checkpointPath); ok && saved.Settings == opts.Settings {
+			state.Done = saved.Done
+		}
+	}
+
+	var pending []BatchSpec
+	for _, spec := range specs {
+		hash, err := fileHash(spec.Path)
+		if err != nil {
+			return result, err
+		}
+		if done, ok := state.Done[spec.ID]; ok && done == hash &&
+			exists(outputPath(opts, spec.ID)) {
+			result.Resumed++
+
+			continue
+		}
+		delete(state.Done, spec.ID)
+		pending = append(pending, spec)
+	}
+
+	parent := ctx
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	queue := make(chan BatchSpec, len(pending))
+	for _, spec := range pending {
+		queue <- spec
+	}
+	close(queue)
+
+	results := make(chan rendered)
+	for range min(max(opts.Jobs, 1), len(pending)) {
+		go func() {
+			for spec := range queue {
+				results <- renderOne(ctx, spec, opts, render)
+			}
+		}()
+	}
+
+	var firstErr error
+	for range pending {
+		r := <-results
+		if r.err != nil {
+			if firstErr == nil && !errors.Is(r.err, context.Canceled) {
+				firstErr = r.err
+			}
+			cancel()
+
+			continue
+		}
+		state.Done[r.id] = r.hash
+		result.Written++
+		events.Emit("export", r.id, result.Resumed+result.Written, len(specs))
+		if err := saveCheckpoint(checkpointPath, state); err != nil && firstErr == nil {
+			firstErr = err
+			cancel()
+		}
+	}
+
+	if firstErr == nil {
+		firstErr = parent.Err()
+	}
+	if firstErr != nil {
+		return result, firstErr
+	}
+	if err := os.Remove(checkpointPath); err != nil && !os.IsNotExist(err) {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// renderOne renders spec and writes its output file, unless ctx is done.
+func renderOne(
+	ctx context.Context,
+	spec BatchSpec,
+	opts BatchOptions,
+	render func(spec BatchSpec) (string, error),
+) rendered {
+	r := rendered{id: spec.ID}
+	if r.err = ctx.Err(); r.err != nil {
+		return r
+	}
+	// Hash before rendering, so an edit made during the export is picked
+	// up on resume
+	if r.hash, r.err = fileHash(spec.Path); r.err != nil {
+		return r
+	}
+
+	output, err := render(spec)
+	if err != nil {
+		r.err = fmt.Errorf("failed to export %s: %w", spec.ID, err)
+
+		return r
+	}
+	path := outputPath(opts, spec.ID)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		r.err = fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+
+		return r
+	}
+	if err := writeFileAtomic(path, []byte(output)); err != nil {
+		r.err = fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	return r
+}
+
+// outputPath returns the output file of a spec; nested spec IDs get
+// nested directories.
+func outputPath(opts BatchOptions, specID string) string {
+	return filepath.Join(opts.OutputDir, filepath.FromSlash(specID)+opts.Ext)
+}
+
+// loadCheckpoint reads the checkpoint at path. A missing or unreadable
+// checkpoint is not resumed.
+func loadCheckpoint(path string) (checkpoint, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return checkpoint{}, false
+	}
+	var state checkpoint
+	if err := json.Unmarshal(data, &state); err != nil || state.Done == nil {
+		return checkpoint{}, false
+	}
+
+	return state, true
+}
+
+// saveCheckpoint writes state to path.
+func saveCheckpoint(path string, state checkpoint) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(path, append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to save export checkpoint: %w", err)
+	}
+
+	return nil
+}
+
+// writeFileAtomic writes data to a temporary file next to path and
+// renames it into place, so an interrupted export never leaves a
+// truncated file.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmpPath, 0o644)
+	}
+	if err == nil {
+		err = os.Rename(tmpPath, path)
+	}
+	if err != nil {
+		_ = os.Remove(tmpPath)
+	}
+
+	return err
+}
+
+// fileHash returns the hex SHA-256 of the file at path.
+func fileHash(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// exists reports whether path exists.
+func exists(path string) bool {
+	_, err := os.Stat(path)
+
+	return err == nil
+}
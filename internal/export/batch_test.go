@@ -0,0 +1,173 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+)
+
+// batchSpecs writes spec files for ids and returns them as batch specs.
+func batchSpecs(t *testing.T, ids ...string) []BatchSpec {
+	t.Helper()
+
+	dir := t.TempDir()
+	specs := make([]BatchSpec, 0, len(ids))
+	for _, id := range ids {
+		path := filepath.Join(dir, filepath.FromSlash(id), "spec.md")
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("# "+id+"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		specs = append(specs, BatchSpec{ID: id, Path: path})
+	}
+
+	return specs
+}
+
+// recorder renders specs as their ID and records which it rendered,
+// failing on the IDs in fail.
+type recorder struct {
+	mu       sync.Mutex
+	rendered []string
+	fail     map[string]bool
+}
+
+func (r *recorder) render(spec BatchSpec) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.fail[spec.ID] {
+		return "", errors.New("boom")
+	}
+	r.rendered = append(r.rendered, spec.ID)
+
+	return "<p>" + spec.ID + "</p>", nil
+}
+
+func TestBatch_Resume(t *testing.T) {
+	specs := batchSpecs(t, "auth", "billing", "payments/refunds", "search")
+	opts := BatchOptions{OutputDir: t.TempDir(), Ext: ".html", Settings: "html", Jobs: 1}
+
+	first := &recorder{fail: map[string]bool{"search": true}}
+	result, err := Batch(context.Background(), specs, opts, first.render)
+	if err == nil {
+		t.Fatal("Batch() error = nil, want the render failure")
+	}
+	if result.Written != 3 {
+		t.Errorf("first Written = %d, want 3", result.Written)
+	}
+	if _, err := os.Stat(filepath.Join(opts.OutputDir, CheckpointFile)); err != nil {
+		t.Fatalf("checkpoint not saved: %v", err)
+	}
+
+	// Editing a written spec exports it again on resume
+	if err := os.WriteFile(specs[0].Path, []byte("# auth v2\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	second := &recorder{}
+	result, err = Batch(context.Background(), specs, opts, second.render)
+	if err != nil {
+		t.Fatalf("resumed Batch() error = %v", err)
+	}
+	slices.Sort(second.rendered)
+	if want := []string{"auth", "search"}; !slices.Equal(second.rendered, want) {
+		t.Errorf("resumed run rendered %v, want %v", second.rendered, want)
+	}
+	if result.Resumed != 2 || result.Written != 2 {
+		t.Errorf("resumed result = %+v, want 2 resumed and 2 written", result)
+	}
+	if _, err := os.Stat(filepath.Join(opts.OutputDir, CheckpointFile)); !os.IsNotExist(err) {
+		t.Errorf("checkpoint left after a complete export: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(opts.OutputDir, "payments", "refunds.html"))
+	if err != nil || string(data) != "<p>payments/refunds</p>" {
+		t.Errorf("nested output = %q, %v", data, err)
+	}
+}
+
+func TestBatch_CheckpointSettings(t *testing.T) {
+	specs := batchSpecs(t, "auth", "billing")
+	opts := BatchOptions{OutputDir: t.TempDir(), Ext: ".md", Settings: "markdown", Jobs: 1}
+
+	failing := &recorder{fail: map[string]bool{"billing": true}}
+	if _, err := Batch(context.Background(), specs, opts, failing.render); err == nil {
+		t.Fatal("Batch() error = nil, want the render failure")
+	}
+
+	tests := []struct {
+		name string
+		opts BatchOptions
+	}{
+		{"other settings", BatchOptions{OutputDir: opts.OutputDir, Ext: ".md", Settings: "markdown quality"}},
+		{"restart", BatchOptions{OutputDir: opts.OutputDir, Ext: ".md", Settings: "markdown", Restart: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Leave a checkpoint that records auth as written
+			_ = saveCheckpoint(filepath.Join(opts.OutputDir, CheckpointFile), checkpoint{
+				Settings: "markdown",
+				Done:     map[string]string{"auth": mustHash(t, specs[0].Path)},
+			})
+
+			r := &recorder{}
+			result, err := Batch(context.Background(), specs, tt.opts, r.render)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.Resumed != 0 || result.Written != 2 {
+				t.Errorf("result = %+v, want every spec written", result)
+			}
+		})
+	}
+}
+
+func TestBatch_Parallel(t *testing.T) {
+	ids := []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+	specs := batchSpecs(t, ids...)
+	opts := BatchOptions{OutputDir: t.TempDir(), Ext: ".feature", Jobs: 4}
+
+	r := &recorder{}
+	result, err := Batch(context.Background(), specs, opts, r.render)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Written != len(ids) {
+		t.Errorf("Written = %d, want %d", result.Written, len(ids))
+	}
+	for _, id := range ids {
+		if _, err := os.Stat(filepath.Join(opts.OutputDir, id+".feature")); err != nil {
+			t.Errorf("missing output for %s: %v", id, err)
+		}
+	}
+}
+
+func TestBatch_Cancelled(t *testing.T) {
+	specs := batchSpecs(t, "auth", "billing")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	r := &recorder{}
+	_, err := Batch(ctx, specs, BatchOptions{OutputDir: t.TempDir(), Ext: ".html", Jobs: 2}, r.render)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Batch() error = %v, want context.Canceled", err)
+	}
+	if len(r.rendered) != 0 {
+		t.Errorf("rendered %v after cancellation", r.rendered)
+	}
+}
+
+func mustHash(t *testing.T, path string) string {
+	t.Helper()
+	hash, err := fileHash(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return hash
+}
@@ -0,0 +1,139 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/markdown"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+// Flags is the feature-flag set of an environment, mapping flag names to
+// whether they are on.
+type Flags map[string]bool
+
+// LoadFlags reads a flag set from a JSON object of flag names to booleans,
+// such as {"new_checkout": true, "legacy_login": false}.
+func LoadFlags(path string) (Flags, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read flags file: %w", err)
+	}
+
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, &specterrs.InvalidFlagsFileError{Path: path, Reason: err.Error()}
+	}
+
+	flags := make(Flags, len(raw))
+	for name, value := range raw {
+		on, ok := value.(bool)
+		if !ok {
+			return nil, &specterrs.InvalidFlagsFileError{
+				Path:   path,
+				Reason: fmt.Sprintf("flag %q is not true or false", name),
+			}
+		}
+		flags[name] = on
+	}
+
+	return flags, nil
+}
+
+// Enabled reports whether a when condition ("flag" or "!flag") holds.
+// Flags missing from the set are off.
+func (f Flags) Enabled(cond string) bool {
+	if flag, negated := strings.CutPrefix(cond, "!"); negated {
+		return !f[flag]
+	}
+
+	return f[cond]
+}
+
+// heading is a header node of the spec with its gating condition.
+type heading struct {
+	level      int
+	start, end int
+	when       string
+}
+
+// FilterFlags returns source without the requirements and scenarios whose
+// when condition does not hold under flags. The when lines of the content
+// that is kept are dropped too, so the result reads as the spec of that
+// environment.
+func FilterFlags(source []byte, flags Flags) []byte {
+	root, _ := markdown.Parse(source)
+
+	var headings []heading
+	for _, node := range markdown.Find(root, func(n markdown.Node) bool {
+		switch n.(type) {
+		case *markdown.NodeSection, *markdown.NodeRequirement, *markdown.NodeScenario:
+			return true
+		}
+
+		return false
+	}) {
+		start, end := node.Span()
+		h := heading{start: start, end: end}
+		switch n := node.(type) {
+		case *markdown.NodeSection:
+			h.level = n.Level()
+		case *markdown.NodeRequirement:
+			h.level, h.when = 3, n.When()
+		case *markdown.NodeScenario:
+			h.level, h.when = 4, n.When()
+		}
+		headings = append(headings, h)
+	}
+
+	var cuts [][2]int
+	for i, h := range headings {
+		if h.when == "" {
+			continue
+		}
+		if !flags.Enabled(h.when) {
+			end := len(source)
+			for _, next := range headings[i+1:] {
+				if next.level <= h.level {
+					end = next.start
+
+					break
+				}
+			}
+			cuts = append(cuts, [2]int{h.start, end})
+
+			continue
+		}
+
+		_, start, end, ok := markdown.FindWhenLine(source, h.end)
+		if !ok {
+			continue
+		}
+		// Drop a blank line left between two blank lines
+		if start >= 2 && source[start-2] == '\n' && end < len(source) && source[end] == '\n' {
+			end++
+		}
+		cuts = append(cuts, [2]int{start, end})
+	}
+
+	return cut(source, cuts)
+}
+
+// cut returns source without the given byte ranges, which may overlap.
+func cut(source []byte, ranges [][2]int) []byte {
+	slices.SortFunc(ranges, func(a, b [2]int) int { return a[0] - b[0] })
+
+	out := make([]byte, 0, len(source))
+	pos := 0
+	for _, r := range ranges {
+		if r[0] > pos {
+			out = append(out, source[pos:r[0]]...)
+		}
+		pos = max(pos, r[1])
+	}
+
+	return append(out, source[pos:]...)
+}
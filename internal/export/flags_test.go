@@ -0,0 +1,150 @@
+package export
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+const flaggedSpec = `# Checkout
+
+## Requirements
+
+### Requirement: Checkout
+when: new_checkout
+The system SHALL check out in one step.
+
+#### Scenario: Express
+
+when: !legacy
+
+- **WHEN** a user pays
+- **THEN** the order is placed
+
+#### Scenario: Receipt
+- **WHEN** a user pays
+- **THEN** a receipt is sent
+
+### Requirement: Cart
+The system SHALL keep a cart.
+
+#### Scenario: Add
+- **WHEN** a user adds an item
+- **THEN** the cart shows it
+`
+
+func TestFilterFlags(t *testing.T) {
+	tests := []struct {
+		name  string
+		flags Flags
+		want  string
+	}{
+		{
+			name:  "all on",
+			flags: Flags{"new_checkout": true},
+			want: `# Checkout
+
+## Requirements
+
+### Requirement: Checkout
+The system SHALL check out in one step.
+
+#### Scenario: Express
+
+- **WHEN** a user pays
+- **THEN** the order is placed
+
+#### Scenario: Receipt
+- **WHEN** a user pays
+- **THEN** a receipt is sent
+
+### Requirement: Cart
+The system SHALL keep a cart.
+
+#### Scenario: Add
+- **WHEN** a user adds an item
+- **THEN** the cart shows it
+`,
+		},
+		{
+			name:  "negated scenario off",
+			flags: Flags{"new_checkout": true, "legacy": true},
+			want: `# Checkout
+
+## Requirements
+
+### Requirement: Checkout
+The system SHALL check out in one step.
+
+#### Scenario: Receipt
+- **WHEN** a user pays
+- **THEN** a receipt is sent
+
+### Requirement: Cart
+The system SHALL keep a cart.
+
+#### Scenario: Add
+- **WHEN** a user adds an item
+- **THEN** the cart shows it
+`,
+		},
+		{
+			name:  "requirement off",
+			flags: Flags{},
+			want: `# Checkout
+
+## Requirements
+
+### Requirement: Cart
+The system SHALL keep a cart.
+
+#### Scenario: Add
+- **WHEN** a user adds an item
+- **THEN** the cart shows it
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := string(FilterFlags([]byte(flaggedSpec), tt.flags))
+			if got != tt.want {
+				t.Errorf("FilterFlags() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadFlags(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		return path
+	}
+
+	flags, err := LoadFlags(write("prod.json", `{"new_checkout": true, "legacy": false}`))
+	if err != nil {
+		t.Fatalf("LoadFlags() error = %v", err)
+	}
+	if !flags.Enabled("new_checkout") || flags.Enabled("legacy") ||
+		!flags.Enabled("!legacy") || flags.Enabled("unlisted") {
+		t.Errorf("LoadFlags() = %v", flags)
+	}
+
+	for name, content := range map[string]string{
+		"array.json":  `["new_checkout"]`,
+		"string.json": `{"new_checkout": "yes"}`,
+	} {
+		var target *specterrs.InvalidFlagsFileError
+		if _, err := LoadFlags(write(name, content)); !errors.As(err, &target) {
+			t.Errorf("LoadFlags(%s) error = %v, want InvalidFlagsFileError", name, err)
+		}
+	}
+}
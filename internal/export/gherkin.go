@@ -0,0 +1,125 @@
+// Package export converts specs into formats consumed by other tools.
+package export
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/evidence"
+	"github.com/connerohnesorge/spectr/internal/parsers"
+)
+
+// gherkinKeywords maps Spectr step keywords to Gherkin step keywords.
+var gherkinKeywords = map[string]string{
+	"GIVEN": "Given",
+	"WHEN":  "When",
+	"THEN":  "Then",
+	"AND":   "And",
+	"BUT":   "But",
+}
+
+// htmlCommentPattern matches HTML comments, including multi-line ones.
+var htmlCommentPattern = regexp.MustCompile(`(?s)<!--.*?-->`)
+
+// FormatGherkin renders a spec as a Gherkin feature file. Each requirement
+// becomes a Rule and each scenario a Scenario. Scenarios with an Examples
+// table are emitted as a Scenario Outline with a matching Examples block.
+// HTML comments are reviewer notes, not spec content, and are left out.
+func FormatGherkin(
+	title string,
+	requirements []parsers.RequirementBlock,
+) string {
+	return FormatGherkinWithEvidence(title, requirements, nil)
+}
+
+// FormatGherkinWithEvidence is FormatGherkin with each scenario's
+// evidence from ev written as "# Evidence:" comments above it.
+func FormatGherkinWithEvidence(
+	title string,
+	requirements []parsers.RequirementBlock,
+	ev *evidence.File,
+) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "Feature: %s\n", title)
+
+	for _, req := range requirements {
+		fmt.Fprintf(&sb, "\n  Rule: %s\n", req.Name)
+
+		for _, scenario := range parsers.ParseScenarioBlocks(
+			htmlCommentPattern.ReplaceAllString(req.Raw, ""),
+		) {
+			var refs []string
+			if ev != nil {
+				for _, entry := range ev.For(req.Name, scenario.Name) {
+					refs = append(refs, entry.Ref)
+				}
+			}
+			writeGherkinScenario(&sb, scenario, refs)
+		}
+	}
+
+	return sb.String()
+}
+
+// writeGherkinScenario writes a single scenario, including its Examples,
+// preceded by a comment for each evidence ref.
+func writeGherkinScenario(
+	sb *strings.Builder,
+	scenario parsers.ScenarioBlock,
+	refs []string,
+) {
+	keyword := "Scenario"
+	if scenario.Examples != nil && len(scenario.Examples.Header) > 0 {
+		keyword = "Scenario Outline"
+	}
+
+	sb.WriteString("\n")
+	for _, ref := range refs {
+		fmt.Fprintf(sb, "    # Evidence: %s\n", ref)
+	}
+	fmt.Fprintf(sb, "    %s: %s\n", keyword, scenario.Name)
+
+	for _, step := range scenario.Steps {
+		fmt.Fprintf(
+			sb,
+			"      %s %s\n",
+			gherkinKeywords[step.Keyword],
+			step.Text,
+		)
+	}
+
+	if keyword != "Scenario Outline" {
+		return
+	}
+
+	sb.WriteString("\n      Examples:\n")
+	writeGherkinTable(sb, scenario.Examples)
+}
+
+// writeGherkinTable writes an Examples table with aligned columns.
+func writeGherkinTable(
+	sb *strings.Builder,
+	table *parsers.ExamplesTable,
+) {
+	widths := make([]int, len(table.Header))
+	rows := append([][]string{table.Header}, table.Rows...)
+	for _, row := range rows {
+		for i := range min(len(row), len(widths)) {
+			widths[i] = max(widths[i], len(row[i]))
+		}
+	}
+
+	for _, row := range rows {
+		sb.WriteString("        |")
+		for i, width := range widths {
+			cell := ""
+			if i < len(row) {
+				cell = strings.ReplaceAll(row[i], "|", "\\|")
+			}
+			fmt.Fprintf(sb, " %-*s |", width, cell)
+		}
+		sb.WriteString("\n")
+	}
+}
@@ -0,0 +1,125 @@
+package export
+
+import (
+	"testing"
+
+	"github.com/connerohnesorge/spectr/internal/evidence"
+	"github.com/connerohnesorge/spectr/internal/parsers"
+)
+
+func TestFormatGherkin(t *testing.T) {
+	reqs := []parsers.RequirementBlock{
+		{
+			Name: "Login",
+			Raw: `### Requirement: Login
+The system SHALL log users in.
+
+#### Scenario: Login as <role>
+- **WHEN** a <role> logs in
+- **THEN** they see <page>
+
+**Examples:**
+
+| role | page      |
+| ---- | --------- |
+| admin | dashboard |
+
+#### Scenario: Bad password
+- **WHEN** the password is wrong
+- **THEN** login fails
+`,
+		},
+	}
+
+	want := `Feature: Auth
+
+  Rule: Login
+
+    Scenario Outline: Login as <role>
+      When a <role> logs in
+      Then they see <page>
+
+      Examples:
+        | role  | page      |
+        | admin | dashboard |
+
+    Scenario: Bad password
+      When the password is wrong
+      Then login fails
+`
+
+	if got := FormatGherkin("Auth", reqs); got != want {
+		t.Errorf("FormatGherkin() mismatch\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestFormatGherkin_SkipsHTMLComments(t *testing.T) {
+	reqs := []parsers.RequirementBlock{
+		{
+			Name: "Login",
+			Raw: `### Requirement: Login
+The system SHALL log users in.
+
+#### Scenario: Valid login
+- **WHEN** credentials are valid <!-- check SSO too -->
+<!--
+- **AND** a draft step under review
+-->
+- **THEN** a session is created
+`,
+		},
+	}
+
+	want := `Feature: Auth
+
+  Rule: Login
+
+    Scenario: Valid login
+      When credentials are valid
+      Then a session is created
+`
+	if got := FormatGherkin("Auth", reqs); got != want {
+		t.Errorf("FormatGherkin() mismatch\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestFormatGherkinWithEvidence(t *testing.T) {
+	reqs := []parsers.RequirementBlock{
+		{
+			Name: "Login",
+			Raw: `### Requirement: Login
+The system SHALL log users in.
+
+#### Scenario: Valid login
+- **WHEN** credentials are valid
+- **THEN** a session is created
+
+#### Scenario: Lockout
+- **WHEN** a user fails five times
+- **THEN** the account is locked
+`,
+		},
+	}
+	ev := &evidence.File{Evidence: []evidence.Entry{
+		{Requirement: "login", Scenario: "valid login", Ref: "https://ci.example/runs/7"},
+		{Requirement: "Login", Scenario: "Valid login", Ref: "shots/login.png"},
+	}}
+
+	want := `Feature: Auth
+
+  Rule: Login
+
+    # Evidence: https://ci.example/runs/7
+    # Evidence: shots/login.png
+    Scenario: Valid login
+      When credentials are valid
+      Then a session is created
+
+    Scenario: Lockout
+      When a user fails five times
+      Then the account is locked
+`
+	if got := FormatGherkinWithEvidence("Auth", reqs, ev); got != want {
+		t.Errorf("FormatGherkinWithEvidence() mismatch\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,167 @@
+package export
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/connerohnesorge/spectr/internal/heatmap"
+	"github.com/connerohnesorge/spectr/internal/markdown"
+	"github.com/connerohnesorge/spectr/internal/parsers"
+)
+
+// boldPattern matches **bold** text in escaped HTML.
+var boldPattern = regexp.MustCompile(`\*\*(.+?)\*\*`)
+
+// htmlStyle is the stylesheet of exported HTML. The heat-N classes color
+// requirements by heatmap level.
+const htmlStyle = `body{font-family:system-ui,sans-serif;max-width:50rem;margin:2rem auto;padding:0 1rem;line-height:1.5}
+section.requirement{border-left:.4rem solid #ddd;padding:.1rem 1rem;margin:1rem 0}
+.heat-1{background:#fff8e1;border-color:#ffe082!important}
+.heat-2{background:#ffecb3;border-color:#ffca28!important}
+.heat-3{background:#ffe0b2;border-color:#fb8c00!important}
+.heat-4{background:#ffccbc;border-color:#e64a19!important}
+.heat{font-size:.85rem;color:#555}
+.legend span{display:inline-block;padding:0 .5rem;border-left:.4rem solid #ddd}`
+
+// FormatHTML renders a spec as a standalone HTML page, one section per
+// requirement. With a heatmap, each requirement is colored by its edit
+// level and labelled with its edit count, so unstable requirements stand
+// out. HTML comments are reviewer notes and are left out.
+func FormatHTML(
+	title string,
+	requirements []parsers.RequirementBlock,
+	hm *heatmap.Heatmap,
+) string {
+	heat := make(map[string]heatmap.Entry)
+	if hm != nil {
+		for _, entry := range hm.Requirements {
+			heat[parsers.NormalizeRequirementName(entry.Requirement)] = entry
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString("<!DOCTYPE html>\n<html lang=\"en\">\n<head>\n<meta charset=\"utf-8\">\n")
+	fmt.Fprintf(&sb, "<title>%s</title>\n<style>\n%s\n</style>\n</head>\n<body>\n", html.EscapeString(title), htmlStyle)
+	fmt.Fprintf(&sb, "<h1>%s</h1>\n", html.EscapeString(title))
+	if hm != nil {
+		writeHeatLegend(&sb, hm)
+	}
+
+	for _, req := range requirements {
+		class := "requirement"
+		entry, ok := heat[parsers.NormalizeRequirementName(req.Name)]
+		if ok && entry.Level > 0 {
+			class += fmt.Sprintf(" heat-%d", entry.Level)
+		}
+		fmt.Fprintf(
+			&sb,
+			"<section class=\"%s\" id=\"%s\">\n<h2>Requirement: %s</h2>\n",
+			class,
+			markdown.HeadingAnchor("Requirement: "+req.Name),
+			html.EscapeString(req.Name),
+		)
+		if ok {
+			fmt.Fprintf(&sb, "<p class=\"heat\">%s</p>\n", html.EscapeString(heatLabel(entry)))
+		}
+		body := htmlCommentPattern.ReplaceAllString(req.Raw, "")
+		_, body, _ = strings.Cut(body, "\n")
+		writeHTMLBody(&sb, body)
+		sb.WriteString("</section>\n")
+	}
+
+	sb.WriteString("</body>\n</html>\n")
+
+	return sb.String()
+}
+
+// writeHeatLegend writes the heatmap window and a key of its levels.
+func writeHeatLegend(sb *strings.Builder, hm *heatmap.Heatmap) {
+	fmt.Fprintf(
+		sb,
+		"<p class=\"legend\">Edits from %s to %s:",
+		hm.Since.Format(time.DateOnly),
+		hm.Until.Format(time.DateOnly),
+	)
+	labels := [heatmap.MaxLevel + 1]string{"no edits", "fewest", "&nbsp;", "&nbsp;", "most"}
+	for level, label := range labels {
+		fmt.Fprintf(sb, " <span class=\"heat-%d\">%s</span>", level, label)
+	}
+	sb.WriteString("</p>\n")
+}
+
+// heatLabel describes the edits of entry, e.g. "3 edits, last 2026-10-01".
+func heatLabel(entry heatmap.Entry) string {
+	switch entry.Edits {
+	case 0:
+		return "No edits"
+	case 1:
+		return "1 edit, last " + entry.LastEdited.Format(time.DateOnly)
+	default:
+		return fmt.Sprintf("%d edits, last %s", entry.Edits, entry.LastEdited.Format(time.DateOnly))
+	}
+}
+
+// writeHTMLBody renders the markdown of a requirement body: scenario and
+// other headings, bullet lists and paragraphs. Text is escaped and only
+// **bold** is kept as markup.
+func writeHTMLBody(sb *strings.Builder, body string) {
+	inList, inPara := false, false
+	closeBlocks := func() {
+		if inList {
+			sb.WriteString("</ul>\n")
+			inList = false
+		}
+		if inPara {
+			sb.WriteString("</p>\n")
+			inPara = false
+		}
+	}
+
+	for line := range strings.SplitSeq(body, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "":
+			closeBlocks()
+		case strings.HasPrefix(trimmed, "#"):
+			closeBlocks()
+			// Requirements are <h2>, so a #### Scenario: becomes <h3>
+			hashes := len(trimmed) - len(strings.TrimLeft(trimmed, "#"))
+			level := min(max(hashes-1, 3), 6)
+			fmt.Fprintf(sb, "<h%d>%s</h%d>\n", level, inlineHTML(trimmed[hashes:]), level)
+		case strings.HasPrefix(trimmed, "- ") || strings.HasPrefix(trimmed, "* "):
+			if inPara {
+				sb.WriteString("</p>\n")
+				inPara = false
+			}
+			if !inList {
+				sb.WriteString("<ul>\n")
+				inList = true
+			}
+			fmt.Fprintf(sb, "<li>%s</li>\n", inlineHTML(trimmed[2:]))
+		default:
+			if inList {
+				sb.WriteString("</ul>\n")
+				inList = false
+			}
+			if inPara {
+				sb.WriteString("\n")
+			} else {
+				sb.WriteString("<p>")
+				inPara = true
+			}
+			sb.WriteString(inlineHTML(trimmed))
+		}
+	}
+	closeBlocks()
+}
+
+// inlineHTML escapes text and converts **bold** to <strong>.
+func inlineHTML(text string) string {
+	return boldPattern.ReplaceAllString(
+		html.EscapeString(strings.TrimSpace(text)),
+		"<strong>$1</strong>",
+	)
+}
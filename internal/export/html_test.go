@@ -0,0 +1,85 @@
+package export
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/connerohnesorge/spectr/internal/heatmap"
+	"github.com/connerohnesorge/spectr/internal/parsers"
+)
+
+func TestFormatHTML(t *testing.T) {
+	reqs := []parsers.RequirementBlock{
+		{
+			Name: "Login",
+			Raw: `### Requirement: Login
+The system SHALL log <users> in.
+<!-- reviewer note -->
+
+#### Scenario: Valid password
+- **WHEN** a user signs in
+- **THEN** a session starts
+`,
+		},
+		{
+			Name: "Logout",
+			Raw:  "### Requirement: Logout\nThe system SHALL log users out.\n",
+		},
+	}
+	edited := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	hm := &heatmap.Heatmap{
+		Since: edited.AddDate(0, 0, -90),
+		Until: edited,
+		Requirements: []heatmap.Entry{
+			{Spec: "auth", ID: "AUTH-R1", Requirement: "Login", Edits: 3, LastEdited: edited, Level: 4},
+			{Spec: "auth", ID: "AUTH-R2", Requirement: "Logout"},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		hm      *heatmap.Heatmap
+		want    []string
+		notWant []string
+	}{
+		{
+			name: "plain",
+			want: []string{
+				"<title>Auth</title>",
+				`<section class="requirement" id="requirement-login">`,
+				"<p>The system SHALL log &lt;users&gt; in.</p>",
+				"<h3>Scenario: Valid password</h3>",
+				"<li><strong>WHEN</strong> a user signs in</li>",
+			},
+			notWant: []string{"reviewer note", `class="requirement heat-`, `class="legend"`},
+		},
+		{
+			name: "heatmap overlay",
+			hm:   hm,
+			want: []string{
+				"Edits from 2026-07-03 to 2026-10-01",
+				`<section class="requirement heat-4" id="requirement-login">`,
+				`<p class="heat">3 edits, last 2026-10-01</p>`,
+				`<section class="requirement" id="requirement-logout">`,
+				`<p class="heat">No edits</p>`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FormatHTML("Auth", reqs, tt.hm)
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("FormatHTML() missing %q in:\n%s", want, got)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(got, notWant) {
+					t.Errorf("FormatHTML() contains %q in:\n%s", notWant, got)
+				}
+			}
+		})
+	}
+}
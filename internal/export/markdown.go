@@ -0,0 +1,34 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/evidence"
+	"github.com/connerohnesorge/spectr/internal/markdown"
+)
+
+// FormatMarkdown renders a spec as markdown for readers outside the
+// project. The spec is kept as written, except that scenario keywords
+// aliased in spectr.yaml (scenarios.keywords) are translated back to the
+// English GIVEN, WHEN, THEN, AND and BUT.
+func FormatMarkdown(source []byte) string {
+	return string(markdown.CanonicalizeKeywords(source))
+}
+
+// AppendEvidence appends an "## Evidence" section listing the evidence in
+// ev to an exported markdown spec. Specs without evidence are unchanged.
+func AppendEvidence(output string, ev *evidence.File) string {
+	if ev == nil || len(ev.Evidence) == 0 {
+		return output
+	}
+
+	var sb strings.Builder
+	sb.WriteString(strings.TrimRight(output, "\n"))
+	sb.WriteString("\n\n## Evidence\n\n")
+	for _, entry := range ev.Evidence {
+		fmt.Fprintf(&sb, "- %s / %s: %s\n", entry.Requirement, entry.Scenario, entry.Ref)
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,70 @@
+package export
+
+import (
+	"testing"
+
+	"github.com/connerohnesorge/spectr/internal/evidence"
+	"github.com/connerohnesorge/spectr/internal/markdown"
+	"github.com/connerohnesorge/spectr/internal/parsers"
+)
+
+func TestFormatMarkdown_TranslatesKeywords(t *testing.T) {
+	err := markdown.SetKeywordAliases(map[string][]string{
+		"WHEN": {"QUAND"},
+		"THEN": {"ALORS"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = markdown.SetKeywordAliases(nil) })
+
+	source := `### Requirement: Connexion
+Le système DOIT connecter les utilisateurs.
+
+#### Scenario: Mot de passe correct
+- **QUAND** l'utilisateur se connecte
+- **ALORS** la session commence
+`
+
+	want := `### Requirement: Connexion
+Le système DOIT connecter les utilisateurs.
+
+#### Scenario: Mot de passe correct
+- **WHEN** l'utilisateur se connecte
+- **THEN** la session commence
+`
+	if got := FormatMarkdown([]byte(source)); got != want {
+		t.Errorf("FormatMarkdown() =\n%s\nwant:\n%s", got, want)
+	}
+
+	gherkin := FormatGherkin("Auth", []parsers.RequirementBlock{
+		{Name: "Connexion", Raw: source},
+	})
+	wantGherkin := `Feature: Auth
+
+  Rule: Connexion
+
+    Scenario: Mot de passe correct
+      When l'utilisateur se connecte
+      Then la session commence
+`
+	if gherkin != wantGherkin {
+		t.Errorf("FormatGherkin() =\n%s\nwant:\n%s", gherkin, wantGherkin)
+	}
+}
+
+func TestAppendEvidence(t *testing.T) {
+	source := "# Auth\n\n## Requirements\n"
+	if got := AppendEvidence(source, &evidence.File{}); got != source {
+		t.Errorf("AppendEvidence() without evidence = %q", got)
+	}
+
+	ev := &evidence.File{Evidence: []evidence.Entry{
+		{Requirement: "Login", Scenario: "Lockout", Ref: "https://ci.example/runs/7"},
+	}}
+	want := "# Auth\n\n## Requirements\n\n## Evidence\n\n" +
+		"- Login / Lockout: https://ci.example/runs/7\n"
+	if got := AppendEvidence(source, ev); got != want {
+		t.Errorf("AppendEvidence() = %q, want %q", got, want)
+	}
+}
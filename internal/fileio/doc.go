@@ -0,0 +1,17 @@
+// Package fileio reads project files for scans that touch every spec and
+// change, such as spectr list and spectr validate.
+//
+// On network filesystems the latency of each read, not its size,
+// dominates a scan, so Prefetch reads a tree with several workers ahead
+// of the scan; each prefetched file is handed out once and later reads go
+// to disk again, so a file edited mid-command is never served stale.
+// Reads that are not prefetched reuse pooled buffers for small files and,
+// when enabled, memory-map large ones instead of copying them through
+// read(2). Callers that keep the bytes use ReadFile; callers that only
+// need a string or a stream use ReadString or Open, which avoid the extra
+// copy.
+//
+// Every read converts the file to UTF-8: byte order marks are dropped and
+// UTF-16 and Windows-1252 files, as saved by Word and other Windows
+// editors, are decoded, so the markdown lexer always sees UTF-8 offsets.
+package fileio
@@ -0,0 +1,233 @@
+package fileio
+
+import (
+	"bytes"
+	"io"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Encoding is a text encoding detected by DetectEncoding.
+type Encoding string
+
+// Encodings that Decode converts to UTF-8.
+const (
+	EncodingUTF8        Encoding = "utf-8"
+	EncodingUTF8BOM     Encoding = "utf-8-bom"
+	EncodingUTF16LE     Encoding = "utf-16le"
+	EncodingUTF16BE     Encoding = "utf-16be"
+	EncodingWindows1252 Encoding = "windows-1252"
+)
+
+// Byte order marks.
+var (
+	bomUTF8    = []byte{0xEF, 0xBB, 0xBF}
+	bomUTF16LE = []byte{0xFF, 0xFE}
+	bomUTF16BE = []byte{0xFE, 0xFF}
+)
+
+// utf16Sample is how many leading bytes DetectEncoding inspects for
+// UTF-16 without a byte order mark.
+const utf16Sample = 512
+
+// DetectEncoding guesses the encoding of data. A byte order mark decides;
+// without one, text whose every other byte is NUL is taken as UTF-16,
+// valid UTF-8 as UTF-8 and anything else as Windows-1252, which is what
+// Word and most Windows editors save as "ANSI".
+func DetectEncoding(data []byte) Encoding {
+	switch {
+	case bytes.HasPrefix(data, bomUTF8):
+		return EncodingUTF8BOM
+	case bytes.HasPrefix(data, bomUTF16LE):
+		return EncodingUTF16LE
+	case bytes.HasPrefix(data, bomUTF16BE):
+		return EncodingUTF16BE
+	}
+
+	if enc, ok := detectUTF16(data); ok {
+		return enc
+	}
+	if utf8.Valid(data) {
+		return EncodingUTF8
+	}
+
+	return EncodingWindows1252
+}
+
+// detectUTF16 recognizes UTF-16 without a byte order mark by its NUL
+// bytes: mostly-ASCII text has a NUL in every high byte.
+func detectUTF16(data []byte) (Encoding, bool) {
+	if len(data) < 2 || len(data)%2 != 0 {
+		return "", false
+	}
+
+	sample := data[:min(len(data), utf16Sample)]
+
+	var evenNUL, oddNUL int
+	for i, b := range sample {
+		if b != 0 {
+			continue
+		}
+		if i%2 == 0 {
+			evenNUL++
+		} else {
+			oddNUL++
+		}
+	}
+
+	// Most code units must be ASCII, with NULs on one side only
+	units := len(sample) / 2
+	switch {
+	case oddNUL*2 > units && evenNUL*10 < oddNUL:
+		return EncodingUTF16LE, true
+	case evenNUL*2 > units && oddNUL*10 < evenNUL:
+		return EncodingUTF16BE, true
+	default:
+		return "", false
+	}
+}
+
+// Decode converts data to UTF-8 without a byte order mark and reports the
+// encoding it was in. UTF-8 input without a BOM is returned as is.
+func Decode(data []byte) ([]byte, Encoding) {
+	enc := DetectEncoding(data)
+	switch enc {
+	case EncodingUTF8BOM:
+		return data[len(bomUTF8):], enc
+	case EncodingUTF16LE:
+		return decodeUTF16(bytes.TrimPrefix(data, bomUTF16LE), false), enc
+	case EncodingUTF16BE:
+		return decodeUTF16(bytes.TrimPrefix(data, bomUTF16BE), true), enc
+	case EncodingWindows1252:
+		return appendLenient(make([]byte, 0, len(data)+len(data)/8), data), enc
+	default:
+		return data, enc
+	}
+}
+
+// decodeUTF16 converts UTF-16 to UTF-8. A trailing odd byte is dropped
+// and unpaired surrogates become U+FFFD.
+func decodeUTF16(data []byte, bigEndian bool) []byte {
+	units := make([]uint16, len(data)/2)
+	for i := range units {
+		hi, lo := data[2*i+1], data[2*i]
+		if bigEndian {
+			hi, lo = lo, hi
+		}
+		units[i] = uint16(hi)<<8 | uint16(lo)
+	}
+
+	out := make([]byte, 0, len(units))
+	for _, r := range utf16.Decode(units) {
+		out = utf8.AppendRune(out, r)
+	}
+
+	return out
+}
+
+// windows1252 maps the bytes 0x80-0x9F, where Windows-1252 differs from
+// Latin-1. Unassigned bytes map to U+FFFD.
+var windows1252 = [32]rune{
+	'€', '�', '‚', 'ƒ', '„', '…', '†', '‡',
+	'ˆ', '‰', 'Š', '‹', 'Œ', '�', 'Ž', '�',
+	'�', '‘', '’', '“', '”', '•', '–', '—',
+	'˜', '™', 'š', '›', 'œ', '�', 'ž', 'Ÿ',
+}
+
+// appendLenient appends data to out, keeping valid UTF-8 and decoding
+// every other byte as Windows-1252, so a stray byte from a Windows editor
+// does not garble the rest of a mostly UTF-8 file.
+func appendLenient(out, data []byte) []byte {
+	for len(data) > 0 {
+		r, size := utf8.DecodeRune(data)
+		if r == utf8.RuneError && size <= 1 {
+			out = utf8.AppendRune(out, decodeWindows1252Byte(data[0]))
+			data = data[1:]
+
+			continue
+		}
+		out = append(out, data[:size]...)
+		data = data[size:]
+	}
+
+	return out
+}
+
+// decodeWindows1252Byte returns the character b encodes in Windows-1252.
+func decodeWindows1252Byte(b byte) rune {
+	switch {
+	case b < 0x80:
+		return rune(b)
+	case b < 0xA0:
+		return windows1252[b-0x80]
+	default:
+		return rune(b)
+	}
+}
+
+// decodingReader converts a UTF-8 stream with stray Windows-1252 bytes to
+// UTF-8 the way Decode does, without reading it all first.
+type decodingReader struct {
+	src io.Reader
+	// buf holds a partial character carried over from the previous read
+	// followed by the bytes of the current one
+	buf     []byte
+	pending int
+	out     []byte
+	err     error
+}
+
+// newDecodingReader returns a decodingReader reading from src.
+func newDecodingReader(src io.Reader) *decodingReader {
+	return &decodingReader{src: src, buf: make([]byte, decodeChunk+utf8.UTFMax)}
+}
+
+// decodeChunk is the number of bytes a decodingReader reads at a time.
+const decodeChunk = 32 << 10
+
+// Read implements io.Reader.
+func (d *decodingReader) Read(p []byte) (int, error) {
+	for len(d.out) == 0 {
+		if d.err != nil {
+			return 0, d.err
+		}
+
+		n, err := d.src.Read(d.buf[d.pending : d.pending+decodeChunk])
+		data := d.buf[:d.pending+n]
+		d.err = err
+
+		keep := 0
+		if err == nil {
+			keep = incompleteTail(data)
+		}
+		data = data[:len(data)-keep]
+		if utf8.Valid(data) {
+			d.out = append(d.out[:0], data...)
+		} else {
+			d.out = appendLenient(d.out[:0], data)
+		}
+		d.pending = copy(d.buf, d.buf[len(data):len(data)+keep])
+	}
+
+	n := copy(p, d.out)
+	d.out = d.out[n:]
+
+	return n, nil
+}
+
+// incompleteTail returns the length of a character cut off at the end of
+// data, or 0 when data ends on a character boundary.
+func incompleteTail(data []byte) int {
+	for i := len(data) - 1; i >= 0 && i >= len(data)-utf8.UTFMax; i-- {
+		if !utf8.RuneStart(data[i]) {
+			continue
+		}
+		if utf8.FullRune(data[i:]) {
+			return 0
+		}
+
+		return len(data) - i
+	}
+
+	return 0
+}
@@ -0,0 +1,128 @@
+package fileio
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"unicode/utf16"
+)
+
+// encodeUTF16 encodes s as UTF-16 with an optional byte order mark.
+func encodeUTF16(s string, bigEndian, bom bool) []byte {
+	units := utf16.Encode([]rune(s))
+	if bom {
+		units = append([]uint16{0xFEFF}, units...)
+	}
+	out := make([]byte, 0, 2*len(units))
+	for _, u := range units {
+		if bigEndian {
+			out = append(out, byte(u>>8), byte(u))
+		} else {
+			out = append(out, byte(u), byte(u>>8))
+		}
+	}
+
+	return out
+}
+
+func TestDecode(t *testing.T) {
+	const text = "# Spec\n\nThe “system” SHALL — always.\n"
+
+	tests := []struct {
+		name string
+		data []byte
+		want string
+		enc  Encoding
+	}{
+		{"utf-8", []byte(text), text, EncodingUTF8},
+		{"utf-8 bom", append([]byte{0xEF, 0xBB, 0xBF}, text...), text, EncodingUTF8BOM},
+		{"utf-16le bom", encodeUTF16(text, false, true), text, EncodingUTF16LE},
+		{"utf-16be bom", encodeUTF16(text, true, true), text, EncodingUTF16BE},
+		{"utf-16le", encodeUTF16(text, false, false), text, EncodingUTF16LE},
+		{"utf-16be", encodeUTF16(text, true, false), text, EncodingUTF16BE},
+		{
+			"windows-1252",
+			[]byte("The \x93system\x94 SHALL \x97 caf\xe9.\n"),
+			"The “system” SHALL — café.\n",
+			EncodingWindows1252,
+		},
+		{
+			"utf-8 with stray byte",
+			[]byte("caf\xc3\xa9 \x85\n"),
+			"café …\n",
+			EncodingWindows1252,
+		},
+		{"empty", nil, "", EncodingUTF8},
+		{"odd length is not utf-16", []byte("a\x00b"), "a\x00b", EncodingUTF8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, enc := Decode(tt.data)
+			if string(got) != tt.want || enc != tt.enc {
+				t.Errorf("Decode() = %q, %s; want %q, %s", got, enc, tt.want, tt.enc)
+			}
+		})
+	}
+}
+
+func TestDecodingReader(t *testing.T) {
+	// Multi-byte characters and stray bytes straddle every read boundary
+	var src bytes.Buffer
+	for src.Len() < 3*decodeChunk {
+		src.WriteString("é—x\x93")
+	}
+	want, _ := Decode(src.Bytes())
+
+	got, err := io.ReadAll(newDecodingReader(iotest.OneByteReader(bytes.NewReader(src.Bytes()))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("one byte reads: got %d bytes, want %d", len(got), len(want))
+	}
+
+	got, err = io.ReadAll(newDecodingReader(bytes.NewReader(src.Bytes())))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("chunked reads: got %d bytes, want %d", len(got), len(want))
+	}
+}
+
+func TestReader_Decodes(t *testing.T) {
+	dir := t.TempDir()
+	want := "## Requirements\n\n“Quoted”\n"
+	small := writeTestFile(t, dir, "small.md", string(encodeUTF16(want, false, true)))
+	large := strings.Repeat(want, mmapMinSize/len(want)+1)
+	largePath := writeTestFile(t, dir, "large.md", "\xEF\xBB\xBF"+large)
+	files := map[string]string{small: want, largePath: large}
+
+	for _, mmap := range []bool{false, true} {
+		r := New(Options{Mmap: mmap})
+		for path, want := range files {
+			data, err := r.ReadFile(path)
+			if err != nil || string(data) != want {
+				t.Errorf("mmap=%v ReadFile(%s) = %d bytes, %v", mmap, path, len(data), err)
+			}
+
+			s, err := r.ReadString(path)
+			if err != nil || s != want {
+				t.Errorf("mmap=%v ReadString(%s) = %d bytes, %v", mmap, path, len(s), err)
+			}
+
+			rc, err := r.Open(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			data, err = io.ReadAll(rc)
+			_ = rc.Close()
+			if err != nil || string(data) != want {
+				t.Errorf("mmap=%v Open(%s) = %d bytes, %v", mmap, path, len(data), err)
+			}
+		}
+	}
+}
@@ -0,0 +1,399 @@
+package fileio
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+const (
+	// DefaultPrefetchWorkers is the number of parallel reads Prefetch
+	// uses when Options.PrefetchWorkers is not set.
+	DefaultPrefetchWorkers = 8
+
+	// pooledMaxSize is the largest file read into a pooled buffer.
+	pooledMaxSize = 64 << 10
+
+	// mmapMinSize is the smallest file memory-mapped when mmap is
+	// enabled; below it a read is cheaper than setting up a mapping.
+	mmapMinSize = 256 << 10
+)
+
+// Options configures a Reader.
+type Options struct {
+	// Mmap memory-maps large files on platforms that support it.
+	Mmap bool
+	// PrefetchWorkers is the number of parallel reads used by Prefetch.
+	// Zero means DefaultPrefetchWorkers.
+	PrefetchWorkers int
+}
+
+// Reader reads files through an optional prefetch cache.
+type Reader struct {
+	opts Options
+
+	mu      sync.Mutex
+	pending map[string]*prefetched
+}
+
+// prefetched is a file being, or already, read ahead of use.
+type prefetched struct {
+	done chan struct{}
+	data []byte
+	err  error
+}
+
+// bufPool holds buffers of pooledMaxSize capacity for small files.
+var bufPool = sync.Pool{
+	New: func() any {
+		buf := make([]byte, 0, pooledMaxSize)
+
+		return &buf
+	},
+}
+
+// New returns a Reader with the given options.
+func New(opts Options) *Reader {
+	if opts.PrefetchWorkers <= 0 {
+		opts.PrefetchWorkers = DefaultPrefetchWorkers
+	}
+
+	return &Reader{opts: opts, pending: make(map[string]*prefetched)}
+}
+
+// std is the Reader behind the package-level functions.
+var std = New(Options{})
+
+// Configure replaces the options of the package-level Reader. It must be
+// called before any reads, as spectr does while parsing flags.
+func Configure(opts Options) {
+	std = New(opts)
+}
+
+// ReadFile reads a file like os.ReadFile, using the package-level Reader.
+func ReadFile(path string) ([]byte, error) {
+	return std.ReadFile(path)
+}
+
+// ReadString reads a file as a string, using the package-level Reader.
+func ReadString(path string) (string, error) {
+	return std.ReadString(path)
+}
+
+// Open opens a file for streaming, using the package-level Reader.
+func Open(path string) (io.ReadCloser, error) {
+	return std.Open(path)
+}
+
+// Prefetch reads files ahead of use with the package-level Reader.
+func Prefetch(ctx context.Context, paths []string) (release func()) {
+	return std.Prefetch(ctx, paths)
+}
+
+// PrefetchTree prefetches a tree with the package-level Reader.
+func PrefetchTree(ctx context.Context, dir string) (release func()) {
+	return std.PrefetchTree(ctx, dir)
+}
+
+// ReadFile reads a file like os.ReadFile, converted to UTF-8 by Decode.
+// The caller owns the returned bytes.
+func (r *Reader) ReadFile(path string) ([]byte, error) {
+	if data, ok := r.take(path); ok {
+		decoded, _ := Decode(data)
+
+		return decoded, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	decoded, _ := Decode(data)
+
+	return decoded, nil
+}
+
+// ReadString reads a file as a string, converted to UTF-8 by Decode. Small
+// files go through a pooled buffer and large ones through a mapping when
+// mmap is enabled, so for UTF-8 files the string is the only copy made.
+func (r *Reader) ReadString(path string) (string, error) {
+	if data, ok := r.take(path); ok {
+		return decodeString(data), nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = f.Close() }()
+
+	info, err := f.Stat()
+	if err != nil {
+		return "", err
+	}
+	size := info.Size()
+
+	switch {
+	case size <= pooledMaxSize:
+		bufp, _ := bufPool.Get().(*[]byte)
+		defer bufPool.Put(bufp)
+		buf := bytes.NewBuffer((*bufp)[:0])
+		if _, err := buf.ReadFrom(f); err != nil {
+			return "", err
+		}
+		// Keep the buffer if the file grew past the pooled size
+		if buf.Cap() <= pooledMaxSize {
+			*bufp = buf.Bytes()[:0]
+		}
+
+		return decodeString(buf.Bytes()), nil
+	case r.opts.Mmap && size >= mmapMinSize:
+		data, unmap, err := mmapFile(f, size)
+		if err == nil {
+			defer unmap()
+
+			return decodeString(data), nil
+		}
+	}
+
+	data, err := io.ReadAll(f)
+
+	return decodeString(data), err
+}
+
+// decodeString returns data converted to UTF-8 as a string.
+func decodeString(data []byte) string {
+	decoded, _ := Decode(data)
+
+	return string(decoded)
+}
+
+// Open opens a file for streaming, converted to UTF-8 by Decode.
+// Prefetched files are served from memory and large files from a mapping
+// when mmap is enabled; the mapping is released on Close.
+func (r *Reader) Open(path string) (io.ReadCloser, error) {
+	if data, ok := r.take(path); ok {
+		decoded, _ := Decode(data)
+
+		return io.NopCloser(bytes.NewReader(decoded)), nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	if !r.opts.Mmap {
+		return openDecoded(f)
+	}
+
+	info, err := f.Stat()
+	if err != nil || info.Size() < mmapMinSize {
+		return openDecoded(f)
+	}
+	data, unmap, err := mmapFile(f, info.Size())
+	_ = f.Close()
+	if err != nil {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+
+		return openDecoded(f)
+	}
+	switch DetectEncoding(data) {
+	case EncodingUTF8:
+	case EncodingUTF8BOM:
+		data = data[len(bomUTF8):]
+	default:
+		// Converted text is a copy, so the mapping can go now
+		decoded, _ := Decode(data)
+		unmap()
+
+		return io.NopCloser(bytes.NewReader(decoded)), nil
+	}
+
+	return &mappedReader{Reader: bytes.NewReader(data), unmap: unmap}, nil
+}
+
+// openDecoded streams f as UTF-8. The start of the file decides: a UTF-8
+// byte order mark is skipped and UTF-16 is converted in one go; anything
+// else is streamed with stray bytes decoded as Windows-1252.
+func openDecoded(f *os.File) (io.ReadCloser, error) {
+	br := bufio.NewReaderSize(f, utf16Sample)
+	head, err := br.Peek(utf16Sample)
+	if err != nil && !errors.Is(err, io.EOF) {
+		_ = f.Close()
+
+		return nil, err
+	}
+
+	switch DetectEncoding(head) {
+	case EncodingUTF8BOM:
+		_, _ = br.Discard(len(bomUTF8))
+	case EncodingUTF16LE, EncodingUTF16BE:
+		data, err := io.ReadAll(br)
+		_ = f.Close()
+		if err != nil {
+			return nil, err
+		}
+		decoded, _ := Decode(data)
+
+		return io.NopCloser(bytes.NewReader(decoded)), nil
+	}
+
+	return &decodedFile{Reader: newDecodingReader(br), Closer: f}, nil
+}
+
+// decodedFile is a file read through a decodingReader.
+type decodedFile struct {
+	io.Reader
+	io.Closer
+}
+
+// mappedReader reads a memory-mapped file.
+type mappedReader struct {
+	*bytes.Reader
+	unmap func()
+	once  sync.Once
+}
+
+// Close releases the mapping.
+func (m *mappedReader) Close() error {
+	m.once.Do(m.unmap)
+
+	return nil
+}
+
+// Prefetch starts reading paths in parallel and returns at once. Later
+// reads of a path wait for its prefetch instead of going to disk; each
+// prefetched file is served once. Prefetching stops when ctx is done, and
+// release drops prefetched files nobody read.
+func (r *Reader) Prefetch(ctx context.Context, paths []string) (release func()) {
+	queue := make(chan string, len(paths))
+	entries := make(map[string]*prefetched, len(paths))
+
+	r.mu.Lock()
+	for _, path := range paths {
+		key := filepath.Clean(path)
+		if _, ok := r.pending[key]; ok {
+			continue
+		}
+		entry := &prefetched{done: make(chan struct{})}
+		r.pending[key] = entry
+		entries[key] = entry
+		queue <- key
+	}
+	r.mu.Unlock()
+	close(queue)
+
+	for range min(r.opts.PrefetchWorkers, len(entries)) {
+		go func() {
+			for key := range queue {
+				entry := entries[key]
+				if err := ctx.Err(); err != nil {
+					entry.err = err
+				} else {
+					entry.data, entry.err = os.ReadFile(key)
+				}
+				close(entry.done)
+			}
+		}()
+	}
+
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		for key, entry := range entries {
+			if r.pending[key] == entry {
+				delete(r.pending, key)
+			}
+		}
+	}
+}
+
+// PrefetchTree prefetches the markdown and JSON files under dir, skipping
+// archived changes.
+func (r *Reader) PrefetchTree(ctx context.Context, dir string) (release func()) {
+	var paths []string
+	_ = WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			// Unreadable parts of the tree are reported by the scan itself
+			return nil
+		}
+		if entry.IsDir() {
+			if entry.Name() == "archive" {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+		switch filepath.Ext(path) {
+		case ".md", ".json", ".jsonc":
+			paths = append(paths, path)
+		}
+
+		return nil
+	})
+
+	return r.Prefetch(ctx, paths)
+}
+
+// take returns and forgets the prefetched contents of path, waiting for
+// the prefetch to finish. It reports false when path was not prefetched
+// or the prefetch failed.
+func (r *Reader) take(path string) ([]byte, bool) {
+	key := filepath.Clean(path)
+
+	r.mu.Lock()
+	entry, ok := r.pending[key]
+	if ok {
+		delete(r.pending, key)
+	}
+	r.mu.Unlock()
+	if !ok {
+		return nil, false
+	}
+
+	<-entry.done
+	if entry.err != nil {
+		return nil, false
+	}
+
+	return entry.data, true
+}
+
+// WalkDir walks root like filepath.WalkDir, but follows root itself when
+// it is a symbolic link, so a symlinked spectr/ or specs/ directory is
+// walked rather than reported as a single entry. Paths are still reported
+// under root. Links below root are not followed, so a link cycle cannot
+// make the walk loop.
+func WalkDir(root string, fn fs.WalkDirFunc) error {
+	info, err := os.Lstat(root)
+	if err != nil || info.Mode()&fs.ModeSymlink == 0 {
+		return filepath.WalkDir(root, fn)
+	}
+
+	target, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		// Let fn see the broken link the way filepath.WalkDir reports it
+		return filepath.WalkDir(root, fn)
+	}
+
+	return filepath.WalkDir(
+		target,
+		func(path string, entry fs.DirEntry, err error) error {
+			rel, relErr := filepath.Rel(target, path)
+			if relErr != nil {
+				return relErr
+			}
+
+			return fn(filepath.Join(root, rel), entry, err)
+		},
+	)
+}
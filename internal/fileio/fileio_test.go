@@ -0,0 +1,172 @@
+package fileio
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func writeTestFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestReader_Reads(t *testing.T) {
+	dir := t.TempDir()
+	small := strings.Repeat("small\n", 10)
+	large := strings.Repeat("large\n", mmapMinSize/6+1)
+	files := map[string]string{
+		writeTestFile(t, dir, "small.md", small): small,
+		writeTestFile(t, dir, "large.md", large): large,
+		writeTestFile(t, dir, "empty.md", ""):    "",
+	}
+
+	for _, mmap := range []bool{false, true} {
+		r := New(Options{Mmap: mmap})
+		for path, want := range files {
+			name := filepath.Base(path)
+
+			data, err := r.ReadFile(path)
+			if err != nil || string(data) != want {
+				t.Errorf("mmap=%v ReadFile(%s) = %d bytes, %v", mmap, name, len(data), err)
+			}
+
+			// Twice, so the second small read reuses a pooled buffer
+			for range 2 {
+				s, err := r.ReadString(path)
+				if err != nil || s != want {
+					t.Errorf("mmap=%v ReadString(%s) = %d bytes, %v", mmap, name, len(s), err)
+				}
+			}
+
+			rc, err := r.Open(path)
+			if err != nil {
+				t.Fatalf("mmap=%v Open(%s): %v", mmap, name, err)
+			}
+			data, err = io.ReadAll(rc)
+			if err != nil || string(data) != want {
+				t.Errorf("mmap=%v Open(%s) read %d bytes, %v", mmap, name, len(data), err)
+			}
+			if err := rc.Close(); err != nil {
+				t.Errorf("mmap=%v Close(%s): %v", mmap, name, err)
+			}
+		}
+	}
+
+	if _, err := New(Options{}).ReadString(filepath.Join(dir, "missing.md")); !os.IsNotExist(err) {
+		t.Errorf("ReadString(missing) error = %v, want not exist", err)
+	}
+}
+
+func TestReader_PrefetchServesOnce(t *testing.T) {
+	dir := t.TempDir()
+	path := writeTestFile(t, dir, "spec.md", "before")
+
+	r := New(Options{PrefetchWorkers: 2})
+	release := r.Prefetch(context.Background(), []string{path})
+	defer release()
+
+	// Wait for the prefetch, then change the file behind its back
+	r.mu.Lock()
+	entry := r.pending[path]
+	r.mu.Unlock()
+	<-entry.done
+	writeTestFile(t, dir, "spec.md", "after")
+
+	if s, _ := r.ReadString(path); s != "before" {
+		t.Errorf("first read = %q, want prefetched %q", s, "before")
+	}
+	if s, _ := r.ReadString(path); s != "after" {
+		t.Errorf("second read = %q, want %q from disk", s, "after")
+	}
+}
+
+func TestReader_PrefetchTree(t *testing.T) {
+	dir := t.TempDir()
+	spec := writeTestFile(t, dir, "specs/auth/spec.md", "spec")
+	tasks := writeTestFile(t, dir, "changes/add-2fa/tasks.jsonc", "{}")
+	archived := writeTestFile(t, dir, "changes/archive/old/proposal.md", "old")
+	other := writeTestFile(t, dir, "notes.txt", "notes")
+
+	r := New(Options{})
+	release := r.PrefetchTree(context.Background(), dir)
+
+	r.mu.Lock()
+	got := make(map[string]bool)
+	for path := range r.pending {
+		got[path] = true
+	}
+	r.mu.Unlock()
+	if !got[spec] || !got[tasks] || got[archived] || got[other] || len(got) != 2 {
+		t.Errorf("prefetched %v, want only %s and %s", got, spec, tasks)
+	}
+
+	release()
+	r.mu.Lock()
+	remaining := len(r.pending)
+	r.mu.Unlock()
+	if remaining != 0 {
+		t.Errorf("%d files still prefetched after release", remaining)
+	}
+}
+
+func TestReader_PrefetchCanceled(t *testing.T) {
+	dir := t.TempDir()
+	path := writeTestFile(t, dir, "spec.md", "content")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	r := New(Options{})
+	defer r.Prefetch(ctx, []string{path})()
+
+	// A canceled prefetch falls back to reading the file
+	if s, err := r.ReadString(path); err != nil || s != "content" {
+		t.Errorf("ReadString = %q, %v; want %q", s, err, "content")
+	}
+}
+
+func TestWalkDir_FollowsSymlinkedRoot(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, "real/auth/spec.md", "spec")
+	// A link back up must not make the walk loop
+	if err := os.Symlink("..", filepath.Join(dir, "real", "auth", "up")); err != nil {
+		t.Fatal(err)
+	}
+	link := filepath.Join(dir, "specs")
+	if err := os.Symlink(filepath.Join(dir, "real"), link); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	err := WalkDir(link, func(path string, _ fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(dir, path)
+		got = append(got, filepath.ToSlash(rel))
+
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"specs", "specs/auth", "specs/auth/spec.md", "specs/auth/up"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("walked %v, want %v", got, want)
+	}
+}
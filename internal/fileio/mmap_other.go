@@ -0,0 +1,14 @@
+//go:build !unix
+
+package fileio
+
+import (
+	"errors"
+	"os"
+)
+
+// mmapFile is not supported on this platform; callers fall back to
+// reading the file.
+func mmapFile(_ *os.File, _ int64) ([]byte, func(), error) {
+	return nil, nil, errors.ErrUnsupported
+}
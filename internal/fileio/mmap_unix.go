@@ -0,0 +1,25 @@
+//go:build unix
+
+package fileio
+
+import (
+	"os"
+	"syscall"
+)
+
+// mmapFile maps size bytes of f read-only. unmap releases the mapping;
+// the data must not be used after it.
+func mmapFile(f *os.File, size int64) (data []byte, unmap func(), err error) {
+	data, err = syscall.Mmap(
+		int(f.Fd()),
+		0,
+		int(size),
+		syscall.PROT_READ,
+		syscall.MAP_SHARED,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return data, func() { _ = syscall.Munmap(data) }, nil
+}
@@ -1,8 +1,9 @@
 package git
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"os/exec"
 	"strings"
 
 	"github.com/connerohnesorge/spectr/internal/specterrs"
@@ -12,11 +13,13 @@ import (
 // If preferredBase is provided and exists, it returns origin/<preferredBase>.
 // Otherwise, it auto-detects origin/main or falls back to origin/master.
 func GetBaseBranch(
+	ctx context.Context,
 	preferredBase string,
 ) (string, error) {
 	if preferredBase != "" {
 		// Check if the preferred base exists
 		exists, err := remoteBranchExists(
+			ctx,
 			preferredBase,
 		)
 		if err != nil {
@@ -40,7 +43,7 @@ func GetBaseBranch(
 	}
 
 	// Auto-detect: check for main first, then master
-	mainExists, err := remoteBranchExists("main")
+	mainExists, err := remoteBranchExists(ctx, "main")
 	if err != nil {
 		return "", fmt.Errorf(
 			"failed to check for main branch: %w",
@@ -52,6 +55,7 @@ func GetBaseBranch(
 	}
 
 	masterExists, err := remoteBranchExists(
+		ctx,
 		"master",
 	)
 	if err != nil {
@@ -69,30 +73,19 @@ func GetBaseBranch(
 
 // remoteBranchExists checks if a branch exists on the origin remote.
 func remoteBranchExists(
+	ctx context.Context,
 	branchName string,
 ) (bool, error) {
-	cmd := exec.Command(
-		gitCmd,
+	output, err := Run(
+		ctx,
+		"",
 		"ls-remote",
 		"--heads",
 		"origin",
 		branchName,
 	)
-	output, err := cmd.Output()
 	if err != nil {
-		if exitErr, ok := err.(*exec.ExitError); ok {
-			return false, fmt.Errorf(
-				"git ls-remote failed: %s",
-				strings.TrimSpace(
-					string(exitErr.Stderr),
-				),
-			)
-		}
-
-		return false, fmt.Errorf(
-			"failed to run git ls-remote: %w",
-			err,
-		)
+		return false, err
 	}
 
 	// If output contains the branch name, it exists
@@ -103,41 +96,43 @@ func remoteBranchExists(
 
 // BranchExists checks if a branch exists on the origin remote.
 func BranchExists(
+	ctx context.Context,
 	branchName string,
 ) (bool, error) {
-	return remoteBranchExists(branchName)
+	return remoteBranchExists(ctx, branchName)
+}
+
+// CurrentBranch returns the short name of the branch checked out in dir,
+// or "" when HEAD is detached.
+func CurrentBranch(ctx context.Context, dir string) (string, error) {
+	output, err := Run(ctx, dir, "symbolic-ref", "--quiet", "--short", "HEAD")
+	if err != nil {
+		// A detached HEAD makes symbolic-ref fail without a message
+		var cmdErr *specterrs.GitCommandError
+		if errors.As(err, &cmdErr) && cmdErr.Stderr == "" {
+			return "", nil
+		}
+
+		return "", err
+	}
+
+	return strings.TrimSpace(string(output)), nil
 }
 
 // DeleteRemoteBranch deletes a branch from the origin remote.
-func DeleteRemoteBranch(branchName string) error {
-	cmd := exec.Command(
-		gitCmd,
-		"push",
-		"origin",
-		"--delete",
-		branchName,
-	)
-	output, err := cmd.CombinedOutput()
+func DeleteRemoteBranch(ctx context.Context, branchName string) error {
+	_, err := Run(ctx, "", "push", "origin", "--delete", branchName)
 	if err != nil {
-		return fmt.Errorf(
-			"failed to delete remote branch '%s': %s",
-			branchName,
-			strings.TrimSpace(string(output)),
-		)
+		return Failuref(err, "failed to delete remote branch '%s'", branchName)
 	}
 
 	return nil
 }
 
 // FetchOrigin fetches the latest refs from the origin remote.
-func FetchOrigin() error {
-	cmd := exec.Command(gitCmd, "fetch", "origin")
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return fmt.Errorf(
-			"failed to fetch from origin: %s",
-			strings.TrimSpace(string(output)),
-		)
+func FetchOrigin(ctx context.Context) error {
+	if _, err := Run(ctx, "", "fetch", "origin"); err != nil {
+		return Failuref(err, "failed to fetch from origin")
 	}
 
 	return nil
@@ -146,34 +141,21 @@ func FetchOrigin() error {
 // GetRepoRoot returns the absolute path to the root of the git repository.
 // Returns an error if not in a git repository.
 func GetRepoRoot() (string, error) {
-	cmd := exec.Command(
-		gitCmd,
+	output, err := Run(
+		context.Background(),
+		"",
 		"rev-parse",
 		"--show-toplevel",
 	)
-	output, err := cmd.Output()
 	if err != nil {
-		if exitErr, ok := err.(*exec.ExitError); ok {
-			stderr := strings.TrimSpace(
-				string(exitErr.Stderr),
-			)
-			if strings.Contains(
-				stderr,
-				"not a git repository",
-			) {
-				return "", &specterrs.NotInGitRepositoryError{}
-			}
-
-			return "", fmt.Errorf(
-				"git rev-parse failed: %s",
-				stderr,
-			)
+		if strings.Contains(
+			FailureOutput(err),
+			"not a git repository",
+		) {
+			return "", &specterrs.NotInGitRepositoryError{}
 		}
 
-		return "", fmt.Errorf(
-			"failed to run git rev-parse: %w",
-			err,
-		)
+		return "", err
 	}
 
 	return strings.TrimSpace(string(output)), nil
@@ -183,29 +165,12 @@ func GetRepoRoot() (string, error) {
 // The ref should be a full ref like "origin/main" or "origin/master".
 // The path should be relative to the repository root.
 func PathExistsOnRef(
+	ctx context.Context,
 	ref, path string,
 ) (bool, error) {
-	cmd := exec.Command(
-		gitCmd,
-		"ls-tree",
-		ref,
-		path,
-	)
-	output, err := cmd.Output()
+	output, err := Run(ctx, "", "ls-tree", ref, path)
 	if err != nil {
-		if exitErr, ok := err.(*exec.ExitError); ok {
-			return false, fmt.Errorf(
-				"git ls-tree failed: %s",
-				strings.TrimSpace(
-					string(exitErr.Stderr),
-				),
-			)
-		}
-
-		return false, fmt.Errorf(
-			"failed to run git ls-tree: %w",
-			err,
-		)
+		return false, err
 	}
 
 	// If output is non-empty, the path exists on the ref
@@ -216,17 +181,17 @@ func PathExistsOnRef(
 
 // deleteBranch deletes a local branch, ignoring errors if not found.
 func deleteBranch(branchName string) []string {
-	cmd := exec.Command(
-		gitCmd,
+	_, err := Run(
+		context.Background(),
+		"",
 		"branch",
 		"-D",
 		branchName,
 	)
-	output, err := cmd.CombinedOutput()
 	if err == nil {
 		return nil
 	}
-	outputStr := strings.TrimSpace(string(output))
+	outputStr := FailureOutput(err)
 	notFound := strings.Contains(
 		outputStr,
 		"not found",
@@ -0,0 +1,41 @@
+package git
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCurrentBranch(t *testing.T) {
+	if !isGitAvailable() {
+		t.Skip("git is not available")
+	}
+
+	ctx := context.Background()
+	repo := t.TempDir()
+	runGit(t, repo, "init", "-q")
+	if err := os.WriteFile(filepath.Join(repo, "a.txt"), []byte("a\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, repo, "add", "-A")
+	runGit(t, repo, "commit", "-q", "-m", "init")
+	runGit(t, repo, "checkout", "-q", "-b", "spectr/add-2fa")
+
+	got, err := CurrentBranch(ctx, repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "spectr/add-2fa" {
+		t.Errorf("CurrentBranch() = %q, want spectr/add-2fa", got)
+	}
+
+	runGit(t, repo, "checkout", "-q", "--detach")
+	got, err = CurrentBranch(ctx, repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "" {
+		t.Errorf("CurrentBranch() on detached HEAD = %q, want empty", got)
+	}
+}
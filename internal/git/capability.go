@@ -0,0 +1,57 @@
+package git
+
+import (
+	"errors"
+	"os/exec"
+
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+// Checker is implemented by executors that can tell, without running a
+// command, whether they are usable. Executors that do not implement it are
+// assumed to be.
+type Checker interface {
+	// Available returns nil when the executor can run git commands.
+	Available() error
+}
+
+// Available implements Checker: the git binary must be in PATH.
+func (ExecExecutor) Available() error {
+	if _, err := exec.LookPath(gitCmd); err != nil {
+		return &specterrs.GitUnavailableError{Err: err}
+	}
+
+	return nil
+}
+
+// Available reports whether the default executor can run git commands.
+// Commands that only read the spectr/ tree never need git; features that
+// do should check this first so they fail with a clear message, or are
+// skipped when they are optional.
+func Available() error {
+	checker, ok := DefaultExecutor().(Checker)
+	if !ok {
+		return nil
+	}
+
+	return checker.Available()
+}
+
+// Require returns a *specterrs.GitUnavailableError naming feature when git
+// is not available.
+func Require(feature string) error {
+	err := Available()
+	if err == nil {
+		return nil
+	}
+
+	var unavailableErr *specterrs.GitUnavailableError
+	if errors.As(err, &unavailableErr) {
+		return &specterrs.GitUnavailableError{
+			Feature: feature,
+			Err:     unavailableErr.Err,
+		}
+	}
+
+	return &specterrs.GitUnavailableError{Feature: feature, Err: err}
+}
@@ -0,0 +1,45 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+func TestRequire_GitMissing(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+	previous := SetExecutor(ExecExecutor{})
+	t.Cleanup(func() { SetExecutor(previous) })
+
+	err := Require("spectr hooks install")
+	var unavailableErr *specterrs.GitUnavailableError
+	if !errors.As(err, &unavailableErr) {
+		t.Fatalf("Require() error = %v, want GitUnavailableError", err)
+	}
+	if !strings.HasPrefix(err.Error(), "spectr hooks install needs git") {
+		t.Errorf("error = %q, want it to name the feature", err)
+	}
+
+	_, err = Run(context.Background(), "", "status")
+	if !errors.As(err, &unavailableErr) {
+		t.Errorf("Run() error = %v, want GitUnavailableError", err)
+	}
+
+	err = ExportTree(context.Background(), ".", "HEAD", "spectr", t.TempDir())
+	if !errors.As(err, &unavailableErr) {
+		t.Errorf("ExportTree() error = %v, want GitUnavailableError", err)
+	}
+}
+
+func TestRequire_ExecutorWithoutChecker(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+	previous := SetExecutor(&recordingExecutor{})
+	t.Cleanup(func() { SetExecutor(previous) })
+
+	if err := Require("spectr pr"); err != nil {
+		t.Errorf("Require() error = %v, want nil", err)
+	}
+}
@@ -0,0 +1,115 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+const (
+	// ChangeBranchPrefix prefixes branches created for change worktrees.
+	ChangeBranchPrefix = "spectr/change/"
+	// changeMarkerFile records a worktree's change ID. It lives in the
+	// worktree's private git directory (.git/worktrees/<name>/), so it is
+	// never committed and goes away when the worktree is removed.
+	changeMarkerFile = "spectr-change"
+	// markerPerm is the permission for the change marker file.
+	markerPerm = 0o644
+)
+
+// ChangeWorktreeConfig describes a worktree dedicated to one change.
+type ChangeWorktreeConfig struct {
+	RepoDir  string // Directory inside the repository to branch from
+	Path     string // Where to create the worktree
+	ChangeID string // Change the worktree is for
+	Base     string // Commit-ish to start from; empty means HEAD
+}
+
+// ChangeBranchName returns the branch used for a change worktree.
+func ChangeBranchName(changeID string) string {
+	return ChangeBranchPrefix + changeID
+}
+
+// CreateChangeWorktree adds a worktree at config.Path on a new branch named
+// after the change and records the change ID in it, so WorktreeChangeID
+// finds it from anywhere inside the worktree. It returns the branch name.
+func CreateChangeWorktree(
+	ctx context.Context,
+	config ChangeWorktreeConfig,
+) (string, error) {
+	if _, err := os.Stat(config.Path); err == nil {
+		return "", &specterrs.WorktreePathExistsError{Path: config.Path}
+	}
+
+	branch := ChangeBranchName(config.ChangeID)
+	args := []string{"worktree", "add", "-b", branch, config.Path}
+	if config.Base != "" {
+		args = append(args, config.Base)
+	}
+	if _, err := Run(ctx, config.RepoDir, args...); err != nil {
+		return "", handleWorktreeError(
+			FailureOutput(err),
+			WorktreeConfig{BranchName: branch, BaseBranch: config.Base},
+		)
+	}
+
+	gitDir, err := worktreeGitDir(ctx, config.Path)
+	if err == nil {
+		err = os.WriteFile(
+			filepath.Join(gitDir, changeMarkerFile),
+			[]byte(config.ChangeID+"\n"),
+			markerPerm,
+		)
+	}
+	if err != nil {
+		return "", fmt.Errorf(
+			"worktree created at %s but could not record change %s: %w",
+			config.Path,
+			config.ChangeID,
+			err,
+		)
+	}
+
+	return branch, nil
+}
+
+// WorktreeChangeID returns the change ID recorded for the worktree that
+// contains dir, or "" when dir is not in a change worktree.
+func WorktreeChangeID(ctx context.Context, dir string) (string, error) {
+	gitDir, err := worktreeGitDir(ctx, dir)
+	if err != nil {
+		var gitErr *specterrs.GitCommandError
+		if errors.As(err, &gitErr) {
+			// Not inside a repository
+			return "", nil
+		}
+
+		return "", err
+	}
+
+	data, err := os.ReadFile(filepath.Join(gitDir, changeMarkerFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("read worktree change marker: %w", err)
+	}
+
+	return strings.TrimSpace(string(data)), nil
+}
+
+// worktreeGitDir returns the absolute private git directory of the
+// worktree containing dir.
+func worktreeGitDir(ctx context.Context, dir string) (string, error) {
+	out, err := Run(ctx, dir, "rev-parse", "--absolute-git-dir")
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
@@ -0,0 +1,76 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+func TestCreateChangeWorktree(t *testing.T) {
+	if !isGitAvailable() {
+		t.Skip("git is not available")
+	}
+
+	ctx := context.Background()
+	base := t.TempDir()
+	repo := filepath.Join(base, "repo")
+	if err := os.MkdirAll(filepath.Join(repo, "sub"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, repo, "init", "-q")
+	if err := os.WriteFile(filepath.Join(repo, "sub", "a.txt"), []byte("a\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, repo, "add", "-A")
+	runGit(t, repo, "commit", "-q", "-m", "init")
+
+	path := filepath.Join(base, "repo-add-2fa")
+	branch, err := CreateChangeWorktree(ctx, ChangeWorktreeConfig{
+		RepoDir:  repo,
+		Path:     path,
+		ChangeID: "add-2fa",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if branch != "spectr/change/add-2fa" {
+		t.Errorf("branch = %q", branch)
+	}
+
+	out, err := Run(ctx, path, "branch", "--show-current")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(out); got != branch+"\n" {
+		t.Errorf("worktree is on %q, want %q", got, branch)
+	}
+
+	for dir, want := range map[string]string{
+		path:                       "add-2fa",
+		filepath.Join(path, "sub"): "add-2fa",
+		repo:                       "",
+		base:                       "",
+	} {
+		got, err := WorktreeChangeID(ctx, dir)
+		if err != nil {
+			t.Fatalf("%s: %v", dir, err)
+		}
+		if got != want {
+			t.Errorf("WorktreeChangeID(%s) = %q, want %q", dir, got, want)
+		}
+	}
+
+	_, err = CreateChangeWorktree(ctx, ChangeWorktreeConfig{
+		RepoDir:  repo,
+		Path:     path,
+		ChangeID: "add-2fa",
+	})
+	var existsErr *specterrs.WorktreePathExistsError
+	if !errors.As(err, &existsErr) {
+		t.Errorf("expected WorktreePathExistsError, got %v", err)
+	}
+}
@@ -1,3 +1,9 @@
 // Package git provides Git operations for PR workflows including platform
 // detection and worktree management.
+//
+// Every git invocation goes through an Executor. The default ExecExecutor
+// shells out to the git binary. GoGitExecutor, registered as "go-git", runs
+// the read-only commands in-process for environments without git. Backends
+// are registered with RegisterBackend and selected with the git.backend key
+// in spectr.yaml.
 package git
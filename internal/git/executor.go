@@ -0,0 +1,213 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+// BackendExec is the default backend, which runs the git binary.
+const BackendExec = "exec"
+
+// Command describes a single git invocation.
+type Command struct {
+	// Dir is the working directory. Empty means the current directory.
+	Dir string
+	// Args are the git arguments, excluding the "git" program name.
+	Args []string
+	// Stdin is fed to the command when non-nil.
+	Stdin io.Reader
+	// Env holds extra KEY=VALUE entries added to the environment.
+	Env []string
+}
+
+// Executor runs git commands. All git access in spectr goes through an
+// Executor so the exec-based backend can be replaced, for example by a
+// pure-Go implementation in environments without a git binary.
+type Executor interface {
+	// Run executes cmd and returns its stdout. A command that ran but
+	// failed is reported as *specterrs.GitCommandError, and a missing git
+	// as *specterrs.GitUnavailableError.
+	Run(ctx context.Context, cmd Command) ([]byte, error)
+}
+
+// ExecExecutor runs commands with the git binary found in PATH.
+type ExecExecutor struct{}
+
+// Run implements Executor.
+func (ExecExecutor) Run(
+	ctx context.Context,
+	cmd Command,
+) ([]byte, error) {
+	c := exec.CommandContext(ctx, gitCmd, cmd.Args...)
+	c.Dir = cmd.Dir
+	c.Stdin = cmd.Stdin
+	if len(cmd.Env) > 0 {
+		c.Env = append(os.Environ(), cmd.Env...)
+	}
+
+	var stderr bytes.Buffer
+	c.Stderr = &stderr
+
+	output, err := c.Output()
+	if err == nil {
+		return output, nil
+	}
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return nil, ctxErr
+	}
+
+	if errors.Is(err, exec.ErrNotFound) {
+		return nil, &specterrs.GitUnavailableError{Err: err}
+	}
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return nil, &specterrs.GitCommandError{
+			Args:   cmd.Args,
+			Stderr: strings.TrimSpace(stderr.String()),
+			Err:    err,
+		}
+	}
+
+	return nil, &specterrs.GitCommandError{Args: cmd.Args, Err: err}
+}
+
+// backends maps backend names to constructors. Optional backends register
+// themselves from init functions in their own files.
+var (
+	backendsMu sync.RWMutex
+	backends   = map[string]func() Executor{
+		BackendExec: func() Executor { return ExecExecutor{} },
+	}
+
+	defaultExecutor Executor = ExecExecutor{}
+)
+
+// RegisterBackend makes a backend selectable by name.
+func RegisterBackend(name string, newExecutor func() Executor) {
+	backendsMu.Lock()
+	defer backendsMu.Unlock()
+
+	backends[name] = newExecutor
+}
+
+// NewExecutor returns the executor for the named backend. An empty name
+// selects the exec backend.
+func NewExecutor(backend string) (Executor, error) {
+	if backend == "" {
+		backend = BackendExec
+	}
+
+	backendsMu.RLock()
+	defer backendsMu.RUnlock()
+
+	newExecutor, ok := backends[backend]
+	if !ok {
+		return nil, &specterrs.UnknownGitBackendError{
+			Backend:   backend,
+			Available: backendNames(),
+		}
+	}
+
+	return newExecutor(), nil
+}
+
+// backendNames returns the registered backend names in sorted order. The
+// caller must hold backendsMu.
+func backendNames() []string {
+	names := make([]string, 0, len(backends))
+	for name := range backends {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// UseBackend selects the executor used by the package-level git functions.
+func UseBackend(backend string) error {
+	executor, err := NewExecutor(backend)
+	if err != nil {
+		return err
+	}
+	SetExecutor(executor)
+
+	return nil
+}
+
+// SetExecutor replaces the executor used by the package-level git
+// functions and returns the previous one.
+func SetExecutor(executor Executor) Executor {
+	backendsMu.Lock()
+	defer backendsMu.Unlock()
+
+	previous := defaultExecutor
+	defaultExecutor = executor
+
+	return previous
+}
+
+// DefaultExecutor returns the executor used by the package-level git
+// functions.
+func DefaultExecutor() Executor {
+	backendsMu.RLock()
+	defer backendsMu.RUnlock()
+
+	return defaultExecutor
+}
+
+// Run executes git with args in dir using the default executor.
+func Run(
+	ctx context.Context,
+	dir string,
+	args ...string,
+) ([]byte, error) {
+	return DefaultExecutor().Run(ctx, Command{Dir: dir, Args: args})
+}
+
+// FailureOutput returns the diagnostic text of a failed git command: its
+// stderr when available, otherwise the error message.
+func FailureOutput(err error) string {
+	var cmdErr *specterrs.GitCommandError
+	if errors.As(err, &cmdErr) && cmdErr.Stderr != "" {
+		return cmdErr.Stderr
+	}
+
+	return err.Error()
+}
+
+// failureError reports a failed git command by its output alone while
+// keeping the command error in the chain, so spectr still exits with
+// specterrs.ExitGit.
+type failureError struct {
+	msg string
+	err error
+}
+
+func (e *failureError) Error() string { return e.msg }
+
+func (e *failureError) Unwrap() error { return e.err }
+
+// Failure returns an error reading FailureOutput(err) that wraps err.
+func Failure(err error) error {
+	return &failureError{msg: FailureOutput(err), err: err}
+}
+
+// Failuref returns an error reading "<message>: <FailureOutput(err)>" that
+// wraps err.
+func Failuref(err error, format string, args ...any) error {
+	return &failureError{
+		msg: fmt.Sprintf(format, args...) + ": " + FailureOutput(err),
+		err: err,
+	}
+}
@@ -0,0 +1,162 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+// recordingExecutor records commands and returns canned output.
+type recordingExecutor struct {
+	commands [][]string
+	output   string
+	err      error
+}
+
+func (r *recordingExecutor) Run(
+	_ context.Context,
+	cmd Command,
+) ([]byte, error) {
+	r.commands = append(r.commands, cmd.Args)
+
+	return []byte(r.output), r.err
+}
+
+func TestNewExecutor(t *testing.T) {
+	tests := []struct {
+		name    string
+		backend string
+		want    Executor
+		wantErr bool
+	}{
+		{"default", "", ExecExecutor{}, false},
+		{"exec", BackendExec, ExecExecutor{}, false},
+		{"go-git", BackendGoGit, GoGitExecutor{}, false},
+		{"unknown", "svn", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			executor, err := NewExecutor(tt.backend)
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("NewExecutor(%q) error: %v", tt.backend, err)
+				}
+				if executor != tt.want {
+					t.Errorf("NewExecutor(%q) = %T, want %T", tt.backend, executor, tt.want)
+				}
+
+				return
+			}
+
+			var backendErr *specterrs.UnknownGitBackendError
+			if !errors.As(err, &backendErr) {
+				t.Fatalf("NewExecutor(%q) error = %v, want UnknownGitBackendError", tt.backend, err)
+			}
+			if !strings.Contains(err.Error(), "available: exec, go-git") {
+				t.Errorf("error %q does not list available backends", err)
+			}
+		})
+	}
+}
+
+func TestRegisterBackend(t *testing.T) {
+	fake := &recordingExecutor{output: "origin/main\n"}
+	RegisterBackend("fake", func() Executor { return fake })
+	t.Cleanup(func() {
+		backendsMu.Lock()
+		delete(backends, "fake")
+		backendsMu.Unlock()
+	})
+
+	previous := DefaultExecutor()
+	t.Cleanup(func() { SetExecutor(previous) })
+
+	if err := UseBackend("fake"); err != nil {
+		t.Fatalf("UseBackend: %v", err)
+	}
+
+	exists, err := PathExistsOnRef(context.Background(), "origin/main", "spectr")
+	if err != nil {
+		t.Fatalf("PathExistsOnRef: %v", err)
+	}
+	if !exists {
+		t.Error("PathExistsOnRef = false, want true")
+	}
+
+	want := "ls-tree origin/main spectr"
+	if len(fake.commands) != 1 || strings.Join(fake.commands[0], " ") != want {
+		t.Errorf("commands = %v, want [%s]", fake.commands, want)
+	}
+}
+
+func TestExecExecutor_Failure(t *testing.T) {
+	if !isGitAvailable() {
+		t.Skip("git is not available")
+	}
+
+	_, err := ExecExecutor{}.Run(context.Background(), Command{
+		Dir:  t.TempDir(),
+		Args: []string{"rev-parse", "--verify", "no-such-ref"},
+	})
+
+	var cmdErr *specterrs.GitCommandError
+	if !errors.As(err, &cmdErr) {
+		t.Fatalf("error = %v, want GitCommandError", err)
+	}
+	if !strings.HasPrefix(err.Error(), "git rev-parse failed: ") {
+		t.Errorf("error = %q, want git rev-parse prefix", err)
+	}
+	if FailureOutput(err) == "" {
+		t.Error("FailureOutput is empty")
+	}
+}
+
+func TestExecExecutor_Cancelled(t *testing.T) {
+	if !isGitAvailable() {
+		t.Skip("git is not available")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := ExecExecutor{}.Run(ctx, Command{Args: []string{"version"}})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("error = %v, want context.Canceled", err)
+	}
+}
+
+func TestFailuref(t *testing.T) {
+	cmdErr := &specterrs.GitCommandError{
+		Args:   []string{"fetch", "origin"},
+		Stderr: "fatal: could not read from remote",
+		Err:    errors.New("exit status 128"),
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"with message", Failuref(cmdErr, "failed to fetch from %s", "origin"), "failed to fetch from origin: fatal: could not read from remote"},
+		{"output only", Failure(cmdErr), "fatal: could not read from remote"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.err.Error() != tt.want {
+				t.Errorf("Error() = %q, want %q", tt.err, tt.want)
+			}
+			var got *specterrs.GitCommandError
+			if !errors.As(tt.err, &got) {
+				t.Fatal("GitCommandError not in chain")
+			}
+			if got.ExitCode() != specterrs.ExitGit {
+				t.Errorf("ExitCode() = %d, want %d", got.ExitCode(), specterrs.ExitGit)
+			}
+		})
+	}
+}
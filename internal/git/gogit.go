@@ -0,0 +1,686 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/filesystem"
+
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+// BackendGoGit is the pure-Go backend. It needs no git binary but only
+// runs the read-only commands spectr uses to inspect a repository.
+const BackendGoGit = "go-git"
+
+func init() {
+	RegisterBackend(BackendGoGit, func() Executor { return GoGitExecutor{} })
+}
+
+// GoGitExecutor runs git commands in-process with go-git. It supports the
+// read-only subset spectr needs for validation, listing and reports:
+// rev-parse, symbolic-ref, ls-tree, cat-file --batch, show <rev>:<path>,
+// config --get, log and status --porcelain. Any other command, including
+// everything that writes or talks to a remote, fails with a
+// *specterrs.GitCommandError that suggests the exec backend.
+type GoGitExecutor struct{}
+
+// Available implements Checker: go-git is compiled in.
+func (GoGitExecutor) Available() error {
+	return nil
+}
+
+// goGitRepo is an opened repository and the location a command runs in.
+type goGitRepo struct {
+	repo *gogit.Repository
+	// root is the worktree root, empty for a bare repository.
+	root string
+	// prefix is the slash-separated path of the command directory below
+	// root, with a trailing slash, or empty at the root.
+	prefix string
+}
+
+// goGitCommands maps the supported git subcommands to their handlers.
+var goGitCommands = map[string]func(*goGitRepo, Command) ([]byte, error){
+	"rev-parse":    goGitRevParse,
+	"symbolic-ref": goGitSymbolicRef,
+	"ls-tree":      goGitLsTree,
+	"cat-file":     goGitCatFile,
+	"show":         goGitShow,
+	"config":       goGitConfig,
+	"log":          goGitLog,
+	"status":       goGitStatus,
+}
+
+// errGoGitUnsupported marks commands the go-git backend does not run.
+var errGoGitUnsupported = errors.New("not supported by the go-git backend")
+
+// Run implements Executor.
+func (GoGitExecutor) Run(
+	ctx context.Context,
+	cmd Command,
+) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if len(cmd.Args) == 0 {
+		return nil, unsupported(cmd.Args)
+	}
+	handler, ok := goGitCommands[cmd.Args[0]]
+	if !ok {
+		return nil, unsupported(cmd.Args)
+	}
+
+	repo, err := openGoGitRepo(cmd.Dir)
+	if err != nil {
+		return nil, goGitFailure(cmd.Args, err)
+	}
+
+	output, err := handler(repo, cmd)
+	if err != nil {
+		return nil, goGitFailure(cmd.Args, err)
+	}
+
+	return output, nil
+}
+
+// openGoGitRepo opens the repository containing dir, or the current
+// directory when dir is empty.
+func openGoGitRepo(dir string) (*goGitRepo, error) {
+	if dir == "" {
+		wd, err := os.Getwd()
+		if err != nil {
+			return nil, err
+		}
+		dir = wd
+	}
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	repo, err := gogit.PlainOpenWithOptions(dir, &gogit.PlainOpenOptions{
+		DetectDotGit:          true,
+		EnableDotGitCommonDir: true,
+	})
+	if errors.Is(err, gogit.ErrRepositoryNotExists) {
+		return nil, errors.New(
+			"not a git repository (or any of the parent directories): .git",
+		)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	opened := &goGitRepo{repo: repo}
+	worktree, err := repo.Worktree()
+	if errors.Is(err, gogit.ErrIsBareRepository) {
+		return opened, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	opened.root = worktree.Filesystem.Root()
+	opened.prefix = relativePrefix(opened.root, dir)
+
+	return opened, nil
+}
+
+// relativePrefix returns dir relative to root in the form printed by
+// "git rev-parse --show-prefix".
+func relativePrefix(root, dir string) string {
+	if resolved, err := filepath.EvalSymlinks(root); err == nil {
+		root = resolved
+	}
+	if resolved, err := filepath.EvalSymlinks(dir); err == nil {
+		dir = resolved
+	}
+
+	rel, err := filepath.Rel(root, dir)
+	if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return ""
+	}
+
+	return filepath.ToSlash(rel) + "/"
+}
+
+// repoPath resolves a pathspec given relative to the command directory to
+// a path relative to the repository root. fullTree ignores the directory.
+func (r *goGitRepo) repoPath(spec string, fullTree bool) string {
+	if !fullTree {
+		spec = r.prefix + spec
+	}
+	cleaned := path.Clean(spec)
+	if cleaned == "." || cleaned == "/" {
+		return ""
+	}
+
+	return strings.TrimPrefix(cleaned, "/")
+}
+
+// commit resolves rev to a commit.
+func (r *goGitRepo) commit(rev string) (*object.Commit, error) {
+	hash, err := r.repo.ResolveRevision(plumbing.Revision(strings.TrimSuffix(rev, "^{commit}")))
+	if err != nil {
+		return nil, fmt.Errorf("ambiguous argument '%s': unknown revision", rev)
+	}
+
+	return r.repo.CommitObject(*hash)
+}
+
+// unsupported reports a command the go-git backend cannot run.
+func unsupported(args []string) error {
+	name := "git"
+	if len(args) > 0 {
+		name = "git " + args[0]
+	}
+
+	return &specterrs.GitCommandError{
+		Args: args,
+		Stderr: fmt.Sprintf(
+			"%s is not supported by the go-git backend; install git or set git.backend: exec",
+			name,
+		),
+		Err: errGoGitUnsupported,
+	}
+}
+
+// goGitFailure reports err as a failed command, keeping errors that are
+// already command errors as they are.
+func goGitFailure(args []string, err error) error {
+	var cmdErr *specterrs.GitCommandError
+	if errors.As(err, &cmdErr) {
+		return err
+	}
+
+	return &specterrs.GitCommandError{
+		Args:   args,
+		Stderr: "fatal: " + err.Error(),
+		Err:    err,
+	}
+}
+
+// silentFailure is a failure git reports only through its exit status,
+// such as "rev-parse --quiet" on an unknown ref.
+func silentFailure(args []string, err error) error {
+	return &specterrs.GitCommandError{Args: args, Err: err}
+}
+
+// goGitRevParse handles --show-toplevel, --show-prefix, --absolute-git-dir
+// and revisions, optionally with --verify and --quiet.
+func goGitRevParse(r *goGitRepo, cmd Command) ([]byte, error) {
+	var (
+		out   bytes.Buffer
+		quiet bool
+	)
+
+	for _, arg := range cmd.Args[1:] {
+		switch arg {
+		case "--verify":
+		case "--quiet", "-q":
+			quiet = true
+		case "--show-toplevel":
+			if r.root == "" {
+				return nil, errors.New("this operation must be run in a work tree")
+			}
+			out.WriteString(r.root + "\n")
+		case "--show-prefix":
+			out.WriteString(r.prefix + "\n")
+		case "--absolute-git-dir":
+			storage, ok := r.repo.Storer.(*filesystem.Storage)
+			if !ok {
+				return nil, unsupported(cmd.Args)
+			}
+			out.WriteString(storage.Filesystem().Root() + "\n")
+		default:
+			if strings.HasPrefix(arg, "-") {
+				return nil, unsupported(cmd.Args)
+			}
+			commit, err := r.commit(arg)
+			if err != nil {
+				if quiet {
+					return nil, silentFailure(cmd.Args, err)
+				}
+
+				return nil, err
+			}
+			out.WriteString(commit.Hash.String() + "\n")
+		}
+	}
+
+	return out.Bytes(), nil
+}
+
+// goGitSymbolicRef handles "symbolic-ref [--quiet] [--short] HEAD".
+func goGitSymbolicRef(r *goGitRepo, cmd Command) ([]byte, error) {
+	short := false
+	for _, arg := range cmd.Args[1:] {
+		switch arg {
+		case "--quiet", "-q":
+		case "--short":
+			short = true
+		case "HEAD":
+		default:
+			return nil, unsupported(cmd.Args)
+		}
+	}
+
+	head, err := r.repo.Reference(plumbing.HEAD, false)
+	if err != nil {
+		return nil, err
+	}
+	if head.Type() != plumbing.SymbolicReference {
+		// Like git with --quiet, a detached HEAD fails without a message
+		return nil, silentFailure(cmd.Args, errors.New("HEAD is not a symbolic ref"))
+	}
+
+	name := head.Target().String()
+	if short {
+		name = head.Target().Short()
+	}
+
+	return []byte(name + "\n"), nil
+}
+
+// goGitLsTree handles "ls-tree [-r] [-z] [--full-tree] [--name-only]
+// <tree-ish> [--] [<path>...]".
+func goGitLsTree(r *goGitRepo, cmd Command) ([]byte, error) {
+	var (
+		recursive, nulTerminated, fullTree, nameOnly bool
+		positional                                   []string
+	)
+
+	for _, arg := range cmd.Args[1:] {
+		switch arg {
+		case "-r":
+			recursive = true
+		case "-z":
+			nulTerminated = true
+		case "--full-tree":
+			fullTree = true
+		case "--name-only", "--name-status":
+			nameOnly = true
+		case "--":
+		default:
+			if strings.HasPrefix(arg, "-") {
+				return nil, unsupported(cmd.Args)
+			}
+			positional = append(positional, arg)
+		}
+	}
+	if len(positional) == 0 {
+		return nil, errors.New("ls-tree needs a tree-ish")
+	}
+
+	commit, err := r.commit(positional[0])
+	if err != nil {
+		return nil, fmt.Errorf("not a valid object name %s", positional[0])
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, err
+	}
+
+	var specs []string
+	for _, spec := range positional[1:] {
+		specs = append(specs, r.repoPath(spec, fullTree))
+	}
+
+	terminator := "\n"
+	if nulTerminated {
+		terminator = "\x00"
+	}
+
+	var out bytes.Buffer
+	err = walkTree(tree, recursive, specs, func(name string, entry object.TreeEntry) {
+		if !fullTree {
+			name = strings.TrimPrefix(name, r.prefix)
+		}
+		if nameOnly {
+			out.WriteString(name + terminator)
+
+			return
+		}
+		fmt.Fprintf(&out, "%06o %s %s\t%s%s",
+			uint32(entry.Mode), entryType(entry.Mode), entry.Hash, name, terminator)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return out.Bytes(), nil
+}
+
+// walkTree calls fn for the entries of tree matching specs, the way
+// ls-tree selects them: without recursive, the matching entries
+// themselves; with it, the blobs at or below them. Empty specs match
+// everything at the top level.
+func walkTree(
+	tree *object.Tree,
+	recursive bool,
+	specs []string,
+	fn func(name string, entry object.TreeEntry),
+) error {
+	walker := object.NewTreeWalker(tree, true, nil)
+	defer walker.Close()
+
+	for {
+		name, entry, err := walker.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if recursive {
+			if entry.Mode != filemode.Dir && matchesAny(name, specs, true) {
+				fn(name, entry)
+			}
+
+			continue
+		}
+		if len(specs) == 0 {
+			if !strings.Contains(name, "/") {
+				fn(name, entry)
+			}
+
+			continue
+		}
+		if matchesAny(name, specs, false) {
+			fn(name, entry)
+		}
+	}
+}
+
+// matchesAny reports whether name equals one of specs or, with below, lies
+// under one of them.
+func matchesAny(name string, specs []string, below bool) bool {
+	if len(specs) == 0 {
+		return true
+	}
+	for _, spec := range specs {
+		if spec == "" || name == spec {
+			return true
+		}
+		if below && strings.HasPrefix(name, spec+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// entryType returns the object type ls-tree prints for mode.
+func entryType(mode filemode.FileMode) string {
+	switch mode {
+	case filemode.Dir:
+		return "tree"
+	case filemode.Submodule:
+		return "commit"
+	default:
+		return "blob"
+	}
+}
+
+// goGitCatFile handles "cat-file --batch" for blobs named by hash on
+// stdin.
+func goGitCatFile(r *goGitRepo, cmd Command) ([]byte, error) {
+	if len(cmd.Args) != 2 || cmd.Args[1] != "--batch" {
+		return nil, unsupported(cmd.Args)
+	}
+	if cmd.Stdin == nil {
+		return nil, nil
+	}
+
+	var out bytes.Buffer
+	scanner := bufio.NewScanner(cmd.Stdin)
+	for scanner.Scan() {
+		name := strings.TrimSpace(scanner.Text())
+		if name == "" {
+			continue
+		}
+		blob, err := r.repo.BlobObject(plumbing.NewHash(name))
+		if err != nil {
+			out.WriteString(name + " missing\n")
+
+			continue
+		}
+		reader, err := blob.Reader()
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(&out, "%s blob %d\n", blob.Hash, blob.Size)
+		_, err = io.Copy(&out, reader)
+		reader.Close()
+		if err != nil {
+			return nil, err
+		}
+		out.WriteString("\n")
+	}
+
+	return out.Bytes(), scanner.Err()
+}
+
+// goGitShow handles "show <rev>:<path>", where a path starting with "./"
+// is relative to the command directory.
+func goGitShow(r *goGitRepo, cmd Command) ([]byte, error) {
+	if len(cmd.Args) != 2 {
+		return nil, unsupported(cmd.Args)
+	}
+	rev, name, ok := strings.Cut(cmd.Args[1], ":")
+	if !ok {
+		return nil, unsupported(cmd.Args)
+	}
+
+	commit, err := r.commit(rev)
+	if err != nil {
+		return nil, err
+	}
+	relative := strings.HasPrefix(name, "./")
+	file, err := commit.File(r.repoPath(name, !relative))
+	if err != nil {
+		return nil, fmt.Errorf("path '%s' does not exist in '%s'", name, rev)
+	}
+	contents, err := file.Contents()
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(contents), nil
+}
+
+// goGitConfig handles "config --get <key>" against the repository and
+// global configuration.
+func goGitConfig(r *goGitRepo, cmd Command) ([]byte, error) {
+	if len(cmd.Args) != 3 || cmd.Args[1] != "--get" {
+		return nil, unsupported(cmd.Args)
+	}
+	key := cmd.Args[2]
+
+	cfg, err := r.repo.ConfigScoped(config.GlobalScope)
+	if err != nil {
+		return nil, err
+	}
+
+	dot := strings.LastIndex(key, ".")
+	first := strings.Index(key, ".")
+	if dot <= 0 {
+		return nil, fmt.Errorf("key does not contain a section: %s", key)
+	}
+	section := cfg.Raw.Section(key[:first])
+	options := section.Options
+	if first != dot {
+		options = section.Subsection(key[first+1 : dot]).Options
+	}
+	if !options.Has(key[dot+1:]) {
+		// Like git, a missing key fails without a message
+		return nil, silentFailure(cmd.Args, fmt.Errorf("%s is not set", key))
+	}
+
+	return []byte(options.Get(key[dot+1:]) + "\n"), nil
+}
+
+// goGitLog handles "log [-n <count>] [--format=<format>] [--] [<path>...]"
+// where the format uses %H, %h, %ct, %s and %x1e.
+func goGitLog(r *goGitRepo, cmd Command) ([]byte, error) {
+	var (
+		limit  = -1
+		format = "%H"
+		specs  []string
+	)
+
+	args := cmd.Args[1:]
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--":
+			for _, spec := range args[i+1:] {
+				specs = append(specs, r.repoPath(spec, false))
+			}
+			i = len(args)
+		case arg == "-n" && i+1 < len(args):
+			i++
+			n, err := strconv.Atoi(args[i])
+			if err != nil {
+				return nil, unsupported(cmd.Args)
+			}
+			limit = n
+		case strings.HasPrefix(arg, "--max-count="):
+			n, err := strconv.Atoi(strings.TrimPrefix(arg, "--max-count="))
+			if err != nil {
+				return nil, unsupported(cmd.Args)
+			}
+			limit = n
+		case strings.HasPrefix(arg, "--format="):
+			format = strings.TrimPrefix(arg, "--format=")
+		case strings.HasPrefix(arg, "--pretty=format:"):
+			format = strings.TrimPrefix(arg, "--pretty=format:")
+		case len(arg) > 1 && arg[0] == '-' && isDigits(arg[1:]):
+			limit, _ = strconv.Atoi(arg[1:])
+		default:
+			return nil, unsupported(cmd.Args)
+		}
+	}
+
+	head, err := r.repo.Head()
+	if err != nil {
+		return nil, err
+	}
+	options := &gogit.LogOptions{From: head.Hash(), Order: gogit.LogOrderCommitterTime}
+	if len(specs) > 0 {
+		options.PathFilter = func(name string) bool {
+			return matchesAny(name, specs, true)
+		}
+	}
+	commits, err := r.repo.Log(options)
+	if err != nil {
+		return nil, err
+	}
+	defer commits.Close()
+
+	var out bytes.Buffer
+	for count := 0; limit < 0 || count < limit; count++ {
+		commit, err := commits.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		out.WriteString(formatCommit(format, commit) + "\n")
+	}
+
+	return out.Bytes(), nil
+}
+
+// formatCommit expands the supported log placeholders in format.
+func formatCommit(format string, commit *object.Commit) string {
+	subject, _, _ := strings.Cut(commit.Message, "\n")
+
+	return strings.NewReplacer(
+		"%H", commit.Hash.String(),
+		"%h", commit.Hash.String()[:7],
+		"%ct", strconv.FormatInt(commit.Committer.When.Unix(), 10),
+		"%s", subject,
+		"%x1e", "\x1e",
+	).Replace(format)
+}
+
+// isDigits reports whether s is a non-empty run of ASCII digits.
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// goGitStatus handles "status --porcelain [--] [<path>...]".
+func goGitStatus(r *goGitRepo, cmd Command) ([]byte, error) {
+	var specs []string
+	porcelain := false
+	for _, arg := range cmd.Args[1:] {
+		switch {
+		case arg == "--porcelain":
+			porcelain = true
+		case arg == "--":
+		case strings.HasPrefix(arg, "-"):
+			return nil, unsupported(cmd.Args)
+		default:
+			specs = append(specs, r.repoPath(arg, false))
+		}
+	}
+	if !porcelain {
+		return nil, unsupported(cmd.Args)
+	}
+
+	worktree, err := r.repo.Worktree()
+	if err != nil {
+		return nil, err
+	}
+	status, err := worktree.Status()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(status))
+	for name, file := range status {
+		if file.Staging == gogit.Unmodified && file.Worktree == gogit.Unmodified {
+			continue
+		}
+		if matchesAny(name, specs, true) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var out bytes.Buffer
+	for _, name := range names {
+		file := status[name]
+		fmt.Fprintf(&out, "%c%c %s\n", file.Staging, file.Worktree, name)
+	}
+
+	return out.Bytes(), nil
+}
@@ -0,0 +1,267 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+// goGitFixture is a repository built with go-git, so its tests run
+// without a git binary.
+type goGitFixture struct {
+	dir  string
+	repo *gogit.Repository
+	v1   string
+	v2   string
+}
+
+// newGoGitFixture creates a repository with two commits to
+// spectr/specs/auth/spec.md, the first tagged v1.
+func newGoGitFixture(t *testing.T) *goGitFixture {
+	t.Helper()
+
+	dir := t.TempDir()
+	repo, err := gogit.PlainInit(dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commit := func(files map[string]string, message string, when time.Time) string {
+		t.Helper()
+
+		for name, content := range files {
+			path := filepath.Join(dir, filepath.FromSlash(name))
+			if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := worktree.Add(name); err != nil {
+				t.Fatal(err)
+			}
+		}
+		signature := &object.Signature{Name: "test", Email: "test@example.com", When: when}
+		hash, err := worktree.Commit(message, &gogit.CommitOptions{
+			Author:    signature,
+			Committer: signature,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return hash.String()
+	}
+
+	fixture := &goGitFixture{dir: dir, repo: repo}
+	fixture.v1 = commit(map[string]string{
+		"README.md":                  "readme\n",
+		"spectr/specs/auth/spec.md":  "# v1\n",
+		"spectr/specs/other/spec.md": "# other\n",
+	}, "v1", time.Unix(1700000000, 0))
+	if _, err := repo.CreateTag("v1", plumbing.NewHash(fixture.v1), nil); err != nil {
+		t.Fatal(err)
+	}
+	fixture.v2 = commit(map[string]string{
+		"spectr/specs/auth/spec.md": "# v2\n",
+	}, "v2", time.Unix(1700000100, 0))
+
+	return fixture
+}
+
+func TestGoGitExecutor_Run(t *testing.T) {
+	fixture := newGoGitFixture(t)
+	specs := filepath.Join(fixture.dir, "spectr", "specs")
+
+	tests := []struct {
+		name string
+		dir  string
+		args []string
+		want string
+	}{
+		{
+			name: "show toplevel",
+			dir:  specs,
+			args: []string{"rev-parse", "--show-toplevel"},
+			want: fixture.dir + "\n",
+		},
+		{
+			name: "show prefix",
+			dir:  specs,
+			args: []string{"rev-parse", "--show-prefix"},
+			want: "spectr/specs/\n",
+		},
+		{
+			name: "verify tag",
+			dir:  fixture.dir,
+			args: []string{"rev-parse", "--verify", "--quiet", "v1^{commit}"},
+			want: fixture.v1 + "\n",
+		},
+		{
+			name: "current branch",
+			dir:  fixture.dir,
+			args: []string{"symbolic-ref", "--quiet", "--short", "HEAD"},
+			want: "master\n",
+		},
+		{
+			name: "ls-tree path",
+			dir:  fixture.dir,
+			args: []string{"ls-tree", "HEAD", "spectr"},
+			want: "040000 tree ",
+		},
+		{
+			name: "ls-tree names from a subdirectory",
+			dir:  specs,
+			args: []string{"ls-tree", "--full-tree", "--name-only", "v1", "--", "spectr/specs/auth"},
+			want: "spectr/specs/auth\n",
+		},
+		{
+			name: "ls-tree recursive",
+			dir:  fixture.dir,
+			args: []string{"ls-tree", "-r", "--full-tree", "--name-only", "HEAD", "--", "spectr"},
+			want: "spectr/specs/auth/spec.md\nspectr/specs/other/spec.md\n",
+		},
+		{
+			name: "show relative path",
+			dir:  specs,
+			args: []string{"show", fixture.v2 + "^:./auth/spec.md"},
+			want: "# v1\n",
+		},
+		{
+			name: "log touching a directory",
+			dir:  filepath.Join(specs, "other"),
+			args: []string{"log", "-1", "--format=%ct %s", "--", "."},
+			want: "1700000000 v1\n",
+		},
+		{
+			name: "clean status",
+			dir:  specs,
+			args: []string{"status", "--porcelain", "--", "."},
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GoGitExecutor{}.Run(context.Background(), Command{Dir: tt.dir, Args: tt.args})
+			if err != nil {
+				t.Fatalf("Run(%v) error = %v", tt.args, err)
+			}
+			if !strings.HasPrefix(string(got), tt.want) || (tt.want == "" && len(got) > 0) {
+				t.Errorf("Run(%v) = %q, want %q", tt.args, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGoGitExecutor_Failures(t *testing.T) {
+	fixture := newGoGitFixture(t)
+
+	head, err := fixture.repo.Head()
+	if err != nil {
+		t.Fatal(err)
+	}
+	detached := t.TempDir()
+	if _, err := gogit.PlainClone(detached, false, &gogit.CloneOptions{URL: fixture.dir}); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(detached, ".git", "HEAD"), []byte(head.Hash().String()+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		dir        string
+		args       []string
+		wantStderr string
+	}{
+		{
+			name:       "unsupported command",
+			dir:        fixture.dir,
+			args:       []string{"commit", "-m", "x"},
+			wantStderr: "git commit is not supported by the go-git backend",
+		},
+		{
+			name:       "unsupported flag",
+			dir:        fixture.dir,
+			args:       []string{"log", "--reverse"},
+			wantStderr: "git log is not supported by the go-git backend",
+		},
+		{
+			name:       "not a repository",
+			dir:        t.TempDir(),
+			args:       []string{"rev-parse", "--show-toplevel"},
+			wantStderr: "not a git repository",
+		},
+		{
+			name:       "missing path",
+			dir:        fixture.dir,
+			args:       []string{"show", "HEAD:missing.md"},
+			wantStderr: "path 'missing.md' does not exist in 'HEAD'",
+		},
+		{
+			name: "quiet unknown ref",
+			dir:  fixture.dir,
+			args: []string{"rev-parse", "--verify", "--quiet", "no-such-ref^{commit}"},
+		},
+		{
+			name: "detached HEAD",
+			dir:  detached,
+			args: []string{"symbolic-ref", "--quiet", "--short", "HEAD"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := GoGitExecutor{}.Run(context.Background(), Command{Dir: tt.dir, Args: tt.args})
+
+			var cmdErr *specterrs.GitCommandError
+			if !errors.As(err, &cmdErr) {
+				t.Fatalf("Run(%v) error = %v, want GitCommandError", tt.args, err)
+			}
+			if tt.wantStderr == "" && cmdErr.Stderr != "" {
+				t.Errorf("Stderr = %q, want none", cmdErr.Stderr)
+			}
+			if !strings.Contains(cmdErr.Stderr, tt.wantStderr) {
+				t.Errorf("Stderr = %q, want it to contain %q", cmdErr.Stderr, tt.wantStderr)
+			}
+		})
+	}
+}
+
+func TestGoGitExecutor_ExportTree(t *testing.T) {
+	fixture := newGoGitFixture(t)
+
+	previous := SetExecutor(GoGitExecutor{})
+	t.Cleanup(func() { SetExecutor(previous) })
+
+	dest := t.TempDir()
+	if err := ExportTree(context.Background(), fixture.dir, "v1", "spectr", dest); err != nil {
+		t.Fatalf("ExportTree() error = %v", err)
+	}
+
+	got, err := os.ReadFile(filepath.Join(dest, "spectr", "specs", "auth", "spec.md"))
+	if err != nil {
+		t.Fatalf("exported spec missing: %v", err)
+	}
+	if string(got) != "# v1\n" {
+		t.Errorf("spec content = %q, want %q", got, "# v1\n")
+	}
+	if _, err := os.Stat(filepath.Join(dest, "README.md")); !os.IsNotExist(err) {
+		t.Error("files outside the requested path should not be exported")
+	}
+}
@@ -0,0 +1,93 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// gitattributesPerm is the permission mode for .gitattributes files.
+const gitattributesPerm = 0o644
+
+// MergeDriver describes a custom git merge driver.
+type MergeDriver struct {
+	Name        string   // Driver name referenced from .gitattributes
+	Description string   // Human-readable name stored in git config
+	Command     string   // Driver command with %O %A %B placeholders
+	Patterns    []string // .gitattributes patterns routed to the driver
+}
+
+// InstallMergeDriver registers a merge driver in the repository's local git
+// config and routes its patterns to it in the repository's .gitattributes.
+// It is idempotent. Returns the .gitattributes lines that were added.
+func InstallMergeDriver(
+	repoRoot string,
+	driver MergeDriver,
+) ([]string, error) {
+	settings := [][2]string{
+		{"merge." + driver.Name + ".name", driver.Description},
+		{"merge." + driver.Name + ".driver", driver.Command},
+	}
+	for _, setting := range settings {
+		_, err := Run(
+			context.Background(),
+			repoRoot,
+			"config",
+			setting[0],
+			setting[1],
+		)
+		if err != nil {
+			return nil, Failuref(err, "git config %s failed", setting[0])
+		}
+	}
+
+	return ensureAttributes(
+		filepath.Join(repoRoot, ".gitattributes"),
+		driver,
+	)
+}
+
+// ensureAttributes appends "<pattern> merge=<name>" lines that are missing
+// from the attributes file.
+func ensureAttributes(
+	path string,
+	driver MergeDriver,
+) ([]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	existing := make(map[string]bool)
+	for _, line := range strings.Split(string(content), "\n") {
+		existing[strings.Join(strings.Fields(line), " ")] = true
+	}
+
+	var added []string
+	for _, pattern := range driver.Patterns {
+		line := pattern + " merge=" + driver.Name
+		if !existing[line] {
+			added = append(added, line)
+		}
+	}
+	if len(added) == 0 {
+		return nil, nil
+	}
+
+	var sb strings.Builder
+	sb.Write(content)
+	if len(content) > 0 && !strings.HasSuffix(string(content), "\n") {
+		sb.WriteString("\n")
+	}
+	for _, line := range added {
+		sb.WriteString(line + "\n")
+	}
+
+	if err := os.WriteFile(path, []byte(sb.String()), gitattributesPerm); err != nil {
+		return nil, fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	return added, nil
+}
@@ -0,0 +1,68 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestInstallMergeDriver(t *testing.T) {
+	if !isGitAvailable() {
+		t.Skip("git is not available")
+	}
+
+	repo := t.TempDir()
+	if output, err := exec.Command("git", "init", "-q", repo).CombinedOutput(); err != nil {
+		t.Fatalf("git init failed: %s", output)
+	}
+
+	attrsPath := filepath.Join(repo, ".gitattributes")
+	if err := os.WriteFile(attrsPath, []byte("*.png binary"), 0o644); err != nil {
+		t.Fatalf("failed to write .gitattributes: %v", err)
+	}
+
+	driver := MergeDriver{
+		Name:        "spectr-test",
+		Description: "Test driver",
+		Command:     "spectr merge-test %O %A %B",
+		Patterns:    []string{"tasks*.jsonc"},
+	}
+
+	added, err := InstallMergeDriver(repo, driver)
+	if err != nil {
+		t.Fatalf("InstallMergeDriver() error = %v", err)
+	}
+	if len(added) != 1 || added[0] != "tasks*.jsonc merge=spectr-test" {
+		t.Errorf("added = %v", added)
+	}
+
+	// Second install is a no-op
+	added, err = InstallMergeDriver(repo, driver)
+	if err != nil {
+		t.Fatalf("InstallMergeDriver() second run error = %v", err)
+	}
+	if len(added) != 0 {
+		t.Errorf("second install added %v, want nothing", added)
+	}
+
+	content, err := os.ReadFile(attrsPath)
+	if err != nil {
+		t.Fatalf("failed to read .gitattributes: %v", err)
+	}
+	want := "*.png binary\ntasks*.jsonc merge=spectr-test\n"
+	if string(content) != want {
+		t.Errorf(".gitattributes = %q, want %q", content, want)
+	}
+
+	cmd := exec.Command("git", "config", "merge.spectr-test.driver")
+	cmd.Dir = repo
+	output, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("git config failed: %v", err)
+	}
+	if strings.TrimSpace(string(output)) != driver.Command {
+		t.Errorf("driver = %q, want %q", output, driver.Command)
+	}
+}
@@ -1,8 +1,8 @@
 package git
 
 import (
+	"context"
 	"fmt"
-	"os/exec"
 	"regexp"
 	"strings"
 
@@ -82,28 +82,10 @@ func DetectPlatform(
 
 // GetOriginURL retrieves the URL for the 'origin' remote.
 // Returns an error if not in a git repository or if no origin remote exists.
-func GetOriginURL() (string, error) {
-	cmd := exec.Command(
-		"git",
-		"remote",
-		"get-url",
-		"origin",
-	)
-	output, err := cmd.Output()
+func GetOriginURL(ctx context.Context) (string, error) {
+	output, err := Run(ctx, "", "remote", "get-url", "origin")
 	if err != nil {
-		if exitErr, ok := err.(*exec.ExitError); ok {
-			return "", fmt.Errorf(
-				"failed to get origin URL: %s",
-				strings.TrimSpace(
-					string(exitErr.Stderr),
-				),
-			)
-		}
-
-		return "", fmt.Errorf(
-			"failed to run git command: %w",
-			err,
-		)
+		return "", Failuref(err, "failed to get origin URL")
 	}
 
 	return strings.TrimSpace(string(output)), nil
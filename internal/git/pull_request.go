@@ -0,0 +1,80 @@
+package git
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// PullRequestRef identifies a pull request (or merge request) on a
+// hosting platform.
+type PullRequestRef struct {
+	Platform Platform // The detected platform
+	Host     string   // Hostname, e.g. "github.com"
+	Owner    string   // Repository owner/organization (may be nested)
+	Repo     string   // Repository name
+	Number   int      // Pull request number or merge request IID
+}
+
+// ProjectPath returns the "owner/repo" path of the repository.
+func (r PullRequestRef) ProjectPath() string {
+	return r.Owner + "/" + r.Repo
+}
+
+// ParsePullRequestURL parses a pull request web URL.
+// Supports:
+//   - GitHub: https://github.com/owner/repo/pull/42
+//   - GitLab: https://gitlab.com/group/sub/repo/-/merge_requests/42
+//   - Gitea:  https://gitea.example.com/owner/repo/pulls/42
+func ParsePullRequestURL(
+	rawURL string,
+) (PullRequestRef, error) {
+	parsed, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || parsed.Host == "" {
+		return PullRequestRef{}, fmt.Errorf(
+			"invalid pull request URL: %s",
+			rawURL,
+		)
+	}
+
+	parts := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+
+	// Find the "<marker>/<number>" pair; everything before it is the
+	// repository path (GitLab inserts a "-" segment before the marker).
+	for i := len(parts) - 2; i >= 2; i-- {
+		switch parts[i] {
+		case "pull", "pulls", "merge_requests":
+		default:
+			continue
+		}
+
+		number, convErr := strconv.Atoi(parts[i+1])
+		if convErr != nil || number <= 0 {
+			break
+		}
+
+		repoParts := parts[:i]
+		if repoParts[len(repoParts)-1] == "-" {
+			repoParts = repoParts[:len(repoParts)-1]
+		}
+
+		owner, repo := extractOwnerRepo(strings.Join(repoParts, "/"))
+		if owner == "" || repo == "" {
+			break
+		}
+
+		return PullRequestRef{
+			Platform: detectPlatformFromHost(parsed.Host),
+			Host:     parsed.Host,
+			Owner:    owner,
+			Repo:     repo,
+			Number:   number,
+		}, nil
+	}
+
+	return PullRequestRef{}, fmt.Errorf(
+		"unrecognized pull request URL: %s",
+		rawURL,
+	)
+}
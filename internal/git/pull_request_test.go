@@ -0,0 +1,91 @@
+package git
+
+import "testing"
+
+func TestParsePullRequestURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		rawURL  string
+		want    PullRequestRef
+		wantErr bool
+	}{
+		{
+			name:   "GitHub pull request",
+			rawURL: "https://github.com/owner/repo/pull/42",
+			want: PullRequestRef{
+				Platform: PlatformGitHub,
+				Host:     "github.com",
+				Owner:    "owner",
+				Repo:     "repo",
+				Number:   42,
+			},
+		},
+		{
+			name:   "GitHub pull request files tab",
+			rawURL: "https://github.com/owner/repo/pull/42/files",
+			want: PullRequestRef{
+				Platform: PlatformGitHub,
+				Host:     "github.com",
+				Owner:    "owner",
+				Repo:     "repo",
+				Number:   42,
+			},
+		},
+		{
+			name:   "GitLab nested group merge request",
+			rawURL: "https://gitlab.com/group/sub/repo/-/merge_requests/7",
+			want: PullRequestRef{
+				Platform: PlatformGitLab,
+				Host:     "gitlab.com",
+				Owner:    "group/sub",
+				Repo:     "repo",
+				Number:   7,
+			},
+		},
+		{
+			name:   "Gitea pull request",
+			rawURL: "https://gitea.example.com/owner/repo/pulls/3",
+			want: PullRequestRef{
+				Platform: PlatformGitea,
+				Host:     "gitea.example.com",
+				Owner:    "owner",
+				Repo:     "repo",
+				Number:   3,
+			},
+		},
+		{
+			name:    "repository URL",
+			rawURL:  "https://github.com/owner/repo",
+			wantErr: true,
+		},
+		{
+			name:    "non-numeric number",
+			rawURL:  "https://github.com/owner/repo/pull/abc",
+			wantErr: true,
+		},
+		{
+			name:    "not a URL",
+			rawURL:  "owner/repo#42",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePullRequestURL(tt.rawURL)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf(
+					"ParsePullRequestURL() error = %v, wantErr %v",
+					err,
+					tt.wantErr,
+				)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ParsePullRequestURL() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
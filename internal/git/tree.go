@@ -0,0 +1,165 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+// Tree export permissions.
+const (
+	treeDirPerm  = 0o755
+	treeFilePerm = 0o644
+)
+
+// treeEntry is a blob listed by git ls-tree.
+type treeEntry struct {
+	mode string
+	sha  string
+	path string
+}
+
+// ExportTree writes the files under path at ref to destDir without
+// requiring a working tree. repoPath may be a bare repository. Blobs are
+// read with a single "git cat-file --batch" invocation. Symlinks and
+// submodules are skipped.
+func ExportTree(ctx context.Context, repoPath, ref, path, destDir string) error {
+	commit, err := gitOutput(ctx, repoPath, "rev-parse", "--verify", "--quiet", ref+"^{commit}")
+	var unavailableErr *specterrs.GitUnavailableError
+	if errors.As(err, &unavailableErr) {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("unknown ref '%s' in %s", ref, repoPath)
+	}
+
+	listing, err := gitOutput(ctx, repoPath, "ls-tree", "-r", "-z", "--full-tree", commit, "--", path)
+	if err != nil {
+		return err
+	}
+
+	entries := parseTreeEntries(listing)
+	if len(entries) == 0 {
+		return fmt.Errorf("no %s/ directory at %s", path, ref)
+	}
+
+	return writeBlobs(ctx, repoPath, entries, destDir)
+}
+
+// parseTreeEntries parses "git ls-tree -r -z" output, keeping regular
+// file blobs only.
+func parseTreeEntries(listing string) []treeEntry {
+	var entries []treeEntry
+
+	for _, record := range strings.Split(listing, "\x00") {
+		meta, path, ok := strings.Cut(record, "\t")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(meta)
+		if len(fields) != 3 || fields[1] != "blob" || fields[0] == "120000" {
+			continue
+		}
+		entries = append(entries, treeEntry{
+			mode: fields[0],
+			sha:  fields[2],
+			path: path,
+		})
+	}
+
+	return entries
+}
+
+// writeBlobs reads blob contents with git cat-file --batch and writes them
+// below destDir.
+func writeBlobs(
+	ctx context.Context,
+	repoPath string,
+	entries []treeEntry,
+	destDir string,
+) error {
+	var input bytes.Buffer
+	for _, entry := range entries {
+		input.WriteString(entry.sha + "\n")
+	}
+
+	output, err := DefaultExecutor().Run(ctx, Command{
+		Dir:   repoPath,
+		Args:  []string{"cat-file", "--batch"},
+		Stdin: &input,
+	})
+	if err != nil {
+		return err
+	}
+
+	reader := bufio.NewReader(bytes.NewReader(output))
+	for _, entry := range entries {
+		if err := writeBlob(reader, entry, destDir); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeBlob reads one "<sha> blob <size>\n<content>\n" record and writes
+// the content to the entry's path below destDir.
+func writeBlob(reader *bufio.Reader, entry treeEntry, destDir string) error {
+	header, err := reader.ReadString('\n')
+	if err != nil {
+		return fmt.Errorf("failed to read blob %s: %w", entry.sha, err)
+	}
+
+	fields := strings.Fields(header)
+	if len(fields) != 3 {
+		return fmt.Errorf("unexpected cat-file output for %s: %s", entry.path, header)
+	}
+	size, err := strconv.Atoi(fields[2])
+	if err != nil {
+		return fmt.Errorf("unexpected blob size for %s: %s", entry.path, fields[2])
+	}
+
+	content := make([]byte, size+1) // Content is followed by a newline
+	if _, err := io.ReadFull(reader, content); err != nil {
+		return fmt.Errorf("failed to read blob %s: %w", entry.path, err)
+	}
+
+	target := filepath.Join(destDir, filepath.FromSlash(entry.path))
+	if err := os.MkdirAll(filepath.Dir(target), treeDirPerm); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", entry.path, err)
+	}
+
+	perm := os.FileMode(treeFilePerm)
+	if entry.mode == "100755" {
+		perm = treeDirPerm
+	}
+	if err := os.WriteFile(target, content[:size], perm); err != nil {
+		return fmt.Errorf("failed to write %s: %w", entry.path, err)
+	}
+
+	return nil
+}
+
+// gitOutput runs a git command against repoPath and returns its trimmed
+// stdout.
+func gitOutput(
+	ctx context.Context,
+	repoPath string,
+	args ...string,
+) (string, error) {
+	output, err := Run(ctx, repoPath, args...)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
@@ -0,0 +1,94 @@
+package git
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// runGit runs a git command in dir and fails the test on error.
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(
+		os.Environ(),
+		"GIT_AUTHOR_NAME=test",
+		"GIT_AUTHOR_EMAIL=test@example.com",
+		"GIT_COMMITTER_NAME=test",
+		"GIT_COMMITTER_EMAIL=test@example.com",
+	)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v failed: %s", args, output)
+	}
+}
+
+func TestExportTree_BareRepository(t *testing.T) {
+	if !isGitAvailable() {
+		t.Skip("git is not available")
+	}
+
+	work := t.TempDir()
+	runGit(t, work, "init", "-q")
+
+	specPath := filepath.Join(work, "spectr", "specs", "auth", "spec.md")
+	if err := os.MkdirAll(filepath.Dir(specPath), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(specPath, []byte("# v1\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(work, "README.md"), []byte("readme\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, work, "add", "-A")
+	runGit(t, work, "commit", "-q", "-m", "v1")
+	runGit(t, work, "tag", "v1")
+
+	if err := os.WriteFile(specPath, []byte("# v2\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, work, "commit", "-q", "-am", "v2")
+
+	bare := filepath.Join(t.TempDir(), "repo.git")
+	runGit(t, work, "clone", "-q", "--bare", work, bare)
+
+	tests := []struct {
+		ref  string
+		want string
+	}{
+		{ref: "HEAD", want: "# v2\n"},
+		{ref: "v1", want: "# v1\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			dest := t.TempDir()
+			if err := ExportTree(context.Background(), bare, tt.ref, "spectr", dest); err != nil {
+				t.Fatalf("ExportTree() error = %v", err)
+			}
+
+			got, err := os.ReadFile(filepath.Join(dest, "spectr", "specs", "auth", "spec.md"))
+			if err != nil {
+				t.Fatalf("exported spec missing: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("spec content = %q, want %q", got, tt.want)
+			}
+
+			if _, err := os.Stat(filepath.Join(dest, "README.md")); !os.IsNotExist(err) {
+				t.Error("files outside the requested path should not be exported")
+			}
+		})
+	}
+
+	if err := ExportTree(context.Background(), bare, "no-such-ref", "spectr", t.TempDir()); err == nil {
+		t.Error("expected error for unknown ref")
+	}
+	if err := ExportTree(context.Background(), bare, "HEAD", "missing", t.TempDir()); err == nil {
+		t.Error("expected error for missing path")
+	}
+}
@@ -1,11 +1,11 @@
 package git
 
 import (
+	"context"
 	"crypto/rand"
 	"encoding/hex"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
 
@@ -36,6 +36,7 @@ type WorktreeInfo struct {
 // CreateWorktree creates a new git worktree in a temporary directory.
 // It creates a new branch based on the specified base branch.
 func CreateWorktree(
+	ctx context.Context,
 	config WorktreeConfig,
 ) (*WorktreeInfo, error) {
 	if config.BranchName == "" {
@@ -60,8 +61,9 @@ func CreateWorktree(
 		fmt.Sprintf("spectr-pr-%s", suffix),
 	)
 
-	cmd := exec.Command(
-		gitCmd,
+	_, err = Run(
+		ctx,
+		"",
 		"worktree",
 		"add",
 		tempDir,
@@ -69,10 +71,9 @@ func CreateWorktree(
 		config.BranchName,
 		config.BaseBranch,
 	)
-	output, err := cmd.CombinedOutput()
 	if err != nil {
 		return nil, handleWorktreeError(
-			output,
+			FailureOutput(err),
 			config,
 		)
 	}
@@ -86,10 +87,9 @@ func CreateWorktree(
 
 // handleWorktreeError processes worktree creation errors.
 func handleWorktreeError(
-	output []byte,
+	outputStr string,
 	config WorktreeConfig,
 ) error {
-	outputStr := strings.TrimSpace(string(output))
 	if strings.Contains(
 		outputStr,
 		"already exists",
@@ -128,7 +128,8 @@ func handleWorktreeError(
 }
 
 // CleanupWorktree removes a git worktree and its associated branch.
-// It is safe to call multiple times.
+// It is safe to call multiple times. It takes no context so that cleanup
+// still runs after the operation that created the worktree was cancelled.
 func CleanupWorktree(info *WorktreeInfo) error {
 	if info == nil {
 		return nil
@@ -158,18 +159,18 @@ func CleanupWorktree(info *WorktreeInfo) error {
 
 // removeWorktree removes a worktree, ignoring errors if already removed.
 func removeWorktree(path string) []string {
-	cmd := exec.Command(
-		gitCmd,
+	_, err := Run(
+		context.Background(),
+		"",
 		"worktree",
 		"remove",
 		path,
 		"--force",
 	)
-	output, err := cmd.CombinedOutput()
 	if err == nil {
 		return nil
 	}
-	outputStr := strings.TrimSpace(string(output))
+	outputStr := FailureOutput(err)
 	isNotWT := strings.Contains(
 		outputStr,
 		"is not a working tree",
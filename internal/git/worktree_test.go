@@ -1,6 +1,7 @@
 package git
 
 import (
+	"context"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -149,7 +150,7 @@ func TestGetBaseBranch(t *testing.T) {
 	}
 
 	t.Run("auto-detect", func(t *testing.T) {
-		branch, err := GetBaseBranch("")
+		branch, err := GetBaseBranch(context.Background(), "")
 		if err != nil {
 			t.Fatalf(
 				"GetBaseBranch(\"\") error = %v",
@@ -171,7 +172,7 @@ func TestGetBaseBranch(t *testing.T) {
 		"with valid branch",
 		func(t *testing.T) {
 			// First detect what branch exists
-			baseBranch, err := GetBaseBranch("")
+			baseBranch, err := GetBaseBranch(context.Background(), "")
 			if err != nil {
 				t.Skip(
 					"could not auto-detect base branch",
@@ -186,6 +187,7 @@ func TestGetBaseBranch(t *testing.T) {
 
 			// Now test with the explicit branch name
 			branch, err := GetBaseBranch(
+				context.Background(),
 				branchName,
 			)
 			if err != nil {
@@ -211,6 +213,7 @@ func TestGetBaseBranch(t *testing.T) {
 		"with invalid branch",
 		func(t *testing.T) {
 			_, err := GetBaseBranch(
+				context.Background(),
 				"nonexistent-branch-12345-xyz",
 			)
 			if err == nil {
@@ -244,7 +247,7 @@ func TestBranchExists(t *testing.T) {
 
 	t.Run("existing branch", func(t *testing.T) {
 		// First detect what branch exists
-		baseBranch, err := GetBaseBranch("")
+		baseBranch, err := GetBaseBranch(context.Background(), "")
 		if err != nil {
 			t.Skip(
 				"could not auto-detect base branch",
@@ -257,7 +260,7 @@ func TestBranchExists(t *testing.T) {
 			"origin/",
 		)
 
-		exists, err := BranchExists(branchName)
+		exists, err := BranchExists(context.Background(), branchName)
 		if err != nil {
 			t.Fatalf(
 				"BranchExists(%q) error = %v",
@@ -277,6 +280,7 @@ func TestBranchExists(t *testing.T) {
 		"non-existent branch",
 		func(t *testing.T) {
 			exists, err := BranchExists(
+				context.Background(),
 				"nonexistent-branch-12345-xyz",
 			)
 			if err != nil {
@@ -329,7 +333,7 @@ func TestCreateWorktree_ValidationErrors(
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, err := CreateWorktree(tt.config)
+			_, err := CreateWorktree(context.Background(), tt.config)
 			if err == nil {
 				t.Fatal(
 					"CreateWorktree() expected error, got nil",
@@ -364,7 +368,7 @@ func TestCreateWorktree_InvalidBaseBranch(
 		BaseBranch: "origin/nonexistent-branch-12345",
 	}
 
-	_, err := CreateWorktree(config)
+	_, err := CreateWorktree(context.Background(), config)
 	if err == nil {
 		t.Fatal(
 			"CreateWorktree() with invalid base branch expected error, got nil",
@@ -401,7 +405,7 @@ func TestCreateWorktree_And_Cleanup_Integration(
 	}
 
 	// First detect what base branch exists
-	baseBranch, err := GetBaseBranch("")
+	baseBranch, err := GetBaseBranch(context.Background(), "")
 	if err != nil {
 		t.Skipf(
 			"could not auto-detect base branch: %v",
@@ -425,7 +429,7 @@ func TestCreateWorktree_And_Cleanup_Integration(
 	}
 
 	// Create the worktree
-	info, err := CreateWorktree(config)
+	info, err := CreateWorktree(context.Background(), config)
 	if err != nil {
 		t.Fatalf(
 			"CreateWorktree() error = %v",
@@ -664,7 +668,7 @@ func TestCreateWorktree_UniquePathGeneration(
 	}
 
 	// First detect what base branch exists
-	baseBranch, err := GetBaseBranch("")
+	baseBranch, err := GetBaseBranch(context.Background(), "")
 	if err != nil {
 		t.Skipf(
 			"could not auto-detect base branch: %v",
@@ -687,7 +691,7 @@ func TestCreateWorktree_UniquePathGeneration(
 		BaseBranch: baseBranch,
 	}
 
-	info1, err := CreateWorktree(config1)
+	info1, err := CreateWorktree(context.Background(), config1)
 	if err != nil {
 		t.Fatalf(
 			"CreateWorktree(config1) error = %v",
@@ -696,7 +700,7 @@ func TestCreateWorktree_UniquePathGeneration(
 	}
 	defer func() { _ = CleanupWorktree(info1) }()
 
-	info2, err := CreateWorktree(config2)
+	info2, err := CreateWorktree(context.Background(), config2)
 	if err != nil {
 		t.Fatalf(
 			"CreateWorktree(config2) error = %v",
@@ -767,7 +771,7 @@ func TestPathExistsOnRef_ExistingPath(
 	}
 
 	// Auto-detect the base branch
-	baseBranch, err := GetBaseBranch("")
+	baseBranch, err := GetBaseBranch(context.Background(), "")
 	if err != nil {
 		t.Skipf(
 			"could not auto-detect base branch: %v",
@@ -787,6 +791,7 @@ func TestPathExistsOnRef_ExistingPath(
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			exists, err := PathExistsOnRef(
+				context.Background(),
 				baseBranch,
 				tt.path,
 			)
@@ -843,7 +848,7 @@ func TestPathExistsOnRef_NonExistingPath(
 	}
 
 	// Auto-detect the base branch
-	baseBranch, err := GetBaseBranch("")
+	baseBranch, err := GetBaseBranch(context.Background(), "")
 	if err != nil {
 		t.Skipf(
 			"could not auto-detect base branch: %v",
@@ -854,6 +859,7 @@ func TestPathExistsOnRef_NonExistingPath(
 	// Test with a path that should not exist
 	nonExistentPath := "nonexistent-path-xyz-12345"
 	exists, err := PathExistsOnRef(
+		context.Background(),
 		baseBranch,
 		nonExistentPath,
 	)
@@ -908,7 +914,7 @@ func TestPathExistsOnRef_SubDirectoryPath(
 	}
 
 	// Auto-detect the base branch
-	baseBranch, err := GetBaseBranch("")
+	baseBranch, err := GetBaseBranch(context.Background(), "")
 	if err != nil {
 		t.Skipf(
 			"could not auto-detect base branch: %v",
@@ -931,6 +937,7 @@ func TestPathExistsOnRef_SubDirectoryPath(
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			exists, err := PathExistsOnRef(
+				context.Background(),
 				baseBranch,
 				tt.path,
 			)
@@ -0,0 +1,10 @@
+// Package heatmap measures how often each requirement was edited over a
+// time window, to point out the unstable areas of a spec suite.
+//
+// The git history of spectr/specs is read commit by commit. For every
+// spec.md a commit adds or modifies, the requirements of the file before
+// and after the commit are parsed and compared by contract.Hash, so a
+// whitespace-only change is not an edit. Edits are matched to the
+// requirements of the working tree by name; requirements removed since
+// are left out, and a rename starts a new history.
+package heatmap
@@ -0,0 +1,299 @@
+package heatmap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/connerohnesorge/spectr/internal/contract"
+	"github.com/connerohnesorge/spectr/internal/discovery"
+	"github.com/connerohnesorge/spectr/internal/git"
+	"github.com/connerohnesorge/spectr/internal/parsers"
+)
+
+// DefaultDays is the time window used when none is given.
+const DefaultDays = 90
+
+// MaxLevel is the level of the most edited requirements.
+const MaxLevel = 4
+
+// specsPath is the specs directory relative to the project root.
+const specsPath = "spectr/specs"
+
+// Entry is the edit activity of one requirement.
+type Entry struct {
+	Spec        string `json:"spec"`
+	ID          string `json:"id"`
+	Requirement string `json:"requirement"`
+	// Edits is the number of commits in the window that changed it
+	Edits      int       `json:"edits"`
+	LastEdited time.Time `json:"lastEdited,omitzero"`
+	// Level ranks Edits against the most edited requirement, from 0 (no
+	// edits) to MaxLevel
+	Level int `json:"level"`
+}
+
+// Heatmap is the edit activity of the requirements of a project.
+type Heatmap struct {
+	Since time.Time `json:"since"`
+	Until time.Time `json:"until"`
+	// Commits is the number of commits in the window that edited a spec
+	Commits int `json:"commits"`
+	// Requirements are in spec order, then in document order
+	Requirements []Entry `json:"requirements"`
+}
+
+// Options select what Compute measures.
+type Options struct {
+	// Since starts the window; the zero time means DefaultDays before now
+	Since time.Time
+	// Spec limits the heatmap to one spec; empty means every spec
+	Spec string
+}
+
+// commit is a commit that added or modified spec files.
+type commit struct {
+	sha   string
+	time  time.Time
+	paths []string
+}
+
+// Compute returns the edit heatmap of the project at projectRoot for the
+// window from opts.Since to now. The project must be in a git repository.
+func Compute(
+	ctx context.Context,
+	projectRoot string,
+	opts Options,
+	now time.Time,
+) (*Heatmap, error) {
+	if opts.Since.IsZero() {
+		opts.Since = now.AddDate(0, 0, -DefaultDays)
+	}
+	opts.Spec = strings.Trim(filepath.ToSlash(opts.Spec), "/")
+
+	hm := &Heatmap{Since: opts.Since, Until: now}
+	index, err := current(projectRoot, opts.Spec, hm)
+	if err != nil {
+		return nil, err
+	}
+
+	commits, err := history(ctx, projectRoot, opts, now)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range commits {
+		edited := false
+		for _, path := range c.paths {
+			specID := specOf(path)
+			names, err := editedRequirements(ctx, projectRoot, c.sha, path)
+			if err != nil {
+				return nil, err
+			}
+			for _, name := range names {
+				i, ok := index[specID+"\x00"+name]
+				if !ok {
+					continue
+				}
+				entry := &hm.Requirements[i]
+				entry.Edits++
+				if c.time.After(entry.LastEdited) {
+					entry.LastEdited = c.time
+				}
+			}
+			edited = edited || len(names) > 0
+		}
+		if edited {
+			hm.Commits++
+		}
+	}
+	rank(hm.Requirements)
+
+	return hm, nil
+}
+
+// Hottest returns the entries with at least one edit, the most edited
+// first, at most limit of them (all when limit is zero or less).
+func (hm *Heatmap) Hottest(limit int) []Entry {
+	var hot []Entry
+	for _, entry := range hm.Requirements {
+		if entry.Edits > 0 {
+			hot = append(hot, entry)
+		}
+	}
+	sort.SliceStable(hot, func(i, j int) bool {
+		return hot[i].Edits > hot[j].Edits
+	})
+	if limit > 0 && len(hot) > limit {
+		hot = hot[:limit]
+	}
+
+	return hot
+}
+
+// current adds the requirements of the working tree to hm and returns
+// their indexes keyed by spec ID and normalized name.
+func current(projectRoot, specID string, hm *Heatmap) (map[string]int, error) {
+	specIDs := []string{specID}
+	if specID == "" {
+		var err error
+		if specIDs, err = discovery.GetSpecIDs(projectRoot); err != nil {
+			return nil, err
+		}
+	}
+
+	index := make(map[string]int)
+	for _, id := range specIDs {
+		path := filepath.Join(projectRoot, filepath.FromSlash(specsPath), filepath.FromSlash(id), "spec.md")
+		reqs, err := parsers.ParseRequirements(path)
+		if errors.Is(err, fs.ErrNotExist) && specID != "" {
+			return nil, fmt.Errorf("spec '%s' not found", specID)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		for i, req := range reqs {
+			index[id+"\x00"+parsers.NormalizeRequirementName(req.Name)] = len(hm.Requirements)
+			hm.Requirements = append(hm.Requirements, Entry{
+				Spec:        id,
+				ID:          parsers.RequirementID(id, i+1),
+				Requirement: req.Name,
+			})
+		}
+	}
+
+	return index, nil
+}
+
+// history lists the commits in the window that added or modified spec
+// files, oldest first, with paths relative to projectRoot.
+func history(
+	ctx context.Context,
+	projectRoot string,
+	opts Options,
+	now time.Time,
+) ([]commit, error) {
+	pathspec := specsPath
+	if opts.Spec != "" {
+		pathspec = specsPath + "/" + opts.Spec + "/spec.md"
+	}
+	out, err := git.Run(ctx, projectRoot,
+		"log", "--reverse", "--no-merges", "--relative", "--diff-filter=AM",
+		"--since=@"+strconv.FormatInt(opts.Since.Unix(), 10),
+		"--until=@"+strconv.FormatInt(now.Unix(), 10),
+		"--format=%x1e%H %ct", "--name-only",
+		"--", pathspec,
+	)
+	if err != nil {
+		return nil, git.Failuref(err, "failed to read the history of %s", specsPath)
+	}
+
+	return parseLog(string(out)), nil
+}
+
+// parseLog parses git log output written with --format=%x1e%H %ct and
+// --name-only, keeping the spec.md files of each commit.
+func parseLog(out string) []commit {
+	var commits []commit
+	for record := range strings.SplitSeq(out, "\x1e") {
+		lines := strings.Split(strings.TrimSpace(record), "\n")
+		sha, ct, ok := strings.Cut(lines[0], " ")
+		if !ok {
+			continue
+		}
+		seconds, err := strconv.ParseInt(ct, 10, 64)
+		if err != nil {
+			continue
+		}
+		c := commit{sha: sha, time: time.Unix(seconds, 0)}
+		for _, path := range lines[1:] {
+			path = strings.TrimSpace(path)
+			if strings.HasSuffix(path, "/spec.md") && specOf(path) != "" {
+				c.paths = append(c.paths, path)
+			}
+		}
+		if len(c.paths) > 0 {
+			commits = append(commits, c)
+		}
+	}
+
+	return commits
+}
+
+// specOf returns the spec ID of a spec.md path relative to the project
+// root, or "" when path is not under the specs directory.
+func specOf(path string) string {
+	rest, ok := strings.CutPrefix(path, specsPath+"/")
+	if !ok {
+		return ""
+	}
+
+	return strings.TrimSuffix(rest, "/spec.md")
+}
+
+// editedRequirements returns the normalized names of the requirements of
+// the spec at path that commit sha added or changed.
+func editedRequirements(ctx context.Context, projectRoot, sha, path string) ([]string, error) {
+	after, err := git.Run(ctx, projectRoot, "show", sha+":./"+path)
+	if err != nil {
+		return nil, git.Failuref(err, "failed to read %s at %s", path, sha)
+	}
+	// A spec added by the commit, or a root commit, has no earlier version
+	before, err := git.Run(ctx, projectRoot, "show", sha+"^:./"+path)
+	if err != nil {
+		before = nil
+	}
+
+	old, err := hashes(string(before))
+	if err != nil {
+		return nil, err
+	}
+	reqs, err := parsers.ParseRequirementsContent(string(after))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s at %s: %w", path, sha, err)
+	}
+
+	var names []string
+	for _, req := range reqs {
+		name := parsers.NormalizeRequirementName(req.Name)
+		if old[name] != contract.Hash(req.Raw) {
+			names = append(names, name)
+		}
+	}
+
+	return names, nil
+}
+
+// hashes returns the contract.Hash of each requirement of spec content,
+// keyed by normalized name.
+func hashes(content string) (map[string]string, error) {
+	reqs, err := parsers.ParseRequirementsContent(content)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]string, len(reqs))
+	for _, req := range reqs {
+		byName[parsers.NormalizeRequirementName(req.Name)] = contract.Hash(req.Raw)
+	}
+
+	return byName, nil
+}
+
+// rank sets the Level of each entry: 0 without edits, else Edits scaled
+// against the most edited entry to 1 through MaxLevel.
+func rank(entries []Entry) {
+	most := 0
+	for _, entry := range entries {
+		most = max(most, entry.Edits)
+	}
+	for i := range entries {
+		if entries[i].Edits > 0 {
+			entries[i].Level = (entries[i].Edits*MaxLevel + most - 1) / most
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package heatmap
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// runGit runs a git command in dir with extra environment and fails the
+// test on error.
+func runGit(t *testing.T, dir string, env []string, args ...string) {
+	t.Helper()
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(
+		append(
+			os.Environ(),
+			"GIT_AUTHOR_NAME=test",
+			"GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test",
+			"GIT_COMMITTER_EMAIL=test@example.com",
+		),
+		env...,
+	)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v failed: %s", args, output)
+	}
+}
+
+// writeSpec writes spectr/specs/<id>/spec.md under project with the
+// given requirement bodies, in order.
+func writeSpec(t *testing.T, project, id string, reqs ...string) {
+	t.Helper()
+
+	dir := filepath.Join(project, "spectr", "specs", id)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	content := "# " + id + "\n\n## Requirements\n"
+	for _, req := range reqs {
+		content += "\n" + req + "\n"
+	}
+	if err := os.WriteFile(filepath.Join(dir, "spec.md"), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// commitAt commits everything in root with the given commit time.
+func commitAt(t *testing.T, root string, when time.Time) {
+	t.Helper()
+
+	date := "GIT_COMMITTER_DATE=" + when.Format(time.RFC3339)
+	runGit(t, root, nil, "add", "-A")
+	runGit(t, root, []string{date}, "commit", "-q", "-m", "update")
+}
+
+func TestCompute(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not available")
+	}
+
+	now := time.Now().Truncate(time.Second)
+	root := t.TempDir()
+	project := filepath.Join(root, "service")
+	runGit(t, root, nil, "init", "-q")
+
+	const (
+		a1 = "### Requirement: A\nThe system SHALL a."
+		a2 = "### Requirement: A\nThe system SHALL a, twice."
+		a3 = "### Requirement: A\nThe system SHALL a, thrice."
+		b  = "### Requirement: B\nThe system SHALL b."
+		bw = "### Requirement: B\nThe system  SHALL\nb."
+		c  = "### Requirement: C\nThe system SHALL c."
+		x  = "### Requirement: X\nThe system SHALL x."
+	)
+	writeSpec(t, project, "auth", a1, b)
+	commitAt(t, root, now.AddDate(0, 0, -120)) // Before the window
+	writeSpec(t, project, "auth", a2, b)
+	commitAt(t, root, now.AddDate(0, 0, -30))
+	writeSpec(t, project, "auth", a3, bw) // Whitespace-only change to B
+	commitAt(t, root, now.AddDate(0, 0, -10))
+	writeSpec(t, project, "auth", a3, bw, c)
+	commitAt(t, root, now.AddDate(0, 0, -5))
+	writeSpec(t, project, "billing", x)
+	commitAt(t, root, now.AddDate(0, 0, -3))
+
+	tests := []struct {
+		name    string
+		spec    string
+		commits int
+		want    []Entry
+	}{
+		{
+			name:    "all specs",
+			commits: 4,
+			want: []Entry{
+				{Spec: "auth", ID: "AUTH-R1", Requirement: "A", Edits: 2, LastEdited: now.AddDate(0, 0, -10), Level: 4},
+				{Spec: "auth", ID: "AUTH-R2", Requirement: "B"},
+				{Spec: "auth", ID: "AUTH-R3", Requirement: "C", Edits: 1, LastEdited: now.AddDate(0, 0, -5), Level: 2},
+				{Spec: "billing", ID: "BILLING-R1", Requirement: "X", Edits: 1, LastEdited: now.AddDate(0, 0, -3), Level: 2},
+			},
+		},
+		{
+			name:    "one spec",
+			spec:    "billing",
+			commits: 1,
+			want: []Entry{
+				{Spec: "billing", ID: "BILLING-R1", Requirement: "X", Edits: 1, LastEdited: now.AddDate(0, 0, -3), Level: 4},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hm, err := Compute(context.Background(), project, Options{Spec: tt.spec}, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if hm.Commits != tt.commits {
+				t.Errorf("Commits = %d, want %d", hm.Commits, tt.commits)
+			}
+			if len(hm.Requirements) != len(tt.want) {
+				t.Fatalf("Requirements = %+v, want %+v", hm.Requirements, tt.want)
+			}
+			for i, got := range hm.Requirements {
+				want := tt.want[i]
+				if got.Spec != want.Spec || got.ID != want.ID || got.Requirement != want.Requirement ||
+					got.Edits != want.Edits || got.Level != want.Level || !got.LastEdited.Equal(want.LastEdited) {
+					t.Errorf("Requirements[%d] = %+v, want %+v", i, got, want)
+				}
+			}
+		})
+	}
+
+	hm, err := Compute(context.Background(), project, Options{}, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hot := hm.Hottest(2)
+	if len(hot) != 2 || hot[0].Requirement != "A" || hot[1].Requirement != "C" {
+		t.Errorf("Hottest(2) = %+v", hot)
+	}
+}
+
+func TestCompute_NotARepository(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not available")
+	}
+
+	project := t.TempDir()
+	writeSpec(t, project, "auth", "### Requirement: A\nThe system SHALL a.")
+	t.Setenv("GIT_CEILING_DIRECTORIES", filepath.Dir(project))
+
+	if _, err := Compute(context.Background(), project, Options{}, time.Now()); err == nil {
+		t.Fatal("Compute() outside a git repository succeeded")
+	}
+}
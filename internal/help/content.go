@@ -0,0 +1,372 @@
+package help
+
+// sandboxBase is written to every sandbox project before the topic's
+// example files.
+var sandboxBase = []File{
+	{
+		Path: "spectr/project.md",
+		Content: `# Sandbox Project
+
+A throwaway project created by ` + "`spectr help <topic> --example`" + `.
+`,
+	},
+	{
+		Path: "spectr/specs/auth/spec.md",
+		Content: `# Auth Specification
+
+## Purpose
+
+Describes how users sign in to the example application.
+
+## Requirements
+
+### Requirement: User Login
+
+The system SHALL authenticate users with an email address and password.
+
+#### Scenario: Valid credentials
+
+- **WHEN** a user submits a registered email and the correct password
+- **THEN** the system SHALL start a session for that user
+
+### Requirement: Password Rules
+
+Passwords SHALL be at least 8 characters long.
+
+#### Scenario: Short password rejected
+
+- **WHEN** a user chooses a 6 character password
+- **THEN** the system SHALL reject it
+
+### Requirement: Legacy Login
+
+The system SHALL accept logins through the v1 API.
+
+#### Scenario: v1 login
+
+- **WHEN** a client posts credentials to /v1/login
+- **THEN** the system SHALL start a session
+`,
+	},
+}
+
+// proposal returns a minimal proposal.md for an example change.
+func proposal(title, why, what string) string {
+	return "# Change: " + title + `
+
+## Why
+
+` + why + `
+
+## What Changes
+
+- ` + what + `
+
+## Impact
+
+- Affected specs: ` + "`auth`" + `
+`
+}
+
+var topics = []Topic{
+	{
+		Name:    "changes",
+		Title:   "Creating a change",
+		Summary: "Propose a change: proposal, tasks and delta specs",
+		Intro: `A change is a directory under ` + "`spectr/changes/<change-id>/`" + `
+describing work that will alter the specs. It holds:
+
+- ` + "`proposal.md`" + ` with **Why**, **What Changes** and **Impact** sections
+- ` + "`tasks.md`" + `, a checklist of implementation steps
+- ` + "`specs/<capability>/spec.md`" + `, delta specs saying how each
+  capability's requirements change (see ` + "`spectr help deltas`" + `)
+
+Change IDs are kebab-case and start with a verb: ` + "`add-`" + `,
+` + "`update-`" + `, ` + "`remove-`" + `, ` + "`refactor-`" + `;
+` + "`spectr new change --title \"...\"`" + ` generates one. Once the work
+is done, ` + "`spectr archive`" + ` merges the deltas into ` + "`spectr/specs/`" + `.`,
+		Keywords: []string{"proposal", "change id", "new change", "archive"},
+		Examples: []Example{
+			{
+				Title: "Add a remember-me option",
+				Description: `A complete change that adds one requirement to the
+` + "`auth`" + ` capability.`,
+				Files: []File{
+					{
+						Path: "spectr/changes/add-remember-me/proposal.md",
+						Content: proposal(
+							"Add remember-me login",
+							"Users on personal devices sign in every day.",
+							"Add an optional remember-me checkbox to login",
+						),
+					},
+					{
+						Path: "spectr/changes/add-remember-me/tasks.md",
+						Content: `## 1. Implementation
+
+- [ ] 1.1 Add the Remember Me checkbox to the login form
+- [ ] 1.2 Issue a 30 day session when it is checked
+- [ ] 1.3 Add tests for both session lengths
+`,
+					},
+					{
+						Path: "spectr/changes/add-remember-me/specs/auth/spec.md",
+						Content: `## ADDED Requirements
+
+### Requirement: Remember Me
+
+The system SHALL keep a session for 30 days when the user selects
+remember me at login.
+
+#### Scenario: Remembered login
+
+- **WHEN** a user logs in with remember me selected
+- **THEN** the session SHALL stay valid for 30 days
+`,
+					},
+				},
+				Commands: []string{
+					"spectr list",
+					"spectr validate add-remember-me",
+					"spectr diff add-remember-me",
+					"spectr archive add-remember-me --yes",
+				},
+			},
+		},
+	},
+	{
+		Name:    "deltas",
+		Title:   "Delta spec syntax",
+		Summary: "ADDED, MODIFIED, REMOVED and RENAMED requirements",
+		Intro: `Delta specs live in a change at
+` + "`specs/<capability>/spec.md`" + ` and group requirements under
+operation headers:
+
+- ` + "`## ADDED Requirements`" + ` for new behavior
+- ` + "`## MODIFIED Requirements`" + ` for changed behavior; repeat the whole
+  requirement, not just the changed lines
+- ` + "`## REMOVED Requirements`" + ` with a **Reason** and **Migration**
+- ` + "`## RENAMED Requirements`" + ` with FROM/TO pairs
+
+Every ADDED or MODIFIED requirement needs SHALL or MUST and at least one
+` + "`#### Scenario:`" + ` with **WHEN**/**THEN** bullets. MODIFIED, REMOVED
+and RENAMED headers must match an existing requirement exactly.`,
+		Keywords: []string{
+			"delta", "added", "modified", "removed", "renamed",
+			"requirement", "scenario", "when", "then",
+		},
+		Examples: []Example{
+			{
+				Title:       "ADDED",
+				Description: "Add a requirement that does not exist yet.",
+				Files: []File{
+					{
+						Path: "spectr/changes/add-session-timeout/proposal.md",
+						Content: proposal(
+							"Add session timeout",
+							"Idle sessions stay open forever.",
+							"Expire idle sessions after 30 minutes",
+						),
+						Hidden: true,
+					},
+					{
+						Path: "spectr/changes/add-session-timeout/specs/auth/spec.md",
+						Content: `## ADDED Requirements
+
+### Requirement: Session Timeout
+
+The system SHALL end sessions that are idle for 30 minutes.
+
+#### Scenario: Idle session expires
+
+- **WHEN** a session has no activity for 30 minutes
+- **THEN** the next request SHALL be redirected to login
+`,
+					},
+				},
+				Commands: []string{"spectr validate add-session-timeout"},
+			},
+			{
+				Title: "MODIFIED",
+				Description: `Replace a requirement. The header matches the
+current spec; the body is the complete new version.`,
+				Files: []File{
+					{
+						Path: "spectr/changes/update-password-rules/proposal.md",
+						Content: proposal(
+							"Update password rules",
+							"Eight characters is below current guidance.",
+							"Require 12 character passwords",
+						),
+						Hidden: true,
+					},
+					{
+						Path: "spectr/changes/update-password-rules/specs/auth/spec.md",
+						Content: `## MODIFIED Requirements
+
+### Requirement: Password Rules
+
+Passwords SHALL be at least 12 characters long.
+
+#### Scenario: Short password rejected
+
+- **WHEN** a user chooses a 10 character password
+- **THEN** the system SHALL reject it
+`,
+					},
+				},
+				Commands: []string{"spectr diff update-password-rules"},
+			},
+			{
+				Title:       "REMOVED",
+				Description: "Drop a requirement, saying why and what replaces it.",
+				Files: []File{
+					{
+						Path: "spectr/changes/remove-legacy-login/proposal.md",
+						Content: proposal(
+							"Remove legacy login",
+							"The v1 API has no remaining clients.",
+							"Remove the v1 login endpoint",
+						),
+						Hidden: true,
+					},
+					{
+						Path: "spectr/changes/remove-legacy-login/specs/auth/spec.md",
+						Content: `## REMOVED Requirements
+
+### Requirement: Legacy Login
+
+**Reason**: The v1 API is retired.
+**Migration**: Clients post credentials to /v2/login instead.
+`,
+					},
+				},
+				Commands: []string{"spectr validate remove-legacy-login"},
+			},
+			{
+				Title:       "RENAMED",
+				Description: "Rename a requirement without changing its text.",
+				Files: []File{
+					{
+						Path: "spectr/changes/rename-user-login/proposal.md",
+						Content: proposal(
+							"Rename user login",
+							"Login now supports more than one method.",
+							"Rename User Login to Email Login",
+						),
+						Hidden: true,
+					},
+					{
+						Path: "spectr/changes/rename-user-login/specs/auth/spec.md",
+						Content: `## RENAMED Requirements
+
+- FROM: ### Requirement: User Login
+- TO: ### Requirement: Email Login
+`,
+					},
+				},
+				Commands: []string{"spectr validate --all"},
+			},
+		},
+	},
+	{
+		Name:    "tasks",
+		Title:   "Tasks and the tasks.jsonc schema",
+		Summary: "Write tasks.md, accept it, and track status in tasks.jsonc",
+		Intro: `Write tasks as a numbered checklist in ` + "`tasks.md`" + `.
+` + "`spectr accept`" + ` converts it into ` + "`tasks.jsonc`" + `, the
+machine-readable file agents update while they work. Status changes in
+` + "`tasks.jsonc`" + ` are synced back to the checkboxes in ` + "`tasks.md`" + `.
+
+Fields of ` + "`tasks.jsonc`" + `:
+
+- ` + "`version`" + `: format version (1, or 2 for hierarchical files)
+- ` + "`tasks[].id`" + `: task number such as "1.2"
+- ` + "`tasks[].section`" + `: the ` + "`##`" + ` heading the task came from
+- ` + "`tasks[].description`" + `: the task text
+- ` + "`tasks[].status`" + `: "pending", "in_progress" or "completed"
+- ` + "`tasks[].children`" + `: v2 only, a "$ref:" to a child tasks file
+
+` + "`spectr migrate tasks`" + ` upgrades version 1 files to version 2.`,
+		Keywords: []string{
+			"tasks.md", "tasks.jsonc", "accept", "status", "pending",
+			"in_progress", "completed", "schema", "checklist",
+		},
+		Examples: []Example{
+			{
+				Title: "From tasks.md to tasks.jsonc",
+				Description: `A change with a tasks.md checklist; after
+` + "`spectr accept`" + ` it also has this tasks.jsonc.`,
+				Files: []File{
+					{
+						Path: "spectr/changes/add-login-audit/proposal.md",
+						Content: proposal(
+							"Add login audit log",
+							"Security reviews need a record of sign-ins.",
+							"Record every login attempt",
+						),
+						Hidden: true,
+					},
+					{
+						Path: "spectr/changes/add-login-audit/specs/auth/spec.md",
+						Content: `## ADDED Requirements
+
+### Requirement: Login Audit
+
+The system SHALL record the time and outcome of every login attempt.
+
+#### Scenario: Failed login recorded
+
+- **WHEN** a login attempt fails
+- **THEN** an audit entry SHALL record the email and failure time
+`,
+						Hidden: true,
+					},
+					{
+						Path: "spectr/changes/add-login-audit/tasks.md",
+						Content: `## 1. Implementation
+
+- [x] 1.1 Create the audit table
+- [ ] 1.2 Write a Login Audit entry on every login attempt
+
+## 2. Testing
+
+- [ ] 2.1 Cover successful and failed logins
+`,
+					},
+					{
+						Path: "spectr/changes/add-login-audit/tasks.jsonc",
+						Content: `{
+  "version": 1,
+  "tasks": [
+    {
+      "id": "1.1",
+      "section": "Implementation",
+      "description": "Create the audit table",
+      "status": "completed"
+    },
+    {
+      "id": "1.2",
+      "section": "Implementation",
+      "description": "Write a Login Audit entry on every login attempt",
+      "status": "in_progress"
+    },
+    {
+      "id": "2.1",
+      "section": "Testing",
+      "description": "Cover successful and failed logins",
+      "status": "pending"
+    }
+  ]
+}
+`,
+					},
+				},
+				Commands: []string{
+					"spectr list --long",
+					"spectr accept add-login-audit --dry-run",
+				},
+			},
+		},
+	},
+}
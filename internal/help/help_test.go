@@ -0,0 +1,141 @@
+package help
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/connerohnesorge/spectr/internal/validation"
+)
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		name   string
+		want   string
+		wantOK bool
+	}{
+		{"deltas", "deltas", true},
+		{"DELTAS", "deltas", true},
+		{"tasks", "tasks", true},
+		{"nosuch", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			topic, ok := Lookup(tt.name)
+			if ok != tt.wantOK || topic.Name != tt.want {
+				t.Errorf(
+					"Lookup(%q) = %q, %v; want %q, %v",
+					tt.name, topic.Name, ok, tt.want, tt.wantOK,
+				)
+			}
+		})
+	}
+}
+
+func TestTopicMarkdown(t *testing.T) {
+	topic, _ := Lookup("deltas")
+	md := topic.Markdown()
+
+	for _, want := range []string{
+		"# Delta spec syntax",
+		"## Example: RENAMED",
+		"`spectr/changes/rename-user-login/specs/auth/spec.md`:",
+		"spectr help deltas --example",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("Markdown() missing %q", want)
+		}
+	}
+
+	if strings.Contains(md, "rename-user-login/proposal.md") {
+		t.Error("Markdown() shows a hidden file")
+	}
+}
+
+func TestRender(t *testing.T) {
+	out := Render("# Title\n\n- **Reason** in `code`\n\n```bash\nspectr list\n\n```\n")
+
+	for _, want := range []string{"Title", "• Reason in code", codeIndent + "spectr list"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Render() = %q, missing %q", out, want)
+		}
+	}
+	if strings.Contains(out, "```") || strings.Contains(out, "**") {
+		t.Errorf("Render() left markdown syntax: %q", out)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"renamed", []string{"deltas"}},
+		{"in_progress", []string{"tasks"}},
+		{"remember me", []string{"changes"}},
+		{"spectr validate", []string{"changes", "deltas"}},
+		{"no such words", nil},
+		{"", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			var got []string
+			for _, match := range Search(tt.query) {
+				got = append(got, match.Topic.Name)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Search(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScaffold_Validates(t *testing.T) {
+	for _, topic := range Topics() {
+		t.Run(topic.Name, func(t *testing.T) {
+			dir, err := Scaffold(topic, t.TempDir())
+			if err != nil {
+				t.Fatalf("Scaffold: %v", err)
+			}
+			if !strings.HasPrefix(filepath.Base(dir), "spectr-help-"+topic.Name+"-") {
+				t.Errorf("sandbox dir = %s", dir)
+			}
+
+			spectrRoot := filepath.Join(dir, "spectr")
+			report, err := validation.ValidateSpecFile(
+				filepath.Join(spectrRoot, "specs", "auth", "spec.md"),
+			)
+			assertValid(t, report, err)
+
+			changes, err := os.ReadDir(filepath.Join(spectrRoot, "changes"))
+			if err != nil {
+				t.Fatalf("read changes: %v", err)
+			}
+			if len(changes) == 0 {
+				t.Fatal("sandbox has no changes")
+			}
+			for _, change := range changes {
+				changeDir := filepath.Join(spectrRoot, "changes", change.Name())
+				report, err := validation.ValidateChangeDeltaSpecs(changeDir, spectrRoot)
+				assertValid(t, report, err)
+			}
+		})
+	}
+}
+
+func assertValid(t *testing.T, report *validation.ValidationReport, err error) {
+	t.Helper()
+
+	if err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	for _, issue := range report.Issues {
+		if issue.Level == validation.LevelInfo {
+			continue
+		}
+		t.Errorf("%s: %s: %s", issue.Level, issue.Path, issue.Message)
+	}
+}
@@ -0,0 +1,86 @@
+package help
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/connerohnesorge/spectr/internal/tui"
+)
+
+const codeIndent = "    "
+
+var (
+	titleStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color(tui.ColorHeader))
+	headingStyle = lipgloss.NewStyle().
+			Bold(true).
+			Underline(true)
+	codeStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("6"))
+	captionStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color(tui.ColorHelp))
+	strongStyle = lipgloss.NewStyle().Bold(true)
+
+	inlineCodePattern = regexp.MustCompile("`([^`]+)`")
+	strongPattern     = regexp.MustCompile(`\*\*([^*]+)\*\*`)
+)
+
+// Render formats topic markdown for the terminal. It understands the subset
+// Markdown emits: headings, fenced code blocks, bullets, inline code and
+// bold text. Code blocks are indented and printed verbatim so they can be
+// copied. lipgloss drops the styling when stdout is not a terminal.
+func Render(md string) string {
+	var (
+		sb      strings.Builder
+		inFence bool
+	)
+
+	for _, line := range strings.Split(md, "\n") {
+		if strings.HasPrefix(line, "```") {
+			inFence = !inFence
+
+			continue
+		}
+
+		switch {
+		case inFence && line == "":
+		case inFence:
+			sb.WriteString(codeIndent + codeStyle.Render(line))
+		case strings.HasPrefix(line, "# "):
+			sb.WriteString(titleStyle.Render(strings.TrimPrefix(line, "# ")))
+		case strings.HasPrefix(line, "## "):
+			sb.WriteString(headingStyle.Render(strings.TrimPrefix(line, "## ")))
+		case isCaption(line):
+			sb.WriteString(captionStyle.Render(strings.Trim(line, "`:")))
+		case strings.HasPrefix(line, "- "):
+			sb.WriteString("  • " + renderInline(strings.TrimPrefix(line, "- ")))
+		case strings.HasPrefix(line, "  "):
+			sb.WriteString("    " + renderInline(strings.TrimSpace(line)))
+		default:
+			sb.WriteString(renderInline(line))
+		}
+		sb.WriteByte('\n')
+	}
+
+	return strings.TrimRight(sb.String(), "\n") + "\n"
+}
+
+// isCaption reports whether line is a file caption such as "`path`:".
+func isCaption(line string) bool {
+	return strings.HasPrefix(line, "`") &&
+		strings.HasSuffix(line, "`:") &&
+		strings.Count(line, "`") == 2
+}
+
+// renderInline styles inline code and bold spans.
+func renderInline(text string) string {
+	text = inlineCodePattern.ReplaceAllStringFunc(text, func(m string) string {
+		return codeStyle.Render(strings.Trim(m, "`"))
+	})
+
+	return strongPattern.ReplaceAllStringFunc(text, func(m string) string {
+		return strongStyle.Render(strings.Trim(m, "*"))
+	})
+}
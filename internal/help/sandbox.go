@@ -0,0 +1,40 @@
+package help
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const (
+	sandboxDirPerm  = 0o755
+	sandboxFilePerm = 0o644
+)
+
+// Scaffold creates a sandbox project for topic in a new directory under
+// parent (os.TempDir() when empty) and returns its path. The sandbox holds
+// a small auth spec plus every example file of the topic, hidden ones
+// included, so the topic's commands can be run inside it.
+func Scaffold(topic Topic, parent string) (string, error) {
+	dir, err := os.MkdirTemp(parent, "spectr-help-"+topic.Name+"-*")
+	if err != nil {
+		return "", fmt.Errorf("create sandbox directory: %w", err)
+	}
+
+	files := append([]File(nil), sandboxBase...)
+	for _, example := range topic.Examples {
+		files = append(files, example.Files...)
+	}
+
+	for _, file := range files {
+		path := filepath.Join(dir, filepath.FromSlash(file.Path))
+		if err := os.MkdirAll(filepath.Dir(path), sandboxDirPerm); err != nil {
+			return "", fmt.Errorf("create sandbox directory: %w", err)
+		}
+		if err := os.WriteFile(path, []byte(file.Content), sandboxFilePerm); err != nil {
+			return "", fmt.Errorf("write %s: %w", file.Path, err)
+		}
+	}
+
+	return dir, nil
+}
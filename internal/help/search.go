@@ -0,0 +1,73 @@
+package help
+
+import "strings"
+
+// Match is a topic that matched a search query.
+type Match struct {
+	Topic Topic
+	// Where names the matching part, e.g. "keyword" or an example title.
+	Where string
+}
+
+// Search returns the topics mentioning every word of query, ignoring case.
+// Titles, summaries and keywords are checked before example text, and each
+// topic appears at most once.
+func Search(query string) []Match {
+	words := strings.Fields(strings.ToLower(query))
+	if len(words) == 0 {
+		return nil
+	}
+
+	var matches []Match
+	for _, topic := range topics {
+		if where, ok := topicMatches(topic, words); ok {
+			matches = append(matches, Match{Topic: topic, Where: where})
+		}
+	}
+
+	return matches
+}
+
+// topicMatches reports where in topic all words occur.
+func topicMatches(topic Topic, words []string) (string, bool) {
+	header := strings.Join(
+		append(
+			[]string{topic.Name, topic.Title, topic.Summary},
+			topic.Keywords...,
+		),
+		" ",
+	)
+	if containsAll(header, words) {
+		return "topic", true
+	}
+	if containsAll(topic.Intro, words) {
+		return "introduction", true
+	}
+
+	for _, example := range topic.Examples {
+		text := []string{example.Title, example.Description}
+		text = append(text, example.Commands...)
+		for _, file := range example.Files {
+			if !file.Hidden {
+				text = append(text, file.Path, file.Content)
+			}
+		}
+		if containsAll(strings.Join(text, "\n"), words) {
+			return "example: " + example.Title, true
+		}
+	}
+
+	return "", false
+}
+
+// containsAll reports whether text contains every word, ignoring case.
+func containsAll(text string, words []string) bool {
+	text = strings.ToLower(text)
+	for _, word := range words {
+		if !strings.Contains(text, word) {
+			return false
+		}
+	}
+
+	return true
+}
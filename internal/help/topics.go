@@ -0,0 +1,108 @@
+// Package help provides spectr's built-in help topics. Topics are
+// structured data compiled into the binary, so they work offline and match
+// the running version. Each topic renders to markdown for the terminal, and
+// its example files can be scaffolded into a sandbox project.
+package help
+
+import (
+	"fmt"
+	"strings"
+)
+
+// File is an example file, relative to the project root.
+type File struct {
+	Path    string
+	Content string
+	// Hidden files are written to the sandbox but not shown in the topic,
+	// e.g. the proposal a delta example needs to validate.
+	Hidden bool
+}
+
+// Example is a worked example within a topic.
+type Example struct {
+	Title       string
+	Description string   // Markdown
+	Files       []File   // Files shown and written by --example
+	Commands    []string // Commands to try in the sandbox
+}
+
+// Topic is a help topic shown by "spectr help <name>".
+type Topic struct {
+	Name     string
+	Title    string
+	Summary  string // One line, shown in the topic list
+	Intro    string // Markdown
+	Keywords []string
+	Examples []Example
+}
+
+// Topics returns all help topics in display order.
+func Topics() []Topic {
+	return topics
+}
+
+// Lookup returns the topic with the given name, ignoring case.
+func Lookup(name string) (Topic, bool) {
+	for _, topic := range topics {
+		if strings.EqualFold(topic.Name, name) {
+			return topic, true
+		}
+	}
+
+	return Topic{}, false
+}
+
+// Markdown renders the topic as a markdown document.
+func (t Topic) Markdown() string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "# %s\n\n%s\n", t.Title, strings.TrimSpace(t.Intro))
+
+	for _, example := range t.Examples {
+		fmt.Fprintf(&sb, "\n## Example: %s\n\n", example.Title)
+		if example.Description != "" {
+			fmt.Fprintf(&sb, "%s\n", strings.TrimSpace(example.Description))
+		}
+
+		for _, file := range example.Files {
+			if file.Hidden {
+				continue
+			}
+			fmt.Fprintf(
+				&sb,
+				"\n`%s`:\n\n```%s\n%s\n```\n",
+				file.Path,
+				fenceLanguage(file.Path),
+				strings.TrimRight(file.Content, "\n"),
+			)
+		}
+
+		if len(example.Commands) > 0 {
+			fmt.Fprintf(
+				&sb,
+				"\nTry:\n\n```bash\n%s\n```\n",
+				strings.Join(example.Commands, "\n"),
+			)
+		}
+	}
+
+	fmt.Fprintf(
+		&sb,
+		"\nRun `spectr help %s --example` to try these in a sandbox project.\n",
+		t.Name,
+	)
+
+	return sb.String()
+}
+
+// fenceLanguage returns the code fence language for a file path.
+func fenceLanguage(path string) string {
+	switch {
+	case strings.HasSuffix(path, ".md"):
+		return "markdown"
+	case strings.HasSuffix(path, ".jsonc"):
+		return "jsonc"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,57 @@
+package hostapi
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+)
+
+// CLICommand describes a hosting platform CLI invocation.
+type CLICommand struct {
+	Dir  string   // Working directory; empty means the current directory
+	Env  []string // Extra KEY=VALUE entries, e.g. a resolved token
+	Name string   // Program name (gh, glab, tea)
+	Args []string
+}
+
+// RunCLI runs a hosting platform CLI and returns its stdout. Failures
+// whose stderr looks like a network or rate-limit problem are retried
+// according to policy. A failed command is returned as its *exec.ExitError
+// with Stderr populated.
+func RunCLI(
+	ctx context.Context,
+	policy Policy,
+	command CLICommand,
+) ([]byte, error) {
+	var output []byte
+	err := Retry(ctx, policy, func(ctx context.Context) error {
+		cmd := exec.CommandContext(ctx, command.Name, command.Args...)
+		cmd.Dir = command.Dir
+		if len(command.Env) > 0 {
+			cmd.Env = append(os.Environ(), command.Env...)
+		}
+
+		var err error
+		output, err = cmd.Output()
+		if err == nil {
+			return nil
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) &&
+			IsTransientOutput(string(exitErr.Stderr)) {
+			return Transient(err)
+		}
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
@@ -0,0 +1,259 @@
+package hostapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+// defaultHTTPTimeout bounds a single HTTP attempt.
+const defaultHTTPTimeout = 30 * time.Second
+
+// maxErrorBody is the number of response body bytes kept in errors.
+const maxErrorBody = 512
+
+// Client sends requests to hosting platform HTTP APIs, retrying transient
+// failures according to Policy.
+type Client struct {
+	HTTPClient *http.Client
+	Policy     Policy
+	// Header is added to every request, e.g. Authorization.
+	Header http.Header
+}
+
+// NewClient creates a Client with DefaultPolicy.
+func NewClient() *Client {
+	return &Client{
+		HTTPClient: &http.Client{Timeout: defaultHTTPTimeout},
+		Policy:     DefaultPolicy,
+		Header:     http.Header{},
+	}
+}
+
+// Do sends req and returns the first successful (2xx) response; the caller
+// must close its body. Rate-limited responses are retried once the limit
+// resets, and connection failures before the request was sent are
+// retried. Other network errors and 5xx responses may come after the
+// server acted on the request, so they are retried only for idempotent
+// requests: GET, HEAD, OPTIONS, TRACE, PUT and DELETE, or any request
+// with an Idempotency-Key header. Unsuccessful responses are returned as
+// *specterrs.HostingAPIError or *specterrs.RateLimitError.
+func (c *Client) Do(
+	ctx context.Context,
+	req *http.Request,
+) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("read request body: %w", err)
+		}
+	}
+
+	idempotent := isIdempotent(req)
+	var resp *http.Response
+	err := Retry(ctx, c.Policy, func(ctx context.Context) error {
+		var err error
+		resp, err = c.send(ctx, req, body)
+		if err != nil && !idempotent && !safeToResend(err) {
+			return &finalError{err: unwrapTransient(err)}
+		}
+
+		return err
+	})
+	var final *finalError
+	if errors.As(err, &final) {
+		return nil, final.err
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// finalError stops Retry from retrying err. It deliberately has no Unwrap
+// method, so IsTransient does not see the 5xx or network error inside.
+type finalError struct {
+	err error
+}
+
+func (e *finalError) Error() string { return e.err.Error() }
+
+// isIdempotent reports whether req can be sent twice without a second
+// effect. Like net/http, an Idempotency-Key or X-Idempotency-Key header
+// marks any request as idempotent.
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions,
+		http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	_, hasKey := req.Header["Idempotency-Key"]
+	_, hasXKey := req.Header["X-Idempotency-Key"]
+
+	return hasKey || hasXKey
+}
+
+// safeToResend reports whether a failed attempt certainly had no effect:
+// the server rejected it for a rate limit, or the connection failed before
+// the request was sent.
+func safeToResend(err error) bool {
+	var rateErr *specterrs.RateLimitError
+	if errors.As(err, &rateErr) {
+		return true
+	}
+
+	var opErr *net.OpError
+
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// DoJSON sends a request with in encoded as the JSON body (when non-nil)
+// and decodes the response into out (when non-nil).
+func (c *Client) DoJSON(
+	ctx context.Context,
+	method, url string,
+	in, out any,
+) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("encode request: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.Do(ctx, req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode %s response: %w", url, err)
+	}
+
+	return nil
+}
+
+// send performs a single attempt.
+func (c *Client) send(
+	ctx context.Context,
+	req *http.Request,
+	body []byte,
+) (*http.Response, error) {
+	attempt := req.Clone(ctx)
+	if body != nil {
+		attempt.Body = io.NopCloser(bytes.NewReader(body))
+		attempt.ContentLength = int64(len(body))
+	}
+	for key, values := range c.Header {
+		for _, value := range values {
+			attempt.Header.Add(key, value)
+		}
+	}
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	resp, err := httpClient.Do(attempt)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+
+		return nil, Transient(err)
+	}
+	if resp.StatusCode >= http.StatusOK &&
+		resp.StatusCode < http.StatusMultipleChoices {
+		return resp, nil
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+
+	if isRateLimited(resp) {
+		return nil, &specterrs.RateLimitError{
+			URL:     req.URL.Redacted(),
+			ResetAt: rateLimitReset(resp.Header, time.Now()),
+		}
+	}
+
+	return nil, &specterrs.HostingAPIError{
+		Method:     req.Method,
+		URL:        req.URL.Redacted(),
+		StatusCode: resp.StatusCode,
+		Body:       strings.TrimSpace(string(data)),
+	}
+}
+
+// isRateLimited reports whether resp rejects the request for exceeding a
+// rate limit. GitHub answers 403 with X-RateLimit-Remaining: 0 for its
+// primary limit; everyone else uses 429.
+func isRateLimited(resp *http.Response) bool {
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+
+	return resp.StatusCode == http.StatusForbidden &&
+		resp.Header.Get("X-RateLimit-Remaining") == "0"
+}
+
+// rateLimitReset returns when a rate limit resets, from Retry-After
+// (seconds or HTTP date), X-RateLimit-Reset (GitHub, Unix seconds) or
+// RateLimit-Reset (GitLab, Unix seconds). Returns zero if unknown.
+func rateLimitReset(header http.Header, now time.Time) time.Time {
+	if value := header.Get("Retry-After"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil {
+			return now.Add(time.Duration(seconds) * time.Second)
+		}
+		if at, err := http.ParseTime(value); err == nil {
+			return at
+		}
+	}
+
+	for _, key := range []string{"X-RateLimit-Reset", "RateLimit-Reset"} {
+		epoch, err := strconv.ParseInt(header.Get(key), 10, 64)
+		if err == nil {
+			return time.Unix(epoch, 0)
+		}
+	}
+
+	return time.Time{}
+}
+
+// IsStatus reports whether err is a hosting API error with the given
+// status code.
+func IsStatus(err error, status int) bool {
+	var apiErr *specterrs.HostingAPIError
+
+	return errors.As(err, &apiErr) && apiErr.StatusCode == status
+}
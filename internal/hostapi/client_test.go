@@ -0,0 +1,252 @@
+package hostapi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+// newTestClient returns a client with fast retries.
+func newTestClient() *Client {
+	client := NewClient()
+	client.Policy = testPolicy
+
+	return client
+}
+
+func TestClient_RetriesServerErrors(t *testing.T) {
+	attempts := 0
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			body, _ := io.ReadAll(r.Body)
+			bodies = append(bodies, string(body))
+			if r.Header.Get("Authorization") != "token secret" {
+				t.Errorf("missing Authorization header")
+			}
+			if attempts < 3 {
+				w.WriteHeader(http.StatusBadGateway)
+
+				return
+			}
+			_, _ = w.Write([]byte(`{"number": 7}`))
+		},
+	))
+	defer server.Close()
+
+	client := newTestClient()
+	client.Header.Set("Authorization", "token secret")
+
+	var out struct {
+		Number int `json:"number"`
+	}
+	err := client.DoJSON(
+		context.Background(),
+		http.MethodPut,
+		server.URL,
+		map[string]string{"title": "t"},
+		&out,
+	)
+	if err != nil {
+		t.Fatalf("DoJSON: %v", err)
+	}
+	if out.Number != 7 {
+		t.Errorf("Number = %d, want 7", out.Number)
+	}
+	if attempts != 3 {
+		t.Errorf("attempts = %d, want 3", attempts)
+	}
+	for i, body := range bodies {
+		if body != `{"title":"t"}` {
+			t.Errorf("attempt %d body = %q, want replayed JSON", i+1, body)
+		}
+	}
+}
+
+func TestClient_ClientErrorNotRetried(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, _ *http.Request) {
+			attempts++
+			http.Error(w, "Not Found", http.StatusNotFound)
+		},
+	))
+	defer server.Close()
+
+	err := newTestClient().DoJSON(
+		context.Background(),
+		http.MethodGet,
+		server.URL,
+		nil,
+		nil,
+	)
+
+	var apiErr *specterrs.HostingAPIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("err = %v, want HostingAPIError", err)
+	}
+	if apiErr.StatusCode != http.StatusNotFound || apiErr.Body != "Not Found" {
+		t.Errorf("apiErr = %+v", apiErr)
+	}
+	if !IsStatus(err, http.StatusNotFound) {
+		t.Error("IsStatus(404) = false")
+	}
+	if attempts != 1 {
+		t.Errorf("attempts = %d, want 1", attempts)
+	}
+}
+
+func TestClient_RateLimit(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, _ *http.Request) {
+			attempts++
+			if attempts == 1 {
+				w.Header().Set("X-RateLimit-Remaining", "0")
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusForbidden)
+
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		},
+	))
+	defer server.Close()
+
+	err := newTestClient().DoJSON(
+		context.Background(),
+		http.MethodGet,
+		server.URL,
+		nil,
+		nil,
+	)
+	if err != nil {
+		t.Fatalf("DoJSON: %v", err)
+	}
+	if attempts != 2 {
+		t.Errorf("attempts = %d, want 2", attempts)
+	}
+}
+
+func TestClient_NonIdempotentRetries(t *testing.T) {
+	tests := []struct {
+		name           string
+		idempotencyKey bool
+		status         int
+		wantAttempts   int
+		wantErr        bool
+	}{
+		{name: "server error not retried", status: http.StatusBadGateway, wantAttempts: 1, wantErr: true},
+		{name: "rate limit retried", status: http.StatusTooManyRequests, wantAttempts: 2},
+		{name: "idempotency key opts in", idempotencyKey: true, status: http.StatusBadGateway, wantAttempts: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			server := httptest.NewServer(http.HandlerFunc(
+				func(w http.ResponseWriter, _ *http.Request) {
+					attempts++
+					if attempts == 1 {
+						w.Header().Set("Retry-After", "0")
+						w.WriteHeader(tt.status)
+
+						return
+					}
+					w.WriteHeader(http.StatusNoContent)
+				},
+			))
+			defer server.Close()
+
+			req, err := http.NewRequestWithContext(
+				context.Background(), http.MethodPost, server.URL, strings.NewReader("{}"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.idempotencyKey {
+				req.Header.Set("Idempotency-Key", "reminder-1")
+			}
+
+			resp, err := newTestClient().Do(context.Background(), req)
+			if err == nil {
+				_ = resp.Body.Close()
+			}
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Do() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && !IsStatus(err, tt.status) {
+				t.Errorf("Do() error = %v, want status %d", err, tt.status)
+			}
+			if attempts != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", attempts, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestClient_PostRetriedWhenNotSent(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close() // Connections are refused before anything is sent
+
+	err := newTestClient().DoJSON(context.Background(), http.MethodPost, url, map[string]string{}, nil)
+
+	var exhausted *specterrs.RetriesExhaustedError
+	if !errors.As(err, &exhausted) {
+		t.Fatalf("err = %v, want RetriesExhaustedError", err)
+	}
+	if exhausted.Attempts != testPolicy.MaxAttempts {
+		t.Errorf("Attempts = %d, want %d", exhausted.Attempts, testPolicy.MaxAttempts)
+	}
+}
+
+func TestRateLimitReset(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	reset := now.Add(time.Minute)
+
+	tests := []struct {
+		name   string
+		header http.Header
+		want   time.Time
+	}{
+		{
+			"retry-after seconds",
+			http.Header{"Retry-After": {"60"}},
+			reset,
+		},
+		{
+			"retry-after date",
+			http.Header{"Retry-After": {reset.UTC().Format(http.TimeFormat)}},
+			reset,
+		},
+		{
+			"github reset",
+			http.Header{"X-Ratelimit-Reset": {strconv.FormatInt(reset.Unix(), 10)}},
+			reset,
+		},
+		{
+			"gitlab reset",
+			http.Header{"Ratelimit-Reset": {strconv.FormatInt(reset.Unix(), 10)}},
+			reset,
+		},
+		{"unknown", http.Header{}, time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := rateLimitReset(tt.header, now)
+			if !got.Equal(tt.want) {
+				t.Errorf("rateLimitReset = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,12 @@
+// Package hostapi provides the shared client used to talk to hosting
+// platforms (GitHub, GitLab, Gitea, Jira), either over HTTP or through
+// their CLIs (gh, glab, tea).
+//
+// Transient failures such as network errors, 5xx responses and exhausted
+// rate limits are retried with exponential backoff so a flaky connection
+// does not abort a multi-step workflow (archive, commit, push, open PR)
+// halfway through. Requests that are not idempotent, such as webhook
+// POSTs, are only retried when they certainly had no effect, so a flaky
+// connection never delivers them twice. Failures are reported with
+// specterrs types.
+package hostapi
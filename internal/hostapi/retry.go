@@ -0,0 +1,188 @@
+package hostapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+// Policy controls how failed operations are retried.
+type Policy struct {
+	MaxAttempts int           // Total attempts, including the first
+	BaseDelay   time.Duration // Delay before the first retry; doubles after
+	MaxDelay    time.Duration // Cap on any single wait, rate-limit waits too
+}
+
+// DefaultPolicy is the retry policy used for hosting platform calls.
+var DefaultPolicy = Policy{
+	MaxAttempts: 4,
+	BaseDelay:   time.Second,
+	MaxDelay:    30 * time.Second,
+}
+
+// backoff returns the delay before the given retry (1-based).
+func (p Policy) backoff(retry int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < retry && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, p.MaxDelay)
+}
+
+// transientError marks an error as safe to retry.
+type transientError struct {
+	err error
+}
+
+func (e *transientError) Error() string { return e.err.Error() }
+
+func (e *transientError) Unwrap() error { return e.err }
+
+// Transient marks err as a transient failure that Retry should retry.
+func Transient(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &transientError{err: err}
+}
+
+// IsTransient reports whether err is worth retrying: errors marked with
+// Transient, rate limits, and 5xx API responses.
+func IsTransient(err error) bool {
+	var marked *transientError
+	if errors.As(err, &marked) {
+		return true
+	}
+
+	var rateErr *specterrs.RateLimitError
+	if errors.As(err, &rateErr) {
+		return true
+	}
+
+	var apiErr *specterrs.HostingAPIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode >= http.StatusInternalServerError
+	}
+
+	return false
+}
+
+// transientMarkers are output fragments of network and server failures
+// reported by git, gh, glab and tea.
+var transientMarkers = []string{
+	"could not resolve host",
+	"connection reset",
+	"connection refused",
+	"connection timed out",
+	"operation timed out",
+	"i/o timeout",
+	"tls handshake timeout",
+	"temporary failure in name resolution",
+	"the remote end hung up unexpectedly",
+	"early eof",
+	"rate limit",
+	"bad gateway",
+	"service unavailable",
+	"gateway timeout",
+	"http 502",
+	"http 503",
+	"http 504",
+}
+
+// IsTransientOutput reports whether CLI output describes a failure that
+// is likely to succeed on retry.
+func IsTransientOutput(output string) bool {
+	output = strings.ToLower(output)
+	for _, marker := range transientMarkers {
+		if strings.Contains(output, marker) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Retry runs op until it succeeds, fails with a non-transient error, or
+// the policy's attempts are used up, in which case the last error is
+// returned in a *specterrs.RetriesExhaustedError. Rate limits are waited
+// out when they reset within MaxDelay and returned immediately otherwise.
+func Retry(
+	ctx context.Context,
+	policy Policy,
+	op func(ctx context.Context) error,
+) error {
+	for attempt := 1; ; attempt++ {
+		err := op(ctx)
+		if err == nil || !IsTransient(err) {
+			return err
+		}
+		err = unwrapTransient(err)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		if attempt >= policy.MaxAttempts {
+			return &specterrs.RetriesExhaustedError{
+				Attempts: attempt,
+				Err:      err,
+			}
+		}
+
+		delay, ok := retryDelay(policy, attempt, err)
+		if !ok {
+			return err
+		}
+		if err := sleep(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// retryDelay returns how long to wait before retrying after err, or false
+// when a rate limit resets too far in the future to wait for.
+func retryDelay(
+	policy Policy,
+	attempt int,
+	err error,
+) (time.Duration, bool) {
+	delay := policy.backoff(attempt)
+
+	var rateErr *specterrs.RateLimitError
+	if errors.As(err, &rateErr) && !rateErr.ResetAt.IsZero() {
+		wait := time.Until(rateErr.ResetAt)
+		if wait > policy.MaxDelay {
+			return 0, false
+		}
+		delay = max(delay, wait)
+	}
+
+	return delay, true
+}
+
+// unwrapTransient strips the Transient marker so callers see the original
+// error.
+func unwrapTransient(err error) error {
+	if marked, ok := err.(*transientError); ok {
+		return marked.err
+	}
+
+	return err
+}
+
+// sleep waits for d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
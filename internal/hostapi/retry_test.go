@@ -0,0 +1,147 @@
+package hostapi
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+// testPolicy retries quickly so tests stay fast.
+var testPolicy = Policy{
+	MaxAttempts: 3,
+	BaseDelay:   time.Millisecond,
+	MaxDelay:    10 * time.Millisecond,
+}
+
+func TestRetry(t *testing.T) {
+	errFlaky := errors.New("connection reset")
+	errFatal := errors.New("bad credentials")
+	errNotFound := &specterrs.HostingAPIError{StatusCode: 404}
+
+	tests := []struct {
+		name         string
+		errs         []error
+		wantAttempts int
+		wantErr      error
+		wantExhaust  bool
+	}{
+		{"success first try", nil, 1, nil, false},
+		{"recovers", []error{Transient(errFlaky)}, 2, nil, false},
+		{"non-transient fails fast", []error{errFatal}, 1, errFatal, false},
+		{
+			"exhausted",
+			[]error{Transient(errFlaky), Transient(errFlaky), Transient(errFlaky)},
+			3,
+			errFlaky,
+			true,
+		},
+		{
+			"server error retried",
+			[]error{&specterrs.HostingAPIError{StatusCode: 502}},
+			2,
+			nil,
+			false,
+		},
+		{
+			"client error not retried",
+			[]error{errNotFound},
+			1,
+			errNotFound,
+			false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			err := Retry(context.Background(), testPolicy, func(context.Context) error {
+				attempts++
+				if attempts <= len(tt.errs) {
+					return tt.errs[attempts-1]
+				}
+
+				return nil
+			})
+
+			if attempts != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", attempts, tt.wantAttempts)
+			}
+
+			var exhausted *specterrs.RetriesExhaustedError
+			if got := errors.As(err, &exhausted); got != tt.wantExhaust {
+				t.Errorf("RetriesExhaustedError = %v, want %v (err %v)", got, tt.wantExhaust, err)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRetry_RateLimitTooFarAway(t *testing.T) {
+	rateErr := &specterrs.RateLimitError{
+		URL:     "https://api.github.com",
+		ResetAt: time.Now().Add(time.Hour),
+	}
+
+	attempts := 0
+	err := Retry(context.Background(), testPolicy, func(context.Context) error {
+		attempts++
+
+		return rateErr
+	})
+
+	if attempts != 1 {
+		t.Errorf("attempts = %d, want 1", attempts)
+	}
+	if !errors.Is(err, rateErr) {
+		t.Errorf("err = %v, want rate limit error", err)
+	}
+}
+
+func TestRetry_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	err := Retry(ctx, DefaultPolicy, func(context.Context) error {
+		cancel()
+
+		return Transient(errors.New("connection reset"))
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v, want context.Canceled", err)
+	}
+}
+
+func TestPolicyBackoff(t *testing.T) {
+	policy := Policy{BaseDelay: time.Second, MaxDelay: 5 * time.Second}
+
+	want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second}
+	for i, w := range want {
+		if got := policy.backoff(i + 1); got != w {
+			t.Errorf("backoff(%d) = %s, want %s", i+1, got, w)
+		}
+	}
+}
+
+func TestIsTransientOutput(t *testing.T) {
+	tests := []struct {
+		output string
+		want   bool
+	}{
+		{"fatal: unable to access: Could not resolve host: github.com", true},
+		{"HTTP 502: Bad Gateway (https://api.github.com/graphql)", true},
+		{"API rate limit exceeded for user", true},
+		{"fatal: the remote end hung up unexpectedly", true},
+		{"a pull request for branch \"x\" already exists", false},
+		{"HTTP 401: Bad credentials", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsTransientOutput(tt.output); got != tt.want {
+			t.Errorf("IsTransientOutput(%q) = %v, want %v", tt.output, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,7 @@
+// Package ide generates editor integration files for spectr projects.
+//
+// The generated files depend on the project: one validate task per spectr
+// root, schema associations for the tasks.jsonc version in use, and a
+// migrate task when some changes still use an older tasks format. Files
+// that already exist are merged so entries not written by spectr are kept.
+package ide
@@ -0,0 +1,89 @@
+package ide
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/connerohnesorge/spectr/internal/discovery"
+	"github.com/connerohnesorge/spectr/internal/parsers"
+	"github.com/connerohnesorge/spectr/internal/taskschema"
+)
+
+// Project describes a spectr root as seen from the editor workspace.
+type Project struct {
+	// Name labels the project's tasks, e.g. "api" or "services/api".
+	Name string
+	// Dir is the root relative to the workspace, with forward slashes
+	// ("." for the workspace itself).
+	Dir string
+	// TasksVersion is the newest tasks.jsonc version used by the active
+	// changes, or parsers.LatestTasksVersion when there are none.
+	TasksVersion int
+	// NeedsMigration is true when some active change uses an older
+	// tasks.jsonc version than parsers.LatestTasksVersion.
+	NeedsMigration bool
+}
+
+// Projects describes each root relative to workspace.
+func Projects(
+	workspace string,
+	roots []discovery.SpectrRoot,
+) ([]Project, error) {
+	projects := make([]Project, 0, len(roots))
+	for _, root := range roots {
+		dir, err := filepath.Rel(workspace, root.Path)
+		if err != nil {
+			dir = root.Path
+		}
+
+		project := Project{
+			Name: filepath.ToSlash(dir),
+			Dir:  filepath.ToSlash(dir),
+		}
+		if dir == "." {
+			project.Name = filepath.Base(root.Path)
+		}
+		if err := detectTasksFormat(root, &project); err != nil {
+			return nil, err
+		}
+
+		projects = append(projects, project)
+	}
+
+	return projects, nil
+}
+
+// detectTasksFormat records the tasks.jsonc versions used by the root's
+// active changes. Files that cannot be read or parsed are skipped;
+// 'spectr validate' reports them.
+func detectTasksFormat(root discovery.SpectrRoot, project *Project) error {
+	changeIDs, err := discovery.GetActiveChangeIDs(root.Path)
+	if err != nil {
+		return err
+	}
+
+	newest := 0
+	for _, changeID := range changeIDs {
+		path := filepath.Join(root.ChangesDir(), changeID, "tasks.jsonc")
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		version, err := taskschema.DetectVersion(path, data)
+		if err != nil {
+			continue
+		}
+
+		newest = max(newest, version)
+		if version < parsers.LatestTasksVersion {
+			project.NeedsMigration = true
+		}
+	}
+
+	project.TasksVersion = newest
+	if newest == 0 {
+		project.TasksVersion = parsers.LatestTasksVersion
+	}
+
+	return nil
+}
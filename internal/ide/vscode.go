@@ -0,0 +1,460 @@
+package ide
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/jsonschema"
+	"github.com/connerohnesorge/spectr/internal/parsers"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+const (
+	// TaskLabelPrefix marks tasks written by spectr. Existing tasks with
+	// this prefix are replaced on regeneration; others are kept.
+	TaskLabelPrefix = "spectr: "
+
+	// settingsPrefix marks settings owned by spectr.
+	settingsPrefix = "spectr."
+
+	vscodeDir = ".vscode"
+	dirPerm   = 0o755
+	filePerm  = 0o644
+)
+
+// ProblemMatcherPattern matches one line of 'spectr validate
+// --format=jsonl' output. Groups: 1 severity, 2 file, 3 line, 4 column,
+// 5 message. It relies on the fixed field order of
+// validation.DiagnosticLine.
+const ProblemMatcherPattern = `^\{"level":"(ERROR|WARNING|INFO)",` +
+	`"path":"((?:[^"\\]|\\.)*)","line":(\d+),"column":(\d+),` +
+	`"message":"((?:[^"\\]|\\.)*)"`
+
+// VSCodeOptions configures the generated VS Code files.
+type VSCodeOptions struct {
+	// Command is the spectr executable tasks run. Defaults to "spectr".
+	Command string
+	// Force replaces existing files instead of merging into them.
+	Force bool
+}
+
+// File is a generated file, relative to the workspace.
+type File struct {
+	Path    string
+	Data    []byte
+	Changed bool
+}
+
+// VSCode returns .vscode/tasks.json and .vscode/settings.json for the
+// projects, merged with the files already in workspace unless
+// opts.Force is set.
+func VSCode(
+	workspace string,
+	projects []Project,
+	opts VSCodeOptions,
+) ([]File, error) {
+	if opts.Command == "" {
+		opts.Command = "spectr"
+	}
+
+	tasks, err := renderFile(
+		workspace,
+		filepath.Join(vscodeDir, "tasks.json"),
+		opts.Force,
+		func(doc map[string]json.RawMessage) error {
+			return mergeTasks(doc, vscodeTasks(projects, opts.Command))
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := renderFile(
+		workspace,
+		filepath.Join(vscodeDir, "settings.json"),
+		opts.Force,
+		func(doc map[string]json.RawMessage) error {
+			return mergeSettings(doc, projects)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return []File{tasks, settings}, nil
+}
+
+// WriteFiles writes files into workspace, skipping unchanged ones.
+func WriteFiles(workspace string, files []File) error {
+	for _, f := range files {
+		if !f.Changed {
+			continue
+		}
+
+		target := filepath.Join(workspace, f.Path)
+		if err := os.MkdirAll(filepath.Dir(target), dirPerm); err != nil {
+			return fmt.Errorf("create directory for %s: %w", f.Path, err)
+		}
+		if err := os.WriteFile(target, f.Data, filePerm); err != nil {
+			return fmt.Errorf("write %s: %w", f.Path, err)
+		}
+	}
+
+	return nil
+}
+
+// renderFile loads path (unless force), applies merge and returns the
+// resulting file. Comments in existing files are not preserved.
+func renderFile(
+	workspace, path string,
+	force bool,
+	merge func(map[string]json.RawMessage) error,
+) (File, error) {
+	existing, err := os.ReadFile(filepath.Join(workspace, path))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return File{}, fmt.Errorf("read %s: %w", path, err)
+	}
+
+	doc := map[string]json.RawMessage{}
+	if len(existing) > 0 && !force {
+		stripped := parsers.StripJSONComments(existing)
+		if len(bytes.TrimSpace(stripped)) > 0 {
+			if err := json.Unmarshal(stripped, &doc); err != nil {
+				return File{}, &specterrs.EditorConfigParseError{
+					Path: path,
+					Err:  err,
+				}
+			}
+		}
+	}
+
+	if err := merge(doc); err != nil {
+		return File{}, &specterrs.EditorConfigParseError{Path: path, Err: err}
+	}
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return File{}, err
+	}
+	data = append(data, '\n')
+
+	return File{
+		Path:    path,
+		Data:    data,
+		Changed: !bytes.Equal(data, existing),
+	}, nil
+}
+
+// vscodeTask is a task in tasks.json. Fields are declared in the order
+// VS Code documents them.
+type vscodeTask struct {
+	Label          string          `json:"label"`
+	Type           string          `json:"type,omitempty"`
+	Command        string          `json:"command,omitempty"`
+	Args           []string        `json:"args,omitempty"`
+	Options        *taskOptions    `json:"options,omitempty"`
+	DependsOn      []string        `json:"dependsOn,omitempty"`
+	Group          *taskGroup      `json:"group,omitempty"`
+	ProblemMatcher *problemMatcher `json:"problemMatcher,omitempty"`
+	Detail         string          `json:"detail,omitempty"`
+}
+
+type taskOptions struct {
+	Cwd string            `json:"cwd,omitempty"`
+	Env map[string]string `json:"env,omitempty"`
+}
+
+type taskGroup struct {
+	Kind      string `json:"kind"`
+	IsDefault bool   `json:"isDefault,omitempty"`
+}
+
+type problemMatcher struct {
+	Owner        string         `json:"owner"`
+	Source       string         `json:"source"`
+	FileLocation string         `json:"fileLocation"`
+	Pattern      matcherPattern `json:"pattern"`
+}
+
+type matcherPattern struct {
+	Regexp   string `json:"regexp"`
+	Severity int    `json:"severity"`
+	File     int    `json:"file"`
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+	Message  int    `json:"message"`
+}
+
+// spectrMatcher turns 'spectr validate --format=jsonl' lines into
+// problems. Paths in that output are absolute.
+var spectrMatcher = &problemMatcher{
+	Owner:        "spectr",
+	Source:       "spectr",
+	FileLocation: "absolute",
+	Pattern: matcherPattern{
+		Regexp:   ProblemMatcherPattern,
+		Severity: 1,
+		File:     2,
+		Line:     3,
+		Column:   4,
+		Message:  5,
+	},
+}
+
+// vscodeTasks builds the spectr tasks. A single project gets one validate
+// task; several projects get one per root, pinned with SPECTR_ROOT, plus a
+// default task running them all.
+func vscodeTasks(projects []Project, command string) []vscodeTask {
+	validateArgs := []string{
+		"validate", "--all", "--no-interactive", "--format=jsonl",
+	}
+	testGroup := &taskGroup{Kind: "test", IsDefault: true}
+
+	var tasks []vscodeTask
+	if len(projects) <= 1 {
+		task := vscodeTask{
+			Label:          TaskLabelPrefix + "validate",
+			Type:           "process",
+			Command:        command,
+			Args:           validateArgs,
+			Options:        projectOptions(projects),
+			Group:          testGroup,
+			ProblemMatcher: spectrMatcher,
+			Detail:         "Validate specs, changes, tasks.jsonc and spectr.yaml",
+		}
+		tasks = append(tasks, task)
+	} else {
+		labels := make([]string, 0, len(projects))
+		for _, p := range projects {
+			label := TaskLabelPrefix + "validate (" + p.Name + ")"
+			labels = append(labels, label)
+			tasks = append(tasks, vscodeTask{
+				Label:          label,
+				Type:           "process",
+				Command:        command,
+				Args:           validateArgs,
+				Options:        rootOptions(p),
+				Group:          &taskGroup{Kind: "test"},
+				ProblemMatcher: spectrMatcher,
+				Detail:         "Validate the spectr project in " + p.Dir,
+			})
+		}
+		tasks = append(tasks, vscodeTask{
+			Label:     TaskLabelPrefix + "validate all",
+			DependsOn: labels,
+			Group:     testGroup,
+			Detail:    "Validate every spectr project in the workspace",
+		})
+	}
+
+	for _, p := range projects {
+		if !p.NeedsMigration {
+			continue
+		}
+		label := TaskLabelPrefix + "migrate tasks"
+		if len(projects) > 1 {
+			label += " (" + p.Name + ")"
+		}
+		tasks = append(tasks, vscodeTask{
+			Label:   label,
+			Type:    "process",
+			Command: command,
+			Args:    []string{"migrate", "tasks"},
+			Options: rootOptions(p),
+			Detail: fmt.Sprintf(
+				"Upgrade tasks.jsonc files to version %d",
+				parsers.LatestTasksVersion,
+			),
+		})
+	}
+
+	return tasks
+}
+
+// projectOptions runs a single project's task from its root when that is
+// not the workspace folder.
+func projectOptions(projects []Project) *taskOptions {
+	if len(projects) == 0 || projects[0].Dir == "." {
+		return nil
+	}
+
+	return rootOptions(projects[0])
+}
+
+// rootOptions runs a task in the project's root and pins discovery to it.
+func rootOptions(p Project) *taskOptions {
+	dir := workspacePath(p.Dir)
+
+	return &taskOptions{
+		Cwd: dir,
+		Env: map[string]string{"SPECTR_ROOT": dir},
+	}
+}
+
+func workspacePath(dir string) string {
+	if dir == "." {
+		return "${workspaceFolder}"
+	}
+
+	return "${workspaceFolder}/" + dir
+}
+
+// mergeTasks replaces the spectr tasks in a tasks.json document.
+func mergeTasks(doc map[string]json.RawMessage, tasks []vscodeTask) error {
+	var existing []json.RawMessage
+	if raw, ok := doc["tasks"]; ok {
+		if err := json.Unmarshal(raw, &existing); err != nil {
+			return fmt.Errorf("tasks: %w", err)
+		}
+	}
+
+	merged := make([]any, 0, len(existing)+len(tasks))
+	for _, raw := range existing {
+		var task struct {
+			Label string `json:"label"`
+		}
+		if json.Unmarshal(raw, &task) == nil &&
+			strings.HasPrefix(task.Label, TaskLabelPrefix) {
+			continue
+		}
+		merged = append(merged, raw)
+	}
+	for _, task := range tasks {
+		merged = append(merged, task)
+	}
+
+	if _, ok := doc["version"]; !ok {
+		doc["version"] = json.RawMessage(`"2.0.0"`)
+	}
+
+	return setJSON(doc, "tasks", merged)
+}
+
+// mergeSettings sets the spectr-owned settings: schema associations for
+// tasks files and spectr.yaml, and the document selector the spectr
+// language server will use for spec markdown. Schema entries that do not
+// point at spectr schemas are kept.
+func mergeSettings(doc map[string]json.RawMessage, projects []Project) error {
+	for key := range doc {
+		if strings.HasPrefix(key, settingsPrefix) {
+			delete(doc, key)
+		}
+	}
+
+	if err := mergeJSONSchemas(doc, projects); err != nil {
+		return err
+	}
+	if err := mergeYAMLSchemas(doc, projects); err != nil {
+		return err
+	}
+
+	selector := make([]map[string]string, 0, len(projects))
+	roots := make([]string, 0, len(projects))
+	for _, p := range projects {
+		roots = append(roots, p.Dir)
+		selector = append(selector, map[string]string{
+			"language": "markdown",
+			"pattern":  globPrefix(p.Dir) + "spectr/**/*.md",
+		})
+	}
+	if err := setJSON(doc, settingsPrefix+"roots", roots); err != nil {
+		return err
+	}
+
+	return setJSON(doc, settingsPrefix+"lsp.documentSelector", selector)
+}
+
+func mergeJSONSchemas(doc map[string]json.RawMessage, projects []Project) error {
+	var existing []json.RawMessage
+	if raw, ok := doc["json.schemas"]; ok {
+		if err := json.Unmarshal(raw, &existing); err != nil {
+			return fmt.Errorf("json.schemas: %w", err)
+		}
+	}
+
+	merged := make([]any, 0, len(existing)+len(projects))
+	for _, raw := range existing {
+		var entry struct {
+			URL string `json:"url"`
+		}
+		if json.Unmarshal(raw, &entry) == nil &&
+			strings.HasPrefix(entry.URL, jsonschema.BaseURL) {
+			continue
+		}
+		merged = append(merged, raw)
+	}
+
+	// Group projects by tasks version so each schema appears once.
+	byVersion := map[int][]string{}
+	for _, p := range projects {
+		byVersion[p.TasksVersion] = append(
+			byVersion[p.TasksVersion],
+			"/"+globPrefix(p.Dir)+"spectr/changes/*/tasks.jsonc",
+			"/"+globPrefix(p.Dir)+"spectr/changes/*/tasks-*.jsonc",
+		)
+	}
+	versions := make([]int, 0, len(byVersion))
+	for v := range byVersion {
+		versions = append(versions, v)
+	}
+	sort.Ints(versions)
+
+	tasks := jsonschema.MustLookup("tasks")
+	for _, v := range versions {
+		merged = append(merged, map[string]any{
+			"fileMatch": byVersion[v],
+			"url":       tasks.URL(v),
+		})
+	}
+
+	return setJSON(doc, "json.schemas", merged)
+}
+
+func mergeYAMLSchemas(doc map[string]json.RawMessage, projects []Project) error {
+	schemas := map[string]json.RawMessage{}
+	if raw, ok := doc["yaml.schemas"]; ok {
+		if err := json.Unmarshal(raw, &schemas); err != nil {
+			return fmt.Errorf("yaml.schemas: %w", err)
+		}
+	}
+	for url := range schemas {
+		if strings.HasPrefix(url, jsonschema.BaseURL) {
+			delete(schemas, url)
+		}
+	}
+
+	files := make([]string, 0, len(projects))
+	for _, p := range projects {
+		files = append(files, globPrefix(p.Dir)+"spectr.yaml")
+	}
+	if err := setJSON(schemas, jsonschema.MustLookup("config").URL(0), files); err != nil {
+		return err
+	}
+
+	return setJSON(doc, "yaml.schemas", schemas)
+}
+
+// globPrefix returns dir as a glob path prefix ("" for the workspace).
+func globPrefix(dir string) string {
+	if dir == "." {
+		return ""
+	}
+
+	return dir + "/"
+}
+
+func setJSON(doc map[string]json.RawMessage, key string, value any) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	doc[key] = data
+
+	return nil
+}
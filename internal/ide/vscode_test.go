@@ -0,0 +1,244 @@
+package ide
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/connerohnesorge/spectr/internal/demo"
+	"github.com/connerohnesorge/spectr/internal/discovery"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+	"github.com/connerohnesorge/spectr/internal/validation"
+)
+
+func TestProblemMatcherPattern(t *testing.T) {
+	line, err := json.Marshal(validation.DiagnosticLine{
+		Level:   validation.LevelWarning,
+		Path:    `/work/spectr/changes/a "b"/tasks.jsonc`,
+		Line:    12,
+		Column:  7,
+		Message: `tasks[0].status: must be one of "pending" \ "done"`,
+		Item:    "a",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := regexp.MustCompile(ProblemMatcherPattern).FindStringSubmatch(string(line))
+	if m == nil {
+		t.Fatalf("pattern does not match %s", line)
+	}
+	want := []string{
+		"WARNING",
+		`/work/spectr/changes/a \"b\"/tasks.jsonc`,
+		"12",
+		"7",
+		`tasks[0].status: must be one of \"pending\" \\ \"done\"`,
+	}
+	for i, w := range want {
+		if m[i+1] != w {
+			t.Errorf("group %d = %q, want %q", i+1, m[i+1], w)
+		}
+	}
+}
+
+func TestProjects(t *testing.T) {
+	workspace := t.TempDir()
+	apiDir := filepath.Join(workspace, "services", "api")
+	if err := demo.Write(apiDir); err != nil {
+		t.Fatal(err)
+	}
+
+	projects, err := Projects(workspace, []discovery.SpectrRoot{
+		{Path: workspace},
+		{Path: apiDir},
+	})
+	if err != nil {
+		t.Fatalf("Projects: %v", err)
+	}
+
+	if projects[0].Dir != "." || projects[0].Name != filepath.Base(workspace) {
+		t.Errorf("workspace project = %+v", projects[0])
+	}
+	if projects[0].TasksVersion != 2 || projects[0].NeedsMigration {
+		t.Errorf("project without changes = %+v, want latest version", projects[0])
+	}
+
+	api := projects[1]
+	if api.Dir != "services/api" || api.Name != "services/api" {
+		t.Errorf("api project = %+v", api)
+	}
+	// The demo's two-factor change has a version 1 tasks.jsonc.
+	if api.TasksVersion != 1 || !api.NeedsMigration {
+		t.Errorf("api project = %+v, want v1 needing migration", api)
+	}
+}
+
+func TestVSCode_MultiRoot(t *testing.T) {
+	projects := []Project{
+		{Name: "web", Dir: "web", TasksVersion: 2},
+		{Name: "api", Dir: "api", TasksVersion: 1, NeedsMigration: true},
+	}
+
+	files, err := VSCode(t.TempDir(), projects, VSCodeOptions{})
+	if err != nil {
+		t.Fatalf("VSCode: %v", err)
+	}
+
+	tasks := decodeTasks(t, files[0].Data)
+	labels := make([]string, len(tasks))
+	for i, task := range tasks {
+		labels[i] = task.Label
+	}
+	wantLabels := []string{
+		"spectr: validate (web)",
+		"spectr: validate (api)",
+		"spectr: validate all",
+		"spectr: migrate tasks (api)",
+	}
+	if strings.Join(labels, "|") != strings.Join(wantLabels, "|") {
+		t.Errorf("labels = %v, want %v", labels, wantLabels)
+	}
+	if env := tasks[1].Options.Env["SPECTR_ROOT"]; env != "${workspaceFolder}/api" {
+		t.Errorf("api SPECTR_ROOT = %q", env)
+	}
+	if got := strings.Join(tasks[0].Args, " "); got != "validate --all --no-interactive --format=jsonl" {
+		t.Errorf("args = %q", got)
+	}
+
+	settings := string(files[1].Data)
+	for _, want := range []string{
+		`"/web/spectr/changes/*/tasks.jsonc"`,
+		`tasks-v1.schema.json`,
+		`tasks-v2.schema.json`,
+		`"api/spectr.yaml"`,
+		`"pattern": "web/spectr/**/*.md"`,
+	} {
+		if !strings.Contains(settings, want) {
+			t.Errorf("settings.json missing %s:\n%s", want, settings)
+		}
+	}
+}
+
+func TestVSCode_Merge(t *testing.T) {
+	workspace := t.TempDir()
+	writeFile(t, workspace, ".vscode/tasks.json", `{
+  // user tasks
+  "version": "2.0.0",
+  "tasks": [
+    {"label": "build", "type": "shell", "command": "make"},
+    {"label": "spectr: old task", "type": "shell", "command": "spectr list"}
+  ]
+}`)
+	writeFile(t, workspace, ".vscode/settings.json", `{
+  "editor.tabSize": 4,
+  "spectr.stale": true,
+  "json.schemas": [{"fileMatch": ["/x.json"], "url": "https://example.com/x.json"}]
+}`)
+
+	projects := []Project{{Name: "app", Dir: ".", TasksVersion: 2}}
+	files, err := VSCode(workspace, projects, VSCodeOptions{Command: "./bin/spectr"})
+	if err != nil {
+		t.Fatalf("VSCode: %v", err)
+	}
+	if err := WriteFiles(workspace, files); err != nil {
+		t.Fatal(err)
+	}
+
+	tasks := decodeTasks(t, readFile(t, workspace, ".vscode/tasks.json"))
+	if len(tasks) != 2 || tasks[0].Label != "build" || tasks[1].Label != "spectr: validate" {
+		t.Errorf("tasks = %+v", tasks)
+	}
+	if tasks[1].Command != "./bin/spectr" || tasks[1].Options != nil {
+		t.Errorf("validate task = %+v", tasks[1])
+	}
+
+	settings := string(readFile(t, workspace, ".vscode/settings.json"))
+	if !strings.Contains(settings, `"editor.tabSize": 4`) ||
+		!strings.Contains(settings, "https://example.com/x.json") {
+		t.Errorf("user settings lost:\n%s", settings)
+	}
+	if strings.Contains(settings, "spectr.stale") {
+		t.Errorf("stale spectr setting kept:\n%s", settings)
+	}
+
+	// Regenerating is a no-op.
+	again, err := VSCode(workspace, projects, VSCodeOptions{Command: "./bin/spectr"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range again {
+		if f.Changed {
+			t.Errorf("%s changed on regeneration", f.Path)
+		}
+	}
+}
+
+func TestVSCode_UnparsableFile(t *testing.T) {
+	workspace := t.TempDir()
+	writeFile(t, workspace, ".vscode/settings.json", "{\n  \"a\": 1,\n}\n")
+	projects := []Project{{Name: "app", Dir: ".", TasksVersion: 2}}
+
+	_, err := VSCode(workspace, projects, VSCodeOptions{})
+	var parseErr *specterrs.EditorConfigParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("error = %v, want EditorConfigParseError", err)
+	}
+
+	files, err := VSCode(workspace, projects, VSCodeOptions{Force: true})
+	if err != nil {
+		t.Fatalf("VSCode with Force: %v", err)
+	}
+	if strings.Contains(string(files[1].Data), `"a"`) {
+		t.Error("Force kept existing settings")
+	}
+}
+
+type decodedTask struct {
+	Label   string   `json:"label"`
+	Command string   `json:"command"`
+	Args    []string `json:"args"`
+	Options *struct {
+		Env map[string]string `json:"env"`
+	} `json:"options"`
+}
+
+func decodeTasks(t *testing.T, data []byte) []decodedTask {
+	t.Helper()
+
+	var doc struct {
+		Tasks []decodedTask `json:"tasks"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("decode tasks.json: %v", err)
+	}
+
+	return doc.Tasks
+}
+
+func writeFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+
+	path := filepath.Join(dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readFile(t *testing.T, dir, name string) []byte {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return data
+}
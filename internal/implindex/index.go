@@ -0,0 +1,145 @@
+// Package implindex builds an index that links spec requirements to the
+// source code implementing them.
+//
+// Source files opt in by carrying a marker comment of the form:
+//
+//	// spectr:impl <spec-id>#<Requirement Name>
+//
+// The marker may use any common line-comment prefix (//, #, --, ;) or appear
+// inside a block comment. When the marker directly precedes a function
+// declaration, the function name is recorded alongside the file and line.
+//
+// Tests opt in the same way with a test marker, which spectr scaffold tests
+// writes above each generated test:
+//
+//	// spectr:req <spec-id>#<Requirement Name>
+//
+// Test markers are kept apart from implementation markers; see LookupTests.
+package implindex
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/parsers"
+)
+
+// MarkerKeyword is the keyword that introduces an implementation marker.
+const MarkerKeyword = "spectr:impl"
+
+// TestMarkerKeyword is the keyword that introduces a test marker.
+const TestMarkerKeyword = "spectr:req"
+
+// Marker is a single implementation marker found in a source file.
+type Marker struct {
+	// Spec is the spec ID the marker refers to (e.g., "validation")
+	Spec string `json:"spec"`
+	// Requirement is the requirement name as written in the marker
+	Requirement string `json:"requirement"`
+	// File is the path of the source file, relative to the scanned root
+	File string `json:"file"`
+	// Line is the 1-based line number of the marker comment
+	Line int `json:"line"`
+	// Function is the name of the function following the marker, if any
+	Function string `json:"function,omitempty"`
+	// Test marks a test marker (spectr:req) rather than an implementation
+	// marker
+	Test bool `json:"test,omitempty"`
+}
+
+// Index maps spec requirements to the markers that implement and test
+// them. Requirement names are matched case-insensitively.
+type Index struct {
+	markers map[string][]Marker
+	tests   map[string][]Marker
+}
+
+// NewIndex creates an empty Index.
+func NewIndex() *Index {
+	return &Index{
+		markers: make(map[string][]Marker),
+		tests:   make(map[string][]Marker),
+	}
+}
+
+// Add records a marker in the index.
+func (idx *Index) Add(m Marker) {
+	key := indexKey(m.Spec, m.Requirement)
+	if m.Test {
+		idx.tests[key] = append(idx.tests[key], m)
+
+		return
+	}
+	idx.markers[key] = append(idx.markers[key], m)
+}
+
+// Lookup returns the markers implementing the given requirement of a spec.
+// Returns nil if the requirement has no markers.
+func (idx *Index) Lookup(specID, requirement string) []Marker {
+	if idx == nil {
+		return nil
+	}
+
+	return idx.markers[indexKey(specID, requirement)]
+}
+
+// LookupTests returns the test markers of the given requirement of a spec.
+// Returns nil if the requirement has no test markers.
+func (idx *Index) LookupTests(specID, requirement string) []Marker {
+	if idx == nil {
+		return nil
+	}
+
+	return idx.tests[indexKey(specID, requirement)]
+}
+
+// ForSpec returns all implementation markers that reference the given spec, sorted by
+// file and line.
+func (idx *Index) ForSpec(specID string) []Marker {
+	if idx == nil {
+		return nil
+	}
+
+	var result []Marker
+	for _, markers := range idx.markers {
+		for _, m := range markers {
+			if m.Spec == specID {
+				result = append(result, m)
+			}
+		}
+	}
+	sortMarkers(result)
+
+	return result
+}
+
+// Len returns the total number of implementation markers in the index.
+func (idx *Index) Len() int {
+	if idx == nil {
+		return 0
+	}
+
+	count := 0
+	for _, markers := range idx.markers {
+		count += len(markers)
+	}
+
+	return count
+}
+
+// indexKey builds the lookup key for a spec requirement.
+func indexKey(specID, requirement string) string {
+	return strings.TrimSpace(specID) + "#" +
+		parsers.NormalizeRequirementName(requirement)
+}
+
+// sortMarkers orders markers by file, then line.
+func sortMarkers(markers []Marker) {
+	sort.Slice(markers, func(i, j int) bool {
+		if markers[i].File != markers[j].File {
+			return markers[i].File < markers[j].File
+		}
+
+		return markers[i].Line < markers[j].Line
+	})
+}
@@ -0,0 +1,242 @@
+package implindex
+
+import (
+	"bufio"
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/discovery"
+)
+
+// maxScanFileSize bounds the size of files inspected for markers.
+// Larger files are almost always generated or vendored.
+const maxScanFileSize = 1 << 20
+
+// sourceExtensions lists the file extensions scanned for markers.
+var sourceExtensions = map[string]struct{}{
+	".go": {}, ".py": {}, ".js": {}, ".jsx": {}, ".ts": {}, ".tsx": {},
+	".rs": {}, ".java": {}, ".kt": {}, ".c": {}, ".h": {}, ".cc": {},
+	".cpp": {}, ".hpp": {}, ".cs": {}, ".rb": {}, ".php": {}, ".swift": {},
+	".scala": {}, ".sh": {}, ".lua": {}, ".sql": {}, ".zig": {}, ".nix": {},
+	".ex": {}, ".exs": {}, ".hs": {},
+}
+
+// functionPrefixes lists declaration keywords used to detect the function
+// that follows a marker comment.
+var functionPrefixes = []string{
+	"func ", "def ", "fn ", "pub fn ", "function ", "async function ",
+	"export function ", "export async function ",
+}
+
+// Scan walks root and returns an Index of all implementation markers found
+// in source files. The spectr/ directory and common dependency or build
+// directories are skipped. File paths in the index are relative to root.
+func Scan(root string) (*Index, error) {
+	return ScanContext(context.Background(), root)
+}
+
+// ScanContext is like Scan but stops with ctx.Err() once ctx is done.
+func ScanContext(ctx context.Context, root string) (*Index, error) {
+	idx := NewIndex()
+
+	err := filepath.WalkDir(
+		root,
+		func(path string, d fs.DirEntry, err error) error {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return ctxErr
+			}
+			if err != nil {
+				// Unreadable entries are skipped rather than aborting the scan
+				return nil
+			}
+
+			if d.IsDir() {
+				if path != root && skipDir(d.Name()) {
+					return filepath.SkipDir
+				}
+
+				return nil
+			}
+
+			if !isSourceFile(d) {
+				return nil
+			}
+
+			rel, relErr := filepath.Rel(root, path)
+			if relErr != nil {
+				rel = path
+			}
+
+			return scanFile(path, filepath.ToSlash(rel), idx)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return idx, nil
+}
+
+// skipDir reports whether a directory should not be descended into.
+func skipDir(name string) bool {
+	return name == "spectr" || discovery.ShouldSkipDirectory(name)
+}
+
+// isSourceFile reports whether a directory entry is a scannable source file.
+func isSourceFile(d fs.DirEntry) bool {
+	if _, ok := sourceExtensions[filepath.Ext(d.Name())]; !ok {
+		return false
+	}
+
+	info, err := d.Info()
+	if err != nil {
+		return false
+	}
+
+	return info.Size() <= maxScanFileSize
+}
+
+// scanFile adds every marker found in a single file to idx.
+func scanFile(path, relPath string, idx *Index) error {
+	file, err := os.Open(path)
+	if err != nil {
+		// Unreadable files are skipped rather than aborting the scan
+		return nil
+	}
+	defer func() { _ = file.Close() }()
+
+	var pending []Marker
+	lineNum := 0
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxScanFileSize)
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+
+		if spec, req, ok := ParseMarker(line); ok {
+			pending = append(pending, Marker{
+				Spec:        spec,
+				Requirement: req,
+				File:        relPath,
+				Line:        lineNum,
+			})
+
+			continue
+		}
+		if spec, req, ok := ParseTestMarker(line); ok {
+			pending = append(pending, Marker{
+				Spec:        spec,
+				Requirement: req,
+				File:        relPath,
+				Line:        lineNum,
+				Test:        true,
+			})
+
+			continue
+		}
+
+		// Keep pending markers attached through the rest of a comment block
+		if line == "" || isCommentLine(line) || len(pending) == 0 {
+			continue
+		}
+
+		name := functionName(line)
+		for _, m := range pending {
+			m.Function = name
+			idx.Add(m)
+		}
+		pending = nil
+	}
+
+	for _, m := range pending {
+		idx.Add(m)
+	}
+
+	return scanner.Err()
+}
+
+// ParseMarker extracts the spec ID and requirement name from a line that
+// contains a spectr:impl marker.
+//
+// Example:
+//
+//	spec, req, ok := ParseMarker("// spectr:impl validation#Strict Mode")
+//	// spec = "validation", req = "Strict Mode", ok = true
+func ParseMarker(line string) (spec, requirement string, ok bool) {
+	return parseMarker(line, MarkerKeyword)
+}
+
+// ParseTestMarker is like ParseMarker for a line that contains a
+// spectr:req test marker.
+func ParseTestMarker(line string) (spec, requirement string, ok bool) {
+	return parseMarker(line, TestMarkerKeyword)
+}
+
+// parseMarker extracts the spec ID and requirement name that follow
+// keyword in a comment line.
+func parseMarker(line, keyword string) (spec, requirement string, ok bool) {
+	if !isCommentLine(line) {
+		return "", "", false
+	}
+
+	pos := strings.Index(line, keyword)
+	if pos < 0 {
+		return "", "", false
+	}
+
+	rest := strings.TrimSpace(line[pos+len(keyword):])
+	rest = strings.TrimSpace(strings.TrimSuffix(rest, "*/"))
+
+	spec, requirement, found := strings.Cut(rest, "#")
+	spec = strings.TrimSpace(spec)
+	requirement = strings.TrimSpace(requirement)
+	if !found || spec == "" || requirement == "" ||
+		strings.ContainsAny(spec, " \t") {
+		return "", "", false
+	}
+
+	return spec, requirement, true
+}
+
+// isCommentLine reports whether a trimmed line starts with a comment prefix.
+func isCommentLine(line string) bool {
+	for _, prefix := range []string{"//", "#", "--", ";", "/*", "*"} {
+		if strings.HasPrefix(line, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// functionName extracts a function name from a declaration line, or returns
+// an empty string if the line does not declare a function.
+func functionName(line string) string {
+	for _, prefix := range functionPrefixes {
+		if !strings.HasPrefix(line, prefix) {
+			continue
+		}
+
+		rest := strings.TrimPrefix(line, prefix)
+
+		// Skip Go method receivers: func (r *Type) Name(
+		if strings.HasPrefix(rest, "(") {
+			if end := strings.Index(rest, ")"); end >= 0 {
+				rest = strings.TrimSpace(rest[end+1:])
+			}
+		}
+
+		end := strings.IndexAny(rest, "([< \t:")
+		if end <= 0 {
+			return ""
+		}
+
+		return rest[:end]
+	}
+
+	return ""
+}
@@ -0,0 +1,182 @@
+package implindex
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseMarker(t *testing.T) {
+	tests := []struct {
+		name     string
+		line     string
+		wantOK   bool
+		wantSpec string
+		wantReq  string
+	}{
+		{
+			name:     "go line comment",
+			line:     "// spectr:impl validation#Strict Mode",
+			wantOK:   true,
+			wantSpec: "validation",
+			wantReq:  "Strict Mode",
+		},
+		{
+			name:     "hash comment",
+			line:     "# spectr:impl cli-interface#List Command",
+			wantOK:   true,
+			wantSpec: "cli-interface",
+			wantReq:  "List Command",
+		},
+		{
+			name:     "block comment",
+			line:     "/* spectr:impl parser#Headers */",
+			wantOK:   true,
+			wantSpec: "parser",
+			wantReq:  "Headers",
+		},
+		{
+			name:   "missing requirement",
+			line:   "// spectr:impl validation",
+			wantOK: false,
+		},
+		{
+			name:   "not a comment",
+			line:   `s := "spectr:impl validation#Strict Mode"`,
+			wantOK: false,
+		},
+		{
+			name:   "spec with spaces",
+			line:   "// spectr:impl my spec#Req",
+			wantOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec, req, ok := ParseMarker(tt.line)
+			if ok != tt.wantOK {
+				t.Fatalf("ParseMarker() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if spec != tt.wantSpec || req != tt.wantReq {
+				t.Errorf(
+					"ParseMarker() = (%q, %q), want (%q, %q)",
+					spec, req, tt.wantSpec, tt.wantReq,
+				)
+			}
+		})
+	}
+}
+
+func TestScan(t *testing.T) {
+	root := t.TempDir()
+
+	writeFile(t, root, "internal/foo/foo.go", `package foo
+
+// spectr:impl validation#Strict Mode
+// Validate does things.
+func Validate() {}
+
+// spectr:impl validation#Report Format
+func (r *Reporter) Print(x int) {}
+`)
+	writeFile(t, root, "scripts/run.py", `# spectr:impl cli-interface#List Command
+def run_list(args):
+    pass
+`)
+	// Markers inside spectr/ and skipped directories are ignored
+	writeFile(t, root, "spectr/specs/x/helper.go", "// spectr:impl x#Y\n")
+	writeFile(t, root, "node_modules/dep/index.js", "// spectr:impl x#Y\n")
+	// Non-source files are ignored
+	writeFile(t, root, "README.md", "// spectr:impl x#Y\n")
+
+	idx, err := Scan(root)
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+
+	if idx.Len() != 3 {
+		t.Fatalf("Scan() found %d markers, want 3", idx.Len())
+	}
+
+	strict := idx.Lookup("validation", "strict mode")
+	if len(strict) != 1 {
+		t.Fatalf("Lookup(strict mode) = %d markers, want 1", len(strict))
+	}
+	if strict[0].File != "internal/foo/foo.go" || strict[0].Line != 3 {
+		t.Errorf("unexpected location %s:%d", strict[0].File, strict[0].Line)
+	}
+	if strict[0].Function != "Validate" {
+		t.Errorf("Function = %q, want Validate", strict[0].Function)
+	}
+
+	report := idx.Lookup("validation", "Report Format")
+	if len(report) != 1 || report[0].Function != "Print" {
+		t.Errorf("expected method Print, got %+v", report)
+	}
+
+	list := idx.Lookup("cli-interface", "List Command")
+	if len(list) != 1 || list[0].Function != "run_list" {
+		t.Errorf("expected python function run_list, got %+v", list)
+	}
+
+	if got := idx.ForSpec("validation"); len(got) != 2 {
+		t.Errorf("ForSpec(validation) = %d markers, want 2", len(got))
+	}
+
+	if got := idx.Lookup("x", "Y"); got != nil {
+		t.Errorf("expected skipped markers to be ignored, got %+v", got)
+	}
+}
+
+func writeFile(t *testing.T, root, rel, content string) {
+	t.Helper()
+
+	path := filepath.Join(root, rel)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestScanContext_Cancelled(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, "a.go", "package a\n")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := ScanContext(ctx, root); !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v, want context.Canceled", err)
+	}
+}
+
+func TestScan_TestMarkers(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, "auth/auth_test.go", `package auth
+
+// spectr:req auth#Login
+// spectr:impl auth#Helpers
+func TestLogin(t *testing.T) {}
+`)
+
+	idx, err := Scan(root)
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+
+	tests := idx.LookupTests("auth", "login")
+	if len(tests) != 1 || !tests[0].Test || tests[0].Function != "TestLogin" || tests[0].Line != 3 {
+		t.Errorf("LookupTests(login) = %+v", tests)
+	}
+	if got := idx.Lookup("auth", "Login"); got != nil {
+		t.Errorf("Lookup(Login) = %+v, want test markers kept apart", got)
+	}
+	if idx.Len() != 1 {
+		t.Errorf("Len() = %d, want 1 implementation marker", idx.Len())
+	}
+}
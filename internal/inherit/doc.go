@@ -0,0 +1,16 @@
+// Package inherit resolves spec inheritance.
+//
+// A spec names a base capability in its frontmatter:
+//
+//	---
+//	extends: base-auth
+//	---
+//
+// and then holds every requirement of base-auth without repeating them.
+// Inherited requirements are read-only in the child: changes modify them
+// in the base. A requirement in the child with the name of an inherited
+// one overrides it scenario by scenario. Scenarios with the same name
+// replace the base's, new ones are added, and a description, when given,
+// replaces the base's. Requirements with new names are the child's own.
+// Bases may extend other specs; cycles are reported.
+package inherit
@@ -0,0 +1,314 @@
+package inherit
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/domain"
+	"github.com/connerohnesorge/spectr/internal/markdown"
+	"github.com/connerohnesorge/spectr/internal/parsers"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+// Requirement is a requirement of a spec with inheritance resolved.
+type Requirement struct {
+	parsers.RequirementBlock
+	// Spec is the spec that defines the requirement: the base for
+	// inherited requirements, the spec itself otherwise.
+	Spec string
+	// Index is the 1-based position of the requirement in Spec's own
+	// spec.md, as used by scenario IDs.
+	Index int
+	// Inherited reports a requirement that comes from a base spec.
+	Inherited bool
+	// Overrides lists the scenarios the spec replaced or added in an
+	// inherited requirement.
+	Overrides []string
+	// Description reports an inherited requirement whose description the
+	// spec replaced.
+	Description bool
+}
+
+// Base returns the extends of the spec at specPath, or "" when the spec
+// does not extend another.
+func Base(specPath string) (string, error) {
+	meta, err := domain.ParseSpecFrontmatterFromFile(specPath)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.Trim(filepath.ToSlash(strings.TrimSpace(meta.Extends)), "/"), nil
+}
+
+// SpecsDir returns the spectr/specs directory that contains the spec at
+// specPath, or "" when specPath is not under one.
+func SpecsDir(specPath string) string {
+	dir := filepath.Dir(specPath)
+	for {
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		if filepath.Base(dir) == "specs" && filepath.Base(parent) == "spectr" {
+			return dir
+		}
+		dir = parent
+	}
+}
+
+// ResolveFile is Resolve for the spec at specPath. A spec outside a
+// spectr/specs directory resolves to its own requirements.
+func ResolveFile(specPath string) ([]Requirement, error) {
+	specsDir := SpecsDir(specPath)
+	if specsDir == "" {
+		return own(specPath, filepath.Base(filepath.Dir(specPath)))
+	}
+	rel, err := filepath.Rel(specsDir, filepath.Dir(specPath))
+	if err != nil {
+		return nil, err
+	}
+
+	return Resolve(specsDir, filepath.ToSlash(rel))
+}
+
+// Resolve returns the requirements of specID in specsDir: those of its
+// base first, with overrides applied, then its own.
+func Resolve(specsDir, specID string) ([]Requirement, error) {
+	return resolve(specsDir, specID, nil)
+}
+
+// resolve is Resolve for a spec reached through the specs in chain.
+func resolve(specsDir, specID string, chain []string) ([]Requirement, error) {
+	if slices.Contains(chain, specID) {
+		return nil, &specterrs.InheritanceCycleError{Chain: append(slices.Clone(chain), specID)}
+	}
+
+	specPath := filepath.Join(specsDir, filepath.FromSlash(specID), "spec.md")
+	if _, err := os.Stat(specPath); err != nil && len(chain) > 0 {
+		return nil, &specterrs.BaseSpecNotFoundError{Spec: chain[len(chain)-1], Base: specID}
+	}
+	base, err := Base(specPath)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s frontmatter: %w", specID, err)
+	}
+	reqs, err := own(specPath, specID)
+	if err != nil || base == "" {
+		return reqs, err
+	}
+
+	resolved, err := resolve(specsDir, base, append(chain, specID))
+	if err != nil {
+		return nil, err
+	}
+	for i := range resolved {
+		resolved[i].Inherited = true
+	}
+
+	for _, req := range reqs {
+		name := parsers.NormalizeRequirementName(req.Name)
+		i := slices.IndexFunc(resolved, func(r Requirement) bool {
+			return r.Inherited && parsers.NormalizeRequirementName(r.Name) == name
+		})
+		if i < 0 {
+			resolved = append(resolved, req)
+
+			continue
+		}
+		resolved[i] = override(resolved[i], req)
+	}
+
+	return resolved, nil
+}
+
+// own returns the requirements written in the spec at specPath.
+func own(specPath, specID string) ([]Requirement, error) {
+	blocks, err := parsers.ParseRequirements(specPath)
+	if err != nil {
+		return nil, err
+	}
+
+	reqs := make([]Requirement, 0, len(blocks))
+	for i, block := range blocks {
+		reqs = append(reqs, Requirement{RequirementBlock: block, Spec: specID, Index: i + 1})
+	}
+
+	return reqs, nil
+}
+
+// override applies the child requirement to the inherited one.
+func override(inherited, child Requirement) Requirement {
+	baseHead, baseScenarios := split(inherited.Raw)
+	childHead, childScenarios := split(child.Raw)
+
+	merged := inherited
+	merged.Overrides = slices.Clone(inherited.Overrides)
+	parts := []string{baseHead}
+	if hasDescription(childHead) {
+		parts[0] = childHead
+		merged.Description = true
+	}
+
+	used := make([]bool, len(childScenarios))
+	for _, s := range baseScenarios {
+		j := slices.IndexFunc(childScenarios, func(c scenario) bool {
+			return strings.EqualFold(c.name, s.name)
+		})
+		if j < 0 {
+			parts = append(parts, s.raw)
+
+			continue
+		}
+		parts = append(parts, childScenarios[j].raw)
+		used[j] = true
+		merged.Overrides = append(merged.Overrides, childScenarios[j].name)
+	}
+	for j, c := range childScenarios {
+		if !used[j] {
+			parts = append(parts, c.raw)
+			merged.Overrides = append(merged.Overrides, c.name)
+		}
+	}
+	merged.Raw = strings.Join(parts, "\n\n") + "\n"
+
+	return merged
+}
+
+// scenario is a scenario block of a requirement.
+type scenario struct {
+	name string
+	raw  string
+}
+
+// split returns the header and description of a requirement and its
+// scenario blocks, each without trailing blank lines.
+func split(raw string) (string, []scenario) {
+	var (
+		head      strings.Builder
+		scenarios []scenario
+		current   *strings.Builder
+	)
+	scanner := bufio.NewScanner(strings.NewReader(raw))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if name, ok := markdown.MatchScenarioHeader(strings.TrimSpace(line)); ok {
+			scenarios = append(scenarios, scenario{name: strings.TrimSpace(name)})
+			current = &strings.Builder{}
+		}
+		if current == nil {
+			head.WriteString(line + "\n")
+
+			continue
+		}
+		current.WriteString(line + "\n")
+		scenarios[len(scenarios)-1].raw = strings.TrimRight(current.String(), "\n")
+	}
+
+	return strings.TrimRight(head.String(), "\n"), scenarios
+}
+
+// hasDescription reports whether a requirement head has text below its
+// header.
+func hasDescription(head string) bool {
+	_, rest, _ := strings.Cut(head, "\n")
+
+	return strings.TrimSpace(rest) != ""
+}
+
+// Render returns the spec.md content with the Requirements section
+// holding every resolved requirement, inherited ones marked read-only.
+// Content without a Requirements section gets one appended.
+func Render(content string, reqs []Requirement) string {
+	lines := strings.Split(content, "\n")
+	start := slices.IndexFunc(lines, func(line string) bool {
+		return markdown.IsH2Header(line) && strings.TrimSpace(strings.TrimLeft(line, "#")) == "Requirements"
+	})
+
+	var before, intro, after []string
+	if start < 0 {
+		before = append(slices.Clone(lines), "## Requirements")
+	} else {
+		before = lines[:start+1]
+		end := len(lines)
+		for i := start + 1; i < len(lines); i++ {
+			if markdown.IsH2Header(lines[i]) {
+				end = i
+
+				break
+			}
+		}
+		for _, line := range lines[start+1 : end] {
+			if _, ok := markdown.MatchRequirementHeader(line); ok {
+				break
+			}
+			intro = append(intro, line)
+		}
+		after = lines[end:]
+	}
+
+	var sb strings.Builder
+	sb.WriteString(strings.TrimRight(strings.Join(before, "\n"), "\n") + "\n")
+	if text := strings.TrimSpace(strings.Join(intro, "\n")); text != "" {
+		sb.WriteString("\n" + text + "\n")
+	}
+	for _, req := range reqs {
+		sb.WriteString("\n" + renderRequirement(req) + "\n")
+	}
+	if len(after) > 0 {
+		sb.WriteString("\n" + strings.Join(after, "\n"))
+	}
+
+	return sb.String()
+}
+
+// renderRequirement returns the requirement block with a note under the
+// header of an inherited requirement.
+func renderRequirement(req Requirement) string {
+	raw := strings.TrimRight(req.Raw, "\n")
+	if !req.Inherited {
+		return raw
+	}
+
+	note := fmt.Sprintf("_Inherited from `%s` (read-only)._", req.Spec)
+	if len(req.Overrides) > 0 {
+		note = fmt.Sprintf(
+			"_Inherited from `%s`; overrides scenarios: %s._",
+			req.Spec,
+			strings.Join(req.Overrides, ", "),
+		)
+	}
+	header, body, _ := strings.Cut(raw, "\n")
+
+	return header + "\n\n" + note + "\n\n" + strings.TrimLeft(body, "\n")
+}
+
+// InheritedFrom returns the base spec an inherited requirement named name
+// comes from when the spec at specPath does not override it, so the
+// requirement is not in the spec's own file.
+func InheritedFrom(specPath, name string) (string, bool) {
+	reqs, err := ResolveFile(specPath)
+	if err != nil {
+		return "", false
+	}
+	blocks, err := parsers.ParseRequirements(specPath)
+	if err != nil {
+		return "", false
+	}
+
+	name = parsers.NormalizeRequirementName(name)
+	for _, block := range blocks {
+		if parsers.NormalizeRequirementName(block.Name) == name {
+			return "", false
+		}
+	}
+	for _, req := range reqs {
+		if req.Inherited && parsers.NormalizeRequirementName(req.Name) == name {
+			return req.Spec, true
+		}
+	}
+
+	return "", false
+}
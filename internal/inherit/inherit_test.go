@@ -0,0 +1,219 @@
+package inherit
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+const baseSpec = `# Base Auth
+
+## Purpose
+Shared authentication.
+
+## Requirements
+
+### Requirement: Login
+The system SHALL authenticate users.
+
+#### Scenario: Valid password
+- **WHEN** a user enters a valid password
+- **THEN** they are signed in
+
+#### Scenario: Lockout
+- **WHEN** a user fails five times
+- **THEN** the account is locked
+
+### Requirement: Logout
+The system SHALL end sessions.
+
+#### Scenario: Sign out
+- **WHEN** a user signs out
+- **THEN** the session ends
+`
+
+const childSpec = `---
+extends: base-auth
+---
+# Kiosk Auth
+
+## Purpose
+Authentication for kiosks.
+
+## Requirements
+
+### Requirement: Login
+
+#### Scenario: Lockout
+- **WHEN** a user fails three times
+- **THEN** the kiosk is locked
+
+#### Scenario: Badge
+- **WHEN** a user taps a badge
+- **THEN** they are signed in
+
+### Requirement: Idle Timeout
+The system SHALL sign users out after a minute.
+
+#### Scenario: Idle
+- **WHEN** a minute passes without input
+- **THEN** the session ends
+`
+
+// writeSpecs writes spec.md files under a spectr/specs directory and
+// returns it.
+func writeSpecs(t *testing.T, specs map[string]string) string {
+	t.Helper()
+
+	specsDir := filepath.Join(t.TempDir(), "spectr", "specs")
+	for id, content := range specs {
+		path := filepath.Join(specsDir, id, "spec.md")
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return specsDir
+}
+
+func TestResolve(t *testing.T) {
+	specsDir := writeSpecs(t, map[string]string{"base-auth": baseSpec, "kiosk-auth": childSpec})
+
+	reqs, err := Resolve(specsDir, "kiosk-auth")
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+
+	var names []string
+	for _, req := range reqs {
+		names = append(names, req.Name)
+	}
+	if want := []string{"Login", "Logout", "Idle Timeout"}; !slices.Equal(names, want) {
+		t.Fatalf("Resolve() names = %v, want %v", names, want)
+	}
+
+	login := reqs[0]
+	if !login.Inherited || login.Spec != "base-auth" || login.Index != 1 || login.Description {
+		t.Errorf("Login = %+v", login)
+	}
+	if want := []string{"Lockout", "Badge"}; !slices.Equal(login.Overrides, want) {
+		t.Errorf("Login overrides = %v, want %v", login.Overrides, want)
+	}
+	for _, want := range []string{"SHALL authenticate", "valid password", "fails three times", "taps a badge"} {
+		if !strings.Contains(login.Raw, want) {
+			t.Errorf("Login missing %q:\n%s", want, login.Raw)
+		}
+	}
+	if strings.Contains(login.Raw, "fails five times") {
+		t.Errorf("Login kept the overridden scenario:\n%s", login.Raw)
+	}
+
+	if !reqs[1].Inherited || len(reqs[1].Overrides) != 0 {
+		t.Errorf("Logout = %+v", reqs[1])
+	}
+	if reqs[2].Inherited || reqs[2].Spec != "kiosk-auth" || reqs[2].Index != 2 {
+		t.Errorf("Idle Timeout = %+v", reqs[2])
+	}
+}
+
+func TestResolve_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		specs map[string]string
+		check func(error) bool
+	}{
+		{
+			name:  "missing base",
+			specs: map[string]string{"kiosk-auth": childSpec},
+			check: func(err error) bool {
+				var target *specterrs.BaseSpecNotFoundError
+				return errors.As(err, &target) && target.Base == "base-auth"
+			},
+		},
+		{
+			name: "cycle",
+			specs: map[string]string{
+				"base-auth":  "---\nextends: kiosk-auth\n---\n" + baseSpec,
+				"kiosk-auth": childSpec,
+			},
+			check: func(err error) bool {
+				var target *specterrs.InheritanceCycleError
+				return errors.As(err, &target) && len(target.Chain) == 3
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Resolve(writeSpecs(t, tt.specs), "kiosk-auth")
+			if !tt.check(err) {
+				t.Errorf("Resolve() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestResolve_Chain(t *testing.T) {
+	specsDir := writeSpecs(t, map[string]string{
+		"base-auth":  baseSpec,
+		"kiosk-auth": childSpec,
+		"lobby-auth": "---\nextends: kiosk-auth\n---\n# Lobby\n\n## Requirements\n",
+	})
+
+	reqs, err := Resolve(specsDir, "lobby-auth")
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if len(reqs) != 3 || !reqs[2].Inherited || reqs[2].Spec != "kiosk-auth" {
+		t.Errorf("Resolve() = %+v", reqs)
+	}
+}
+
+func TestRender(t *testing.T) {
+	specsDir := writeSpecs(t, map[string]string{"base-auth": baseSpec, "kiosk-auth": childSpec})
+	reqs, err := Resolve(specsDir, "kiosk-auth")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := Render(childSpec, reqs)
+	for _, want := range []string{
+		"## Purpose\nAuthentication for kiosks.",
+		"### Requirement: Login\n\n_Inherited from `base-auth`; overrides scenarios: Lockout, Badge._",
+		"### Requirement: Logout\n\n_Inherited from `base-auth` (read-only)._",
+		"### Requirement: Idle Timeout\nThe system SHALL",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Render() missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Count(got, "### Requirement: Login") != 1 {
+		t.Errorf("Render() repeats Login:\n%s", got)
+	}
+}
+
+func TestInheritedFrom(t *testing.T) {
+	specsDir := writeSpecs(t, map[string]string{"base-auth": baseSpec, "kiosk-auth": childSpec})
+	specPath := filepath.Join(specsDir, "kiosk-auth", "spec.md")
+
+	if SpecsDir(specPath) != specsDir {
+		t.Errorf("SpecsDir() = %q, want %q", SpecsDir(specPath), specsDir)
+	}
+	if from, ok := InheritedFrom(specPath, "logout"); !ok || from != "base-auth" {
+		t.Errorf("InheritedFrom(Logout) = %q, %v", from, ok)
+	}
+	// Overridden and own requirements are in the spec's file
+	for _, name := range []string{"Login", "Idle Timeout", "Missing"} {
+		if _, ok := InheritedFrom(specPath, name); ok {
+			t.Errorf("InheritedFrom(%s) = true", name)
+		}
+	}
+}
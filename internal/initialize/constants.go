@@ -4,6 +4,9 @@ const (
 	// File and directory permission constants
 	filePerm = 0o644
 
+	// configFile is the project configuration file created by init
+	configFile = "spectr.yaml"
+
 	// UI control keys
 	keyQuit  = "q"
 	keyEnter = "enter"
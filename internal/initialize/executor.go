@@ -10,11 +10,13 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 
 	"github.com/spf13/afero"
 
 	"github.com/connerohnesorge/spectr/internal/domain"
 	"github.com/connerohnesorge/spectr/internal/initialize/providers"
+	"github.com/connerohnesorge/spectr/internal/version"
 )
 
 // InitExecutor handles the actual initialization process using the new provider architecture
@@ -128,7 +130,18 @@ func (e *InitExecutor) Execute(
 		)
 	}
 
-	// 5. Create AGENTS.md
+	// 5. Create spectr.yaml stamped with the spectr version
+	if err := createConfigFile(projectFs, result); err != nil {
+		result.Errors = append(
+			result.Errors,
+			fmt.Sprintf(
+				"failed to create spectr.yaml: %v",
+				err,
+			),
+		)
+	}
+
+	// 6. Create AGENTS.md
 	err = e.createAgentsMd(
 		projectFs,
 		spectrDir,
@@ -144,7 +157,7 @@ func (e *InitExecutor) Execute(
 		)
 	}
 
-	// 6. Configure selected providers using new architecture (Tasks 8.3-8.10)
+	// 7. Configure selected providers using new architecture (Tasks 8.3-8.10)
 	providerResult, err := e.configureProviders(
 		selectedProviderIDs,
 		projectFs,
@@ -167,7 +180,7 @@ func (e *InitExecutor) Execute(
 		)
 	}
 
-	// 7. Create CI workflow if enabled
+	// 8. Create CI workflow if enabled
 	if ciWorkflowEnabled {
 		err = e.createCIWorkflow(
 			projectFs,
@@ -302,6 +315,48 @@ func (e *InitExecutor) createProjectMd(
 	return nil
 }
 
+// createConfigFile creates spectr.yaml recording the spectr version that
+// created the project and the oldest version that can read it, so older
+// releases refuse the project instead of misreading it. An existing
+// spectr.yaml is left alone.
+func createConfigFile(
+	projectFs afero.Fs,
+	result *ExecutionResult,
+) error {
+	exists, err := afero.Exists(projectFs, configFile)
+	if err != nil {
+		return fmt.Errorf(
+			"failed to check spectr.yaml: %w",
+			err,
+		)
+	}
+	if exists {
+		return nil
+	}
+
+	var content strings.Builder
+	content.WriteString("# Spectr project configuration\n")
+	// Local builds have no release version to record
+	if version.IsRelease(version.Version) {
+		fmt.Fprintf(&content, "spectr_version: %s\n", version.Version)
+	}
+	fmt.Fprintf(&content, "min_spectr_version: %s\n", version.MinProjectVersion)
+
+	if err := afero.WriteFile(projectFs, configFile, []byte(content.String()), filePerm); err != nil {
+		return fmt.Errorf(
+			"failed to write spectr.yaml: %w",
+			err,
+		)
+	}
+
+	result.CreatedFiles = append(
+		result.CreatedFiles,
+		configFile,
+	)
+
+	return nil
+}
+
 // createAgentsMd creates the AGENTS.md file
 func (e *InitExecutor) createAgentsMd(
 	projectFs afero.Fs,
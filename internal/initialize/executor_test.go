@@ -8,6 +8,7 @@ import (
 	"github.com/spf13/afero"
 
 	"github.com/connerohnesorge/spectr/internal/initialize/providers"
+	"github.com/connerohnesorge/spectr/internal/version"
 )
 
 // TestExecutorIntegration_FullInitializationFlow tests the full initialization flow
@@ -981,3 +982,69 @@ func TestAggregateResultsDeduplication(
 		})
 	}
 }
+
+func TestCreateConfigFile(t *testing.T) {
+	saved := version.Version
+	t.Cleanup(func() { version.Version = saved })
+
+	tests := []struct {
+		name    string
+		version string
+		want    string
+	}{
+		{
+			name:    "release",
+			version: "v1.2.3",
+			want: "# Spectr project configuration\n" +
+				"spectr_version: v1.2.3\n" +
+				"min_spectr_version: " + version.MinProjectVersion + "\n",
+		},
+		{
+			name:    "local build",
+			version: "dev",
+			want: "# Spectr project configuration\n" +
+				"min_spectr_version: " + version.MinProjectVersion + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version.Version = tt.version
+			projectFs := afero.NewMemMapFs()
+			result := &ExecutionResult{}
+
+			if err := createConfigFile(projectFs, result); err != nil {
+				t.Fatal(err)
+			}
+
+			content, err := afero.ReadFile(projectFs, "spectr.yaml")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(content) != tt.want {
+				t.Errorf("spectr.yaml = %q, want %q", content, tt.want)
+			}
+			if len(result.CreatedFiles) != 1 {
+				t.Errorf("CreatedFiles = %v, want spectr.yaml", result.CreatedFiles)
+			}
+		})
+	}
+}
+
+func TestCreateConfigFile_KeepsExisting(t *testing.T) {
+	projectFs := afero.NewMemMapFs()
+	existing := []byte("git:\n  backend: exec\n")
+	if err := afero.WriteFile(projectFs, "spectr.yaml", existing, filePerm); err != nil {
+		t.Fatal(err)
+	}
+	result := &ExecutionResult{}
+
+	if err := createConfigFile(projectFs, result); err != nil {
+		t.Fatal(err)
+	}
+
+	content, _ := afero.ReadFile(projectFs, "spectr.yaml")
+	if string(content) != string(existing) || len(result.CreatedFiles) != 0 {
+		t.Errorf("existing spectr.yaml was modified: %q", content)
+	}
+}
@@ -15,8 +15,8 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/connerohnesorge/spectr/internal/clipboard"
 	"github.com/connerohnesorge/spectr/internal/initialize/providers"
-	"github.com/connerohnesorge/spectr/internal/tui"
 	"github.com/spf13/afero"
 )
 
@@ -54,6 +54,9 @@ type WizardModel struct {
 	searchQuery       string                   // current search query
 	searchInput       textinput.Model          // text input for search
 	filteredProviders []providers.Registration // providers matching search query
+	// Clipboard state
+	copied     bool             // whether the populate context prompt was copied
+	copyMethod clipboard.Method // how the prompt was copied
 }
 
 // ExecutionResult holds the result of initialization
@@ -464,11 +467,10 @@ func (m *WizardModel) handleCompleteKeys(
 	case keyCopy:
 		// Only allow copy on success screen (no init error)
 		if m.err == nil {
-			// Copy the populate context prompt to clipboard
-			// CopyToClipboard uses OSC 52 fallback, so it never fails
-			_ = tui.CopyToClipboard(
-				PopulateContextPrompt,
-			)
+			// Copy the populate context prompt to clipboard; the result
+			// is reported by the caller once the alt screen is gone
+			m.copied = true
+			m.copyMethod = clipboard.Copy(PopulateContextPrompt)
 
 			return m, tea.Quit
 		}
@@ -987,6 +989,16 @@ func (m *WizardModel) GetError() error {
 	return m.err
 }
 
+// GetCopyMessage returns the message reporting how the populate context
+// prompt was copied, or "" if it was not copied.
+func (m *WizardModel) GetCopyMessage() string {
+	if !m.copied {
+		return ""
+	}
+
+	return m.copyMethod.Message("populate context prompt", PopulateContextPrompt)
+}
+
 // ASCII art for Spectr branding
 const asciiArt = `
 ███████ ██████  ███████  ██████ ███████ ████████
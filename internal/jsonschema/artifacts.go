@@ -0,0 +1,156 @@
+package jsonschema
+
+import (
+	"embed"
+	"fmt"
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+//go:embed schemas/*.schema.json
+var schemaFS embed.FS
+
+// BaseURL is where the documentation site publishes the schemas.
+const BaseURL = "https://connerohnesorge.github.io/spectr/schemas/"
+
+// Artifact is a spectr data file with a published JSON Schema.
+type Artifact struct {
+	// Name is the short name used by 'spectr schema print', e.g. "tasks".
+	Name string
+	// FileName is the file the schema describes, e.g. "tasks.jsonc".
+	FileName string
+	// Description is shown by 'spectr schema list'.
+	Description string
+	// Versions lists the format versions with a schema, oldest first.
+	// Unversioned artifacts have a single version 0.
+	Versions []int
+}
+
+// artifacts is the registry of files with schemas. approvals.jsonc and
+// releases.jsonc will be added here when spectr starts writing them.
+var artifacts = []Artifact{
+	{
+		Name:        "tasks",
+		FileName:    "tasks.jsonc",
+		Description: "Change task list written by spectr accept",
+		Versions:    []int{1, 2},
+	},
+	{
+		Name:        "config",
+		FileName:    "spectr.yaml",
+		Description: "Project configuration",
+		Versions:    []int{0},
+	},
+}
+
+// Artifacts returns every artifact with a schema.
+func Artifacts() []Artifact {
+	return append([]Artifact(nil), artifacts...)
+}
+
+// Lookup finds an artifact by name or file name.
+func Lookup(name string) (Artifact, error) {
+	for _, a := range artifacts {
+		if strings.EqualFold(name, a.Name) || strings.EqualFold(name, a.FileName) {
+			return a, nil
+		}
+	}
+
+	names := make([]string, len(artifacts))
+	for i, a := range artifacts {
+		names[i] = a.Name
+	}
+
+	return Artifact{}, &specterrs.UnknownSchemaArtifactError{
+		Artifact:  name,
+		Available: names,
+	}
+}
+
+// MustLookup is Lookup for names known to be registered.
+func MustLookup(name string) Artifact {
+	a, err := Lookup(name)
+	if err != nil {
+		panic(err)
+	}
+
+	return a
+}
+
+// Latest returns the newest schema version of the artifact.
+func (a Artifact) Latest() int {
+	return a.Versions[len(a.Versions)-1]
+}
+
+// SchemaFile returns the published file name of the schema for version,
+// e.g. "tasks-v2.schema.json" or "config.schema.json".
+func (a Artifact) SchemaFile(version int) string {
+	if version == 0 {
+		return a.Name + ".schema.json"
+	}
+
+	return fmt.Sprintf("%s-v%d.schema.json", a.Name, version)
+}
+
+// URL returns the published URL of the schema for version. Version 0 on
+// a versioned artifact selects the latest version.
+func (a Artifact) URL(version int) string {
+	if version == 0 {
+		version = a.Latest()
+	}
+
+	return BaseURL + a.SchemaFile(version)
+}
+
+// Schema returns the raw schema document for version. Version 0 on a
+// versioned artifact selects the latest version.
+func (a Artifact) Schema(version int) ([]byte, error) {
+	if version == 0 {
+		version = a.Latest()
+	}
+	if !a.hasVersion(version) {
+		return nil, &specterrs.UnknownSchemaVersionError{
+			Artifact: a.Name,
+			Version:  version,
+		}
+	}
+
+	return schemaFS.ReadFile("schemas/" + a.SchemaFile(version))
+}
+
+// Validate checks doc against the schema for version. Problems are
+// returned as *specterrs.SchemaError.
+func (a Artifact) Validate(path string, doc *Value, version int) error {
+	if version == 0 {
+		version = a.Latest()
+	}
+	data, err := a.Schema(version)
+	if err != nil {
+		return err
+	}
+	schema, err := ParseJSONC(a.SchemaFile(version), data)
+	if err != nil {
+		return err
+	}
+
+	if problems := Validate(schema, doc); len(problems) > 0 {
+		return &specterrs.SchemaError{
+			Path:     path,
+			Schema:   a.SchemaFile(version),
+			Problems: problems,
+		}
+	}
+
+	return nil
+}
+
+func (a Artifact) hasVersion(version int) bool {
+	for _, v := range a.Versions {
+		if v == version {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,164 @@
+package jsonschema
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/connerohnesorge/spectr/internal/config"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+func TestLookup(t *testing.T) {
+	for _, name := range []string{"tasks", "tasks.jsonc", "config", "spectr.yaml", "CONFIG"} {
+		if _, err := Lookup(name); err != nil {
+			t.Errorf("Lookup(%q): %v", name, err)
+		}
+	}
+
+	_, err := Lookup("approvals")
+	var unknownErr *specterrs.UnknownSchemaArtifactError
+	if !errors.As(err, &unknownErr) {
+		t.Fatalf("error = %v, want UnknownSchemaArtifactError", err)
+	}
+	if !strings.Contains(err.Error(), "tasks, config") {
+		t.Errorf("error = %q does not list artifacts", err)
+	}
+}
+
+func TestArtifactSchema(t *testing.T) {
+	tasks := MustLookup("tasks")
+
+	latest, err := tasks.Schema(0)
+	if err != nil {
+		t.Fatalf("Schema(0): %v", err)
+	}
+	if !bytes.Contains(latest, []byte("tasks-v2.schema.json")) {
+		t.Error("Schema(0) is not the latest version")
+	}
+
+	if got := tasks.URL(0); got != BaseURL+"tasks-v2.schema.json" {
+		t.Errorf("URL(0) = %q", got)
+	}
+	// The published $id must match the URL editors are pointed at.
+	if !bytes.Contains(latest, []byte(`"$id": "`+tasks.URL(0)+`"`)) {
+		t.Error("schema $id does not match URL")
+	}
+
+	_, err = tasks.Schema(9)
+	var versionErr *specterrs.UnknownSchemaVersionError
+	if !errors.As(err, &versionErr) {
+		t.Errorf("error = %v, want UnknownSchemaVersionError", err)
+	}
+}
+
+func TestConfigSchema(t *testing.T) {
+	cfg := MustLookup("config")
+
+	tests := []struct {
+		name    string
+		content string
+		want    string // Empty means valid
+	}{
+		{name: "empty", content: ""},
+		{
+			name:    "full",
+			content: "append_tasks:\n  section: Ops\n  tasks: [lint]\nrefs_always_prepend:\n  tasks: [read]\nrefs_always_append:\nGit:\n",
+			want:    "line 7, col 1: Git: is not allowed",
+		},
+		{name: "backend", content: "git:\n  backend: go-git\n"},
+		{
+			name:    "bad backend",
+			content: "git:\n  backend: libgit2\n",
+			want:    `line 2, col 12: git.backend: must be one of "exec", "go-git"`,
+		},
+		{
+			name:    "tasks not a list",
+			content: "append_tasks:\n  tasks: lint\n",
+			want:    "line 2, col 10: append_tasks.tasks: must be an array",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := ParseYAML("spectr.yaml", []byte(tt.content))
+			if err != nil {
+				t.Fatalf("ParseYAML: %v", err)
+			}
+
+			err = cfg.Validate("spectr.yaml", doc, 0)
+			if tt.want == "" {
+				if err != nil {
+					t.Errorf("Validate: %v", err)
+				}
+
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+// TestConfigSchemaMatchesStruct keeps the schema in step with the yaml
+// tags of config.Config.
+func TestConfigSchemaMatchesStruct(t *testing.T) {
+	data, err := MustLookup("config").Schema(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	schema := mustParse(t, string(data))
+
+	var want []string
+	for _, f := range reflect.VisibleFields(reflect.TypeFor[config.Config]()) {
+		if !f.IsExported() {
+			continue
+		}
+		want = append(want, strings.Split(f.Tag.Get("yaml"), ",")[0])
+	}
+	var got []string
+	for _, m := range schema.Get("properties").Members {
+		got = append(got, m.Key)
+	}
+	sort.Strings(want)
+	sort.Strings(got)
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("config schema properties = %v, config.Config fields = %v", got, want)
+	}
+}
+
+// TestPublishedSchemas checks the copies served by the documentation site
+// match the embedded schemas.
+func TestPublishedSchemas(t *testing.T) {
+	for _, a := range Artifacts() {
+		for _, version := range a.Versions {
+			embedded, err := a.Schema(version)
+			if err != nil {
+				t.Fatalf("%s Schema(%d): %v", a.Name, version, err)
+			}
+			if _, err := ParseJSONC(a.SchemaFile(version), embedded); err != nil {
+				t.Errorf("schema does not parse: %v", err)
+			}
+
+			published, err := os.ReadFile(filepath.Join(
+				"..", "..", "docs", "public", "schemas", a.SchemaFile(version),
+			))
+			if err != nil {
+				t.Fatalf("read published schema: %v", err)
+			}
+			if !bytes.Equal(embedded, published) {
+				t.Errorf(
+					"docs/public/schemas/%s is out of date; copy it from internal/jsonschema/schemas",
+					a.SchemaFile(version),
+				)
+			}
+		}
+	}
+}
@@ -0,0 +1,13 @@
+// Package jsonschema embeds the JSON Schemas for spectr's data files and
+// validates documents against them.
+//
+// Documents are parsed into a Value tree that remembers the line and
+// column of every node, so schema problems in a JSONC or YAML file can be
+// reported at the position where they occur. The schemas are also
+// published with the documentation site under /schemas/ and printed by
+// 'spectr schema print' for editor completion.
+//
+// Validation implements the subset of JSON Schema the embedded schemas
+// use: type, const, enum, required, properties, additionalProperties,
+// items, minLength, minimum, pattern and local $ref.
+package jsonschema
@@ -0,0 +1,302 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+// ParseJSONC parses a JSON document that may contain // and /* */
+// comments. Syntax errors are returned as *specterrs.SyntaxError with the
+// line and column of the offending character.
+func ParseJSONC(path string, data []byte) (*Value, error) {
+	p := &jsoncParser{path: path, data: data, line: 1, col: 1}
+
+	p.skipSpace()
+	value, err := p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipSpace()
+	if p.pos < len(p.data) {
+		return nil, p.errorf("unexpected %s after document", p.describe())
+	}
+
+	return value, nil
+}
+
+type jsoncParser struct {
+	path string
+	data []byte
+	pos  int
+	line int
+	col  int
+}
+
+func (p *jsoncParser) parseValue() (*Value, error) {
+	if p.pos >= len(p.data) {
+		return nil, p.errorf("unexpected end of input")
+	}
+
+	line, col := p.line, p.col
+	var (
+		value *Value
+		err   error
+	)
+
+	switch c := p.data[p.pos]; {
+	case c == '{':
+		value, err = p.parseObject()
+	case c == '[':
+		value, err = p.parseArray()
+	case c == '"':
+		var s string
+		s, err = p.parseString()
+		value = &Value{Kind: KindString, String: s}
+	case c == '-' || (c >= '0' && c <= '9'):
+		value, err = p.parseNumber()
+	case p.consumeWord("true"):
+		value = &Value{Kind: KindBool, Bool: true}
+	case p.consumeWord("false"):
+		value = &Value{Kind: KindBool}
+	case p.consumeWord("null"):
+		value = &Value{Kind: KindNull}
+	default:
+		return nil, p.errorf("unexpected %s", p.describe())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	value.Line, value.Column = line, col
+
+	return value, nil
+}
+
+func (p *jsoncParser) parseObject() (*Value, error) {
+	obj := &Value{Kind: KindObject}
+	p.advance(1)
+	p.skipSpace()
+	if p.peek() == '}' {
+		p.advance(1)
+
+		return obj, nil
+	}
+
+	for {
+		if p.peek() != '"' {
+			return nil, p.errorf("expected object key, found %s", p.describe())
+		}
+		line, col := p.line, p.col
+		key, err := p.parseString()
+		if err != nil {
+			return nil, err
+		}
+		if _, dup := obj.member(key); dup {
+			return nil, &specterrs.SyntaxError{
+				Path:    p.path,
+				Line:    line,
+				Column:  col,
+				Message: fmt.Sprintf("duplicate key %q", key),
+			}
+		}
+
+		p.skipSpace()
+		if p.peek() != ':' {
+			return nil, p.errorf("expected ':' after object key, found %s", p.describe())
+		}
+		p.advance(1)
+		p.skipSpace()
+
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		obj.Members = append(obj.Members, Member{
+			Key:    key,
+			Value:  value,
+			Line:   line,
+			Column: col,
+		})
+
+		p.skipSpace()
+		switch p.peek() {
+		case ',':
+			p.advance(1)
+			p.skipSpace()
+		case '}':
+			p.advance(1)
+
+			return obj, nil
+		default:
+			return nil, p.errorf("expected ',' or '}', found %s", p.describe())
+		}
+	}
+}
+
+func (p *jsoncParser) parseArray() (*Value, error) {
+	arr := &Value{Kind: KindArray}
+	p.advance(1)
+	p.skipSpace()
+	if p.peek() == ']' {
+		p.advance(1)
+
+		return arr, nil
+	}
+
+	for {
+		item, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		arr.Items = append(arr.Items, item)
+
+		p.skipSpace()
+		switch p.peek() {
+		case ',':
+			p.advance(1)
+			p.skipSpace()
+		case ']':
+			p.advance(1)
+
+			return arr, nil
+		default:
+			return nil, p.errorf("expected ',' or ']', found %s", p.describe())
+		}
+	}
+}
+
+// parseString scans a string literal and decodes its escapes with
+// encoding/json.
+func (p *jsoncParser) parseString() (string, error) {
+	line, col := p.line, p.col
+	end := p.pos + 1
+	for end < len(p.data) {
+		switch p.data[end] {
+		case '\\':
+			end += 2
+
+			continue
+		case '\n':
+			return "", p.errorAt(line, col, "unterminated string")
+		case '"':
+			var s string
+			if err := json.Unmarshal(p.data[p.pos:end+1], &s); err != nil {
+				return "", p.errorAt(line, col, "invalid string: "+err.Error())
+			}
+			p.advance(end + 1 - p.pos)
+
+			return s, nil
+		}
+		end++
+	}
+
+	return "", p.errorAt(line, col, "unterminated string")
+}
+
+func (p *jsoncParser) parseNumber() (*Value, error) {
+	line, col := p.line, p.col
+	end := p.pos
+	for end < len(p.data) && isNumberByte(p.data[end]) {
+		end++
+	}
+
+	literal := string(p.data[p.pos:end])
+	if !json.Valid([]byte(literal)) {
+		return nil, p.errorAt(line, col, fmt.Sprintf("invalid number %q", literal))
+	}
+	p.advance(end - p.pos)
+
+	return &Value{Kind: KindNumber, Number: json.Number(literal)}, nil
+}
+
+func isNumberByte(c byte) bool {
+	return c == '-' || c == '+' || c == '.' || c == 'e' || c == 'E' ||
+		(c >= '0' && c <= '9')
+}
+
+// consumeWord advances past word if the input continues with it.
+func (p *jsoncParser) consumeWord(word string) bool {
+	if len(p.data)-p.pos < len(word) ||
+		string(p.data[p.pos:p.pos+len(word)]) != word {
+		return false
+	}
+	p.advance(len(word))
+
+	return true
+}
+
+// skipSpace skips whitespace and comments.
+func (p *jsoncParser) skipSpace() {
+	for p.pos < len(p.data) {
+		switch c := p.data[p.pos]; {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			p.advance(1)
+		case c == '/' && p.pos+1 < len(p.data) && p.data[p.pos+1] == '/':
+			for p.pos < len(p.data) && p.data[p.pos] != '\n' {
+				p.advance(1)
+			}
+		case c == '/' && p.pos+1 < len(p.data) && p.data[p.pos+1] == '*':
+			p.advance(2)
+			for p.pos < len(p.data) &&
+				(p.data[p.pos] != '*' || p.pos+1 >= len(p.data) || p.data[p.pos+1] != '/') {
+				p.advance(1)
+			}
+			p.advance(2)
+		default:
+			return
+		}
+	}
+}
+
+// advance moves n bytes forward, keeping line and column in step.
+// Columns count runes, not bytes.
+func (p *jsoncParser) advance(n int) {
+	end := min(p.pos+n, len(p.data))
+	for p.pos < end {
+		if p.data[p.pos] == '\n' {
+			p.line++
+			p.col = 1
+			p.pos++
+
+			continue
+		}
+		_, size := utf8.DecodeRune(p.data[p.pos:])
+		p.pos += size
+		p.col++
+	}
+}
+
+func (p *jsoncParser) peek() byte {
+	if p.pos >= len(p.data) {
+		return 0
+	}
+
+	return p.data[p.pos]
+}
+
+// describe names the character at the current position for errors.
+func (p *jsoncParser) describe() string {
+	if p.pos >= len(p.data) {
+		return "end of input"
+	}
+	r, _ := utf8.DecodeRune(p.data[p.pos:])
+
+	return fmt.Sprintf("%q", r)
+}
+
+func (p *jsoncParser) errorf(format string, args ...any) error {
+	return p.errorAt(p.line, p.col, fmt.Sprintf(format, args...))
+}
+
+func (p *jsoncParser) errorAt(line, col int, message string) error {
+	return &specterrs.SyntaxError{
+		Path:    p.path,
+		Line:    line,
+		Column:  col,
+		Message: message,
+	}
+}
@@ -0,0 +1,124 @@
+package jsonschema
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+func TestParseJSONC_Positions(t *testing.T) {
+	data := []byte(`// header
+{
+  /* block */ "version": 2,
+  "tasks": [
+    {"id": "1.1", "ok": true, "none": null}
+  ]
+}`)
+
+	doc, err := ParseJSONC("tasks.jsonc", data)
+	if err != nil {
+		t.Fatalf("ParseJSONC: %v", err)
+	}
+	if doc.Line != 2 || doc.Column != 1 {
+		t.Errorf("root at %d:%d, want 2:1", doc.Line, doc.Column)
+	}
+
+	version, ok := doc.member("version")
+	if !ok || version.Line != 3 || version.Column != 15 {
+		t.Errorf("version key at %d:%d, want 3:15", version.Line, version.Column)
+	}
+	if version.Value.Number != "2" || version.Value.Column != 26 {
+		t.Errorf("version value = %s at col %d", version.Value.Number, version.Value.Column)
+	}
+
+	task := doc.Get("tasks").Items[0]
+	if task.Line != 5 || task.Column != 5 {
+		t.Errorf("task at %d:%d, want 5:5", task.Line, task.Column)
+	}
+	if id := task.Get("id"); id.String != "1.1" || id.Column != 12 {
+		t.Errorf("id = %q at col %d", id.String, id.Column)
+	}
+	if !task.Get("ok").Bool || task.Get("none").Kind != KindNull {
+		t.Error("literals decoded incorrectly")
+	}
+}
+
+func TestParseJSONC_Strings(t *testing.T) {
+	doc, err := ParseJSONC("x.jsonc", []byte(`{"a": "say \"hi\" // not a comment", "b": "é", "c": 1}`))
+	if err != nil {
+		t.Fatalf("ParseJSONC: %v", err)
+	}
+	if got := doc.Get("a").String; got != `say "hi" // not a comment` {
+		t.Errorf("a = %q", got)
+	}
+	// Columns count runes, so the multi-byte é occupies one column.
+	if got := doc.Get("c").Column; got != 53 {
+		t.Errorf("c at col %d, want 53", got)
+	}
+}
+
+func TestParseJSONC_SyntaxErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		wantLine int
+		wantCol  int
+	}{
+		{"trailing comma", "{\n  \"a\": 1,\n}", 3, 1},
+		{"missing colon", `{"a" 1}`, 1, 6},
+		{"unterminated string", "{\n  \"a\": \"b\n}", 2, 8},
+		{"bad literal", `[tru]`, 1, 2},
+		{"duplicate key", "{\"a\": 1,\n \"a\": 2}", 2, 2},
+		{"empty", "// nothing", 1, 11},
+		{"extra content", `{} {}`, 1, 4},
+		{"bad number", `[1.]`, 1, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseJSONC("x.jsonc", []byte(tt.content))
+
+			var syntaxErr *specterrs.SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("error = %v, want SyntaxError", err)
+			}
+			if syntaxErr.Line != tt.wantLine || syntaxErr.Column != tt.wantCol {
+				t.Errorf(
+					"error at %d:%d, want %d:%d (%v)",
+					syntaxErr.Line, syntaxErr.Column, tt.wantLine, tt.wantCol, err,
+				)
+			}
+		})
+	}
+}
+
+func TestParseYAML(t *testing.T) {
+	doc, err := ParseYAML("spectr.yaml", []byte("# comment\nappend_tasks:\n  section: Ops\n  tasks:\n    - Run lint\ngit:\n  backend: exec\nflag: true\nn: 3\n"))
+	if err != nil {
+		t.Fatalf("ParseYAML: %v", err)
+	}
+
+	appendTasks, ok := doc.member("append_tasks")
+	if !ok || appendTasks.Line != 2 || appendTasks.Column != 1 {
+		t.Errorf("append_tasks key at %d:%d, want 2:1", appendTasks.Line, appendTasks.Column)
+	}
+	task := appendTasks.Value.Get("tasks").Items[0]
+	if task.String != "Run lint" || task.Line != 5 || task.Column != 7 {
+		t.Errorf("task = %q at %d:%d", task.String, task.Line, task.Column)
+	}
+	if doc.Get("flag").Kind != KindBool || doc.Get("n").Kind != KindNumber {
+		t.Error("scalars not typed")
+	}
+
+	empty, err := ParseYAML("spectr.yaml", nil)
+	if err != nil || empty.Kind != KindNull {
+		t.Errorf("empty document = %v, %v; want null", empty, err)
+	}
+
+	_, err = ParseYAML("spectr.yaml", []byte("a: b\n c: d\n"))
+	var syntaxErr *specterrs.SyntaxError
+	if !errors.As(err, &syntaxErr) || syntaxErr.Line == 0 {
+		t.Errorf("error = %v, want SyntaxError with a line", err)
+	}
+}
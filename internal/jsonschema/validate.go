@@ -0,0 +1,263 @@
+package jsonschema
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+// Validate checks doc against schema and returns every problem found, in
+// document order. Unknown keywords are ignored, as JSON Schema requires.
+func Validate(schema, doc *Value) []specterrs.SchemaProblem {
+	v := &validator{root: schema}
+	v.validate(schema, doc, "")
+
+	return v.problems
+}
+
+type validator struct {
+	root     *Value
+	problems []specterrs.SchemaProblem
+}
+
+// validate checks value against schema and records problems under path.
+func (v *validator) validate(schema, value *Value, path string) {
+	if schema == nil || schema.Kind != KindObject {
+		return
+	}
+
+	if ref := schema.Get("$ref"); ref != nil && ref.Kind == KindString {
+		v.validate(v.resolve(ref.String), value, path)
+
+		return
+	}
+
+	if want := schema.Get("const"); want != nil && !want.Equal(value) {
+		v.addf(value, path, "must be %s", describe(want))
+
+		return
+	}
+	if enum := schema.Get("enum"); enum != nil && !contains(enum.Items, value) {
+		v.addf(value, path, "must be one of %s", describeAll(enum.Items))
+
+		return
+	}
+	if types := typeNames(schema.Get("type")); len(types) > 0 && !hasAnyType(value, types) {
+		v.addf(value, path, "must be %s", describeTypes(types))
+
+		return
+	}
+
+	switch value.Kind {
+	case KindObject:
+		v.validateObject(schema, value, path)
+	case KindArray:
+		if items := schema.Get("items"); items != nil {
+			for i, item := range value.Items {
+				v.validate(items, item, fmt.Sprintf("%s[%d]", path, i))
+			}
+		}
+	case KindString:
+		v.validateString(schema, value, path)
+	case KindNumber:
+		if minimum := schema.Get("minimum"); minimum != nil && minimum.Kind == KindNumber {
+			got, _ := value.Number.Float64()
+			limit, _ := minimum.Number.Float64()
+			if got < limit {
+				v.addf(value, path, "must be at least %s", minimum.Number)
+			}
+		}
+	case KindNull, KindBool:
+	}
+}
+
+// validateObject checks required, properties and additionalProperties.
+func (v *validator) validateObject(schema, obj *Value, path string) {
+	if required := schema.Get("required"); required != nil {
+		for _, name := range required.Items {
+			if _, present := obj.member(name.String); !present {
+				v.addf(obj, join(path, name.String), "is required")
+			}
+		}
+	}
+
+	properties := schema.Get("properties")
+	additional := schema.Get("additionalProperties")
+	for _, m := range obj.Members {
+		if propSchema := properties.Get(m.Key); propSchema != nil {
+			v.validate(propSchema, m.Value, join(path, m.Key))
+
+			continue
+		}
+		if additional != nil && additional.Kind == KindBool && !additional.Bool {
+			v.problems = append(v.problems, specterrs.SchemaProblem{
+				Path:    join(path, m.Key),
+				Message: "is not allowed",
+				Line:    m.Line,
+				Column:  m.Column,
+			})
+		}
+	}
+}
+
+// validateString checks minLength and pattern.
+func (v *validator) validateString(schema, str *Value, path string) {
+	if minLength := schema.Get("minLength"); minLength != nil {
+		n, _ := minLength.Number.Int64()
+		if int64(utf8.RuneCountInString(str.String)) < n {
+			if n == 1 {
+				v.addf(str, path, "must not be empty")
+			} else {
+				v.addf(str, path, "must be at least %d characters", n)
+			}
+		}
+	}
+	if pattern := schema.Get("pattern"); pattern != nil {
+		re, err := regexp.Compile(pattern.String)
+		if err == nil && !re.MatchString(str.String) {
+			v.addf(str, path, "must match %s", pattern.String)
+		}
+	}
+}
+
+// resolve looks up a local reference such as "#/$defs/task".
+func (v *validator) resolve(ref string) *Value {
+	node := v.root
+	for _, part := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+		node = node.Get(part)
+	}
+
+	return node
+}
+
+func (v *validator) addf(at *Value, path, format string, args ...any) {
+	v.problems = append(v.problems, specterrs.SchemaProblem{
+		Path:    path,
+		Message: fmt.Sprintf(format, args...),
+		Line:    at.Line,
+		Column:  at.Column,
+	})
+}
+
+// join appends an object key to a JSON path.
+func join(path, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
+
+// typeNames returns the type keyword as a list; it may be a single name
+// or an array of names.
+func typeNames(typ *Value) []string {
+	if typ == nil {
+		return nil
+	}
+	if typ.Kind == KindString {
+		return []string{typ.String}
+	}
+
+	names := make([]string, 0, len(typ.Items))
+	for _, item := range typ.Items {
+		names = append(names, item.String)
+	}
+
+	return names
+}
+
+func hasAnyType(value *Value, types []string) bool {
+	for _, typ := range types {
+		if hasType(value, typ) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasType reports whether value has the JSON Schema type typ.
+func hasType(value *Value, typ string) bool {
+	switch typ {
+	case "object":
+		return value.Kind == KindObject
+	case "array":
+		return value.Kind == KindArray
+	case "string":
+		return value.Kind == KindString
+	case "boolean":
+		return value.Kind == KindBool
+	case "null":
+		return value.Kind == KindNull
+	case "number":
+		return value.Kind == KindNumber
+	case "integer":
+		if value.Kind != KindNumber {
+			return false
+		}
+		_, err := value.Number.Int64()
+
+		return err == nil
+	}
+
+	return true
+}
+
+func contains(values []*Value, value *Value) bool {
+	for _, candidate := range values {
+		if candidate.Equal(value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func describe(value *Value) string {
+	switch value.Kind {
+	case KindString:
+		return fmt.Sprintf("%q", value.String)
+	case KindNumber:
+		return value.Number.String()
+	case KindBool:
+		return fmt.Sprint(value.Bool)
+	case KindNull:
+		return "null"
+	}
+
+	return "a specific value"
+}
+
+func describeAll(values []*Value) string {
+	parts := make([]string, len(values))
+	for i, value := range values {
+		parts[i] = describe(value)
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// describeTypes names the allowed types, ignoring null so optional
+// sections read naturally ("must be an object").
+func describeTypes(types []string) string {
+	parts := make([]string, 0, len(types))
+	for _, typ := range types {
+		if typ != "null" || len(types) == 1 {
+			parts = append(parts, article(typ))
+		}
+	}
+
+	return strings.Join(parts, " or ")
+}
+
+func article(typ string) string {
+	switch typ {
+	case "object", "array", "integer":
+		return "an " + typ
+	}
+
+	return "a " + typ
+}
@@ -0,0 +1,102 @@
+package jsonschema
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	schema := mustParse(t, `{
+  "type": "object",
+  "required": ["name"],
+  "additionalProperties": false,
+  "properties": {
+    "name": { "type": "string", "minLength": 1 },
+    "kind": { "enum": ["a", "b"] },
+    "count": { "type": "integer", "minimum": 0 },
+    "ref": { "type": "string", "pattern": "^\\$ref:" },
+    "opt": { "type": ["object", "null"] },
+    "items": { "type": "array", "items": { "$ref": "#/$defs/item" } }
+  },
+  "$defs": {
+    "item": { "type": "object", "properties": { "v": { "const": 1 } } }
+  }
+}`)
+
+	tests := []struct {
+		name string
+		doc  string
+		want []string // "line:col path: message", in order
+	}{
+		{
+			name: "valid",
+			doc:  `{"name": "x", "kind": "a", "count": 2, "opt": null, "items": [{"v": 1}]}`,
+		},
+		{
+			name: "required reported at object",
+			doc:  "\n  {}",
+			want: []string{"2:3 name: is required"},
+		},
+		{
+			name: "unknown key reported at key",
+			doc:  "{\"name\": \"x\",\n \"extra\": 1}",
+			want: []string{"2:2 extra: is not allowed"},
+		},
+		{
+			name: "value problems reported at value",
+			doc:  `{"name": "", "kind": "c", "count": -1}`,
+			want: []string{
+				"1:10 name: must not be empty",
+				"1:22 kind: must be one of \"a\", \"b\"",
+				"1:36 count: must be at least 0",
+			},
+		},
+		{
+			name: "type list names non-null types",
+			doc:  `{"name": "x", "opt": []}`,
+			want: []string{"1:22 opt: must be an object"},
+		},
+		{
+			name: "integer",
+			doc:  `{"name": "x", "count": 1.5}`,
+			want: []string{"1:24 count: must be an integer"},
+		},
+		{
+			name: "pattern",
+			doc:  `{"name": "x", "ref": "tasks-1.jsonc"}`,
+			want: []string{"1:22 ref: must match ^\\$ref:"},
+		},
+		{
+			name: "ref and items",
+			doc:  `{"name": "x", "items": [{"v": 1}, {"v": 2}]}`,
+			want: []string{"1:41 items[1].v: must be 1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problems := Validate(schema, mustParse(t, tt.doc))
+
+			got := make([]string, len(problems))
+			for i, p := range problems {
+				got[i] = strings.TrimPrefix(p.String(), "line ")
+				got[i] = strings.Replace(got[i], ", col ", ":", 1)
+				got[i] = strings.Replace(got[i], ": ", " ", 1)
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("problems:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func mustParse(t *testing.T, s string) *Value {
+	t.Helper()
+
+	v, err := ParseJSONC("test.jsonc", []byte(s))
+	if err != nil {
+		t.Fatalf("ParseJSONC: %v", err)
+	}
+
+	return v
+}
@@ -0,0 +1,110 @@
+package jsonschema
+
+import "encoding/json"
+
+// Kind is the JSON type of a Value.
+type Kind int
+
+const (
+	KindNull Kind = iota
+	KindBool
+	KindNumber
+	KindString
+	KindArray
+	KindObject
+)
+
+// Value is a parsed JSON or YAML node together with its source position.
+// Line and Column are 1-based.
+type Value struct {
+	Kind    Kind
+	Bool    bool
+	Number  json.Number
+	String  string
+	Items   []*Value
+	Members []Member
+	Line    int
+	Column  int
+}
+
+// Member is an object key and its value. Line and Column locate the key.
+type Member struct {
+	Key    string
+	Value  *Value
+	Line   int
+	Column int
+}
+
+// Get returns the member named key of an object, or nil.
+func (v *Value) Get(key string) *Value {
+	if v == nil || v.Kind != KindObject {
+		return nil
+	}
+	for _, m := range v.Members {
+		if m.Key == key {
+			return m.Value
+		}
+	}
+
+	return nil
+}
+
+// member returns the Member named key of an object.
+func (v *Value) member(key string) (Member, bool) {
+	for _, m := range v.Members {
+		if m.Key == key {
+			return m, true
+		}
+	}
+
+	return Member{}, false
+}
+
+// Equal reports whether two values are the same JSON value. Numbers
+// compare numerically and object member order is ignored.
+func (v *Value) Equal(other *Value) bool {
+	if v == nil || other == nil {
+		return v == other
+	}
+	if v.Kind != other.Kind {
+		return false
+	}
+
+	switch v.Kind {
+	case KindNull:
+		return true
+	case KindBool:
+		return v.Bool == other.Bool
+	case KindNumber:
+		a, _ := v.Number.Float64()
+		b, _ := other.Number.Float64()
+
+		return a == b
+	case KindString:
+		return v.String == other.String
+	case KindArray:
+		if len(v.Items) != len(other.Items) {
+			return false
+		}
+		for i := range v.Items {
+			if !v.Items[i].Equal(other.Items[i]) {
+				return false
+			}
+		}
+
+		return true
+	case KindObject:
+		if len(v.Members) != len(other.Members) {
+			return false
+		}
+		for _, m := range v.Members {
+			if !m.Value.Equal(other.Get(m.Key)) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	return false
+}
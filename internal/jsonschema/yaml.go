@@ -0,0 +1,119 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+// yamlLinePattern extracts the line number from yaml.v3 error messages,
+// which look like "yaml: line 3: mapping values are not allowed here".
+var yamlLinePattern = regexp.MustCompile(`^yaml: line (\d+): (.*)$`)
+
+// ParseYAML parses a YAML document into a Value so it can be validated
+// against a JSON Schema. An empty document is null.
+func ParseYAML(path string, data []byte) (*Value, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		if errors.Is(err, io.EOF) {
+			return &Value{Kind: KindNull, Line: 1, Column: 1}, nil
+		}
+
+		return nil, yamlSyntaxError(path, err)
+	}
+	if len(doc.Content) == 0 {
+		return &Value{Kind: KindNull, Line: 1, Column: 1}, nil
+	}
+
+	return fromYAML(path, doc.Content[0])
+}
+
+func fromYAML(path string, node *yaml.Node) (*Value, error) {
+	value := &Value{Line: node.Line, Column: node.Column}
+
+	switch node.Kind {
+	case yaml.AliasNode:
+		return fromYAML(path, node.Alias)
+	case yaml.MappingNode:
+		value.Kind = KindObject
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			keyNode, valueNode := node.Content[i], node.Content[i+1]
+			member, err := fromYAML(path, valueNode)
+			if err != nil {
+				return nil, err
+			}
+			value.Members = append(value.Members, Member{
+				Key:    keyNode.Value,
+				Value:  member,
+				Line:   keyNode.Line,
+				Column: keyNode.Column,
+			})
+		}
+	case yaml.SequenceNode:
+		value.Kind = KindArray
+		for _, itemNode := range node.Content {
+			item, err := fromYAML(path, itemNode)
+			if err != nil {
+				return nil, err
+			}
+			value.Items = append(value.Items, item)
+		}
+	case yaml.ScalarNode:
+		scalarValue(value, node)
+	default:
+		return nil, &specterrs.SyntaxError{
+			Path:    path,
+			Line:    node.Line,
+			Column:  node.Column,
+			Message: "unsupported YAML node",
+		}
+	}
+
+	return value, nil
+}
+
+// scalarValue maps a YAML scalar onto the JSON type its resolved tag
+// names.
+func scalarValue(value *Value, node *yaml.Node) {
+	switch node.ShortTag() {
+	case "!!null":
+		value.Kind = KindNull
+	case "!!bool":
+		value.Kind = KindBool
+		value.Bool, _ = strconv.ParseBool(strings.ToLower(node.Value))
+	case "!!int", "!!float":
+		if json.Valid([]byte(node.Value)) {
+			value.Kind = KindNumber
+			value.Number = json.Number(node.Value)
+
+			return
+		}
+		value.Kind = KindString
+		value.String = node.Value
+	default:
+		value.Kind = KindString
+		value.String = node.Value
+	}
+}
+
+func yamlSyntaxError(path string, err error) error {
+	msg := err.Error()
+	line := 0
+	if m := yamlLinePattern.FindStringSubmatch(msg); m != nil {
+		line, _ = strconv.Atoi(m[1])
+		msg = m[2]
+	}
+
+	return &specterrs.SyntaxError{
+		Path:    path,
+		Line:    line,
+		Message: strings.TrimPrefix(msg, "yaml: "),
+	}
+}
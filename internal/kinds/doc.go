@@ -0,0 +1,19 @@
+// Package kinds manages custom item kinds declared in spectr.yaml, such
+// as architecture decision records or runbooks, with the same machinery
+// as specs and changes.
+//
+// A kind names a directory of markdown files, one item per file, the ##
+// sections each item must have and a validation profile. spectr list
+// --kind lists the items of a kind, spectr new creates one from the
+// kind's template, and spectr validate checks them along with specs and
+// changes:
+//
+//	kinds:
+//	  - name: adr
+//	    dir: spectr/adrs
+//	    title: Architecture decision records
+//	    sections: [Context, Decision, Consequences]
+//
+// The names change, spec and config are reserved for the built-in item
+// types.
+package kinds
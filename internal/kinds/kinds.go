@@ -0,0 +1,278 @@
+package kinds
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/config"
+	"github.com/connerohnesorge/spectr/internal/fileio"
+	"github.com/connerohnesorge/spectr/internal/parsers"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+// Validation profiles.
+const (
+	// ValidationSections checks the title and required sections
+	ValidationSections = "sections"
+	// ValidationSpec also runs the spec rules (purpose, requirements,
+	// scenarios)
+	ValidationSpec = "spec"
+	// ValidationNone skips validation
+	ValidationNone = "none"
+)
+
+// filePerm is the permission of new item files.
+const filePerm = 0o644
+
+// reserved are the names of the built-in item types.
+var reserved = []string{"change", "spec", "config"}
+
+// idPattern matches the IDs of items, which are also their file names.
+var idPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// Kind is a custom item kind.
+type Kind struct {
+	Name  string `json:"name"`
+	Title string `json:"title,omitempty"`
+	// Dir is the absolute directory of the items
+	Dir        string   `json:"-"`
+	Sections   []string `json:"sections,omitempty"`
+	Template   string   `json:"-"`
+	Validation string   `json:"validation"`
+}
+
+// Item is one item of a custom kind.
+type Item struct {
+	Kind  string `json:"kind"`
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	Path  string `json:"-"`
+}
+
+// Registry holds the kinds of a project in spectr.yaml order.
+type Registry []Kind
+
+// Load returns the kinds declared in the spectr.yaml of projectRoot,
+// which is empty when there is no config file or it declares none.
+func Load(projectRoot string) (Registry, error) {
+	cfg, err := config.LoadConfig(projectRoot)
+	if err != nil || cfg == nil {
+		return nil, err
+	}
+
+	return FromConfig(projectRoot, cfg.Kinds)
+}
+
+// FromConfig checks the kinds declared in spectr.yaml and resolves their
+// paths against projectRoot.
+func FromConfig(projectRoot string, declared []config.KindConfig) (Registry, error) {
+	registry := make(Registry, 0, len(declared))
+	for _, kc := range declared {
+		name := strings.TrimSpace(kc.Name)
+		switch {
+		case name == "":
+			return nil, &specterrs.InvalidKindError{Kind: name, Reason: "name is empty"}
+		case slices.Contains(reserved, name):
+			return nil, &specterrs.InvalidKindError{Kind: name, Reason: "the name is reserved"}
+		case registry.has(name):
+			return nil, &specterrs.InvalidKindError{Kind: name, Reason: "declared twice"}
+		}
+
+		dir, err := projectPath(projectRoot, kc.Dir)
+		if err != nil {
+			return nil, &specterrs.InvalidKindError{Kind: name, Reason: "dir " + err.Error()}
+		}
+		kind := Kind{
+			Name:       name,
+			Title:      kc.Title,
+			Dir:        dir,
+			Sections:   kc.Sections,
+			Validation: kc.Validation,
+		}
+		if kind.Validation == "" {
+			kind.Validation = ValidationSections
+		}
+		if !slices.Contains([]string{ValidationSections, ValidationSpec, ValidationNone}, kind.Validation) {
+			return nil, &specterrs.InvalidKindError{
+				Kind:   name,
+				Reason: fmt.Sprintf("unknown validation profile %q", kind.Validation),
+			}
+		}
+		if kc.Template != "" {
+			if kind.Template, err = projectPath(projectRoot, kc.Template); err != nil {
+				return nil, &specterrs.InvalidKindError{Kind: name, Reason: "template " + err.Error()}
+			}
+		}
+		registry = append(registry, kind)
+	}
+
+	return registry, nil
+}
+
+// projectPath resolves a path relative to projectRoot that must stay
+// inside it.
+func projectPath(projectRoot, rel string) (string, error) {
+	rel = filepath.Clean(filepath.FromSlash(strings.TrimSpace(rel)))
+	switch {
+	case rel == "." || rel == "":
+		return "", errors.New("is empty")
+	case filepath.IsAbs(rel) || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)):
+		return "", fmt.Errorf("%q must be inside the project", rel)
+	}
+
+	return filepath.Join(projectRoot, rel), nil
+}
+
+// Get returns the kind with the given name.
+func (r Registry) Get(name string) (Kind, error) {
+	for _, kind := range r {
+		if kind.Name == name {
+			return kind, nil
+		}
+	}
+
+	return Kind{}, &specterrs.UnknownKindError{Kind: name, Available: r.Names()}
+}
+
+// Names returns the names of the kinds.
+func (r Registry) Names() []string {
+	names := make([]string, len(r))
+	for i, kind := range r {
+		names[i] = kind.Name
+	}
+
+	return names
+}
+
+// has reports whether the registry holds a kind with the given name.
+func (r Registry) has(name string) bool {
+	_, err := r.Get(name)
+
+	return err == nil
+}
+
+// Find returns the item a reference names: "<kind>/<id>", or the path of
+// an item file. ok is false when no kind matches.
+func (r Registry) Find(ref string) (Item, bool) {
+	if name, id, found := strings.Cut(filepath.ToSlash(ref), "/"); found {
+		if kind, err := r.Get(name); err == nil {
+			item := kind.item(strings.TrimSuffix(id, ".md"))
+			if _, err := os.Stat(item.Path); err == nil {
+				return item, true
+			}
+		}
+	}
+
+	abs, err := filepath.Abs(ref)
+	if err != nil || filepath.Ext(abs) != ".md" {
+		return Item{}, false
+	}
+	for _, kind := range r {
+		if filepath.Dir(abs) != kind.Dir {
+			continue
+		}
+		if _, err := os.Stat(abs); err == nil {
+			return kind.item(strings.TrimSuffix(filepath.Base(abs), ".md")), true
+		}
+	}
+
+	return Item{}, false
+}
+
+// Label returns the display name of the kind: its title, or its name.
+func (k Kind) Label() string {
+	if k.Title != "" {
+		return k.Title
+	}
+
+	return k.Name
+}
+
+// Items returns the items of the kind sorted by ID: the markdown files of
+// its directory other than README.md. A missing directory has no items.
+func (k Kind) Items() ([]Item, error) {
+	entries, err := os.ReadDir(k.Dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", k.Dir, err)
+	}
+
+	var items []Item
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || filepath.Ext(name) != ".md" || strings.EqualFold(name, "README.md") {
+			continue
+		}
+		items = append(items, k.item(strings.TrimSuffix(name, ".md")))
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].ID < items[j].ID })
+
+	return items, nil
+}
+
+// item returns the item with the given ID, titled by its # heading.
+func (k Kind) item(id string) Item {
+	item := Item{Kind: k.Name, ID: id, Path: filepath.Join(k.Dir, id+".md")}
+	if title, err := parsers.ExtractTitle(item.Path); err == nil {
+		item.Title = title
+	}
+
+	return item
+}
+
+// New writes a new item with the given ID and title, from the kind's
+// template or else a heading per required section, and returns its path.
+func (k Kind) New(id, title string) (string, error) {
+	if !idPattern.MatchString(id) {
+		return "", &specterrs.InvalidItemIDError{ID: id}
+	}
+	path := filepath.Join(k.Dir, id+".md")
+	if _, err := os.Stat(path); err == nil {
+		return "", &specterrs.ItemExistsError{Kind: k.Name, ID: id, Path: path}
+	}
+	if title == "" {
+		title = id
+	}
+
+	content, err := k.render(id, title)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(k.Dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", k.Dir, err)
+	}
+	if err := os.WriteFile(path, []byte(content), filePerm); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	return path, nil
+}
+
+// render returns the content of a new item.
+func (k Kind) render(id, title string) (string, error) {
+	if k.Template != "" {
+		data, err := fileio.ReadFile(k.Template)
+		if err != nil {
+			return "", fmt.Errorf("failed to read template of kind %s: %w", k.Name, err)
+		}
+
+		return strings.NewReplacer("{{id}}", id, "{{title}}", title).Replace(string(data)), nil
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n", title)
+	for _, section := range k.Sections {
+		fmt.Fprintf(&b, "\n## %s\n", section)
+	}
+
+	return b.String(), nil
+}
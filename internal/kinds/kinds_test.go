@@ -0,0 +1,184 @@
+package kinds
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/connerohnesorge/spectr/internal/config"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+func TestFromConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		declared []config.KindConfig
+		wantErr  bool
+	}{
+		{
+			name:     "valid",
+			declared: []config.KindConfig{{Name: "adr", Dir: "docs/adr", Sections: []string{"Context"}}},
+		},
+		{
+			name:     "empty name",
+			declared: []config.KindConfig{{Dir: "docs/adr"}},
+			wantErr:  true,
+		},
+		{
+			name:     "reserved name",
+			declared: []config.KindConfig{{Name: "spec", Dir: "docs/spec"}},
+			wantErr:  true,
+		},
+		{
+			name: "declared twice",
+			declared: []config.KindConfig{
+				{Name: "adr", Dir: "docs/adr"},
+				{Name: "adr", Dir: "docs/decisions"},
+			},
+			wantErr: true,
+		},
+		{
+			name:     "dir outside project",
+			declared: []config.KindConfig{{Name: "adr", Dir: "../adr"}},
+			wantErr:  true,
+		},
+		{
+			name:     "missing dir",
+			declared: []config.KindConfig{{Name: "adr"}},
+			wantErr:  true,
+		},
+		{
+			name:     "unknown profile",
+			declared: []config.KindConfig{{Name: "adr", Dir: "docs/adr", Validation: "strict"}},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry, err := FromConfig("/project", tt.declared)
+			if tt.wantErr {
+				var kindErr *specterrs.InvalidKindError
+				if !errors.As(err, &kindErr) {
+					t.Fatalf("FromConfig() error = %v, want InvalidKindError", err)
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			kind := registry[0]
+			if kind.Dir != filepath.Join("/project", "docs", "adr") || kind.Validation != ValidationSections {
+				t.Errorf("kind = %+v", kind)
+			}
+		})
+	}
+}
+
+func TestKind_NewAndItems(t *testing.T) {
+	root := t.TempDir()
+	template := filepath.Join(root, "adr-template.md")
+	if err := os.WriteFile(template, []byte("# {{id}}: {{title}}\n\n## Status\nProposed\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	registry, err := FromConfig(root, []config.KindConfig{
+		{Name: "adr", Dir: "docs/adr", Sections: []string{"Context", "Decision"}},
+		{Name: "rfc", Dir: "docs/rfc", Template: "adr-template.md"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	adr, _ := registry.Get("adr")
+	path, err := adr.New("0002", "Use Postgres")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertContent(t, path, "# Use Postgres\n\n## Context\n\n## Decision\n")
+	if _, err := adr.New("0001", ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := adr.New("0001", "Again"); !errors.As(err, new(*specterrs.ItemExistsError)) {
+		t.Errorf("New() over an existing item error = %v, want ItemExistsError", err)
+	}
+	if _, err := adr.New("../escape", ""); !errors.As(err, new(*specterrs.InvalidItemIDError)) {
+		t.Errorf("New() with a bad ID error = %v, want InvalidItemIDError", err)
+	}
+
+	rfc, _ := registry.Get("rfc")
+	path, err = rfc.New("7", "Streaming")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertContent(t, path, "# 7: Streaming\n\n## Status\nProposed\n")
+
+	readme := filepath.Join(adr.Dir, "README.md")
+	if err := os.WriteFile(readme, []byte("# ADRs\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	items, err := adr.Items()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 2 || items[0].ID != "0001" || items[0].Title != "0001" ||
+		items[1].ID != "0002" || items[1].Title != "Use Postgres" {
+		t.Errorf("Items() = %+v", items)
+	}
+
+	if _, err := registry.Get("rfd"); !errors.As(err, new(*specterrs.UnknownKindError)) {
+		t.Errorf("Get() of an undeclared kind error = %v, want UnknownKindError", err)
+	}
+}
+
+func TestRegistry_Find(t *testing.T) {
+	root := t.TempDir()
+	registry, err := FromConfig(root, []config.KindConfig{{Name: "adr", Dir: "docs/adr"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	adr, _ := registry.Get("adr")
+	path, err := adr.New("0001", "Use Postgres")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		ref    string
+		wantOK bool
+	}{
+		{name: "kind and id", ref: "adr/0001", wantOK: true},
+		{name: "kind and file", ref: "adr/0001.md", wantOK: true},
+		{name: "path", ref: path, wantOK: true},
+		{name: "missing item", ref: "adr/0002"},
+		{name: "unknown kind", ref: "rfc/0001"},
+		{name: "plain id", ref: "0001"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item, ok := registry.Find(tt.ref)
+			if ok != tt.wantOK {
+				t.Fatalf("Find(%q) ok = %v, want %v", tt.ref, ok, tt.wantOK)
+			}
+			if ok && (item.Kind != "adr" || item.ID != "0001" || item.Path != path) {
+				t.Errorf("Find(%q) = %+v", tt.ref, item)
+			}
+		})
+	}
+}
+
+// assertContent fails the test unless the file at path holds want.
+func assertContent(t *testing.T, path, want string) {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != want {
+		t.Errorf("%s = %q, want %q", path, data, want)
+	}
+}
@@ -0,0 +1,352 @@
+// Package links resolves wikilinks across the projects of a repository.
+//
+// A wikilink target may be qualified with a namespace, as in
+// [[payments:refunds]], to point into another project. Unqualified targets
+// are looked up tier by tier: the local project, then vendored projects
+// (copies of another project's spectr/ directory in spectr/vendor/<name>/),
+// then sibling projects in the same git repository. The first tier with a
+// match wins; two matches in the same tier are an ambiguity error rather
+// than a silent pick. A target found nowhere that names a renamed spec or
+// change resolves through spectr/aliases.yaml.
+//
+// Sibling projects are named by their path from the git root, e.g.
+// "services/payments"; vendored projects by their directory name.
+package links
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/alias"
+	"github.com/connerohnesorge/spectr/internal/discovery"
+	"github.com/connerohnesorge/spectr/internal/markdown"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+// VendorDir is the directory of vendored projects inside spectr/.
+const VendorDir = "vendor"
+
+// namespaceSep separates a namespace from the target in a wikilink.
+const namespaceSep = ":"
+
+// Kind is the resolution tier of a namespace.
+type Kind string
+
+// Namespace kinds, in resolution order.
+const (
+	Local    Kind = "local"
+	Vendored Kind = "vendored"
+	Sibling  Kind = "sibling"
+)
+
+// kindOrder lists the tiers in resolution order.
+var kindOrder = []Kind{Local, Vendored, Sibling}
+
+// Namespace is a project wikilinks can resolve into.
+type Namespace struct {
+	Name      string `json:"name"`
+	Kind      Kind   `json:"kind"`
+	SpectrDir string `json:"spectrDir"`
+}
+
+// Attempt is one place a target was looked for.
+type Attempt struct {
+	Namespace string `json:"namespace"`
+	Kind      Kind   `json:"kind"`
+	Path      string `json:"path"`
+	Exists    bool   `json:"exists"`
+}
+
+// Resolution is the outcome of resolving a target. Path is empty when
+// nothing matched.
+type Resolution struct {
+	Target    string    `json:"target"`
+	Namespace string    `json:"namespace,omitempty"`
+	Kind      Kind      `json:"kind,omitempty"`
+	Path      string    `json:"path,omitempty"`
+	Attempts  []Attempt `json:"attempts"`
+	// Renamed is the target to use instead, when Target names a renamed
+	// spec or change
+	Renamed string `json:"renamed,omitempty"`
+}
+
+// Found reports whether the target resolved to an existing file.
+func (r *Resolution) Found() bool {
+	return r.Path != ""
+}
+
+// Resolver resolves wikilink targets across namespaces.
+type Resolver struct {
+	namespaces []Namespace
+}
+
+// New returns a resolver over namespaces. Order within a kind is the
+// order namespaces are reported in; resolution order across kinds is
+// always local, vendored, sibling.
+func New(namespaces []Namespace) *Resolver {
+	return &Resolver{namespaces: namespaces}
+}
+
+// NewResolver returns the resolver for the project at projectRoot: the
+// project itself, its vendored projects, and the other spectr projects in
+// its git repository.
+func NewResolver(projectRoot string) (*Resolver, error) {
+	projectRoot, err := filepath.Abs(projectRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := discovery.FindSpectrRootAt(projectRoot, projectRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	namespaces := []Namespace{{
+		Name:      namespaceName(root),
+		Kind:      Local,
+		SpectrDir: root.SpectrDir(),
+	}}
+
+	vendored, err := vendoredNamespaces(root.SpectrDir())
+	if err != nil {
+		return nil, err
+	}
+	namespaces = append(namespaces, vendored...)
+
+	if root.GitRoot != "" {
+		siblings, err := discovery.FindSpectrRoots(root.GitRoot)
+		if err != nil {
+			return nil, err
+		}
+		for _, sibling := range siblings {
+			if sibling.Path == root.Path {
+				continue
+			}
+			namespaces = append(namespaces, Namespace{
+				Name:      namespaceName(sibling),
+				Kind:      Sibling,
+				SpectrDir: sibling.SpectrDir(),
+			})
+		}
+	}
+
+	return New(namespaces), nil
+}
+
+// Namespaces returns the namespaces in resolution order.
+func (r *Resolver) Namespaces() []Namespace {
+	var ordered []Namespace
+	for _, kind := range kindOrder {
+		for _, ns := range r.namespaces {
+			if ns.Kind == kind {
+				ordered = append(ordered, ns)
+			}
+		}
+	}
+
+	return ordered
+}
+
+// SplitNamespace splits a qualified target such as "payments:refunds"
+// into its namespace and target. Unqualified targets return an empty
+// namespace.
+func SplitNamespace(target string) (namespace, rest string) {
+	path, _, _ := strings.Cut(target, "#")
+	ns, rest, ok := strings.Cut(path, namespaceSep)
+	if !ok || ns == "" {
+		return "", target
+	}
+
+	return ns, strings.TrimPrefix(target, ns+namespaceSep)
+}
+
+// Resolve resolves target. An unknown namespace or an ambiguous
+// unqualified target is an error; a target found nowhere is not, and
+// yields a Resolution that is not Found.
+func (r *Resolver) Resolve(target string) (*Resolution, error) {
+	ns, rest := SplitNamespace(target)
+	res := &Resolution{Target: target}
+
+	candidates := r.Namespaces()
+	if ns != "" {
+		candidates = r.named(ns)
+		if len(candidates) == 0 {
+			return nil, &specterrs.UnknownWikilinkNamespaceError{
+				Namespace: ns,
+				Known:     r.names(),
+			}
+		}
+	}
+
+	for _, kind := range kindOrder {
+		var hits []Attempt
+		for _, candidate := range candidates {
+			if candidate.Kind != kind {
+				continue
+			}
+			path, exists := markdown.ResolveWikilinkIn(rest, candidate.SpectrDir)
+			attempt := Attempt{
+				Namespace: candidate.Name,
+				Kind:      kind,
+				Path:      path,
+				Exists:    exists,
+			}
+			res.Attempts = append(res.Attempts, attempt)
+			if exists {
+				hits = append(hits, attempt)
+			}
+		}
+
+		switch len(hits) {
+		case 0:
+			continue
+		case 1:
+			res.Namespace = hits[0].Namespace
+			res.Kind = hits[0].Kind
+			res.Path = hits[0].Path
+
+			return res, nil
+		default:
+			names := make([]string, 0, len(hits))
+			for _, hit := range hits {
+				names = append(names, hit.Namespace)
+			}
+
+			return res, &specterrs.AmbiguousWikilinkError{
+				Target:     rest,
+				Namespaces: names,
+			}
+		}
+	}
+
+	resolveRenamed(res, candidates, rest)
+
+	return res, nil
+}
+
+// resolveRenamed resolves a target found nowhere through the aliases of
+// renamed specs and changes, in the first namespace that has one.
+func resolveRenamed(res *Resolution, candidates []Namespace, target string) {
+	path, anchor, hasAnchor := strings.Cut(target, "#")
+	prefix := ""
+	kinds := []alias.Kind{alias.Spec, alias.Change}
+	switch {
+	case strings.HasPrefix(path, "specs/"):
+		prefix, kinds = "specs/", []alias.Kind{alias.Spec}
+	case strings.HasPrefix(path, "changes/"):
+		prefix, kinds = "changes/", []alias.Kind{alias.Change}
+	}
+	id := strings.TrimPrefix(path, prefix)
+
+	for _, candidate := range candidates {
+		aliases, err := alias.Load(candidate.SpectrDir)
+		if err != nil {
+			continue
+		}
+		for _, kind := range kinds {
+			newID, ok := aliases.Resolve(kind, id)
+			if !ok {
+				continue
+			}
+			renamed := prefix + newID
+			if hasAnchor {
+				renamed += "#" + anchor
+			}
+			resolved, exists := markdown.ResolveWikilinkIn(renamed, candidate.SpectrDir)
+			if !exists {
+				continue
+			}
+			res.Namespace = candidate.Name
+			res.Kind = candidate.Kind
+			res.Path = resolved
+			res.Renamed = renamed
+
+			return
+		}
+	}
+}
+
+// Func adapts the resolver to markdown.ValidateWikilinksWith. Targets
+// that resolve nowhere report the path expected in the first namespace
+// tried.
+func (r *Resolver) Func() markdown.WikilinkResolver {
+	return func(target string) (string, bool, error) {
+		res, err := r.Resolve(target)
+		if err != nil {
+			return "", false, err
+		}
+		if res.Found() {
+			return res.Path, true, nil
+		}
+		if len(res.Attempts) > 0 {
+			return res.Attempts[0].Path, false, nil
+		}
+
+		return "", false, nil
+	}
+}
+
+// named returns the namespaces called name.
+func (r *Resolver) named(name string) []Namespace {
+	var matches []Namespace
+	for _, ns := range r.namespaces {
+		if ns.Name == name {
+			matches = append(matches, ns)
+		}
+	}
+
+	return matches
+}
+
+// names returns the sorted namespace names.
+func (r *Resolver) names() []string {
+	names := make([]string, 0, len(r.namespaces))
+	for _, ns := range r.namespaces {
+		names = append(names, ns.Name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// namespaceName names a project by its path from the git root, or by its
+// directory name outside git.
+func namespaceName(root discovery.SpectrRoot) string {
+	if root.GitRoot != "" {
+		rel, err := filepath.Rel(root.GitRoot, root.Path)
+		if err == nil && rel != "." {
+			return filepath.ToSlash(rel)
+		}
+	}
+
+	return filepath.Base(root.Path)
+}
+
+// vendoredNamespaces lists the vendored projects in spectrDir.
+func vendoredNamespaces(spectrDir string) ([]Namespace, error) {
+	dir := filepath.Join(spectrDir, VendorDir)
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+
+	var namespaces []Namespace
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		namespaces = append(namespaces, Namespace{
+			Name:      entry.Name(),
+			Kind:      Vendored,
+			SpectrDir: filepath.Join(dir, entry.Name()),
+		})
+	}
+
+	return namespaces, nil
+}
@@ -0,0 +1,180 @@
+package links
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/connerohnesorge/spectr/internal/markdown"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+// writeSpec creates spectrDir/specs/<id>/spec.md.
+func writeSpec(t *testing.T, spectrDir, id string) {
+	t.Helper()
+
+	path := filepath.Join(spectrDir, "specs", id, "spec.md")
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	content := "# Spec\n\n## Requirements\n\n### Requirement: Refunds\nThe system SHALL refund.\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSplitNamespace(t *testing.T) {
+	tests := []struct {
+		target string
+		ns     string
+		rest   string
+	}{
+		{"auth", "", "auth"},
+		{"payments:refunds", "payments", "refunds"},
+		{"services/payments:specs/refunds", "services/payments", "specs/refunds"},
+		{"auth#Requirement: Login", "", "auth#Requirement: Login"},
+		{":auth", "", ":auth"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			ns, rest := SplitNamespace(tt.target)
+			if ns != tt.ns || rest != tt.rest {
+				t.Errorf("SplitNamespace(%q) = %q, %q; want %q, %q",
+					tt.target, ns, rest, tt.ns, tt.rest)
+			}
+		})
+	}
+}
+
+func TestResolve(t *testing.T) {
+	base := t.TempDir()
+	local := filepath.Join(base, "app", "spectr")
+	vendor := filepath.Join(local, VendorDir, "billing")
+	payments := filepath.Join(base, "services", "payments", "spectr")
+	ledger := filepath.Join(base, "services", "ledger", "spectr")
+
+	writeSpec(t, local, "auth")
+	writeSpec(t, vendor, "invoices")
+	writeSpec(t, vendor, "auth")
+	writeSpec(t, payments, "refunds")
+	writeSpec(t, payments, "invoices")
+	writeSpec(t, payments, "accounts")
+	writeSpec(t, ledger, "accounts")
+
+	r := New([]Namespace{
+		{Name: "services/payments", Kind: Sibling, SpectrDir: payments},
+		{Name: "services/ledger", Kind: Sibling, SpectrDir: ledger},
+		{Name: "billing", Kind: Vendored, SpectrDir: vendor},
+		{Name: "app", Kind: Local, SpectrDir: local},
+	})
+
+	tests := []struct {
+		target    string
+		namespace string
+	}{
+		// Local wins over the vendored copy
+		{"auth", "app"},
+		// Vendored wins over siblings
+		{"invoices", "billing"},
+		{"refunds", "services/payments"},
+		{"services/payments:invoices", "services/payments"},
+		{"billing:auth", "billing"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			res, err := r.Resolve(tt.target)
+			if err != nil {
+				t.Fatalf("Resolve() error = %v", err)
+			}
+			if !res.Found() || res.Namespace != tt.namespace {
+				t.Errorf("Resolve(%q) = %+v, want namespace %s", tt.target, res, tt.namespace)
+			}
+		})
+	}
+
+	t.Run("ambiguous", func(t *testing.T) {
+		_, err := r.Resolve("accounts")
+		var ambiguous *specterrs.AmbiguousWikilinkError
+		if !errors.As(err, &ambiguous) {
+			t.Fatalf("Resolve() error = %v, want AmbiguousWikilinkError", err)
+		}
+		if len(ambiguous.Namespaces) != 2 {
+			t.Errorf("namespaces = %v", ambiguous.Namespaces)
+		}
+	})
+
+	t.Run("unknown namespace", func(t *testing.T) {
+		_, err := r.Resolve("shipping:rates")
+		var unknown *specterrs.UnknownWikilinkNamespaceError
+		if !errors.As(err, &unknown) {
+			t.Errorf("Resolve() error = %v, want UnknownWikilinkNamespaceError", err)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		res, err := r.Resolve("missing")
+		if err != nil || res.Found() {
+			t.Fatalf("Resolve() = %+v, %v", res, err)
+		}
+		if len(res.Attempts) != 4 || res.Attempts[0].Namespace != "app" {
+			t.Errorf("attempts = %+v, want all four namespaces, local first", res.Attempts)
+		}
+	})
+}
+
+func TestFunc_ValidateWikilinks(t *testing.T) {
+	base := t.TempDir()
+	local := filepath.Join(base, "app", "spectr")
+	payments := filepath.Join(base, "payments", "spectr")
+	writeSpec(t, local, "auth")
+	writeSpec(t, payments, "refunds")
+
+	r := New([]Namespace{
+		{Name: "app", Kind: Local, SpectrDir: local},
+		{Name: "payments", Kind: Sibling, SpectrDir: payments},
+	})
+
+	source := []byte("See [[auth]], [[payments:refunds#Requirement: Refunds]], " +
+		"[[payments:refunds#Requirement: Chargebacks]] and [[shipping:rates]].\n")
+	root, _ := markdown.Parse(source)
+	errs := markdown.ValidateWikilinksWith(root, r.Func())
+	if len(errs) != 2 {
+		t.Fatalf("ValidateWikilinksWith() = %v, want 2 errors", errs)
+	}
+}
+
+func TestResolve_Renamed(t *testing.T) {
+	local := filepath.Join(t.TempDir(), "spectr")
+	writeSpec(t, local, "identity/auth")
+	aliases := "specs:\n  auth: identity/auth\n"
+	if err := os.WriteFile(filepath.Join(local, "aliases.yaml"), []byte(aliases), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	r := New([]Namespace{{Name: "app", Kind: Local, SpectrDir: local}})
+	tests := []struct {
+		target  string
+		renamed string
+	}{
+		{"auth", "identity/auth"},
+		{"specs/auth#Requirement: Refunds", "specs/identity/auth#Requirement: Refunds"},
+		{"identity/auth", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			res, err := r.Resolve(tt.target)
+			if err != nil {
+				t.Fatalf("Resolve() error = %v", err)
+			}
+			if !res.Found() || res.Renamed != tt.renamed {
+				t.Errorf("Resolve(%q) = %+v, want renamed %q", tt.target, res, tt.renamed)
+			}
+		})
+	}
+
+	res, err := r.Resolve("changes/auth")
+	if err != nil || res.Found() {
+		t.Errorf("Resolve(changes/auth) = %+v, %v; want not found", res, err)
+	}
+}
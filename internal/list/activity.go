@@ -0,0 +1,151 @@
+package list
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Relative time thresholds used by FormatRelativeTime.
+const (
+	hoursPerDay   = 24
+	daysPerWeek   = 7
+	daysPerMonth  = 30
+	daysPerYear   = 365
+	weeksShownMax = 8
+)
+
+// noActivity is shown when a change has no readable files.
+const noActivity = "-"
+
+// timeNow is the clock used for relative times; tests replace it.
+var timeNow = time.Now
+
+// ActivityScanner finds the most recent modification time of the files in
+// a change directory (proposal, design, tasks and spec deltas). Results are
+// cached per directory, so listing the same change for several views walks
+// it only once.
+type ActivityScanner struct {
+	mu    sync.Mutex
+	cache map[string]time.Time
+}
+
+// NewActivityScanner creates an ActivityScanner with an empty cache.
+func NewActivityScanner() *ActivityScanner {
+	return &ActivityScanner{cache: make(map[string]time.Time)}
+}
+
+// LastActivity returns the latest file modification time under changeDir,
+// or the zero time if it has no readable files.
+func (s *ActivityScanner) LastActivity(changeDir string) time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if latest, ok := s.cache[changeDir]; ok {
+		return latest
+	}
+
+	var latest time.Time
+	_ = filepath.WalkDir(
+		changeDir,
+		func(_ string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return nil
+			}
+			info, err := d.Info()
+			if err == nil && info.ModTime().After(latest) {
+				latest = info.ModTime()
+			}
+
+			return nil
+		},
+	)
+	s.cache[changeDir] = latest
+
+	return latest
+}
+
+// Invalidate drops the cached result for changeDir.
+func (s *ActivityScanner) Invalidate(changeDir string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.cache, changeDir)
+}
+
+// FormatRelativeTime renders t relative to now for people, e.g. "just now",
+// "5m ago", "3h ago", "3d ago", "2w ago", "4mo ago" or "1y ago". The zero
+// time renders as "-".
+func FormatRelativeTime(t, now time.Time) string {
+	if t.IsZero() {
+		return noActivity
+	}
+
+	d := now.Sub(t)
+	days := int(d.Hours()) / hoursPerDay
+
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < hoursPerDay*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	case days < 2*daysPerWeek:
+		return fmt.Sprintf("%dd ago", days)
+	case days < weeksShownMax*daysPerWeek:
+		return fmt.Sprintf("%dw ago", days/daysPerWeek)
+	case days < daysPerYear:
+		return fmt.Sprintf("%dmo ago", days/daysPerMonth)
+	default:
+		return fmt.Sprintf("%dy ago", days/daysPerYear)
+	}
+}
+
+// lastActivityText renders a change's last activity relative to the clock.
+func lastActivityText(change ChangeInfo) string {
+	return FormatRelativeTime(change.LastActivity, timeNow())
+}
+
+// SortChangesByActivity orders changes with the least recently active
+// first, so stale changes surface at the top. Changes without activity
+// come last; ties are ordered by ID.
+func SortChangesByActivity(changes []ChangeInfo) {
+	sort.SliceStable(changes, activityLess(changes))
+}
+
+// sortedByActivity reports whether changes are already in activity order.
+func sortedByActivity(changes []ChangeInfo) bool {
+	return sort.SliceIsSorted(changes, activityLess(changes))
+}
+
+// activityLess is the ordering used by SortChangesByActivity.
+func activityLess(changes []ChangeInfo) func(i, j int) bool {
+	return func(i, j int) bool {
+		a, b := changes[i].LastActivity, changes[j].LastActivity
+		switch {
+		case a.IsZero() != b.IsZero():
+			return b.IsZero()
+		case !a.Equal(b):
+			return a.Before(b)
+		default:
+			return changes[i].ID < changes[j].ID
+		}
+	}
+}
+
+// sortChanges orders changes for the text formatters according to mode.
+func sortChanges(changes []ChangeInfo, mode FormatMode) {
+	if mode.SortsByActivity() {
+		SortChangesByActivity(changes)
+
+		return
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].ID < changes[j].ID
+	})
+}
@@ -0,0 +1,193 @@
+package list
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/connerohnesorge/spectr/internal/parsers"
+)
+
+func TestFormatRelativeTime(t *testing.T) {
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		ago  time.Duration
+		want string
+	}{
+		{30 * time.Second, "just now"},
+		{5 * time.Minute, "5m ago"},
+		{3 * time.Hour, "3h ago"},
+		{3 * 24 * time.Hour, "3d ago"},
+		{13 * 24 * time.Hour, "13d ago"},
+		{20 * 24 * time.Hour, "2w ago"},
+		{120 * 24 * time.Hour, "4mo ago"},
+		{800 * 24 * time.Hour, "2y ago"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := FormatRelativeTime(now.Add(-tt.ago), now); got != tt.want {
+				t.Errorf("FormatRelativeTime(-%v) = %q, want %q", tt.ago, got, tt.want)
+			}
+		})
+	}
+
+	if got := FormatRelativeTime(time.Time{}, now); got != noActivity {
+		t.Errorf("zero time = %q, want %q", got, noActivity)
+	}
+}
+
+func TestActivityScannerLastActivity(t *testing.T) {
+	changeDir := t.TempDir()
+	old := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	recent := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	files := map[string]time.Time{
+		"proposal.md":           old,
+		"tasks.md":              old,
+		"specs/auth/spec.md":    recent,
+		"specs/billing/spec.md": old,
+	}
+	for name, mtime := range files {
+		path := filepath.Join(changeDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("x"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	scanner := NewActivityScanner()
+	if got := scanner.LastActivity(changeDir); !got.Equal(recent) {
+		t.Fatalf("LastActivity() = %v, want %v", got, recent)
+	}
+
+	// Cached until invalidated
+	newer := recent.Add(time.Hour)
+	proposal := filepath.Join(changeDir, "proposal.md")
+	if err := os.Chtimes(proposal, newer, newer); err != nil {
+		t.Fatal(err)
+	}
+	if got := scanner.LastActivity(changeDir); !got.Equal(recent) {
+		t.Errorf("cached LastActivity() = %v, want %v", got, recent)
+	}
+	scanner.Invalidate(changeDir)
+	if got := scanner.LastActivity(changeDir); !got.Equal(newer) {
+		t.Errorf("LastActivity() after Invalidate = %v, want %v", got, newer)
+	}
+
+	if got := scanner.LastActivity(filepath.Join(changeDir, "missing")); !got.IsZero() {
+		t.Errorf("missing directory activity = %v, want zero", got)
+	}
+}
+
+func TestSortChangesByActivity(t *testing.T) {
+	base := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	changes := []ChangeInfo{
+		{ID: "recent", LastActivity: base.Add(48 * time.Hour)},
+		{ID: "unknown"},
+		{ID: "stale-b", LastActivity: base},
+		{ID: "stale-a", LastActivity: base},
+	}
+
+	SortChangesByActivity(changes)
+
+	got := make([]string, len(changes))
+	for i, c := range changes {
+		got[i] = c.ID
+	}
+	want := "stale-a stale-b recent unknown"
+	if strings.Join(got, " ") != want {
+		t.Errorf("order = %v, want %s", got, want)
+	}
+	if !sortedByActivity(changes) {
+		t.Error("sortedByActivity() = false after sorting")
+	}
+}
+
+func TestFormatChangesWithActivity(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	t.Cleanup(func() { timeNow = time.Now })
+
+	changes := []ChangeInfo{
+		{
+			ID:           "add-2fa",
+			Title:        "Add 2FA",
+			TaskStatus:   parsers.TaskStatus{Completed: 2, Total: 5},
+			LastActivity: now.Add(-3 * 24 * time.Hour),
+		},
+		{
+			ID:           "fix-login",
+			Title:        "Fix login",
+			TaskStatus:   parsers.TaskStatus{Completed: 10, Total: 12},
+			LastActivity: now.Add(-20 * 24 * time.Hour),
+		},
+	}
+
+	text := FormatChangesTextMulti(changes, FormatModeSingle|FormatSortByActivity)
+	wantText := "fix-login  10/12 tasks  2w ago\n" +
+		"add-2fa    2/5 tasks    3d ago"
+	if text != wantText {
+		t.Errorf("text =\n%s\nwant\n%s", text, wantText)
+	}
+
+	long := FormatChangesLongMulti(changes, FormatModeSingle)
+	if !strings.HasPrefix(long, "add-2fa: Add 2FA [deltas 0] [tasks 2/5] [active 3d ago]") {
+		t.Errorf("long output = %q", long)
+	}
+}
+
+func TestCalculateChangesColumns_Wide(t *testing.T) {
+	for _, width := range []int{breakpointWide, 150, 200} {
+		cols := calculateChangesColumns(width, LineNumberOff)
+		if len(cols) != 5 || cols[4].Title != columnTitleActivity {
+			t.Errorf("width %d: columns = %+v, want Last activity as 5th", width, cols)
+		}
+		if hasHiddenColumns(itemTypeChange, width) {
+			t.Errorf("width %d: hasHiddenColumns() = true", width)
+		}
+
+		rows := buildChangesRows(
+			[]ChangeInfo{{ID: "x", Title: "X"}},
+			changeTitleTruncate,
+			len(cols),
+			LineNumberOff,
+			0,
+		)
+		if len(rows[0]) != 5 || rows[0][4] != noActivity {
+			t.Errorf("width %d: row = %v", width, rows[0])
+		}
+	}
+}
+
+func TestBuildChangesRows_StaleBadge(t *testing.T) {
+	changes := []ChangeInfo{
+		{ID: "idle", TaskStatus: parsers.TaskStatus{Completed: 1, Total: 3}, Stale: true},
+		{ID: "busy", TaskStatus: parsers.TaskStatus{Completed: 2, Total: 3}},
+	}
+
+	for _, numColumns := range []int{2, 3, 4, 5} {
+		rows := buildChangesRows(changes, changeTitleTruncate, numColumns, LineNumberOff, 0)
+		tasksCol := 1
+		if numColumns > 2 {
+			tasksCol = numColumns - 1
+			if numColumns == 5 {
+				tasksCol = 3
+			}
+		}
+		if got := rows[0][tasksCol]; got != "1/3 [stale]" {
+			t.Errorf("%d columns: stale tasks cell = %q", numColumns, got)
+		}
+		if got := rows[1][tasksCol]; got != "2/3" {
+			t.Errorf("%d columns: tasks cell = %q", numColumns, got)
+		}
+	}
+}
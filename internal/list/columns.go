@@ -0,0 +1,495 @@
+package list
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/connerohnesorge/spectr/internal/parsers"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+// Column names accepted by --columns.
+const (
+	ColumnID           = "id"
+	ColumnTitle        = "title"
+	ColumnDeltas       = "deltas"
+	ColumnTasks        = "tasks"
+	ColumnOwner        = "owner"
+	ColumnLastActivity = "last-activity"
+	ColumnRequirements = "requirements"
+	ColumnStatus       = "status"
+	ColumnQuality      = "quality"
+
+	// emptyCell stands in for a missing value in column output
+	emptyCell = "-"
+)
+
+// ChangeColumns lists the columns available when listing changes.
+var ChangeColumns = []string{
+	ColumnID,
+	ColumnTitle,
+	ColumnDeltas,
+	ColumnTasks,
+	ColumnOwner,
+	ColumnLastActivity,
+}
+
+// SpecColumns lists the columns available when listing specs.
+var SpecColumns = []string{
+	ColumnID,
+	ColumnTitle,
+	ColumnRequirements,
+	ColumnStatus,
+}
+
+// OptionalSpecColumns are spec columns that are only shown when asked for
+// by name, as they are slow to compute: the quality score scans the
+// project for implementation markers. CSV and TSV output of all columns
+// leaves them out.
+var OptionalSpecColumns = []string{
+	ColumnQuality,
+}
+
+// ParseColumns normalizes column names and checks them against supported.
+// Names are case-insensitive and blank entries are ignored, so an empty
+// result means no columns were requested.
+func ParseColumns(names, supported []string) ([]string, error) {
+	columns := make([]string, 0, len(names))
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		known := false
+		for _, s := range supported {
+			if s == name {
+				known = true
+
+				break
+			}
+		}
+		if !known {
+			return nil, &specterrs.UnknownColumnError{
+				Column:    name,
+				Supported: supported,
+			}
+		}
+
+		columns = append(columns, name)
+	}
+
+	return columns, nil
+}
+
+// FormatChangesColumns renders changes as a table with the given columns
+// (see ChangeColumns) under a header row.
+func FormatChangesColumns(
+	changes []ChangeInfo,
+	columns []string,
+	mode FormatMode,
+) string {
+	if len(changes) == 0 {
+		return noItemsFoundMsg
+	}
+
+	sortChanges(changes, mode)
+
+	return formatColumnsText(changes, columns, func(c ChangeInfo, col string) string {
+		text, _ := changeCell(c, col, mode)
+
+		return text
+	})
+}
+
+// FormatChangesColumnsJSON renders changes as JSON objects holding only the
+// given columns, keyed by column name in column order.
+func FormatChangesColumnsJSON(
+	changes []ChangeInfo,
+	columns []string,
+) (string, error) {
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].ID < changes[j].ID
+	})
+
+	return formatColumnsJSON(changes, columns, func(c ChangeInfo, col string) any {
+		_, value := changeCell(c, col, FormatModeSingle)
+
+		return value
+	})
+}
+
+// FormatSpecsColumns renders specs as a table with the given columns
+// (see SpecColumns) under a header row.
+func FormatSpecsColumns(
+	specs []SpecInfo,
+	columns []string,
+	mode FormatMode,
+) string {
+	if len(specs) == 0 {
+		return noItemsFoundMsg
+	}
+
+	sort.Slice(specs, func(i, j int) bool {
+		return specs[i].ID < specs[j].ID
+	})
+
+	return formatColumnsText(specs, columns, func(s SpecInfo, col string) string {
+		text, _ := specCell(s, col, mode)
+
+		return text
+	})
+}
+
+// FormatSpecsColumnsJSON renders specs as JSON objects holding only the
+// given columns, keyed by column name in column order.
+func FormatSpecsColumnsJSON(
+	specs []SpecInfo,
+	columns []string,
+) (string, error) {
+	sort.Slice(specs, func(i, j int) bool {
+		return specs[i].ID < specs[j].ID
+	})
+
+	return formatColumnsJSON(specs, columns, func(s SpecInfo, col string) any {
+		_, value := specCell(s, col, FormatModeSingle)
+
+		return value
+	})
+}
+
+// changeCell returns a change's column as display text and as a JSON value.
+func changeCell(
+	change ChangeInfo,
+	column string,
+	mode FormatMode,
+) (string, any) {
+	switch column {
+	case ColumnID:
+		return FormatItemWithRoot(change.RootPath, change.ID, mode), change.ID
+	case ColumnTitle:
+		return change.Title, change.Title
+	case ColumnDeltas:
+		return fmt.Sprintf("%d", change.DeltaCount), change.DeltaCount
+	case ColumnTasks:
+		return fmt.Sprintf(
+			"%d/%d",
+			change.TaskStatus.Completed,
+			change.TaskStatus.Total,
+		), change.TaskStatus
+	case ColumnOwner:
+		if change.Owner == "" {
+			return emptyCell, nil
+		}
+
+		return change.Owner, change.Owner
+	case ColumnLastActivity:
+		if change.LastActivity.IsZero() {
+			return lastActivityText(change), nil
+		}
+
+		return lastActivityText(change), change.LastActivity
+	default:
+		return "", nil
+	}
+}
+
+// specCell returns a spec's column as display text and as a JSON value.
+func specCell(
+	spec SpecInfo,
+	column string,
+	mode FormatMode,
+) (string, any) {
+	switch column {
+	case ColumnID:
+		return FormatItemWithRoot(spec.RootPath, spec.ID, mode), spec.ID
+	case ColumnTitle:
+		return spec.Title, spec.Title
+	case ColumnRequirements:
+		return fmt.Sprintf("%d", spec.RequirementCount), spec.RequirementCount
+	case ColumnStatus:
+		breakdown := parsers.FormatStatusBreakdown(spec.StatusCounts)
+		if breakdown == "" {
+			return emptyCell, nil
+		}
+
+		return breakdown, spec.StatusCounts
+	case ColumnQuality:
+		if spec.Quality == nil {
+			return emptyCell, nil
+		}
+
+		return strconv.Itoa(spec.Quality.Score), spec.Quality
+	default:
+		return "", nil
+	}
+}
+
+// formatColumnsText writes a header row and one row per item through a
+// tabwriter, e.g. "ID  TASKS  LAST ACTIVITY".
+func formatColumnsText[T any](
+	items []T,
+	columns []string,
+	cell func(T, string) string,
+) string {
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+
+	header := make([]string, len(columns))
+	for i, col := range columns {
+		header[i] = strings.ToUpper(strings.ReplaceAll(col, "-", " "))
+	}
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+
+	cells := make([]string, len(columns))
+	for _, item := range items {
+		for i, col := range columns {
+			cells[i] = cell(item, col)
+		}
+		fmt.Fprintln(w, strings.Join(cells, "\t"))
+	}
+	_ = w.Flush()
+
+	return trimTrailingSpaces(b.String())
+}
+
+// formatColumnsJSON encodes one object per item with keys in column order.
+func formatColumnsJSON[T any](
+	items []T,
+	columns []string,
+	value func(T, string) any,
+) (string, error) {
+	rows := make([]columnRow, len(items))
+	for i, item := range items {
+		values := make([]any, len(columns))
+		for j, col := range columns {
+			values[j] = value(item, col)
+		}
+		rows[i] = columnRow{columns: columns, values: values}
+	}
+
+	data, err := json.MarshalIndent(rows, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf(
+			"failed to marshal JSON: %w",
+			err,
+		)
+	}
+
+	return string(data), nil
+}
+
+// columnRow is a JSON object whose keys keep their column order, which a
+// map would not.
+type columnRow struct {
+	columns []string
+	values  []any
+}
+
+// MarshalJSON implements json.Marshaler.
+func (r columnRow) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, col := range r.columns {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+
+		key, err := json.Marshal(col)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(r.values[i])
+		if err != nil {
+			return nil, err
+		}
+
+		b.Write(key)
+		b.WriteByte(':')
+		b.Write(value)
+	}
+	b.WriteByte('}')
+
+	return b.Bytes(), nil
+}
+
+// trimTrailingSpaces drops the trailing newline and the padding tabwriter
+// leaves after the last cell of each line.
+func trimTrailingSpaces(s string) string {
+	lines := strings.Split(
+		strings.TrimSuffix(s, lineSeparator),
+		lineSeparator,
+	)
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+
+	return strings.Join(lines, lineSeparator)
+}
+
+// delimitedRootHeader names the leading column that delimited output adds
+// in multi-root mode, so spreadsheets can filter by root.
+const delimitedRootHeader = "root"
+
+// FormatChangesDelimited renders changes as CSV (comma ',') or TSV (comma
+// '\t') with a header row. Values are kept machine-friendly: tasks become
+// tasks-completed and tasks-total columns (a spreadsheet would read "2/5"
+// as a date) and last-activity is an RFC 3339 timestamp.
+func FormatChangesDelimited(
+	changes []ChangeInfo,
+	columns []string,
+	mode FormatMode,
+	comma rune,
+) (string, error) {
+	sortChanges(changes, mode)
+
+	return formatDelimited(
+		changes,
+		columns,
+		mode,
+		comma,
+		func(c ChangeInfo) string { return c.RootPath },
+		changeFields,
+	)
+}
+
+// FormatSpecsDelimited renders specs as CSV or TSV with a header row, like
+// FormatChangesDelimited.
+func FormatSpecsDelimited(
+	specs []SpecInfo,
+	columns []string,
+	mode FormatMode,
+	comma rune,
+) (string, error) {
+	sort.Slice(specs, func(i, j int) bool {
+		return specs[i].ID < specs[j].ID
+	})
+
+	return formatDelimited(
+		specs,
+		columns,
+		mode,
+		comma,
+		func(s SpecInfo) string { return s.RootPath },
+		specFields,
+	)
+}
+
+// changeFields returns the header names (nil change) or values of a
+// change column for delimited output.
+func changeFields(change *ChangeInfo, column string) []string {
+	if column == ColumnTasks {
+		if change == nil {
+			return []string{"tasks-completed", "tasks-total"}
+		}
+
+		return []string{
+			strconv.Itoa(change.TaskStatus.Completed),
+			strconv.Itoa(change.TaskStatus.Total),
+		}
+	}
+
+	if change == nil {
+		return []string{column}
+	}
+
+	switch column {
+	case ColumnID:
+		return []string{change.ID}
+	case ColumnTitle:
+		return []string{change.Title}
+	case ColumnDeltas:
+		return []string{strconv.Itoa(change.DeltaCount)}
+	case ColumnOwner:
+		return []string{change.Owner}
+	case ColumnLastActivity:
+		if change.LastActivity.IsZero() {
+			return []string{""}
+		}
+
+		return []string{change.LastActivity.Format(time.RFC3339)}
+	default:
+		return []string{""}
+	}
+}
+
+// specFields returns the header names (nil spec) or values of a spec
+// column for delimited output.
+func specFields(spec *SpecInfo, column string) []string {
+	if spec == nil {
+		return []string{column}
+	}
+
+	switch column {
+	case ColumnID:
+		return []string{spec.ID}
+	case ColumnTitle:
+		return []string{spec.Title}
+	case ColumnRequirements:
+		return []string{strconv.Itoa(spec.RequirementCount)}
+	case ColumnStatus:
+		return []string{parsers.FormatStatusBreakdown(spec.StatusCounts)}
+	case ColumnQuality:
+		if spec.Quality == nil {
+			return []string{""}
+		}
+
+		return []string{strconv.Itoa(spec.Quality.Score)}
+	default:
+		return []string{""}
+	}
+}
+
+// formatDelimited writes a header row and one record per item. fields
+// returns a column's header names when called with nil.
+func formatDelimited[T any](
+	items []T,
+	columns []string,
+	mode FormatMode,
+	comma rune,
+	rootPath func(T) string,
+	fields func(*T, string) []string,
+) (string, error) {
+	var b strings.Builder
+	w := csv.NewWriter(&b)
+	w.Comma = comma
+
+	record := func(item *T) []string {
+		var rec []string
+		if mode.IsMulti() {
+			if item == nil {
+				rec = append(rec, delimitedRootHeader)
+			} else {
+				rec = append(rec, cmp.Or(rootPath(*item), currentDirPath))
+			}
+		}
+		for _, col := range columns {
+			rec = append(rec, fields(item, col)...)
+		}
+
+		return rec
+	}
+
+	if err := w.Write(record(nil)); err != nil {
+		return "", fmt.Errorf("failed to write header: %w", err)
+	}
+	for i := range items {
+		if err := w.Write(record(&items[i])); err != nil {
+			return "", fmt.Errorf("failed to write record: %w", err)
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return "", fmt.Errorf("failed to write records: %w", err)
+	}
+
+	return strings.TrimSuffix(b.String(), lineSeparator), nil
+}
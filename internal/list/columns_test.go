@@ -0,0 +1,257 @@
+package list
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/connerohnesorge/spectr/internal/parsers"
+	"github.com/connerohnesorge/spectr/internal/quality"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+func TestParseColumns(t *testing.T) {
+	tests := []struct {
+		name    string
+		names   []string
+		want    string
+		wantErr string
+	}{
+		{"ordered", []string{"owner", "id"}, "owner,id", ""},
+		{"normalized", []string{" ID ", "", "Last-Activity"}, "id,last-activity", ""},
+		{"empty", nil, "", ""},
+		{"unknown", []string{"id", "requirements"}, "", "requirements"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseColumns(tt.names, ChangeColumns)
+			if tt.wantErr != "" {
+				var colErr *specterrs.UnknownColumnError
+				if !errors.As(err, &colErr) || colErr.Column != tt.wantErr {
+					t.Fatalf("err = %v, want unknown column %q", err, tt.wantErr)
+				}
+				if !strings.Contains(err.Error(), "last-activity") {
+					t.Errorf("error should list supported columns: %v", err)
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if joined := strings.Join(got, ","); joined != tt.want {
+				t.Errorf("ParseColumns() = %q, want %q", joined, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatChangesColumns(t *testing.T) {
+	restore := timeNow
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	t.Cleanup(func() { timeNow = restore })
+
+	changes := []ChangeInfo{
+		{
+			ID:           "b-change",
+			TaskStatus:   parsers.TaskStatus{Completed: 1, Total: 4},
+			LastActivity: now.Add(-3 * 24 * time.Hour),
+		},
+		{
+			ID:         "a-change",
+			Owner:      "payments-team",
+			TaskStatus: parsers.TaskStatus{Completed: 2, Total: 2},
+		},
+	}
+
+	got := FormatChangesColumns(
+		changes,
+		[]string{ColumnID, ColumnTasks, ColumnOwner, ColumnLastActivity},
+		FormatModeSingle,
+	)
+	want := strings.Join([]string{
+		"ID        TASKS  OWNER          LAST ACTIVITY",
+		"a-change  2/2    payments-team  -",
+		"b-change  1/4    -              3d ago",
+	}, "\n")
+	if got != want {
+		t.Errorf("FormatChangesColumns() =\n%s\nwant\n%s", got, want)
+	}
+
+	if got := FormatChangesColumns(nil, ChangeColumns, FormatModeSingle); got != noItemsFoundMsg {
+		t.Errorf("empty = %q, want %q", got, noItemsFoundMsg)
+	}
+}
+
+func TestFormatChangesColumnsJSON(t *testing.T) {
+	changes := []ChangeInfo{
+		{ID: "add-auth", Owner: "alice", DeltaCount: 2},
+		{ID: "add-billing", DeltaCount: 1},
+	}
+
+	got, err := FormatChangesColumnsJSON(
+		changes,
+		[]string{ColumnOwner, ColumnID, ColumnDeltas},
+	)
+	if err != nil {
+		t.Fatalf("FormatChangesColumnsJSON: %v", err)
+	}
+
+	// Keys keep column order rather than being sorted
+	if !strings.Contains(got, "\"owner\": \"alice\",\n    \"id\": \"add-auth\"") {
+		t.Errorf("keys out of column order:\n%s", got)
+	}
+
+	var rows []map[string]any
+	if err := json.Unmarshal([]byte(got), &rows); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(rows) != 2 || len(rows[0]) != 3 {
+		t.Fatalf("rows = %v", rows)
+	}
+	if rows[1]["owner"] != nil || rows[1]["deltas"] != float64(1) {
+		t.Errorf("second row = %v", rows[1])
+	}
+}
+
+func TestFormatSpecsColumns(t *testing.T) {
+	specs := []SpecInfo{
+		{
+			ID:               "payments/refunds",
+			RequirementCount: 3,
+			StatusCounts: map[parsers.RequirementStatus]int{
+				parsers.RequirementStatusDraft: 2,
+			},
+		},
+		{ID: "auth", RequirementCount: 1},
+	}
+
+	got := FormatSpecsColumns(
+		specs,
+		[]string{ColumnID, ColumnRequirements, ColumnStatus},
+		FormatModeSingle,
+	)
+	want := strings.Join([]string{
+		"ID                REQUIREMENTS  STATUS",
+		"auth              1             -",
+		"payments/refunds  3             2 draft",
+	}, "\n")
+	if got != want {
+		t.Errorf("FormatSpecsColumns() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestFormatSpecsColumns_Quality(t *testing.T) {
+	specs := []SpecInfo{
+		{ID: "auth", Quality: &quality.Report{Score: 82}},
+		{ID: "billing"},
+	}
+	columns, err := ParseColumns(
+		[]string{"id", "quality"},
+		append(SpecColumns, OptionalSpecColumns...),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := FormatSpecsColumns(specs, columns, FormatModeSingle)
+	want := strings.Join([]string{
+		"ID       QUALITY",
+		"auth     82",
+		"billing  -",
+	}, "\n")
+	if got != want {
+		t.Errorf("FormatSpecsColumns() =\n%s\nwant\n%s", got, want)
+	}
+
+	if _, err := ParseColumns([]string{"quality"}, SpecColumns); err == nil {
+		t.Error("quality should not be a default spec column")
+	}
+}
+
+func TestFormatChangesDelimited(t *testing.T) {
+	activity := time.Date(2025, 6, 12, 9, 30, 0, 0, time.UTC)
+	changes := []ChangeInfo{
+		{
+			ID:           "add-auth",
+			Title:        "Add auth, with 2FA",
+			TaskStatus:   parsers.TaskStatus{Completed: 2, Total: 5},
+			LastActivity: activity,
+		},
+		{ID: "add-billing", Title: "Add billing", RootPath: "services/api"},
+	}
+
+	tests := []struct {
+		name  string
+		mode  FormatMode
+		comma rune
+		want  string
+	}{
+		{
+			name:  "csv",
+			mode:  FormatModeSingle,
+			comma: ',',
+			want: "id,title,tasks-completed,tasks-total,last-activity\n" +
+				"add-auth,\"Add auth, with 2FA\",2,5,2025-06-12T09:30:00Z\n" +
+				"add-billing,Add billing,0,0,",
+		},
+		{
+			name:  "tsv multi-root",
+			mode:  FormatModeMulti,
+			comma: '\t',
+			want: "root\tid\ttitle\ttasks-completed\ttasks-total\tlast-activity\n" +
+				".\tadd-auth\tAdd auth, with 2FA\t2\t5\t2025-06-12T09:30:00Z\n" +
+				"services/api\tadd-billing\tAdd billing\t0\t0\t",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FormatChangesDelimited(
+				changes,
+				[]string{ColumnID, ColumnTitle, ColumnTasks, ColumnLastActivity},
+				tt.mode,
+				tt.comma,
+			)
+			if err != nil {
+				t.Fatalf("FormatChangesDelimited: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatSpecsDelimited(t *testing.T) {
+	specs := []SpecInfo{
+		{
+			ID:               "auth",
+			RequirementCount: 3,
+			StatusCounts: map[parsers.RequirementStatus]int{
+				parsers.RequirementStatusDraft:    1,
+				parsers.RequirementStatusApproved: 2,
+			},
+		},
+	}
+
+	got, err := FormatSpecsDelimited(specs, SpecColumns, FormatModeSingle, ',')
+	if err != nil {
+		t.Fatalf("FormatSpecsDelimited: %v", err)
+	}
+
+	want := "id,title,requirements,status\n" +
+		"auth,,3,\"1 draft, 2 approved\""
+	if got != want {
+		t.Errorf("got\n%q\nwant\n%q", got, want)
+	}
+
+	empty, err := FormatSpecsDelimited(nil, SpecColumns, FormatModeSingle, ',')
+	if err != nil || empty != "id,title,requirements,status" {
+		t.Errorf("empty = %q, %v; want header only", empty, err)
+	}
+}
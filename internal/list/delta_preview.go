@@ -0,0 +1,302 @@
+package list
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/connerohnesorge/spectr/internal/fileio"
+	"github.com/connerohnesorge/spectr/internal/parsers"
+)
+
+const (
+	// deltaPreviewMinHeight is the viewport height used before the
+	// terminal size is known or when the terminal is very short.
+	deltaPreviewMinHeight = 10
+	// deltaPreviewChrome is the number of lines the footer needs below
+	// the preview viewport.
+	deltaPreviewChrome = 3
+	// deltaPreviewDefaultWidth is used before the terminal size is known.
+	deltaPreviewDefaultWidth = 80
+
+	deltaPreviewHelp = "↑/↓/j/k: scroll | d/esc: back | q: quit"
+)
+
+var (
+	deltaAddedStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("2")).
+			Bold(true)
+	deltaModifiedStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("3")).
+				Bold(true)
+	deltaRemovedStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("1")).
+				Bold(true)
+	deltaRenamedStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("6")).
+				Bold(true)
+	deltaSpecStyle = lipgloss.NewStyle().Bold(true)
+)
+
+// SpecDeltas holds the delta operations a change makes to one spec.
+type SpecDeltas struct {
+	SpecID   string
+	Added    []string
+	Modified []string
+	Removed  []string
+	Renamed  []parsers.RenameOp
+}
+
+// Count returns the number of operations on the spec.
+func (s SpecDeltas) Count() int {
+	return len(s.Added) + len(s.Modified) + len(s.Removed) + len(s.Renamed)
+}
+
+// LoadDeltaPreview parses every delta spec under changeDir/specs and
+// groups the operations by target spec, sorted by spec ID.
+func LoadDeltaPreview(changeDir string) ([]SpecDeltas, error) {
+	specsDir := filepath.Join(changeDir, "specs")
+	if _, err := os.Stat(specsDir); os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	var specs []SpecDeltas
+	err := fileio.WalkDir(
+		specsDir,
+		func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() || d.Name() != "spec.md" {
+				return nil
+			}
+
+			plan, err := parsers.ParseDeltaSpec(path)
+			if err != nil {
+				return fmt.Errorf("parse %s: %w", path, err)
+			}
+
+			specID, err := filepath.Rel(specsDir, filepath.Dir(path))
+			if err != nil {
+				return err
+			}
+			specs = append(specs, specDeltasFromPlan(
+				filepath.ToSlash(specID),
+				plan,
+			))
+
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(specs, func(i, j int) bool {
+		return specs[i].SpecID < specs[j].SpecID
+	})
+
+	return specs, nil
+}
+
+func specDeltasFromPlan(specID string, plan *parsers.DeltaPlan) SpecDeltas {
+	s := SpecDeltas{
+		SpecID:  specID,
+		Removed: plan.Removed,
+		Renamed: plan.Renamed,
+	}
+	for _, req := range plan.Added {
+		s.Added = append(s.Added, req.Name)
+	}
+	for _, req := range plan.Modified {
+		s.Modified = append(s.Modified, req.Name)
+	}
+
+	return s
+}
+
+// RenderDeltaPreview renders the deltas of a change with a summary line,
+// one block per target spec and a colored marker per operation:
+// + added, ~ modified, - removed, → renamed.
+func RenderDeltaPreview(changeID string, specs []SpecDeltas) string {
+	var total SpecDeltas
+	for _, s := range specs {
+		total.Added = append(total.Added, s.Added...)
+		total.Modified = append(total.Modified, s.Modified...)
+		total.Removed = append(total.Removed, s.Removed...)
+		total.Renamed = append(total.Renamed, s.Renamed...)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(
+		&b,
+		"Deltas for %s: %d spec(s), %d operation(s)  %s\n",
+		changeID,
+		len(specs),
+		total.Count(),
+		deltaCounts(total),
+	)
+
+	if len(specs) == 0 {
+		b.WriteString("\nNo delta specs in this change.\n")
+
+		return b.String()
+	}
+
+	for _, s := range specs {
+		b.WriteString("\n" + deltaSpecStyle.Render(s.SpecID))
+		if counts := deltaCounts(s); counts != "" {
+			b.WriteString("  " + counts)
+		}
+		b.WriteString("\n")
+		if s.Count() == 0 {
+			b.WriteString("  (no delta sections)\n")
+
+			continue
+		}
+		writeDeltaLines(&b, deltaAddedStyle, "+", "ADDED", s.Added)
+		writeDeltaLines(&b, deltaModifiedStyle, "~", "MODIFIED", s.Modified)
+		writeDeltaLines(&b, deltaRemovedStyle, "-", "REMOVED", s.Removed)
+		for _, op := range s.Renamed {
+			fmt.Fprintf(
+				&b,
+				"  %s %-8s  %s → %s\n",
+				deltaRenamedStyle.Render("→"),
+				"RENAMED",
+				op.From,
+				op.To,
+			)
+		}
+	}
+
+	return b.String()
+}
+
+func writeDeltaLines(
+	b *strings.Builder,
+	style lipgloss.Style,
+	marker, label string,
+	names []string,
+) {
+	for _, name := range names {
+		fmt.Fprintf(b, "  %s %-8s  %s\n", style.Render(marker), label, name)
+	}
+}
+
+// deltaCounts formats per-operation counts, omitting zeros, e.g.
+// "+2 ~1 -1".
+func deltaCounts(s SpecDeltas) string {
+	var parts []string
+	add := func(style lipgloss.Style, marker string, n int) {
+		if n > 0 {
+			parts = append(parts, style.Render(fmt.Sprintf("%s%d", marker, n)))
+		}
+	}
+	add(deltaAddedStyle, "+", len(s.Added))
+	add(deltaModifiedStyle, "~", len(s.Modified))
+	add(deltaRemovedStyle, "-", len(s.Removed))
+	add(deltaRenamedStyle, "→", len(s.Renamed))
+
+	return strings.Join(parts, " ")
+}
+
+// handleDeltaPreview opens the delta preview for the change under the
+// cursor. It does nothing on spec rows.
+func (m *interactiveModel) handleDeltaPreview() (tea.Model, tea.Cmd) {
+	cursor := m.table.Cursor()
+	if cursor < 0 || cursor >= len(m.table.Rows()) {
+		return m, nil
+	}
+	row := m.table.Rows()[cursor]
+
+	colOffset := 0
+	if m.lineNumberMode != LineNumberOff {
+		colOffset = 1
+	}
+
+	var change *ChangeInfo
+	switch m.itemType {
+	case itemTypeChange:
+		change = m.findChangeForCursor(cursor, row, colOffset)
+	case itemTypeAll:
+		typeColIdx := colOffset + 1
+		if len(row) > typeColIdx && row[typeColIdx] == typeDisplayChange {
+			change = m.findChangeInAllItems(row, colOffset)
+		}
+	}
+	if change == nil {
+		return m, nil
+	}
+
+	changeDir := filepath.Join(
+		change.RootAbsPath,
+		"spectr",
+		"changes",
+		change.ID,
+	)
+	specs, err := LoadDeltaPreview(changeDir)
+	if err != nil {
+		m.err = fmt.Errorf("delta preview: %w", err)
+
+		return m, nil
+	}
+
+	vp := viewport.New(m.deltaPreviewSize())
+	vp.SetContent(RenderDeltaPreview(change.ID, specs))
+	m.deltaPreview = &vp
+	m.err = nil
+
+	return m, nil
+}
+
+// handleDeltaPreviewKey handles keys while the delta preview is open.
+func (m *interactiveModel) handleDeltaPreviewKey(
+	msg tea.KeyMsg,
+) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "d", "esc":
+		m.deltaPreview = nil
+
+		return m, nil
+	case "q", "ctrl+c":
+		m.quitting = true
+
+		return m, tea.Quit
+	}
+
+	vp, cmd := m.deltaPreview.Update(msg)
+	m.deltaPreview = &vp
+
+	return m, cmd
+}
+
+// deltaPreviewSize returns the viewport width and height for the current
+// terminal size.
+func (m *interactiveModel) deltaPreviewSize() (width, height int) {
+	width = m.terminalWidth
+	if width <= 0 {
+		width = deltaPreviewDefaultWidth
+	}
+
+	return width, max(m.terminalHeight-deltaPreviewChrome, deltaPreviewMinHeight)
+}
+
+// viewDeltaPreview renders the open delta preview with its footer.
+func (m *interactiveModel) viewDeltaPreview() string {
+	footer := deltaPreviewHelp
+	if !m.deltaPreview.AtBottom() || m.deltaPreview.YOffset > 0 {
+		footer = fmt.Sprintf(
+			"%3.f%% | %s",
+			m.deltaPreview.ScrollPercent()*100,
+			footer,
+		)
+	}
+
+	return m.deltaPreview.View() + "\n" + footer + "\n"
+}
@@ -0,0 +1,155 @@
+package list
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/connerohnesorge/spectr/internal/parsers"
+)
+
+func writeDeltaSpec(t *testing.T, changeDir, specID, content string) {
+	t.Helper()
+
+	dir := filepath.Join(changeDir, "specs", specID)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "spec.md"), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadDeltaPreview(t *testing.T) {
+	changeDir := t.TempDir()
+	writeDeltaSpec(t, changeDir, "notifications", `## REMOVED Requirements
+
+### Requirement: Legacy Digest
+**Reason**: Replaced
+**Migration**: None
+
+## RENAMED Requirements
+
+- FROM: `+"`### Requirement: Email Alerts`"+`
+- TO: `+"`### Requirement: Email Notifications`"+`
+`)
+	writeDeltaSpec(t, changeDir, "auth", `## ADDED Requirements
+
+### Requirement: Two-Factor Authentication
+The system SHALL support TOTP.
+
+#### Scenario: Enroll
+- **WHEN** a user enrolls
+- **THEN** a secret is stored
+
+### Requirement: Recovery Codes
+The system SHALL issue recovery codes.
+
+#### Scenario: Issue
+- **WHEN** 2FA is enabled
+- **THEN** codes are shown
+
+## MODIFIED Requirements
+
+### Requirement: User Login
+The system SHALL require a second factor.
+
+#### Scenario: Login
+- **WHEN** a user logs in
+- **THEN** a code is requested
+`)
+	writeDeltaSpec(t, changeDir, "billing", "# Notes only\n")
+
+	specs, err := LoadDeltaPreview(changeDir)
+	if err != nil {
+		t.Fatalf("LoadDeltaPreview: %v", err)
+	}
+
+	want := []SpecDeltas{
+		{SpecID: "auth", Added: []string{"Two-Factor Authentication", "Recovery Codes"}, Modified: []string{"User Login"}},
+		{SpecID: "billing"},
+		{
+			SpecID:  "notifications",
+			Removed: []string{"Legacy Digest"},
+			Renamed: []parsers.RenameOp{{From: "Email Alerts", To: "Email Notifications"}},
+		},
+	}
+	if len(specs) != len(want) {
+		t.Fatalf("got %d specs, want %d: %+v", len(specs), len(want), specs)
+	}
+	for i := range want {
+		if specs[i].SpecID != want[i].SpecID || specs[i].Count() != want[i].Count() {
+			t.Errorf("spec %d = %+v, want %+v", i, specs[i], want[i])
+		}
+	}
+
+	out := RenderDeltaPreview("add-2fa", specs)
+	for _, s := range []string{
+		"Deltas for add-2fa: 3 spec(s), 5 operation(s)",
+		"+ ADDED     Recovery Codes",
+		"~ MODIFIED  User Login",
+		"- REMOVED   Legacy Digest",
+		"→ RENAMED   Email Alerts → Email Notifications",
+		"billing\n  (no delta sections)",
+	} {
+		if !strings.Contains(out, s) {
+			t.Errorf("preview missing %q:\n%s", s, out)
+		}
+	}
+}
+
+func TestLoadDeltaPreview_NoSpecs(t *testing.T) {
+	specs, err := LoadDeltaPreview(t.TempDir())
+	if err != nil || len(specs) != 0 {
+		t.Fatalf("LoadDeltaPreview = %v, %v; want empty", specs, err)
+	}
+	if out := RenderDeltaPreview("x", nil); !strings.Contains(out, "No delta specs") {
+		t.Errorf("preview = %q", out)
+	}
+}
+
+func TestInteractiveModel_DeltaPreviewKeys(t *testing.T) {
+	root := t.TempDir()
+	writeDeltaSpec(
+		t,
+		filepath.Join(root, "spectr", "changes", "add-2fa"),
+		"auth",
+		"## ADDED Requirements\n\n### Requirement: Two-Factor Authentication\nText.\n",
+	)
+
+	changes := []ChangeInfo{{ID: "add-2fa", RootAbsPath: root}}
+	m := &interactiveModel{
+		table: table.New(
+			table.WithColumns(calculateChangesColumns(breakpointFull, LineNumberOff)),
+			table.WithRows(buildChangesRows(changes, changeTitleTruncate, 4, LineNumberOff, 0)),
+		),
+		itemType:    itemTypeChange,
+		changesData: changes,
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	if m.deltaPreview == nil {
+		t.Fatalf("d did not open the preview (err: %v)", m.err)
+	}
+	if view := m.View(); !strings.Contains(view, "Two-Factor Authentication") ||
+		!strings.Contains(view, deltaPreviewHelp) {
+		t.Errorf("preview view = %q", view)
+	}
+
+	// Keys other than close and quit go to the viewport, not the table.
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	if m.archiveRequested {
+		t.Error("a archived while the preview was open")
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.deltaPreview != nil {
+		t.Error("esc did not close the preview")
+	}
+	if m.quitting {
+		t.Error("esc quit the TUI")
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/parsers"
 )
 
 const (
@@ -18,14 +20,19 @@ const (
 	currentDirPath = "."
 )
 
-// FormatMode represents the display mode for multi-root formatting.
+// FormatMode represents the display mode for multi-root formatting. The
+// sort flag can be combined with either root mode, e.g.
+// FormatModeMulti|FormatSortByActivity.
 type FormatMode int
 
 const (
 	// FormatModeSingle indicates single-root mode (no prefix).
-	FormatModeSingle FormatMode = iota
+	FormatModeSingle FormatMode = 0
 	// FormatModeMulti indicates multi-root mode (show root prefix).
-	FormatModeMulti
+	FormatModeMulti FormatMode = 1 << 0
+	// FormatSortByActivity orders changes by last activity, stalest first,
+	// instead of by ID.
+	FormatSortByActivity FormatMode = 1 << 1
 )
 
 // NewFormatMode creates a FormatMode based on whether there are multiple roots.
@@ -41,7 +48,12 @@ func NewFormatMode(hasMultipleRoots bool) FormatMode {
 
 // IsMulti returns true if this is multi-root mode.
 func (m FormatMode) IsMulti() bool {
-	return m == FormatModeMulti
+	return m&FormatModeMulti != 0
+}
+
+// SortsByActivity returns true if changes are ordered by last activity.
+func (m FormatMode) SortsByActivity() bool {
+	return m&FormatSortByActivity != 0
 }
 
 // FormatItemWithRoot formats an item ID with a root prefix when in multi-root mode.
@@ -89,6 +101,15 @@ func FormatChangesText(
 	return strings.Join(lines, lineSeparator)
 }
 
+// formatTaskCount renders a change's task progress, e.g. "3/5 tasks".
+func formatTaskCount(change ChangeInfo) string {
+	return fmt.Sprintf(
+		"%d/%d tasks",
+		change.TaskStatus.Completed,
+		change.TaskStatus.Total,
+	)
+}
+
 // FormatChangesLong formats changes with detailed information
 func FormatChangesLong(
 	changes []ChangeInfo,
@@ -183,7 +204,7 @@ func FormatSpecsLong(specs []SpecInfo) string {
 			spec.ID,
 			spec.Title,
 			spec.RequirementCount,
-		)
+		) + formatStatusSuffix(spec) + formatReviewSuffix(spec)
 		lines = append(lines, line)
 	}
 
@@ -229,14 +250,12 @@ func FormatChangesTextMulti(
 		return noItemsFoundMsg
 	}
 
-	// Sort by ID
-	sort.Slice(changes, func(i, j int) bool {
-		return changes[i].ID < changes[j].ID
-	})
+	sortChanges(changes, mode)
 
-	// Find the longest ID and root for alignment
+	// Find the longest ID, root and task count for alignment
 	maxIDLen := 0
 	maxRootLen := 0
+	maxTasksLen := 0
 	for _, change := range changes {
 		if len(change.ID) > maxIDLen {
 			maxIDLen = len(change.ID)
@@ -244,26 +263,33 @@ func FormatChangesTextMulti(
 		if len(change.RootPath) > maxRootLen && mode.IsMulti() {
 			maxRootLen = len(change.RootPath)
 		}
+		maxTasksLen = max(maxTasksLen, len(formatTaskCount(change)))
 	}
 
 	lines := make([]string, 0, len(changes))
 	for _, change := range changes {
 		var line string
 		if change.RootPath != currentDirPath && change.RootPath != "" && mode.IsMulti() {
-			line = fmt.Sprintf("[%-*s] %-*s  %d/%d tasks",
+			line = fmt.Sprintf("[%-*s] %-*s  %s",
 				maxRootLen,
 				change.RootPath,
 				maxIDLen,
 				change.ID,
-				change.TaskStatus.Completed,
-				change.TaskStatus.Total,
+				formatTaskCount(change),
 			)
 		} else {
-			line = fmt.Sprintf("%-*s  %d/%d tasks",
+			line = fmt.Sprintf("%-*s  %s",
 				maxIDLen,
 				change.ID,
-				change.TaskStatus.Completed,
-				change.TaskStatus.Total,
+				formatTaskCount(change),
+			)
+		}
+		if !change.LastActivity.IsZero() {
+			line = fmt.Sprintf(
+				"%-*s  %s",
+				len(line)-len(formatTaskCount(change))+maxTasksLen,
+				line,
+				lastActivityText(change),
 			)
 		}
 		lines = append(lines, line)
@@ -281,10 +307,7 @@ func FormatChangesLongMulti(
 		return noItemsFoundMsg
 	}
 
-	// Sort by ID
-	sort.Slice(changes, func(i, j int) bool {
-		return changes[i].ID < changes[j].ID
-	})
+	sortChanges(changes, mode)
 
 	lines := make([]string, 0, len(changes))
 	for _, change := range changes {
@@ -309,6 +332,9 @@ func FormatChangesLongMulti(
 				change.TaskStatus.Total,
 			)
 		}
+		if !change.LastActivity.IsZero() {
+			line += fmt.Sprintf(" [active %s]", lastActivityText(change))
+		}
 		lines = append(lines, line)
 	}
 
@@ -376,8 +402,30 @@ func FormatSpecsLongMulti(
 				spec.RequirementCount,
 			)
 		}
+		line += formatStatusSuffix(spec) + formatReviewSuffix(spec)
 		lines = append(lines, line)
 	}
 
 	return strings.Join(lines, lineSeparator)
 }
+
+// formatStatusSuffix renders a spec's requirement status breakdown for long
+// output, e.g. " [status: 2 draft, 1 approved]". Returns an empty string if
+// no requirement declares a status.
+func formatStatusSuffix(spec SpecInfo) string {
+	breakdown := parsers.FormatStatusBreakdown(spec.StatusCounts)
+	if breakdown == "" {
+		return ""
+	}
+
+	return " [status: " + breakdown + "]"
+}
+
+// formatReviewSuffix marks a spec past its review interval in long output.
+func formatReviewSuffix(spec SpecInfo) string {
+	if !spec.ReviewDue {
+		return ""
+	}
+
+	return " [review due]"
+}
@@ -352,6 +352,35 @@ func TestFormatSpecsLong(t *testing.T) {
 	}
 }
 
+func TestFormatSpecsLong_StatusBreakdown(t *testing.T) {
+	specs := []SpecInfo{
+		{
+			ID:               "auth",
+			Title:            "Auth",
+			RequirementCount: 4,
+			StatusCounts: map[parsers.RequirementStatus]int{
+				parsers.RequirementStatusImplemented: 3,
+				parsers.RequirementStatusDraft:       1,
+			},
+		},
+		{
+			ID:               "cli",
+			Title:            "CLI",
+			RequirementCount: 2,
+		},
+	}
+
+	lines := strings.Split(FormatSpecsLong(specs), "\n")
+
+	want := "auth: Auth [requirements 4] [status: 1 draft, 3 implemented]"
+	if lines[0] != want {
+		t.Errorf("expected %q, got %q", want, lines[0])
+	}
+	if lines[1] != "cli: CLI [requirements 2]" {
+		t.Errorf("expected no status suffix, got %q", lines[1])
+	}
+}
+
 func TestFormatSpecsJSON(t *testing.T) {
 	specs := []SpecInfo{
 		{
@@ -25,11 +25,16 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"sort"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/table"
 	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/connerohnesorge/spectr/internal/clipboard"
+	"github.com/connerohnesorge/spectr/internal/parsers"
+	"github.com/connerohnesorge/spectr/internal/reader"
 	"github.com/connerohnesorge/spectr/internal/specterrs"
 	"github.com/connerohnesorge/spectr/internal/tui"
 )
@@ -40,6 +45,15 @@ const (
 	changeTitleWidth = 40
 	changeDeltaWidth = 10
 	changeTasksWidth = 15
+	// changeActivityWidth fits "Last activity" and values like "11mo ago"
+	changeActivityWidth = 14
+
+	// staleBadge is appended to the tasks cell of stale changes; it fits
+	// the tasks column alongside counts like "12/34"
+	staleBadge = " [stale]"
+	// reviewBadge is appended to the requirements cell, or the title in
+	// the minimal layout, of specs due for review
+	reviewBadge = " [review]"
 
 	// Table column widths for specs view
 	specIDWidth           = 35
@@ -80,6 +94,7 @@ const (
 	columnTitleTasks        = "Tasks"
 	columnTitleDetails      = "Details"
 	columnTitleRequirements = "Requirements"
+	columnTitleActivity     = "Last activity"
 
 	// Text input settings
 	searchInputCharLimit = 50
@@ -89,6 +104,8 @@ const (
 	errInteractiveModeFormat = "error running interactive mode: %w"
 
 	// Width breakpoint thresholds for responsive column layout.
+	// breakpointWide: changes view adds the Last activity column
+	breakpointWide = 130
 	// breakpointFull: all columns shown at default widths
 	breakpointFull = 110
 	// breakpointMedium: title column narrowed, all columns still visible
@@ -134,7 +151,8 @@ const (
 //   - Deltas: Medium (hidden below 70 columns)
 //   - Title: Low (hidden below 80 columns to prioritize Tasks)
 //
-// Column order is always: ID | Title | Deltas | Tasks (when visible)
+// Column order is always: ID | Title | Deltas | Tasks (when visible), with
+// Last activity appended on wide terminals (130+).
 func calculateChangesColumns(
 	width int,
 	lineNumberMode LineNumberMode,
@@ -152,8 +170,35 @@ func calculateChangesColumns(
 	}
 
 	switch {
+	case width >= breakpointWide:
+		// Wide (130+): the 4 full-width columns plus Last activity
+		cols := []table.Column{
+			{
+				Title: columnTitleID,
+				Width: changeIDWidth,
+			},
+			{
+				Title: columnTitleTitle,
+				Width: changeTitleWidth,
+			},
+			{
+				Title: columnTitleDeltas,
+				Width: changeDeltaWidth,
+			},
+			{
+				Title: columnTitleTasks,
+				Width: changeTasksWidth,
+			},
+			{
+				Title: columnTitleActivity,
+				Width: changeActivityWidth,
+			},
+		}
+
+		return append(lineNumCol, cols...)
+
 	case width >= breakpointFull:
-		// Full width (110+): all 4 columns at default widths
+		// Full width (110-129): all 4 columns at default widths
 		cols := []table.Column{
 			{
 				Title: columnTitleID,
@@ -588,6 +633,9 @@ func buildChangesRows(
 		tasksStatus := fmt.Sprintf("%d/%d",
 			change.TaskStatus.Completed,
 			change.TaskStatus.Total)
+		if change.Stale {
+			tasksStatus += staleBadge
+		}
 
 		// Format ID with project prefix if in multi-root mode
 		displayID := formatChangeIDWithProject(change.ID, change.RootPath, hasMultipleRoots)
@@ -600,6 +648,26 @@ func buildChangesRows(
 		}
 
 		switch dataColumns {
+		case 5:
+			// Wide: ID, Title, Deltas, Tasks, Last activity
+			row := table.Row{
+				displayID,
+				tui.TruncateString(
+					change.Title,
+					titleTruncate,
+				),
+				fmt.Sprintf(
+					"%d",
+					change.DeltaCount,
+				),
+				tasksStatus,
+				lastActivityText(change),
+			}
+			if lineNumberMode != LineNumberOff {
+				rows[i] = append(table.Row{lineNumStr}, row...)
+			} else {
+				rows[i] = row
+			}
 		case 4:
 			// Full: ID, Title, Deltas, Tasks
 			row := table.Row{
@@ -682,6 +750,11 @@ func buildSpecsRows(
 		// Format ID with project prefix if in multi-root mode
 		displayID := formatSpecIDWithProject(spec.ID, spec.RootPath, hasMultipleRoots)
 
+		badge := ""
+		if spec.ReviewDue {
+			badge = reviewBadge
+		}
+
 		switch numColumns {
 		case 3:
 			// Full: ID, Title, Requirements
@@ -694,7 +767,7 @@ func buildSpecsRows(
 				fmt.Sprintf(
 					"%d",
 					spec.RequirementCount,
-				),
+				) + badge,
 			}
 		default:
 			// Minimal: ID, Title only
@@ -702,8 +775,8 @@ func buildSpecsRows(
 				displayID,
 				tui.TruncateString(
 					spec.Title,
-					titleTruncate,
-				),
+					max(titleTruncate-len(badge), 0),
+				) + badge,
 			}
 		}
 	}
@@ -803,6 +876,8 @@ type interactiveModel struct {
 	table            table.Model
 	selectedID       string
 	copied           bool
+	copiedText       string           // value copied, shown if the clipboard is unavailable
+	copyMethod       clipboard.Method // how the value was copied
 	quitting         bool
 	archiveRequested bool
 	selectedRootPath string // absolute path to root for archive/PR workflows
@@ -823,11 +898,21 @@ type interactiveModel struct {
 	selectionMode    bool        // true: Enter selects without copying
 	stdoutMode       bool        // true: prints ID to stdout instead of clipboard
 	terminalWidth    int         // current terminal width for responsive columns
+	terminalHeight   int         // current terminal height for the delta preview
 	// Source data for rebuilding rows on resize
 	changesData      []ChangeInfo         // original changes data for changes/archive views
 	specsData        []SpecInfo           // original specs data for specs view
 	countPrefixState tui.CountPrefixState // vim-style count prefix state
 	lineNumberMode   LineNumberMode       // line number display mode (off, relative, hybrid)
+	// statusFilter limits the specs view to specs with at least one
+	// requirement in this status (empty = no filter)
+	statusFilter parsers.RequirementStatus
+	// treeView groups the specs view by directory hierarchy
+	treeView bool
+	// deltaPreview shows the selected change's deltas when non-nil
+	deltaPreview *viewport.Model
+	// specPreview shows the selected spec with its outline when non-nil
+	specPreview *reader.Preview
 }
 
 // Init initializes the model
@@ -843,6 +928,13 @@ func (m *interactiveModel) Update(
 
 	switch typedMsg := msg.(type) {
 	case tea.KeyMsg:
+		if m.deltaPreview != nil {
+			return m.handleDeltaPreviewKey(typedMsg)
+		}
+		if m.specPreview != nil {
+			return m.handleSpecPreviewKey(typedMsg)
+		}
+
 		keyStr := typedMsg.String()
 
 		// Handle count prefix (before search mode)
@@ -913,12 +1005,43 @@ func (m *interactiveModel) Update(
 				return m, nil
 			}
 
+		case "s":
+			// Cycle requirement status filter in specs mode
+			if m.itemType == itemTypeSpec {
+				m.cycleStatusFilter()
+
+				return m, nil
+			}
+
+		case "g":
+			// Toggle the directory tree grouping in specs mode
+			if m.itemType == itemTypeSpec {
+				m.toggleTreeView()
+
+				return m, nil
+			}
+
 		case "a":
 			return m.handleArchive()
 
 		case "P":
 			return m.handlePR()
 
+		case "d":
+			if specID, ok := m.specUnderCursor(); ok {
+				return m.handleSpecPreview(specID)
+			}
+
+			return m.handleDeltaPreview()
+
+		case "o":
+			// Toggle ordering by last activity in changes mode
+			if m.itemType == itemTypeChange {
+				m.toggleActivitySort()
+
+				return m, nil
+			}
+
 		case "/":
 			m.toggleSearchMode()
 
@@ -953,6 +1076,13 @@ func (m *interactiveModel) Update(
 	case tea.WindowSizeMsg:
 		// Store terminal width for responsive column calculations
 		m.terminalWidth = typedMsg.Width
+		m.terminalHeight = typedMsg.Height
+		if m.deltaPreview != nil {
+			m.deltaPreview.Width, m.deltaPreview.Height = m.deltaPreviewSize()
+		}
+		if m.specPreview != nil {
+			m.specPreview.SetSize(m.deltaPreviewSize())
+		}
 		// Trigger table rebuild to apply new column widths
 		m.rebuildTableForWidth()
 
@@ -1012,10 +1142,10 @@ func (m *interactiveModel) handleEnter() {
 
 	// Copy to clipboard
 	m.copied = true
-	err := tui.CopyToClipboard(copyPath)
-	if err != nil {
-		m.err = err
-	}
+	m.copiedText = copyPath
+	m.copyMethod = clipboard.Copy(copyPath)
+	// Render the quit view so the user sees how the copy went
+	m.quitting = true
 }
 
 // buildCopyPath builds the path to copy for the selected item.
@@ -1386,7 +1516,7 @@ func rebuildUnifiedTable(
 	}
 	m.helpText = fmt.Sprintf(
 		"↑/↓/j/k: navigate (try 9j) | Enter: copy ID | e: edit | "+
-			"a: archive | t: filter (%s) | #: line numbers | /: search | q: quit",
+			"a: archive | d: preview | t: filter (%s) | #: line numbers | /: search | q: quit",
 		filterDesc,
 	)
 	m.minimalFooter = fmt.Sprintf(
@@ -1617,6 +1747,21 @@ func (m *interactiveModel) rebuildTableForWidth() {
 	}
 }
 
+// toggleActivitySort switches the changes view between ID order and last
+// activity order (stalest first) and rebuilds the table.
+func (m *interactiveModel) toggleActivitySort() {
+	if !sortedByActivity(m.changesData) {
+		SortChangesByActivity(m.changesData)
+	} else {
+		sort.SliceStable(m.changesData, func(i, j int) bool {
+			return m.changesData[i].ID < m.changesData[j].ID
+		})
+	}
+
+	m.table.SetCursor(0)
+	m.rebuildTableForWidth()
+}
+
 // rebuildChangesTable rebuilds the changes table with responsive columns
 func (m *interactiveModel) rebuildChangesTable(
 	width int,
@@ -1663,8 +1808,12 @@ func (m *interactiveModel) rebuildSpecsTable(
 		itemTypeSpec,
 		width,
 	)
-	rows := buildSpecsRows(
-		m.specsData,
+	buildRows := buildSpecsRows
+	if m.treeView {
+		buildRows = buildSpecTreeRows
+	}
+	rows := buildRows(
+		m.visibleSpecs(),
 		titleTruncate,
 		len(columns),
 	)
@@ -1681,6 +1830,63 @@ func (m *interactiveModel) rebuildSpecsTable(
 	m.allRows = rows
 }
 
+// toggleTreeView switches the specs view between the flat table and rows
+// grouped by directory, then rebuilds the table.
+func (m *interactiveModel) toggleTreeView() {
+	m.treeView = !m.treeView
+	m.table.SetCursor(0)
+	m.rebuildTableForWidth()
+
+	if m.lineNumberMode != LineNumberOff {
+		m.updateLineNumbers()
+	}
+}
+
+// visibleSpecs returns the specs matching the current status filter.
+func (m *interactiveModel) visibleSpecs() []SpecInfo {
+	if m.statusFilter == "" {
+		return m.specsData
+	}
+
+	specs := make([]SpecInfo, 0, len(m.specsData))
+	for _, spec := range m.specsData {
+		if spec.StatusCounts[m.statusFilter] > 0 {
+			specs = append(specs, spec)
+		}
+	}
+
+	return specs
+}
+
+// cycleStatusFilter advances the specs status filter through
+// all -> draft -> approved -> implemented -> deprecated -> all
+// and rebuilds the table.
+func (m *interactiveModel) cycleStatusFilter() {
+	m.statusFilter = nextStatusFilter(m.statusFilter)
+	m.rebuildTableForWidth()
+
+	if m.lineNumberMode != LineNumberOff {
+		m.updateLineNumbers()
+	}
+}
+
+// nextStatusFilter returns the status filter following current.
+func nextStatusFilter(
+	current parsers.RequirementStatus,
+) parsers.RequirementStatus {
+	if current == "" {
+		return parsers.RequirementStatuses[0]
+	}
+
+	for i, status := range parsers.RequirementStatuses {
+		if status == current && i+1 < len(parsers.RequirementStatuses) {
+			return parsers.RequirementStatuses[i+1]
+		}
+	}
+
+	return ""
+}
+
 // getEditFilePath returns the file path to edit based on item type
 func (m *interactiveModel) getEditFilePath(
 	itemID string,
@@ -1861,10 +2067,7 @@ func (m *interactiveModel) View() string {
 		}
 
 		if m.copied && m.err == nil {
-			return fmt.Sprintf(
-				"✓ Copied: %s\n",
-				m.selectedID,
-			)
+			return m.copyMethod.Message(m.selectedID, m.copiedText)
 		} else if m.err != nil {
 			return fmt.Sprintf(
 				"Copied: %s\nError: %v\n",
@@ -1876,6 +2079,13 @@ func (m *interactiveModel) View() string {
 		return "Cancelled.\n"
 	}
 
+	if m.deltaPreview != nil {
+		return m.viewDeltaPreview()
+	}
+	if m.specPreview != nil {
+		return m.viewSpecPreview()
+	}
+
 	// Display search input if search mode is active
 	var view string
 	if m.searchMode {
@@ -1904,6 +2114,10 @@ func (m *interactiveModel) View() string {
 		footer += fmt.Sprintf(" | count: %s_", m.countPrefixState.String())
 	}
 
+	if m.statusFilter != "" {
+		footer += fmt.Sprintf(" | status: %s", m.statusFilter)
+	}
+
 	if m.lineNumberMode != LineNumberOff {
 		modeStr := "rel"
 		if m.lineNumberMode == LineNumberHybrid {
@@ -1981,7 +2195,8 @@ func RunInteractiveChanges(
 		stdoutMode:     stdoutMode,         // Output to stdout instead of clipboard
 		lineNumberMode: LineNumberRelative, // Default to relative line numbers
 		helpText: "↑/↓/j/k: navigate (try 9j) | Enter: copy ID | e: edit | " +
-			"a: archive | P: pr | #: line numbers | /: search | q: quit",
+			"a: archive | P: pr | d: deltas | o: sort by activity | " +
+			"#: line numbers | /: search | q: quit",
 		minimalFooter: fmt.Sprintf(
 			"showing: %d | project: %s | ?: help",
 			len(rows),
@@ -2112,11 +2327,13 @@ func RunInteractiveArchive(
 	return "", nil
 }
 
-// RunInteractiveSpecs runs the interactive table for specs
+// RunInteractiveSpecs runs the interactive table for specs. When treeView is
+// set, rows start grouped by directory; 'g' toggles grouping either way.
 func RunInteractiveSpecs(
 	specs []SpecInfo,
 	projectPath string,
 	stdoutMode bool,
+	treeView bool,
 ) error {
 	if len(specs) == 0 {
 		return nil
@@ -2133,7 +2350,11 @@ func RunInteractiveSpecs(
 		breakpointFull,
 	)
 
-	rows := buildSpecsRows(
+	buildRows := buildSpecsRows
+	if treeView {
+		buildRows = buildSpecTreeRows
+	}
+	rows := buildRows(
 		specs,
 		titleTruncate,
 		len(columns),
@@ -2157,9 +2378,10 @@ func RunInteractiveSpecs(
 		terminalWidth:  0,                  // Will be set by WindowSizeMsg
 		specsData:      specs,              // Store for rebuild on resize
 		stdoutMode:     stdoutMode,         // Output to stdout instead of clipboard
+		treeView:       treeView,           // Group rows by directory
 		lineNumberMode: LineNumberRelative, // Default to relative line numbers
 		helpText: "↑/↓/j/k: navigate (try 9j) | Enter: copy ID | e: edit | " +
-			"#: line numbers | /: search | q: quit",
+			"d: preview | s: status filter | g: tree | #: line numbers | /: search | q: quit",
 		minimalFooter: fmt.Sprintf(
 			"showing: %d | project: %s | ?: help",
 			len(specs),
@@ -2239,7 +2461,7 @@ func RunInteractiveAll(
 		stdoutMode:     stdoutMode,         // Output to stdout instead of clipboard
 		lineNumberMode: LineNumberRelative, // Default to relative line numbers
 		helpText: "↑/↓/j/k: navigate (try 9j) | Enter: copy ID | e: edit | " +
-			"a: archive | t: filter (all) | #: line numbers | /: search | q: quit",
+			"a: archive | d: preview | t: filter (all) | #: line numbers | /: search | q: quit",
 		minimalFooter: fmt.Sprintf(
 			"showing: %d | project: %s | ?: help",
 			len(rows),
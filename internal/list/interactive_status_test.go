@@ -0,0 +1,55 @@
+package list
+
+import (
+	"testing"
+
+	"github.com/connerohnesorge/spectr/internal/parsers"
+)
+
+func TestNextStatusFilter(t *testing.T) {
+	want := []parsers.RequirementStatus{
+		parsers.RequirementStatusDraft,
+		parsers.RequirementStatusApproved,
+		parsers.RequirementStatusImplemented,
+		parsers.RequirementStatusDeprecated,
+		"",
+	}
+
+	current := parsers.RequirementStatus("")
+	for _, expected := range want {
+		current = nextStatusFilter(current)
+		if current != expected {
+			t.Fatalf("nextStatusFilter() = %q, want %q", current, expected)
+		}
+	}
+}
+
+func TestVisibleSpecs(t *testing.T) {
+	m := &interactiveModel{
+		specsData: []SpecInfo{
+			{
+				ID: "auth",
+				StatusCounts: map[parsers.RequirementStatus]int{
+					parsers.RequirementStatusDraft: 1,
+				},
+			},
+			{
+				ID: "billing",
+				StatusCounts: map[parsers.RequirementStatus]int{
+					parsers.RequirementStatusImplemented: 3,
+				},
+			},
+			{ID: "cli"},
+		},
+	}
+
+	if got := m.visibleSpecs(); len(got) != 3 {
+		t.Errorf("expected all specs without filter, got %d", len(got))
+	}
+
+	m.statusFilter = parsers.RequirementStatusImplemented
+	got := m.visibleSpecs()
+	if len(got) != 1 || got[0].ID != "billing" {
+		t.Errorf("expected only billing, got %+v", got)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/charmbracelet/bubbles/table"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/x/exp/teatest"
+	"github.com/connerohnesorge/spectr/internal/clipboard"
 	"github.com/connerohnesorge/spectr/internal/parsers"
 )
 
@@ -67,6 +68,7 @@ func TestRunInteractiveSpecs_EmptyList(
 		specs,
 		"/tmp/test-project",
 		false,
+		false,
 	)
 	if err != nil {
 		t.Errorf(
@@ -167,6 +169,27 @@ func TestInteractiveModel_View_Quitting(
 			},
 			wantSubstr: "Copied: test-id",
 		},
+		{
+			name: "quit with copy over OSC 52",
+			model: interactiveModel{
+				quitting:   true,
+				copied:     true,
+				selectedID: "test-id",
+				copyMethod: clipboard.OSC52,
+			},
+			wantSubstr: "Copied via terminal (OSC 52): test-id",
+		},
+		{
+			name: "quit with clipboard unavailable",
+			model: interactiveModel{
+				quitting:   true,
+				copied:     true,
+				selectedID: "test-id",
+				copiedText: "spectr/changes/test-id/proposal.md",
+				copyMethod: clipboard.Printed,
+			},
+			wantSubstr: "copy test-id from here:\nspectr/changes/test-id/proposal.md",
+		},
 	}
 
 	for _, tt := range tests {
@@ -2202,11 +2225,11 @@ func TestHelpToggleInUnifiedMode(t *testing.T) {
 }
 
 // TestCalculateChangesColumns_FullWidth tests that all 4 columns are returned
-// at full width (110+)
+// at full width (110-129); wider terminals add Last activity
 func TestCalculateChangesColumns_FullWidth(
 	t *testing.T,
 ) {
-	testWidths := []int{110, 120, 150, 200}
+	testWidths := []int{110, 120, 129}
 
 	for _, width := range testWidths {
 		t.Run(
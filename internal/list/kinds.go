@@ -0,0 +1,123 @@
+package list
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/connerohnesorge/spectr/internal/clipboard"
+	"github.com/connerohnesorge/spectr/internal/kinds"
+	"github.com/connerohnesorge/spectr/internal/tui"
+)
+
+// Column widths of the interactive table of custom kind items.
+const (
+	kindIDWidth    = 30
+	kindTitleWidth = 50
+)
+
+// FormatKindItemsText formats items of a custom kind as a list of IDs.
+func FormatKindItemsText(items []kinds.Item) string {
+	if len(items) == 0 {
+		return noItemsFoundMsg
+	}
+
+	lines := make([]string, 0, len(items))
+	for _, item := range items {
+		lines = append(lines, item.ID)
+	}
+
+	return strings.Join(lines, lineSeparator)
+}
+
+// FormatKindItemsLong formats items of a custom kind with their titles.
+func FormatKindItemsLong(items []kinds.Item) string {
+	if len(items) == 0 {
+		return noItemsFoundMsg
+	}
+
+	lines := make([]string, 0, len(items))
+	for _, item := range items {
+		lines = append(lines, fmt.Sprintf("%s: %s", item.ID, item.Title))
+	}
+
+	return strings.Join(lines, lineSeparator)
+}
+
+// FormatKindItemsJSON formats items of a custom kind as a JSON array.
+func FormatKindItemsJSON(items []kinds.Item) (string, error) {
+	if len(items) == 0 {
+		return "[]", nil
+	}
+
+	data, err := json.MarshalIndent(items, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf(
+			"failed to marshal JSON: %w",
+			err,
+		)
+	}
+
+	return string(data), nil
+}
+
+// RunInteractiveKind shows the items of a custom kind in a table. Enter
+// copies the selected ID, or prints it to stdout in stdout mode.
+func RunInteractiveKind(
+	kind kinds.Kind,
+	items []kinds.Item,
+	projectPath string,
+	stdoutMode bool,
+) error {
+	rows := make([]table.Row, len(items))
+	for i, item := range items {
+		rows[i] = table.Row{
+			item.ID,
+			tui.TruncateString(item.Title, kindTitleWidth),
+		}
+	}
+
+	picker := tui.NewTablePicker(&tui.TableConfig{
+		Columns: []table.Column{
+			{Title: "ID", Width: kindIDWidth},
+			{Title: "Title", Width: kindTitleWidth},
+		},
+		Rows:        rows,
+		Height:      min(len(rows), tableHeight),
+		ProjectPath: projectPath,
+		FooterExtra: fmt.Sprintf("%s: %d", kind.Label(), len(items)),
+		Actions: map[string]tui.Action{
+			"enter": {
+				Key:         "enter",
+				Description: "copy ID",
+				Handler: func(row table.Row) (tea.Cmd, *tui.ActionResult) {
+					if len(row) == 0 {
+						return nil, nil
+					}
+					if stdoutMode {
+						return tea.Quit, &tui.ActionResult{ID: row[0], Quit: true}
+					}
+
+					return tea.Quit, &tui.ActionResult{
+						ID:         row[0],
+						Quit:       true,
+						Copied:     true,
+						CopyMethod: clipboard.Copy(row[0]),
+					}
+				},
+			},
+		},
+	})
+
+	result, err := picker.Run()
+	if err != nil {
+		return fmt.Errorf(errInteractiveModeFormat, err)
+	}
+	if stdoutMode && result != nil && !result.Cancelled && result.ID != "" {
+		fmt.Println(result.ID)
+	}
+
+	return nil
+}
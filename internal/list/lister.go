@@ -1,11 +1,13 @@
 package list
 
 import (
+	"context"
 	"fmt"
 	"path/filepath"
 	"sort"
 
 	"github.com/connerohnesorge/spectr/internal/discovery"
+	"github.com/connerohnesorge/spectr/internal/domain"
 	"github.com/connerohnesorge/spectr/internal/git"
 	"github.com/connerohnesorge/spectr/internal/parsers"
 )
@@ -17,6 +19,8 @@ type Lister struct {
 	rootPath string
 	// absPath is the absolute path to the project root
 	absPath string
+	// activity finds and caches each change's last activity
+	activity *ActivityScanner
 }
 
 // NewLister creates a new Lister for the given project path
@@ -24,6 +28,7 @@ func NewLister(projectPath string) *Lister {
 	return &Lister{
 		projectPath: projectPath,
 		absPath:     projectPath,
+		activity:    NewActivityScanner(),
 	}
 }
 
@@ -33,11 +38,20 @@ func NewListerWithRoot(projectPath, rootPath string) *Lister {
 		projectPath: projectPath,
 		rootPath:    rootPath,
 		absPath:     projectPath,
+		activity:    NewActivityScanner(),
 	}
 }
 
 // ListChanges retrieves information about all active changes
 func (l *Lister) ListChanges() ([]ChangeInfo, error) {
+	return l.ListChangesContext(context.Background())
+}
+
+// ListChangesContext is like ListChanges but stops with ctx.Err() between
+// changes once ctx is done.
+func (l *Lister) ListChangesContext(
+	ctx context.Context,
+) ([]ChangeInfo, error) {
 	changeIDs, err := discovery.GetActiveChanges(
 		l.projectPath,
 	)
@@ -54,6 +68,10 @@ func (l *Lister) ListChanges() ([]ChangeInfo, error) {
 		len(changeIDs),
 	)
 	for _, id := range changeIDs {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
 		changeDir := filepath.Join(
 			l.projectPath,
 			"spectr",
@@ -93,13 +111,23 @@ func (l *Lister) ListChanges() ([]ChangeInfo, error) {
 			deltaCount = 0
 		}
 
+		// Read the owner from frontmatter; malformed frontmatter is
+		// reported by validate, not here
+		var owner string
+		meta, err := domain.ParseProposalFrontmatterFromFile(proposalPath)
+		if err == nil {
+			owner = meta.Owner
+		}
+
 		changes = append(changes, ChangeInfo{
-			ID:          id,
-			Title:       title,
-			DeltaCount:  deltaCount,
-			TaskStatus:  taskStatus,
-			RootPath:    l.rootPath,
-			RootAbsPath: l.absPath,
+			ID:           id,
+			Title:        title,
+			DeltaCount:   deltaCount,
+			TaskStatus:   taskStatus,
+			Owner:        owner,
+			LastActivity: l.activity.LastActivity(changeDir),
+			RootPath:     l.rootPath,
+			RootAbsPath:  l.absPath,
 		})
 	}
 
@@ -108,6 +136,14 @@ func (l *Lister) ListChanges() ([]ChangeInfo, error) {
 
 // ListSpecs retrieves information about all specs
 func (l *Lister) ListSpecs() ([]SpecInfo, error) {
+	return l.ListSpecsContext(context.Background())
+}
+
+// ListSpecsContext is like ListSpecs but stops with ctx.Err() between
+// specs once ctx is done.
+func (l *Lister) ListSpecsContext(
+	ctx context.Context,
+) ([]SpecInfo, error) {
 	specIDs, err := discovery.GetSpecs(
 		l.projectPath,
 	)
@@ -120,6 +156,10 @@ func (l *Lister) ListSpecs() ([]SpecInfo, error) {
 
 	specs := make([]SpecInfo, 0, len(specIDs))
 	for _, id := range specIDs {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
 		specPath := filepath.Join(
 			l.projectPath,
 			"spectr",
@@ -145,10 +185,19 @@ func (l *Lister) ListSpecs() ([]SpecInfo, error) {
 			reqCount = 0
 		}
 
+		// Aggregate declared requirement statuses
+		statusCounts, err := parsers.CountRequirementStatuses(
+			specPath,
+		)
+		if err != nil || len(statusCounts) == 0 {
+			statusCounts = nil
+		}
+
 		specs = append(specs, SpecInfo{
 			ID:               id,
 			Title:            title,
 			RequirementCount: reqCount,
+			StatusCounts:     statusCounts,
 			RootPath:         l.rootPath,
 			RootAbsPath:      l.absPath,
 		})
@@ -168,6 +217,14 @@ type ListAllOptions struct {
 // ListAll retrieves all changes and specs as a unified ItemList
 func (l *Lister) ListAll(
 	opts *ListAllOptions,
+) (ItemList, error) {
+	return l.ListAllContext(context.Background(), opts)
+}
+
+// ListAllContext is like ListAll but stops with ctx.Err() once ctx is done.
+func (l *Lister) ListAllContext(
+	ctx context.Context,
+	opts *ListAllOptions,
 ) (ItemList, error) {
 	// Use default options if none provided
 	options := opts
@@ -182,7 +239,7 @@ func (l *Lister) ListAll(
 	// Load changes if not filtered out
 	if options.FilterType == nil ||
 		*options.FilterType == ItemTypeChange {
-		changes, err := l.ListChanges()
+		changes, err := l.ListChangesContext(ctx)
 		if err != nil {
 			return nil, fmt.Errorf(
 				"failed to list changes: %w",
@@ -200,7 +257,7 @@ func (l *Lister) ListAll(
 	// Load specs if not filtered out
 	if options.FilterType == nil ||
 		*options.FilterType == ItemTypeSpec {
-		specs, err := l.ListSpecs()
+		specs, err := l.ListSpecsContext(ctx)
 		if err != nil {
 			return nil, fmt.Errorf(
 				"failed to list specs: %w",
@@ -230,6 +287,7 @@ func (l *Lister) ListAll(
 // identifying unmerged changes that haven't been merged to the main branch yet.
 // The ref should be a full ref like "origin/main" or "origin/master".
 func FilterChangesNotOnRef(
+	ctx context.Context,
 	changes []ChangeInfo,
 	ref string,
 ) ([]ChangeInfo, error) {
@@ -242,6 +300,7 @@ func FilterChangesNotOnRef(
 			change.ID,
 		)
 		exists, err := git.PathExistsOnRef(
+			ctx,
 			ref,
 			changePath,
 		)
@@ -280,10 +339,18 @@ func NewMultiRootLister(roots []discovery.SpectrRoot) *MultiRootLister {
 
 // ListChanges retrieves changes from all roots.
 func (m *MultiRootLister) ListChanges() ([]ChangeInfo, error) {
+	return m.ListChangesContext(context.Background())
+}
+
+// ListChangesContext is like ListChanges but stops with ctx.Err() once ctx
+// is done.
+func (m *MultiRootLister) ListChangesContext(
+	ctx context.Context,
+) ([]ChangeInfo, error) {
 	var allChanges []ChangeInfo
 
 	for _, lister := range m.listers {
-		changes, err := lister.ListChanges()
+		changes, err := lister.ListChangesContext(ctx)
 		if err != nil {
 			return nil, fmt.Errorf(
 				"failed to list changes from %s: %w",
@@ -304,10 +371,18 @@ func (m *MultiRootLister) ListChanges() ([]ChangeInfo, error) {
 
 // ListSpecs retrieves specs from all roots.
 func (m *MultiRootLister) ListSpecs() ([]SpecInfo, error) {
+	return m.ListSpecsContext(context.Background())
+}
+
+// ListSpecsContext is like ListSpecs but stops with ctx.Err() once ctx is
+// done.
+func (m *MultiRootLister) ListSpecsContext(
+	ctx context.Context,
+) ([]SpecInfo, error) {
 	var allSpecs []SpecInfo
 
 	for _, lister := range m.listers {
-		specs, err := lister.ListSpecs()
+		specs, err := lister.ListSpecsContext(ctx)
 		if err != nil {
 			return nil, fmt.Errorf(
 				"failed to list specs from %s: %w",
@@ -328,10 +403,18 @@ func (m *MultiRootLister) ListSpecs() ([]SpecInfo, error) {
 
 // ListAll retrieves all items from all roots.
 func (m *MultiRootLister) ListAll(opts *ListAllOptions) (ItemList, error) {
+	return m.ListAllContext(context.Background(), opts)
+}
+
+// ListAllContext is like ListAll but stops with ctx.Err() once ctx is done.
+func (m *MultiRootLister) ListAllContext(
+	ctx context.Context,
+	opts *ListAllOptions,
+) (ItemList, error) {
 	var items ItemList
 
 	for _, lister := range m.listers {
-		rootItems, err := lister.ListAll(opts)
+		rootItems, err := lister.ListAllContext(ctx, opts)
 		if err != nil {
 			return nil, fmt.Errorf(
 				"failed to list items from %s: %w",
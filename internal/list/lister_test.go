@@ -1,6 +1,8 @@
 package list
 
 import (
+	"context"
+	"errors"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -9,6 +11,46 @@ import (
 	"github.com/connerohnesorge/spectr/internal/parsers"
 )
 
+func TestLister_ContextCancelled(t *testing.T) {
+	tmpDir := t.TempDir()
+	for _, dir := range []string{
+		filepath.Join(tmpDir, "spectr", "changes", "add-feature"),
+		filepath.Join(tmpDir, "spectr", "specs", "auth"),
+	} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(
+		filepath.Join(tmpDir, "spectr", "changes", "add-feature", "proposal.md"),
+		[]byte("# Change: Add Feature\n"),
+		0o644,
+	); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(
+		filepath.Join(tmpDir, "spectr", "specs", "auth", "spec.md"),
+		[]byte("# Auth\n"),
+		0o644,
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	lister := NewLister(tmpDir)
+	if _, err := lister.ListChangesContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("ListChangesContext error = %v, want context.Canceled", err)
+	}
+	if _, err := lister.ListSpecsContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("ListSpecsContext error = %v, want context.Canceled", err)
+	}
+	if _, err := lister.ListAllContext(ctx, nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("ListAllContext error = %v, want context.Canceled", err)
+	}
+}
+
 func TestListChanges(t *testing.T) {
 	tmpDir := t.TempDir()
 	changesDir := filepath.Join(
@@ -161,6 +203,31 @@ func TestListChanges_FallbackTitle(t *testing.T) {
 	}
 }
 
+func TestListChanges_Owner(t *testing.T) {
+	tmpDir := t.TempDir()
+	changeDir := filepath.Join(tmpDir, "spectr", "changes", "add-refunds")
+	if err := os.MkdirAll(changeDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	proposalContent := "---\nowner: payments-team\n---\n# Change: Add refunds\n"
+	if err := os.WriteFile(filepath.Join(changeDir, "proposal.md"), []byte(proposalContent), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	changes, err := NewLister(tmpDir).ListChanges()
+	if err != nil {
+		t.Fatalf("ListChanges failed: %v", err)
+	}
+
+	if len(changes) != 1 || changes[0].Owner != "payments-team" {
+		t.Fatalf("Expected owner payments-team, got %+v", changes)
+	}
+	if changes[0].Title != "Add refunds" {
+		t.Errorf("Expected title 'Add refunds', got %q", changes[0].Title)
+	}
+}
+
 func TestListSpecs(t *testing.T) {
 	tmpDir := t.TempDir()
 	specsDir := filepath.Join(
@@ -595,6 +662,7 @@ func TestFilterChangesNotOnRef_EmptySlice(
 	// Test with empty changes slice - should return empty slice without error
 	var changes []ChangeInfo
 	result, err := FilterChangesNotOnRef(
+		context.Background(),
 		changes,
 		"origin/main",
 	)
@@ -696,6 +764,7 @@ func TestFilterChangesNotOnRef_Integration(
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			result, err := FilterChangesNotOnRef(
+				context.Background(),
 				tc.changes,
 				tc.ref,
 			)
@@ -879,6 +948,7 @@ func TestFilterChangesNotOnRef_MixedChanges(
 	}
 
 	result, err := FilterChangesNotOnRef(
+		context.Background(),
 		changes,
 		"HEAD",
 	)
@@ -1023,6 +1093,7 @@ func TestFilterChangesNotOnRef_PreservesChangeInfo(
 	changes := []ChangeInfo{originalChange}
 
 	result, err := FilterChangesNotOnRef(
+		context.Background(),
 		changes,
 		"HEAD",
 	)
@@ -0,0 +1,115 @@
+package list
+
+import (
+	"fmt"
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/connerohnesorge/spectr/internal/comment"
+	"github.com/connerohnesorge/spectr/internal/fileio"
+	"github.com/connerohnesorge/spectr/internal/inherit"
+	"github.com/connerohnesorge/spectr/internal/reader"
+)
+
+const specPreviewHelp = "↑/↓/j/k: outline | Enter/z: fold | Z: fold all | " +
+	"J/K: scroll | d/esc: back | q: quit"
+
+// specUnderCursor returns the ID of the spec on the cursor row, if the row
+// is a spec.
+func (m *interactiveModel) specUnderCursor() (string, bool) {
+	cursor := m.table.Cursor()
+	if cursor < 0 || cursor >= len(m.table.Rows()) {
+		return "", false
+	}
+	row := m.table.Rows()[cursor]
+
+	// Spec tables have no line number column, whatever the mode
+	colOffset := 0
+	if m.lineNumberMode != LineNumberOff && m.itemType != itemTypeSpec {
+		colOffset = 1
+	}
+	if len(row) <= colOffset {
+		return "", false
+	}
+
+	switch m.itemType {
+	case itemTypeSpec:
+		return row[colOffset], true
+	case itemTypeAll:
+		if len(row) > colOffset+1 && row[colOffset+1] == typeDisplaySpec {
+			return row[colOffset], true
+		}
+	}
+
+	return "", false
+}
+
+// handleSpecPreview opens the preview of a spec: an outline of its
+// sections, requirements and scenarios beside the styled text, with
+// requirements that have open review comments marked in the gutter.
+func (m *interactiveModel) handleSpecPreview(specID string) (tea.Model, tea.Cmd) {
+	path := m.getEditFilePath(specID, itemTypeSpec)
+	source, err := fileio.ReadFile(path)
+	if err != nil {
+		m.err = fmt.Errorf("spec preview: %w", err)
+
+		return m, nil
+	}
+
+	preview := reader.NewPreview(specID, reader.NewDocument(source))
+	preview.MarkRequirements(commentedRequirements(path, specID))
+	preview.SetSize(m.deltaPreviewSize())
+	m.specPreview = preview
+	m.err = nil
+
+	return m, nil
+}
+
+// handleSpecPreviewKey handles keys while the spec preview is open.
+func (m *interactiveModel) handleSpecPreviewKey(
+	msg tea.KeyMsg,
+) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "d", "esc":
+		m.specPreview = nil
+
+		return m, nil
+	case "q", "ctrl+c":
+		m.quitting = true
+
+		return m, tea.Quit
+	}
+
+	m.specPreview.Update(msg)
+
+	return m, nil
+}
+
+// viewSpecPreview renders the open spec preview with its footer.
+func (m *interactiveModel) viewSpecPreview() string {
+	return m.specPreview.View() + "\n" +
+		m.specPreview.Title() + " | " + specPreviewHelp + "\n"
+}
+
+// commentedRequirements returns the names of the requirements of the spec
+// at specPath with open review comments. Unreadable comments mark nothing;
+// spectr comment list reports them.
+func commentedRequirements(specPath, specID string) []string {
+	specsDir := inherit.SpecsDir(specPath)
+	if specsDir == "" {
+		return nil
+	}
+
+	threads, err := comment.List(filepath.Dir(filepath.Dir(specsDir)), specID, false)
+	if err != nil {
+		return nil
+	}
+	names := make([]string, 0, len(threads))
+	for _, thread := range threads {
+		if thread.CurrentName != "" {
+			names = append(names, thread.CurrentName)
+		}
+	}
+
+	return names
+}
@@ -0,0 +1,60 @@
+package list
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestInteractiveModel_SpecPreviewKeys(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "spectr", "specs", "auth")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	spec := "# Auth\n\n## Requirements\n\n### Requirement: User Login\n\nText.\n\n" +
+		"#### Scenario: Valid credentials\n\n- **WHEN** signing in\n"
+	if err := os.WriteFile(filepath.Join(dir, "spec.md"), []byte(spec), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	specs := []SpecInfo{{ID: "auth", RootAbsPath: root}}
+	m := &interactiveModel{
+		table: table.New(
+			table.WithColumns(calculateSpecsColumns(breakpointFull)),
+			table.WithRows(buildSpecsRows(specs, specTitleTruncate, 3)),
+		),
+		itemType:       itemTypeSpec,
+		projectPath:    root,
+		specsData:      specs,
+		lineNumberMode: LineNumberRelative,
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	if m.specPreview == nil {
+		t.Fatalf("d did not open the spec preview (err: %v)", m.err)
+	}
+	view := m.View()
+	for _, s := range []string{"▾ User Login", "· Valid credentials", specPreviewHelp} {
+		if !strings.Contains(view, s) {
+			t.Errorf("preview missing %q:\n%s", s, view)
+		}
+	}
+
+	// j selects the requirement; z folds it and hides its scenario
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("z")})
+	if view := m.View(); !strings.Contains(view, "▸ User Login") ||
+		strings.Contains(view, "Valid credentials") {
+		t.Errorf("z did not fold the requirement:\n%s", view)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.specPreview != nil || m.quitting {
+		t.Error("esc should close the preview without quitting")
+	}
+}
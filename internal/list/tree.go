@@ -0,0 +1,277 @@
+package list
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/connerohnesorge/spectr/internal/tui"
+)
+
+const (
+	// Tree drawing prefixes for FormatSpecsTree
+	treeBranch     = "├── "
+	treeLastBranch = "└── "
+	treePipe       = "│   "
+	treeSpace      = "    "
+
+	// treeGroupMarker separates group levels in the interactive tree view,
+	// e.g. "payments ▸ refunds"
+	treeGroupMarker = " ▸ "
+)
+
+// SpecTreeNode is one directory in the spec hierarchy. A node holds a spec
+// when its directory contains spec.md, children when it contains nested
+// spec directories, or both. SpecCount and RequirementCount aggregate the
+// node and everything beneath it.
+type SpecTreeNode struct {
+	Name             string          `json:"name"`
+	Path             string          `json:"path"`
+	Spec             *SpecInfo       `json:"spec,omitempty"`
+	Children         []*SpecTreeNode `json:"children,omitempty"`
+	SpecCount        int             `json:"specCount"`
+	RequirementCount int             `json:"requirementCount"`
+}
+
+// IsGroup reports whether the node has nested specs.
+func (n *SpecTreeNode) IsGroup() bool {
+	return len(n.Children) > 0
+}
+
+// BuildSpecTree groups specs by the directory hierarchy encoded in their
+// slash-separated IDs (payments/refunds sits under payments). When specs
+// come from several roots, each root becomes a top-level "[root]" group.
+// The returned root node is unnamed; its children are sorted by name.
+func BuildSpecTree(specs []SpecInfo) *SpecTreeNode {
+	root := &SpecTreeNode{}
+	hasMultipleRoots := detectMultiRootSpecs(specs)
+
+	for i := range specs {
+		spec := specs[i]
+		parts := strings.Split(spec.ID, "/")
+		if hasMultipleRoots && spec.RootPath != "" &&
+			spec.RootPath != currentDirPath {
+			parts = append(
+				[]string{"[" + spec.RootPath + "]"},
+				parts...,
+			)
+		}
+
+		node := root
+		for _, part := range parts {
+			node = node.child(part)
+		}
+		node.Spec = &spec
+	}
+
+	root.aggregate()
+
+	return root
+}
+
+// child returns the child named name, creating it if needed.
+func (n *SpecTreeNode) child(name string) *SpecTreeNode {
+	for _, c := range n.Children {
+		if c.Name == name {
+			return c
+		}
+	}
+
+	path := name
+	if n.Path != "" {
+		path = n.Path + "/" + name
+	}
+	c := &SpecTreeNode{Name: name, Path: path}
+	n.Children = append(n.Children, c)
+
+	return c
+}
+
+// aggregate sorts the subtree and fills in the aggregate counts.
+func (n *SpecTreeNode) aggregate() {
+	sort.Slice(n.Children, func(i, j int) bool {
+		return n.Children[i].Name < n.Children[j].Name
+	})
+
+	n.SpecCount, n.RequirementCount = 0, 0
+	if n.Spec != nil {
+		n.SpecCount = 1
+		n.RequirementCount = n.Spec.RequirementCount
+	}
+
+	for _, c := range n.Children {
+		c.aggregate()
+		n.SpecCount += c.SpecCount
+		n.RequirementCount += c.RequirementCount
+	}
+}
+
+// FormatSpecsTree renders specs as an indented tree grouped by directory.
+// Groups show aggregate spec and requirement counts; in long mode specs
+// also show their titles.
+//
+//nolint:revive // flag-parameter: long mirrors the --long flag
+func FormatSpecsTree(specs []SpecInfo, long bool) string {
+	if len(specs) == 0 {
+		return noItemsFoundMsg
+	}
+
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	writeSpecTree(w, BuildSpecTree(specs).Children, "", true, long)
+	_ = w.Flush()
+
+	return trimTrailingSpaces(b.String())
+}
+
+// writeSpecTree writes one line per node, recursing into groups. Top-level
+// nodes are written flush left; nested nodes hang off tree branches.
+//
+//nolint:revive // flag-parameter: long mirrors the --long flag
+func writeSpecTree(
+	w *tabwriter.Writer,
+	nodes []*SpecTreeNode,
+	indent string,
+	topLevel bool,
+	long bool,
+) {
+	for i, node := range nodes {
+		branch, next := treeBranch, treePipe
+		switch {
+		case topLevel:
+			branch, next = "", ""
+		case i == len(nodes)-1:
+			branch, next = treeLastBranch, treeSpace
+		}
+
+		fmt.Fprintf(w, "%s%s%s\n", indent, branch, formatTreeNode(node, long))
+		writeSpecTree(w, node.Children, indent+next, false, long)
+	}
+}
+
+// formatTreeNode renders a node's name and counts as tab-separated cells.
+//
+//nolint:revive // flag-parameter: long mirrors the --long flag
+func formatTreeNode(node *SpecTreeNode, long bool) string {
+	if node.IsGroup() {
+		line := fmt.Sprintf(
+			"%s/\t%s, %s",
+			node.Name,
+			pluralize(node.SpecCount, "spec"),
+			pluralize(node.RequirementCount, "requirement"),
+		)
+		if long {
+			// A group that is also a spec shows its title; others keep
+			// an empty cell so the title column stays aligned
+			line += "\t"
+			if node.Spec != nil {
+				line += node.Spec.Title
+			}
+		}
+
+		return line
+	}
+
+	line := fmt.Sprintf(
+		"%s\t%s",
+		node.Name,
+		pluralize(node.RequirementCount, "requirement"),
+	)
+	if long {
+		line += "\t" + node.Spec.Title
+	}
+
+	return line
+}
+
+// FormatSpecsTreeJSON renders the spec hierarchy as nested JSON.
+func FormatSpecsTreeJSON(specs []SpecInfo) (string, error) {
+	nodes := BuildSpecTree(specs).Children
+	if nodes == nil {
+		nodes = make([]*SpecTreeNode, 0)
+	}
+
+	data, err := json.MarshalIndent(nodes, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf(
+			"failed to marshal JSON: %w",
+			err,
+		)
+	}
+
+	return string(data), nil
+}
+
+// pluralize formats a count with its noun, e.g. "1 spec" or "3 specs".
+func pluralize(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, noun)
+	}
+
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+// buildSpecTreeRows creates table rows for the interactive tree view. Each
+// group gets a header row (ID "payments/", title "payments ▸ 2 specs")
+// carrying aggregate requirement counts, followed by its specs and
+// subgroups in tree order. Spec rows keep their full IDs so copy and edit
+// work as in the flat view.
+func buildSpecTreeRows(
+	specs []SpecInfo,
+	titleTruncate int,
+	numColumns int,
+) []table.Row {
+	var rows []table.Row
+	hasMultipleRoots := detectMultiRootSpecs(specs)
+
+	var walk func(nodes []*SpecTreeNode)
+	walk = func(nodes []*SpecTreeNode) {
+		for _, node := range nodes {
+			if node.IsGroup() {
+				title := strings.ReplaceAll(node.Path, "/", treeGroupMarker) +
+					treeGroupMarker + pluralize(node.SpecCount, "spec")
+				rows = append(rows, specTreeRow(
+					node.Path+"/",
+					tui.TruncateString(title, titleTruncate),
+					node.RequirementCount,
+					numColumns,
+				))
+			}
+
+			if node.Spec != nil {
+				rows = append(rows, specTreeRow(
+					formatSpecIDWithProject(
+						node.Spec.ID,
+						node.Spec.RootPath,
+						hasMultipleRoots,
+					),
+					tui.TruncateString(node.Spec.Title, titleTruncate),
+					node.Spec.RequirementCount,
+					numColumns,
+				))
+			}
+
+			walk(node.Children)
+		}
+	}
+	walk(BuildSpecTree(specs).Children)
+
+	return rows
+}
+
+// specTreeRow builds a specs table row, dropping the Requirements column
+// when the table is too narrow to show it.
+func specTreeRow(
+	id, title string,
+	requirements, numColumns int,
+) table.Row {
+	if numColumns == 3 {
+		return table.Row{id, title, strconv.Itoa(requirements)}
+	}
+
+	return table.Row{id, title}
+}
@@ -0,0 +1,149 @@
+package list
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func treeTestSpecs() []SpecInfo {
+	return []SpecInfo{
+		{ID: "payments/refunds", Title: "Refunds", RequirementCount: 4},
+		{ID: "auth", Title: "Auth", RequirementCount: 3},
+		{ID: "payments", Title: "Payments", RequirementCount: 1},
+		{ID: "payments/payouts/instant", Title: "Instant", RequirementCount: 2},
+	}
+}
+
+func TestBuildSpecTree(t *testing.T) {
+	root := BuildSpecTree(treeTestSpecs())
+
+	if root.SpecCount != 4 || root.RequirementCount != 10 {
+		t.Fatalf(
+			"root counts = %d specs, %d requirements; want 4, 10",
+			root.SpecCount,
+			root.RequirementCount,
+		)
+	}
+
+	if len(root.Children) != 2 {
+		t.Fatalf("got %d top-level nodes, want 2", len(root.Children))
+	}
+
+	auth, payments := root.Children[0], root.Children[1]
+	if auth.Name != "auth" || auth.IsGroup() || auth.Spec == nil {
+		t.Errorf("auth node = %+v, want a leaf spec", auth)
+	}
+
+	if payments.Spec == nil || payments.Spec.ID != "payments" {
+		t.Errorf("payments node should hold the payments spec")
+	}
+	if payments.SpecCount != 3 || payments.RequirementCount != 7 {
+		t.Errorf(
+			"payments counts = %d specs, %d requirements; want 3, 7",
+			payments.SpecCount,
+			payments.RequirementCount,
+		)
+	}
+
+	payouts := payments.Children[0]
+	if payouts.Path != "payments/payouts" || payouts.Spec != nil {
+		t.Errorf("payouts node = %+v, want a pure group", payouts)
+	}
+	if payouts.Children[0].Path != "payments/payouts/instant" {
+		t.Errorf("instant path = %q", payouts.Children[0].Path)
+	}
+}
+
+func TestBuildSpecTree_MultiRoot(t *testing.T) {
+	root := BuildSpecTree([]SpecInfo{
+		{ID: "auth", RootPath: "."},
+		{ID: "billing/invoices", RootPath: "services/api"},
+	})
+
+	names := make([]string, 0, len(root.Children))
+	for _, c := range root.Children {
+		names = append(names, c.Name)
+	}
+
+	want := "[services/api],auth"
+	if got := strings.Join(names, ","); got != want {
+		t.Errorf("top-level nodes = %q, want %q", got, want)
+	}
+}
+
+func TestFormatSpecsTree(t *testing.T) {
+	want := strings.Join([]string{
+		"auth             3 requirements",
+		"payments/        3 specs, 7 requirements",
+		"├── payouts/     1 spec, 2 requirements",
+		"│   └── instant  2 requirements",
+		"└── refunds      4 requirements",
+	}, "\n")
+
+	if got := FormatSpecsTree(treeTestSpecs(), false); got != want {
+		t.Errorf("FormatSpecsTree() =\n%s\nwant\n%s", got, want)
+	}
+
+	long := FormatSpecsTree(treeTestSpecs(), true)
+	if !strings.Contains(long, "Refunds") ||
+		!strings.Contains(long, "7 requirements  Payments") {
+		t.Errorf("long output missing titles:\n%s", long)
+	}
+	for line := range strings.SplitSeq(long, "\n") {
+		if strings.HasSuffix(line, " ") {
+			t.Errorf("line has trailing padding: %q", line)
+		}
+	}
+
+	if got := FormatSpecsTree(nil, false); got != noItemsFoundMsg {
+		t.Errorf("empty = %q, want %q", got, noItemsFoundMsg)
+	}
+}
+
+func TestFormatSpecsTreeJSON(t *testing.T) {
+	out, err := FormatSpecsTreeJSON(treeTestSpecs())
+	if err != nil {
+		t.Fatalf("FormatSpecsTreeJSON: %v", err)
+	}
+
+	var nodes []SpecTreeNode
+	if err := json.Unmarshal([]byte(out), &nodes); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(nodes) != 2 || nodes[1].RequirementCount != 7 {
+		t.Errorf("unexpected tree: %s", out)
+	}
+
+	empty, err := FormatSpecsTreeJSON(nil)
+	if err != nil || empty != "[]" {
+		t.Errorf("empty = %q, %v; want []", empty, err)
+	}
+}
+
+func TestBuildSpecTreeRows(t *testing.T) {
+	rows := buildSpecTreeRows(treeTestSpecs(), specTitleTruncate, 3)
+
+	var ids []string
+	for _, row := range rows {
+		ids = append(ids, row[0])
+	}
+
+	want := "auth,payments/,payments,payments/payouts/," +
+		"payments/payouts/instant,payments/refunds"
+	if got := strings.Join(ids, ","); got != want {
+		t.Errorf("row IDs = %q, want %q", got, want)
+	}
+
+	group := rows[1]
+	if group[1] != "payments ▸ 3 specs" || group[2] != "7" {
+		t.Errorf("group row = %v", group)
+	}
+	if rows[3][1] != "payments ▸ payouts ▸ 1 spec" {
+		t.Errorf("nested group title = %q", rows[3][1])
+	}
+
+	if narrow := buildSpecTreeRows(treeTestSpecs(), 20, 2); len(narrow[0]) != 2 {
+		t.Errorf("narrow rows have %d columns, want 2", len(narrow[0]))
+	}
+}
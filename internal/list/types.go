@@ -1,6 +1,11 @@
 package list
 
-import "github.com/connerohnesorge/spectr/internal/parsers"
+import (
+	"time"
+
+	"github.com/connerohnesorge/spectr/internal/parsers"
+	"github.com/connerohnesorge/spectr/internal/quality"
+)
 
 // ChangeInfo represents information about a change
 type ChangeInfo struct {
@@ -8,6 +13,12 @@ type ChangeInfo struct {
 	Title      string             `json:"title"`
 	DeltaCount int                `json:"deltaCount"`
 	TaskStatus parsers.TaskStatus `json:"taskStatus"`
+	// Owner is the owner declared in the proposal frontmatter, if any
+	Owner string `json:"owner,omitempty"`
+	// LastActivity is the latest modification time of the change's files
+	LastActivity time.Time `json:"lastActivity,omitzero"`
+	// Stale is set when the change has been idle past the stale threshold
+	Stale bool `json:"stale,omitempty"`
 	// RootPath is the relative path to the spectr root from cwd (empty for single root)
 	RootPath string `json:"rootPath,omitempty"`
 	// RootAbsPath is the absolute path to the spectr root (for internal use)
@@ -19,10 +30,16 @@ type SpecInfo struct {
 	ID               string `json:"id"`
 	Title            string `json:"title"`
 	RequirementCount int    `json:"requirementCount"`
+	// StatusCounts is the number of requirements per declared status
+	StatusCounts map[parsers.RequirementStatus]int `json:"statusCounts,omitempty"`
 	// RootPath is the relative path to the spectr root from cwd (empty for single root)
 	RootPath string `json:"rootPath,omitempty"`
 	// RootAbsPath is the absolute path to the spectr root (for internal use)
 	RootAbsPath string `json:"-"`
+	// Quality is the quality score, set only when the quality column is shown
+	Quality *quality.Report `json:"quality,omitempty"`
+	// ReviewDue is set when the spec is past its review interval
+	ReviewDue bool `json:"reviewDue,omitempty"`
 }
 
 // ItemType represents the type of an item (change or spec)
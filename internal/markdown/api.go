@@ -54,6 +54,10 @@ type Requirement struct {
 	// Scenarios contains all scenarios within this requirement.
 	Scenarios []*Scenario
 
+	// When is the feature-flag condition the requirement is gated on
+	// ("flag" or "!flag"), empty if ungated.
+	When string
+
 	// Node is the underlying AST node for this requirement.
 	Node *NodeRequirement
 }
@@ -63,6 +67,10 @@ type Scenario struct {
 	// Name is the scenario name (text after "Scenario:").
 	Name string
 
+	// When is the feature-flag condition the scenario is gated on, empty
+	// if ungated.
+	When string
+
 	// Node is the underlying AST node for this scenario.
 	Node *NodeScenario
 }
@@ -160,6 +168,7 @@ func (e *specExtractor) VisitRequirement(
 		Name:      n.Name(),
 		Section:   e.currentSection,
 		Scenarios: make([]*Scenario, 0),
+		When:      n.When(),
 		Node:      n,
 	}
 
@@ -182,6 +191,7 @@ func (e *specExtractor) VisitScenario(
 			e.currentReq.Scenarios,
 			&Scenario{
 				Name: n.Name(),
+				When: n.When(),
 				Node: n,
 			},
 		)
@@ -284,6 +294,7 @@ func (e *requirementExtractor) VisitRequirement(
 		Name:      n.Name(),
 		Section:   e.currentSection,
 		Scenarios: make([]*Scenario, 0),
+		When:      n.When(),
 		Node:      n,
 	}
 
@@ -302,6 +313,7 @@ func (e *requirementExtractor) VisitScenario(
 			e.currentReq.Scenarios,
 			&Scenario{
 				Name: n.Name(),
+				When: n.When(),
 				Node: n,
 			},
 		)
@@ -471,6 +483,7 @@ func (f *scenarioFinder) VisitScenario(
 	if strings.ToLower(n.Name()) == f.targetName {
 		f.found = &Scenario{
 			Name: n.Name(),
+			When: n.When(),
 			Node: n,
 		}
 		// Stop traversal
@@ -12,6 +12,7 @@ package markdown
 
 import (
 	"bytes"
+	"slices"
 	"strings"
 	"unicode"
 )
@@ -611,12 +612,13 @@ func MatchH2SectionHeader(
 }
 
 // MatchH2DeltaSection checks if a line is a delta section header
-// (## ADDED|MODIFIED|REMOVED|RENAMED Requirements).
+// (## ADDED|MODIFIED|REMOVED|RENAMED Requirements), in any of the forms
+// CanonicalDeltaSection accepts.
 // Returns the delta type and true if matched, or empty string and false.
 //
 // Example:
 //
-//	deltaType, ok := MatchH2DeltaSection("## ADDED Requirements")
+//	deltaType, ok := MatchH2DeltaSection("## Added requirements")
 //	// deltaType = "ADDED", ok = true
 func MatchH2DeltaSection(
 	line string,
@@ -625,24 +627,9 @@ func MatchH2DeltaSection(
 		return "", false
 	}
 
-	rest := strings.TrimPrefix(line, "## ")
-	rest = strings.TrimSpace(rest)
+	dt, ok := CanonicalDeltaSection(strings.TrimPrefix(line, "## "))
 
-	// Check for each delta type
-	deltaTypes := []string{
-		"ADDED",
-		"MODIFIED",
-		"REMOVED",
-		"RENAMED",
-	}
-	for _, dt := range deltaTypes {
-		suffix := dt + " Requirements"
-		if rest == suffix {
-			return dt, true
-		}
-	}
-
-	return "", false
+	return string(dt), ok
 }
 
 // IsTaskChecked returns true if the checkbox state indicates completion.
@@ -722,8 +709,9 @@ func TrimLeadingHashes(line string) string {
 }
 
 // ContainsKeyword checks if a line contains one of the Spectr keywords
-// (WHEN, THEN, AND, GIVEN) typically used in scenario descriptions.
-// Returns the keyword found and true, or empty and false.
+// (WHEN, THEN, AND, GIVEN) typically used in scenario descriptions, or an
+// alias of one set by SetKeywordAliases.
+// Returns the canonical keyword found and true, or empty and false.
 func ContainsKeyword(
 	line string,
 ) (keyword string, ok bool) {
@@ -742,6 +730,24 @@ func ContainsKeyword(
 		}
 	}
 
+	// Then for bold aliases
+	for rest := line; ; {
+		start := strings.Index(rest, "**")
+		if start < 0 {
+			break
+		}
+		rest = rest[start+2:]
+		end := strings.Index(rest, "**")
+		if end < 0 {
+			break
+		}
+		kw, ok := AliasedKeyword(rest[:end])
+		if ok && slices.Contains(keywords, kw) {
+			return kw, true
+		}
+		rest = rest[end+2:]
+	}
+
 	return "", false
 }
 
@@ -779,7 +785,8 @@ func IsHorizontalRule(line string) bool {
 }
 
 // FindDeltaSectionContent extracts the content from a delta section
-// (ADDED, MODIFIED, REMOVED, RENAMED) without the header line.
+// (ADDED, MODIFIED, REMOVED, RENAMED) without the header line. The
+// section header may be written in any form MatchH2DeltaSection accepts.
 // This is a compatibility function that matches the behavior of the
 // old regex.FindDeltaSectionContent function.
 //
@@ -795,57 +802,35 @@ func IsHorizontalRule(line string) bool {
 //	## MODIFIED Requirements
 //	...`
 //	FindDeltaSectionContent([]byte(content), DeltaAdded)
-//	// returns "\n### Requirement: New Feature\nContent here.\n"
+//	// returns "### Requirement: New Feature\nContent here.\n"
 func FindDeltaSectionContent(
 	content []byte,
 	deltaType DeltaType,
 ) string {
-	// Build the section header to search for
-	sectionHeader := "## " + string(
-		deltaType,
-	) + " Requirements"
-
-	// Find the start of the section
-	headerStart := bytes.Index(
-		content,
-		[]byte(sectionHeader),
-	)
-	if headerStart == -1 {
-		return ""
-	}
-
-	// Find the end of the header line
-	headerEnd := headerStart + len(sectionHeader)
-	for headerEnd < len(content) && content[headerEnd] != '\n' {
-		headerEnd++
-	}
-	if headerEnd < len(content) {
-		headerEnd++ // Include the newline
-	}
-
-	// Find the next H2 header (## followed by space)
-	rest := content[headerEnd:]
-	nextH2 := -1
-	lines := bytes.Split(rest, []byte("\n"))
+	// Find the first line that is a header of the section, and the next
+	// H2 header (## followed by space) after it
+	start, end := -1, len(content)
 	offset := 0
-	for _, line := range lines {
-		trimmed := bytes.TrimLeft(line, " \t")
-		if bytes.HasPrefix(
-			trimmed,
-			[]byte("## "),
-		) {
-			nextH2 = offset
-
+	for _, line := range bytes.SplitAfter(content, []byte("\n")) {
+		trimmed := strings.TrimSpace(string(line))
+		switch {
+		case start < 0:
+			if dt, ok := MatchH2DeltaSection(trimmed); ok && dt == string(deltaType) {
+				start = offset + len(line)
+			}
+		case strings.HasPrefix(trimmed, "## "):
+			end = offset
+		}
+		if end < len(content) {
 			break
 		}
-		offset += len(line) + 1 // +1 for newline
+		offset += len(line)
 	}
-
-	if nextH2 != -1 {
-		return string(rest[:nextH2])
+	if start < 0 {
+		return ""
 	}
 
-	return string(rest)
+	return string(content[start:end])
 }
 
 // MatchRenamedFrom checks if a line matches the backtick-wrapped FROM format
@@ -1108,15 +1108,20 @@ func TestMatchH2DeltaSection(t *testing.T) {
 			true,
 		}, // trailing spaces trimmed
 		{
-			"## Added Requirements",
-			"",
-			false,
-		}, // case sensitive
+			"## Added requirements",
+			"ADDED",
+			true,
+		}, // case insensitive
+		{
+			"## REMOVED Requirement:",
+			"REMOVED",
+			true,
+		}, // singular with a colon
 		{
-			"## ADDED Reqs",
+			"## Additions",
 			"",
 			false,
-		}, // must be "Requirements"
+		}, // not an operation without an alias
 		{
 			"### ADDED Requirements",
 			"",
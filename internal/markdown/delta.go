@@ -608,24 +608,7 @@ func MergeDelta(merged, delta *Delta) {
 func isDeltaSectionHeader(
 	title string,
 ) (DeltaType, bool) {
-	upper := strings.ToUpper(
-		strings.TrimSpace(title),
-	)
-
-	if strings.HasPrefix(upper, "ADDED") {
-		return DeltaAdded, true
-	}
-	if strings.HasPrefix(upper, "MODIFIED") {
-		return DeltaModified, true
-	}
-	if strings.HasPrefix(upper, "REMOVED") {
-		return DeltaRemoved, true
-	}
-	if strings.HasPrefix(upper, "RENAMED") {
-		return DeltaRenamed, true
-	}
-
-	return "", false
+	return CanonicalDeltaSection(title)
 }
 
 // FindRenamedPairs extracts all FROM/TO pairs from the content.
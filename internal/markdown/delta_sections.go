@@ -0,0 +1,137 @@
+package markdown
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"sync/atomic"
+
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+// DeltaTypes are the delta operations in the order their sections are
+// written.
+var DeltaTypes = []DeltaType{DeltaAdded, DeltaModified, DeltaRemoved, DeltaRenamed}
+
+// deltaAliases maps each upper-cased section title set by SetDeltaAliases
+// to its delta type.
+var deltaAliases atomic.Pointer[map[string]DeltaType]
+
+// SetDeltaAliases lets delta sections use other titles, e.g.
+// {"ADDED": {"Additions"}, "REMOVED": {"Deletions"}}. Aliases are matched
+// without regard to case or a trailing colon and replace any set before;
+// nil removes them all. Keys must be in DeltaTypes.
+func SetDeltaAliases(aliases map[string][]string) error {
+	if len(aliases) == 0 {
+		deltaAliases.Store(nil)
+
+		return nil
+	}
+
+	lookup := make(map[string]DeltaType)
+	for _, key := range slices.Sorted(maps.Keys(aliases)) {
+		deltaType := DeltaType(strings.ToUpper(strings.TrimSpace(key)))
+		if !slices.Contains(DeltaTypes, deltaType) {
+			return &specterrs.UnknownDeltaTypeError{Type: key}
+		}
+
+		for _, alias := range aliases[key] {
+			title := deltaTitleKey(alias)
+			if title == "" {
+				continue
+			}
+			if builtin, ok := builtinDeltaSection(title); ok {
+				if builtin == deltaType {
+					continue
+				}
+
+				return &specterrs.ConflictingDeltaAliasError{
+					Alias: alias, First: string(builtin), Second: string(deltaType),
+				}
+			}
+			if other, ok := lookup[title]; ok && other != deltaType {
+				return &specterrs.ConflictingDeltaAliasError{
+					Alias: alias, First: string(other), Second: string(deltaType),
+				}
+			}
+			lookup[title] = deltaType
+		}
+	}
+	deltaAliases.Store(&lookup)
+
+	return nil
+}
+
+// DeltaSectionTitle returns the canonical section title of deltaType,
+// e.g. "ADDED Requirements".
+func DeltaSectionTitle(deltaType DeltaType) string {
+	return string(deltaType) + " Requirements"
+}
+
+// CanonicalDeltaSection returns the delta type an H2 title names: any
+// title whose first word is the operation, in any case ("Added
+// requirements", "ADDED Requirement:", "REMOVED"), or an alias set by
+// SetDeltaAliases.
+func CanonicalDeltaSection(title string) (DeltaType, bool) {
+	key := deltaTitleKey(title)
+	if deltaType, ok := builtinDeltaSection(key); ok {
+		return deltaType, true
+	}
+
+	return aliasedDeltaSection(key)
+}
+
+// CanonicalizeDeltaSections returns source with every H2 delta section
+// title rewritten to its canonical form, so "## Added requirements" and
+// aliases such as "## Additions" become "## ADDED Requirements". Headings
+// in code blocks and all other text are kept as written.
+func CanonicalizeDeltaSections(source []byte) []byte {
+	var edits []sourceEdit
+	for _, h := range findHeadings(source) {
+		if h.level != 2 {
+			continue
+		}
+		deltaType, ok := CanonicalDeltaSection(h.text)
+		if !ok || h.text == DeltaSectionTitle(deltaType) {
+			continue
+		}
+		edits = append(edits, sourceEdit{
+			start: h.start,
+			end:   h.end,
+			text:  "## " + DeltaSectionTitle(deltaType),
+		})
+	}
+
+	return applyEdits(source, edits)
+}
+
+// deltaTitleKey returns title upper-cased, without surrounding space or a
+// trailing colon, as delta section titles are compared.
+func deltaTitleKey(title string) string {
+	title = strings.TrimSuffix(strings.TrimSpace(title), ":")
+
+	return strings.ToUpper(strings.TrimSpace(title))
+}
+
+// builtinDeltaSection returns the delta type of a title key whose first
+// word is an operation, such as "ADDED", "ADDED REQUIREMENT" or
+// "ADDED CAPABILITIES".
+func builtinDeltaSection(key string) (DeltaType, bool) {
+	op, _, _ := strings.Cut(key, " ")
+	if deltaType := DeltaType(op); slices.Contains(DeltaTypes, deltaType) {
+		return deltaType, true
+	}
+
+	return "", false
+}
+
+// aliasedDeltaSection returns the delta type a title key is an alias for.
+func aliasedDeltaSection(key string) (DeltaType, bool) {
+	aliases := deltaAliases.Load()
+	if aliases == nil {
+		return "", false
+	}
+	deltaType, ok := (*aliases)[key]
+
+	return deltaType, ok
+}
@@ -0,0 +1,125 @@
+package markdown
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+// setTestDeltaAliases sets delta section aliases for the test.
+func setTestDeltaAliases(t *testing.T) {
+	t.Helper()
+	err := SetDeltaAliases(map[string][]string{
+		"ADDED":   {"Additions", "New Requirements"},
+		"REMOVED": {"Deletions"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = SetDeltaAliases(nil) })
+}
+
+func TestSetDeltaAliases_Errors(t *testing.T) {
+	t.Cleanup(func() { _ = SetDeltaAliases(nil) })
+
+	var unknown *specterrs.UnknownDeltaTypeError
+	err := SetDeltaAliases(map[string][]string{"CHANGED": {"Changes"}})
+	if !errors.As(err, &unknown) || unknown.Type != "CHANGED" {
+		t.Errorf("unknown delta type: got %v", err)
+	}
+
+	var conflict *specterrs.ConflictingDeltaAliasError
+	err = SetDeltaAliases(map[string][]string{"ADDED": {"Changes"}, "MODIFIED": {"changes:"}})
+	if !errors.As(err, &conflict) || conflict.First != "ADDED" || conflict.Second != "MODIFIED" {
+		t.Errorf("duplicate alias: got %v", err)
+	}
+
+	err = SetDeltaAliases(map[string][]string{"ADDED": {"Removed Requirements"}})
+	if !errors.As(err, &conflict) || conflict.First != "REMOVED" {
+		t.Errorf("built-in title as alias: got %v", err)
+	}
+}
+
+func TestCanonicalDeltaSection(t *testing.T) {
+	setTestDeltaAliases(t)
+
+	tests := []struct {
+		title string
+		want  DeltaType
+		ok    bool
+	}{
+		{"ADDED Requirements", DeltaAdded, true},
+		{"Added requirements", DeltaAdded, true},
+		{"MODIFIED Requirement:", DeltaModified, true},
+		{"removed", DeltaRemoved, true},
+		{"RENAMED Capabilities", DeltaRenamed, true},
+		{"Additions", DeltaAdded, true},
+		{"new requirements:", DeltaAdded, true},
+		{"Deletions", DeltaRemoved, true},
+		{"Requirements", "", false},
+		{"Notes", "", false},
+		{"ADDEDX", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := CanonicalDeltaSection(tt.title)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("CanonicalDeltaSection(%q) = %q, %v; want %q, %v", tt.title, got, ok, tt.want, tt.ok)
+		}
+	}
+
+	_ = SetDeltaAliases(nil)
+	if _, ok := CanonicalDeltaSection("Additions"); ok {
+		t.Error("aliases should be cleared")
+	}
+}
+
+func TestFindDeltaSectionContent_Tolerant(t *testing.T) {
+	setTestDeltaAliases(t)
+
+	content := "# Delta\n\n## Added requirements\n### Requirement: A\n\n" +
+		"## Deletions\n### Requirement: B\n\n## Notes\nprose\n"
+	if got, want := FindDeltaSectionContent([]byte(content), DeltaAdded), "### Requirement: A\n\n"; got != want {
+		t.Errorf("ADDED content = %q, want %q", got, want)
+	}
+	if got, want := FindDeltaSectionContent([]byte(content), DeltaRemoved), "### Requirement: B\n\n"; got != want {
+		t.Errorf("REMOVED content = %q, want %q", got, want)
+	}
+	if got := FindDeltaSectionContent([]byte(content), DeltaRenamed); got != "" {
+		t.Errorf("RENAMED content = %q, want none", got)
+	}
+}
+
+func TestCanonicalizeDeltaSections(t *testing.T) {
+	setTestDeltaAliases(t)
+
+	input := "# Delta\n\n## Added requirements\n\n### Requirement: A\n\n" +
+		"## Deletions:\n\n```md\n## Removed\n```\n\n## Notes\n"
+	want := "# Delta\n\n## ADDED Requirements\n\n### Requirement: A\n\n" +
+		"## REMOVED Requirements\n\n```md\n## Removed\n```\n\n## Notes\n"
+	if got := string(CanonicalizeDeltaSections([]byte(input))); got != want {
+		t.Errorf("CanonicalizeDeltaSections() = %q, want %q", got, want)
+	}
+}
+
+func TestDiagnoseDeltaHeaders_Aliases(t *testing.T) {
+	setTestDeltaAliases(t)
+
+	if errs := DiagnoseDeltaHeaders([]byte("## Additions\n\n### Requirement: A\n")); len(errs) != 0 {
+		t.Errorf("DiagnoseDeltaHeaders() = %v, want aliases accepted", errs)
+	}
+}
+
+func TestUnknownDeltaSections(t *testing.T) {
+	setTestDeltaAliases(t)
+
+	input := "# Delta\n\n## ADDED Requirements\n\n## Additions\n\n## Deleted Requirements\n\n" +
+		"## Notes\n\n```md\n## Example\n```\n"
+	errs := UnknownDeltaSections([]byte(input))
+	if len(errs) != 1 || errs[0].Message != "section '## Notes' is not a delta section and is ignored" {
+		t.Fatalf("UnknownDeltaSections() = %v, want only Notes", errs)
+	}
+	if errs[0].Offset != 71 {
+		t.Errorf("Offset = %d, want 71", errs[0].Offset)
+	}
+}
@@ -0,0 +1,255 @@
+package markdown
+
+import (
+	"bytes"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Fix is a quick fix for a ParseError: replacing source[Start:End] with
+// NewText. Editors offer it as a code action and ApplyFixes applies it.
+type Fix struct {
+	Title   string // Short description, e.g. "Replace with '### Requirement:'"
+	Start   int    // Byte offset where the replacement starts
+	End     int    // Byte offset where the replacement ends (exclusive)
+	NewText string // Replacement text
+}
+
+// Canonical Spectr headers that near misses are corrected to.
+const (
+	requirementHeader = "### Requirement:"
+	scenarioHeader    = "#### Scenario:"
+)
+
+// nearMissHeader matches a line that looks like a "Keyword: title" header
+// once list markers, hashes and bold markers are stripped. headerKind
+// decides whether the keyword is a requirement or scenario.
+var nearMissHeader = regexp.MustCompile(`^([A-Za-z]+)\s*:\s*(\S.*)$`)
+
+// maxKeywordTypos is the edit distance up to which a header keyword is
+// read as a misspelled "Requirement" or "Scenario".
+const maxKeywordTypos = 2
+
+// Diagnose reports structural mistakes in Spectr markdown that parse
+// without error but silently lose content: requirement and scenario
+// headers written with the wrong level, as bold text or misspelled, and
+// code fences that are never closed. Each error carries a Suggestion and,
+// where the intent is unambiguous, a Fix. Text inside code fences is not
+// checked.
+//
+// Like Parse, Diagnose stops after DefaultMaxErrors errors; WithMaxErrors
+// changes the limit. Other options are ignored.
+func Diagnose(source []byte, opts ...ParseOption) []ParseError {
+	cfg := parser{maxErrors: DefaultMaxErrors}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	limited := func(errs []ParseError) bool {
+		return cfg.maxErrors > 0 && len(errs) >= cfg.maxErrors
+	}
+
+	var errs []ParseError
+	var fence []byte // Opening fence marker while inside a code fence
+	fenceStart := 0
+
+	for start := 0; start < len(source) && !limited(errs); {
+		end := start + bytes.IndexByte(source[start:], '\n')
+		next := end + 1
+		if end < start {
+			end, next = len(source), len(source)
+		}
+		line := bytes.TrimSuffix(source[start:end], []byte("\r"))
+
+		switch marker := fenceMarker(line); {
+		case fence != nil:
+			if marker != nil && marker[0] == fence[0] &&
+				len(marker) >= len(fence) &&
+				len(bytes.TrimSpace(line)) == len(marker) {
+				fence = nil
+			}
+		case marker != nil:
+			fence, fenceStart = marker, start
+		default:
+			if err, ok := diagnoseHeader(line, start); ok {
+				errs = append(errs, err)
+			}
+		}
+		start = next
+	}
+
+	if fence != nil && !limited(errs) {
+		errs = append(errs, unclosedFence(source, fence, fenceStart))
+	}
+
+	return errs
+}
+
+// ApplyFixes returns source with the fixes of errs applied. Fixes that
+// overlap an earlier fix are skipped; errors without a fix are ignored.
+func ApplyFixes(source []byte, errs []ParseError) []byte {
+	var edits []sourceEdit
+	for _, err := range errs {
+		if err.Fix != nil {
+			edits = append(edits, sourceEdit{
+				start: err.Fix.Start,
+				end:   err.Fix.End,
+				text:  err.Fix.NewText,
+			})
+		}
+	}
+	slices.SortStableFunc(edits, func(a, b sourceEdit) int {
+		return a.start - b.start
+	})
+
+	kept := edits[:0]
+	for _, edit := range edits {
+		if len(kept) > 0 && edit.start < kept[len(kept)-1].end {
+			continue
+		}
+		kept = append(kept, edit)
+	}
+
+	return applyEdits(source, kept)
+}
+
+// fenceMarker returns the backtick or tilde run opening a code fence on
+// line, or nil when line is not a fence. Up to three spaces of indentation
+// are allowed, as in CommonMark.
+func fenceMarker(line []byte) []byte {
+	trimmed := bytes.TrimLeft(line, " ")
+	if len(line)-len(trimmed) > 3 || len(trimmed) < 3 {
+		return nil
+	}
+	ch := trimmed[0]
+	if ch != '`' && ch != '~' {
+		return nil
+	}
+	n := 0
+	for n < len(trimmed) && trimmed[n] == ch {
+		n++
+	}
+	if n < 3 {
+		return nil
+	}
+
+	return trimmed[:n]
+}
+
+// unclosedFence reports a code fence opened at fenceStart that runs to
+// the end of source, with a fix that closes it there.
+func unclosedFence(source, fence []byte, fenceStart int) ParseError {
+	closing := string(fence) + "\n"
+	if len(source) > 0 && source[len(source)-1] != '\n' {
+		closing = "\n" + closing
+	}
+	line, _ := NewLineIndex(source).LineCol(fenceStart)
+	expected := TokenBacktick
+	if fence[0] == '~' {
+		expected = TokenTilde
+	}
+
+	return ParseError{
+		Offset: fenceStart,
+		Message: "unclosed code fence started at line " + itoa(line) +
+			"; everything after it is read as code",
+		Expected:   []TokenType{expected},
+		Suggestion: "close it with '" + string(fence) + "'",
+		Fix: &Fix{
+			Title:   "Close code fence at end of file",
+			Start:   len(source),
+			End:     len(source),
+			NewText: closing,
+		},
+	}
+}
+
+// diagnoseHeader reports line, starting at offset, when it is a
+// requirement or scenario header in the wrong form.
+func diagnoseHeader(line []byte, offset int) (ParseError, bool) {
+	text := strings.TrimSpace(string(line))
+	rest := text
+	for _, marker := range []string{"- ", "* ", "+ "} {
+		rest = strings.TrimPrefix(rest, marker)
+	}
+	level := len(rest) - len(strings.TrimLeft(rest, "#"))
+	rest = strings.TrimSpace(rest[level:])
+	bold := strings.Contains(rest, "**")
+	rest = strings.TrimSpace(strings.ReplaceAll(rest, "**", ""))
+
+	m := nearMissHeader.FindStringSubmatch(rest)
+	if m == nil || (level == 0 && !bold) {
+		return ParseError{}, false
+	}
+
+	kind := headerKind(m[1])
+	if kind == "" {
+		return ParseError{}, false
+	}
+	want := requirementHeader
+	if kind == "scenario" {
+		want = scenarioHeader
+	}
+	// The parser accepts the exact header with any spacing after the colon
+	wantLevel := len(want) - len(strings.TrimLeft(want, "#"))
+	if !bold && level == wantLevel &&
+		strings.HasPrefix(text, want[:wantLevel+1]) &&
+		strings.HasPrefix(strings.TrimSpace(text[wantLevel:]), want[wantLevel+1:]) {
+		return ParseError{}, false
+	}
+	fixed := want + " " + strings.TrimSpace(m[2])
+
+	start := offset + bytes.IndexByte(line, text[0])
+	err := ParseError{
+		Offset:     start,
+		Message:    "malformed " + kind + " header '" + text + "'",
+		Suggestion: "did you mean '" + want + "'?",
+		Fix: &Fix{
+			Title:   "Replace with '" + want + "'",
+			Start:   offset,
+			End:     start + len(text),
+			NewText: fixed,
+		},
+	}
+	if level != wantLevel {
+		err.Expected = []TokenType{TokenHash}
+	}
+
+	return err, true
+}
+
+// headerKind returns "requirement" or "scenario" when keyword is one of
+// them, in any case, plural or misspelled by up to maxKeywordTypos edits,
+// and "" otherwise.
+func headerKind(keyword string) string {
+	word := strings.ToLower(keyword)
+	for _, kind := range []string{"requirement", "scenario"} {
+		if word == kind+"s" || editDistance(word, kind) <= maxKeywordTypos {
+			return kind
+		}
+	}
+
+	return ""
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
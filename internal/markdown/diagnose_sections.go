@@ -0,0 +1,212 @@
+package markdown
+
+import (
+	"regexp"
+	"strings"
+)
+
+// deltaOpWords maps the words a delta section title may start with to the
+// canonical operation.
+var deltaOpWords = map[string]string{
+	"add":      "ADDED",
+	"added":    "ADDED",
+	"adds":     "ADDED",
+	"new":      "ADDED",
+	"modify":   "MODIFIED",
+	"modified": "MODIFIED",
+	"modifies": "MODIFIED",
+	"changed":  "MODIFIED",
+	"remove":   "REMOVED",
+	"removed":  "REMOVED",
+	"removes":  "REMOVED",
+	"deleted":  "REMOVED",
+	"rename":   "RENAMED",
+	"renamed":  "RENAMED",
+	"renames":  "RENAMED",
+}
+
+// nearMissDeltaTitle matches a heading that names a delta section, such
+// as "Added Requirements", "ADDED Requirement:" or "Removed".
+var nearMissDeltaTitle = regexp.MustCompile(
+	`(?i)^([a-z]+)(\s+requirements?)?\s*:?$`,
+)
+
+// DiagnoseDeltaHeaders reports delta section headings that are not read
+// as delta sections: other verb forms such as "## Deleted Requirements"
+// and delta titles at the wrong heading level. Aliases set by
+// SetDeltaAliases are accepted, and H2 titles CanonicalDeltaSection reads
+// in another form are left to NonCanonicalDeltaHeaders. Each error has a
+// fix replacing the heading.
+func DiagnoseDeltaHeaders(source []byte) []ParseError {
+	var errs []ParseError
+	for _, h := range findHeadings(source) {
+		if h.requirement || h.scenario || isReadDeltaHeading(h) {
+			continue
+		}
+		if want, ok := nearMissDeltaSection(h); ok {
+			errs = append(errs, headingError("delta section", h, DeltaSectionTitle(want)))
+		}
+	}
+
+	return errs
+}
+
+// NonCanonicalDeltaHeaders reports H2 headings CanonicalDeltaSection reads
+// as a delta section that are not written as the canonical
+// "## ADDED Requirements" (or MODIFIED, REMOVED, RENAMED), such as
+// "## Added requirements". They apply as written, so each is only a style
+// issue, with a fix replacing the heading as CanonicalizeDeltaSections
+// does.
+func NonCanonicalDeltaHeaders(source []byte) []ParseError {
+	var errs []ParseError
+	for _, h := range findHeadings(source) {
+		if !isReadDeltaHeading(h) {
+			continue
+		}
+		if want, ok := nearMissDeltaSection(h); ok {
+			fixed := "## " + DeltaSectionTitle(want)
+			errs = append(errs, ParseError{
+				Offset:     h.start,
+				Message:    "delta section header '## " + h.text + "' is not in canonical form",
+				Suggestion: "use '" + fixed + "', or run 'spectr fmt --delta-sections'",
+				Fix: &Fix{
+					Title:   "Replace with '" + fixed + "'",
+					Start:   h.start,
+					End:     h.end,
+					NewText: fixed,
+				},
+			})
+		}
+	}
+
+	return errs
+}
+
+// isReadDeltaHeading reports whether h is an H2 heading whose title
+// starts with a delta operation in any case, so it is read as a delta
+// section.
+func isReadDeltaHeading(h heading) bool {
+	if h.level != 2 || h.requirement || h.scenario {
+		return false
+	}
+	_, ok := builtinDeltaSection(deltaTitleKey(h.text))
+
+	return ok
+}
+
+// UnknownDeltaSections reports the H2 sections of a delta spec that are
+// not delta sections, nor near misses reported by DiagnoseDeltaHeaders.
+// Their content is ignored when the delta is applied.
+func UnknownDeltaSections(source []byte) []ParseError {
+	var errs []ParseError
+	for _, h := range findHeadings(source) {
+		if h.level != 2 {
+			continue
+		}
+		if _, ok := CanonicalDeltaSection(h.text); ok {
+			continue
+		}
+		if _, ok := nearMissDeltaSection(h); ok {
+			continue
+		}
+		errs = append(errs, ParseError{
+			Offset: h.start,
+			Message: "section '## " + h.text + "' is not a delta section " +
+				"and is ignored",
+			Suggestion: "use ## ADDED, MODIFIED, REMOVED or RENAMED Requirements, " +
+				"or an alias from deltas.aliases in spectr.yaml",
+		})
+	}
+
+	return errs
+}
+
+// nearMissDeltaSection returns the delta type of heading h when it names
+// a delta section in a form other than "## <TYPE> Requirements".
+func nearMissDeltaSection(h heading) (DeltaType, bool) {
+	if h.level == 2 {
+		key := deltaTitleKey(h.text)
+		if _, ok := aliasedDeltaSection(key); ok {
+			return "", false
+		}
+		if deltaType, ok := builtinDeltaSection(key); ok {
+			return deltaType, h.text != DeltaSectionTitle(deltaType)
+		}
+	}
+
+	m := nearMissDeltaTitle.FindStringSubmatch(h.text)
+	if m == nil {
+		return "", false
+	}
+	op, ok := deltaOpWords[strings.ToLower(m[1])]
+	// A lone verb names a delta section only at H2; deeper headings such
+	// as "### Removed" are ordinary prose
+	if !ok || (m[2] == "" && h.level != 2) {
+		return "", false
+	}
+
+	return DeltaType(op), true
+}
+
+// DiagnoseSectionHeaders reports headings that name one of titles but are
+// not written as "## <title>": other capitalization, a trailing colon or
+// the wrong heading level. Each error has a fix replacing the heading.
+func DiagnoseSectionHeaders(source []byte, titles []string) []ParseError {
+	var errs []ParseError
+	for _, h := range findHeadings(source) {
+		name := strings.TrimSpace(strings.TrimSuffix(h.text, ":"))
+		for _, title := range titles {
+			if !strings.EqualFold(name, title) || (h.level == 2 && h.text == title) {
+				continue
+			}
+			errs = append(errs, headingError("section", h, title))
+
+			break
+		}
+	}
+
+	return errs
+}
+
+// headingError reports heading h, which should be "## want".
+func headingError(kind string, h heading, want string) ParseError {
+	fixed := "## " + want
+	err := ParseError{
+		Offset: h.start,
+		Message: "malformed " + kind + " header '" +
+			strings.Repeat("#", h.level) + " " + h.text + "'",
+		Suggestion: "did you mean '" + fixed + "'?",
+		Fix: &Fix{
+			Title:   "Replace with '" + fixed + "'",
+			Start:   h.start,
+			End:     h.end,
+			NewText: fixed,
+		},
+	}
+	if h.level != 2 {
+		err.Expected = []TokenType{TokenHash}
+	}
+
+	return err
+}
+
+// SectionOffsets returns the byte offset of the "## <title>" heading of
+// each of titles in source, or -1 for a title without one.
+func SectionOffsets(source []byte, titles []string) []int {
+	offsets := make([]int, len(titles))
+	for i := range offsets {
+		offsets[i] = -1
+	}
+	for _, h := range findHeadings(source) {
+		if h.level != 2 {
+			continue
+		}
+		for i, title := range titles {
+			if h.text == title && offsets[i] < 0 {
+				offsets[i] = h.start
+			}
+		}
+	}
+
+	return offsets
+}
@@ -0,0 +1,126 @@
+package markdown
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiagnoseDeltaHeaders(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		messages []string
+		fixed    string
+		// style cases are reported by NonCanonicalDeltaHeaders
+		style bool
+	}{
+		{
+			name: "canonical titles",
+			input: "## ADDED Requirements\n\n### Requirement: A\n\n" +
+				"## MODIFIED Requirements\n\n## REMOVED Requirements\n\n## RENAMED Requirements\n",
+		},
+		{
+			name:     "title case",
+			input:    "## Added Requirements\n\n### Requirement: A\n",
+			messages: []string{"delta section header '## Added Requirements' is not in canonical form"},
+			style:    true,
+			fixed:    "## ADDED Requirements\n\n### Requirement: A\n",
+		},
+		{
+			name:     "singular with colon",
+			input:    "## MODIFIED Requirement:\n",
+			messages: []string{"delta section header '## MODIFIED Requirement:' is not in canonical form"},
+			style:    true,
+			fixed:    "## MODIFIED Requirements\n",
+		},
+		{
+			name:     "verb only",
+			input:    "## Removed\n",
+			messages: []string{"delta section header '## Removed' is not in canonical form"},
+			style:    true,
+			fixed:    "## REMOVED Requirements\n",
+		},
+		{
+			name:     "wrong level",
+			input:    "# RENAMED Requirements\n",
+			messages: []string{"malformed delta section header '# RENAMED Requirements'"},
+			fixed:    "## RENAMED Requirements\n",
+		},
+		{
+			name:     "other verb form",
+			input:    "## Deleted Requirements\n",
+			messages: []string{"malformed delta section header '## Deleted Requirements'"},
+			fixed:    "## REMOVED Requirements\n",
+		},
+		{
+			name:  "deeper verb headings are prose",
+			input: "## ADDED Requirements\n\n### Requirement: A\n\n#### Removed\n",
+		},
+		{
+			name:  "headers in code are ignored",
+			input: "```md\n## Added Requirements\n```\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diagnose, other := DiagnoseDeltaHeaders, NonCanonicalDeltaHeaders
+			if tt.style {
+				diagnose, other = other, diagnose
+			}
+			errs := diagnose([]byte(tt.input))
+			if len(errs) != len(tt.messages) {
+				t.Fatalf("diagnose() = %v, want %d error(s)", errs, len(tt.messages))
+			}
+			if extra := other([]byte(tt.input)); len(extra) != 0 {
+				t.Errorf("other diagnose() = %v, want none", extra)
+			}
+			for i, err := range errs {
+				if err.Message != tt.messages[i] {
+					t.Errorf("errs[%d].Message = %q, want %q", i, err.Message, tt.messages[i])
+				}
+			}
+			if len(errs) == 0 {
+				return
+			}
+			if got := string(ApplyFixes([]byte(tt.input), errs)); got != tt.fixed {
+				t.Errorf("ApplyFixes() = %q, want %q", got, tt.fixed)
+			}
+			if again := diagnose([]byte(tt.fixed)); len(again) != 0 {
+				t.Errorf("diagnose(fixed) = %v, want none", again)
+			}
+		})
+	}
+}
+
+func TestDiagnoseSectionHeaders(t *testing.T) {
+	titles := []string{"Why", "What Changes", "Impact"}
+	input := "# Change\n\n## why\n\nReasons.\n\n### What Changes:\n\n- x\n\n## Impact\n\n## Whys\n"
+
+	errs := DiagnoseSectionHeaders([]byte(input), titles)
+	want := []string{
+		"malformed section header '## why'",
+		"malformed section header '### What Changes:'",
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("DiagnoseSectionHeaders() = %v, want %d errors", errs, len(want))
+	}
+	for i, err := range errs {
+		if err.Message != want[i] {
+			t.Errorf("errs[%d].Message = %q, want %q", i, err.Message, want[i])
+		}
+	}
+
+	fixed := "# Change\n\n## Why\n\nReasons.\n\n## What Changes\n\n- x\n\n## Impact\n\n## Whys\n"
+	if got := string(ApplyFixes([]byte(input), errs)); got != fixed {
+		t.Errorf("ApplyFixes() = %q, want %q", got, fixed)
+	}
+}
+
+func TestSectionOffsets(t *testing.T) {
+	input := "# Change\n\n## Impact\n\n## Why\n\n### What Changes\n"
+	got := SectionOffsets([]byte(input), []string{"Why", "What Changes", "Impact"})
+	if want := []int{21, -1, 10}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SectionOffsets() = %v, want %v", got, want)
+	}
+}
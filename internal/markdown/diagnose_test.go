@@ -0,0 +1,195 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDiagnose(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		messages   []string
+		suggestion string
+		fixed      string
+	}{
+		{
+			name: "well formed",
+			input: "## Requirements\n\n### Requirement: A\nThe system SHALL x.\n\n" +
+				"#### Scenario: One\n- **WHEN** x\n",
+		},
+		{
+			name:  "spacing after colon is accepted",
+			input: "### Requirement:  A\n#### Scenario:B\n",
+		},
+		{
+			name:       "requirement at level 2",
+			input:      "## Requirement: Login\n",
+			messages:   []string{"malformed requirement header '## Requirement: Login'"},
+			suggestion: "did you mean '### Requirement:'?",
+			fixed:      "### Requirement: Login\n",
+		},
+		{
+			name:       "lowercase plural requirement",
+			input:      "### requirements: Login\n",
+			messages:   []string{"malformed requirement header '### requirements: Login'"},
+			suggestion: "did you mean '### Requirement:'?",
+			fixed:      "### Requirement: Login\n",
+		},
+		{
+			name:       "scenario at level 3",
+			input:      "### Requirement: A\n\n### Scenario: One\n",
+			messages:   []string{"malformed scenario header '### Scenario: One'"},
+			suggestion: "did you mean '#### Scenario:'?",
+			fixed:      "### Requirement: A\n\n#### Scenario: One\n",
+		},
+		{
+			name:       "bold bullet scenario",
+			input:      "  - **Scenario: One**\n",
+			messages:   []string{"malformed scenario header '- **Scenario: One**'"},
+			suggestion: "did you mean '#### Scenario:'?",
+			fixed:      "#### Scenario: One\n",
+		},
+		{
+			name:       "misspelled requirement",
+			input:      "### Requirment: Foo\n",
+			messages:   []string{"malformed requirement header '### Requirment: Foo'"},
+			suggestion: "did you mean '### Requirement:'?",
+			fixed:      "### Requirement: Foo\n",
+		},
+		{
+			name:       "misspelled plural requirement",
+			input:      "### Requirments: Foo\n",
+			messages:   []string{"malformed requirement header '### Requirments: Foo'"},
+			suggestion: "did you mean '### Requirement:'?",
+			fixed:      "### Requirement: Foo\n",
+		},
+		{
+			name:       "misspelled scenario at level 3",
+			input:      "### Requirement: A\n\n### Senario: One\n",
+			messages:   []string{"malformed scenario header '### Senario: One'"},
+			suggestion: "did you mean '#### Scenario:'?",
+			fixed:      "### Requirement: A\n\n#### Scenario: One\n",
+		},
+		{
+			name:       "transposed scenario",
+			input:      "#### Scenraio: One\n",
+			messages:   []string{"malformed scenario header '#### Scenraio: One'"},
+			suggestion: "did you mean '#### Scenario:'?",
+			fixed:      "#### Scenario: One\n",
+		},
+		{
+			name:  "other keywords are not headers",
+			input: "### Requires: Login\n### Rationale: Simpler\n#### Note: x\n",
+		},
+		{
+			name:  "prose and sections are not headers",
+			input: "## Requirements\n\nScenario: plain prose\n- FROM: `### Requirement: Old`\n",
+		},
+		{
+			name:  "headers in code are ignored",
+			input: "```md\n## Requirement: Example\n```\n",
+		},
+		{
+			name:  "longer closing fence closes",
+			input: "````\n```\n## Requirement: Example\n`````\n",
+		},
+		{
+			name:       "unclosed fence",
+			input:      "# Spec\n\n```go\ncode\n## Requirement: Hidden",
+			messages:   []string{"unclosed code fence started at line 3; everything after it is read as code"},
+			suggestion: "close it with '```'",
+			fixed:      "# Spec\n\n```go\ncode\n## Requirement: Hidden\n```\n",
+		},
+		{
+			name:     "both",
+			input:    "## Requirement: A\n~~~\n",
+			messages: []string{"malformed requirement header '## Requirement: A'", "unclosed code fence started at line 2; everything after it is read as code"},
+			fixed:    "### Requirement: A\n~~~\n~~~\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := Diagnose([]byte(tt.input))
+			if len(errs) != len(tt.messages) {
+				t.Fatalf("Diagnose() = %v, want %d error(s)", errs, len(tt.messages))
+			}
+			for i, err := range errs {
+				if err.Message != tt.messages[i] {
+					t.Errorf("errs[%d].Message = %q, want %q", i, err.Message, tt.messages[i])
+				}
+				if err.Fix == nil {
+					t.Errorf("errs[%d].Fix = nil", i)
+				}
+			}
+			if tt.suggestion != "" && errs[0].Suggestion != tt.suggestion {
+				t.Errorf("Suggestion = %q, want %q", errs[0].Suggestion, tt.suggestion)
+			}
+			if len(errs) == 0 {
+				return
+			}
+			if got := string(ApplyFixes([]byte(tt.input), errs)); got != tt.fixed {
+				t.Errorf("ApplyFixes() = %q, want %q", got, tt.fixed)
+			}
+			if again := Diagnose([]byte(tt.fixed)); len(again) != 0 {
+				t.Errorf("Diagnose(fixed) = %v, want none", again)
+			}
+		})
+	}
+}
+
+func TestApplyFixes_SkipsOverlapping(t *testing.T) {
+	source := []byte("abcdef")
+	errs := []ParseError{
+		{Fix: &Fix{Start: 2, End: 4, NewText: "X"}},
+		{Message: "no fix"},
+		{Fix: &Fix{Start: 0, End: 3, NewText: "Y"}},
+		{Fix: &Fix{Start: 6, End: 6, NewText: "!"}},
+	}
+
+	if got := string(ApplyFixes(source, errs)); got != "Ydef!" {
+		t.Errorf("ApplyFixes() = %q, want %q", got, "Ydef!")
+	}
+}
+
+func TestDiagnose_MaxErrors(t *testing.T) {
+	source := []byte(strings.Repeat("## Requirement: Login\n", 150))
+
+	tests := []struct {
+		name string
+		opts []ParseOption
+		want int
+	}{
+		{name: "default limit", want: DefaultMaxErrors},
+		{name: "lower limit", opts: []ParseOption{WithMaxErrors(3)}, want: 3},
+		{name: "no limit", opts: []ParseOption{WithMaxErrors(0)}, want: 150},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := len(Diagnose(source, tt.opts...)); got != tt.want {
+				t.Errorf("len(Diagnose()) = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"requirement", "requirement", 0},
+		{"requirment", "requirement", 1},
+		{"scenraio", "scenario", 2},
+		{"", "scenario", 8},
+		{"requires", "requirement", 4},
+	}
+
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -36,6 +36,7 @@
 //   - Requirement headers: ### Requirement: Name
 //   - Scenario headers: #### Scenario: Description
 //   - WHEN/THEN/AND bullets: - **WHEN** condition, - **THEN** result
+//     (or aliases set with SetKeywordAliases, e.g. - **QUAND** condition)
 //   - Delta sections: ## ADDED Requirements, ## MODIFIED Requirements, etc.
 //
 // # Key Types
@@ -78,23 +79,32 @@
 //   - NodeCode: Inline code
 //   - NodeLink: Link with URL() and Title() getters
 //   - NodeWikilink: Wikilink with Target(), Display(), and Anchor() getters
+//   - NodeHTMLComment: Block-level HTML comment with Content() getter
+//   - NodeTable: Pipe table with Alignments(), Header(), and Rows() getters
+//   - NodeTableRow: Table row with IsHeader() and Cells() getters
+//   - NodeTableCell: Table cell with Align() and Text() getters
 //
 // ParseError represents a parse error with location:
 //
 //	type ParseError struct {
-//	    Offset   int           // Byte offset where error occurred
-//	    Message  string        // Human-readable error description
-//	    Expected []TokenType   // What tokens would have been valid
+//	    Offset     int           // Byte offset where error occurred
+//	    Message    string        // Human-readable error description
+//	    Expected   []TokenType   // What tokens would have been valid
+//	    Suggestion string        // How to correct it ("did you mean ...?")
+//	    Fix        *Fix          // Quick fix replacing a byte range
 //	}
 //
 // # Key Functions
 //
 // Parse is the main entry point for parsing markdown:
 //
-//	func Parse(source []byte) (Node, []ParseError)
+//	func Parse(source []byte, opts ...ParseOption) (Node, []ParseError)
 //
 // Parse is stateless and safe for concurrent calls. It returns the root document
 // node and any errors encountered. Even with errors, a partial AST is returned.
+// Pass WithHTMLComments to keep block-level HTML comments as NodeHTMLComment
+// nodes so they survive a Parse/Print round-trip, and WithTables to parse
+// GFM pipe tables into NodeTable nodes.
 //
 // ParseIncremental enables efficient reparsing after edits:
 //
@@ -104,6 +114,24 @@
 // affected regions, reparses only changed sections, and reuses unchanged subtrees
 // via content hash matching. This provides tree-sitter style incremental parsing.
 //
+// Render serializes an AST back to markdown:
+//
+//	func Render(node Node) []byte
+//
+// Nodes unchanged since Parse are written as they appear in the source, with
+// the whitespace between them, so Render(Parse(src)) returns src. Nodes built
+// or changed by a transform are written in Print's canonical form, so a
+// transform such as RenameRequirement followed by Render rewrites only the
+// lines it touched. Print normalizes the whole tree instead.
+//
+// Diagnose finds Spectr mistakes that parse cleanly but lose content:
+//
+//	func Diagnose(source []byte) []ParseError
+//
+// It reports near-miss requirement and scenario headers ("## Requirement:",
+// "**Scenario:**") and unclosed code fences, each with a Suggestion and a Fix.
+// ApplyFixes applies the fixes, as spectr validate --fix does.
+//
 // # Usage Examples
 //
 // Basic parsing:
@@ -211,10 +239,9 @@
 // This package intentionally does not support:
 //
 //   - Full CommonMark compliance (focused subset for Spectr)
-//   - Tables
 //   - HTML passthrough
 //   - Setext-style headers (underlined with === or ---)
-//   - GFM extensions beyond task checkboxes and strikethrough
+//   - GFM extensions beyond task checkboxes, strikethrough, and pipe tables
 //
 //nolint:revive // line-length-limit: documentation lines exceed 80 chars for readability
 package markdown
@@ -0,0 +1,125 @@
+package markdown
+
+import (
+	"bytes"
+	"strings"
+)
+
+// HeadingIssueKind classifies a problem found by LintHeadings.
+type HeadingIssueKind int
+
+const (
+	// HeadingJump is a heading more than one level below the heading
+	// before it, e.g. ## followed directly by ####.
+	HeadingJump HeadingIssueKind = iota + 1
+	// OrphanScenario is a #### Scenario: heading that is not inside a
+	// ### Requirement:, so parsers attach it to the wrong requirement or
+	// drop it.
+	OrphanScenario
+)
+
+// HeadingIssue is a problem with the heading hierarchy of a document.
+type HeadingIssue struct {
+	Kind HeadingIssueKind
+	// Line is the 1-based line of the heading
+	Line int
+	// Level is the heading level as written
+	Level int
+	// Prev is the level of the heading before it, or 0 for the first
+	Prev int
+	// Want is the level FixHeadingLevels gives the heading. It equals
+	// Level when re-leveling cannot fix the issue.
+	Want int
+	// Text is the heading text without the # prefix
+	Text string
+}
+
+// LintHeadings reports heading level jumps and scenarios outside a
+// requirement. A scenario outside a requirement is reported once, as an
+// OrphanScenario, even when it also follows a jump.
+func LintHeadings(source []byte) []HeadingIssue {
+	headings := findHeadings(source)
+	wants := headingLevels(headings)
+	lines := NewLineIndex(source)
+
+	var issues []HeadingIssue
+	for i, h := range headings {
+		var kind HeadingIssueKind
+		switch {
+		case h.scenario && !insideRequirement(headings, wants, i):
+			kind = OrphanScenario
+		case i > 0 && h.level > headings[i-1].level+1:
+			kind = HeadingJump
+		default:
+			continue
+		}
+
+		line, _ := lines.LineCol(h.start)
+		prev := 0
+		if i > 0 {
+			prev = headings[i-1].level
+		}
+		issues = append(issues, HeadingIssue{
+			Kind:  kind,
+			Line:  line,
+			Level: h.level,
+			Prev:  prev,
+			Want:  wants[i],
+			Text:  h.text,
+		})
+	}
+
+	return issues
+}
+
+// FixHeadingLevels returns source with heading level jumps closed by
+// raising each heading to one level below the heading before it; headings
+// nested under a raised heading move up with it. Requirement and scenario
+// headings keep their level, since re-leveling them would change how they
+// parse. All other text is kept byte-for-byte.
+func FixHeadingLevels(source []byte) []byte {
+	headings := findHeadings(source)
+	wants := headingLevels(headings)
+
+	var edits []sourceEdit
+	for i, h := range headings {
+		if wants[i] == h.level {
+			continue
+		}
+		hashes := h.start + bytes.IndexByte(source[h.start:h.end], '#')
+		edits = append(edits, sourceEdit{
+			start: hashes,
+			end:   hashes + h.level,
+			text:  strings.Repeat("#", wants[i]),
+		})
+	}
+
+	return applyEdits(source, edits)
+}
+
+// headingLevels returns the level each heading should have: no deeper
+// than one below the (fixed) heading before it. Requirement and scenario
+// headings keep their level.
+func headingLevels(headings []heading) []int {
+	wants := make([]int, len(headings))
+	for i, h := range headings {
+		wants[i] = h.level
+		if i > 0 && !h.requirement && !h.scenario {
+			wants[i] = min(h.level, wants[i-1]+1)
+		}
+	}
+
+	return wants
+}
+
+// insideRequirement reports whether the nearest heading above headings[i]
+// at a higher level is a requirement.
+func insideRequirement(headings []heading, wants []int, i int) bool {
+	for j := i - 1; j >= 0; j-- {
+		if wants[j] < headings[i].level {
+			return headings[j].requirement
+		}
+	}
+
+	return false
+}
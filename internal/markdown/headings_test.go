@@ -0,0 +1,107 @@
+package markdown
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLintHeadings(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []HeadingIssue
+	}{
+		{
+			name: "well formed",
+			input: "# Spec\n\n## Requirements\n\n### Requirement: A\n\n" +
+				"#### Scenario: One\n- **WHEN** x\n\n##### Notes\n\n" +
+				"#### Scenario: Two\n",
+			want: nil,
+		},
+		{
+			name:  "jump",
+			input: "# Spec\n\n## Context\n\n#### Details\n\n##### More\n",
+			want: []HeadingIssue{
+				{Kind: HeadingJump, Line: 5, Level: 4, Prev: 2, Want: 3, Text: "Details"},
+			},
+		},
+		{
+			name: "scenario under a section",
+			input: "## Requirements\n\n#### Scenario: Stray\n" +
+				"- **WHEN** x\n",
+			want: []HeadingIssue{
+				{Kind: OrphanScenario, Line: 3, Level: 4, Prev: 2, Want: 4, Text: "Scenario: Stray"},
+			},
+		},
+		{
+			name: "scenario after another section",
+			input: "### Requirement: A\n\n#### Scenario: Ok\n\n" +
+				"### Notes\n\n#### Scenario: Lost\n",
+			want: []HeadingIssue{
+				{Kind: OrphanScenario, Line: 7, Level: 4, Prev: 3, Want: 4, Text: "Scenario: Lost"},
+			},
+		},
+		{
+			name:  "requirement jump cannot be re-leveled",
+			input: "# Spec\n\n### Requirement: A\n",
+			want: []HeadingIssue{
+				{Kind: HeadingJump, Line: 3, Level: 3, Prev: 1, Want: 3, Text: "Requirement: A"},
+			},
+		},
+		{
+			name:  "headings in code are ignored",
+			input: "## A\n\n```\n#### B\n```\n",
+			want:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := LintHeadings([]byte(tt.input))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LintHeadings() =\n%+v\nwant\n%+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFixHeadingLevels(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+		// stray is set when the fix leaves a stray scenario behind
+		stray bool
+	}{
+		{
+			name:  "raises nested headings together",
+			input: "# Spec\n\n## Context\n\n#### Details\n\n##### More\n\n## Next\n",
+			want:  "# Spec\n\n## Context\n\n### Details\n\n#### More\n\n## Next\n",
+		},
+		{
+			name: "keeps requirement and scenario levels",
+			input: "# Spec\n\n## Requirements\n\n#### Scenario: Stray\n\n" +
+				"### Requirement: A\n\n###### Deep\n",
+			want: "# Spec\n\n## Requirements\n\n#### Scenario: Stray\n\n" +
+				"### Requirement: A\n\n#### Deep\n",
+			stray: true,
+		},
+		{
+			name:  "already valid",
+			input: "# A\n\n## B\n\n### C\n",
+			want:  "# A\n\n## B\n\n### C\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := string(FixHeadingLevels([]byte(tt.input)))
+			if got != tt.want {
+				t.Errorf("FixHeadingLevels() =\n%s\nwant:\n%s", got, tt.want)
+			}
+			if issues := LintHeadings([]byte(got)); len(issues) > 0 && !tt.stray {
+				t.Errorf("issues left after fix: %+v", issues)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package markdown
+
+import (
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"sync/atomic"
+
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+// ScenarioKeywords are the canonical scenario step keywords, as written in
+// English: - **WHEN** condition.
+var ScenarioKeywords = []string{"GIVEN", "WHEN", "THEN", "AND", "BUT"}
+
+// keywordAliases maps each upper-cased alias set by SetKeywordAliases to
+// its canonical keyword.
+var keywordAliases atomic.Pointer[map[string]string]
+
+// SetKeywordAliases lets scenarios use other words for the step keywords,
+// e.g. {"WHEN": {"QUAND", "当"}, "THEN": {"ALORS", "那么"}}. Aliases are
+// matched without regard to case and replace any set before; nil removes
+// them all. Keys must be in ScenarioKeywords.
+func SetKeywordAliases(aliases map[string][]string) error {
+	if len(aliases) == 0 {
+		keywordAliases.Store(nil)
+
+		return nil
+	}
+
+	lookup := make(map[string]string)
+	for _, keyword := range slices.Sorted(maps.Keys(aliases)) {
+		canonical := strings.ToUpper(strings.TrimSpace(keyword))
+		if !slices.Contains(ScenarioKeywords, canonical) {
+			return &specterrs.UnknownScenarioKeywordError{Keyword: keyword}
+		}
+
+		for _, alias := range aliases[keyword] {
+			key := strings.ToUpper(strings.TrimSpace(alias))
+			if key == "" || key == canonical {
+				continue
+			}
+			if slices.Contains(ScenarioKeywords, key) {
+				return &specterrs.ConflictingKeywordAliasError{
+					Alias: alias, First: key, Second: canonical,
+				}
+			}
+			if other, ok := lookup[key]; ok && other != canonical {
+				return &specterrs.ConflictingKeywordAliasError{
+					Alias: alias, First: other, Second: canonical,
+				}
+			}
+			lookup[key] = canonical
+		}
+	}
+	keywordAliases.Store(&lookup)
+
+	return nil
+}
+
+// CanonicalKeyword returns the scenario keyword that word stands for,
+// either a keyword itself or an alias set by SetKeywordAliases, ignoring
+// case.
+func CanonicalKeyword(word string) (string, bool) {
+	key := strings.ToUpper(strings.TrimSpace(word))
+	if slices.Contains(ScenarioKeywords, key) {
+		return key, true
+	}
+
+	return AliasedKeyword(word)
+}
+
+// AliasedKeyword returns the scenario keyword that word is an alias for,
+// ignoring case. Keywords themselves are not aliases.
+func AliasedKeyword(word string) (string, bool) {
+	aliases := keywordAliases.Load()
+	if aliases == nil {
+		return "", false
+	}
+	canonical, ok := (*aliases)[strings.ToUpper(strings.TrimSpace(word))]
+
+	return canonical, ok
+}
+
+// stepBulletPattern matches a list item that starts with a bold word:
+// "- **WHEN**", "* [ ] **QUAND**".
+var stepBulletPattern = regexp.MustCompile(
+	`^(\s*[-*+]\s+(?:\[[ xX]\]\s+)?)\*\*([^*\n]+)\*\*`,
+)
+
+// CanonicalizeKeywords returns source with aliased scenario keywords
+// rewritten to their English keyword, so "- **QUAND** ..." becomes
+// "- **WHEN** ...". Fenced code blocks and all other text are kept as
+// written.
+func CanonicalizeKeywords(source []byte) []byte {
+	if keywordAliases.Load() == nil {
+		return source
+	}
+
+	var (
+		out   strings.Builder
+		fence rune
+	)
+	out.Grow(len(source))
+
+	for line := range strings.Lines(string(source)) {
+		if isFence, delim := IsCodeFence(line); isFence {
+			switch fence {
+			case 0:
+				fence = delim
+			case delim:
+				fence = 0
+			}
+		}
+		if fence != 0 {
+			out.WriteString(line)
+
+			continue
+		}
+
+		m := stepBulletPattern.FindStringSubmatchIndex(line)
+		if m == nil {
+			out.WriteString(line)
+
+			continue
+		}
+		canonical, ok := AliasedKeyword(line[m[4]:m[5]])
+		if !ok {
+			out.WriteString(line)
+
+			continue
+		}
+		out.WriteString(line[:m[4]])
+		out.WriteString(canonical)
+		out.WriteString(line[m[5]:])
+	}
+
+	return []byte(out.String())
+}
@@ -0,0 +1,141 @@
+package markdown
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+// setTestAliases sets French and Chinese keyword aliases for the test.
+func setTestAliases(t *testing.T) {
+	t.Helper()
+	err := SetKeywordAliases(map[string][]string{
+		"GIVEN": {"ÉTANT DONNÉ", "假如"},
+		"WHEN":  {"QUAND", "当"},
+		"THEN":  {"ALORS", "那么"},
+		"AND":   {"ET", "并且"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = SetKeywordAliases(nil) })
+}
+
+func TestSetKeywordAliases_Errors(t *testing.T) {
+	t.Cleanup(func() { _ = SetKeywordAliases(nil) })
+
+	var unknown *specterrs.UnknownScenarioKeywordError
+	err := SetKeywordAliases(map[string][]string{"WHILE": {"PENDANT"}})
+	if !errors.As(err, &unknown) || unknown.Keyword != "WHILE" {
+		t.Errorf("unknown keyword: got %v", err)
+	}
+
+	var conflict *specterrs.ConflictingKeywordAliasError
+	err = SetKeywordAliases(map[string][]string{"WHEN": {"SI"}, "THEN": {"si"}})
+	if !errors.As(err, &conflict) || conflict.First != "THEN" || conflict.Second != "WHEN" {
+		t.Errorf("duplicate alias: got %v", err)
+	}
+
+	err = SetKeywordAliases(map[string][]string{"WHEN": {"THEN"}})
+	if !errors.As(err, &conflict) {
+		t.Errorf("keyword as alias: got %v", err)
+	}
+}
+
+func TestCanonicalKeyword(t *testing.T) {
+	setTestAliases(t)
+
+	tests := []struct {
+		word string
+		want string
+		ok   bool
+	}{
+		{"WHEN", "WHEN", true},
+		{"when", "WHEN", true},
+		{"Quand", "WHEN", true},
+		{"当", "WHEN", true},
+		{"那么", "THEN", true},
+		{"étant donné", "GIVEN", true},
+		{"PENDANT", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := CanonicalKeyword(tt.word)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("CanonicalKeyword(%q) = %q, %v; want %q, %v", tt.word, got, ok, tt.want, tt.ok)
+		}
+	}
+
+	if _, ok := AliasedKeyword("WHEN"); ok {
+		t.Error("AliasedKeyword(WHEN) should not be an alias")
+	}
+
+	_ = SetKeywordAliases(nil)
+	if _, ok := CanonicalKeyword("QUAND"); ok {
+		t.Error("aliases should be cleared")
+	}
+}
+
+func TestParse_Spectr_Keyword_Alias(t *testing.T) {
+	setTestAliases(t)
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"- **QUAND** l'utilisateur se connecte", "WHEN"},
+		{"- **当** 用户登录", "WHEN"},
+		{"- **alors** la session commence", "THEN"},
+		{"- **ET** un courriel est envoyé", "AND"},
+		{"- **PENDANT** rien", ""},
+		{"- **ÉTANT DONNÉ** un compte", ""}, // GIVEN is not a list item keyword
+	}
+	for _, tt := range tests {
+		doc, _ := Parse([]byte(tt.input))
+		list, ok := doc.Children()[0].(*NodeList)
+		if !ok {
+			t.Fatalf("%q: expected *NodeList, got %T", tt.input, doc.Children()[0])
+		}
+		item, ok := list.Children()[0].(*NodeListItem)
+		if !ok {
+			t.Fatalf("%q: expected *NodeListItem", tt.input)
+		}
+		if item.Keyword() != tt.want {
+			t.Errorf("%q: keyword = %q, want %q", tt.input, item.Keyword(), tt.want)
+		}
+	}
+}
+
+func TestContainsKeyword_Alias(t *testing.T) {
+	setTestAliases(t)
+
+	if kw, ok := ContainsKeyword("- **ALORS** ok"); !ok || kw != "THEN" {
+		t.Errorf("ContainsKeyword(ALORS) = %q, %v", kw, ok)
+	}
+	if _, ok := ContainsKeyword("- **important** note"); ok {
+		t.Error("plain bold text should not be a keyword")
+	}
+}
+
+func TestCanonicalizeKeywords(t *testing.T) {
+	setTestAliases(t)
+
+	input := "#### Scenario: Connexion\n" +
+		"- **ÉTANT DONNÉ** un compte\n" +
+		"- **Quand** l'utilisateur se connecte\n" +
+		"  * [ ] **那么** 会话开始\n" +
+		"- **WHEN** unchanged\n" +
+		"Un **QUAND** au milieu reste.\n" +
+		"```\n- **QUAND** code\n```\n"
+	want := "#### Scenario: Connexion\n" +
+		"- **GIVEN** un compte\n" +
+		"- **WHEN** l'utilisateur se connecte\n" +
+		"  * [ ] **THEN** 会话开始\n" +
+		"- **WHEN** unchanged\n" +
+		"Un **QUAND** au milieu reste.\n" +
+		"```\n- **QUAND** code\n```\n"
+
+	if got := string(CanonicalizeKeywords([]byte(input))); got != want {
+		t.Errorf("CanonicalizeKeywords() =\n%s\nwant:\n%s", got, want)
+	}
+}
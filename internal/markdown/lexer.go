@@ -53,6 +53,10 @@ type lexer struct {
 	fenceLen      int  // Length of opening fence (3+)
 	backtickCount int  // Number of backticks for inline code matching
 
+	// escapedPipes keeps \| in text tokens, so it never splits a table
+	// cell; set when the parser emits tables
+	escapedPipes bool
+
 	// Peek caching to avoid re-lexing
 	peeked  Token
 	hasPeek bool
@@ -474,6 +478,15 @@ func (l *lexer) lexText() Token {
 	for l.pos < len(l.source) {
 		b := l.source[l.pos]
 
+		// An escaped pipe (\|) stays in the text, so it never splits a
+		// table cell
+		if l.escapedPipes && b == '\\' && l.pos+1 < len(l.source) &&
+			l.source[l.pos+1] == '|' {
+			l.pos += 2
+
+			continue
+		}
+
 		// Stop at delimiters, whitespace, and newlines
 		if isDelimiterByte(b) || b == ' ' ||
 			b == '\t' ||
@@ -219,6 +219,36 @@ func TestLexer_Text(t *testing.T) {
 	}
 }
 
+// TestLexer_EscapedPipe verifies that \| stays in text only when the lexer
+// keeps escaped pipes for tables.
+func TestLexer_EscapedPipe(t *testing.T) {
+	tests := []struct {
+		name         string
+		escapedPipes bool
+		expected     string
+	}{
+		{"kept for tables", true, "a\\|b"},
+		{"split without tables", false, "a\\"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := newLexer([]byte("a\\|b"))
+			l.escapedPipes = tt.escapedPipes
+			tok := l.Next()
+
+			if tok.Type != TokenText || string(tok.Source) != tt.expected {
+				t.Errorf(
+					"got %v %q, want TokenText %q",
+					tok.Type,
+					tok.Source,
+					tt.expected,
+				)
+			}
+		})
+	}
+}
+
 // TestLexer_Number verifies digit sequence tokenization.
 func TestLexer_Number(t *testing.T) {
 	tests := []struct {
@@ -50,6 +50,19 @@ const (
 	NodeTypeLinkDef
 	// NodeTypeWikilink represents a wikilink [[target|display#anchor]].
 	NodeTypeWikilink
+
+	// NodeTypeHTMLComment represents a block-level HTML comment
+	// (<!-- ... -->). Only produced when parsing WithHTMLComments.
+	NodeTypeHTMLComment
+
+	// Table node types, only produced when parsing WithTables
+
+	// NodeTypeTable represents a GFM pipe table.
+	NodeTypeTable
+	// NodeTypeTableRow represents a header or body row of a table.
+	NodeTypeTableRow
+	// NodeTypeTableCell represents a single cell of a table row.
+	NodeTypeTableCell
 )
 
 // String returns a human-readable name for the node type.
@@ -89,6 +102,14 @@ func (t NodeType) String() string {
 		return "LinkDef"
 	case NodeTypeWikilink:
 		return "Wikilink"
+	case NodeTypeHTMLComment:
+		return "HTMLComment"
+	case NodeTypeTable:
+		return "Table"
+	case NodeTypeTableRow:
+		return "TableRow"
+	case NodeTypeTableCell:
+		return "TableCell"
 	default:
 		return unknownTokenType
 	}
@@ -130,6 +151,7 @@ type baseNode struct {
 	end      int
 	source   []byte
 	children []Node
+	verbatim bool // Source is the node's markdown as written, see Render
 }
 
 // NodeType returns the type classification of this node.
@@ -164,6 +186,19 @@ func (n *baseNode) Children() []Node {
 	return result
 }
 
+// base returns the embedded baseNode, for setting builder-managed
+// fields after construction.
+func (n *baseNode) base() *baseNode {
+	return n
+}
+
+// isVerbatim reports whether Source is the node's markdown as written.
+func isVerbatim(n Node) bool {
+	b, ok := n.(interface{ base() *baseNode })
+
+	return ok && b.base().verbatim
+}
+
 // computeHash computes a content hash for a node using FNV-1a.
 // The hash includes: NodeType, children hashes, and source content.
 func computeHash(
@@ -298,8 +333,9 @@ type NodeBuilder struct {
 	title     []byte // for Section
 	deltaType string // for Section
 	name      string // for Requirement, Scenario
+	when      string // for Requirement, Scenario
 	language  []byte // for CodeBlock
-	content   []byte // for CodeBlock
+	content   []byte // for CodeBlock, HTMLComment
 	ordered   bool   // for List
 	checked   *bool  // for ListItem
 	keyword   string // for ListItem
@@ -308,6 +344,12 @@ type NodeBuilder struct {
 	target    []byte // for Wikilink
 	display   []byte // for Wikilink
 	anchor    []byte // for Wikilink
+
+	alignments []TableAlign // for Table
+	header     bool         // for TableRow
+	align      TableAlign   // for TableCell
+
+	orig Node // Node the builder was created from by ToBuilder, if any
 }
 
 // NewNodeBuilder creates a new builder for the specified node type.
@@ -397,6 +439,16 @@ func (b *NodeBuilder) WithName(
 	return b
 }
 
+// WithWhen sets the feature-flag condition (for Requirement and Scenario
+// nodes).
+func (b *NodeBuilder) WithWhen(
+	when string,
+) *NodeBuilder {
+	b.when = when
+
+	return b
+}
+
 // WithLanguage sets the language (for CodeBlock nodes).
 func (b *NodeBuilder) WithLanguage(
 	language []byte,
@@ -406,7 +458,7 @@ func (b *NodeBuilder) WithLanguage(
 	return b
 }
 
-// WithContent sets the code content (for CodeBlock nodes).
+// WithContent sets the content (for CodeBlock and HTMLComment nodes).
 func (b *NodeBuilder) WithContent(
 	content []byte,
 ) *NodeBuilder {
@@ -488,6 +540,33 @@ func (b *NodeBuilder) WithAnchor(
 	return b
 }
 
+// WithAlignments sets the column alignments (for Table nodes).
+func (b *NodeBuilder) WithAlignments(
+	alignments []TableAlign,
+) *NodeBuilder {
+	b.alignments = alignments
+
+	return b
+}
+
+// WithHeader sets whether the row is the header row (for TableRow nodes).
+func (b *NodeBuilder) WithHeader(
+	header bool,
+) *NodeBuilder {
+	b.header = header
+
+	return b
+}
+
+// WithAlign sets the column alignment (for TableCell nodes).
+func (b *NodeBuilder) WithAlign(
+	align TableAlign,
+) *NodeBuilder {
+	b.align = align
+
+	return b
+}
+
 // Validate checks that the builder state is valid.
 // Returns an error if validation fails.
 func (b *NodeBuilder) Validate() error {
@@ -535,8 +614,27 @@ func (b *NodeBuilder) Validate() error {
 // It validates the builder and computes the content hash.
 // Returns nil if validation fails (caller should check Validate() first for error details).
 //
-//nolint:revive // function-length: builder pattern requires type dispatch
+// A node built with source keeps that source as its markdown for Render,
+// unless it was created by ToBuilder and its content changed.
 func (b *NodeBuilder) Build() Node {
+	node := b.build()
+	if node == nil {
+		return nil
+	}
+
+	built, ok := node.(interface{ base() *baseNode })
+	if ok && len(b.source) > 0 {
+		built.base().verbatim = b.orig == nil ||
+			(isVerbatim(b.orig) && b.orig.Hash() == node.Hash())
+	}
+
+	return node
+}
+
+// build creates the node for Build.
+//
+//nolint:revive // function-length: builder pattern requires type dispatch
+func (b *NodeBuilder) build() Node {
 	if err := b.Validate(); err != nil {
 		return nil
 	}
@@ -596,12 +694,13 @@ func (b *NodeBuilder) Build() Node {
 			b.nodeType,
 			children,
 			b.source,
-			[]byte(b.name),
+			[]byte(b.name+"\x00"+b.when),
 		)
 
 		return &NodeRequirement{
 			baseNode: base,
 			name:     b.name,
+			when:     b.when,
 		}
 
 	case NodeTypeScenario:
@@ -609,12 +708,13 @@ func (b *NodeBuilder) Build() Node {
 			b.nodeType,
 			children,
 			b.source,
-			[]byte(b.name),
+			[]byte(b.name+"\x00"+b.when),
 		)
 
 		return &NodeScenario{
 			baseNode: base,
 			name:     b.name,
+			when:     b.when,
 		}
 
 	case NodeTypeParagraph:
@@ -821,6 +921,66 @@ func (b *NodeBuilder) Build() Node {
 			anchor:   b.anchor,
 		}
 
+	case NodeTypeHTMLComment:
+		base.hash = computeHashWithExtra(
+			b.nodeType,
+			children,
+			b.source,
+			b.content,
+		)
+
+		return &NodeHTMLComment{
+			baseNode: base,
+			content:  b.content,
+		}
+
+	case NodeTypeTable:
+		extra := make([]byte, len(b.alignments))
+		for i, align := range b.alignments {
+			extra[i] = byte(align)
+		}
+		base.hash = computeHashWithExtra(
+			b.nodeType,
+			children,
+			b.source,
+			extra,
+		)
+
+		return &NodeTable{
+			baseNode:   base,
+			alignments: append([]TableAlign(nil), b.alignments...),
+		}
+
+	case NodeTypeTableRow:
+		var extra byte
+		if b.header {
+			extra = 1
+		}
+		base.hash = computeHashWithExtra(
+			b.nodeType,
+			children,
+			b.source,
+			[]byte{extra},
+		)
+
+		return &NodeTableRow{
+			baseNode: base,
+			header:   b.header,
+		}
+
+	case NodeTypeTableCell:
+		base.hash = computeHashWithExtra(
+			b.nodeType,
+			children,
+			b.source,
+			[]byte{byte(b.align)},
+		)
+
+		return &NodeTableCell{
+			baseNode: base,
+			align:    b.align,
+		}
+
 	default:
 		return nil
 	}
@@ -857,6 +1017,7 @@ func nodeToBuilder(n Node) *NodeBuilder {
 		end:      end,
 		source:   n.Source(),
 		children: n.Children(),
+		orig:     n,
 	}
 
 	// Copy type-specific fields
@@ -867,8 +1028,10 @@ func nodeToBuilder(n Node) *NodeBuilder {
 		b.deltaType = node.deltaType
 	case *NodeRequirement:
 		b.name = node.name
+		b.when = node.when
 	case *NodeScenario:
 		b.name = node.name
+		b.when = node.when
 	case *NodeList:
 		b.ordered = node.ordered
 	case *NodeListItem:
@@ -887,6 +1050,14 @@ func nodeToBuilder(n Node) *NodeBuilder {
 		b.target = node.target
 		b.display = node.display
 		b.anchor = node.anchor
+	case *NodeHTMLComment:
+		b.content = node.content
+	case *NodeTable:
+		b.alignments = node.Alignments()
+	case *NodeTableRow:
+		b.header = node.header
+	case *NodeTableCell:
+		b.align = node.align
 	}
 
 	return b
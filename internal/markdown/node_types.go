@@ -1,6 +1,8 @@
 //nolint:revive // max-public-structs - node types intentionally public for AST API
 package markdown
 
+import "strings"
+
 // NodeDocument is the root node of an AST.
 // It contains all top-level block nodes from the parsed document.
 type NodeDocument struct {
@@ -73,6 +75,7 @@ func (n *NodeSection) ToBuilder() *NodeBuilder {
 type NodeRequirement struct {
 	baseNode
 	name string
+	when string
 }
 
 // Name returns the requirement name extracted from the header.
@@ -80,6 +83,12 @@ func (n *NodeRequirement) Name() string {
 	return n.name
 }
 
+// When returns the feature-flag condition declared under the header
+// ("when: flag" or "when: !flag"), or "" if the requirement is not gated.
+func (n *NodeRequirement) When() string {
+	return n.when
+}
+
 // Equal performs deep structural comparison with another node.
 func (n *NodeRequirement) Equal(other Node) bool {
 	if other == nil {
@@ -89,7 +98,7 @@ func (n *NodeRequirement) Equal(other Node) bool {
 	if !ok {
 		return false
 	}
-	if n.name != otherReq.name {
+	if n.name != otherReq.name || n.when != otherReq.when {
 		return false
 	}
 
@@ -105,6 +114,7 @@ func (n *NodeRequirement) ToBuilder() *NodeBuilder {
 type NodeScenario struct {
 	baseNode
 	name string
+	when string
 }
 
 // Name returns the scenario name extracted from the header.
@@ -112,6 +122,12 @@ func (n *NodeScenario) Name() string {
 	return n.name
 }
 
+// When returns the feature-flag condition declared under the header, or
+// "" if the scenario is not gated.
+func (n *NodeScenario) When() string {
+	return n.when
+}
+
 // Equal performs deep structural comparison with another node.
 func (n *NodeScenario) Equal(other Node) bool {
 	if other == nil {
@@ -121,7 +137,7 @@ func (n *NodeScenario) Equal(other Node) bool {
 	if !ok {
 		return false
 	}
-	if n.name != otherScenario.name {
+	if n.name != otherScenario.name || n.when != otherScenario.when {
 		return false
 	}
 
@@ -534,6 +550,235 @@ func (n *NodeWikilink) ToBuilder() *NodeBuilder {
 	return nodeToBuilder(n)
 }
 
+// NodeHTMLComment represents an HTML comment (<!-- ... -->) that stands on
+// its own lines. The parser only produces it when WithHTMLComments is set;
+// otherwise comments are parsed as paragraph text.
+type NodeHTMLComment struct {
+	baseNode
+	content []byte // Text between <!-- and -->
+}
+
+// Content returns the comment text without the <!-- and --> delimiters.
+func (n *NodeHTMLComment) Content() []byte {
+	return n.content
+}
+
+// Equal performs deep structural comparison with another node.
+func (n *NodeHTMLComment) Equal(other Node) bool {
+	if other == nil {
+		return false
+	}
+	otherComment, ok := other.(*NodeHTMLComment)
+	if !ok {
+		return false
+	}
+	if !bytesEqual(n.content, otherComment.content) {
+		return false
+	}
+
+	return equalNodes(n, other)
+}
+
+// ToBuilder creates a builder pre-populated with this node's data.
+func (n *NodeHTMLComment) ToBuilder() *NodeBuilder {
+	return nodeToBuilder(n)
+}
+
+// TableAlign is the alignment of a table column, set by colons in the
+// delimiter row under the table header.
+type TableAlign uint8
+
+const (
+	// TableAlignNone is a column without colons ("---").
+	TableAlignNone TableAlign = iota
+	// TableAlignLeft is a column with a leading colon (":---").
+	TableAlignLeft
+	// TableAlignCenter is a column with colons on both sides (":---:").
+	TableAlignCenter
+	// TableAlignRight is a column with a trailing colon ("---:").
+	TableAlignRight
+)
+
+// String returns the alignment name.
+func (a TableAlign) String() string {
+	switch a {
+	case TableAlignNone:
+		return "none"
+	case TableAlignLeft:
+		return "left"
+	case TableAlignCenter:
+		return "center"
+	case TableAlignRight:
+		return "right"
+	default:
+		return unknownTokenType
+	}
+}
+
+// NodeTable represents a GFM pipe table. Its children are NodeTableRow
+// nodes, the header row first. The parser only produces it when WithTables
+// is set; otherwise tables are parsed as paragraph text.
+type NodeTable struct {
+	baseNode
+	alignments []TableAlign // One per header cell
+}
+
+// Alignments returns the column alignments from the delimiter row.
+func (n *NodeTable) Alignments() []TableAlign {
+	return append([]TableAlign(nil), n.alignments...)
+}
+
+// Header returns the header row, or nil for a table built without rows.
+func (n *NodeTable) Header() *NodeTableRow {
+	for _, child := range n.children {
+		if row, ok := child.(*NodeTableRow); ok && row.header {
+			return row
+		}
+	}
+
+	return nil
+}
+
+// Rows returns the body rows, without the header row.
+func (n *NodeTable) Rows() []*NodeTableRow {
+	var rows []*NodeTableRow
+	for _, child := range n.children {
+		if row, ok := child.(*NodeTableRow); ok && !row.header {
+			rows = append(rows, row)
+		}
+	}
+
+	return rows
+}
+
+// ColumnIndex returns the index of the column whose header cell text is
+// name, or -1 if the table has no such column.
+func (n *NodeTable) ColumnIndex(name string) int {
+	header := n.Header()
+	if header == nil {
+		return -1
+	}
+	for i, cell := range header.Cells() {
+		if cell.Text() == name {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// Equal performs deep structural comparison with another node.
+func (n *NodeTable) Equal(other Node) bool {
+	if other == nil {
+		return false
+	}
+	otherTable, ok := other.(*NodeTable)
+	if !ok {
+		return false
+	}
+	if len(n.alignments) != len(otherTable.alignments) {
+		return false
+	}
+	for i := range n.alignments {
+		if n.alignments[i] != otherTable.alignments[i] {
+			return false
+		}
+	}
+
+	return equalNodes(n, other)
+}
+
+// ToBuilder creates a builder pre-populated with this node's data.
+func (n *NodeTable) ToBuilder() *NodeBuilder {
+	return nodeToBuilder(n)
+}
+
+// NodeTableRow represents a row of a table. Its children are NodeTableCell
+// nodes, as many as the row has; body rows may have more or fewer cells
+// than the header.
+type NodeTableRow struct {
+	baseNode
+	header bool
+}
+
+// IsHeader returns true for the header row.
+func (n *NodeTableRow) IsHeader() bool {
+	return n.header
+}
+
+// Cells returns the cells of the row.
+func (n *NodeTableRow) Cells() []*NodeTableCell {
+	cells := make([]*NodeTableCell, 0, len(n.children))
+	for _, child := range n.children {
+		if cell, ok := child.(*NodeTableCell); ok {
+			cells = append(cells, cell)
+		}
+	}
+
+	return cells
+}
+
+// Equal performs deep structural comparison with another node.
+func (n *NodeTableRow) Equal(other Node) bool {
+	if other == nil {
+		return false
+	}
+	otherRow, ok := other.(*NodeTableRow)
+	if !ok {
+		return false
+	}
+	if n.header != otherRow.header {
+		return false
+	}
+
+	return equalNodes(n, other)
+}
+
+// ToBuilder creates a builder pre-populated with this node's data.
+func (n *NodeTableRow) ToBuilder() *NodeBuilder {
+	return nodeToBuilder(n)
+}
+
+// NodeTableCell represents a table cell. Its children are inline nodes;
+// its source is the cell content without the surrounding pipes and
+// whitespace.
+type NodeTableCell struct {
+	baseNode
+	align TableAlign
+}
+
+// Align returns the alignment of the cell's column.
+func (n *NodeTableCell) Align() TableAlign {
+	return n.align
+}
+
+// Text returns the cell content as written, with escaped pipes (\|)
+// unescaped.
+func (n *NodeTableCell) Text() string {
+	return strings.ReplaceAll(string(n.source), `\|`, "|")
+}
+
+// Equal performs deep structural comparison with another node.
+func (n *NodeTableCell) Equal(other Node) bool {
+	if other == nil {
+		return false
+	}
+	otherCell, ok := other.(*NodeTableCell)
+	if !ok {
+		return false
+	}
+	if n.align != otherCell.align {
+		return false
+	}
+
+	return equalNodes(n, other)
+}
+
+// ToBuilder creates a builder pre-populated with this node's data.
+func (n *NodeTableCell) ToBuilder() *NodeBuilder {
+	return nodeToBuilder(n)
+}
+
 // bytesEqual compares two byte slices for equality.
 // Handles nil slices correctly.
 func bytesEqual(a, b []byte) bool {
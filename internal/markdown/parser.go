@@ -3,6 +3,7 @@ package markdown
 
 import (
 	"bytes"
+	"slices"
 	"strings"
 	"sync"
 	"unicode"
@@ -13,11 +14,13 @@ const DefaultMaxErrors = 100
 
 // ParseError represents an error encountered during parsing.
 // It contains the byte offset where the error occurred, a human-readable message,
-// and optionally a list of expected token types.
+// and optionally a list of expected token types, a suggestion and a quick fix.
 type ParseError struct {
-	Offset   int         // Byte offset where error occurred
-	Message  string      // Human-readable error description
-	Expected []TokenType // What tokens would have been valid (may be nil)
+	Offset     int         // Byte offset where error occurred
+	Message    string      // Human-readable error description
+	Expected   []TokenType // What tokens would have been valid (may be nil)
+	Suggestion string      // How to correct the error, e.g. "did you mean ...?" (may be empty)
+	Fix        *Fix        // Edit that corrects the error (may be nil)
 }
 
 // Error implements the error interface.
@@ -79,6 +82,40 @@ type parser struct {
 	linkDefs    map[string]linkDefinition // Case-insensitive label -> definition
 	lineIndex   *LineIndex
 	inlineState *inlineParser
+
+	htmlComments bool // Emit NodeHTMLComment for block-level comments
+	tables       bool // Emit NodeTable for GFM pipe tables
+}
+
+// ParseOption configures optional parser behavior.
+type ParseOption func(*parser)
+
+// WithHTMLComments makes the parser emit NodeHTMLComment nodes for HTML
+// comments that stand on their own lines, so they survive a Parse/Print
+// round-trip. Without it, comments are parsed as paragraph text.
+func WithHTMLComments() ParseOption {
+	return func(p *parser) {
+		p.htmlComments = true
+	}
+}
+
+// WithTables makes the parser emit NodeTable nodes for GFM pipe tables: a
+// header row, a delimiter row such as "| --- | :---: |" with as many cells,
+// and the body rows up to the first line without a pipe. Without it,
+// tables are parsed as paragraph text.
+func WithTables() ParseOption {
+	return func(p *parser) {
+		p.tables = true
+	}
+}
+
+// WithMaxErrors sets how many parse errors the parser, or Diagnose,
+// collects before it stops, in place of DefaultMaxErrors. Zero or less
+// never stops early.
+func WithMaxErrors(n int) ParseOption {
+	return func(p *parser) {
+		p.maxErrors = n
+	}
 }
 
 // delimiter represents an emphasis delimiter on the stack.
@@ -143,7 +180,10 @@ var (
 // This function is stateless and safe for concurrent calls.
 //
 //nolint:revive // function-length: parse entry point requires setup/teardown
-func Parse(source []byte) (Node, []ParseError) {
+func Parse(
+	source []byte,
+	opts ...ParseOption,
+) (Node, []ParseError) {
 	// Get parser from pool
 	p, ok := parserPool.Get().(*parser)
 	if !ok {
@@ -164,6 +204,8 @@ func Parse(source []byte) (Node, []ParseError) {
 		}
 		p.lineIndex = nil
 		p.inlineState = nil
+		p.htmlComments = false
+		p.tables = false
 		parserPool.Put(p)
 	}()
 
@@ -171,9 +213,13 @@ func Parse(source []byte) (Node, []ParseError) {
 	p.source = source
 	p.maxErrors = DefaultMaxErrors
 	p.lineIndex = NewLineIndex(source)
+	for _, opt := range opts {
+		opt(p)
+	}
 
 	// Tokenize
 	lex := newLexer(source)
+	lex.escapedPipes = p.tables
 	tokensPtr, ok := tokenSlicePool.Get().(*[]Token)
 	if !ok {
 		slice := make([]Token, 0, 256)
@@ -224,7 +270,7 @@ func (p *parser) addError(
 		Expected: expected,
 	})
 
-	return len(p.errors) < p.maxErrors
+	return p.maxErrors <= 0 || len(p.errors) < p.maxErrors
 }
 
 // current returns the current token without advancing.
@@ -498,7 +544,7 @@ func (p *parser) parseDocument() Node {
 		}
 
 		// Check for too many errors
-		if len(p.errors) >= p.maxErrors {
+		if p.maxErrors > 0 && len(p.errors) >= p.maxErrors {
 			break
 		}
 	}
@@ -518,7 +564,8 @@ func (p *parser) parseDocument() Node {
 }
 
 // parseBlock parses a single block-level element.
-// Block detection order: code fence, header, blockquote, list item, paragraph
+// Block detection order: HTML comment (when enabled), code fence, header,
+// blockquote, table (when enabled), list item, paragraph
 func (p *parser) parseBlock() Node {
 	p.skipWhitespace()
 
@@ -527,6 +574,12 @@ func (p *parser) parseBlock() Node {
 		return nil
 	}
 
+	if p.htmlComments {
+		if node := p.tryParseHTMLComment(); node != nil {
+			return node
+		}
+	}
+
 	// Check for code fence (3+ backticks or tildes at line start)
 	if tok.Type == TokenBacktick ||
 		tok.Type == TokenTilde {
@@ -547,6 +600,12 @@ func (p *parser) parseBlock() Node {
 		return p.parseBlockquote()
 	}
 
+	if p.tables {
+		if node := p.tryParseTable(); node != nil {
+			return node
+		}
+	}
+
 	// Check for list item
 	if tok.Type == TokenDash ||
 		tok.Type == TokenPlus ||
@@ -670,6 +729,45 @@ func (p *parser) tryParseCodeFence() Node {
 		Build()
 }
 
+// tryParseHTMLComment attempts to parse an HTML comment that starts at the
+// current token and is followed only by whitespace on its closing line.
+// Returns nil if there is no such comment, leaving the position unchanged.
+func (p *parser) tryParseHTMLComment() Node {
+	startOffset := p.current().Start
+	rest := p.source[startOffset:]
+	if !bytes.HasPrefix(rest, []byte("<!--")) {
+		return nil
+	}
+
+	closeIdx := bytes.Index(rest[4:], []byte("-->"))
+	if closeIdx < 0 {
+		return nil
+	}
+	contentEnd := startOffset + 4 + closeIdx
+	endOffset := contentEnd + 3
+
+	// Comments followed by other text on the same line stay inline
+	lineEnd := bytes.IndexByte(p.source[endOffset:], '\n')
+	if lineEnd < 0 {
+		lineEnd = len(p.source) - endOffset
+	}
+	if len(bytes.TrimSpace(p.source[endOffset:endOffset+lineEnd])) > 0 {
+		return nil
+	}
+
+	for p.current().Type != TokenEOF && p.current().Start < endOffset {
+		p.advance()
+	}
+	p.skipToNextLine()
+
+	return NewNodeBuilder(NodeTypeHTMLComment).
+		WithStart(startOffset).
+		WithEnd(endOffset).
+		WithSource(p.source[startOffset:endOffset]).
+		WithContent(p.source[startOffset+4 : contentEnd]).
+		Build()
+}
+
 // collectLineContent collects all tokens on the current line as content.
 func (p *parser) collectLineContent() []byte {
 	var parts [][]byte
@@ -754,6 +852,7 @@ func (p *parser) parseHeader() Node {
 			WithEnd(endOffset).
 			WithSource(p.source[startOffset:endOffset]).
 			WithName(name).
+			WithWhen(whenAfter(p.source, endOffset)).
 			Build()
 	}
 
@@ -772,6 +871,7 @@ func (p *parser) parseHeader() Node {
 			WithEnd(endOffset).
 			WithSource(p.source[startOffset:endOffset]).
 			WithName(name).
+			WithWhen(whenAfter(p.source, endOffset)).
 			Build()
 	}
 
@@ -805,26 +905,13 @@ func (p *parser) parseHeader() Node {
 		Build()
 }
 
-// detectDeltaType checks if the header text indicates a delta section.
+// detectDeltaType checks if the header text names a delta section, as
+// matched by CanonicalDeltaSection.
 // Returns "ADDED", "MODIFIED", "REMOVED", "RENAMED", or empty string.
 func detectDeltaType(title string) string {
-	title = strings.TrimSpace(title)
-	upper := strings.ToUpper(title)
+	deltaType, _ := CanonicalDeltaSection(title)
 
-	if strings.HasPrefix(upper, "ADDED") {
-		return "ADDED"
-	}
-	if strings.HasPrefix(upper, "MODIFIED") {
-		return "MODIFIED"
-	}
-	if strings.HasPrefix(upper, "REMOVED") {
-		return "REMOVED"
-	}
-	if strings.HasPrefix(upper, "RENAMED") {
-		return "RENAMED"
-	}
-
-	return ""
+	return string(deltaType)
 }
 
 // parseBlockquote parses a blockquote (lines starting with >).
@@ -1083,7 +1170,12 @@ func (p *parser) parseListItem(
 		Build()
 }
 
-// detectKeyword checks if the list item content starts with **WHEN**, **THEN**, or **AND**.
+// listItemKeywords are the keywords detectKeyword records on list items.
+var listItemKeywords = []string{"WHEN", "THEN", "AND"}
+
+// detectKeyword checks if the list item content starts with **WHEN**,
+// **THEN**, or **AND**, or an alias of one set by SetKeywordAliases.
+// Returns the canonical keyword.
 func (p *parser) detectKeyword(
 	start, end int,
 ) string {
@@ -1091,47 +1183,40 @@ func (p *parser) detectKeyword(
 		return ""
 	}
 
-	// Look for **WHEN**, **THEN**, or **AND** pattern
+	// Look for **KEYWORD** pattern
 	pos := start
-	for pos < end && pos < len(p.tokens) {
-		tok := p.tokens[pos]
-		if tok.Type == TokenWhitespace {
-			pos++
-
-			continue
-		}
+	for pos < end && pos < len(p.tokens) &&
+		p.tokens[pos].Type == TokenWhitespace {
+		pos++
+	}
+	if pos+2 >= end || !p.isDoubleAsterisk(pos) {
+		return ""
+	}
 
-		// Check for ** opening
-		if tok.Type == TokenAsterisk {
-			if pos+1 < end &&
-				p.tokens[pos+1].Type == TokenAsterisk {
-				// Found **, now check for keyword
-				if pos+2 < end &&
-					p.tokens[pos+2].Type == TokenText {
-					text := strings.ToUpper(
-						string(
-							p.tokens[pos+2].Source,
-						),
-					)
-					if text == "WHEN" ||
-						text == "THEN" ||
-						text == "AND" {
-						// Check for closing **
-						if pos+3 < end &&
-							p.tokens[pos+3].Type == TokenAsterisk &&
-							pos+4 < end &&
-							p.tokens[pos+4].Type == TokenAsterisk {
-							return text
-						}
-					}
-				}
-			}
-		}
+	// The keyword runs to the closing **; aliases may be several words
+	closing := pos + 2
+	for closing+1 < end && !p.isDoubleAsterisk(closing) {
+		closing++
+	}
+	if closing+1 >= end || closing == pos+2 {
+		return ""
+	}
 
-		break
+	word := p.source[p.tokens[pos+2].Start:p.tokens[closing].Start]
+	keyword, ok := CanonicalKeyword(string(word))
+	if !ok || !slices.Contains(listItemKeywords, keyword) {
+		return ""
 	}
 
-	return ""
+	return keyword
+}
+
+// isDoubleAsterisk reports whether the tokens at pos and pos+1 are both
+// asterisks.
+func (p *parser) isDoubleAsterisk(pos int) bool {
+	return pos+1 < len(p.tokens) &&
+		p.tokens[pos].Type == TokenAsterisk &&
+		p.tokens[pos+1].Type == TokenAsterisk
 }
 
 // parseParagraph parses a paragraph (consecutive non-blank lines).
@@ -1169,6 +1254,15 @@ func (p *parser) parseParagraph() Node {
 				// Could be list marker
 				break
 			}
+			if p.htmlComments && bytes.HasPrefix(
+				p.source[nextTok.Start:],
+				[]byte("<!--"),
+			) {
+				break
+			}
+			if p.tables && p.startsTable(p.pos) {
+				break
+			}
 			if nextTok.Type == TokenNumber {
 				next := p.peek(1)
 				if next.Type == TokenDot {
@@ -2111,3 +2111,54 @@ func TestParse_EdgeCase_WindowsLineEndings(
 		)
 	}
 }
+
+func TestParse_HTMLComments(t *testing.T) {
+	input := "# Spec\n\n<!-- reviewer notes -->\n\nText <!-- inline --> here.\n<!--\nmulti\nline\n-->\n\n## Requirements\n"
+
+	t.Run("disabled by default", func(t *testing.T) {
+		doc, _ := Parse([]byte(input))
+		for _, child := range doc.Children() {
+			if child.NodeType() == NodeTypeHTMLComment {
+				t.Fatal("unexpected HTMLComment node without option")
+			}
+		}
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		doc, _ := Parse([]byte(input), WithHTMLComments())
+
+		var comments []string
+		for _, child := range doc.Children() {
+			if comment, ok := child.(*NodeHTMLComment); ok {
+				comments = append(comments, string(comment.Content()))
+			}
+		}
+
+		want := []string{" reviewer notes ", "\nmulti\nline\n"}
+		if strings.Join(comments, "|") != strings.Join(want, "|") {
+			t.Errorf("comments = %q, want %q", comments, want)
+		}
+	})
+}
+
+func TestPrint_HTMLCommentRoundTrip(t *testing.T) {
+	input := "<!-- keep me -->\n\nParagraph.\n\n<!--\nmulti\nline\n-->\n"
+
+	doc, _ := Parse([]byte(input), WithHTMLComments())
+	printed := string(Print(doc))
+	for _, comment := range []string{"<!-- keep me -->", "<!--\nmulti\nline\n-->"} {
+		if !strings.Contains(printed, comment) {
+			t.Errorf("Print() dropped %q:\n%s", comment, printed)
+		}
+	}
+
+	reparsed, _ := Parse([]byte(printed), WithHTMLComments())
+	if len(reparsed.Children()) != len(doc.Children()) {
+		t.Fatalf("reparse produced %d nodes, want %d", len(reparsed.Children()), len(doc.Children()))
+	}
+	for i, child := range doc.Children() {
+		if child.NodeType() != reparsed.Children()[i].NodeType() {
+			t.Errorf("node %d: %v != %v", i, reparsed.Children()[i].NodeType(), child.NodeType())
+		}
+	}
+}
@@ -310,6 +310,7 @@ func clearBaseNode(b *baseNode) {
 	b.end = 0
 	b.source = nil
 	b.children = nil
+	b.verbatim = false
 }
 
 // PutNode returns a node to the appropriate pool based on its type.
@@ -27,7 +27,15 @@ func PrintTo(w io.Writer, node Node) error {
 	if node == nil {
 		return nil
 	}
-	p := &printer{
+	p := newPrinter(w)
+	p.printNode(node, false)
+
+	return p.err
+}
+
+// newPrinter creates a printer writing to w.
+func newPrinter(w io.Writer) *printer {
+	return &printer{
 		w:         w,
 		indent:    0,
 		listDepth: 0,
@@ -37,9 +45,6 @@ func PrintTo(w io.Writer, node Node) error {
 			8, //nolint:revive // add-constant
 		), // Stack of ordered list counters
 	}
-	p.printNode(node, false)
-
-	return p.err
 }
 
 // printer maintains state during markdown rendering.
@@ -50,6 +55,10 @@ type printer struct {
 	ordered    []int // Stack of ordered list counters (1-based)
 	err        error // Accumulated error
 	needsBlank bool  // Whether next block needs preceding blank line
+
+	preserve    bool   // Write verbatim nodes as their source (Render)
+	pendingWhen string // Condition to write under the next requirement or scenario header
+	linePrefix  string // Written after the indent of continuation lines ("> " in blockquotes)
 }
 
 // write writes bytes to the output, tracking errors.
@@ -137,6 +146,10 @@ func (p *printer) printNode(
 		p.printLinkDef(n, isFirst)
 	case *NodeWikilink:
 		p.printWikilink(n)
+	case *NodeHTMLComment:
+		p.printHTMLComment(n, isFirst)
+	case *NodeTable:
+		p.printTable(n, isFirst)
 	default:
 		// For unknown node types, try to print children
 		children := node.Children()
@@ -149,12 +162,5 @@ func (p *printer) printNode(
 // printDocument prints the document root node.
 func (p *printer) printDocument(n *NodeDocument) {
 	p.needsBlank = false // Don't start with blank line
-	children := n.Children()
-	for i, child := range children {
-		p.printNode(child, i == 0)
-	}
-	// Ensure single trailing newline (if there's content)
-	if len(children) > 0 {
-		p.writeByte('\n')
-	}
+	p.printBlocks(n, n.Children())
 }
@@ -37,11 +37,9 @@ func (p *printer) printSection(
 	}
 	p.writeByte('\n')
 
-	// Print children (content under the header)
-	children := n.Children()
-	for i, child := range children {
-		p.printNode(child, i == 0)
-	}
+	// Print block children (content under the header); inline children
+	// repeat the title
+	p.printHeaderChildren(n)
 }
 
 // printRequirement prints a requirement header (### Requirement: Name).
@@ -58,12 +56,10 @@ func (p *printer) printRequirement(
 	p.writeString("### Requirement: ")
 	p.writeString(n.Name())
 	p.writeByte('\n')
+	p.printPendingWhen()
 
 	// Print children (scenarios, paragraphs, etc.)
-	children := n.Children()
-	for i, child := range children {
-		p.printNode(child, i == 0)
-	}
+	p.printHeaderChildren(n)
 }
 
 // printScenario prints a scenario header (#### Scenario: Name).
@@ -80,12 +76,38 @@ func (p *printer) printScenario(
 	p.writeString("#### Scenario: ")
 	p.writeString(n.Name())
 	p.writeByte('\n')
+	p.printPendingWhen()
 
 	// Print children
-	children := n.Children()
-	for i, child := range children {
-		p.printNode(child, i == 0)
+	p.printHeaderChildren(n)
+}
+
+// printPendingWhen writes the condition of the header just printed, unless
+// a paragraph after it already declares one.
+func (p *printer) printPendingWhen() {
+	if p.pendingWhen == "" {
+		return
+	}
+	p.writeString("when: ")
+	p.writeString(p.pendingWhen)
+	p.writeByte('\n')
+	p.pendingWhen = ""
+}
+
+// printHeaderChildren prints the block children of a header, separated
+// from the header line by a blank line.
+func (p *printer) printHeaderChildren(n Node) {
+	var blocks []Node
+	for _, child := range n.Children() {
+		if !isInlineNode(child) {
+			blocks = append(blocks, child)
+		}
+	}
+	if len(blocks) == 0 {
+		return
 	}
+	p.writeByte('\n')
+	p.printBlocks(n, blocks)
 }
 
 // printList prints an unordered or ordered list.
@@ -100,9 +122,12 @@ func (p *printer) printList(
 	}
 
 	p.listDepth++
+	// Push a counter for this list; 0 marks an unordered list, so its
+	// items are not numbered by an enclosing ordered list
 	if n.Ordered() {
-		// Push a new counter for this ordered list
 		p.ordered = append(p.ordered, 1)
+	} else {
+		p.ordered = append(p.ordered, 0)
 	}
 
 	children := n.Children()
@@ -118,10 +143,8 @@ func (p *printer) printList(
 		}
 	}
 
-	if n.Ordered() {
-		// Pop the counter
-		p.ordered = p.ordered[:len(p.ordered)-1]
-	}
+	// Pop the counter
+	p.ordered = p.ordered[:len(p.ordered)-1]
 	p.listDepth--
 }
 
@@ -153,18 +176,24 @@ func (p *printer) printListItem(n *NodeListItem) {
 		}
 	}
 
-	// Handle WHEN/THEN/AND keywords
+	// Handle WHEN/THEN/AND keywords, unless the children already start
+	// with the bold keyword
+	children := n.Children()
 	keyword := n.Keyword()
-	if keyword != "" {
+	if keyword != "" && !startsWithKeyword(children, keyword) {
 		p.writeString("**")
 		p.writeString(strings.ToUpper(keyword))
 		p.writeString("** ")
 	}
 
 	// Print children inline
-	children := n.Children()
 	hasNestedList := false
 	for i, child := range children {
+		if i > 0 && isInlineNode(child) && p.breaksLine(n, children[i-1], child) {
+			p.writeByte('\n')
+			p.writeIndent()
+			p.writeString("  ")
+		}
 		if _, isList := child.(*NodeList); isList {
 			hasNestedList = true
 			if i > 0 {
@@ -186,6 +215,23 @@ func (p *printer) printListItem(n *NodeListItem) {
 	}
 }
 
+// startsWithKeyword reports whether children start with keyword in bold.
+func startsWithKeyword(children []Node, keyword string) bool {
+	if len(children) == 0 {
+		return false
+	}
+	strong, ok := children[0].(*NodeStrong)
+	if !ok {
+		return false
+	}
+	var text []byte
+	for _, child := range strong.Children() {
+		text = append(text, child.Source()...)
+	}
+
+	return strings.EqualFold(strings.TrimSpace(string(text)), keyword)
+}
+
 // printCodeBlock prints a fenced code block.
 //
 //nolint:revive // flag-parameter
@@ -197,8 +243,11 @@ func (p *printer) printCodeBlock(
 		p.writeBlankLine()
 	}
 
+	// The fence is longer than any backtick run in the content
+	fence := strings.Repeat("`", max(3, longestRun(n.Content(), '`')+1)) //nolint:revive // add-constant
+
 	p.writeIndent()
-	p.writeString("```")
+	p.writeString(fence)
 	if lang := n.Language(); len(lang) > 0 {
 		p.write(lang)
 	}
@@ -230,7 +279,87 @@ func (p *printer) printCodeBlock(
 	}
 
 	p.writeIndent()
-	p.writeString("```\n")
+	p.writeString(fence)
+	p.writeByte('\n')
+}
+
+// longestRun returns the length of the longest run of c in b.
+func longestRun(b []byte, c byte) int {
+	longest, run := 0, 0
+	for _, x := range b {
+		if x != c {
+			run = 0
+
+			continue
+		}
+		run++
+		longest = max(longest, run)
+	}
+
+	return longest
+}
+
+// printHTMLComment prints an HTML comment verbatim from its source.
+//
+//nolint:revive // flag-parameter
+func (p *printer) printHTMLComment(
+	n *NodeHTMLComment,
+	isFirst bool,
+) {
+	if !isFirst {
+		p.writeBlankLine()
+	}
+
+	p.writeIndent()
+	p.write(bytes.TrimSpace(n.Source()))
+	p.writeByte('\n')
+}
+
+// printTable prints a table with one space around each cell, the header
+// row first, then a delimiter row from the column alignments.
+//
+//nolint:revive // flag-parameter
+func (p *printer) printTable(
+	n *NodeTable,
+	isFirst bool,
+) {
+	if !isFirst {
+		p.writeBlankLine()
+	}
+
+	if header := n.Header(); header != nil {
+		p.printTableRow(header)
+	}
+	p.writeIndent()
+	for _, align := range n.Alignments() {
+		switch align {
+		case TableAlignLeft:
+			p.writeString("| :--- ")
+		case TableAlignCenter:
+			p.writeString("| :---: ")
+		case TableAlignRight:
+			p.writeString("| ---: ")
+		case TableAlignNone:
+			p.writeString("| --- ")
+		}
+	}
+	p.writeString("|\n")
+	for _, row := range n.Rows() {
+		p.printTableRow(row)
+	}
+}
+
+// printTableRow prints a table row on one line.
+func (p *printer) printTableRow(n *NodeTableRow) {
+	p.writeIndent()
+	for _, cell := range n.Cells() {
+		p.writeString("| ")
+		for _, child := range cell.Children() {
+			p.printInline(child)
+		}
+		p.writeByte(' ')
+	}
+	p.writeString("|\n")
 }
 
 // printBlockquoteChild prints a child of a blockquote with > prefix.
@@ -247,10 +376,10 @@ func (p *printer) printBlockquoteChild(
 	case *NodeParagraph:
 		p.writeIndent()
 		p.writeString("> ")
-		children := n.Children()
-		for _, child := range children {
-			p.printInline(child)
-		}
+		prefix := p.linePrefix
+		p.linePrefix = "> "
+		p.printInlines(n, n.Children())
+		p.linePrefix = prefix
 		p.writeByte('\n')
 	case *NodeBlockquote:
 		// Nested blockquote - print with additional >
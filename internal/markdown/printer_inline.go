@@ -15,11 +15,29 @@ func (p *printer) printParagraph(
 
 	p.writeIndent()
 	// Print inline children
-	children := n.Children()
-	for _, child := range children {
+	p.printInlines(n, n.Children())
+	p.writeByte('\n')
+}
+
+// printInlines prints inline nodes, keeping the line breaks that separate
+// them in parent's source.
+func (p *printer) printInlines(parent Node, children []Node) {
+	for i, child := range children {
+		if i > 0 && p.breaksLine(parent, children[i-1], child) {
+			p.writeByte('\n')
+			p.writeIndent()
+			p.writeString(p.linePrefix)
+		}
 		p.printInline(child)
 	}
-	p.writeByte('\n')
+}
+
+// breaksLine reports whether a line break separates prev and next in
+// parent's source. Nodes without a span in parent are on the same line.
+func (p *printer) breaksLine(parent, prev, next Node) bool {
+	gap, ok := sourceBetween(parent, prev, next)
+
+	return ok && bytes.IndexByte(gap, '\n') >= 0
 }
 
 // printInline prints an inline node without block-level formatting.
@@ -122,9 +140,15 @@ func (p *printer) printStrikethrough(
 	p.writeString("~~")
 }
 
-// printCode prints inline code with backticks.
+// printCode prints inline code with backticks. Code parsed from markdown
+// keeps its delimiters in its source and is written as is.
 func (p *printer) printCode(n *NodeCode) {
 	source := n.Source()
+	if len(source) > 0 && source[0] == '`' {
+		p.write(source)
+
+		return
+	}
 
 	// Check if content contains backticks
 	hasBacktick := bytes.Contains(
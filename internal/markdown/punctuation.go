@@ -0,0 +1,108 @@
+package markdown
+
+import (
+	"bytes"
+	"strings"
+)
+
+// asciiPunctuation replaces the typographic punctuation that word
+// processors substitute while typing with its plain ASCII spelling.
+var asciiPunctuation = strings.NewReplacer(
+	"‘", "'", // left single quote
+	"’", "'", // right single quote
+	"‚", "'", // single low-9 quote
+	"‛", "'", // single high-reversed-9 quote
+	"′", "'", // prime
+	"“", `"`, // left double quote
+	"”", `"`, // right double quote
+	"„", `"`, // double low-9 quote
+	"‟", `"`, // double high-reversed-9 quote
+	"″", `"`, // double prime
+	"‒", "-", // figure dash
+	"–", "-", // en dash
+	"—", "--", // em dash
+	"―", "--", // horizontal bar
+	"…", "...", // ellipsis
+	"\u00a0", " ", // no-break space
+)
+
+// NormalizePunctuation returns source with smart quotes, typographic
+// dashes, ellipses and no-break spaces replaced by ASCII. Fenced code
+// blocks and code spans are kept as written.
+func NormalizePunctuation(source []byte) []byte {
+	var (
+		out   bytes.Buffer
+		fence rune
+	)
+	out.Grow(len(source))
+
+	for line := range strings.Lines(string(source)) {
+		if isFence, delim := IsCodeFence(line); isFence {
+			switch fence {
+			case 0:
+				fence = delim
+			case delim:
+				fence = 0
+				out.WriteString(line)
+
+				continue
+			}
+		}
+		if fence != 0 {
+			out.WriteString(line)
+
+			continue
+		}
+
+		normalizeLine(&out, line)
+	}
+
+	return out.Bytes()
+}
+
+// normalizeLine writes line to out with punctuation outside code spans
+// replaced.
+func normalizeLine(out *bytes.Buffer, line string) {
+	for line != "" {
+		start := strings.IndexByte(line, '`')
+		if start < 0 {
+			_, _ = asciiPunctuation.WriteString(out, line)
+
+			return
+		}
+		_, _ = asciiPunctuation.WriteString(out, line[:start])
+		line = line[start:]
+
+		// A code span closes on a backtick run of the same length
+		ticks := len(line) - len(strings.TrimLeft(line, "`"))
+		end := closingTicks(line[ticks:], ticks)
+		if end < 0 {
+			out.WriteString(line[:ticks])
+			line = line[ticks:]
+
+			continue
+		}
+		end += 2 * ticks
+		out.WriteString(line[:end])
+		line = line[end:]
+	}
+}
+
+// closingTicks returns the offset in s of the first run of exactly n
+// backticks, or -1 if there is none.
+func closingTicks(s string, n int) int {
+	for i := 0; i < len(s); {
+		if s[i] != '`' {
+			i++
+
+			continue
+		}
+		run := len(s[i:]) - len(strings.TrimLeft(s[i:], "`"))
+		if run == n {
+			return i
+		}
+		i += run
+	}
+
+	return -1
+}
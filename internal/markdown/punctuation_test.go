@@ -0,0 +1,61 @@
+package markdown
+
+import "testing"
+
+func TestNormalizePunctuation(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "quotes",
+			input: "The “system” SHALL use the user‘s ‚token’.\n",
+			want:  "The \"system\" SHALL use the user's 'token'.\n",
+		},
+		{
+			name:  "dashes and ellipsis",
+			input: "Pages 1–3 — see above…\n",
+			want:  "Pages 1-3 -- see above...\n",
+		},
+		{
+			name:  "no-break space",
+			input: "10 ms\n",
+			want:  "10 ms\n",
+		},
+		{
+			name:  "code span kept",
+			input: "Use `“raw”` and ``a ` “b”`` but “c”\n",
+			want:  "Use `“raw”` and ``a ` “b”`` but \"c\"\n",
+		},
+		{
+			name:  "unclosed backtick",
+			input: "A ` “quote”\n",
+			want:  "A ` \"quote\"\n",
+		},
+		{
+			name:  "fenced code kept",
+			input: "“a”\n```\n“b”\n```\n“c”\n",
+			want:  "\"a\"\n```\n“b”\n```\n\"c\"\n",
+		},
+		{
+			name:  "tilde fence ignores backtick fence",
+			input: "~~~\n```\n“b”\n~~~\n“c”",
+			want:  "~~~\n```\n“b”\n~~~\n\"c\"",
+		},
+		{
+			name:  "ascii unchanged",
+			input: "plain \"text\" -- here\n",
+			want:  "plain \"text\" -- here\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := string(NormalizePunctuation([]byte(tt.input)))
+			if got != tt.want {
+				t.Errorf("NormalizePunctuation() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
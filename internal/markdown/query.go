@@ -202,6 +202,18 @@ func HasName(name string) Predicate {
 	}
 }
 
+// HasColumn returns a predicate matching tables with a column whose header
+// cell text is name.
+func HasColumn(name string) Predicate {
+	return func(n Node) bool {
+		if table, ok := n.(*NodeTable); ok {
+			return table.ColumnIndex(name) >= 0
+		}
+
+		return false
+	}
+}
+
 // InRange returns a predicate matching nodes within [start, end).
 // A node is in range if its span overlaps the given range.
 // Overlap occurs when node.start < end AND node.end > start.
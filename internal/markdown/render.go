@@ -0,0 +1,183 @@
+package markdown
+
+import (
+	"bytes"
+	"io"
+)
+
+// Render serializes the AST node back to markdown source.
+//
+// Unlike Print, which normalizes every node, Render writes nodes that come
+// unchanged from Parse exactly as they were written, along with the
+// whitespace between them. Nodes built or changed since, such as a
+// requirement renamed with ToBuilder, are printed in Print's canonical
+// form. So Render(Parse(src)) returns src, and a transform followed by
+// Render changes only the lines of the nodes it touched.
+//
+// Render is deterministic: the same AST always renders to the same bytes.
+//
+// Example:
+//
+//	root, _ := markdown.Parse(source)
+//	renamed, _ := markdown.Transform(root, markdown.RenameRequirement("Old", "New"))
+//	updated := markdown.Render(renamed) // source with one header line changed
+func Render(node Node) []byte {
+	var buf bytes.Buffer
+	_ = RenderTo(&buf, node) // bytes.Buffer.Write never fails
+
+	return buf.Bytes()
+}
+
+// RenderTo renders the AST node to the provided io.Writer like Render.
+// Returns any write errors encountered.
+func RenderTo(w io.Writer, node Node) error {
+	if node == nil {
+		return nil
+	}
+	p := newPrinter(w)
+	p.preserve = true
+	if isVerbatim(node) {
+		p.write(node.Source())
+	} else {
+		p.printNode(node, true)
+	}
+
+	return p.err
+}
+
+// printBlocks prints a sequence of block nodes, each ending with a
+// newline. Blocks are separated by a blank line, except when rendering two
+// blocks that were neighbours in parent's source, which keep the
+// whitespace between them.
+func (p *printer) printBlocks(parent Node, blocks []Node) {
+	for i, block := range blocks {
+		if i > 0 {
+			p.writeBlockGap(parent, blocks[i-1], block)
+		}
+		if p.preserve && isVerbatim(block) {
+			p.write(blockContent(block.Source()))
+			p.writeByte('\n')
+
+			continue
+		}
+		if when := headerWhen(block); when != "" &&
+			!declaresWhen(block, blocks[i+1:]) {
+			p.pendingWhen = when
+		}
+		p.printNode(block, true)
+		p.pendingWhen = ""
+	}
+}
+
+// writeBlockGap writes the separator between two printed blocks.
+func (p *printer) writeBlockGap(parent, prev, next Node) {
+	if p.preserve {
+		if gap, ok := sourceBetween(parent, prev, next); ok && isBlank(gap) {
+			// The newline ending prev is already written
+			gap = bytes.TrimPrefix(gap, []byte("\r"))
+			gap = bytes.TrimPrefix(gap, []byte("\n"))
+			p.write(gap)
+
+			return
+		}
+	}
+	p.writeByte('\n')
+}
+
+// sourceBetween returns the part of parent's source between the end of
+// prev's content, without trailing newlines, and the start of next. ok is
+// false if either node has no span inside parent's source.
+func sourceBetween(parent, prev, next Node) (gap []byte, ok bool) {
+	if parent == nil {
+		return nil, false
+	}
+	source := parent.Source()
+	base, _ := parent.Span()
+	prevStart, prevEnd := prev.Span()
+	nextStart, nextEnd := next.Span()
+	if prevEnd <= prevStart || nextEnd <= nextStart {
+		return nil, false
+	}
+	lo := prevStart - base + len(blockContent(prev.Source()))
+	hi := nextStart - base
+	if lo < 0 || lo > hi || hi > len(source) {
+		return nil, false
+	}
+
+	return source[lo:hi], true
+}
+
+// blockContent returns a block's source without the blank lines and
+// indentation that follow its last line.
+func blockContent(source []byte) []byte {
+	end := len(bytes.TrimRight(source, " \t\r\n"))
+	for end < len(source) && (source[end] == ' ' || source[end] == '\t') {
+		end++
+	}
+
+	return source[:end]
+}
+
+// isBlank reports whether b is only whitespace.
+func isBlank(b []byte) bool {
+	return len(bytes.TrimSpace(b)) == 0
+}
+
+// isInlineNode reports whether n is an inline node.
+func isInlineNode(n Node) bool {
+	switch n.(type) {
+	case *NodeText, *NodeStrong, *NodeEmphasis, *NodeStrikethrough,
+		*NodeCode, *NodeLink, *NodeWikilink:
+		return true
+	default:
+		return false
+	}
+}
+
+// headerWhen returns the condition of a requirement or scenario block.
+func headerWhen(n Node) string {
+	switch h := n.(type) {
+	case *NodeRequirement:
+		return h.When()
+	case *NodeScenario:
+		return h.When()
+	default:
+		return ""
+	}
+}
+
+// declaresWhen reports whether a paragraph declares the condition of
+// header: one of its children, or one of the blocks following it up to the
+// next header.
+func declaresWhen(header Node, following []Node) bool {
+	for _, child := range header.Children() {
+		if isWhenParagraph(child) {
+			return true
+		}
+	}
+	for _, block := range following {
+		switch block.(type) {
+		case *NodeSection, *NodeRequirement, *NodeScenario:
+			return false
+		}
+		if isWhenParagraph(block) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isWhenParagraph reports whether n is a paragraph with a "when:" line.
+func isWhenParagraph(n Node) bool {
+	if _, ok := n.(*NodeParagraph); !ok {
+		return false
+	}
+	for line := range bytes.SplitSeq(n.Source(), []byte{'\n'}) {
+		if _, ok := MatchWhenLine(string(line)); ok {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,154 @@
+//nolint:revive // unchecked-type-assertion: tests use controlled type assertions
+package markdown
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const renderFixture = `# Auth Specification
+
+## Purpose
+
+Handles   user login
+and sessions.
+
+## Requirements
+
+### Requirement: Login
+when: beta
+Users SHALL log in with a *password* or ` + "`token`" + `.
+
+#### Scenario: Valid login
+
+- **WHEN** the password is correct
+- **THEN** a session starts
+  1. nested step
+
+* [x] done item
++ other bullet
+
+
+
+> quoted **text**
+> second line
+
+~~~sh
+echo ` + "```" + `
+~~~
+
+| Role | Access |
+|:-----|-------:|
+| admin | all |
+
+<!-- note -->
+[ref]: https://example.com "Example"
+`
+
+func TestRender_RoundTrip(t *testing.T) {
+	sources := map[string]string{
+		"fixture":          renderFixture,
+		"no final newline": "# Title\n\nText",
+		"crlf":             "# Title\r\n\r\nText\r\n",
+		"leading blanks":   "\n\n# Title\n",
+		"empty":            "",
+	}
+	paths, _ := filepath.Glob("../../spectr/specs/*/spec.md")
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sources[path] = string(data)
+	}
+
+	for name, source := range sources {
+		t.Run(name, func(t *testing.T) {
+			doc, _ := Parse([]byte(source), WithHTMLComments(), WithTables())
+			if got := string(Render(doc)); got != source {
+				t.Errorf("Render() = %q, want %q", got, source)
+			}
+		})
+	}
+}
+
+func TestRender_Transformed(t *testing.T) {
+	doc, _ := Parse([]byte(renderFixture), WithHTMLComments(), WithTables())
+
+	renamed, err := Transform(doc, RenameRequirement("Login", "Sign In"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := strings.Replace(renderFixture, "Requirement: Login", "Requirement: Sign In", 1)
+	if got := string(Render(renamed)); got != want {
+		t.Errorf("Render() after rename = %q, want %q", got, want)
+	}
+
+	filtered, err := Transform(doc, Filter(Not(IsType[*NodeTable]())))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(Render(filtered))
+	if strings.Contains(got, "| admin |") ||
+		!strings.Contains(got, "~~~\n\n<!-- note -->\n[ref]:") {
+		t.Errorf("Render() after filter = %q", got)
+	}
+}
+
+func TestRender_Built(t *testing.T) {
+	tests := []struct {
+		name string
+		node Node
+		want string
+	}{
+		{
+			name: "requirement with condition",
+			node: NewNodeBuilder(NodeTypeDocument).WithChildren([]Node{
+				NewNodeBuilder(NodeTypeRequirement).WithName("Export").WithWhen("beta").Build(),
+				NewNodeBuilder(NodeTypeParagraph).WithChildren([]Node{
+					NewNodeBuilder(NodeTypeText).WithSource([]byte("Body.")).Build(),
+				}).Build(),
+			}).Build(),
+			want: "### Requirement: Export\nwhen: beta\n\nBody.\n",
+		},
+		{
+			name: "code block with fences in content",
+			node: NewNodeBuilder(NodeTypeCodeBlock).
+				WithLanguage([]byte("md")).
+				WithContent([]byte("```go\n```\n")).
+				Build(),
+			want: "````md\n```go\n```\n````\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(Render(tt.node)); got != tt.want {
+				t.Errorf("Render() = %q, want %q", got, tt.want)
+			}
+			if got := string(Print(tt.node)); got != tt.want {
+				t.Errorf("Print() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrint_Canonical(t *testing.T) {
+	input := "# Title\n## Requirements\n### Requirement: Login\nwhen: beta\nLine one\nline two with `code`.\n" +
+		"#### Scenario: Ok\n- **WHEN** it runs\n- **THEN** it works\n\nSteps:\n\n1. first\n2. second\n"
+	want := "# Title\n\n## Requirements\n\n### Requirement: Login\n\nwhen: beta\nLine one\nline two with `code`.\n\n" +
+		"#### Scenario: Ok\n\n- **WHEN** it runs\n- **THEN** it works\n\nSteps:\n\n1. first\n2. second\n"
+
+	doc, _ := Parse([]byte(input))
+	printed := string(Print(doc))
+	if printed != want {
+		t.Errorf("Print() = %q, want %q", printed, want)
+	}
+
+	reparsed, _ := Parse([]byte(printed))
+	if got := string(Print(reparsed)); got != printed {
+		t.Errorf("second Print() = %q, want %q", got, printed)
+	}
+}
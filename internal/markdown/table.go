@@ -0,0 +1,246 @@
+package markdown
+
+// tableCell is a cell found by scanTableRow: the token range [start, end)
+// of its content without surrounding whitespace, and the byte offset where
+// the content starts (the following pipe for an empty cell).
+type tableCell struct {
+	start  int
+	end    int
+	offset int
+}
+
+// tableRow is a table line found by scanTableRow.
+type tableRow struct {
+	cells []tableCell
+	start int // Byte offset of the row without leading whitespace
+	end   int // Byte offset of the row without trailing whitespace
+	next  int // Token index of the next line, or of EOF
+}
+
+// tryParseTable attempts to parse a GFM pipe table starting at the current
+// token. Returns nil if the current line does not start a table, leaving
+// the position unchanged.
+func (p *parser) tryParseTable() Node {
+	header, delimiter, alignments, ok := p.scanTableHead(p.pos)
+	if !ok {
+		return nil
+	}
+
+	rows := []Node{p.buildTableRow(header, alignments, true)}
+	end := delimiter.end
+	next := delimiter.next
+	for !p.interruptsTable(next) {
+		row, ok := p.scanTableRow(next)
+		if !ok {
+			break
+		}
+		rows = append(rows, p.buildTableRow(row, alignments, false))
+		end = row.end
+		next = row.next
+	}
+	p.pos = next
+
+	return NewNodeBuilder(NodeTypeTable).
+		WithStart(header.start).
+		WithEnd(end).
+		WithSource(p.source[header.start:end]).
+		WithChildren(rows).
+		WithAlignments(alignments).
+		Build()
+}
+
+// startsTable reports whether a table starts at token pos.
+func (p *parser) startsTable(pos int) bool {
+	_, _, _, ok := p.scanTableHead(pos)
+
+	return ok
+}
+
+// scanTableHead scans the header and delimiter rows of a table starting at
+// token pos. The delimiter row must have as many cells as the header.
+func (p *parser) scanTableHead(
+	pos int,
+) (header, delimiter tableRow, alignments []TableAlign, ok bool) {
+	header, ok = p.scanTableRow(pos)
+	if !ok {
+		return header, delimiter, nil, false
+	}
+	delimiter, ok = p.scanTableRow(header.next)
+	if !ok {
+		return header, delimiter, nil, false
+	}
+	alignments, ok = p.tableAlignments(delimiter)
+	if !ok || len(alignments) != len(header.cells) {
+		return header, delimiter, nil, false
+	}
+
+	return header, delimiter, alignments, true
+}
+
+// scanTableRow splits the line starting at token pos into cells at its
+// pipes. Leading and trailing pipes are optional. Escaped pipes (\|) are
+// lexed as text and do not split cells. ok is false for a line without a
+// cell, such as a blank line or one without pipes.
+func (p *parser) scanTableRow(pos int) (row tableRow, ok bool) {
+	first := pos
+	for p.tokens[first].Type == TokenWhitespace {
+		first++
+	}
+
+	var pipes []int
+	lineEnd := first
+	for ; !isLineEnd(p.tokens[lineEnd]); lineEnd++ {
+		if p.tokens[lineEnd].Type == TokenPipe {
+			pipes = append(pipes, lineEnd)
+		}
+	}
+	if len(pipes) == 0 {
+		return row, false
+	}
+	last := lineEnd
+	for last > first && p.tokens[last-1].Type == TokenWhitespace {
+		last--
+	}
+
+	// Cells lie between consecutive bounds: the pipes, and the line's ends
+	// where it has no leading or trailing pipe
+	bounds := pipes
+	if pipes[0] != first {
+		bounds = append([]int{first - 1}, bounds...)
+	}
+	if pipes[len(pipes)-1] != last-1 {
+		bounds = append(bounds, last)
+	}
+	if len(bounds) < 2 {
+		return row, false
+	}
+
+	for i := range len(bounds) - 1 {
+		row.cells = append(row.cells, p.trimTableCell(bounds[i]+1, bounds[i+1]))
+	}
+	row.start = p.tokens[first].Start
+	row.end = p.tokens[last-1].End
+	row.next = lineEnd
+	if p.tokens[lineEnd].Type == TokenNewline {
+		row.next++
+	}
+
+	return row, true
+}
+
+// trimTableCell returns the cell in the token range [start, end) without
+// surrounding whitespace.
+func (p *parser) trimTableCell(start, end int) tableCell {
+	for start < end && p.tokens[start].Type == TokenWhitespace {
+		start++
+	}
+	for end > start && p.tokens[end-1].Type == TokenWhitespace {
+		end--
+	}
+
+	return tableCell{start: start, end: end, offset: p.tokens[start].Start}
+}
+
+// tableAlignments returns the column alignments of a delimiter row, whose
+// cells are dashes with an optional colon on either side. ok is false if
+// the row is not a delimiter row.
+func (p *parser) tableAlignments(row tableRow) ([]TableAlign, bool) {
+	alignments := make([]TableAlign, 0, len(row.cells))
+	for _, cell := range row.cells {
+		tokens := p.tokens[cell.start:cell.end]
+		left := len(tokens) > 0 && tokens[0].Type == TokenColon
+		if left {
+			tokens = tokens[1:]
+		}
+		right := len(tokens) > 0 && tokens[len(tokens)-1].Type == TokenColon
+		if right {
+			tokens = tokens[:len(tokens)-1]
+		}
+		if len(tokens) == 0 {
+			return nil, false
+		}
+		for _, tok := range tokens {
+			if tok.Type != TokenDash {
+				return nil, false
+			}
+		}
+
+		switch {
+		case left && right:
+			alignments = append(alignments, TableAlignCenter)
+		case left:
+			alignments = append(alignments, TableAlignLeft)
+		case right:
+			alignments = append(alignments, TableAlignRight)
+		default:
+			alignments = append(alignments, TableAlignNone)
+		}
+	}
+
+	return alignments, true
+}
+
+// interruptsTable reports whether the line starting at token pos begins a
+// header, blockquote or code fence, which ends a table even if it contains
+// a pipe.
+func (p *parser) interruptsTable(pos int) bool {
+	for p.tokens[pos].Type == TokenWhitespace {
+		pos++
+	}
+
+	switch tok := p.tokens[pos]; tok.Type {
+	case TokenHash, TokenGreaterThan:
+		return true
+	case TokenBacktick, TokenTilde:
+		count := 0
+		for p.tokens[pos+count].Type == tok.Type {
+			count++
+		}
+
+		return count >= 3 //nolint:revive // add-constant
+	default:
+		return false
+	}
+}
+
+// buildTableRow builds a table row node, parsing each cell's inline
+// content. Cells beyond the header's columns have no alignment.
+//
+//nolint:revive // flag-parameter
+func (p *parser) buildTableRow(
+	row tableRow,
+	alignments []TableAlign,
+	header bool,
+) Node {
+	cells := make([]Node, 0, len(row.cells))
+	for i, cell := range row.cells {
+		align := TableAlignNone
+		if i < len(alignments) {
+			align = alignments[i]
+		}
+		end := cell.offset
+		if cell.end > cell.start {
+			end = p.tokens[cell.end-1].End
+		}
+		cells = append(cells, NewNodeBuilder(NodeTypeTableCell).
+			WithStart(cell.offset).
+			WithEnd(end).
+			WithSource(p.source[cell.offset:end]).
+			WithChildren(p.parseInlineContent(cell.start, cell.end)).
+			WithAlign(align).
+			Build())
+	}
+
+	return NewNodeBuilder(NodeTypeTableRow).
+		WithStart(row.start).
+		WithEnd(row.end).
+		WithSource(p.source[row.start:row.end]).
+		WithChildren(cells).
+		WithHeader(header).
+		Build()
+}
+
+// isLineEnd reports whether tok ends a line.
+func isLineEnd(tok Token) bool {
+	return tok.Type == TokenNewline || tok.Type == TokenEOF
+}
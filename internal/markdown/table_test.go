@@ -0,0 +1,227 @@
+//nolint:revive // unchecked-type-assertion: tests use controlled type assertions
+package markdown
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// tableTexts returns the cell texts of each row of table.
+func tableTexts(table *NodeTable) [][]string {
+	var rows [][]string
+	for _, child := range table.Children() {
+		var texts []string
+		for _, cell := range child.(*NodeTableRow).Cells() {
+			texts = append(texts, cell.Text())
+		}
+		rows = append(rows, texts)
+	}
+
+	return rows
+}
+
+func TestParse_Tables(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		rows       [][]string
+		alignments []TableAlign
+	}{
+		{
+			name:       "leading and trailing pipes",
+			input:      "| user | role |\n| --- | --- |\n| alice | admin |\n| bob | viewer |\n",
+			rows:       [][]string{{"user", "role"}, {"alice", "admin"}, {"bob", "viewer"}},
+			alignments: []TableAlign{TableAlignNone, TableAlignNone},
+		},
+		{
+			name:       "alignments",
+			input:      "a | b | c | d\n:-- | :-: | --: | -\n1 | 2 | 3 | 4\n",
+			rows:       [][]string{{"a", "b", "c", "d"}, {"1", "2", "3", "4"}},
+			alignments: []TableAlign{TableAlignLeft, TableAlignCenter, TableAlignRight, TableAlignNone},
+		},
+		{
+			name:       "escaped pipe and inline code",
+			input:      "| op | example |\n|----|----|\n| or | `a \\| b` |\n| pipe | a\\|b |\n",
+			rows:       [][]string{{"op", "example"}, {"or", "`a | b`"}, {"pipe", "a|b"}},
+			alignments: []TableAlign{TableAlignNone, TableAlignNone},
+		},
+		{
+			name:       "ragged and empty cells",
+			input:      "| a | b |\n|---|---|\n| 1 |\n| | 2 | 3 |\n",
+			rows:       [][]string{{"a", "b"}, {"1"}, {"", "2", "3"}},
+			alignments: []TableAlign{TableAlignNone, TableAlignNone},
+		},
+		{
+			name:       "header only, ended by heading",
+			input:      "| a |\n| :---: |\n## Next | heading\n",
+			rows:       [][]string{{"a"}},
+			alignments: []TableAlign{TableAlignCenter},
+		},
+		{
+			name:       "interrupts paragraph",
+			input:      "Examples:\n| a | b |\n| - | - |\n| 1 | 2 |\n\nAfter.\n",
+			rows:       [][]string{{"a", "b"}, {"1", "2"}},
+			alignments: []TableAlign{TableAlignNone, TableAlignNone},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, errs := Parse([]byte(tt.input), WithTables())
+			if len(errs) != 0 {
+				t.Fatalf("Parse() errors = %v", errs)
+			}
+			tables := FindByType[*NodeTable](doc)
+			if len(tables) != 1 {
+				t.Fatalf("found %d tables, want 1", len(tables))
+			}
+			table := tables[0]
+
+			got := tableTexts(table)
+			if len(got) != len(tt.rows) {
+				t.Fatalf("rows = %q, want %q", got, tt.rows)
+			}
+			for i := range got {
+				if !slices.Equal(got[i], tt.rows[i]) {
+					t.Errorf("row %d = %q, want %q", i, got[i], tt.rows[i])
+				}
+			}
+			if !slices.Equal(table.Alignments(), tt.alignments) {
+				t.Errorf("Alignments() = %v, want %v", table.Alignments(), tt.alignments)
+			}
+			if !table.Header().IsHeader() || len(table.Rows()) != len(tt.rows)-1 {
+				t.Errorf("Header()/Rows() do not split %d rows", len(tt.rows))
+			}
+		})
+	}
+}
+
+func TestParse_NotTables(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"no delimiter row", "| a | b |\n| c | d |\n"},
+		{"column count mismatch", "| a | b |\n| --- |\n"},
+		{"delimiter without dashes", "| a | b |\n| : | :: |\n"},
+		{"delimiter without pipes", "a | b\n---\n"},
+		{"escaped pipes only", "a \\| b\n--- \\| ---\n"},
+		{"in code block", "```\n| a | b |\n| - | - |\n```\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, _ := Parse([]byte(tt.input), WithTables())
+			if Exists(doc, IsType[*NodeTable]()) {
+				t.Errorf("unexpected table in %q", tt.input)
+			}
+		})
+	}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		doc, _ := Parse([]byte("| a | b |\n| - | - |\n"))
+		if Exists(doc, IsType[*NodeTable]()) {
+			t.Error("unexpected table without option")
+		}
+	})
+}
+
+func TestParse_TableCells(t *testing.T) {
+	input := "### Requirement: Roles\n\n| Role | **Access** |\n| --- | ---: |\n| admin | [all](docs.md) |\n"
+	doc, _ := Parse([]byte(input), WithTables())
+
+	table := FindFirstByType[*NodeTable](doc)
+	if table == nil {
+		t.Fatal("no table")
+	}
+	if children := doc.Children(); len(children) != 2 || children[1] != Node(table) {
+		t.Errorf("document children = %v, want the requirement and the table", children)
+	}
+
+	header := table.Header().Cells()
+	if _, ok := header[1].Children()[0].(*NodeStrong); !ok {
+		t.Errorf("header cell children = %v, want Strong", header[1].Children())
+	}
+	if header[1].Align() != TableAlignRight {
+		t.Errorf("Align() = %v, want right", header[1].Align())
+	}
+
+	cell := table.Rows()[0].Cells()[1]
+	start, end := cell.Span()
+	if got := input[start:end]; got != "[all](docs.md)" {
+		t.Errorf("cell span = %q", got)
+	}
+	if _, ok := cell.Children()[0].(*NodeLink); !ok {
+		t.Errorf("cell children = %v, want Link", cell.Children())
+	}
+}
+
+func TestPrint_TableRoundTrip(t *testing.T) {
+	input := "|a|b|c|\n|:-|:-:|-:|\n|1|a \\| b||\n"
+	want := "| a | b | c |\n| :--- | :---: | ---: |\n| 1 | a \\| b |  |\n"
+
+	doc, _ := Parse([]byte(input), WithTables())
+	printed := string(Print(doc))
+	if printed != want {
+		t.Errorf("Print() = %q, want %q", printed, want)
+	}
+
+	reparsed, _ := Parse([]byte(printed), WithTables())
+	if got := string(Print(reparsed)); got != printed {
+		t.Errorf("second Print() = %q, want %q", got, printed)
+	}
+}
+
+// tableCounter counts table nodes by type.
+type tableCounter struct {
+	BaseVisitor
+	tables, rows, cells int
+}
+
+func (c *tableCounter) VisitTable(*NodeTable) error {
+	c.tables++
+
+	return nil
+}
+
+func (c *tableCounter) VisitTableRow(*NodeTableRow) error {
+	c.rows++
+
+	return nil
+}
+
+func (c *tableCounter) VisitTableCell(*NodeTableCell) error {
+	c.cells++
+
+	return nil
+}
+
+func TestWalk_Tables(t *testing.T) {
+	input := "| Name | Value |\n| --- | --- |\n| a | 1 |\n\n| Key |\n| --- |\n| k |\n"
+	doc, _ := Parse([]byte(input), WithTables())
+
+	counter := &tableCounter{}
+	if err := Walk(doc, counter); err != nil {
+		t.Fatal(err)
+	}
+	if counter.tables != 2 || counter.rows != 4 || counter.cells != 6 {
+		t.Errorf("visited %+v, want 2 tables, 4 rows and 6 cells", *counter)
+	}
+
+	withValue := Find(doc, HasColumn("Value"))
+	if len(withValue) != 1 || withValue[0].(*NodeTable).ColumnIndex("Value") != 1 {
+		t.Errorf("HasColumn(Value) = %v", withValue)
+	}
+
+	stripped, err := Transform(doc, Filter(Not(HasColumn("Key"))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := Count(stripped, IsType[*NodeTable]()); got != 1 {
+		t.Errorf("Filter left %d tables, want 1", got)
+	}
+	if !strings.Contains(NodeTypeTableCell.String(), "TableCell") {
+		t.Errorf("NodeTypeTableCell.String() = %q", NodeTypeTableCell.String())
+	}
+}
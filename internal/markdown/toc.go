@@ -0,0 +1,269 @@
+package markdown
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Markers delimiting a generated table of contents. Re-running InsertTOC
+// replaces everything between them.
+const (
+	TOCStartMarker = "<!-- spectr:toc -->"
+	TOCEndMarker   = "<!-- /spectr:toc -->"
+)
+
+// Heading levels that NumberHeadings and InsertTOC act on.
+const (
+	minNumberedLevel = 2
+	maxNumberedLevel = 4
+	maxTOCLevel      = 3
+)
+
+// headingNumberPattern matches a number prefix written by NumberHeadings,
+// e.g. "2. " or "2.1 ".
+var headingNumberPattern = regexp.MustCompile(`^\d+(?:\.\d+)*\.? `)
+
+// structuralSections are H2 sections that Spectr parses by name. Numbering
+// them would break spec validation, so they are left as-is.
+var structuralSections = map[string]bool{
+	"Purpose":      true,
+	"Requirements": true,
+}
+
+// heading is a top-level ATX header located in the source.
+type heading struct {
+	level      int
+	text       string // Header text without the # prefix
+	start, end int    // Byte range of the header line, excluding newline
+	numbered   bool   // Whether NumberHeadings may renumber it
+	// Whether it is a ### Requirement: or #### Scenario: header
+	requirement, scenario bool
+}
+
+// NumberHeadings returns source with H2-H4 section headings numbered
+// hierarchically ("## 1. Context", "### 1.1 Details"). Existing numbers are
+// replaced, so running it again is idempotent. Spectr structural headings
+// (Purpose, Requirements, delta sections, requirements and scenarios) and
+// anything nested under them are left untouched. All other text is kept
+// byte-for-byte.
+func NumberHeadings(source []byte) []byte {
+	var (
+		counters [maxNumberedLevel + 1]int
+		skipping bool
+		edits    []sourceEdit
+	)
+
+	for _, h := range findHeadings(source) {
+		if h.level == minNumberedLevel {
+			skipping = !h.numbered
+		}
+		if !h.numbered || skipping || h.level > maxNumberedLevel {
+			continue
+		}
+		// A heading without a numbered parent has nothing to nest under
+		if h.level > minNumberedLevel && counters[h.level-1] == 0 {
+			continue
+		}
+
+		counters[h.level]++
+		for level := h.level + 1; level <= maxNumberedLevel; level++ {
+			counters[level] = 0
+		}
+
+		parts := make([]string, 0, h.level-1)
+		for level := minNumberedLevel; level <= h.level; level++ {
+			parts = append(parts, strconv.Itoa(counters[level]))
+		}
+		number := strings.Join(parts, ".")
+		if h.level == minNumberedLevel {
+			number += "."
+		}
+
+		edits = append(edits, sourceEdit{
+			start: h.start,
+			end:   h.end,
+			text: fmt.Sprintf(
+				"%s %s %s",
+				strings.Repeat("#", h.level),
+				number,
+				stripHeadingNumber(h.text),
+			),
+		})
+	}
+
+	return applyEdits(source, edits)
+}
+
+// InsertTOC returns source with a generated table of contents listing its
+// H2 and H3 headings as links. The block is wrapped in TOCStartMarker and
+// TOCEndMarker; an existing block is updated in place, otherwise a new one
+// is inserted after the H1 title (or at the top if there is none).
+func InsertTOC(source []byte) []byte {
+	headings := findHeadings(source)
+
+	var sb strings.Builder
+	sb.WriteString(TOCStartMarker + "\n")
+	slugs := make(map[string]int)
+	for _, h := range headings {
+		if h.level < minNumberedLevel || h.level > maxTOCLevel {
+			continue
+		}
+		fmt.Fprintf(
+			&sb,
+			"%s- [%s](#%s)\n",
+			strings.Repeat("  ", h.level-minNumberedLevel),
+			h.text,
+			uniqueSlug(slugs, h.text),
+		)
+	}
+	sb.WriteString(TOCEndMarker)
+	block := sb.String()
+
+	if start, end, ok := findTOCBlock(source); ok {
+		return applyEdits(source, []sourceEdit{
+			{start: start, end: end, text: block},
+		})
+	}
+
+	if len(headings) > 0 && headings[0].level == 1 {
+		return applyEdits(source, []sourceEdit{{
+			start: headings[0].end,
+			end:   headings[0].end,
+			text:  "\n\n" + block,
+		}})
+	}
+
+	return applyEdits(source, []sourceEdit{
+		{start: 0, end: 0, text: block + "\n\n"},
+	})
+}
+
+// findHeadings returns the top-level headers of source in document order.
+func findHeadings(source []byte) []heading {
+	root, _ := Parse(source)
+	if root == nil {
+		return nil
+	}
+
+	var headings []heading
+	for _, child := range root.Children() {
+		start, _ := child.Span()
+		h := heading{start: start, end: lineEnd(source, start)}
+
+		switch n := child.(type) {
+		case *NodeSection:
+			h.level = n.Level()
+			h.text = strings.TrimSpace(string(n.Title()))
+			h.numbered = h.level >= minNumberedLevel &&
+				n.DeltaType() == "" &&
+				!structuralSections[h.text]
+		case *NodeRequirement:
+			h.level = 3
+			h.text = "Requirement: " + n.Name()
+			h.requirement = true
+		case *NodeScenario:
+			h.level = 4
+			h.text = "Scenario: " + n.Name()
+			h.scenario = true
+		default:
+			continue
+		}
+
+		headings = append(headings, h)
+	}
+
+	return headings
+}
+
+// findTOCBlock returns the byte range of an existing generated table of
+// contents, from the start marker through the end marker.
+func findTOCBlock(source []byte) (start, end int, ok bool) {
+	start = bytes.Index(source, []byte(TOCStartMarker))
+	if start < 0 {
+		return 0, 0, false
+	}
+
+	rel := bytes.Index(source[start:], []byte(TOCEndMarker))
+	if rel < 0 {
+		return 0, 0, false
+	}
+
+	return start, start + rel + len(TOCEndMarker), true
+}
+
+// stripHeadingNumber removes a number prefix written by NumberHeadings.
+func stripHeadingNumber(text string) string {
+	return headingNumberPattern.ReplaceAllString(text, "")
+}
+
+// HeadingAnchor returns the GitHub-style anchor of a heading, e.g.
+// "Requirement: Login" -> "requirement-login".
+func HeadingAnchor(text string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(strings.TrimSpace(text)) {
+		switch {
+		case unicode.IsLetter(r), unicode.IsDigit(r), r == '-', r == '_':
+			sb.WriteRune(r)
+		case r == ' ':
+			sb.WriteByte('-')
+		}
+	}
+
+	return sb.String()
+}
+
+// uniqueSlug returns the anchor for a heading, suffixing repeated anchors
+// with -1, -2, ... as GitHub does.
+func uniqueSlug(seen map[string]int, text string) string {
+	slug := HeadingAnchor(text)
+	count := seen[slug]
+	seen[slug] = count + 1
+	if count > 0 {
+		return fmt.Sprintf("%s-%d", slug, count)
+	}
+
+	return slug
+}
+
+// lineEnd returns the offset of the end of the line containing offset,
+// excluding the newline and any carriage return.
+func lineEnd(source []byte, offset int) int {
+	end := len(source)
+	if idx := bytes.IndexByte(source[offset:], '\n'); idx >= 0 {
+		end = offset + idx
+	}
+	if end > offset && source[end-1] == '\r' {
+		end--
+	}
+
+	return end
+}
+
+// sourceEdit replaces source[start:end] with text.
+type sourceEdit struct {
+	start, end int
+	text       string
+}
+
+// applyEdits applies non-overlapping edits sorted by start offset.
+func applyEdits(source []byte, edits []sourceEdit) []byte {
+	if len(edits) == 0 {
+		return source
+	}
+
+	var buf bytes.Buffer
+	buf.Grow(len(source))
+	prev := 0
+	for _, edit := range edits {
+		buf.Write(source[prev:edit.start])
+		buf.WriteString(edit.text)
+		prev = edit.end
+	}
+	buf.Write(source[prev:])
+
+	return buf.Bytes()
+}
@@ -0,0 +1,115 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+const tocDesign = `# Design
+
+Intro text.
+
+## Context
+
+### Background
+
+## 3. Goals
+
+### 9.9 Non-Goals
+
+` + "```markdown\n## Not A Heading\n```" + `
+
+## Context
+`
+
+func TestNumberHeadings(t *testing.T) {
+	got := string(NumberHeadings([]byte(tocDesign)))
+
+	for _, want := range []string{
+		"# Design\n",
+		"## 1. Context\n",
+		"### 1.1 Background\n",
+		"## 2. Goals\n",
+		"### 2.1 Non-Goals\n",
+		"## Not A Heading\n",
+		"## 3. Context\n",
+		"Intro text.\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("NumberHeadings() missing %q:\n%s", want, got)
+		}
+	}
+
+	if again := string(NumberHeadings([]byte(got))); again != got {
+		t.Errorf("NumberHeadings() not idempotent:\n%s", again)
+	}
+}
+
+func TestNumberHeadings_SkipsSpectrStructure(t *testing.T) {
+	spec := `# Auth Specification
+
+## Purpose
+Authentication.
+
+## Requirements
+
+### Requirement: Login
+The system SHALL log users in.
+
+#### Scenario: Valid login
+- **WHEN** credentials are valid
+- **THEN** a session is created
+
+## Notes
+
+### Open Questions
+`
+	got := string(NumberHeadings([]byte(spec)))
+	want := strings.Replace(
+		strings.Replace(spec, "## Notes", "## 1. Notes", 1),
+		"### Open Questions", "### 1.1 Open Questions", 1,
+	)
+	if got != want {
+		t.Errorf("NumberHeadings() =\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestInsertTOC(t *testing.T) {
+	numbered := NumberHeadings([]byte(tocDesign))
+	got := string(InsertTOC(numbered))
+
+	wantTOC := TOCStartMarker + `
+- [1. Context](#1-context)
+  - [1.1 Background](#11-background)
+- [2. Goals](#2-goals)
+  - [2.1 Non-Goals](#21-non-goals)
+- [3. Context](#3-context)
+` + TOCEndMarker
+
+	if !strings.HasPrefix(got, "# Design\n\n"+wantTOC+"\n\nIntro text.") {
+		t.Errorf("InsertTOC() =\n%s", got)
+	}
+
+	// Re-running updates the block in place
+	renamed := strings.Replace(got, "## 2. Goals", "## 2. Objectives", 1)
+	updated := string(InsertTOC([]byte(renamed)))
+	if strings.Count(updated, TOCStartMarker) != 1 {
+		t.Fatalf("InsertTOC() duplicated the block:\n%s", updated)
+	}
+	if !strings.Contains(updated, "- [2. Objectives](#2-objectives)") {
+		t.Errorf("InsertTOC() did not update the block:\n%s", updated)
+	}
+	if again := string(InsertTOC([]byte(updated))); again != updated {
+		t.Errorf("InsertTOC() not idempotent:\n%s", again)
+	}
+}
+
+func TestInsertTOC_WithoutTitle(t *testing.T) {
+	got := string(InsertTOC([]byte("## One\n\n## One\n")))
+
+	want := TOCStartMarker + "\n- [One](#one)\n- [One](#one-1)\n" +
+		TOCEndMarker + "\n\n## One\n\n## One\n"
+	if got != want {
+		t.Errorf("InsertTOC() =\n%q\nwant:\n%q", got, want)
+	}
+}
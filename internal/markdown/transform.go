@@ -97,6 +97,18 @@ type TransformVisitor interface {
 	TransformWikilink(
 		*NodeWikilink,
 	) (Node, TransformAction, error)
+	TransformHTMLComment(
+		*NodeHTMLComment,
+	) (Node, TransformAction, error)
+	TransformTable(
+		*NodeTable,
+	) (Node, TransformAction, error)
+	TransformTableRow(
+		*NodeTableRow,
+	) (Node, TransformAction, error)
+	TransformTableCell(
+		*NodeTableCell,
+	) (Node, TransformAction, error)
 }
 
 // BaseTransformVisitor provides default no-op implementations for all
@@ -224,6 +236,34 @@ func (BaseTransformVisitor) TransformWikilink(
 	return n, ActionKeep, nil
 }
 
+// TransformHTMLComment returns the HTML comment unchanged.
+func (BaseTransformVisitor) TransformHTMLComment(
+	n *NodeHTMLComment,
+) (Node, TransformAction, error) {
+	return n, ActionKeep, nil
+}
+
+// TransformTable returns the table unchanged.
+func (BaseTransformVisitor) TransformTable(
+	n *NodeTable,
+) (Node, TransformAction, error) {
+	return n, ActionKeep, nil
+}
+
+// TransformTableRow returns the table row unchanged.
+func (BaseTransformVisitor) TransformTableRow(
+	n *NodeTableRow,
+) (Node, TransformAction, error) {
+	return n, ActionKeep, nil
+}
+
+// TransformTableCell returns the table cell unchanged.
+func (BaseTransformVisitor) TransformTableCell(
+	n *NodeTableCell,
+) (Node, TransformAction, error) {
+	return n, ActionKeep, nil
+}
+
 // Transform applies a TransformVisitor to an AST using post-order traversal.
 // Children are transformed before their parent, so parent transform methods
 // see the results of child transformations.
@@ -391,6 +431,14 @@ func applyTransform(
 		return v.TransformLinkDef(n)
 	case *NodeWikilink:
 		return v.TransformWikilink(n)
+	case *NodeHTMLComment:
+		return v.TransformHTMLComment(n)
+	case *NodeTable:
+		return v.TransformTable(n)
+	case *NodeTableRow:
+		return v.TransformTableRow(n)
+	case *NodeTableCell:
+		return v.TransformTableCell(n)
 	default:
 		// Unknown node type - keep as-is
 		return node, ActionKeep, nil
@@ -598,6 +646,46 @@ func (c *composedTransform) TransformWikilink(
 	)
 }
 
+func (c *composedTransform) TransformHTMLComment(
+	n *NodeHTMLComment,
+) (Node, TransformAction, error) {
+	return composeTransform(
+		n,
+		c.t1.TransformHTMLComment,
+		c.t2.TransformHTMLComment,
+	)
+}
+
+func (c *composedTransform) TransformTable(
+	n *NodeTable,
+) (Node, TransformAction, error) {
+	return composeTransform(
+		n,
+		c.t1.TransformTable,
+		c.t2.TransformTable,
+	)
+}
+
+func (c *composedTransform) TransformTableRow(
+	n *NodeTableRow,
+) (Node, TransformAction, error) {
+	return composeTransform(
+		n,
+		c.t1.TransformTableRow,
+		c.t2.TransformTableRow,
+	)
+}
+
+func (c *composedTransform) TransformTableCell(
+	n *NodeTableCell,
+) (Node, TransformAction, error) {
+	return composeTransform(
+		n,
+		c.t1.TransformTableCell,
+		c.t2.TransformTableCell,
+	)
+}
+
 // composeTransform applies two transforms in sequence.
 func composeTransform[T Node](
 	n T,
@@ -856,6 +944,46 @@ func (c *conditionalTransform) TransformWikilink(
 	return n, ActionKeep, nil
 }
 
+func (c *conditionalTransform) TransformHTMLComment(
+	n *NodeHTMLComment,
+) (Node, TransformAction, error) {
+	if c.pred(n) {
+		return c.transform.TransformHTMLComment(n)
+	}
+
+	return n, ActionKeep, nil
+}
+
+func (c *conditionalTransform) TransformTable(
+	n *NodeTable,
+) (Node, TransformAction, error) {
+	if c.pred(n) {
+		return c.transform.TransformTable(n)
+	}
+
+	return n, ActionKeep, nil
+}
+
+func (c *conditionalTransform) TransformTableRow(
+	n *NodeTableRow,
+) (Node, TransformAction, error) {
+	if c.pred(n) {
+		return c.transform.TransformTableRow(n)
+	}
+
+	return n, ActionKeep, nil
+}
+
+func (c *conditionalTransform) TransformTableCell(
+	n *NodeTableCell,
+) (Node, TransformAction, error) {
+	if c.pred(n) {
+		return c.transform.TransformTableCell(n)
+	}
+
+	return n, ActionKeep, nil
+}
+
 // Map creates a TransformVisitor that applies the given function to every node.
 // If f returns the same node (by pointer equality), it is treated as ActionKeep.
 // Otherwise, it is treated as ActionReplace with the returned node.
@@ -981,6 +1109,30 @@ func (m *mapTransform) TransformWikilink(
 	return m.applyMap(n)
 }
 
+func (m *mapTransform) TransformHTMLComment(
+	n *NodeHTMLComment,
+) (Node, TransformAction, error) {
+	return m.applyMap(n)
+}
+
+func (m *mapTransform) TransformTable(
+	n *NodeTable,
+) (Node, TransformAction, error) {
+	return m.applyMap(n)
+}
+
+func (m *mapTransform) TransformTableRow(
+	n *NodeTableRow,
+) (Node, TransformAction, error) {
+	return m.applyMap(n)
+}
+
+func (m *mapTransform) TransformTableCell(
+	n *NodeTableCell,
+) (Node, TransformAction, error) {
+	return m.applyMap(n)
+}
+
 // Filter creates a TransformVisitor that deletes nodes where the predicate
 // returns false. Nodes matching the predicate (returns true) are kept.
 func Filter(
@@ -1106,6 +1258,30 @@ func (f *filterTransform) TransformWikilink(
 	return f.applyFilter(n)
 }
 
+func (f *filterTransform) TransformHTMLComment(
+	n *NodeHTMLComment,
+) (Node, TransformAction, error) {
+	return f.applyFilter(n)
+}
+
+func (f *filterTransform) TransformTable(
+	n *NodeTable,
+) (Node, TransformAction, error) {
+	return f.applyFilter(n)
+}
+
+func (f *filterTransform) TransformTableRow(
+	n *NodeTableRow,
+) (Node, TransformAction, error) {
+	return f.applyFilter(n)
+}
+
+func (f *filterTransform) TransformTableCell(
+	n *NodeTableCell,
+) (Node, TransformAction, error) {
+	return f.applyFilter(n)
+}
+
 // RenameRequirement creates a TransformVisitor that renames requirements
 // matching oldName to newName. Only requirements with Name() == oldName
 // are affected; other nodes pass through unchanged.
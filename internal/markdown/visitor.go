@@ -35,6 +35,10 @@ type Visitor interface {
 	VisitLink(*NodeLink) error
 	VisitLinkDef(*NodeLinkDef) error
 	VisitWikilink(*NodeWikilink) error
+	VisitHTMLComment(*NodeHTMLComment) error
+	VisitTable(*NodeTable) error
+	VisitTableRow(*NodeTableRow) error
+	VisitTableCell(*NodeTableCell) error
 }
 
 // BaseVisitor provides no-op default implementations for all Visitor methods.
@@ -160,6 +164,34 @@ func (BaseVisitor) VisitWikilink(
 	return nil
 }
 
+// VisitHTMLComment is a no-op that returns nil (continue traversal).
+func (BaseVisitor) VisitHTMLComment(
+	*NodeHTMLComment,
+) error {
+	return nil
+}
+
+// VisitTable is a no-op that returns nil (continue traversal).
+func (BaseVisitor) VisitTable(
+	*NodeTable,
+) error {
+	return nil
+}
+
+// VisitTableRow is a no-op that returns nil (continue traversal).
+func (BaseVisitor) VisitTableRow(
+	*NodeTableRow,
+) error {
+	return nil
+}
+
+// VisitTableCell is a no-op that returns nil (continue traversal).
+func (BaseVisitor) VisitTableCell(
+	*NodeTableCell,
+) error {
+	return nil
+}
+
 // Walk traverses the AST in pre-order depth-first order, calling the appropriate
 // visitor method for each node. It handles the traversal logic including child
 // recursion and error handling.
@@ -215,6 +247,14 @@ func Walk(node Node, v Visitor) error {
 		err = v.VisitLinkDef(n)
 	case *NodeWikilink:
 		err = v.VisitWikilink(n)
+	case *NodeHTMLComment:
+		err = v.VisitHTMLComment(n)
+	case *NodeTable:
+		err = v.VisitTable(n)
+	case *NodeTableRow:
+		err = v.VisitTableRow(n)
+	case *NodeTableCell:
+		err = v.VisitTableCell(n)
 	default:
 		// Unknown node type - skip it
 		return nil
@@ -332,6 +372,22 @@ type ContextVisitor interface {
 		*NodeWikilink,
 		*VisitorContext,
 	) error
+	VisitHTMLCommentWithContext(
+		*NodeHTMLComment,
+		*VisitorContext,
+	) error
+	VisitTableWithContext(
+		*NodeTable,
+		*VisitorContext,
+	) error
+	VisitTableRowWithContext(
+		*NodeTableRow,
+		*VisitorContext,
+	) error
+	VisitTableCellWithContext(
+		*NodeTableCell,
+		*VisitorContext,
+	) error
 }
 
 // BaseContextVisitor provides no-op defaults for all ContextVisitor methods.
@@ -473,6 +529,38 @@ func (BaseContextVisitor) VisitWikilinkWithContext(
 	return nil
 }
 
+// VisitHTMLCommentWithContext is a no-op that returns nil.
+func (BaseContextVisitor) VisitHTMLCommentWithContext(
+	*NodeHTMLComment,
+	*VisitorContext,
+) error {
+	return nil
+}
+
+// VisitTableWithContext is a no-op that returns nil.
+func (BaseContextVisitor) VisitTableWithContext(
+	*NodeTable,
+	*VisitorContext,
+) error {
+	return nil
+}
+
+// VisitTableRowWithContext is a no-op that returns nil.
+func (BaseContextVisitor) VisitTableRowWithContext(
+	*NodeTableRow,
+	*VisitorContext,
+) error {
+	return nil
+}
+
+// VisitTableCellWithContext is a no-op that returns nil.
+func (BaseContextVisitor) VisitTableCellWithContext(
+	*NodeTableCell,
+	*VisitorContext,
+) error {
+	return nil
+}
+
 // WalkWithContext traverses the AST like Walk but provides context information
 // including parent node access to the visitor.
 func WalkWithContext(
@@ -540,6 +628,14 @@ func walkWithContextInternal(
 		err = v.VisitLinkDefWithContext(n, ctx)
 	case *NodeWikilink:
 		err = v.VisitWikilinkWithContext(n, ctx)
+	case *NodeHTMLComment:
+		err = v.VisitHTMLCommentWithContext(n, ctx)
+	case *NodeTable:
+		err = v.VisitTableWithContext(n, ctx)
+	case *NodeTableRow:
+		err = v.VisitTableRowWithContext(n, ctx)
+	case *NodeTableCell:
+		err = v.VisitTableCellWithContext(n, ctx)
 	default:
 		return nil
 	}
@@ -604,6 +700,14 @@ type EnterLeaveVisitor interface {
 	LeaveLinkDef(*NodeLinkDef) error
 	EnterWikilink(*NodeWikilink) error
 	LeaveWikilink(*NodeWikilink) error
+	EnterHTMLComment(*NodeHTMLComment) error
+	LeaveHTMLComment(*NodeHTMLComment) error
+	EnterTable(*NodeTable) error
+	LeaveTable(*NodeTable) error
+	EnterTableRow(*NodeTableRow) error
+	LeaveTableRow(*NodeTableRow) error
+	EnterTableCell(*NodeTableCell) error
+	LeaveTableCell(*NodeTableCell) error
 }
 
 // BaseEnterLeaveVisitor provides no-op default implementations for all
@@ -849,6 +953,62 @@ func (BaseEnterLeaveVisitor) LeaveWikilink(
 	return nil
 }
 
+// EnterHTMLComment is a no-op that returns nil.
+func (BaseEnterLeaveVisitor) EnterHTMLComment(
+	*NodeHTMLComment,
+) error {
+	return nil
+}
+
+// LeaveHTMLComment is a no-op that returns nil.
+func (BaseEnterLeaveVisitor) LeaveHTMLComment(
+	*NodeHTMLComment,
+) error {
+	return nil
+}
+
+// EnterTable is a no-op that returns nil.
+func (BaseEnterLeaveVisitor) EnterTable(
+	*NodeTable,
+) error {
+	return nil
+}
+
+// LeaveTable is a no-op that returns nil.
+func (BaseEnterLeaveVisitor) LeaveTable(
+	*NodeTable,
+) error {
+	return nil
+}
+
+// EnterTableRow is a no-op that returns nil.
+func (BaseEnterLeaveVisitor) EnterTableRow(
+	*NodeTableRow,
+) error {
+	return nil
+}
+
+// LeaveTableRow is a no-op that returns nil.
+func (BaseEnterLeaveVisitor) LeaveTableRow(
+	*NodeTableRow,
+) error {
+	return nil
+}
+
+// EnterTableCell is a no-op that returns nil.
+func (BaseEnterLeaveVisitor) EnterTableCell(
+	*NodeTableCell,
+) error {
+	return nil
+}
+
+// LeaveTableCell is a no-op that returns nil.
+func (BaseEnterLeaveVisitor) LeaveTableCell(
+	*NodeTableCell,
+) error {
+	return nil
+}
+
 // WalkEnterLeave traverses the AST calling Enter methods before visiting children
 // and Leave methods after visiting children.
 //
@@ -1199,6 +1359,72 @@ func WalkEnterLeave(
 
 		return v.LeaveWikilink(n)
 
+	case *NodeHTMLComment:
+		// HTML comments have no children
+		if err := v.EnterHTMLComment(n); err != nil &&
+			!errors.Is(err, SkipChildren) {
+			return err
+		}
+
+		return v.LeaveHTMLComment(n)
+
+	case *NodeTable:
+		err = v.EnterTable(n)
+		if err != nil {
+			if errors.Is(err, SkipChildren) {
+				skipChildren = true
+			} else {
+				return err
+			}
+		}
+		if !skipChildren {
+			for _, child := range node.Children() {
+				if err := WalkEnterLeave(child, v); err != nil {
+					return err
+				}
+			}
+		}
+
+		return v.LeaveTable(n)
+
+	case *NodeTableRow:
+		err = v.EnterTableRow(n)
+		if err != nil {
+			if errors.Is(err, SkipChildren) {
+				skipChildren = true
+			} else {
+				return err
+			}
+		}
+		if !skipChildren {
+			for _, child := range node.Children() {
+				if err := WalkEnterLeave(child, v); err != nil {
+					return err
+				}
+			}
+		}
+
+		return v.LeaveTableRow(n)
+
+	case *NodeTableCell:
+		err = v.EnterTableCell(n)
+		if err != nil {
+			if errors.Is(err, SkipChildren) {
+				skipChildren = true
+			} else {
+				return err
+			}
+		}
+		if !skipChildren {
+			for _, child := range node.Children() {
+				if err := WalkEnterLeave(child, v); err != nil {
+					return err
+				}
+			}
+		}
+
+		return v.LeaveTableCell(n)
+
 	default:
 		return nil
 	}
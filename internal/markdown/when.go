@@ -0,0 +1,79 @@
+package markdown
+
+import (
+	"strings"
+)
+
+// whenPrefix starts a feature-flag condition line.
+const whenPrefix = "when:"
+
+// MatchWhenLine checks if a line declares the feature flag a requirement
+// or scenario is gated on ("when: flag" or "when: !flag") and returns the
+// condition. Flag names are letters, digits, '_', '-' and '.', so prose
+// that happens to start with "When:" is not mistaken for a condition.
+//
+// Example:
+//
+//	cond, ok := MatchWhenLine("when: !legacy_login")
+//	// cond = "!legacy_login", ok = true
+func MatchWhenLine(line string) (string, bool) {
+	trimmed := strings.TrimSpace(line)
+	if len(trimmed) < len(whenPrefix) ||
+		!strings.EqualFold(trimmed[:len(whenPrefix)], whenPrefix) {
+		return "", false
+	}
+
+	cond := strings.Trim(strings.TrimSpace(trimmed[len(whenPrefix):]), "`")
+	flag := strings.TrimPrefix(cond, "!")
+	if flag == "" {
+		return "", false
+	}
+	for _, r := range flag {
+		if !isFlagRune(r) {
+			return "", false
+		}
+	}
+
+	return cond, true
+}
+
+// isFlagRune reports whether r may appear in a flag name.
+func isFlagRune(r rune) bool {
+	return r == '_' || r == '-' || r == '.' ||
+		(r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')
+}
+
+// FindWhenLine returns the condition declared under the requirement or
+// scenario header ending at offset, with the byte range of its line
+// including the newline. Only the lines before the next heading or code
+// fence are searched.
+func FindWhenLine(source []byte, offset int) (cond string, start, end int, ok bool) {
+	for pos := offset; pos < len(source); {
+		lineEnd := pos
+		for lineEnd < len(source) && source[lineEnd] != '\n' {
+			lineEnd++
+		}
+		next := min(lineEnd+1, len(source))
+
+		line := strings.TrimSpace(string(source[pos:lineEnd]))
+		if strings.HasPrefix(line, "#") ||
+			strings.HasPrefix(line, "```") ||
+			strings.HasPrefix(line, "~~~") {
+			return "", 0, 0, false
+		}
+		if cond, ok := MatchWhenLine(line); ok {
+			return cond, pos, next, true
+		}
+		pos = next
+	}
+
+	return "", 0, 0, false
+}
+
+// whenAfter returns the condition under the header ending at offset, or
+// "" if there is none.
+func whenAfter(source []byte, offset int) string {
+	cond, _, _, _ := FindWhenLine(source, offset)
+
+	return cond
+}
@@ -0,0 +1,72 @@
+package markdown
+
+import (
+	"testing"
+)
+
+func TestMatchWhenLine(t *testing.T) {
+	tests := []struct {
+		name     string
+		line     string
+		wantCond string
+		wantOk   bool
+	}{
+		{name: "flag", line: "when: new_checkout", wantCond: "new_checkout", wantOk: true},
+		{name: "negated", line: "when: !legacy-login", wantCond: "!legacy-login", wantOk: true},
+		{name: "capitalized with code span", line: "When: `beta.search`", wantCond: "beta.search", wantOk: true},
+		{name: "indented", line: "  when: beta", wantCond: "beta", wantOk: true},
+		{name: "prose", line: "When: the user signs in"},
+		{name: "step keyword", line: "- **WHEN** a user signs in"},
+		{name: "empty", line: "when:"},
+		{name: "bare negation", line: "when: !"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cond, ok := MatchWhenLine(tt.line)
+			if cond != tt.wantCond || ok != tt.wantOk {
+				t.Errorf("MatchWhenLine(%q) = %q, %v; want %q, %v",
+					tt.line, cond, ok, tt.wantCond, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestParse_When(t *testing.T) {
+	source := []byte(`## Requirements
+
+### Requirement: Checkout
+when: new_checkout
+The system SHALL check out in one step.
+
+#### Scenario: Express
+when: !legacy
+- **WHEN** a user pays
+- **THEN** the order is placed
+
+#### Scenario: Ungated
+- **WHEN** a user pays
+- **THEN** a receipt is sent
+
+### Requirement: Cart
+The system SHALL keep a cart.
+
+` + "```text\nwhen: not_a_condition\n```\n")
+
+	reqs := ExtractRequirements(source)
+	if len(reqs) != 2 {
+		t.Fatalf("ExtractRequirements() = %d requirements, want 2", len(reqs))
+	}
+	if reqs[0].When != "new_checkout" || reqs[0].Node.When() != "new_checkout" {
+		t.Errorf("Checkout When = %q", reqs[0].When)
+	}
+	if got := reqs[0].Scenarios[0].When; got != "!legacy" {
+		t.Errorf("Express When = %q, want !legacy", got)
+	}
+	if got := reqs[0].Scenarios[1].When; got != "" {
+		t.Errorf("Ungated When = %q, want empty", got)
+	}
+	if reqs[1].When != "" {
+		t.Errorf("Cart When = %q, want empty", reqs[1].When)
+	}
+}
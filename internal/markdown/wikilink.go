@@ -5,6 +5,8 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/fileio"
 )
 
 // WikilinkError represents an error found during wikilink validation.
@@ -37,6 +39,11 @@ func (e WikilinkError) Error() string {
 	return e.Message
 }
 
+// WikilinkResolver maps a wikilink target to the file it points at and
+// reports whether that file exists. A non-nil error means the target
+// cannot be resolved unambiguously.
+type WikilinkResolver func(target string) (path string, exists bool, err error)
+
 // ResolveWikilink resolves a wikilink target to a file path within the project.
 // It follows the Spectr resolution rules:
 //  1. First check spectr/specs/{target}/spec.md
@@ -47,6 +54,17 @@ func (e WikilinkError) Error() string {
 // The projectRoot should be the root directory containing the spectr/ folder.
 func ResolveWikilink(
 	target, projectRoot string,
+) (path string, exists bool) {
+	return ResolveWikilinkIn(
+		target,
+		filepath.Join(projectRoot, "spectr"),
+	)
+}
+
+// ResolveWikilinkIn is ResolveWikilink for a spectr/ directory given
+// directly, such as a vendored copy of another project's spectr/.
+func ResolveWikilinkIn(
+	target, spectrDir string,
 ) (path string, exists bool) {
 	if target == "" {
 		return "", false
@@ -69,8 +87,7 @@ func ResolveWikilink(
 			"changes/",
 		)
 		path = filepath.Join(
-			projectRoot,
-			"spectr",
+			spectrDir,
 			"changes",
 			changeName,
 			"proposal.md",
@@ -87,8 +104,7 @@ func ResolveWikilink(
 			"specs/",
 		)
 		path = filepath.Join(
-			projectRoot,
-			"spectr",
+			spectrDir,
 			"specs",
 			specName,
 			"spec.md",
@@ -102,8 +118,7 @@ func ResolveWikilink(
 
 	// Try spectr/specs/{target}/spec.md
 	specPath := filepath.Join(
-		projectRoot,
-		"spectr",
+		spectrDir,
 		"specs",
 		cleanTarget,
 		"spec.md",
@@ -114,8 +129,7 @@ func ResolveWikilink(
 
 	// Try spectr/changes/{target}/proposal.md
 	changePath := filepath.Join(
-		projectRoot,
-		"spectr",
+		spectrDir,
 		"changes",
 		cleanTarget,
 		"proposal.md",
@@ -160,7 +174,7 @@ func ResolveWikilinkWithAnchor(
 	}
 
 	// Read and parse the target file to find the anchor
-	content, err := os.ReadFile(path)
+	content, err := fileio.ReadFile(path)
 	if err != nil {
 		return path, false, err
 	}
@@ -370,14 +384,30 @@ func ValidateWikilinks(
 	root Node,
 	_ []byte,
 	projectRoot string,
+) []WikilinkError {
+	return ValidateWikilinksWith(
+		root,
+		func(target string) (string, bool, error) {
+			path, exists := ResolveWikilink(target, projectRoot)
+
+			return path, exists, nil
+		},
+	)
+}
+
+// ValidateWikilinksWith is ValidateWikilinks with a custom resolver, for
+// links that may point into other projects.
+func ValidateWikilinksWith(
+	root Node,
+	resolve WikilinkResolver,
 ) []WikilinkError {
 	if root == nil {
 		return nil
 	}
 
 	validator := &wikilinkValidator{
-		projectRoot: projectRoot,
-		errors:      make([]WikilinkError, 0),
+		resolve: resolve,
+		errors:  make([]WikilinkError, 0),
 	}
 
 	_ = Walk(root, validator)
@@ -388,8 +418,8 @@ func ValidateWikilinks(
 // wikilinkValidator is a visitor that validates wikilinks.
 type wikilinkValidator struct {
 	BaseVisitor
-	projectRoot string
-	errors      []WikilinkError
+	resolve WikilinkResolver
+	errors  []WikilinkError
 }
 
 func (v *wikilinkValidator) VisitWikilink(
@@ -401,10 +431,18 @@ func (v *wikilinkValidator) VisitWikilink(
 	start, _ := n.Span()
 
 	// Resolve the wikilink target
-	path, exists := ResolveWikilink(
-		target,
-		v.projectRoot,
-	)
+	path, exists, err := v.resolve(target)
+	if err != nil {
+		v.errors = append(v.errors, WikilinkError{
+			Target:  target,
+			Display: display,
+			Anchor:  anchor,
+			Offset:  start,
+			Message: err.Error(),
+		})
+
+		return nil
+	}
 
 	if !exists {
 		msg := "wikilink target not found: " + target + " (expected at " + path + ")" //nolint:revive // line-length-limit
@@ -421,11 +459,7 @@ func (v *wikilinkValidator) VisitWikilink(
 
 	// If there's an anchor, validate it
 	if anchor != "" {
-		_, anchorValid, err := ResolveWikilinkWithAnchor(
-			target,
-			anchor,
-			v.projectRoot,
-		)
+		content, err := fileio.ReadFile(path)
 		if err != nil {
 			v.errors = append(
 				v.errors,
@@ -441,7 +475,7 @@ func (v *wikilinkValidator) VisitWikilink(
 			return nil
 		}
 
-		if !anchorValid {
+		if !anchorExistsInContent(content, anchor) {
 			v.errors = append(
 				v.errors,
 				WikilinkError{
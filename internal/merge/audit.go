@@ -0,0 +1,15 @@
+package merge
+
+import "github.com/connerohnesorge/spectr/internal/audit"
+
+// MergeAuditLog merges spectr/audit.log.jsonl contents: the entries added
+// on theirs are rechained after those of ours (see audit.Merge). An
+// append-only log never conflicts; base is not needed.
+func MergeAuditLog(_, ours, theirs []byte) ([]byte, []Conflict, error) {
+	merged, err := audit.Merge(ours, theirs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return merged, nil, nil
+}
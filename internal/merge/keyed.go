@@ -0,0 +1,153 @@
+package merge
+
+import "fmt"
+
+// Conflict describes an item that was changed incompatibly on both sides
+// of a merge.
+type Conflict struct {
+	ID     string // Task ID, requirement name or section header
+	Reason string // Human-readable description of the conflict
+}
+
+func (c Conflict) String() string {
+	return fmt.Sprintf("%s: %s", c.ID, c.Reason)
+}
+
+// mergeItemFunc merges an item present on both sides. base is nil when
+// both sides added an item with the same key.
+type mergeItemFunc[T any] func(base *T, ours, theirs T) (T, []Conflict)
+
+// mergeKeyed three-way merges ordered lists of items identified by key.
+// The result follows the order of ours; items only present in theirs are
+// inserted after the item that precedes them in theirs. Items deleted on
+// one side and untouched on the other are dropped; items modified on one
+// side and deleted on the other are kept and reported as conflicts. equal
+// tells whether an item is unchanged from its base version.
+func mergeKeyed[T any](
+	base, ours, theirs []T,
+	key func(T) string,
+	equal func(a, b T) bool,
+	mergeItem mergeItemFunc[T],
+) ([]T, []Conflict) {
+	baseByKey := indexByKey(base, key)
+	oursByKey := indexByKey(ours, key)
+	theirsByKey := indexByKey(theirs, key)
+
+	var conflicts []Conflict
+	result := make([]T, 0, len(ours)+len(theirs))
+
+	for _, item := range ours {
+		k := key(item)
+		baseItem, inBase := baseByKey[k]
+		theirItem, inTheirs := theirsByKey[k]
+
+		switch {
+		case inTheirs:
+			var basePtr *T
+			if inBase {
+				basePtr = &baseItem
+			}
+			merged, itemConflicts := mergeItem(basePtr, item, theirItem)
+			result = append(result, merged)
+			conflicts = append(conflicts, itemConflicts...)
+		case !inBase:
+			// Added on our side
+			result = append(result, item)
+		case !equal(item, baseItem):
+			// Deleted on their side but modified on ours
+			result = append(result, item)
+			conflicts = append(conflicts, deleteConflict(k))
+		default:
+			// Deleted on their side, untouched on ours
+		}
+	}
+
+	for i, item := range theirs {
+		k := key(item)
+		if _, inOurs := oursByKey[k]; inOurs {
+			continue
+		}
+
+		baseItem, inBase := baseByKey[k]
+		if inBase {
+			if equal(item, baseItem) {
+				// Deleted on our side, untouched on theirs
+				continue
+			}
+			conflicts = append(conflicts, deleteConflict(k))
+		}
+
+		result = insertAfter(result, theirs[:i], item, key, theirsByKey)
+	}
+
+	return result, conflicts
+}
+
+// deleteConflict reports an item modified on one side and deleted on the
+// other.
+func deleteConflict(key string) Conflict {
+	return Conflict{
+		ID:     key,
+		Reason: "modified on one side and deleted on the other",
+	}
+}
+
+// indexByKey maps keys to items.
+func indexByKey[T any](items []T, key func(T) string) map[string]T {
+	byKey := make(map[string]T, len(items))
+	for _, item := range items {
+		byKey[key(item)] = item
+	}
+
+	return byKey
+}
+
+// insertAfter inserts item into result after the closest preceding item
+// (from predecessors, searched backwards) that is present in result, and
+// after any items following it that theirs does not have, so additions
+// from ours come before additions from theirs. Appends when no predecessor
+// is present.
+func insertAfter[T any](
+	result, predecessors []T,
+	item T,
+	key func(T) string,
+	theirsByKey map[string]T,
+) []T {
+	for i := len(predecessors) - 1; i >= 0; i-- {
+		predecessorKey := key(predecessors[i])
+		for j := range result {
+			if key(result[j]) != predecessorKey {
+				continue
+			}
+
+			for j+1 < len(result) {
+				if _, inTheirs := theirsByKey[key(result[j+1])]; inTheirs {
+					break
+				}
+				j++
+			}
+
+			var zero T
+			result = append(result, zero)
+			copy(result[j+2:], result[j+1:])
+			result[j+1] = item
+
+			return result
+		}
+	}
+
+	return append(result, item)
+}
+
+// mergeValue three-way merges a single value. Returns false if both sides
+// changed it differently.
+func mergeValue(base, ours, theirs string) (string, bool) {
+	switch {
+	case ours == theirs, theirs == base:
+		return ours, true
+	case ours == base:
+		return theirs, true
+	default:
+		return ours, false
+	}
+}
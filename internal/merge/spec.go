@@ -0,0 +1,221 @@
+package merge
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/markdown"
+	"github.com/connerohnesorge/spectr/internal/parsers"
+)
+
+// Segment key prefixes used to identify spec segments across versions.
+const (
+	preambleKey          = "preamble"
+	sectionKeyPrefix     = "section:"
+	requirementKeyPrefix = "requirement:"
+)
+
+// specSegment is a contiguous block of a spec file: the preamble before the
+// first header, a section (H1/H2) header with the text that follows it, or
+// a requirement block up to the next requirement or section.
+type specSegment struct {
+	Key  string // Identity of the segment across versions
+	Text string // Raw markdown, including the header line
+	// Gap is the whitespace and blank lines after Text. It is kept apart so
+	// appending a segment after this one does not count as changing it.
+	Gap string
+}
+
+// MergeSpec performs a three-way merge of spec.md contents at the
+// requirement level. Requirements and sections are matched by name, so
+// requirements added on different sides are both kept and an edit on one
+// side applies cleanly. A conflict is reported only when both sides change
+// the same requirement (or section) differently; that block is written
+// with git-style conflict markers.
+//
+// base may be empty when the file was added on both sides.
+func MergeSpec(
+	base, ours, theirs []byte,
+) ([]byte, []Conflict, error) {
+	oursSegments := splitSpec(ours)
+
+	merged, conflicts := mergeKeyed(
+		splitSpec(base),
+		oursSegments,
+		splitSpec(theirs),
+		func(segment specSegment) string { return segment.Key },
+		func(a, b specSegment) bool { return a.Text == b.Text },
+		mergeSegment,
+	)
+
+	return []byte(joinSegments(merged, oursSegments)), conflicts, nil
+}
+
+// mergeSegment merges a segment present on both sides, emitting conflict
+// markers when both sides changed it differently.
+func mergeSegment(
+	base *specSegment,
+	ours, theirs specSegment,
+) (specSegment, []Conflict) {
+	baseText, baseGap := "", ""
+	if base != nil {
+		baseText, baseGap = base.Text, base.Gap
+	}
+	gap, ok := mergeValue(baseGap, ours.Gap, theirs.Gap)
+	if !ok {
+		gap = ours.Gap
+	}
+
+	text, ok := mergeValue(baseText, ours.Text, theirs.Text)
+	if ok {
+		return specSegment{Key: ours.Key, Text: text, Gap: gap}, nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString("<<<<<<< ours\n")
+	sb.WriteString(withTrailingNewline(ours.Text))
+	sb.WriteString("=======\n")
+	sb.WriteString(withTrailingNewline(theirs.Text))
+	sb.WriteString(">>>>>>> theirs")
+
+	return specSegment{Key: ours.Key, Text: sb.String(), Gap: "\n\n"}, []Conflict{{
+		ID:     firstLine(ours.Text),
+		Reason: "changed on both sides",
+	}}
+}
+
+// splitSpec splits spec content into segments at requirement headers and
+// H1/H2 section headers found in the markdown AST, so headers inside code
+// blocks are not treated as boundaries.
+func splitSpec(content []byte) []specSegment {
+	if len(content) == 0 {
+		return nil
+	}
+
+	root, _ := markdown.Parse(content)
+	collector := &boundaryCollector{}
+	_ = markdown.Walk(root, collector)
+	sort.Ints(collector.starts)
+
+	var segments []specSegment
+	if len(collector.starts) == 0 || collector.starts[0] > 0 {
+		end := len(content)
+		if len(collector.starts) > 0 {
+			end = collector.starts[0]
+		}
+		segments = append(segments, newSegment(preambleKey, string(content[:end])))
+	}
+
+	seen := make(map[string]int)
+	for i, start := range collector.starts {
+		end := len(content)
+		if i+1 < len(collector.starts) {
+			end = collector.starts[i+1]
+		}
+		text := string(content[start:end])
+
+		key := segmentKey(text)
+		seen[key]++
+		if n := seen[key]; n > 1 {
+			// Disambiguate repeated headers by occurrence
+			key += "#" + strconv.Itoa(n)
+		}
+
+		segments = append(segments, newSegment(key, text))
+	}
+
+	return segments
+}
+
+// newSegment returns a segment with the trailing whitespace of text split
+// off into its Gap.
+func newSegment(key, text string) specSegment {
+	trimmed := strings.TrimRight(text, " \t\r\n")
+
+	return specSegment{Key: key, Text: trimmed, Gap: text[len(trimmed):]}
+}
+
+// segmentKey derives the identity of a segment from its header line.
+func segmentKey(text string) string {
+	header := firstLine(text)
+	if name, ok := markdown.MatchRequirementHeader(header); ok {
+		return requirementKeyPrefix + parsers.NormalizeRequirementName(name)
+	}
+
+	return sectionKeyPrefix + header
+}
+
+// boundaryCollector records the start offsets of requirement headers and
+// H1/H2 section headers.
+type boundaryCollector struct {
+	markdown.BaseVisitor
+	starts []int
+}
+
+// VisitSection records H1/H2 section headers.
+func (c *boundaryCollector) VisitSection(n *markdown.NodeSection) error {
+	if n.Level() <= 2 {
+		start, _ := n.Span()
+		c.starts = append(c.starts, start)
+	}
+
+	return nil
+}
+
+// VisitRequirement records requirement headers.
+func (c *boundaryCollector) VisitRequirement(
+	n *markdown.NodeRequirement,
+) error {
+	start, _ := n.Span()
+	c.starts = append(c.starts, start)
+
+	return nil
+}
+
+// joinSegments concatenates merged segments, each followed by its gap.
+// Segments that were not adjacent in ours (e.g. requirements added by
+// theirs) are separated by at least one blank line so headers never run
+// into the preceding text.
+func joinSegments(segments, oursSegments []specSegment) string {
+	nextInOurs := make(map[string]string, len(oursSegments))
+	for i := 0; i+1 < len(oursSegments); i++ {
+		nextInOurs[oursSegments[i].Key] = oursSegments[i+1].Key
+	}
+
+	var sb strings.Builder
+	for i, segment := range segments {
+		sb.WriteString(segment.Text)
+		if i+1 == len(segments) {
+			break
+		}
+		gap := segment.Gap
+		if nextInOurs[segment.Key] != segments[i+1].Key &&
+			strings.Count(gap, "\n") < 2 {
+			gap = "\n\n"
+		}
+		sb.WriteString(gap)
+	}
+
+	if sb.Len() == 0 {
+		return ""
+	}
+
+	return strings.TrimRight(sb.String(), "\n") + "\n"
+}
+
+// firstLine returns the first line of text, trimmed.
+func firstLine(text string) string {
+	line, _, _ := strings.Cut(text, "\n")
+
+	return strings.TrimSpace(line)
+}
+
+// withTrailingNewline ensures text ends with a newline.
+func withTrailingNewline(text string) string {
+	if strings.HasSuffix(text, "\n") {
+		return text
+	}
+
+	return text + "\n"
+}
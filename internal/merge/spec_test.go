@@ -0,0 +1,159 @@
+package merge
+
+import (
+	"strings"
+	"testing"
+)
+
+const baseSpec = `# Auth Specification
+
+## Purpose
+
+Authentication for users.
+
+## Requirements
+
+### Requirement: Login
+The system SHALL log users in.
+
+#### Scenario: Valid login
+- **WHEN** credentials are valid
+- **THEN** a session is created
+
+### Requirement: Logout
+The system SHALL log users out.
+
+#### Scenario: Logout
+- **WHEN** the user logs out
+- **THEN** the session ends
+`
+
+func TestMergeSpec(t *testing.T) {
+	addRequirement := func(spec, name string) string {
+		return spec + "\n### Requirement: " + name + "\nThe system SHALL " +
+			strings.ToLower(name) + ".\n\n#### Scenario: " + name +
+			"\n- **WHEN** x\n- **THEN** y\n"
+	}
+
+	tests := []struct {
+		name          string
+		ours          string
+		theirs        string
+		wantContains  []string
+		wantAbsent    []string
+		wantConflicts int
+	}{
+		{
+			name:   "requirements added on both sides",
+			ours:   addRequirement(baseSpec, "Reset"),
+			theirs: addRequirement(baseSpec, "Lockout"),
+			wantContains: []string{
+				"### Requirement: Reset",
+				"### Requirement: Lockout",
+				"### Requirement: Logout",
+			},
+		},
+		{
+			name:   "edits to different requirements",
+			ours:   strings.Replace(baseSpec, "log users in.", "log users in securely.", 1),
+			theirs: strings.Replace(baseSpec, "log users out.", "log users out everywhere.", 1),
+			wantContains: []string{
+				"log users in securely.",
+				"log users out everywhere.",
+			},
+		},
+		{
+			name: "requirement removed on one side",
+			ours: baseSpec,
+			theirs: strings.Replace(
+				baseSpec,
+				baseSpec[strings.Index(baseSpec, "### Requirement: Logout"):],
+				"",
+				1,
+			),
+			wantAbsent: []string{"### Requirement: Logout"},
+		},
+		{
+			name:          "same requirement diverges",
+			ours:          strings.Replace(baseSpec, "log users in.", "log users in via SSO.", 1),
+			theirs:        strings.Replace(baseSpec, "log users in.", "log users in via OTP.", 1),
+			wantContains:  []string{"<<<<<<< ours", "via SSO", "=======", "via OTP", ">>>>>>> theirs"},
+			wantConflicts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, conflicts, err := MergeSpec(
+				[]byte(baseSpec),
+				[]byte(tt.ours),
+				[]byte(tt.theirs),
+			)
+			if err != nil {
+				t.Fatalf("MergeSpec() error = %v", err)
+			}
+			if len(conflicts) != tt.wantConflicts {
+				t.Errorf("got %d conflicts, want %d: %v", len(conflicts), tt.wantConflicts, conflicts)
+			}
+			for _, want := range tt.wantContains {
+				if !strings.Contains(string(output), want) {
+					t.Errorf("output missing %q:\n%s", want, output)
+				}
+			}
+			for _, absent := range tt.wantAbsent {
+				if strings.Contains(string(output), absent) {
+					t.Errorf("output should not contain %q:\n%s", absent, output)
+				}
+			}
+		})
+	}
+}
+
+func TestMergeSpec_BothAddedKeepsBlankLines(t *testing.T) {
+	ours := baseSpec + "\n### Requirement: Reset\nThe system SHALL reset.\n"
+	theirs := baseSpec + "\n### Requirement: Lockout\nThe system SHALL lock.\n"
+
+	output, _, err := MergeSpec([]byte(baseSpec), []byte(ours), []byte(theirs))
+	if err != nil {
+		t.Fatalf("MergeSpec() error = %v", err)
+	}
+
+	want := baseSpec +
+		"\n### Requirement: Reset\nThe system SHALL reset.\n" +
+		"\n### Requirement: Lockout\nThe system SHALL lock.\n"
+	if string(output) != want {
+		t.Errorf("output =\n%s\nwant\n%s", output, want)
+	}
+}
+
+func TestMergeSpec_AppendAfterEditedRequirement(t *testing.T) {
+	base := "## Requirements\n\n### Requirement: Login\nThe system SHALL log users in.\n"
+	ours := base + "\n### Requirement: Logout\nThe system SHALL log users out.\n"
+	theirs := strings.Replace(base, "log users in.", "log users in securely.", 1)
+
+	output, conflicts, err := MergeSpec([]byte(base), []byte(ours), []byte(theirs))
+	if err != nil {
+		t.Fatalf("MergeSpec() error = %v", err)
+	}
+	if len(conflicts) != 0 {
+		t.Errorf("got conflicts %v, want none", conflicts)
+	}
+
+	want := "## Requirements\n\n### Requirement: Login\nThe system SHALL log users in securely.\n" +
+		"\n### Requirement: Logout\nThe system SHALL log users out.\n"
+	if string(output) != want {
+		t.Errorf("output =\n%s\nwant\n%s", output, want)
+	}
+}
+
+func TestMergeSpec_IgnoresHeadersInCodeBlocks(t *testing.T) {
+	spec := "## Requirements\n\n### Requirement: A\nText\n\n```markdown\n### Requirement: Fake\n```\n"
+
+	segments := splitSpec([]byte(spec))
+	if len(segments) != 2 {
+		t.Fatalf("got %d segments, want 2: %+v", len(segments), segments)
+	}
+	if segments[1].Key != "requirement:a" {
+		t.Errorf("segment key = %q, want requirement:a", segments[1].Key)
+	}
+}
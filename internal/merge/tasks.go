@@ -0,0 +1,188 @@
+// Package merge implements structural three-way merges of spectr files,
+// used as git merge drivers so concurrent edits do not produce textual
+// conflicts.
+package merge
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/connerohnesorge/spectr/internal/parsers"
+	"github.com/connerohnesorge/spectr/internal/utils"
+)
+
+// statusRank orders task statuses by progress. When both sides change the
+// status of a task, the more progressed status wins.
+var statusRank = map[parsers.TaskStatusValue]int{
+	parsers.TaskStatusPending:    0,
+	parsers.TaskStatusInProgress: 1,
+	parsers.TaskStatusCompleted:  2,
+}
+
+// MergeTasks performs a three-way merge of tasks.jsonc contents by task ID.
+// Tasks added on either side are kept, tasks deleted on one side and
+// untouched on the other are dropped, and status changes never conflict:
+// the more progressed status wins. Diverging edits to other task fields are
+// reported as conflicts. The comment header of ours is preserved, and a
+// version 2 summary is recomputed from the merged tasks.
+//
+// base may be empty when the file was added on both sides.
+func MergeTasks(
+	base, ours, theirs []byte,
+) ([]byte, []Conflict, error) {
+	baseFile, err := parseTasks(base)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse base: %w", err)
+	}
+	oursFile, err := parseTasks(ours)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse ours: %w", err)
+	}
+	theirsFile, err := parseTasks(theirs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse theirs: %w", err)
+	}
+
+	merged := *oursFile
+	var conflicts []Conflict
+	merged.Tasks, conflicts = mergeTaskLists(
+		baseFile.Tasks,
+		oursFile.Tasks,
+		theirsFile.Tasks,
+	)
+	if merged.Summary != nil {
+		merged.Summary = summarizeTasks(merged.Tasks)
+	}
+
+	jsonData, err := json.MarshalIndent(&merged, "", "  ")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal merged tasks: %w", err)
+	}
+
+	output := utils.LeadingJSONCComments(ours) + string(jsonData) + "\n"
+
+	return []byte(output), conflicts, nil
+}
+
+// summarizeTasks counts tasks by status.
+func summarizeTasks(tasks []parsers.Task) *parsers.TaskSummary {
+	summary := &parsers.TaskSummary{Total: len(tasks)}
+	for _, task := range tasks {
+		switch task.Status {
+		case parsers.TaskStatusCompleted:
+			summary.Completed++
+		case parsers.TaskStatusInProgress:
+			summary.InProgress++
+		case parsers.TaskStatusPending:
+			summary.Pending++
+		}
+	}
+
+	return summary
+}
+
+// parseTasks parses JSONC task file content. Empty content yields an empty
+// tasks file.
+func parseTasks(data []byte) (*parsers.TasksFile, error) {
+	var tasksFile parsers.TasksFile
+
+	stripped := utils.StripJSONCComments(data)
+	if len(stripped) == 0 {
+		return &tasksFile, nil
+	}
+	if err := json.Unmarshal(stripped, &tasksFile); err != nil {
+		return nil, err
+	}
+	if err := tasksFile.CheckVersion("tasks.jsonc"); err != nil {
+		return nil, err
+	}
+
+	return &tasksFile, nil
+}
+
+// mergeTaskLists merges task lists by ID.
+func mergeTaskLists(
+	base, ours, theirs []parsers.Task,
+) ([]parsers.Task, []Conflict) {
+	return mergeKeyed(
+		base,
+		ours,
+		theirs,
+		func(task parsers.Task) string { return task.ID },
+		func(a, b parsers.Task) bool { return reflect.DeepEqual(a, b) },
+		mergeTask,
+	)
+}
+
+// mergeTask merges a task present on both sides. base is nil when both
+// sides added a task with the same ID.
+func mergeTask(
+	base *parsers.Task,
+	ours, theirs parsers.Task,
+) (parsers.Task, []Conflict) {
+	var baseTask parsers.Task
+	if base != nil {
+		baseTask = *base
+	}
+
+	var conflicts []Conflict
+	merged := ours
+
+	fields := []struct {
+		name               string
+		base, ours, theirs string
+		target             *string
+	}{
+		{"section", baseTask.Section, ours.Section, theirs.Section, &merged.Section},
+		{"description", baseTask.Description, ours.Description, theirs.Description, &merged.Description},
+		{"children", baseTask.Children, ours.Children, theirs.Children, &merged.Children},
+		{"estimate", baseTask.Estimate, ours.Estimate, theirs.Estimate, &merged.Estimate},
+		{"assignee", baseTask.Assignee, ours.Assignee, theirs.Assignee, &merged.Assignee},
+	}
+	for _, field := range fields {
+		value, ok := mergeValue(field.base, field.ours, field.theirs)
+		if !ok {
+			conflicts = append(conflicts, Conflict{
+				ID:     ours.ID,
+				Reason: field.name + " changed on both sides",
+			})
+
+			continue
+		}
+		*field.target = value
+	}
+
+	switch {
+	case slices.Equal(ours.Covers, theirs.Covers), slices.Equal(theirs.Covers, baseTask.Covers):
+	case slices.Equal(ours.Covers, baseTask.Covers):
+		merged.Covers = theirs.Covers
+	default:
+		conflicts = append(conflicts, Conflict{
+			ID:     ours.ID,
+			Reason: "covers changed on both sides",
+		})
+	}
+
+	merged.Status = mergeStatus(baseTask.Status, ours.Status, theirs.Status)
+
+	return merged, conflicts
+}
+
+// mergeStatus three-way merges a task status, preferring the more
+// progressed status when both sides changed it.
+func mergeStatus(
+	base, ours, theirs parsers.TaskStatusValue,
+) parsers.TaskStatusValue {
+	switch {
+	case ours == theirs, theirs == base:
+		return ours
+	case ours == base:
+		return theirs
+	case statusRank[theirs] > statusRank[ours]:
+		return theirs
+	default:
+		return ours
+	}
+}
@@ -0,0 +1,172 @@
+package merge
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/connerohnesorge/spectr/internal/parsers"
+)
+
+const baseTasks = `// Spectr Tasks File (JSONC)
+
+{
+  "version": 1,
+  "tasks": [
+    {"id": "1.1", "section": "Impl", "description": "First", "status": "pending"},
+    {"id": "1.2", "section": "Impl", "description": "Second", "status": "pending"},
+    {"id": "1.3", "section": "Impl", "description": "Third", "status": "pending"}
+  ]
+}`
+
+func TestMergeTasks(t *testing.T) {
+	tests := []struct {
+		name          string
+		ours          string
+		theirs        string
+		wantStatuses  map[string]parsers.TaskStatusValue
+		wantIDs       []string
+		wantConflicts int
+	}{
+		{
+			name:   "different tasks completed on each side",
+			ours:   strings.Replace(baseTasks, `"First", "status": "pending"`, `"First", "status": "completed"`, 1),
+			theirs: strings.Replace(baseTasks, `"Second", "status": "pending"`, `"Second", "status": "completed"`, 1),
+			wantStatuses: map[string]parsers.TaskStatusValue{
+				"1.1": parsers.TaskStatusCompleted,
+				"1.2": parsers.TaskStatusCompleted,
+				"1.3": parsers.TaskStatusPending,
+			},
+			wantIDs: []string{"1.1", "1.2", "1.3"},
+		},
+		{
+			name:   "same task progressed differently keeps furthest status",
+			ours:   strings.Replace(baseTasks, `"First", "status": "pending"`, `"First", "status": "in_progress"`, 1),
+			theirs: strings.Replace(baseTasks, `"First", "status": "pending"`, `"First", "status": "completed"`, 1),
+			wantStatuses: map[string]parsers.TaskStatusValue{
+				"1.1": parsers.TaskStatusCompleted,
+			},
+			wantIDs: []string{"1.1", "1.2", "1.3"},
+		},
+		{
+			name: "task added on their side keeps position",
+			ours: baseTasks,
+			theirs: strings.Replace(
+				baseTasks,
+				`"Second", "status": "pending"},`,
+				`"Second", "status": "pending"},
+    {"id": "1.4", "section": "Impl", "description": "New", "status": "pending"},`,
+				1,
+			),
+			wantIDs: []string{"1.1", "1.2", "1.4", "1.3"},
+		},
+		{
+			name: "task deleted on our side",
+			ours: strings.Replace(
+				baseTasks,
+				`,
+    {"id": "1.3", "section": "Impl", "description": "Third", "status": "pending"}`,
+				"",
+				1,
+			),
+			theirs:  baseTasks,
+			wantIDs: []string{"1.1", "1.2"},
+		},
+		{
+			name:          "description edited on both sides conflicts",
+			ours:          strings.Replace(baseTasks, `"First"`, `"First (ours)"`, 1),
+			theirs:        strings.Replace(baseTasks, `"First"`, `"First (theirs)"`, 1),
+			wantIDs:       []string{"1.1", "1.2", "1.3"},
+			wantConflicts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, conflicts, err := MergeTasks(
+				[]byte(baseTasks),
+				[]byte(tt.ours),
+				[]byte(tt.theirs),
+			)
+			if err != nil {
+				t.Fatalf("MergeTasks() error = %v", err)
+			}
+			if len(conflicts) != tt.wantConflicts {
+				t.Errorf("got %d conflicts, want %d: %v", len(conflicts), tt.wantConflicts, conflicts)
+			}
+			if !strings.HasPrefix(string(output), "// Spectr Tasks File (JSONC)\n") {
+				t.Errorf("header not preserved:\n%s", output)
+			}
+
+			merged, err := parseTasks(output)
+			if err != nil {
+				t.Fatalf("failed to parse merged output: %v", err)
+			}
+
+			ids := make([]string, 0, len(merged.Tasks))
+			for _, task := range merged.Tasks {
+				ids = append(ids, task.ID)
+				if want, ok := tt.wantStatuses[task.ID]; ok && task.Status != want {
+					t.Errorf("task %s status = %s, want %s", task.ID, task.Status, want)
+				}
+			}
+			if strings.Join(ids, ",") != strings.Join(tt.wantIDs, ",") {
+				t.Errorf("task IDs = %v, want %v", ids, tt.wantIDs)
+			}
+		})
+	}
+}
+
+func TestMergeTasks_EmptyBase(t *testing.T) {
+	ours := `{"version": 1, "tasks": [{"id": "1.1", "section": "A", "description": "x", "status": "pending"}]}`
+	theirs := `{"version": 1, "tasks": [{"id": "2.1", "section": "B", "description": "y", "status": "pending"}]}`
+
+	output, conflicts, err := MergeTasks(nil, []byte(ours), []byte(theirs))
+	if err != nil {
+		t.Fatalf("MergeTasks() error = %v", err)
+	}
+	if len(conflicts) != 0 {
+		t.Errorf("unexpected conflicts: %v", conflicts)
+	}
+
+	merged, err := parseTasks(output)
+	if err != nil {
+		t.Fatalf("failed to parse merged output: %v", err)
+	}
+	if len(merged.Tasks) != 2 {
+		t.Errorf("got %d tasks, want 2", len(merged.Tasks))
+	}
+}
+
+func TestMergeTasks_RecomputesSummary(t *testing.T) {
+	base := `{
+  "version": 2,
+  "tasks": [
+    {"id": "1", "section": "A", "description": "x", "status": "pending"},
+    {"id": "2", "section": "A", "description": "y", "status": "pending"}
+  ],
+  "summary": {"total": 2, "completed": 0, "in_progress": 0, "pending": 2}
+}`
+	ours := strings.Replace(base, `"x", "status": "pending"`, `"x", "status": "completed"`, 1)
+	ours = strings.Replace(ours, `"completed": 0, "in_progress": 0, "pending": 2`,
+		`"completed": 1, "in_progress": 0, "pending": 1`, 1)
+	theirs := strings.Replace(base, `"y", "status": "pending"`, `"y", "status": "completed"`, 1)
+	theirs = strings.Replace(theirs, `"completed": 0, "in_progress": 0, "pending": 2`,
+		`"completed": 1, "in_progress": 0, "pending": 1`, 1)
+
+	output, conflicts, err := MergeTasks([]byte(base), []byte(ours), []byte(theirs))
+	if err != nil {
+		t.Fatalf("MergeTasks() error = %v", err)
+	}
+	if len(conflicts) != 0 {
+		t.Errorf("unexpected conflicts: %v", conflicts)
+	}
+
+	merged, err := parseTasks(output)
+	if err != nil {
+		t.Fatalf("failed to parse merged output: %v", err)
+	}
+	want := parsers.TaskSummary{Total: 2, Completed: 2}
+	if merged.Summary == nil || *merged.Summary != want {
+		t.Errorf("summary = %+v, want %+v", merged.Summary, want)
+	}
+}
@@ -0,0 +1,8 @@
+// Package migrate upgrades spectr files written in older formats to the
+// current one. Each migration validates its input against the schema of
+// the version it declares, refuses versions newer than this build knows,
+// and validates its output before writing anything.
+//
+// Migrations:
+//   - tasks.go: tasks.jsonc version 1 (flat) to version 2 (hierarchical)
+package migrate
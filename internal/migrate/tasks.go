@@ -0,0 +1,318 @@
+package migrate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/parsers"
+	"github.com/connerohnesorge/spectr/internal/taskschema"
+)
+
+const (
+	tasksFileName = "tasks.jsonc"
+	filePerm      = 0o644
+
+	// splitThreshold matches spectr accept: files with more tasks than
+	// this, spread over several sections, are split into child files.
+	splitThreshold = 20
+)
+
+// TasksOptions controls a tasks.jsonc migration.
+type TasksOptions struct {
+	// DryRun reports what would be written without touching any file.
+	DryRun bool
+	// Split moves every numbered section into a child file, even when the
+	// file is below the size at which spectr accept would split it.
+	Split bool
+}
+
+// TasksResult describes the migration of one change.
+type TasksResult struct {
+	ChangeID string
+	// From and To are the versions before and after migration. They are
+	// equal when the file was already current.
+	From int
+	To   int
+	// Files lists the files written (or that would be written on a dry
+	// run), relative to the change directory.
+	Files []string
+	// Missing is set when the change has no tasks.jsonc.
+	Missing bool
+}
+
+// UpToDate reports whether the change needed no migration.
+func (r *TasksResult) UpToDate() bool {
+	return r.Missing || r.From == r.To
+}
+
+// Tasks migrates the tasks.jsonc of the change in changeDir to the latest
+// version. Version 2 files are validated, including their child files,
+// and left untouched.
+func Tasks(changeDir string, opts TasksOptions) (*TasksResult, error) {
+	result := &TasksResult{ChangeID: filepath.Base(changeDir)}
+	rootPath := filepath.Join(changeDir, tasksFileName)
+
+	data, err := os.ReadFile(rootPath)
+	if errors.Is(err, os.ErrNotExist) {
+		result.Missing = true
+
+		return result, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", rootPath, err)
+	}
+
+	version, err := taskschema.Validate(rootPath, data)
+	if err != nil {
+		return nil, err
+	}
+	result.From = version
+	result.To = version
+
+	if version == parsers.LatestTasksVersion {
+		return result, validateChildren(changeDir, data)
+	}
+
+	var file parsers.TasksFile
+	if err := json.Unmarshal(parsers.StripJSONComments(data), &file); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", rootPath, err)
+	}
+
+	files, err := upgradeToV2(result.ChangeID, file.Tasks, opts.Split)
+	if err != nil {
+		return nil, err
+	}
+
+	result.To = parsers.TasksVersionHierarchical
+	for _, f := range files {
+		result.Files = append(result.Files, f.name)
+	}
+	if opts.DryRun {
+		return result, nil
+	}
+
+	return result, writeFiles(changeDir, files)
+}
+
+// validateChildren validates the child files referenced by a v2 root.
+func validateChildren(changeDir string, rootData []byte) error {
+	var root parsers.TasksFile
+	if err := json.Unmarshal(parsers.StripJSONComments(rootData), &root); err != nil {
+		return err
+	}
+
+	for _, task := range root.Tasks {
+		if task.Children == "" {
+			continue
+		}
+
+		childPath := filepath.Join(
+			changeDir,
+			strings.TrimPrefix(task.Children, "$ref:"),
+		)
+		data, err := os.ReadFile(childPath)
+		if err != nil {
+			return fmt.Errorf("task %s children: %w", task.ID, err)
+		}
+		if err := taskschema.ValidateVersion(
+			childPath,
+			data,
+			parsers.TasksVersionHierarchical,
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// outputFile is a file produced by a migration.
+type outputFile struct {
+	name   string
+	header string
+	file   parsers.TasksFile
+}
+
+// upgradeToV2 converts v1 tasks to the v2 layout spectr accept produces:
+// a root tasks.jsonc holding section summary tasks that reference child
+// tasks-N.jsonc files. Small files keep their flat task list under
+// version 2 unless split is set.
+func upgradeToV2(
+	changeID string,
+	tasks []parsers.Task,
+	split bool,
+) ([]outputFile, error) {
+	sections := groupBySection(tasks)
+
+	numbered := 0
+	for _, section := range sections {
+		if section.num != "0" {
+			numbered++
+		}
+	}
+	if !split {
+		split = len(tasks) > splitThreshold && numbered > 1
+	}
+
+	if !split || numbered == 0 {
+		return checked([]outputFile{{
+			name:   tasksFileName,
+			header: parsers.TasksJSONHeader,
+			file: parsers.TasksFile{
+				Version: parsers.TasksVersionHierarchical,
+				Tasks:   tasks,
+			},
+		}})
+	}
+
+	var (
+		files     []outputFile
+		rootTasks []parsers.Task
+	)
+	for _, section := range sections {
+		// Tasks without a section number stay in the root, as in accept
+		if section.num == "0" {
+			rootTasks = append(rootTasks, section.tasks...)
+
+			continue
+		}
+
+		childName := fmt.Sprintf("tasks-%s.jsonc", section.num)
+		rootTasks = append(rootTasks, parsers.Task{
+			ID:          section.num,
+			Section:     section.name,
+			Description: section.name + " tasks",
+			Status:      aggregateStatus(section.tasks),
+			Children:    "$ref:" + childName,
+		})
+		files = append(files, outputFile{
+			name:   childName,
+			header: parsers.ChildTasksHeader(changeID, section.num),
+			file: parsers.TasksFile{
+				Version: parsers.TasksVersionHierarchical,
+				Parent:  section.num,
+				Tasks:   section.tasks,
+			},
+		})
+	}
+
+	files = append(files, outputFile{
+		name:   tasksFileName,
+		header: parsers.TasksJSONHeader,
+		file: parsers.TasksFile{
+			Version:  parsers.TasksVersionHierarchical,
+			Tasks:    rootTasks,
+			Includes: []string{"tasks-*.jsonc"},
+		},
+	})
+
+	return checked(files)
+}
+
+// checked validates generated files against the v2 schema so a bug in
+// the migration can never write an invalid file.
+func checked(files []outputFile) ([]outputFile, error) {
+	for _, f := range files {
+		data, err := f.render()
+		if err != nil {
+			return nil, err
+		}
+		if err := taskschema.ValidateVersion(
+			f.name,
+			data,
+			parsers.TasksVersionHierarchical,
+		); err != nil {
+			return nil, fmt.Errorf("migration produced an invalid file: %w", err)
+		}
+	}
+
+	return files, nil
+}
+
+func (f *outputFile) render() ([]byte, error) {
+	data, err := json.MarshalIndent(&f.file, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal %s: %w", f.name, err)
+	}
+
+	return append([]byte(f.header), data...), nil
+}
+
+// writeFiles writes child files first and the root last, each through a
+// temporary file, so an interrupted migration leaves the v1 root intact.
+func writeFiles(changeDir string, files []outputFile) error {
+	for _, f := range files {
+		data, err := f.render()
+		if err != nil {
+			return err
+		}
+
+		path := filepath.Join(changeDir, f.name)
+		tmp := path + ".tmp"
+		if err := os.WriteFile(tmp, data, filePerm); err != nil {
+			return fmt.Errorf("write %s: %w", f.name, err)
+		}
+		if err := os.Rename(tmp, path); err != nil {
+			_ = os.Remove(tmp)
+
+			return fmt.Errorf("write %s: %w", f.name, err)
+		}
+	}
+
+	return nil
+}
+
+// section groups tasks sharing the first component of their ID.
+type section struct {
+	num   string
+	name  string
+	tasks []parsers.Task
+}
+
+// groupBySection groups tasks by section number in first-seen order.
+// Tasks whose ID has no dot (e.g. "5") belong to section "0".
+func groupBySection(tasks []parsers.Task) []section {
+	var sections []section
+	index := make(map[string]int)
+
+	for _, task := range tasks {
+		num := "0"
+		if before, _, found := strings.Cut(task.ID, "."); found {
+			num = before
+		}
+
+		i, ok := index[num]
+		if !ok {
+			i = len(sections)
+			index[num] = i
+			sections = append(sections, section{num: num, name: task.Section})
+		}
+		sections[i].tasks = append(sections[i].tasks, task)
+	}
+
+	return sections
+}
+
+// aggregateStatus is completed when every task is, in_progress when any
+// task is in progress, and pending otherwise.
+func aggregateStatus(tasks []parsers.Task) parsers.TaskStatusValue {
+	allCompleted := true
+	for _, task := range tasks {
+		if task.Status == parsers.TaskStatusInProgress {
+			return parsers.TaskStatusInProgress
+		}
+		if task.Status != parsers.TaskStatusCompleted {
+			allCompleted = false
+		}
+	}
+
+	if allCompleted {
+		return parsers.TaskStatusCompleted
+	}
+
+	return parsers.TaskStatusPending
+}
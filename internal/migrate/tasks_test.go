@@ -0,0 +1,254 @@
+package migrate
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/connerohnesorge/spectr/internal/parsers"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+	"github.com/connerohnesorge/spectr/internal/taskschema"
+)
+
+// v1Tasks builds a v1 tasks.jsonc with the given number of sections and
+// tasks per section. The first task of every section is completed.
+func v1Tasks(sections, perSection int) string {
+	var tasks []string
+	for s := 1; s <= sections; s++ {
+		for n := 1; n <= perSection; n++ {
+			status := "pending"
+			if n == 1 {
+				status = "completed"
+			}
+			tasks = append(tasks, fmt.Sprintf(
+				`{"id": "%d.%d", "section": "Section %d", "description": "Task %d.%d", "status": "%s"}`,
+				s, n, s, s, n, status,
+			))
+		}
+	}
+
+	return "// v1 file\n{\"version\": 1, \"tasks\": [" + strings.Join(tasks, ",\n") + "]}"
+}
+
+func writeChange(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := filepath.Join(t.TempDir(), "add-feature")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return dir
+}
+
+func readTasks(t *testing.T, path string) *parsers.TasksFile {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := taskschema.ValidateVersion(path, data, 2); err != nil {
+		t.Fatalf("written file is not valid v2: %v", err)
+	}
+
+	file, err := parsers.ReadTasksJson(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return file
+}
+
+func TestTasks_SmallV1StaysFlat(t *testing.T) {
+	dir := writeChange(t, map[string]string{"tasks.jsonc": v1Tasks(2, 3)})
+
+	result, err := Tasks(dir, TasksOptions{})
+	if err != nil {
+		t.Fatalf("Tasks: %v", err)
+	}
+	if result.From != 1 || result.To != 2 || result.UpToDate() {
+		t.Errorf("result = %+v, want v1 -> v2", result)
+	}
+	if strings.Join(result.Files, ",") != "tasks.jsonc" {
+		t.Errorf("files = %v, want [tasks.jsonc]", result.Files)
+	}
+
+	file := readTasks(t, filepath.Join(dir, "tasks.jsonc"))
+	if file.Version != 2 || len(file.Tasks) != 6 || len(file.Includes) != 0 {
+		t.Errorf("migrated file = %+v", file)
+	}
+}
+
+func TestTasks_LargeV1Splits(t *testing.T) {
+	dir := writeChange(t, map[string]string{"tasks.jsonc": v1Tasks(3, 8)})
+
+	before, err := parsers.CountTasks(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := Tasks(dir, TasksOptions{})
+	if err != nil {
+		t.Fatalf("Tasks: %v", err)
+	}
+	want := "tasks-1.jsonc,tasks-2.jsonc,tasks-3.jsonc,tasks.jsonc"
+	if strings.Join(result.Files, ",") != want {
+		t.Errorf("files = %v, want %s", result.Files, want)
+	}
+
+	root := readTasks(t, filepath.Join(dir, "tasks.jsonc"))
+	if len(root.Tasks) != 3 || root.Tasks[0].Children != "$ref:tasks-1.jsonc" {
+		t.Errorf("root tasks = %+v", root.Tasks)
+	}
+	if root.Tasks[0].Status != parsers.TaskStatusPending {
+		t.Errorf("section status = %s, want pending", root.Tasks[0].Status)
+	}
+
+	child := readTasks(t, filepath.Join(dir, "tasks-2.jsonc"))
+	if child.Parent != "2" || len(child.Tasks) != 8 || child.Tasks[0].ID != "2.1" {
+		t.Errorf("child = %+v", child)
+	}
+
+	// The child files hold every task of the v1 file
+	var after parsers.TaskStatus
+	for _, section := range root.Tasks {
+		ref := strings.TrimPrefix(section.Children, "$ref:")
+		for _, task := range readTasks(t, filepath.Join(dir, ref)).Tasks {
+			after.Total++
+			switch task.Status {
+			case parsers.TaskStatusCompleted:
+				after.Completed++
+			case parsers.TaskStatusInProgress:
+				after.InProgress++
+			case parsers.TaskStatusPending:
+			}
+		}
+	}
+	if after != before {
+		t.Errorf("task counts changed: before %+v, after %+v", before, after)
+	}
+
+	// A second run finds the v2 layout and leaves it alone
+	again, err := Tasks(dir, TasksOptions{})
+	if err != nil {
+		t.Fatalf("second Tasks: %v", err)
+	}
+	if !again.UpToDate() || len(again.Files) != 0 {
+		t.Errorf("second run = %+v, want up to date", again)
+	}
+}
+
+func TestTasks_ForcedSplit(t *testing.T) {
+	dir := writeChange(t, map[string]string{"tasks.jsonc": v1Tasks(2, 2)})
+
+	result, err := Tasks(dir, TasksOptions{Split: true})
+	if err != nil {
+		t.Fatalf("Tasks: %v", err)
+	}
+	if len(result.Files) != 3 {
+		t.Errorf("files = %v, want two children and the root", result.Files)
+	}
+}
+
+func TestTasks_DryRun(t *testing.T) {
+	content := v1Tasks(3, 8)
+	dir := writeChange(t, map[string]string{"tasks.jsonc": content})
+
+	result, err := Tasks(dir, TasksOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("Tasks: %v", err)
+	}
+	if len(result.Files) != 4 {
+		t.Errorf("files = %v, want 4", result.Files)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "tasks.jsonc"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != content {
+		t.Error("dry run modified tasks.jsonc")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "tasks-1.jsonc")); !os.IsNotExist(err) {
+		t.Error("dry run wrote a child file")
+	}
+}
+
+func TestTasks_Missing(t *testing.T) {
+	result, err := Tasks(writeChange(t, nil), TasksOptions{})
+	if err != nil {
+		t.Fatalf("Tasks: %v", err)
+	}
+	if !result.Missing || !result.UpToDate() {
+		t.Errorf("result = %+v, want missing", result)
+	}
+}
+
+func TestTasks_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		check func(error) bool
+	}{
+		{
+			name:  "future version",
+			files: map[string]string{"tasks.jsonc": `{"version": 3, "tasks": []}`},
+			check: func(err error) bool {
+				var target *specterrs.UnsupportedTasksVersionError
+
+				return errors.As(err, &target)
+			},
+		},
+		{
+			name:  "invalid v1",
+			files: map[string]string{"tasks.jsonc": `{"version": 1, "tasks": [{"id": "1.1"}]}`},
+			check: func(err error) bool {
+				var target *specterrs.TasksSchemaError
+
+				return errors.As(err, &target)
+			},
+		},
+		{
+			name: "invalid v2 child",
+			files: map[string]string{
+				"tasks.jsonc": `{"version": 2, "tasks": [
+					{"id": "1", "section": "A", "description": "A tasks", "status": "pending", "children": "$ref:tasks-1.jsonc"}
+				]}`,
+				"tasks-1.jsonc": `{"version": 2, "parent": "1", "tasks": [{"id": "1.1", "status": "started"}]}`,
+			},
+			check: func(err error) bool {
+				var target *specterrs.TasksSchemaError
+
+				return errors.As(err, &target) &&
+					strings.HasSuffix(target.Path, "tasks-1.jsonc")
+			},
+		},
+		{
+			name: "missing v2 child",
+			files: map[string]string{
+				"tasks.jsonc": `{"version": 2, "tasks": [
+					{"id": "1", "section": "A", "description": "A tasks", "status": "pending", "children": "$ref:tasks-1.jsonc"}
+				]}`,
+			},
+			check: func(err error) bool { return errors.Is(err, os.ErrNotExist) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Tasks(writeChange(t, tt.files), TasksOptions{})
+			if err == nil || !tt.check(err) {
+				t.Errorf("Tasks error = %v", err)
+			}
+		})
+	}
+}
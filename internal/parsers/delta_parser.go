@@ -6,9 +6,9 @@ package parsers
 
 import (
 	"bufio"
-	"os"
 	"strings"
 
+	"github.com/connerohnesorge/spectr/internal/fileio"
 	"github.com/connerohnesorge/spectr/internal/markdown"
 )
 
@@ -31,11 +31,10 @@ type RenameOp struct {
 func ParseDeltaSpec(
 	filePath string,
 ) (*DeltaPlan, error) {
-	file, err := os.Open(filePath)
+	content, err := fileio.ReadString(filePath)
 	if err != nil {
 		return nil, err
 	}
-	defer func() { _ = file.Close() }()
 
 	plan := &DeltaPlan{
 		Added:    make([]RequirementBlock, 0),
@@ -44,30 +43,38 @@ func ParseDeltaSpec(
 		Renamed:  make([]RenameOp, 0),
 	}
 
-	content, err := os.ReadFile(filePath)
-	if err != nil {
-		return nil, err
-	}
-
 	// Parse each section
 	plan.Added = parseDeltaSection(
-		string(content),
+		content,
 		"ADDED",
 	)
 	plan.Modified = parseDeltaSection(
-		string(content),
+		content,
 		"MODIFIED",
 	)
 	plan.Removed = parseRemovedSection(
-		string(content),
+		content,
 	)
 	plan.Renamed = parseRenamedSection(
-		string(content),
+		content,
 	)
 
 	return plan, nil
 }
 
+// ParseRemovedBlocks returns the requirement blocks of a delta spec's
+// REMOVED section, including their Reason and Migration notes.
+func ParseRemovedBlocks(
+	filePath string,
+) ([]RequirementBlock, error) {
+	content, err := fileio.ReadString(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseDeltaSection(content, "REMOVED"), nil
+}
+
 // parseDeltaSection extracts requirements from a delta section
 func parseDeltaSection(
 	content, sectionType string,
@@ -229,7 +236,7 @@ func parseRenamedSection(
 	}
 
 	// Parse FROM/TO pairs
-	// Expected format:
+	// Expected format (the backticks are optional):
 	// - FROM: `### Requirement: Old Name`
 	// - TO: `### Requirement: New Name`
 	var currentFrom string
@@ -239,15 +246,15 @@ func parseRenamedSection(
 	for scanner.Scan() {
 		line := strings.TrimSpace(scanner.Text())
 
-		// Check for FROM line (backtick format)
-		if name, ok := markdown.MatchRenamedFrom(line); ok {
+		// Check for FROM line
+		if name, ok := markdown.MatchAnyRenamedFrom(line); ok {
 			currentFrom = strings.TrimSpace(name)
 
 			continue
 		}
 
-		// Check for TO line (backtick format)
-		if name, ok := markdown.MatchRenamedTo(line); ok {
+		// Check for TO line
+		if name, ok := markdown.MatchAnyRenamedTo(line); ok {
 			if currentFrom == "" {
 				continue
 			}
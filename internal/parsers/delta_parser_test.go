@@ -223,6 +223,30 @@ func TestParseDeltaSpec_Renamed(t *testing.T) {
 	}
 }
 
+func TestParseDeltaSpec_RenamedWithoutBackticks(t *testing.T) {
+	content := `## RENAMED Requirements
+
+- FROM: ### Requirement: Old Name
+- TO: ### Requirement: New Name
+`
+
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "spec.md")
+	if err := os.WriteFile(filePath, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	plan, err := ParseDeltaSpec(filePath)
+	if err != nil {
+		t.Fatalf("ParseDeltaSpec failed: %v", err)
+	}
+
+	want := RenameOp{From: "Old Name", To: "New Name"}
+	if len(plan.Renamed) != 1 || plan.Renamed[0] != want {
+		t.Errorf("Renamed = %+v, want [%+v]", plan.Renamed, want)
+	}
+}
+
 func TestParseDeltaSpec_AllOperations(
 	t *testing.T,
 ) {
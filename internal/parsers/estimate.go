@@ -0,0 +1,98 @@
+package parsers
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+// Estimate units: a day is HoursPerDay hours of focused work and a week is
+// DaysPerWeek days, whatever a team's calendar hours are.
+const (
+	HoursPerDay = 8
+	DaysPerWeek = 5
+)
+
+// estimatePattern matches a trailing "(estimate: 4h)" annotation on a
+// tasks.md line.
+var estimatePattern = regexp.MustCompile(`(?i)\s*\(estimate:\s*([^)]*)\)\s*$`)
+
+// assigneePattern matches a trailing "(assignee: alice)" annotation on a
+// tasks.md line.
+var assigneePattern = regexp.MustCompile(`(?i)\s*\(assignee:\s*([^)]*)\)\s*$`)
+
+// estimateValuePattern matches an estimate value such as "4h" or "1.5d".
+var estimateValuePattern = regexp.MustCompile(`^(\d+(?:\.\d+)?)\s*([hdw])$`)
+
+// TaskAnnotations are the trailing annotations of a tasks.md line.
+type TaskAnnotations struct {
+	Description string
+	Covers      []string
+	Estimate    string
+	Assignee    string
+}
+
+// ExtractTaskAnnotations removes the trailing "(covers: ...)",
+// "(estimate: ...)" and "(assignee: ...)" annotations of a task
+// description, in any order, and returns them with the description.
+func ExtractTaskAnnotations(description string) TaskAnnotations {
+	a := TaskAnnotations{Description: description}
+	for {
+		if rest, covers := ExtractCovers(a.Description); covers != nil {
+			a.Description, a.Covers = rest, append(covers, a.Covers...)
+
+			continue
+		}
+		if rest, value, ok := extractAnnotation(estimatePattern, a.Description); ok {
+			a.Description, a.Estimate = rest, strings.ToLower(value)
+
+			continue
+		}
+		if rest, value, ok := extractAnnotation(assigneePattern, a.Description); ok {
+			a.Description, a.Assignee = rest, strings.TrimPrefix(value, "@")
+
+			continue
+		}
+
+		return a
+	}
+}
+
+// extractAnnotation removes the trailing annotation matched by pattern and
+// returns its non-empty value.
+func extractAnnotation(pattern *regexp.Regexp, description string) (string, string, bool) {
+	m := pattern.FindStringSubmatchIndex(description)
+	if m == nil {
+		return description, "", false
+	}
+	value := strings.TrimSpace(description[m[2]:m[3]])
+	if value == "" {
+		return description, "", false
+	}
+
+	return strings.TrimSpace(description[:m[0]]), value, true
+}
+
+// ParseEstimate returns the hours of an estimate such as "4h", "2d" or
+// "1w". Units are case-insensitive.
+func ParseEstimate(estimate string) (float64, error) {
+	m := estimateValuePattern.FindStringSubmatch(strings.ToLower(strings.TrimSpace(estimate)))
+	if m == nil {
+		return 0, &specterrs.InvalidEstimateError{Estimate: estimate}
+	}
+	value, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return 0, &specterrs.InvalidEstimateError{Estimate: estimate}
+	}
+
+	switch m[2] {
+	case "d":
+		value *= HoursPerDay
+	case "w":
+		value *= HoursPerDay * DaysPerWeek
+	}
+
+	return value, nil
+}
@@ -0,0 +1,60 @@
+package parsers
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractTaskAnnotations(t *testing.T) {
+	tests := []struct {
+		in   string
+		want TaskAnnotations
+	}{
+		{"Add login form", TaskAnnotations{Description: "Add login form"}},
+		{
+			"Add login form (estimate: 4H) (covers: AUTH-R1-S1)",
+			TaskAnnotations{Description: "Add login form", Covers: []string{"AUTH-R1-S1"}, Estimate: "4h"},
+		},
+		{
+			"Wire (optional) flag (covers: AUTH-R2-S1) (assignee: @alice) (estimate: 1.5d)",
+			TaskAnnotations{
+				Description: "Wire (optional) flag",
+				Covers:      []string{"AUTH-R2-S1"},
+				Estimate:    "1.5d",
+				Assignee:    "alice",
+			},
+		},
+		{"Note (estimate: ) here", TaskAnnotations{Description: "Note (estimate: ) here"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			if got := ExtractTaskAnnotations(tt.in); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExtractTaskAnnotations() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseEstimate(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    float64
+		wantErr bool
+	}{
+		{"4h", 4, false},
+		{"1.5d", 12, false},
+		{"1W", 40, false},
+		{" 2 d ", 16, false},
+		{"4", 0, true},
+		{"soon", 0, true},
+		{"-1h", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseEstimate(tt.in)
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("ParseEstimate() = %v, %v; want %v, error %v", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
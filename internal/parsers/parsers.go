@@ -9,6 +9,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/connerohnesorge/spectr/internal/fileio"
 	"github.com/connerohnesorge/spectr/internal/markdown"
 )
 
@@ -17,7 +18,7 @@ import (
 func ExtractTitle(
 	filePath string,
 ) (string, error) {
-	file, err := os.Open(filePath)
+	file, err := fileio.Open(filePath)
 	if err != nil {
 		return "", err
 	}
@@ -145,10 +146,11 @@ func copyJSONString(
 
 // ReadTasksJson reads and parses a tasks.json file.
 // Supports JSONC format with single-line and multi-line comments.
+// Files with an unsupported future version are rejected.
 func ReadTasksJson(
 	filePath string,
 ) (*TasksFile, error) {
-	data, err := os.ReadFile(filePath)
+	data, err := fileio.ReadFile(filePath)
 	if err != nil {
 		return nil, err
 	}
@@ -160,6 +162,9 @@ func ReadTasksJson(
 	if err := json.Unmarshal(data, &tasksFile); err != nil {
 		return nil, err
 	}
+	if err := tasksFile.CheckVersion(filePath); err != nil {
+		return nil, err
+	}
 
 	return &tasksFile, nil
 }
@@ -223,7 +228,7 @@ func countTasksFromMarkdown(
 		InProgress: 0,
 	}
 
-	file, err := os.Open(filePath)
+	file, err := fileio.Open(filePath)
 	if err != nil {
 		// Return zero status if file doesn't exist or can't be read
 		return status, nil
@@ -265,7 +270,7 @@ func CountDeltas(changeDir string) (int, error) {
 	err := walkSpecFiles(
 		specsDir,
 		func(filePath string) error {
-			file, err := os.Open(filePath)
+			file, err := fileio.Open(filePath)
 			if err != nil {
 				return err
 			}
@@ -292,7 +297,7 @@ func CountDeltas(changeDir string) (int, error) {
 func CountRequirements(
 	specPath string,
 ) (int, error) {
-	file, err := os.Open(specPath)
+	file, err := fileio.Open(specPath)
 	if err != nil {
 		return 0, err
 	}
@@ -1,9 +1,12 @@
 package parsers
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
+
+	"github.com/connerohnesorge/spectr/internal/specterrs"
 )
 
 func TestExtractTitle(t *testing.T) {
@@ -1039,3 +1042,22 @@ func TestCountTasksFromJson_AllScenarios(
 		})
 	}
 }
+
+func TestReadTasksJson_FutureVersion(t *testing.T) {
+	tmpDir := t.TempDir()
+	path := filepath.Join(tmpDir, "tasks.jsonc")
+	content := `{"version": 3, "tasks": []}`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := ReadTasksJson(path)
+
+	var versionErr *specterrs.UnsupportedTasksVersionError
+	if !errors.As(err, &versionErr) {
+		t.Fatalf("ReadTasksJson error = %v, want UnsupportedTasksVersionError", err)
+	}
+	if versionErr.Version != 3 || versionErr.Latest != LatestTasksVersion {
+		t.Errorf("error = %+v", versionErr)
+	}
+}
@@ -3,9 +3,10 @@ package parsers
 
 import (
 	"bufio"
-	"os"
+	"io"
 	"strings"
 
+	"github.com/connerohnesorge/spectr/internal/fileio"
 	"github.com/connerohnesorge/spectr/internal/markdown"
 )
 
@@ -19,21 +20,32 @@ type RequirementBlock struct {
 // ParseRequirements parses all requirement blocks from a spec file.
 //
 // Returns a slice of RequirementBlock with their names and full content.
-//
-//nolint:revive // function-length - parser is clearest as single function
 func ParseRequirements(
 	filePath string,
 ) ([]RequirementBlock, error) {
-	file, err := os.Open(filePath)
+	file, err := fileio.Open(filePath)
 	if err != nil {
 		return nil, err
 	}
 	defer func() { _ = file.Close() }()
 
+	return parseRequirementBlocks(file)
+}
+
+// ParseRequirementsContent parses all requirement blocks from spec
+// content, such as a spec read from git history.
+func ParseRequirementsContent(content string) ([]RequirementBlock, error) {
+	return parseRequirementBlocks(strings.NewReader(content))
+}
+
+// parseRequirementBlocks parses all requirement blocks read from r.
+//
+//nolint:revive // function-length - parser is clearest as single function
+func parseRequirementBlocks(r io.Reader) ([]RequirementBlock, error) {
 	var requirements []RequirementBlock
 	var currentReq *RequirementBlock
 
-	scanner := bufio.NewScanner(file)
+	scanner := bufio.NewScanner(r)
 	for scanner.Scan() {
 		line := scanner.Text()
 
@@ -194,6 +194,22 @@ Content for second feature.
 	}
 }
 
+func TestParseRequirementsContent(t *testing.T) {
+	content := "## Requirements\n\n### Requirement: One\nA SHALL x.\n\n" +
+		"### Requirement: Two\nB SHALL y.\n\n## Notes\ntrailing\n"
+
+	reqs, err := ParseRequirementsContent(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reqs) != 2 || reqs[0].Name != "One" || reqs[1].Name != "Two" {
+		t.Fatalf("ParseRequirementsContent() = %+v", reqs)
+	}
+	if want := "### Requirement: Two\nB SHALL y.\n\n"; reqs[1].Raw != want {
+		t.Errorf("Raw = %q, want %q", reqs[1].Raw, want)
+	}
+}
+
 func TestParseScenarios(t *testing.T) {
 	tests := []struct {
 		name     string
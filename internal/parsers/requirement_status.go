@@ -0,0 +1,134 @@
+package parsers
+
+import (
+	"bufio"
+	"strconv"
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/markdown"
+)
+
+// RequirementStatus is the maturity status declared on a requirement.
+type RequirementStatus string
+
+// Requirement status constants, in lifecycle order
+const (
+	RequirementStatusDraft       RequirementStatus = "draft"
+	RequirementStatusApproved    RequirementStatus = "approved"
+	RequirementStatusImplemented RequirementStatus = "implemented"
+	RequirementStatusDeprecated  RequirementStatus = "deprecated"
+)
+
+// RequirementStatuses lists all valid requirement statuses in lifecycle
+// order. Use it to render status breakdowns consistently.
+var RequirementStatuses = []RequirementStatus{
+	RequirementStatusDraft,
+	RequirementStatusApproved,
+	RequirementStatusImplemented,
+	RequirementStatusDeprecated,
+}
+
+// IsValid reports whether s is one of the known requirement statuses.
+func (s RequirementStatus) IsValid() bool {
+	for _, status := range RequirementStatuses {
+		if s == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MatchStatusLine checks if a line declares a requirement status and
+// extracts the (lowercased) value. Accepted forms:
+//
+//	status: approved
+//	Status: approved
+//	**Status:** approved
+//	**Status**: approved
+//
+// The value is returned even if it is not a known status so callers can
+// report it.
+func MatchStatusLine(line string) (RequirementStatus, bool) {
+	trimmed := strings.TrimSpace(line)
+	trimmed = strings.TrimPrefix(trimmed, "- ")
+
+	lower := strings.ToLower(trimmed)
+	var rest string
+	switch {
+	case strings.HasPrefix(lower, "**status:**"):
+		rest = trimmed[len("**status:**"):]
+	case strings.HasPrefix(lower, "**status**:"):
+		rest = trimmed[len("**status**:"):]
+	case strings.HasPrefix(lower, "status:"):
+		rest = trimmed[len("status:"):]
+	default:
+		return "", false
+	}
+
+	value := strings.ToLower(strings.Trim(strings.TrimSpace(rest), "`*_"))
+	if value == "" {
+		return "", false
+	}
+
+	return RequirementStatus(value), true
+}
+
+// ParseRequirementStatus returns the status declared in a requirement's
+// content. Only the requirement body before the first scenario is searched.
+// Returns false if the requirement declares no status.
+func ParseRequirementStatus(
+	requirementContent string,
+) (RequirementStatus, bool) {
+	scanner := bufio.NewScanner(
+		strings.NewReader(requirementContent),
+	)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if _, ok := markdown.MatchScenarioHeader(
+			strings.TrimSpace(line),
+		); ok {
+			break
+		}
+		if status, ok := MatchStatusLine(line); ok {
+			return status, true
+		}
+	}
+
+	return "", false
+}
+
+// CountRequirementStatuses counts requirements per declared status in a
+// spec.md file. Requirements without a status, or with an unknown status,
+// are not counted.
+func CountRequirementStatuses(
+	specPath string,
+) (map[RequirementStatus]int, error) {
+	reqs, err := ParseRequirements(specPath)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[RequirementStatus]int)
+	for _, req := range reqs {
+		status, ok := ParseRequirementStatus(req.Raw)
+		if ok && status.IsValid() {
+			counts[status]++
+		}
+	}
+
+	return counts, nil
+}
+
+// FormatStatusBreakdown renders status counts in lifecycle order, e.g.
+// "2 draft, 1 approved". Returns an empty string if counts is empty.
+func FormatStatusBreakdown(counts map[RequirementStatus]int) string {
+	parts := make([]string, 0, len(RequirementStatuses))
+	for _, status := range RequirementStatuses {
+		if n := counts[status]; n > 0 {
+			parts = append(parts, strconv.Itoa(n)+" "+string(status))
+		}
+	}
+
+	return strings.Join(parts, ", ")
+}
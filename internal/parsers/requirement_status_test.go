@@ -0,0 +1,85 @@
+package parsers
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMatchStatusLine(t *testing.T) {
+	tests := []struct {
+		line   string
+		want   RequirementStatus
+		wantOk bool
+	}{
+		{"status: approved", RequirementStatusApproved, true},
+		{"Status: Draft", RequirementStatusDraft, true},
+		{"**Status:** implemented", RequirementStatusImplemented, true},
+		{"**Status**: deprecated", RequirementStatusDeprecated, true},
+		{"- status: `approved`", RequirementStatusApproved, true},
+		{"status: reviewed", RequirementStatus("reviewed"), true},
+		{"status:", "", false},
+		{"The status: field is described here", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			got, ok := MatchStatusLine(tt.line)
+			if ok != tt.wantOk || got != tt.want {
+				t.Errorf(
+					"MatchStatusLine(%q) = (%q, %v), want (%q, %v)",
+					tt.line, got, ok, tt.want, tt.wantOk,
+				)
+			}
+		})
+	}
+}
+
+func TestCountRequirementStatuses(t *testing.T) {
+	content := `# Spec
+
+## Requirements
+
+### Requirement: One
+status: approved
+The system SHALL do one.
+
+#### Scenario: One
+- **WHEN** x
+- **THEN** y
+
+### Requirement: Two
+**Status:** approved
+
+### Requirement: Three
+**Status:** draft
+
+### Requirement: Four
+No status here.
+
+#### Scenario: Status in scenario is ignored
+status: implemented
+
+### Requirement: Five
+status: bogus
+`
+	specPath := filepath.Join(t.TempDir(), "spec.md")
+	if err := os.WriteFile(specPath, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	counts, err := CountRequirementStatuses(specPath)
+	if err != nil {
+		t.Fatalf("CountRequirementStatuses() error = %v", err)
+	}
+
+	if counts[RequirementStatusApproved] != 2 ||
+		counts[RequirementStatusDraft] != 1 ||
+		counts[RequirementStatusImplemented] != 0 {
+		t.Errorf("unexpected counts %v", counts)
+	}
+
+	if got := FormatStatusBreakdown(counts); got != "1 draft, 2 approved" {
+		t.Errorf("FormatStatusBreakdown() = %q", got)
+	}
+}
@@ -0,0 +1,86 @@
+package parsers
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// scenarioIDPattern matches a scenario ID such as "AUTH-R3-S2": the spec
+// key, then the 1-based requirement and scenario positions.
+var scenarioIDPattern = regexp.MustCompile(`^(.+)-R(\d+)-S(\d+)$`)
+
+// requirementIDPattern matches a requirement ID such as "AUTH-R3".
+var requirementIDPattern = regexp.MustCompile(`^(.+)-R(\d+)$`)
+
+// coversPattern matches a trailing "(covers: AUTH-R1-S1, AUTH-R1-S2)"
+// annotation on a tasks.md line.
+var coversPattern = regexp.MustCompile(`(?i)\s*\(covers:\s*([^)]*)\)\s*$`)
+
+// SpecKey returns the scenario ID prefix of a spec: its ID in upper case
+// with slashes turned into hyphens ("billing/invoices" -> "BILLING-INVOICES").
+func SpecKey(specID string) string {
+	return strings.ToUpper(strings.ReplaceAll(specID, "/", "-"))
+}
+
+// RequirementID returns the ID of the reqNum-th requirement of a spec,
+// counted from 1, such as "AUTH-R3". Scenario IDs extend it.
+func RequirementID(specID string, reqNum int) string {
+	return fmt.Sprintf("%s-R%d", SpecKey(specID), reqNum)
+}
+
+// ScenarioID returns the ID of the scenarioNum-th scenario of the
+// reqNum-th requirement of a spec, both counted from 1.
+func ScenarioID(specID string, reqNum, scenarioNum int) string {
+	return fmt.Sprintf("%s-S%d", RequirementID(specID, reqNum), scenarioNum)
+}
+
+// ParseScenarioID splits a scenario ID into its spec key and positions.
+// IDs are matched case-insensitively.
+func ParseScenarioID(id string) (specKey string, reqNum, scenarioNum int, ok bool) {
+	m := scenarioIDPattern.FindStringSubmatch(strings.ToUpper(strings.TrimSpace(id)))
+	if m == nil {
+		return "", 0, 0, false
+	}
+	reqNum, _ = strconv.Atoi(m[2])
+	scenarioNum, _ = strconv.Atoi(m[3])
+	if reqNum == 0 || scenarioNum == 0 {
+		return "", 0, 0, false
+	}
+
+	return m[1], reqNum, scenarioNum, true
+}
+
+// ParseRequirementID splits a requirement ID into its spec key and
+// position. IDs are matched case-insensitively.
+func ParseRequirementID(id string) (specKey string, reqNum int, ok bool) {
+	m := requirementIDPattern.FindStringSubmatch(strings.ToUpper(strings.TrimSpace(id)))
+	if m == nil {
+		return "", 0, false
+	}
+	reqNum, _ = strconv.Atoi(m[2])
+	if reqNum == 0 {
+		return "", 0, false
+	}
+
+	return m[1], reqNum, true
+}
+
+// ExtractCovers removes a trailing "(covers: ...)" annotation from a task
+// description and returns the description and the scenario IDs it lists.
+func ExtractCovers(description string) (string, []string) {
+	m := coversPattern.FindStringSubmatchIndex(description)
+	if m == nil {
+		return description, nil
+	}
+
+	var covers []string
+	for _, id := range strings.Split(description[m[2]:m[3]], ",") {
+		if id = strings.ToUpper(strings.TrimSpace(id)); id != "" {
+			covers = append(covers, id)
+		}
+	}
+
+	return strings.TrimSpace(description[:m[0]]), covers
+}
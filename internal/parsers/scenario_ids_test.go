@@ -0,0 +1,61 @@
+package parsers
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestScenarioID(t *testing.T) {
+	if got := ScenarioID("billing/invoices", 3, 2); got != "BILLING-INVOICES-R3-S2" {
+		t.Errorf("ScenarioID() = %s", got)
+	}
+
+	key, req, scenario, ok := ParseScenarioID("user-auth-r3-s12")
+	if !ok || key != "USER-AUTH" || req != 3 || scenario != 12 {
+		t.Errorf("ParseScenarioID() = %s, %d, %d, %v", key, req, scenario, ok)
+	}
+
+	for _, id := range []string{"AUTH", "AUTH-R3", "AUTH-R0-S1", "-R1-S1"} {
+		if _, _, _, ok := ParseScenarioID(id); ok {
+			t.Errorf("ParseScenarioID(%q) should fail", id)
+		}
+	}
+}
+
+func TestRequirementID(t *testing.T) {
+	if got := RequirementID("billing/invoices", 3); got != "BILLING-INVOICES-R3" {
+		t.Errorf("RequirementID() = %s", got)
+	}
+
+	key, req, ok := ParseRequirementID("user-auth-r12")
+	if !ok || key != "USER-AUTH" || req != 12 {
+		t.Errorf("ParseRequirementID() = %s, %d, %v", key, req, ok)
+	}
+
+	for _, id := range []string{"AUTH", "AUTH-R0", "AUTH-R1-S1", "-R1"} {
+		if _, _, ok := ParseRequirementID(id); ok {
+			t.Errorf("ParseRequirementID(%q) should fail", id)
+		}
+	}
+}
+
+func TestExtractCovers(t *testing.T) {
+	tests := []struct {
+		in     string
+		desc   string
+		covers []string
+	}{
+		{"Add login form", "Add login form", nil},
+		{"Add login form (covers: AUTH-R1-S1, auth-r1-s2)", "Add login form", []string{"AUTH-R1-S1", "AUTH-R1-S2"}},
+		{"Wire (optional) flag (Covers: AUTH-R2-S1)", "Wire (optional) flag", []string{"AUTH-R2-S1"}},
+		{"Note (covers: ) here", "Note (covers: ) here", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			desc, covers := ExtractCovers(tt.in)
+			if desc != tt.desc || !slices.Equal(covers, tt.covers) {
+				t.Errorf("ExtractCovers() = %q, %v; want %q, %v", desc, covers, tt.desc, tt.covers)
+			}
+		})
+	}
+}
@@ -0,0 +1,240 @@
+package parsers
+
+import (
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/markdown"
+)
+
+// ScenarioStep is a single WHEN/THEN/AND/GIVEN bullet in a scenario.
+type ScenarioStep struct {
+	Keyword string // "GIVEN", "WHEN", "THEN", "AND" or "BUT"
+	Text    string // Step text without the keyword
+	// Wrapped holds the indented lines that continue the step, trimmed.
+	// Text has them joined to its first line with spaces.
+	Wrapped []string
+}
+
+// ExamplesTable is a parameter table attached to a scenario.
+// Each row is expected to have the same number of cells as Header.
+type ExamplesTable struct {
+	Header []string   // Column names
+	Rows   [][]string // Data rows, in source order
+	// Line is the 1-based line of the header row, relative to the
+	// requirement content passed to ParseScenarioBlocks
+	Line int
+}
+
+// ScenarioBlock is a scenario parsed with its steps and optional
+// Examples table.
+type ScenarioBlock struct {
+	Name     string
+	Steps    []ScenarioStep
+	Examples *ExamplesTable
+}
+
+// stepKeywords lists the bold keywords recognized as scenario steps.
+var stepKeywords = []string{"GIVEN", "WHEN", "THEN", "AND", "BUT"}
+
+// ParseScenarioBlocks extracts scenarios with their steps and Examples
+// tables from requirement content. Indented lines right after a step are
+// wrapped text of that step.
+//
+// An Examples table is introduced by a line reading "Examples:",
+// "**Examples:**" or "##### Examples" and is followed by a GFM pipe table,
+// parsed with markdown.WithTables:
+//
+//	#### Scenario: Login with <role>
+//	- **WHEN** a <role> logs in
+//	- **THEN** they see <page>
+//
+//	**Examples:**
+//
+//	| role  | page      |
+//	| ----- | --------- |
+//	| admin | dashboard |
+//	| guest | home      |
+func ParseScenarioBlocks(
+	requirementContent string,
+) []ScenarioBlock {
+	var blocks []ScenarioBlock
+	var current *ScenarioBlock
+	afterStep := false
+
+	lines := strings.Split(requirementContent, "\n")
+	for i := 0; i < len(lines); i++ {
+		raw := strings.TrimSuffix(lines[i], "\r")
+		line := strings.TrimSpace(raw)
+
+		// An indented line right after a step continues the step's text
+		indented := line != "" && strings.TrimLeft(raw, " \t") != raw
+		if _, isStep := ParseScenarioStep(line); afterStep && indented && !isStep {
+			step := &current.Steps[len(current.Steps)-1]
+			step.Text += " " + line
+			step.Wrapped = append(step.Wrapped, line)
+
+			continue
+		}
+		afterStep = false
+
+		if name, ok := markdown.MatchScenarioHeader(line); ok {
+			if current != nil {
+				blocks = append(blocks, *current)
+			}
+			current = &ScenarioBlock{Name: strings.TrimSpace(name)}
+
+			continue
+		}
+
+		if current == nil {
+			continue
+		}
+
+		if IsExamplesMarker(line) {
+			var tableLines int
+			current.Examples, tableLines = parseExamplesTable(lines[i+1:], i+1)
+			i += tableLines
+
+			continue
+		}
+
+		if step, ok := ParseScenarioStep(line); ok {
+			current.Steps = append(current.Steps, step)
+			afterStep = true
+		}
+	}
+
+	if current != nil {
+		blocks = append(blocks, *current)
+	}
+
+	return blocks
+}
+
+// parseExamplesTable parses the pipe table that starts the lines following
+// an Examples marker on line markerLine. Returns the table and the number
+// of lines it spans, up to its last row. If the lines do not start with a
+// table, the returned table has no header and spans no lines.
+func parseExamplesTable(
+	lines []string,
+	markerLine int,
+) (*ExamplesTable, int) {
+	source := []byte(strings.Join(lines, "\n"))
+	doc, _ := markdown.Parse(source, markdown.WithTables())
+
+	table := &ExamplesTable{}
+	children := doc.Children()
+	if len(children) == 0 {
+		return table, 0
+	}
+	node, ok := children[0].(*markdown.NodeTable)
+	if !ok || node.Header() == nil {
+		return table, 0
+	}
+
+	start, end := node.Span()
+	index := markdown.NewLineIndex(source)
+	startLine, _ := index.LineCol(start)
+	endLine, _ := index.LineCol(end)
+
+	table.Header = TableRowCells(node.Header())
+	table.Line = markerLine + startLine
+	for _, row := range node.Rows() {
+		table.Rows = append(table.Rows, TableRowCells(row))
+	}
+
+	return table, endLine
+}
+
+// TableRowCells returns the text of each cell in a table row, with
+// escaped pipes unescaped.
+func TableRowCells(row *markdown.NodeTableRow) []string {
+	cells := make([]string, 0, len(row.Cells()))
+	for _, cell := range row.Cells() {
+		cells = append(cells, cell.Text())
+	}
+
+	return cells
+}
+
+// IsExamplesMarker reports whether a trimmed line introduces a scenario
+// Examples table.
+func IsExamplesMarker(line string) bool {
+	switch line {
+	case "Examples:", "**Examples:**", "**Examples**:", "##### Examples",
+		"##### Examples:":
+		return true
+	default:
+		return false
+	}
+}
+
+// ParseScenarioStep parses a scenario bullet of the form
+// "- **WHEN** something happens". Keywords aliased with
+// markdown.SetKeywordAliases are returned as the keyword they stand for.
+func ParseScenarioStep(line string) (ScenarioStep, bool) {
+	trimmed := strings.TrimSpace(line)
+	if !strings.HasPrefix(trimmed, "- ") &&
+		!strings.HasPrefix(trimmed, "* ") {
+		return ScenarioStep{}, false
+	}
+	trimmed = strings.TrimSpace(trimmed[2:])
+
+	for _, kw := range stepKeywords {
+		prefix := "**" + kw + "**"
+		if !strings.HasPrefix(trimmed, prefix) {
+			continue
+		}
+
+		return ScenarioStep{
+			Keyword: kw,
+			Text:    strings.TrimSpace(trimmed[len(prefix):]),
+		}, true
+	}
+
+	// An aliased keyword, e.g. **QUAND**
+	rest, ok := strings.CutPrefix(trimmed, "**")
+	if !ok {
+		return ScenarioStep{}, false
+	}
+	word, text, ok := strings.Cut(rest, "**")
+	if !ok {
+		return ScenarioStep{}, false
+	}
+	kw, ok := markdown.AliasedKeyword(word)
+	if !ok {
+		return ScenarioStep{}, false
+	}
+
+	return ScenarioStep{Keyword: kw, Text: strings.TrimSpace(text)}, true
+}
+
+// Placeholders returns the distinct <name> placeholders referenced in text,
+// in order of first appearance.
+func Placeholders(text string) []string {
+	var names []string
+	seen := make(map[string]bool)
+
+	for {
+		start := strings.Index(text, "<")
+		if start < 0 {
+			break
+		}
+		end := strings.Index(text[start+1:], ">")
+		if end < 0 {
+			break
+		}
+
+		name := text[start+1 : start+1+end]
+		text = text[start+1+end+1:]
+		if name == "" || strings.ContainsAny(name, " \t/<") {
+			continue
+		}
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
@@ -0,0 +1,173 @@
+package parsers
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/connerohnesorge/spectr/internal/markdown"
+)
+
+func TestParseScenarioBlocks(t *testing.T) {
+	content := `The system SHALL log users in.
+
+#### Scenario: Login as <role>
+- **GIVEN** a <role> account
+- **WHEN** the user logs in
+- **THEN** they see the <page> page
+
+**Examples:**
+
+| role  | page      |
+| ----- | --------- |
+| admin | dashboard |
+| guest | home      |
+
+#### Scenario: Logout
+- **WHEN** the user logs out
+- **THEN** the session ends
+  and the user is redirected
+`
+
+	blocks := ParseScenarioBlocks(content)
+	if len(blocks) != 2 {
+		t.Fatalf("expected 2 scenarios, got %d", len(blocks))
+	}
+
+	outline := blocks[0]
+	if outline.Name != "Login as <role>" {
+		t.Errorf("unexpected name %q", outline.Name)
+	}
+	if len(outline.Steps) != 3 || outline.Steps[0].Keyword != "GIVEN" ||
+		outline.Steps[2].Text != "they see the <page> page" {
+		t.Errorf("unexpected steps %+v", outline.Steps)
+	}
+	if outline.Examples == nil {
+		t.Fatal("expected Examples table")
+	}
+	if !reflect.DeepEqual(outline.Examples.Header, []string{"role", "page"}) {
+		t.Errorf("unexpected header %q", outline.Examples.Header)
+	}
+	wantRows := [][]string{{"admin", "dashboard"}, {"guest", "home"}}
+	if !reflect.DeepEqual(outline.Examples.Rows, wantRows) {
+		t.Errorf("unexpected rows %q", outline.Examples.Rows)
+	}
+	if outline.Examples.Line != 10 {
+		t.Errorf("header line = %d, want 10", outline.Examples.Line)
+	}
+
+	if blocks[1].Examples != nil {
+		t.Errorf("expected no Examples for second scenario")
+	}
+	if len(blocks[1].Steps) != 2 {
+		t.Fatalf("expected 2 steps, got %d", len(blocks[1].Steps))
+	}
+	if got := blocks[1].Steps[1].Text; got != "the session ends and the user is redirected" {
+		t.Errorf("continuation not joined: %q", got)
+	}
+	if got := blocks[1].Steps[1].Wrapped; !reflect.DeepEqual(got, []string{"and the user is redirected"}) {
+		t.Errorf("wrapped lines = %q", got)
+	}
+}
+
+func TestParseScenarioBlocks_ExamplesTables(t *testing.T) {
+	tests := []struct {
+		name       string
+		examples   string
+		wantHeader []string
+		wantRows   [][]string
+	}{
+		{
+			name:       "escaped pipe in cell",
+			examples:   "| op | text |\n| -- | ---- |\n| or | a \\| b |\n",
+			wantHeader: []string{"op", "text"},
+			wantRows:   [][]string{{"or", "a | b"}},
+		},
+		{
+			name:       "ragged rows",
+			examples:   "| a | b |\n|---|---|\n| 1 |\n| 2 | 3 | 4 |\n",
+			wantHeader: []string{"a", "b"},
+			wantRows:   [][]string{{"1"}, {"2", "3", "4"}},
+		},
+		{
+			name:     "no delimiter row",
+			examples: "| a | b |\n| 1 | 2 |\n",
+		},
+		{
+			name:     "paragraph instead of table",
+			examples: "See the fixtures.\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := "#### Scenario: S\n- **WHEN** <a>\n\n**Examples:**\n\n" +
+				tt.examples + "\n- **THEN** done\n"
+
+			blocks := ParseScenarioBlocks(content)
+			if len(blocks) != 1 || blocks[0].Examples == nil {
+				t.Fatalf("blocks = %+v, want one scenario with Examples", blocks)
+			}
+			table := blocks[0].Examples
+			if !reflect.DeepEqual(table.Header, tt.wantHeader) ||
+				!reflect.DeepEqual(table.Rows, tt.wantRows) {
+				t.Errorf("table = %q %q, want %q %q",
+					table.Header, table.Rows, tt.wantHeader, tt.wantRows)
+			}
+			if tt.wantHeader != nil && table.Line != 6 {
+				t.Errorf("header line = %d, want 6", table.Line)
+			}
+			if len(blocks[0].Steps) != 2 {
+				t.Errorf("steps = %+v, want the step after the table", blocks[0].Steps)
+			}
+		})
+	}
+}
+
+func TestPlaceholders(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"a <role> sees <page> as <role>", []string{"role", "page"}},
+		{"no placeholders", nil},
+		{"html </div> and <a b>", nil},
+		{"unterminated <role", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			if got := Placeholders(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Placeholders(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseScenarioStep_Alias(t *testing.T) {
+	err := markdown.SetKeywordAliases(map[string][]string{
+		"WHEN": {"QUAND", "当"},
+		"THEN": {"ALORS"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = markdown.SetKeywordAliases(nil) })
+
+	tests := []struct {
+		line string
+		want ScenarioStep
+		ok   bool
+	}{
+		{"- **QUAND** on se connecte", ScenarioStep{Keyword: "WHEN", Text: "on se connecte"}, true},
+		{"- **当** 用户登录", ScenarioStep{Keyword: "WHEN", Text: "用户登录"}, true},
+		{"* **Alors** ça marche", ScenarioStep{Keyword: "THEN", Text: "ça marche"}, true},
+		{"- **WHEN** as before", ScenarioStep{Keyword: "WHEN", Text: "as before"}, true},
+		{"- **Note** not a step", ScenarioStep{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := ParseScenarioStep(tt.line)
+		if !reflect.DeepEqual(got, tt.want) || ok != tt.ok {
+			t.Errorf("ParseScenarioStep(%q) = %+v, %v; want %+v, %v", tt.line, got, ok, tt.want, tt.ok)
+		}
+	}
+}
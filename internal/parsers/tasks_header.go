@@ -0,0 +1,53 @@
+package parsers
+
+import "fmt"
+
+// TasksJSONHeader is the comprehensive JSONC comment header prepended to
+// tasks.jsonc files. It explains status values, transitions, and workflow.
+const TasksJSONHeader = `// Spectr Tasks File (JSONC)
+//
+// This file contains machine-readable task definitions for a Spectr change.
+// JSONC format allows comments while maintaining JSON compatibility.
+//
+// Status Values:
+//   - "pending"     : Task has not been started yet
+//   - "in_progress" : Task is currently being worked on
+//   - "completed"   : Task has been finished and verified
+//
+// Status Transitions:
+//   pending -> in_progress -> completed
+//
+//   Tasks should only move forward through these states.
+//   Do not skip states or move backward.
+//
+// Workflow:
+//   1. BEFORE starting work on a task, mark it as "in_progress"
+//   2. Complete the implementation for the task
+//   3. Verify the work is correct and complete
+//   4. IMMEDIATELY mark the task as "completed" after verification
+//   5. Move to the next task and repeat
+//
+// IMPORTANT - Update Status Immediately:
+//   - Update each task's status IMMEDIATELY after it transitions
+//   - Do NOT batch status updates at the end of all work
+//   - Do NOT wait until all tasks are done to update statuses
+//   - This file should reflect accurate progress at any point in time
+//   - Using a single edit to mark a task completed AND the next task
+//     in_progress is allowed (this is a single transition, not batching)
+//
+// Note: This file is auto-generated by 'spectr accept'. Manual edits to
+// task status are expected, but structure changes may be overwritten.
+// The original tasks.md file is preserved alongside tasks.jsonc to retain
+// human-readable formatting, links, and context.
+//
+
+`
+
+// ChildTasksHeader generates the JSONC header for child task files.
+func ChildTasksHeader(changeID, parentTaskID string) string {
+	return fmt.Sprintf(`// Generated by: spectr accept %s
+// Parent change: %s
+// Parent task: %s
+
+`, changeID, changeID, parentTaskID)
+}
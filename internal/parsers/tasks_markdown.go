@@ -0,0 +1,152 @@
+package parsers
+
+import (
+	"bufio"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/fileio"
+	"github.com/connerohnesorge/spectr/internal/markdown"
+)
+
+// taskParseState tracks state during tasks.md parsing.
+type taskParseState struct {
+	lastSectionNum   int    // Track for section numbering continuity
+	sectionNum       string // Current section number as string
+	sectionName      string // Current section name
+	taskSeqInSection int    // Task sequence within section
+	globalTaskSeq    int    // For tasks with no section
+}
+
+// handleSection processes a section header line and updates state.
+func (s *taskParseState) handleSection(
+	name, number string,
+) {
+	if number != "" {
+		// Numbered section - use explicit number
+		num, _ := strconv.Atoi(number)
+		s.lastSectionNum = num
+		s.sectionNum = number
+	} else {
+		// Unnumbered section - increment from last
+		s.lastSectionNum++
+		s.sectionNum = strconv.Itoa(s.lastSectionNum)
+	}
+	s.sectionName = strings.TrimSpace(name)
+	s.taskSeqInSection = 0
+}
+
+// generateTaskID creates a task ID based on current state and match.
+func (s *taskParseState) generateTaskID(
+	matchNumber string,
+) string {
+	if s.sectionNum == "" {
+		// No section context - global sequential
+		s.globalTaskSeq++
+
+		return strconv.Itoa(s.globalTaskSeq)
+	}
+
+	s.taskSeqInSection++
+	expectedID := fmt.Sprintf(
+		"%s.%d",
+		s.sectionNum,
+		s.taskSeqInSection,
+	)
+
+	if matchNumber != "" &&
+		matchNumber == expectedID {
+		return matchNumber // Explicit matches expected
+	}
+
+	return expectedID // Auto-generate or override
+}
+
+// createTask builds a Task from parsed match and current state.
+func (s *taskParseState) createTask(
+	match *markdown.FlexibleTaskMatch,
+) Task {
+	taskID := s.generateTaskID(match.Number)
+
+	var status TaskStatusValue
+	if match.Status == ' ' {
+		status = TaskStatusPending
+	} else {
+		status = TaskStatusCompleted
+	}
+
+	// A trailing "(covers: AUTH-R1-S1)" links the task to scenarios;
+	// "(estimate: 4h)" and "(assignee: alice)" feed capacity planning
+	annotations := ExtractTaskAnnotations(
+		strings.TrimSpace(match.Content),
+	)
+
+	return Task{
+		ID:          taskID,
+		Section:     s.sectionName,
+		Description: annotations.Description,
+		Status:      status,
+		Covers:      annotations.Covers,
+		Estimate:    annotations.Estimate,
+		Assignee:    annotations.Assignee,
+	}
+}
+
+// ParseTasksMarkdown parses tasks.md and returns a slice of Task structs.
+// It extracts section headers (## lines), task IDs, descriptions, and status
+// from the markdown structure. Supports flexible task formats:
+//   - "- [ ] 1.1 Task" (decimal ID)
+//   - "- [ ] 1. Task" (simple dot ID)
+//   - "- [ ] 1 Task" (number only ID)
+//   - "- [ ] Task" (no ID - auto-generated)
+func ParseTasksMarkdown(
+	path string,
+) ([]Task, error) {
+	file, err := fileio.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to open file: %w",
+			err,
+		)
+	}
+	defer func() { _ = file.Close() }()
+
+	var tasks []Task
+	state := &taskParseState{}
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		// Check for section header (numbered or unnumbered)
+		if name, number, ok := markdown.MatchAnySection(line); ok {
+			state.handleSection(name, number)
+
+			continue
+		}
+
+		// Check for task line using flexible matching
+		match, ok := markdown.MatchFlexibleTask(
+			line,
+		)
+		if !ok {
+			continue
+		}
+
+		tasks = append(
+			tasks,
+			state.createTask(match),
+		)
+	}
+
+	err = scanner.Err()
+	if err != nil {
+		return nil, fmt.Errorf(
+			"error reading file: %w",
+			err,
+		)
+	}
+
+	return tasks, nil
+}
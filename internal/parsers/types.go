@@ -5,6 +5,8 @@
 // This file contains JSON schema types for the tasks.json file format.
 package parsers
 
+import "github.com/connerohnesorge/spectr/internal/specterrs"
+
 // TaskStatusValue represents the status of a task in tasks.json
 type TaskStatusValue string
 
@@ -28,6 +30,12 @@ type Task struct {
 	// Children is a $ref to a child task file (v2 hierarchical format)
 	// Format: "$ref:specs/capability/tasks.jsonc"
 	Children string `json:"children,omitempty"`
+	// Covers lists the scenario IDs the task implements, e.g. "AUTH-R3-S2"
+	Covers []string `json:"covers,omitempty"`
+	// Estimate is the expected effort, e.g. "4h", "2d" or "1w"
+	Estimate string `json:"estimate,omitempty"`
+	// Assignee names the team member doing the task
+	Assignee string `json:"assignee,omitempty"`
 }
 
 // TaskSummary represents task completion statistics
@@ -38,6 +46,15 @@ type TaskSummary struct {
 	Pending    int `json:"pending"`
 }
 
+// Tasks file format versions. Version 1 is a flat task list; version 2
+// adds child task files referenced through Task.Children.
+const (
+	TasksVersionFlat         = 1
+	TasksVersionHierarchical = 2
+	// LatestTasksVersion is the newest version this build can read.
+	LatestTasksVersion = TasksVersionHierarchical
+)
+
 // TasksFile represents the root structure of a tasks.json file
 type TasksFile struct {
 	Version int    `json:"version"`
@@ -49,3 +66,19 @@ type TasksFile struct {
 	// Parent is the parent task ID (used in child task files, v2 format only)
 	Parent string `json:"parent,omitempty"`
 }
+
+// CheckVersion returns *specterrs.UnsupportedTasksVersionError when the
+// file declares a version newer than LatestTasksVersion, so callers fail
+// loudly instead of misreading (or rewriting) a layout they do not know.
+// A missing version is read as version 1.
+func (f *TasksFile) CheckVersion(path string) error {
+	if f.Version > LatestTasksVersion {
+		return &specterrs.UnsupportedTasksVersionError{
+			Path:    path,
+			Version: f.Version,
+			Latest:  LatestTasksVersion,
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,362 @@
+package plan
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"math"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/connerohnesorge/spectr/internal/domain"
+	"github.com/connerohnesorge/spectr/internal/fileio"
+	"github.com/connerohnesorge/spectr/internal/markdown"
+	"github.com/connerohnesorge/spectr/internal/parsers"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+// CapacityOptions describe the team that works through the plan.
+type CapacityOptions struct {
+	// TeamSize is the number of people; named assignees beyond it are
+	// added to the team
+	TeamSize int
+	// WeeklyHours is the working time of each person per week
+	WeeklyHours float64
+	// Start is the first day of work; weekends are skipped
+	Start time.Time
+}
+
+// Work is the remaining work of one change.
+type Work struct {
+	Release string
+	Tasks   []WorkTask
+	// Unestimated counts the remaining tasks without an estimate
+	Unestimated int
+}
+
+// WorkTask is a remaining task with an estimate.
+type WorkTask struct {
+	ID       string
+	Hours    float64
+	Assignee string
+}
+
+// Capacity is the projected completion of the active changes.
+type Capacity struct {
+	Start       time.Time         `json:"start"`
+	TeamSize    int               `json:"teamSize"`
+	WeeklyHours float64           `json:"weeklyHours"`
+	Changes     []ChangeForecast  `json:"changes"`
+	Releases    []ReleaseForecast `json:"releases"`
+	Blocked     []Blocked         `json:"blocked,omitempty"`
+}
+
+// ChangeForecast is the projected schedule of one change.
+type ChangeForecast struct {
+	ID             string    `json:"id"`
+	Title          string    `json:"title,omitempty"`
+	Release        string    `json:"release,omitempty"`
+	RemainingHours float64   `json:"remainingHours"`
+	Unestimated    int       `json:"unestimated"`
+	Start          time.Time `json:"start"`
+	Finish         time.Time `json:"finish"`
+	// People lists who works on the change
+	People []string `json:"people,omitempty"`
+}
+
+// ReleaseForecast is the projected completion of the changes of a
+// release.
+type ReleaseForecast struct {
+	Name           string    `json:"name"`
+	Changes        []string  `json:"changes"`
+	RemainingHours float64   `json:"remainingHours"`
+	Unestimated    int       `json:"unestimated"`
+	Finish         time.Time `json:"finish"`
+}
+
+// BuildCapacity orders the active changes of the project at projectRoot
+// and forecasts when each change and release is done.
+func BuildCapacity(projectRoot string, opts CapacityOptions) (*Capacity, error) {
+	p, err := Build(projectRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	work := make(map[string]Work)
+	for _, change := range changes(p) {
+		w, err := loadWork(filepath.Join(projectRoot, "spectr", "changes", change.ID))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read tasks of %s: %w", change.ID, err)
+		}
+		work[change.ID] = w
+	}
+
+	return Forecast(p, work, opts), nil
+}
+
+// Forecast schedules the remaining work of each planned change on the
+// team, in plan order. A change starts once the changes it waits for are
+// done. Its tasks are handed out one by one: an assigned task goes to its
+// assignee, any other to whoever is free first, so one change can be
+// worked on by several people at once. In-progress tasks count in full.
+func Forecast(p *Plan, work map[string]Work, opts CapacityOptions) *Capacity {
+	people := team(p, work, opts.TeamSize)
+	free := make(map[string]float64, len(people))
+	hoursPerDay := opts.WeeklyHours / parsers.DaysPerWeek
+	start := nextWorkday(opts.Start)
+
+	c := &Capacity{
+		Start:       start,
+		TeamSize:    len(people),
+		WeeklyHours: opts.WeeklyHours,
+		Blocked:     p.Blocked,
+	}
+
+	// done holds the hour each change is finished, counted from start
+	done := make(map[string]float64)
+	releases := make(map[string]*ReleaseForecast)
+	for _, change := range changes(p) {
+		w := work[change.ID]
+		ready := 0.0
+		for _, dep := range change.After {
+			ready = math.Max(ready, done[dep])
+		}
+
+		first, last := math.Inf(1), ready
+		var remaining float64
+		working := make(map[string]bool)
+		for _, task := range w.Tasks {
+			person := task.Assignee
+			if person == "" {
+				person = firstFree(people, free, ready)
+			}
+			begin := math.Max(free[person], ready)
+			free[person] = begin + task.Hours
+			first = math.Min(first, begin)
+			last = math.Max(last, free[person])
+			remaining += task.Hours
+			working[person] = true
+		}
+		if math.IsInf(first, 1) {
+			first = ready
+		}
+		done[change.ID] = last
+
+		forecast := ChangeForecast{
+			ID:             change.ID,
+			Title:          change.Title,
+			Release:        w.Release,
+			RemainingHours: remaining,
+			Unestimated:    w.Unestimated,
+			Start:          workdayAt(start, first, hoursPerDay, false),
+			Finish:         workdayAt(start, last, hoursPerDay, true),
+		}
+		for _, person := range people {
+			if working[person] {
+				forecast.People = append(forecast.People, person)
+			}
+		}
+		c.Changes = append(c.Changes, forecast)
+
+		if w.Release == "" {
+			continue
+		}
+		release, ok := releases[w.Release]
+		if !ok {
+			release = &ReleaseForecast{Name: w.Release}
+			releases[w.Release] = release
+		}
+		release.Changes = append(release.Changes, change.ID)
+		release.RemainingHours += remaining
+		release.Unestimated += w.Unestimated
+		if forecast.Finish.After(release.Finish) {
+			release.Finish = forecast.Finish
+		}
+	}
+
+	for _, release := range releases {
+		c.Releases = append(c.Releases, *release)
+	}
+	sort.Slice(c.Releases, func(i, j int) bool {
+		a, b := c.Releases[i], c.Releases[j]
+		if !a.Finish.Equal(b.Finish) {
+			return a.Finish.Before(b.Finish)
+		}
+
+		return a.Name < b.Name
+	})
+
+	return c
+}
+
+// team returns the people working on the plan: the assignees of the
+// remaining tasks, sorted, then unnamed members up to teamSize.
+func team(p *Plan, work map[string]Work, teamSize int) []string {
+	named := make(map[string]bool)
+	for _, change := range changes(p) {
+		for _, task := range work[change.ID].Tasks {
+			if task.Assignee != "" {
+				named[task.Assignee] = true
+			}
+		}
+	}
+
+	people := make([]string, 0, max(teamSize, len(named)))
+	for name := range named {
+		people = append(people, name)
+	}
+	sort.Strings(people)
+	for i := 1; len(people) < teamSize; i++ {
+		people = append(people, fmt.Sprintf("member %d", i))
+	}
+
+	return people
+}
+
+// firstFree returns the person who can start a task at ready the
+// earliest, the first listed on ties.
+func firstFree(people []string, free map[string]float64, ready float64) string {
+	best := people[0]
+	for _, person := range people[1:] {
+		if math.Max(free[person], ready) < math.Max(free[best], ready) {
+			best = person
+		}
+	}
+
+	return best
+}
+
+// workdayAt returns the day on which the given number of working hours
+// since start falls. With end set, an hour that closes a day belongs to
+// that day rather than the next.
+func workdayAt(start time.Time, hours, hoursPerDay float64, end bool) time.Time {
+	days := math.Floor(hours / hoursPerDay)
+	if end && hours > 0 {
+		days = math.Ceil(hours/hoursPerDay) - 1
+	}
+
+	day := start
+	for n := int(days); n > 0; {
+		day = day.AddDate(0, 0, 1)
+		if !isWeekend(day) {
+			n--
+		}
+	}
+
+	return day
+}
+
+// nextWorkday returns the date of t, moved forward to Monday on weekends.
+func nextWorkday(t time.Time) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	for isWeekend(day) {
+		day = day.AddDate(0, 0, 1)
+	}
+
+	return day
+}
+
+// isWeekend reports whether t is a Saturday or Sunday.
+func isWeekend(t time.Time) bool {
+	return t.Weekday() == time.Saturday || t.Weekday() == time.Sunday
+}
+
+// loadWork reads the release of a change and its remaining tasks from
+// tasks.jsonc, or from tasks.md before the change is accepted. Version 2
+// child task files are followed one level deep.
+func loadWork(changeDir string) (Work, error) {
+	var w Work
+	meta, err := domain.ParseProposalFrontmatterFromFile(filepath.Join(changeDir, "proposal.md"))
+	if err == nil {
+		w.Release = strings.TrimSpace(meta.Release)
+	}
+
+	err = w.addTasks(filepath.Join(changeDir, "tasks.jsonc"), true)
+	if !errors.Is(err, fs.ErrNotExist) {
+		return w, err
+	}
+
+	return w, w.addMarkdownTasks(filepath.Join(changeDir, "tasks.md"))
+}
+
+// addMarkdownTasks adds the unchecked tasks of a tasks.md file to w, with
+// the estimates and assignees of their trailing annotations.
+func (w *Work) addMarkdownTasks(path string) error {
+	content, err := fileio.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	for line := range strings.SplitSeq(string(content), "\n") {
+		match, ok := markdown.MatchFlexibleTask(line)
+		if !ok || match.Status != ' ' {
+			continue
+		}
+		annotations := parsers.ExtractTaskAnnotations(strings.TrimSpace(match.Content))
+		err := w.add(parsers.Task{
+			ID:       match.Number,
+			Estimate: annotations.Estimate,
+			Assignee: annotations.Assignee,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// addTasks adds the remaining tasks of a tasks.jsonc file to w. A task
+// with children is counted through its child file when that can be read.
+func (w *Work) addTasks(path string, followChildren bool) error {
+	tasksFile, err := parsers.ReadTasksJson(path)
+	if err != nil {
+		return err
+	}
+
+	for _, task := range tasksFile.Tasks {
+		ref, hasChildren := strings.CutPrefix(task.Children, "$ref:")
+		if followChildren && hasChildren {
+			err := w.addTasks(filepath.Join(filepath.Dir(path), ref), false)
+			if err == nil {
+				continue
+			}
+			if !errors.Is(err, fs.ErrNotExist) {
+				return err
+			}
+		}
+		if task.Status == parsers.TaskStatusCompleted {
+			continue
+		}
+		if err := w.add(task); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// add adds a remaining task to w, or counts it as unestimated.
+func (w *Work) add(task parsers.Task) error {
+	if task.Estimate == "" {
+		w.Unestimated++
+
+		return nil
+	}
+	hours, err := parsers.ParseEstimate(task.Estimate)
+	if err != nil {
+		return &specterrs.InvalidEstimateError{Task: task.ID, Estimate: task.Estimate}
+	}
+	w.Tasks = append(w.Tasks, WorkTask{
+		ID:       task.ID,
+		Hours:    hours,
+		Assignee: strings.TrimSpace(task.Assignee),
+	})
+
+	return nil
+}
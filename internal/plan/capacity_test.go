@@ -0,0 +1,165 @@
+package plan
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+// friday is a Friday, so forecasts in these tests cross a weekend.
+var friday = time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+
+func capacityTestForecast() *Capacity {
+	p := Order([]Input{
+		{ID: "api"},
+		{ID: "ui", Requires: []string{"api"}},
+		{ID: "docs"},
+		{ID: "audit", Missing: []string{"logging"}},
+	})
+	work := map[string]Work{
+		"api":  {Release: "v1", Tasks: []WorkTask{{ID: "1.1", Hours: 16}, {ID: "1.2", Hours: 8}}},
+		"docs": {Release: "v2", Tasks: []WorkTask{{ID: "1.1", Hours: 4, Assignee: "alice"}}},
+		"ui":   {Release: "v1", Tasks: []WorkTask{{ID: "1.1", Hours: 8}}, Unestimated: 2},
+	}
+
+	return Forecast(p, work, CapacityOptions{TeamSize: 2, WeeklyHours: 40, Start: friday})
+}
+
+func TestForecast(t *testing.T) {
+	c := capacityTestForecast()
+	day := func(offset int) time.Time { return friday.AddDate(0, 0, offset) }
+
+	// api runs on both people from day 0; docs waits for alice, ui for
+	// api, and the weekend sits between Friday and Monday
+	want := []ChangeForecast{
+		{ID: "api", Release: "v1", RemainingHours: 24, Start: day(0), Finish: day(3), People: []string{"alice", "member 1"}},
+		{ID: "docs", Release: "v2", RemainingHours: 4, Start: day(4), Finish: day(4), People: []string{"alice"}},
+		{ID: "ui", Release: "v1", RemainingHours: 8, Unestimated: 2, Start: day(4), Finish: day(4), People: []string{"member 1"}},
+	}
+	if !reflect.DeepEqual(c.Changes, want) {
+		t.Errorf("Changes = %+v\nwant %+v", c.Changes, want)
+	}
+
+	wantReleases := []ReleaseForecast{
+		{Name: "v1", Changes: []string{"api", "ui"}, RemainingHours: 32, Unestimated: 2, Finish: day(4)},
+		{Name: "v2", Changes: []string{"docs"}, RemainingHours: 4, Finish: day(4)},
+	}
+	if !reflect.DeepEqual(c.Releases, wantReleases) {
+		t.Errorf("Releases = %+v\nwant %+v", c.Releases, wantReleases)
+	}
+	if c.TeamSize != 2 || len(c.Blocked) != 1 || c.Blocked[0].ID != "audit" {
+		t.Errorf("TeamSize = %d, Blocked = %+v", c.TeamSize, c.Blocked)
+	}
+}
+
+func TestWorkdayAt(t *testing.T) {
+	tests := []struct {
+		hours float64
+		end   bool
+		want  int
+	}{
+		{0, false, 0},
+		{0, true, 0},
+		{6, true, 0},
+		{6, false, 3}, // Monday
+		{12, true, 3},
+		{13, true, 4},
+	}
+	for _, tt := range tests {
+		got := workdayAt(friday, tt.hours, 6, tt.end)
+		if want := friday.AddDate(0, 0, tt.want); !got.Equal(want) {
+			t.Errorf("workdayAt(%v, end=%v) = %s, want %s", tt.hours, tt.end, got, want)
+		}
+	}
+}
+
+func TestWriteCapacityGantt(t *testing.T) {
+	var b strings.Builder
+	if err := WriteCapacityGantt(&b, capacityTestForecast()); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `gantt
+  title Capacity forecast
+  dateFormat YYYY-MM-DD
+  excludes weekends
+  section v1
+  api :c0, 2026-10-16, 2026-10-20
+  ui :c1, 2026-10-20, 2026-10-21
+  v1 :milestone, m0, 2026-10-20, 0d
+  section v2
+  docs :c2, 2026-10-20, 2026-10-21
+  v2 :milestone, m1, 2026-10-20, 0d
+  %% blocked: audit (requires logging, which is neither active nor archived)
+`
+	if b.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+}
+
+func TestBuildCapacity(t *testing.T) {
+	root := t.TempDir()
+	write := func(rel, content string) {
+		t.Helper()
+		path := filepath.Join(root, "spectr", filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("changes/add-sso/proposal.md", "---\nrelease: v1.4\n---\n# Add SSO\n")
+	write("changes/add-sso/tasks.jsonc", `{
+  "version": 2,
+  "tasks": [
+    {"id": "1", "section": "Build", "description": "Build", "status": "pending", "children": "$ref:tasks-1.jsonc"},
+    {"id": "2.1", "section": "Docs", "description": "Docs", "status": "pending", "estimate": "1d", "assignee": "bob"},
+    {"id": "2.2", "section": "Docs", "description": "Review", "status": "pending"}
+  ]
+}`)
+	write("changes/add-sso/tasks-1.jsonc", `{
+  "version": 2,
+  "parent": "1",
+  "tasks": [
+    {"id": "1.1", "section": "Build", "description": "Done", "status": "completed", "estimate": "1w"},
+    {"id": "1.2", "section": "Build", "description": "Wire", "status": "in_progress", "estimate": "4h"}
+  ]
+}`)
+	write("changes/add-ui/proposal.md", "# Add UI\n")
+	write("changes/add-ui/tasks.md", "## 1. UI\n- [ ] 1.1 Form (estimate: 3h)\n- [x] 1.2 Page (estimate: 1d)\n- [ ] 1.3 Style\n")
+
+	c, err := BuildCapacity(root, CapacityOptions{TeamSize: 1, WeeklyHours: 40, Start: friday})
+	if err != nil {
+		t.Fatalf("BuildCapacity returned error: %v", err)
+	}
+	byID := make(map[string]ChangeForecast)
+	for _, change := range c.Changes {
+		byID[change.ID] = change
+	}
+	if got := byID["add-sso"]; got.Release != "v1.4" || got.RemainingHours != 12 || got.Unestimated != 1 {
+		t.Errorf("add-sso = %+v, want release v1.4, 12h, 1 unestimated", got)
+	}
+	if got := byID["add-ui"]; got.RemainingHours != 3 || got.Unestimated != 1 {
+		t.Errorf("add-ui = %+v, want 3h from tasks.md, 1 unestimated", got)
+	}
+	if c.TeamSize != 1 {
+		t.Errorf("TeamSize = %d, want 1 (bob)", c.TeamSize)
+	}
+
+	write("changes/add-ui/tasks.jsonc", `{"version": 1, "tasks": [
+    {"id": "1.1", "section": "UI", "description": "Form", "status": "pending", "estimate": "soon"}
+  ]}`)
+	_, err = BuildCapacity(root, CapacityOptions{TeamSize: 1, WeeklyHours: 40, Start: friday})
+	var estimateErr *specterrs.InvalidEstimateError
+	if !errors.As(err, &estimateErr) || estimateErr.Task != "1.1" {
+		t.Errorf("BuildCapacity() error = %v, want InvalidEstimateError for task 1.1", err)
+	}
+}
@@ -0,0 +1,17 @@
+// Package plan suggests an order in which to merge and archive the active
+// changes of a project.
+//
+// Changes are grouped into phases. A change is placed after the changes it
+// requires and after the changes that list it under enables, so phase N
+// only holds changes whose dependencies were archived before or land in
+// an earlier phase. Changes whose deltas touch the same requirement
+// conflict: they are kept in different phases so each can be reviewed and
+// archived against the other's result. Changes that cannot be ordered,
+// because they require a change that does not exist or sit on a
+// dependency cycle, are reported as blocked.
+//
+// Forecast turns the plan into dates: the estimated remaining tasks of
+// each change are scheduled on a team of people with a weekly number of
+// working hours, and changes sharing a release are rolled up into the
+// release's completion date.
+package plan
@@ -0,0 +1,415 @@
+package plan
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/discovery"
+	"github.com/connerohnesorge/spectr/internal/domain"
+	"github.com/connerohnesorge/spectr/internal/fileio"
+	"github.com/connerohnesorge/spectr/internal/parsers"
+)
+
+// Input describes one active change for Order.
+type Input struct {
+	ID    string
+	Title string
+	// Requires and Enables come from the proposal frontmatter. Entries
+	// that are not active changes are ignored, except those in Missing.
+	Requires []string
+	Enables  []string
+	// Missing lists required changes that are neither active nor archived
+	Missing []string
+	// Touches lists the requirements the change's deltas touch, as
+	// "<spec-id>#<requirement>" with the requirement name normalized
+	Touches []string
+}
+
+// Plan is a suggested archive order.
+type Plan struct {
+	Phases  []Phase   `json:"phases"`
+	Blocked []Blocked `json:"blocked,omitempty"`
+}
+
+// Phase is a group of changes that can be merged and archived in any
+// order once the earlier phases are done.
+type Phase struct {
+	Number  int      `json:"phase"`
+	Changes []Change `json:"changes"`
+}
+
+// Change is a change placed in a phase.
+type Change struct {
+	ID    string `json:"id"`
+	Title string `json:"title,omitempty"`
+	// After lists the active changes this one waits for
+	After []string `json:"after,omitempty"`
+	// Conflicts lists the other changes touching the same requirements
+	Conflicts []Conflict `json:"conflicts,omitempty"`
+}
+
+// Conflict is a change touching some of the same requirements.
+type Conflict struct {
+	Change       string   `json:"change"`
+	Requirements []string `json:"requirements"`
+}
+
+// Blocked is a change that cannot be placed in a phase.
+type Blocked struct {
+	ID     string `json:"id"`
+	Title  string `json:"title,omitempty"`
+	Reason string `json:"reason"`
+}
+
+// Build loads the active changes of the project at projectRoot and orders
+// them.
+func Build(projectRoot string) (*Plan, error) {
+	ids, err := discovery.GetActiveChangeIDs(projectRoot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active changes: %w", err)
+	}
+
+	active := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		active[id] = true
+	}
+
+	inputs := make([]Input, 0, len(ids))
+	for _, id := range ids {
+		input, err := loadInput(projectRoot, id, active)
+		if err != nil {
+			return nil, err
+		}
+		inputs = append(inputs, input)
+	}
+
+	return Order(inputs), nil
+}
+
+// loadInput reads a change's title, dependencies and touched requirements.
+func loadInput(
+	projectRoot, changeID string,
+	active map[string]bool,
+) (Input, error) {
+	changeDir := filepath.Join(projectRoot, "spectr", "changes", changeID)
+	input := Input{ID: changeID}
+
+	proposalPath := filepath.Join(changeDir, "proposal.md")
+	if title, err := parsers.ExtractTitle(proposalPath); err == nil {
+		input.Title = title
+	}
+
+	// Unreadable frontmatter is reported by validate; plan the change
+	// as if it had no dependencies
+	meta, err := domain.ParseProposalFrontmatterFromFile(proposalPath)
+	if err != nil {
+		meta = &domain.ProposalMetadata{}
+	}
+
+	for _, dep := range meta.RequiredIDs() {
+		if active[dep] {
+			input.Requires = append(input.Requires, dep)
+
+			continue
+		}
+		archived, err := discovery.IsChangeArchived(dep, projectRoot)
+		if err != nil || !archived {
+			input.Missing = append(input.Missing, dep)
+		}
+	}
+	input.Enables = meta.EnabledIDs()
+
+	input.Touches, err = touchedRequirements(filepath.Join(changeDir, "specs"))
+	if err != nil {
+		return Input{}, fmt.Errorf("failed to read deltas of %s: %w", changeID, err)
+	}
+
+	return input, nil
+}
+
+// touchedRequirements lists the requirements touched by the delta specs
+// under deltaDir, including both sides of renames.
+func touchedRequirements(deltaDir string) ([]string, error) {
+	var touched []string
+	err := fileio.WalkDir(
+		deltaDir,
+		func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) && path == deltaDir {
+					return filepath.SkipDir
+				}
+
+				return err
+			}
+			if entry.IsDir() || entry.Name() != "spec.md" {
+				return nil
+			}
+
+			rel, err := filepath.Rel(deltaDir, filepath.Dir(path))
+			if err != nil {
+				return err
+			}
+			specID := filepath.ToSlash(rel)
+
+			plan, err := parsers.ParseDeltaSpec(path)
+			if err != nil {
+				return fmt.Errorf("parse %s: %w", path, err)
+			}
+
+			var names []string
+			for _, req := range plan.Added {
+				names = append(names, req.Name)
+			}
+			for _, req := range plan.Modified {
+				names = append(names, req.Name)
+			}
+			names = append(names, plan.Removed...)
+			for _, op := range plan.Renamed {
+				names = append(names, op.From, op.To)
+			}
+			for _, name := range names {
+				touched = append(
+					touched,
+					specID+"#"+parsers.NormalizeRequirementName(name),
+				)
+			}
+
+			return nil
+		},
+	)
+
+	return touched, err
+}
+
+// Order places changes into phases. Changes are visited in dependency
+// order, alphabetically among those that are ready; each goes into the
+// first phase after all of its dependencies that holds no conflicting
+// change. So when two independent changes conflict, the one whose ID sorts
+// first goes first.
+func Order(inputs []Input) *Plan {
+	byID := make(map[string]*Input, len(inputs))
+	for i := range inputs {
+		byID[inputs[i].ID] = &inputs[i]
+	}
+
+	after := dependencies(inputs, byID)
+	conflicts := findConflicts(inputs)
+
+	plan := &Plan{Phases: []Phase{}}
+	phaseOf := make(map[string]int)
+	blocked := make(map[string]string)
+
+	for _, input := range inputs {
+		if len(input.Missing) > 0 {
+			blocked[input.ID] = fmt.Sprintf(
+				"requires %s, which is neither active nor archived",
+				strings.Join(input.Missing, ", "),
+			)
+		}
+	}
+
+	resolved := func(id string) bool {
+		_, placed := phaseOf[id]
+		_, isBlocked := blocked[id]
+
+		return placed || isBlocked
+	}
+
+	for {
+		next := ""
+		for _, input := range inputs {
+			if resolved(input.ID) ||
+				!allResolved(after[input.ID], resolved) {
+				continue
+			}
+			if next == "" || input.ID < next {
+				next = input.ID
+			}
+		}
+		if next == "" {
+			break
+		}
+
+		if dep := firstBlocked(after[next], blocked); dep != "" {
+			blocked[next] = fmt.Sprintf("waits on blocked change %s", dep)
+
+			continue
+		}
+
+		phase := 1
+		for _, dep := range after[next] {
+			phase = max(phase, phaseOf[dep]+1)
+		}
+		for conflictsInPhase(next, phase, conflicts, phaseOf) {
+			phase++
+		}
+		phaseOf[next] = phase
+	}
+
+	var cycle []string
+	for _, input := range inputs {
+		if !resolved(input.ID) {
+			cycle = append(cycle, input.ID)
+		}
+	}
+	sort.Strings(cycle)
+	for _, id := range cycle {
+		blocked[id] = "dependency cycle among " + strings.Join(cycle, ", ")
+	}
+
+	for _, input := range inputs {
+		phase, ok := phaseOf[input.ID]
+		if !ok {
+			continue
+		}
+		for len(plan.Phases) < phase {
+			plan.Phases = append(plan.Phases, Phase{Number: len(plan.Phases) + 1})
+		}
+		plan.Phases[phase-1].Changes = append(
+			plan.Phases[phase-1].Changes,
+			Change{
+				ID:        input.ID,
+				Title:     input.Title,
+				After:     after[input.ID],
+				Conflicts: conflicts[input.ID],
+			},
+		)
+	}
+	for i := range plan.Phases {
+		sort.Slice(plan.Phases[i].Changes, func(a, b int) bool {
+			return plan.Phases[i].Changes[a].ID < plan.Phases[i].Changes[b].ID
+		})
+	}
+
+	for _, input := range inputs {
+		if reason, ok := blocked[input.ID]; ok {
+			plan.Blocked = append(plan.Blocked, Blocked{
+				ID:     input.ID,
+				Title:  input.Title,
+				Reason: reason,
+			})
+		}
+	}
+	sort.Slice(plan.Blocked, func(i, j int) bool {
+		return plan.Blocked[i].ID < plan.Blocked[j].ID
+	})
+
+	return plan
+}
+
+// dependencies returns, for each change, the sorted active changes it must
+// follow: those it requires and those that list it under enables.
+func dependencies(
+	inputs []Input,
+	byID map[string]*Input,
+) map[string][]string {
+	after := make(map[string][]string, len(inputs))
+	add := func(id, dep string) {
+		if _, ok := byID[dep]; !ok || dep == id ||
+			slices.Contains(after[id], dep) {
+			return
+		}
+		after[id] = append(after[id], dep)
+	}
+
+	for _, input := range inputs {
+		for _, dep := range input.Requires {
+			add(input.ID, dep)
+		}
+		for _, enabled := range input.Enables {
+			add(enabled, input.ID)
+		}
+	}
+	for id := range after {
+		sort.Strings(after[id])
+	}
+
+	return after
+}
+
+// findConflicts returns, for each change, the other changes that touch
+// some of the same requirements, sorted by change ID.
+func findConflicts(inputs []Input) map[string][]Conflict {
+	touchedBy := make(map[string][]string)
+	for _, input := range inputs {
+		seen := make(map[string]bool)
+		for _, req := range input.Touches {
+			if seen[req] {
+				continue
+			}
+			seen[req] = true
+			touchedBy[req] = append(touchedBy[req], input.ID)
+		}
+	}
+
+	shared := make(map[[2]string][]string)
+	for req, ids := range touchedBy {
+		for _, a := range ids {
+			for _, b := range ids {
+				if a != b {
+					shared[[2]string{a, b}] = append(shared[[2]string{a, b}], req)
+				}
+			}
+		}
+	}
+
+	conflicts := make(map[string][]Conflict)
+	for pair, reqs := range shared {
+		sort.Strings(reqs)
+		conflicts[pair[0]] = append(conflicts[pair[0]], Conflict{
+			Change:       pair[1],
+			Requirements: reqs,
+		})
+	}
+	for id := range conflicts {
+		sort.Slice(conflicts[id], func(i, j int) bool {
+			return conflicts[id][i].Change < conflicts[id][j].Change
+		})
+	}
+
+	return conflicts
+}
+
+// allResolved reports whether every id is resolved.
+func allResolved(ids []string, resolved func(string) bool) bool {
+	for _, id := range ids {
+		if !resolved(id) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// firstBlocked returns the first of ids that is blocked, or "".
+func firstBlocked(ids []string, blocked map[string]string) string {
+	for _, id := range ids {
+		if _, ok := blocked[id]; ok {
+			return id
+		}
+	}
+
+	return ""
+}
+
+// conflictsInPhase reports whether a change conflicting with id is
+// already placed in phase.
+func conflictsInPhase(
+	id string,
+	phase int,
+	conflicts map[string][]Conflict,
+	phaseOf map[string]int,
+) bool {
+	for _, conflict := range conflicts[id] {
+		if p, ok := phaseOf[conflict.Change]; ok && p == phase {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,178 @@
+package plan
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestOrder(t *testing.T) {
+	tests := []struct {
+		name        string
+		inputs      []Input
+		wantPhases  [][]string
+		wantBlocked map[string]string
+	}{
+		{
+			name: "independent changes share a phase",
+			inputs: []Input{
+				{ID: "b"},
+				{ID: "a"},
+			},
+			wantPhases: [][]string{{"a", "b"}},
+		},
+		{
+			name: "requires and enables",
+			inputs: []Input{
+				{ID: "api", Requires: []string{"schema"}},
+				{ID: "schema", Enables: []string{"ui"}},
+				{ID: "ui", Requires: []string{"api"}},
+			},
+			wantPhases: [][]string{{"schema"}, {"api"}, {"ui"}},
+		},
+		{
+			name: "conflicting changes are split",
+			inputs: []Input{
+				{ID: "b", Touches: []string{"auth#login"}},
+				{ID: "a", Touches: []string{"auth#login"}},
+				{ID: "c", Touches: []string{"auth#logout"}},
+			},
+			wantPhases: [][]string{{"a", "c"}, {"b"}},
+		},
+		{
+			name: "missing dependency blocks dependents",
+			inputs: []Input{
+				{ID: "a", Missing: []string{"gone"}},
+				{ID: "b", Requires: []string{"a"}},
+				{ID: "c"},
+			},
+			wantPhases: [][]string{{"c"}},
+			wantBlocked: map[string]string{
+				"a": "requires gone, which is neither active nor archived",
+				"b": "waits on blocked change a",
+			},
+		},
+		{
+			name: "cycle",
+			inputs: []Input{
+				{ID: "a", Requires: []string{"b"}},
+				{ID: "b", Requires: []string{"a"}},
+				{ID: "c"},
+			},
+			wantPhases: [][]string{{"c"}},
+			wantBlocked: map[string]string{
+				"a": "dependency cycle among a, b",
+				"b": "dependency cycle among a, b",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := Order(tt.inputs)
+
+			var phases [][]string
+			for i, phase := range p.Phases {
+				if phase.Number != i+1 {
+					t.Errorf("phase %d numbered %d", i+1, phase.Number)
+				}
+				var ids []string
+				for _, change := range phase.Changes {
+					ids = append(ids, change.ID)
+				}
+				phases = append(phases, ids)
+			}
+			if !reflect.DeepEqual(phases, tt.wantPhases) {
+				t.Errorf("phases = %v, want %v", phases, tt.wantPhases)
+			}
+
+			blocked := make(map[string]string)
+			for _, b := range p.Blocked {
+				blocked[b.ID] = b.Reason
+			}
+			if len(blocked) == 0 {
+				blocked = nil
+			}
+			if !reflect.DeepEqual(blocked, tt.wantBlocked) {
+				t.Errorf("blocked = %v, want %v", blocked, tt.wantBlocked)
+			}
+		})
+	}
+}
+
+func TestOrder_ReportsAfterAndConflicts(t *testing.T) {
+	p := Order([]Input{
+		{ID: "a", Touches: []string{"auth#login", "auth#logout"}},
+		{ID: "b", Requires: []string{"a"}, Touches: []string{"auth#logout"}},
+	})
+
+	if len(p.Phases) != 2 {
+		t.Fatalf("got %d phases, want 2", len(p.Phases))
+	}
+	b := p.Phases[1].Changes[0]
+	if !reflect.DeepEqual(b.After, []string{"a"}) {
+		t.Errorf("After = %v, want [a]", b.After)
+	}
+	want := []Conflict{{Change: "a", Requirements: []string{"auth#logout"}}}
+	if !reflect.DeepEqual(b.Conflicts, want) {
+		t.Errorf("Conflicts = %+v, want %+v", b.Conflicts, want)
+	}
+}
+
+func TestBuild(t *testing.T) {
+	root := t.TempDir()
+	write := func(rel, content string) {
+		t.Helper()
+		path := filepath.Join(root, "spectr", filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	delta := "## MODIFIED Requirements\n\n" +
+		"### Requirement: User Login\n" +
+		"The system SHALL authenticate users.\n\n" +
+		"#### Scenario: Login\n" +
+		"- **WHEN** a user signs in\n" +
+		"- **THEN** a session starts\n"
+
+	write("changes/add-sso/proposal.md", "# Add SSO\n")
+	write("changes/add-sso/specs/auth/spec.md", delta)
+	write("changes/add-2fa/proposal.md", "---\n"+
+		"requires:\n"+
+		"  - id: add-users\n"+
+		"---\n"+
+		"# Add 2FA\n")
+	write("changes/add-2fa/specs/auth/spec.md", delta)
+	write("changes/archive/2024-01-01-add-users/proposal.md", "# Add users\n")
+	write("changes/add-audit/proposal.md", "---\n"+
+		"requires:\n"+
+		"  - id: add-logging\n"+
+		"---\n"+
+		"# Add audit\n")
+
+	p, err := Build(root)
+	if err != nil {
+		t.Fatalf("Build returned error: %v", err)
+	}
+
+	if len(p.Phases) != 2 ||
+		p.Phases[0].Changes[0].ID != "add-2fa" ||
+		p.Phases[1].Changes[0].ID != "add-sso" {
+		t.Fatalf("phases = %+v, want add-2fa then add-sso", p.Phases)
+	}
+	if p.Phases[0].Changes[0].Title != "Add 2FA" {
+		t.Errorf("title = %q, want %q", p.Phases[0].Changes[0].Title, "Add 2FA")
+	}
+	want := []Conflict{{Change: "add-2fa", Requirements: []string{"auth#user login"}}}
+	if !reflect.DeepEqual(p.Phases[1].Changes[0].Conflicts, want) {
+		t.Errorf("conflicts = %+v, want %+v", p.Phases[1].Changes[0].Conflicts, want)
+	}
+	if len(p.Blocked) != 1 || p.Blocked[0].ID != "add-audit" {
+		t.Errorf("blocked = %+v, want add-audit", p.Blocked)
+	}
+}
@@ -0,0 +1,174 @@
+package plan
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// WriteMermaid writes the plan as a Mermaid flowchart: one subgraph per
+// phase, solid arrows for dependencies and dotted links for conflicts.
+// Blocked changes are grouped in a separate subgraph.
+func WriteMermaid(w io.Writer, p *Plan) error {
+	var b strings.Builder
+	nodes := nodeIDs(p)
+
+	b.WriteString("graph LR\n")
+	for _, phase := range p.Phases {
+		fmt.Fprintf(&b, "  subgraph phase%d[\"Phase %d\"]\n", phase.Number, phase.Number)
+		for _, change := range phase.Changes {
+			fmt.Fprintf(&b, "    %s[\"%s\"]\n", nodes[change.ID], mermaidLabel(change.ID))
+		}
+		b.WriteString("  end\n")
+	}
+	if len(p.Blocked) > 0 {
+		b.WriteString("  subgraph blocked[\"Blocked\"]\n")
+		for _, blocked := range p.Blocked {
+			fmt.Fprintf(&b, "    %s[\"%s\"]\n", nodes[blocked.ID], mermaidLabel(blocked.ID))
+		}
+		b.WriteString("  end\n")
+	}
+
+	for _, change := range changes(p) {
+		for _, dep := range change.After {
+			fmt.Fprintf(&b, "  %s --> %s\n", nodes[dep], nodes[change.ID])
+		}
+	}
+	for _, change := range changes(p) {
+		for _, conflict := range change.Conflicts {
+			// Each pair is listed on both changes; draw it once
+			if conflict.Change < change.ID {
+				continue
+			}
+			fmt.Fprintf(&b, "  %s -. conflict .- %s\n", nodes[change.ID], nodes[conflict.Change])
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+
+	return err
+}
+
+// WriteGantt writes the plan as a Mermaid gantt chart with one section,
+// and one unit of time, per phase. Blocked changes are listed as comments
+// since they have no place on the timeline.
+func WriteGantt(w io.Writer, p *Plan) error {
+	var b strings.Builder
+	nodes := nodeIDs(p)
+
+	b.WriteString("gantt\n")
+	b.WriteString("  title Suggested archive order\n")
+	b.WriteString("  dateFormat X\n")
+	b.WriteString("  axisFormat %s\n")
+	for _, phase := range p.Phases {
+		fmt.Fprintf(&b, "  section Phase %d\n", phase.Number)
+		for _, change := range phase.Changes {
+			fmt.Fprintf(
+				&b,
+				"  %s :%s, %d, %d\n",
+				ganttLabel(change.ID),
+				nodes[change.ID],
+				phase.Number-1,
+				phase.Number,
+			)
+		}
+	}
+	for _, blocked := range p.Blocked {
+		fmt.Fprintf(&b, "  %%%% blocked: %s (%s)\n", blocked.ID, blocked.Reason)
+	}
+
+	_, err := io.WriteString(w, b.String())
+
+	return err
+}
+
+// WriteCapacityGantt writes a capacity forecast as a Mermaid gantt chart
+// on the calendar: one section per release, ending in a milestone, then a
+// section for the changes without a release. Blocked changes are listed as
+// comments.
+func WriteCapacityGantt(w io.Writer, c *Capacity) error {
+	var b strings.Builder
+
+	b.WriteString("gantt\n")
+	b.WriteString("  title Capacity forecast\n")
+	b.WriteString("  dateFormat YYYY-MM-DD\n")
+	b.WriteString("  excludes weekends\n")
+
+	byRelease := make(map[string][]ChangeForecast)
+	for _, change := range c.Changes {
+		byRelease[change.Release] = append(byRelease[change.Release], change)
+	}
+	node := 0
+	writeChanges := func(changes []ChangeForecast) {
+		for _, change := range changes {
+			fmt.Fprintf(
+				&b,
+				"  %s :c%d, %s, %s\n",
+				ganttLabel(change.ID),
+				node,
+				change.Start.Format(time.DateOnly),
+				// Mermaid end dates are exclusive
+				change.Finish.AddDate(0, 0, 1).Format(time.DateOnly),
+			)
+			node++
+		}
+	}
+	for i, release := range c.Releases {
+		fmt.Fprintf(&b, "  section %s\n", ganttLabel(release.Name))
+		writeChanges(byRelease[release.Name])
+		fmt.Fprintf(
+			&b,
+			"  %s :milestone, m%d, %s, 0d\n",
+			ganttLabel(release.Name),
+			i,
+			release.Finish.Format(time.DateOnly),
+		)
+	}
+	if unreleased := byRelease[""]; len(unreleased) > 0 {
+		b.WriteString("  section No release\n")
+		writeChanges(unreleased)
+	}
+	for _, blocked := range c.Blocked {
+		fmt.Fprintf(&b, "  %%%% blocked: %s (%s)\n", blocked.ID, blocked.Reason)
+	}
+
+	_, err := io.WriteString(w, b.String())
+
+	return err
+}
+
+// changes returns the placed changes of a plan in phase order.
+func changes(p *Plan) []Change {
+	var all []Change
+	for _, phase := range p.Phases {
+		all = append(all, phase.Changes...)
+	}
+
+	return all
+}
+
+// nodeIDs assigns each change a Mermaid-safe node ID. Change IDs may
+// contain characters Mermaid treats as syntax, so they only appear in
+// labels.
+func nodeIDs(p *Plan) map[string]string {
+	ids := make(map[string]string)
+	for _, change := range changes(p) {
+		ids[change.ID] = fmt.Sprintf("c%d", len(ids))
+	}
+	for _, blocked := range p.Blocked {
+		ids[blocked.ID] = fmt.Sprintf("c%d", len(ids))
+	}
+
+	return ids
+}
+
+// mermaidLabel escapes a quoted flowchart label.
+func mermaidLabel(s string) string {
+	return strings.ReplaceAll(s, `"`, "#quot;")
+}
+
+// ganttLabel strips the characters that end a gantt task name.
+func ganttLabel(s string) string {
+	return strings.NewReplacer(":", " ", "#", " ", ";", " ").Replace(s)
+}
@@ -0,0 +1,62 @@
+package plan
+
+import (
+	"strings"
+	"testing"
+)
+
+func renderTestPlan() *Plan {
+	return Order([]Input{
+		{ID: "add-sso", Touches: []string{"auth#login"}},
+		{ID: "add-2fa", Touches: []string{"auth#login"}},
+		{ID: "add-ui", Requires: []string{"add-2fa"}},
+		{ID: "add-audit", Missing: []string{"add-logging"}},
+	})
+}
+
+func TestWriteMermaid(t *testing.T) {
+	var b strings.Builder
+	if err := WriteMermaid(&b, renderTestPlan()); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `graph LR
+  subgraph phase1["Phase 1"]
+    c0["add-2fa"]
+  end
+  subgraph phase2["Phase 2"]
+    c1["add-sso"]
+    c2["add-ui"]
+  end
+  subgraph blocked["Blocked"]
+    c3["add-audit"]
+  end
+  c0 --> c2
+  c0 -. conflict .- c1
+`
+	if b.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+}
+
+func TestWriteGantt(t *testing.T) {
+	var b strings.Builder
+	if err := WriteGantt(&b, renderTestPlan()); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `gantt
+  title Suggested archive order
+  dateFormat X
+  axisFormat %s
+  section Phase 1
+  add-2fa :c0, 0, 1
+  section Phase 2
+  add-sso :c1, 1, 2
+  add-ui :c2, 1, 2
+  %% blocked: add-audit (requires add-logging, which is neither active nor archived)
+`
+	if b.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+	}
+}
@@ -0,0 +1,651 @@
+// Package pr provides the review bot for spec changes in pull requests.
+// This file computes requirement-level diffs of the spec files a PR
+// changes, posts them as a single comment that later runs update in place,
+// and sets a commit status from the validation of the touched items.
+package pr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/git"
+	"github.com/connerohnesorge/spectr/internal/hostapi"
+	"github.com/connerohnesorge/spectr/internal/parsers"
+	"github.com/connerohnesorge/spectr/internal/validation"
+)
+
+// BotCommentMarker identifies the comment of spectr bot review, so each
+// run edits it instead of adding another.
+const BotCommentMarker = "<!-- spectr:bot-review -->"
+
+// BotStatusContext names the commit status set by spectr bot review.
+const BotStatusContext = "spectr/review"
+
+// maxStatusDescription is the longest commit status description GitHub
+// accepts.
+const maxStatusDescription = 140
+
+// Requirement diff operations. Delta specs also rename requirements.
+const (
+	ReqAdded    = "ADDED"
+	ReqModified = "MODIFIED"
+	ReqRemoved  = "REMOVED"
+	ReqRenamed  = "RENAMED"
+)
+
+// BotReviewConfig configures spectr bot review.
+type BotReviewConfig struct {
+	ProjectRoot string // Project root, checked out at the PR's head
+	Number      int    // Pull request number or merge request IID
+	Base        string // Commit to diff against; the PR's base when empty
+	DryRun      bool   // Print the comment and status instead of posting
+	Token       string // Explicit --token value
+}
+
+// RequirementDiff is a requirement-level change in one spec file.
+type RequirementDiff struct {
+	Op   string // ADDED, MODIFIED or REMOVED; delta specs also RENAMED
+	Name string // Requirement name, "Old → New" for renames
+}
+
+// SpecFileDiff holds the requirement changes of one spec file in the PR.
+type SpecFileDiff struct {
+	Path string // Repository path, e.g. "spectr/specs/auth/spec.md"
+	Spec string // Spec ID, e.g. "auth"
+	// Change is the change ID of a delta spec, empty for main specs
+	Change       string
+	Requirements []RequirementDiff
+}
+
+// ItemValidation is the validation result of a spec or change the PR
+// touches.
+type ItemValidation struct {
+	Item   string // "spec auth" or "change add-2fa"
+	Errors int
+}
+
+// SpecReview is what spectr bot review reports about a PR.
+type SpecReview struct {
+	Files      []SpecFileDiff
+	Validation []ItemValidation
+}
+
+// Valid reports whether every touched item passed validation.
+func (r *SpecReview) Valid() bool {
+	for _, item := range r.Validation {
+		if item.Errors > 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// BotReviewResult describes a completed bot review.
+type BotReviewResult struct {
+	Review    *SpecReview
+	Comment   string // Rendered comment body
+	State     string // Commit status state: "success" or "failure"
+	HeadSHA   string
+	CommentID int64 // ID of the posted comment; zero on dry runs
+	Updated   bool  // Whether an earlier comment was edited
+}
+
+// botPR is what the bot reads about a pull request.
+type botPR struct {
+	baseSHA string
+	headSHA string
+}
+
+// BotReview reviews the spec changes of pull request config.Number: it
+// diffs the spec files the PR changes between the base commit and the
+// checked-out head, validates the specs and changes they belong to,
+// posts or updates the summary comment and sets the commit status.
+func BotReview(ctx context.Context, config BotReviewConfig) (*BotReviewResult, error) {
+	originURL, err := git.GetOriginURL(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get origin URL: %w", err)
+	}
+	platformInfo, err := git.DetectPlatform(originURL)
+	if err != nil {
+		return nil, fmt.Errorf("detect platform: %w", err)
+	}
+	if platformInfo.Platform != git.PlatformGitHub && platformInfo.Platform != git.PlatformGitLab {
+		return nil, fmt.Errorf("spectr bot review is not supported for %s", platformInfo.Platform)
+	}
+	if err := checkCLITool(platformInfo.CLITool); err != nil {
+		return nil, err
+	}
+
+	ref := git.PullRequestRef{
+		Platform: platformInfo.Platform,
+		Owner:    platformInfo.Owner,
+		Repo:     platformInfo.Repo,
+		Number:   config.Number,
+	}
+	if repoURL, err := url.Parse(platformInfo.RepoURL); err == nil {
+		ref.Host = repoURL.Host
+	}
+	env := resolveCLIEnv(ctx, platformInfo, config.Token)
+
+	info, err := fetchBotPR(ctx, ref, env)
+	if err != nil {
+		return nil, err
+	}
+	base := config.Base
+	if base == "" {
+		base = info.baseSHA
+	}
+
+	review, err := BuildSpecReview(ctx, config.ProjectRoot, base)
+	if err != nil {
+		return nil, err
+	}
+	result := &BotReviewResult{
+		Review:  review,
+		Comment: FormatBotComment(review, platformInfo, info.headSHA),
+		State:   "success",
+		HeadSHA: info.headSHA,
+	}
+	if !review.Valid() {
+		result.State = "failure"
+	}
+	if config.DryRun {
+		return result, nil
+	}
+
+	result.CommentID, result.Updated, err = upsertBotComment(ctx, ref, env, result.Comment)
+	if err != nil {
+		return nil, err
+	}
+	if err := setCommitStatus(ctx, ref, env, info.headSHA, result.State, BotStatusDescription(review)); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// BuildSpecReview diffs the spec files changed between base and the
+// working tree of projectRoot, which may be a directory of a larger
+// repository. Main specs are compared requirement by
+// requirement; delta specs list their operations. Archived changes are
+// left out, as the main specs they were merged into already show them.
+func BuildSpecReview(ctx context.Context, projectRoot, base string) (*SpecReview, error) {
+	output, err := git.Run(
+		ctx, projectRoot,
+		"diff", "--name-only", "--no-renames", "--relative", base+"...HEAD", "--", "spectr",
+	)
+	if err != nil {
+		return nil, git.Failuref(err, "list files changed since %s", base)
+	}
+
+	review := &SpecReview{}
+	specs := make(map[string]bool)
+	changes := make(map[string]bool)
+	for _, file := range strings.Fields(string(output)) {
+		spec, change, ok := classifySpecPath(file)
+		if !ok {
+			continue
+		}
+
+		var diff SpecFileDiff
+		if change == "" {
+			diff, err = diffMainSpec(ctx, projectRoot, base, file)
+		} else {
+			diff, err = diffDeltaSpec(projectRoot, file)
+		}
+		if err != nil {
+			return nil, err
+		}
+		diff.Path, diff.Spec, diff.Change = file, spec, change
+		if len(diff.Requirements) > 0 {
+			review.Files = append(review.Files, diff)
+		}
+
+		if change != "" {
+			changes[change] = true
+		} else if exists(filepath.Join(projectRoot, filepath.FromSlash(file))) {
+			specs[spec] = true
+		}
+	}
+
+	review.Validation = validateTouched(projectRoot, specs, changes)
+
+	return review, nil
+}
+
+// classifySpecPath returns the spec and change ID of a spec file path:
+// spectr/specs/<spec>/spec.md or
+// spectr/changes/<change>/specs/<spec>/spec.md. Other files and archived
+// items are not spec files.
+func classifySpecPath(file string) (spec, change string, ok bool) {
+	if path.Base(file) != "spec.md" {
+		return "", "", false
+	}
+	dir := path.Dir(file)
+
+	if rest, found := strings.CutPrefix(dir, "spectr/specs/"); found {
+		if strings.HasPrefix(rest, "archive/") {
+			return "", "", false
+		}
+
+		return rest, "", true
+	}
+
+	rest, found := strings.CutPrefix(dir, "spectr/changes/")
+	if !found {
+		return "", "", false
+	}
+	change, spec, found = strings.Cut(rest, "/specs/")
+	if !found || change == "archive" || strings.Contains(change, "/") {
+		return "", "", false
+	}
+
+	return spec, change, true
+}
+
+// diffMainSpec compares the requirements of a main spec at base with the
+// working tree. A spec added or deleted by the PR compares with nothing.
+func diffMainSpec(ctx context.Context, projectRoot, base, file string) (SpecFileDiff, error) {
+	var before, after string
+	if output, err := git.Run(ctx, projectRoot, "show", base+":./"+file); err == nil {
+		before = string(output)
+	}
+	data, err := os.ReadFile(filepath.Join(projectRoot, filepath.FromSlash(file)))
+	if err != nil && !os.IsNotExist(err) {
+		return SpecFileDiff{}, fmt.Errorf("read %s: %w", file, err)
+	}
+	after = string(data)
+
+	return SpecFileDiff{Requirements: DiffRequirements(before, after)}, nil
+}
+
+// DiffRequirements compares the requirements of two versions of a spec:
+// added and modified requirements in the order of after, then removed ones
+// in the order of before.
+func DiffRequirements(before, after string) []RequirementDiff {
+	oldBlocks, _ := parsers.ParseRequirementsContent(before)
+	newBlocks, _ := parsers.ParseRequirementsContent(after)
+
+	old := make(map[string]string, len(oldBlocks))
+	for _, block := range oldBlocks {
+		old[parsers.NormalizeRequirementName(block.Name)] = strings.TrimSpace(block.Raw)
+	}
+
+	var diffs []RequirementDiff
+	kept := make(map[string]bool, len(newBlocks))
+	for _, block := range newBlocks {
+		key := parsers.NormalizeRequirementName(block.Name)
+		kept[key] = true
+		raw, found := old[key]
+		switch {
+		case !found:
+			diffs = append(diffs, RequirementDiff{Op: ReqAdded, Name: block.Name})
+		case raw != strings.TrimSpace(block.Raw):
+			diffs = append(diffs, RequirementDiff{Op: ReqModified, Name: block.Name})
+		}
+	}
+	for _, block := range oldBlocks {
+		if !kept[parsers.NormalizeRequirementName(block.Name)] {
+			diffs = append(diffs, RequirementDiff{Op: ReqRemoved, Name: block.Name})
+		}
+	}
+
+	return diffs
+}
+
+// diffDeltaSpec lists the operations of a delta spec in the working tree.
+// A delta spec deleted by the PR has none.
+func diffDeltaSpec(projectRoot, file string) (SpecFileDiff, error) {
+	fullPath := filepath.Join(projectRoot, filepath.FromSlash(file))
+	if !exists(fullPath) {
+		return SpecFileDiff{}, nil
+	}
+	plan, err := parsers.ParseDeltaSpec(fullPath)
+	if err != nil {
+		return SpecFileDiff{}, fmt.Errorf("parse %s: %w", file, err)
+	}
+
+	var diff SpecFileDiff
+	for _, req := range plan.Added {
+		diff.Requirements = append(diff.Requirements, RequirementDiff{Op: ReqAdded, Name: req.Name})
+	}
+	for _, req := range plan.Modified {
+		diff.Requirements = append(diff.Requirements, RequirementDiff{Op: ReqModified, Name: req.Name})
+	}
+	for _, name := range plan.Removed {
+		diff.Requirements = append(diff.Requirements, RequirementDiff{Op: ReqRemoved, Name: name})
+	}
+	for _, op := range plan.Renamed {
+		diff.Requirements = append(diff.Requirements, RequirementDiff{
+			Op:   ReqRenamed,
+			Name: op.From + " → " + op.To,
+		})
+	}
+
+	return diff, nil
+}
+
+// validateTouched validates the specs and changes the PR touches, in name
+// order.
+func validateTouched(projectRoot string, specs, changes map[string]bool) []ItemValidation {
+	validator := validation.NewValidator()
+	var results []ItemValidation
+
+	for _, spec := range sortedKeys(specs) {
+		specPath := filepath.Join(projectRoot, "spectr", "specs", filepath.FromSlash(spec), "spec.md")
+		report, err := validator.ValidateSpec(specPath)
+		results = append(results, itemValidation("spec "+spec, report, err))
+	}
+	for _, change := range sortedKeys(changes) {
+		changeDir := filepath.Join(projectRoot, "spectr", "changes", change)
+		if !exists(changeDir) {
+			continue
+		}
+		report, err := validator.ValidateChange(changeDir)
+		results = append(results, itemValidation("change "+change, report, err))
+	}
+
+	return results
+}
+
+// itemValidation counts the errors of a report. An item that could not be
+// validated counts as one error.
+func itemValidation(item string, report *validation.ValidationReport, err error) ItemValidation {
+	if err != nil {
+		return ItemValidation{Item: item, Errors: 1}
+	}
+
+	return ItemValidation{Item: item, Errors: report.Summary.Errors}
+}
+
+// sortedKeys returns the keys of set in order.
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// FormatBotComment renders the review comment. Spec paths link to the
+// file at headSHA when the repository URL is known.
+func FormatBotComment(review *SpecReview, platformInfo git.PlatformInfo, headSHA string) string {
+	var sb strings.Builder
+	sb.WriteString(BotCommentMarker + "\n## Spectr review\n\n")
+
+	if len(review.Files) == 0 {
+		sb.WriteString("This pull request changes no requirements.\n")
+	}
+	for _, file := range review.Files {
+		title := fmt.Sprintf("Spec `%s`", file.Spec)
+		if file.Change != "" {
+			title = fmt.Sprintf("Change `%s`, delta for `%s`", file.Change, file.Spec)
+		}
+		fmt.Fprintf(&sb, "### %s\n\n", title)
+		fmt.Fprintf(&sb, "%s\n\n", fileLink(platformInfo, headSHA, file.Path))
+		for _, req := range file.Requirements {
+			fmt.Fprintf(&sb, "- **%s** %s\n", req.Op, req.Name)
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("### Validation\n\n")
+	if len(review.Validation) == 0 {
+		sb.WriteString("No specs or changes to validate.\n")
+	}
+	for _, item := range review.Validation {
+		mark := "x"
+		detail := ""
+		if item.Errors > 0 {
+			mark = " "
+			detail = " (" + plural(item.Errors, "error") + ")"
+		}
+		fmt.Fprintf(&sb, "- [%s] %s%s\n", mark, item.Item, detail)
+	}
+
+	sb.WriteString("\n---\n*Generated by `spectr bot review`*\n")
+
+	return sb.String()
+}
+
+// fileLink links path at commit sha, or returns it as code when the
+// repository URL is unknown.
+func fileLink(platformInfo git.PlatformInfo, sha, file string) string {
+	if platformInfo.RepoURL == "" || sha == "" {
+		return "`" + file + "`"
+	}
+	blob := "blob"
+	if platformInfo.Platform == git.PlatformGitLab {
+		blob = "-/blob"
+	}
+
+	return fmt.Sprintf("[`%s`](%s/%s/%s/%s)", file, platformInfo.RepoURL, blob, sha, file)
+}
+
+// BotStatusDescription summarizes the review in one line for the commit
+// status.
+func BotStatusDescription(review *SpecReview) string {
+	requirements := 0
+	for _, file := range review.Files {
+		requirements += len(file.Requirements)
+	}
+
+	var description string
+	failed := 0
+	for _, item := range review.Validation {
+		if item.Errors > 0 {
+			failed++
+		}
+	}
+	if failed > 0 {
+		description = fmt.Sprintf(
+			"%d of %s fail validation",
+			failed,
+			plural(len(review.Validation), "item"),
+		)
+	} else {
+		description = fmt.Sprintf(
+			"%s in %s; validation passed",
+			plural(requirements, "requirement change"),
+			plural(len(review.Files), "spec file"),
+		)
+	}
+	if len(description) > maxStatusDescription {
+		description = description[:maxStatusDescription]
+	}
+
+	return description
+}
+
+// fetchBotPR reads the base and head commits of the pull request.
+func fetchBotPR(ctx context.Context, ref git.PullRequestRef, env []string) (*botPR, error) {
+	name, endpoint := "gh", fmt.Sprintf("repos/%s/pulls/%d", ref.ProjectPath(), ref.Number)
+	if ref.Platform == git.PlatformGitLab {
+		name, endpoint = "glab", fmt.Sprintf("%s/merge_requests/%d", gitLabProject(ref), ref.Number)
+	}
+
+	output, err := runAPI(ctx, env, name, ref.Host, endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("read pull request %d: %w", ref.Number, err)
+	}
+
+	return parseBotPR(ref.Platform, output)
+}
+
+// parseBotPR decodes the pull request from the GitHub or GitLab API.
+func parseBotPR(platform git.Platform, output []byte) (*botPR, error) {
+	var resp struct {
+		Base struct {
+			SHA string `json:"sha"`
+		} `json:"base"`
+		Head struct {
+			SHA string `json:"sha"`
+		} `json:"head"`
+		DiffRefs struct {
+			BaseSHA string `json:"base_sha"`
+			HeadSHA string `json:"head_sha"`
+		} `json:"diff_refs"`
+	}
+	if err := json.Unmarshal(output, &resp); err != nil {
+		return nil, fmt.Errorf("parse %s pull request: %w", platform, err)
+	}
+
+	if platform == git.PlatformGitLab {
+		return &botPR{baseSHA: resp.DiffRefs.BaseSHA, headSHA: resp.DiffRefs.HeadSHA}, nil
+	}
+
+	return &botPR{baseSHA: resp.Base.SHA, headSHA: resp.Head.SHA}, nil
+}
+
+// upsertBotComment edits the comment carrying BotCommentMarker, or posts
+// a new one. It returns the comment ID and whether it was edited.
+func upsertBotComment(
+	ctx context.Context,
+	ref git.PullRequestRef,
+	env []string,
+	body string,
+) (int64, bool, error) {
+	name := "gh"
+	list := fmt.Sprintf("repos/%s/issues/%d/comments", ref.ProjectPath(), ref.Number)
+	edit := fmt.Sprintf("repos/%s/issues/comments/", ref.ProjectPath())
+	editMethod := "PATCH"
+	if ref.Platform == git.PlatformGitLab {
+		name = "glab"
+		list = fmt.Sprintf("%s/merge_requests/%d/notes", gitLabProject(ref), ref.Number)
+		edit = list + "/"
+		editMethod = "PUT"
+	}
+
+	output, err := runAPI(ctx, env, name, ref.Host, list, "--paginate")
+	if err != nil {
+		return 0, false, fmt.Errorf("list comments: %w", err)
+	}
+	existing, err := findBotComment(output)
+	if err != nil {
+		return 0, false, err
+	}
+
+	if existing != 0 {
+		endpoint := edit + strconv.FormatInt(existing, 10)
+		if _, err := runAPI(ctx, env, name, ref.Host, endpoint, "-X", editMethod, "-f", "body="+body); err != nil {
+			return 0, false, fmt.Errorf("update comment: %w", err)
+		}
+
+		return existing, true, nil
+	}
+
+	output, err = runAPI(ctx, env, name, ref.Host, list, "-X", "POST", "-f", "body="+body)
+	if err != nil {
+		return 0, false, fmt.Errorf("post comment: %w", err)
+	}
+	var created struct {
+		ID int64 `json:"id"`
+	}
+	if err := json.Unmarshal(output, &created); err != nil {
+		return 0, false, fmt.Errorf("parse posted comment: %w", err)
+	}
+
+	return created.ID, false, nil
+}
+
+// findBotComment returns the ID of the first comment carrying
+// BotCommentMarker in the output of a paginated comment listing, which
+// is one JSON array per page.
+func findBotComment(output []byte) (int64, error) {
+	decoder := json.NewDecoder(strings.NewReader(string(output)))
+	for {
+		var page []struct {
+			ID   int64  `json:"id"`
+			Body string `json:"body"`
+		}
+		err := decoder.Decode(&page)
+		if errors.Is(err, io.EOF) {
+			return 0, nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("parse comments: %w", err)
+		}
+		for _, comment := range page {
+			if strings.Contains(comment.Body, BotCommentMarker) {
+				return comment.ID, nil
+			}
+		}
+	}
+}
+
+// setCommitStatus sets the BotStatusContext status of commit sha.
+func setCommitStatus(
+	ctx context.Context,
+	ref git.PullRequestRef,
+	env []string,
+	sha, state, description string,
+) error {
+	name := "gh"
+	endpoint := fmt.Sprintf("repos/%s/statuses/%s", ref.ProjectPath(), sha)
+	args := []string{"-f", "state=" + state, "-f", "context=" + BotStatusContext}
+	if ref.Platform == git.PlatformGitLab {
+		name = "glab"
+		endpoint = fmt.Sprintf("%s/statuses/%s", gitLabProject(ref), sha)
+		if state == "failure" {
+			state = "failed"
+		}
+		args = []string{"-f", "state=" + state, "-f", "name=" + BotStatusContext}
+	}
+	args = append([]string{"-X", "POST", "-f", "description=" + description}, args...)
+
+	if _, err := runAPI(ctx, env, name, ref.Host, endpoint, args...); err != nil {
+		return fmt.Errorf("set commit status: %w", err)
+	}
+
+	return nil
+}
+
+// gitLabProject is the API path of the merge request's project.
+func gitLabProject(ref git.PullRequestRef) string {
+	return "projects/" + url.PathEscape(ref.ProjectPath())
+}
+
+// runAPI calls endpoint through gh api or glab api.
+func runAPI(
+	ctx context.Context,
+	env []string,
+	name, host, endpoint string,
+	extra ...string,
+) ([]byte, error) {
+	args := []string{"api"}
+	if host != "" {
+		args = append(args, "--hostname", host)
+	}
+	args = append(append(args, extra...), endpoint)
+
+	output, err := hostapi.RunCLI(
+		ctx,
+		hostapi.DefaultPolicy,
+		hostapi.CLICommand{Env: env, Name: name, Args: args},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s api %s: %s", name, endpoint, commandErrorOutput(err))
+	}
+
+	return output, nil
+}
+
+// exists reports whether path exists.
+func exists(path string) bool {
+	_, err := os.Stat(path)
+
+	return err == nil
+}
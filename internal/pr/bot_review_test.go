@@ -0,0 +1,210 @@
+package pr
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/connerohnesorge/spectr/internal/git"
+)
+
+// runBotGit runs a git command in dir and fails the test on error.
+func runBotGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(
+		os.Environ(),
+		"GIT_AUTHOR_NAME=test",
+		"GIT_AUTHOR_EMAIL=test@example.com",
+		"GIT_COMMITTER_NAME=test",
+		"GIT_COMMITTER_EMAIL=test@example.com",
+	)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v failed: %s", args, output)
+	}
+
+	return strings.TrimSpace(string(output))
+}
+
+// writeBotFile writes content to dir/name, creating directories.
+func writeBotFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+
+	path := filepath.Join(dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// botRequirement renders a requirement block with one scenario.
+func botRequirement(name, text string) string {
+	return "### Requirement: " + name + "\nThe system SHALL " + text + ".\n\n" +
+		"#### Scenario: " + name + " works\n- **WHEN** it is used\n- **THEN** it works\n\n"
+}
+
+func TestClassifySpecPath(t *testing.T) {
+	tests := []struct {
+		file   string
+		spec   string
+		change string
+		ok     bool
+	}{
+		{"spectr/specs/auth/spec.md", "auth", "", true},
+		{"spectr/specs/payments/refunds/spec.md", "payments/refunds", "", true},
+		{"spectr/changes/add-2fa/specs/auth/spec.md", "auth", "add-2fa", true},
+		{"spectr/changes/archive/2024-01-01-add-2fa/specs/auth/spec.md", "", "", false},
+		{"spectr/specs/archive/legacy/spec.md", "", "", false},
+		{"spectr/changes/add-2fa/proposal.md", "", "", false},
+		{"docs/spec.md", "", "", false},
+	}
+	for _, tt := range tests {
+		spec, change, ok := classifySpecPath(tt.file)
+		if spec != tt.spec || change != tt.change || ok != tt.ok {
+			t.Errorf("classifySpecPath(%q) = %q, %q, %v; want %q, %q, %v",
+				tt.file, spec, change, ok, tt.spec, tt.change, tt.ok)
+		}
+	}
+}
+
+func TestDiffRequirements(t *testing.T) {
+	before := "# Auth\n\n## Requirements\n\n" +
+		botRequirement("Login", "log users in") +
+		botRequirement("Logout", "log users out") +
+		botRequirement("Remember Me", "remember users")
+	after := "# Auth\n\n## Requirements\n\n" +
+		botRequirement("Login", "log users in with a password") +
+		botRequirement("Two-Factor Login", "ask for a second factor") +
+		botRequirement("Remember Me", "remember users")
+
+	want := []RequirementDiff{
+		{ReqModified, "Login"},
+		{ReqAdded, "Two-Factor Login"},
+		{ReqRemoved, "Logout"},
+	}
+	if got := DiffRequirements(before, after); !reflect.DeepEqual(got, want) {
+		t.Errorf("DiffRequirements() = %v, want %v", got, want)
+	}
+}
+
+func TestBuildSpecReview(t *testing.T) {
+	root := t.TempDir()
+	runBotGit(t, root, "init", "-q")
+	writeBotFile(t, root, "spectr/specs/auth/spec.md",
+		"# Auth\n\n## Purpose\n\nSign users in.\n\n## Requirements\n\n"+botRequirement("Login", "log users in"))
+	writeBotFile(t, root, "README.md", "readme\n")
+	runBotGit(t, root, "add", ".")
+	runBotGit(t, root, "commit", "-q", "-m", "base")
+	base := runBotGit(t, root, "rev-parse", "HEAD")
+
+	writeBotFile(t, root, "spectr/specs/auth/spec.md",
+		"# Auth\n\n## Purpose\n\nSign users in.\n\n## Requirements\n\n"+
+			botRequirement("Login", "log users in")+botRequirement("Logout", "log users out"))
+	writeBotFile(t, root, "spectr/changes/add-2fa/proposal.md",
+		"# Change: 2FA\n\n## Why\n\nAccounts get taken over too easily.\n\n"+
+			"## What Changes\n\n- Second factor\n\n## Impact\n\nAuth.\n")
+	writeBotFile(t, root, "spectr/changes/add-2fa/specs/auth/spec.md",
+		"## ADDED Requirements\n\n"+botRequirement("Two-Factor Login", "ask for a second factor"))
+	writeBotFile(t, root, "README.md", "changed\n")
+	runBotGit(t, root, "add", ".")
+	runBotGit(t, root, "commit", "-q", "-m", "head")
+
+	review, err := BuildSpecReview(context.Background(), root, base)
+	if err != nil {
+		t.Fatalf("BuildSpecReview() error = %v", err)
+	}
+
+	want := []SpecFileDiff{
+		{
+			Path:         "spectr/changes/add-2fa/specs/auth/spec.md",
+			Spec:         "auth",
+			Change:       "add-2fa",
+			Requirements: []RequirementDiff{{ReqAdded, "Two-Factor Login"}},
+		},
+		{
+			Path:         "spectr/specs/auth/spec.md",
+			Spec:         "auth",
+			Requirements: []RequirementDiff{{ReqAdded, "Logout"}},
+		},
+	}
+	if !reflect.DeepEqual(review.Files, want) {
+		t.Errorf("Files = %+v, want %+v", review.Files, want)
+	}
+
+	items := make([]string, 0, len(review.Validation))
+	for _, item := range review.Validation {
+		items = append(items, item.Item)
+	}
+	if !reflect.DeepEqual(items, []string{"spec auth", "change add-2fa"}) {
+		t.Errorf("validated items = %v", items)
+	}
+}
+
+func TestFormatBotComment(t *testing.T) {
+	review := &SpecReview{
+		Files: []SpecFileDiff{{
+			Path:         "spectr/specs/auth/spec.md",
+			Spec:         "auth",
+			Requirements: []RequirementDiff{{ReqModified, "Login"}},
+		}},
+		Validation: []ItemValidation{{Item: "spec auth"}, {Item: "change add-2fa", Errors: 2}},
+	}
+	info := git.PlatformInfo{Platform: git.PlatformGitHub, RepoURL: "https://github.com/o/r"}
+
+	comment := FormatBotComment(review, info, "abc123")
+	for _, want := range []string{
+		BotCommentMarker,
+		"### Spec `auth`",
+		"[`spectr/specs/auth/spec.md`](https://github.com/o/r/blob/abc123/spectr/specs/auth/spec.md)",
+		"- **MODIFIED** Login",
+		"- [x] spec auth",
+		"- [ ] change add-2fa (2 errors)",
+	} {
+		if !strings.Contains(comment, want) {
+			t.Errorf("comment missing %q\nGot:\n%s", want, comment)
+		}
+	}
+
+	if review.Valid() {
+		t.Error("Valid() = true with a failing item")
+	}
+	if got, want := BotStatusDescription(review), "1 of 2 items fail validation"; got != want {
+		t.Errorf("BotStatusDescription() = %q, want %q", got, want)
+	}
+}
+
+func TestFindBotComment(t *testing.T) {
+	pages := `[{"id":1,"body":"LGTM"}]` + "\n" +
+		`[{"id":7,"body":"` + BotCommentMarker + `\n## Spectr review"}]`
+	if id, err := findBotComment([]byte(pages)); err != nil || id != 7 {
+		t.Errorf("findBotComment() = %d, %v; want 7", id, err)
+	}
+	if id, err := findBotComment([]byte(`[]`)); err != nil || id != 0 {
+		t.Errorf("findBotComment() without a bot comment = %d, %v", id, err)
+	}
+}
+
+func TestParseBotPR(t *testing.T) {
+	tests := []struct {
+		platform git.Platform
+		output   string
+	}{
+		{git.PlatformGitHub, `{"base":{"sha":"b1"},"head":{"sha":"h1"}}`},
+		{git.PlatformGitLab, `{"diff_refs":{"base_sha":"b1","head_sha":"h1"}}`},
+	}
+	for _, tt := range tests {
+		got, err := parseBotPR(tt.platform, []byte(tt.output))
+		if err != nil || got.baseSHA != "b1" || got.headSHA != "h1" {
+			t.Errorf("parseBotPR(%s) = %+v, %v", tt.platform, got, err)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+// Package pr provides the review checklist of proposal PRs.
+// This file computes the checklist from the change's validation results
+// and the PR's review state, so every box reflects a check spectr ran
+// instead of a promise reviewers tick by hand.
+package pr
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/connerohnesorge/spectr/internal/config"
+	"github.com/connerohnesorge/spectr/internal/parsers"
+	"github.com/connerohnesorge/spectr/internal/validation"
+)
+
+// ChecklistItem is one line of the review checklist.
+type ChecklistItem struct {
+	Label string // What the item checks
+	Done  bool   // Whether the check passed
+	// Detail explains the state, e.g. "2 errors"
+	Detail string
+}
+
+// BuildChecklist returns the review checklist of the change in changeDir:
+//   - validation passes
+//   - the PR is approved
+//   - every ADDED requirement has a task, when the change has tasks
+//   - no frozen requirement is touched, when spectr.yaml freezes any
+//
+// Items follow what validation enforces for the project, so a project
+// without frozen requirements gets no frozen item.
+func BuildChecklist(changeDir string, approved bool) []ChecklistItem {
+	report, err := validation.NewValidator().ValidateChange(changeDir)
+	items := []ChecklistItem{validationItem(report, err)}
+
+	approval := ChecklistItem{Label: "Approvals collected", Done: approved}
+	if !approved {
+		approval.Detail = "waiting for review"
+	}
+	items = append(items, approval)
+
+	if err != nil {
+		return items
+	}
+	if tasks, err := parsers.CountTasks(changeDir); err == nil && tasks.Total > 0 {
+		items = append(items, countItem(
+			"Every added requirement has a task",
+			report,
+			validation.IsUncoveredRequirement,
+			"%d without a task",
+		))
+	}
+	projectRoot := filepath.Dir(filepath.Dir(filepath.Dir(changeDir)))
+	if cfg, err := config.LoadConfig(projectRoot); err == nil && cfg != nil && len(cfg.Frozen) > 0 {
+		items = append(items, frozenItem(report))
+	}
+
+	return items
+}
+
+// validationItem is the item for the change's validation result.
+func validationItem(report *validation.ValidationReport, err error) ChecklistItem {
+	item := ChecklistItem{Label: "Validation passes"}
+	switch {
+	case err != nil:
+		item.Detail = "validation failed to run"
+	case report.Valid:
+		item.Done = true
+	default:
+		item.Detail = plural(report.Summary.Errors, "error")
+	}
+
+	return item
+}
+
+// frozenItem is the item for deltas touching frozen requirements. Touches
+// allowed by an override ticket do not fail it.
+func frozenItem(report *validation.ValidationReport) ChecklistItem {
+	item := ChecklistItem{Label: "No frozen requirements touched", Done: true}
+	blocked, overridden := 0, 0
+	for _, issue := range report.Issues {
+		if !validation.IsFrozenTouch(issue) {
+			continue
+		}
+		if issue.Level == validation.LevelInfo {
+			overridden++
+		} else {
+			blocked++
+		}
+	}
+	switch {
+	case blocked > 0:
+		item.Done = false
+		item.Detail = plural(blocked, "frozen requirement") + " touched"
+	case overridden > 0:
+		item.Detail = plural(overridden, "frozen requirement") + " touched with an override"
+	}
+
+	return item
+}
+
+// countItem is an item that passes when no issue of the report matches.
+func countItem(
+	label string,
+	report *validation.ValidationReport,
+	match func(validation.ValidationIssue) bool,
+	detail string,
+) ChecklistItem {
+	count := 0
+	for _, issue := range report.Issues {
+		if match(issue) {
+			count++
+		}
+	}
+	item := ChecklistItem{Label: label, Done: count == 0}
+	if count > 0 {
+		item.Detail = fmt.Sprintf(detail, count)
+	}
+
+	return item
+}
+
+// plural formats n with noun, adding an s unless n is 1.
+func plural(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("1 %s", noun)
+	}
+
+	return fmt.Sprintf("%d %ss", n, noun)
+}
@@ -0,0 +1,115 @@
+package pr
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeChecklistProject creates a project whose change add-refunds adds a
+// requirement and modifies the frozen requirement Refund Window.
+func writeChecklistProject(t *testing.T, proposal, tasks string) string {
+	t.Helper()
+
+	root := t.TempDir()
+	files := map[string]string{
+		"spectr.yaml": "frozen:\n  - spec: payments\n    requirement: Refund Window\n",
+		"spectr/specs/payments/spec.md": "# Payments\n\n## Purpose\n\nRefunds for orders.\n\n" +
+			"## Requirements\n\n### Requirement: Refund Window\n" +
+			"The system SHALL accept refunds within 30 days.\n\n" +
+			"#### Scenario: Late refund\n- **WHEN** a refund is requested after 30 days\n" +
+			"- **THEN** it is rejected\n",
+		"spectr/changes/add-refunds/proposal.md": proposal,
+		"spectr/changes/add-refunds/tasks.md":    tasks,
+		"spectr/changes/add-refunds/specs/payments/spec.md": "## ADDED Requirements\n\n" +
+			"### Requirement: Partial Refunds\nThe system SHALL refund part of an order.\n\n" +
+			"#### Scenario: Partial refund\n- **WHEN** one item is refunded\n" +
+			"- **THEN** only its price is returned\n\n" +
+			"## MODIFIED Requirements\n\n### Requirement: Refund Window\n" +
+			"The system SHALL accept refunds within 60 days.\n\n" +
+			"#### Scenario: Late refund\n- **WHEN** a refund is requested after 60 days\n" +
+			"- **THEN** it is rejected\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return filepath.Join(root, "spectr", "changes", "add-refunds")
+}
+
+func TestBuildChecklist(t *testing.T) {
+	proposal := "# Change: Refunds\n\n## Why\n\nCustomers return single items.\n\n" +
+		"## What Changes\n\n- Partial refunds\n\n## Impact\n\nPayments.\n"
+	tests := []struct {
+		name     string
+		proposal string
+		tasks    string
+		approved bool
+		want     map[string]bool
+	}{
+		{
+			name:     "failing checks",
+			proposal: proposal,
+			tasks:    "## 1. Implementation\n\n- [ ] 1.1 Build it\n",
+			want: map[string]bool{
+				"Validation passes":                  false,
+				"Approvals collected":                false,
+				"Every added requirement has a task": false,
+				"No frozen requirements touched":     false,
+			},
+		},
+		{
+			name:     "passing checks",
+			proposal: "---\noverride: PAY-12\n---\n\n" + proposal,
+			tasks:    "## 1. Implementation\n\n- [ ] 1.1 Implement `payments#Partial Refunds`\n",
+			approved: true,
+			want: map[string]bool{
+				"Validation passes":                  true,
+				"Approvals collected":                true,
+				"Every added requirement has a task": true,
+				"No frozen requirements touched":     true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changeDir := writeChecklistProject(t, tt.proposal, tt.tasks)
+			items := BuildChecklist(changeDir, tt.approved)
+			if len(items) != len(tt.want) {
+				t.Fatalf("BuildChecklist() = %+v, want %d items", items, len(tt.want))
+			}
+			for _, item := range items {
+				want, ok := tt.want[item.Label]
+				if !ok || item.Done != want {
+					t.Errorf("item %+v, want done = %v", item, want)
+				}
+				if !item.Done && item.Detail == "" {
+					t.Errorf("item %q fails without a detail", item.Label)
+				}
+			}
+		})
+	}
+}
+
+func TestBuildChecklist_NoFrozenOrTasks(t *testing.T) {
+	changeDir := writeChecklistProject(t, "# Change: Refunds\n", "")
+	root := filepath.Dir(filepath.Dir(filepath.Dir(changeDir)))
+	if err := os.Remove(filepath.Join(root, "spectr.yaml")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(filepath.Join(changeDir, "tasks.md")); err != nil {
+		t.Fatal(err)
+	}
+
+	items := BuildChecklist(changeDir, false)
+	if len(items) != 2 {
+		t.Errorf("BuildChecklist() = %+v, want only validation and approvals", items)
+	}
+}
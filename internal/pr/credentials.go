@@ -0,0 +1,59 @@
+package pr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/connerohnesorge/spectr/internal/credentials"
+	"github.com/connerohnesorge/spectr/internal/git"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+// resolveCLIEnv resolves the token for the repository's platform and
+// returns the environment entries that hand it to the platform CLI.
+func resolveCLIEnv(
+	ctx context.Context,
+	platform git.PlatformInfo,
+	flagToken string,
+) []string {
+	var host string
+	if repoURL, err := url.Parse(platform.RepoURL); err == nil {
+		host = repoURL.Host
+	}
+
+	return resolveCLIEnvForHost(ctx, platform.Platform, host, flagToken)
+}
+
+// resolveCLIEnvForHost is resolveCLIEnv for a known host. When no token is
+// found the CLI runs with its own login, so only unexpected resolution
+// failures are reported, as warnings.
+func resolveCLIEnvForHost(
+	ctx context.Context,
+	platform git.Platform,
+	host, flagToken string,
+) []string {
+	provider, ok := credentials.ProviderForPlatform(platform)
+	if !ok {
+		return nil
+	}
+
+	cred, err := credentials.NewResolver().Resolve(
+		ctx,
+		provider,
+		host,
+		flagToken,
+	)
+	if err != nil {
+		var notFound *specterrs.CredentialNotFoundError
+		var hostRequired *specterrs.ProviderHostRequiredError
+		if !errors.As(err, &notFound) && !errors.As(err, &hostRequired) {
+			fmt.Printf("Warning: credential lookup failed: %v\n", err)
+		}
+
+		return nil
+	}
+
+	return provider.CLIEnv(cred.Token)
+}
@@ -13,9 +13,14 @@
 //     including spec delta counts and updated capability information.
 //
 //   - New mode: Creates PRs for new change proposals ready for team review,
-//     including proposal structure and file listings.
+//     including proposal structure, file listings and task progress. Their
+//     body can be regenerated later with UpdatePR, which keeps the text
+//     between KeepStartMarker and KeepEndMarker.
 //
 // Templates use Go's text/template package and are designed to produce
 // conventional commit messages and well-structured PR bodies that integrate
-// with GitHub, GitLab, Gitea, and other git hosting platforms.
+// with GitHub, GitLab, Gitea, and other git hosting platforms. A project
+// replaces them with spectr/templates/pr-body.md.tmpl and pr-commit.txt.tmpl
+// (see package templates), which can extend the defaults through the
+// "spectr/pr-body" and "spectr/pr-commit" templates.
 package pr
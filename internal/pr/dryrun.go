@@ -10,7 +10,7 @@ import (
 // executeDryRun simulates the PR workflow without making changes.
 func executeDryRun(
 	config PRConfig,
-	ctx *workflowContext,
+	wf *workflowContext,
 ) (*PRResult, error) {
 	fmt.Println()
 	fmt.Println("=== DRY RUN MODE ===")
@@ -19,29 +19,29 @@ func executeDryRun(
 	)
 	fmt.Println()
 
-	printWorktreeStep(ctx)
+	printWorktreeStep(wf)
 	printOperationStep(config)
 	printStageStep()
 	printCommitStep(config)
-	printPushStep(ctx)
-	printPRStep(config, ctx)
+	printPushStep(wf)
+	printPRStep(config, wf)
 	printCleanupStep()
 
 	fmt.Println()
 	fmt.Println("=== END DRY RUN ===")
 
 	return &PRResult{
-		BranchName: ctx.branchName,
-		Platform:   ctx.platformInfo.Platform,
+		BranchName: wf.branchName,
+		Platform:   wf.platformInfo.Platform,
 	}, nil
 }
 
 // printWorktreeStep prints the worktree creation step.
-func printWorktreeStep(ctx *workflowContext) {
+func printWorktreeStep(wf *workflowContext) {
 	fmt.Printf(
 		"1. Create worktree on branch: %s (based on %s)\n",
-		ctx.branchName,
-		ctx.baseBranch,
+		wf.branchName,
+		wf.baseBranch,
 	)
 }
 
@@ -87,9 +87,15 @@ func printCommitStep(config PRConfig) {
 		Mode:     config.Mode,
 	}
 
-	commitMsg, _ := RenderCommitMessage(
+	commitMsg, err := RenderProjectCommitMessage(
+		config.ProjectRoot,
 		&commitData,
 	)
+	if err != nil {
+		fmt.Printf("   (template error: %v)\n", err)
+
+		return
+	}
 
 	for _, line := range strings.Split(commitMsg, "\n") {
 		fmt.Printf("   | %s\n", line)
@@ -97,17 +103,17 @@ func printCommitStep(config PRConfig) {
 }
 
 // printPushStep prints the push step.
-func printPushStep(ctx *workflowContext) {
+func printPushStep(wf *workflowContext) {
 	fmt.Printf(
 		"\n6. Push branch: git push -u origin %s\n",
-		ctx.branchName,
+		wf.branchName,
 	)
 }
 
 // printPRStep prints the PR creation step.
 func printPRStep(
 	config PRConfig,
-	ctx *workflowContext,
+	wf *workflowContext,
 ) {
 	prTitle := GetPRTitle(
 		config.ChangeID,
@@ -117,21 +123,43 @@ func printPRStep(
 	fmt.Println("7. Create PR:")
 	fmt.Printf(
 		"   Platform: %s\n",
-		ctx.platformInfo.Platform,
+		wf.platformInfo.Platform,
 	)
 	fmt.Printf(
 		"   CLI tool: %s\n",
-		ctx.platformInfo.CLITool,
+		wf.platformInfo.CLITool,
 	)
 	fmt.Printf("   Title: %s\n", prTitle)
 	fmt.Printf(
 		"   Base: %s\n",
 		strings.TrimPrefix(
-			ctx.baseBranch,
+			wf.baseBranch,
 			"origin/",
 		),
 	)
 	fmt.Printf("   Draft: %v\n", config.Draft)
+	if config.ReviewComments {
+		fmt.Printf(
+			"   Review comments: %d (one per MODIFIED/REMOVED requirement)\n",
+			countRequirementComments(config),
+		)
+	}
+	if config.Mode == ModeRemove {
+		return
+	}
+	if impacts := loadCrossTeamImpact(config); len(impacts) > 0 {
+		owners := impactOwners(impacts)
+		fmt.Printf(
+			"   Cross-team impact: %s\n",
+			strings.Join(owners, ", "),
+		)
+		if config.OwnerReview {
+			fmt.Printf(
+				"   Reviewers: %s\n",
+				strings.Join(reviewerHandles(owners), ", "),
+			)
+		}
+	}
 }
 
 // printCleanupStep prints the cleanup step.
@@ -1,12 +1,15 @@
 package pr
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
-	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/git"
+	"github.com/connerohnesorge/spectr/internal/hostapi"
 )
 
 // dirPerm is the default permission for created directories.
@@ -115,72 +118,53 @@ func copyFile(src, dst string) error {
 
 // stageAndCommit stages the spectr/ directory and creates a commit.
 func stageAndCommit(
+	ctx context.Context,
 	worktreePath, commitMsg string,
 ) error {
 	fmt.Println("Staging changes...")
 
 	// git add spectr/
-	addCmd := exec.Command(
-		"git",
-		"add",
-		"spectr/",
-	)
-	addCmd.Dir = worktreePath
-
-	output, err := addCmd.CombinedOutput()
-	if err != nil {
-		return fmt.Errorf(
-			"git add failed: %s",
-			strings.TrimSpace(string(output)),
-		)
+	if _, err := git.Run(ctx, worktreePath, "add", "spectr/"); err != nil {
+		return err
 	}
 
 	fmt.Println("Creating commit...")
 
 	// git commit
-	commitCmd := exec.Command(
-		"git",
-		"commit",
-		"-m",
-		commitMsg,
-	)
-	commitCmd.Dir = worktreePath
-
-	output, err = commitCmd.CombinedOutput()
-	if err != nil {
-		return fmt.Errorf(
-			"git commit failed: %s",
-			strings.TrimSpace(string(output)),
-		)
-	}
+	_, err := git.Run(ctx, worktreePath, "commit", "-m", commitMsg)
 
-	return nil
+	return err
 }
 
 // pushBranch pushes the worktree's current HEAD to the remote branch.
 // Uses explicit refspec (HEAD:branchName) to ensure the current HEAD is pushed,
 // avoiding ambiguity in worktree contexts where the checked-out branch might differ.
+// Network failures are retried so a flaky connection does not strand the
+// archive commit in the worktree.
 func pushBranch(
+	ctx context.Context,
 	worktreePath, branchName string,
 ) error {
 	fmt.Printf("Pushing branch: %s\n", branchName)
 
-	cmd := exec.Command(
-		"git",
-		"push",
-		"-u",
-		"origin",
-		fmt.Sprintf("HEAD:%s", branchName),
-	)
-	cmd.Dir = worktreePath
-
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return fmt.Errorf(
-			"git push failed: %s",
-			strings.TrimSpace(string(output)),
-		)
-	}
+	return hostapi.Retry(
+		ctx,
+		hostapi.DefaultPolicy,
+		func(ctx context.Context) error {
+			_, err := git.Run(
+				ctx,
+				worktreePath,
+				"push",
+				"-u",
+				"origin",
+				fmt.Sprintf("HEAD:%s", branchName),
+			)
+			if err != nil &&
+				hostapi.IsTransientOutput(git.FailureOutput(err)) {
+				return hostapi.Transient(err)
+			}
 
-	return nil
+			return err
+		},
+	)
 }
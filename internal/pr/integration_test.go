@@ -6,6 +6,7 @@
 package pr
 
 import (
+	"context"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -312,7 +313,7 @@ func TestIntegration_ExecutePR_Archive_DryRun(
 
 	// This will fail because there's no origin remote, but DryRun
 	// should still show intended actions before failing
-	result, err := ExecutePR(config)
+	result, err := ExecutePR(context.Background(), config)
 
 	// We expect an error because there's no origin remote
 	if err == nil {
@@ -406,7 +407,7 @@ func TestIntegration_ExecutePR_Proposal_DryRun(
 		ProjectRoot: repoPath,
 	}
 
-	result, err := ExecutePR(config)
+	result, err := ExecutePR(context.Background(), config)
 
 	// We expect an error because there's no origin remote
 	if err == nil {
@@ -479,7 +480,7 @@ func TestIntegration_ExecutePR_InvalidChangeID(
 		ProjectRoot: repoPath,
 	}
 
-	_, err = ExecutePR(config)
+	_, err = ExecutePR(context.Background(), config)
 
 	// We expect an error about the change not being found
 	if err == nil {
@@ -536,7 +537,7 @@ func TestIntegration_ExecutePR_NoOriginRemote(
 		ProjectRoot: repoPath,
 	}
 
-	_, err = ExecutePR(config)
+	_, err = ExecutePR(context.Background(), config)
 
 	// We expect an error about no origin remote
 	if err == nil {
@@ -622,7 +623,7 @@ func TestIntegration_WorktreeIsolation(
 	}
 
 	// Execute (will fail due to no origin, but should not modify files)
-	_, _ = ExecutePR(config)
+	_, _ = ExecutePR(context.Background(), config)
 
 	// Verify uncommitted file still exists with same content
 	content, err := os.ReadFile(uncommittedFile)
@@ -725,7 +726,7 @@ func TestIntegration_ExecutePR_InvalidMode(
 		ProjectRoot: repoPath,
 	}
 
-	_, err = ExecutePR(config)
+	_, err = ExecutePR(context.Background(), config)
 
 	// We expect an error about invalid mode
 	if err == nil {
@@ -780,7 +781,7 @@ func TestIntegration_ExecutePR_Remove_DryRun(
 		ProjectRoot: repoPath,
 	}
 
-	result, err := ExecutePR(config)
+	result, err := ExecutePR(context.Background(), config)
 
 	// We expect an error because there's no origin remote
 	if err == nil {
@@ -0,0 +1,183 @@
+// Package pr provides cross-team impact detection for pull requests.
+// This file maps a change's delta specs to the teams that own them (the
+// owners list in spectr.yaml) so PR bodies can call out other teams.
+package pr
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/config"
+	"github.com/connerohnesorge/spectr/internal/domain"
+	"github.com/connerohnesorge/spectr/internal/fileio"
+	"github.com/connerohnesorge/spectr/internal/parsers"
+)
+
+// OwnerImpact lists the requirements a change touches in specs owned by a
+// team other than the change's own.
+type OwnerImpact struct {
+	Owner string       // Owning team, e.g. "@acme/payments"
+	Specs []SpecImpact // Touched specs owned by Owner, sorted by ID
+}
+
+// SpecImpact lists the touched requirements of one spec.
+type SpecImpact struct {
+	Spec         string   // Spec ID, e.g. "payments/refunds"
+	Requirements []string // e.g. "MODIFIED Refund Window"
+}
+
+// crossTeamImpact returns the owners, other than the change's owner, of
+// specs the change's deltas touch. The change owner comes from the owner
+// field of its proposal frontmatter; without one every owned spec counts.
+// Returns nil when spectr.yaml assigns no owners.
+func crossTeamImpact(projectRoot, changeID string) ([]OwnerImpact, error) {
+	cfg, err := config.LoadConfig(projectRoot)
+	if err != nil {
+		return nil, err
+	}
+	if cfg == nil || len(cfg.Owners) == 0 {
+		return nil, nil
+	}
+
+	changeDir := filepath.Join(projectRoot, "spectr", "changes", changeID)
+	changeOwner := ""
+	meta, err := domain.ParseProposalFrontmatterFromFile(
+		filepath.Join(changeDir, "proposal.md"),
+	)
+	if err == nil {
+		changeOwner = meta.Owner
+	}
+
+	touched, err := touchedRequirements(filepath.Join(changeDir, "specs"))
+	if err != nil {
+		return nil, err
+	}
+
+	byOwner := make(map[string][]SpecImpact)
+	for _, spec := range touched {
+		owner := cfg.SpecOwner(spec.Spec)
+		if owner == "" || owner == changeOwner {
+			continue
+		}
+		byOwner[owner] = append(byOwner[owner], spec)
+	}
+
+	impacts := make([]OwnerImpact, 0, len(byOwner))
+	for owner, specs := range byOwner {
+		sort.Slice(specs, func(i, j int) bool {
+			return specs[i].Spec < specs[j].Spec
+		})
+		impacts = append(impacts, OwnerImpact{Owner: owner, Specs: specs})
+	}
+	sort.Slice(impacts, func(i, j int) bool {
+		return impacts[i].Owner < impacts[j].Owner
+	})
+
+	return impacts, nil
+}
+
+// touchedRequirements parses every delta spec under deltaDir, including
+// nested capability directories, and lists each spec's delta operations.
+func touchedRequirements(deltaDir string) ([]SpecImpact, error) {
+	var specs []SpecImpact
+	err := fileio.WalkDir(
+		deltaDir,
+		func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) && path == deltaDir {
+					return filepath.SkipDir
+				}
+
+				return err
+			}
+			if entry.IsDir() || entry.Name() != "spec.md" {
+				return nil
+			}
+
+			rel, err := filepath.Rel(deltaDir, filepath.Dir(path))
+			if err != nil {
+				return err
+			}
+
+			plan, err := parsers.ParseDeltaSpec(path)
+			if err != nil {
+				return fmt.Errorf("parse %s: %w", path, err)
+			}
+
+			requirements := deltaOperations(plan)
+			if len(requirements) > 0 {
+				specs = append(specs, SpecImpact{
+					Spec:         filepath.ToSlash(rel),
+					Requirements: requirements,
+				})
+			}
+
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return specs, nil
+}
+
+// deltaOperations renders a delta plan as "OPERATION Requirement" lines.
+func deltaOperations(plan *parsers.DeltaPlan) []string {
+	var ops []string
+	for _, req := range plan.Added {
+		ops = append(ops, "ADDED "+req.Name)
+	}
+	for _, req := range plan.Modified {
+		ops = append(ops, "MODIFIED "+req.Name)
+	}
+	for _, name := range plan.Removed {
+		ops = append(ops, "REMOVED "+name)
+	}
+	for _, op := range plan.Renamed {
+		ops = append(ops, fmt.Sprintf("RENAMED %s → %s", op.From, op.To))
+	}
+
+	return ops
+}
+
+// loadCrossTeamImpact is crossTeamImpact for the PR workflow: failures
+// only cost the PR body its cross-team section, so they are printed as a
+// warning instead of aborting a PR whose branch is already pushed.
+func loadCrossTeamImpact(prConfig PRConfig) []OwnerImpact {
+	impacts, err := crossTeamImpact(prConfig.ProjectRoot, prConfig.ChangeID)
+	if err != nil {
+		fmt.Printf("Warning: cross-team impact skipped: %v\n", err)
+
+		return nil
+	}
+
+	return impacts
+}
+
+// impactOwners returns the owners in impacts.
+func impactOwners(impacts []OwnerImpact) []string {
+	owners := make([]string, len(impacts))
+	for i, impact := range impacts {
+		owners[i] = impact.Owner
+	}
+
+	return owners
+}
+
+// reviewerHandles turns owners into reviewer handles for the platform
+// CLIs, which take "acme/payments" rather than "@acme/payments".
+func reviewerHandles(owners []string) []string {
+	handles := make([]string, 0, len(owners))
+	for _, owner := range owners {
+		if handle := strings.TrimPrefix(owner, "@"); handle != "" {
+			handles = append(handles, handle)
+		}
+	}
+
+	return handles
+}
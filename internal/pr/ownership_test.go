@@ -0,0 +1,110 @@
+package pr
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestCrossTeamImpact(t *testing.T) {
+	root := t.TempDir()
+	writeFile := func(rel, content string) {
+		t.Helper()
+		path := filepath.Join(root, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	writeFile("spectr.yaml", `owners:
+  - spec: payments
+    owner: "@acme/payments"
+  - spec: auth
+    owner: "@acme/identity"
+  - spec: notifications
+    owner: "@acme/growth"
+`)
+
+	change := "spectr/changes/add-refunds/"
+	writeFile(change+"proposal.md", "---\nowner: \"@acme/growth\"\n---\n# Change: Add refunds\n")
+	writeFile(change+"specs/payments/refunds/spec.md", `## MODIFIED Requirements
+
+### Requirement: Refund Window
+The system SHALL allow refunds within 30 days.
+
+#### Scenario: Refund
+- **WHEN** a refund is requested
+- **THEN** it is accepted
+
+## REMOVED Requirements
+
+### Requirement: Store Credit
+`)
+	writeFile(change+"specs/auth/spec.md", `## RENAMED Requirements
+
+- FROM: `+"`### Requirement: Login`"+`
+- TO: `+"`### Requirement: Sign In`"+`
+`)
+	// Owned by the change's own team, so not cross-team
+	writeFile(change+"specs/notifications/spec.md", `## ADDED Requirements
+
+### Requirement: Refund Notice
+The system SHALL notify users of refunds.
+
+#### Scenario: Notice
+- **WHEN** a refund completes
+- **THEN** the user is notified
+`)
+
+	got, err := crossTeamImpact(root, "add-refunds")
+	if err != nil {
+		t.Fatalf("crossTeamImpact: %v", err)
+	}
+
+	want := []OwnerImpact{
+		{
+			Owner: "@acme/identity",
+			Specs: []SpecImpact{{
+				Spec:         "auth",
+				Requirements: []string{"RENAMED Login → Sign In"},
+			}},
+		},
+		{
+			Owner: "@acme/payments",
+			Specs: []SpecImpact{{
+				Spec: "payments/refunds",
+				Requirements: []string{
+					"MODIFIED Refund Window",
+					"REMOVED Store Credit",
+				},
+			}},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("crossTeamImpact() =\n%+v\nwant\n%+v", got, want)
+	}
+
+	if owners := reviewerHandles(impactOwners(got)); !reflect.DeepEqual(
+		owners,
+		[]string{"acme/identity", "acme/payments"},
+	) {
+		t.Errorf("reviewerHandles() = %v", owners)
+	}
+}
+
+func TestCrossTeamImpact_NoOwners(t *testing.T) {
+	root := t.TempDir()
+	deltaDir := filepath.Join(root, "spectr", "changes", "c", "specs", "auth")
+	if err := os.MkdirAll(deltaDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := crossTeamImpact(root, "c")
+	if err != nil || got != nil {
+		t.Errorf("crossTeamImpact() = %v, %v; want nil, nil", got, err)
+	}
+}
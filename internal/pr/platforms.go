@@ -5,12 +5,13 @@
 package pr
 
 import (
+	"context"
 	"fmt"
 	"os"
-	"os/exec"
 	"strings"
 
 	"github.com/connerohnesorge/spectr/internal/git"
+	"github.com/connerohnesorge/spectr/internal/hostapi"
 	"github.com/connerohnesorge/spectr/internal/specterrs"
 )
 
@@ -23,6 +24,8 @@ type prCreateArgs struct {
 	body         string
 	draft        bool
 	worktreePath string
+	env          []string // Token handed to the platform CLI
+	reviewers    []string // Reviewers to request, e.g. "acme/payments"
 }
 
 // prResult holds the result of a PR creation operation.
@@ -41,6 +44,8 @@ type createPRInput struct {
 	body         string
 	draft        bool
 	worktreePath string
+	env          []string
+	reviewers    []string
 }
 
 // createPR creates a pull request using the appropriate platform CLI.
@@ -49,6 +54,7 @@ type createPRInput struct {
 //
 //nolint:revive // argument-limit - kept for API compatibility
 func createPR(
+	ctx context.Context,
 	platform *git.PlatformInfo,
 	branchName, baseBranch, title, body string,
 	draft bool,
@@ -64,11 +70,12 @@ func createPR(
 		worktreePath: worktreePath,
 	}
 
-	return doCreatePR(input)
+	return doCreatePR(ctx, input)
 }
 
 // doCreatePR is the internal implementation of createPR.
 func doCreatePR(
+	ctx context.Context,
 	input *createPRInput,
 ) (prURL, manualURL string, err error) {
 	args := prCreateArgs{
@@ -78,9 +85,12 @@ func doCreatePR(
 		body:         input.body,
 		draft:        input.draft,
 		worktreePath: input.worktreePath,
+		env:          input.env,
+		reviewers:    input.reviewers,
 	}
 
 	result, err := createPRForPlatform(
+		ctx,
 		&input.platform,
 		&args,
 	)
@@ -94,20 +104,25 @@ func doCreatePR(
 // createPRForPlatform dispatches to the platform-specific PR creator.
 // Returns an error for unknown or unsupported platforms.
 func createPRForPlatform(
+	ctx context.Context,
 	platform *git.PlatformInfo,
 	args *prCreateArgs,
 ) (*prResult, error) {
 	switch platform.Platform {
 	case git.PlatformGitHub:
-		return createGitHubPR(args)
+		return createGitHubPR(ctx, args)
 
 	case git.PlatformGitLab:
-		return createGitLabMR(args)
+		return createGitLabMR(ctx, args)
 
 	case git.PlatformGitea:
-		return createGiteaPR(args)
+		printManualReviewers(args.reviewers)
+
+		return createGiteaPR(ctx, args)
 
 	case git.PlatformBitbucket:
+		printManualReviewers(args.reviewers)
+
 		return createBitbucketPR(platform, args)
 
 	case git.PlatformUnknown:
@@ -125,6 +140,7 @@ func createPRForPlatform(
 // createGitHubPR creates a GitHub pull request using the gh CLI.
 // It writes the PR body to a temp file and uses --body-file.
 func createGitHubPR(
+	ctx context.Context,
 	args *prCreateArgs,
 ) (*prResult, error) {
 	fmt.Println("Creating GitHub pull request...")
@@ -147,15 +163,27 @@ func createGitHubPR(
 	if args.draft {
 		cmdArgs = append(cmdArgs, "--draft")
 	}
+	if len(args.reviewers) > 0 {
+		cmdArgs = append(
+			cmdArgs,
+			"--reviewer", strings.Join(args.reviewers, ","),
+		)
+	}
 
-	cmd := exec.Command("gh", cmdArgs...)
-	cmd.Dir = args.worktreePath
-
-	output, err := cmd.CombinedOutput()
+	output, err := hostapi.RunCLI(
+		ctx,
+		hostapi.DefaultPolicy,
+		hostapi.CLICommand{
+			Dir:  args.worktreePath,
+			Env:  args.env,
+			Name: "gh",
+			Args: cmdArgs,
+		},
+	)
 	if err != nil {
 		return nil, fmt.Errorf(
 			"gh pr create failed: %s",
-			strings.TrimSpace(string(output)),
+			commandErrorOutput(err),
 		)
 	}
 
@@ -167,6 +195,7 @@ func createGitHubPR(
 // createGitLabMR creates a GitLab merge request using the glab CLI.
 // GitLab uses --description instead of --body-file.
 func createGitLabMR(
+	ctx context.Context,
 	args *prCreateArgs,
 ) (*prResult, error) {
 	fmt.Println(
@@ -184,15 +213,27 @@ func createGitLabMR(
 	if args.draft {
 		cmdArgs = append(cmdArgs, "--draft")
 	}
+	if len(args.reviewers) > 0 {
+		cmdArgs = append(
+			cmdArgs,
+			"--reviewer", strings.Join(args.reviewers, ","),
+		)
+	}
 
-	cmd := exec.Command("glab", cmdArgs...)
-	cmd.Dir = args.worktreePath
-
-	output, err := cmd.CombinedOutput()
+	output, err := hostapi.RunCLI(
+		ctx,
+		hostapi.DefaultPolicy,
+		hostapi.CLICommand{
+			Dir:  args.worktreePath,
+			Env:  args.env,
+			Name: "glab",
+			Args: cmdArgs,
+		},
+	)
 	if err != nil {
 		return nil, fmt.Errorf(
 			"glab mr create failed: %s",
-			strings.TrimSpace(string(output)),
+			commandErrorOutput(err),
 		)
 	}
 
@@ -206,6 +247,7 @@ func createGitLabMR(
 // createGiteaPR creates a Gitea pull request using the tea CLI.
 // Gitea uses --description and --base for the target branch.
 func createGiteaPR(
+	ctx context.Context,
 	args *prCreateArgs,
 ) (*prResult, error) {
 	fmt.Println("Creating Gitea pull request...")
@@ -218,14 +260,20 @@ func createGiteaPR(
 		"--head", args.branchName,
 	}
 
-	cmd := exec.Command("tea", cmdArgs...)
-	cmd.Dir = args.worktreePath
-
-	output, err := cmd.CombinedOutput()
+	output, err := hostapi.RunCLI(
+		ctx,
+		hostapi.DefaultPolicy,
+		hostapi.CLICommand{
+			Dir:  args.worktreePath,
+			Env:  args.env,
+			Name: "tea",
+			Args: cmdArgs,
+		},
+	)
 	if err != nil {
 		return nil, fmt.Errorf(
 			"tea pr create failed: %s",
-			strings.TrimSpace(string(output)),
+			commandErrorOutput(err),
 		)
 	}
 
@@ -263,6 +311,19 @@ func createBitbucketPR(
 	}, nil
 }
 
+// printManualReviewers tells the user whom to request review from on
+// platforms whose PR creation cannot request reviewers.
+func printManualReviewers(reviewers []string) {
+	if len(reviewers) == 0 {
+		return
+	}
+
+	fmt.Printf(
+		"Request review manually from: %s\n",
+		strings.Join(reviewers, ", "),
+	)
+}
+
 // writeTempBodyFile writes the PR body to a temporary file.
 // The caller is responsible for removing the file when done.
 func writeTempBodyFile(
@@ -0,0 +1,311 @@
+// Package pr provides requirement-level review comments for pull requests.
+// This file posts one review thread per MODIFIED or REMOVED requirement so
+// that reviewers discuss each requirement change in its own thread.
+package pr
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/git"
+	"github.com/connerohnesorge/spectr/internal/hostapi"
+	"github.com/connerohnesorge/spectr/internal/markdown"
+	"github.com/connerohnesorge/spectr/internal/parsers"
+)
+
+// RequirementComment is a review comment about one requirement change.
+type RequirementComment struct {
+	Operation   string // "MODIFIED" or "REMOVED"
+	Spec        string // Capability the requirement belongs to
+	Requirement string // Requirement name
+	Path        string // Repository path of the delta spec in the PR
+	Body        string // Markdown body of the comment
+}
+
+// requirementCommentsInput bundles what is needed to build comments.
+type requirementCommentsInput struct {
+	changeDir  string // Local change directory holding the delta specs
+	prSpecsDir string // Repository path of the delta specs in the PR
+	repoURL    string // Web URL of the repository
+	platform   git.Platform
+	baseBranch string // Branch the spec anchors link to
+}
+
+// buildRequirementComments builds one comment per MODIFIED and REMOVED
+// requirement in the change's delta specs, ordered by spec.
+func buildRequirementComments(
+	input requirementCommentsInput,
+) ([]RequirementComment, error) {
+	deltaDir := filepath.Join(input.changeDir, "specs")
+	entries, err := os.ReadDir(deltaDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("read %s: %w", deltaDir, err)
+	}
+
+	var specIDs []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			specIDs = append(specIDs, entry.Name())
+		}
+	}
+	sort.Strings(specIDs)
+
+	var comments []RequirementComment
+	for _, specID := range specIDs {
+		deltaPath := filepath.Join(deltaDir, specID, "spec.md")
+		if _, err := os.Stat(deltaPath); err != nil {
+			continue
+		}
+
+		plan, err := parsers.ParseDeltaSpec(deltaPath)
+		if err != nil {
+			return nil, fmt.Errorf("parse %s: %w", deltaPath, err)
+		}
+		removed, err := parsers.ParseRemovedBlocks(deltaPath)
+		if err != nil {
+			return nil, fmt.Errorf("parse %s: %w", deltaPath, err)
+		}
+
+		for _, op := range []struct {
+			name   string
+			blocks []parsers.RequirementBlock
+		}{
+			{"MODIFIED", plan.Modified},
+			{"REMOVED", removed},
+		} {
+			for _, req := range op.blocks {
+				comments = append(comments, RequirementComment{
+					Operation:   op.name,
+					Spec:        specID,
+					Requirement: req.Name,
+					Path:        path.Join(input.prSpecsDir, specID, "spec.md"),
+					Body: formatRequirementComment(
+						op.name,
+						specID,
+						req,
+						specAnchorURL(input, specID, req.Name),
+					),
+				})
+			}
+		}
+	}
+
+	return comments, nil
+}
+
+// formatRequirementComment renders the comment body: a heading linking to
+// the requirement in the current spec, followed by the quoted delta.
+func formatRequirementComment(
+	operation, specID string,
+	req parsers.RequirementBlock,
+	anchorURL string,
+) string {
+	var sb strings.Builder
+	fmt.Fprintf(
+		&sb,
+		"**%s** requirement in `%s`: [%s](%s)\n\n",
+		operation,
+		specID,
+		req.Name,
+		anchorURL,
+	)
+
+	for _, line := range strings.Split(strings.TrimRight(req.Raw, "\n"), "\n") {
+		if line == "" {
+			sb.WriteString(">\n")
+
+			continue
+		}
+		fmt.Fprintf(&sb, "> %s\n", line)
+	}
+
+	return sb.String()
+}
+
+// specAnchorURL links to a requirement in the spec on the base branch.
+func specAnchorURL(
+	input requirementCommentsInput,
+	specID, requirement string,
+) string {
+	blob := "blob"
+	if input.platform == git.PlatformGitLab {
+		blob = "-/blob"
+	}
+
+	return fmt.Sprintf(
+		"%s/%s/%s/spectr/specs/%s/spec.md#%s",
+		input.repoURL,
+		blob,
+		input.baseBranch,
+		specID,
+		markdown.HeadingAnchor("Requirement: "+requirement),
+	)
+}
+
+// postRequirementComments posts each comment as its own review thread on
+// the pull request. GitHub comments are attached to the delta spec file at
+// headSHA; GitLab comments open a resolvable discussion.
+func postRequirementComments(
+	ctx context.Context,
+	ref git.PullRequestRef,
+	headSHA string,
+	comments []RequirementComment,
+	env []string,
+) error {
+	for _, comment := range comments {
+		var args []string
+		switch ref.Platform {
+		case git.PlatformGitHub:
+			args = []string{
+				"gh", "api",
+				"--hostname", ref.Host,
+				"-X", "POST",
+				fmt.Sprintf(
+					"repos/%s/pulls/%d/comments",
+					ref.ProjectPath(),
+					ref.Number,
+				),
+				"-f", "body=" + comment.Body,
+				"-f", "commit_id=" + headSHA,
+				"-f", "path=" + comment.Path,
+				"-f", "subject_type=file",
+			}
+
+		case git.PlatformGitLab:
+			args = []string{
+				"glab", "api",
+				"--hostname", ref.Host,
+				"-X", "POST",
+				fmt.Sprintf(
+					"projects/%s/merge_requests/%d/discussions",
+					url.PathEscape(ref.ProjectPath()),
+					ref.Number,
+				),
+				"-f", "body=" + comment.Body,
+			}
+
+		case git.PlatformGitea, git.PlatformBitbucket, git.PlatformUnknown:
+			return fmt.Errorf(
+				"requirement review comments are not supported for %s",
+				ref.Platform,
+			)
+		}
+
+		_, err := hostapi.RunCLI(
+			ctx,
+			hostapi.DefaultPolicy,
+			hostapi.CLICommand{Env: env, Name: args[0], Args: args[1:]},
+		)
+		if err != nil {
+			return fmt.Errorf(
+				"post comment for %s/%s: %s",
+				comment.Spec,
+				comment.Requirement,
+				commandErrorOutput(err),
+			)
+		}
+	}
+
+	return nil
+}
+
+// postReviewComments builds and posts requirement review comments for a
+// created PR. Failures are reported as warnings: the PR itself exists.
+func postReviewComments(
+	ctx context.Context,
+	config PRConfig,
+	wf *workflowContext,
+	result *PRResult,
+	worktreePath string,
+) {
+	ref, err := git.ParsePullRequestURL(result.PRURL)
+	if err != nil {
+		fmt.Printf("Warning: review comments skipped: %v\n", err)
+
+		return
+	}
+	ref.Platform = wf.platformInfo.Platform
+
+	prSpecsDir := path.Join("spectr", "changes", config.ChangeID, "specs")
+	if result.ArchivePath != "" {
+		prSpecsDir = path.Join(filepath.ToSlash(result.ArchivePath), "specs")
+	}
+
+	comments, err := buildRequirementComments(requirementCommentsInput{
+		changeDir:  localChangeDir(config),
+		prSpecsDir: prSpecsDir,
+		repoURL:    wf.platformInfo.RepoURL,
+		platform:   wf.platformInfo.Platform,
+		baseBranch: strings.TrimPrefix(wf.baseBranch, "origin/"),
+	})
+	if err != nil {
+		fmt.Printf("Warning: review comments skipped: %v\n", err)
+
+		return
+	}
+	if len(comments) == 0 {
+		return
+	}
+
+	headSHA, err := headCommit(ctx, worktreePath)
+	if err != nil {
+		fmt.Printf("Warning: review comments skipped: %v\n", err)
+
+		return
+	}
+
+	fmt.Printf(
+		"Posting %d requirement review comment(s)...\n",
+		len(comments),
+	)
+	err = postRequirementComments(ctx, ref, headSHA, comments, wf.cliEnv)
+	if err != nil {
+		fmt.Printf("Warning: %v\n", err)
+	}
+}
+
+// headCommit returns the commit checked out in a worktree.
+func headCommit(
+	ctx context.Context,
+	worktreePath string,
+) (string, error) {
+	output, err := git.Run(ctx, worktreePath, "rev-parse", "HEAD")
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
+
+// localChangeDir returns the change directory in the main working tree.
+func localChangeDir(config PRConfig) string {
+	return filepath.Join(
+		config.ProjectRoot,
+		"spectr",
+		"changes",
+		config.ChangeID,
+	)
+}
+
+// countRequirementComments returns how many requirement comments a change
+// would produce, for dry-run output.
+func countRequirementComments(config PRConfig) int {
+	comments, err := buildRequirementComments(requirementCommentsInput{
+		changeDir: localChangeDir(config),
+	})
+	if err != nil {
+		return 0
+	}
+
+	return len(comments)
+}
@@ -0,0 +1,120 @@
+package pr
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/connerohnesorge/spectr/internal/git"
+)
+
+func TestBuildRequirementComments(t *testing.T) {
+	changeDir := t.TempDir()
+	specDir := filepath.Join(changeDir, "specs", "auth")
+	if err := os.MkdirAll(specDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	delta := `## ADDED Requirements
+
+### Requirement: Sessions
+The system SHALL keep sessions.
+
+## MODIFIED Requirements
+
+### Requirement: User Login
+The system SHALL require two factors.
+
+#### Scenario: Login
+- **WHEN** a user logs in
+- **THEN** a second factor is requested
+
+## REMOVED Requirements
+
+### Requirement: Legacy Tokens
+**Reason**: Replaced by sessions
+`
+	if err := os.WriteFile(
+		filepath.Join(specDir, "spec.md"),
+		[]byte(delta),
+		0o644,
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		platform git.Platform
+		wantLink string
+	}{
+		{
+			name:     "github",
+			platform: git.PlatformGitHub,
+			wantLink: "https://github.com/o/r/blob/main/spectr/specs/auth/spec.md#requirement-user-login",
+		},
+		{
+			name:     "gitlab",
+			platform: git.PlatformGitLab,
+			wantLink: "https://github.com/o/r/-/blob/main/spectr/specs/auth/spec.md#requirement-user-login",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			comments, err := buildRequirementComments(
+				requirementCommentsInput{
+					changeDir:  changeDir,
+					prSpecsDir: "spectr/changes/c/specs",
+					repoURL:    "https://github.com/o/r",
+					platform:   tt.platform,
+					baseBranch: "main",
+				},
+			)
+			if err != nil {
+				t.Fatalf("buildRequirementComments: %v", err)
+			}
+			if len(comments) != 2 {
+				t.Fatalf("got %d comments, want 2", len(comments))
+			}
+
+			modified := comments[0]
+			if modified.Operation != "MODIFIED" ||
+				modified.Requirement != "User Login" {
+				t.Errorf("unexpected first comment: %+v", modified)
+			}
+			if modified.Path != "spectr/changes/c/specs/auth/spec.md" {
+				t.Errorf("Path = %q", modified.Path)
+			}
+			if !strings.Contains(modified.Body, "("+tt.wantLink+")") {
+				t.Errorf("body missing link %q:\n%s", tt.wantLink, modified.Body)
+			}
+			if !strings.Contains(
+				modified.Body,
+				"> ### Requirement: User Login\n> The system SHALL require two factors.\n>\n> #### Scenario: Login\n",
+			) {
+				t.Errorf("body does not quote the delta:\n%s", modified.Body)
+			}
+
+			removed := comments[1]
+			if removed.Operation != "REMOVED" ||
+				removed.Requirement != "Legacy Tokens" {
+				t.Errorf("unexpected second comment: %+v", removed)
+			}
+			if !strings.Contains(removed.Body, "> **Reason**: Replaced by sessions") {
+				t.Errorf("removed body missing reason:\n%s", removed.Body)
+			}
+		})
+	}
+}
+
+func TestBuildRequirementComments_NoSpecs(t *testing.T) {
+	comments, err := buildRequirementComments(
+		requirementCommentsInput{changeDir: t.TempDir()},
+	)
+	if err != nil {
+		t.Fatalf("buildRequirementComments: %v", err)
+	}
+	if len(comments) != 0 {
+		t.Errorf("got %d comments, want 0", len(comments))
+	}
+}
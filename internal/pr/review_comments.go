@@ -0,0 +1,277 @@
+// Package pr provides review comment retrieval for pull requests.
+// This file fetches unresolved review threads via gh (GitHub) and
+// glab (GitLab).
+package pr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/git"
+	"github.com/connerohnesorge/spectr/internal/hostapi"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+// ReviewComment is the first comment of an unresolved review thread.
+type ReviewComment struct {
+	Author string // Login/username of the comment author
+	Body   string // Markdown body of the comment
+	Path   string // File the comment is attached to, if any
+	Line   int    // Line the comment is attached to, if any
+	URL    string // Web URL linking back to the comment
+}
+
+// githubReviewThreadsQuery fetches review threads of a pull request along
+// with the first comment of each thread.
+const githubReviewThreadsQuery = `query($owner: String!, $repo: String!, $number: Int!) {
+  repository(owner: $owner, name: $repo) {
+    pullRequest(number: $number) {
+      reviewThreads(first: 100) {
+        nodes {
+          isResolved
+          path
+          line
+          comments(first: 1) {
+            nodes { author { login } body url }
+          }
+        }
+      }
+    }
+  }
+}`
+
+// FetchUnresolvedReviewComments returns the opening comment of every
+// unresolved review thread on a pull request, using the platform CLI with
+// the token resolved from the environment or stored credentials.
+func FetchUnresolvedReviewComments(
+	ctx context.Context,
+	ref git.PullRequestRef,
+) ([]ReviewComment, error) {
+	env := resolveCLIEnvForHost(ctx, ref.Platform, ref.Host, "")
+
+	switch ref.Platform {
+	case git.PlatformGitHub:
+		return fetchGitHubReviewComments(ctx, ref, env)
+
+	case git.PlatformGitLab:
+		return fetchGitLabReviewComments(ctx, ref, env)
+
+	case git.PlatformGitea, git.PlatformBitbucket, git.PlatformUnknown:
+		return nil, fmt.Errorf(
+			"fetching review comments is not supported for %s",
+			ref.Platform,
+		)
+	}
+
+	return nil, &specterrs.UnknownPlatformError{
+		Platform: string(ref.Platform),
+	}
+}
+
+// fetchGitHubReviewComments queries review threads through gh api graphql.
+func fetchGitHubReviewComments(
+	ctx context.Context,
+	ref git.PullRequestRef,
+	env []string,
+) ([]ReviewComment, error) {
+	output, err := hostapi.RunCLI(
+		ctx,
+		hostapi.DefaultPolicy,
+		hostapi.CLICommand{
+			Env:  env,
+			Name: "gh",
+			Args: []string{
+				"api", "graphql",
+				"--hostname", ref.Host,
+				"-f", "query=" + githubReviewThreadsQuery,
+				"-F", "owner=" + ref.Owner,
+				"-F", "repo=" + ref.Repo,
+				"-F", "number=" + strconv.Itoa(ref.Number),
+			},
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"gh api graphql failed: %s",
+			commandErrorOutput(err),
+		)
+	}
+
+	return parseGitHubReviewThreads(output)
+}
+
+// parseGitHubReviewThreads extracts unresolved comments from the
+// GraphQL response of githubReviewThreadsQuery.
+func parseGitHubReviewThreads(
+	data []byte,
+) ([]ReviewComment, error) {
+	var resp struct {
+		Data struct {
+			Repository struct {
+				PullRequest struct {
+					ReviewThreads struct {
+						Nodes []struct {
+							IsResolved bool   `json:"isResolved"`
+							Path       string `json:"path"`
+							Line       int    `json:"line"`
+							Comments   struct {
+								Nodes []struct {
+									Author struct {
+										Login string `json:"login"`
+									} `json:"author"`
+									Body string `json:"body"`
+									URL  string `json:"url"`
+								} `json:"nodes"`
+							} `json:"comments"`
+						} `json:"nodes"`
+					} `json:"reviewThreads"`
+				} `json:"pullRequest"`
+			} `json:"repository"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf(
+			"failed to parse review threads: %w",
+			err,
+		)
+	}
+
+	threads := resp.Data.Repository.PullRequest.ReviewThreads.Nodes
+	comments := make([]ReviewComment, 0, len(threads))
+	for _, thread := range threads {
+		if thread.IsResolved || len(thread.Comments.Nodes) == 0 {
+			continue
+		}
+		first := thread.Comments.Nodes[0]
+		comments = append(comments, ReviewComment{
+			Author: first.Author.Login,
+			Body:   first.Body,
+			Path:   thread.Path,
+			Line:   thread.Line,
+			URL:    first.URL,
+		})
+	}
+
+	return comments, nil
+}
+
+// fetchGitLabReviewComments lists merge request discussions through
+// glab api.
+func fetchGitLabReviewComments(
+	ctx context.Context,
+	ref git.PullRequestRef,
+	env []string,
+) ([]ReviewComment, error) {
+	endpoint := fmt.Sprintf(
+		"projects/%s/merge_requests/%d/discussions?per_page=100",
+		url.PathEscape(ref.ProjectPath()),
+		ref.Number,
+	)
+
+	output, err := hostapi.RunCLI(
+		ctx,
+		hostapi.DefaultPolicy,
+		hostapi.CLICommand{
+			Env:  env,
+			Name: "glab",
+			Args: []string{"api", endpoint, "--hostname", ref.Host},
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"glab api failed: %s",
+			commandErrorOutput(err),
+		)
+	}
+
+	mrURL := fmt.Sprintf(
+		"https://%s/%s/-/merge_requests/%d",
+		ref.Host,
+		ref.ProjectPath(),
+		ref.Number,
+	)
+
+	return parseGitLabDiscussions(output, mrURL)
+}
+
+// parseGitLabDiscussions extracts unresolved comments from a GitLab
+// discussions response. Notes that are not resolvable (e.g. system notes
+// and plain MR comments) are skipped.
+func parseGitLabDiscussions(
+	data []byte,
+	mrURL string,
+) ([]ReviewComment, error) {
+	var discussions []struct {
+		Notes []struct {
+			ID     int `json:"id"`
+			Author struct {
+				Username string `json:"username"`
+			} `json:"author"`
+			Body       string `json:"body"`
+			Resolvable bool   `json:"resolvable"`
+			Resolved   bool   `json:"resolved"`
+			Position   *struct {
+				NewPath string `json:"new_path"`
+				NewLine int    `json:"new_line"`
+			} `json:"position"`
+		} `json:"notes"`
+	}
+	if err := json.Unmarshal(data, &discussions); err != nil {
+		return nil, fmt.Errorf(
+			"failed to parse discussions: %w",
+			err,
+		)
+	}
+
+	comments := make([]ReviewComment, 0, len(discussions))
+	for _, discussion := range discussions {
+		if len(discussion.Notes) == 0 {
+			continue
+		}
+		first := discussion.Notes[0]
+		if !first.Resolvable || first.Resolved {
+			continue
+		}
+
+		comment := ReviewComment{
+			Author: first.Author.Username,
+			Body:   first.Body,
+			URL:    fmt.Sprintf("%s#note_%d", mrURL, first.ID),
+		}
+		if first.Position != nil {
+			comment.Path = first.Position.NewPath
+			comment.Line = first.Position.NewLine
+		}
+		comments = append(comments, comment)
+	}
+
+	return comments, nil
+}
+
+// commandErrorOutput returns the stderr of a failed command, falling back
+// to the error message. Commands that were retried note the attempt count.
+func commandErrorOutput(err error) string {
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) || len(exitErr.Stderr) == 0 {
+		return err.Error()
+	}
+
+	output := strings.TrimSpace(string(exitErr.Stderr))
+
+	var exhausted *specterrs.RetriesExhaustedError
+	if errors.As(err, &exhausted) {
+		return fmt.Sprintf(
+			"%s (after %d attempts)",
+			output,
+			exhausted.Attempts,
+		)
+	}
+
+	return output
+}
@@ -0,0 +1,130 @@
+package pr
+
+import (
+	"context"
+	"testing"
+
+	"github.com/connerohnesorge/spectr/internal/git"
+)
+
+func TestParseGitHubReviewThreads(t *testing.T) {
+	data := []byte(`{
+  "data": {
+    "repository": {
+      "pullRequest": {
+        "reviewThreads": {
+          "nodes": [
+            {
+              "isResolved": false,
+              "path": "cmd/root.go",
+              "line": 12,
+              "comments": {"nodes": [{
+                "author": {"login": "alice"},
+                "body": "Please add a test",
+                "url": "https://github.com/o/r/pull/1#discussion_r1"
+              }]}
+            },
+            {
+              "isResolved": true,
+              "path": "cmd/list.go",
+              "line": 3,
+              "comments": {"nodes": [{
+                "author": {"login": "bob"},
+                "body": "Done already",
+                "url": "https://github.com/o/r/pull/1#discussion_r2"
+              }]}
+            },
+            {
+              "isResolved": false,
+              "path": "",
+              "line": 0,
+              "comments": {"nodes": []}
+            }
+          ]
+        }
+      }
+    }
+  }
+}`)
+
+	comments, err := parseGitHubReviewThreads(data)
+	if err != nil {
+		t.Fatalf("parseGitHubReviewThreads() error = %v", err)
+	}
+	if len(comments) != 1 {
+		t.Fatalf("got %d comments, want 1: %+v", len(comments), comments)
+	}
+
+	want := ReviewComment{
+		Author: "alice",
+		Body:   "Please add a test",
+		Path:   "cmd/root.go",
+		Line:   12,
+		URL:    "https://github.com/o/r/pull/1#discussion_r1",
+	}
+	if comments[0] != want {
+		t.Errorf("comment = %+v, want %+v", comments[0], want)
+	}
+}
+
+func TestParseGitLabDiscussions(t *testing.T) {
+	data := []byte(`[
+  {"notes": [{
+    "id": 101,
+    "author": {"username": "carol"},
+    "body": "Rename this",
+    "resolvable": true,
+    "resolved": false,
+    "position": {"new_path": "main.go", "new_line": 7}
+  }]},
+  {"notes": [{
+    "id": 102,
+    "author": {"username": "dave"},
+    "body": "Fixed",
+    "resolvable": true,
+    "resolved": true
+  }]},
+  {"notes": [{
+    "id": 103,
+    "author": {"username": "bot"},
+    "body": "added 1 commit",
+    "resolvable": false,
+    "resolved": false
+  }]}
+]`)
+
+	comments, err := parseGitLabDiscussions(
+		data,
+		"https://gitlab.com/g/r/-/merge_requests/5",
+	)
+	if err != nil {
+		t.Fatalf("parseGitLabDiscussions() error = %v", err)
+	}
+	if len(comments) != 1 {
+		t.Fatalf("got %d comments, want 1: %+v", len(comments), comments)
+	}
+
+	want := ReviewComment{
+		Author: "carol",
+		Body:   "Rename this",
+		Path:   "main.go",
+		Line:   7,
+		URL:    "https://gitlab.com/g/r/-/merge_requests/5#note_101",
+	}
+	if comments[0] != want {
+		t.Errorf("comment = %+v, want %+v", comments[0], want)
+	}
+}
+
+func TestFetchUnresolvedReviewComments_Unsupported(t *testing.T) {
+	_, err := FetchUnresolvedReviewComments(context.Background(), git.PullRequestRef{
+		Platform: git.PlatformBitbucket,
+		Host:     "bitbucket.org",
+		Owner:    "o",
+		Repo:     "r",
+		Number:   1,
+	})
+	if err == nil {
+		t.Fatal("expected error for unsupported platform")
+	}
+}
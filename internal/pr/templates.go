@@ -7,6 +7,15 @@ import (
 	"text/template"
 
 	"github.com/connerohnesorge/spectr/internal/archive"
+	"github.com/connerohnesorge/spectr/internal/parsers"
+	"github.com/connerohnesorge/spectr/internal/templates"
+)
+
+// Names of the user templates that replace the built-in commit message and
+// PR body, as files spectr/templates/<name>.tmpl
+const (
+	CommitTemplateName = "pr-commit.txt"
+	PRBodyTemplateName = "pr-body.md"
 )
 
 // Mode constants for PR template rendering
@@ -30,11 +39,21 @@ type CommitTemplateData struct {
 type PRTemplateData struct {
 	ChangeID     string   // The change identifier
 	ArchivePath  string   // Full archive path (archive mode only)
-	Capabilities []string // Updated capability names (archive mode only)
+	Capabilities []string // Updated (archive) or touched (proposal) capability names
 	Mode         string   // "archive" or "new"
 
-	// Counts tracks spec operation counts (archive mode only)
+	// Counts tracks spec operation counts: applied in archive mode,
+	// proposed by the deltas in proposal mode
 	Counts archive.OperationCounts
+
+	// Tasks is the change's task progress (proposal mode only)
+	Tasks parsers.TaskStatus
+
+	// CrossTeam lists other teams' specs the change touches
+	CrossTeam []OwnerImpact
+
+	// Checklist is the computed review checklist (proposal mode only)
+	Checklist []ChecklistItem
 }
 
 // Template strings for commit messages
@@ -71,6 +90,42 @@ Removed: spectr/changes/{{.ChangeID}}
 Generated by: spectr pr rm`
 
 // Template strings for PR bodies (markdown)
+
+// crossTeamSection is shared by the archive and proposal bodies. It lists
+// each owning team with the requirements the change touches in its specs.
+const crossTeamSection = `
+{{- if .CrossTeam}}
+
+## Cross-team impact
+
+This change touches specs owned by other teams:
+{{range .CrossTeam}}
+- **{{.Owner}}**
+{{- range .Specs}}
+  - ` + "`{{.Spec}}`" + `
+{{- range .Requirements}}
+    - {{.}}
+{{- end}}
+{{- end}}
+{{- end}}
+{{- end}}`
+
+// crossTeamChecklistItem asks for owner sign-off when CrossTeam is set.
+const crossTeamChecklistItem = `
+{{- if .CrossTeam}}
+- [ ] Owning teams have approved the cross-team changes
+{{- end}}`
+
+// proposalChecklist lists the computed checklist items, ticked when their
+// check passes. Bodies rendered without a computed checklist keep the
+// manual items.
+const proposalChecklist = `{{if .Checklist}}{{range .Checklist}}
+- [{{if .Done}}x{{else}} {{end}}] {{.Label}}{{if .Detail}} ({{.Detail}}){{end}}
+{{- end}}{{else}}
+- [ ] Proposal addresses the stated problem
+- [ ] Delta specs are properly formatted
+- [ ] Tasks are clear and actionable{{end}}`
+
 const archivePRBodyTemplate = `## Summary
 
 Archived completed change: ` + "`{{.ChangeID}}`" + `
@@ -91,13 +146,13 @@ Archived completed change: ` + "`{{.ChangeID}}`" + `
 **Updated capabilities**:
 {{range .Capabilities}}- {{.}}
 {{end}}
-{{- end}}
+{{- end}}` + crossTeamSection + `
 
 ## Review Checklist
 
 - [ ] Archived change structure is complete
 - [ ] Spec deltas are accurate
-- [ ] Merged spec content is correct
+- [ ] Merged spec content is correct` + crossTeamChecklistItem + `
 
 ---
 *Generated by ` + "`spectr pr archive`" + `*`
@@ -113,12 +168,22 @@ Proposal for review: ` + "`{{.ChangeID}}`" + `
 - ` + "`proposal.md`" + ` - Change overview
 - ` + "`tasks.md`" + ` - Implementation checklist
 - ` + "`specs/`" + ` - Delta specifications
+{{- if .Capabilities}}
+
+## Progress
+
+**Deltas**: +{{.Counts.Added}} ~{{.Counts.Modified}} -{{.Counts.Removed}} ->{{.Counts.Renamed}} across {{range $i, $c := .Capabilities}}{{if $i}}, {{end}}` + "`{{$c}}`" + `{{end}}
+{{- end}}
+{{- if .Tasks.Total}}
+
+**Tasks**: {{.Tasks.Completed}}/{{.Tasks.Total}} completed
+{{- end}}` + crossTeamSection + `
 
 ## Review Checklist
+` + proposalChecklist + crossTeamChecklistItem + `
 
-- [ ] Proposal addresses the stated problem
-- [ ] Delta specs are properly formatted
-- [ ] Tasks are clear and actionable
+` + KeepStartMarker + `
+` + KeepEndMarker + `
 
 ---
 *Generated by ` + "`spectr pr proposal`" + `*`
@@ -175,6 +240,71 @@ func init() {
 	)
 }
 
+// modeDispatch renders the built-in template of the data's mode, so user
+// templates can extend the default with {{template "spectr/pr-body" .}}.
+const modeDispatch = `{{if eq .Mode "archive"}}{{template "spectr/%[1]s-archive" .}}` +
+	`{{else if eq .Mode "proposal"}}{{template "spectr/%[1]s-proposal" .}}` +
+	`{{else}}{{template "spectr/%[1]s-remove" .}}{{end}}`
+
+// builtinTemplates are the default templates, available to user templates
+// under the "spectr/" prefix.
+var builtinTemplates = map[string]string{
+	"pr-commit":          fmt.Sprintf(modeDispatch, "pr-commit"),
+	"pr-commit-archive":  archiveCommitTemplate,
+	"pr-commit-proposal": proposalCommitTemplate,
+	"pr-commit-remove":   removeCommitTemplate,
+	"pr-body":            fmt.Sprintf(modeDispatch, "pr-body"),
+	"pr-body-archive":    archivePRBodyTemplate,
+	"pr-body-proposal":   proposalPRBodyTemplate,
+	"pr-body-remove":     removePRBodyTemplate,
+}
+
+// RenderProjectCommitMessage renders the commit message with the
+// project's pr-commit.txt template, or the built-in one when the project
+// has none.
+func RenderProjectCommitMessage(
+	projectRoot string,
+	data *CommitTemplateData,
+) (string, error) {
+	return renderProject(projectRoot, CommitTemplateName, data, func() (string, error) {
+		return RenderCommitMessage(data)
+	})
+}
+
+// RenderProjectPRBody renders the PR body with the project's pr-body.md
+// template, or the built-in one when the project has none.
+func RenderProjectPRBody(
+	projectRoot string,
+	data *PRTemplateData,
+) (string, error) {
+	return renderProject(projectRoot, PRBodyTemplateName, data, func() (string, error) {
+		return RenderPRBody(data)
+	})
+}
+
+// renderProject renders the user template called name, falling back to
+// builtin when no template directory provides it.
+func renderProject(
+	projectRoot, name string,
+	data any,
+	builtin func() (string, error),
+) (string, error) {
+	set, err := templates.Load(projectRoot, builtinTemplates)
+	if err != nil {
+		return "", err
+	}
+	if !set.Has(name) {
+		return builtin()
+	}
+
+	out, err := set.Render(name, data)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(out), nil
+}
+
 // RenderCommitMessage renders the appropriate commit message based on the mode.
 func RenderCommitMessage(
 	data *CommitTemplateData,
@@ -1,6 +1,8 @@
 package pr
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -489,6 +491,56 @@ func TestRenderPRBody_Proposal(t *testing.T) {
 	}
 }
 
+// TestRenderPRBody_CrossTeam tests the cross-team impact section in the
+// archive and proposal bodies.
+func TestRenderPRBody_CrossTeam(t *testing.T) {
+	crossTeam := []OwnerImpact{{
+		Owner: "@acme/payments",
+		Specs: []SpecImpact{{
+			Spec:         "payments/refunds",
+			Requirements: []string{"MODIFIED Refund Window", "ADDED Partial Refunds"},
+		}},
+	}}
+
+	want := "## Cross-team impact\n\n" +
+		"This change touches specs owned by other teams:\n\n" +
+		"- **@acme/payments**\n" +
+		"  - `payments/refunds`\n" +
+		"    - MODIFIED Refund Window\n" +
+		"    - ADDED Partial Refunds\n\n" +
+		"## Review Checklist"
+
+	for _, mode := range []string{ModeArchive, ModeProposal} {
+		t.Run(mode, func(t *testing.T) {
+			result, err := RenderPRBody(&PRTemplateData{
+				ChangeID:  "add-refunds",
+				Mode:      mode,
+				CrossTeam: crossTeam,
+			})
+			if err != nil {
+				t.Fatalf("RenderPRBody() error = %v", err)
+			}
+			if !strings.Contains(result, want) {
+				t.Errorf("missing cross-team section\nGot:\n%s", result)
+			}
+			if !strings.Contains(result, "- [ ] Owning teams have approved") {
+				t.Errorf("missing owner approval checklist item\nGot:\n%s", result)
+			}
+
+			plain, err := RenderPRBody(&PRTemplateData{
+				ChangeID: "add-refunds",
+				Mode:     mode,
+			})
+			if err != nil {
+				t.Fatalf("RenderPRBody() error = %v", err)
+			}
+			if strings.Contains(plain, "Cross-team") || strings.Contains(plain, "Owning teams") {
+				t.Errorf("unexpected cross-team content\nGot:\n%s", plain)
+			}
+		})
+	}
+}
+
 // TestRenderPRBody_InvalidMode tests that invalid modes return an error.
 func TestRenderPRBody_InvalidMode(t *testing.T) {
 	tests := []struct {
@@ -754,3 +806,36 @@ func TestModeRemoveConstant(t *testing.T) {
 		)
 	}
 }
+
+// TestRenderProjectPRBody tests that a project template extends the
+// built-in PR body, and that projects without one get the default.
+func TestRenderProjectPRBody(t *testing.T) {
+	data := &PRTemplateData{ChangeID: "add-mfa", Mode: ModeProposal}
+
+	root := t.TempDir()
+	want, err := RenderPRBody(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := RenderProjectPRBody(root, data)
+	if err != nil || got != want {
+		t.Fatalf("RenderProjectPRBody() without template = %q, %v", got, err)
+	}
+
+	dir := filepath.Join(root, "spectr", "templates")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	custom := "{{template \"spectr/pr-body\" .}}\n\n## Payments sign-off\n\n- [ ] On-call notified\n"
+	if err := os.WriteFile(filepath.Join(dir, PRBodyTemplateName+".tmpl"), []byte(custom), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err = RenderProjectPRBody(root, data)
+	if err != nil {
+		t.Fatalf("RenderProjectPRBody() error = %v", err)
+	}
+	if !strings.HasPrefix(got, want) || !strings.HasSuffix(got, "- [ ] On-call notified") {
+		t.Errorf("RenderProjectPRBody() = %q", got)
+	}
+}
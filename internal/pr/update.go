@@ -0,0 +1,295 @@
+// Package pr provides PR body regeneration for existing pull requests.
+// This file rebuilds the body of an open proposal PR from the templates
+// and patches it via gh (GitHub) or glab (GitLab), keeping the section
+// between the keep markers as the reviewers left it.
+package pr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/archive"
+	"github.com/connerohnesorge/spectr/internal/git"
+	"github.com/connerohnesorge/spectr/internal/hostapi"
+	"github.com/connerohnesorge/spectr/internal/parsers"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+// Markers delimiting the part of a PR body that spectr never rewrites.
+// The built-in proposal body contains an empty pair; text written between
+// them survives spectr pr proposal --update.
+const (
+	KeepStartMarker = "<!-- spectr:keep -->"
+	KeepEndMarker   = "<!-- /spectr:keep -->"
+)
+
+// existingPR is an open pull request found for a branch.
+type existingPR struct {
+	url  string
+	body string
+	// approved reports whether reviewers approved the PR
+	approved bool
+}
+
+// MergeKeptSection returns body with its keep section replaced by the one
+// in previous. When body has no keep markers, the kept section of previous
+// is appended. A previous body without a complete keep section leaves
+// body unchanged.
+func MergeKeptSection(body, previous string) string {
+	kept, ok := keptSection(previous)
+	if !ok {
+		return body
+	}
+
+	start := strings.Index(body, KeepStartMarker)
+	end := strings.Index(body, KeepEndMarker)
+	if start < 0 || end < start {
+		return strings.TrimRight(body, "\n") + "\n\n" +
+			KeepStartMarker + kept + KeepEndMarker
+	}
+
+	return body[:start+len(KeepStartMarker)] + kept + body[end:]
+}
+
+// keptSection returns the text between the keep markers of body.
+func keptSection(body string) (string, bool) {
+	start := strings.Index(body, KeepStartMarker)
+	if start < 0 {
+		return "", false
+	}
+	rest := body[start+len(KeepStartMarker):]
+	end := strings.Index(rest, KeepEndMarker)
+	if end < 0 {
+		return "", false
+	}
+
+	return rest[:end], true
+}
+
+// proposalProgress fills the delta counts, touched specs and task progress
+// of a proposal PR body from the change in the main working tree.
+// Unreadable files leave the fields empty.
+func proposalProgress(config PRConfig, data *PRTemplateData) {
+	changeDir := localChangeDir(config)
+	if tasks, err := parsers.CountTasks(changeDir); err == nil {
+		data.Tasks = tasks
+	}
+
+	specs, err := touchedRequirements(changeDir + "/specs")
+	if err != nil {
+		return
+	}
+	var counts archive.OperationCounts
+	for _, spec := range specs {
+		data.Capabilities = append(data.Capabilities, spec.Spec)
+		for _, op := range spec.Requirements {
+			switch {
+			case strings.HasPrefix(op, "ADDED "):
+				counts.Added++
+			case strings.HasPrefix(op, "MODIFIED "):
+				counts.Modified++
+			case strings.HasPrefix(op, "REMOVED "):
+				counts.Removed++
+			case strings.HasPrefix(op, "RENAMED "):
+				counts.Renamed++
+			}
+		}
+	}
+	data.Counts = counts
+}
+
+// UpdatePR regenerates the body of the open proposal PR for
+// config.ChangeID and patches it on the hosting platform. The review
+// checklist is computed again, with the PR's current approval state. Text
+// between the keep markers of the current body is carried over. With
+// config.DryRun the new body is printed instead.
+func UpdatePR(
+	ctx context.Context,
+	config PRConfig,
+) (*PRResult, error) {
+	config.Mode = ModeProposal
+	if err := validatePrerequisites(ctx, config); err != nil {
+		return nil, fmt.Errorf(
+			"prerequisite check failed: %w",
+			err,
+		)
+	}
+
+	originURL, err := git.GetOriginURL(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get origin URL: %w", err)
+	}
+	platformInfo, err := git.DetectPlatform(originURL)
+	if err != nil {
+		return nil, fmt.Errorf("detect platform: %w", err)
+	}
+	if platformInfo.CLITool != "" {
+		if err := checkCLITool(platformInfo.CLITool); err != nil {
+			return nil, err
+		}
+	}
+	env := resolveCLIEnv(ctx, platformInfo, config.Token)
+	branchName := branchNameFor(config.Mode, config.ChangeID)
+
+	current, err := viewPR(ctx, platformInfo.Platform, branchName, env)
+	if err != nil {
+		return nil, err
+	}
+
+	data := PRTemplateData{
+		ChangeID:  config.ChangeID,
+		Mode:      ModeProposal,
+		CrossTeam: loadCrossTeamImpact(config),
+	}
+	proposalProgress(config, &data)
+	data.Checklist = BuildChecklist(localChangeDir(config), current.approved)
+	body, err := RenderProjectPRBody(config.ProjectRoot, &data)
+	if err != nil {
+		return nil, fmt.Errorf("render PR body: %w", err)
+	}
+	body = MergeKeptSection(body, current.body)
+
+	result := &PRResult{
+		PRURL:      current.url,
+		BranchName: branchName,
+		Platform:   platformInfo.Platform,
+	}
+	if config.DryRun {
+		fmt.Println(body)
+
+		return result, nil
+	}
+	if strings.TrimSpace(body) == strings.TrimSpace(current.body) {
+		result.Unchanged = true
+
+		return result, nil
+	}
+
+	if err := editPRBody(ctx, platformInfo.Platform, branchName, body, env); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// viewPR returns the open PR whose head is branchName.
+func viewPR(
+	ctx context.Context,
+	platform git.Platform,
+	branchName string,
+	env []string,
+) (*existingPR, error) {
+	var (
+		name string
+		args []string
+	)
+	switch platform {
+	case git.PlatformGitHub:
+		name = "gh"
+		args = []string{"pr", "view", branchName, "--json", "url,body,state,reviewDecision"}
+	case git.PlatformGitLab:
+		name = "glab"
+		args = []string{"mr", "view", branchName, "--output", "json"}
+	case git.PlatformGitea, git.PlatformBitbucket, git.PlatformUnknown:
+		return nil, fmt.Errorf(
+			"updating PR descriptions is not supported for %s",
+			platform,
+		)
+	default:
+		return nil, &specterrs.UnknownPlatformError{Platform: string(platform)}
+	}
+
+	output, err := hostapi.RunCLI(
+		ctx,
+		hostapi.DefaultPolicy,
+		hostapi.CLICommand{Env: env, Name: name, Args: args},
+	)
+	if err != nil {
+		return nil, &specterrs.PRNotFoundError{
+			Branch:  branchName,
+			Details: commandErrorOutput(err),
+		}
+	}
+
+	return parsePRView(platform, branchName, output)
+}
+
+// parsePRView decodes the output of gh pr view or glab mr view.
+func parsePRView(
+	platform git.Platform,
+	branchName string,
+	output []byte,
+) (*existingPR, error) {
+	var resp struct {
+		URL         string `json:"url"`
+		WebURL      string `json:"web_url"`
+		Body        string `json:"body"`
+		Description string `json:"description"`
+		State       string `json:"state"`
+		// ReviewDecision is GitHub's review state, e.g. "APPROVED"
+		ReviewDecision string `json:"reviewDecision"`
+		// DetailedMergeStatus is GitLab's merge state, "not_approved"
+		// while approvals are missing
+		DetailedMergeStatus string `json:"detailed_merge_status"`
+	}
+	if err := json.Unmarshal(output, &resp); err != nil {
+		return nil, fmt.Errorf("parse %s PR: %w", platform, err)
+	}
+
+	state := strings.ToLower(resp.State)
+	if state != "open" && state != "opened" {
+		return nil, &specterrs.PRNotFoundError{
+			Branch:  branchName,
+			Details: "the pull request is " + state,
+		}
+	}
+	if platform == git.PlatformGitLab {
+		return &existingPR{
+			url:      resp.WebURL,
+			body:     resp.Description,
+			approved: resp.DetailedMergeStatus != "" && resp.DetailedMergeStatus != "not_approved",
+		}, nil
+	}
+
+	return &existingPR{
+		url:      resp.URL,
+		body:     resp.Body,
+		approved: resp.ReviewDecision == "APPROVED",
+	}, nil
+}
+
+// editPRBody replaces the body of the PR whose head is branchName.
+func editPRBody(
+	ctx context.Context,
+	platform git.Platform,
+	branchName, body string,
+	env []string,
+) error {
+	cmd := hostapi.CLICommand{Env: env}
+	switch platform {
+	case git.PlatformGitHub:
+		bodyFile, err := writeTempBodyFile(body)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = os.Remove(bodyFile) }()
+		cmd.Name = "gh"
+		cmd.Args = []string{"pr", "edit", branchName, "--body-file", bodyFile}
+	default:
+		cmd.Name = "glab"
+		cmd.Args = []string{"mr", "update", branchName, "--description", body}
+	}
+
+	if _, err := hostapi.RunCLI(ctx, hostapi.DefaultPolicy, cmd); err != nil {
+		return fmt.Errorf(
+			"%s failed to update the PR body: %s",
+			cmd.Name,
+			commandErrorOutput(err),
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,169 @@
+package pr
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/connerohnesorge/spectr/internal/git"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+func TestMergeKeptSection(t *testing.T) {
+	const generated = "## Summary\n\nNew\n\n" + KeepStartMarker + "\n" + KeepEndMarker + "\n\n---\nfooter"
+
+	tests := []struct {
+		name     string
+		body     string
+		previous string
+		want     string
+	}{
+		{
+			name:     "carries notes over",
+			body:     generated,
+			previous: "## Summary\n\nOld\n\n" + KeepStartMarker + "\nShip after the freeze.\n" + KeepEndMarker + "\n",
+			want:     "## Summary\n\nNew\n\n" + KeepStartMarker + "\nShip after the freeze.\n" + KeepEndMarker + "\n\n---\nfooter",
+		},
+		{
+			name:     "previous without markers",
+			body:     generated,
+			previous: "Hand-written body",
+			want:     generated,
+		},
+		{
+			name:     "previous with unclosed marker",
+			body:     generated,
+			previous: KeepStartMarker + "\nnotes",
+			want:     generated,
+		},
+		{
+			name:     "custom template without markers",
+			body:     "Custom body\n",
+			previous: KeepStartMarker + "notes" + KeepEndMarker,
+			want:     "Custom body\n\n" + KeepStartMarker + "notes" + KeepEndMarker,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MergeKeptSection(tt.body, tt.previous); got != tt.want {
+				t.Errorf("MergeKeptSection() =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsePRView(t *testing.T) {
+	tests := []struct {
+		name     string
+		platform git.Platform
+		output   string
+		wantURL  string
+		wantBody string
+		// wantApproved is the expected review state
+		wantApproved bool
+		wantErr      bool
+	}{
+		{
+			name:     "github",
+			platform: git.PlatformGitHub,
+			output:   `{"url":"https://github.com/o/r/pull/7","body":"hello","state":"OPEN"}`,
+			wantURL:  "https://github.com/o/r/pull/7",
+			wantBody: "hello",
+		},
+		{
+			name:     "gitlab",
+			platform: git.PlatformGitLab,
+			output:   `{"web_url":"https://gitlab.com/o/r/-/merge_requests/3","description":"hi","state":"opened"}`,
+			wantURL:  "https://gitlab.com/o/r/-/merge_requests/3",
+			wantBody: "hi",
+		},
+		{
+			name:         "github approved",
+			platform:     git.PlatformGitHub,
+			output:       `{"url":"u","body":"b","state":"OPEN","reviewDecision":"APPROVED"}`,
+			wantURL:      "u",
+			wantBody:     "b",
+			wantApproved: true,
+		},
+		{
+			name:         "gitlab approved",
+			platform:     git.PlatformGitLab,
+			output:       `{"web_url":"u","description":"b","state":"opened","detailed_merge_status":"mergeable"}`,
+			wantURL:      "u",
+			wantBody:     "b",
+			wantApproved: true,
+		},
+		{
+			name:     "gitlab not approved",
+			platform: git.PlatformGitLab,
+			output:   `{"web_url":"u","description":"b","state":"opened","detailed_merge_status":"not_approved"}`,
+			wantURL:  "u",
+			wantBody: "b",
+		},
+		{
+			name:     "merged",
+			platform: git.PlatformGitHub,
+			output:   `{"url":"u","body":"b","state":"MERGED"}`,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parsePRView(tt.platform, "spectr/proposal/x", []byte(tt.output))
+			if tt.wantErr {
+				var notFound *specterrs.PRNotFoundError
+				if !errors.As(err, &notFound) {
+					t.Fatalf("parsePRView() error = %v, want PRNotFoundError", err)
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("parsePRView() error = %v", err)
+			}
+			if got.url != tt.wantURL || got.body != tt.wantBody || got.approved != tt.wantApproved {
+				t.Errorf("parsePRView() = %+v", got)
+			}
+		})
+	}
+}
+
+func TestProposalProgress(t *testing.T) {
+	root := t.TempDir()
+	changeDir := filepath.Join(root, "spectr", "changes", "add-refunds")
+	files := map[string]string{
+		"tasks.md": "- [x] 1.1 Done\n- [ ] 1.2 Todo\n",
+		"specs/payments/spec.md": "## ADDED Requirements\n\n### Requirement: Partial Refunds\n\n" +
+			"## MODIFIED Requirements\n\n### Requirement: Refund Window\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(changeDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	data := PRTemplateData{ChangeID: "add-refunds", Mode: ModeProposal}
+	proposalProgress(PRConfig{ChangeID: "add-refunds", ProjectRoot: root}, &data)
+
+	body, err := RenderPRBody(&data)
+	if err != nil {
+		t.Fatalf("RenderPRBody() error = %v", err)
+	}
+	for _, want := range []string{
+		"**Deltas**: +1 ~1 -0 ->0 across `payments`",
+		"**Tasks**: 1/2 completed",
+		KeepStartMarker + "\n" + KeepEndMarker,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("body missing %q\nGot:\n%s", want, body)
+		}
+	}
+}
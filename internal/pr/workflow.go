@@ -5,6 +5,7 @@
 package pr
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -24,6 +25,18 @@ type PRConfig struct {
 	DryRun      bool   // Show what would be done without executing
 	SkipSpecs   bool   // For archive mode: pass --skip-specs to archive command
 	ProjectRoot string // Project root directory (for source change)
+
+	// ReviewComments posts one review comment per MODIFIED/REMOVED
+	// requirement after the PR is created
+	ReviewComments bool
+
+	// OwnerReview requests review from the owners of other teams' specs
+	// that the change touches (see the owners list in spectr.yaml)
+	OwnerReview bool
+
+	// Token is an explicit --token value; otherwise the token is resolved
+	// from the environment, stored credentials or git credential helpers
+	Token string
 }
 
 // PRResult contains the result of the PR workflow.
@@ -35,6 +48,7 @@ type PRResult struct {
 	Capabilities []string                // Updated capabilities (archive mode only)
 	Platform     git.Platform            // Detected platform
 	ManualURL    string                  // Manual PR creation URL (Bitbucket)
+	Unchanged    bool                    // Update found the PR body already current
 }
 
 // ExecutePR orchestrates the complete PR workflow:
@@ -44,11 +58,15 @@ type PRResult struct {
 // 4. Stage, commit, push
 // 5. Create PR
 // 6. Cleanup worktree
+//
+// Git and platform CLI commands are killed once ctx is done. The worktree
+// is cleaned up even then.
 func ExecutePR(
+	ctx context.Context,
 	config PRConfig,
 ) (*PRResult, error) {
 	// Validate prerequisites
-	if err := validatePrerequisites(config); err != nil {
+	if err := validatePrerequisites(ctx, config); err != nil {
 		return nil, fmt.Errorf(
 			"prerequisite check failed: %w",
 			err,
@@ -56,16 +74,16 @@ func ExecutePR(
 	}
 
 	// Prepare workflow context
-	ctx, err := prepareWorkflowContext(config)
+	wf, err := prepareWorkflowContext(ctx, config)
 	if err != nil {
 		return nil, err
 	}
 
 	if config.DryRun {
-		return executeDryRun(config, ctx)
+		return executeDryRun(config, wf)
 	}
 
-	return executeWorkflow(config, ctx)
+	return executeWorkflow(ctx, config, wf)
 }
 
 // workflowContext holds prepared context for the PR workflow.
@@ -73,14 +91,16 @@ type workflowContext struct {
 	platformInfo git.PlatformInfo
 	baseBranch   string
 	branchName   string
+	cliEnv       []string // Resolved token for the platform CLI
 }
 
 // prepareWorkflowContext prepares the context needed for the workflow.
 func prepareWorkflowContext(
+	ctx context.Context,
 	config PRConfig,
 ) (*workflowContext, error) {
 	// Get origin URL and detect platform
-	originURL, err := git.GetOriginURL()
+	originURL, err := git.GetOriginURL(ctx)
 	if err != nil {
 		return nil, fmt.Errorf(
 			"get origin URL: %w",
@@ -107,6 +127,7 @@ func prepareWorkflowContext(
 
 	// Get base branch (auto-detect or use provided)
 	baseBranch, err := git.GetBaseBranch(
+		ctx,
 		config.BaseBranch,
 	)
 	if err != nil {
@@ -116,34 +137,15 @@ func prepareWorkflowContext(
 		)
 	}
 
-	// Generate mode-specific branch name:
-	// - archive mode: spectr/archive/<change-id>
-	// - proposal mode: spectr/proposal/<change-id>
-	// - remove mode: spectr/remove/<change-id>
-	var branchPrefix string
-	switch config.Mode {
-	case ModeArchive:
-		branchPrefix = "spectr/archive"
-	case ModeProposal:
-		branchPrefix = "spectr/proposal"
-	case ModeRemove:
-		branchPrefix = "spectr/remove"
-	default:
-		branchPrefix = "spectr"
-	}
-	branchName := fmt.Sprintf(
-		"%s/%s",
-		branchPrefix,
-		config.ChangeID,
-	)
+	branchName := branchNameFor(config.Mode, config.ChangeID)
 
 	// Handle existing branch
-	if err := handleExistingBranch(config, branchName); err != nil {
+	if err := handleExistingBranch(ctx, config, branchName); err != nil {
 		return nil, err
 	}
 
 	// Fetch origin to ensure refs are up to date
-	if err := fetchOrigin(config); err != nil {
+	if err := fetchOrigin(ctx, config); err != nil {
 		return nil, err
 	}
 
@@ -151,15 +153,41 @@ func prepareWorkflowContext(
 		platformInfo: platformInfo,
 		baseBranch:   baseBranch,
 		branchName:   branchName,
+		cliEnv:       resolveCLIEnv(ctx, platformInfo, config.Token),
 	}, nil
 }
 
+// branchNameFor returns the mode-specific branch of a change:
+//   - archive mode: spectr/archive/<change-id>
+//   - proposal mode: spectr/proposal/<change-id>
+//   - remove mode: spectr/remove/<change-id>
+func branchNameFor(mode, changeID string) string {
+	var branchPrefix string
+	switch mode {
+	case ModeArchive:
+		branchPrefix = "spectr/archive"
+	case ModeProposal:
+		branchPrefix = "spectr/proposal"
+	case ModeRemove:
+		branchPrefix = "spectr/remove"
+	default:
+		branchPrefix = "spectr"
+	}
+
+	return fmt.Sprintf(
+		"%s/%s",
+		branchPrefix,
+		changeID,
+	)
+}
+
 // handleExistingBranch handles the case where the branch already exists.
 func handleExistingBranch(
+	ctx context.Context,
 	config PRConfig,
 	branchName string,
 ) error {
-	exists, err := git.BranchExists(branchName)
+	exists, err := git.BranchExists(ctx, branchName)
 	if err != nil {
 		return fmt.Errorf(
 			"check branch existence: %w",
@@ -192,11 +220,11 @@ func handleExistingBranch(
 		branchName,
 	)
 
-	return git.DeleteRemoteBranch(branchName)
+	return git.DeleteRemoteBranch(ctx, branchName)
 }
 
 // fetchOrigin fetches the origin remote.
-func fetchOrigin(config PRConfig) error {
+func fetchOrigin(ctx context.Context, config PRConfig) error {
 	if config.DryRun {
 		fmt.Println(
 			"[dry-run] Would fetch origin",
@@ -207,7 +235,7 @@ func fetchOrigin(config PRConfig) error {
 
 	fmt.Println("Fetching origin...")
 
-	if err := git.FetchOrigin(); err != nil {
+	if err := git.FetchOrigin(ctx); err != nil {
 		return fmt.Errorf("fetch origin: %w", err)
 	}
 
@@ -216,25 +244,27 @@ func fetchOrigin(config PRConfig) error {
 
 // executeWorkflow executes the main PR workflow.
 func executeWorkflow(
+	ctx context.Context,
 	config PRConfig,
-	ctx *workflowContext,
+	wf *workflowContext,
 ) (*PRResult, error) {
 	result := &PRResult{
-		BranchName: ctx.branchName,
-		Platform:   ctx.platformInfo.Platform,
+		BranchName: wf.branchName,
+		Platform:   wf.platformInfo.Platform,
 	}
 
 	// Create worktree
 	fmt.Printf(
 		"Creating worktree on branch: %s (based on %s)\n",
-		ctx.branchName,
-		ctx.baseBranch,
+		wf.branchName,
+		wf.baseBranch,
 	)
 
 	worktreeInfo, err := git.CreateWorktree(
+		ctx,
 		git.WorktreeConfig{
-			BranchName: ctx.branchName,
-			BaseBranch: ctx.baseBranch,
+			BranchName: wf.branchName,
+			BaseBranch: wf.baseBranch,
 		},
 	)
 	if err != nil {
@@ -248,19 +278,22 @@ func executeWorkflow(
 	defer cleanupWorktree(worktreeInfo)
 
 	// Execute operation in worktree
-	if err := executeOperation(config, worktreeInfo.Path, result); err != nil {
+	err = executeOperation(ctx, config, worktreeInfo.Path, result)
+	if err != nil {
 		return nil, err
 	}
 
 	// Stage, commit, and push
-	if err := commitAndPush(config, ctx, result, worktreeInfo.Path); err != nil {
+	err = commitAndPush(ctx, config, wf, result, worktreeInfo.Path)
+	if err != nil {
 		return nil, err
 	}
 
 	// Create PR
 	result, err = createPRAndFinalize(
-		config,
 		ctx,
+		config,
+		wf,
 		result,
 		worktreeInfo.Path,
 	)
@@ -268,6 +301,12 @@ func executeWorkflow(
 		return nil, err
 	}
 
+	// Post requirement review comments while the worktree and local change
+	// still exist
+	if config.ReviewComments {
+		postReviewComments(ctx, config, wf, result, worktreeInfo.Path)
+	}
+
 	// Clean up local change directory for archive and remove modes
 	// (not for proposal mode, as the user may still be working on the proposal)
 	if config.Mode == ModeArchive ||
@@ -301,6 +340,7 @@ func cleanupWorktree(info *git.WorktreeInfo) {
 
 // executeOperation executes the archive or copy operation.
 func executeOperation(
+	ctx context.Context,
 	config PRConfig,
 	worktreePath string,
 	result *PRResult,
@@ -308,6 +348,7 @@ func executeOperation(
 	switch config.Mode {
 	case ModeArchive:
 		archiveResult, err := executeArchiveInWorktree(
+			ctx,
 			config,
 			worktreePath,
 		)
@@ -357,8 +398,9 @@ func executeOperation(
 
 // commitAndPush stages, commits, and pushes the changes.
 func commitAndPush(
+	ctx context.Context,
 	config PRConfig,
-	ctx *workflowContext,
+	wf *workflowContext,
 	result *PRResult,
 	worktreePath string,
 ) error {
@@ -370,7 +412,8 @@ func commitAndPush(
 		Counts:      result.Counts,
 	}
 
-	commitMsg, err := RenderCommitMessage(
+	commitMsg, err := RenderProjectCommitMessage(
+		config.ProjectRoot,
 		&commitData,
 	)
 	if err != nil {
@@ -381,7 +424,7 @@ func commitAndPush(
 	}
 
 	// Stage and commit
-	if err := stageAndCommit(worktreePath, commitMsg); err != nil {
+	if err := stageAndCommit(ctx, worktreePath, commitMsg); err != nil {
 		return fmt.Errorf(
 			"stage and commit: %w",
 			err,
@@ -389,7 +432,7 @@ func commitAndPush(
 	}
 
 	// Push branch
-	if err := pushBranch(worktreePath, ctx.branchName); err != nil {
+	if err := pushBranch(ctx, worktreePath, wf.branchName); err != nil {
 		return fmt.Errorf("push branch: %w", err)
 	}
 
@@ -398,11 +441,23 @@ func commitAndPush(
 
 // createPRAndFinalize creates the PR and finalizes the result.
 func createPRAndFinalize(
+	ctx context.Context,
 	config PRConfig,
-	ctx *workflowContext,
+	wf *workflowContext,
 	result *PRResult,
 	worktreePath string,
 ) (*PRResult, error) {
+	// Find other teams' specs the change touches
+	var crossTeam []OwnerImpact
+	if config.Mode != ModeRemove {
+		crossTeam = loadCrossTeamImpact(config)
+	}
+
+	var reviewers []string
+	if config.OwnerReview {
+		reviewers = reviewerHandles(impactOwners(crossTeam))
+	}
+
 	// Generate PR body
 	prData := PRTemplateData{
 		ChangeID:     config.ChangeID,
@@ -410,9 +465,14 @@ func createPRAndFinalize(
 		Capabilities: result.Capabilities,
 		Mode:         config.Mode,
 		Counts:       result.Counts,
+		CrossTeam:    crossTeam,
+	}
+	if config.Mode == ModeProposal {
+		proposalProgress(config, &prData)
+		prData.Checklist = BuildChecklist(localChangeDir(config), false)
 	}
 
-	prBody, err := RenderPRBody(&prData)
+	prBody, err := RenderProjectPRBody(config.ProjectRoot, &prData)
 	if err != nil {
 		return nil, fmt.Errorf(
 			"render PR body: %w",
@@ -425,20 +485,22 @@ func createPRAndFinalize(
 		config.Mode,
 	)
 	baseBranchName := strings.TrimPrefix(
-		ctx.baseBranch,
+		wf.baseBranch,
 		"origin/",
 	)
 
 	// Create PR
-	prURL, manualURL, err := createPR(
-		&ctx.platformInfo,
-		ctx.branchName,
-		baseBranchName,
-		prTitle,
-		prBody,
-		config.Draft,
-		worktreePath,
-	)
+	prURL, manualURL, err := doCreatePR(ctx, &createPRInput{
+		platform:     wf.platformInfo,
+		branchName:   wf.branchName,
+		baseBranch:   baseBranchName,
+		title:        prTitle,
+		body:         prBody,
+		draft:        config.Draft,
+		worktreePath: worktreePath,
+		env:          wf.cliEnv,
+		reviewers:    reviewers,
+	})
 	if err != nil {
 		return nil, fmt.Errorf(
 			"create PR: %w",
@@ -454,8 +516,13 @@ func createPRAndFinalize(
 
 // validatePrerequisites checks all prerequisites before starting the workflow.
 func validatePrerequisites(
+	ctx context.Context,
 	config PRConfig,
 ) error {
+	if err := git.Require("spectr pr"); err != nil {
+		return err
+	}
+
 	// Check we're in a git repository
 	repoRoot, err := git.GetRepoRoot()
 	if err != nil {
@@ -472,7 +539,7 @@ func validatePrerequisites(
 	}
 
 	// Check origin remote exists
-	_, err = git.GetOriginURL()
+	_, err = git.GetOriginURL(ctx)
 	if err != nil {
 		return &specterrs.PRPrerequisiteError{
 			Check:   "origin remote",
@@ -1,6 +1,7 @@
 package pr
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -26,6 +27,7 @@ func getCLIInstallSuggestion(tool string) string {
 
 // executeArchiveInWorktree runs the archive workflow within the worktree.
 func executeArchiveInWorktree(
+	ctx context.Context,
 	config PRConfig,
 	worktreePath string,
 ) (archive.ArchiveResult, error) {
@@ -52,6 +54,7 @@ func executeArchiveInWorktree(
 	// Execute archive within the worktree and capture results.
 	// The ArchiveResult contains path, operation counts, and capabilities.
 	result, err := archive.Archive(
+		ctx,
 		archiveCmd,
 		worktreePath,
 	)
@@ -0,0 +1,15 @@
+// Package profile fetches the shared settings a spectr.yaml extends.
+//
+// A platform team publishes a profile, itself a spectr.yaml, in a git
+// repository or an HTTPS tarball. Projects point at it with extends and pin
+// its SHA-256:
+//
+//	extends:
+//	  url: https://platform.acme.example/spectr-profile-v3.tar.gz
+//	  sha256: 9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08
+//
+// The checksum covers the profile file, not the archive or commit, so the
+// same profile verifies whichever way it is published. Verified profiles
+// are cached under the user cache directory by checksum; a cached profile
+// is used without going to the network again.
+package profile
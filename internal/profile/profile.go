@@ -0,0 +1,292 @@
+package profile
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/git"
+	"github.com/connerohnesorge/spectr/internal/hostapi"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+// DefaultPath is the profile file looked up in a bundle when Source.Path
+// is empty.
+const DefaultPath = "spectr.yaml"
+
+// Download limits: a bundle is a handful of small files.
+const (
+	maxArchiveSize = 32 << 20
+	maxProfileSize = 1 << 20
+)
+
+// filePerm is the permission of cached profiles.
+const filePerm = 0o644
+
+// gitPrefix marks a git repository URL served over another scheme, as in
+// git+https://github.com/acme/spectr-profile.
+const gitPrefix = "git+"
+
+// Source locates a profile, mirroring extends in spectr.yaml.
+type Source struct {
+	// URL is an HTTPS tarball (.tar.gz or .tgz) or a git repository
+	// (git+https://..., ssh://..., git@host:path or a URL ending in .git).
+	URL string
+	// Ref is the branch, tag or commit to fetch from a git repository.
+	Ref string
+	// Path is the profile file in the bundle, DefaultPath when empty.
+	Path string
+	// SHA256 is the hex SHA-256 of the profile file.
+	SHA256 string
+}
+
+// file returns the profile path inside the bundle.
+func (s Source) file() string {
+	if s.Path == "" {
+		return DefaultPath
+	}
+
+	return path.Clean(filepath.ToSlash(s.Path))
+}
+
+// isGit reports whether the source is a git repository.
+func (s Source) isGit() bool {
+	return strings.HasPrefix(s.URL, gitPrefix) ||
+		strings.HasPrefix(s.URL, "ssh://") ||
+		strings.HasPrefix(s.URL, "git@") ||
+		strings.HasSuffix(strings.TrimSuffix(s.URL, "/"), ".git")
+}
+
+// Check reports a source that cannot be fetched and verified.
+func (s Source) Check() error {
+	switch {
+	case s.URL == "":
+		return &specterrs.InvalidProfileSourceError{Reason: "url is required"}
+	case !s.isGit() && !strings.HasPrefix(s.URL, "https://"):
+		return &specterrs.InvalidProfileSourceError{
+			Reason: "url must be an https:// tarball or a git repository",
+		}
+	case s.Ref != "" && !s.isGit():
+		return &specterrs.InvalidProfileSourceError{Reason: "ref only applies to git repositories"}
+	}
+
+	sum, err := hex.DecodeString(s.SHA256)
+	if err != nil || len(sum) != sha256.Size {
+		return &specterrs.InvalidProfileSourceError{
+			Reason: "sha256 must be the 64-character hex SHA-256 of the profile file",
+		}
+	}
+
+	return nil
+}
+
+// CacheDir returns the directory verified profiles are cached in:
+// $SPECTR_CACHE_DIR/profiles, or e.g. ~/.cache/spectr/profiles.
+func CacheDir() (string, error) {
+	if dir := os.Getenv("SPECTR_CACHE_DIR"); dir != "" {
+		return filepath.Join(dir, "profiles"), nil
+	}
+
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(base, "spectr", "profiles"), nil
+}
+
+// CachePath returns where the profile of src is cached.
+func CachePath(src Source) (string, error) {
+	dir, err := CacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, strings.ToLower(src.SHA256)+".yaml"), nil
+}
+
+// Load returns the profile of src, from the cache when a verified copy is
+// there and fetched otherwise.
+func Load(ctx context.Context, src Source) ([]byte, error) {
+	if err := src.Check(); err != nil {
+		return nil, err
+	}
+
+	cachePath, err := CachePath(src)
+	if err == nil {
+		if data, readErr := os.ReadFile(cachePath); readErr == nil &&
+			verify(src, data) == nil {
+			return data, nil
+		}
+	}
+
+	return Fetch(ctx, src)
+}
+
+// Fetch downloads the profile of src, verifies its checksum and caches
+// it. A profile that does not match is neither returned nor cached.
+func Fetch(ctx context.Context, src Source) ([]byte, error) {
+	return fetch(ctx, hostapi.NewClient(), src)
+}
+
+// fetch is Fetch with the HTTP client used for tarballs.
+func fetch(
+	ctx context.Context,
+	client *hostapi.Client,
+	src Source,
+) ([]byte, error) {
+	if err := src.Check(); err != nil {
+		return nil, err
+	}
+
+	var (
+		data []byte
+		err  error
+	)
+	if src.isGit() {
+		data, err = fetchGit(ctx, src)
+	} else {
+		data, err = fetchTarball(ctx, client, src)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := verify(src, data); err != nil {
+		return nil, err
+	}
+
+	// A read-only cache only costs a refetch next time
+	if cachePath, err := CachePath(src); err == nil {
+		if err := os.MkdirAll(filepath.Dir(cachePath), 0o755); err == nil {
+			_ = os.WriteFile(cachePath, data, filePerm)
+		}
+	}
+
+	return data, nil
+}
+
+// verify checks data against the checksum pinned by src.
+func verify(src Source, data []byte) error {
+	sum := sha256.Sum256(data)
+	got := hex.EncodeToString(sum[:])
+	if !strings.EqualFold(got, src.SHA256) {
+		return &specterrs.ProfileChecksumError{URL: src.URL, Want: src.SHA256, Got: got}
+	}
+
+	return nil
+}
+
+// fetchTarball downloads a gzipped tarball and returns its profile file.
+func fetchTarball(
+	ctx context.Context,
+	client *hostapi.Client,
+	src Source,
+) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, src.URL, http.NoBody)
+	if err != nil {
+		return nil, &specterrs.ProfileFetchError{URL: src.URL, Err: err}
+	}
+	resp, err := client.Do(ctx, req)
+	if err != nil {
+		return nil, &specterrs.ProfileFetchError{URL: src.URL, Err: err}
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	archive, err := io.ReadAll(io.LimitReader(resp.Body, maxArchiveSize+1))
+	if err != nil {
+		return nil, &specterrs.ProfileFetchError{URL: src.URL, Err: err}
+	}
+	if len(archive) > maxArchiveSize {
+		return nil, &specterrs.ProfileFetchError{
+			URL: src.URL,
+			Err: fmt.Errorf("archive larger than %d bytes", maxArchiveSize),
+		}
+	}
+
+	return extract(src, archive)
+}
+
+// extract returns the profile file from a gzipped tarball. The file may
+// sit at the root or under one top-level directory, as in the archives
+// hosting platforms generate for tags.
+func extract(src Source, archive []byte) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, &specterrs.ProfileFetchError{URL: src.URL, Err: err}
+	}
+	defer func() { _ = gz.Close() }()
+
+	want := src.file()
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil, &specterrs.ProfileFileNotFoundError{URL: src.URL, Path: want}
+		}
+		if err != nil {
+			return nil, &specterrs.ProfileFetchError{URL: src.URL, Err: err}
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		name := path.Clean(strings.TrimPrefix(header.Name, "./"))
+		_, nested, found := strings.Cut(name, "/")
+		if name != want && (!found || nested != want) {
+			continue
+		}
+
+		data, err := io.ReadAll(io.LimitReader(tr, maxProfileSize))
+		if err != nil {
+			return nil, &specterrs.ProfileFetchError{URL: src.URL, Err: err}
+		}
+
+		return data, nil
+	}
+}
+
+// fetchGit shallow-fetches Ref (or the default branch) of a git
+// repository into a temporary directory and returns its profile file.
+func fetchGit(ctx context.Context, src Source) ([]byte, error) {
+	dir, err := os.MkdirTemp("", "spectr-profile-")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	ref := src.Ref
+	if ref == "" {
+		ref = "HEAD"
+	}
+	url := strings.TrimPrefix(src.URL, gitPrefix)
+	for _, args := range [][]string{
+		{"init", "--quiet"},
+		{"fetch", "--quiet", "--depth", "1", url, ref},
+		{"checkout", "--quiet", "FETCH_HEAD"},
+	} {
+		if _, err := git.Run(ctx, dir, args...); err != nil {
+			return nil, &specterrs.ProfileFetchError{URL: src.URL, Err: git.Failure(err)}
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(src.file())))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, &specterrs.ProfileFileNotFoundError{URL: src.URL, Path: src.file()}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
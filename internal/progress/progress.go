@@ -0,0 +1,162 @@
+// Package progress computes implementation progress per scenario from the
+// tasks that cover it.
+//
+// Tasks link to scenarios through their covers field in tasks.jsonc (or a
+// trailing "(covers: AUTH-R3-S2)" in tasks.md, carried over by accept).
+// Scenario IDs are positional, <SPEC>-R<n>-S<m>, so a scenario's progress
+// is the share of its covering tasks, across active and archived changes,
+// that are completed.
+package progress
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/parsers"
+)
+
+// archiveDirName is the archived changes directory inside spectr/changes.
+const archiveDirName = "archive"
+
+// TaskRef is a task that covers a scenario.
+type TaskRef struct {
+	Change string                  `json:"change"`
+	Task   string                  `json:"task"`
+	Status parsers.TaskStatusValue `json:"status"`
+}
+
+// Index maps scenario IDs, in upper case, to the tasks covering them.
+type Index map[string][]TaskRef
+
+// Scenario is the progress of one scenario.
+type Scenario struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Tasks     []TaskRef `json:"tasks"`
+	Completed int       `json:"completed"`
+}
+
+// Done reports whether the scenario has covering tasks and all of them
+// are completed.
+func (s Scenario) Done() bool {
+	return len(s.Tasks) > 0 && s.Completed == len(s.Tasks)
+}
+
+// Load reads the covers of every task in changesDir, both active and
+// archived changes. Version 2 child task files are followed one level
+// deep. Changes without tasks.jsonc are skipped.
+func Load(changesDir string) (Index, error) {
+	idx := make(Index)
+
+	dirs, err := changeDirs(changesDir)
+	if err != nil {
+		return nil, err
+	}
+	for _, dir := range dirs {
+		changeID := filepath.Base(dir)
+		if filepath.Base(filepath.Dir(dir)) == archiveDirName {
+			changeID = archiveDirName + "/" + changeID
+		}
+		if err := idx.addFile(changeID, filepath.Join(dir, "tasks.jsonc"), true); err != nil {
+			return nil, err
+		}
+	}
+
+	for id := range idx {
+		sort.SliceStable(idx[id], func(i, j int) bool {
+			a, b := idx[id][i], idx[id][j]
+			if a.Change != b.Change {
+				return a.Change < b.Change
+			}
+
+			return a.Task < b.Task
+		})
+	}
+
+	return idx, nil
+}
+
+// Requirement returns the progress of the scenarios of the reqNum-th
+// requirement (counted from 1) of specID, given their names in order.
+func (idx Index) Requirement(
+	specID string,
+	reqNum int,
+	scenarioNames []string,
+) []Scenario {
+	scenarios := make([]Scenario, 0, len(scenarioNames))
+	for i, name := range scenarioNames {
+		id := parsers.ScenarioID(specID, reqNum, i+1)
+		s := Scenario{ID: id, Name: name, Tasks: idx[id]}
+		if s.Tasks == nil {
+			s.Tasks = make([]TaskRef, 0)
+		}
+		for _, task := range s.Tasks {
+			if task.Status == parsers.TaskStatusCompleted {
+				s.Completed++
+			}
+		}
+		scenarios = append(scenarios, s)
+	}
+
+	return scenarios
+}
+
+// addFile adds the covers of the tasks in a tasks.jsonc file.
+func (idx Index) addFile(changeID, path string, followChildren bool) error {
+	tasksFile, err := parsers.ReadTasksJson(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	for _, task := range tasksFile.Tasks {
+		ref, hasChildren := strings.CutPrefix(task.Children, "$ref:")
+		if followChildren && hasChildren {
+			err := idx.addFile(changeID, filepath.Join(filepath.Dir(path), ref), false)
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, id := range task.Covers {
+			key := strings.ToUpper(strings.TrimSpace(id))
+			idx[key] = append(idx[key], TaskRef{
+				Change: changeID,
+				Task:   task.ID,
+				Status: task.Status,
+			})
+		}
+	}
+
+	return nil
+}
+
+// changeDirs returns the active and archived change directories.
+func changeDirs(changesDir string) ([]string, error) {
+	var dirs []string
+	for _, dir := range []string{changesDir, filepath.Join(changesDir, archiveDirName)} {
+		entries, err := os.ReadDir(dir)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", dir, err)
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() || entry.Name() == archiveDirName ||
+				strings.HasPrefix(entry.Name(), ".") {
+				continue
+			}
+			dirs = append(dirs, filepath.Join(dir, entry.Name()))
+		}
+	}
+
+	return dirs, nil
+}
@@ -0,0 +1,73 @@
+package progress
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/connerohnesorge/spectr/internal/testutil"
+)
+
+func TestLoadAndRequirement(t *testing.T) {
+	changesDir := filepath.Join(t.TempDir(), "changes")
+
+	testutil.WriteFile(t, filepath.Join(changesDir, "add-mfa", "tasks.jsonc"), `{
+  // Generated by spectr accept
+  "version": 2,
+  "tasks": [
+    {"id": "1", "section": "Impl", "description": "Impl", "status": "in_progress",
+     "children": "$ref:tasks-1.jsonc"},
+    {"id": "2.1", "section": "Docs", "description": "Docs", "status": "completed",
+     "covers": ["auth-r1-s2"]}
+  ]
+}`)
+	testutil.WriteFile(t, filepath.Join(changesDir, "add-mfa", "tasks-1.jsonc"), `{
+  "version": 2,
+  "parent": "1",
+  "tasks": [
+    {"id": "1.1", "section": "Impl", "description": "Form", "status": "completed",
+     "covers": ["AUTH-R1-S1", "AUTH-R1-S2"]},
+    {"id": "1.2", "section": "Impl", "description": "Codes", "status": "pending",
+     "covers": ["AUTH-R1-S2"]}
+  ]
+}`)
+	testutil.WriteFile(t, filepath.Join(changesDir, "archive", "2025-01-01-add-login", "tasks.jsonc"), `{
+  "version": 1,
+  "tasks": [
+    {"id": "1.1", "section": "Impl", "description": "Login", "status": "completed",
+     "covers": ["AUTH-R2-S1"]}
+  ]
+}`)
+	// Changes that were never accepted have no covers
+	testutil.WriteFile(t, filepath.Join(changesDir, "draft", "tasks.md"), "- [ ] 1.1 Draft\n")
+
+	idx, err := Load(changesDir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	first := idx.Requirement("auth", 1, []string{"Code sent", "Code checked", "Code expired"})
+	if len(first) != 3 {
+		t.Fatalf("got %d scenarios, want 3", len(first))
+	}
+	if first[0].ID != "AUTH-R1-S1" || !first[0].Done() {
+		t.Errorf("scenario 1 = %+v, want done", first[0])
+	}
+	if len(first[1].Tasks) != 3 || first[1].Completed != 2 || first[1].Done() {
+		t.Errorf("scenario 2 = %+v, want 2 of 3 tasks completed", first[1])
+	}
+	if len(first[2].Tasks) != 0 || first[2].Done() {
+		t.Errorf("uncovered scenario = %+v, want not done", first[2])
+	}
+
+	second := idx.Requirement("auth", 2, []string{"Login"})
+	if !second[0].Done() || second[0].Tasks[0].Change != "archive/2025-01-01-add-login" {
+		t.Errorf("archived coverage = %+v", second[0])
+	}
+}
+
+func TestLoad_MissingChangesDir(t *testing.T) {
+	idx, err := Load(filepath.Join(t.TempDir(), "changes"))
+	if err != nil || len(idx) != 0 {
+		t.Errorf("Load() = %v, %v; want empty index", idx, err)
+	}
+}
@@ -0,0 +1,387 @@
+package prompt
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/fileio"
+	"github.com/connerohnesorge/spectr/internal/markdown"
+	"github.com/connerohnesorge/spectr/internal/parsers"
+)
+
+// Reasons a requirement is included. Requirements the change modifies,
+// removes or renames are active; the other requirements of the same specs
+// are siblings; requirements reached through wikilinks are background.
+const (
+	ReasonModified = "modified"
+	ReasonRemoved  = "removed"
+	ReasonRenamed  = "renamed"
+	ReasonSibling  = "sibling"
+	ReasonLinked   = "linked"
+)
+
+// requirementAnchorPrefix marks a wikilink anchor naming a requirement.
+const requirementAnchorPrefix = "requirement:"
+
+// Document is the assembled context of a change.
+type Document struct {
+	ChangeID string `json:"changeId"`
+	// Model is the tokenizer preset used for estimates
+	Model string `json:"model"`
+	// MaxTokens is the limit the document was fitted to, 0 for none
+	MaxTokens int `json:"maxTokens"`
+	// Tokens is the estimated size of the rendered document
+	Tokens       int           `json:"estimatedTokens"`
+	Proposal     string        `json:"proposal"`
+	Design       string        `json:"design,omitempty"`
+	Tasks        []Task        `json:"pendingTasks"`
+	Deltas       []Delta       `json:"deltas"`
+	Requirements []Requirement `json:"requirements"`
+	// Omitted lists what was dropped or shortened to meet the budget
+	Omitted []string `json:"omitted,omitempty"`
+}
+
+// Task is a task that is not completed yet.
+type Task struct {
+	ID          string `json:"id,omitempty"`
+	Section     string `json:"section,omitempty"`
+	Description string `json:"description"`
+	Status      string `json:"status"`
+}
+
+// Delta is one delta spec file of the change.
+type Delta struct {
+	Spec    string `json:"spec"`
+	Content string `json:"content"`
+}
+
+// Requirement is the current text of a requirement in spectr/specs.
+type Requirement struct {
+	Spec    string `json:"spec"`
+	Name    string `json:"name"`
+	Reason  string `json:"reason"`
+	Content string `json:"content"`
+}
+
+// Build assembles the full, unfitted document for changeID in the project
+// at projectRoot.
+func Build(projectRoot, changeID string) (*Document, error) {
+	changeDir := filepath.Join(projectRoot, "spectr", "changes", changeID)
+	proposal, err := fileio.ReadFile(filepath.Join(changeDir, "proposal.md"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read proposal: %w", err)
+	}
+	design, err := readOptional(filepath.Join(changeDir, "design.md"))
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &Document{
+		ChangeID: changeID,
+		Proposal: string(proposal),
+		Design:   design,
+	}
+
+	doc.Tasks, err = pendingTasks(changeDir)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &builder{
+		specsDir: filepath.Join(projectRoot, "spectr", "specs"),
+		seen:     make(map[string]bool),
+	}
+	if err := b.addDeltas(doc, changeDir); err != nil {
+		return nil, err
+	}
+	for _, delta := range doc.Deltas {
+		if err := b.addSiblings(doc, delta.Spec); err != nil {
+			return nil, err
+		}
+	}
+
+	sources := []string{doc.Proposal, doc.Design}
+	for _, delta := range doc.Deltas {
+		sources = append(sources, delta.Content)
+	}
+	for _, source := range sources {
+		for _, link := range markdown.ExtractWikilinks([]byte(source)) {
+			if err := b.addLinked(doc, projectRoot, link); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return doc, nil
+}
+
+// builder collects requirements without duplicates.
+type builder struct {
+	specsDir string
+	// seen holds spec + "\x00" + normalized requirement name
+	seen map[string]bool
+}
+
+// addDeltas adds the change's delta specs and the base requirements they
+// modify, remove or rename.
+func (b *builder) addDeltas(doc *Document, changeDir string) error {
+	deltaDir := filepath.Join(changeDir, "specs")
+	var paths []string
+	err := fileio.WalkDir(
+		deltaDir,
+		func(path string, d os.DirEntry, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) && path == deltaDir {
+					return filepath.SkipDir
+				}
+
+				return err
+			}
+			if !d.IsDir() && d.Name() == "spec.md" {
+				paths = append(paths, path)
+			}
+
+			return nil
+		},
+	)
+	if err != nil {
+		return err
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		rel, err := filepath.Rel(deltaDir, filepath.Dir(path))
+		if err != nil {
+			return err
+		}
+		spec := filepath.ToSlash(rel)
+
+		content, err := fileio.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		doc.Deltas = append(doc.Deltas, Delta{Spec: spec, Content: string(content)})
+
+		plan, err := parsers.ParseDeltaSpec(path)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		for _, req := range plan.Modified {
+			if err := b.addRequirement(doc, spec, req.Name, ReasonModified); err != nil {
+				return err
+			}
+		}
+		for _, name := range plan.Removed {
+			if err := b.addRequirement(doc, spec, name, ReasonRemoved); err != nil {
+				return err
+			}
+		}
+		for _, op := range plan.Renamed {
+			if err := b.addRequirement(doc, spec, op.From, ReasonRenamed); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// addSiblings adds the requirements of spec that the change does not
+// touch.
+func (b *builder) addSiblings(doc *Document, spec string) error {
+	path := filepath.Join(b.specsDir, filepath.FromSlash(spec), "spec.md")
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil
+	}
+	blocks, err := parsers.ParseRequirements(path)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	for _, block := range blocks {
+		b.appendRequirement(doc, spec, block, ReasonSibling)
+	}
+
+	return nil
+}
+
+// addLinked adds the requirements a wikilink points at. Links to a spec
+// with a requirement anchor add that requirement; links to a whole spec
+// add all of its requirements. Links to changes are ignored.
+func (b *builder) addLinked(
+	doc *Document,
+	projectRoot string,
+	link *markdown.Wikilink,
+) error {
+	path, exists := markdown.ResolveWikilink(link.Target, projectRoot)
+	if !exists || filepath.Base(path) != "spec.md" {
+		return nil
+	}
+	rel, err := filepath.Rel(b.specsDir, filepath.Dir(path))
+	if err != nil {
+		return err
+	}
+	if strings.HasPrefix(rel, "..") {
+		// Not a spec under spectr/specs
+		return nil
+	}
+	spec := filepath.ToSlash(rel)
+
+	anchor := strings.TrimSpace(link.Anchor)
+	if name, ok := cutPrefixFold(anchor, requirementAnchorPrefix); ok {
+		return b.addRequirement(doc, spec, strings.TrimSpace(name), ReasonLinked)
+	}
+
+	blocks, err := parsers.ParseRequirements(path)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	for _, block := range blocks {
+		if anchor != "" && !strings.EqualFold(block.Name, anchor) {
+			continue
+		}
+		b.appendRequirement(doc, spec, block, ReasonLinked)
+	}
+
+	return nil
+}
+
+// addRequirement adds the current text of a named requirement of spec.
+// Requirements missing from spectr/specs are skipped.
+func (b *builder) addRequirement(
+	doc *Document,
+	spec, name, reason string,
+) error {
+	path := filepath.Join(b.specsDir, filepath.FromSlash(spec), "spec.md")
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		// New specs have no base requirements
+		return nil
+	}
+	blocks, err := parsers.ParseRequirements(path)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	want := parsers.NormalizeRequirementName(name)
+	for _, block := range blocks {
+		if parsers.NormalizeRequirementName(block.Name) == want {
+			b.appendRequirement(doc, spec, block, reason)
+
+			break
+		}
+	}
+
+	return nil
+}
+
+// appendRequirement adds block unless it is already in the document.
+func (b *builder) appendRequirement(
+	doc *Document,
+	spec string,
+	block parsers.RequirementBlock,
+	reason string,
+) {
+	key := spec + "\x00" + parsers.NormalizeRequirementName(block.Name)
+	if b.seen[key] {
+		return
+	}
+	b.seen[key] = true
+
+	doc.Requirements = append(doc.Requirements, Requirement{
+		Spec:    spec,
+		Name:    block.Name,
+		Reason:  reason,
+		Content: strings.TrimSpace(block.Raw),
+	})
+}
+
+// pendingTasks returns the tasks that are not completed, from tasks.jsonc
+// (following version 2 child files) or, before accept, from tasks.md.
+func pendingTasks(changeDir string) ([]Task, error) {
+	jsoncPath := filepath.Join(changeDir, "tasks.jsonc")
+	if _, err := os.Stat(jsoncPath); err == nil {
+		return pendingTasksFromJSON(jsoncPath, true)
+	}
+
+	content, err := readOptional(filepath.Join(changeDir, "tasks.md"))
+	if err != nil {
+		return nil, err
+	}
+
+	var tasks []Task
+	for _, line := range strings.Split(content, "\n") {
+		state, ok := markdown.MatchTaskCheckbox(line)
+		if !ok || markdown.IsTaskChecked(state) {
+			continue
+		}
+		task := Task{Status: string(parsers.TaskStatusPending)}
+		if match, ok := markdown.MatchNumberedTask(line); ok {
+			task.ID = match.Number
+			task.Description = match.Content
+		} else {
+			// Drop the "- [ ] " marker
+			task.Description = strings.TrimSpace(strings.TrimLeft(line, " \t")[5:])
+		}
+		tasks = append(tasks, task)
+	}
+
+	return tasks, nil
+}
+
+// pendingTasksFromJSON reads the incomplete tasks of a tasks.jsonc file.
+func pendingTasksFromJSON(path string, followChildren bool) ([]Task, error) {
+	file, err := parsers.ReadTasksJson(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var tasks []Task
+	for _, task := range file.Tasks {
+		if ref, ok := strings.CutPrefix(task.Children, "$ref:"); ok && followChildren {
+			children, err := pendingTasksFromJSON(
+				filepath.Join(filepath.Dir(path), ref),
+				false,
+			)
+			if err == nil {
+				tasks = append(tasks, children...)
+
+				continue
+			}
+		}
+		if task.Status == parsers.TaskStatusCompleted {
+			continue
+		}
+		tasks = append(tasks, Task{
+			ID:          task.ID,
+			Section:     task.Section,
+			Description: task.Description,
+			Status:      string(task.Status),
+		})
+	}
+
+	return tasks, nil
+}
+
+// readOptional reads a file that may not exist.
+func readOptional(path string) (string, error) {
+	data, err := fileio.ReadFile(path)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+// cutPrefixFold is strings.CutPrefix ignoring case.
+func cutPrefixFold(s, prefix string) (string, bool) {
+	if len(s) < len(prefix) || !strings.EqualFold(s[:len(prefix)], prefix) {
+		return s, false
+	}
+
+	return s[len(prefix):], true
+}
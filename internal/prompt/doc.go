@@ -0,0 +1,10 @@
+// Package prompt assembles the context of a change into one document for
+// an LLM: the proposal and design, pending tasks, the change's delta specs,
+// the current text of the requirements those deltas touch and of their
+// sibling requirements, and the requirements its documents link to with
+// wikilinks.
+//
+// Documents are sized to a token limit by dropping the least important
+// items first; see Fit. Token counts are estimates from a tokens.Model
+// preset.
+package prompt
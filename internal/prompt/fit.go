@@ -0,0 +1,137 @@
+package prompt
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/connerohnesorge/spectr/internal/tokens"
+)
+
+// truncationNote marks a shortened proposal.
+const truncationNote = "\n\n[... truncated to fit the token limit]"
+
+// Fit shrinks doc until render(doc) is estimated by model to take at most
+// maxTokens tokens, recording each dropped item in Omitted. Items go least
+// important first: background (linked) requirements, sibling requirements,
+// the design, active requirements, delta specs, then pending tasks from
+// the end of the list. If the proposal alone is still too long it is
+// truncated. A maxTokens of 0 or less leaves the document whole.
+func Fit(
+	doc *Document,
+	maxTokens int,
+	model tokens.Model,
+	render func(*Document) string,
+) {
+	doc.Model = model.Name
+	doc.MaxTokens = max(maxTokens, 0)
+	defer func() { doc.Tokens = model.Estimate(render(doc)) }()
+	if maxTokens <= 0 {
+		return
+	}
+
+	fits := func() bool { return model.Estimate(render(doc)) <= maxTokens }
+
+	for !fits() {
+		if !dropOne(doc) {
+			break
+		}
+	}
+	if fits() {
+		return
+	}
+
+	// Only the proposal is left; keep as much of its start as fits
+	full := doc.Proposal
+	doc.Omitted = append(doc.Omitted, "end of the proposal")
+	low, high := 0, len(full)
+	for low < high {
+		mid := (low + high + 1) / 2
+		doc.Proposal = full[:mid] + truncationNote
+		if fits() {
+			low = mid
+		} else {
+			high = mid - 1
+		}
+	}
+	// Do not split a multi-byte character
+	for low > 0 && low < len(full) && !utf8.RuneStart(full[low]) {
+		low--
+	}
+	doc.Proposal = full[:low] + truncationNote
+}
+
+// dropOrder lists requirement reasons from least to most important.
+var dropOrder = [][]string{
+	{ReasonLinked},
+	{ReasonSibling},
+	nil, // the design
+	{ReasonModified, ReasonRemoved, ReasonRenamed},
+}
+
+// dropOne removes the least important remaining item, reporting false
+// when only the proposal is left.
+func dropOne(doc *Document) bool {
+	for _, reasons := range dropOrder {
+		if reasons == nil {
+			if doc.Design != "" {
+				doc.Design = ""
+				doc.Omitted = append(doc.Omitted, "design.md")
+
+				return true
+			}
+
+			continue
+		}
+		if i := lastRequirement(doc, reasons); i >= 0 {
+			dropRequirement(doc, i)
+
+			return true
+		}
+	}
+	if n := len(doc.Deltas); n > 0 {
+		doc.Omitted = append(
+			doc.Omitted,
+			fmt.Sprintf("delta spec %s", doc.Deltas[n-1].Spec),
+		)
+		doc.Deltas = doc.Deltas[:n-1]
+
+		return true
+	}
+	if n := len(doc.Tasks); n > 0 {
+		task := doc.Tasks[n-1]
+		name := task.Description
+		if task.ID != "" {
+			name = task.ID
+		}
+		doc.Omitted = append(doc.Omitted, "task "+name)
+		doc.Tasks = doc.Tasks[:n-1]
+
+		return true
+	}
+
+	return false
+}
+
+// lastRequirement returns the index of the last requirement included for
+// one of reasons, or -1.
+func lastRequirement(doc *Document, reasons []string) int {
+	for i := len(doc.Requirements) - 1; i >= 0; i-- {
+		for _, reason := range reasons {
+			if doc.Requirements[i].Reason == reason {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
+
+// dropRequirement removes requirement i and records it as omitted.
+func dropRequirement(doc *Document, i int) {
+	req := doc.Requirements[i]
+	doc.Omitted = append(
+		doc.Omitted,
+		fmt.Sprintf("requirement %s: %s (%s)", req.Spec, req.Name, req.Reason),
+	)
+	doc.Requirements = append(doc.Requirements[:i], doc.Requirements[i+1:]...)
+}
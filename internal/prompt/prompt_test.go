@@ -0,0 +1,246 @@
+package prompt
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/connerohnesorge/spectr/internal/tokens"
+)
+
+const authSpec = `# Auth
+
+## Requirements
+
+### Requirement: User Login
+
+Users SHALL sign in with email and password.
+
+### Requirement: Session Expiry
+
+Sessions SHALL expire after 14 days.
+`
+
+const billingSpec = `# Billing
+
+## Requirements
+
+### Requirement: Invoices
+
+The system SHALL email an invoice every month.
+
+### Requirement: Refunds
+
+Refunds SHALL be issued within 5 days.
+`
+
+const deltaSpec = `## MODIFIED Requirements
+
+### Requirement: User Login
+
+Users SHALL sign in with email, password and a TOTP code.
+
+## ADDED Requirements
+
+### Requirement: Recovery Codes
+
+Users SHALL receive ten recovery codes. See [[billing#Requirement: Invoices]].
+`
+
+const tasksJSONC = `// Generated by: spectr accept add-2fa
+{
+  "version": 1,
+  "tasks": [
+    {"id": "1.1", "section": "Build", "description": "Store secrets", "status": "completed"},
+    {"id": "1.2", "section": "Build", "description": "Ask for the code", "status": "in_progress"},
+    {"id": "2.1", "section": "Test", "description": "Test login", "status": "pending"}
+  ]
+}
+`
+
+// setupProject writes a project with one change, add-2fa.
+func setupProject(t *testing.T, proposal string) string {
+	t.Helper()
+
+	root := t.TempDir()
+	files := map[string]string{
+		"spectr/specs/auth/spec.md":                 authSpec,
+		"spectr/specs/billing/spec.md":              billingSpec,
+		"spectr/changes/add-2fa/proposal.md":        proposal,
+		"spectr/changes/add-2fa/specs/auth/spec.md": deltaSpec,
+		"spectr/changes/add-2fa/tasks.jsonc":        tasksJSONC,
+	}
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return root
+}
+
+func TestBuild(t *testing.T) {
+	root := setupProject(t, "# Change: Add 2FA\n\nBuilds on [[auth]].\n")
+
+	doc, err := Build(root, "add-2fa")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(doc.Tasks) != 2 || doc.Tasks[0].ID != "1.2" || doc.Tasks[1].ID != "2.1" {
+		t.Errorf("pending tasks = %+v, want 1.2 and 2.1", doc.Tasks)
+	}
+	if len(doc.Deltas) != 1 || doc.Deltas[0].Spec != "auth" {
+		t.Errorf("deltas = %+v", doc.Deltas)
+	}
+
+	var got []string
+	for _, req := range doc.Requirements {
+		got = append(got, req.Spec+"/"+req.Name+"/"+req.Reason)
+	}
+	want := []string{
+		"auth/User Login/modified",
+		"auth/Session Expiry/sibling",
+		"billing/Invoices/linked",
+	}
+	if strings.Join(got, ", ") != strings.Join(want, ", ") {
+		t.Errorf("requirements = %v, want %v", got, want)
+	}
+	if !strings.Contains(doc.Requirements[0].Content, "email and password.") {
+		t.Errorf("modified requirement should carry the current text, got %q",
+			doc.Requirements[0].Content)
+	}
+}
+
+func TestBuild_TasksMarkdown(t *testing.T) {
+	root := setupProject(t, "# Change\n")
+	changeDir := filepath.Join(root, "spectr", "changes", "add-2fa")
+	if err := os.Remove(filepath.Join(changeDir, "tasks.jsonc")); err != nil {
+		t.Fatal(err)
+	}
+	tasks := "## 1. Build\n\n- [x] 1.1 Store secrets\n- [ ] 1.2 Ask for the code\n"
+	if err := os.WriteFile(filepath.Join(changeDir, "tasks.md"), []byte(tasks), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	doc, err := Build(root, "add-2fa")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(doc.Tasks) != 1 || doc.Tasks[0].ID != "1.2" ||
+		doc.Tasks[0].Description != "Ask for the code" {
+		t.Errorf("pending tasks = %+v", doc.Tasks)
+	}
+}
+
+func TestMarkdown(t *testing.T) {
+	root := setupProject(t, "# Change: Add 2FA\n\n```go\nx := 1\n```\n")
+	doc, err := Build(root, "add-2fa")
+	if err != nil {
+		t.Fatal(err)
+	}
+	Fit(doc, 0, defaultModel(t), Markdown)
+
+	out := Markdown(doc)
+	for _, want := range []string{
+		"# Change context: add-2fa",
+		"## Proposal\n\n````markdown\n# Change: Add 2FA",
+		"- [ ] 1.2 Ask for the code (in progress)\n- [ ] 2.1 Test login\n",
+		"### auth\n\n```markdown\n## MODIFIED Requirements",
+		"### billing: Invoices (linked from this change)",
+		"### auth: User Login (modified by this change)",
+		"### auth: Session Expiry (same spec, unchanged)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("markdown does not contain %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Omitted") || strings.Contains(out, "limit") {
+		t.Errorf("unlimited output should not mention the limit:\n%s", out)
+	}
+}
+
+func TestFit(t *testing.T) {
+	root := setupProject(t, "# Change: Add 2FA\n\n"+strings.Repeat("Context. ", 40))
+	model := defaultModel(t)
+
+	full, err := Build(root, "add-2fa")
+	if err != nil {
+		t.Fatal(err)
+	}
+	Fit(full, 0, model, Markdown)
+
+	for _, render := range []func(*Document) string{Markdown, JSON} {
+		for _, limit := range []int{full.Tokens - 40, full.Tokens / 2, 150} {
+			doc, _ := Build(root, "add-2fa")
+			Fit(doc, limit, model, render)
+
+			if doc.Tokens > limit {
+				t.Errorf("limit %d: %d tokens", limit, doc.Tokens)
+			}
+			if len(doc.Omitted) == 0 {
+				t.Fatalf("limit %d: nothing omitted", limit)
+			}
+			// Background goes first
+			if doc.Omitted[0] != "requirement billing: Invoices (linked)" {
+				t.Errorf("limit %d: first omitted %q", limit, doc.Omitted[0])
+			}
+		}
+	}
+
+	doc, _ := Build(root, "add-2fa")
+	Fit(doc, 150, model, Markdown)
+	want := []string{
+		"requirement billing: Invoices (linked)",
+		"requirement auth: Session Expiry (sibling)",
+		"requirement auth: User Login (modified)",
+		"delta spec auth",
+		"task 2.1",
+		"task 1.2",
+		"end of the proposal",
+	}
+	if strings.Join(doc.Omitted, "; ") != strings.Join(want, "; ") {
+		t.Errorf("omitted = %q, want %q", doc.Omitted, want)
+	}
+	if !strings.HasSuffix(doc.Proposal, truncationNote) {
+		t.Errorf("proposal should be truncated at a small limit: %q", doc.Proposal)
+	}
+	if doc.Model != tokens.DefaultModel || doc.MaxTokens != 150 {
+		t.Errorf("model %q, max tokens %d", doc.Model, doc.MaxTokens)
+	}
+}
+
+func TestFit_Model(t *testing.T) {
+	root := setupProject(t, "# Change\n\n"+strings.Repeat("Context. ", 40))
+	claude, err := tokens.Lookup("claude")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	byDefault, _ := Build(root, "add-2fa")
+	Fit(byDefault, 0, defaultModel(t), Markdown)
+	byClaude, _ := Build(root, "add-2fa")
+	Fit(byClaude, 0, claude, Markdown)
+
+	if byClaude.Tokens <= byDefault.Tokens {
+		t.Errorf("claude preset estimated %d tokens, default %d; want more",
+			byClaude.Tokens, byDefault.Tokens)
+	}
+}
+
+// defaultModel returns the default tokenizer preset.
+func defaultModel(t *testing.T) tokens.Model {
+	t.Helper()
+
+	model, err := tokens.Lookup(tokens.DefaultModel)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return model
+}
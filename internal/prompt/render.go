@@ -0,0 +1,124 @@
+package prompt
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// JSON renders the document as indented JSON.
+func JSON(doc *Document) string {
+	// A Document holds only strings, ints and slices, so encoding cannot fail
+	data, _ := json.MarshalIndent(doc, "", "  ")
+
+	return string(data) + "\n"
+}
+
+// Markdown renders the document as markdown. Source documents are embedded
+// in fenced blocks so their headings do not mix with the outline.
+func Markdown(doc *Document) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# Change context: %s\n\n", doc.ChangeID)
+	if doc.MaxTokens > 0 {
+		fmt.Fprintf(
+			&sb,
+			"_About %d tokens (%s estimate), limited to %d._\n\n",
+			doc.Tokens,
+			doc.Model,
+			doc.MaxTokens,
+		)
+	}
+
+	writeSection(&sb, "Proposal", doc.Proposal)
+	if doc.Design != "" {
+		writeSection(&sb, "Design", doc.Design)
+	}
+
+	if len(doc.Tasks) > 0 {
+		sb.WriteString("## Pending Tasks\n\n")
+		for _, task := range doc.Tasks {
+			sb.WriteString(formatTask(task))
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(doc.Deltas) > 0 {
+		sb.WriteString("## Spec Deltas\n\n")
+		for _, delta := range doc.Deltas {
+			fmt.Fprintf(&sb, "### %s\n\n", delta.Spec)
+			writeFenced(&sb, delta.Content)
+		}
+	}
+
+	if len(doc.Requirements) > 0 {
+		sb.WriteString("## Current Requirements\n\n")
+		for _, req := range doc.Requirements {
+			fmt.Fprintf(
+				&sb,
+				"### %s: %s (%s)\n\n",
+				req.Spec,
+				req.Name,
+				describeReason(req.Reason),
+			)
+			writeFenced(&sb, req.Content)
+		}
+	}
+
+	if len(doc.Omitted) > 0 {
+		sb.WriteString("## Omitted to fit the token limit\n\n")
+		for _, item := range doc.Omitted {
+			fmt.Fprintf(&sb, "- %s\n", item)
+		}
+		sb.WriteString("\n")
+	}
+
+	return strings.TrimRight(sb.String(), "\n") + "\n"
+}
+
+// writeSection writes a titled, fenced source document.
+func writeSection(sb *strings.Builder, title, content string) {
+	fmt.Fprintf(sb, "## %s\n\n", title)
+	writeFenced(sb, content)
+}
+
+// writeFenced writes content in a markdown code fence long enough not to
+// be closed by fences inside the content.
+func writeFenced(sb *strings.Builder, content string) {
+	fence := "```"
+	for strings.Contains(content, fence) {
+		fence += "`"
+	}
+	fmt.Fprintf(
+		sb,
+		"%smarkdown\n%s\n%s\n\n",
+		fence,
+		strings.TrimRight(content, "\n"),
+		fence,
+	)
+}
+
+// formatTask renders a pending task as a checklist line.
+func formatTask(task Task) string {
+	line := "- [ ] "
+	if task.ID != "" {
+		line += task.ID + " "
+	}
+	line += task.Description
+	if task.Status != "" && task.Status != "pending" {
+		line += " (" + strings.ReplaceAll(task.Status, "_", " ") + ")"
+	}
+
+	return line + "\n"
+}
+
+// describeReason explains why a requirement is included.
+func describeReason(reason string) string {
+	switch reason {
+	case ReasonLinked:
+		return "linked from this change"
+	case ReasonSibling:
+		return "same spec, unchanged"
+	default:
+		return reason + " by this change"
+	}
+}
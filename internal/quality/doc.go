@@ -0,0 +1,16 @@
+// Package quality scores specs from 0 to 100 so that thin or neglected
+// specs stand out in `spectr list --columns quality` and in exports.
+//
+// The score is a weighted mean of four parts, each from 0 to 1:
+//
+//   - lint: 1 without validation findings, less 0.25 per error and 0.1
+//     per warning
+//   - coverage: the share of requirements with `spectr:impl` markers
+//   - scenarios: scenarios per requirement, counting up to two for each
+//   - links: the share of wikilinks whose target (and anchor) resolve,
+//     including into vendored and sibling projects; 1 when the spec has no
+//     links
+//
+// DefaultWeights can be overridden per part under quality.weights in
+// spectr.yaml.
+package quality
@@ -0,0 +1,196 @@
+package quality
+
+import (
+	"fmt"
+	"math"
+	"path/filepath"
+
+	"github.com/connerohnesorge/spectr/internal/config"
+	"github.com/connerohnesorge/spectr/internal/fileio"
+	"github.com/connerohnesorge/spectr/internal/implindex"
+	"github.com/connerohnesorge/spectr/internal/links"
+	"github.com/connerohnesorge/spectr/internal/markdown"
+	"github.com/connerohnesorge/spectr/internal/parsers"
+	"github.com/connerohnesorge/spectr/internal/validation"
+)
+
+const (
+	// Lint part penalties per validation finding.
+	lintErrorPenalty   = 0.25
+	lintWarningPenalty = 0.1
+
+	// targetScenarios is the number of scenarios per requirement that earns
+	// the full scenarios part.
+	targetScenarios = 2
+)
+
+// Weights are the relative weights of the parts of the score. They need
+// not add up to 100; a weight of zero leaves that part out.
+type Weights struct {
+	Lint      float64 `json:"lint"`
+	Coverage  float64 `json:"coverage"`
+	Scenarios float64 `json:"scenarios"`
+	Links     float64 `json:"links"`
+}
+
+// DefaultWeights are used for the parts spectr.yaml does not weigh.
+var DefaultWeights = Weights{
+	Lint:      40,
+	Coverage:  20,
+	Scenarios: 25,
+	Links:     15,
+}
+
+// ConfiguredWeights returns DefaultWeights with the overrides of
+// quality.weights in cfg applied.
+func ConfiguredWeights(cfg *config.Config) Weights {
+	w := DefaultWeights
+	overrides := cfg.QualityWeights()
+	for _, o := range []struct {
+		value  *float64
+		weight *float64
+	}{
+		{overrides.Lint, &w.Lint},
+		{overrides.Coverage, &w.Coverage},
+		{overrides.Scenarios, &w.Scenarios},
+		{overrides.Links, &w.Links},
+	} {
+		if o.value != nil {
+			*o.weight = *o.value
+		}
+	}
+
+	return w
+}
+
+// Report is the quality score of a spec with its parts, each from 0 to
+// 100, and the counts they were computed from.
+type Report struct {
+	Score int `json:"score"`
+
+	Lint      int `json:"lint"`
+	Coverage  int `json:"coverage"`
+	Scenarios int `json:"scenarios"`
+	Links     int `json:"links"`
+
+	Errors        int `json:"errors"`
+	Warnings      int `json:"warnings"`
+	Requirements  int `json:"requirements"`
+	Implemented   int `json:"implemented"`
+	ScenarioTotal int `json:"scenarioTotal"`
+	LinkTotal     int `json:"linkTotal"`
+	BrokenLinks   int `json:"brokenLinks"`
+}
+
+// String summarizes the report as the score followed by its parts.
+func (r *Report) String() string {
+	return fmt.Sprintf(
+		"%d/100 (lint %d, coverage %d, scenarios %d, links %d)",
+		r.Score, r.Lint, r.Coverage, r.Scenarios, r.Links,
+	)
+}
+
+// Scorer scores the specs of one project.
+type Scorer struct {
+	projectRoot string
+	weights     Weights
+	index       *implindex.Index
+	resolver    *links.Resolver
+}
+
+// NewScorer scans projectRoot for implementation markers and returns a
+// Scorer for its specs. Wikilinks resolve across the projects of the
+// repository (see links.NewResolver).
+func NewScorer(projectRoot string, weights Weights) (*Scorer, error) {
+	idx, err := implindex.Scan(projectRoot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan implementation markers: %w", err)
+	}
+	resolver, err := links.NewResolver(projectRoot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find wikilink namespaces: %w", err)
+	}
+
+	return &Scorer{
+		projectRoot: projectRoot,
+		weights:     weights,
+		index:       idx,
+		resolver:    resolver,
+	}, nil
+}
+
+// Score scores the spec with the given ID.
+func (s *Scorer) Score(specID string) (*Report, error) {
+	specPath := filepath.Join(s.projectRoot, "spectr", "specs", specID, "spec.md")
+
+	lint, err := validation.ValidateSpecFile(specPath)
+	if err != nil {
+		return nil, err
+	}
+	reqs, err := parsers.ParseRequirements(specPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", specPath, err)
+	}
+	source, err := fileio.ReadFile(specPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", specPath, err)
+	}
+
+	r := &Report{
+		Errors:       lint.Summary.Errors,
+		Warnings:     lint.Summary.Warnings,
+		Requirements: len(reqs),
+	}
+
+	var density float64
+	for _, req := range reqs {
+		if len(s.index.Lookup(specID, req.Name)) > 0 {
+			r.Implemented++
+		}
+		n := len(parsers.ParseScenarios(req.Raw))
+		r.ScenarioTotal += n
+		density += float64(min(n, targetScenarios)) / targetScenarios
+	}
+
+	root, _ := markdown.Parse(source)
+	r.LinkTotal = len(markdown.ExtractWikilinks(source))
+	r.BrokenLinks = len(markdown.ValidateWikilinksWith(root, s.resolver.Func()))
+
+	lintPart := max(0, 1-lintErrorPenalty*float64(r.Errors)-
+		lintWarningPenalty*float64(r.Warnings))
+	coveragePart := ratio(r.Implemented, r.Requirements, 0)
+	scenariosPart := 0.0
+	if r.Requirements > 0 {
+		scenariosPart = density / float64(r.Requirements)
+	}
+	linksPart := ratio(r.LinkTotal-r.BrokenLinks, r.LinkTotal, 1)
+
+	r.Lint = percent(lintPart)
+	r.Coverage = percent(coveragePart)
+	r.Scenarios = percent(scenariosPart)
+	r.Links = percent(linksPart)
+
+	w := s.weights
+	total := w.Lint + w.Coverage + w.Scenarios + w.Links
+	if total <= 0 {
+		w, total = DefaultWeights, 100
+	}
+	r.Score = percent((w.Lint*lintPart + w.Coverage*coveragePart +
+		w.Scenarios*scenariosPart + w.Links*linksPart) / total)
+
+	return r, nil
+}
+
+// ratio returns n/total, or empty when total is zero.
+func ratio(n, total int, empty float64) float64 {
+	if total == 0 {
+		return empty
+	}
+
+	return float64(n) / float64(total)
+}
+
+// percent converts a part from 0 to 1 to a rounded percentage.
+func percent(part float64) int {
+	return int(math.Round(100 * part))
+}
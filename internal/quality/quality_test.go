@@ -0,0 +1,161 @@
+package quality
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/connerohnesorge/spectr/internal/config"
+)
+
+const testSpec = `# Auth Specification
+
+## Purpose
+
+Describes how users sign in and recover their accounts over time.
+
+## Requirements
+
+### Requirement: User Login
+
+The system SHALL authenticate users with an email and password.
+
+#### Scenario: Valid credentials
+
+- **WHEN** a user submits the correct password
+- **THEN** the system SHALL start a session
+
+#### Scenario: Invalid credentials
+
+- **WHEN** a user submits an incorrect password
+- **THEN** the system SHALL reject the login
+
+### Requirement: Password Reset
+
+The system SHALL email a reset link on request.
+
+#### Scenario: Reset requested
+
+- **WHEN** a user asks for a reset
+- **THEN** the system SHALL email a link
+`
+
+// writeProject creates a project with the auth spec and the given source
+// files, returning its root.
+func writeProject(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	root := t.TempDir()
+	files["spectr/specs/auth/spec.md"] = testSpec
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return root
+}
+
+func TestScorer_Score(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string
+		weights Weights
+		want    Report
+	}{
+		{
+			name:    "no implementation",
+			files:   map[string]string{},
+			weights: DefaultWeights,
+			want: Report{
+				Score: 74, Lint: 100, Coverage: 0, Scenarios: 75, Links: 100,
+				Requirements: 2, ScenarioTotal: 3,
+			},
+		},
+		{
+			name: "one requirement implemented",
+			files: map[string]string{
+				"login.go": "package main\n\n// spectr:impl auth#User Login\n",
+			},
+			weights: DefaultWeights,
+			want: Report{
+				Score: 84, Lint: 100, Coverage: 50, Scenarios: 75, Links: 100,
+				Requirements: 2, Implemented: 1, ScenarioTotal: 3,
+			},
+		},
+		{
+			name:    "coverage only",
+			files:   map[string]string{},
+			weights: Weights{Coverage: 1},
+			want: Report{
+				Score: 0, Lint: 100, Coverage: 0, Scenarios: 75, Links: 100,
+				Requirements: 2, ScenarioTotal: 3,
+			},
+		},
+		{
+			name:    "zero weights fall back to defaults",
+			files:   map[string]string{},
+			weights: Weights{},
+			want: Report{
+				Score: 74, Lint: 100, Coverage: 0, Scenarios: 75, Links: 100,
+				Requirements: 2, ScenarioTotal: 3,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scorer, err := NewScorer(writeProject(t, tt.files), tt.weights)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := scorer.Score("auth")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if *got != tt.want {
+				t.Errorf("Score() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScorer_ScoreMissingSpec(t *testing.T) {
+	scorer, err := NewScorer(writeProject(t, map[string]string{}), DefaultWeights)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := scorer.Score("missing"); err == nil {
+		t.Error("expected an error for a missing spec")
+	}
+}
+
+func TestConfiguredWeights(t *testing.T) {
+	zero, links := 0.0, 30.0
+	cfg := &config.Config{Quality: &config.QualityConfig{
+		Weights: &config.QualityWeights{Coverage: &zero, Links: &links},
+	}}
+
+	got := ConfiguredWeights(cfg)
+	want := Weights{Lint: 40, Coverage: 0, Scenarios: 25, Links: 30}
+	if got != want {
+		t.Errorf("ConfiguredWeights() = %+v, want %+v", got, want)
+	}
+
+	if got := ConfiguredWeights(&config.Config{}); got != DefaultWeights {
+		t.Errorf("ConfiguredWeights(empty) = %+v, want defaults", got)
+	}
+}
+
+func TestReport_String(t *testing.T) {
+	r := &Report{Score: 82, Lint: 100, Coverage: 50, Scenarios: 75, Links: 100}
+	got := r.String()
+	if !strings.HasPrefix(got, "82/100") || !strings.Contains(got, "coverage 50") {
+		t.Errorf("String() = %q", got)
+	}
+}
@@ -0,0 +1,9 @@
+// Package reader implements the pager behind `spectr read`, which shows a
+// spec with terminal styling for reading without an editor.
+//
+// A Document splits the spec into lines and records its headings, found
+// with the markdown parser so that headers inside code blocks are not
+// mistaken for sections. The Pager scrolls the styled lines and adds
+// search, jumping between headings (directly or from a filterable
+// outline) and folding requirements down to their header line.
+package reader
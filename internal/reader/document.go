@@ -0,0 +1,101 @@
+package reader
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/markdown"
+)
+
+// Levels of the headings that Spectr gives structure to.
+const (
+	requirementLevel = 3
+	scenarioLevel    = 4
+)
+
+// Heading is a header line of a Document.
+type Heading struct {
+	// Level is the header level (1-6).
+	Level int
+	// Text is the header text without the # prefix, e.g.
+	// "Requirement: User Login".
+	Text string
+	// Line is the index of the header line in Document.Lines.
+	Line int
+	// Requirement is set for "### Requirement:" headers, which can be
+	// folded.
+	Requirement bool
+}
+
+// Document is a spec split into lines, with its headings.
+type Document struct {
+	// Lines are the lines of the source, without line endings.
+	Lines []string
+	// Headings are the headers in document order.
+	Headings []Heading
+}
+
+// NewDocument splits source into lines and locates its headings.
+func NewDocument(source []byte) *Document {
+	text := strings.ReplaceAll(string(source), "\r\n", "\n")
+	doc := &Document{
+		Lines: strings.Split(strings.TrimSuffix(text, "\n"), "\n"),
+	}
+
+	root, _ := markdown.Parse(source)
+	if root == nil {
+		return doc
+	}
+	for _, child := range root.Children() {
+		start, _ := child.Span()
+		h := Heading{Line: bytes.Count(source[:start], []byte("\n"))}
+
+		switch n := child.(type) {
+		case *markdown.NodeSection:
+			h.Level = n.Level()
+			h.Text = strings.TrimSpace(string(n.Title()))
+		case *markdown.NodeRequirement:
+			h.Level = requirementLevel
+			h.Text = "Requirement: " + n.Name()
+			h.Requirement = true
+		case *markdown.NodeScenario:
+			h.Level = scenarioLevel
+			h.Text = "Scenario: " + n.Name()
+		default:
+			continue
+		}
+
+		doc.Headings = append(doc.Headings, h)
+	}
+
+	return doc
+}
+
+// sectionEnd returns the index of the line after the section started by
+// heading i: the next heading of the same or a higher level, or the end of
+// the document.
+func (d *Document) sectionEnd(i int) int {
+	for _, h := range d.Headings[i+1:] {
+		if h.Level <= d.Headings[i].Level {
+			return h.Line
+		}
+	}
+
+	return len(d.Lines)
+}
+
+// headingAt returns the index of the innermost heading with a level of at
+// most maxLevel whose section contains line, or -1.
+func (d *Document) headingAt(line, maxLevel int) int {
+	found := -1
+	for i, h := range d.Headings {
+		if h.Line > line {
+			break
+		}
+		if h.Level <= maxLevel && line < d.sectionEnd(i) {
+			found = i
+		}
+	}
+
+	return found
+}
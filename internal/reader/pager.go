@@ -0,0 +1,616 @@
+//nolint:revive // TUI code - interactive model patterns require specific structure
+package reader
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/connerohnesorge/spectr/internal/tui"
+)
+
+const (
+	// Terminal size used until the first WindowSizeMsg.
+	defaultWidth  = 80
+	defaultHeight = 24
+
+	// outlineChrome is the number of lines the outline needs above and
+	// below its list.
+	outlineChrome = 5
+
+	// gutterMark flags a marked line; gutterWidth is the width of the
+	// gutter column, mark and space.
+	gutterMark  = "●"
+	gutterWidth = 2
+
+	readHelp = "/: search | n/N: next/prev | [/]: heading | o: outline | z/Z: fold | q: quit"
+)
+
+// mode is what the keyboard currently drives.
+type mode int
+
+const (
+	modeRead mode = iota
+	modeSearch
+	modeOutline
+)
+
+// row is a line shown by the pager. A folded requirement is shown as a
+// single row for its header.
+type row struct {
+	line   int
+	folded bool
+}
+
+// PagerConfig holds configuration for Pager.
+type PagerConfig struct {
+	// Title is shown in the status line, usually the spec ID.
+	Title string
+
+	// Document is the spec to show.
+	Document *Document
+
+	// Folded starts with every requirement folded.
+	Folded bool
+}
+
+// Pager shows a Document one screen at a time, like less, with search,
+// heading jumps, an outline and requirement folding.
+type Pager struct {
+	title  string
+	doc    *Document
+	styled []string
+
+	// folded holds the indexes of the folded requirement headings.
+	folded map[int]bool
+	// rows are the lines left visible by the folds.
+	rows []row
+	// offset is the index in rows of the top line on screen.
+	offset int
+
+	width  int
+	height int
+	// embedded is set when the text is drawn inside another view, such
+	// as a Preview, which leaves out the status line.
+	embedded bool
+
+	mode mode
+	// input is the query typed in search or outline mode.
+	input string
+	// message replaces the help in the status line until the next key.
+	message string
+
+	// search is the last search for query, matching lines in matches;
+	// match is the index in matches of the selected one, or -1.
+	query   string
+	search  *regexp.Regexp
+	matches []int
+	match   int
+
+	// marks holds the lines flagged in the gutter, such as requirements
+	// with open review comments. The gutter is only drawn when set.
+	marks map[int]bool
+
+	// outline holds the indexes of the headings matching input in outline
+	// mode, best match first; outlineCursor is the highlighted position.
+	outline       []int
+	outlineCursor int
+}
+
+// NewPager creates a Pager with the given configuration.
+func NewPager(config PagerConfig) *Pager {
+	p := &Pager{
+		title:  config.Title,
+		doc:    config.Document,
+		styled: StyleLines(config.Document),
+		folded: make(map[int]bool),
+		width:  defaultWidth,
+		height: defaultHeight,
+		match:  -1,
+	}
+	if config.Folded {
+		p.setAllFolded(true)
+	}
+	p.rebuild()
+
+	return p
+}
+
+// MarkRequirements flags the header lines of the named requirements in a
+// gutter beside the text, or removes the gutter when names is empty.
+// Names are matched ignoring case.
+func (p *Pager) MarkRequirements(names []string) {
+	p.marks = nil
+	for _, h := range p.doc.Headings {
+		if !h.Requirement {
+			continue
+		}
+		for _, name := range names {
+			if strings.EqualFold(strings.TrimPrefix(h.Text, "Requirement: "), strings.TrimSpace(name)) {
+				if p.marks == nil {
+					p.marks = make(map[int]bool)
+				}
+				p.marks[h.Line] = true
+			}
+		}
+	}
+}
+
+// Init implements tea.Model.
+func (*Pager) Init() tea.Cmd {
+	return nil
+}
+
+// Update implements tea.Model.
+func (p *Pager) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		p.width, p.height = msg.Width, msg.Height
+		p.scrollTo(p.offset)
+	case tea.KeyMsg:
+		if msg.Type == tea.KeyCtrlC {
+			return p, tea.Quit
+		}
+		p.message = ""
+
+		switch p.mode {
+		case modeSearch:
+			p.updateSearch(msg)
+		case modeOutline:
+			p.updateOutline(msg)
+		case modeRead:
+			return p.updateRead(msg)
+		}
+	}
+
+	return p, nil
+}
+
+// updateRead handles keys while reading.
+func (p *Pager) updateRead(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	page := p.pageHeight()
+
+	switch msg.String() {
+	case "q":
+		return p, tea.Quit
+	case "j", "down", "ctrl+n":
+		p.scrollTo(p.offset + 1)
+	case "k", "up", "ctrl+p":
+		p.scrollTo(p.offset - 1)
+	case "f", " ", "pgdown", "ctrl+f":
+		p.scrollTo(p.offset + page)
+	case "b", "pgup", "ctrl+b":
+		p.scrollTo(p.offset - page)
+	case "d", "ctrl+d":
+		p.scrollTo(p.offset + page/2)
+	case "u", "ctrl+u":
+		p.scrollTo(p.offset - page/2)
+	case "g", "home":
+		p.scrollTo(0)
+	case "G", "end":
+		p.scrollTo(len(p.rows))
+	case "]":
+		p.jumpHeading(1)
+	case "[":
+		p.jumpHeading(-1)
+	case "/":
+		p.mode = modeSearch
+		p.input = ""
+	case "n":
+		p.nextMatch(1)
+	case "N":
+		p.nextMatch(-1)
+	case "esc":
+		p.search, p.matches, p.match = nil, nil, -1
+	case "o":
+		p.openOutline()
+	case "z":
+		p.toggleFold()
+	case "Z":
+		p.setAllFolded(len(p.folded) == 0)
+		p.rebuild()
+		p.scrollTo(p.rowOf(p.topLine()))
+	}
+
+	return p, nil
+}
+
+// updateSearch handles keys while a search is typed.
+func (p *Pager) updateSearch(msg tea.KeyMsg) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		p.mode = modeRead
+	case tea.KeyEnter:
+		p.mode = modeRead
+		p.runSearch()
+	case tea.KeyBackspace:
+		if p.input == "" {
+			p.mode = modeRead
+		} else {
+			runes := []rune(p.input)
+			p.input = string(runes[:len(runes)-1])
+		}
+	case tea.KeySpace:
+		p.input += " "
+	case tea.KeyRunes:
+		p.input += string(msg.Runes)
+	}
+}
+
+// runSearch searches for the typed query, or repeats the last search when
+// nothing was typed, and selects the first match from the top line on.
+func (p *Pager) runSearch() {
+	if p.input != "" {
+		p.query = p.input
+		p.search = searchPattern(p.query)
+	}
+	if p.search == nil {
+		return
+	}
+
+	p.matches = p.matches[:0]
+	for i, line := range p.doc.Lines {
+		if p.search.MatchString(line) {
+			p.matches = append(p.matches, i)
+		}
+	}
+	if len(p.matches) == 0 {
+		p.match = -1
+		p.message = "Pattern not found: " + p.query
+
+		return
+	}
+
+	top := p.topLine()
+	p.match = 0
+	for i, line := range p.matches {
+		if line >= top {
+			p.match = i
+
+			break
+		}
+	}
+	p.jumpTo(p.matches[p.match])
+}
+
+// nextMatch selects the next (dir 1) or previous (dir -1) match, wrapping
+// around the ends of the document.
+func (p *Pager) nextMatch(dir int) {
+	if len(p.matches) == 0 {
+		if p.search != nil {
+			p.message = "Pattern not found"
+		}
+
+		return
+	}
+
+	p.match += dir
+	switch {
+	case p.match >= len(p.matches):
+		p.match = 0
+		p.message = "Search wrapped to top"
+	case p.match < 0:
+		p.match = len(p.matches) - 1
+		p.message = "Search wrapped to bottom"
+	}
+	p.jumpTo(p.matches[p.match])
+}
+
+// jumpHeading moves the top line to the next (dir 1) or previous (dir -1)
+// section or requirement header.
+func (p *Pager) jumpHeading(dir int) {
+	top := p.topLine()
+	target := -1
+	for _, h := range p.doc.Headings {
+		if h.Level > requirementLevel {
+			continue
+		}
+		if dir > 0 && h.Line > top {
+			target = h.Line
+
+			break
+		}
+		if dir < 0 && h.Line < top {
+			target = h.Line
+		}
+	}
+	if target >= 0 {
+		p.jumpTo(target)
+	}
+}
+
+// openOutline switches to the outline with the current heading
+// highlighted.
+func (p *Pager) openOutline() {
+	p.mode = modeOutline
+	p.setOutlineQuery("")
+
+	current := p.doc.headingAt(p.topLine(), scenarioLevel)
+	for i, index := range p.outline {
+		if index == current {
+			p.outlineCursor = i
+		}
+	}
+}
+
+// updateOutline handles keys in the outline.
+func (p *Pager) updateOutline(msg tea.KeyMsg) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		if p.input != "" {
+			p.setOutlineQuery("")
+		} else {
+			p.mode = modeRead
+		}
+	case tea.KeyEnter:
+		if len(p.outline) > 0 {
+			p.mode = modeRead
+			p.jumpTo(p.doc.Headings[p.outline[p.outlineCursor]].Line)
+		}
+	case tea.KeyUp, tea.KeyCtrlP:
+		p.outlineCursor = max(0, p.outlineCursor-1)
+	case tea.KeyDown, tea.KeyCtrlN:
+		p.outlineCursor = max(0, min(len(p.outline)-1, p.outlineCursor+1))
+	case tea.KeyBackspace:
+		if runes := []rune(p.input); len(runes) > 0 {
+			p.setOutlineQuery(string(runes[:len(runes)-1]))
+		}
+	case tea.KeySpace:
+		p.setOutlineQuery(p.input + " ")
+	case tea.KeyRunes:
+		p.setOutlineQuery(p.input + string(msg.Runes))
+	}
+}
+
+// setOutlineQuery filters the outline by query.
+func (p *Pager) setOutlineQuery(query string) {
+	p.input = query
+	labels := make([]string, len(p.doc.Headings))
+	for i, h := range p.doc.Headings {
+		labels[i] = h.Text
+	}
+	p.outline = tui.FuzzyFilter(query, labels)
+	p.outlineCursor = 0
+}
+
+// toggleFold folds or unfolds the requirement at the top line.
+func (p *Pager) toggleFold() {
+	req := p.requirementAt(p.topLine())
+	if req < 0 {
+		p.message = "No requirement here to fold"
+
+		return
+	}
+
+	if p.folded[req] {
+		delete(p.folded, req)
+	} else {
+		p.folded[req] = true
+	}
+	p.rebuild()
+	p.scrollTo(p.rowOf(p.doc.Headings[req].Line))
+}
+
+// setAllFolded folds or unfolds every requirement.
+func (p *Pager) setAllFolded(folded bool) {
+	clear(p.folded)
+	if !folded {
+		return
+	}
+	for i, h := range p.doc.Headings {
+		if h.Requirement {
+			p.folded[i] = true
+		}
+	}
+}
+
+// requirementAt returns the index of the requirement heading whose
+// section contains line, or -1.
+func (p *Pager) requirementAt(line int) int {
+	for i, h := range p.doc.Headings {
+		if h.Requirement && h.Line <= line && line < p.doc.sectionEnd(i) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// rebuild recomputes the visible rows from the folds.
+func (p *Pager) rebuild() {
+	p.rows = p.rows[:0]
+	for line := 0; line < len(p.doc.Lines); line++ {
+		req := p.requirementAt(line)
+		if req >= 0 && p.folded[req] && p.doc.Headings[req].Line == line {
+			p.rows = append(p.rows, row{line: line, folded: true})
+			line = p.doc.sectionEnd(req) - 1
+
+			continue
+		}
+		p.rows = append(p.rows, row{line: line})
+	}
+}
+
+// jumpTo unfolds the requirement hiding line, if any, and scrolls so that
+// line is the top line.
+func (p *Pager) jumpTo(line int) {
+	if req := p.requirementAt(line); req >= 0 && p.folded[req] &&
+		p.doc.Headings[req].Line != line {
+		delete(p.folded, req)
+		p.rebuild()
+	}
+	p.scrollTo(p.rowOf(line))
+}
+
+// rowOf returns the index of the row showing line, or of the folded
+// header hiding it.
+func (p *Pager) rowOf(line int) int {
+	index := 0
+	for i, r := range p.rows {
+		if r.line > line {
+			break
+		}
+		index = i
+	}
+
+	return index
+}
+
+// topLine returns the document line at the top of the screen.
+func (p *Pager) topLine() int {
+	if len(p.rows) == 0 {
+		return 0
+	}
+
+	return p.rows[p.offset].line
+}
+
+// scrollTo sets the top row, keeping the screen filled where possible.
+func (p *Pager) scrollTo(offset int) {
+	p.offset = max(0, min(offset, len(p.rows)-p.pageHeight()))
+}
+
+// pageHeight is the number of rows shown above the status line.
+func (p *Pager) pageHeight() int {
+	if p.embedded {
+		return max(1, p.height)
+	}
+
+	return max(1, p.height-1)
+}
+
+// View implements tea.Model.
+func (p *Pager) View() string {
+	if p.mode == modeOutline {
+		return p.viewOutline()
+	}
+
+	return strings.Join(p.screen(), "\n") + "\n" + p.clip(p.statusLine())
+}
+
+// screen renders the rows on screen, padding past the end of the text
+// with "~" lines.
+func (p *Pager) screen() []string {
+	lines := make([]string, 0, p.pageHeight())
+	end := min(len(p.rows), p.offset+p.pageHeight())
+	for _, r := range p.rows[p.offset:end] {
+		if len(p.marks) == 0 {
+			lines = append(lines, p.clip(p.renderRow(r)))
+
+			continue
+		}
+
+		gutter := "  "
+		if p.marks[r.line] {
+			gutter = markStyle.Render(gutterMark) + " "
+		}
+		text := lipgloss.NewStyle().MaxWidth(max(1, p.width-gutterWidth)).Render(p.renderRow(r))
+		lines = append(lines, gutter+text)
+	}
+	for len(lines) < p.pageHeight() {
+		lines = append(lines, foldStyle.Render("~"))
+	}
+
+	return lines
+}
+
+// renderRow renders one visible row.
+func (p *Pager) renderRow(r row) string {
+	if r.folded {
+		req := p.requirementAt(r.line)
+		hidden := p.doc.sectionEnd(req) - r.line - 1
+
+		return p.styled[r.line] + foldStyle.Render(fmt.Sprintf("  ⋯ %d lines", hidden))
+	}
+
+	if p.search != nil && p.search.MatchString(p.doc.Lines[r.line]) {
+		current := p.match >= 0 && p.matches[p.match] == r.line
+
+		return highlightMatches(p.doc.Lines[r.line], p.search, current)
+	}
+
+	return p.styled[r.line]
+}
+
+// statusLine renders the line below the text: the search being typed, or
+// the position in the document and the help.
+func (p *Pager) statusLine() string {
+	if p.mode == modeSearch {
+		return "/" + p.input
+	}
+
+	location := p.title
+	if h := p.doc.headingAt(p.topLine(), requirementLevel); h >= 0 &&
+		p.doc.Headings[h].Level > 1 {
+		location += " › " + p.doc.Headings[h].Text
+	}
+
+	help := readHelp
+	if p.message != "" {
+		help = p.message
+	}
+
+	return statusStyle.Render(fmt.Sprintf("%s | %s | %s", location, p.position(), help))
+}
+
+// position describes how far into the document the screen is, like less.
+func (p *Pager) position() string {
+	bottom := p.offset+p.pageHeight() >= len(p.rows)
+	switch {
+	case p.offset == 0 && bottom:
+		return "All"
+	case p.offset == 0:
+		return "Top"
+	case bottom:
+		return "Bot"
+	default:
+		return fmt.Sprintf("%d%%", 100*p.offset/max(1, len(p.rows)-p.pageHeight()))
+	}
+}
+
+// viewOutline renders the outline of headings with the filter.
+func (p *Pager) viewOutline() string {
+	var sb strings.Builder
+	sb.WriteString(tui.TitleStyle().Render("Outline of "+p.title) + "\n")
+	sb.WriteString("> " + p.input + "\n\n")
+
+	height := max(1, p.height-outlineChrome)
+	start := max(0, min(p.outlineCursor-height/2, len(p.outline)-height))
+	end := min(len(p.outline), start+height)
+	for i := start; i < end; i++ {
+		h := p.doc.Headings[p.outline[i]]
+		label := strings.Repeat("  ", max(0, h.Level-2)) + h.Text
+		if i == p.outlineCursor {
+			sb.WriteString(p.clip(tui.SelectedStyle().Render("> "+label)) + "\n")
+		} else {
+			sb.WriteString(p.clip(tui.ChoiceStyle().Render("  "+label)) + "\n")
+		}
+	}
+	if len(p.outline) == 0 {
+		sb.WriteString(tui.ChoiceStyle().Render("  (no matches)") + "\n")
+	}
+
+	sb.WriteString(statusStyle.Render(fmt.Sprintf(
+		"%d/%d | type to filter | ↑/↓: navigate | Enter: jump | Esc: back",
+		len(p.outline),
+		len(p.doc.Headings),
+	)))
+
+	return sb.String()
+}
+
+// clip cuts a rendered line to the terminal width.
+func (p *Pager) clip(s string) string {
+	return lipgloss.NewStyle().MaxWidth(p.width).Render(s)
+}
+
+// Run shows the pager in the alternate screen until the user quits.
+func (p *Pager) Run() error {
+	if _, err := tea.NewProgram(p, tea.WithAltScreen()).Run(); err != nil {
+		return fmt.Errorf("error running pager: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,229 @@
+package reader
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	// outlineMaxWidth caps the width of a Preview's outline column.
+	outlineMaxWidth = 36
+
+	// outlineSeparator is drawn between the outline and the text.
+	outlineSeparator = " │ "
+)
+
+// Preview shows a Document as an outline of its headings beside the
+// styled text, for preview panes such as the one in `spectr list -I`.
+// Moving through the outline scrolls the text to the selected heading,
+// and requirements are folded and unfolded from the outline.
+type Preview struct {
+	pager *Pager
+
+	// entries are the indexes of the headings listed in the outline:
+	// every heading below the title, except those inside folded
+	// requirements.
+	entries []int
+	// cursor is the selected position in entries.
+	cursor int
+
+	width  int
+	height int
+}
+
+// NewPreview creates a Preview of doc, with every requirement unfolded.
+func NewPreview(title string, doc *Document) *Preview {
+	pager := NewPager(PagerConfig{Title: title, Document: doc})
+	pager.embedded = true
+
+	v := &Preview{pager: pager}
+	v.refresh()
+	v.SetSize(defaultWidth, defaultHeight)
+
+	return v
+}
+
+// Title returns the title the preview was created with.
+func (v *Preview) Title() string {
+	return v.pager.title
+}
+
+// MarkRequirements flags the named requirements in a gutter beside the
+// text, as Pager.MarkRequirements does.
+func (v *Preview) MarkRequirements(names []string) {
+	v.pager.MarkRequirements(names)
+}
+
+// SetSize sets the size of the area the preview is drawn in.
+func (v *Preview) SetSize(width, height int) {
+	v.width, v.height = width, height
+	v.pager.width = max(1, width-v.outlineWidth()-lipgloss.Width(outlineSeparator))
+	v.pager.height = height
+	v.pager.scrollTo(v.pager.offset)
+}
+
+// Update handles a key: j/k move through the outline, Enter, space or z
+// fold the selected requirement (h and l fold and unfold it), Z folds or
+// unfolds all, and J/K scroll the text without moving the selection.
+func (v *Preview) Update(msg tea.KeyMsg) {
+	page := v.pager.pageHeight()
+
+	switch msg.String() {
+	case "j", "down":
+		v.selectEntry(v.cursor + 1)
+	case "k", "up":
+		v.selectEntry(v.cursor - 1)
+	case "g", "home":
+		v.selectEntry(0)
+	case "G", "end":
+		v.selectEntry(len(v.entries) - 1)
+	case "enter", " ", "z":
+		v.fold(func(folded bool) bool { return !folded })
+	case "h", "left":
+		v.fold(func(bool) bool { return true })
+	case "l", "right":
+		v.fold(func(bool) bool { return false })
+	case "Z":
+		v.pager.setAllFolded(len(v.pager.folded) == 0)
+		v.pager.rebuild()
+		v.refresh()
+		v.selectEntry(v.cursor)
+	case "J", "ctrl+d", "pgdown":
+		v.pager.scrollTo(v.pager.offset + page/2)
+	case "K", "ctrl+u", "pgup":
+		v.pager.scrollTo(v.pager.offset - page/2)
+	}
+}
+
+// selectEntry selects the outline entry at index and scrolls the text to
+// its heading.
+func (v *Preview) selectEntry(index int) {
+	if len(v.entries) == 0 {
+		return
+	}
+
+	v.cursor = max(0, min(index, len(v.entries)-1))
+	v.pager.jumpTo(v.pager.doc.Headings[v.entries[v.cursor]].Line)
+}
+
+// fold sets the fold of the selected requirement, or of the requirement
+// holding the selected scenario, to next(current fold).
+func (v *Preview) fold(next func(folded bool) bool) {
+	if len(v.entries) == 0 {
+		return
+	}
+
+	req := v.pager.requirementAt(v.pager.doc.Headings[v.entries[v.cursor]].Line)
+	if req < 0 {
+		return
+	}
+
+	if next(v.pager.folded[req]) {
+		v.pager.folded[req] = true
+	} else {
+		delete(v.pager.folded, req)
+	}
+	v.pager.rebuild()
+	v.refresh()
+	v.selectEntry(v.cursor)
+}
+
+// refresh recomputes the outline entries after the folds changed. The
+// selection stays on the same heading, or moves to the requirement that
+// now hides it.
+func (v *Preview) refresh() {
+	doc := v.pager.doc
+
+	selected := -1
+	if v.cursor < len(v.entries) {
+		selected = v.entries[v.cursor]
+	}
+	if selected >= 0 {
+		if req := v.pager.requirementAt(doc.Headings[selected].Line); req >= 0 &&
+			v.pager.folded[req] {
+			selected = req
+		}
+	}
+
+	v.entries = v.entries[:0]
+	v.cursor = 0
+	for i, h := range doc.Headings {
+		if h.Level < 2 {
+			continue
+		}
+		if req := v.pager.requirementAt(h.Line); req >= 0 && req != i &&
+			v.pager.folded[req] {
+			continue
+		}
+		if i == selected {
+			v.cursor = len(v.entries)
+		}
+		v.entries = append(v.entries, i)
+	}
+}
+
+// View renders the outline and the text side by side.
+func (v *Preview) View() string {
+	outline := v.outlineLines()
+	text := v.pager.screen()
+	separator := foldStyle.Render(outlineSeparator)
+
+	lines := make([]string, len(text))
+	for i := range text {
+		lines[i] = outline[i] + separator + text[i]
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// outlineLines renders the outline column, one line per text row,
+// scrolled to keep the selection in view.
+func (v *Preview) outlineLines() []string {
+	width := v.outlineWidth()
+	cell := lipgloss.NewStyle().Width(width).MaxWidth(width)
+	height := v.pager.pageHeight()
+
+	start := max(0, min(v.cursor-height/2, len(v.entries)-height))
+	lines := make([]string, 0, height)
+	for i := start; i < len(v.entries) && len(lines) < height; i++ {
+		label := v.outlineLabel(v.entries[i])
+		if i == v.cursor {
+			lines = append(lines, cell.Inherit(currentStyle).Render(label))
+		} else {
+			lines = append(lines, cell.Render(label))
+		}
+	}
+	for len(lines) < height {
+		lines = append(lines, cell.Render(""))
+	}
+
+	return lines
+}
+
+// outlineLabel renders the outline entry of heading i, indented by level,
+// with a fold marker for requirements.
+func (v *Preview) outlineLabel(i int) string {
+	h := v.pager.doc.Headings[i]
+	indent := strings.Repeat("  ", max(0, h.Level-2))
+
+	switch {
+	case h.Requirement:
+		marker := "▾ "
+		if v.pager.folded[i] {
+			marker = "▸ "
+		}
+
+		return indent + marker + strings.TrimPrefix(h.Text, "Requirement: ")
+	case h.Level == scenarioLevel && strings.HasPrefix(h.Text, "Scenario: "):
+		return indent + "· " + strings.TrimPrefix(h.Text, "Scenario: ")
+	default:
+		return indent + h.Text
+	}
+}
+
+// outlineWidth returns the width of the outline column.
+func (v *Preview) outlineWidth() int {
+	return max(1, min(outlineMaxWidth, v.width/3))
+}
@@ -0,0 +1,259 @@
+//nolint:revive // test file
+package reader
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const testSpec = `# Auth Specification
+
+## Purpose
+
+Sign in.
+
+## Requirements
+
+### Requirement: User Login
+
+The system SHALL authenticate users.
+
+` + "```text\n## Not a heading\n```" + `
+
+#### Scenario: Valid credentials
+
+- **WHEN** a user signs in
+- **THEN** a session starts
+
+### Requirement: Password Reset
+
+The system SHALL email a reset link.
+
+#### Scenario: Expired link
+
+- **WHEN** a link is older than one hour
+- **THEN** it is rejected
+`
+
+func testPager(t *testing.T) *Pager {
+	t.Helper()
+
+	p := NewPager(PagerConfig{Title: "auth", Document: NewDocument([]byte(testSpec))})
+	p.Update(tea.WindowSizeMsg{Width: 80, Height: 6})
+
+	return p
+}
+
+func sendKeys(p *Pager, keys ...string) tea.Cmd {
+	var cmd tea.Cmd
+	for _, key := range keys {
+		msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+		switch key {
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		case "esc":
+			msg = tea.KeyMsg{Type: tea.KeyEsc}
+		}
+		_, cmd = p.Update(msg)
+	}
+
+	return cmd
+}
+
+func TestNewDocument_Headings(t *testing.T) {
+	doc := NewDocument([]byte(testSpec))
+
+	var got []string
+	for _, h := range doc.Headings {
+		got = append(got, doc.Lines[h.Line])
+	}
+	want := []string{
+		"# Auth Specification",
+		"## Purpose",
+		"## Requirements",
+		"### Requirement: User Login",
+		"#### Scenario: Valid credentials",
+		"### Requirement: Password Reset",
+		"#### Scenario: Expired link",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("heading lines = %q, want %q", got, want)
+	}
+	if !doc.Headings[3].Requirement || doc.Headings[4].Requirement {
+		t.Error("only requirement headers should be marked Requirement")
+	}
+	if end := doc.sectionEnd(3); doc.Lines[end] != "### Requirement: Password Reset" {
+		t.Errorf("User Login section ends at %q", doc.Lines[end])
+	}
+}
+
+func TestPager_JumpHeadings(t *testing.T) {
+	p := testPager(t)
+
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{"]", "## Purpose"},
+		{"]", "## Requirements"},
+		{"]", "### Requirement: User Login"},
+		{"]", "### Requirement: Password Reset"},
+		{"[", "### Requirement: User Login"},
+	}
+	for _, tt := range tests {
+		sendKeys(p, tt.key)
+		if got := p.doc.Lines[p.topLine()]; got != tt.want {
+			t.Fatalf("after %q top line = %q, want %q", tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestPager_Fold(t *testing.T) {
+	p := testPager(t)
+	total := len(p.rows)
+
+	sendKeys(p, "]", "]", "]", "z")
+	if len(p.rows) != total-12 {
+		t.Errorf("folding User Login left %d of %d rows", len(p.rows), total)
+	}
+	if !strings.Contains(p.View(), "⋯ 12 lines") {
+		t.Errorf("folded requirement not marked:\n%s", p.View())
+	}
+
+	sendKeys(p, "z")
+	if len(p.rows) != total {
+		t.Errorf("unfolding left %d of %d rows", len(p.rows), total)
+	}
+
+	sendKeys(p, "Z")
+	if len(p.folded) != 2 {
+		t.Errorf("Z folded %d requirements, want 2", len(p.folded))
+	}
+	sendKeys(p, "Z")
+	if len(p.folded) != 0 {
+		t.Errorf("second Z left %d requirements folded", len(p.folded))
+	}
+}
+
+func TestPager_SearchUnfoldsMatch(t *testing.T) {
+	p := NewPager(PagerConfig{
+		Title:    "auth",
+		Document: NewDocument([]byte(testSpec)),
+		Folded:   true,
+	})
+	p.Update(tea.WindowSizeMsg{Width: 80, Height: 6})
+
+	sendKeys(p, "/", "hour", "enter")
+	if !strings.Contains(p.View(), "one hour") {
+		t.Fatalf("search match not on screen:\n%s", p.View())
+	}
+	if len(p.folded) != 1 {
+		t.Errorf("search should unfold only the matching requirement, %d folded", len(p.folded))
+	}
+
+	sendKeys(p, "g", "/", "shall", "enter")
+	if len(p.matches) != 2 || p.match != 0 {
+		t.Fatalf("matches = %v, selected %d", p.matches, p.match)
+	}
+	if len(p.folded) != 0 {
+		t.Errorf("selected match left %d requirements folded", len(p.folded))
+	}
+	sendKeys(p, "n", "n")
+	if p.match != 0 || p.message != "Search wrapped to top" {
+		t.Errorf("n did not wrap: match %d, message %q", p.match, p.message)
+	}
+	sendKeys(p, "N")
+	if p.match != 1 || p.message != "Search wrapped to bottom" {
+		t.Errorf("N did not wrap: match %d, message %q", p.match, p.message)
+	}
+
+	sendKeys(p, "/", "nowhere", "enter")
+	if p.message != "Pattern not found: nowhere" {
+		t.Errorf("message = %q", p.message)
+	}
+}
+
+func TestPager_Outline(t *testing.T) {
+	p := testPager(t)
+
+	sendKeys(p, "o", "expired", "enter")
+	if p.mode != modeRead {
+		t.Fatal("enter should close the outline")
+	}
+	if !strings.Contains(p.View(), "#### Scenario: Expired link") {
+		t.Errorf("outline did not jump to the scenario:\n%s", p.View())
+	}
+
+	sendKeys(p, "o", "zzz")
+	if !strings.Contains(p.View(), "(no matches)") {
+		t.Error("outline should say when nothing matches")
+	}
+	sendKeys(p, "esc", "esc")
+	if p.mode != modeRead {
+		t.Error("esc should clear the filter, then close the outline")
+	}
+}
+
+func TestPager_Quit(t *testing.T) {
+	p := testPager(t)
+
+	if cmd := sendKeys(p, "/", "q"); cmd != nil {
+		t.Error("q while searching should be typed, not quit")
+	}
+	if cmd := sendKeys(p, "esc", "q"); cmd == nil {
+		t.Error("q should quit")
+	}
+}
+
+func TestPreview_OutlineScrollsAndFolds(t *testing.T) {
+	v := NewPreview("auth", NewDocument([]byte(testSpec)))
+	v.SetSize(90, 6)
+
+	// Purpose, Requirements, User Login, Valid credentials, Password Reset
+	for range 4 {
+		v.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	}
+	if got := v.pager.doc.Lines[v.pager.topLine()]; got != "### Requirement: Password Reset" {
+		t.Errorf("selecting Password Reset scrolled to %q", got)
+	}
+
+	v.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("k")})
+	v.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("h")})
+	if !strings.Contains(v.View(), "▸ User Login") || strings.Contains(v.View(), "Valid credentials") {
+		t.Errorf("h on a scenario should fold its requirement:\n%s", v.View())
+	}
+	if got := strings.TrimSpace(v.outlineLabel(v.entries[v.cursor])); got != "▸ User Login" {
+		t.Errorf("selection moved to %q, want the folded requirement", got)
+	}
+
+	v.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+	if len(v.pager.folded) != 0 || len(v.entries) != 6 {
+		t.Errorf("l left %d folded, %d entries", len(v.pager.folded), len(v.entries))
+	}
+
+	v.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("Z")})
+	if len(v.entries) != 4 {
+		t.Errorf("Z left %d outline entries, want 4", len(v.entries))
+	}
+}
+
+func TestPager_MarkRequirements(t *testing.T) {
+	p := testPager(t)
+
+	p.MarkRequirements([]string{"password reset"})
+	if len(p.marks) != 1 {
+		t.Fatalf("marks = %v, want the Password Reset header", p.marks)
+	}
+	p.jumpTo(p.doc.Headings[5].Line)
+	if line := p.screen()[0]; !strings.Contains(line, gutterMark) ||
+		!strings.Contains(line, "Password Reset") {
+		t.Errorf("marked header rendered as %q", line)
+	}
+
+	p.MarkRequirements(nil)
+	if strings.Contains(strings.Join(p.screen(), "\n"), gutterMark) {
+		t.Error("gutter drawn without marks")
+	}
+}
@@ -0,0 +1,122 @@
+package reader
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/connerohnesorge/spectr/internal/tui"
+)
+
+var (
+	titleStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color(tui.ColorHeader))
+	sectionStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color(tui.ColorHeader))
+	requirementStyle = lipgloss.NewStyle().
+				Bold(true).
+				Foreground(lipgloss.Color("6"))
+	scenarioStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("3"))
+	keywordStyle = lipgloss.NewStyle().Bold(true)
+	codeStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("2"))
+	fenceStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color(tui.ColorHelp))
+	foldStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color(tui.ColorHelp))
+	matchStyle   = lipgloss.NewStyle().Reverse(true)
+	currentStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color(tui.ColorSelected)).
+			Background(lipgloss.Color(tui.ColorHighlight)).
+			Bold(true)
+	statusStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(tui.ColorHelp))
+	markStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("5")).Bold(true)
+)
+
+var (
+	// boldPattern matches **bold** spans, including the **WHEN** and
+	// **THEN** keywords of scenario bullets.
+	boldPattern = regexp.MustCompile(`\*\*([^*]+)\*\*`)
+	// codePattern matches `inline code`.
+	codePattern = regexp.MustCompile("`([^`]+)`")
+	// normativePattern matches the RFC 2119 keywords requirements rely on.
+	normativePattern = regexp.MustCompile(`\b(SHALL NOT|MUST NOT|SHALL|MUST)\b`)
+)
+
+// StyleLines returns the lines of doc rendered with terminal styling:
+// headers by kind, bold and code spans, normative keywords and fenced
+// code blocks.
+func StyleLines(doc *Document) []string {
+	headings := make(map[int]Heading, len(doc.Headings))
+	for _, h := range doc.Headings {
+		headings[h.Line] = h
+	}
+
+	styled := make([]string, len(doc.Lines))
+	inFence := false
+	for i, line := range doc.Lines {
+		trimmed := strings.TrimSpace(line)
+		switch h, ok := headings[i]; {
+		case strings.HasPrefix(trimmed, "```"):
+			inFence = !inFence
+			styled[i] = fenceStyle.Render(line)
+		case inFence:
+			styled[i] = fenceStyle.Render(line)
+		case ok:
+			styled[i] = headingStyle(h).Render(line)
+		default:
+			styled[i] = styleInline(line)
+		}
+	}
+
+	return styled
+}
+
+// headingStyle returns the style of a header line.
+func headingStyle(h Heading) lipgloss.Style {
+	switch {
+	case h.Requirement:
+		return requirementStyle
+	case h.Level == scenarioLevel && strings.HasPrefix(h.Text, "Scenario:"):
+		return scenarioStyle
+	case h.Level == 1:
+		return titleStyle
+	default:
+		return sectionStyle
+	}
+}
+
+// styleInline styles the spans of a body line. The markers around bold
+// and code spans are dropped, as a renderer would.
+func styleInline(line string) string {
+	line = codePattern.ReplaceAllStringFunc(line, func(s string) string {
+		return codeStyle.Render(strings.Trim(s, "`"))
+	})
+	line = boldPattern.ReplaceAllStringFunc(line, func(s string) string {
+		return keywordStyle.Render(strings.Trim(s, "*"))
+	})
+
+	return normativePattern.ReplaceAllStringFunc(line, func(s string) string {
+		return keywordStyle.Render(s)
+	})
+}
+
+// highlightMatches renders line unstyled with the matches of search
+// highlighted, more strongly when the line holds the selected match.
+func highlightMatches(line string, search *regexp.Regexp, current bool) string {
+	style := matchStyle
+	if current {
+		style = currentStyle
+	}
+
+	return search.ReplaceAllStringFunc(line, func(s string) string {
+		return style.Render(s)
+	})
+}
+
+// searchPattern returns a case-insensitive pattern matching query
+// literally.
+func searchPattern(query string) *regexp.Regexp {
+	return regexp.MustCompile("(?i)" + regexp.QuoteMeta(query))
+}
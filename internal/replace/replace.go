@@ -0,0 +1,370 @@
+// Package replace performs find-and-replace across specs, restricted to
+// chosen kinds of markdown text.
+//
+// Matches are looked for in the text nodes of the parsed document only.
+// Code blocks, inline code, links, wikilinks, link reference definitions,
+// HTML comments and bare URLs are never touched, so a term that also
+// appears as an identifier or in a URL survives the rename. Replacements
+// are made in the original source, leaving every other byte as written.
+package replace
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/discovery"
+	"github.com/connerohnesorge/spectr/internal/fileio"
+	"github.com/connerohnesorge/spectr/internal/markdown"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+	"github.com/connerohnesorge/spectr/internal/txn"
+)
+
+// filePerm is the permission of rewritten spec files.
+const filePerm = 0o644
+
+// Scope selects which text a replacement may touch.
+type Scope string
+
+// Scopes
+const (
+	// ScopeRequirementBody is the text between a requirement header and
+	// its first scenario.
+	ScopeRequirementBody Scope = "requirement-body"
+	// ScopeScenario is the text of scenario bullets.
+	ScopeScenario Scope = "scenario"
+	// ScopeHeading is section titles and requirement and scenario names.
+	ScopeHeading Scope = "heading"
+	// ScopeProse is all non-heading text, including text outside
+	// requirements.
+	ScopeProse Scope = "prose"
+)
+
+// Scopes lists the valid scopes.
+var Scopes = []Scope{ScopeRequirementBody, ScopeScenario, ScopeHeading, ScopeProse}
+
+// ParseScope returns the scope named s.
+func ParseScope(s string) (Scope, error) {
+	scope := Scope(s)
+	if slices.Contains(Scopes, scope) {
+		return scope, nil
+	}
+
+	valid := make([]string, len(Scopes))
+	for i, scope := range Scopes {
+		valid[i] = string(scope)
+	}
+
+	return "", &specterrs.UnknownReplaceScopeError{Scope: s, Valid: valid}
+}
+
+// Options describe a replacement.
+type Options struct {
+	Old    string
+	New    string
+	Scopes []Scope
+	// SpecIDs limits the replacement to these specs; empty means all.
+	SpecIDs []string
+}
+
+// Line is a changed line of a file.
+type Line struct {
+	Number int    `json:"line"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// FileChange is the result of replacing in one spec file.
+type FileChange struct {
+	SpecID       string `json:"specId"`
+	Path         string `json:"path"`
+	Replacements int    `json:"replacements"`
+	Lines        []Line `json:"lines"`
+
+	content []byte
+}
+
+// Plan computes the replacements in the specs of the project at
+// projectRoot without writing anything. Specs without a match are left
+// out.
+func Plan(projectRoot string, opts Options) ([]FileChange, error) {
+	if opts.Old == "" {
+		return nil, &specterrs.EmptySearchTermError{}
+	}
+
+	specIDs := opts.SpecIDs
+	if len(specIDs) == 0 {
+		var err error
+		specIDs, err = discovery.GetSpecIDs(projectRoot)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var changes []FileChange
+	for _, specID := range specIDs {
+		path := filepath.Join(projectRoot, "spectr", "specs", specID, "spec.md")
+		source, err := fileio.ReadFile(path)
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("spec '%s' not found", specID)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+
+		out, count := Source(source, opts.Old, opts.New, opts.Scopes)
+		if count == 0 {
+			continue
+		}
+		changes = append(changes, FileChange{
+			SpecID:       specID,
+			Path:         path,
+			Replacements: count,
+			Lines:        changedLines(source, out),
+			content:      out,
+		})
+	}
+
+	return changes, nil
+}
+
+// Apply writes planned changes as one transaction: either every file is
+// updated or none is.
+func Apply(ctx context.Context, changes []FileChange) error {
+	tx := txn.New()
+	for _, change := range changes {
+		tx.WriteFile(change.Path, change.content, filePerm)
+	}
+
+	return tx.Commit(ctx)
+}
+
+// Source replaces every occurrence of oldTerm with newTerm inside text of
+// the given scopes and returns the new source and the number of
+// replacements.
+func Source(
+	source []byte,
+	oldTerm, newTerm string,
+	scopes []Scope,
+) ([]byte, int) {
+	root, _ := markdown.Parse(source, markdown.WithHTMLComments())
+	if root == nil || oldTerm == "" {
+		return source, 0
+	}
+
+	guards := guardedRanges(source)
+	var matches []int
+	collect := func(start, end int) {
+		for i := start; i+len(oldTerm) <= end; {
+			idx := bytes.Index(source[i:end], []byte(oldTerm))
+			if idx < 0 {
+				return
+			}
+			pos := i + idx
+			if !overlaps(guards, pos, pos+len(oldTerm)) {
+				matches = append(matches, pos)
+			}
+			i = pos + len(oldTerm)
+		}
+	}
+
+	w := &walker{scopes: scopes, collect: collect, source: source}
+	for _, node := range root.Children() {
+		w.block(node)
+	}
+	if len(matches) == 0 {
+		return source, 0
+	}
+
+	slices.Sort(matches)
+	var out bytes.Buffer
+	prev := 0
+	for _, pos := range matches {
+		out.Write(source[prev:pos])
+		out.WriteString(newTerm)
+		prev = pos + len(oldTerm)
+	}
+	out.Write(source[prev:])
+
+	return out.Bytes(), len(matches)
+}
+
+// walker visits the top-level blocks of a document in order, tracking
+// whether they belong to a requirement body or a scenario.
+type walker struct {
+	scopes  []Scope
+	collect func(start, end int)
+	source  []byte
+
+	inRequirement bool
+	inScenario    bool
+}
+
+// block handles one top-level node.
+func (w *walker) block(node markdown.Node) {
+	switch n := node.(type) {
+	case *markdown.NodeRequirement:
+		w.inRequirement, w.inScenario = true, false
+		w.name(n, n.Name())
+
+		return
+	case *markdown.NodeScenario:
+		w.inScenario = w.inRequirement
+		w.name(n, n.Name())
+
+		return
+	case *markdown.NodeSection:
+		if n.Level() <= 3 {
+			w.inRequirement = false
+		}
+		w.inScenario = false
+		if w.enabled(ScopeHeading) {
+			w.text(n)
+		}
+
+		return
+	}
+
+	scope := ScopeProse
+	switch {
+	case w.inScenario:
+		scope = ScopeScenario
+	case w.inRequirement:
+		scope = ScopeRequirementBody
+	}
+	if w.enabled(scope) || w.enabled(ScopeProse) {
+		w.text(node)
+	}
+}
+
+// name collects matches in the name of a requirement or scenario header.
+func (w *walker) name(node markdown.Node, name string) {
+	if !w.enabled(ScopeHeading) || name == "" {
+		return
+	}
+
+	start, end := node.Span()
+	header := w.source[start:end]
+	colon := bytes.IndexByte(header, ':')
+	if colon < 0 {
+		return
+	}
+	idx := bytes.Index(header[colon+1:], []byte(name))
+	if idx < 0 {
+		return
+	}
+	nameStart := start + colon + 1 + idx
+	w.collect(nameStart, nameStart+len(name))
+}
+
+// text collects matches in the text nodes under node, skipping nodes whose
+// text is not prose.
+func (w *walker) text(node markdown.Node) {
+	switch node.(type) {
+	case *markdown.NodeCodeBlock, *markdown.NodeCode, *markdown.NodeLink,
+		*markdown.NodeLinkDef, *markdown.NodeWikilink, *markdown.NodeHTMLComment:
+		return
+	case *markdown.NodeText:
+		w.collect(node.Span())
+
+		return
+	}
+
+	for _, child := range node.Children() {
+		w.text(child)
+	}
+}
+
+// enabled reports whether scope was selected.
+func (w *walker) enabled(scope Scope) bool {
+	return slices.Contains(w.scopes, scope)
+}
+
+var (
+	// urlPattern matches bare URLs, which the parser keeps as text.
+	urlPattern = regexp.MustCompile(`[a-zA-Z][a-zA-Z0-9+.-]*://[^\s<>()\[\]]+`)
+	// linkDefPattern matches link reference definitions.
+	linkDefPattern = regexp.MustCompile(`(?m)^ {0,3}\[[^\]]+\]:.*$`)
+	// commentPattern matches HTML comments.
+	commentPattern = regexp.MustCompile(`(?s)<!--.*?-->`)
+	// inlineCodePattern matches single-line code spans.
+	inlineCodePattern = regexp.MustCompile("`[^`\\n]*`")
+)
+
+// guardedRanges returns byte ranges that are never rewritten, found
+// lexically as a backstop to the parser: fenced code blocks, bare URLs,
+// code spans, link reference definitions and HTML comments.
+func guardedRanges(source []byte) [][2]int {
+	var ranges [][2]int
+	patterns := []*regexp.Regexp{
+		urlPattern,
+		linkDefPattern,
+		commentPattern,
+		inlineCodePattern,
+	}
+	for _, pattern := range patterns {
+		for _, m := range pattern.FindAllIndex(source, -1) {
+			ranges = append(ranges, [2]int{m[0], m[1]})
+		}
+	}
+
+	var (
+		fence      rune
+		fenceStart int
+		offset     int
+	)
+	for line := range strings.Lines(string(source)) {
+		if isFence, delim := markdown.IsCodeFence(line); isFence {
+			switch fence {
+			case 0:
+				fence, fenceStart = delim, offset
+			case delim:
+				ranges = append(ranges, [2]int{fenceStart, offset + len(line)})
+				fence = 0
+			}
+		}
+		offset += len(line)
+	}
+	if fence != 0 {
+		// An unclosed fence runs to the end of the file
+		ranges = append(ranges, [2]int{fenceStart, len(source)})
+	}
+
+	return ranges
+}
+
+// overlaps reports whether [start, end) intersects any range.
+func overlaps(ranges [][2]int, start, end int) bool {
+	for _, r := range ranges {
+		if start < r[1] && r[0] < end {
+			return true
+		}
+	}
+
+	return false
+}
+
+// changedLines pairs up the lines that differ between before and after.
+// Replacements never add or remove newlines unless the terms contain
+// them, so lines are compared by position.
+func changedLines(before, after []byte) []Line {
+	a := strings.Split(string(before), "\n")
+	b := strings.Split(string(after), "\n")
+	if len(a) != len(b) {
+		return []Line{{Number: 1, Before: string(before), After: string(after)}}
+	}
+
+	var lines []Line
+	for i := range a {
+		if a[i] != b[i] {
+			lines = append(lines, Line{Number: i + 1, Before: a[i], After: b[i]})
+		}
+	}
+
+	return lines
+}
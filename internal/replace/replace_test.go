@@ -0,0 +1,164 @@
+package replace
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+const sampleSpec = "# Widget Spec\n\n## Requirements\n\n" +
+	"### Requirement: Widget Export\n" +
+	"The system SHALL export each widget as `widget.json`.\n" +
+	"See [widget docs](https://example.com/widget) and [[widget-store]].\n" +
+	"Details at https://example.com/widget/export.\n\n" +
+	"```go\nwidget := New()\n```\n\n" +
+	"<!-- widget TODO -->\n\n" +
+	"#### Scenario: Widget exported\n" +
+	"- **WHEN** a widget is exported\n" +
+	"- **THEN** the widget file exists\n\n" +
+	"[widget]: https://example.com/widget\n"
+
+func TestSource(t *testing.T) {
+	tests := []struct {
+		name   string
+		scopes []Scope
+		count  int
+		want   string
+	}{
+		{
+			name:   "requirement body",
+			scopes: []Scope{ScopeRequirementBody},
+			count:  1,
+			want: "# Widget Spec\n\n## Requirements\n\n" +
+				"### Requirement: Widget Export\n" +
+				"The system SHALL export each gadget as `widget.json`.\n" +
+				"See [widget docs](https://example.com/widget) and [[widget-store]].\n" +
+				"Details at https://example.com/widget/export.\n\n" +
+				"```go\nwidget := New()\n```\n\n" +
+				"<!-- widget TODO -->\n\n" +
+				"#### Scenario: Widget exported\n" +
+				"- **WHEN** a widget is exported\n" +
+				"- **THEN** the widget file exists\n\n" +
+				"[widget]: https://example.com/widget\n",
+		},
+		{
+			name:   "scenario",
+			scopes: []Scope{ScopeScenario},
+			count:  2,
+			want: "# Widget Spec\n\n## Requirements\n\n" +
+				"### Requirement: Widget Export\n" +
+				"The system SHALL export each widget as `widget.json`.\n" +
+				"See [widget docs](https://example.com/widget) and [[widget-store]].\n" +
+				"Details at https://example.com/widget/export.\n\n" +
+				"```go\nwidget := New()\n```\n\n" +
+				"<!-- widget TODO -->\n\n" +
+				"#### Scenario: Widget exported\n" +
+				"- **WHEN** a gadget is exported\n" +
+				"- **THEN** the gadget file exists\n\n" +
+				"[widget]: https://example.com/widget\n",
+		},
+		{
+			name:   "prose covers bodies and scenarios",
+			scopes: []Scope{ScopeProse},
+			count:  3,
+		},
+		{
+			name:   "heading",
+			scopes: []Scope{ScopeHeading},
+			count:  0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, count := Source([]byte(sampleSpec), "widget", "gadget", tt.scopes)
+			if count != tt.count {
+				t.Errorf("count = %d, want %d\n%s", count, tt.count, got)
+			}
+			if tt.want != "" && string(got) != tt.want {
+				t.Errorf("Source() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSource_Headings(t *testing.T) {
+	got, count := Source([]byte(sampleSpec), "Widget", "Gadget", []Scope{ScopeHeading})
+	if count != 3 {
+		t.Fatalf("count = %d, want 3 (title, requirement, scenario)\n%s", count, got)
+	}
+	for _, want := range []string{
+		"# Gadget Spec\n",
+		"### Requirement: Gadget Export\n",
+		"#### Scenario: Gadget exported\n",
+	} {
+		if !strings.Contains(string(got), want) {
+			t.Errorf("missing %q in\n%s", want, got)
+		}
+	}
+}
+
+func TestPlanAndApply(t *testing.T) {
+	root := t.TempDir()
+	for id, content := range map[string]string{
+		"widgets": sampleSpec,
+		"auth":    "# Auth\n\n## Requirements\n\n### Requirement: Login\nUsers SHALL log in.\n",
+	} {
+		path := filepath.Join(root, "spectr", "specs", id, "spec.md")
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	changes, err := Plan(root, Options{
+		Old:    "widget",
+		New:    "gadget",
+		Scopes: []Scope{ScopeScenario},
+	})
+	if err != nil {
+		t.Fatalf("Plan() error = %v", err)
+	}
+	if len(changes) != 1 || changes[0].SpecID != "widgets" || changes[0].Replacements != 2 {
+		t.Fatalf("changes = %+v", changes)
+	}
+	if lines := changes[0].Lines; len(lines) != 2 || lines[0].Number != 17 ||
+		lines[0].After != "- **WHEN** a gadget is exported" {
+		t.Errorf("lines = %+v", lines)
+	}
+
+	if err := Apply(context.Background(), changes); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	data, err := os.ReadFile(changes[0].Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "the gadget file exists") {
+		t.Errorf("file not rewritten:\n%s", data)
+	}
+
+	_, err = Plan(root, Options{Scopes: []Scope{ScopeProse}})
+	var empty *specterrs.EmptySearchTermError
+	if !errors.As(err, &empty) {
+		t.Errorf("Plan() with empty term error = %v", err)
+	}
+}
+
+func TestParseScope(t *testing.T) {
+	if scope, err := ParseScope("scenario"); err != nil || scope != ScopeScenario {
+		t.Errorf("ParseScope(scenario) = %q, %v", scope, err)
+	}
+
+	_, err := ParseScope("code")
+	var unknown *specterrs.UnknownReplaceScopeError
+	if !errors.As(err, &unknown) {
+		t.Errorf("ParseScope(code) error = %v", err)
+	}
+}
@@ -0,0 +1,85 @@
+package retire
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+)
+
+// ChangelogFile is the project changelog inside spectr/.
+const ChangelogFile = "CHANGELOG.md"
+
+// changelogEntry is the changelog bullet for a retired spec.
+func changelogEntry(specID, reason string) string {
+	entry := fmt.Sprintf("- Retired spec `%s`", specID)
+	if reason != "" {
+		entry += ": " + reason
+	}
+
+	return entry
+}
+
+// AppendChangelog adds entry under the "## YYYY-MM-DD" section for now.
+// When the newest section is already today's the entry is appended to it;
+// otherwise a new section is inserted above the older ones. An empty
+// changelog gets a "# Changelog" title.
+func AppendChangelog(changelog []byte, now time.Time, entry string) []byte {
+	heading := "## " + now.Format(time.DateOnly)
+
+	if len(bytes.TrimSpace(changelog)) == 0 {
+		return fmt.Appendf(nil, "# Changelog\n\n%s\n\n%s\n", heading, entry)
+	}
+
+	lines := bytes.SplitAfter(changelog, []byte("\n"))
+	first := -1
+	for i, line := range lines {
+		if bytes.HasPrefix(line, []byte("## ")) {
+			first = i
+
+			break
+		}
+	}
+
+	var out bytes.Buffer
+	switch {
+	case first >= 0 && string(bytes.TrimSpace(lines[first])) == heading:
+		// Today's section exists: append after its last non-blank line
+		end := len(lines)
+		for i := first + 1; i < len(lines); i++ {
+			if bytes.HasPrefix(lines[i], []byte("## ")) {
+				end = i
+
+				break
+			}
+		}
+		last := end - 1
+		for last > first && len(bytes.TrimSpace(lines[last])) == 0 {
+			last--
+		}
+		writeLines(&out, lines[:last+1])
+		out.WriteString(entry + "\n")
+		if end < len(lines) {
+			out.WriteString("\n")
+			writeLines(&out, lines[end:])
+		}
+	case first >= 0:
+		writeLines(&out, lines[:first])
+		fmt.Fprintf(&out, "%s\n\n%s\n\n", heading, entry)
+		writeLines(&out, lines[first:])
+	default:
+		out.Write(bytes.TrimRight(changelog, "\n"))
+		fmt.Fprintf(&out, "\n\n%s\n\n%s\n", heading, entry)
+	}
+
+	return out.Bytes()
+}
+
+// writeLines writes lines, ensuring the last one ends with a newline.
+func writeLines(out *bytes.Buffer, lines [][]byte) {
+	for _, line := range lines {
+		out.Write(line)
+	}
+	if out.Len() > 0 && !bytes.HasSuffix(out.Bytes(), []byte("\n")) {
+		out.WriteString("\n")
+	}
+}
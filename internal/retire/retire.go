@@ -0,0 +1,287 @@
+// Package retire moves specs that no longer describe the system out of
+// spectr/specs/ and into spectr/specs/archive/, keeping history intact.
+//
+// A spec is only retired when no active change modifies or links to it.
+// Wikilinks from other specs that resolve to the retired spec are
+// reported, and optionally rewritten to point at its archived copy. The
+// move, link rewrites and changelog entry are applied as one transaction;
+// the audit entry is recorded last.
+package retire
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/connerohnesorge/spectr/internal/audit"
+	"github.com/connerohnesorge/spectr/internal/discovery"
+	"github.com/connerohnesorge/spectr/internal/fileio"
+	"github.com/connerohnesorge/spectr/internal/markdown"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+	"github.com/connerohnesorge/spectr/internal/txn"
+)
+
+// filePerm is the permission of rewritten files and the changelog.
+const filePerm = 0o644
+
+// Options control a retirement.
+type Options struct {
+	// Reason is recorded in the changelog and audit log.
+	Reason string
+	// RewriteLinks points inbound wikilinks at the archived spec instead
+	// of only reporting them.
+	RewriteLinks bool
+}
+
+// Link is a wikilink from another spec to the retired one.
+type Link struct {
+	File   string `json:"file"` // relative to the project root
+	Line   int    `json:"line"`
+	Target string `json:"target"`
+}
+
+// Result describes a completed retirement.
+type Result struct {
+	SpecID      string `json:"specId"`
+	ArchivePath string `json:"archivePath"` // relative to the project root
+	Links       []Link `json:"links"`
+	Rewritten   bool   `json:"rewritten"`
+}
+
+// Retire moves specID to spectr/specs/archive/ in the project at
+// projectRoot. It fails with *specterrs.SpecInUseError when active changes
+// still reference the spec.
+func Retire(
+	ctx context.Context,
+	projectRoot, specID string,
+	opts Options,
+) (*Result, error) {
+	specID = strings.Trim(filepath.ToSlash(specID), "/")
+	specsDir := filepath.Join(projectRoot, "spectr", "specs")
+	specDir := filepath.Join(specsDir, filepath.FromSlash(specID))
+	if strings.HasPrefix(specID, discovery.SpecArchiveDir+"/") ||
+		!fileExists(filepath.Join(specDir, "spec.md")) {
+		return nil, fmt.Errorf("spec '%s' not found", specID)
+	}
+
+	archiveDir := filepath.Join(specsDir, discovery.SpecArchiveDir, filepath.FromSlash(specID))
+	if _, err := os.Stat(archiveDir); err == nil {
+		return nil, &specterrs.SpecAlreadyRetiredError{SpecID: specID, Path: archiveDir}
+	}
+
+	blockers, err := Blockers(projectRoot, specID)
+	if err != nil {
+		return nil, err
+	}
+	if len(blockers) > 0 {
+		return nil, &specterrs.SpecInUseError{SpecID: specID, Changes: blockers}
+	}
+
+	links, rewrites, err := InboundLinks(projectRoot, specID)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := txn.New()
+	tx.Move(specDir, archiveDir)
+	if opts.RewriteLinks {
+		for _, path := range sortedKeys(rewrites) {
+			tx.WriteFile(path, rewrites[path], filePerm)
+		}
+	}
+
+	changelogPath := filepath.Join(projectRoot, "spectr", ChangelogFile)
+	changelog, err := os.ReadFile(changelogPath)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read %s: %w", changelogPath, err)
+	}
+	tx.WriteFile(
+		changelogPath,
+		AppendChangelog(changelog, time.Now(), changelogEntry(specID, opts.Reason)),
+		filePerm,
+	)
+
+	archivePath := "spectr/specs/" + discovery.SpecArchiveDir + "/" + specID + "/"
+
+	details := map[string]string{"retired_to": archivePath}
+	if opts.Reason != "" {
+		details["reason"] = opts.Reason
+	}
+	audit.RecordLast(
+		tx,
+		filepath.Join(projectRoot, "spectr"),
+		audit.OpRetire,
+		[]string{"specs/" + specID},
+		details,
+	)
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("apply retirement: %w", err)
+	}
+
+	return &Result{
+		SpecID:      specID,
+		ArchivePath: archivePath,
+		Links:       links,
+		Rewritten:   opts.RewriteLinks && len(links) > 0,
+	}, nil
+}
+
+// Blockers returns the active changes that have a delta for specID or a
+// wikilink to it, sorted.
+func Blockers(projectRoot, specID string) ([]string, error) {
+	changes, err := discovery.GetActiveChanges(projectRoot)
+	if err != nil {
+		return nil, err
+	}
+	specPath := filepath.Join(projectRoot, "spectr", "specs", filepath.FromSlash(specID), "spec.md")
+
+	var blockers []string
+	for _, changeID := range changes {
+		changeDir := filepath.Join(projectRoot, "spectr", "changes", changeID)
+		if fileExists(filepath.Join(changeDir, "specs", filepath.FromSlash(specID), "spec.md")) {
+			blockers = append(blockers, changeID)
+
+			continue
+		}
+
+		linked := false
+		err := walkMarkdown(changeDir, func(path string, source []byte) error {
+			if !linked && len(linksTo(source, projectRoot, specPath)) > 0 {
+				linked = true
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		if linked {
+			blockers = append(blockers, changeID)
+		}
+	}
+	slices.Sort(blockers)
+
+	return blockers, nil
+}
+
+// InboundLinks finds wikilinks in other specs that resolve to specID. It
+// returns the links and, per file, the content with each link pointed at
+// the archived spec.
+func InboundLinks(
+	projectRoot, specID string,
+) ([]Link, map[string][]byte, error) {
+	specsDir := filepath.Join(projectRoot, "spectr", "specs")
+	specDir := filepath.Join(specsDir, filepath.FromSlash(specID))
+	specPath := filepath.Join(specDir, "spec.md")
+	archivedTarget := "specs/" + discovery.SpecArchiveDir + "/" + specID
+
+	var links []Link
+	rewrites := make(map[string][]byte)
+	err := walkMarkdown(specsDir, func(path string, source []byte) error {
+		if filepath.Dir(path) == specDir ||
+			strings.HasPrefix(path, filepath.Join(specsDir, discovery.SpecArchiveDir)+string(filepath.Separator)) {
+			return nil
+		}
+
+		found := linksTo(source, projectRoot, specPath)
+		if len(found) == 0 {
+			return nil
+		}
+
+		rel, err := filepath.Rel(projectRoot, path)
+		if err != nil {
+			rel = path
+		}
+		var out []byte
+		prev := 0
+		for _, link := range found {
+			links = append(links, Link{
+				File:   filepath.ToSlash(rel),
+				Line:   1 + strings.Count(string(source[:link.Start]), "\n"),
+				Target: link.Target,
+			})
+
+			raw := string(source[link.Start:link.End])
+			out = append(out, source[prev:link.Start]...)
+			out = append(out, strings.Replace(raw, link.Target, archivedTarget, 1)...)
+			prev = link.End
+		}
+		rewrites[path] = append(out, source[prev:]...)
+
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return links, rewrites, nil
+}
+
+// linksTo returns the wikilinks in source that resolve to specPath, in
+// source order.
+func linksTo(source []byte, projectRoot, specPath string) []*markdown.Wikilink {
+	var found []*markdown.Wikilink
+	for _, link := range markdown.ExtractWikilinks(source) {
+		path, exists := markdown.ResolveWikilink(link.Target, projectRoot)
+		if exists && path == specPath {
+			found = append(found, link)
+		}
+	}
+
+	return found
+}
+
+// walkMarkdown calls fn with the content of every markdown file under dir.
+// A missing dir has no files.
+func walkMarkdown(dir string, fn func(path string, source []byte) error) error {
+	err := fileio.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) && path == dir {
+				return filepath.SkipDir
+			}
+
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ".md" {
+			return nil
+		}
+
+		source, err := fileio.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+
+		return fn(path, source)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to scan %s: %w", dir, err)
+	}
+
+	return nil
+}
+
+// sortedKeys returns the keys of m in order, so rewrites apply
+// deterministically.
+func sortedKeys(m map[string][]byte) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	return keys
+}
+
+// fileExists reports whether path is an existing regular file.
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+
+	return err == nil && !info.IsDir()
+}
@@ -0,0 +1,201 @@
+package retire
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/connerohnesorge/spectr/internal/audit"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+	"github.com/connerohnesorge/spectr/internal/testutil"
+)
+
+// setupProject creates a project with a legacy spec linked from billing.
+func setupProject(t *testing.T) string {
+	t.Helper()
+
+	root := t.TempDir()
+	specs := filepath.Join(root, "spectr", "specs")
+	testutil.WriteFile(t, filepath.Join(specs, "legacy", "spec.md"), "# Legacy\n")
+	testutil.WriteFile(t, filepath.Join(specs, "billing", "spec.md"),
+		"# Billing\n\nReplaces [[legacy]].\nSee [[legacy#Requirement: Old|the old flow]] and [[auth]].\n")
+	testutil.WriteFile(t, filepath.Join(specs, "auth", "spec.md"), "# Auth\n")
+	testutil.WriteFile(t, filepath.Join(root, "spectr", "changes", "add-mfa", "proposal.md"),
+		"# Add MFA\n\nBuilds on [[auth]].\n")
+
+	return root
+}
+
+func TestRetire(t *testing.T) {
+	for _, rewrite := range []bool{false, true} {
+		t.Run(map[bool]string{false: "flag", true: "rewrite"}[rewrite], func(t *testing.T) {
+			root := setupProject(t)
+			specs := filepath.Join(root, "spectr", "specs")
+			billing := filepath.Join(specs, "billing", "spec.md")
+			before := testutil.ReadFile(t, billing)
+
+			res, err := Retire(context.Background(), root, "legacy", Options{
+				Reason:       "replaced by billing",
+				RewriteLinks: rewrite,
+			})
+			if err != nil {
+				t.Fatalf("Retire() error = %v", err)
+			}
+
+			if _, err := os.Stat(filepath.Join(specs, "archive", "legacy", "spec.md")); err != nil {
+				t.Errorf("archived spec missing: %v", err)
+			}
+			if _, err := os.Stat(filepath.Join(specs, "legacy")); !os.IsNotExist(err) {
+				t.Errorf("spec still in place: %v", err)
+			}
+
+			if len(res.Links) != 2 || res.Links[0].Line != 3 || res.Links[1].Line != 4 {
+				t.Errorf("links = %+v, want two in billing on lines 3 and 4", res.Links)
+			}
+
+			got := testutil.ReadFile(t, billing)
+			if !rewrite && got != before {
+				t.Errorf("billing changed without --rewrite-links:\n%s", got)
+			}
+			if rewrite {
+				want := "# Billing\n\nReplaces [[specs/archive/legacy]].\n" +
+					"See [[specs/archive/legacy#Requirement: Old|the old flow]] and [[auth]].\n"
+				if got != want {
+					t.Errorf("billing = %q, want %q", got, want)
+				}
+			}
+
+			changelog := testutil.ReadFile(t, filepath.Join(root, "spectr", ChangelogFile))
+			if !strings.Contains(changelog, "- Retired spec `legacy`: replaced by billing\n") {
+				t.Errorf("changelog = %q", changelog)
+			}
+
+			entries, err := audit.Read(filepath.Join(root, "spectr", audit.LogFileName))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(entries) != 1 || entries[0].Operation != audit.OpRetire ||
+				entries[0].Details["retired_to"] != "spectr/specs/archive/legacy/" {
+				t.Errorf("audit entries = %+v", entries)
+			}
+		})
+	}
+}
+
+func TestRetire_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		specID string
+		setup  func(t *testing.T, root string)
+		check  func(err error) bool
+	}{
+		{
+			name:   "delta in active change",
+			specID: "legacy",
+			setup: func(t *testing.T, root string) {
+				change := filepath.Join(root, "spectr", "changes", "fix-legacy")
+				testutil.WriteFile(t, filepath.Join(change, "proposal.md"), "# Fix legacy\n")
+				testutil.WriteFile(t, filepath.Join(change, "specs", "legacy", "spec.md"), "## MODIFIED Requirements\n")
+			},
+			check: func(err error) bool {
+				var inUse *specterrs.SpecInUseError
+
+				return errors.As(err, &inUse) && inUse.Changes[0] == "fix-legacy"
+			},
+		},
+		{
+			name:   "linked from active change",
+			specID: "auth",
+			check: func(err error) bool {
+				var inUse *specterrs.SpecInUseError
+
+				return errors.As(err, &inUse) && inUse.Changes[0] == "add-mfa"
+			},
+		},
+		{
+			name:   "already retired",
+			specID: "legacy",
+			setup: func(t *testing.T, root string) {
+				testutil.WriteFile(t, filepath.Join(root, "spectr", "specs", "archive", "legacy", "spec.md"), "# Old\n")
+			},
+			check: func(err error) bool {
+				var retired *specterrs.SpecAlreadyRetiredError
+
+				return errors.As(err, &retired)
+			},
+		},
+		{
+			name:   "unknown spec",
+			specID: "missing",
+			check:  func(err error) bool { return err != nil },
+		},
+		{
+			name:   "archived spec",
+			specID: "archive/legacy",
+			setup: func(t *testing.T, root string) {
+				testutil.WriteFile(t, filepath.Join(root, "spectr", "specs", "archive", "legacy", "spec.md"), "# Old\n")
+			},
+			check: func(err error) bool { return err != nil },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := setupProject(t)
+			if tt.setup != nil {
+				tt.setup(t, root)
+			}
+
+			_, err := Retire(context.Background(), root, tt.specID, Options{})
+			if !tt.check(err) {
+				t.Fatalf("Retire() error = %v", err)
+			}
+			if _, err := os.Stat(filepath.Join(root, "spectr", ChangelogFile)); !os.IsNotExist(err) {
+				t.Errorf("changelog written on failure")
+			}
+		})
+	}
+}
+
+func TestAppendChangelog(t *testing.T) {
+	now := time.Date(2025, 3, 4, 10, 0, 0, 0, time.UTC)
+	entry := "- Retired spec `legacy`"
+
+	tests := []struct {
+		name      string
+		changelog string
+		want      string
+	}{
+		{
+			name: "empty",
+			want: "# Changelog\n\n## 2025-03-04\n\n- Retired spec `legacy`\n",
+		},
+		{
+			name:      "today's section exists",
+			changelog: "# Changelog\n\n## 2025-03-04\n\n- Retired spec `old`\n\n## 2025-01-01\n\n- Earlier\n",
+			want: "# Changelog\n\n## 2025-03-04\n\n- Retired spec `old`\n- Retired spec `legacy`\n\n" +
+				"## 2025-01-01\n\n- Earlier\n",
+		},
+		{
+			name:      "older sections only",
+			changelog: "# Changelog\n\n## 2025-01-01\n\n- Earlier\n",
+			want:      "# Changelog\n\n## 2025-03-04\n\n- Retired spec `legacy`\n\n## 2025-01-01\n\n- Earlier\n",
+		},
+		{
+			name:      "title only",
+			changelog: "# Changelog",
+			want:      "# Changelog\n\n## 2025-03-04\n\n- Retired spec `legacy`\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := string(AppendChangelog([]byte(tt.changelog), now, entry))
+			if got != tt.want {
+				t.Errorf("AppendChangelog() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
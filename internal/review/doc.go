@@ -0,0 +1,9 @@
+// Package review records when specs were last reviewed and finds the ones
+// due for another review.
+//
+// A spec's last review is the last_reviewed date in its frontmatter,
+// written by spectr review mark. How long a spec may go between reviews
+// comes from the review section of spectr.yaml: a default interval plus
+// intervals per tag, matched against the tags in the spec's frontmatter.
+// A spec with an interval that was never reviewed is due at once.
+package review
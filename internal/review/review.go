@@ -0,0 +1,281 @@
+package review
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/connerohnesorge/spectr/internal/audit"
+	"github.com/connerohnesorge/spectr/internal/config"
+	"github.com/connerohnesorge/spectr/internal/discovery"
+	"github.com/connerohnesorge/spectr/internal/domain"
+	"github.com/connerohnesorge/spectr/internal/fileio"
+	"github.com/connerohnesorge/spectr/internal/list"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+	"github.com/connerohnesorge/spectr/internal/txn"
+)
+
+// filePerm is the permission of a stamped spec.md.
+const filePerm = 0o644
+
+// hoursPerDay converts review intervals to durations.
+const hoursPerDay = 24
+
+// Spec is the review state of a spec with a review interval.
+type Spec struct {
+	ID   string   `json:"id"`
+	Tags []string `json:"tags,omitempty"`
+	// LastReviewed is the last_reviewed date, empty if never reviewed
+	LastReviewed string `json:"lastReviewed,omitempty"`
+	IntervalDays int    `json:"intervalDays"`
+	// DueDate is when the next review is due, empty if never reviewed
+	DueDate string `json:"dueDate,omitempty"`
+	// OverdueDays is how long past DueDate the spec is, or 0
+	OverdueDays int  `json:"overdueDays"`
+	Due         bool `json:"due"`
+	// RootPath is the relative path to the spectr root (multi-root)
+	RootPath string `json:"rootPath,omitempty"`
+}
+
+// Result describes a recorded review.
+type Result struct {
+	SpecID       string `json:"specId"`
+	LastReviewed string `json:"lastReviewed"`
+	// Previous is the date the review replaced, empty if none
+	Previous string `json:"previous,omitempty"`
+}
+
+// ParseDate parses a YYYY-MM-DD review date.
+func ParseDate(value string) (time.Time, error) {
+	date, err := time.Parse(time.DateOnly, strings.TrimSpace(value))
+	if err != nil {
+		return time.Time{}, &specterrs.InvalidReviewDateError{Value: value}
+	}
+
+	return date, nil
+}
+
+// Mark records date as the last review of specID in the project at
+// projectRoot by setting last_reviewed in the spec's frontmatter, then
+// records the review in the audit log.
+func Mark(
+	ctx context.Context,
+	projectRoot, specID string,
+	date time.Time,
+) (*Result, error) {
+	specID = strings.Trim(filepath.ToSlash(specID), "/")
+	specPath := filepath.Join(projectRoot, "spectr", "specs", filepath.FromSlash(specID), "spec.md")
+	if strings.HasPrefix(specID, discovery.SpecArchiveDir+"/") {
+		return nil, fmt.Errorf("spec '%s' not found", specID)
+	}
+	content, err := fileio.ReadFile(specPath)
+	if err != nil {
+		return nil, fmt.Errorf("spec '%s' not found", specID)
+	}
+
+	meta, err := domain.ParseSpecFrontmatter(content)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s frontmatter: %w", specID, err)
+	}
+	res := &Result{
+		SpecID:       specID,
+		LastReviewed: date.Format(time.DateOnly),
+		Previous:     meta.LastReviewed,
+	}
+
+	tx := txn.New()
+	tx.WriteFile(specPath, SetLastReviewed(content, res.LastReviewed), filePerm)
+
+	details := map[string]string{"last_reviewed": res.LastReviewed}
+	if res.Previous != "" {
+		details["previous"] = res.Previous
+	}
+	audit.RecordLast(
+		tx,
+		filepath.Join(projectRoot, "spectr"),
+		audit.OpReview,
+		[]string{"specs/" + specID},
+		details,
+	)
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("record review: %w", err)
+	}
+
+	return res, nil
+}
+
+// SetLastReviewed returns spec.md content with last_reviewed set to date,
+// replacing an existing value, adding the key to existing frontmatter, or
+// adding frontmatter when the spec has none.
+func SetLastReviewed(content []byte, date string) []byte {
+	text := string(content)
+	newline := "\n"
+	if strings.Contains(text, "\r\n") {
+		newline = "\r\n"
+	}
+	entry := "last_reviewed: " + date
+
+	lines := strings.Split(text, newline)
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != "---" {
+		return []byte("---" + newline + entry + newline + "---" + newline + newline + text)
+	}
+
+	for i := 1; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		switch {
+		case strings.HasPrefix(line, "last_reviewed:"):
+			lines[i] = entry
+
+			return []byte(strings.Join(lines, newline))
+		case line == "---":
+			lines = append(lines[:i], append([]string{entry}, lines[i:]...)...)
+
+			return []byte(strings.Join(lines, newline))
+		}
+	}
+
+	// Unclosed frontmatter is left for validation to report
+	return content
+}
+
+// Status returns the review state of the spec at specPath on now, or
+// false when its interval under cfg is 0. A last_reviewed that is not a
+// date counts as never reviewed.
+func Status(
+	cfg *config.Config,
+	specID, specPath string,
+	now time.Time,
+) (Spec, bool, error) {
+	meta, err := domain.ParseSpecFrontmatterFromFile(specPath)
+	if err != nil {
+		return Spec{}, false, fmt.Errorf("parse %s frontmatter: %w", specID, err)
+	}
+	interval := cfg.ReviewInterval(meta.Tags)
+	if interval <= 0 {
+		return Spec{}, false, nil
+	}
+
+	spec := Spec{
+		ID:           specID,
+		Tags:         meta.Tags,
+		LastReviewed: meta.LastReviewed,
+		IntervalDays: interval,
+		Due:          true,
+	}
+	reviewed, err := ParseDate(meta.LastReviewed)
+	if err != nil {
+		return spec, true, nil
+	}
+
+	due := reviewed.AddDate(0, 0, interval)
+	spec.DueDate = due.Format(time.DateOnly)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	spec.Due = !today.Before(due)
+	if spec.Due {
+		spec.OverdueDays = int(today.Sub(due).Hours() / hoursPerDay)
+	}
+
+	return spec, true, nil
+}
+
+// Find returns the specs of the project at projectRoot that are due for
+// review on now: never-reviewed specs first, then the most overdue.
+func Find(
+	ctx context.Context,
+	projectRoot string,
+	now time.Time,
+) ([]Spec, error) {
+	specs, err := statuses(ctx, projectRoot, now)
+	if err != nil {
+		return nil, err
+	}
+
+	var due []Spec
+	for _, spec := range specs {
+		if spec.Due {
+			due = append(due, spec)
+		}
+	}
+	sort.SliceStable(due, func(i, j int) bool {
+		if (due[i].DueDate == "") != (due[j].DueDate == "") {
+			return due[i].DueDate == ""
+		}
+		if due[i].OverdueDays != due[j].OverdueDays {
+			return due[i].OverdueDays > due[j].OverdueDays
+		}
+
+		return due[i].ID < due[j].ID
+	})
+
+	return due, nil
+}
+
+// FlagDue sets ReviewDue on the listed specs that are due for review on
+// now. Specs whose frontmatter or config cannot be read are left alone;
+// validation reports those.
+func FlagDue(ctx context.Context, specs []list.SpecInfo, now time.Time) {
+	due := make(map[string]bool)
+	roots := make(map[string]bool)
+	for _, spec := range specs {
+		if roots[spec.RootAbsPath] {
+			continue
+		}
+		roots[spec.RootAbsPath] = true
+
+		statuses, err := statuses(ctx, spec.RootAbsPath, now)
+		if err != nil {
+			continue
+		}
+		for _, status := range statuses {
+			if status.Due {
+				due[spec.RootAbsPath+"\x00"+status.ID] = true
+			}
+		}
+	}
+	for i := range specs {
+		specs[i].ReviewDue = due[specs[i].RootAbsPath+"\x00"+specs[i].ID]
+	}
+}
+
+// statuses returns the review state of every spec with an interval in the
+// project at projectRoot, sorted by ID.
+func statuses(
+	ctx context.Context,
+	projectRoot string,
+	now time.Time,
+) ([]Spec, error) {
+	cfg, err := config.LoadConfig(projectRoot)
+	if err != nil {
+		return nil, err
+	}
+	if cfg == nil || cfg.Review == nil {
+		return nil, nil
+	}
+
+	ids, err := discovery.GetSpecs(projectRoot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover specs: %w", err)
+	}
+	sort.Strings(ids)
+
+	var specs []Spec
+	for _, id := range ids {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		specPath := filepath.Join(projectRoot, "spectr", "specs", filepath.FromSlash(id), "spec.md")
+		spec, ok, err := Status(cfg, id, specPath, now)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			specs = append(specs, spec)
+		}
+	}
+
+	return specs, nil
+}
@@ -0,0 +1,179 @@
+package review
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/connerohnesorge/spectr/internal/audit"
+	"github.com/connerohnesorge/spectr/internal/list"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+	"github.com/connerohnesorge/spectr/internal/testutil"
+)
+
+const specBody = "# Auth\n\n## Purpose\nAuth.\n\n## Requirements\n"
+
+// setupProject creates a project whose spectr.yaml reviews every spec
+// yearly and compliance specs quarterly.
+func setupProject(t *testing.T, specs map[string]string) string {
+	t.Helper()
+
+	root := t.TempDir()
+	testutil.WriteFile(t, filepath.Join(root, "spectr.yaml"),
+		"review:\n  interval_days: 365\n  tags:\n    compliance: 90\n")
+	for id, content := range specs {
+		testutil.WriteFile(t, filepath.Join(root, "spectr", "specs", id, "spec.md"), content)
+	}
+
+	return root
+}
+
+func TestSetLastReviewed(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "no frontmatter",
+			content: specBody,
+			want:    "---\nlast_reviewed: 2026-10-16\n---\n\n" + specBody,
+		},
+		{
+			name:    "frontmatter without the key",
+			content: "---\ntags: [compliance]\n---\n\n" + specBody,
+			want:    "---\ntags: [compliance]\nlast_reviewed: 2026-10-16\n---\n\n" + specBody,
+		},
+		{
+			name:    "replaces previous date",
+			content: "---\nlast_reviewed: 2025-01-01\ntags: [a]\n---\n" + specBody,
+			want:    "---\nlast_reviewed: 2026-10-16\ntags: [a]\n---\n" + specBody,
+		},
+		{
+			name:    "keeps CRLF line endings",
+			content: "---\r\ntags: [a]\r\n---\r\n# Auth\r\n",
+			want:    "---\r\ntags: [a]\r\nlast_reviewed: 2026-10-16\r\n---\r\n# Auth\r\n",
+		},
+		{
+			name:    "unclosed frontmatter left alone",
+			content: "---\ntags: [a]\n",
+			want:    "---\ntags: [a]\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := string(SetLastReviewed([]byte(tt.content), "2026-10-16"))
+			if got != tt.want {
+				t.Errorf("SetLastReviewed() =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFind(t *testing.T) {
+	now := time.Date(2026, 10, 16, 15, 0, 0, 0, time.UTC)
+	root := setupProject(t, map[string]string{
+		"auth":     "---\nlast_reviewed: 2026-01-01\ntags: [compliance]\n---\n" + specBody,
+		"billing":  "---\nlast_reviewed: 2026-09-01\ntags: [compliance, ui]\n---\n" + specBody,
+		"search":   specBody,
+		"ui":       "---\nlast_reviewed: 2026-03-01\n---\n" + specBody,
+		"payments": "---\nlast_reviewed: 2026-07-18\ntags: [compliance]\n---\n" + specBody,
+	})
+
+	due, err := Find(context.Background(), root, now)
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+
+	var ids []string
+	for _, spec := range due {
+		ids = append(ids, spec.ID)
+	}
+	// search was never reviewed; auth is 198 days overdue; payments is
+	// due today; billing and ui are within their intervals
+	if want := []string{"search", "auth", "payments"}; !slices.Equal(ids, want) {
+		t.Fatalf("Find() = %v, want %v", ids, want)
+	}
+	if due[1].DueDate != "2026-04-01" || due[1].OverdueDays != 198 || due[1].IntervalDays != 90 {
+		t.Errorf("auth = %+v", due[1])
+	}
+	if due[0].DueDate != "" || due[0].IntervalDays != 365 {
+		t.Errorf("search = %+v", due[0])
+	}
+}
+
+func TestFind_NoReviewConfig(t *testing.T) {
+	root := t.TempDir()
+	testutil.WriteFile(t, filepath.Join(root, "spectr", "specs", "auth", "spec.md"), specBody)
+
+	due, err := Find(context.Background(), root, time.Now())
+	if err != nil || len(due) != 0 {
+		t.Errorf("Find() = %v, %v; want nothing due", due, err)
+	}
+}
+
+func TestFlagDue(t *testing.T) {
+	root := setupProject(t, map[string]string{
+		"auth":    specBody,
+		"billing": "---\nlast_reviewed: 2026-09-01\n---\n" + specBody,
+	})
+	specs := []list.SpecInfo{
+		{ID: "auth", RootAbsPath: root},
+		{ID: "billing", RootAbsPath: root},
+	}
+
+	FlagDue(context.Background(), specs, time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC))
+
+	if !specs[0].ReviewDue || specs[1].ReviewDue {
+		t.Errorf("FlagDue() = %+v", specs)
+	}
+}
+
+func TestMark(t *testing.T) {
+	root := setupProject(t, map[string]string{
+		"auth": "---\nlast_reviewed: 2026-01-01\n---\n" + specBody,
+	})
+	date := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+
+	res, err := Mark(context.Background(), root, "auth", date)
+	if err != nil {
+		t.Fatalf("Mark() error = %v", err)
+	}
+	if res.LastReviewed != "2026-10-16" || res.Previous != "2026-01-01" {
+		t.Errorf("Mark() = %+v", res)
+	}
+
+	due, err := Find(context.Background(), root, date)
+	if err != nil || len(due) != 0 {
+		t.Errorf("Find() after Mark = %v, %v; want nothing due", due, err)
+	}
+
+	entries, err := audit.Read(filepath.Join(root, "spectr", audit.LogFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Operation != audit.OpReview ||
+		entries[0].Details["last_reviewed"] != "2026-10-16" {
+		t.Errorf("audit entries = %+v, want one review", entries)
+	}
+
+	if _, err := Mark(context.Background(), root, "missing", date); err == nil {
+		t.Error("Mark() of a missing spec succeeded")
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	if _, err := ParseDate("2026-10-16"); err != nil {
+		t.Errorf("ParseDate() error = %v", err)
+	}
+
+	_, err := ParseDate("16/10/2026")
+	var target *specterrs.InvalidReviewDateError
+	if !errors.As(err, &target) {
+		t.Errorf("ParseDate() error = %v, want InvalidReviewDateError", err)
+	}
+}
@@ -0,0 +1,158 @@
+// Package scaffold generates test skeletons from the scenarios of a spec.
+//
+// Tests writes one test function per requirement, marked with a
+// `spectr:req` test marker so `spectr validate --tests` counts the
+// requirement as tested, and one table-driven subtest per scenario, named
+// by the scenario's stable ID (such as AUTH-R1-S2). Subtests skip with a
+// TODO until they are filled in.
+package scaffold
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/connerohnesorge/spectr/internal/implindex"
+	"github.com/connerohnesorge/spectr/internal/inherit"
+	"github.com/connerohnesorge/spectr/internal/parsers"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+// LangGo generates Go tests.
+const LangGo = "go"
+
+// Languages lists the languages Tests generates.
+var Languages = []string{LangGo}
+
+// Options configures Tests.
+type Options struct {
+	// Lang is the language of the tests, one of Languages
+	Lang string
+	// Package is the package clause of Go tests; empty derives it from
+	// the last segment of the spec ID
+	Package string
+}
+
+// Tests generates a test file for the requirements of specID in
+// projectRoot, including those it inherits.
+func Tests(projectRoot, specID string, opts Options) ([]byte, error) {
+	if opts.Lang != LangGo {
+		return nil, &specterrs.UnsupportedScaffoldLanguageError{
+			Lang:      opts.Lang,
+			Supported: Languages,
+		}
+	}
+
+	specPath := filepath.Join(projectRoot, "spectr", "specs", filepath.FromSlash(specID), "spec.md")
+	if _, err := os.Stat(specPath); err != nil {
+		return nil, fmt.Errorf("spec '%s' not found", specID)
+	}
+	reqs, err := inherit.ResolveFile(specPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse spec: %w", err)
+	}
+
+	pkg := opts.Package
+	if pkg == "" {
+		pkg = PackageName(specID[strings.LastIndex(specID, "/")+1:])
+	}
+
+	return goTests(specID, pkg, reqs)
+}
+
+// goTests renders the Go test file for reqs.
+func goTests(specID, pkg string, reqs []inherit.Requirement) ([]byte, error) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Tests scaffolded by spectr scaffold tests from spec %s.\n", specID)
+	b.WriteString("// Replace each TODO with the scenario's test.\n\n")
+	fmt.Fprintf(&b, "package %s\n\nimport \"testing\"\n", pkg)
+
+	used := make(map[string]bool)
+	for _, req := range reqs {
+		name := uniqueName(testName(req.Name), used)
+		fmt.Fprintf(&b, "\n// %s %s#%s\n", implindex.TestMarkerKeyword, req.Spec, req.Name)
+		fmt.Fprintf(&b, "func %s(t *testing.T) {\n", name)
+		b.WriteString("\ttests := []struct {\n\t\tid       string\n\t\tscenario string\n\t}{\n")
+
+		scenarios := parsers.ParseScenarioBlocks(req.Raw)
+		if len(scenarios) == 0 {
+			b.WriteString("\t\t// TODO: the requirement has no scenarios\n")
+		}
+		for i, scenario := range scenarios {
+			for _, step := range scenario.Steps {
+				fmt.Fprintf(&b, "\t\t// %s %s\n", step.Keyword, step.Text)
+			}
+			fmt.Fprintf(&b, "\t\t{id: %s, scenario: %s},\n",
+				strconv.Quote(parsers.ScenarioID(req.Spec, req.Index, i+1)),
+				strconv.Quote(scenario.Name))
+		}
+
+		b.WriteString("\t}\n\n\tfor _, tt := range tests {\n")
+		b.WriteString("\t\tt.Run(tt.id, func(t *testing.T) {\n")
+		b.WriteString("\t\t\tt.Skip(\"TODO: \" + tt.scenario)\n")
+		b.WriteString("\t\t})\n\t}\n}\n")
+	}
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated tests: %w", err)
+	}
+
+	return src, nil
+}
+
+// testName returns the Go test function name for a requirement: its words
+// in CamelCase after "Test".
+func testName(requirement string) string {
+	var b strings.Builder
+	b.WriteString("Test")
+	upper := true
+	for _, r := range requirement {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+// uniqueName returns name, or name with the first free numeric suffix
+// when used has it, and marks the result used.
+func uniqueName(name string, used map[string]bool) string {
+	unique := name
+	for i := 2; used[unique]; i++ {
+		unique = name + strconv.Itoa(i)
+	}
+	used[unique] = true
+
+	return unique
+}
+
+// PackageName derives a Go package name from a spec ID segment or a
+// directory name, keeping its lowercase letters and digits
+// ("cli-interface" -> "cliinterface").
+func PackageName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || (unicode.IsDigit(r) && b.Len() > 0) {
+			b.WriteRune(r)
+		}
+	}
+	if b.Len() == 0 {
+		return "spec"
+	}
+
+	return b.String()
+}
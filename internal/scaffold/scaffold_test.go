@@ -0,0 +1,139 @@
+package scaffold
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/connerohnesorge/spectr/internal/implindex"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+const authSpec = `# Auth
+
+## Requirements
+
+### Requirement: User Login
+The system SHALL log users in.
+
+#### Scenario: Valid credentials
+- **WHEN** the password is correct
+- **THEN** a session starts
+
+#### Scenario: Locked account
+- **WHEN** the account is locked
+- **THEN** login fails
+
+### Requirement: User-Login
+The system SHALL log users in again.
+
+#### Scenario: Retry
+- **WHEN** the user retries
+
+### Requirement: Audit
+The system SHALL keep an audit log.
+`
+
+// writeSpec writes a spec into a new project and returns its root.
+func writeSpec(t *testing.T, specID, content string) string {
+	t.Helper()
+	root := t.TempDir()
+	dir := filepath.Join(root, "spectr", "specs", filepath.FromSlash(specID))
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "spec.md"), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	return root
+}
+
+func TestTests_Go(t *testing.T) {
+	root := writeSpec(t, "auth", authSpec)
+
+	src, err := Tests(root, "auth", Options{Lang: LangGo})
+	if err != nil {
+		t.Fatalf("Tests() error = %v", err)
+	}
+	got := string(src)
+
+	for _, want := range []string{
+		"package auth\n",
+		"// spectr:req auth#User Login\nfunc TestUserLogin(t *testing.T) {",
+		"// spectr:req auth#User-Login\nfunc TestUserLogin2(t *testing.T) {",
+		"// spectr:req auth#Audit\nfunc TestAudit(t *testing.T) {",
+		"// WHEN the password is correct\n\t\t// THEN a session starts\n" +
+			"\t\t{id: \"AUTH-R1-S1\", scenario: \"Valid credentials\"},",
+		"{id: \"AUTH-R1-S2\", scenario: \"Locked account\"},",
+		"{id: \"AUTH-R2-S1\", scenario: \"Retry\"},",
+		"// TODO: the requirement has no scenarios",
+		"t.Run(tt.id, func(t *testing.T) {\n\t\t\tt.Skip(\"TODO: \" + tt.scenario)",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Tests() missing %q in:\n%s", want, got)
+		}
+	}
+
+	// The markers make every requirement count as tested
+	testsDir := filepath.Join(root, "auth")
+	if err := os.MkdirAll(testsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(testsDir, "auth_test.go"), src, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	idx, err := implindex.Scan(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, req := range []string{"User Login", "User-Login", "Audit"} {
+		if markers := idx.LookupTests("auth", req); len(markers) != 1 {
+			t.Errorf("LookupTests(%q) = %+v, want one marker", req, markers)
+		}
+	}
+}
+
+func TestTests_Options(t *testing.T) {
+	root := writeSpec(t, "billing/cli-export", authSpec)
+
+	tests := []struct {
+		name    string
+		opts    Options
+		wantPkg string
+		wantErr bool
+	}{
+		{name: "package from spec ID", opts: Options{Lang: LangGo}, wantPkg: "package cliexport\n"},
+		{name: "explicit package", opts: Options{Lang: LangGo, Package: "billing_test"}, wantPkg: "package billing_test\n"},
+		{name: "unsupported language", opts: Options{Lang: "rust"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src, err := Tests(root, "billing/cli-export", tt.opts)
+			if tt.wantErr {
+				var langErr *specterrs.UnsupportedScaffoldLanguageError
+				if !errors.As(err, &langErr) {
+					t.Fatalf("Tests() error = %v, want UnsupportedScaffoldLanguageError", err)
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("Tests() error = %v", err)
+			}
+			if !strings.Contains(string(src), tt.wantPkg) {
+				t.Errorf("Tests() = %s, want %q", src, tt.wantPkg)
+			}
+			if !strings.Contains(string(src), `"BILLING-CLI-EXPORT-R1-S1"`) {
+				t.Errorf("Tests() = %s, want nested spec scenario IDs", src)
+			}
+		})
+	}
+
+	if _, err := Tests(root, "missing", Options{Lang: LangGo}); err == nil {
+		t.Error("Tests() for a missing spec: want an error")
+	}
+}
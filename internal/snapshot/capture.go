@@ -0,0 +1,118 @@
+package snapshot
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/connerohnesorge/spectr/internal/parsers"
+)
+
+// Capture records the current version of every requirement that the
+// change's delta specs modify, remove or rename, reading base requirements
+// from specsDir. Added requirements have no base version and are skipped,
+// as are delta specs whose base spec does not exist yet. The index is
+// rewritten; existing objects are kept.
+func Capture(specsDir, changeDir string) (*Index, error) {
+	store := Open(changeDir)
+	index := &Index{
+		Version: indexVersion,
+		Created: time.Now().UTC().Format(time.RFC3339),
+	}
+
+	deltaDir := filepath.Join(changeDir, "specs")
+	entries, err := os.ReadDir(deltaDir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read %s: %w", deltaDir, err)
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		captured, err := captureSpec(
+			store,
+			entry.Name(),
+			filepath.Join(deltaDir, entry.Name(), "spec.md"),
+			filepath.Join(specsDir, entry.Name(), "spec.md"),
+		)
+		if err != nil {
+			return nil, err
+		}
+		index.Requirements = append(index.Requirements, captured...)
+	}
+
+	sort.Slice(index.Requirements, func(i, j int) bool {
+		a, b := index.Requirements[i], index.Requirements[j]
+		if a.Spec != b.Spec {
+			return a.Spec < b.Spec
+		}
+
+		return a.Requirement < b.Requirement
+	})
+
+	if err := store.WriteIndex(index); err != nil {
+		return nil, err
+	}
+
+	return index, nil
+}
+
+// captureSpec stores the base requirements affected by one delta spec.
+func captureSpec(
+	store *Store,
+	specID, deltaPath, basePath string,
+) ([]Entry, error) {
+	if _, err := os.Stat(deltaPath); err != nil {
+		return nil, nil
+	}
+	if _, err := os.Stat(basePath); err != nil {
+		return nil, nil
+	}
+
+	plan, err := parsers.ParseDeltaSpec(deltaPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", deltaPath, err)
+	}
+
+	affected := make(map[string]bool)
+	for _, req := range plan.Modified {
+		affected[parsers.NormalizeRequirementName(req.Name)] = true
+	}
+	for _, name := range plan.Removed {
+		affected[parsers.NormalizeRequirementName(name)] = true
+	}
+	for _, op := range plan.Renamed {
+		affected[parsers.NormalizeRequirementName(op.From)] = true
+	}
+	if len(affected) == 0 {
+		return nil, nil
+	}
+
+	baseReqs, err := parsers.ParseRequirements(basePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", basePath, err)
+	}
+
+	var captured []Entry
+	for _, req := range baseReqs {
+		if !affected[parsers.NormalizeRequirementName(req.Name)] {
+			continue
+		}
+
+		hash, err := store.Put(req.Raw)
+		if err != nil {
+			return nil, err
+		}
+		captured = append(captured, Entry{
+			Spec:        specID,
+			Requirement: req.Name,
+			Hash:        hash,
+		})
+	}
+
+	return captured, nil
+}
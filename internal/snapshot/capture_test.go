@@ -0,0 +1,150 @@
+package snapshot
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/connerohnesorge/spectr/internal/testutil"
+)
+
+const baseSpec = `# Auth Specification
+
+## Requirements
+
+### Requirement: Login
+The system SHALL log users in.
+
+#### Scenario: Valid login
+- **WHEN** credentials are valid
+- **THEN** a session is created
+
+### Requirement: Logout
+The system SHALL log users out.
+
+#### Scenario: Logout
+- **WHEN** the user logs out
+- **THEN** the session ends
+
+### Requirement: Remember Me
+The system SHALL remember users.
+
+#### Scenario: Remembered
+- **WHEN** the box is checked
+- **THEN** the session persists
+`
+
+const deltaSpec = `## ADDED Requirements
+
+### Requirement: Lockout
+The system SHALL lock accounts.
+
+#### Scenario: Locked
+- **WHEN** too many attempts fail
+- **THEN** the account is locked
+
+## MODIFIED Requirements
+
+### Requirement: Login
+The system SHALL log users in with MFA.
+
+#### Scenario: Valid login
+- **WHEN** credentials and code are valid
+- **THEN** a session is created
+
+## REMOVED Requirements
+
+### Requirement: Remember Me
+**Reason**: Security
+**Migration**: None
+
+## RENAMED Requirements
+
+- FROM: ` + "`### Requirement: Logout`" + `
+- TO: ` + "`### Requirement: Sign Out`" + `
+`
+
+func TestCapture(t *testing.T) {
+	root := t.TempDir()
+	specsDir := filepath.Join(root, "specs")
+	changeDir := filepath.Join(root, "changes", "add-mfa")
+
+	testutil.WriteFile(t, filepath.Join(specsDir, "auth", "spec.md"), baseSpec)
+	testutil.WriteFile(t, filepath.Join(changeDir, "specs", "auth", "spec.md"), deltaSpec)
+	// Delta for a new capability has no base version
+	testutil.WriteFile(t, filepath.Join(changeDir, "specs", "billing", "spec.md"), deltaSpec)
+
+	index, err := Capture(specsDir, changeDir)
+	if err != nil {
+		t.Fatalf("Capture() error = %v", err)
+	}
+
+	var names []string
+	for _, entry := range index.Requirements {
+		if entry.Spec != "auth" {
+			t.Errorf("unexpected spec %q", entry.Spec)
+		}
+		names = append(names, entry.Requirement)
+	}
+	if got := strings.Join(names, ","); got != "Login,Logout,Remember Me" {
+		t.Errorf("captured requirements = %s", got)
+	}
+
+	store := Open(changeDir)
+	if !store.Exists() {
+		t.Fatal("store should exist after capture")
+	}
+
+	content, ok, err := store.Lookup("auth", "login")
+	if err != nil || !ok {
+		t.Fatalf("Lookup() = %v, %v", ok, err)
+	}
+	if !strings.Contains(content, "The system SHALL log users in.") {
+		t.Errorf("captured content is not the base version:\n%s", content)
+	}
+	if Hash(content) != index.Requirements[0].Hash {
+		t.Error("object content does not match its hash")
+	}
+
+	// The base spec changes later; the snapshot keeps the original version
+	testutil.WriteFile(t, filepath.Join(specsDir, "auth", "spec.md"), strings.ReplaceAll(baseSpec, "log users in.", "log in."))
+	content, _, err = store.Lookup("auth", "Login")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(content, "log users in.") {
+		t.Error("snapshot content should not change with the base spec")
+	}
+
+	if _, ok, _ := store.Lookup("auth", "Lockout"); ok {
+		t.Error("added requirements should not be captured")
+	}
+}
+
+func TestStore_PutIsContentAddressed(t *testing.T) {
+	store := Open(t.TempDir())
+
+	first, err := store.Put("same content\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := store.Put("same content\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first != second {
+		t.Errorf("hashes differ for identical content: %s != %s", first, second)
+	}
+
+	got, err := store.Get(first)
+	if err != nil || got != "same content\n" {
+		t.Errorf("Get() = %q, %v", got, err)
+	}
+}
+
+func TestStore_LookupWithoutIndex(t *testing.T) {
+	_, ok, err := Open(t.TempDir()).Lookup("auth", "Login")
+	if err != nil || ok {
+		t.Errorf("Lookup() on empty store = %v, %v; want false, nil", ok, err)
+	}
+}
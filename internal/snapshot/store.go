@@ -0,0 +1,174 @@
+// Package snapshot provides a content-addressed store of requirement
+// versions, written into a change directory. It records the base version of
+// every requirement a change modifies, removes or renames, so that later
+// tooling (compatibility checks, three-way merges) has a reliable base even
+// after the main specs have moved on.
+//
+// Layout inside a change directory:
+//
+//	snapshot/
+//	├── index.json            # spec/requirement -> content hash
+//	└── objects/<sha256>.md   # requirement content, keyed by hash
+package snapshot
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/connerohnesorge/spectr/internal/parsers"
+)
+
+// File layout constants
+const (
+	// DirName is the snapshot directory inside a change directory.
+	DirName    = "snapshot"
+	indexFile  = "index.json"
+	objectsDir = "objects"
+	dirPerm    = 0o755
+	filePerm   = 0o644
+)
+
+// indexVersion is the current index.json format version.
+const indexVersion = 1
+
+// Entry maps a requirement of a spec to the hash of its content.
+type Entry struct {
+	Spec        string `json:"spec"`
+	Requirement string `json:"requirement"`
+	Hash        string `json:"hash"`
+}
+
+// Index lists the requirements captured in a snapshot.
+type Index struct {
+	Version      int     `json:"version"`
+	Created      string  `json:"created"` // RFC 3339 timestamp
+	Requirements []Entry `json:"requirements"`
+}
+
+// Store is a snapshot store rooted in a change directory.
+type Store struct {
+	dir string
+}
+
+// Open returns the snapshot store of a change directory. The store is not
+// created on disk until something is written to it.
+func Open(changeDir string) *Store {
+	return &Store{dir: filepath.Join(changeDir, DirName)}
+}
+
+// Exists reports whether the store has an index.
+func (s *Store) Exists() bool {
+	_, err := os.Stat(filepath.Join(s.dir, indexFile))
+
+	return err == nil
+}
+
+// Hash returns the content hash used to address requirement content.
+func Hash(content string) string {
+	sum := sha256.Sum256([]byte(content))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// Put stores content and returns its hash. Storing the same content twice
+// is a no-op.
+func (s *Store) Put(content string) (string, error) {
+	hash := Hash(content)
+	path := s.objectPath(hash)
+
+	if _, err := os.Stat(path); err == nil {
+		return hash, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), dirPerm); err != nil {
+		return "", fmt.Errorf("failed to create snapshot objects: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(content), filePerm); err != nil {
+		return "", fmt.Errorf("failed to write snapshot object: %w", err)
+	}
+
+	return hash, nil
+}
+
+// Get returns the content stored under hash.
+func (s *Store) Get(hash string) (string, error) {
+	data, err := os.ReadFile(s.objectPath(hash))
+	if err != nil {
+		return "", fmt.Errorf("snapshot object %s: %w", hash, err)
+	}
+
+	return string(data), nil
+}
+
+// ReadIndex reads the snapshot index.
+func (s *Store) ReadIndex() (*Index, error) {
+	data, err := os.ReadFile(filepath.Join(s.dir, indexFile))
+	if err != nil {
+		return nil, err
+	}
+
+	var index Index
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot index: %w", err)
+	}
+
+	return &index, nil
+}
+
+// WriteIndex writes the snapshot index.
+func (s *Store) WriteIndex(index *Index) error {
+	data, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal snapshot index: %w", err)
+	}
+
+	if err := os.MkdirAll(s.dir, dirPerm); err != nil {
+		return fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+
+	return os.WriteFile(
+		filepath.Join(s.dir, indexFile),
+		append(data, '\n'),
+		filePerm,
+	)
+}
+
+// Lookup returns the captured base content of a requirement. The
+// requirement name is matched case-insensitively. Returns false if the
+// store has no entry for it.
+func (s *Store) Lookup(spec, requirement string) (string, bool, error) {
+	index, err := s.ReadIndex()
+	if errors.Is(err, os.ErrNotExist) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+
+	normalized := parsers.NormalizeRequirementName(requirement)
+	for _, entry := range index.Requirements {
+		if entry.Spec != spec ||
+			parsers.NormalizeRequirementName(entry.Requirement) != normalized {
+			continue
+		}
+
+		content, err := s.Get(entry.Hash)
+		if err != nil {
+			return "", false, err
+		}
+
+		return content, true, nil
+	}
+
+	return "", false, nil
+}
+
+// objectPath returns the path of the object with the given hash.
+func (s *Store) objectPath(hash string) string {
+	return filepath.Join(s.dir, objectsDir, hash+".md")
+}
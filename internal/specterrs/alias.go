@@ -0,0 +1,27 @@
+package specterrs
+
+import "fmt"
+
+// RenameTargetExistsError indicates spectr rename was given a new ID that
+// is already used.
+type RenameTargetExistsError struct {
+	Kind string
+	ID   string
+	Path string
+}
+
+func (e *RenameTargetExistsError) Error() string {
+	return fmt.Sprintf("cannot rename to %s '%s': %s already exists", e.Kind, e.ID, e.Path)
+}
+
+// InvalidRenameError indicates a rename that cannot be recorded, such as
+// renaming an ID to itself or out of the project.
+type InvalidRenameError struct {
+	From   string
+	To     string
+	Reason string
+}
+
+func (e *InvalidRenameError) Error() string {
+	return fmt.Sprintf("cannot rename '%s' to '%s': %s", e.From, e.To, e.Reason)
+}
@@ -0,0 +1,81 @@
+package specterrs
+
+import "fmt"
+
+// AttestationNotFoundError indicates a spec that was never attested.
+type AttestationNotFoundError struct {
+	Spec string
+}
+
+func (e *AttestationNotFoundError) Error() string {
+	return fmt.Sprintf(
+		"no attestation for spec '%s'\nHint: Run 'spectr attest %s' first",
+		e.Spec,
+		e.Spec,
+	)
+}
+
+// AttestationVersionError indicates an attestation manifest written in an
+// unsupported format version.
+type AttestationVersionError struct {
+	Path    string
+	Version int
+}
+
+func (e *AttestationVersionError) Error() string {
+	return fmt.Sprintf(
+		"unsupported attestation version %d in %s\nHint: Upgrade spectr",
+		e.Version,
+		e.Path,
+	)
+}
+
+// SigningKeyRequiredError indicates a signing tool that has no default
+// key to fall back on.
+type SigningKeyRequiredError struct {
+	Tool string
+}
+
+func (e *SigningKeyRequiredError) Error() string {
+	return fmt.Sprintf("--key is required with --tool %s", e.Tool)
+}
+
+// SigningToolNotFoundError indicates a signing tool missing from PATH.
+type SigningToolNotFoundError struct {
+	Tool string
+}
+
+func (e *SigningToolNotFoundError) Error() string {
+	return fmt.Sprintf(
+		"%s not found in PATH\nHint: Install it, or choose another tool with --tool",
+		e.Tool,
+	)
+}
+
+// SignatureInvalidError indicates an attestation whose signature does not
+// verify against the given key.
+type SignatureInvalidError struct {
+	Path   string
+	Reason string
+}
+
+func (e *SignatureInvalidError) Error() string {
+	return fmt.Sprintf("signature of %s does not verify: %s", e.Path, e.Reason)
+}
+
+// AttestationDriftError indicates requirements that changed, went away or
+// were added since the spec was attested.
+type AttestationDriftError struct {
+	Spec  string
+	Count int
+}
+
+func (e *AttestationDriftError) Error() string {
+	return fmt.Sprintf(
+		"%d requirement(s) of %s differ from the attested text\n"+
+			"Hint: Have the changes approved, then run 'spectr attest %s' again",
+		e.Count,
+		e.Spec,
+		e.Spec,
+	)
+}
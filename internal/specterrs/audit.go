@@ -0,0 +1,34 @@
+package specterrs
+
+import "fmt"
+
+// AuditChainError indicates the audit log's hash chain does not verify,
+// meaning an entry was edited, removed or reordered after it was written.
+type AuditChainError struct {
+	Path   string
+	Line   int
+	Reason string
+}
+
+func (e *AuditChainError) Error() string {
+	return fmt.Sprintf(
+		"audit log %s is not intact at line %d: %s",
+		e.Path,
+		e.Line,
+		e.Reason,
+	)
+}
+
+// InvalidSinceError indicates a --since value is neither a date nor a
+// duration.
+type InvalidSinceError struct {
+	Value string
+}
+
+func (e *InvalidSinceError) Error() string {
+	return fmt.Sprintf(
+		"invalid --since %q: use a date (2006-01-02), an RFC 3339 time, "+
+			"or a duration such as 36h or 7d",
+		e.Value,
+	)
+}
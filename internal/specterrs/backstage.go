@@ -0,0 +1,18 @@
+package specterrs
+
+import "fmt"
+
+// InvalidBackstageKindError indicates a spec whose backstage.kind
+// frontmatter names an entity kind spectr does not generate.
+type InvalidBackstageKindError struct {
+	Spec string
+	Kind string
+}
+
+func (e *InvalidBackstageKindError) Error() string {
+	return fmt.Sprintf(
+		"spec %s: backstage kind %q is not supported (use Component, API or Resource)",
+		e.Spec,
+		e.Kind,
+	)
+}
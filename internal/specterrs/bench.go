@@ -0,0 +1,51 @@
+package specterrs
+
+import (
+	"fmt"
+	"strings"
+)
+
+// InvalidRegressionThresholdError indicates a negative --threshold for
+// spectr bench.
+type InvalidRegressionThresholdError struct {
+	Value float64
+}
+
+func (e *InvalidRegressionThresholdError) Error() string {
+	return fmt.Sprintf(
+		"invalid --threshold %g: must be a percentage of at least 0",
+		e.Value,
+	)
+}
+
+// BenchBaselineVersionError indicates a benchmark baseline written in an
+// unsupported format version.
+type BenchBaselineVersionError struct {
+	Path    string
+	Version int
+}
+
+func (e *BenchBaselineVersionError) Error() string {
+	return fmt.Sprintf(
+		"unsupported benchmark baseline version %d in %s\n"+
+			"Hint: Run 'spectr bench --save' to write a new baseline",
+		e.Version,
+		e.Path,
+	)
+}
+
+// BenchRegressionError indicates benchmarks that got slower, or allocate
+// more, than the allowed threshold.
+type BenchRegressionError struct {
+	Names     []string
+	Threshold float64
+}
+
+func (e *BenchRegressionError) Error() string {
+	return fmt.Sprintf(
+		"%d benchmark(s) regressed by more than %g%%: %s",
+		len(e.Names),
+		e.Threshold,
+		strings.Join(e.Names, ", "),
+	)
+}
@@ -0,0 +1,41 @@
+package specterrs
+
+import "fmt"
+
+// InvalidChangeIDError indicates a change ID that breaks the naming policy
+// of spectr.yaml.
+type InvalidChangeIDError struct {
+	ID     string
+	Reason string
+}
+
+func (e *InvalidChangeIDError) Error() string {
+	return fmt.Sprintf("invalid change ID '%s': %s", e.ID, e.Reason)
+}
+
+// InvalidChangeIDPatternError indicates a change_ids.pattern in
+// spectr.yaml that is not a valid regular expression.
+type InvalidChangeIDPatternError struct {
+	Pattern string
+	Err     error
+}
+
+func (e *InvalidChangeIDPatternError) Error() string {
+	return fmt.Sprintf(
+		"change_ids.pattern %q in spectr.yaml is not a valid regular expression: %v",
+		e.Pattern,
+		e.Err,
+	)
+}
+
+func (e *InvalidChangeIDPatternError) Unwrap() error {
+	return e.Err
+}
+
+// ChangeTitleRequiredError indicates spectr new change was given neither
+// an ID nor a title to generate one from.
+type ChangeTitleRequiredError struct{}
+
+func (*ChangeTitleRequiredError) Error() string {
+	return "pass a change ID, or --title to generate one"
+}
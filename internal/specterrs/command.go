@@ -0,0 +1,80 @@
+package specterrs
+
+import (
+	"fmt"
+	"time"
+)
+
+// TimeoutError indicates a command was aborted because it ran longer than
+// its --timeout.
+type TimeoutError struct {
+	Command string
+	Timeout time.Duration
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf(
+		"%s timed out after %s",
+		e.Command,
+		e.Timeout,
+	)
+}
+
+// UsageError wraps a command line parse error, such as an unknown flag
+// or a missing argument, so it exits with ExitFailure instead of kong's
+// own usage status.
+type UsageError struct {
+	Err error
+}
+
+func (e *UsageError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *UsageError) Unwrap() error {
+	return e.Err
+}
+
+// ExitCode implements kong.ExitCoder.
+func (*UsageError) ExitCode() int {
+	return ExitFailure
+}
+
+// MissingArgumentError indicates an omitted argument that would be picked
+// interactively, but there is no terminal or --no-input is set.
+type MissingArgumentError struct {
+	Argument string
+}
+
+func (e *MissingArgumentError) Error() string {
+	return fmt.Sprintf(
+		"missing %s; pass it, or run in a terminal without --no-input to pick one",
+		e.Argument,
+	)
+}
+
+// ExitCode implements kong.ExitCoder.
+func (*MissingArgumentError) ExitCode() int {
+	return ExitNoInput
+}
+
+// NoTerminalError indicates a command that only works interactively was
+// run without a terminal or with --no-input. Alternative is the
+// non-interactive form to use instead.
+type NoTerminalError struct {
+	Command     string
+	Alternative string
+}
+
+func (e *NoTerminalError) Error() string {
+	return fmt.Sprintf(
+		"%s needs a terminal for input; use %s instead",
+		e.Command,
+		e.Alternative,
+	)
+}
+
+// ExitCode implements kong.ExitCoder.
+func (*NoTerminalError) ExitCode() int {
+	return ExitNoInput
+}
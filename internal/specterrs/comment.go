@@ -0,0 +1,41 @@
+package specterrs
+
+import "fmt"
+
+// CommentNotFoundError indicates a comment ID that no comment of the
+// spec has.
+type CommentNotFoundError struct {
+	Spec string
+	ID   int
+}
+
+func (e *CommentNotFoundError) Error() string {
+	return fmt.Sprintf(
+		"comment #%d not found in spec '%s'\nHint: Run 'spectr comment list %s --all'",
+		e.ID,
+		e.Spec,
+		e.Spec,
+	)
+}
+
+// EmptyCommentError indicates a comment without text.
+type EmptyCommentError struct{}
+
+func (*EmptyCommentError) Error() string {
+	return "comment text is empty"
+}
+
+// CommentVersionError indicates a comments.jsonc written in an
+// unsupported format version.
+type CommentVersionError struct {
+	Path    string
+	Version int
+}
+
+func (e *CommentVersionError) Error() string {
+	return fmt.Sprintf(
+		"unsupported comments version %d in %s\nHint: Upgrade spectr",
+		e.Version,
+		e.Path,
+	)
+}
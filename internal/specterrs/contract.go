@@ -0,0 +1,33 @@
+package specterrs
+
+import "fmt"
+
+// ContractVersionError indicates a contract file written in an unsupported
+// format version.
+type ContractVersionError struct {
+	Path    string
+	Version int
+}
+
+func (e *ContractVersionError) Error() string {
+	return fmt.Sprintf(
+		"unsupported contract version %d in %s\n"+
+			"Hint: Upgrade spectr or run 'spectr contract freeze' again",
+		e.Version,
+		e.Path,
+	)
+}
+
+// ContractDriftError indicates pinned requirements that changed or were
+// removed in the provider's spec.
+type ContractDriftError struct {
+	Count int
+}
+
+func (e *ContractDriftError) Error() string {
+	return fmt.Sprintf(
+		"%d pinned requirement(s) changed\n"+
+			"Hint: Review the changes, then run 'spectr contract freeze' to accept them",
+		e.Count,
+	)
+}
@@ -0,0 +1,15 @@
+package specterrs
+
+import "fmt"
+
+// InvalidThresholdError indicates a similarity threshold outside (0, 1].
+type InvalidThresholdError struct {
+	Value float64
+}
+
+func (e *InvalidThresholdError) Error() string {
+	return fmt.Sprintf(
+		"invalid --threshold %g: must be greater than 0 and at most 1",
+		e.Value,
+	)
+}
@@ -0,0 +1,36 @@
+package specterrs
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SpectrLinkError indicates a spectr/ symlink that cannot be followed,
+// because its target is missing or the link is part of a cycle.
+type SpectrLinkError struct {
+	Path string
+	Err  error
+}
+
+func (e *SpectrLinkError) Error() string {
+	return fmt.Sprintf("cannot follow spectr/ symlink %s: %v", e.Path, e.Err)
+}
+
+func (e *SpectrLinkError) Unwrap() error {
+	return e.Err
+}
+
+// AmbiguousRootError indicates that a command needing one project found
+// several spectr/ directories below the working directory and none above.
+type AmbiguousRootError struct {
+	Candidates []string
+}
+
+func (e *AmbiguousRootError) Error() string {
+	return fmt.Sprintf(
+		"found %d spectr projects: %s\n"+
+			"Hint: Run from inside one of them or pass --project-dir",
+		len(e.Candidates),
+		strings.Join(e.Candidates, ", "),
+	)
+}
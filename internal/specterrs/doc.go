@@ -4,13 +4,53 @@
 //   - Use pointer receivers for the Error() method
 //   - Include structured fields for contextual information
 //   - Implement Unwrap() when wrapping underlying errors
+//   - Implement ExitCode() when they map to a status in exit.go
 //
 // Error types are organized by domain:
 //   - git.go: Git repository and branch errors
 //   - archive.go: Archive workflow errors
 //   - validation.go: Spec/change validation errors
-//   - initialize.go: Project initialization errors
+//   - initialize.go: Project initialization and demo errors
 //   - list.go: List command errors
 //   - environment.go: Environment configuration errors
 //   - pr.go: Pull request workflow errors
+//   - command.go: Command execution errors (usage, timeouts, missing arguments)
+//   - hosting.go: Hosting platform API and credential errors
+//   - help.go: Built-in help topic errors
+//   - tasks.go: tasks.jsonc format version and schema errors
+//   - schema.go: JSON Schema, JSONC syntax and schema lookup errors
+//   - ide.go: Editor integration errors
+//   - audit.go: Audit log integrity and query errors
+//   - transaction.go: Multi-file transaction errors
+//   - stale.go: Stale change detection errors
+//   - dedupe.go: Duplicate requirement detection errors
+//   - tokens.go: Token estimation preset errors
+//   - bench.go: Benchmark baseline and regression errors
+//   - discovery.go: Project root discovery errors
+//   - version.go: Project version compatibility errors
+//   - subscription.go: Spec subscription errors
+//   - contract.go: Requirement contract errors
+//   - links.go: Wikilink namespace resolution errors
+//   - retire.go: Spec retirement errors
+//   - replace.go: Scoped find-and-replace errors
+//   - templates.go: User template variable and include errors
+//   - keywords.go: Scenario keyword and delta section alias configuration errors
+//   - split.go: Change splitting errors
+//   - review.go: Spec review date and due-review errors
+//   - profile.go: Shared profile fetch and checksum errors
+//   - inherit.go: Spec inheritance errors (missing base, cycles)
+//   - flags.go: Feature-flag file errors
+//   - evidence.go: Scenario lookup and acceptance evidence errors
+//   - comment.go: Requirement review comment errors
+//   - attest.go: Signed attestation and verification errors
+//   - heatmap.go: Requirement edit heatmap errors
+//   - plan.go: Capacity planning errors
+//   - kinds.go: Custom item kind errors
+//   - yamldoc.go: Project YAML document import errors
+//   - backstage.go: Backstage catalog export errors
+//   - changeid.go: Change ID policy errors
+//   - alias.go: Spec and change rename errors
+//   - export.go: Batch export errors
+//   - scaffold.go: Test scaffolding errors
+//   - exit.go: Exit statuses returned through kong.ExitCoder
 package specterrs
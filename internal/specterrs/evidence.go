@@ -0,0 +1,70 @@
+package specterrs
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ScenarioNotFoundError indicates a scenario name or ID that matches no
+// scenario of the spec.
+type ScenarioNotFoundError struct {
+	Spec     string
+	Scenario string
+}
+
+func (e *ScenarioNotFoundError) Error() string {
+	return fmt.Sprintf(
+		"scenario '%s' not found in spec '%s'\n"+
+			"Hint: Use a scenario name or an ID from 'spectr show %s'",
+		e.Scenario,
+		e.Spec,
+		e.Spec,
+	)
+}
+
+// AmbiguousScenarioError indicates a scenario name shared by several
+// requirements of a spec.
+type AmbiguousScenarioError struct {
+	Spec         string
+	Scenario     string
+	Requirements []string
+}
+
+func (e *AmbiguousScenarioError) Error() string {
+	return fmt.Sprintf(
+		"scenario '%s' appears in several requirements of spec '%s' (%s)\n"+
+			"Hint: Use the scenario ID from 'spectr show %s'",
+		e.Scenario,
+		e.Spec,
+		strings.Join(e.Requirements, ", "),
+		e.Spec,
+	)
+}
+
+// EvidenceFileNotFoundError indicates evidence given as a file path that
+// does not exist.
+type EvidenceFileNotFoundError struct {
+	Path string
+}
+
+func (e *EvidenceFileNotFoundError) Error() string {
+	return fmt.Sprintf(
+		"evidence file %s not found (give an existing file or an http(s) URL)",
+		e.Path,
+	)
+}
+
+// EvidenceVersionError indicates an evidence.jsonc written in an
+// unsupported format version.
+type EvidenceVersionError struct {
+	Path    string
+	Version int
+}
+
+func (e *EvidenceVersionError) Error() string {
+	return fmt.Sprintf(
+		"unsupported evidence version %d in %s\nHint: Upgrade spectr",
+		e.Version,
+		e.Path,
+	)
+}
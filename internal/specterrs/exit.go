@@ -0,0 +1,21 @@
+package specterrs
+
+// Exit statuses of the spectr binary. Errors that implement kong.ExitCoder
+// return one of these; any other error exits with ExitFailure.
+const (
+	// ExitOK means the command succeeded.
+	ExitOK = 0
+	// ExitFailure covers usage errors and failures without a more
+	// specific status.
+	ExitFailure = 1
+	// ExitValidation means validation found errors.
+	ExitValidation = 2
+	// ExitWarnings means validation found only warnings and ran with
+	// --fail-on=warning.
+	ExitWarnings = 3
+	// ExitGit means a git command failed or git is not installed.
+	ExitGit = 4
+	// ExitNoInput means the command needed input it could not ask for,
+	// because there is no terminal or --no-input is set.
+	ExitNoInput = 5
+)
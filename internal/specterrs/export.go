@@ -0,0 +1,13 @@
+package specterrs
+
+import "fmt"
+
+// InvalidJobsError indicates a negative --jobs value for a parallel
+// export.
+type InvalidJobsError struct {
+	Jobs int
+}
+
+func (e *InvalidJobsError) Error() string {
+	return fmt.Sprintf("invalid --jobs %d: must be 0 (one per CPU) or more", e.Jobs)
+}
@@ -0,0 +1,18 @@
+package specterrs
+
+import "fmt"
+
+// InvalidFlagsFileError indicates a feature-flag file that is not a JSON
+// object of flag names to booleans.
+type InvalidFlagsFileError struct {
+	Path   string
+	Reason string
+}
+
+func (e *InvalidFlagsFileError) Error() string {
+	return fmt.Sprintf(
+		"invalid flags file %s: %s (expected a JSON object such as {\"new_checkout\": true})",
+		e.Path,
+		e.Reason,
+	)
+}
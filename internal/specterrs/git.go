@@ -1,5 +1,10 @@
 package specterrs
 
+import (
+	"fmt"
+	"strings"
+)
+
 // EmptyRemoteURLError indicates an empty remote URL was encountered.
 type EmptyRemoteURLError struct{}
 
@@ -40,3 +45,149 @@ type BaseBranchNotFoundError struct {
 func (*BaseBranchNotFoundError) Error() string {
 	return "could not determine base branch, please specify with --base"
 }
+
+// MergeConflictError indicates a merge driver could not merge a file
+// without conflicts. The merged file is left with the "ours" version of
+// each conflicting item.
+type MergeConflictError struct {
+	Path      string
+	Conflicts []string
+}
+
+func (e *MergeConflictError) Error() string {
+	return fmt.Sprintf(
+		"%d merge conflict(s) in %s",
+		len(e.Conflicts),
+		e.Path,
+	)
+}
+
+// GitCommandError indicates a git command failed. Stderr holds the
+// command's trimmed standard error when it ran to completion.
+type GitCommandError struct {
+	Args   []string
+	Stderr string
+	Err    error
+}
+
+func (e *GitCommandError) Error() string {
+	name := "git"
+	if len(e.Args) > 0 {
+		name = "git " + e.Args[0]
+	}
+	if e.Stderr != "" {
+		return fmt.Sprintf("%s failed: %s", name, e.Stderr)
+	}
+
+	return fmt.Sprintf("%s failed: %v", name, e.Err)
+}
+
+func (e *GitCommandError) Unwrap() error {
+	return e.Err
+}
+
+// ExitCode implements kong.ExitCoder.
+func (*GitCommandError) ExitCode() int {
+	return ExitGit
+}
+
+// GitUnavailableError indicates git is needed but not installed. Feature
+// names what needed it, when known.
+type GitUnavailableError struct {
+	Feature string
+	Err     error
+}
+
+func (e *GitUnavailableError) Error() string {
+	if e.Feature == "" {
+		return "git is not installed or not in PATH"
+	}
+
+	return fmt.Sprintf(
+		"%s needs git, which is not installed or not in PATH",
+		e.Feature,
+	)
+}
+
+func (e *GitUnavailableError) Unwrap() error {
+	return e.Err
+}
+
+// ExitCode implements kong.ExitCoder.
+func (*GitUnavailableError) ExitCode() int {
+	return ExitGit
+}
+
+// UnknownGitBackendError indicates the configured git backend is not
+// available in this build.
+type UnknownGitBackendError struct {
+	Backend   string
+	Available []string
+}
+
+func (e *UnknownGitBackendError) Error() string {
+	return fmt.Sprintf(
+		"git backend '%s' is not available in this build (available: %s)",
+		e.Backend,
+		strings.Join(e.Available, ", "),
+	)
+}
+
+// WorktreePathExistsError indicates a worktree cannot be created because
+// its target path is already taken.
+type WorktreePathExistsError struct {
+	Path string
+}
+
+func (e *WorktreePathExistsError) Error() string {
+	return fmt.Sprintf(
+		"worktree path %s already exists; choose another with --path",
+		e.Path,
+	)
+}
+
+// AmbiguousBranchChangeError indicates the current branch name matches
+// more than one active change, so the change must be given explicitly.
+type AmbiguousBranchChangeError struct {
+	Branch     string
+	Candidates []string
+}
+
+func (e *AmbiguousBranchChangeError) Error() string {
+	return fmt.Sprintf(
+		"branch %s matches several changes (%s); pass the change ID explicitly",
+		e.Branch,
+		strings.Join(e.Candidates, ", "),
+	)
+}
+
+// UnknownBranchChangeError indicates the current branch names no active
+// change exactly but is a prefix of Candidates, so the change must be
+// given explicitly.
+type UnknownBranchChangeError struct {
+	Branch     string
+	ChangeID   string
+	Candidates []string
+}
+
+func (e *UnknownBranchChangeError) Error() string {
+	return fmt.Sprintf(
+		"branch %s names no active change '%s' (did you mean %s?); pass the change ID explicitly",
+		e.Branch,
+		e.ChangeID,
+		strings.Join(e.Candidates, ", "),
+	)
+}
+
+// InvalidBranchPatternError indicates a git.change_branches pattern in
+// spectr.yaml does not contain the {change} placeholder exactly once.
+type InvalidBranchPatternError struct {
+	Pattern string
+}
+
+func (e *InvalidBranchPatternError) Error() string {
+	return fmt.Sprintf(
+		"invalid git.change_branches pattern %q: must contain {change} exactly once",
+		e.Pattern,
+	)
+}
@@ -0,0 +1,13 @@
+package specterrs
+
+import "fmt"
+
+// InvalidHeatmapDaysError indicates a heatmap window that is not a
+// positive number of days.
+type InvalidHeatmapDaysError struct {
+	Days int
+}
+
+func (e *InvalidHeatmapDaysError) Error() string {
+	return fmt.Sprintf("invalid --days %d: must be at least 1", e.Days)
+}
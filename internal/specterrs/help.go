@@ -0,0 +1,21 @@
+package specterrs
+
+import (
+	"fmt"
+	"strings"
+)
+
+// UnknownHelpTopicError indicates no help topic matched the requested name
+// or search query.
+type UnknownHelpTopicError struct {
+	Topic     string
+	Available []string
+}
+
+func (e *UnknownHelpTopicError) Error() string {
+	return fmt.Sprintf(
+		"no help topic matches '%s' (topics: %s)",
+		e.Topic,
+		strings.Join(e.Available, ", "),
+	)
+}
@@ -0,0 +1,117 @@
+package specterrs
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// HostingAPIError indicates a hosting platform API (GitHub, GitLab, Jira)
+// answered with an unsuccessful status code.
+type HostingAPIError struct {
+	Method     string
+	URL        string
+	StatusCode int
+	Body       string
+}
+
+func (e *HostingAPIError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf(
+			"%s %s: status %d",
+			e.Method,
+			e.URL,
+			e.StatusCode,
+		)
+	}
+
+	return fmt.Sprintf(
+		"%s %s: status %d: %s",
+		e.Method,
+		e.URL,
+		e.StatusCode,
+		e.Body,
+	)
+}
+
+// RateLimitError indicates a hosting platform API rejected a request
+// because the rate limit was exhausted. ResetAt is zero when the platform
+// did not say when the limit resets.
+type RateLimitError struct {
+	URL     string
+	ResetAt time.Time
+}
+
+func (e *RateLimitError) Error() string {
+	if e.ResetAt.IsZero() {
+		return fmt.Sprintf("rate limited by %s", e.URL)
+	}
+
+	return fmt.Sprintf(
+		"rate limited by %s until %s",
+		e.URL,
+		e.ResetAt.Format(time.RFC3339),
+	)
+}
+
+// RetriesExhaustedError indicates an operation kept failing with transient
+// errors until its retry budget ran out. Err is the last failure.
+type RetriesExhaustedError struct {
+	Attempts int
+	Err      error
+}
+
+func (e *RetriesExhaustedError) Error() string {
+	return fmt.Sprintf(
+		"giving up after %d attempts: %v",
+		e.Attempts,
+		e.Err,
+	)
+}
+
+func (e *RetriesExhaustedError) Unwrap() error {
+	return e.Err
+}
+
+// UnknownProviderError indicates an unsupported credential provider name.
+type UnknownProviderError struct {
+	Provider string
+}
+
+func (e *UnknownProviderError) Error() string {
+	return fmt.Sprintf(
+		"unknown provider '%s' (expected github, gitlab, gitea or jira)",
+		e.Provider,
+	)
+}
+
+// ProviderHostRequiredError indicates a self-hosted provider was used
+// without a host.
+type ProviderHostRequiredError struct {
+	Provider string
+}
+
+func (e *ProviderHostRequiredError) Error() string {
+	return fmt.Sprintf(
+		"%s has no default host; pass --host",
+		e.Provider,
+	)
+}
+
+// CredentialNotFoundError indicates no token was found for a provider in
+// any credential source.
+type CredentialNotFoundError struct {
+	Provider string
+	Host     string
+	Sources  []string
+}
+
+func (e *CredentialNotFoundError) Error() string {
+	return fmt.Sprintf(
+		"no %s token for %s (checked %s); run 'spectr auth login %s'",
+		e.Provider,
+		e.Host,
+		strings.Join(e.Sources, ", "),
+		e.Provider,
+	)
+}
@@ -0,0 +1,22 @@
+package specterrs
+
+import "fmt"
+
+// EditorConfigParseError indicates an existing editor settings file could
+// not be parsed, so generated entries cannot be merged into it.
+type EditorConfigParseError struct {
+	Path string
+	Err  error
+}
+
+func (e *EditorConfigParseError) Error() string {
+	return fmt.Sprintf(
+		"cannot merge into %s: %v (fix the file or rerun with --force to overwrite it)",
+		e.Path,
+		e.Err,
+	)
+}
+
+func (e *EditorConfigParseError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,26 @@
+package specterrs
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BaseSpecNotFoundError indicates a spec whose extends frontmatter names
+// a spec that does not exist.
+type BaseSpecNotFoundError struct {
+	Spec string
+	Base string
+}
+
+func (e *BaseSpecNotFoundError) Error() string {
+	return fmt.Sprintf("spec '%s' extends '%s', which does not exist", e.Spec, e.Base)
+}
+
+// InheritanceCycleError indicates specs that extend each other.
+type InheritanceCycleError struct {
+	Chain []string
+}
+
+func (e *InheritanceCycleError) Error() string {
+	return "spec inheritance cycle: " + strings.Join(e.Chain, " -> ")
+}
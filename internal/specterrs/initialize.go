@@ -54,3 +54,16 @@ func (e *InitializationCompletedWithErrorsError) ErrorMessages() string {
 
 	return strings.Join(msgs, "\n")
 }
+
+// DemoExistsError indicates the target of spectr demo already contains a
+// spectr/ directory.
+type DemoExistsError struct {
+	Path string
+}
+
+func (e *DemoExistsError) Error() string {
+	return fmt.Sprintf(
+		"%s already exists; choose an empty directory for the demo",
+		e.Path,
+	)
+}
@@ -0,0 +1,65 @@
+package specterrs
+
+import "fmt"
+
+// UnknownScenarioKeywordError indicates scenarios.keywords in spectr.yaml
+// lists aliases for a keyword that is not a scenario step keyword.
+type UnknownScenarioKeywordError struct {
+	Keyword string
+}
+
+func (e *UnknownScenarioKeywordError) Error() string {
+	return fmt.Sprintf(
+		"scenarios.keywords: unknown keyword '%s' (want GIVEN, WHEN, THEN, AND or BUT)",
+		e.Keyword,
+	)
+}
+
+// ConflictingKeywordAliasError indicates scenarios.keywords in spectr.yaml
+// gives the same alias to two keywords, or uses another keyword's name as
+// an alias.
+type ConflictingKeywordAliasError struct {
+	Alias  string
+	First  string
+	Second string
+}
+
+func (e *ConflictingKeywordAliasError) Error() string {
+	return fmt.Sprintf(
+		"scenarios.keywords: '%s' cannot stand for both %s and %s",
+		e.Alias,
+		e.First,
+		e.Second,
+	)
+}
+
+// UnknownDeltaTypeError indicates deltas.aliases in spectr.yaml lists
+// aliases for a delta operation that does not exist.
+type UnknownDeltaTypeError struct {
+	Type string
+}
+
+func (e *UnknownDeltaTypeError) Error() string {
+	return fmt.Sprintf(
+		"deltas.aliases: unknown delta type '%s' (want ADDED, MODIFIED, REMOVED or RENAMED)",
+		e.Type,
+	)
+}
+
+// ConflictingDeltaAliasError indicates deltas.aliases in spectr.yaml gives
+// the same section title to two delta operations, or uses another
+// operation's canonical title as an alias.
+type ConflictingDeltaAliasError struct {
+	Alias  string
+	First  string
+	Second string
+}
+
+func (e *ConflictingDeltaAliasError) Error() string {
+	return fmt.Sprintf(
+		"deltas.aliases: '%s' cannot stand for both %s and %s",
+		e.Alias,
+		e.First,
+		e.Second,
+	)
+}
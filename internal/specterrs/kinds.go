@@ -0,0 +1,72 @@
+package specterrs
+
+import (
+	"fmt"
+	"strings"
+)
+
+// InvalidKindError indicates a custom item kind in spectr.yaml that
+// cannot be used, such as a reserved or duplicate name.
+type InvalidKindError struct {
+	Kind   string
+	Reason string
+}
+
+func (e *InvalidKindError) Error() string {
+	return fmt.Sprintf("invalid kind '%s' in spectr.yaml: %s", e.Kind, e.Reason)
+}
+
+// UnknownKindError indicates a kind name that spectr.yaml does not
+// declare.
+type UnknownKindError struct {
+	Kind      string
+	Available []string
+}
+
+func (e *UnknownKindError) Error() string {
+	if len(e.Available) == 0 {
+		return fmt.Sprintf(
+			"unknown kind '%s': declare custom kinds under kinds: in spectr.yaml",
+			e.Kind,
+		)
+	}
+
+	return fmt.Sprintf(
+		"unknown kind '%s' (available: %s)",
+		e.Kind,
+		strings.Join(e.Available, ", "),
+	)
+}
+
+// InvalidItemIDError indicates an ID that cannot name an item file.
+type InvalidItemIDError struct {
+	ID string
+}
+
+func (e *InvalidItemIDError) Error() string {
+	return fmt.Sprintf(
+		"invalid ID '%s': use letters, digits, '.', '_' and '-', starting with a letter or digit",
+		e.ID,
+	)
+}
+
+// ItemExistsError indicates spectr new would overwrite an existing item.
+type ItemExistsError struct {
+	Kind string
+	ID   string
+	Path string
+}
+
+func (e *ItemExistsError) Error() string {
+	return fmt.Sprintf("%s '%s' already exists at %s", e.Kind, e.ID, e.Path)
+}
+
+// MissingItemIDError indicates spectr new was asked for an item of a
+// custom kind without its ID.
+type MissingItemIDError struct {
+	Kind string
+}
+
+func (e *MissingItemIDError) Error() string {
+	return fmt.Sprintf("pass the ID of the new %s, e.g. spectr new %s <id>", e.Kind, e.Kind)
+}
@@ -0,0 +1,39 @@
+package specterrs
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AmbiguousWikilinkError indicates an unqualified wikilink target found in
+// several projects of the same resolution tier.
+type AmbiguousWikilinkError struct {
+	Target     string
+	Namespaces []string
+}
+
+func (e *AmbiguousWikilinkError) Error() string {
+	return fmt.Sprintf(
+		"wikilink target '%s' is ambiguous: found in %s\n"+
+			"Hint: Qualify it with a namespace, e.g. [[%s:%s]]",
+		e.Target,
+		strings.Join(e.Namespaces, ", "),
+		e.Namespaces[0],
+		e.Target,
+	)
+}
+
+// UnknownWikilinkNamespaceError indicates a qualified wikilink whose
+// namespace names no known project.
+type UnknownWikilinkNamespaceError struct {
+	Namespace string
+	Known     []string
+}
+
+func (e *UnknownWikilinkNamespaceError) Error() string {
+	return fmt.Sprintf(
+		"unknown wikilink namespace '%s' (known: %s)",
+		e.Namespace,
+		strings.Join(e.Known, ", "),
+	)
+}
@@ -1,6 +1,9 @@
 package specterrs
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // IncompatibleFlagsError indicates two flags cannot be used together.
 type IncompatibleFlagsError struct {
@@ -29,3 +32,18 @@ func (e *RequiresFlagError) Error() string {
 		e.RequiredFlag,
 	)
 }
+
+// UnknownColumnError indicates a --columns entry that the listed item type
+// does not support.
+type UnknownColumnError struct {
+	Column    string
+	Supported []string
+}
+
+func (e *UnknownColumnError) Error() string {
+	return fmt.Sprintf(
+		"unknown column %q (supported: %s)",
+		e.Column,
+		strings.Join(e.Supported, ", "),
+	)
+}
@@ -0,0 +1,15 @@
+package specterrs
+
+import "fmt"
+
+// InvalidCapacityFlagError indicates a capacity planning flag with an
+// unusable value, such as a team of zero people.
+type InvalidCapacityFlagError struct {
+	Flag   string
+	Value  string
+	Reason string
+}
+
+func (e *InvalidCapacityFlagError) Error() string {
+	return fmt.Sprintf("invalid %s %s: %s", e.Flag, e.Value, e.Reason)
+}
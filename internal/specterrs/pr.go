@@ -37,3 +37,19 @@ func (e *PRPrerequisiteError) Error() string {
 func (e *PRPrerequisiteError) Unwrap() error {
 	return e.Err
 }
+
+// PRNotFoundError indicates there is no open pull request for a change's
+// branch to update.
+type PRNotFoundError struct {
+	Branch  string
+	Details string
+}
+
+func (e *PRNotFoundError) Error() string {
+	msg := fmt.Sprintf("no open pull request for branch '%s'", e.Branch)
+	if e.Details != "" {
+		msg += ": " + e.Details
+	}
+
+	return msg + "\nHint: Create it with 'spectr pr proposal' first"
+}
@@ -0,0 +1,63 @@
+package specterrs
+
+import "fmt"
+
+// InvalidProfileSourceError indicates an extends entry in spectr.yaml that
+// cannot be fetched, such as one without a URL or checksum.
+type InvalidProfileSourceError struct {
+	Reason string
+}
+
+func (e *InvalidProfileSourceError) Error() string {
+	return "invalid extends in spectr.yaml: " + e.Reason
+}
+
+// ProfileFetchError indicates a shared profile could not be downloaded.
+type ProfileFetchError struct {
+	URL string
+	Err error
+}
+
+func (e *ProfileFetchError) Error() string {
+	return fmt.Sprintf("failed to fetch profile %s: %v", e.URL, e.Err)
+}
+
+func (e *ProfileFetchError) Unwrap() error {
+	return e.Err
+}
+
+// ProfileFileNotFoundError indicates a fetched bundle without the profile
+// file named by extends.path.
+type ProfileFileNotFoundError struct {
+	URL  string
+	Path string
+}
+
+func (e *ProfileFileNotFoundError) Error() string {
+	return fmt.Sprintf("profile %s has no %s", e.URL, e.Path)
+}
+
+// ProfileChecksumError indicates a fetched profile whose SHA-256 does not
+// match extends.sha256. The profile is not used or cached.
+type ProfileChecksumError struct {
+	URL  string
+	Want string
+	Got  string
+}
+
+func (e *ProfileChecksumError) Error() string {
+	return fmt.Sprintf(
+		"profile %s checksum mismatch: spectr.yaml pins sha256 %s, fetched %s",
+		e.URL,
+		e.Want,
+		e.Got,
+	)
+}
+
+// NoProfileError indicates a profile command run in a project whose
+// spectr.yaml has no extends entry.
+type NoProfileError struct{}
+
+func (*NoProfileError) Error() string {
+	return "spectr.yaml does not extend a profile (add an extends entry)"
+}
@@ -0,0 +1,29 @@
+package specterrs
+
+import (
+	"fmt"
+	"strings"
+)
+
+// EmptySearchTermError indicates spectr replace was given an empty term to
+// search for.
+type EmptySearchTermError struct{}
+
+func (*EmptySearchTermError) Error() string {
+	return "the term to replace must not be empty"
+}
+
+// UnknownReplaceScopeError indicates an --in value that names no node
+// type.
+type UnknownReplaceScopeError struct {
+	Scope string
+	Valid []string
+}
+
+func (e *UnknownReplaceScopeError) Error() string {
+	return fmt.Sprintf(
+		"unknown --in scope '%s' (valid: %s)",
+		e.Scope,
+		strings.Join(e.Valid, ", "),
+	)
+}
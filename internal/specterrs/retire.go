@@ -0,0 +1,37 @@
+package specterrs
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SpecInUseError indicates a spec cannot be retired because active
+// changes still modify or link to it.
+type SpecInUseError struct {
+	SpecID  string
+	Changes []string
+}
+
+func (e *SpecInUseError) Error() string {
+	return fmt.Sprintf(
+		"spec '%s' is referenced by active change(s): %s\n"+
+			"Hint: Archive or update those changes first",
+		e.SpecID,
+		strings.Join(e.Changes, ", "),
+	)
+}
+
+// SpecAlreadyRetiredError indicates the archive already holds a retired
+// spec with the same ID.
+type SpecAlreadyRetiredError struct {
+	SpecID string
+	Path   string
+}
+
+func (e *SpecAlreadyRetiredError) Error() string {
+	return fmt.Sprintf(
+		"a retired spec '%s' already exists at %s",
+		e.SpecID,
+		e.Path,
+	)
+}
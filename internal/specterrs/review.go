@@ -0,0 +1,22 @@
+package specterrs
+
+import "fmt"
+
+// InvalidReviewDateError indicates a review date that is not YYYY-MM-DD.
+type InvalidReviewDateError struct {
+	Value string
+}
+
+func (e *InvalidReviewDateError) Error() string {
+	return fmt.Sprintf("invalid review date %q: expected YYYY-MM-DD", e.Value)
+}
+
+// ReviewDueError indicates specs past their review interval, reported by
+// spectr review due --check.
+type ReviewDueError struct {
+	Count int
+}
+
+func (e *ReviewDueError) Error() string {
+	return fmt.Sprintf("%d spec(s) due for review", e.Count)
+}
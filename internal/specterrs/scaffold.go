@@ -0,0 +1,34 @@
+package specterrs
+
+import (
+	"fmt"
+	"strings"
+)
+
+// UnsupportedScaffoldLanguageError indicates a --lang that spectr
+// scaffold tests cannot generate tests for.
+type UnsupportedScaffoldLanguageError struct {
+	Lang      string
+	Supported []string
+}
+
+func (e *UnsupportedScaffoldLanguageError) Error() string {
+	return fmt.Sprintf(
+		"cannot scaffold tests in %q (supported: %s)",
+		e.Lang,
+		strings.Join(e.Supported, ", "),
+	)
+}
+
+// ScaffoldFileExistsError indicates a scaffold output file that already
+// exists and would be overwritten.
+type ScaffoldFileExistsError struct {
+	Path string
+}
+
+func (e *ScaffoldFileExistsError) Error() string {
+	return fmt.Sprintf(
+		"%s already exists\nHint: Use --force to overwrite it, or print to stdout without --output",
+		e.Path,
+	)
+}
@@ -0,0 +1,108 @@
+package specterrs
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SchemaProblem is a single JSON Schema violation. Line and Column are
+// 1-based positions in the source file; zero means unknown.
+type SchemaProblem struct {
+	Path    string
+	Message string
+	Line    int
+	Column  int
+}
+
+func (p SchemaProblem) String() string {
+	path := p.Path
+	if path == "" {
+		path = "(root)"
+	}
+	if p.Line == 0 {
+		return path + ": " + p.Message
+	}
+
+	return fmt.Sprintf("line %d, col %d: %s: %s", p.Line, p.Column, path, p.Message)
+}
+
+// SchemaError indicates a file does not match the JSON Schema of its
+// artifact.
+type SchemaError struct {
+	Path     string
+	Schema   string
+	Problems []SchemaProblem
+}
+
+func (e *SchemaError) Error() string {
+	return fmt.Sprintf(
+		"%s does not match %s:\n  - %s",
+		e.Path,
+		e.Schema,
+		joinProblems(e.Problems),
+	)
+}
+
+// SyntaxError indicates a JSONC or YAML file could not be parsed.
+type SyntaxError struct {
+	Path    string
+	Line    int
+	Column  int
+	Message string
+}
+
+func (e *SyntaxError) Error() string {
+	switch {
+	case e.Line == 0:
+		return fmt.Sprintf("%s: %s", e.Path, e.Message)
+	case e.Column == 0:
+		return fmt.Sprintf("%s:%d: %s", e.Path, e.Line, e.Message)
+	}
+
+	return fmt.Sprintf(
+		"%s:%d:%d: %s",
+		e.Path,
+		e.Line,
+		e.Column,
+		e.Message,
+	)
+}
+
+// UnknownSchemaArtifactError indicates 'spectr schema print' was given an
+// artifact that has no schema.
+type UnknownSchemaArtifactError struct {
+	Artifact  string
+	Available []string
+}
+
+func (e *UnknownSchemaArtifactError) Error() string {
+	return fmt.Sprintf(
+		"no schema for %q; available: %s",
+		e.Artifact,
+		strings.Join(e.Available, ", "),
+	)
+}
+
+// UnknownSchemaVersionError indicates a schema version was requested that
+// the artifact does not have.
+type UnknownSchemaVersionError struct {
+	Artifact string
+	Version  int
+}
+
+func (e *UnknownSchemaVersionError) Error() string {
+	return fmt.Sprintf(
+		"%s has no schema version %d",
+		e.Artifact,
+		e.Version,
+	)
+}
+
+func joinProblems(problems []SchemaProblem) string {
+	parts := make([]string, len(problems))
+	for i, p := range problems {
+		parts[i] = p.String()
+	}
+
+	return strings.Join(parts, "\n  - ")
+}
@@ -0,0 +1,72 @@
+package specterrs
+
+import "fmt"
+
+// ChangeExistsError indicates split-change was asked to create a change
+// that already exists.
+type ChangeExistsError struct {
+	ChangeID string
+}
+
+func (e *ChangeExistsError) Error() string {
+	return fmt.Sprintf("change '%s' already exists", e.ChangeID)
+}
+
+// EmptySplitError indicates split-change was given neither a delta spec
+// nor a task to move.
+type EmptySplitError struct {
+	ChangeID string
+}
+
+func (e *EmptySplitError) Error() string {
+	return fmt.Sprintf(
+		"nothing to split out of change '%s'\n"+
+			"Hint: Select delta specs with --spec and tasks with --task",
+		e.ChangeID,
+	)
+}
+
+// SplitItemNotFoundError indicates a delta spec or task selected for
+// split-change does not exist in the source change.
+type SplitItemNotFoundError struct {
+	ChangeID string
+	Kind     string // "delta spec" or "task"
+	Item     string
+}
+
+func (e *SplitItemNotFoundError) Error() string {
+	return fmt.Sprintf(
+		"change '%s' has no %s '%s'",
+		e.ChangeID,
+		e.Kind,
+		e.Item,
+	)
+}
+
+// SplitTasksAcceptedError indicates tasks were selected for split-change
+// from a change whose tasks.md has already been accepted into
+// tasks.jsonc.
+type SplitTasksAcceptedError struct {
+	ChangeID string
+}
+
+func (e *SplitTasksAcceptedError) Error() string {
+	return fmt.Sprintf(
+		"change '%s' is accepted; tasks can only be split from tasks.md",
+		e.ChangeID,
+	)
+}
+
+// SplitLeavesNoDeltasError indicates split-change would move every delta
+// spec out of the source change.
+type SplitLeavesNoDeltasError struct {
+	ChangeID string
+}
+
+func (e *SplitLeavesNoDeltasError) Error() string {
+	return fmt.Sprintf(
+		"splitting would leave change '%s' without delta specs\n"+
+			"Hint: Keep at least one --spec in the original change",
+		e.ChangeID,
+	)
+}
@@ -0,0 +1,13 @@
+package specterrs
+
+import "fmt"
+
+// InvalidStaleDaysError indicates a stale threshold that is not a positive
+// number of days.
+type InvalidStaleDaysError struct {
+	Days int
+}
+
+func (e *InvalidStaleDaysError) Error() string {
+	return fmt.Sprintf("invalid --days %d: must be at least 1", e.Days)
+}
@@ -0,0 +1,9 @@
+package specterrs
+
+// MissingSubscriberEmailError indicates spectr subscribe could not tell
+// who is subscribing: no --email was given and git has no user.email.
+type MissingSubscriberEmailError struct{}
+
+func (e *MissingSubscriberEmailError) Error() string {
+	return "no email to subscribe with; pass --email or set git config user.email"
+}
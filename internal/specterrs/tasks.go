@@ -0,0 +1,84 @@
+package specterrs
+
+import (
+	"fmt"
+	"strings"
+)
+
+// UnsupportedTasksVersionError indicates a tasks.jsonc file declares a
+// format version this build does not understand, typically because it was
+// written by a newer spectr.
+type UnsupportedTasksVersionError struct {
+	Path    string
+	Version int
+	Latest  int
+}
+
+func (e *UnsupportedTasksVersionError) Error() string {
+	return fmt.Sprintf(
+		"%s uses tasks format version %d, but this spectr supports "+
+			"versions up to %d; upgrade spectr to read it",
+		e.Path,
+		e.Version,
+		e.Latest,
+	)
+}
+
+// TasksSchemaError indicates a tasks.jsonc file does not match the JSON
+// Schema of the version it declares.
+type TasksSchemaError struct {
+	Path     string
+	Version  int
+	Problems []SchemaProblem
+}
+
+func (e *TasksSchemaError) Error() string {
+	return fmt.Sprintf(
+		"%s does not match the tasks v%d schema:\n  - %s",
+		e.Path,
+		e.Version,
+		joinProblems(e.Problems),
+	)
+}
+
+// TasksMigrationFailedError indicates one or more changes could not be
+// migrated. Per-change errors are printed as they occur.
+type TasksMigrationFailedError struct {
+	Failed int
+}
+
+func (e *TasksMigrationFailedError) Error() string {
+	return fmt.Sprintf("%d change(s) failed to migrate", e.Failed)
+}
+
+// TasksMigrationNeededError is returned by 'spectr migrate tasks --check'
+// when some changes still use an older tasks.jsonc version.
+type TasksMigrationNeededError struct {
+	ChangeIDs []string
+}
+
+func (e *TasksMigrationNeededError) Error() string {
+	return fmt.Sprintf(
+		"tasks.jsonc needs migration: %s (run 'spectr migrate tasks')",
+		strings.Join(e.ChangeIDs, ", "),
+	)
+}
+
+// InvalidEstimateError indicates a task estimate that is not a number
+// followed by h, d or w, such as "4h" or "1.5d".
+type InvalidEstimateError struct {
+	Task     string
+	Estimate string
+}
+
+func (e *InvalidEstimateError) Error() string {
+	if e.Task == "" {
+		return fmt.Sprintf("invalid estimate %q: use a number of hours, days or weeks, e.g. 4h, 2d, 1w", e.Estimate)
+	}
+
+	return fmt.Sprintf(
+		"task %s has invalid estimate %q: use a number of hours, days or weeks, e.g. 4h, 2d, 1w",
+		e.Task,
+		e.Estimate,
+	)
+}
@@ -0,0 +1,44 @@
+package specterrs
+
+import (
+	"fmt"
+	"strings"
+)
+
+// UndefinedTemplateVariableError indicates a strict-mode template read a
+// variable or environment variable that is not set.
+type UndefinedTemplateVariableError struct {
+	// Kind is "var" for spectr.yaml templates.vars or "env" for the
+	// environment.
+	Kind string
+	Name string
+}
+
+func (e *UndefinedTemplateVariableError) Error() string {
+	if e.Kind == "env" {
+		return fmt.Sprintf(
+			"environment variable '%s' is not set (templates.strict is on)",
+			e.Name,
+		)
+	}
+
+	return fmt.Sprintf(
+		"template variable '%s' is not defined in templates.vars (templates.strict is on)",
+		e.Name,
+	)
+}
+
+// TemplateNotFoundError indicates an include of a template that no
+// template directory provides.
+type TemplateNotFoundError struct {
+	Name string
+	Dirs []string
+}
+
+func (e *TemplateNotFoundError) Error() string {
+	return fmt.Sprintf(
+		"template '%s' not found in %s",
+		e.Name,
+		strings.Join(e.Dirs, ", "),
+	)
+}
@@ -0,0 +1,21 @@
+package specterrs
+
+import (
+	"fmt"
+	"strings"
+)
+
+// UnknownModelError indicates a --model value that is not a tokenizer
+// preset.
+type UnknownModelError struct {
+	Name  string
+	Known []string
+}
+
+func (e *UnknownModelError) Error() string {
+	return fmt.Sprintf(
+		"unknown model %q (known: %s)",
+		e.Name,
+		strings.Join(e.Known, ", "),
+	)
+}
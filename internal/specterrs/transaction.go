@@ -0,0 +1,41 @@
+package specterrs
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TransactionError indicates a step of a multi-file transaction failed.
+// Steps applied before it were rolled back; RollbackErrors lists any
+// undo that failed, in which case the tree may be partially changed.
+type TransactionError struct {
+	Step           string
+	Err            error
+	RollbackErrors []error
+}
+
+func (e *TransactionError) Error() string {
+	if len(e.RollbackErrors) == 0 {
+		return fmt.Sprintf(
+			"%s: %v (all changes rolled back)",
+			e.Step,
+			e.Err,
+		)
+	}
+
+	msgs := make([]string, len(e.RollbackErrors))
+	for i, err := range e.RollbackErrors {
+		msgs[i] = err.Error()
+	}
+
+	return fmt.Sprintf(
+		"%s: %v; rollback incomplete, the tree may be partially changed: %s",
+		e.Step,
+		e.Err,
+		strings.Join(msgs, "; "),
+	)
+}
+
+func (e *TransactionError) Unwrap() error {
+	return e.Err
+}
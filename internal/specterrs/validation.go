@@ -3,25 +3,58 @@ package specterrs
 import "fmt"
 
 // ValidationFailedError indicates validation failed for a single item.
+// WarningsOnly is set when it failed only because of --fail-on=warning.
 type ValidationFailedError struct {
 	ItemCount    int
 	ErrorCount   int
 	WarningCount int
+	WarningsOnly bool
 }
 
-func (*ValidationFailedError) Error() string {
+func (e *ValidationFailedError) Error() string {
+	if e.WarningsOnly {
+		return fmt.Sprintf(
+			"validation found %d warning(s) (--fail-on=warning)",
+			e.WarningCount,
+		)
+	}
+
 	return "validation failed"
 }
 
+// ExitCode implements kong.ExitCoder.
+func (e *ValidationFailedError) ExitCode() int {
+	if e.WarningsOnly {
+		return ExitWarnings
+	}
+
+	return ExitValidation
+}
+
 // MultiValidationFailedError indicates validation failed for multiple items.
+// WarningsOnly is set when they failed only because of --fail-on=warning.
 type MultiValidationFailedError struct {
-	ItemCount int
+	ItemCount    int
+	WarningsOnly bool
 }
 
-func (*MultiValidationFailedError) Error() string {
+func (e *MultiValidationFailedError) Error() string {
+	if e.WarningsOnly {
+		return "validation found warnings in one or more items (--fail-on=warning)"
+	}
+
 	return "validation failed for one or more items"
 }
 
+// ExitCode implements kong.ExitCoder.
+func (e *MultiValidationFailedError) ExitCode() int {
+	if e.WarningsOnly {
+		return ExitWarnings
+	}
+
+	return ExitValidation
+}
+
 // DeltaSpecParseError indicates a delta spec failed to parse.
 type DeltaSpecParseError struct {
 	SpecPath string
@@ -49,3 +82,18 @@ func (e *DeltaSpecParseError) Error() string {
 func (e *DeltaSpecParseError) Unwrap() error {
 	return e.Err
 }
+
+// RequirementNotFoundError indicates a requirement: selector matched no
+// requirement. Spec is empty when every spec was searched.
+type RequirementNotFoundError struct {
+	Spec string
+	Name string
+}
+
+func (e *RequirementNotFoundError) Error() string {
+	if e.Spec == "" {
+		return fmt.Sprintf("requirement %q not found in any spec", e.Name)
+	}
+
+	return fmt.Sprintf("requirement %q not found in spec %s", e.Name, e.Spec)
+}
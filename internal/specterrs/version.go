@@ -0,0 +1,21 @@
+package specterrs
+
+import "fmt"
+
+// IncompatibleVersionError indicates a project whose spectr.yaml requires
+// a newer spectr than the one running.
+type IncompatibleVersionError struct {
+	Path     string
+	Required string
+	Running  string
+}
+
+func (e *IncompatibleVersionError) Error() string {
+	return fmt.Sprintf(
+		"%s requires spectr %s or newer, but this is %s\n"+
+			"Hint: Upgrade spectr, or set SPECTR_IGNORE_VERSION=1 to run anyway",
+		e.Path,
+		e.Required,
+		e.Running,
+	)
+}
@@ -0,0 +1,57 @@
+package specterrs
+
+import "fmt"
+
+// UnsupportedDocumentVersionError indicates a project YAML document
+// written by a newer spectr.
+type UnsupportedDocumentVersionError struct {
+	Path    string
+	Version int
+	Latest  int
+}
+
+func (e *UnsupportedDocumentVersionError) Error() string {
+	return fmt.Sprintf(
+		"%s uses project document version %d, but this spectr supports "+
+			"versions up to %d; upgrade spectr to import it",
+		e.Path,
+		e.Version,
+		e.Latest,
+	)
+}
+
+// InvalidDocumentError indicates a project YAML document that cannot be
+// imported, such as an entry without an ID.
+type InvalidDocumentError struct {
+	Path   string
+	Reason string
+}
+
+func (e *InvalidDocumentError) Error() string {
+	return fmt.Sprintf("cannot import %s: %s", e.Path, e.Reason)
+}
+
+// ImportConflictError indicates an import would overwrite existing files.
+type ImportConflictError struct {
+	Paths []string
+}
+
+func (e *ImportConflictError) Error() string {
+	if len(e.Paths) == 1 {
+		return fmt.Sprintf("import would overwrite %s (use --force to replace it)", e.Paths[0])
+	}
+
+	return fmt.Sprintf(
+		"import would overwrite %d existing files, starting with %s (use --force to replace them)",
+		len(e.Paths),
+		e.Paths[0],
+	)
+}
+
+// NoExportSelectionError indicates a YAML export with neither spec IDs nor
+// --all.
+type NoExportSelectionError struct{}
+
+func (*NoExportSelectionError) Error() string {
+	return "nothing to export: pass spec IDs, or --all for every spec and active change"
+}
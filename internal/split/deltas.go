@@ -0,0 +1,123 @@
+package split
+
+import (
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/markdown"
+	"github.com/connerohnesorge/spectr/internal/parsers"
+)
+
+// requirementPrefix starts a requirement heading in a delta spec.
+const requirementPrefix = "### Requirement:"
+
+// deltaSection is an operation section of a delta spec and the lines
+// under it. The preamble before the first section has an empty header.
+type deltaSection struct {
+	header string
+	lines  []string
+}
+
+// splitRequirements moves the requirement blocks named in names (keys are
+// normalized requirement names) out of delta spec source. Each block runs
+// from its "### Requirement:" heading to the next heading of level 3 or
+// less and moves under a copy of its operation header. It returns the
+// remaining source, the moved blocks, the names it found and whether any
+// requirement or RENAMED entry is left.
+func splitRequirements(
+	source string,
+	names map[string]bool,
+) (kept, moved string, found map[string]bool, rest bool) {
+	found = make(map[string]bool, len(names))
+
+	var keptSections, movedSections []deltaSection
+	var (
+		fence  rune
+		moving bool
+	)
+	section := -1
+	for _, line := range strings.Split(strings.TrimRight(source, "\n"), "\n") {
+		if _, ok := markdown.MatchH2DeltaSection(strings.TrimSpace(line)); ok && fence == 0 {
+			keptSections = append(keptSections, deltaSection{header: line})
+			movedSections = append(movedSections, deltaSection{header: line})
+			section = len(keptSections) - 1
+			moving = false
+
+			continue
+		}
+		if section < 0 {
+			keptSections = append(keptSections, deltaSection{})
+			movedSections = append(movedSections, deltaSection{})
+			section = 0
+		}
+
+		if isFence, delim := markdown.IsCodeFence(line); isFence {
+			switch fence {
+			case 0:
+				fence = delim
+			case delim:
+				fence = 0
+			}
+		} else if fence == 0 {
+			switch {
+			case strings.HasPrefix(line, requirementPrefix):
+				name := parsers.NormalizeRequirementName(
+					strings.TrimSpace(strings.TrimPrefix(line, requirementPrefix)),
+				)
+				moving = names[name]
+				if moving {
+					found[name] = true
+				} else {
+					rest = true
+				}
+			case strings.HasPrefix(line, "## "), strings.HasPrefix(line, "### "):
+				moving = false
+			}
+		}
+
+		if moving {
+			movedSections[section].lines = append(movedSections[section].lines, line)
+		} else {
+			keptSections[section].lines = append(keptSections[section].lines, line)
+		}
+	}
+
+	// RENAMED entries are bullets, not requirement headings, and stay put
+	for _, s := range keptSections {
+		if strings.Contains(s.header, "RENAMED") &&
+			strings.TrimSpace(strings.Join(s.lines, "")) != "" {
+			rest = true
+		}
+	}
+
+	return renderDeltaSections(keptSections), renderDeltaSections(movedSections), found, rest
+}
+
+// renderDeltaSections joins sections back into a delta spec, dropping
+// operation sections left without content.
+func renderDeltaSections(sections []deltaSection) string {
+	var parts []string
+	for _, section := range sections {
+		body := strings.Trim(strings.Join(section.lines, "\n"), "\n")
+		if strings.TrimSpace(body) == "" {
+			continue
+		}
+		if section.header != "" {
+			body = section.header + "\n\n" + body
+		}
+		parts = append(parts, body)
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+
+	return strings.Join(parts, "\n\n") + "\n"
+}
+
+// parseRequirementRef splits a "spec#Requirement Name" reference.
+func parseRequirementRef(ref string) (spec, name string, ok bool) {
+	spec, name, ok = strings.Cut(ref, "#")
+	spec = strings.Trim(strings.TrimSpace(spec), "/")
+	name = strings.TrimSpace(name)
+
+	return spec, name, ok && spec != "" && name != ""
+}
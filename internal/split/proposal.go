@@ -0,0 +1,126 @@
+package split
+
+import (
+	"fmt"
+	"strings"
+)
+
+// whatChangesHeading is the proposal.md section the split summaries are
+// added to.
+const whatChangesHeading = "## What Changes"
+
+// describe lists the items of a split for a proposal summary, e.g.
+// "delta spec `billing`; requirement `auth#Refunds`; tasks 2.1, 2.2".
+func describe(res *Result) string {
+	var parts []string
+	if len(res.Specs) > 0 {
+		parts = append(parts, plural("delta spec", len(res.Specs))+" "+quoteAll(res.Specs))
+	}
+	if len(res.Requirements) > 0 {
+		parts = append(parts,
+			plural("requirement", len(res.Requirements))+" "+quoteAll(res.Requirements))
+	}
+	if len(res.Tasks) > 0 {
+		parts = append(parts, plural("task", len(res.Tasks))+" "+strings.Join(res.Tasks, ", "))
+	}
+
+	return strings.Join(parts, "; ")
+}
+
+// stubProposal returns the proposal.md of the change split out by res.
+func stubProposal(res *Result, specs []string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "---\nsplit_from: %s\n---\n\n", res.ChangeID)
+	fmt.Fprintf(&b, "# Change: %s\n\n", title(res.NewID))
+	b.WriteString("## Why\n\n<!-- Why this part ships separately -->\n\n")
+	b.WriteString(whatChangesHeading + "\n\n")
+	fmt.Fprintf(&b, "- Split from `%s`: %s", res.ChangeID, describe(res))
+	if len(res.Tasks) > 0 {
+		b.WriteString(" (task IDs unchanged)")
+	}
+	b.WriteString("\n\n## Impact\n\n")
+	if len(specs) == 0 {
+		b.WriteString("- Affected specs: none yet\n")
+
+		return b.String()
+	}
+	fmt.Fprintf(&b, "- Affected specs: %s\n", quoteAll(specs))
+
+	return b.String()
+}
+
+// sourceSummary returns the source change's proposal.md with a bullet
+// naming what moved to the new change added to the end of its What
+// Changes section, which is appended when missing.
+func sourceSummary(proposal string, res *Result) string {
+	bullet := fmt.Sprintf("- Split out to `%s`: %s", res.NewID, describe(res))
+
+	lines := strings.Split(strings.TrimRight(proposal, "\n"), "\n")
+	start := -1
+	for i, line := range lines {
+		if strings.TrimSpace(line) == whatChangesHeading {
+			start = i
+
+			break
+		}
+	}
+	if start < 0 {
+		return strings.Join(lines, "\n") + "\n\n" + whatChangesHeading + "\n\n" + bullet + "\n"
+	}
+
+	end := len(lines)
+	for i := start + 1; i < len(lines); i++ {
+		if strings.HasPrefix(lines[i], "# ") || strings.HasPrefix(lines[i], "## ") {
+			end = i
+
+			break
+		}
+	}
+	last := end - 1
+	for last > start && strings.TrimSpace(lines[last]) == "" {
+		last--
+	}
+
+	out := append([]string{}, lines[:last+1]...)
+	if last == start {
+		out = append(out, "")
+	}
+	out = append(out, bullet)
+	if end < len(lines) {
+		out = append(out, "")
+		out = append(out, lines[end:]...)
+	}
+
+	return strings.Join(out, "\n") + "\n"
+}
+
+// quoteAll formats items as a comma-separated list of code spans.
+func quoteAll(items []string) string {
+	quoted := make([]string, len(items))
+	for i, item := range items {
+		quoted[i] = "`" + item + "`"
+	}
+
+	return strings.Join(quoted, ", ")
+}
+
+// plural appends "s" to word unless n is 1.
+func plural(word string, n int) string {
+	if n == 1 {
+		return word
+	}
+
+	return word + "s"
+}
+
+// title turns a kebab-case change ID into words: "add-2fa" -> "Add 2fa".
+func title(id string) string {
+	words := strings.Split(id, "-")
+	for i, word := range words {
+		if word != "" {
+			words[i] = strings.ToUpper(word[:1]) + word[1:]
+		}
+	}
+
+	return strings.Join(words, " ")
+}
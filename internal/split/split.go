@@ -0,0 +1,310 @@
+// Package split moves part of a change into a new change, for proposals
+// that grew past what one review can cover.
+//
+// Selected delta specs (spectr/changes/<id>/specs/<spec-id>/) move as whole
+// directories; selected requirements move out of their delta spec under
+// the same ADDED/MODIFIED/REMOVED header. Selected tasks move out of
+// tasks.md together with their section headers and keep their IDs; the
+// mapping from old to new task is returned and recorded in the audit log.
+// The new change gets a stub proposal.md naming its origin, and the source
+// proposal's What Changes section notes what left. The moves and writes
+// are applied as one transaction; the audit entry is recorded last.
+package split
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/audit"
+	"github.com/connerohnesorge/spectr/internal/fileio"
+	"github.com/connerohnesorge/spectr/internal/parsers"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+	"github.com/connerohnesorge/spectr/internal/txn"
+)
+
+// filePerm is the permission of written files.
+const filePerm = 0o644
+
+// Options select what moves to the new change.
+type Options struct {
+	// Specs are the delta spec IDs to move whole, e.g. "auth".
+	Specs []string
+	// Requirements are single requirement deltas to move, as
+	// "spec#Requirement Name".
+	Requirements []string
+	// Tasks are the tasks.md task numbers to move, e.g. "2.1".
+	Tasks []string
+}
+
+// Result describes a completed split.
+type Result struct {
+	ChangeID     string   `json:"changeId"`
+	NewID        string   `json:"newId"`
+	Specs        []string `json:"specs"`
+	Requirements []string `json:"requirements"`
+	Tasks        []string `json:"tasks"`
+	// TaskMap maps each moved task, as <change>#<id>, to its ID in the
+	// new change
+	TaskMap map[string]string `json:"taskMap,omitempty"`
+	Path    string            `json:"path"` // relative to the project root
+}
+
+// Split moves the delta specs, requirements and tasks selected by opts
+// from change changeID into a new change newID in the project at
+// projectRoot.
+func Split(
+	ctx context.Context,
+	projectRoot, changeID, newID string,
+	opts Options,
+) (*Result, error) {
+	changesDir := filepath.Join(projectRoot, "spectr", "changes")
+	changeDir := filepath.Join(changesDir, changeID)
+	newDir := filepath.Join(changesDir, newID)
+	if info, err := os.Stat(changeDir); err != nil || !info.IsDir() {
+		return nil, fmt.Errorf("change '%s' not found", changeID)
+	}
+	if newID == "" || newID == changeID || strings.ContainsAny(newID, `/\`) ||
+		strings.HasPrefix(newID, ".") {
+		return nil, fmt.Errorf("invalid change ID '%s'", newID)
+	}
+	if _, err := os.Stat(newDir); err == nil {
+		return nil, &specterrs.ChangeExistsError{ChangeID: newID}
+	}
+	if len(opts.Specs) == 0 && len(opts.Requirements) == 0 && len(opts.Tasks) == 0 {
+		return nil, &specterrs.EmptySplitError{ChangeID: changeID}
+	}
+
+	deltaSpecs, err := DeltaSpecs(changeDir)
+	if err != nil {
+		return nil, err
+	}
+	res := &Result{
+		ChangeID: changeID,
+		NewID:    newID,
+		Path:     "spectr/changes/" + newID + "/",
+	}
+	for _, spec := range opts.Specs {
+		spec = strings.Trim(filepath.ToSlash(spec), "/")
+		if !slices.Contains(deltaSpecs, spec) {
+			return nil, &specterrs.SplitItemNotFoundError{
+				ChangeID: changeID, Kind: "delta spec", Item: spec,
+			}
+		}
+		if !slices.Contains(res.Specs, spec) {
+			res.Specs = append(res.Specs, spec)
+		}
+	}
+
+	tx := txn.New()
+	partial, err := splitDeltaFiles(tx, res, changeDir, newDir, deltaSpecs, opts.Requirements)
+	if err != nil {
+		return nil, err
+	}
+	if len(deltaSpecs) > 0 && len(res.Specs) == len(deltaSpecs) {
+		return nil, &specterrs.SplitLeavesNoDeltasError{ChangeID: changeID}
+	}
+	for _, spec := range res.Specs {
+		tx.Move(
+			filepath.Join(changeDir, "specs", filepath.FromSlash(spec)),
+			filepath.Join(newDir, "specs", filepath.FromSlash(spec)),
+		)
+	}
+
+	if len(opts.Tasks) > 0 {
+		if err := splitTaskFiles(tx, changeID, changeDir, newDir, opts.Tasks); err != nil {
+			return nil, err
+		}
+		res.Tasks = opts.Tasks
+		res.TaskMap = make(map[string]string, len(opts.Tasks))
+		for _, id := range opts.Tasks {
+			res.TaskMap[changeID+"#"+normalizeTaskID(id)] = normalizeTaskID(id)
+		}
+	}
+
+	proposalPath := filepath.Join(changeDir, "proposal.md")
+	proposal, err := fileio.ReadFile(proposalPath)
+	switch {
+	case err == nil:
+		tx.WriteFile(proposalPath, []byte(sourceSummary(string(proposal), res)), filePerm)
+	case !errors.Is(err, fs.ErrNotExist):
+		return nil, fmt.Errorf("failed to read %s: %w", proposalPath, err)
+	}
+	affected := append(slices.Clone(res.Specs), partial...)
+	slices.Sort(affected)
+	tx.WriteFile(
+		filepath.Join(newDir, "proposal.md"),
+		[]byte(stubProposal(res, slices.Compact(affected))),
+		filePerm,
+	)
+
+	details := map[string]string{}
+	if len(res.Specs) > 0 {
+		details["specs"] = strings.Join(res.Specs, ",")
+	}
+	if len(res.Requirements) > 0 {
+		details["requirements"] = strings.Join(res.Requirements, ",")
+	}
+	if len(res.TaskMap) > 0 {
+		var pairs []string
+		for _, from := range slices.Sorted(maps.Keys(res.TaskMap)) {
+			pairs = append(pairs, from+"="+newID+"#"+res.TaskMap[from])
+		}
+		details["task_map"] = strings.Join(pairs, ",")
+	}
+	audit.RecordLast(
+		tx,
+		filepath.Join(projectRoot, "spectr"),
+		audit.OpSplit,
+		[]string{"changes/" + changeID, "changes/" + newID},
+		details,
+	)
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("apply split: %w", err)
+	}
+
+	return res, nil
+}
+
+// splitDeltaFiles registers the delta spec rewrites that move the
+// requirements refs from changeDir to newDir and records them in res. A
+// spec left with no requirement is moved whole instead, by adding it to
+// res.Specs. It returns the specs that were split between both changes.
+func splitDeltaFiles(
+	tx *txn.Tx,
+	res *Result,
+	changeDir, newDir string,
+	deltaSpecs, refs []string,
+) ([]string, error) {
+	bySpec := make(map[string]map[string]bool)
+	for _, ref := range refs {
+		spec, name, ok := parseRequirementRef(ref)
+		if !ok || !slices.Contains(deltaSpecs, spec) {
+			return nil, &specterrs.SplitItemNotFoundError{
+				ChangeID: res.ChangeID, Kind: "requirement", Item: ref,
+			}
+		}
+		if slices.Contains(res.Specs, spec) {
+			continue // Moves with its whole spec
+		}
+		if bySpec[spec] == nil {
+			bySpec[spec] = make(map[string]bool)
+		}
+		bySpec[spec][parsers.NormalizeRequirementName(name)] = true
+		res.Requirements = append(res.Requirements, spec+"#"+name)
+	}
+
+	var partial []string
+	for _, spec := range slices.Sorted(maps.Keys(bySpec)) {
+		rel := filepath.Join("specs", filepath.FromSlash(spec), "spec.md")
+		source, err := fileio.ReadFile(filepath.Join(changeDir, rel))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read delta spec %s: %w", spec, err)
+		}
+
+		kept, moved, found, rest := splitRequirements(string(source), bySpec[spec])
+		for _, ref := range res.Requirements {
+			refSpec, name, _ := parseRequirementRef(ref)
+			if refSpec == spec && !found[parsers.NormalizeRequirementName(name)] {
+				return nil, &specterrs.SplitItemNotFoundError{
+					ChangeID: res.ChangeID, Kind: "requirement", Item: ref,
+				}
+			}
+		}
+		if !rest {
+			res.Specs = append(res.Specs, spec)
+			res.Requirements = slices.DeleteFunc(res.Requirements, func(ref string) bool {
+				refSpec, _, _ := parseRequirementRef(ref)
+
+				return refSpec == spec
+			})
+
+			continue
+		}
+		tx.WriteFile(filepath.Join(changeDir, rel), []byte(kept), filePerm)
+		tx.WriteFile(filepath.Join(newDir, rel), []byte(moved), filePerm)
+		partial = append(partial, spec)
+	}
+
+	return partial, nil
+}
+
+// splitTaskFiles registers the tasks.md rewrites that move tasks ids
+// from changeDir to newDir.
+func splitTaskFiles(
+	tx *txn.Tx,
+	changeID, changeDir, newDir string,
+	ids []string,
+) error {
+	if fileExists(filepath.Join(changeDir, "tasks.jsonc")) {
+		return &specterrs.SplitTasksAcceptedError{ChangeID: changeID}
+	}
+
+	tasksPath := filepath.Join(changeDir, "tasks.md")
+	source, err := fileio.ReadFile(tasksPath)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to read %s: %w", tasksPath, err)
+	}
+
+	kept, moved, missing := splitTasks(string(source), ids)
+	if len(missing) > 0 {
+		return &specterrs.SplitItemNotFoundError{
+			ChangeID: changeID, Kind: "task", Item: missing[0],
+		}
+	}
+	if !hasTask(strings.Split(kept, "\n")) {
+		// Every task moves: move the file rather than leave an empty one
+		tx.Move(tasksPath, filepath.Join(newDir, "tasks.md"))
+
+		return nil
+	}
+	tx.WriteFile(tasksPath, []byte(kept), filePerm)
+	tx.WriteFile(filepath.Join(newDir, "tasks.md"), []byte(moved), filePerm)
+
+	return nil
+}
+
+// DeltaSpecs returns the IDs of the delta specs in changeDir, sorted.
+func DeltaSpecs(changeDir string) ([]string, error) {
+	specsDir := filepath.Join(changeDir, "specs")
+	var specs []string
+	err := filepath.WalkDir(specsDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+
+			return err
+		}
+		if d.IsDir() || d.Name() != "spec.md" {
+			return nil
+		}
+		rel, err := filepath.Rel(specsDir, filepath.Dir(path))
+		if err != nil {
+			return err
+		}
+		specs = append(specs, filepath.ToSlash(rel))
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list delta specs: %w", err)
+	}
+	slices.Sort(specs)
+
+	return specs, nil
+}
+
+// fileExists reports whether path is an existing regular file.
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+
+	return err == nil && !info.IsDir()
+}
@@ -0,0 +1,296 @@
+package split
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/connerohnesorge/spectr/internal/audit"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+	"github.com/connerohnesorge/spectr/internal/testutil"
+)
+
+const bigTasks = `## 1. Auth
+
+- [ ] 1.1 Add login
+  form and session handling
+- [ ] 1.2 Add logout
+
+## 2. Billing
+
+- [ ] 2.1 Add invoices
+  - [ ] Render PDF
+- [x] 2.2 Add refunds
+`
+
+// setupProject creates a project whose big-change touches auth and
+// billing.
+func setupProject(t *testing.T) string {
+	t.Helper()
+
+	root := t.TempDir()
+	change := filepath.Join(root, "spectr", "changes", "big-change")
+	testutil.WriteFile(t, filepath.Join(change, "proposal.md"),
+		"# Change: Big\n\n## What Changes\n\n- Auth and billing\n\n## Impact\n\n- Lots\n")
+	testutil.WriteFile(t, filepath.Join(change, "tasks.md"), bigTasks)
+	for _, spec := range []string{"auth", "billing"} {
+		testutil.WriteFile(t, filepath.Join(change, "specs", spec, "spec.md"),
+			"## ADDED Requirements\n\n### Requirement: "+spec+"\n")
+	}
+
+	return root
+}
+
+func TestSplit(t *testing.T) {
+	root := setupProject(t)
+	changes := filepath.Join(root, "spectr", "changes")
+
+	res, err := Split(context.Background(), root, "big-change", "add-billing", Options{
+		Specs: []string{"billing"},
+		Tasks: []string{"2.1", "2.2"},
+	})
+	if err != nil {
+		t.Fatalf("Split() error = %v", err)
+	}
+	if res.Path != "spectr/changes/add-billing/" || !slices.Equal(res.Specs, []string{"billing"}) {
+		t.Errorf("result = %+v", res)
+	}
+
+	if _, err := os.Stat(filepath.Join(changes, "add-billing", "specs", "billing", "spec.md")); err != nil {
+		t.Errorf("moved delta spec missing: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(changes, "big-change", "specs", "billing")); !os.IsNotExist(err) {
+		t.Errorf("delta spec still in source: %v", err)
+	}
+
+	wantKept := "## 1. Auth\n\n- [ ] 1.1 Add login\n  form and session handling\n- [ ] 1.2 Add logout\n"
+	if got := testutil.ReadFile(t, filepath.Join(changes, "big-change", "tasks.md")); got != wantKept {
+		t.Errorf("source tasks.md =\n%s\nwant\n%s", got, wantKept)
+	}
+	wantMoved := "## 2. Billing\n\n- [ ] 2.1 Add invoices\n  - [ ] Render PDF\n- [x] 2.2 Add refunds\n"
+	if got := testutil.ReadFile(t, filepath.Join(changes, "add-billing", "tasks.md")); got != wantMoved {
+		t.Errorf("new tasks.md =\n%s\nwant\n%s", got, wantMoved)
+	}
+
+	proposal := testutil.ReadFile(t, filepath.Join(changes, "add-billing", "proposal.md"))
+	for _, want := range []string{
+		"split_from: big-change",
+		"# Change: Add Billing",
+		"- Split from `big-change`: delta spec `billing`; tasks 2.1, 2.2 (task IDs unchanged)",
+		"Affected specs: `billing`",
+	} {
+		if !strings.Contains(proposal, want) {
+			t.Errorf("proposal missing %q:\n%s", want, proposal)
+		}
+	}
+	wantSource := "# Change: Big\n\n## What Changes\n\n- Auth and billing\n" +
+		"- Split out to `add-billing`: delta spec `billing`; tasks 2.1, 2.2\n\n## Impact\n\n- Lots\n"
+	if got := testutil.ReadFile(t, filepath.Join(changes, "big-change", "proposal.md")); got != wantSource {
+		t.Errorf("source proposal =\n%s\nwant\n%s", got, wantSource)
+	}
+	if res.TaskMap["big-change#2.1"] != "2.1" || len(res.TaskMap) != 2 {
+		t.Errorf("task map = %v", res.TaskMap)
+	}
+
+	entries, err := audit.Read(filepath.Join(root, "spectr", audit.LogFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Operation != audit.OpSplit ||
+		entries[0].Details["task_map"] != "big-change#2.1=add-billing#2.1,big-change#2.2=add-billing#2.2" {
+		t.Errorf("audit entries = %+v, want one split with the task map", entries)
+	}
+}
+
+func TestSplit_AllTasks(t *testing.T) {
+	root := setupProject(t)
+	changes := filepath.Join(root, "spectr", "changes")
+
+	_, err := Split(context.Background(), root, "big-change", "all-tasks", Options{
+		Tasks: []string{"1.1", "1.2", "2.1", "2.2"},
+	})
+	if err != nil {
+		t.Fatalf("Split() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(changes, "big-change", "tasks.md")); !os.IsNotExist(err) {
+		t.Errorf("empty tasks.md left behind: %v", err)
+	}
+	if got := testutil.ReadFile(t, filepath.Join(changes, "all-tasks", "tasks.md")); got != bigTasks {
+		t.Errorf("moved tasks.md =\n%s", got)
+	}
+}
+
+func TestSplit_Requirements(t *testing.T) {
+	root := setupProject(t)
+	changes := filepath.Join(root, "spectr", "changes")
+	auth := filepath.Join(changes, "big-change", "specs", "auth", "spec.md")
+	testutil.WriteFile(t, auth, `## ADDED Requirements
+
+### Requirement: Login
+The system SHALL log users in.
+
+#### Scenario: Valid password
+- **WHEN** the password matches
+- **THEN** a session starts
+
+### Requirement: Lockout
+The system SHALL lock accounts.
+
+## REMOVED Requirements
+
+### Requirement: Guest Access
+**Reason**: Unused
+`)
+
+	res, err := Split(context.Background(), root, "big-change", "add-lockout", Options{
+		Requirements: []string{"auth#Lockout", "auth#guest access", "billing#billing"},
+	})
+	if err != nil {
+		t.Fatalf("Split() error = %v", err)
+	}
+
+	// billing's only requirement moved, so the whole spec moved
+	if !slices.Equal(res.Specs, []string{"billing"}) ||
+		!slices.Equal(res.Requirements, []string{"auth#Lockout", "auth#guest access"}) {
+		t.Errorf("result = %+v", res)
+	}
+	if _, err := os.Stat(filepath.Join(changes, "add-lockout", "specs", "billing", "spec.md")); err != nil {
+		t.Errorf("billing not moved: %v", err)
+	}
+
+	wantKept := "## ADDED Requirements\n\n### Requirement: Login\nThe system SHALL log users in.\n\n" +
+		"#### Scenario: Valid password\n- **WHEN** the password matches\n- **THEN** a session starts\n"
+	if got := testutil.ReadFile(t, auth); got != wantKept {
+		t.Errorf("kept delta =\n%s\nwant\n%s", got, wantKept)
+	}
+	wantMoved := "## ADDED Requirements\n\n### Requirement: Lockout\nThe system SHALL lock accounts.\n\n" +
+		"## REMOVED Requirements\n\n### Requirement: Guest Access\n**Reason**: Unused\n"
+	if got := testutil.ReadFile(t, filepath.Join(changes, "add-lockout", "specs", "auth", "spec.md")); got != wantMoved {
+		t.Errorf("moved delta =\n%s\nwant\n%s", got, wantMoved)
+	}
+
+	proposal := testutil.ReadFile(t, filepath.Join(changes, "add-lockout", "proposal.md"))
+	if !strings.Contains(proposal, "Affected specs: `auth`, `billing`") {
+		t.Errorf("proposal impact:\n%s", proposal)
+	}
+}
+
+func TestSplit_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		newID string
+		opts  Options
+		setup func(t *testing.T, change string)
+		check func(err error) bool
+	}{
+		{
+			name:  "nothing selected",
+			newID: "other",
+			check: func(err error) bool {
+				var target *specterrs.EmptySplitError
+
+				return errors.As(err, &target)
+			},
+		},
+		{
+			name:  "unknown spec",
+			newID: "other",
+			opts:  Options{Specs: []string{"search"}},
+			check: func(err error) bool {
+				var target *specterrs.SplitItemNotFoundError
+
+				return errors.As(err, &target) && target.Kind == "delta spec"
+			},
+		},
+		{
+			name:  "unknown task",
+			newID: "other",
+			opts:  Options{Tasks: []string{"3.1"}},
+			check: func(err error) bool {
+				var target *specterrs.SplitItemNotFoundError
+
+				return errors.As(err, &target) && target.Item == "3.1"
+			},
+		},
+		{
+			name:  "unknown requirement",
+			newID: "other",
+			opts:  Options{Requirements: []string{"auth#Logout"}},
+			check: func(err error) bool {
+				var target *specterrs.SplitItemNotFoundError
+
+				return errors.As(err, &target) && target.Kind == "requirement"
+			},
+		},
+		{
+			name:  "every requirement",
+			newID: "other",
+			opts:  Options{Specs: []string{"auth"}, Requirements: []string{"billing#billing"}},
+			check: func(err error) bool {
+				var target *specterrs.SplitLeavesNoDeltasError
+
+				return errors.As(err, &target)
+			},
+		},
+		{
+			name:  "all specs",
+			newID: "other",
+			opts:  Options{Specs: []string{"auth", "billing"}},
+			check: func(err error) bool {
+				var target *specterrs.SplitLeavesNoDeltasError
+
+				return errors.As(err, &target)
+			},
+		},
+		{
+			name:  "accepted tasks",
+			newID: "other",
+			opts:  Options{Tasks: []string{"1.1"}},
+			setup: func(t *testing.T, change string) {
+				t.Helper()
+				testutil.WriteFile(t, filepath.Join(change, "tasks.jsonc"), "{}")
+			},
+			check: func(err error) bool {
+				var target *specterrs.SplitTasksAcceptedError
+
+				return errors.As(err, &target)
+			},
+		},
+		{
+			name:  "target exists",
+			newID: "existing",
+			opts:  Options{Specs: []string{"auth"}},
+			setup: func(t *testing.T, change string) {
+				t.Helper()
+				testutil.WriteFile(t, filepath.Join(filepath.Dir(change), "existing", "proposal.md"), "")
+			},
+			check: func(err error) bool {
+				var target *specterrs.ChangeExistsError
+
+				return errors.As(err, &target)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := setupProject(t)
+			change := filepath.Join(root, "spectr", "changes", "big-change")
+			if tt.setup != nil {
+				tt.setup(t, change)
+			}
+
+			_, err := Split(context.Background(), root, "big-change", tt.newID, tt.opts)
+			if err == nil || !tt.check(err) {
+				t.Fatalf("Split() error = %v", err)
+			}
+			if got := testutil.ReadFile(t, filepath.Join(change, "tasks.md")); got != bigTasks {
+				t.Errorf("tasks.md changed on error:\n%s", got)
+			}
+		})
+	}
+}
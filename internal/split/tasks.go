@@ -0,0 +1,127 @@
+package split
+
+import (
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/markdown"
+)
+
+// taskSection is a "## N. Name" section of tasks.md and the lines under
+// it. The preamble before the first section has an empty header.
+type taskSection struct {
+	header string
+	lines  []string
+}
+
+// splitTasks moves the tasks numbered ids out of tasks.md source. A task
+// takes along the more-indented lines under it (wrapped text and
+// subtasks). It returns the remaining source, the moved tasks under copies
+// of their section headers, and the ids it did not find. Sections left
+// without tasks are dropped from the remaining source.
+func splitTasks(source string, ids []string) (kept, moved string, missing []string) {
+	want := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		want[normalizeTaskID(id)] = true
+	}
+	found := make(map[string]bool, len(ids))
+
+	var keptSections, movedSections []taskSection
+	lines := strings.Split(strings.TrimRight(source, "\n"), "\n")
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		if _, _, ok := markdown.MatchAnySection(line); ok {
+			keptSections = append(keptSections, taskSection{header: line})
+			movedSections = append(movedSections, taskSection{header: line})
+			i++
+
+			continue
+		}
+		if len(keptSections) == 0 {
+			keptSections = append(keptSections, taskSection{})
+			movedSections = append(movedSections, taskSection{})
+		}
+		cur := len(keptSections) - 1
+
+		match, ok := markdown.MatchFlexibleTask(line)
+		if !ok || match.Number == "" || !want[normalizeTaskID(match.Number)] {
+			keptSections[cur].lines = append(keptSections[cur].lines, line)
+			i++
+
+			continue
+		}
+
+		end := taskEnd(lines, i)
+		found[normalizeTaskID(match.Number)] = true
+		movedSections[cur].lines = append(movedSections[cur].lines, lines[i:end]...)
+		i = end
+	}
+
+	for _, id := range ids {
+		if !found[normalizeTaskID(id)] {
+			missing = append(missing, id)
+		}
+	}
+
+	return renderSections(keptSections), renderSections(movedSections), missing
+}
+
+// taskEnd returns the index of the first line after the task at lines[i]
+// that is blank or not indented deeper than it.
+func taskEnd(lines []string, i int) int {
+	indent := indentOf(lines[i])
+	end := i + 1
+	for end < len(lines) &&
+		strings.TrimSpace(lines[end]) != "" &&
+		indentOf(lines[end]) > indent {
+		end++
+	}
+
+	return end
+}
+
+// renderSections joins sections back into tasks.md text, dropping
+// sections that hold no task.
+func renderSections(sections []taskSection) string {
+	var parts []string
+	for _, section := range sections {
+		body := strings.Trim(strings.Join(section.lines, "\n"), "\n")
+		if section.header != "" && !hasTask(section.lines) {
+			continue
+		}
+		if section.header == "" && strings.TrimSpace(body) == "" {
+			continue
+		}
+
+		part := body
+		if section.header != "" {
+			part = section.header + "\n\n" + body
+		}
+		parts = append(parts, part)
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+
+	return strings.Join(parts, "\n\n") + "\n"
+}
+
+// hasTask reports whether any of lines is a task checkbox.
+func hasTask(lines []string) bool {
+	for _, line := range lines {
+		if _, ok := markdown.MatchFlexibleTask(line); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// normalizeTaskID drops the trailing dot of "1." style numbers.
+func normalizeTaskID(id string) string {
+	return strings.TrimSuffix(strings.TrimSpace(id), ".")
+}
+
+// indentOf returns the width of line's leading whitespace.
+func indentOf(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " \t"))
+}
@@ -0,0 +1,8 @@
+// Package stale finds changes that have seen no activity for a number of
+// days and sends reminders about them.
+//
+// A change's activity is the time of the last git commit touching its
+// directory. When the directory has uncommitted edits, or is not in a git
+// repository, the newest file modification time is used instead, so work
+// in progress counts and projects without git still work.
+package stale
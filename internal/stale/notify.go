@@ -0,0 +1,65 @@
+package stale
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/hostapi"
+)
+
+// Reminder is the JSON body posted to a webhook. Text makes it usable as a
+// Slack or Mattermost incoming webhook message; Changes carries the
+// details for other receivers.
+type Reminder struct {
+	Text    string   `json:"text"`
+	Days    int      `json:"days"`
+	Changes []Change `json:"changes"`
+}
+
+// NewReminder builds the reminder for changes idle at least days.
+func NewReminder(changes []Change, days int) Reminder {
+	var sb strings.Builder
+	fmt.Fprintf(
+		&sb,
+		"%d spectr change(s) with no activity for %d+ days:",
+		len(changes),
+		days,
+	)
+	for _, change := range changes {
+		fmt.Fprintf(
+			&sb,
+			"\n• %s (%s, %d/%d tasks)",
+			change.ID,
+			idleText(change),
+			change.Completed,
+			change.Total,
+		)
+	}
+
+	return Reminder{Text: sb.String(), Days: days, Changes: changes}
+}
+
+// Notify posts reminder to the webhook at url.
+func Notify(
+	ctx context.Context,
+	client *hostapi.Client,
+	url string,
+	reminder Reminder,
+) error {
+	if err := client.DoJSON(ctx, http.MethodPost, url, reminder, nil); err != nil {
+		return fmt.Errorf("post stale reminder: %w", err)
+	}
+
+	return nil
+}
+
+// idleText describes how long a change has been idle.
+func idleText(change Change) string {
+	if change.LastActivity.IsZero() {
+		return "no activity recorded"
+	}
+
+	return fmt.Sprintf("idle %dd", change.IdleDays)
+}
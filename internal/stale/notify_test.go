@@ -0,0 +1,78 @@
+package stale
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/connerohnesorge/spectr/internal/hostapi"
+)
+
+func TestNotify(t *testing.T) {
+	var got Reminder
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost {
+				t.Errorf("method = %s, want POST", r.Method)
+			}
+			if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+				t.Errorf("decode body: %v", err)
+			}
+			w.WriteHeader(http.StatusOK)
+		},
+	))
+	defer server.Close()
+
+	changes := []Change{
+		{
+			ID:           "add-2fa",
+			LastActivity: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			IdleDays:     45,
+			Completed:    2,
+			Total:        5,
+		},
+		{ID: "draft"},
+	}
+	reminder := NewReminder(changes, 30)
+
+	err := Notify(context.Background(), hostapi.NewClient(), server.URL, reminder)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		"2 spectr change(s) with no activity for 30+ days",
+		"add-2fa (idle 45d, 2/5 tasks)",
+		"draft (no activity recorded, 0/0 tasks)",
+	} {
+		if !strings.Contains(got.Text, want) {
+			t.Errorf("text %q does not contain %q", got.Text, want)
+		}
+	}
+	if got.Days != 30 || len(got.Changes) != 2 {
+		t.Errorf("got days %d and %d changes", got.Days, len(got.Changes))
+	}
+}
+
+func TestNotify_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		},
+	))
+	defer server.Close()
+
+	err := Notify(
+		context.Background(),
+		hostapi.NewClient(),
+		server.URL,
+		NewReminder(nil, 30),
+	)
+	if err == nil || !strings.Contains(err.Error(), "post stale reminder") {
+		t.Fatalf("expected post error, got %v", err)
+	}
+}
@@ -0,0 +1,136 @@
+package stale
+
+import (
+	"context"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/connerohnesorge/spectr/internal/git"
+	"github.com/connerohnesorge/spectr/internal/list"
+)
+
+// DefaultDays is the idle period after which a change is stale.
+const DefaultDays = 30
+
+// hoursPerDay converts day thresholds to durations.
+const hoursPerDay = 24
+
+// Activity sources reported in Change.Source.
+const (
+	SourceCommit = "commit"
+	SourceFiles  = "files"
+)
+
+// Change is a change that has been idle for at least the threshold.
+type Change struct {
+	ID           string    `json:"id"`
+	Title        string    `json:"title"`
+	RootPath     string    `json:"rootPath,omitempty"`
+	LastActivity time.Time `json:"lastActivity,omitzero"`
+	// Source is "commit" or "files", naming where LastActivity came from
+	Source string `json:"source"`
+	// IdleDays is the number of whole days since LastActivity
+	IdleDays  int `json:"idleDays"`
+	Completed int `json:"tasksCompleted"`
+	Total     int `json:"tasksTotal"`
+}
+
+// Threshold converts a number of days to a duration.
+func Threshold(days int) time.Duration {
+	return time.Duration(days) * hoursPerDay * time.Hour
+}
+
+// Activity returns when change last saw activity and where that time came
+// from. See the package documentation for the rules.
+func Activity(ctx context.Context, change list.ChangeInfo) (time.Time, string) {
+	dir := filepath.Join(change.RootAbsPath, "spectr", "changes", change.ID)
+	if committed, ok := lastCommit(ctx, dir); ok && !dirty(ctx, dir) {
+		return committed, SourceCommit
+	}
+
+	return change.LastActivity, SourceFiles
+}
+
+// Find returns the changes idle for at least threshold as of now, the
+// longest idle first. Changes with no known activity are treated as stale.
+func Find(
+	ctx context.Context,
+	changes []list.ChangeInfo,
+	threshold time.Duration,
+	now time.Time,
+) []Change {
+	var result []Change
+	for _, change := range changes {
+		last, source := Activity(ctx, change)
+		idle := now.Sub(last)
+		if !last.IsZero() && idle < threshold {
+			continue
+		}
+
+		idleDays := 0
+		if !last.IsZero() {
+			idleDays = int(idle.Hours()) / hoursPerDay
+		}
+		result = append(result, Change{
+			ID:           change.ID,
+			Title:        change.Title,
+			RootPath:     change.RootPath,
+			LastActivity: last,
+			Source:       source,
+			IdleDays:     idleDays,
+			Completed:    change.TaskStatus.Completed,
+			Total:        change.TaskStatus.Total,
+		})
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		a, b := result[i].LastActivity, result[j].LastActivity
+		if !a.Equal(b) {
+			return a.Before(b)
+		}
+
+		return result[i].ID < result[j].ID
+	})
+
+	return result
+}
+
+// Mark sets Stale on every change idle for at least threshold.
+func Mark(
+	ctx context.Context,
+	changes []list.ChangeInfo,
+	threshold time.Duration,
+	now time.Time,
+) {
+	stale := make(map[string]bool)
+	for _, change := range Find(ctx, changes, threshold, now) {
+		stale[change.RootPath+"\x00"+change.ID] = true
+	}
+	for i := range changes {
+		changes[i].Stale = stale[changes[i].RootPath+"\x00"+changes[i].ID]
+	}
+}
+
+// lastCommit returns the time of the newest commit touching dir.
+func lastCommit(ctx context.Context, dir string) (time.Time, bool) {
+	out, err := git.Run(ctx, dir, "log", "-1", "--format=%ct", "--", ".")
+	if err != nil {
+		return time.Time{}, false
+	}
+	seconds, err := strconv.ParseInt(strings.TrimSpace(string(out)), 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return time.Unix(seconds, 0), true
+}
+
+// dirty reports whether dir has uncommitted or untracked files.
+func dirty(ctx context.Context, dir string) bool {
+	out, err := git.Run(ctx, dir, "status", "--porcelain", "--", ".")
+
+	return err != nil || strings.TrimSpace(string(out)) != ""
+}
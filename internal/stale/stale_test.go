@@ -0,0 +1,134 @@
+package stale
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/connerohnesorge/spectr/internal/list"
+	"github.com/connerohnesorge/spectr/internal/parsers"
+)
+
+// runGit runs a git command in dir with extra environment and fails the
+// test on error.
+func runGit(t *testing.T, dir string, env []string, args ...string) {
+	t.Helper()
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(
+		append(
+			os.Environ(),
+			"GIT_AUTHOR_NAME=test",
+			"GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test",
+			"GIT_COMMITTER_EMAIL=test@example.com",
+		),
+		env...,
+	)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v failed: %s", args, output)
+	}
+}
+
+// writeChange creates spectr/changes/<id>/proposal.md under root.
+func writeChange(t *testing.T, root, id string) string {
+	t.Helper()
+
+	dir := filepath.Join(root, "spectr", "changes", id)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "proposal.md")
+	if err := os.WriteFile(path, []byte("# "+id+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+// commitAt commits everything in root with the given commit time.
+func commitAt(t *testing.T, root string, when time.Time) {
+	t.Helper()
+
+	date := "GIT_COMMITTER_DATE=" + when.Format(time.RFC3339)
+	runGit(t, root, nil, "add", "-A")
+	runGit(t, root, []string{date}, "commit", "-q", "-m", "update")
+}
+
+func TestFind(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not available")
+	}
+
+	now := time.Now()
+	root := t.TempDir()
+	runGit(t, root, nil, "init", "-q")
+
+	writeChange(t, root, "old")
+	writeChange(t, root, "dirty")
+	commitAt(t, root, now.AddDate(0, 0, -60))
+	writeChange(t, root, "fresh")
+	commitAt(t, root, now.AddDate(0, 0, -2))
+	// Uncommitted work counts as activity through file times
+	dirtyPath := writeChange(t, root, "dirty")
+	if err := os.WriteFile(dirtyPath, []byte("# edited\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	changes := []list.ChangeInfo{
+		{ID: "fresh", RootAbsPath: root},
+		{ID: "old", RootAbsPath: root, TaskStatus: parsers.TaskStatus{Completed: 1, Total: 4}},
+		{ID: "dirty", RootAbsPath: root, LastActivity: now},
+	}
+
+	got := Find(context.Background(), changes, Threshold(30), now)
+	if len(got) != 1 {
+		t.Fatalf("expected only old to be stale, got %+v", got)
+	}
+	if got[0].ID != "old" || got[0].Source != SourceCommit {
+		t.Errorf("got %+v, want old from commit", got[0])
+	}
+	if got[0].IdleDays < 59 || got[0].IdleDays > 60 {
+		t.Errorf("IdleDays = %d, want about 60", got[0].IdleDays)
+	}
+	if got[0].Completed != 1 || got[0].Total != 4 {
+		t.Errorf("tasks = %d/%d, want 1/4", got[0].Completed, got[0].Total)
+	}
+
+	Mark(context.Background(), changes, Threshold(30), now)
+	for _, change := range changes {
+		if change.Stale != (change.ID == "old") {
+			t.Errorf("%s: Stale = %v", change.ID, change.Stale)
+		}
+	}
+}
+
+func TestFind_WithoutGit(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	root := t.TempDir()
+	writeChange(t, root, "a")
+	writeChange(t, root, "b")
+	writeChange(t, root, "c")
+
+	changes := []list.ChangeInfo{
+		{ID: "a", RootAbsPath: root, LastActivity: now.AddDate(0, 0, -40)},
+		{ID: "b", RootAbsPath: root, LastActivity: now.AddDate(0, 0, -10)},
+		{ID: "c", RootAbsPath: root},
+	}
+
+	got := Find(context.Background(), changes, Threshold(30), now)
+	if len(got) != 2 {
+		t.Fatalf("expected 2 stale changes, got %+v", got)
+	}
+	// Never-active changes sort first as the longest idle
+	if got[0].ID != "c" || got[1].ID != "a" {
+		t.Errorf("order = %s, %s; want c, a", got[0].ID, got[1].ID)
+	}
+	if got[1].Source != SourceFiles || got[1].IdleDays != 40 {
+		t.Errorf("got %+v, want 40 idle days from files", got[1])
+	}
+}
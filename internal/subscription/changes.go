@@ -0,0 +1,222 @@
+package subscription
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/diff"
+	"github.com/connerohnesorge/spectr/internal/git"
+	"github.com/connerohnesorge/spectr/internal/parsers"
+)
+
+// Kind says how a requirement changed.
+type Kind string
+
+// Requirement change kinds.
+const (
+	Added    Kind = "added"
+	Modified Kind = "modified"
+	Removed  Kind = "removed"
+)
+
+// RequirementChange is one changed requirement of a spec.
+type RequirementChange struct {
+	Kind        Kind   `json:"kind"`
+	Requirement string `json:"requirement"`
+}
+
+// SpecChange lists the changed requirements of one spec, in the order
+// they appear in the newer version (removed requirements last).
+type SpecChange struct {
+	Spec    string              `json:"spec"`
+	Changes []RequirementChange `json:"changes"`
+}
+
+// DetectSince returns the requirement changes between ref and the working
+// tree of the specs that any of subs cover. projectRoot is the directory
+// holding spectr/ inside a git repository.
+func DetectSince(
+	ctx context.Context,
+	projectRoot, ref string,
+	subs []Subscription,
+) ([]SpecChange, error) {
+	oldSpecsDir, cleanup, err := exportSpecs(ctx, projectRoot, ref)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	return Detect(oldSpecsDir, filepath.Join(projectRoot, "spectr", "specs"), subs)
+}
+
+// Detect returns the requirement changes between the specs in oldSpecsDir
+// and newSpecsDir, for the specs that any of subs cover. Either directory
+// may be missing.
+func Detect(oldSpecsDir, newSpecsDir string, subs []Subscription) ([]SpecChange, error) {
+	ids, err := specIDs(oldSpecsDir, newSpecsDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []SpecChange
+	for _, id := range ids {
+		if !covered(subs, id) {
+			continue
+		}
+
+		oldReqs, err := readRequirements(filepath.Join(oldSpecsDir, id, "spec.md"))
+		if err != nil {
+			return nil, err
+		}
+		newReqs, err := readRequirements(filepath.Join(newSpecsDir, id, "spec.md"))
+		if err != nil {
+			return nil, err
+		}
+
+		if reqChanges := CompareRequirements(oldReqs, newReqs); len(reqChanges) > 0 {
+			changes = append(changes, SpecChange{Spec: id, Changes: reqChanges})
+		}
+	}
+
+	return changes, nil
+}
+
+// CompareRequirements returns the requirements added, modified or removed
+// between two versions of a spec. Requirements are matched by normalized
+// name, and only word changes count: re-flowed text is not a change.
+func CompareRequirements(oldReqs, newReqs []parsers.RequirementBlock) []RequirementChange {
+	old := make(map[string]parsers.RequirementBlock, len(oldReqs))
+	for _, req := range oldReqs {
+		old[parsers.NormalizeRequirementName(req.Name)] = req
+	}
+
+	var changes []RequirementChange
+	seen := make(map[string]bool, len(newReqs))
+	for _, req := range newReqs {
+		key := parsers.NormalizeRequirementName(req.Name)
+		seen[key] = true
+
+		before, ok := old[key]
+		switch {
+		case !ok:
+			changes = append(changes, RequirementChange{Kind: Added, Requirement: req.Name})
+		case diff.Changed(diff.Words(before.Raw, req.Raw)):
+			changes = append(changes, RequirementChange{Kind: Modified, Requirement: req.Name})
+		}
+	}
+	for _, req := range oldReqs {
+		if !seen[parsers.NormalizeRequirementName(req.Name)] {
+			changes = append(changes, RequirementChange{Kind: Removed, Requirement: req.Name})
+		}
+	}
+
+	return changes
+}
+
+// exportSpecs writes the spectr/specs tree of projectRoot at ref to a
+// temporary directory and returns the specs directory inside it. A ref
+// without specs yields an empty directory, so every spec counts as added.
+func exportSpecs(
+	ctx context.Context,
+	projectRoot, ref string,
+) (string, func(), error) {
+	if err := git.Require("comparing specs with --since"); err != nil {
+		return "", nil, err
+	}
+
+	prefix, err := git.Run(ctx, projectRoot, "rev-parse", "--show-prefix")
+	if err != nil {
+		return "", nil, fmt.Errorf("%s is not in a git repository: %w", projectRoot, err)
+	}
+	specsPath := strings.TrimSpace(string(prefix)) + "spectr/specs"
+
+	dir, err := os.MkdirTemp("", "spectr-notify-")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	cleanup := func() { _ = os.RemoveAll(dir) }
+
+	listing, err := git.Run(ctx, projectRoot, "ls-tree", "--full-tree", "--name-only", ref, "--", specsPath)
+	if err != nil {
+		cleanup()
+
+		return "", nil, fmt.Errorf("unknown ref '%s': %s", ref, strings.TrimSpace(git.FailureOutput(err)))
+	}
+	if strings.TrimSpace(string(listing)) != "" {
+		if err := git.ExportTree(ctx, projectRoot, ref, specsPath, dir); err != nil {
+			cleanup()
+
+			return "", nil, err
+		}
+	}
+
+	return filepath.Join(dir, filepath.FromSlash(specsPath)), cleanup, nil
+}
+
+// specIDs returns the sorted IDs of the specs in either directory.
+func specIDs(dirs ...string) ([]string, error) {
+	found := make(map[string]bool)
+	for _, dir := range dirs {
+		err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if errors.Is(err, fs.ErrNotExist) {
+					return fs.SkipAll
+				}
+
+				return err
+			}
+			if d.IsDir() || d.Name() != "spec.md" {
+				return nil
+			}
+			rel, err := filepath.Rel(dir, filepath.Dir(path))
+			if err != nil {
+				return err
+			}
+			found[filepath.ToSlash(rel)] = true
+
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan %s: %w", dir, err)
+		}
+	}
+
+	ids := make([]string, 0, len(found))
+	for id := range found {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	return ids, nil
+}
+
+// readRequirements parses the requirements of a spec file, treating a
+// missing file as a spec without requirements.
+func readRequirements(path string) ([]parsers.RequirementBlock, error) {
+	reqs, err := parsers.ParseRequirements(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	return reqs, nil
+}
+
+// covered reports whether any subscription covers specID.
+func covered(subs []Subscription, specID string) bool {
+	for _, sub := range subs {
+		if sub.Covers(specID) {
+			return true
+		}
+	}
+
+	return false
+}
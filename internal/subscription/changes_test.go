@@ -0,0 +1,136 @@
+package subscription
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/connerohnesorge/spectr/internal/parsers"
+)
+
+func requirement(name, body string) parsers.RequirementBlock {
+	header := "### Requirement: " + name
+
+	return parsers.RequirementBlock{
+		HeaderLine: header,
+		Name:       name,
+		Raw:        header + "\n\n" + body + "\n",
+	}
+}
+
+func TestCompareRequirements(t *testing.T) {
+	oldReqs := []parsers.RequirementBlock{
+		requirement("Login", "The system SHALL log users in."),
+		requirement("Expiry", "Sessions SHALL expire after 14 days."),
+		requirement("Legacy", "The system SHALL support v1 tokens."),
+		requirement("Reflowed", "The system SHALL keep\nthis text."),
+	}
+	newReqs := []parsers.RequirementBlock{
+		requirement("Login", "The system SHALL log users in."),
+		requirement("Expiry", "Sessions SHALL expire after 7 days."),
+		requirement("Reflowed", "The system SHALL keep this text."),
+		requirement("Lockout", "The system SHALL lock accounts."),
+	}
+
+	got := CompareRequirements(oldReqs, newReqs)
+	want := []RequirementChange{
+		{Kind: Modified, Requirement: "Expiry"},
+		{Kind: Added, Requirement: "Lockout"},
+		{Kind: Removed, Requirement: "Legacy"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CompareRequirements() = %+v, want %+v", got, want)
+	}
+}
+
+const specTemplate = "# Spec\n\n## Requirements\n\n"
+
+// writeSpec writes a spec with the given requirement bodies below dir.
+func writeSpec(t *testing.T, dir, id string, reqs ...parsers.RequirementBlock) {
+	t.Helper()
+
+	content := specTemplate
+	for _, req := range reqs {
+		content += req.Raw + "\n"
+	}
+	path := filepath.Join(dir, id, "spec.md")
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDetect(t *testing.T) {
+	oldDir, newDir := t.TempDir(), t.TempDir()
+	writeSpec(t, oldDir, "auth", requirement("Login", "Old."))
+	writeSpec(t, newDir, "auth", requirement("Login", "New."))
+	writeSpec(t, oldDir, "payments/refunds", requirement("Window", "30 days."))
+	writeSpec(t, newDir, "payments/refunds", requirement("Window", "30 days."))
+	writeSpec(t, newDir, "payments/payouts", requirement("Weekly", "Weekly."))
+	writeSpec(t, oldDir, "search", requirement("Index", "Old."))
+	writeSpec(t, newDir, "search", requirement("Index", "New."))
+
+	subs := []Subscription{
+		{Spec: "auth", Email: "ada@example.com"},
+		{Spec: "payments", Email: "bob@example.com"},
+	}
+	got, err := Detect(oldDir, newDir, subs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []SpecChange{
+		{Spec: "auth", Changes: []RequirementChange{{Kind: Modified, Requirement: "Login"}}},
+		{Spec: "payments/payouts", Changes: []RequirementChange{{Kind: Added, Requirement: "Weekly"}}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Detect() = %+v, want %+v", got, want)
+	}
+}
+
+// runGit runs a git command in dir and fails the test on error.
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=Test", "GIT_AUTHOR_EMAIL=test@example.com",
+		"GIT_COMMITTER_NAME=Test", "GIT_COMMITTER_EMAIL=test@example.com",
+	)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v failed: %s", args, output)
+	}
+}
+
+func TestDetectSince(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not available")
+	}
+
+	repo := t.TempDir()
+	project := filepath.Join(repo, "project")
+	specsDir := filepath.Join(project, "spectr", "specs")
+	writeSpec(t, specsDir, "auth", requirement("Login", "Old."))
+	runGit(t, repo, "init", "-q")
+	runGit(t, repo, "add", "-A")
+	runGit(t, repo, "commit", "-q", "-m", "init")
+	writeSpec(t, specsDir, "auth", requirement("Login", "New."))
+
+	subs := []Subscription{{Spec: "auth", Email: "ada@example.com"}}
+	got, err := DetectSince(context.Background(), project, "HEAD", subs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].Changes[0].Kind != Modified {
+		t.Errorf("DetectSince() = %+v", got)
+	}
+
+	if _, err := DetectSince(context.Background(), project, "missing", subs); err == nil {
+		t.Error("expected an error for an unknown ref")
+	}
+}
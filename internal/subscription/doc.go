@@ -0,0 +1,12 @@
+// Package subscription lets people watch specs and tells them when the
+// requirements of those specs change.
+//
+// Subscriptions live in spectr/subscriptions.yaml so they are reviewed and
+// shared like the specs themselves; `spectr subscribe` edits the file.
+// A subscription to a directory such as "payments" covers every spec
+// nested beneath it.
+//
+// `spectr notify --since <ref>` compares each subscribed spec at ref with
+// the working tree requirement by requirement and sends the changes to
+// the spec's subscribers by email, Slack-compatible webhook, or both.
+package subscription
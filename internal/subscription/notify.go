@@ -0,0 +1,201 @@
+package subscription
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/smtp"
+	"sort"
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/hostapi"
+)
+
+// Notice is what one subscriber is told: the changes to the specs they
+// watch.
+type Notice struct {
+	Email string       `json:"email"`
+	Slack string       `json:"slack,omitempty"`
+	Specs []SpecChange `json:"specs"`
+}
+
+// Notices groups changes by subscriber, sorted by email. Subscribers
+// whose specs did not change get no notice.
+func Notices(subs []Subscription, changes []SpecChange) []Notice {
+	byEmail := make(map[string]*Notice)
+	for _, change := range changes {
+		notified := make(map[string]bool)
+		for _, sub := range subs {
+			email := strings.ToLower(sub.Email)
+			if !sub.Covers(change.Spec) || notified[email] {
+				continue
+			}
+			notified[email] = true
+
+			notice, ok := byEmail[email]
+			if !ok {
+				notice = &Notice{Email: sub.Email}
+				byEmail[email] = notice
+			}
+			if notice.Slack == "" {
+				notice.Slack = sub.Slack
+			}
+			notice.Specs = append(notice.Specs, change)
+		}
+	}
+
+	notices := make([]Notice, 0, len(byEmail))
+	for _, notice := range byEmail {
+		notices = append(notices, *notice)
+	}
+	sort.Slice(notices, func(i, j int) bool {
+		return notices[i].Email < notices[j].Email
+	})
+
+	return notices
+}
+
+// Summary describes changes as text, one line per spec followed by one
+// line per changed requirement.
+func Summary(changes []SpecChange) string {
+	var sb strings.Builder
+	for i, change := range changes {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		fmt.Fprintf(&sb, "%s:", change.Spec)
+		for _, req := range change.Changes {
+			fmt.Fprintf(&sb, "\n  %s %s", kindMarker(req.Kind), req.Requirement)
+		}
+	}
+
+	return sb.String()
+}
+
+// kindMarker is the diff-style marker shown before a changed requirement.
+func kindMarker(kind Kind) string {
+	switch kind {
+	case Added:
+		return "+"
+	case Removed:
+		return "-"
+	default:
+		return "~"
+	}
+}
+
+// Message is the JSON body posted to a webhook. Text makes it usable as a
+// Slack or Mattermost incoming webhook message; Specs and Notices carry
+// the details for other receivers.
+type Message struct {
+	Text    string       `json:"text"`
+	Since   string       `json:"since"`
+	Specs   []SpecChange `json:"specs"`
+	Notices []Notice     `json:"notices"`
+}
+
+// NewMessage builds the webhook message for changes since ref. Each spec
+// mentions the Slack handles of its subscribers, or their emails.
+func NewMessage(ref string, changes []SpecChange, notices []Notice) Message {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%d watched spec(s) changed since %s:", len(changes), ref)
+	for _, change := range changes {
+		fmt.Fprintf(&sb, "\n\n%s", Summary([]SpecChange{change}))
+		if mentions := mentions(change.Spec, notices); mentions != "" {
+			fmt.Fprintf(&sb, "\n  cc %s", mentions)
+		}
+	}
+
+	return Message{Text: sb.String(), Since: ref, Specs: changes, Notices: notices}
+}
+
+// mentions lists the subscribers notified about spec.
+func mentions(spec string, notices []Notice) string {
+	var names []string
+	for _, notice := range notices {
+		for _, change := range notice.Specs {
+			if change.Spec != spec {
+				continue
+			}
+			if notice.Slack != "" {
+				names = append(names, notice.Slack)
+			} else {
+				names = append(names, notice.Email)
+			}
+		}
+	}
+
+	return strings.Join(names, " ")
+}
+
+// Post posts message to the webhook at url.
+func Post(
+	ctx context.Context,
+	client *hostapi.Client,
+	url string,
+	message Message,
+) error {
+	if err := client.DoJSON(ctx, http.MethodPost, url, message, nil); err != nil {
+		return fmt.Errorf("post spec change notification: %w", err)
+	}
+
+	return nil
+}
+
+// Mailer sends notices by email through an SMTP server.
+type Mailer struct {
+	// Addr is the server's host:port.
+	Addr string
+	// From is the sender address.
+	From string
+	// Username and Password authenticate with PLAIN auth when Username is
+	// set.
+	Username string
+	Password string
+}
+
+// Send emails notice to its subscriber about the changes since ref.
+func (m *Mailer) Send(ref string, notice Notice) error {
+	var auth smtp.Auth
+	if m.Username != "" {
+		host, _, err := net.SplitHostPort(m.Addr)
+		if err != nil {
+			return fmt.Errorf("invalid SMTP address %q: %w", m.Addr, err)
+		}
+		auth = smtp.PlainAuth("", m.Username, m.Password, host)
+	}
+
+	err := smtp.SendMail(m.Addr, auth, m.From, []string{notice.Email}, EmailMessage(m.From, ref, notice))
+	if err != nil {
+		return fmt.Errorf("email %s: %w", notice.Email, err)
+	}
+
+	return nil
+}
+
+// EmailMessage builds the RFC 5322 message telling notice's subscriber
+// about the changes since ref.
+func EmailMessage(from, ref string, notice Notice) []byte {
+	specs := make([]string, len(notice.Specs))
+	for i, change := range notice.Specs {
+		specs[i] = change.Spec
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "From: %s\r\n", from)
+	fmt.Fprintf(&sb, "To: %s\r\n", notice.Email)
+	fmt.Fprintf(&sb, "Subject: [spectr] %s changed\r\n", strings.Join(specs, ", "))
+	sb.WriteString("MIME-Version: 1.0\r\n")
+	sb.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+
+	body := fmt.Sprintf(
+		"Specs you watch changed since %s:\n\n%s\n\n"+
+			"Run `spectr subscribe <spec-id> --remove` to stop watching a spec.\n",
+		ref,
+		Summary(notice.Specs),
+	)
+	sb.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+
+	return []byte(sb.String())
+}
@@ -0,0 +1,96 @@
+package subscription
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/connerohnesorge/spectr/internal/hostapi"
+)
+
+var testChanges = []SpecChange{
+	{Spec: "auth", Changes: []RequirementChange{{Kind: Modified, Requirement: "Login"}}},
+	{Spec: "payments/refunds", Changes: []RequirementChange{
+		{Kind: Added, Requirement: "Partial Refunds"},
+		{Kind: Removed, Requirement: "Legacy"},
+	}},
+}
+
+var testSubs = []Subscription{
+	{Spec: "payments", Email: "ada@example.com", Slack: "@ada"},
+	{Spec: "payments/refunds", Email: "ada@example.com"},
+	{Spec: "auth", Email: "bob@example.com"},
+	{Spec: "search", Email: "cy@example.com"},
+}
+
+func TestNotices(t *testing.T) {
+	notices := Notices(testSubs, testChanges)
+	if len(notices) != 2 {
+		t.Fatalf("Notices() = %+v, want 2 notices", notices)
+	}
+	if notices[0].Email != "ada@example.com" || notices[0].Slack != "@ada" ||
+		len(notices[0].Specs) != 1 || notices[0].Specs[0].Spec != "payments/refunds" {
+		t.Errorf("first notice = %+v", notices[0])
+	}
+	if notices[1].Email != "bob@example.com" || notices[1].Specs[0].Spec != "auth" {
+		t.Errorf("second notice = %+v", notices[1])
+	}
+}
+
+func TestSummary(t *testing.T) {
+	want := "auth:\n  ~ Login\npayments/refunds:\n  + Partial Refunds\n  - Legacy"
+	if got := Summary(testChanges); got != want {
+		t.Errorf("Summary() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestNewMessage(t *testing.T) {
+	msg := NewMessage("HEAD~1", testChanges, Notices(testSubs, testChanges))
+	for _, want := range []string{
+		"2 watched spec(s) changed since HEAD~1:",
+		"cc bob@example.com",
+		"cc @ada",
+	} {
+		if !strings.Contains(msg.Text, want) {
+			t.Errorf("message text missing %q:\n%s", want, msg.Text)
+		}
+	}
+}
+
+func TestEmailMessage(t *testing.T) {
+	notice := Notices(testSubs, testChanges)[1]
+	got := string(EmailMessage("spectr@example.com", "HEAD~1", notice))
+	for _, want := range []string{
+		"To: bob@example.com\r\n",
+		"Subject: [spectr] auth changed\r\n",
+		"changed since HEAD~1:\r\n\r\nauth:\r\n  ~ Login\r\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("email missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestPost(t *testing.T) {
+	var got Message
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+				t.Errorf("decode body: %v", err)
+			}
+			w.WriteHeader(http.StatusOK)
+		},
+	))
+	defer server.Close()
+
+	msg := NewMessage("HEAD~1", testChanges, Notices(testSubs, testChanges))
+	if err := Post(context.Background(), hostapi.NewClient(), server.URL, msg); err != nil {
+		t.Fatalf("Post() error = %v", err)
+	}
+	if got.Since != "HEAD~1" || len(got.Specs) != 2 || len(got.Notices) != 2 {
+		t.Errorf("posted message = %+v", got)
+	}
+}
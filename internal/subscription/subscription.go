@@ -0,0 +1,116 @@
+package subscription
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileName is the name of the subscriptions file in the spectr/ directory.
+const FileName = "subscriptions.yaml"
+
+// Subscriptions file permission and indentation.
+const (
+	filePerm   = 0o644
+	yamlIndent = 2
+)
+
+// Subscription records that Email watches Spec. Slack is an optional
+// handle (e.g. "@ada") mentioned in webhook messages.
+type Subscription struct {
+	Spec  string `yaml:"spec"`
+	Email string `yaml:"email"`
+	Slack string `yaml:"slack,omitempty"`
+}
+
+// Covers reports whether the subscription covers specID: its own spec or
+// a spec nested beneath it.
+func (s Subscription) Covers(specID string) bool {
+	spec := strings.Trim(s.Spec, "/")
+
+	return specID == spec || strings.HasPrefix(specID, spec+"/")
+}
+
+// file is the layout of subscriptions.yaml.
+type file struct {
+	Subscriptions []Subscription `yaml:"subscriptions"`
+}
+
+// Load reads the subscriptions of the project whose spectr/ directory is
+// spectrDir. A missing file yields no subscriptions.
+func Load(spectrDir string) ([]Subscription, error) {
+	path := filepath.Join(spectrDir, FileName)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var f file
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	return f.Subscriptions, nil
+}
+
+// Save writes subs to spectrDir's subscriptions file, sorted by spec and
+// email.
+func Save(spectrDir string, subs []Subscription) error {
+	sort.Slice(subs, func(i, j int) bool {
+		if subs[i].Spec != subs[j].Spec {
+			return subs[i].Spec < subs[j].Spec
+		}
+
+		return subs[i].Email < subs[j].Email
+	})
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(yamlIndent)
+	if err := enc.Encode(file{Subscriptions: subs}); err != nil {
+		return fmt.Errorf("failed to encode subscriptions: %w", err)
+	}
+
+	path := filepath.Join(spectrDir, FileName)
+	if err := os.WriteFile(path, buf.Bytes(), filePerm); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// Add adds sub to subs. An existing subscription of the same email to the
+// same spec is replaced, so its Slack handle can be updated. It reports
+// whether sub is new.
+func Add(subs []Subscription, sub Subscription) ([]Subscription, bool) {
+	for i, existing := range subs {
+		if existing.Spec == sub.Spec && strings.EqualFold(existing.Email, sub.Email) {
+			subs[i] = sub
+
+			return subs, false
+		}
+	}
+
+	return append(subs, sub), true
+}
+
+// Remove removes the subscription of email to spec from subs and reports
+// whether there was one.
+func Remove(subs []Subscription, spec, email string) ([]Subscription, bool) {
+	for i, existing := range subs {
+		if existing.Spec == spec && strings.EqualFold(existing.Email, email) {
+			return append(subs[:i], subs[i+1:]...), true
+		}
+	}
+
+	return subs, false
+}
@@ -0,0 +1,86 @@
+package subscription
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSubscription_Covers(t *testing.T) {
+	tests := []struct {
+		spec   string
+		specID string
+		want   bool
+	}{
+		{"payments", "payments", true},
+		{"payments", "payments/refunds", true},
+		{"payments/", "payments/refunds", true},
+		{"payments", "payments-v2", false},
+		{"payments/refunds", "payments", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec+" "+tt.specID, func(t *testing.T) {
+			got := Subscription{Spec: tt.spec}.Covers(tt.specID)
+			if got != tt.want {
+				t.Errorf("Covers(%q) = %v, want %v", tt.specID, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddRemove(t *testing.T) {
+	subs, added := Add(nil, Subscription{Spec: "auth", Email: "ada@example.com"})
+	if !added || len(subs) != 1 {
+		t.Fatalf("Add() = %v, %v", subs, added)
+	}
+
+	subs, added = Add(subs, Subscription{
+		Spec: "auth", Email: "ADA@example.com", Slack: "@ada",
+	})
+	if added || len(subs) != 1 || subs[0].Slack != "@ada" {
+		t.Fatalf("Add() of an existing subscription = %v, %v", subs, added)
+	}
+
+	if _, removed := Remove(subs, "payments", "ada@example.com"); removed {
+		t.Error("Remove() of a missing subscription reported a removal")
+	}
+	subs, removed := Remove(subs, "auth", "ada@example.com")
+	if !removed || len(subs) != 0 {
+		t.Errorf("Remove() = %v, %v", subs, removed)
+	}
+}
+
+func TestLoadSave(t *testing.T) {
+	dir := t.TempDir()
+
+	subs, err := Load(dir)
+	if err != nil || subs != nil {
+		t.Fatalf("Load() without a file = %v, %v", subs, err)
+	}
+
+	want := []Subscription{
+		{Spec: "payments", Email: "ada@example.com", Slack: "@ada"},
+		{Spec: "auth", Email: "bob@example.com"},
+	}
+	if err := Save(dir, want); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, FileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), "subscriptions:\n  - spec: auth\n") {
+		t.Errorf("subscriptions file is not sorted:\n%s", data)
+	}
+
+	got, err := Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0].Spec != "auth" || got[1].Slack != "@ada" {
+		t.Errorf("Load() = %+v", got)
+	}
+}
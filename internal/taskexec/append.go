@@ -0,0 +1,105 @@
+package taskexec
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/parsers"
+	"github.com/connerohnesorge/spectr/internal/utils"
+)
+
+// AppendTasks appends pending tasks with the given descriptions to a section
+// of a tasks.jsonc file. If the section already exists, new tasks continue
+// its numbering (e.g. 4.3, 4.4); otherwise a new section is created after
+// the highest existing section number. The leading comment header of the
+// file is preserved. Returns the tasks that were added.
+func AppendTasks(
+	tasksPath, section string,
+	descriptions []string,
+) ([]parsers.Task, error) {
+	data, err := os.ReadFile(tasksPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tasks file %s: %w", tasksPath, err)
+	}
+
+	var tasksFile parsers.TasksFile
+	if err := json.Unmarshal(utils.StripJSONCComments(data), &tasksFile); err != nil {
+		return nil, fmt.Errorf("failed to parse tasks file %s: %w", tasksPath, err)
+	}
+	if err := tasksFile.CheckVersion(tasksPath); err != nil {
+		return nil, err
+	}
+
+	sectionNum, nextSeq := nextTaskPosition(tasksFile.Tasks, section)
+
+	added := make([]parsers.Task, 0, len(descriptions))
+	for i, desc := range descriptions {
+		added = append(added, parsers.Task{
+			ID:          fmt.Sprintf("%d.%d", sectionNum, nextSeq+i),
+			Section:     section,
+			Description: desc,
+			Status:      parsers.TaskStatusPending,
+		})
+	}
+	tasksFile.Tasks = append(tasksFile.Tasks, added...)
+
+	updatedJSON, err := json.MarshalIndent(tasksFile, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal tasks data: %w", err)
+	}
+
+	output := append([]byte(utils.LeadingJSONCComments(data)), updatedJSON...)
+
+	// Write back to the file atomically
+	tmpFile := tasksPath + ".tmp"
+	if err := os.WriteFile(tmpFile, output, filePerm); err != nil {
+		return nil, fmt.Errorf("failed to write temporary file: %w", err)
+	}
+
+	if err := os.Rename(tmpFile, tasksPath); err != nil {
+		_ = os.Remove(tmpFile)
+
+		return nil, fmt.Errorf("failed to rename temporary file: %w", err)
+	}
+
+	return added, nil
+}
+
+// nextTaskPosition returns the section number and next task sequence for
+// tasks appended to section. Task IDs are expected in "N.M" form.
+func nextTaskPosition(
+	tasks []parsers.Task,
+	section string,
+) (sectionNum, nextSeq int) {
+	maxSection := 0
+	sectionNum = -1
+	maxSeq := 0
+
+	for _, task := range tasks {
+		parts := strings.Split(task.ID, ".")
+		num, err := strconv.Atoi(parts[0])
+		if err != nil {
+			continue
+		}
+		maxSection = max(maxSection, num)
+
+		if task.Section != section {
+			continue
+		}
+		sectionNum = num
+		if len(parts) > 1 {
+			if seq, err := strconv.Atoi(parts[len(parts)-1]); err == nil {
+				maxSeq = max(maxSeq, seq)
+			}
+		}
+	}
+
+	if sectionNum < 0 {
+		return maxSection + 1, 1
+	}
+
+	return sectionNum, maxSeq + 1
+}
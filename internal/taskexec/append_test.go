@@ -0,0 +1,100 @@
+package taskexec
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/connerohnesorge/spectr/internal/parsers"
+)
+
+func TestAppendTasks(t *testing.T) {
+	tests := []struct {
+		name           string
+		initialContent string
+		section        string
+		descriptions   []string
+		wantIDs        []string
+	}{
+		{
+			name: "new section after highest section",
+			initialContent: `// Header comment
+
+{
+  "version": 1,
+  "tasks": [
+    {"id": "1.1", "section": "Impl", "description": "a", "status": "completed"},
+    {"id": "2.1", "section": "Test", "description": "b", "status": "pending"}
+  ]
+}`,
+			section:      "Review feedback",
+			descriptions: []string{"fix x", "fix y"},
+			wantIDs:      []string{"3.1", "3.2"},
+		},
+		{
+			name: "continue existing section",
+			initialContent: `{
+  "version": 1,
+  "tasks": [
+    {"id": "1.1", "section": "Impl", "description": "a", "status": "completed"},
+    {"id": "2.1", "section": "Review feedback", "description": "b", "status": "pending"},
+    {"id": "2.2", "section": "Review feedback", "description": "c", "status": "pending"}
+  ]
+}`,
+			section:      "Review feedback",
+			descriptions: []string{"fix z"},
+			wantIDs:      []string{"2.3"},
+		},
+		{
+			name:           "empty tasks",
+			initialContent: `{"version": 1, "tasks": []}`,
+			section:        "Review feedback",
+			descriptions:   []string{"fix x"},
+			wantIDs:        []string{"1.1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tasksPath := filepath.Join(t.TempDir(), "tasks.jsonc")
+			if err := os.WriteFile(tasksPath, []byte(tt.initialContent), 0o644); err != nil {
+				t.Fatalf("failed to write tasks file: %v", err)
+			}
+
+			added, err := AppendTasks(tasksPath, tt.section, tt.descriptions)
+			if err != nil {
+				t.Fatalf("AppendTasks() error = %v", err)
+			}
+			if len(added) != len(tt.wantIDs) {
+				t.Fatalf("added %d tasks, want %d", len(added), len(tt.wantIDs))
+			}
+			for i, task := range added {
+				if task.ID != tt.wantIDs[i] {
+					t.Errorf("task %d ID = %s, want %s", i, task.ID, tt.wantIDs[i])
+				}
+				if task.Status != parsers.TaskStatusPending {
+					t.Errorf("task %d status = %s, want pending", i, task.Status)
+				}
+			}
+
+			written, err := os.ReadFile(tasksPath)
+			if err != nil {
+				t.Fatalf("failed to read tasks file: %v", err)
+			}
+			if strings.HasPrefix(tt.initialContent, "//") &&
+				!strings.HasPrefix(string(written), "// Header comment\n\n{") {
+				t.Errorf("header not preserved:\n%s", written)
+			}
+
+			tasksFile, err := parsers.ReadTasksJson(tasksPath)
+			if err != nil {
+				t.Fatalf("failed to parse written file: %v", err)
+			}
+			last := tasksFile.Tasks[len(tasksFile.Tasks)-1]
+			if last.Description != tt.descriptions[len(tt.descriptions)-1] {
+				t.Errorf("last task = %+v", last)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package taskexec
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/parsers"
+)
+
+// PendingTasks returns the tasks of a change that are not completed, in
+// file order. Tasks whose children live in a separate file (version 2
+// layout) are replaced by the pending tasks of that file, since those are
+// the tasks that get worked on; their own status is derived.
+func PendingTasks(changeDir string) ([]parsers.Task, error) {
+	return pendingTasksInFile(filepath.Join(changeDir, "tasks.jsonc"), true)
+}
+
+// pendingTasksInFile reads the pending tasks of one tasks.jsonc file,
+// following child file references when followChildren is set.
+func pendingTasksInFile(path string, followChildren bool) ([]parsers.Task, error) {
+	file, err := parsers.ReadTasksJson(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tasks file %s: %w", path, err)
+	}
+
+	var pending []parsers.Task
+	for _, task := range file.Tasks {
+		if ref, ok := strings.CutPrefix(task.Children, "$ref:"); ok && followChildren {
+			children, err := pendingTasksInFile(
+				filepath.Join(filepath.Dir(path), ref),
+				false,
+			)
+			if err != nil {
+				return nil, err
+			}
+			pending = append(pending, children...)
+
+			continue
+		}
+		if task.Status != parsers.TaskStatusCompleted {
+			pending = append(pending, task)
+		}
+	}
+
+	return pending, nil
+}
@@ -0,0 +1,59 @@
+package taskexec
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestPendingTasks(t *testing.T) {
+	changeDir := t.TempDir()
+	writeFile := func(rel, content string) {
+		path := filepath.Join(changeDir, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFile("tasks.jsonc", `// Root tasks
+{
+  "version": 2,
+  "tasks": [
+    {"id": "1.1", "section": "Impl", "description": "done", "status": "completed"},
+    {"id": "1.2", "section": "Impl", "description": "started", "status": "in_progress"},
+    {"id": "2", "section": "Auth", "description": "auth", "status": "in_progress",
+     "children": "$ref:specs/auth/tasks.jsonc"},
+    {"id": "3.1", "section": "Docs", "description": "write docs", "status": "pending"}
+  ]
+}`)
+	writeFile("specs/auth/tasks.jsonc", `{
+  "version": 2,
+  "parent": "2",
+  "tasks": [
+    {"id": "2.1", "section": "Auth", "description": "login", "status": "completed"},
+    {"id": "2.2", "section": "Auth", "description": "logout", "status": "pending"}
+  ]
+}`)
+
+	tasks, err := PendingTasks(changeDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ids []string
+	for _, task := range tasks {
+		ids = append(ids, task.ID)
+	}
+	if want := []string{"1.2", "2.2", "3.1"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("pending IDs = %v, want %v", ids, want)
+	}
+}
+
+func TestPendingTasks_NoTasksFile(t *testing.T) {
+	if _, err := PendingTasks(t.TempDir()); err == nil {
+		t.Error("expected an error for a change without tasks.jsonc")
+	}
+}
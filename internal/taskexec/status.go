@@ -2,13 +2,16 @@
 package taskexec
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 
+	"github.com/connerohnesorge/spectr/internal/audit"
 	"github.com/connerohnesorge/spectr/internal/parsers"
+	"github.com/connerohnesorge/spectr/internal/txn"
 	"github.com/connerohnesorge/spectr/internal/utils"
 )
 
@@ -18,6 +21,11 @@ const filePerm = 0o644
 // StatusUpdater handles updating task statuses in tasks.jsonc files
 type StatusUpdater struct {
 	changeDir string
+
+	// staged holds the files an update rewrites, in the order they were
+	// first written, until they are committed together
+	staged map[string][]byte
+	order  []string
 }
 
 // NewStatusUpdater creates a new StatusUpdater instance
@@ -29,7 +37,14 @@ func NewStatusUpdater(changeDir string) *StatusUpdater {
 
 // UpdateTaskStatus updates the status of a specific task
 // Supports both v1 flat and v2 hierarchical formats
+//
+// The rewritten tasks files and the audit entry are committed as one
+// transaction: if the audit entry cannot be recorded, no file changes.
 func (su *StatusUpdater) UpdateTaskStatus(taskID string, status parsers.TaskStatusValue) error {
+	su.staged = make(map[string][]byte)
+	su.order = nil
+	defer func() { su.staged, su.order = nil, nil }()
+
 	tasksFile := filepath.Join(su.changeDir, "tasks.jsonc")
 
 	// Try to update in the root file first
@@ -41,21 +56,76 @@ func (su *StatusUpdater) UpdateTaskStatus(taskID string, status parsers.TaskStat
 	if updated {
 		// Task was updated in root file
 		// For v2 hierarchical, check if we need to update parent status aggregation
-		return su.updateParentStatusIfNeeded(tasksFile)
+		err = su.updateParentStatusIfNeeded(tasksFile)
+	} else {
+		// Task not found in root file, search in child files (v2 hierarchical)
+		err = su.updateTaskInHierarchy(tasksFile, taskID, status)
+	}
+	if err != nil {
+		return err
+	}
+
+	tx := txn.New()
+	for _, path := range su.order {
+		tx.WriteFile(path, su.staged[path], filePerm)
+	}
+	su.recordStatusChange(tx, taskID, status)
+
+	return tx.Commit(context.Background())
+}
+
+// readFile returns the content of path, as staged by this update if it
+// was already rewritten.
+func (su *StatusUpdater) readFile(path string) ([]byte, error) {
+	if data, ok := su.staged[path]; ok {
+		return data, nil
 	}
 
-	// Task not found in root file, search in child files (v2 hierarchical)
-	return su.updateTaskInHierarchy(tasksFile, taskID, status)
+	return os.ReadFile(path)
+}
+
+// stageFile records new content for path, written when the update is
+// committed.
+func (su *StatusUpdater) stageFile(path string, data []byte) {
+	if su.staged == nil {
+		su.staged = make(map[string][]byte)
+	}
+	if _, ok := su.staged[path]; !ok {
+		su.order = append(su.order, path)
+	}
+	su.staged[path] = data
+}
+
+// recordStatusChange registers adding the status change to the audit log
+// as the last step of tx when the change directory sits in a
+// spectr/changes/ tree.
+func (su *StatusUpdater) recordStatusChange(
+	tx *txn.Tx,
+	taskID string,
+	status parsers.TaskStatusValue,
+) {
+	changesDir := filepath.Dir(su.changeDir)
+	if filepath.Base(changesDir) != "changes" {
+		return
+	}
+
+	audit.RecordLast(
+		tx,
+		filepath.Dir(changesDir),
+		audit.OpTaskStatus,
+		[]string{"changes/" + filepath.Base(su.changeDir)},
+		map[string]string{"task": taskID, "status": string(status)},
+	)
 }
 
 // updateTaskInFile updates a task in a specific file
 // Returns true if the task was found and updated, false otherwise
-func (*StatusUpdater) updateTaskInFile(
+func (su *StatusUpdater) updateTaskInFile(
 	filePath, taskID string,
 	status parsers.TaskStatusValue,
 ) (bool, error) {
 	// Read the tasks file
-	data, err := os.ReadFile(filePath)
+	data, err := su.readFile(filePath)
 	if err != nil {
 		return false, fmt.Errorf("failed to read tasks file %s: %w", filePath, err)
 	}
@@ -68,6 +138,9 @@ func (*StatusUpdater) updateTaskInFile(
 	if err := json.Unmarshal(jsonData, &tasksFileData); err != nil {
 		return false, fmt.Errorf("failed to parse tasks file %s: %w", filePath, err)
 	}
+	if err := tasksFileData.CheckVersion(filePath); err != nil {
+		return false, err
+	}
 
 	// Find and update the task
 	taskFound := false
@@ -92,17 +165,8 @@ func (*StatusUpdater) updateTaskInFile(
 		return false, fmt.Errorf("failed to marshal tasks data: %w", err)
 	}
 
-	// Write back to the file atomically
-	tmpFile := filePath + ".tmp"
-	if err := os.WriteFile(tmpFile, updatedJSON, filePerm); err != nil {
-		return false, fmt.Errorf("failed to write temporary file: %w", err)
-	}
-
-	if err := os.Rename(tmpFile, filePath); err != nil {
-		_ = os.Remove(tmpFile)
-
-		return false, fmt.Errorf("failed to rename temporary file: %w", err)
-	}
+	// Written atomically when the update is committed
+	su.stageFile(filePath, updatedJSON)
 
 	return true, nil
 }
@@ -113,7 +177,7 @@ func (su *StatusUpdater) updateTaskInHierarchy(
 	status parsers.TaskStatusValue,
 ) error {
 	// Read the root tasks file to find child references
-	data, err := os.ReadFile(rootFile)
+	data, err := su.readFile(rootFile)
 	if err != nil {
 		return fmt.Errorf("failed to read tasks file: %w", err)
 	}
@@ -126,6 +190,9 @@ func (su *StatusUpdater) updateTaskInHierarchy(
 	if err := json.Unmarshal(jsonData, &tasksFileData); err != nil {
 		return fmt.Errorf("failed to parse tasks file: %w", err)
 	}
+	if err := tasksFileData.CheckVersion(rootFile); err != nil {
+		return err
+	}
 
 	// Search through tasks with children
 	for _, task := range tasksFileData.Tasks {
@@ -172,7 +239,7 @@ func (*StatusUpdater) resolveChildPath(ref, baseDir string) (string, error) {
 // updateParentStatusIfNeeded updates parent task status based on child completion
 func (su *StatusUpdater) updateParentStatusIfNeeded(filePath string) error {
 	// Read the child tasks file to get the parent ID
-	data, err := os.ReadFile(filePath)
+	data, err := su.readFile(filePath)
 	if err != nil {
 		return fmt.Errorf("failed to read tasks file %s: %w", filePath, err)
 	}
@@ -185,6 +252,9 @@ func (su *StatusUpdater) updateParentStatusIfNeeded(filePath string) error {
 	if err := json.Unmarshal(jsonData, &childFileData); err != nil {
 		return fmt.Errorf("failed to parse tasks file %s: %w", filePath, err)
 	}
+	if err := childFileData.CheckVersion(filePath); err != nil {
+		return err
+	}
 
 	// If there's no parent field, this is a root file or v1 format - nothing to aggregate
 	if childFileData.Parent == "" {
@@ -240,12 +310,12 @@ func (*StatusUpdater) aggregateChildStatuses(tasks []parsers.Task) parsers.TaskS
 }
 
 // updateParentTask updates a parent task's status in the root file
-func (*StatusUpdater) updateParentTask(
+func (su *StatusUpdater) updateParentTask(
 	rootFile, parentID string,
 	status parsers.TaskStatusValue,
 ) error {
 	// Read the root tasks file
-	data, err := os.ReadFile(rootFile)
+	data, err := su.readFile(rootFile)
 	if err != nil {
 		return fmt.Errorf("failed to read root tasks file: %w", err)
 	}
@@ -258,6 +328,9 @@ func (*StatusUpdater) updateParentTask(
 	if err := json.Unmarshal(jsonData, &rootFileData); err != nil {
 		return fmt.Errorf("failed to parse root tasks file: %w", err)
 	}
+	if err := rootFileData.CheckVersion(rootFile); err != nil {
+		return err
+	}
 
 	// Find and update the parent task
 	parentFound := false
@@ -284,17 +357,8 @@ func (*StatusUpdater) updateParentTask(
 		return fmt.Errorf("failed to marshal root tasks data: %w", err)
 	}
 
-	// Write back to the file atomically
-	tmpFile := rootFile + ".tmp"
-	if err := os.WriteFile(tmpFile, updatedJSON, filePerm); err != nil {
-		return fmt.Errorf("failed to write temporary file: %w", err)
-	}
-
-	if err := os.Rename(tmpFile, rootFile); err != nil {
-		_ = os.Remove(tmpFile)
-
-		return fmt.Errorf("failed to rename temporary file: %w", err)
-	}
+	// Written atomically when the update is committed
+	su.stageFile(rootFile, updatedJSON)
 
 	return nil
 }
@@ -269,6 +269,43 @@ func TestUpdateTaskStatusAtomic(t *testing.T) {
 	}
 }
 
+func TestUpdateTaskStatusAuditFailure(t *testing.T) {
+	root := t.TempDir()
+	changeDir := filepath.Join(root, "spectr", "changes", "add-feature")
+	if err := os.MkdirAll(changeDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	initialContent := `{
+		"version": 1,
+		"tasks": [
+			{"id": "1.1", "section": "Test", "description": "First task", "status": "pending"}
+		]
+	}`
+	tasksFile := filepath.Join(changeDir, "tasks.jsonc")
+	if err := os.WriteFile(tasksFile, []byte(initialContent), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// A corrupt audit log makes recording the status change fail
+	auditLog := filepath.Join(root, "spectr", "audit.log.jsonl")
+	if err := os.WriteFile(auditLog, []byte("not json\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	su := NewStatusUpdater(changeDir)
+	if err := su.UpdateTaskStatus("1.1", parsers.TaskStatusCompleted); err == nil {
+		t.Fatal("UpdateTaskStatus() with a corrupt audit log: want an error")
+	}
+
+	content, err := os.ReadFile(tasksFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != initialContent {
+		t.Errorf("tasks.jsonc changed after a failed audit entry:\n%s", content)
+	}
+}
+
 func TestUpdateTaskStatusHierarchical(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -0,0 +1,6 @@
+// Package taskschema defines the tasks.jsonc format versions and validates
+// files against the JSON Schema published for each version.
+//
+// The schemas themselves are registered with package jsonschema, which
+// parses the JSONC and reports problems with their line and column.
+package taskschema
@@ -0,0 +1,139 @@
+package taskschema
+
+import (
+	"errors"
+
+	"github.com/connerohnesorge/spectr/internal/jsonschema"
+	"github.com/connerohnesorge/spectr/internal/parsers"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+// tasks is the schema registry entry for tasks.jsonc.
+var tasks = jsonschema.MustLookup("tasks")
+
+// Versions returns the supported tasks.jsonc versions, oldest first.
+func Versions() []int {
+	return []int{
+		parsers.TasksVersionFlat,
+		parsers.TasksVersionHierarchical,
+	}
+}
+
+// Schema returns the JSON Schema document for version.
+func Schema(version int) ([]byte, error) {
+	if version < parsers.TasksVersionFlat {
+		return nil, &specterrs.UnsupportedTasksVersionError{
+			Path:    "schema",
+			Version: version,
+			Latest:  parsers.LatestTasksVersion,
+		}
+	}
+	data, err := tasks.Schema(version)
+	if err != nil {
+		return nil, &specterrs.UnsupportedTasksVersionError{
+			Path:    "schema",
+			Version: version,
+			Latest:  parsers.LatestTasksVersion,
+		}
+	}
+
+	return data, nil
+}
+
+// SchemaFileName returns the published file name of the schema for
+// version, e.g. "tasks-v2.schema.json".
+func SchemaFileName(version int) string {
+	return tasks.SchemaFile(version)
+}
+
+// DetectVersion returns the version declared by a tasks.jsonc document.
+// A missing version is version 1, matching files from early releases.
+// Versions newer than parsers.LatestTasksVersion are rejected with
+// *specterrs.UnsupportedTasksVersionError.
+func DetectVersion(path string, data []byte) (int, error) {
+	doc, err := jsonschema.ParseJSONC(path, data)
+	if err != nil {
+		return 0, err
+	}
+
+	return detectVersion(path, doc)
+}
+
+func detectVersion(path string, doc *jsonschema.Value) (int, error) {
+	field := doc.Get("version")
+	if field == nil {
+		return parsers.TasksVersionFlat, nil
+	}
+
+	version, err := field.Number.Int64()
+	if field.Kind != jsonschema.KindNumber || err != nil ||
+		version < parsers.TasksVersionFlat {
+		return 0, &specterrs.TasksSchemaError{
+			Path:    path,
+			Version: 0,
+			Problems: []specterrs.SchemaProblem{{
+				Path:    "version",
+				Message: "must be a positive integer",
+				Line:    field.Line,
+				Column:  field.Column,
+			}},
+		}
+	}
+	if version > parsers.LatestTasksVersion {
+		return 0, &specterrs.UnsupportedTasksVersionError{
+			Path:    path,
+			Version: int(version),
+			Latest:  parsers.LatestTasksVersion,
+		}
+	}
+
+	return int(version), nil
+}
+
+// Validate checks a tasks.jsonc document (comments allowed) against the
+// schema of the version it declares and returns that version. Schema
+// violations are reported as *specterrs.TasksSchemaError listing every
+// problem found with its line and column.
+func Validate(path string, data []byte) (int, error) {
+	doc, err := jsonschema.ParseJSONC(path, data)
+	if err != nil {
+		return 0, err
+	}
+
+	version, err := detectVersion(path, doc)
+	if err != nil {
+		return 0, err
+	}
+
+	return version, validateDoc(path, doc, version)
+}
+
+// ValidateVersion checks a tasks.jsonc document against the schema of the
+// given version, regardless of the version it declares.
+func ValidateVersion(path string, data []byte, version int) error {
+	doc, err := jsonschema.ParseJSONC(path, data)
+	if err != nil {
+		return err
+	}
+
+	return validateDoc(path, doc, version)
+}
+
+func validateDoc(path string, doc *jsonschema.Value, version int) error {
+	if _, err := Schema(version); err != nil {
+		return err
+	}
+
+	err := tasks.Validate(path, doc, version)
+
+	var schemaErr *specterrs.SchemaError
+	if errors.As(err, &schemaErr) {
+		return &specterrs.TasksSchemaError{
+			Path:     path,
+			Version:  version,
+			Problems: schemaErr.Problems,
+		}
+	}
+
+	return err
+}
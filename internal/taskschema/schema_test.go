@@ -0,0 +1,143 @@
+package taskschema
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		wantVersion int
+		wantProblem string // Empty means valid
+	}{
+		{
+			name: "v1",
+			content: `// header comment
+{
+  "version": 1,
+  "tasks": [
+    {"id": "1.1", "section": "Impl", "description": "Do it", "status": "pending"}
+  ]
+}`,
+			wantVersion: 1,
+		},
+		{
+			name:        "v1 without version",
+			content:     `{"tasks": []}`,
+			wantVersion: 1,
+		},
+		{
+			name: "v2 root",
+			content: `{
+  "version": 2,
+  "tasks": [
+    {"id": "1", "section": "Impl", "description": "Impl tasks", "status": "completed", "children": "$ref:tasks-1.jsonc"}
+  ],
+  "includes": ["tasks-*.jsonc"]
+}`,
+			wantVersion: 2,
+		},
+		{
+			name:        "v2 child",
+			content:     `{"version": 2, "parent": "1", "tasks": []}`,
+			wantVersion: 2,
+		},
+		{
+			name: "bad status",
+			content: `{"version": 1, "tasks": [
+  {"id": "1.1", "section": "Impl", "description": "Do it", "status": "done"}
+]}`,
+			wantVersion: 1,
+			wantProblem: `line 2, col 70: tasks[0].status: must be one of "pending", "in_progress", "completed"`,
+		},
+		{
+			name:        "missing field",
+			content:     `{"version": 1, "tasks": [{"id": "1.1", "section": "", "status": "pending"}]}`,
+			wantVersion: 1,
+			wantProblem: "tasks[0].description: is required",
+		},
+		{
+			name:        "children not allowed in v1",
+			content:     `{"version": 1, "tasks": [{"id": "1", "section": "", "description": "x", "status": "pending", "children": "$ref:tasks-1.jsonc"}]}`,
+			wantVersion: 1,
+			wantProblem: "tasks[0].children: is not allowed",
+		},
+		{
+			name:        "bad children ref",
+			content:     `{"version": 2, "tasks": [{"id": "1", "section": "", "description": "x", "status": "pending", "children": "tasks-1.jsonc"}]}`,
+			wantVersion: 2,
+			wantProblem: "tasks[0].children: must match",
+		},
+		{
+			name:        "v2 requires tasks",
+			content:     `{"version": 2}`,
+			wantVersion: 2,
+			wantProblem: "tasks: is required",
+		},
+		{
+			name:        "tasks not an array",
+			content:     `{"version": 1, "tasks": {}}`,
+			wantVersion: 1,
+			wantProblem: "tasks: must be an array",
+		},
+		{
+			name:        "negative summary",
+			content:     `{"version": 2, "tasks": [], "summary": {"total": -1}}`,
+			wantVersion: 2,
+			wantProblem: "summary.total: must be at least 0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version, err := Validate("tasks.jsonc", []byte(tt.content))
+			if version != tt.wantVersion {
+				t.Errorf("version = %d, want %d", version, tt.wantVersion)
+			}
+
+			if tt.wantProblem == "" {
+				if err != nil {
+					t.Errorf("Validate error: %v", err)
+				}
+
+				return
+			}
+
+			var schemaErr *specterrs.TasksSchemaError
+			if !errors.As(err, &schemaErr) {
+				t.Fatalf("error = %v, want TasksSchemaError", err)
+			}
+			if !strings.Contains(err.Error(), tt.wantProblem) {
+				t.Errorf("error = %q, want it to contain %q", err, tt.wantProblem)
+			}
+		})
+	}
+}
+
+func TestDetectVersion_Unsupported(t *testing.T) {
+	_, err := DetectVersion("tasks.jsonc", []byte(`{"version": 3, "tasks": []}`))
+
+	var versionErr *specterrs.UnsupportedTasksVersionError
+	if !errors.As(err, &versionErr) {
+		t.Fatalf("error = %v, want UnsupportedTasksVersionError", err)
+	}
+	if !strings.Contains(err.Error(), "version 3") {
+		t.Errorf("error = %q does not name the version", err)
+	}
+}
+
+func TestDetectVersion_Invalid(t *testing.T) {
+	for _, content := range []string{`{"version": 0}`, `{"version": 1.5}`} {
+		_, err := DetectVersion("tasks.jsonc", []byte(content))
+
+		var schemaErr *specterrs.TasksSchemaError
+		if !errors.As(err, &schemaErr) {
+			t.Errorf("DetectVersion(%s) error = %v, want TasksSchemaError", content, err)
+		}
+	}
+}
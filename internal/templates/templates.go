@@ -0,0 +1,265 @@
+// Package templates loads user templates that override spectr's generated
+// text, such as pull request bodies.
+//
+// Templates are text/template files named <name>.tmpl, looked up in an
+// ordered list of directories: the project's spectr/templates/ first,
+// then the directories listed under templates.dirs in spectr.yaml. Every
+// file in every directory is parsed into one set, so templates can include
+// each other and a project can override a single partial of a shared base.
+// Built-in templates are in the set too, under a "spectr/" prefix, so a
+// custom template can extend the default instead of copying it.
+//
+// Templates can read the environment and the templates.vars of
+// spectr.yaml:
+//
+//	{{env "TEAM"}}            environment variable
+//	{{var "team"}}            templates.vars entry
+//	{{var "team" "core"}}     with a fallback
+//	{{include "footer.md" .}} another template, as a string
+//
+// In strict mode (templates.strict) reading an undefined variable without
+// a fallback fails the render instead of producing an empty string.
+package templates
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/connerohnesorge/spectr/internal/config"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+// Ext is the file extension of template files.
+const Ext = ".tmpl"
+
+// ProjectDir is the template directory inside spectr/.
+const ProjectDir = "templates"
+
+// BuiltinPrefix prefixes the names of built-in templates.
+const BuiltinPrefix = "spectr/"
+
+// Options configure a template set.
+type Options struct {
+	// Dirs are searched in order; a template in an earlier directory
+	// overrides one with the same name in a later one.
+	Dirs []string
+	// Vars are the values of {{var}}.
+	Vars map[string]string
+	// Strict makes undefined variables an error.
+	Strict bool
+	// LookupEnv reads environment variables; nil uses os.LookupEnv.
+	LookupEnv func(string) (string, bool)
+}
+
+// FromConfig returns the options for the project at projectRoot. Relative
+// directories in cfg are resolved against the directory of spectr.yaml.
+func FromConfig(projectRoot string, cfg *config.Config) (Options, error) {
+	settings := cfg.TemplateSettings()
+	opts := Options{
+		Dirs:   []string{filepath.Join(projectRoot, "spectr", ProjectDir)},
+		Vars:   make(map[string]string, len(settings.Vars)),
+		Strict: settings.Strict,
+	}
+
+	base := cfg.Dir()
+	if base == "" {
+		base = projectRoot
+	}
+	for _, dir := range settings.Dirs {
+		expanded, err := opts.expand(dir)
+		if err != nil {
+			return Options{}, fmt.Errorf("templates.dirs: %w", err)
+		}
+		if !filepath.IsAbs(expanded) {
+			expanded = filepath.Join(base, expanded)
+		}
+		opts.Dirs = append(opts.Dirs, expanded)
+	}
+	for name, value := range settings.Vars {
+		expanded, err := opts.expand(value)
+		if err != nil {
+			return Options{}, fmt.Errorf("templates.vars.%s: %w", name, err)
+		}
+		opts.Vars[name] = expanded
+	}
+
+	return opts, nil
+}
+
+// Set is a parsed set of built-in and user templates.
+type Set struct {
+	opts Options
+	tmpl *template.Template
+	user map[string]bool
+}
+
+// New parses builtins, keyed by name without BuiltinPrefix, and then every
+// template file in opts.Dirs. Missing directories are skipped.
+func New(opts Options, builtins map[string]string) (*Set, error) {
+	if opts.LookupEnv == nil {
+		opts.LookupEnv = os.LookupEnv
+	}
+	s := &Set{opts: opts, user: make(map[string]bool)}
+	s.tmpl = template.New("").Funcs(template.FuncMap{
+		"env":     s.env,
+		"var":     s.variable,
+		"include": s.include,
+	})
+	if opts.Strict {
+		s.tmpl.Option("missingkey=error")
+	}
+
+	names := make([]string, 0, len(builtins))
+	for name := range builtins {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		if _, err := s.tmpl.New(BuiltinPrefix + name).Parse(builtins[name]); err != nil {
+			return nil, fmt.Errorf("parse built-in template %s: %w", name, err)
+		}
+	}
+
+	// Parse the lowest-priority directory first so earlier ones override
+	for i := len(opts.Dirs) - 1; i >= 0; i-- {
+		if err := s.parseDir(opts.Dirs[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// Load returns the template set of the project at projectRoot.
+func Load(projectRoot string, builtins map[string]string) (*Set, error) {
+	cfg, err := config.LoadConfig(projectRoot)
+	if err != nil {
+		return nil, err
+	}
+	opts, err := FromConfig(projectRoot, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return New(opts, builtins)
+}
+
+// Has reports whether a template file provides name.
+func (s *Set) Has(name string) bool {
+	return s.user[name]
+}
+
+// Render executes the template called name with data.
+func (s *Set) Render(name string, data any) (string, error) {
+	var buf bytes.Buffer
+	if err := s.tmpl.ExecuteTemplate(&buf, name, data); err != nil {
+		return "", fmt.Errorf("render template %s: %w", name, err)
+	}
+
+	return buf.String(), nil
+}
+
+// parseDir parses the template files directly inside dir.
+func (s *Set) parseDir(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read template directory %s: %w", dir, err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), Ext) {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read template %s: %w", path, err)
+		}
+
+		name := strings.TrimSuffix(entry.Name(), Ext)
+		if _, err := s.tmpl.New(name).Parse(string(content)); err != nil {
+			return fmt.Errorf("parse template %s: %w", path, err)
+		}
+		s.user[name] = true
+	}
+
+	return nil
+}
+
+// env implements {{env "NAME" ["fallback"]}}.
+func (s *Set) env(name string, fallback ...string) (string, error) {
+	if value, ok := s.opts.LookupEnv(name); ok {
+		return value, nil
+	}
+
+	return s.undefined("env", name, fallback)
+}
+
+// variable implements {{var "name" ["fallback"]}}.
+func (s *Set) variable(name string, fallback ...string) (string, error) {
+	if value, ok := s.opts.Vars[name]; ok {
+		return value, nil
+	}
+
+	return s.undefined("var", name, fallback)
+}
+
+// undefined returns the fallback of an unset variable, or an error in
+// strict mode when there is none.
+func (s *Set) undefined(kind, name string, fallback []string) (string, error) {
+	if len(fallback) > 0 {
+		return fallback[0], nil
+	}
+	if s.opts.Strict {
+		return "", &specterrs.UndefinedTemplateVariableError{Kind: kind, Name: name}
+	}
+
+	return "", nil
+}
+
+// include implements {{include "name" data}}.
+func (s *Set) include(name string, data any) (string, error) {
+	if s.tmpl.Lookup(name) == nil {
+		return "", &specterrs.TemplateNotFoundError{Name: name, Dirs: s.opts.Dirs}
+	}
+
+	var buf bytes.Buffer
+	if err := s.tmpl.ExecuteTemplate(&buf, name, data); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+// expand replaces ${VAR} and $VAR with environment variables.
+func (o Options) expand(value string) (string, error) {
+	lookup := o.LookupEnv
+	if lookup == nil {
+		lookup = os.LookupEnv
+	}
+
+	var missing []string
+	expanded := os.Expand(value, func(name string) string {
+		v, ok := lookup(name)
+		if !ok {
+			missing = append(missing, name)
+		}
+
+		return v
+	})
+	if o.Strict && len(missing) > 0 {
+		return "", &specterrs.UndefinedTemplateVariableError{Kind: "env", Name: missing[0]}
+	}
+
+	return expanded, nil
+}
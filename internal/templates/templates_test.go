@@ -0,0 +1,161 @@
+package templates
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/connerohnesorge/spectr/internal/config"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+// writeTemplate writes dir/name.tmpl.
+func writeTemplate(t *testing.T, dir, name, content string) {
+	t.Helper()
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name+Ext), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// fakeEnv returns a LookupEnv over vars.
+func fakeEnv(vars map[string]string) func(string) (string, bool) {
+	return func(name string) (string, bool) {
+		v, ok := vars[name]
+
+		return v, ok
+	}
+}
+
+func TestSet_LayeredIncludes(t *testing.T) {
+	base := t.TempDir()
+	org := filepath.Join(base, "org")
+	team := filepath.Join(base, "team")
+
+	// The org base includes a section the team overrides
+	writeTemplate(t, org, "pr-body.md",
+		"{{template \"spectr/default\" .}}\n{{include \"team.md\" .}}\n{{template \"footer\" .}}")
+	writeTemplate(t, org, "team.md", "No team section")
+	writeTemplate(t, org, "footer", `{{define "footer"}}-- {{var "org"}}{{end}}`)
+	writeTemplate(t, team, "team.md", "## {{var \"team\"}} checks for {{.ChangeID}} by {{env \"USER\"}}")
+
+	set, err := New(Options{
+		Dirs:      []string{team, org},
+		Vars:      map[string]string{"org": "acme", "team": "Payments"},
+		LookupEnv: fakeEnv(map[string]string{"USER": "dana"}),
+	}, map[string]string{"default": "Change {{.ChangeID}}"})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if !set.Has("pr-body.md") || set.Has("spectr/default") {
+		t.Errorf("Has() reports built-ins as user templates")
+	}
+
+	got, err := set.Render("pr-body.md", map[string]string{"ChangeID": "add-mfa"})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	want := "Change add-mfa\n## Payments checks for add-mfa by dana\n-- acme"
+	if got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+}
+
+func TestSet_Strict(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		strict  bool
+		want    string
+		wantErr bool
+	}{
+		{"lenient var", `[{{var "team"}}]`, false, "[]", false},
+		{"lenient env", `[{{env "TEAM"}}]`, false, "[]", false},
+		{"strict var", `[{{var "team"}}]`, true, "", true},
+		{"strict env", `[{{env "TEAM"}}]`, true, "", true},
+		{"strict fallback", `[{{var "team" "core"}}]`, true, "[core]", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeTemplate(t, dir, "body", tt.content)
+
+			set, err := New(Options{
+				Dirs:      []string{dir},
+				Strict:    tt.strict,
+				LookupEnv: fakeEnv(nil),
+			}, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := set.Render("body", nil)
+			var undefined *specterrs.UndefinedTemplateVariableError
+			if tt.wantErr != errors.As(err, &undefined) {
+				t.Fatalf("Render() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSet_IncludeMissing(t *testing.T) {
+	dir := t.TempDir()
+	writeTemplate(t, dir, "body", `{{include "missing" .}}`)
+
+	set, err := New(Options{Dirs: []string{dir}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = set.Render("body", nil)
+	var notFound *specterrs.TemplateNotFoundError
+	if !errors.As(err, &notFound) || notFound.Name != "missing" {
+		t.Errorf("Render() error = %v, want TemplateNotFoundError", err)
+	}
+}
+
+func TestFromConfig(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv("SPECTR_TEST_ORG", "/srv/acme")
+	cfgPath := filepath.Join(root, "spectr.yaml")
+	yaml := "templates:\n  dirs: [\"${SPECTR_TEST_ORG}/templates\", shared]\n" +
+		"  vars:\n    org: \"${SPECTR_TEST_ORG}\"\n"
+	if err := os.WriteFile(cfgPath, []byte(yaml), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := config.LoadConfig(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	opts, err := FromConfig(root, cfg)
+	if err != nil {
+		t.Fatalf("FromConfig() error = %v", err)
+	}
+	want := []string{
+		filepath.Join(root, "spectr", ProjectDir),
+		"/srv/acme/templates",
+		filepath.Join(root, "shared"),
+	}
+	if strings.Join(opts.Dirs, "|") != strings.Join(want, "|") {
+		t.Errorf("Dirs = %v, want %v", opts.Dirs, want)
+	}
+	if opts.Vars["org"] != "/srv/acme" {
+		t.Errorf("Vars = %v", opts.Vars)
+	}
+
+	cfg.Templates.Strict = true
+	cfg.Templates.Dirs = []string{"${SPECTR_TEST_UNSET_DIR}"}
+	if _, err := FromConfig(root, cfg); err == nil {
+		t.Error("FromConfig() with an unset variable in strict mode succeeded")
+	}
+}
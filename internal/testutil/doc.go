@@ -0,0 +1,4 @@
+// Package testutil holds helpers shared by the tests of other packages,
+// such as writing fixture files into a temporary project. It is imported
+// from _test.go files only.
+package testutil
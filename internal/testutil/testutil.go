@@ -0,0 +1,37 @@
+package testutil
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Fixture permissions.
+const (
+	dirPerm  = 0o755
+	filePerm = 0o644
+)
+
+// WriteFile writes content to path, creating parent directories.
+func WriteFile(t testing.TB, path, content string) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(path), dirPerm); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), filePerm); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// ReadFile returns the content of path.
+func ReadFile(t testing.TB, path string) string {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(data)
+}
@@ -0,0 +1,16 @@
+package testutil
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteFileCreatesParents(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "spectr", "specs", "auth", "spec.md")
+
+	WriteFile(t, path, "# Auth\n")
+
+	if got := ReadFile(t, path); got != "# Auth\n" {
+		t.Errorf("ReadFile() = %q, want %q", got, "# Auth\n")
+	}
+}
@@ -0,0 +1,6 @@
+// Package tokens estimates how many tokens a text takes up in a language
+// model's context. Tokenizers differ between model families, so estimates
+// come from per-model presets of average characters per token rather than
+// from any particular tokenizer; expect them to be within about 10-20% for
+// English prose and markdown.
+package tokens
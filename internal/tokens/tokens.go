@@ -0,0 +1,77 @@
+package tokens
+
+import (
+	"math"
+	"sort"
+	"unicode/utf8"
+
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+// DefaultModel is the preset used when no model is named.
+const DefaultModel = "default"
+
+// Model is a tokenizer preset.
+type Model struct {
+	// Name is the preset name accepted by Lookup
+	Name string
+	// CharsPerToken is the average number of ASCII characters per token
+	CharsPerToken float64
+}
+
+// presets holds the known models. Non-ASCII characters are counted as one
+// token each by every preset, which is close for CJK text and errs on the
+// high side for accented Latin text.
+var presets = map[string]Model{
+	DefaultModel: {Name: DefaultModel, CharsPerToken: 4},
+	"gpt":        {Name: "gpt", CharsPerToken: 4},
+	"claude":     {Name: "claude", CharsPerToken: 3.5},
+	"gemini":     {Name: "gemini", CharsPerToken: 4},
+	"llama":      {Name: "llama", CharsPerToken: 3.8},
+}
+
+// Lookup returns the preset called name; an empty name selects
+// DefaultModel.
+func Lookup(name string) (Model, error) {
+	if name == "" {
+		name = DefaultModel
+	}
+	model, ok := presets[name]
+	if !ok {
+		return Model{}, &specterrs.UnknownModelError{
+			Name:  name,
+			Known: Names(),
+		}
+	}
+
+	return model, nil
+}
+
+// Names returns the preset names in alphabetical order.
+func Names() []string {
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Estimate returns the estimated number of tokens in text.
+func (m Model) Estimate(text string) int {
+	ascii, other := 0, 0
+	for i := 0; i < len(text); {
+		if text[i] < utf8.RuneSelf {
+			ascii++
+			i++
+
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(text[i:])
+		other++
+		i += size
+	}
+
+	return int(math.Ceil(float64(ascii)/m.CharsPerToken)) + other
+}
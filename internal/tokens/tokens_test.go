@@ -0,0 +1,50 @@
+package tokens
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+func TestEstimate(t *testing.T) {
+	tests := []struct {
+		model string
+		text  string
+		want  int
+	}{
+		{"default", "", 0},
+		{"default", "abcd", 1},
+		{"default", "abcde", 2},
+		{"claude", strings.Repeat("a", 35), 10},
+		{"default", "日本語", 3},
+		{"default", "café", 2},
+	}
+
+	for _, tt := range tests {
+		model, err := Lookup(tt.model)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := model.Estimate(tt.text); got != tt.want {
+			t.Errorf("%s.Estimate(%q) = %d, want %d", tt.model, tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestLookup(t *testing.T) {
+	model, err := Lookup("")
+	if err != nil || model.Name != DefaultModel {
+		t.Errorf("Lookup(\"\") = %+v, %v; want the default preset", model, err)
+	}
+
+	_, err = Lookup("gpt-17")
+	var unknown *specterrs.UnknownModelError
+	if !errors.As(err, &unknown) {
+		t.Fatalf("expected UnknownModelError, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "claude, default, gemini, gpt, llama") {
+		t.Errorf("error should list presets: %v", err)
+	}
+}
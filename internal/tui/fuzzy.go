@@ -0,0 +1,105 @@
+package tui
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Fuzzy match scoring. Matches that run together or start words rank
+// above scattered ones, so "lo" prefers "Login" over "Allow guests".
+const (
+	fuzzyMatchScore       = 1
+	fuzzyConsecutiveBonus = 4
+	fuzzyWordStartBonus   = 6
+)
+
+// FuzzyMatch reports whether every character of query appears in s in
+// order, ignoring case, and returns the score of the best such alignment,
+// where higher is a better match. An empty query matches everything with
+// a score of zero.
+func FuzzyMatch(query, s string) (score int, ok bool) {
+	q := []rune(strings.ToLower(query))
+	if len(q) == 0 {
+		return 0, true
+	}
+	runes := []rune(strings.ToLower(s))
+	if len(q) > len(runes) {
+		return 0, false
+	}
+
+	// prev[j] is the best score with the previous query character matched
+	// at j; best is the best of prev[:j-1], which a match at j can follow
+	// without being consecutive.
+	const none = -1
+	prev := make([]int, len(runes))
+	cur := make([]int, len(runes))
+	for j := range runes {
+		prev[j] = none
+		if runes[j] == q[0] {
+			prev[j] = fuzzyMatchScore + wordStartBonus(runes, j)
+		}
+	}
+	for qi := 1; qi < len(q); qi++ {
+		best := none
+		for j := range runes {
+			cur[j] = none
+			if j >= 2 && prev[j-2] > best {
+				best = prev[j-2]
+			}
+			if runes[j] != q[qi] || j == 0 {
+				continue
+			}
+			from := best
+			if prev[j-1] != none && prev[j-1]+fuzzyConsecutiveBonus > from {
+				from = prev[j-1] + fuzzyConsecutiveBonus
+			}
+			if from != none {
+				cur[j] = from + fuzzyMatchScore + wordStartBonus(runes, j)
+			}
+		}
+		prev, cur = cur, prev
+	}
+
+	score = none
+	for _, v := range prev {
+		score = max(score, v)
+	}
+
+	return max(score, 0), score != none
+}
+
+// wordStartBonus returns fuzzyWordStartBonus when runes[i] starts a word.
+func wordStartBonus(runes []rune, i int) int {
+	if i == 0 || !unicode.IsLetter(runes[i-1]) && !unicode.IsDigit(runes[i-1]) {
+		return fuzzyWordStartBonus
+	}
+
+	return 0
+}
+
+// FuzzyFilter returns the indexes of the labels matching query, best
+// match first. Labels with equal scores keep their order.
+func FuzzyFilter(query string, labels []string) []int {
+	type match struct {
+		index int
+		score int
+	}
+
+	var matches []match
+	for i, label := range labels {
+		if score, ok := FuzzyMatch(query, label); ok {
+			matches = append(matches, match{index: i, score: score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+
+	indexes := make([]int, len(matches))
+	for i, m := range matches {
+		indexes[i] = m.index
+	}
+
+	return indexes
+}
@@ -0,0 +1,53 @@
+package tui
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		query string
+		s     string
+		ok    bool
+	}{
+		{"", "anything", true},
+		{"pwre", "Password Reset", true},
+		{"PWRE", "password reset", true},
+		{"reset", "Password Reset", true},
+		{"rp", "Password Reset", false},
+		{"xyz", "Password Reset", false},
+	}
+
+	for _, tt := range tests {
+		if _, ok := FuzzyMatch(tt.query, tt.s); ok != tt.ok {
+			t.Errorf("FuzzyMatch(%q, %q) ok = %v, want %v", tt.query, tt.s, ok, tt.ok)
+		}
+	}
+}
+
+func TestFuzzyFilter_RanksWordStartsAndRuns(t *testing.T) {
+	labels := []string{
+		"Allow guests",
+		"Login",
+		"Session logout",
+		"Token refresh",
+	}
+
+	got := FuzzyFilter("lo", labels)
+	if want := []int{1, 2, 0}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FuzzyFilter(lo) = %v, want %v", got, want)
+	}
+
+	// The best alignment wins over the first one: "r" in "Reset", not in
+	// "Password"
+	score, _ := FuzzyMatch("pwre", "Password Reset")
+	if greedy := 1 + 6 + 1 + 1 + 1; score <= greedy {
+		t.Errorf("FuzzyMatch(pwre) = %d, want more than the greedy %d", score, greedy)
+	}
+
+	got = FuzzyFilter("", labels)
+	if want := []int{0, 1, 2, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FuzzyFilter(empty) = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,35 @@
+package tui
+
+import (
+	"os"
+	"sync/atomic"
+
+	"github.com/mattn/go-isatty"
+)
+
+// noInput is set by --no-input.
+var noInput atomic.Bool
+
+// SetNoInput turns prompts, pickers and other TUIs off for the process.
+func SetNoInput(off bool) {
+	noInput.Store(off)
+}
+
+// NoInput reports whether --no-input is set.
+func NoInput() bool {
+	return noInput.Load()
+}
+
+// Interactive reports whether spectr may open a TUI or prompt: --no-input
+// is off and both stdin and stdout are terminals. Commands that would
+// otherwise wait on a TUI must fall back to plain output or fail with a
+// *specterrs.MissingArgumentError or *specterrs.NoTerminalError.
+func Interactive() bool {
+	return !NoInput() && IsTerminal(os.Stdin) && IsTerminal(os.Stdout)
+}
+
+// IsTerminal reports whether f is a terminal, including Cygwin and MSYS
+// terminals on Windows.
+func IsTerminal(f *os.File) bool {
+	return isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd())
+}
@@ -0,0 +1,44 @@
+package tui
+
+import (
+	"os"
+	"testing"
+)
+
+func TestInteractive(t *testing.T) {
+	t.Cleanup(func() { SetNoInput(false) })
+
+	// go test runs without a terminal on stdin and stdout
+	tests := []struct {
+		name    string
+		noInput bool
+	}{
+		{"no terminal", false},
+		{"no terminal and --no-input", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetNoInput(tt.noInput)
+			if NoInput() != tt.noInput {
+				t.Errorf("NoInput() = %v, want %v", NoInput(), tt.noInput)
+			}
+			if Interactive() {
+				t.Error("Interactive() = true without a terminal")
+			}
+		})
+	}
+}
+
+func TestIsTerminal_Pipe(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+
+	if IsTerminal(r) || IsTerminal(w) {
+		t.Error("IsTerminal() = true for a pipe")
+	}
+}
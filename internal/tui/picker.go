@@ -0,0 +1,247 @@
+//nolint:revive // TUI code - interactive model patterns require specific structure
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// pickerHeight is the number of choices the TreePicker shows at once.
+const pickerHeight = 12
+
+// PickerNode is a choice in a TreePicker, with the choices offered once it
+// is picked.
+type PickerNode struct {
+	// Label is the text shown and matched against the filter.
+	Label string
+	// Children are the choices of the next level.
+	Children []PickerNode
+}
+
+// TreePickerConfig holds configuration for TreePicker.
+type TreePickerConfig struct {
+	// Titles are the prompts of each level, e.g. "Select a spec" then
+	// "Select a requirement". Their number is the depth picked.
+	Titles []string
+
+	// Roots are the choices of the first level.
+	Roots []PickerNode
+}
+
+// TreePicker picks a path through a tree of choices, one level at a time,
+// with a fuzzy filter typed at each level. It backs the requirement
+// picker used when a command is run without its requirement argument.
+type TreePicker struct {
+	titles []string
+	roots  []PickerNode
+
+	// path holds the index of the node picked at each finished level.
+	path []int
+	// query is the filter typed at the current level.
+	query string
+	// filtered are the indexes of the current level's nodes matching query.
+	filtered []int
+	// cursor is the highlighted position in filtered.
+	cursor int
+	// done is set once the last level is picked.
+	done bool
+	// quitting indicates the picker was cancelled.
+	quitting bool
+}
+
+// NewTreePicker creates a TreePicker with the given configuration.
+func NewTreePicker(config TreePickerConfig) *TreePicker {
+	m := &TreePicker{
+		titles: config.Titles,
+		roots:  config.Roots,
+	}
+	m.refilter()
+
+	return m
+}
+
+// Init implements tea.Model.
+func (*TreePicker) Init() tea.Cmd {
+	return nil
+}
+
+// Update implements tea.Model.
+func (m *TreePicker) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch keyMsg.Type {
+	case tea.KeyCtrlC:
+		m.quitting = true
+
+		return m, tea.Quit
+	case tea.KeyEsc:
+		switch {
+		case m.query != "":
+			m.setQuery("")
+		case len(m.path) > 0:
+			m.back()
+		default:
+			m.quitting = true
+
+			return m, tea.Quit
+		}
+	case tea.KeyUp, tea.KeyCtrlP:
+		m.cursor = maxInt(0, m.cursor-1)
+	case tea.KeyDown, tea.KeyCtrlN:
+		m.cursor = minInt(len(m.filtered)-1, m.cursor+1)
+	case tea.KeyBackspace:
+		if m.query == "" {
+			m.back()
+		} else {
+			runes := []rune(m.query)
+			m.setQuery(string(runes[:len(runes)-1]))
+		}
+	case tea.KeySpace:
+		m.setQuery(m.query + " ")
+	case tea.KeyRunes:
+		m.setQuery(m.query + string(keyMsg.Runes))
+	case tea.KeyEnter:
+		return m.pick()
+	}
+
+	return m, nil
+}
+
+// pick descends into the highlighted node, or finishes on the last level.
+// Nodes without children cannot be picked before the last level.
+func (m *TreePicker) pick() (tea.Model, tea.Cmd) {
+	if len(m.filtered) == 0 {
+		return m, nil
+	}
+
+	index := m.filtered[m.cursor]
+	if len(m.path)+1 == len(m.titles) {
+		m.path = append(m.path, index)
+		m.done = true
+
+		return m, tea.Quit
+	}
+	if len(m.level()[index].Children) == 0 {
+		return m, nil
+	}
+
+	m.path = append(m.path, index)
+	m.setQuery("")
+
+	return m, nil
+}
+
+// back returns to the previous level, highlighting the node picked there.
+func (m *TreePicker) back() {
+	if len(m.path) == 0 {
+		return
+	}
+
+	last := m.path[len(m.path)-1]
+	m.path = m.path[:len(m.path)-1]
+	m.setQuery("")
+	for i, index := range m.filtered {
+		if index == last {
+			m.cursor = i
+		}
+	}
+}
+
+// level returns the nodes offered at the current level.
+func (m *TreePicker) level() []PickerNode {
+	nodes := m.roots
+	for _, index := range m.path {
+		nodes = nodes[index].Children
+	}
+
+	return nodes
+}
+
+// setQuery changes the filter and moves the cursor to the best match.
+func (m *TreePicker) setQuery(query string) {
+	m.query = query
+	m.refilter()
+}
+
+// refilter recomputes the choices matching the query.
+func (m *TreePicker) refilter() {
+	nodes := m.level()
+	labels := make([]string, len(nodes))
+	for i, node := range nodes {
+		labels[i] = node.Label
+	}
+	m.filtered = FuzzyFilter(m.query, labels)
+	m.cursor = 0
+}
+
+// View implements tea.Model.
+func (m *TreePicker) View() string {
+	if m.quitting || m.done {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString(TitleStyle().Render(m.titles[len(m.path)]) + "\n")
+	if crumbs := m.Selection(); len(crumbs) > 0 {
+		sb.WriteString(HelpStyle().UnsetMarginTop().Render(strings.Join(crumbs, " › ")) + "\n")
+	}
+	sb.WriteString("> " + m.query + "\n\n")
+
+	nodes := m.level()
+	start := maxInt(0, minInt(m.cursor-pickerHeight/2, len(m.filtered)-pickerHeight))
+	end := minInt(len(m.filtered), start+pickerHeight)
+	for i := start; i < end; i++ {
+		label := nodes[m.filtered[i]].Label
+		if i == m.cursor {
+			sb.WriteString(SelectedStyle().Render("> "+label) + "\n")
+		} else {
+			sb.WriteString(ChoiceStyle().Render("  "+label) + "\n")
+		}
+	}
+	if len(m.filtered) == 0 {
+		sb.WriteString(ChoiceStyle().Render("  (no matches)") + "\n")
+	}
+
+	helpText := fmt.Sprintf(
+		"%d/%d | type to filter | ↑/↓: navigate | Enter: select | Esc: back",
+		len(m.filtered),
+		len(nodes),
+	)
+	sb.WriteString(HelpStyle().Render(helpText))
+
+	return sb.String()
+}
+
+// Selection returns the labels of the nodes picked so far, from the first
+// level down.
+func (m *TreePicker) Selection() []string {
+	labels := make([]string, 0, len(m.path))
+	nodes := m.roots
+	for _, index := range m.path {
+		labels = append(labels, nodes[index].Label)
+		nodes = nodes[index].Children
+	}
+
+	return labels
+}
+
+// Run runs the TreePicker and returns the labels picked at each level, or
+// nil if cancelled.
+func (m *TreePicker) Run() ([]string, error) {
+	finalModel, err := tea.NewProgram(m).Run()
+	if err != nil {
+		return nil, fmt.Errorf("error running picker: %w", err)
+	}
+
+	fm, ok := finalModel.(*TreePicker)
+	if !ok || !fm.done {
+		return nil, nil
+	}
+
+	return fm.Selection(), nil
+}
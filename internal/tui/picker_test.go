@@ -0,0 +1,105 @@
+//nolint:revive // test file
+package tui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func testPicker() *TreePicker {
+	return NewTreePicker(TreePickerConfig{
+		Titles: []string{"Select a spec", "Select a requirement"},
+		Roots: []PickerNode{
+			{Label: "auth", Children: []PickerNode{
+				{Label: "Login"},
+				{Label: "Password Reset"},
+			}},
+			{Label: "empty"},
+			{Label: "billing", Children: []PickerNode{
+				{Label: "Invoices"},
+			}},
+		},
+	})
+}
+
+func sendKeys(t *testing.T, m *TreePicker, msgs ...tea.KeyMsg) tea.Cmd {
+	t.Helper()
+
+	var cmd tea.Cmd
+	for _, msg := range msgs {
+		var model tea.Model
+		model, cmd = m.Update(msg)
+		if model != m {
+			t.Fatal("Update returned a different model")
+		}
+	}
+
+	return cmd
+}
+
+func typeText(text string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(text)}
+}
+
+func TestTreePicker_PicksPath(t *testing.T) {
+	m := testPicker()
+
+	cmd := sendKeys(t, m,
+		tea.KeyMsg{Type: tea.KeyEnter},
+		typeText("pwre"),
+		tea.KeyMsg{Type: tea.KeyEnter},
+	)
+
+	if !m.done || cmd == nil {
+		t.Fatal("picker should finish after the last level")
+	}
+	if got, want := m.Selection(), []string{"auth", "Password Reset"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Selection = %v, want %v", got, want)
+	}
+}
+
+func TestTreePicker_FilterAndNavigate(t *testing.T) {
+	m := testPicker()
+
+	sendKeys(t, m, typeText("b"))
+	if len(m.filtered) != 1 || m.level()[m.filtered[0]].Label != "billing" {
+		t.Fatalf("filter b = %v", m.filtered)
+	}
+
+	sendKeys(t, m, tea.KeyMsg{Type: tea.KeyBackspace}, tea.KeyMsg{Type: tea.KeyDown})
+	if m.query != "" || m.cursor != 1 {
+		t.Errorf("query = %q, cursor = %d; want empty, 1", m.query, m.cursor)
+	}
+
+	// A node without children cannot be entered before the last level
+	sendKeys(t, m, tea.KeyMsg{Type: tea.KeyEnter})
+	if len(m.path) != 0 {
+		t.Errorf("entered %v, want to stay on the first level", m.Selection())
+	}
+}
+
+func TestTreePicker_BackAndCancel(t *testing.T) {
+	m := testPicker()
+
+	sendKeys(t, m, tea.KeyMsg{Type: tea.KeyDown}, tea.KeyMsg{Type: tea.KeyDown})
+	sendKeys(t, m, tea.KeyMsg{Type: tea.KeyEnter})
+	if got := m.Selection(); !reflect.DeepEqual(got, []string{"billing"}) {
+		t.Fatalf("Selection = %v, want [billing]", got)
+	}
+	if !strings.Contains(m.View(), "Invoices") {
+		t.Error("view should list the requirements of billing")
+	}
+
+	sendKeys(t, m, tea.KeyMsg{Type: tea.KeyEsc})
+	if len(m.path) != 0 || m.cursor != 2 {
+		t.Errorf("after Esc path = %v, cursor = %d; want root level on billing", m.path, m.cursor)
+	}
+
+	cmd := sendKeys(t, m, tea.KeyMsg{Type: tea.KeyEsc})
+	if !m.quitting || cmd == nil || m.View() != "" {
+		t.Error("Esc on the first level should cancel")
+	}
+}
@@ -390,10 +390,7 @@ func (p *TablePicker) renderQuitView() string {
 	}
 
 	if p.result.Copied && p.result.Error == nil {
-		return fmt.Sprintf(
-			"✓ Copied: %s\n",
-			p.result.ID,
-		)
+		return p.result.CopyMethod.Message(p.result.ID, p.result.ID)
 	}
 
 	if p.result.Error != nil {
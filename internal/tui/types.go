@@ -3,6 +3,7 @@ package tui
 import (
 	"github.com/charmbracelet/bubbles/table"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/connerohnesorge/spectr/internal/clipboard"
 )
 
 // ActionHandler is a function that handles a key action on a selected row.
@@ -30,6 +31,9 @@ type ActionResult struct {
 	// Copied indicates an ID was copied to clipboard.
 	Copied bool
 
+	// CopyMethod is how the ID was copied, when Copied is set.
+	CopyMethod clipboard.Method
+
 	// ArchiveRequested indicates archive action was requested.
 	ArchiveRequested bool
 
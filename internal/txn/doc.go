@@ -0,0 +1,12 @@
+// Package txn applies multi-file operations as a unit.
+//
+// Callers register effects (file writes, moves, git commands, or custom
+// steps with an undo) on a Tx and then Commit it. Steps run in order; if
+// one fails, or the context is cancelled between steps, every step that
+// already ran is undone in reverse order. This keeps commands such as
+// archive from leaving a change half-moved or specs half-written.
+//
+// Rollback restores the filesystem, not the world: git commands are only
+// undone when the caller supplies undo arguments, and custom steps only
+// when they supply an undo function.
+package txn
@@ -0,0 +1,129 @@
+package txn
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// writeFile atomically replaces path with data and returns its undo.
+func writeFile(
+	path string,
+	data []byte,
+	perm os.FileMode,
+) (func() error, error) {
+	created, err := mkdirAll(filepath.Dir(path))
+	if err != nil {
+		return nil, err
+	}
+
+	previous, readErr := os.ReadFile(path)
+	existed := readErr == nil
+	prevPerm := perm
+	if info, statErr := os.Stat(path); statErr == nil {
+		prevPerm = info.Mode().Perm()
+	}
+
+	if err := replaceFile(path, data, perm); err != nil {
+		return nil, errors.Join(err, removeDirs(created))
+	}
+
+	return func() error {
+		if existed {
+			return replaceFile(path, previous, prevPerm)
+		}
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+
+		return removeDirs(created)
+	}, nil
+}
+
+// replaceFile writes data to a temporary file next to path and renames it
+// into place.
+func replaceFile(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(
+		filepath.Dir(path),
+		"."+filepath.Base(path)+".tmp-*",
+	)
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmpPath, perm)
+	}
+	if err == nil {
+		err = os.Rename(tmpPath, path)
+	}
+	if err != nil {
+		_ = os.Remove(tmpPath)
+
+		return err
+	}
+
+	return nil
+}
+
+// move renames from to to and returns its undo.
+func move(from, to string) (func() error, error) {
+	if _, err := os.Lstat(to); err == nil {
+		return nil, fmt.Errorf("destination already exists: %s", to)
+	}
+
+	created, err := mkdirAll(filepath.Dir(to))
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Rename(from, to); err != nil {
+		return nil, errors.Join(err, removeDirs(created))
+	}
+
+	return func() error {
+		if err := os.Rename(to, from); err != nil {
+			return err
+		}
+
+		return removeDirs(created)
+	}, nil
+}
+
+// mkdirAll creates dir and any missing parents, returning the directories
+// it created from deepest to shallowest.
+func mkdirAll(dir string) ([]string, error) {
+	var missing []string
+	for d := dir; ; d = filepath.Dir(d) {
+		if _, err := os.Stat(d); err == nil {
+			break
+		}
+		missing = append(missing, d)
+		if filepath.Dir(d) == d {
+			break
+		}
+	}
+
+	if err := os.MkdirAll(dir, dirPerm); err != nil {
+		return nil, err
+	}
+
+	return missing, nil
+}
+
+// removeDirs removes directories created by mkdirAll. It only removes
+// empty directories, so anything written there by others is left alone.
+func removeDirs(dirs []string) error {
+	for _, dir := range dirs {
+		if err := os.Remove(dir); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,134 @@
+package txn
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/connerohnesorge/spectr/internal/git"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+// dirPerm is the permission mode for directories created by a step.
+const dirPerm = 0o755
+
+// step is one registered effect. apply returns the function that reverts
+// it, or nil when there is nothing to revert.
+type step struct {
+	name  string
+	apply func(ctx context.Context) (undo func() error, err error)
+}
+
+// Tx is an ordered list of effects applied by Commit.
+type Tx struct {
+	steps []step
+}
+
+// New returns an empty transaction.
+func New() *Tx {
+	return &Tx{}
+}
+
+// Len returns the number of registered steps.
+func (t *Tx) Len() int {
+	return len(t.steps)
+}
+
+// WriteFile registers writing data to path, creating parent directories.
+// The write goes through a temporary file and a rename, so path never
+// holds partial content. Rollback restores the previous content or removes
+// the file.
+func (t *Tx) WriteFile(path string, data []byte, perm os.FileMode) {
+	t.add("write "+path, func(context.Context) (func() error, error) {
+		return writeFile(path, data, perm)
+	})
+}
+
+// Move registers renaming from to to, creating parent directories. The
+// destination must not exist. Rollback moves it back.
+func (t *Tx) Move(from, to string) {
+	t.add("move "+from+" to "+to, func(context.Context) (func() error, error) {
+		return move(from, to)
+	})
+}
+
+// Git registers running git with args in dir. If undoArgs is non-nil,
+// rollback runs git with undoArgs; otherwise the command is not reverted.
+func (t *Tx) Git(dir string, args, undoArgs []string) {
+	t.add(fmt.Sprintf("git %v", args), func(ctx context.Context) (func() error, error) {
+		if _, err := git.Run(ctx, dir, args...); err != nil {
+			return nil, git.Failure(err)
+		}
+		if undoArgs == nil {
+			return nil, nil
+		}
+
+		return func() error {
+			_, err := git.Run(context.Background(), dir, undoArgs...)
+
+			return err
+		}, nil
+	})
+}
+
+// Do registers a custom step. undo may be nil for steps that cannot or
+// need not be reverted, which is usually only safe for the last step.
+func (t *Tx) Do(name string, apply, undo func() error) {
+	t.add(name, func(context.Context) (func() error, error) {
+		if err := apply(); err != nil {
+			return nil, err
+		}
+
+		return undo, nil
+	})
+}
+
+func (t *Tx) add(
+	name string,
+	apply func(ctx context.Context) (func() error, error),
+) {
+	t.steps = append(t.steps, step{name: name, apply: apply})
+}
+
+// Commit applies the steps in order. On the first failure, or if ctx is
+// cancelled between steps, the applied steps are undone in reverse order
+// and a *specterrs.TransactionError is returned. A committed Tx is empty.
+func (t *Tx) Commit(ctx context.Context) error {
+	type applied struct {
+		name string
+		undo func() error
+	}
+	var done []applied
+
+	for _, s := range t.steps {
+		var undo func() error
+		err := ctx.Err()
+		if err == nil {
+			undo, err = s.apply(ctx)
+		}
+		if err != nil {
+			var rollbackErrs []error
+			for i := len(done) - 1; i >= 0; i-- {
+				if undoErr := done[i].undo(); undoErr != nil {
+					rollbackErrs = append(
+						rollbackErrs,
+						fmt.Errorf("undo %s: %w", done[i].name, undoErr),
+					)
+				}
+			}
+
+			return &specterrs.TransactionError{
+				Step:           s.name,
+				Err:            err,
+				RollbackErrors: rollbackErrs,
+			}
+		}
+		if undo != nil {
+			done = append(done, applied{name: s.name, undo: undo})
+		}
+	}
+
+	t.steps = nil
+
+	return nil
+}
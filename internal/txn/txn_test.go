@@ -0,0 +1,140 @@
+package txn
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+	"github.com/connerohnesorge/spectr/internal/testutil"
+)
+
+func TestCommitAppliesSteps(t *testing.T) {
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "existing.md")
+	if err := os.WriteFile(existing, []byte("old"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	src := filepath.Join(dir, "change")
+	if err := os.Mkdir(src, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	tx := New()
+	tx.WriteFile(existing, []byte("new"), 0o644)
+	tx.WriteFile(filepath.Join(dir, "a", "b", "spec.md"), []byte("spec"), 0o644)
+	tx.Move(src, filepath.Join(dir, "archive", "change"))
+	if err := tx.Commit(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := testutil.ReadFile(t, existing); got != "new" {
+		t.Errorf("existing = %q, want new", got)
+	}
+	if got := testutil.ReadFile(t, filepath.Join(dir, "a", "b", "spec.md")); got != "spec" {
+		t.Errorf("spec = %q", got)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "archive", "change")); err != nil {
+		t.Errorf("moved directory missing: %v", err)
+	}
+	if tx.Len() != 0 {
+		t.Errorf("Len() after commit = %d, want 0", tx.Len())
+	}
+}
+
+func TestCommitRollsBack(t *testing.T) {
+	failing := errors.New("boom")
+
+	tests := []struct {
+		name    string
+		cancel  bool
+		wantErr error
+	}{
+		{name: "failing step", wantErr: failing},
+		{name: "cancelled context", cancel: true, wantErr: context.Canceled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			existing := filepath.Join(dir, "existing.md")
+			if err := os.WriteFile(existing, []byte("old"), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			src := filepath.Join(dir, "change")
+			if err := os.Mkdir(src, 0o755); err != nil {
+				t.Fatal(err)
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			customUndone := false
+
+			tx := New()
+			tx.WriteFile(existing, []byte("new"), 0o644)
+			tx.WriteFile(filepath.Join(dir, "a", "b", "spec.md"), []byte("spec"), 0o644)
+			tx.Move(src, filepath.Join(dir, "archive", "change"))
+			tx.Do("custom", func() error {
+				if tt.cancel {
+					cancel()
+				}
+
+				return nil
+			}, func() error {
+				customUndone = true
+
+				return nil
+			})
+			tx.Do("last", func() error { return failing }, nil)
+
+			err := tx.Commit(ctx)
+			var txErr *specterrs.TransactionError
+			if !errors.As(err, &txErr) || !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Commit() error = %v, want TransactionError wrapping %v", err, tt.wantErr)
+			}
+			if txErr.Step != "last" || len(txErr.RollbackErrors) != 0 {
+				t.Errorf("TransactionError = %+v", txErr)
+			}
+
+			if got := testutil.ReadFile(t, existing); got != "old" {
+				t.Errorf("existing = %q, want old", got)
+			}
+			if info, err := os.Stat(existing); err != nil || info.Mode().Perm() != 0o600 {
+				t.Errorf("existing mode not restored: %v %v", info.Mode(), err)
+			}
+			for _, gone := range []string{"a", "archive"} {
+				if _, err := os.Stat(filepath.Join(dir, gone)); !os.IsNotExist(err) {
+					t.Errorf("%s not removed on rollback: %v", gone, err)
+				}
+			}
+			if _, err := os.Stat(src); err != nil {
+				t.Errorf("moved directory not restored: %v", err)
+			}
+			if !customUndone {
+				t.Error("custom step not undone")
+			}
+		})
+	}
+}
+
+func TestMoveRefusesExistingDestination(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "src")
+	dst := filepath.Join(dir, "dst")
+	for _, d := range []string{src, dst} {
+		if err := os.Mkdir(d, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tx := New()
+	tx.Move(src, dst)
+	if err := tx.Commit(context.Background()); err == nil {
+		t.Fatal("Commit() succeeded moving onto an existing directory")
+	}
+	if _, err := os.Stat(src); err != nil {
+		t.Errorf("source missing after failed move: %v", err)
+	}
+}
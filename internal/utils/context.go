@@ -0,0 +1,52 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+// CommandContext returns the context for a long-running command. It is
+// cancelled on interrupt (Ctrl-C) and, if timeout is positive, once
+// timeout has elapsed. Callers must call the returned cancel function.
+func CommandContext(
+	timeout time.Duration,
+) (context.Context, context.CancelFunc) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	if timeout <= 0 {
+		return ctx, stop
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+
+	return ctx, func() {
+		cancel()
+		stop()
+	}
+}
+
+// CommandError reports err from a command run under ctx. If ctx hit its
+// deadline, the error is a *specterrs.TimeoutError naming command, since
+// processes killed on timeout rarely explain why they failed.
+func CommandError(
+	ctx context.Context,
+	command string,
+	timeout time.Duration,
+	err error,
+) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return &specterrs.TimeoutError{
+			Command: command,
+			Timeout: timeout,
+		}
+	}
+
+	return err
+}
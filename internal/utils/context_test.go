@@ -0,0 +1,43 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+func TestCommandContext_Timeout(t *testing.T) {
+	ctx, cancel := CommandContext(time.Millisecond)
+	defer cancel()
+
+	<-ctx.Done()
+	if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		t.Fatalf("ctx.Err() = %v, want DeadlineExceeded", ctx.Err())
+	}
+
+	err := CommandError(ctx, "list", time.Millisecond, errors.New("killed"))
+	var timeoutErr *specterrs.TimeoutError
+	if !errors.As(err, &timeoutErr) {
+		t.Fatalf("CommandError = %v, want *specterrs.TimeoutError", err)
+	}
+	if got := err.Error(); got != "list timed out after 1ms" {
+		t.Errorf("Error() = %q", got)
+	}
+}
+
+func TestCommandError_PassesThrough(t *testing.T) {
+	ctx, cancel := CommandContext(0)
+	defer cancel()
+
+	if err := CommandError(ctx, "list", 0, nil); err != nil {
+		t.Errorf("CommandError(nil) = %v, want nil", err)
+	}
+
+	want := errors.New("boom")
+	if err := CommandError(ctx, "list", 0, want); !errors.Is(err, want) {
+		t.Errorf("CommandError = %v, want %v", err, want)
+	}
+}
@@ -20,6 +20,29 @@ func StripJSONCComments(data []byte) []byte {
 	return []byte(strings.Join(cleaned, "\n"))
 }
 
+// LeadingJSONCComments returns the comment header that precedes the JSON
+// body of a JSONC file, including trailing blank lines. Returns an empty
+// string if the file does not start with a comment.
+func LeadingJSONCComments(data []byte) string {
+	var sb strings.Builder
+	hasComment := false
+
+	for _, line := range strings.SplitAfter(string(data), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed != "" && !strings.HasPrefix(trimmed, "//") {
+			break
+		}
+		hasComment = hasComment || trimmed != ""
+		sb.WriteString(line)
+	}
+
+	if !hasComment {
+		return ""
+	}
+
+	return sb.String()
+}
+
 // stripFullLineComment skips lines that are entirely comments.
 func stripFullLineComment(line string) string {
 	trimmed := strings.TrimSpace(line)
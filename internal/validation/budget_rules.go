@@ -0,0 +1,75 @@
+package validation
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/config"
+	"github.com/connerohnesorge/spectr/internal/parsers"
+)
+
+// overBudgetMsg identifies change budget warnings, which stay warnings
+// under strict validation (see applyStrictLevels).
+const overBudgetMsg = "over the change budget"
+
+// validateChangeBudget warns when a change has more requirement deltas,
+// tasks or touched specs than the budgets in spectr.yaml allow, and
+// suggests spectr split-change. Changes are not checked when no budget is
+// set.
+func validateChangeBudget(
+	changeDir, spectrRoot string,
+	specFiles []string,
+) []ValidationIssue {
+	cfg, err := config.LoadConfig(filepath.Dir(spectrRoot))
+	if err != nil {
+		// Config load failures are already reported by the frozen rules.
+		return nil
+	}
+	budget := cfg.ChangeBudgets()
+	if budget == (config.BudgetsConfig{}) {
+		return nil
+	}
+
+	deltas := 0
+	for _, specPath := range specFiles {
+		plan, err := parsers.ParseDeltaSpec(specPath)
+		if err != nil {
+			continue
+		}
+		deltas += len(plan.Added) + len(plan.Modified) +
+			len(plan.Removed) + len(plan.Renamed)
+	}
+
+	changeID := filepath.Base(changeDir)
+	var issues []ValidationIssue
+	check := func(what string, count, limit int) {
+		if limit <= 0 || count <= limit {
+			return
+		}
+		issues = append(issues, ValidationIssue{
+			Level: LevelWarning,
+			Path:  changeDir,
+			Message: fmt.Sprintf(
+				"Change has %d %s, %s (max %d); "+
+					"consider 'spectr split-change %s <new-id>'",
+				count,
+				what,
+				overBudgetMsg,
+				limit,
+				changeID,
+			),
+		})
+	}
+	check("requirement deltas", deltas, budget.MaxDeltas)
+	check("tasks", len(readCoverageTasks(changeDir)), budget.MaxTasks)
+	check("touched specs", len(specFiles), budget.MaxSpecs)
+
+	return issues
+}
+
+// isBudgetWarning returns true if the message is a change budget warning
+// from validateChangeBudget
+func isBudgetWarning(message string) bool {
+	return strings.Contains(message, overBudgetMsg)
+}
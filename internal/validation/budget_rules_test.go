@@ -0,0 +1,107 @@
+package validation
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/connerohnesorge/spectr/internal/testutil"
+)
+
+func TestValidateChangeBudget(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		tasks  string
+		want   []string // Message fragments, in order
+	}{
+		{
+			name: "no budget",
+		},
+		{
+			name:   "within budget",
+			config: "budgets:\n  max_deltas: 2\n  max_tasks: 2\n  max_specs: 2\n",
+			tasks:  "- [ ] 1.1 One\n- [ ] 1.2 Two\n",
+		},
+		{
+			name:   "too many deltas",
+			config: "budgets:\n  max_deltas: 1\n",
+			want: []string{
+				"Change has 2 requirement deltas, over the change budget (max 1); " +
+					"consider 'spectr split-change test-change <new-id>'",
+			},
+		},
+		{
+			name:   "too many tasks and specs",
+			config: "budgets:\n  max_tasks: 1\n  max_specs: 0\n",
+			tasks:  "- [ ] 1.1 One\n- [ ] 1.2 Two\n",
+			want:   []string{"Change has 2 tasks, over the change budget (max 1)"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changeDir, spectrRoot := createChangeDir(t, map[string]string{
+				"auth/spec.md": coverageDeltaSpec,
+			})
+			if tt.config != "" {
+				testutil.WriteFile(
+					t,
+					filepath.Join(filepath.Dir(spectrRoot), ConfigFileName),
+					tt.config,
+				)
+			}
+			if tt.tasks != "" {
+				testutil.WriteFile(t, filepath.Join(changeDir, "tasks.md"), tt.tasks)
+			}
+
+			issues := validateChangeBudget(
+				changeDir,
+				spectrRoot,
+				[]string{filepath.Join(changeDir, "specs", "auth", "spec.md")},
+			)
+			if len(issues) != len(tt.want) {
+				t.Fatalf("got %d issues %+v, want %d", len(issues), issues, len(tt.want))
+			}
+			for i, want := range tt.want {
+				if issues[i].Level != LevelWarning ||
+					!strings.Contains(issues[i].Message, want) {
+					t.Errorf("issue %d = %+v, want warning %q", i, issues[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateChangeDeltaSpecs_BudgetStaysWarning(t *testing.T) {
+	changeDir, spectrRoot := createChangeDir(t, map[string]string{
+		"auth/spec.md": coverageDeltaSpec,
+	})
+	testutil.WriteFile(
+		t,
+		filepath.Join(filepath.Dir(spectrRoot), ConfigFileName),
+		"budgets:\n  max_specs: 1\n  max_deltas: 1\n",
+	)
+	if err := os.WriteFile(
+		filepath.Join(changeDir, "tasks.md"),
+		[]byte("- [ ] 1.1 Build Two-Factor Login and Recovery Codes\n"),
+		0o644,
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := ValidateChangeDeltaSpecs(changeDir, spectrRoot)
+	if err != nil {
+		t.Fatalf("ValidateChangeDeltaSpecs returned error: %v", err)
+	}
+
+	if !report.Valid || report.Summary.Warnings != 1 {
+		t.Errorf(
+			"report valid=%v summary=%+v, want valid with 1 warning: %+v",
+			report.Valid,
+			report.Summary,
+			report.Issues,
+		)
+	}
+}
@@ -5,11 +5,14 @@ package validation
 
 import (
 	"bufio"
+	"context"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
 
+	"github.com/connerohnesorge/spectr/internal/fileio"
 	"github.com/connerohnesorge/spectr/internal/markdown"
 	"github.com/connerohnesorge/spectr/internal/parsers"
 )
@@ -33,6 +36,27 @@ const (
 func ValidateChangeDeltaSpecs(
 	changeDir string,
 	spectrRoot string,
+) (*ValidationReport, error) {
+	return validateChangeDeltaSpecs(
+		context.Background(),
+		changeDir,
+		spectrRoot,
+		nil,
+		nil,
+	)
+}
+
+// validateChangeDeltaSpecs implements ValidateChangeDeltaSpecs. It stops
+// with ctx.Err() between delta files once ctx is done, and passes each
+// issue to onDiagnostic (if non-nil) as soon as the file or check that
+// produced it has run. parseOpts are passed to the markdown diagnostics
+// of each delta file.
+func validateChangeDeltaSpecs(
+	ctx context.Context,
+	changeDir string,
+	spectrRoot string,
+	onDiagnostic DiagnosticFunc,
+	parseOpts []markdown.ParseOption,
 ) (*ValidationReport, error) {
 	specsDir := filepath.Join(changeDir, "specs")
 
@@ -61,14 +85,14 @@ func ValidateChangeDeltaSpecs(
 
 	// Find all spec.md files under specs/
 	var specFiles []string
-	err = filepath.Walk(
+	err = fileio.WalkDir(
 		specsDir,
-		func(path string, info os.FileInfo, err error) error {
+		func(path string, entry fs.DirEntry, err error) error {
 			if err != nil {
 				return err
 			}
-			if !info.IsDir() &&
-				info.Name() == "spec.md" {
+			if !entry.IsDir() &&
+				entry.Name() == "spec.md" {
 				specFiles = append(
 					specFiles,
 					path,
@@ -94,6 +118,15 @@ func ValidateChangeDeltaSpecs(
 
 	// Track all issues across all spec files
 	var allIssues []ValidationIssue
+	addIssues := func(issues []ValidationIssue) {
+		applyStrictLevels(issues)
+		if onDiagnostic != nil {
+			for _, issue := range issues {
+				onDiagnostic(issue)
+			}
+		}
+		allIssues = append(allIssues, issues...)
+	}
 
 	// Track requirement names for duplicate/conflict detection across all files
 	addedReqs := make(
@@ -117,6 +150,10 @@ func ValidateChangeDeltaSpecs(
 
 	// Process each spec file
 	for _, specPath := range specFiles {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
 		fileIssues, deltaCount, err := validateSingleDeltaFile(
 			specPath,
 			addedReqs,
@@ -124,6 +161,7 @@ func ValidateChangeDeltaSpecs(
 			removedReqs,
 			renamedFromReqs,
 			renamedToReqs,
+			parseOpts,
 		)
 		if err != nil {
 			return nil, fmt.Errorf(
@@ -133,9 +171,7 @@ func ValidateChangeDeltaSpecs(
 			)
 		}
 
-		allIssues = append(
-			allIssues,
-			fileIssues...)
+		addIssues(fileIssues)
 		totalDeltas += deltaCount
 
 		// Validate delta file against base spec
@@ -151,36 +187,48 @@ func ValidateChangeDeltaSpecs(
 				err,
 			)
 		}
-		allIssues = append(
-			allIssues,
-			baseSpecIssues...)
+		addIssues(baseSpecIssues)
 	}
 
 	// Check if there are no deltas at all
 	if totalDeltas == 0 {
-		allIssues = append(
-			allIssues,
-			ValidationIssue{
-				Level: LevelError,
-				Path:  specsDir,
-				Line:  1, // Default to line 1 for missing deltas
-				Message: "Change must have at least one delta " +
-					"(ADDED, MODIFIED, REMOVED, or RENAMED requirement)",
-			},
-		)
+		addIssues([]ValidationIssue{{
+			Level: LevelError,
+			Path:  specsDir,
+			Line:  1, // Default to line 1 for missing deltas
+			Message: "Change must have at least one delta " +
+				"(ADDED, MODIFIED, REMOVED, or RENAMED requirement)",
+		}})
 	}
 
+	// Reject deltas that touch requirements frozen in spectr.yaml
+	addIssues(validateFrozenRequirements(
+		changeDir,
+		specsDir,
+		spectrRoot,
+		specFiles,
+	))
+
+	// Correlate ADDED requirements and task references with the tasks
+	addIssues(validateTaskCoverage(changeDir, specsDir, specFiles))
+
+	// Reject change IDs that break the naming policy in spectr.yaml
+	addIssues(validateChangeID(changeDir, spectrRoot))
+
+	// Suggest splitting changes that exceed the budgets in spectr.yaml
+	addIssues(validateChangeBudget(changeDir, spectrRoot, specFiles))
+
+	// Check the required proposal.md sections and their order
+	addIssues(validateProposalSections(changeDir))
+
 	// Validate tasks.md file if present
-	tasksIssues := validateTasksFile(changeDir)
-	allIssues = append(allIssues, tasksIssues...)
+	addIssues(validateTasksFile(changeDir))
+
+	// Check tasks.jsonc and its child files against the JSON Schema
+	addIssues(validateTasksSchema(changeDir))
 
 	// Check for divergence between tasks.md and tasks.jsonc
-	divergenceIssues := validateTasksDivergence(
-		changeDir,
-	)
-	allIssues = append(
-		allIssues,
-		divergenceIssues...)
+	addIssues(validateTasksDivergence(changeDir))
 
 	// Validate proposal dependencies (chained proposals)
 	// Only validate if proposal.md exists
@@ -191,31 +239,35 @@ func ValidateChangeDeltaSpecs(
 		depResult, err := ValidateDependencies(changeID, projectRoot)
 		if err != nil {
 			// Non-fatal: log warning but continue validation
-			allIssues = append(allIssues, ValidationIssue{
+			addIssues([]ValidationIssue{{
 				Level:   LevelWarning,
 				Path:    proposalPath,
 				Message: fmt.Sprintf("failed to validate dependencies: %v", err),
-			})
+			}})
 		} else {
 			// Add dependency validation issues
 			// Note: For validate command, unmet dependencies are warnings, cycles are errors
-			allIssues = append(allIssues, depResult.Issues...)
+			addIssues(depResult.Issues)
 		}
 	}
 
-	// Always convert warnings to errors (strict mode)
-	// EXCEPT for dependency warnings - those should remain as warnings
-	// so they don't block validation
-	for i := range allIssues {
-		if allIssues[i].Level == LevelWarning {
-			// Keep dependency warnings as warnings (non-blocking)
-			if !isDependencyWarning(allIssues[i].Message) {
-				allIssues[i].Level = LevelError
-			}
+	return NewValidationReport(allIssues), nil
+}
+
+// applyStrictLevels converts warnings to errors (strict mode), EXCEPT for
+// dependency, task coverage, change budget, non-canonical and unknown delta
+// section warnings - those remain warnings so they don't block validation.
+func applyStrictLevels(issues []ValidationIssue) {
+	for i := range issues {
+		if issues[i].Level == LevelWarning &&
+			!isDependencyWarning(issues[i].Message) &&
+			!isTaskCoverageWarning(issues[i].Message) &&
+			!isBudgetWarning(issues[i].Message) &&
+			!isNonCanonicalSectionWarning(issues[i].Message) &&
+			!isUnknownSectionWarning(issues[i].Message) {
+			issues[i].Level = LevelError
 		}
 	}
-
-	return NewValidationReport(allIssues), nil
 }
 
 // isDependencyWarning returns true if the message is a dependency-related warning
@@ -225,14 +277,22 @@ func isDependencyWarning(message string) bool {
 			strings.Contains(message, "not found"))
 }
 
+// isTaskCoverageWarning returns true if the message is a task coverage
+// warning from validateTaskCoverage
+func isTaskCoverageWarning(message string) bool {
+	return strings.Contains(message, uncoveredRequirementMsg) ||
+		strings.Contains(message, danglingTaskRefMsg)
+}
+
 // validateSingleDeltaFile validates a single spec.md delta file
 // Returns issues, delta count, and error
 func validateSingleDeltaFile(
 	specPath string,
 	addedReqs, modifiedReqs, removedReqs, renamedFromReqs, renamedToReqs map[string]string,
+	parseOpts []markdown.ParseOption,
 ) ([]ValidationIssue, int, error) {
 	// Read file
-	content, err := os.ReadFile(specPath)
+	contentStr, err := fileio.ReadString(specPath)
 	if err != nil {
 		return nil, 0, fmt.Errorf(
 			"failed to read file: %w",
@@ -240,11 +300,10 @@ func validateSingleDeltaFile(
 		)
 	}
 
-	contentStr := string(content)
 	lines := strings.Split(contentStr, "\n")
 
-	// Parse sections
-	sections := ExtractSections(contentStr)
+	// Parse sections, keyed by canonical title for delta sections
+	sections := canonicalDeltaSections(ExtractSections(contentStr))
 	var issues []ValidationIssue
 	deltaCount := 0
 
@@ -329,6 +388,19 @@ func validateSingleDeltaFile(
 		issues = append(issues, renamedIssues...)
 	}
 
+	// Check heading hierarchy (jumps and stray scenarios)
+	issues = append(issues, validateHeadingHierarchy(specPath, contentStr)...)
+
+	// Check for near-miss headers, delta section titles and unclosed
+	// code fences
+	diags := diagnoseDeltaFile([]byte(contentStr), parseOpts...)
+	issues = addDiagnostics(specPath, contentStr, diags, issues)
+
+	// Warn about delta section titles not in canonical form, and H2
+	// sections that are not deltas and would be dropped
+	issues = addNonCanonicalDeltaSections(specPath, contentStr, issues)
+	issues = addUnknownDeltaSections(specPath, contentStr, issues)
+
 	// Check for cross-section conflicts within this file
 	for normalized := range fileAddedReqs {
 		if fileModifiedReqs[normalized] {
@@ -374,7 +446,7 @@ type RenamedRequirement struct {
 }
 
 // parseRenamedRequirements parses the RENAMED Requirements section
-// Expected format:
+// Expected format (the requirement headers may be wrapped in backticks):
 // - FROM: ### Requirement: OldName
 // - TO: ### Requirement: NewName
 func parseRenamedRequirements(
@@ -392,7 +464,7 @@ func parseRenamedRequirements(
 		}
 
 		// Check for FROM line using markdown package
-		if fromName, ok := markdown.MatchRenamedFromAlt(line); ok {
+		if fromName, ok := markdown.MatchAnyRenamedFrom(line); ok {
 			currentFrom = strings.TrimSpace(
 				fromName,
 			)
@@ -401,7 +473,7 @@ func parseRenamedRequirements(
 		}
 
 		// Check for TO line using markdown package
-		if toName, ok := markdown.MatchRenamedToAlt(line); ok {
+		if toName, ok := markdown.MatchAnyRenamedTo(line); ok {
 			toName = strings.TrimSpace(toName)
 
 			// If we have a FROM, pair it with this TO
@@ -504,10 +576,42 @@ func validateDeltaAgainstBaseSpec(
 		)
 	}
 
+	// RENAMED pairs are checked on their own so that every failing pair
+	// is reported at its position, not just the first
+	var renameIssues []ValidationIssue
+	preMergePlan := deltaPlan
+	if baseExists && len(deltaPlan.Renamed) > 0 {
+		baseReqs, err := parsers.ParseRequirements(baseSpecPath)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"failed to parse base spec: %w",
+				err,
+			)
+		}
+		content, err := fileio.ReadFile(deltaSpecPath)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"failed to read delta spec: %w",
+				err,
+			)
+		}
+
+		renameIssues = validateRenamedAgainstBaseSpec(
+			deltaSpecPath,
+			strings.Split(string(content), "\n"),
+			baseReqs,
+			deltaPlan.Added,
+		)
+
+		withoutRenames := *deltaPlan
+		withoutRenames.Renamed = nil
+		preMergePlan = &withoutRenames
+	}
+
 	// Validate delta against base spec
-	if err := ValidatePreMerge(baseSpecPath, deltaPlan, baseExists); err != nil {
+	if err := ValidatePreMerge(baseSpecPath, preMergePlan, baseExists); err != nil {
 		// Read delta file to find line number
-		content, readErr := os.ReadFile(
+		content, readErr := fileio.ReadFile(
 			deltaSpecPath,
 		)
 		lineNum := 1
@@ -523,17 +627,15 @@ func validateDeltaAgainstBaseSpec(
 			)
 		}
 
-		return []ValidationIssue{
-			{
-				Level:   LevelError,
-				Path:    deltaSpecPath,
-				Line:    lineNum,
-				Message: err.Error(),
-			},
-		}, nil
+		return append(renameIssues, ValidationIssue{
+			Level:   LevelError,
+			Path:    deltaSpecPath,
+			Line:    lineNum,
+			Message: err.Error(),
+		}), nil
 	}
 
-	return nil, nil
+	return renameIssues, nil
 }
 
 // findDeltaSectionLine finds the line number where a delta section header appears
@@ -542,12 +644,10 @@ func findDeltaSectionLine(
 	lines []string,
 	sectionName string,
 ) int {
-	sectionHeader := "## " + sectionName
+	want, _ := markdown.CanonicalDeltaSection(sectionName)
 	for i, line := range lines {
-		if strings.HasPrefix(
-			strings.TrimSpace(line),
-			sectionHeader,
-		) {
+		deltaType, ok := markdown.MatchH2DeltaSection(strings.TrimSpace(line))
+		if ok && deltaType == string(want) {
 			return i + 1 // Line numbers are 1-indexed
 		}
 	}
@@ -587,11 +687,11 @@ func findRequirementLineInSection(
 	return startLine // Default to section start if not found
 }
 
-// findRenamedPairLine finds the line number of a RENAMED requirement pair
-// Searches for the FROM line
+// findRenamedPairLine finds the line number of a RENAMED FROM or TO line
+// naming the given requirement
 func findRenamedPairLine(
 	lines []string,
-	fromName string,
+	reqName string,
 	startLine int,
 ) int {
 	searchStart := max(startLine-1, 0)
@@ -603,20 +703,11 @@ func findRenamedPairLine(
 			break
 		}
 
-		withoutBullet := strings.TrimSpace(
-			strings.TrimPrefix(trimmed, "-"),
-		)
-		withoutCode := strings.Trim(
-			withoutBullet,
-			"`",
-		)
-		if strings.HasPrefix(
-			withoutCode,
-			"FROM: ### Requirement: "+fromName,
-		) || strings.HasPrefix(
-			withoutCode,
-			"TO: ### Requirement: "+fromName,
-		) {
+		name, ok := markdown.MatchAnyRenamedFrom(trimmed)
+		if !ok {
+			name, ok = markdown.MatchAnyRenamedTo(trimmed)
+		}
+		if ok && name == reqName {
 			return i + 1 // Line numbers are 1-indexed
 		}
 	}
@@ -796,7 +887,7 @@ func validateTasksFile(
 	}
 
 	// Read and scan the file for task items
-	file, err := os.Open(tasksPath)
+	file, err := fileio.Open(tasksPath)
 	if err != nil {
 		return []ValidationIssue{
 			{
@@ -924,7 +1015,7 @@ func validateTasksDivergence(
 func parseTasksMdForValidation(
 	path string,
 ) ([]parsers.Task, error) {
-	file, err := os.Open(path)
+	file, err := fileio.Open(path)
 	if err != nil {
 		return nil, err
 	}
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/connerohnesorge/spectr/internal/markdown"
 	"github.com/connerohnesorge/spectr/internal/parsers"
 )
 
@@ -110,6 +111,19 @@ func createBaseSpec(
 	}
 }
 
+// requirementSpec returns base spec content holding a single requirement
+func requirementSpec(name string) string {
+	return `## Requirements
+
+### Requirement: ` + name + `
+The system SHALL keep this requirement.
+
+#### Scenario: Existing behavior
+- **WHEN** the requirement applies
+- **THEN** the system behaves as specified
+`
+}
+
 func TestValidateChangeDeltaSpecs_ValidAddedRequirements(
 	t *testing.T,
 ) {
@@ -294,6 +308,14 @@ func TestValidateChangeDeltaSpecs_ValidRenamedRequirements(
 		t,
 		specs,
 	)
+
+	// The renamed requirements must exist in the base specs
+	createBaseSpec(
+		t,
+		spectrRoot,
+		"auth",
+		requirementSpec("Login"),
+	)
 	report, err := ValidateChangeDeltaSpecs(
 		changeDir,
 		spectrRoot,
@@ -1418,6 +1440,20 @@ func TestValidateChangeDeltaSpecs_RenamedToAcrossFilesLineNumber(
 		t,
 		specs,
 	)
+
+	// The renamed requirements must exist in the base specs
+	createBaseSpec(
+		t,
+		spectrRoot,
+		"alpha",
+		requirementSpec("Old Name Alpha"),
+	)
+	createBaseSpec(
+		t,
+		spectrRoot,
+		"beta",
+		requirementSpec("Old Name Beta"),
+	)
 	report, err := ValidateChangeDeltaSpecs(
 		changeDir,
 		spectrRoot,
@@ -1778,6 +1814,20 @@ func TestValidateChangeDeltaSpecs_SameNameRenamedFromAcrossCapabilities(
 		t,
 		specs,
 	)
+
+	// The renamed requirements must exist in the base specs
+	createBaseSpec(
+		t,
+		spectrRoot,
+		"support-aider",
+		requirementSpec("Old Config Name"),
+	)
+	createBaseSpec(
+		t,
+		spectrRoot,
+		"support-cursor",
+		requirementSpec("Old Config Name"),
+	)
 	report, err := ValidateChangeDeltaSpecs(
 		changeDir,
 		spectrRoot,
@@ -2403,3 +2453,67 @@ func TestValidateTasksFile_DirectFunction(
 		},
 	)
 }
+
+func TestValidateChangeDeltaSpecs_TolerantSectionTitles(
+	t *testing.T,
+) {
+	if err := markdown.SetDeltaAliases(map[string][]string{"ADDED": {"Additions"}}); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = markdown.SetDeltaAliases(nil) })
+
+	specs := map[string]string{
+		"auth/spec.md": `## Additions
+
+### Requirement: Login
+The system SHALL log users in.
+
+#### Scenario: Valid credentials
+- **WHEN** a user signs in
+- **THEN** a session starts
+
+## Removed requirements
+
+### Requirement: Old Login
+
+## Notes
+
+Rolled out behind a flag.
+`,
+	}
+	changeDir, spectrRoot := createChangeDir(t, specs)
+	createBaseSpec(t, spectrRoot, "auth", requirementSpec("Old Login"))
+
+	report, err := ValidateChangeDeltaSpecs(changeDir, spectrRoot)
+	if err != nil {
+		t.Fatalf("ValidateChangeDeltaSpecs returned error: %v", err)
+	}
+
+	want := []struct {
+		level   ValidationLevel
+		line    int
+		message string
+	}{
+		{LevelWarning, 10, "Delta section header '## Removed requirements' is not in canonical form"},
+		{LevelWarning, 14, "Section '## Notes' is not a delta section and is ignored"},
+	}
+	if len(report.Issues) != len(want) {
+		t.Fatalf("Issues = %+v, want %d", report.Issues, len(want))
+	}
+	for i, w := range want {
+		got := report.Issues[i]
+		if got.Level != w.level || got.Line != w.line || got.Message != w.message {
+			t.Errorf("Issues[%d] = %+v, want %s at line %d: %q", i, got, w.level, w.line, w.message)
+		}
+	}
+
+	// Both warnings show in human output of the valid change
+	output := captureOutput(func() {
+		PrintHumanReport("test-change", report)
+	})
+	for _, w := range want {
+		if !strings.Contains(output, w.message) {
+			t.Errorf("PrintHumanReport() = %q, want %q", output, w.message)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package validation
+
+import (
+	"path/filepath"
+
+	"github.com/connerohnesorge/spectr/internal/changeid"
+	"github.com/connerohnesorge/spectr/internal/config"
+)
+
+// validateChangeID reports a change whose ID breaks the change_ids policy
+// of spectr.yaml. Changes are not checked when no policy is set, so
+// existing projects only see errors once they opt in.
+func validateChangeID(changeDir, spectrRoot string) []ValidationIssue {
+	cfg, err := config.LoadConfig(filepath.Dir(spectrRoot))
+	if err != nil || cfg == nil || cfg.ChangeIDs == nil {
+		// Config load failures are already reported by the frozen rules.
+		return nil
+	}
+
+	policy, err := changeid.FromConfig(cfg)
+	if err == nil {
+		err = policy.Check(filepath.Base(changeDir))
+	}
+	if err == nil {
+		return nil
+	}
+
+	return []ValidationIssue{{
+		Level:   LevelError,
+		Path:    changeDir,
+		Message: err.Error(),
+	}}
+}
@@ -0,0 +1,68 @@
+package validation
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/connerohnesorge/spectr/internal/testutil"
+)
+
+func TestValidateChangeID(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		want   string // Message fragment, empty for no issue
+	}{
+		{
+			name: "no policy",
+		},
+		{
+			name:   "default pattern",
+			config: "change_ids:\n  reserved_prefixes: [tmp-]\n",
+		},
+		{
+			name:   "reserved prefix",
+			config: "change_ids:\n  reserved_prefixes: [test-]\n",
+			want:   `invalid change ID 'test-change': the prefix "test-" is reserved`,
+		},
+		{
+			name:   "pattern mismatch",
+			config: "change_ids:\n  pattern: '^[A-Z]+-[0-9]+-'\n",
+			want:   "does not match ^[A-Z]+-[0-9]+-",
+		},
+		{
+			name:   "invalid pattern",
+			config: "change_ids:\n  pattern: '('\n",
+			want:   "is not a valid regular expression",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changeDir, spectrRoot := createChangeDir(t, map[string]string{
+				"auth/spec.md": coverageDeltaSpec,
+			})
+			if tt.config != "" {
+				testutil.WriteFile(
+					t,
+					filepath.Join(filepath.Dir(spectrRoot), ConfigFileName),
+					tt.config,
+				)
+			}
+
+			issues := validateChangeID(changeDir, spectrRoot)
+			if tt.want == "" {
+				if len(issues) != 0 {
+					t.Errorf("unexpected issues %+v", issues)
+				}
+
+				return
+			}
+			if len(issues) != 1 || issues[0].Level != LevelError ||
+				!strings.Contains(issues[0].Message, tt.want) {
+				t.Errorf("issues = %+v, want one error containing %q", issues, tt.want)
+			}
+		})
+	}
+}
@@ -7,9 +7,14 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/connerohnesorge/spectr/internal/inherit"
 	"github.com/connerohnesorge/spectr/internal/parsers"
 )
 
+// emptyDeltaSuggestion tells how to fill an ADDED, MODIFIED or REMOVED
+// section without requirement blocks.
+const emptyDeltaSuggestion = "add at least one '### Requirement: <name>' block to the section"
+
 // extractCapabilityFromPath extracts the capability name from a delta spec path.
 // Example: spectr/changes/foo/specs/support-aider/spec.md -> support-aider
 func extractCapabilityFromPath(
@@ -68,6 +73,7 @@ func validateAddedRequirements(
 			Line:  sectionLine,
 			Message: "ADDED Requirements section is empty " +
 				"(no requirements found)",
+			Suggestion: emptyDeltaSuggestion,
 		})
 
 		return issues
@@ -174,6 +180,12 @@ func validateAddedRequirements(
 				},
 			)
 		}
+
+		// Check scenario Examples tables
+		issues = append(
+			issues,
+			validateScenarioExamples(reqPath, req, lines, reqLine)...,
+		)
 	}
 
 	return issues
@@ -199,6 +211,7 @@ func validateModifiedRequirements(
 			Line:  sectionLine,
 			Message: "MODIFIED Requirements section is empty " +
 				"(no requirements found)",
+			Suggestion: emptyDeltaSuggestion,
 		})
 
 		return issues
@@ -306,6 +319,12 @@ func validateModifiedRequirements(
 				},
 			)
 		}
+
+		// Check scenario Examples tables
+		issues = append(
+			issues,
+			validateScenarioExamples(reqPath, req, lines, reqLine)...,
+		)
 	}
 
 	return issues
@@ -331,6 +350,7 @@ func validateRemovedRequirements(
 			Line:  sectionLine,
 			Message: "REMOVED Requirements section is empty " +
 				"(no requirements found)",
+			Suggestion: emptyDeltaSuggestion,
 		})
 
 		return issues
@@ -563,9 +583,11 @@ func validateRenamedRequirements(
 
 // ValidatePreMerge validates delta operations against base spec.
 // It checks that:
-// - ADDED requirements don't already exist in base spec
-// - MODIFIED/REMOVED/RENAMED requirements DO exist in base spec
-// - RENAMED TO requirements don't already exist (unless renaming to itself)
+//   - ADDED requirements don't already exist in base spec
+//   - MODIFIED/REMOVED/RENAMED requirements DO exist in base spec, and
+//     MODIFIED/REMOVED do not target requirements the spec only inherits
+//   - RENAMED TO requirements don't already exist (unless renaming to itself)
+//     or collide with ADDED requirements, replaying renames in order
 //
 // If specExists is false, only ADDED operations are allowed.
 //
@@ -614,10 +636,7 @@ func ValidatePreMerge(
 			req.Name,
 		)
 		if !existing[normalized] {
-			return fmt.Errorf(
-				"MODIFIED requirement %q does not exist in base spec",
-				req.Name,
-			)
+			return missingRequirementError(baseSpecPath, "MODIFIED", req.Name)
 		}
 	}
 
@@ -627,36 +646,17 @@ func ValidatePreMerge(
 			name,
 		)
 		if !existing[normalized] {
-			return fmt.Errorf(
-				"REMOVED requirement %q does not exist in base spec",
-				name,
-			)
+			return missingRequirementError(baseSpecPath, "REMOVED", name)
 		}
 	}
 
-	// Validate RENAMED FROM requirements exist in base
-	for _, op := range deltaPlan.Renamed {
-		fromNorm := parsers.NormalizeRequirementName(
-			op.From,
-		)
-		if !existing[fromNorm] {
-			return fmt.Errorf(
-				"RENAMED FROM requirement %q does not exist in base spec",
-				op.From,
-			)
-		}
-
-		// Check that TO name doesn't already exist (unless it's being renamed from something else)
-		toNorm := parsers.NormalizeRequirementName(
-			op.To,
-		)
-		if existing[toNorm] &&
-			toNorm != fromNorm {
-			return fmt.Errorf(
-				"RENAMED TO requirement %q already exists in base spec",
-				op.To,
-			)
-		}
+	// Validate RENAMED pairs apply in order: FROM exists, TO is free
+	if problems := checkRenames(
+		baseReqs,
+		deltaPlan.Added,
+		deltaPlan.Renamed,
+	); len(problems) > 0 {
+		return errors.New(problems[0].message)
 	}
 
 	// Validate ADDED requirements don't exist in base
@@ -674,3 +674,21 @@ func ValidatePreMerge(
 
 	return nil
 }
+
+// missingRequirementError reports a MODIFIED or REMOVED requirement that
+// is not in the base spec file, naming the spec it is inherited from when
+// the base spec extends one.
+func missingRequirementError(baseSpecPath, op, name string) error {
+	if from, ok := inherit.InheritedFrom(baseSpecPath, name); ok {
+		return fmt.Errorf(
+			"%s requirement %q is inherited from spec %s and read-only; "+
+				"change %s, or ADD a requirement of that name to override its scenarios",
+			op,
+			name,
+			from,
+			from,
+		)
+	}
+
+	return fmt.Errorf("%s requirement %q does not exist in base spec", op, name)
+}
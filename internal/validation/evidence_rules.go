@@ -0,0 +1,95 @@
+package validation
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/config"
+	"github.com/connerohnesorge/spectr/internal/evidence"
+	"github.com/connerohnesorge/spectr/internal/inherit"
+	"github.com/connerohnesorge/spectr/internal/parsers"
+)
+
+// validateEvidence rejects requirements marked status: implemented while
+// a scenario has no evidence in the spec's evidence.jsonc, when
+// spectr.yaml sets evidence.require_for_implemented. Inherited
+// requirements are checked in their own spec.
+func validateEvidence(path string, lines []string) []ValidationIssue {
+	specsDir := inherit.SpecsDir(path)
+	if specsDir == "" {
+		return nil
+	}
+	cfg, err := config.LoadConfig(filepath.Dir(filepath.Dir(specsDir)))
+	if err != nil || cfg == nil || cfg.Evidence == nil ||
+		!cfg.Evidence.RequireForImplemented {
+		// Config load failures are reported by spectr validate itself
+		return nil
+	}
+
+	reqs, err := inherit.ResolveFile(path)
+	if err != nil {
+		// Inheritance errors are reported by Rule 8
+		return nil
+	}
+	evidencePath := filepath.Join(filepath.Dir(path), evidence.FileName)
+	ev, err := evidence.Load(evidencePath)
+	if err != nil {
+		return []ValidationIssue{{
+			Level:   LevelError,
+			Path:    evidencePath,
+			Line:    1,
+			Message: err.Error(),
+		}}
+	}
+
+	var issues []ValidationIssue
+	for _, req := range reqs {
+		if req.Inherited {
+			continue
+		}
+		status, ok := parsers.ParseRequirementStatus(req.Raw)
+		if !ok || status != parsers.RequirementStatusImplemented {
+			continue
+		}
+		missing := ev.Missing(req.Name, parsers.ParseScenarios(req.Raw))
+		if len(missing) == 0 {
+			continue
+		}
+
+		issues = append(issues, ValidationIssue{
+			Level: LevelError,
+			Path:  fmt.Sprintf("%s: Requirement '%s'", path, req.Name),
+			Line:  statusLine(lines, findRequirementLine(lines, req.Name, 1)),
+			Message: fmt.Sprintf(
+				"Requirement marked implemented without evidence for %s; "+
+					"attach it with 'spectr evidence attach'",
+				quoteList(missing),
+			),
+		})
+	}
+
+	return issues
+}
+
+// statusLine returns the 1-based number of the first status line at or
+// after reqLine, or reqLine when there is none.
+func statusLine(lines []string, reqLine int) int {
+	for i := reqLine; i < len(lines); i++ {
+		if _, ok := parsers.MatchStatusLine(lines[i]); ok {
+			return i + 1
+		}
+	}
+
+	return reqLine
+}
+
+// quoteList renders names as a comma-separated list of quoted names.
+func quoteList(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = "'" + name + "'"
+	}
+
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,101 @@
+package validation
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const evidenceSpec = `# Auth
+
+## Requirements
+
+### Requirement: Login
+The system SHALL authenticate users.
+status: implemented
+
+#### Scenario: Valid password
+- **WHEN** a user signs in
+- **THEN** a session starts
+
+#### Scenario: Lockout
+- **WHEN** a user fails five times
+- **THEN** the account is locked
+`
+
+func TestValidateSpecFile_Evidence(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   string
+		evidence string
+		want     string // Substring of the single issue, empty for none
+	}{
+		{
+			name:   "policy off",
+			config: "",
+		},
+		{
+			name:   "no evidence",
+			config: "evidence:\n  require_for_implemented: true\n",
+			want:   "without evidence for 'Valid password', 'Lockout'",
+		},
+		{
+			name:   "one scenario missing",
+			config: "evidence:\n  require_for_implemented: true\n",
+			evidence: `{"version": 1, "evidence": [` +
+				`{"requirement": "login", "scenario": "valid password", "ref": "https://ci.example/1"}]}`,
+			want: "without evidence for 'Lockout'",
+		},
+		{
+			name:   "all scenarios covered",
+			config: "evidence:\n  require_for_implemented: true\n",
+			evidence: `// evidence
+{"version": 1, "evidence": [
+  {"requirement": "Login", "scenario": "Valid password", "ref": "https://ci.example/1"},
+  {"requirement": "Login", "scenario": "Lockout", "ref": "shots/lockout.png"}
+]}`,
+		},
+		{
+			name:     "newer evidence version",
+			config:   "evidence:\n  require_for_implemented: true\n",
+			evidence: `{"version": 2, "evidence": []}`,
+			want:     "unsupported evidence version 2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spectrDir := writeInheritSpecs(t, map[string]string{"auth": evidenceSpec})
+			root := filepath.Dir(spectrDir)
+			if err := os.WriteFile(filepath.Join(root, "spectr.yaml"), []byte(tt.config), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			if tt.evidence != "" {
+				path := filepath.Join(spectrDir, "specs", "auth", "evidence.jsonc")
+				if err := os.WriteFile(path, []byte(tt.evidence), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			report, err := ValidateSpecFile(filepath.Join(spectrDir, "specs", "auth", "spec.md"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.want == "" {
+				if len(report.Issues) != 0 {
+					t.Errorf("issues = %+v, want none", report.Issues)
+				}
+
+				return
+			}
+			if len(report.Issues) != 1 || !strings.Contains(report.Issues[0].Message, tt.want) {
+				t.Fatalf("issues = %+v, want one containing %q", report.Issues, tt.want)
+			}
+			if strings.Contains(tt.want, "without evidence") && report.Issues[0].Line != 7 {
+				t.Errorf("line = %d, want the status line 7", report.Issues[0].Line)
+			}
+		})
+	}
+}
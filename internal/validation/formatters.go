@@ -110,11 +110,22 @@ func PrintHumanReport(
 ) {
 	if report.Valid {
 		fmt.Printf("✓ %s valid\n", itemName)
+		// Warnings of a valid report still show, as they can fail
+		// --fail-on=warning
+		for _, issue := range report.Issues {
+			fmt.Printf(
+				"  [%s] %s: %s\n",
+				issue.Level,
+				issue.Path,
+				issueText(issue),
+			)
+		}
+		printHiddenIssues(report, "  ")
 
 		return
 	}
 
-	issueCount := len(report.Issues)
+	issueCount := report.Total()
 	fmt.Printf(
 		"✗ %s has %d issue(s):\n",
 		itemName,
@@ -126,9 +137,44 @@ func PrintHumanReport(
 			"  [%s] %s: %s\n",
 			issue.Level,
 			issue.Path,
-			issue.Message,
+			issueText(issue),
+		)
+	}
+	printHiddenIssues(report, "  ")
+}
+
+// issueText returns the message of issue for human output, followed by
+// its suggestion and a hint when validate --fix can correct it.
+func issueText(issue ValidationIssue) string {
+	text := issue.Message
+	if issue.Suggestion != "" {
+		text += "; " + issue.Suggestion
+	}
+	if issue.Fix != nil {
+		text += " (spectr validate --fix fixes this)"
+	}
+	if issue.Similar > 0 {
+		text += fmt.Sprintf(
+			" (%d more similar %s)",
+			issue.Similar,
+			levelNoun(issue.Level, issue.Similar),
 		)
 	}
+
+	return text
+}
+
+// levelNoun names n issues of level, e.g. "errors".
+func levelNoun(level ValidationLevel, n int) string {
+	noun := "issue"
+	if level == LevelError || level == LevelWarning {
+		noun = strings.ToLower(string(level))
+	}
+	if n != 1 {
+		noun += "s"
+	}
+
+	return noun
 }
 
 // PrintBulkJSONResults prints bulk validation results as JSON
@@ -150,6 +196,81 @@ func PrintBulkJSONResults(results []BulkResult) {
 	fmt.Println(string(data))
 }
 
+// DiagnosticLine is one issue in JSON Lines output. Field order is fixed
+// so editors can match lines with a regular expression, and Line and
+// Column are always at least 1.
+type DiagnosticLine struct {
+	Level   ValidationLevel `json:"level"`
+	Path    string          `json:"path"`
+	Line    int             `json:"line"`
+	Column  int             `json:"column"`
+	Message string          `json:"message"`
+	Item    string          `json:"item,omitempty"`
+	// Suggestion and Fix are copied from the issue
+	Suggestion string `json:"suggestion,omitempty"`
+	Fix        *Fix   `json:"fix,omitempty"`
+}
+
+// PrintJSONLinesReport prints each issue of a single report as one
+// compact JSON object per line.
+func PrintJSONLinesReport(itemName string, report *ValidationReport) {
+	for _, issue := range report.Issues {
+		printDiagnosticLine(itemName, issue)
+	}
+}
+
+// PrintBulkJSONLinesResults prints every issue of bulk results as one
+// compact JSON object per line. results[i] must be the result for
+// items[i]; items that failed to validate at all are reported as a single
+// error at the item's path.
+func PrintBulkJSONLinesResults(
+	items []ValidationItem,
+	results []BulkResult,
+) {
+	for i, result := range results {
+		if result.Error != "" && i < len(items) {
+			printDiagnosticLine(result.Name, ValidationIssue{
+				Level:   LevelError,
+				Path:    items[i].Path,
+				Message: result.Error,
+			})
+
+			continue
+		}
+		if result.Report != nil {
+			PrintJSONLinesReport(result.Name, result.Report)
+		}
+	}
+}
+
+func printDiagnosticLine(itemName string, issue ValidationIssue) {
+	path := issue.Path
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+
+	data, err := json.Marshal(DiagnosticLine{
+		Level:      issue.Level,
+		Path:       filepath.ToSlash(path),
+		Line:       max(issue.Line, 1),
+		Column:     max(issue.Column, 1),
+		Message:    issue.Message,
+		Item:       itemName,
+		Suggestion: issue.Suggestion,
+		Fix:        issue.Fix,
+	})
+	if err != nil {
+		fmt.Fprintf(
+			os.Stderr,
+			"Error marshaling JSON: %v\n",
+			err,
+		)
+
+		return
+	}
+	fmt.Println(string(data))
+}
+
 // PrintBulkHumanResults prints bulk validation results in human format
 // with improved formatting: visual separation between failed items,
 // relative paths, issues grouped by file, colored error/warning labels,
@@ -168,6 +289,11 @@ func PrintBulkHumanResults(results []BulkResult) {
 				result.Name,
 				result.Type,
 			)
+			if result.Report != nil {
+				printGroupedIssues(
+					result.Report, &errorCount, &warningCount,
+				)
+			}
 			passCount++
 		} else {
 			// Add blank line before each failed item (except the first)
@@ -185,7 +311,7 @@ func PrintBulkHumanResults(results []BulkResult) {
 				)
 				errorCount++
 			} else {
-				issueCount := len(result.Report.Issues)
+				issueCount := result.Report.Total()
 				fmt.Printf(
 					"✗ %s (%s) has %d issue(s):\n",
 					result.Name,
@@ -194,7 +320,7 @@ func PrintBulkHumanResults(results []BulkResult) {
 				)
 				// Count and print issues grouped by file
 				printGroupedIssues(
-					result.Report.Issues, &errorCount, &warningCount,
+					result.Report, &errorCount, &warningCount,
 				)
 			}
 			failCount++
@@ -211,16 +337,17 @@ func PrintBulkHumanResults(results []BulkResult) {
 	})
 }
 
-// printGroupedIssues prints issues grouped by their file path with indentation
+// printGroupedIssues prints the issues of report grouped by their file path
+// with indentation
 func printGroupedIssues(
-	issues []ValidationIssue,
+	report *ValidationReport,
 	errorCount, warningCount *int,
 ) {
 	// Group issues by file path
 	grouped := make(map[string][]ValidationIssue)
 	var order []string // Preserve order of first occurrence
 
-	for _, issue := range issues {
+	for _, issue := range report.Issues {
 		relPath := ToRelativePath(issue.Path)
 		if _, exists := grouped[relPath]; !exists {
 			order = append(order, relPath)
@@ -229,13 +356,15 @@ func printGroupedIssues(
 			grouped[relPath],
 			issue,
 		)
+	}
 
-		// Count errors and warnings
+	// Count errors and warnings, including folded and hidden issues
+	for _, issue := range append(report.Issues, report.Hidden...) {
 		switch issue.Level {
 		case LevelError:
-			*errorCount++
+			*errorCount += 1 + issue.Similar
 		case LevelWarning:
-			*warningCount++
+			*warningCount += 1 + issue.Similar
 		case LevelInfo:
 			// Info level issues are not counted in error/warning totals
 		}
@@ -250,7 +379,7 @@ func printGroupedIssues(
 			fmt.Printf("  %s %s: %s\n",
 				formatLevel(issue.Level),
 				path,
-				issue.Message,
+				issueText(issue),
 			)
 		} else {
 			// Multiple issues: print file header then indented issues
@@ -258,11 +387,23 @@ func printGroupedIssues(
 			for _, issue := range fileIssues {
 				fmt.Printf("    %s %s\n",
 					formatLevel(issue.Level),
-					issue.Message,
+					issueText(issue),
 				)
 			}
 		}
 	}
+	printHiddenIssues(report, "  ")
+}
+
+// printHiddenIssues prints how many issues LimitReport left out of report.
+func printHiddenIssues(report *ValidationReport, indent string) {
+	if hidden := countIssues(report.Hidden); hidden > 0 {
+		fmt.Printf(
+			"%s... %d more issue(s) not shown (--max-errors 0 shows all)\n",
+			indent,
+			hidden,
+		)
+	}
 }
 
 // summaryParams holds parameters for printing the validation summary
@@ -276,7 +417,8 @@ type summaryParams struct {
 
 // printSummary prints the validation summary line
 func printSummary(p summaryParams) {
-	if p.failCount > 0 {
+	switch {
+	case p.failCount > 0:
 		fmt.Printf(
 			"\n%d passed, %d failed (%d errors, %d warnings), %d total\n",
 			p.passCount,
@@ -285,7 +427,15 @@ func printSummary(p summaryParams) {
 			p.warningCount,
 			p.total,
 		)
-	} else {
+	case p.warningCount > 0:
+		fmt.Printf(
+			"\n%d passed (%d warnings), %d failed, %d total\n",
+			p.passCount,
+			p.warningCount,
+			p.failCount,
+			p.total,
+		)
+	default:
 		fmt.Printf(
 			"\n%d passed, %d failed, %d total\n",
 			p.passCount,
@@ -324,6 +474,11 @@ func PrintBulkHumanResultsMulti(results []BulkResult, hasMultipleRoots bool) {
 				displayName,
 				result.Type,
 			)
+			if result.Report != nil {
+				printGroupedIssues(
+					result.Report, &errorCount, &warningCount,
+				)
+			}
 			passCount++
 		} else {
 			// Add blank line before each failed item (except the first)
@@ -341,7 +496,7 @@ func PrintBulkHumanResultsMulti(results []BulkResult, hasMultipleRoots bool) {
 				)
 				errorCount++
 			} else {
-				issueCount := len(result.Report.Issues)
+				issueCount := result.Report.Total()
 				fmt.Printf(
 					"✗ %s (%s) has %d issue(s):\n",
 					displayName,
@@ -350,7 +505,7 @@ func PrintBulkHumanResultsMulti(results []BulkResult, hasMultipleRoots bool) {
 				)
 				// Count and print issues grouped by file
 				printGroupedIssues(
-					result.Report.Issues, &errorCount, &warningCount,
+					result.Report, &errorCount, &warningCount,
 				)
 			}
 			failCount++
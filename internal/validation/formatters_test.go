@@ -138,6 +138,31 @@ func TestPrintHumanReport_ValidReport(
 	assert.Contains(t, output, "valid")
 }
 
+// TestPrintHumanReport_ValidWithWarnings tests that the warnings of a
+// valid report are printed, since they can fail --fail-on=warning
+func TestPrintHumanReport_ValidWithWarnings(
+	t *testing.T,
+) {
+	report := NewValidationReport([]ValidationIssue{
+		{
+			Level:   LevelWarning,
+			Path:    "spec.md",
+			Message: "Section '## Additions' is not a delta section and is ignored",
+		},
+	})
+
+	output := captureOutput(func() {
+		PrintHumanReport("test-spec", report)
+	})
+
+	assert.Contains(t, output, "✓ test-spec valid")
+	assert.Contains(
+		t,
+		output,
+		"[WARNING] spec.md: Section '## Additions' is not a delta section and is ignored",
+	)
+}
+
 // TestPrintHumanReport_InvalidReport tests printing an invalid report in human format
 func TestPrintHumanReport_InvalidReport(
 	t *testing.T,
@@ -379,6 +404,39 @@ func TestPrintBulkHumanResults_AllValid(
 	)
 }
 
+// TestPrintBulkHumanResults_ValidWithWarnings tests that warnings of
+// valid results are printed and counted in the summary
+func TestPrintBulkHumanResults_ValidWithWarnings(
+	t *testing.T,
+) {
+	results := []BulkResult{
+		{
+			Name:  "change1",
+			Type:  ItemTypeChange,
+			Valid: true,
+			Report: NewValidationReport([]ValidationIssue{
+				{
+					Level:   LevelWarning,
+					Path:    "tasks.jsonc",
+					Message: "Task coverage warning",
+				},
+			}),
+		},
+		{
+			Name:  "spec1",
+			Type:  ItemTypeSpec,
+			Valid: true,
+		},
+	}
+
+	output := captureOutput(func() {
+		PrintBulkHumanResults(results)
+	})
+
+	assert.Contains(t, output, "✓ change1 (change)\n  [WARNING] tasks.jsonc: Task coverage warning")
+	assert.Contains(t, output, "2 passed (1 warnings), 0 failed, 2 total")
+}
+
 // TestPrintBulkHumanResults_AllInvalid tests printing all invalid results
 func TestPrintBulkHumanResults_AllInvalid(
 	t *testing.T,
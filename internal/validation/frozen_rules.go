@@ -0,0 +1,154 @@
+package validation
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/config"
+	"github.com/connerohnesorge/spectr/internal/domain"
+	"github.com/connerohnesorge/spectr/internal/parsers"
+)
+
+// frozenRequirementMsg identifies the issues of deltas touching a frozen
+// requirement, whether overridden or not.
+const frozenRequirementMsg = "frozen requirement "
+
+// IsFrozenTouch reports whether issue is about a delta touching a frozen
+// requirement. Touches allowed by an override are info issues.
+func IsFrozenTouch(issue ValidationIssue) bool {
+	return strings.Contains(issue.Message, frozenRequirementMsg)
+}
+
+// frozenKey identifies a frozen requirement by spec ID and normalized
+// requirement name.
+type frozenKey struct {
+	spec        string
+	requirement string
+}
+
+// validateFrozenRequirements rejects MODIFIED, REMOVED and RENAMED deltas
+// that touch a requirement listed under frozen in spectr.yaml, unless the
+// change's proposal.md frontmatter carries an override ticket. Overridden
+// touches are reported as info so the ticket shows up in the report.
+func validateFrozenRequirements(
+	changeDir, specsDir, spectrRoot string,
+	specFiles []string,
+) []ValidationIssue {
+	cfg, err := config.LoadConfig(filepath.Dir(spectrRoot))
+	if err != nil {
+		return []ValidationIssue{{
+			Level:   LevelError,
+			Path:    filepath.Join(filepath.Dir(spectrRoot), ConfigFileName),
+			Message: fmt.Sprintf("failed to load frozen requirements: %v", err),
+		}}
+	}
+	if cfg == nil || len(cfg.Frozen) == 0 {
+		return nil
+	}
+
+	frozen := make(map[frozenKey]config.FrozenRequirement, len(cfg.Frozen))
+	for _, req := range cfg.Frozen {
+		frozen[frozenKey{
+			spec:        req.Spec,
+			requirement: parsers.NormalizeRequirementName(req.Requirement),
+		}] = req
+	}
+
+	override := ""
+	proposalPath := filepath.Join(changeDir, "proposal.md")
+	if _, statErr := os.Stat(proposalPath); statErr == nil {
+		meta, err := domain.ParseProposalFrontmatterFromFile(proposalPath)
+		if err == nil {
+			override = strings.TrimSpace(meta.Override)
+		}
+	}
+
+	var issues []ValidationIssue
+	for _, specPath := range specFiles {
+		rel, err := filepath.Rel(specsDir, filepath.Dir(specPath))
+		if err != nil {
+			continue
+		}
+		specID := filepath.ToSlash(rel)
+
+		plan, err := parsers.ParseDeltaSpec(specPath)
+		if err != nil {
+			// Parse failures are already reported by the delta rules.
+			continue
+		}
+
+		var touched []frozenTouch
+		for _, req := range plan.Modified {
+			touched = append(touched, frozenTouch{"modify", req.Name})
+		}
+		for _, name := range plan.Removed {
+			touched = append(touched, frozenTouch{"remove", name})
+		}
+		for _, op := range plan.Renamed {
+			touched = append(touched, frozenTouch{"rename", op.From})
+		}
+
+		for _, touch := range touched {
+			req, ok := frozen[frozenKey{
+				spec:        specID,
+				requirement: parsers.NormalizeRequirementName(touch.name),
+			}]
+			if !ok {
+				continue
+			}
+			issues = append(issues, frozenIssue(specPath, specID, touch, req, override))
+		}
+	}
+
+	return issues
+}
+
+// frozenTouch is a delta operation on a named requirement.
+type frozenTouch struct {
+	op   string
+	name string
+}
+
+// frozenIssue builds the issue for a delta that touches a frozen
+// requirement.
+func frozenIssue(
+	specPath, specID string,
+	touch frozenTouch,
+	req config.FrozenRequirement,
+	override string,
+) ValidationIssue {
+	reason := ""
+	if req.Reason != "" {
+		reason = fmt.Sprintf(" (%s)", req.Reason)
+	}
+
+	if override != "" {
+		return ValidationIssue{
+			Level: LevelInfo,
+			Path:  specPath,
+			Message: fmt.Sprintf(
+				frozenRequirementMsg+"%q in spec %s%s: %s allowed by override %s",
+				touch.name,
+				specID,
+				reason,
+				touch.op,
+				override,
+			),
+		}
+	}
+
+	return ValidationIssue{
+		Level: LevelError,
+		Path:  specPath,
+		Message: fmt.Sprintf(
+			"cannot %s "+frozenRequirementMsg+"%q in spec %s%s; "+
+				"add override: <ticket> to the proposal.md frontmatter",
+			touch.op,
+			touch.name,
+			specID,
+			reason,
+		),
+	}
+}
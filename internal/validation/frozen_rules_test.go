@@ -0,0 +1,115 @@
+package validation
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/connerohnesorge/spectr/internal/testutil"
+)
+
+func TestValidateFrozenRequirements(t *testing.T) {
+	const frozenConfig = `frozen:
+  - spec: auth
+    requirement: Password Hashing
+    reason: SOC2 CC6.1
+`
+
+	tests := []struct {
+		name      string
+		config    string
+		proposal  string
+		delta     string
+		wantLevel ValidationLevel
+		want      string // Substring of the single issue, empty for none
+	}{
+		{
+			name:   "no config",
+			config: "",
+			delta:  "## MODIFIED Requirements\n\n### Requirement: Password Hashing\n",
+		},
+		{
+			name:   "added requirement is fine",
+			config: frozenConfig,
+			delta:  "## ADDED Requirements\n\n### Requirement: Password Hashing v2\n",
+		},
+		{
+			name:      "modify frozen",
+			config:    frozenConfig,
+			delta:     "## MODIFIED Requirements\n\n### Requirement: Password Hashing\n",
+			wantLevel: LevelError,
+			want:      `cannot modify frozen requirement "Password Hashing" in spec auth (SOC2 CC6.1)`,
+		},
+		{
+			name:      "remove frozen",
+			config:    frozenConfig,
+			delta:     "## REMOVED Requirements\n\n### Requirement: Password Hashing\n",
+			wantLevel: LevelError,
+			want:      "cannot remove frozen requirement",
+		},
+		{
+			name:      "rename frozen",
+			config:    frozenConfig,
+			delta:     "## RENAMED Requirements\n\n- FROM: `### Requirement: Password Hashing`\n- TO: `### Requirement: Credential Hashing`\n",
+			wantLevel: LevelError,
+			want:      "cannot rename frozen requirement",
+		},
+		{
+			name:      "override",
+			config:    frozenConfig,
+			proposal:  "---\noverride: SEC-142\n---\n# Change\n",
+			delta:     "## MODIFIED Requirements\n\n### Requirement: Password Hashing\n",
+			wantLevel: LevelInfo,
+			want:      "modify allowed by override SEC-142",
+		},
+		{
+			name:   "other spec",
+			config: "frozen:\n  - spec: billing\n    requirement: Password Hashing\n",
+			delta:  "## MODIFIED Requirements\n\n### Requirement: Password Hashing\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			spectrRoot := filepath.Join(root, "spectr")
+			changeDir := filepath.Join(spectrRoot, "changes", "harden-auth")
+			specsDir := filepath.Join(changeDir, "specs")
+			specPath := filepath.Join(specsDir, "auth", "spec.md")
+			if err := os.MkdirAll(filepath.Dir(specPath), 0o755); err != nil {
+				t.Fatal(err)
+			}
+			testutil.WriteFile(t, specPath, tt.delta)
+			if tt.config != "" {
+				testutil.WriteFile(t, filepath.Join(root, ConfigFileName), tt.config)
+			}
+			if tt.proposal != "" {
+				testutil.WriteFile(t, filepath.Join(changeDir, "proposal.md"), tt.proposal)
+			}
+
+			issues := validateFrozenRequirements(
+				changeDir,
+				specsDir,
+				spectrRoot,
+				[]string{specPath},
+			)
+			if tt.want == "" {
+				if len(issues) != 0 {
+					t.Fatalf("expected no issues, got %+v", issues)
+				}
+
+				return
+			}
+			if len(issues) != 1 {
+				t.Fatalf("expected 1 issue, got %+v", issues)
+			}
+			if issues[0].Level != tt.wantLevel {
+				t.Errorf("level = %s, want %s", issues[0].Level, tt.wantLevel)
+			}
+			if !strings.Contains(issues[0].Message, tt.want) {
+				t.Errorf("message %q does not contain %q", issues[0].Message, tt.want)
+			}
+		})
+	}
+}
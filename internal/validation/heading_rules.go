@@ -0,0 +1,47 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/markdown"
+)
+
+// validateHeadingHierarchy reports heading level jumps (## followed
+// directly by ####), which silently attach content to the wrong section,
+// and scenarios that are not inside a requirement.
+func validateHeadingHierarchy(
+	path, content string,
+) []ValidationIssue {
+	var issues []ValidationIssue
+	for _, h := range markdown.LintHeadings([]byte(content)) {
+		issue := ValidationIssue{Path: path, Line: h.Line}
+		switch h.Kind {
+		case markdown.OrphanScenario:
+			issue.Level = LevelError
+			issue.Message = fmt.Sprintf(
+				"%s is not inside a '### Requirement:'; move it under "+
+					"the requirement it belongs to",
+				h.Text,
+			)
+		case markdown.HeadingJump:
+			issue.Level = LevelWarning
+			issue.Message = fmt.Sprintf(
+				"Heading '%s' jumps from H%d to H%d",
+				h.Text,
+				h.Prev,
+				h.Level,
+			)
+			if h.Want != h.Level {
+				issue.Message += fmt.Sprintf(
+					"; use '%s %s' (spectr fmt --headings fixes this)",
+					strings.Repeat("#", h.Want),
+					h.Text,
+				)
+			}
+		}
+		issues = append(issues, issue)
+	}
+
+	return issues
+}
@@ -0,0 +1,83 @@
+package validation
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateSpecFile_HeadingHierarchy(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{
+			name: "jump",
+			content: `# Spec
+
+## Requirements
+
+### Requirement: Login
+The system SHALL log users in.
+
+#### Scenario: Success
+- **WHEN** a user logs in
+- **THEN** a session starts
+
+###### Notes
+`,
+			want: []string{"Heading 'Notes' jumps from H4 to H6; use '##### Notes'"},
+		},
+		{
+			name: "scenario outside a requirement",
+			content: `# Spec
+
+## Requirements
+
+### Requirement: Login
+The system SHALL log users in.
+
+#### Scenario: Success
+- **WHEN** a user logs in
+- **THEN** a session starts
+
+### Background
+
+#### Scenario: Lost
+- **WHEN** this is read
+- **THEN** it belongs to no requirement
+`,
+			want: []string{"Scenario: Lost is not inside a '### Requirement:'"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "spec.md")
+			if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			report, err := ValidateSpecFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if report.Valid {
+				t.Fatal("expected invalid report")
+			}
+			for _, want := range tt.want {
+				found := false
+				for _, issue := range report.Issues {
+					if strings.Contains(issue.Message, want) {
+						found = true
+					}
+				}
+				if !found {
+					t.Errorf("no issue containing %q in %+v", want, report.Issues)
+				}
+			}
+		})
+	}
+}
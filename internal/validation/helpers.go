@@ -2,6 +2,7 @@
 package validation
 
 import (
+	"context"
 	"fmt"
 	"path/filepath"
 	"slices"
@@ -14,6 +15,8 @@ const (
 	ItemTypeChange = "change"
 	// ItemTypeSpec represents a spec item type
 	ItemTypeSpec = "spec"
+	// ItemTypeConfig represents the spectr.yaml project configuration
+	ItemTypeConfig = "config"
 	// SpectrDir is the base directory for spectr files
 	SpectrDir = "spectr"
 )
@@ -135,16 +138,39 @@ func ValidateItemByType(
 func ValidateSingleItem(
 	validator *Validator,
 	item ValidationItem,
+) (BulkResult, error) {
+	return ValidateSingleItemContext(
+		context.Background(),
+		validator,
+		item,
+	)
+}
+
+// ValidateSingleItemContext is like ValidateSingleItem but stops early
+// with ctx.Err() once ctx is done.
+func ValidateSingleItemContext(
+	ctx context.Context,
+	validator *Validator,
+	item ValidationItem,
 ) (BulkResult, error) {
 	var report *ValidationReport
 	var err error
 
-	if item.ItemType == ItemTypeChange {
-		report, err = validator.ValidateChange(
+	switch {
+	case item.Kind != nil:
+		report, err = validator.ValidateKindContext(ctx, *item.Kind, item.Path)
+	case item.ItemType == ItemTypeChange:
+		report, err = validator.ValidateChangeContext(
+			ctx,
 			item.Path,
 		)
-	} else {
-		report, err = validator.ValidateSpec(item.Path)
+	case item.ItemType == ItemTypeConfig:
+		report, err = validator.ValidateConfigContext(ctx, item.Path)
+	default:
+		report, err = validator.ValidateSpecContext(ctx, item.Path)
+		if err == nil {
+			report, err = ScopeReport(item, report)
+		}
 	}
 
 	if err != nil {
@@ -0,0 +1,97 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/fileio"
+	"github.com/connerohnesorge/spectr/internal/implindex"
+)
+
+// ValidateImplementationMarkers checks that every requirement in a spec
+// has at least one `spectr:impl` marker in the implementation index.
+// Requirements without a marker produce an issue pointing at the
+// requirement header. Like other rules, warnings are reported as errors.
+func ValidateImplementationMarkers(
+	specPath, specID string,
+	idx *implindex.Index,
+) ([]ValidationIssue, error) {
+	return validateMarkers(specPath, specID, idx.Lookup, func(name string) string {
+		return fmt.Sprintf(
+			"Requirement has no implementation marker "+
+				"(add '// %s %s#%s' to the implementing code)",
+			implindex.MarkerKeyword,
+			specID,
+			name,
+		)
+	})
+}
+
+// ValidateTestMarkers checks that every requirement in a spec has at
+// least one `spectr:req` test marker in the index, like
+// ValidateImplementationMarkers does for implementation markers.
+func ValidateTestMarkers(
+	specPath, specID string,
+	idx *implindex.Index,
+) ([]ValidationIssue, error) {
+	return validateMarkers(specPath, specID, idx.LookupTests, func(name string) string {
+		return fmt.Sprintf(
+			"Requirement has no test marker "+
+				"(run 'spectr scaffold tests %s --lang go' or add '// %s %s#%s' to its test)",
+			specID,
+			implindex.TestMarkerKeyword,
+			specID,
+			name,
+		)
+	})
+}
+
+// validateMarkers reports the requirements of a spec for which lookup
+// finds no marker, with the message returned by message.
+func validateMarkers(
+	specPath, specID string,
+	lookup func(specID, requirement string) []implindex.Marker,
+	message func(name string) string,
+) ([]ValidationIssue, error) {
+	contentStr, err := fileio.ReadString(specPath)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to read spec file: %w",
+			err,
+		)
+	}
+
+	lines := strings.Split(contentStr, "\n")
+	requirementsContent, ok := ExtractSections(contentStr)["Requirements"]
+	if !ok {
+		return make([]ValidationIssue, 0), nil
+	}
+
+	requirementsLine := findSectionLine(lines, "Requirements")
+	issues := make([]ValidationIssue, 0)
+	for _, req := range ExtractRequirements(requirementsContent) {
+		if len(lookup(specID, req.Name)) > 0 {
+			continue
+		}
+
+		issues = append(issues, ValidationIssue{
+			Level: LevelWarning,
+			Path: fmt.Sprintf(
+				"%s: Requirement '%s'",
+				specPath,
+				req.Name,
+			),
+			Line: findRequirementLine(
+				lines,
+				req.Name,
+				requirementsLine,
+			),
+			Message: message(req.Name),
+		})
+	}
+
+	// Always convert warnings to errors (strict validation)
+	convertWarningsToErrors(issues)
+
+	return issues, nil
+}
@@ -0,0 +1,90 @@
+package validation
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/connerohnesorge/spectr/internal/implindex"
+)
+
+func TestValidateImplementationMarkers(t *testing.T) {
+	content := `# Auth Specification
+
+## Requirements
+
+### Requirement: Login
+The system SHALL authenticate users.
+
+#### Scenario: Valid login
+- **WHEN** credentials are valid
+- **THEN** a session is created
+
+### Requirement: Logout
+The system SHALL end sessions.
+
+#### Scenario: Logout
+- **WHEN** the user logs out
+- **THEN** the session is destroyed
+`
+
+	tmpDir := t.TempDir()
+	specPath := filepath.Join(tmpDir, "spec.md")
+	if err := os.WriteFile(specPath, []byte(content), 0o644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	idx := implindex.NewIndex()
+	idx.Add(implindex.Marker{
+		Spec:        "auth",
+		Requirement: "login",
+		File:        "auth.go",
+		Line:        10,
+	})
+
+	issues, err := ValidateImplementationMarkers(specPath, "auth", idx)
+	if err != nil {
+		t.Fatalf("ValidateImplementationMarkers returned error: %v", err)
+	}
+
+	if len(issues) != 1 {
+		t.Fatalf("Expected 1 issue, got %d: %+v", len(issues), issues)
+	}
+
+	issue := issues[0]
+	if issue.Level != LevelError {
+		t.Errorf("Expected ERROR level, got %s", issue.Level)
+	}
+	if !strings.Contains(issue.Path, "Logout") {
+		t.Errorf("Expected issue for Logout, got path %q", issue.Path)
+	}
+	if issue.Line != 12 {
+		t.Errorf("Expected line 12, got %d", issue.Line)
+	}
+}
+
+func TestValidateTestMarkers(t *testing.T) {
+	content := "# Auth\n\n## Requirements\n\n### Requirement: Login\nText.\n\n" +
+		"### Requirement: Logout\nText.\n"
+
+	specPath := filepath.Join(t.TempDir(), "spec.md")
+	if err := os.WriteFile(specPath, []byte(content), 0o644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	idx := implindex.NewIndex()
+	idx.Add(implindex.Marker{Spec: "auth", Requirement: "Login", File: "auth.go", Line: 3})
+	idx.Add(implindex.Marker{Spec: "auth", Requirement: "Logout", File: "auth_test.go", Line: 9, Test: true})
+
+	issues, err := ValidateTestMarkers(specPath, "auth", idx)
+	if err != nil {
+		t.Fatalf("ValidateTestMarkers returned error: %v", err)
+	}
+	if len(issues) != 1 || !strings.Contains(issues[0].Path, "'Login'") {
+		t.Fatalf("Expected one issue for Login, got %+v", issues)
+	}
+	if !strings.Contains(issues[0].Message, "spectr scaffold tests auth --lang go") {
+		t.Errorf("Message = %q, want the scaffold hint", issues[0].Message)
+	}
+}
@@ -0,0 +1,58 @@
+package validation
+
+import (
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/inherit"
+	"github.com/connerohnesorge/spectr/internal/markdown"
+	"github.com/connerohnesorge/spectr/internal/parsers"
+)
+
+// validateInheritance resolves the base of a spec that declares extends
+// in its frontmatter. It returns the normalized names of the inherited
+// requirements, which the spec's own blocks of the same name override,
+// and an error for a missing base or an inheritance cycle.
+func validateInheritance(
+	path string,
+	lines []string,
+) (map[string]bool, []ValidationIssue) {
+	base, err := inherit.Base(path)
+	if err != nil || base == "" {
+		// Unparsable frontmatter is validated without inheritance
+		return nil, nil
+	}
+
+	reqs, err := inherit.ResolveFile(path)
+	if err != nil {
+		return nil, []ValidationIssue{{
+			Level:   LevelError,
+			Path:    path,
+			Line:    findLineContaining(lines, "extends:", 1),
+			Message: err.Error(),
+		}}
+	}
+
+	inherited := make(map[string]bool)
+	for _, req := range reqs {
+		if req.Inherited {
+			inherited[parsers.NormalizeRequirementName(req.Name)] = true
+		}
+	}
+
+	return inherited, nil
+}
+
+// hasOwnDescription reports whether requirement content has text before
+// its first scenario. An override without one keeps the base's.
+func hasOwnDescription(content string) bool {
+	for line := range strings.SplitSeq(content, "\n") {
+		if _, ok := markdown.MatchScenarioHeader(strings.TrimSpace(line)); ok {
+			return false
+		}
+		if strings.TrimSpace(line) != "" {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,167 @@
+package validation
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/connerohnesorge/spectr/internal/parsers"
+)
+
+const inheritBaseSpec = `# Base Auth
+
+## Requirements
+
+### Requirement: Login
+The system SHALL authenticate users.
+
+#### Scenario: Lockout
+- **WHEN** a user fails five times
+- **THEN** the account is locked
+
+### Requirement: Logout
+The system SHALL end sessions.
+
+#### Scenario: Sign out
+- **WHEN** a user signs out
+- **THEN** the session ends
+`
+
+// writeInheritSpecs writes spec.md files under spectr/specs and returns
+// the spectr directory.
+func writeInheritSpecs(t *testing.T, specs map[string]string) string {
+	t.Helper()
+
+	spectrDir := filepath.Join(t.TempDir(), "spectr")
+	for id, content := range specs {
+		path := filepath.Join(spectrDir, "specs", id, "spec.md")
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return spectrDir
+}
+
+func TestValidateSpecFile_Inheritance(t *testing.T) {
+	tests := []struct {
+		name  string
+		child string
+		base  bool
+		want  string // Substring of the single issue, empty for none
+	}{
+		{
+			name: "scenario override",
+			child: "---\nextends: base-auth\n---\n# Kiosk\n\n## Requirements\n\n" +
+				"### Requirement: Login\n\n#### Scenario: Lockout\n" +
+				"- **WHEN** a user fails three times\n- **THEN** the kiosk is locked\n",
+			base: true,
+		},
+		{
+			name: "description override without SHALL",
+			child: "---\nextends: base-auth\n---\n# Kiosk\n\n## Requirements\n\n" +
+				"### Requirement: Login\nKiosks log users in.\n",
+			base: true,
+			want: "SHALL or MUST",
+		},
+		{
+			name:  "own requirement still needs a scenario",
+			child: "---\nextends: base-auth\n---\n# Kiosk\n\n## Requirements\n\n### Requirement: Idle\nThe system SHALL time out.\n",
+			base:  true,
+			want:  "at least one scenario",
+		},
+		{
+			name:  "missing base",
+			child: "---\nextends: base-auth\n---\n# Kiosk\n\n## Requirements\n",
+			want:  "extends 'base-auth', which does not exist",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			specs := map[string]string{"kiosk-auth": tt.child}
+			if tt.base {
+				specs["base-auth"] = inheritBaseSpec
+			}
+			spectrDir := writeInheritSpecs(t, specs)
+
+			report, err := ValidateSpecFile(filepath.Join(spectrDir, "specs", "kiosk-auth", "spec.md"))
+			if err != nil {
+				t.Fatalf("ValidateSpecFile() error = %v", err)
+			}
+			if tt.want == "" {
+				if len(report.Issues) != 0 {
+					t.Errorf("issues = %+v, want none", report.Issues)
+				}
+
+				return
+			}
+			if len(report.Issues) != 1 || !strings.Contains(report.Issues[0].Message, tt.want) {
+				t.Errorf("issues = %+v, want one containing %q", report.Issues, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidatePreMerge_InheritedRequirement(t *testing.T) {
+	spectrDir := writeInheritSpecs(t, map[string]string{
+		"base-auth": inheritBaseSpec,
+		"kiosk-auth": "---\nextends: base-auth\n---\n# Kiosk\n\n## Requirements\n\n" +
+			"### Requirement: Login\n\n#### Scenario: Lockout\n- **WHEN** x\n- **THEN** y\n",
+	})
+	childPath := filepath.Join(spectrDir, "specs", "kiosk-auth", "spec.md")
+	deltaPath := filepath.Join(t.TempDir(), "spec.md")
+
+	tests := []struct {
+		name  string
+		delta string
+		want  string
+	}{
+		{
+			name:  "modify inherited",
+			delta: "## MODIFIED Requirements\n\n### Requirement: Logout\nThe system SHALL x.\n",
+			want:  `MODIFIED requirement "Logout" is inherited from spec base-auth and read-only`,
+		},
+		{
+			name:  "remove inherited",
+			delta: "## REMOVED Requirements\n\n### Requirement: Logout\n",
+			want:  `REMOVED requirement "Logout" is inherited from spec base-auth`,
+		},
+		{
+			name:  "modify override",
+			delta: "## MODIFIED Requirements\n\n### Requirement: Login\nThe system SHALL x.\n",
+		},
+		{
+			name:  "add override",
+			delta: "## ADDED Requirements\n\n### Requirement: Logout\n#### Scenario: Kiosk\n- **WHEN** x\n- **THEN** y\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := os.WriteFile(deltaPath, []byte(tt.delta), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			plan, err := parsers.ParseDeltaSpec(deltaPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = ValidatePreMerge(childPath, plan, true)
+			if tt.want == "" {
+				if err != nil {
+					t.Errorf("ValidatePreMerge() error = %v", err)
+				}
+
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ValidatePreMerge() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
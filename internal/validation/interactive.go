@@ -3,12 +3,11 @@ package validation
 
 import (
 	"fmt"
-	"os"
 
 	"github.com/charmbracelet/bubbles/table"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
 	"github.com/connerohnesorge/spectr/internal/tui"
-	"github.com/mattn/go-isatty"
 )
 
 const (
@@ -49,11 +48,11 @@ func RunInteractiveValidation(
 	projectPath string,
 	jsonOutput bool,
 ) error {
-	// Check if running in a TTY
-	if !isatty.IsTerminal(os.Stdout.Fd()) {
-		return fmt.Errorf(
-			"interactive mode requires a TTY",
-		)
+	if !tui.Interactive() {
+		return &specterrs.NoTerminalError{
+			Command:     "interactive validation",
+			Alternative: "spectr validate <item> or --all",
+		}
 	}
 
 	// Show menu and get selection
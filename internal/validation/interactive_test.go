@@ -1,11 +1,13 @@
 package validation
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
 
 	"github.com/alecthomas/assert/v2"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
 )
 
 // TestRunInteractiveValidation_NotTTY tests error when not in a TTY
@@ -38,12 +40,9 @@ func TestRunInteractiveValidation_NotTTY(
 	// Restore stdout before assertions
 	os.Stdout = oldStdout
 
-	assert.Error(t, err)
-	assert.Contains(
-		t,
-		err.Error(),
-		"interactive mode requires a TTY",
-	)
+	var noTerminalErr *specterrs.NoTerminalError
+	assert.True(t, errors.As(err, &noTerminalErr))
+	assert.Equal(t, specterrs.ExitNoInput, noTerminalErr.ExitCode())
 }
 
 // TestValidateItems tests the validateItems helper function
@@ -3,17 +3,24 @@ package validation
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
 
 	"github.com/connerohnesorge/spectr/internal/discovery"
+	"github.com/connerohnesorge/spectr/internal/kinds"
 )
 
 // ValidationItem represents an item to validate
 type ValidationItem struct {
 	Name     string
-	ItemType string // "change" or "spec"
+	ItemType string // "change", "spec", "config" or a custom kind name
 	Path     string
 	RootPath string // Relative path to spectr root (for multi-root scenarios)
+	// Requirements scopes a spec report to these requirement names;
+	// empty validates the whole spec (see ScopeReport)
+	Requirements []string
+	// Kind is set for items of a custom kind declared in spectr.yaml
+	Kind *kinds.Kind
 }
 
 // CreateValidationItems creates validation items from IDs and item type.
@@ -46,7 +53,8 @@ func CreateValidationItems(
 	return items
 }
 
-// GetAllItems returns all changes and specs from the project path.
+// GetAllItems returns all changes, specs and custom kind items from the
+// project path.
 func GetAllItems(
 	projectPath string,
 ) ([]ValidationItem, error) {
@@ -60,7 +68,12 @@ func GetAllItems(
 		return nil, err
 	}
 
-	return append(changes, specs...), nil
+	custom, err := GetKindItems(projectPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(append(changes, specs...), custom...), nil
 }
 
 // GetChangeItems returns all changes from the project path.
@@ -119,7 +132,67 @@ func GetSpecItems(
 	), nil
 }
 
-// GetAllItemsMultiRoot returns all changes and specs from multiple roots.
+// GetKindItems returns the items of the custom kinds declared in the
+// project's spectr.yaml, in declaration order.
+func GetKindItems(
+	projectPath string,
+) ([]ValidationItem, error) {
+	registry, err := kinds.Load(projectPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []ValidationItem
+	for i := range registry {
+		kindItems, err := registry[i].Items()
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range kindItems {
+			items = append(items, KindItem(&registry[i], item))
+		}
+	}
+
+	return items, nil
+}
+
+// KindItem returns the validation item of an item of a custom kind.
+func KindItem(kind *kinds.Kind, item kinds.Item) ValidationItem {
+	return ValidationItem{
+		Name:     item.ID,
+		ItemType: kind.Name,
+		Path:     item.Path,
+		Kind:     kind,
+	}
+}
+
+// GetConfigItems returns the project's spectr.yaml as a validation item,
+// or nothing if the project has no config file.
+func GetConfigItems(
+	projectPath string,
+) ([]ValidationItem, error) {
+	path := filepath.Join(projectPath, ConfigFileName)
+	if _, err := os.Stat(path); err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf(
+			"failed to access %s: %w",
+			ConfigFileName,
+			err,
+		)
+	}
+
+	return []ValidationItem{{
+		Name:     ConfigFileName,
+		ItemType: ItemTypeConfig,
+		Path:     path,
+	}}, nil
+}
+
+// GetAllItemsMultiRoot returns all changes and specs from multiple roots,
+// plus each root's spectr.yaml when present.
 func GetAllItemsMultiRoot(
 	roots []discovery.SpectrRoot,
 ) ([]ValidationItem, error) {
@@ -131,6 +204,12 @@ func GetAllItemsMultiRoot(
 			return nil, err
 		}
 
+		configItems, err := GetConfigItems(root.Path)
+		if err != nil {
+			return nil, err
+		}
+		rootItems = append(rootItems, configItems...)
+
 		// Add root path to each item
 		for i := range rootItems {
 			rootItems[i].RootPath = root.RelativeTo
@@ -0,0 +1,124 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/fileio"
+	"github.com/connerohnesorge/spectr/internal/kinds"
+	"github.com/connerohnesorge/spectr/internal/markdown"
+)
+
+// ValidateKindFile validates an item of a custom kind with the kind's
+// profile: the sections profile checks for a # title and the kind's
+// required ## sections, the spec profile adds the spec rules, and none
+// checks nothing.
+func ValidateKindFile(
+	kind kinds.Kind,
+	path string,
+) (*ValidationReport, error) {
+	return validateKindFile(kind, path, nil)
+}
+
+// validateKindFile implements ValidateKindFile, passing parseOpts to the
+// spec rules.
+func validateKindFile(
+	kind kinds.Kind,
+	path string,
+	parseOpts []markdown.ParseOption,
+) (*ValidationReport, error) {
+	if kind.Validation == kinds.ValidationNone {
+		return NewValidationReport(nil), nil
+	}
+
+	content, err := fileio.ReadString(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	issues := validateKindSections(kind, path, content)
+
+	if kind.Validation == kinds.ValidationSpec {
+		report, err := validateSpecFile(path, parseOpts)
+		if err != nil {
+			return nil, err
+		}
+		issues = append(issues, report.Issues...)
+	}
+
+	convertWarningsToErrors(issues)
+
+	return NewValidationReport(issues), nil
+}
+
+// validateKindSections checks the title and required sections of an item.
+// Sections are matched case-insensitively; a required section with no
+// content is a WARNING.
+func validateKindSections(
+	kind kinds.Kind,
+	path, content string,
+) []ValidationIssue {
+	var issues []ValidationIssue
+
+	// Lines are lowered to find section headings case-insensitively
+	lines := strings.Split(strings.ToLower(content), "\n")
+	hasTitle := slices.ContainsFunc(lines, func(line string) bool {
+		return strings.HasPrefix(strings.TrimSpace(line), "# ")
+	})
+	if !hasTitle {
+		issues = append(issues, ValidationIssue{
+			Level:   LevelError,
+			Path:    path,
+			Line:    1,
+			Message: "Missing '# ' title heading",
+		})
+	}
+
+	sections := make(map[string]string)
+	for name, body := range ExtractSections(content) {
+		sections[strings.ToLower(name)] = body
+	}
+	for _, section := range kind.Sections {
+		body, ok := sections[strings.ToLower(strings.TrimSpace(section))]
+		switch {
+		case !ok:
+			issues = append(issues, ValidationIssue{
+				Level:   LevelError,
+				Path:    path,
+				Line:    1,
+				Message: fmt.Sprintf("Missing required '## %s' section", section),
+			})
+		case body == "":
+			issues = append(issues, ValidationIssue{
+				Level:   LevelWarning,
+				Path:    path,
+				Line:    findLineContaining(lines, "## "+strings.ToLower(strings.TrimSpace(section)), 1),
+				Message: fmt.Sprintf("Section '## %s' is empty", section),
+			})
+		}
+	}
+
+	return issues
+}
+
+// ValidateKindContext is like ValidateKindFile but returns ctx.Err()
+// without validating if ctx is already done.
+func (v *Validator) ValidateKindContext(
+	ctx context.Context,
+	kind kinds.Kind,
+	path string,
+) (*ValidationReport, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	report, err := validateKindFile(kind, path, v.ParseOptions)
+	if err != nil {
+		return nil, err
+	}
+	v.emit(report.Issues)
+
+	return report, nil
+}
@@ -0,0 +1,82 @@
+package validation
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/connerohnesorge/spectr/internal/kinds"
+	"github.com/connerohnesorge/spectr/internal/testutil"
+)
+
+func TestValidateKindFile(t *testing.T) {
+	sections := []string{"Context", "Decision"}
+
+	tests := []struct {
+		name       string
+		validation string
+		content    string
+		want       []string // Message fragments, in order
+	}{
+		{
+			name:       "complete",
+			validation: kinds.ValidationSections,
+			content:    "# Use Postgres\n\n## Context\nWe need a store.\n\n## decision\nPostgres.\n",
+		},
+		{
+			name:       "missing title and section",
+			validation: kinds.ValidationSections,
+			content:    "## Context\nWe need a store.\n",
+			want:       []string{"Missing '# ' title heading", "Missing required '## Decision' section"},
+		},
+		{
+			name:       "empty section",
+			validation: kinds.ValidationSections,
+			content:    "# Use Postgres\n\n## Context\n\n## Decision\nPostgres.\n",
+			want:       []string{"Section '## Context' is empty"},
+		},
+		{
+			name:       "none",
+			validation: kinds.ValidationNone,
+			content:    "no headings at all\n",
+		},
+		{
+			name:       "spec rules",
+			validation: kinds.ValidationSpec,
+			content:    "# Use Postgres\n\n## Context\nWe need a store.\n\n## Decision\nPostgres.\n",
+			want:       []string{"Missing required '## Requirements' section"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "0001.md")
+			testutil.WriteFile(t, path, tt.content)
+
+			report, err := ValidateKindFile(kinds.Kind{
+				Name:       "adr",
+				Sections:   sections,
+				Validation: tt.validation,
+			}, path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(tt.want) == 0 {
+				if !report.Valid {
+					t.Fatalf("report = %+v, want valid", report.Issues)
+				}
+
+				return
+			}
+			if report.Valid || len(report.Issues) < len(tt.want) {
+				t.Fatalf("issues = %+v, want %q", report.Issues, tt.want)
+			}
+			for i, want := range tt.want {
+				if report.Issues[i].Level != LevelError ||
+					!strings.Contains(report.Issues[i].Message, want) {
+					t.Errorf("issue %d = %+v, want error %q", i, report.Issues[i], want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package validation
+
+// DefaultMaxErrors is the number of issues printed per item when neither
+// --max-errors nor validation.max_errors in spectr.yaml sets one.
+const DefaultMaxErrors = 100
+
+// issueKey identifies issues that LimitReport folds together.
+type issueKey struct {
+	level      ValidationLevel
+	message    string
+	suggestion string
+}
+
+// LimitReport returns a copy of report for display: issues with the same
+// level and message (for example the same rule failing in many
+// requirements) are folded into the first of them, counted in Similar,
+// and only the first maxErrors of the remaining issues are kept; the rest
+// move to Hidden. A maxErrors of zero or less keeps them all. Valid and
+// Summary are unchanged.
+func LimitReport(report *ValidationReport, maxErrors int) *ValidationReport {
+	if report == nil {
+		return nil
+	}
+
+	limited := *report
+	limited.Issues = make([]ValidationIssue, 0, len(report.Issues))
+	index := make(map[issueKey]int, len(report.Issues))
+	for _, issue := range report.Issues {
+		key := issueKey{issue.Level, issue.Message, issue.Suggestion}
+		if i, ok := index[key]; ok {
+			limited.Issues[i].Similar += 1 + issue.Similar
+
+			continue
+		}
+		index[key] = len(limited.Issues)
+		limited.Issues = append(limited.Issues, issue)
+	}
+
+	if maxErrors > 0 && len(limited.Issues) > maxErrors {
+		limited.Hidden = limited.Issues[maxErrors:]
+		limited.Issues = limited.Issues[:maxErrors]
+	}
+
+	return &limited
+}
+
+// LimitResults applies LimitReport to the report of each result.
+func LimitResults(results []BulkResult, maxErrors int) []BulkResult {
+	limited := make([]BulkResult, len(results))
+	for i, result := range results {
+		result.Report = LimitReport(result.Report, maxErrors)
+		limited[i] = result
+	}
+
+	return limited
+}
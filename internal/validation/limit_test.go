@@ -0,0 +1,94 @@
+package validation
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+)
+
+func TestLimitReport(t *testing.T) {
+	noScenario := func(req string) ValidationIssue {
+		return ValidationIssue{
+			Level:   LevelError,
+			Path:    fmt.Sprintf("spec.md: Requirement '%s'", req),
+			Message: "Requirement should have at least one scenario",
+		}
+	}
+	issues := []ValidationIssue{
+		noScenario("A"),
+		{Level: LevelError, Path: "spec.md", Message: "Missing purpose"},
+		noScenario("B"),
+		{Level: LevelWarning, Path: "spec.md", Message: "Requirement should have at least one scenario"},
+		noScenario("C"),
+		{Level: LevelError, Path: "spec.md", Message: "Unclosed code fence"},
+	}
+
+	tests := []struct {
+		name      string
+		maxErrors int
+		want      []string
+		similar   []int
+		hidden    int
+	}{
+		{
+			name:      "unlimited folds identical issues",
+			maxErrors: 0,
+			want: []string{
+				"spec.md: Requirement 'A'", "spec.md", "spec.md", "spec.md",
+			},
+			similar: []int{2, 0, 0, 0},
+		},
+		{
+			name:      "limit keeps the first groups",
+			maxErrors: 2,
+			want:      []string{"spec.md: Requirement 'A'", "spec.md"},
+			similar:   []int{2, 0},
+			hidden:    2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := NewValidationReport(issues)
+			got := LimitReport(report, tt.maxErrors)
+
+			paths := make([]string, len(got.Issues))
+			similar := make([]int, len(got.Issues))
+			for i, issue := range got.Issues {
+				paths[i], similar[i] = issue.Path, issue.Similar
+			}
+			assert.Equal(t, tt.want, paths)
+			assert.Equal(t, tt.similar, similar)
+			assert.Equal(t, tt.hidden, len(got.Hidden))
+			assert.Equal(t, len(issues), got.Total())
+			assert.Equal(t, report.Summary, got.Summary)
+			assert.Equal(t, len(issues), len(report.Issues), "input report changed")
+		})
+	}
+}
+
+func TestPrintHumanReport_Limited(t *testing.T) {
+	issues := make([]ValidationIssue, 0, 40)
+	for i := range 38 {
+		issues = append(issues, ValidationIssue{
+			Level:   LevelError,
+			Path:    fmt.Sprintf("spec.md: Requirement 'R%d'", i),
+			Message: "Requirement should have at least one scenario",
+		})
+	}
+	issues = append(issues,
+		ValidationIssue{Level: LevelError, Path: "spec.md", Message: "Missing purpose"},
+		ValidationIssue{Level: LevelError, Path: "spec.md", Message: "Bad header"},
+	)
+
+	output := captureOutput(func() {
+		PrintHumanReport("auth", LimitReport(NewValidationReport(issues), 2))
+	})
+
+	assert.Contains(t, output, "auth has 40 issue(s)")
+	assert.Contains(t, output, "at least one scenario (37 more similar errors)")
+	assert.Contains(t, output, "Missing purpose")
+	assert.NotContains(t, output, "Bad header")
+	assert.Contains(t, output, "... 1 more issue(s) not shown")
+}
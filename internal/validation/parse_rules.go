@@ -0,0 +1,259 @@
+package validation
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/fileio"
+	"github.com/connerohnesorge/spectr/internal/kinds"
+	"github.com/connerohnesorge/spectr/internal/markdown"
+)
+
+// addParseDiagnostics reports the markdown.Diagnose errors of the file at
+// path: near-miss requirement and scenario headers and unclosed code
+// fences. A diagnostic on the line of an existing issue about the same
+// mistake (such as a malformed scenario) adds its suggestion and fix to
+// that issue instead of reporting it twice. opts are passed to
+// markdown.Diagnose.
+func addParseDiagnostics(
+	path, content string,
+	issues []ValidationIssue,
+	opts ...markdown.ParseOption,
+) []ValidationIssue {
+	return addDiagnostics(path, content, markdown.Diagnose([]byte(content), opts...), issues)
+}
+
+// addDiagnostics reports diags, found in the file at path, as issues
+// merged with the existing issues as described for addParseDiagnostics.
+func addDiagnostics(
+	path, content string,
+	diags []markdown.ParseError,
+	issues []ValidationIssue,
+) []ValidationIssue {
+	idx := markdown.NewLineIndex([]byte(content))
+	for _, diag := range diags {
+		pos := idx.PositionAt(diag.Offset)
+		fix := convertFix(idx, diag.Fix)
+
+		merged := false
+		for i := range issues {
+			issue := &issues[i]
+			if issue.Line == pos.Line && issue.Suggestion == "" &&
+				(issue.Path == path || strings.HasPrefix(issue.Path, path+":")) {
+				issue.Suggestion, issue.Fix = diag.Suggestion, fix
+				merged = true
+
+				break
+			}
+		}
+		if merged {
+			continue
+		}
+
+		issues = append(issues, ValidationIssue{
+			Level:      LevelError,
+			Path:       path,
+			Line:       pos.Line,
+			Column:     pos.Column + 1,
+			Message:    capitalize(diag.Message),
+			Suggestion: diag.Suggestion,
+			Fix:        fix,
+		})
+	}
+
+	return issues
+}
+
+// diagnoseDeltaFile returns the markdown.Diagnose errors of a delta spec,
+// found with opts, plus its delta section titles not read as deltas.
+func diagnoseDeltaFile(source []byte, opts ...markdown.ParseOption) []markdown.ParseError {
+	return append(markdown.Diagnose(source, opts...), markdown.DiagnoseDeltaHeaders(source)...)
+}
+
+// fixDeltaFile returns every diagnoseDeltaFile error of a delta spec plus
+// its non-canonical delta section titles, all of which --fix rewrites.
+func fixDeltaFile(source []byte) []markdown.ParseError {
+	return append(
+		diagnoseDeltaFile(source, markdown.WithMaxErrors(0)),
+		markdown.NonCanonicalDeltaHeaders(source)...,
+	)
+}
+
+// nonCanonicalSectionMsg identifies the warnings about delta section
+// titles that apply as written but are not in canonical form.
+const nonCanonicalSectionMsg = "is not in canonical form"
+
+// isNonCanonicalSectionWarning reports whether message is a warning from
+// addNonCanonicalDeltaSections.
+func isNonCanonicalSectionWarning(message string) bool {
+	return strings.Contains(message, nonCanonicalSectionMsg)
+}
+
+// addNonCanonicalDeltaSections warns about the delta section titles of the
+// delta spec at path written in another form than "## ADDED Requirements",
+// such as "## Added requirements". They apply as written, and `spectr fmt
+// --delta-sections` normalizes them.
+func addNonCanonicalDeltaSections(path, content string, issues []ValidationIssue) []ValidationIssue {
+	source := []byte(content)
+	idx := markdown.NewLineIndex(source)
+	for _, diag := range markdown.NonCanonicalDeltaHeaders(source) {
+		pos := idx.PositionAt(diag.Offset)
+		issues = append(issues, ValidationIssue{
+			Level:      LevelWarning,
+			Path:       path,
+			Line:       pos.Line,
+			Column:     pos.Column + 1,
+			Message:    capitalize(diag.Message),
+			Suggestion: diag.Suggestion,
+			Fix:        convertFix(idx, diag.Fix),
+		})
+	}
+
+	return issues
+}
+
+// unknownSectionMsg identifies the warnings about H2 sections of a delta
+// spec that are not delta sections.
+const unknownSectionMsg = "is not a delta section and is ignored"
+
+// isUnknownSectionWarning reports whether message is a warning from
+// addUnknownDeltaSections.
+func isUnknownSectionWarning(message string) bool {
+	return strings.Contains(message, unknownSectionMsg)
+}
+
+// addUnknownDeltaSections warns about the H2 sections of the delta spec at
+// path that are not delta sections, whose content would otherwise be
+// dropped silently when the change is archived.
+func addUnknownDeltaSections(path, content string, issues []ValidationIssue) []ValidationIssue {
+	source := []byte(content)
+	idx := markdown.NewLineIndex(source)
+	for _, diag := range markdown.UnknownDeltaSections(source) {
+		pos := idx.PositionAt(diag.Offset)
+		issues = append(issues, ValidationIssue{
+			Level:      LevelWarning,
+			Path:       path,
+			Line:       pos.Line,
+			Column:     pos.Column + 1,
+			Message:    capitalize(diag.Message),
+			Suggestion: diag.Suggestion,
+		})
+	}
+
+	return issues
+}
+
+// convertFix converts a byte-offset fix to 1-based lines and columns.
+func convertFix(idx *markdown.LineIndex, fix *markdown.Fix) *Fix {
+	if fix == nil {
+		return nil
+	}
+	start, end := idx.PositionAt(fix.Start), idx.PositionAt(fix.End)
+
+	return &Fix{
+		Title:     fix.Title,
+		Line:      start.Line,
+		Column:    start.Column + 1,
+		EndLine:   end.Line,
+		EndColumn: end.Column + 1,
+		NewText:   fix.NewText,
+	}
+}
+
+// capitalize upper-cases the first letter of an ASCII message.
+func capitalize(s string) string {
+	if s == "" || s[0] < 'a' || s[0] > 'z' {
+		return s
+	}
+
+	return string(s[0]-'a'+'A') + s[1:]
+}
+
+// FixItem applies the quick fixes of markdown.Diagnose to the markdown
+// files of item: a spec's spec.md, the delta specs of a change, or the
+// file of a custom kind item validated with the spec profile. Delta specs
+// also get their section titles fixed and a change's proposal.md its
+// section headings. It returns the number of issues fixed.
+func FixItem(item ValidationItem) (int, error) {
+	if item.Kind != nil {
+		if item.Kind.Validation != kinds.ValidationSpec {
+			return 0, nil
+		}
+
+		return FixFile(item.Path)
+	}
+
+	switch item.ItemType {
+	case ItemTypeSpec:
+		return FixFile(item.Path)
+	case ItemTypeChange:
+		total := 0
+		err := fileio.WalkDir(
+			filepath.Join(item.Path, "specs"),
+			func(path string, entry fs.DirEntry, err error) error {
+				if err != nil || entry.IsDir() || entry.Name() != "spec.md" {
+					return err
+				}
+				n, err := fixFile(path, fixDeltaFile)
+				total += n
+
+				return err
+			},
+		)
+		if err != nil && !os.IsNotExist(err) {
+			return total, err
+		}
+
+		n, err := fixFile(filepath.Join(item.Path, "proposal.md"), diagnoseProposal)
+		if err != nil && !os.IsNotExist(err) {
+			return total + n, err
+		}
+
+		return total + n, nil
+	default:
+		return 0, nil
+	}
+}
+
+// FixFile applies the quick fixes of markdown.Diagnose to the file at
+// path and returns the number of issues fixed. The file is only written
+// when something changed.
+func FixFile(path string) (int, error) {
+	return fixFile(path, diagnoseAll)
+}
+
+// diagnoseAll returns every markdown.Diagnose error of source, without
+// the limit on how many are collected, so --fix corrects them all.
+func diagnoseAll(source []byte) []markdown.ParseError {
+	return markdown.Diagnose(source, markdown.WithMaxErrors(0))
+}
+
+// fixFile applies the quick fixes of the diagnose errors of the file at
+// path and returns the number of issues fixed.
+func fixFile(
+	path string,
+	diagnose func([]byte) []markdown.ParseError,
+) (int, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0, err
+	}
+	source, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+
+	diags := diagnose(source)
+	fixed := markdown.ApplyFixes(source, diags)
+	if string(fixed) == string(source) {
+		return 0, nil
+	}
+	if err := os.WriteFile(path, fixed, info.Mode().Perm()); err != nil {
+		return 0, fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	return len(diags) - len(diagnose(fixed)), nil
+}
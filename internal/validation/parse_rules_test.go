@@ -0,0 +1,132 @@
+package validation
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateSpecFile_ParseDiagnostics(t *testing.T) {
+	tests := []struct {
+		name       string
+		content    string
+		message    string
+		suggestion string
+		fix        Fix
+	}{
+		{
+			name: "requirement at level 2",
+			content: `# Spec
+
+## Requirements
+
+## Requirement: Login
+The system SHALL log users in.
+`,
+			message:    "Malformed requirement header '## Requirement: Login'",
+			suggestion: "did you mean '### Requirement:'?",
+			fix: Fix{
+				Title: "Replace with '### Requirement:'", Line: 5, Column: 1,
+				EndLine: 5, EndColumn: 22, NewText: "### Requirement: Login",
+			},
+		},
+		{
+			name: "bold scenario merges with the scenario format rule",
+			content: `# Spec
+
+## Requirements
+
+### Requirement: Login
+The system SHALL log users in.
+
+**Scenario: Success**
+- **WHEN** a user logs in
+- **THEN** a session starts
+`,
+			message:    "Scenarios must use '#### Scenario:' format",
+			suggestion: "did you mean '#### Scenario:'?",
+			fix: Fix{
+				Title: "Replace with '#### Scenario:'", Line: 8, Column: 1,
+				EndLine: 8, EndColumn: 22, NewText: "#### Scenario: Success",
+			},
+		},
+		{
+			name: "unclosed fence",
+			content: "# Spec\n\n## Requirements\n\n### Requirement: Login\n" +
+				"The system SHALL log users in.\n\n#### Scenario: Success\n" +
+				"- **WHEN** a user logs in\n- **THEN** a session starts\n\n```\n",
+			message:    "Unclosed code fence started at line 12",
+			suggestion: "close it with '```'",
+			fix: Fix{
+				Title: "Close code fence at end of file", Line: 13, Column: 1,
+				EndLine: 13, EndColumn: 1, NewText: "```\n",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "spec.md")
+			if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			report, err := ValidateSpecFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var matches []ValidationIssue
+			for _, issue := range report.Issues {
+				if issue.Suggestion != "" {
+					matches = append(matches, issue)
+				}
+			}
+			if len(matches) != 1 {
+				t.Fatalf("want 1 issue with a suggestion, got %+v", report.Issues)
+			}
+			issue := matches[0]
+			if !strings.Contains(issue.Message, tt.message) {
+				t.Errorf("Message = %q, want it to contain %q", issue.Message, tt.message)
+			}
+			if issue.Suggestion != tt.suggestion {
+				t.Errorf("Suggestion = %q, want %q", issue.Suggestion, tt.suggestion)
+			}
+			if issue.Fix == nil || *issue.Fix != tt.fix {
+				t.Errorf("Fix = %+v, want %+v", issue.Fix, tt.fix)
+			}
+		})
+	}
+}
+
+func TestFixFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "spec.md")
+	content := "## Requirements\n\n## Requirement: Login\nThe system SHALL x.\n\n" +
+		"### Scenario: Ok\n- **WHEN** x\n- **THEN** y\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	n, err := FixFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 2 {
+		t.Errorf("FixFile() = %d, want 2", n)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "## Requirements\n\n### Requirement: Login\nThe system SHALL x.\n\n" +
+		"#### Scenario: Ok\n- **WHEN** x\n- **THEN** y\n"
+	if string(data) != want {
+		t.Errorf("file = %q, want %q", data, want)
+	}
+
+	n, err = FixFile(path)
+	if err != nil || n != 0 {
+		t.Errorf("second FixFile() = %d, %v; want 0, nil", n, err)
+	}
+}
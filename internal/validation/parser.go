@@ -62,6 +62,28 @@ func ExtractSections(
 	return sections
 }
 
+// canonicalDeltaSections returns sections with the delta sections keyed by
+// their canonical title, so "Added requirements" or a configured alias is
+// found as "ADDED Requirements". A section already under its canonical
+// title wins over other spellings of it.
+func canonicalDeltaSections(sections map[string]string) map[string]string {
+	canonical := make(map[string]string, len(sections))
+	for name, content := range sections {
+		deltaType, ok := markdown.CanonicalDeltaSection(name)
+		if !ok {
+			canonical[name] = content
+
+			continue
+		}
+		title := markdown.DeltaSectionTitle(deltaType)
+		if _, exists := canonical[title]; !exists || name == title {
+			canonical[title] = content
+		}
+	}
+
+	return canonical
+}
+
 // ExtractRequirements returns all requirements found in content
 // Looks for ### Requirement: headers
 func ExtractRequirements(
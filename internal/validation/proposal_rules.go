@@ -0,0 +1,71 @@
+package validation
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/connerohnesorge/spectr/internal/markdown"
+)
+
+// proposalSections are the H2 sections every proposal.md needs, in order.
+var proposalSections = []string{"Why", "What Changes", "Impact"}
+
+// validateProposalSections checks that the change's proposal.md has the
+// "## Why", "## What Changes" and "## Impact" sections in that order.
+// Headings that name a section in another case or at another level are
+// reported with a fix instead of as missing. A change without a
+// proposal.md is not checked.
+func validateProposalSections(changeDir string) []ValidationIssue {
+	path := filepath.Join(changeDir, "proposal.md")
+	source, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+
+	diags := diagnoseProposal(source)
+	issues := addDiagnostics(path, string(source), diags, nil)
+	misspelled := make(map[string]bool, len(diags))
+	for _, diag := range diags {
+		misspelled[diag.Fix.NewText] = true
+	}
+
+	idx := markdown.NewLineIndex(source)
+	prev := ""
+	prevOffset := -1
+	for i, offset := range markdown.SectionOffsets(source, proposalSections) {
+		header := "## " + proposalSections[i]
+		switch {
+		case offset < 0 && !misspelled[header]:
+			issues = append(issues, ValidationIssue{
+				Level:      LevelError,
+				Path:       path,
+				Line:       1,
+				Message:    fmt.Sprintf("Proposal is missing the '%s' section", header),
+				Suggestion: fmt.Sprintf("add a '%s' section", header),
+			})
+		case offset < 0:
+		case offset < prevOffset:
+			issues = append(issues, ValidationIssue{
+				Level: LevelWarning,
+				Path:  path,
+				Line:  idx.PositionAt(offset).Line,
+				Message: fmt.Sprintf(
+					"Proposal section '%s' should come after '%s'",
+					header,
+					prev,
+				),
+			})
+		default:
+			prev, prevOffset = header, offset
+		}
+	}
+
+	return issues
+}
+
+// diagnoseProposal returns the proposal section headings of source not
+// written as "## <section>".
+func diagnoseProposal(source []byte) []markdown.ParseError {
+	return markdown.DiagnoseSectionHeaders(source, proposalSections)
+}
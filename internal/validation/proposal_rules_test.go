@@ -0,0 +1,125 @@
+package validation
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/connerohnesorge/spectr/internal/testutil"
+)
+
+func TestValidateProposalSections(t *testing.T) {
+	tests := []struct {
+		name     string
+		proposal string
+		want     []ValidationIssue
+	}{
+		{
+			name: "all sections in order",
+			proposal: "---\noverride: PAY-1\n---\n\n# Change: X\n\n## Why\n\nA.\n\n" +
+				"## What Changes\n\n- B\n\n## Impact\n\nC.\n\n## Notes\n",
+		},
+		{
+			name:     "missing section",
+			proposal: "# Change: X\n\n## Why\n\nA.\n\n## What Changes\n\n- B\n",
+			want: []ValidationIssue{{
+				Level:   LevelError,
+				Line:    1,
+				Message: "Proposal is missing the '## Impact' section",
+			}},
+		},
+		{
+			name:     "out of order",
+			proposal: "# Change: X\n\n## What Changes\n\n- B\n\n## Why\n\nA.\n\n## Impact\n\nC.\n",
+			want: []ValidationIssue{{
+				Level:   LevelWarning,
+				Line:    3,
+				Message: "Proposal section '## What Changes' should come after '## Why'",
+			}},
+		},
+		{
+			name:     "misspelled section has a fix",
+			proposal: "# Change: X\n\n## Why\n\nA.\n\n### what changes\n\n- B\n\n## Impact\n\nC.\n",
+			want: []ValidationIssue{{
+				Level:   LevelError,
+				Line:    7,
+				Message: "Malformed section header '### what changes'",
+				Fix: &Fix{
+					Title: "Replace with '## What Changes'", Line: 7, Column: 1,
+					EndLine: 7, EndColumn: 17, NewText: "## What Changes",
+				},
+			}},
+		},
+		{
+			name:     "headings in code are ignored",
+			proposal: "# Change: X\n\n## Why\n\n```md\n## Impact\n```\n\n## What Changes\n\n- B\n",
+			want: []ValidationIssue{{
+				Level:   LevelError,
+				Line:    1,
+				Message: "Proposal is missing the '## Impact' section",
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changeDir := t.TempDir()
+			testutil.WriteFile(t, filepath.Join(changeDir, "proposal.md"), tt.proposal)
+
+			issues := validateProposalSections(changeDir)
+			if len(issues) != len(tt.want) {
+				t.Fatalf("validateProposalSections() = %+v, want %d issue(s)", issues, len(tt.want))
+			}
+			for i, want := range tt.want {
+				got := issues[i]
+				if got.Level != want.Level || got.Line != want.Line || got.Message != want.Message {
+					t.Errorf("issues[%d] = %+v, want %+v", i, got, want)
+				}
+				if want.Fix != nil && (got.Fix == nil || *got.Fix != *want.Fix) {
+					t.Errorf("issues[%d].Fix = %+v, want %+v", i, got.Fix, want.Fix)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateProposalSections_NoProposal(t *testing.T) {
+	if issues := validateProposalSections(t.TempDir()); len(issues) != 0 {
+		t.Errorf("validateProposalSections() = %+v, want none", issues)
+	}
+}
+
+func TestFixItem_Change(t *testing.T) {
+	changeDir := t.TempDir()
+	specDir := filepath.Join(changeDir, "specs", "auth")
+	if err := os.MkdirAll(specDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	testutil.WriteFile(t, filepath.Join(changeDir, "proposal.md"),
+		"# Change: X\n\n## why\n\nA.\n\n## What Changes:\n\n- B\n\n## Impact\n\nC.\n")
+	testutil.WriteFile(t, filepath.Join(specDir, "spec.md"),
+		"## Added Requirements\n\n### Requirement: Login\nThe system SHALL x.\n")
+
+	n, err := FixItem(ValidationItem{ItemType: ItemTypeChange, Path: changeDir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 3 {
+		t.Errorf("FixItem() = %d, want 3", n)
+	}
+
+	for path, want := range map[string]string{
+		filepath.Join(changeDir, "proposal.md"): "# Change: X\n\n## Why\n\nA.\n\n" +
+			"## What Changes\n\n- B\n\n## Impact\n\nC.\n",
+		filepath.Join(specDir, "spec.md"): "## ADDED Requirements\n\n" +
+			"### Requirement: Login\nThe system SHALL x.\n",
+	} {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != want {
+			t.Errorf("%s = %q, want %q", path, data, want)
+		}
+	}
+}
@@ -0,0 +1,220 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/markdown"
+	"github.com/connerohnesorge/spectr/internal/parsers"
+)
+
+// renamedPair is a RENAMED FROM/TO pair with the 1-indexed line and column
+// of each requirement name in the delta file.
+type renamedPair struct {
+	parsers.RenameOp
+	FromLine, FromColumn int
+	ToLine, ToColumn     int
+}
+
+// renameProblem is a RENAMED pair that cannot be applied to the spec.
+type renameProblem struct {
+	index   int  // Index of the pair in the RENAMED section
+	onTo    bool // Whether the TO name (rather than FROM) is at fault
+	message string
+}
+
+// checkRenames replays RENAMED operations in order against the base
+// spec's requirements, the way the archiver applies them, and reports
+// each pair that cannot be applied:
+//   - the FROM requirement must exist at that point and must not be a
+//     name introduced by an earlier pair (A -> B, then B -> C)
+//   - the TO name must not collide with a requirement that exists at that
+//     point, or with an ADDED requirement of the same delta
+//
+// A pair that fails is skipped, so later pairs are checked against the
+// requirements as they would be without it.
+func checkRenames(
+	baseReqs []parsers.RequirementBlock,
+	added []parsers.RequirementBlock,
+	renames []parsers.RenameOp,
+) []renameProblem {
+	current := make(map[string]bool, len(baseReqs))
+	for _, req := range baseReqs {
+		current[parsers.NormalizeRequirementName(req.Name)] = true
+	}
+
+	addedNames := make(map[string]bool, len(added))
+	for _, req := range added {
+		addedNames[parsers.NormalizeRequirementName(req.Name)] = true
+	}
+
+	// renamedTo maps a new name to the FROM name it replaced and
+	// renamedFrom maps a replaced name to its new name
+	renamedTo := make(map[string]string)
+	renamedFrom := make(map[string]string)
+
+	var problems []renameProblem
+	for i, op := range renames {
+		from := parsers.NormalizeRequirementName(op.From)
+		to := parsers.NormalizeRequirementName(op.To)
+
+		if original, ok := renamedTo[from]; ok {
+			problems = append(problems, renameProblem{i, false, fmt.Sprintf(
+				"RENAMED FROM requirement %q is the new name of %q "+
+					"from an earlier rename; rename %q directly",
+				op.From,
+				original,
+				original,
+			)})
+
+			continue
+		}
+
+		if !current[from] {
+			message := fmt.Sprintf(
+				"RENAMED FROM requirement %q does not exist in base spec",
+				op.From,
+			)
+			if newName, ok := renamedFrom[from]; ok {
+				message = fmt.Sprintf(
+					"RENAMED FROM requirement %q was already renamed to %q",
+					op.From,
+					newName,
+				)
+			}
+			problems = append(problems, renameProblem{i, false, message})
+
+			continue
+		}
+
+		if to != from && current[to] {
+			message := fmt.Sprintf(
+				"RENAMED TO requirement %q already exists in base spec",
+				op.To,
+			)
+			if original, ok := renamedTo[to]; ok {
+				message = fmt.Sprintf(
+					"RENAMED TO requirement %q is already the new name of %q",
+					op.To,
+					original,
+				)
+			}
+			problems = append(problems, renameProblem{i, true, message})
+
+			continue
+		}
+
+		if addedNames[to] {
+			problems = append(problems, renameProblem{i, true, fmt.Sprintf(
+				"RENAMED TO requirement %q is also ADDED in this delta",
+				op.To,
+			)})
+
+			continue
+		}
+
+		delete(current, from)
+		current[to] = true
+		renamedTo[to] = op.From
+		renamedFrom[from] = op.To
+	}
+
+	return problems
+}
+
+// validateRenamedAgainstBaseSpec checks the RENAMED pairs of a delta file
+// against the current base spec with checkRenames. Unlike the other
+// pre-merge checks, which stop at the first failure, it reports every
+// failing pair at the line and column of the offending name.
+func validateRenamedAgainstBaseSpec(
+	deltaSpecPath string,
+	lines []string,
+	baseReqs []parsers.RequirementBlock,
+	added []parsers.RequirementBlock,
+) []ValidationIssue {
+	pairs := findRenamedPairs(lines)
+	if len(pairs) == 0 {
+		return nil
+	}
+
+	renames := make([]parsers.RenameOp, len(pairs))
+	for i, pair := range pairs {
+		renames[i] = pair.RenameOp
+	}
+
+	problems := checkRenames(baseReqs, added, renames)
+	issues := make([]ValidationIssue, 0, len(problems))
+	for _, problem := range problems {
+		pair := pairs[problem.index]
+		line, column := pair.FromLine, pair.FromColumn
+		if problem.onTo {
+			line, column = pair.ToLine, pair.ToColumn
+		}
+
+		issues = append(issues, ValidationIssue{
+			Level:   LevelError,
+			Path:    deltaSpecPath,
+			Line:    line,
+			Column:  column,
+			Message: problem.message,
+		})
+	}
+
+	return issues
+}
+
+// findRenamedPairs scans the RENAMED Requirements section of a delta file
+// for FROM/TO pairs, pairing them like parsers.ParseDeltaSpec does: a TO
+// without a preceding FROM is ignored.
+func findRenamedPairs(lines []string) []renamedPair {
+	start := -1
+	for i, line := range lines {
+		deltaType, ok := markdown.MatchH2DeltaSection(strings.TrimSpace(line))
+		if ok && deltaType == string(markdown.DeltaRenamed) {
+			start = i + 1
+
+			break
+		}
+	}
+	if start == -1 {
+		return nil
+	}
+
+	var (
+		pairs   []renamedPair
+		current *renamedPair
+	)
+	for i := start; i < len(lines); i++ {
+		line := lines[i]
+		if strings.HasPrefix(strings.TrimSpace(line), "## ") {
+			break
+		}
+
+		if name, ok := markdown.MatchAnyRenamedFrom(line); ok {
+			current = &renamedPair{
+				RenameOp:   parsers.RenameOp{From: name},
+				FromLine:   i + 1,
+				FromColumn: nameColumn(line, name),
+			}
+
+			continue
+		}
+
+		if name, ok := markdown.MatchAnyRenamedTo(line); ok && current != nil {
+			current.To = name
+			current.ToLine = i + 1
+			current.ToColumn = nameColumn(line, name)
+			pairs = append(pairs, *current)
+			current = nil
+		}
+	}
+
+	return pairs
+}
+
+// nameColumn returns the 1-indexed column where name starts in line. The
+// name ends the FROM/TO line (bar a closing backtick), so the last
+// occurrence is the right one even when the name repeats "Requirement".
+func nameColumn(line, name string) int {
+	return strings.LastIndex(line, name) + 1
+}
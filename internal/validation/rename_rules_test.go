@@ -0,0 +1,180 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/connerohnesorge/spectr/internal/parsers"
+)
+
+func TestCheckRenames(t *testing.T) {
+	base := []parsers.RequirementBlock{
+		{Name: "Login"},
+		{Name: "Logout"},
+		{Name: "Session Expiry"},
+	}
+
+	tests := []struct {
+		name    string
+		added   []string
+		renames []parsers.RenameOp
+		want    []renameProblem // message holds a fragment to match
+	}{
+		{
+			name:    "valid",
+			renames: []parsers.RenameOp{{From: "Login", To: "Sign In"}},
+		},
+		{
+			name:    "case-only rename",
+			renames: []parsers.RenameOp{{From: "login", To: "LOGIN"}},
+		},
+		{
+			name: "name freed by earlier rename",
+			renames: []parsers.RenameOp{
+				{From: "Login", To: "Sign In"},
+				{From: "Logout", To: "Login"},
+			},
+		},
+		{
+			name:    "missing FROM",
+			renames: []parsers.RenameOp{{From: "Signup", To: "Register"}},
+			want:    []renameProblem{{0, false, `"Signup" does not exist in base spec`}},
+		},
+		{
+			name:    "TO collides with base",
+			renames: []parsers.RenameOp{{From: "Login", To: "logout"}},
+			want:    []renameProblem{{0, true, `"logout" already exists in base spec`}},
+		},
+		{
+			name: "chained rename",
+			renames: []parsers.RenameOp{
+				{From: "Login", To: "Sign In"},
+				{From: "Sign In", To: "Authenticate"},
+			},
+			want: []renameProblem{{1, false, `is the new name of "Login"`}},
+		},
+		{
+			name: "renamed twice",
+			renames: []parsers.RenameOp{
+				{From: "Login", To: "Sign In"},
+				{From: "Login", To: "Authenticate"},
+			},
+			want: []renameProblem{{1, false, `was already renamed to "Sign In"`}},
+		},
+		{
+			name: "TO taken by earlier rename",
+			renames: []parsers.RenameOp{
+				{From: "Login", To: "Sign In"},
+				{From: "Logout", To: "Sign In"},
+			},
+			want: []renameProblem{{1, true, `is already the new name of "Login"`}},
+		},
+		{
+			name:    "TO collides with ADDED",
+			added:   []string{"Sign In"},
+			renames: []parsers.RenameOp{{From: "Login", To: "Sign In"}},
+			want:    []renameProblem{{0, true, `is also ADDED in this delta`}},
+		},
+		{
+			name: "reports every failing pair",
+			renames: []parsers.RenameOp{
+				{From: "Signup", To: "Register"},
+				{From: "Login", To: "Session Expiry"},
+				{From: "Logout", To: "Sign Out"},
+			},
+			want: []renameProblem{
+				{0, false, `"Signup" does not exist`},
+				{1, true, `"Session Expiry" already exists`},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			added := make([]parsers.RequirementBlock, len(tt.added))
+			for i, name := range tt.added {
+				added[i] = parsers.RequirementBlock{Name: name}
+			}
+
+			problems := checkRenames(base, added, tt.renames)
+			if len(problems) != len(tt.want) {
+				t.Fatalf("got %d problems %+v, want %d", len(problems), problems, len(tt.want))
+			}
+
+			for i, want := range tt.want {
+				got := problems[i]
+				if got.index != want.index || got.onTo != want.onTo {
+					t.Errorf(
+						"problem %d at pair %d (onTo=%v), want pair %d (onTo=%v)",
+						i, got.index, got.onTo, want.index, want.onTo,
+					)
+				}
+				if !strings.Contains(got.message, want.message) {
+					t.Errorf("problem %d message %q, want %q", i, got.message, want.message)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateChangeDeltaSpecs_RenamedPositions(t *testing.T) {
+	specs := map[string]string{
+		"auth/spec.md": "## RENAMED Requirements\n" +
+			"\n" +
+			"- FROM: `### Requirement: Signup`\n" +
+			"- TO: `### Requirement: Register`\n" +
+			"- FROM: ### Requirement: Login\n" +
+			"- TO: ### Requirement: Logout\n" +
+			"- FROM: `### Requirement: Session Expiry`\n" +
+			"- TO: `### Requirement: Session Timeout`\n",
+	}
+
+	changeDir, spectrRoot := createChangeDir(t, specs)
+	createBaseSpec(t, spectrRoot, "auth", `## Requirements
+
+### Requirement: Login
+The system SHALL authenticate users.
+
+#### Scenario: Login
+- **WHEN** a user signs in
+- **THEN** a session starts
+
+### Requirement: Logout
+The system SHALL end sessions on request.
+
+#### Scenario: Logout
+- **WHEN** a user signs out
+- **THEN** the session ends
+
+### Requirement: Session Expiry
+The system SHALL expire idle sessions.
+
+#### Scenario: Idle
+- **WHEN** a session is idle
+- **THEN** it expires
+`)
+
+	report, err := ValidateChangeDeltaSpecs(changeDir, spectrRoot)
+	if err != nil {
+		t.Fatalf("ValidateChangeDeltaSpecs returned error: %v", err)
+	}
+
+	type position struct{ line, column int }
+	want := map[position]string{
+		{3, 27}: `RENAMED FROM requirement "Signup" does not exist in base spec`,
+		{6, 24}: `RENAMED TO requirement "Logout" already exists in base spec`,
+	}
+
+	for _, issue := range report.Issues {
+		pos := position{issue.Line, issue.Column}
+		if msg, ok := want[pos]; ok && issue.Message == msg {
+			delete(want, pos)
+
+			continue
+		}
+		t.Errorf("unexpected issue at %d:%d: %s", issue.Line, issue.Column, issue.Message)
+	}
+	for pos, msg := range want {
+		t.Errorf("missing issue at %d:%d: %s", pos.line, pos.column, msg)
+	}
+}
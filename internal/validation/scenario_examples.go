@@ -0,0 +1,134 @@
+package validation
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/markdown"
+	"github.com/connerohnesorge/spectr/internal/parsers"
+)
+
+// validateScenarioExamples checks the Examples tables of every scenario in a
+// requirement. Each data row must have the same number of columns as the
+// header, and every <placeholder> used in the scenario steps must name a
+// header column.
+func validateScenarioExamples(
+	reqPath string,
+	req Requirement,
+	lines []string,
+	reqLine int,
+) []ValidationIssue {
+	var issues []ValidationIssue
+
+	for _, scenario := range parsers.ParseScenarioBlocks(req.Content) {
+		table := scenario.Examples
+		if table == nil {
+			continue
+		}
+
+		scenarioPath := fmt.Sprintf(
+			"%s: Scenario '%s'",
+			reqPath,
+			scenario.Name,
+		)
+
+		if len(table.Header) == 0 {
+			issues = append(issues, ValidationIssue{
+				Level:   LevelError,
+				Path:    scenarioPath,
+				Line:    findLineContaining(lines, "Examples", reqLine),
+				Message: "Examples section must contain a table",
+			})
+
+			continue
+		}
+
+		for i, row := range table.Rows {
+			if len(row) == len(table.Header) {
+				continue
+			}
+			issues = append(issues, ValidationIssue{
+				Level: LevelError,
+				Path:  scenarioPath,
+				Line:  findTableRowLine(lines, row, reqLine),
+				Message: fmt.Sprintf(
+					"Examples row %d has %d column(s), expected %d (%s)",
+					i+1,
+					len(row),
+					len(table.Header),
+					strings.Join(table.Header, ", "),
+				),
+			})
+		}
+
+		for _, step := range scenario.Steps {
+			for _, name := range parsers.Placeholders(step.Text) {
+				if slices.Contains(table.Header, name) {
+					continue
+				}
+				issues = append(issues, ValidationIssue{
+					Level: LevelError,
+					Path:  scenarioPath,
+					Line: findLineContaining(
+						lines,
+						"<"+name+">",
+						reqLine,
+					),
+					Message: fmt.Sprintf(
+						"Placeholder <%s> has no matching Examples column",
+						name,
+					),
+				})
+			}
+		}
+	}
+
+	return issues
+}
+
+// findTableRowLine returns the 1-based number of the first table row at or
+// after startLine whose cells equal cells. Returns startLine if not found.
+func findTableRowLine(
+	lines []string,
+	cells []string,
+	startLine int,
+) int {
+	searchStart := max(startLine-1, 0)
+	if searchStart >= len(lines) {
+		return startLine
+	}
+
+	source := []byte(strings.Join(lines[searchStart:], "\n"))
+	doc, _ := markdown.Parse(source, markdown.WithTables())
+	index := markdown.NewLineIndex(source)
+	for _, row := range markdown.FindByType[*markdown.NodeTableRow](doc) {
+		if !slices.Equal(parsers.TableRowCells(row), cells) {
+			continue
+		}
+		start, _ := row.Span()
+		line, _ := index.LineCol(start)
+
+		return searchStart + line
+	}
+
+	return startLine
+}
+
+// findLineContaining returns the 1-based number of the first line at or
+// after startLine that contains text. Returns startLine if not found.
+func findLineContaining(
+	lines []string,
+	text string,
+	startLine int,
+) int {
+	searchStart := max(startLine-1, 0)
+
+	for i := searchStart; i < len(lines); i++ {
+		if strings.Contains(lines[i], text) {
+			return i + 1 // Line numbers are 1-indexed
+		}
+	}
+
+	return startLine
+}
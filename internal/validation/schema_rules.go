@@ -0,0 +1,166 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/fileio"
+	"github.com/connerohnesorge/spectr/internal/jsonschema"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+	"github.com/connerohnesorge/spectr/internal/taskschema"
+)
+
+// ConfigFileName is the project configuration file checked against the
+// config schema.
+const ConfigFileName = "spectr.yaml"
+
+// childRefPrefix marks a task's children field as a reference to a child
+// tasks file.
+const childRefPrefix = "$ref:"
+
+// ValidateConfigFile checks spectr.yaml against the config JSON Schema.
+// Problems are reported with the line and column where they occur.
+func ValidateConfigFile(path string) (*ValidationReport, error) {
+	data, err := fileio.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	doc, err := jsonschema.ParseYAML(path, data)
+	if err == nil {
+		err = jsonschema.MustLookup("config").Validate(path, doc, 0)
+	}
+
+	return NewValidationReport(schemaIssues(path, err)), nil
+}
+
+// validateTasksSchema checks tasks.jsonc, and any child files its tasks
+// reference, against the schema of the version each file declares.
+func validateTasksSchema(changeDir string) []ValidationIssue {
+	rootPath := filepath.Join(changeDir, "tasks.jsonc")
+	issues, doc := validateTasksJSONC(rootPath)
+	if doc == nil {
+		return issues
+	}
+
+	tasks := doc.Get("tasks")
+	if tasks == nil {
+		return issues
+	}
+	for _, task := range tasks.Items {
+		children := task.Get("children")
+		if children == nil || children.Kind != jsonschema.KindString ||
+			!strings.HasPrefix(children.String, childRefPrefix) {
+			continue
+		}
+
+		childPath := filepath.Join(
+			changeDir,
+			strings.TrimPrefix(children.String, childRefPrefix),
+		)
+		if _, err := os.Stat(childPath); os.IsNotExist(err) {
+			issues = append(issues, ValidationIssue{
+				Level:  LevelError,
+				Path:   rootPath,
+				Line:   children.Line,
+				Column: children.Column,
+				Message: fmt.Sprintf(
+					"line %d, col %d: referenced child file %s does not exist",
+					children.Line,
+					children.Column,
+					filepath.Base(childPath),
+				),
+			})
+
+			continue
+		}
+
+		childIssues, _ := validateTasksJSONC(childPath)
+		issues = append(issues, childIssues...)
+	}
+
+	return issues
+}
+
+// validateTasksJSONC validates a single tasks file. It returns the parsed
+// document when the file could be parsed, so references can be followed.
+func validateTasksJSONC(path string) ([]ValidationIssue, *jsonschema.Value) {
+	data, err := fileio.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return []ValidationIssue{{
+			Level:   LevelError,
+			Path:    path,
+			Line:    1,
+			Message: fmt.Sprintf("failed to read %s: %v", filepath.Base(path), err),
+		}}, nil
+	}
+
+	doc, err := jsonschema.ParseJSONC(path, data)
+	if err != nil {
+		return schemaIssues(path, err), nil
+	}
+	_, err = taskschema.Validate(path, data)
+
+	return schemaIssues(path, err), doc
+}
+
+// schemaIssues converts parse and schema errors into validation issues,
+// one per problem, with the position in the message because the human
+// report does not print line numbers.
+func schemaIssues(path string, err error) []ValidationIssue {
+	if err == nil {
+		return nil
+	}
+
+	var (
+		syntaxErr  *specterrs.SyntaxError
+		schemaErr  *specterrs.SchemaError
+		tasksErr   *specterrs.TasksSchemaError
+		problems   []specterrs.SchemaProblem
+		schemaName string
+	)
+	switch {
+	case errors.As(err, &syntaxErr):
+		return []ValidationIssue{{
+			Level:   LevelError,
+			Path:    path,
+			Line:    syntaxErr.Line,
+			Column:  syntaxErr.Column,
+			Message: syntaxErr.Error(),
+		}}
+	case errors.As(err, &schemaErr):
+		problems, schemaName = schemaErr.Problems, schemaErr.Schema
+	case errors.As(err, &tasksErr):
+		problems = tasksErr.Problems
+		schemaName = taskschema.SchemaFileName(tasksErr.Version)
+		if tasksErr.Version == 0 {
+			schemaName = "tasks schema"
+		}
+	default:
+		return []ValidationIssue{{
+			Level:   LevelError,
+			Path:    path,
+			Line:    1,
+			Message: err.Error(),
+		}}
+	}
+
+	issues := make([]ValidationIssue, 0, len(problems))
+	for _, problem := range problems {
+		issues = append(issues, ValidationIssue{
+			Level:   LevelError,
+			Path:    path,
+			Line:    problem.Line,
+			Column:  problem.Column,
+			Message: fmt.Sprintf("%s (%s)", problem, schemaName),
+		})
+	}
+
+	return issues
+}
@@ -0,0 +1,110 @@
+package validation
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateTasksSchema(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  []string // Substrings of issue messages, in order
+	}{
+		{
+			name:  "no tasks.jsonc",
+			files: map[string]string{},
+		},
+		{
+			name: "valid v2 with child",
+			files: map[string]string{
+				"tasks.jsonc": `{"version": 2, "tasks": [
+  {"id": "1", "section": "Impl", "description": "Impl", "status": "pending", "children": "$ref:tasks-1.jsonc"}
+]}`,
+				"tasks-1.jsonc": `{"version": 2, "parent": "1", "tasks": []}`,
+			},
+		},
+		{
+			name: "bad status",
+			files: map[string]string{
+				"tasks.jsonc": "// header\n{\"version\": 1, \"tasks\": [\n  {\"id\": \"1.1\", \"section\": \"\", \"description\": \"x\", \"status\": \"done\"}\n]}",
+			},
+			want: []string{"line 3, col 62: tasks[0].status: must be one of"},
+		},
+		{
+			name: "syntax error",
+			files: map[string]string{
+				"tasks.jsonc": "{\"version\": 1,\n  \"tasks\": [],\n}",
+			},
+			want: []string{"tasks.jsonc:3:1: expected object key"},
+		},
+		{
+			name: "missing child",
+			files: map[string]string{
+				"tasks.jsonc": `{"version": 2, "tasks": [{"id": "1", "section": "", "description": "x", "status": "pending", "children": "$ref:tasks-1.jsonc"}]}`,
+			},
+			want: []string{"child file tasks-1.jsonc does not exist"},
+		},
+		{
+			name: "invalid child",
+			files: map[string]string{
+				"tasks.jsonc":   `{"version": 2, "tasks": [{"id": "1", "section": "", "description": "x", "status": "pending", "children": "$ref:tasks-1.jsonc"}]}`,
+				"tasks-1.jsonc": `{"version": 2, "parent": "1", "tasks": [{"id": "1.1"}]}`,
+			},
+			want: []string{"tasks[0].section: is required", "tasks[0].description: is required", "tasks[0].status: is required"},
+		},
+		{
+			name: "unsupported version",
+			files: map[string]string{
+				"tasks.jsonc": `{"version": 9, "tasks": []}`,
+			},
+			want: []string{"uses tasks format version 9"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tt.files {
+				if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			issues := validateTasksSchema(dir)
+			if len(issues) != len(tt.want) {
+				t.Fatalf("got %d issues %v, want %d", len(issues), issues, len(tt.want))
+			}
+			for i, issue := range issues {
+				if issue.Level != LevelError {
+					t.Errorf("issue %d level = %s, want ERROR", i, issue.Level)
+				}
+				if !strings.Contains(issue.Message, tt.want[i]) {
+					t.Errorf("issue %d = %q, want it to contain %q", i, issue.Message, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestValidateConfigFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ConfigFileName)
+	content := "append_tasks:\n  section: Ops\n  tasks:\n    - lint\ngit:\n  backend: svn\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := ValidateConfigFile(path)
+	if err != nil {
+		t.Fatalf("ValidateConfigFile: %v", err)
+	}
+	if report.Valid || len(report.Issues) != 1 {
+		t.Fatalf("report = %+v, want one issue", report)
+	}
+	issue := report.Issues[0]
+	if issue.Line != 6 || !strings.Contains(issue.Message, "line 6, col 12: git.backend") {
+		t.Errorf("issue = %+v", issue)
+	}
+}
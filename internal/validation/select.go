@@ -0,0 +1,412 @@
+package validation
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/discovery"
+	"github.com/connerohnesorge/spectr/internal/fileio"
+	"github.com/connerohnesorge/spectr/internal/markdown"
+	"github.com/connerohnesorge/spectr/internal/parsers"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+// requirementSelector prefixes a selector naming a requirement, as in
+// requirement:"Token refresh" or requirement:auth#Token refresh.
+const requirementSelector = "requirement:"
+
+// itemPrefixes map the short path forms accepted by SelectItems to the
+// item type they name.
+var itemPrefixes = []struct {
+	prefix   string
+	itemType string
+}{
+	{"spec/", ItemTypeSpec},
+	{"specs/", ItemTypeSpec},
+	{"change/", ItemTypeChange},
+	{"changes/", ItemTypeChange},
+}
+
+// requirementSelection is a parsed requirement: selector. Spec is empty when the
+// selector is not qualified with a spec.
+type requirementSelection struct {
+	spec string
+	name string
+}
+
+// IsSelector reports whether arg is a selector only SelectItems
+// understands, as opposed to a plain item name or path.
+func IsSelector(arg string) bool {
+	if strings.HasPrefix(arg, requirementSelector) {
+		return true
+	}
+	for _, p := range itemPrefixes {
+		if strings.HasPrefix(arg, p.prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// selection accumulates the items chosen by SelectItems, each once.
+type selection struct {
+	items []ValidationItem
+	seen  map[string]int
+}
+
+// add appends item unless an item of the same type and name is already
+// selected, and returns its index.
+func (s *selection) add(item ValidationItem) int {
+	key := item.ItemType + "/" + item.Name
+	if i, ok := s.seen[key]; ok {
+		return i
+	}
+	s.items = append(s.items, item)
+	s.seen[key] = len(s.items) - 1
+
+	return len(s.items) - 1
+}
+
+// SelectItems resolves validate selectors to the items they name in the
+// project at projectPath. A selector is an item name or path ("auth",
+// "spec/auth", "change/add-2fa", "spectr/specs/auth/spec.md") or a
+// requirement ("requirement:Token refresh", "requirement:auth#Token refresh").
+//
+// A requirement selector scopes a spec item to the named requirements.
+// Unqualified, it is looked up in the specs selected alongside it, or in
+// every spec when none is. Active changes whose delta specs touch a
+// selected spec, or a selected requirement of a scoped spec, are added
+// after the named items.
+func SelectItems(
+	projectPath string,
+	selectors []string,
+	typeFlag *string,
+) ([]ValidationItem, error) {
+	sel := &selection{seen: make(map[string]int)}
+	var refs []requirementSelection
+	for _, selector := range selectors {
+		if rest, ok := strings.CutPrefix(selector, requirementSelector); ok {
+			ref, err := parseRequirementSelector(rest)
+			if err != nil {
+				return nil, err
+			}
+			refs = append(refs, ref)
+
+			continue
+		}
+
+		item, err := selectItem(projectPath, selector, typeFlag)
+		if err != nil {
+			return nil, err
+		}
+		sel.add(item)
+	}
+
+	if err := sel.scopeRequirements(projectPath, refs); err != nil {
+		return nil, err
+	}
+	if err := sel.addReferencingChanges(projectPath); err != nil {
+		return nil, err
+	}
+
+	return sel.items, nil
+}
+
+// parseRequirementSelector parses the part of a requirement: selector
+// after the prefix. Surrounding quotes are removed.
+func parseRequirementSelector(value string) (requirementSelection, error) {
+	value = strings.Trim(strings.TrimSpace(value), `"'`)
+	ref := requirementSelection{name: value}
+	if spec, name, ok := strings.Cut(value, "#"); ok {
+		ref = requirementSelection{
+			spec: strings.Trim(strings.TrimSpace(spec), "/"),
+			name: strings.TrimSpace(name),
+		}
+	}
+	if ref.name == "" {
+		return ref, fmt.Errorf(
+			"invalid selector %q: requirement name is empty",
+			requirementSelector+value,
+		)
+	}
+
+	return ref, nil
+}
+
+// selectItem resolves a single item name or path selector.
+func selectItem(
+	projectPath, selector string,
+	typeFlag *string,
+) (ValidationItem, error) {
+	id, inferredType := discovery.NormalizeItemPath(selector)
+	for _, p := range itemPrefixes {
+		if rest, ok := strings.CutPrefix(filepath.ToSlash(selector), p.prefix); ok {
+			id = strings.Trim(rest, "/")
+			inferredType = p.itemType
+
+			break
+		}
+	}
+
+	typeHint := typeFlag
+	if inferredType != "" {
+		typeHint = &inferredType
+	}
+	info, err := DetermineItemType(projectPath, id, typeHint)
+	if err != nil {
+		return ValidationItem{}, err
+	}
+
+	return itemFor(projectPath, id, info.ItemType), nil
+}
+
+// itemFor returns the validation item for a change or spec ID.
+func itemFor(projectPath, id, itemType string) ValidationItem {
+	dir := "changes"
+	if itemType == ItemTypeSpec {
+		dir = "specs"
+	}
+
+	return CreateValidationItems(
+		projectPath,
+		[]string{id},
+		itemType,
+		filepath.Join(projectPath, SpectrDir, dir),
+	)[0]
+}
+
+// scopeRequirements attaches each requirement selector to the spec items
+// containing it, selecting qualified or globally found specs as needed.
+func (s *selection) scopeRequirements(
+	projectPath string,
+	refs []requirementSelection,
+) error {
+	if len(refs) == 0 {
+		return nil
+	}
+
+	var selected, all []string
+	for _, item := range s.items {
+		if item.ItemType == ItemTypeSpec {
+			selected = append(selected, item.Name)
+		}
+	}
+
+	for _, ref := range refs {
+		candidates := selected
+		switch {
+		case ref.spec != "":
+			candidates = []string{ref.spec}
+		case len(candidates) == 0:
+			if all == nil {
+				var err error
+				if all, err = discovery.GetSpecIDs(projectPath); err != nil {
+					return fmt.Errorf("failed to discover specs: %w", err)
+				}
+			}
+			candidates = all
+		}
+
+		found := false
+		for _, spec := range candidates {
+			item := itemFor(projectPath, spec, ItemTypeSpec)
+			names, err := requirementNames(item.Path)
+			if err != nil {
+				if os.IsNotExist(err) {
+					return fmt.Errorf("spec '%s' not found", spec)
+				}
+
+				return err
+			}
+			if !names[parsers.NormalizeRequirementName(ref.name)] {
+				continue
+			}
+			found = true
+			i := s.add(item)
+			s.items[i].Requirements = append(s.items[i].Requirements, ref.name)
+		}
+		if !found {
+			return &specterrs.RequirementNotFoundError{Spec: ref.spec, Name: ref.name}
+		}
+	}
+
+	return nil
+}
+
+// addReferencingChanges selects the active changes whose delta specs touch
+// a selected spec, or one of its requirements when it is scoped.
+func (s *selection) addReferencingChanges(projectPath string) error {
+	changeIDs, err := discovery.GetActiveChangeIDs(projectPath)
+	if err != nil {
+		return fmt.Errorf("failed to discover changes: %w", err)
+	}
+
+	specs := append([]ValidationItem{}, s.items...)
+	for _, item := range specs {
+		if item.ItemType != ItemTypeSpec {
+			continue
+		}
+		for _, changeID := range changeIDs {
+			deltaPath := filepath.Join(
+				projectPath, SpectrDir, "changes", changeID, "specs", item.Name, "spec.md",
+			)
+			touches, err := deltaTouches(deltaPath, item.Requirements)
+			if err != nil {
+				return err
+			}
+			if touches {
+				s.add(itemFor(projectPath, changeID, ItemTypeChange))
+			}
+		}
+	}
+
+	return nil
+}
+
+// deltaTouches reports whether the delta spec at path exists and, when
+// names is not empty, adds, modifies, removes or renames one of them.
+func deltaTouches(path string, names []string) (bool, error) {
+	if _, err := os.Stat(path); err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+
+		return false, err
+	}
+	if len(names) == 0 {
+		return true, nil
+	}
+
+	plan, err := parsers.ParseDeltaSpec(path)
+	if err != nil {
+		return false, err
+	}
+	touched := make(map[string]bool)
+	for _, block := range append(plan.Added, plan.Modified...) {
+		touched[parsers.NormalizeRequirementName(block.Name)] = true
+	}
+	for _, name := range plan.Removed {
+		touched[parsers.NormalizeRequirementName(name)] = true
+	}
+	for _, op := range plan.Renamed {
+		touched[parsers.NormalizeRequirementName(op.From)] = true
+		touched[parsers.NormalizeRequirementName(op.To)] = true
+	}
+	for _, name := range names {
+		if touched[parsers.NormalizeRequirementName(name)] {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// requirementBlock is the 1-based line span of a requirement in a spec.
+type requirementBlock struct {
+	name  string
+	start int
+	end   int
+}
+
+// requirementBlocks returns the requirements of the spec at path with the
+// lines they span: from the "### Requirement:" heading up to the next
+// requirement or level-2 heading outside a code fence.
+func requirementBlocks(path string) ([]requirementBlock, error) {
+	content, err := fileio.ReadString(path)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := strings.Split(strings.TrimRight(content, "\n"), "\n")
+	var (
+		blocks []requirementBlock
+		fence  rune
+	)
+	closeBlock := func(end int) {
+		if n := len(blocks); n > 0 && blocks[n-1].end == 0 {
+			blocks[n-1].end = end
+		}
+	}
+	for i, line := range lines {
+		if isFence, delim := markdown.IsCodeFence(line); isFence {
+			switch fence {
+			case 0:
+				fence = delim
+			case delim:
+				fence = 0
+			}
+
+			continue
+		}
+		if fence != 0 {
+			continue
+		}
+		if name, ok := markdown.MatchRequirementHeader(line); ok {
+			closeBlock(i)
+			blocks = append(blocks, requirementBlock{
+				name:  parsers.NormalizeRequirementName(name),
+				start: i + 1,
+			})
+		} else if markdown.IsH2Header(line) {
+			closeBlock(i)
+		}
+	}
+	closeBlock(len(lines))
+
+	return blocks, nil
+}
+
+// requirementNames returns the normalized requirement names of the spec
+// at path.
+func requirementNames(path string) (map[string]bool, error) {
+	blocks, err := requirementBlocks(path)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]bool, len(blocks))
+	for _, block := range blocks {
+		names[block.name] = true
+	}
+
+	return names, nil
+}
+
+// ScopeReport narrows the report of a spec item selected with requirement
+// selectors to the issues inside those requirements. Issues without a
+// line, or outside every named requirement, are dropped. Reports of other
+// items are returned unchanged.
+func ScopeReport(
+	item ValidationItem,
+	report *ValidationReport,
+) (*ValidationReport, error) {
+	if report == nil || item.ItemType != ItemTypeSpec ||
+		len(item.Requirements) == 0 {
+		return report, nil
+	}
+
+	blocks, err := requirementBlocks(item.Path)
+	if err != nil {
+		return nil, err
+	}
+	wanted := make(map[string]bool, len(item.Requirements))
+	for _, name := range item.Requirements {
+		wanted[parsers.NormalizeRequirementName(name)] = true
+	}
+
+	issues := make([]ValidationIssue, 0, len(report.Issues))
+	for _, issue := range report.Issues {
+		for _, block := range blocks {
+			if wanted[block.name] &&
+				issue.Line >= block.start && issue.Line <= block.end {
+				issues = append(issues, issue)
+
+				break
+			}
+		}
+	}
+
+	return NewValidationReport(issues), nil
+}
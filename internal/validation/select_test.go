@@ -0,0 +1,216 @@
+package validation
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+const selectAuthSpec = `# Auth Specification
+
+## Purpose
+Authentication for the service, covering sessions and tokens end to end.
+
+## Requirements
+
+### Requirement: Login
+The system SHALL log users in.
+
+#### Scenario: Valid password
+- **WHEN** the password matches
+- **THEN** a session starts
+
+### Requirement: Token Refresh
+Tokens are refreshed.
+
+` + "```markdown\n### Requirement: Example\n```\n"
+
+// setupSelectProject creates a project with auth and billing specs and
+// changes touching each.
+func setupSelectProject(t *testing.T) string {
+	t.Helper()
+
+	root := t.TempDir()
+	files := map[string]string{
+		"spectr/specs/auth/spec.md": selectAuthSpec,
+		"spectr/specs/billing/spec.md": "# Billing\n\n## Requirements\n\n" +
+			"### Requirement: Invoices\nThe system SHALL invoice.\n",
+		"spectr/changes/add-login/proposal.md": "# Change\n",
+		"spectr/changes/add-login/specs/auth/spec.md": "## MODIFIED Requirements\n\n" +
+			"### Requirement: Login\nThe system SHALL log users in.\n",
+		"spectr/changes/add-invoices/proposal.md": "# Change\n",
+		"spectr/changes/add-invoices/specs/billing/spec.md": "## ADDED Requirements\n\n" +
+			"### Requirement: Credit Notes\nThe system SHALL credit.\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return root
+}
+
+func TestSelectItems(t *testing.T) {
+	tests := []struct {
+		name      string
+		selectors []string
+		want      []string // type/name, with requirements after ":"
+	}{
+		{
+			name:      "spec with referencing change",
+			selectors: []string{"spec/auth"},
+			want:      []string{"spec/auth", "change/add-login"},
+		},
+		{
+			name:      "change and plain spec name",
+			selectors: []string{"change/add-invoices", "billing"},
+			want:      []string{"change/add-invoices", "spec/billing"},
+		},
+		{
+			name:      "requirement scopes selected spec",
+			selectors: []string{"spec/auth", `requirement:"token refresh"`},
+			want:      []string{"spec/auth:token refresh"},
+		},
+		{
+			name:      "requirement found in any spec",
+			selectors: []string{"requirement:Login"},
+			want:      []string{"spec/auth:Login", "change/add-login"},
+		},
+		{
+			name:      "qualified requirement",
+			selectors: []string{"requirement:billing#Invoices"},
+			want:      []string{"spec/billing:Invoices"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := setupSelectProject(t)
+
+			items, err := SelectItems(root, tt.selectors, nil)
+			if err != nil {
+				t.Fatalf("SelectItems() error = %v", err)
+			}
+			got := make([]string, 0, len(items))
+			for _, item := range items {
+				key := item.ItemType + "/" + item.Name
+				if len(item.Requirements) > 0 {
+					key += ":" + item.Requirements[0]
+				}
+				got = append(got, key)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("SelectItems() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelectItems_Errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		selectors []string
+		check     func(err error) bool
+	}{
+		{
+			name:      "unknown requirement",
+			selectors: []string{"requirement:Logout"},
+			check: func(err error) bool {
+				var target *specterrs.RequirementNotFoundError
+
+				return errors.As(err, &target) && target.Spec == ""
+			},
+		},
+		{
+			name:      "requirement in fenced example",
+			selectors: []string{"spec/auth", "requirement:Example"},
+			check: func(err error) bool {
+				var target *specterrs.RequirementNotFoundError
+
+				return errors.As(err, &target)
+			},
+		},
+		{
+			name:      "empty requirement",
+			selectors: []string{"requirement:"},
+			check:     func(err error) bool { return err != nil },
+		},
+		{
+			name:      "unknown spec",
+			selectors: []string{"spec/search"},
+			check:     func(err error) bool { return err != nil },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := setupSelectProject(t)
+
+			_, err := SelectItems(root, tt.selectors, nil)
+			if err == nil || !tt.check(err) {
+				t.Errorf("SelectItems() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestScopeReport(t *testing.T) {
+	root := setupSelectProject(t)
+	items, err := SelectItems(root, []string{"requirement:Token Refresh"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := ValidateSingleItem(NewValidator(), items[0])
+	if err != nil {
+		t.Fatalf("ValidateSingleItem() error = %v", err)
+	}
+	if len(result.Report.Issues) == 0 {
+		t.Fatal("expected issues for Token Refresh")
+	}
+	for _, issue := range result.Report.Issues {
+		if issue.Line < 15 {
+			t.Errorf("issue outside the requirement: %+v", issue)
+		}
+	}
+
+	login, err := SelectItems(root, []string{"requirement:Login"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err = ValidateSingleItem(NewValidator(), login[0])
+	if err != nil {
+		t.Fatalf("ValidateSingleItem() error = %v", err)
+	}
+	if !result.Valid || len(result.Report.Issues) != 0 {
+		t.Errorf("Login scope = %+v, want no issues", result.Report)
+	}
+}
+
+func TestIsSelector(t *testing.T) {
+	tests := []struct {
+		arg  string
+		want bool
+	}{
+		{"spec/auth", true},
+		{"changes/add-2fa", true},
+		{`requirement:"Token refresh"`, true},
+		{"auth", false},
+		{"spectr/specs/auth/spec.md", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsSelector(tt.arg); got != tt.want {
+			t.Errorf("IsSelector(%q) = %v, want %v", tt.arg, got, tt.want)
+		}
+	}
+}
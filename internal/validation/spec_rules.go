@@ -2,8 +2,11 @@ package validation
 
 import (
 	"fmt"
-	"os"
 	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/fileio"
+	"github.com/connerohnesorge/spectr/internal/markdown"
+	"github.com/connerohnesorge/spectr/internal/parsers"
 )
 
 // ValidateSpecFile validates a spec file according to Spectr rules
@@ -12,9 +15,18 @@ import (
 // Note: Always applies strict validation (warnings are converted to errors)
 func ValidateSpecFile(
 	path string,
+) (*ValidationReport, error) {
+	return validateSpecFile(path, nil)
+}
+
+// validateSpecFile implements ValidateSpecFile, passing parseOpts to the
+// markdown diagnostics of the file.
+func validateSpecFile(
+	path string,
+	parseOpts []markdown.ParseOption,
 ) (*ValidationReport, error) {
 	// Read the file
-	content, err := os.ReadFile(path)
+	contentStr, err := fileio.ReadString(path)
 	if err != nil {
 		return nil, fmt.Errorf(
 			"failed to read spec file: %w",
@@ -22,13 +34,16 @@ func ValidateSpecFile(
 		)
 	}
 
-	contentStr := string(content)
 	lines := strings.Split(contentStr, "\n")
 
 	// Parse sections
 	sections := ExtractSections(contentStr)
 	issues := make([]ValidationIssue, 0)
 
+	// Rule 8: Check the base of an inheriting spec (ERROR if missing or cyclic)
+	inherited, inheritIssues := validateInheritance(path, lines)
+	issues = append(issues, inheritIssues...)
+
 	// Rule 1: Check for ## Requirements section (ERROR if missing)
 	requirementsContent, hasRequirements := sections["Requirements"]
 	if !hasRequirements {
@@ -40,16 +55,27 @@ func ValidateSpecFile(
 		})
 	}
 
-	// Rule 2-5: Validate requirements (only if Requirements section exists)
+	// Rule 2-6: Validate requirements (only if Requirements section exists)
 	if hasRequirements {
 		reqIssues := validateRequirements(
 			path,
 			requirementsContent,
 			lines,
+			inherited,
 		)
 		issues = append(issues, reqIssues...)
 	}
 
+	// Rule 7: Check heading hierarchy (WARNING for jumps, ERROR for stray scenarios)
+	issues = append(issues, validateHeadingHierarchy(path, contentStr)...)
+
+	// Rule 9: Check evidence of implemented requirements (ERROR if missing)
+	issues = append(issues, validateEvidence(path, lines)...)
+
+	// Rule 10: Check for near-miss headers and unclosed code fences (ERROR),
+	// attaching suggestions and quick fixes
+	issues = addParseDiagnostics(path, contentStr, issues, parseOpts...)
+
 	// Always convert warnings to errors (strict validation)
 	convertWarningsToErrors(issues)
 
@@ -57,11 +83,13 @@ func ValidateSpecFile(
 	return NewValidationReport(issues), nil
 }
 
-// validateRequirements validates all requirements in a spec file
+// validateRequirements validates all requirements in a spec file.
+// Requirements named in inherited override an inherited requirement.
 // Returns a slice of validation issues found
 func validateRequirements(
 	path, requirementsContent string,
 	lines []string,
+	inherited map[string]bool,
 ) []ValidationIssue {
 	issues := make([]ValidationIssue, 0)
 	requirements := ExtractRequirements(
@@ -78,6 +106,7 @@ func validateRequirements(
 			req,
 			lines,
 			requirementsLine,
+			inherited[parsers.NormalizeRequirementName(req.Name)],
 		)
 		issues = append(issues, reqIssues...)
 	}
@@ -85,13 +114,18 @@ func validateRequirements(
 	return issues
 }
 
-// validateSingleRequirement validates a single requirement
+// validateSingleRequirement validates a single requirement. An override
+// of an inherited requirement keeps the base's description and scenarios
+// where it gives none.
 // Returns a slice of validation issues found
+//
+//nolint:revive // override is a legitimate control parameter
 func validateSingleRequirement(
 	path string,
 	req Requirement,
 	lines []string,
 	requirementsLine int,
+	override bool,
 ) []ValidationIssue {
 	issues := make([]ValidationIssue, 0)
 	reqPath := fmt.Sprintf(
@@ -106,7 +140,8 @@ func validateSingleRequirement(
 	)
 
 	// Rule 2: Check for SHALL or MUST (WARNING if missing)
-	if !ContainsShallOrMust(req.Content) {
+	if !ContainsShallOrMust(req.Content) &&
+		(!override || hasOwnDescription(req.Content)) {
 		issues = append(issues, ValidationIssue{
 			Level: LevelWarning,
 			Path:  reqPath,
@@ -117,7 +152,7 @@ func validateSingleRequirement(
 	}
 
 	// Rule 3: Check for at least one scenario (WARNING)
-	if len(req.Scenarios) == 0 {
+	if len(req.Scenarios) == 0 && !override {
 		issues = append(issues, ValidationIssue{
 			Level:   LevelWarning,
 			Path:    reqPath,
@@ -142,6 +177,27 @@ func validateSingleRequirement(
 		})
 	}
 
+	// Rule 5: Check scenario Examples tables (ERROR if inconsistent)
+	issues = append(
+		issues,
+		validateScenarioExamples(reqPath, req, lines, reqLine)...,
+	)
+
+	// Rule 6: Check declared requirement status (ERROR if unknown)
+	if status, ok := parsers.ParseRequirementStatus(req.Content); ok &&
+		!status.IsValid() {
+		issues = append(issues, ValidationIssue{
+			Level: LevelError,
+			Path:  reqPath,
+			Line:  findLineContaining(lines, string(status), reqLine),
+			Message: fmt.Sprintf(
+				"Unknown requirement status '%s' "+
+					"(expected draft, approved, implemented, or deprecated)",
+				status,
+			),
+		})
+	}
+
 	return issues
 }
 
@@ -681,3 +681,95 @@ The system SHALL provide some feature.
 		)
 	}
 }
+
+func TestValidateSpecFile_ScenarioExamples(t *testing.T) {
+	content := `# Test Specification
+
+## Requirements
+
+### Requirement: Login
+The system SHALL log users in.
+
+#### Scenario: Login as <role>
+- **WHEN** a <role> logs in
+- **THEN** they see <page> in <theme>
+
+**Examples:**
+
+| role  | page      |
+| ----- | --------- |
+| admin | dashboard |
+| guest |
+`
+
+	tmpDir := t.TempDir()
+	specPath := filepath.Join(tmpDir, "spec.md")
+	if err := os.WriteFile(specPath, []byte(content), 0o644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	report, err := ValidateSpecFile(specPath)
+	if err != nil {
+		t.Fatalf("ValidateSpecFile returned error: %v", err)
+	}
+
+	if report.Valid {
+		t.Fatal("Expected invalid report for inconsistent Examples table")
+	}
+
+	var rowIssue, placeholderIssue bool
+	for _, issue := range report.Issues {
+		if strings.Contains(issue.Message, "Examples row 2 has 1 column(s)") {
+			rowIssue = true
+			if issue.Line != 17 {
+				t.Errorf("Expected row issue on line 17, got %d", issue.Line)
+			}
+		}
+		if strings.Contains(issue.Message, "Placeholder <theme>") {
+			placeholderIssue = true
+		}
+	}
+
+	if !rowIssue {
+		t.Errorf("Expected column count issue, got %+v", report.Issues)
+	}
+	if !placeholderIssue {
+		t.Errorf("Expected placeholder issue, got %+v", report.Issues)
+	}
+}
+
+func TestValidateSpecFile_RequirementStatus(t *testing.T) {
+	content := `# Test Specification
+
+## Requirements
+
+### Requirement: Login
+status: reviewed
+The system SHALL log users in.
+
+#### Scenario: Valid login
+- **WHEN** credentials are valid
+- **THEN** a session is created
+`
+
+	tmpDir := t.TempDir()
+	specPath := filepath.Join(tmpDir, "spec.md")
+	if err := os.WriteFile(specPath, []byte(content), 0o644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	report, err := ValidateSpecFile(specPath)
+	if err != nil {
+		t.Fatalf("ValidateSpecFile returned error: %v", err)
+	}
+
+	if report.Valid || len(report.Issues) != 1 {
+		t.Fatalf("Expected one issue, got %+v", report.Issues)
+	}
+	if !strings.Contains(report.Issues[0].Message, "Unknown requirement status 'reviewed'") {
+		t.Errorf("Unexpected message %q", report.Issues[0].Message)
+	}
+	if report.Issues[0].Line != 6 {
+		t.Errorf("Expected line 6, got %d", report.Issues[0].Line)
+	}
+}
@@ -0,0 +1,282 @@
+package validation
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/fileio"
+	"github.com/connerohnesorge/spectr/internal/markdown"
+	"github.com/connerohnesorge/spectr/internal/parsers"
+)
+
+// Message fragments that identify task coverage warnings, which stay
+// warnings under strict validation (see applyStrictLevels).
+const (
+	uncoveredRequirementMsg = "has no task referencing it"
+	danglingTaskRefMsg      = "which no delta in this change touches"
+)
+
+// IsUncoveredRequirement reports whether issue is the task coverage
+// warning for an ADDED requirement no task references.
+func IsUncoveredRequirement(issue ValidationIssue) bool {
+	return strings.Contains(issue.Message, uncoveredRequirementMsg)
+}
+
+// taskRequirementRefPattern matches a requirement ID in backticks in a task
+// description, e.g. "`auth#User Login`". IDs use the same
+// <spec-id>#<Requirement Name> form as implementation markers.
+var taskRequirementRefPattern = regexp.MustCompile("`([^`#\\s]+)#([^`]+)`")
+
+// coverageTask is a task of a change, with where it was read from.
+type coverageTask struct {
+	ID          string
+	Description string
+	Path        string
+	Line        int
+}
+
+// validateTaskCoverage correlates a change's deltas with its tasks:
+//   - an ADDED requirement that no task mentions by name or by ID
+//     (`spec#Requirement Name`) gets a warning
+//   - a task that references a requirement ID no delta touches (ADDED,
+//     MODIFIED, REMOVED or either side of a RENAMED pair) gets a warning
+//
+// Tasks come from tasks.jsonc, including version 2 child files, or from
+// tasks.md before accept. Changes without tasks are not checked.
+func validateTaskCoverage(
+	changeDir, specsDir string,
+	specFiles []string,
+) []ValidationIssue {
+	tasks := readCoverageTasks(changeDir)
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	var issues []ValidationIssue
+	touched := make(map[string]bool)
+	for _, specPath := range specFiles {
+		rel, err := filepath.Rel(specsDir, filepath.Dir(specPath))
+		if err != nil {
+			continue
+		}
+		specID := filepath.ToSlash(rel)
+
+		plan, err := parsers.ParseDeltaSpec(specPath)
+		if err != nil {
+			// Parse failures are already reported by the delta rules.
+			continue
+		}
+
+		for _, name := range deltaRequirementNames(plan) {
+			touched[requirementRef(specID, name)] = true
+		}
+
+		issues = append(
+			issues,
+			uncoveredAddedRequirements(specPath, specID, plan, tasks)...,
+		)
+	}
+
+	for _, task := range tasks {
+		for _, match := range taskRequirementRefPattern.FindAllStringSubmatch(
+			task.Description,
+			-1,
+		) {
+			if touched[requirementRef(match[1], match[2])] {
+				continue
+			}
+
+			label := "Task"
+			if task.ID != "" {
+				label = "Task " + task.ID
+			}
+			issues = append(issues, ValidationIssue{
+				Level: LevelWarning,
+				Path:  task.Path,
+				Line:  task.Line,
+				Message: fmt.Sprintf(
+					"%s references requirement %s#%s, %s",
+					label,
+					match[1],
+					strings.TrimSpace(match[2]),
+					danglingTaskRefMsg,
+				),
+			})
+		}
+	}
+
+	return issues
+}
+
+// uncoveredAddedRequirements warns about ADDED requirements of one delta
+// file that no task mentions by name or ID.
+func uncoveredAddedRequirements(
+	specPath, specID string,
+	plan *parsers.DeltaPlan,
+	tasks []coverageTask,
+) []ValidationIssue {
+	if len(plan.Added) == 0 {
+		return nil
+	}
+
+	var lines []string
+	if content, err := fileio.ReadFile(specPath); err == nil {
+		lines = strings.Split(string(content), "\n")
+	}
+	addedLine := findDeltaSectionLine(lines, "ADDED Requirements")
+
+	var issues []ValidationIssue
+	for _, req := range plan.Added {
+		if taskMentions(tasks, req.Name) {
+			continue
+		}
+
+		issues = append(issues, ValidationIssue{
+			Level: LevelWarning,
+			Path:  specPath,
+			Line:  findRequirementLineInSection(lines, req.Name, addedLine),
+			Message: fmt.Sprintf(
+				"ADDED requirement %q %s "+
+					"(mention its name or `%s#%s` in a task)",
+				req.Name,
+				uncoveredRequirementMsg,
+				specID,
+				req.Name,
+			),
+		})
+	}
+
+	return issues
+}
+
+// taskMentions reports whether any task mentions the requirement by name,
+// ignoring case and extra whitespace. An ID reference contains the name,
+// so it counts as well.
+func taskMentions(tasks []coverageTask, name string) bool {
+	normalized := NormalizeRequirementName(name)
+	for _, task := range tasks {
+		if strings.Contains(
+			NormalizeRequirementName(task.Description),
+			normalized,
+		) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// deltaRequirementNames lists every requirement name a delta plan
+// touches, including both sides of RENAMED pairs.
+func deltaRequirementNames(plan *parsers.DeltaPlan) []string {
+	var names []string
+	for _, req := range plan.Added {
+		names = append(names, req.Name)
+	}
+	for _, req := range plan.Modified {
+		names = append(names, req.Name)
+	}
+	names = append(names, plan.Removed...)
+	for _, op := range plan.Renamed {
+		names = append(names, op.From, op.To)
+	}
+
+	return names
+}
+
+// requirementRef returns the normalized <spec-id>#<requirement> key used
+// to match task references against deltas.
+func requirementRef(specID, name string) string {
+	return strings.TrimSpace(specID) + "#" + NormalizeRequirementName(name)
+}
+
+// readCoverageTasks reads a change's tasks from tasks.jsonc (following
+// version 2 child files) or, when there is none, from tasks.md. Unreadable
+// files yield no tasks; the tasks rules report them.
+func readCoverageTasks(changeDir string) []coverageTask {
+	jsoncPath := filepath.Join(changeDir, "tasks.jsonc")
+	if _, err := os.Stat(jsoncPath); err == nil {
+		return readCoverageTasksJSON(jsoncPath, true)
+	}
+
+	mdPath := filepath.Join(changeDir, "tasks.md")
+	content, err := fileio.ReadFile(mdPath)
+	if err != nil {
+		return nil
+	}
+
+	var tasks []coverageTask
+	for i, line := range strings.Split(string(content), "\n") {
+		match, ok := markdown.MatchFlexibleTask(line)
+		if !ok {
+			continue
+		}
+		tasks = append(tasks, coverageTask{
+			ID:          match.Number,
+			Description: match.Content,
+			Path:        mdPath,
+			Line:        i + 1,
+		})
+	}
+
+	return tasks
+}
+
+// readCoverageTasksJSON reads the tasks of a tasks.jsonc file, replacing
+// tasks that reference a readable child file with the child's tasks.
+func readCoverageTasksJSON(
+	path string,
+	followChildren bool,
+) []coverageTask {
+	file, err := parsers.ReadTasksJson(path)
+	if err != nil {
+		return nil
+	}
+
+	var lines []string
+	if content, err := fileio.ReadFile(path); err == nil {
+		lines = strings.Split(string(content), "\n")
+	}
+
+	var tasks []coverageTask
+	for _, task := range file.Tasks {
+		if ref, ok := strings.CutPrefix(task.Children, "$ref:"); ok &&
+			followChildren {
+			children := readCoverageTasksJSON(
+				filepath.Join(filepath.Dir(path), ref),
+				false,
+			)
+			if children != nil {
+				tasks = append(tasks, children...)
+
+				continue
+			}
+		}
+		tasks = append(tasks, coverageTask{
+			ID:          task.ID,
+			Description: task.Description,
+			Path:        path,
+			Line:        findTaskIDLine(lines, task.ID),
+		})
+	}
+
+	return tasks
+}
+
+// findTaskIDLine finds the line of a tasks.jsonc file that declares the
+// given task ID. Returns 1 if not found.
+func findTaskIDLine(lines []string, id string) int {
+	quoted := fmt.Sprintf("%q", id)
+	for i, line := range lines {
+		key, value, ok := strings.Cut(line, ":")
+		if ok && strings.TrimSpace(key) == `"id"` &&
+			strings.HasPrefix(strings.TrimSpace(value), quoted) {
+			return i + 1
+		}
+	}
+
+	return 1
+}
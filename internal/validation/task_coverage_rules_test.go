@@ -0,0 +1,176 @@
+package validation
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+const coverageDeltaSpec = `## ADDED Requirements
+
+### Requirement: Two-Factor Login
+The system SHALL require a second factor when enabled.
+
+#### Scenario: Code required
+- **WHEN** a user with 2FA signs in
+- **THEN** the system asks for a code
+
+### Requirement: Recovery Codes
+The system SHALL issue recovery codes.
+
+#### Scenario: Enrollment
+- **WHEN** a user enables 2FA
+- **THEN** ten recovery codes are shown
+`
+
+func TestValidateTaskCoverage(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  []string // "file:line:message fragment"
+	}{
+		{
+			name: "covered by name and ID",
+			files: map[string]string{
+				"tasks.md": "## 1. Implementation\n" +
+					"- [ ] 1.1 Implement two-factor  login\n" +
+					"- [ ] 1.2 Issue codes for `auth#Recovery Codes`\n",
+			},
+		},
+		{
+			name: "uncovered ADDED requirement",
+			files: map[string]string{
+				"tasks.md": "- [ ] 1.1 Implement Two-Factor Login\n",
+			},
+			want: []string{
+				`specs/auth/spec.md:10:"Recovery Codes" has no task referencing it`,
+			},
+		},
+		{
+			name: "dangling task reference",
+			files: map[string]string{
+				"tasks.md": "- [ ] 1.1 Implement Two-Factor Login and Recovery Codes\n" +
+					"- [x] 1.2 Update `auth#Session Expiry`\n",
+			},
+			want: []string{
+				"tasks.md:2:Task 1.2 references requirement auth#Session Expiry",
+			},
+		},
+		{
+			name: "tasks.jsonc with child file",
+			files: map[string]string{
+				"tasks.md": "- [ ] 1.1 Ignored once accepted\n",
+				"tasks.jsonc": `{
+  "version": 2,
+  "tasks": [
+    {"id": "1", "section": "Auth", "description": "Auth work", "status": "pending",
+     "children": "$ref:specs/auth/tasks.jsonc"}
+  ]
+}`,
+				"specs/auth/tasks.jsonc": `{
+  "version": 2,
+  "parent": "1",
+  "tasks": [
+    {
+      "id": "1.1",
+      "section": "Auth",
+      "description": "Build Two-Factor Login",
+      "status": "pending"
+    },
+    {
+      "id": "1.2",
+      "section": "Auth",
+      "description": "Wire up ` + "`billing#Invoices`" + `",
+      "status": "pending"
+    }
+  ]
+}`,
+			},
+			want: []string{
+				`specs/auth/spec.md:10:"Recovery Codes" has no task referencing it`,
+				"specs/auth/tasks.jsonc:12:Task 1.2 references requirement billing#Invoices",
+			},
+		},
+		{
+			name:  "no tasks",
+			files: map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changeDir, _ := createChangeDir(t, map[string]string{
+				"auth/spec.md": coverageDeltaSpec,
+			})
+			for name, content := range tt.files {
+				path := filepath.Join(changeDir, name)
+				if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			specsDir := filepath.Join(changeDir, "specs")
+			issues := validateTaskCoverage(
+				changeDir,
+				specsDir,
+				[]string{filepath.Join(specsDir, "auth", "spec.md")},
+			)
+
+			if len(issues) != len(tt.want) {
+				t.Fatalf("got %d issues %+v, want %d", len(issues), issues, len(tt.want))
+			}
+			for i, want := range tt.want {
+				parts := strings.SplitN(want, ":", 3)
+				issue := issues[i]
+				if issue.Level != LevelWarning ||
+					!strings.HasSuffix(issue.Path, filepath.FromSlash(parts[0])) ||
+					parts[1] != strconv.Itoa(issue.Line) ||
+					!strings.Contains(issue.Message, parts[2]) {
+					t.Errorf("issue %d = %+v, want %s", i, issue, want)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateChangeDeltaSpecs_TaskCoverageStaysWarning(t *testing.T) {
+	changeDir, spectrRoot := createChangeDir(t, map[string]string{
+		"auth/spec.md": coverageDeltaSpec,
+	})
+	tasks := "- [ ] 1.1 Implement `auth#Two-Factor Login`\n"
+	if err := os.WriteFile(
+		filepath.Join(changeDir, "tasks.md"),
+		[]byte(tasks),
+		0o644,
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := ValidateChangeDeltaSpecs(changeDir, spectrRoot)
+	if err != nil {
+		t.Fatalf("ValidateChangeDeltaSpecs returned error: %v", err)
+	}
+
+	if !report.Valid || report.Summary.Warnings != 1 {
+		t.Errorf(
+			"report valid=%v summary=%+v, want valid with 1 warning: %+v",
+			report.Valid,
+			report.Summary,
+			report.Issues,
+		)
+	}
+
+	// The warning shows in human output even though the change is valid
+	output := captureOutput(func() {
+		PrintHumanReport("test-change", report)
+	})
+	if !strings.Contains(output, "✓ test-change valid") ||
+		!strings.Contains(output, "[WARNING]") {
+		t.Errorf("PrintHumanReport() = %q, want the valid change with its warning", output)
+	}
+}
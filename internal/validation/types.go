@@ -17,7 +17,26 @@ type ValidationIssue struct {
 	Level   ValidationLevel `json:"level"`
 	Path    string          `json:"path"`
 	Line    int             `json:"line,omitempty"`
+	Column  int             `json:"column,omitempty"`
 	Message string          `json:"message"`
+	// Suggestion says how to correct the issue, e.g. "did you mean ...?"
+	Suggestion string `json:"suggestion,omitempty"`
+	// Fix is a quick fix for editors; spectr validate --fix applies it
+	Fix *Fix `json:"fix,omitempty"`
+	// Similar counts identical issues folded into this one by LimitReport
+	Similar int `json:"similar,omitempty"`
+}
+
+// Fix is a quick fix for an issue: replace the text from Line:Column up
+// to EndLine:EndColumn with NewText. Lines and columns are 1-based and
+// columns count bytes.
+type Fix struct {
+	Title     string `json:"title"`
+	Line      int    `json:"line"`
+	Column    int    `json:"column"`
+	EndLine   int    `json:"endLine"`
+	EndColumn int    `json:"endColumn"`
+	NewText   string `json:"newText"`
 }
 
 // ValidationSummary provides aggregate counts of validation issues
@@ -32,6 +51,24 @@ type ValidationReport struct {
 	Valid   bool              `json:"valid"`
 	Issues  []ValidationIssue `json:"issues"`
 	Summary ValidationSummary `json:"summary"`
+	// Hidden holds the issues LimitReport left out beyond its limit
+	Hidden []ValidationIssue `json:"-"`
+}
+
+// Total returns the number of issues in the report, including those
+// folded or left out by LimitReport.
+func (r *ValidationReport) Total() int {
+	return countIssues(r.Issues) + countIssues(r.Hidden)
+}
+
+// countIssues counts issues and the similar issues folded into them.
+func countIssues(issues []ValidationIssue) int {
+	total := 0
+	for _, issue := range issues {
+		total += 1 + issue.Similar
+	}
+
+	return total
 }
 
 // NewValidationReport creates a new ValidationReport from a list of issues
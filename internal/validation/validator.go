@@ -1,11 +1,29 @@
 package validation
 
-import "path/filepath"
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/connerohnesorge/spectr/internal/markdown"
+)
+
+// DiagnosticFunc receives a validation issue as soon as it is found.
+// Issues have their final (strict) level when passed.
+type DiagnosticFunc func(issue ValidationIssue)
 
 // Validator is the main orchestrator for validation operations.
 // It coordinates validation of specs and changes using the underlying
 // rule functions.
-type Validator struct{}
+type Validator struct {
+	// OnDiagnostic, if set, is called with each issue as it is found,
+	// before the report for the item is complete. Spec issues are streamed
+	// per spec file; change issues per delta file and per check. Issues are
+	// still included in the returned reports.
+	OnDiagnostic DiagnosticFunc
+	// ParseOptions are passed to the markdown diagnostics of each file,
+	// such as markdown.WithMaxErrors to change how many are collected.
+	ParseOptions []markdown.ParseOption
+}
 
 // NewValidator creates a new Validator.
 // Validation always treats warnings as errors (strict mode).
@@ -17,11 +35,30 @@ func NewValidator() *Validator {
 // This is a wrapper around ValidateSpecFile that always validates strictly.
 // Returns a ValidationReport with all issues found, or an error for
 // filesystem issues.
-func (*Validator) ValidateSpec(
+func (v *Validator) ValidateSpec(
+	path string,
+) (*ValidationReport, error) {
+	return v.ValidateSpecContext(context.Background(), path)
+}
+
+// ValidateSpecContext is like ValidateSpec but returns ctx.Err() without
+// validating if ctx is already done.
+func (v *Validator) ValidateSpecContext(
+	ctx context.Context,
 	path string,
 ) (*ValidationReport, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	// Delegate to the spec validation rule function
-	return ValidateSpecFile(path)
+	report, err := validateSpecFile(path, v.ParseOptions)
+	if err != nil {
+		return nil, err
+	}
+	v.emit(report.Issues)
+
+	return report, nil
 }
 
 // ValidateChange validates all delta spec files in a change directory.
@@ -30,7 +67,16 @@ func (*Validator) ValidateSpec(
 // (e.g., spectr/changes/add-feature).
 // Returns a ValidationReport with all issues found, or an error for
 // filesystem issues.
-func (*Validator) ValidateChange(
+func (v *Validator) ValidateChange(
+	changeDir string,
+) (*ValidationReport, error) {
+	return v.ValidateChangeContext(context.Background(), changeDir)
+}
+
+// ValidateChangeContext is like ValidateChange but stops with ctx.Err()
+// between delta files once ctx is done.
+func (v *Validator) ValidateChangeContext(
+	ctx context.Context,
 	changeDir string,
 ) (*ValidationReport, error) {
 	// Derive spectrRoot from changeDir
@@ -41,12 +87,58 @@ func (*Validator) ValidateChange(
 	)
 
 	// Delegate to the change validation rule function
-	return ValidateChangeDeltaSpecs(
+	return validateChangeDeltaSpecs(
+		ctx,
 		changeDir,
 		spectrRoot,
+		v.OnDiagnostic,
+		v.ParseOptions,
 	)
 }
 
+// ValidateConfigContext checks a spectr.yaml file against the config
+// schema. It returns ctx.Err() without validating if ctx is already done.
+func (v *Validator) ValidateConfigContext(
+	ctx context.Context,
+	path string,
+) (*ValidationReport, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	report, err := ValidateConfigFile(path)
+	if err != nil {
+		return nil, err
+	}
+	v.emit(report.Issues)
+
+	return report, nil
+}
+
+// ValidateItems validates items in order, streaming issues to OnDiagnostic.
+// If ctx is done before all items are validated, the results so far are
+// returned together with ctx.Err(). Per-item failures are recorded in the
+// results rather than returned.
+func (v *Validator) ValidateItems(
+	ctx context.Context,
+	items []ValidationItem,
+) ([]BulkResult, error) {
+	results := make([]BulkResult, 0, len(items))
+	for _, item := range items {
+		if err := ctx.Err(); err != nil {
+			return results, err
+		}
+
+		result, _ := ValidateSingleItemContext(ctx, v, item)
+		if err := ctx.Err(); err != nil {
+			return results, err
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
 // CreateReport creates a ValidationReport from a list of issues.
 // This is a helper method for creating validation reports.
 // Warnings are converted to errors by the underlying validation functions
@@ -59,3 +151,14 @@ func (*Validator) CreateReport(
 	// functions, not here, to ensure consistency across all validation paths
 	return NewValidationReport(issues)
 }
+
+// emit passes issues to OnDiagnostic, if set.
+func (v *Validator) emit(issues []ValidationIssue) {
+	if v.OnDiagnostic == nil {
+		return
+	}
+
+	for _, issue := range issues {
+		v.OnDiagnostic(issue)
+	}
+}
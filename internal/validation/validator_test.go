@@ -1,6 +1,8 @@
 package validation
 
 import (
+	"context"
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
@@ -660,3 +662,97 @@ The system SHALL send email notifications.
 		)
 	}
 }
+
+func TestValidator_OnDiagnostic_StreamsIssues(t *testing.T) {
+	tmpDir := t.TempDir()
+	badSpec := filepath.Join(tmpDir, "bad", "spec.md")
+	goodSpec := filepath.Join(tmpDir, "good", "spec.md")
+	for path, content := range map[string]string{
+		badSpec: "# Bad\n\n## Purpose\nNo requirements here.\n",
+		goodSpec: `# Good
+
+## Requirements
+
+### Requirement: Login
+The system SHALL allow login.
+
+#### Scenario: Valid login
+- **WHEN** a user logs in
+- **THEN** a session is created
+`,
+	} {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var streamed []ValidationIssue
+	v := NewValidator()
+	v.OnDiagnostic = func(issue ValidationIssue) {
+		streamed = append(streamed, issue)
+	}
+
+	results, err := v.ValidateItems(context.Background(), []ValidationItem{
+		{Name: "bad", ItemType: ItemTypeSpec, Path: badSpec},
+		{Name: "good", ItemType: ItemTypeSpec, Path: goodSpec},
+	})
+	if err != nil {
+		t.Fatalf("ValidateItems returned error: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2", len(results))
+	}
+	if results[0].Valid || !results[1].Valid {
+		t.Errorf(
+			"unexpected validity: bad=%v good=%v",
+			results[0].Valid,
+			results[1].Valid,
+		)
+	}
+
+	if len(streamed) != len(results[0].Report.Issues) {
+		t.Fatalf(
+			"streamed %d issues, report has %d",
+			len(streamed),
+			len(results[0].Report.Issues),
+		)
+	}
+	for _, issue := range streamed {
+		if issue.Path != badSpec || issue.Level != LevelError {
+			t.Errorf("unexpected streamed issue: %+v", issue)
+		}
+	}
+}
+
+func TestValidator_ValidateItems_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results, err := NewValidator().ValidateItems(ctx, []ValidationItem{
+		{Name: "x", ItemType: ItemTypeSpec, Path: "missing/spec.md"},
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v, want context.Canceled", err)
+	}
+	if len(results) != 0 {
+		t.Errorf("got %d results, want 0", len(results))
+	}
+
+	changeDir := t.TempDir()
+	deltaPath := filepath.Join(changeDir, "specs", "auth", "spec.md")
+	if err := os.MkdirAll(filepath.Dir(deltaPath), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(deltaPath, []byte("# Delta\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewValidator().ValidateChangeContext(
+		ctx,
+		changeDir,
+	); !errors.Is(err, context.Canceled) {
+		t.Errorf("ValidateChangeContext err = %v, want context.Canceled", err)
+	}
+}
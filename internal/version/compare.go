@@ -0,0 +1,67 @@
+package version
+
+import (
+	"strconv"
+	"strings"
+)
+
+// MinProjectVersion is the oldest release that can read projects created
+// by this build. spectr init stamps it into spectr.yaml as
+// min_spectr_version; raise it whenever a change to the project format
+// would be misread by older releases.
+const MinProjectVersion = "v0.1.0"
+
+// Compare compares two versions of the form v1.2.3 (the "v" and any
+// pre-release or build suffix are optional and ignored). It returns -1, 0
+// or +1, and ok is false when either version cannot be parsed, such as the
+// "dev" version of local builds.
+func Compare(a, b string) (result int, ok bool) {
+	pa, okA := parse(a)
+	pb, okB := parse(b)
+	if !okA || !okB {
+		return 0, false
+	}
+
+	for i := range pa {
+		switch {
+		case pa[i] < pb[i]:
+			return -1, true
+		case pa[i] > pb[i]:
+			return 1, true
+		}
+	}
+
+	return 0, true
+}
+
+// IsRelease reports whether v is a comparable release version rather than
+// a local build such as "dev".
+func IsRelease(v string) bool {
+	_, ok := parse(v)
+
+	return ok
+}
+
+// parse splits v into its major, minor and patch numbers. Missing minor or
+// patch numbers are zero.
+func parse(v string) ([3]int, bool) {
+	var parts [3]int
+
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	if i := strings.IndexAny(v, "-+"); i >= 0 {
+		v = v[:i]
+	}
+	fields := strings.Split(v, ".")
+	if v == "" || len(fields) > len(parts) {
+		return parts, false
+	}
+	for i, field := range fields {
+		n, err := strconv.Atoi(field)
+		if err != nil || n < 0 {
+			return parts, false
+		}
+		parts[i] = n
+	}
+
+	return parts, true
+}
@@ -0,0 +1,39 @@
+package version
+
+import "testing"
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		a, b   string
+		want   int
+		wantOK bool
+	}{
+		{"v1.2.3", "v1.2.3", 0, true},
+		{"v1.2.3", "1.2.3", 0, true},
+		{"v1.2", "v1.2.0", 0, true},
+		{"v1.2.3", "v1.10.0", -1, true},
+		{"v2.0.0", "v1.99.99", 1, true},
+		{"v1.3.0-rc.1", "v1.3.0", 0, true},
+		{"v1.3.0+abc", "v1.2.9", 1, true},
+		{"dev", "v1.0.0", 0, false},
+		{"v1.0.0", "", 0, false},
+		{"v1.0.0.0", "v1.0.0", 0, false},
+		{"v1.x", "v1.0", 0, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := Compare(tt.a, tt.b)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf(
+				"Compare(%q, %q) = %d, %v; want %d, %v",
+				tt.a, tt.b, got, ok, tt.want, tt.wantOK,
+			)
+		}
+	}
+}
+
+func TestIsRelease(t *testing.T) {
+	if IsRelease("dev") || !IsRelease("v0.4.1") {
+		t.Error("IsRelease should accept v0.4.1 and reject dev")
+	}
+}
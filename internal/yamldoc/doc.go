@@ -0,0 +1,19 @@
+// Package yamldoc serializes a whole project as one YAML document and
+// writes such a document back as a project.
+//
+// Specs are stored as structured requirements: each requirement has its
+// description and its scenarios with their steps and Examples tables.
+// Changes keep the sections of proposal.md and design.md, their delta
+// specs as structured ADDED, MODIFIED, REMOVED and RENAMED requirements,
+// and their tasks. Frontmatter is kept as metadata.
+//
+// The round trip is not lossless. The document keeps the order of delta
+// sections, the line breaks of wrapped steps, and the header comment and
+// final newline of tasks.jsonc, but it holds what spectr reads from the
+// files rather than every byte of them: spec sections other than Purpose
+// and Requirements, the title of delta specs, and lines of a scenario that
+// are neither steps nor Examples are left out, and tasks of child task
+// files are merged into one version 1 tasks.jsonc. Importing a document
+// therefore writes canonical markdown that may differ in layout from the
+// files it was exported from.
+package yamldoc
@@ -0,0 +1,522 @@
+package yamldoc
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/discovery"
+	"github.com/connerohnesorge/spectr/internal/fileio"
+	"github.com/connerohnesorge/spectr/internal/markdown"
+	"github.com/connerohnesorge/spectr/internal/parsers"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+	"gopkg.in/yaml.v3"
+)
+
+// Version is the format version of documents written by this build.
+const Version = 1
+
+// deltaTypes lists the delta sections in the order they are written when
+// a delta has no Order.
+var deltaTypes = []markdown.DeltaType{
+	markdown.DeltaAdded,
+	markdown.DeltaModified,
+	markdown.DeltaRemoved,
+	markdown.DeltaRenamed,
+}
+
+// Document is a project serialized as one YAML document.
+type Document struct {
+	Version int      `yaml:"version"`
+	Specs   []Spec   `yaml:"specs,omitempty"`
+	Changes []Change `yaml:"changes,omitempty"`
+}
+
+// Spec is a spec with structured requirements.
+type Spec struct {
+	// ID is the spec directory under spectr/specs, e.g. "auth"
+	ID           string         `yaml:"id"`
+	Title        string         `yaml:"title"`
+	Metadata     map[string]any `yaml:"metadata,omitempty"`
+	Purpose      string         `yaml:"purpose,omitempty"`
+	Requirements []Requirement  `yaml:"requirements"`
+}
+
+// Requirement is a requirement with its description and scenarios.
+type Requirement struct {
+	Name string `yaml:"name"`
+	// Description is the text before the first scenario
+	Description string     `yaml:"description,omitempty"`
+	Scenarios   []Scenario `yaml:"scenarios,omitempty"`
+}
+
+// Scenario is a scenario with its steps and optional Examples table.
+type Scenario struct {
+	Name     string    `yaml:"name"`
+	Steps    []Step    `yaml:"steps,omitempty"`
+	Examples *Examples `yaml:"examples,omitempty"`
+}
+
+// Step is a GIVEN/WHEN/THEN/AND/BUT step of a scenario.
+type Step struct {
+	Keyword string `yaml:"keyword"`
+	// Text keeps the line breaks of a step wrapped over several lines
+	Text string `yaml:"text"`
+}
+
+// Examples is the parameter table of a scenario outline.
+type Examples struct {
+	Header []string   `yaml:"header"`
+	Rows   [][]string `yaml:"rows,omitempty"`
+}
+
+// Change is an active change.
+type Change struct {
+	ID       string         `yaml:"id"`
+	Metadata map[string]any `yaml:"metadata,omitempty"`
+	Proposal Prose          `yaml:"proposal"`
+	Design   *Prose         `yaml:"design,omitempty"`
+	Deltas   []Delta        `yaml:"deltas,omitempty"`
+	Tasks    []Task         `yaml:"tasks,omitempty"`
+	// Accepted is set when the tasks come from tasks.jsonc
+	Accepted bool `yaml:"accepted,omitempty"`
+	// TasksHeader is the comment before the JSON of tasks.jsonc when it
+	// differs from the header of spectr accept, which empty stands for
+	TasksHeader string `yaml:"tasks_header,omitempty"`
+	// TasksNewline is set when tasks.jsonc ends with a newline
+	TasksNewline bool `yaml:"tasks_newline,omitempty"`
+}
+
+// Prose is a free-form document split into its ## sections.
+type Prose struct {
+	Title    string    `yaml:"title"`
+	Sections []Section `yaml:"sections,omitempty"`
+}
+
+// Section is a ## section of a prose document. The text between the title
+// and the first ## heading is a section without a title.
+type Section struct {
+	Title string `yaml:"title,omitempty"`
+	Body  string `yaml:"body,omitempty"`
+}
+
+// Delta holds the requirement changes a change makes to one spec.
+type Delta struct {
+	Spec     string        `yaml:"spec"`
+	Added    []Requirement `yaml:"added,omitempty"`
+	Modified []Requirement `yaml:"modified,omitempty"`
+	Removed  []string      `yaml:"removed,omitempty"`
+	Renamed  []Rename      `yaml:"renamed,omitempty"`
+	// Order lists the delta sections as they appear in the file, such as
+	// [MODIFIED, ADDED], when it differs from ADDED, MODIFIED, REMOVED,
+	// RENAMED. Sections it leaves out follow in that order.
+	Order []markdown.DeltaType `yaml:"order,omitempty"`
+}
+
+// Rename is a RENAMED requirement pair.
+type Rename struct {
+	From string `yaml:"from"`
+	To   string `yaml:"to"`
+}
+
+// Task is a task of a change.
+type Task struct {
+	ID          string                  `yaml:"id"`
+	Section     string                  `yaml:"section,omitempty"`
+	Description string                  `yaml:"description"`
+	Status      parsers.TaskStatusValue `yaml:"status"`
+	Covers      []string                `yaml:"covers,omitempty"`
+	Estimate    string                  `yaml:"estimate,omitempty"`
+	Assignee    string                  `yaml:"assignee,omitempty"`
+}
+
+// Options select what Build includes.
+type Options struct {
+	// Specs limits the document to these specs; empty means every spec
+	Specs []string
+	// Changes includes the active changes
+	Changes bool
+}
+
+// Build serializes the project at projectRoot.
+func Build(projectRoot string, opts Options) (*Document, error) {
+	doc := &Document{Version: Version}
+
+	specIDs := opts.Specs
+	if len(specIDs) == 0 {
+		var err error
+		if specIDs, err = discovery.GetSpecIDs(projectRoot); err != nil {
+			return nil, err
+		}
+	}
+	specsDir := filepath.Join(projectRoot, "spectr", "specs")
+	for _, id := range specIDs {
+		spec, err := readSpec(filepath.Join(specsDir, filepath.FromSlash(id), "spec.md"))
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("spec '%s' not found", id)
+		}
+		if err != nil {
+			return nil, err
+		}
+		spec.ID = id
+		doc.Specs = append(doc.Specs, spec)
+	}
+
+	if !opts.Changes {
+		return doc, nil
+	}
+	changeIDs, err := discovery.GetActiveChangeIDs(projectRoot)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range changeIDs {
+		change, err := readChange(filepath.Join(projectRoot, "spectr", "changes", id))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read change %s: %w", id, err)
+		}
+		change.ID = id
+		doc.Changes = append(doc.Changes, change)
+	}
+
+	return doc, nil
+}
+
+// Encode writes doc as YAML.
+func Encode(doc *Document) ([]byte, error) {
+	var sb strings.Builder
+	enc := yaml.NewEncoder(&sb)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return nil, fmt.Errorf("failed to encode project document: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode project document: %w", err)
+	}
+
+	return []byte(sb.String()), nil
+}
+
+// Decode reads a document written by Encode. path names the document in
+// errors.
+func Decode(path string, data []byte) (*Document, error) {
+	var doc Document
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, &specterrs.InvalidDocumentError{Path: path, Reason: err.Error()}
+	}
+	if doc.Version > Version {
+		return nil, &specterrs.UnsupportedDocumentVersionError{
+			Path:    path,
+			Version: doc.Version,
+			Latest:  Version,
+		}
+	}
+
+	return &doc, nil
+}
+
+// readSpec reads a spec.md file. The ID is left to the caller.
+func readSpec(path string) (Spec, error) {
+	content, err := fileio.ReadString(path)
+	if err != nil {
+		return Spec{}, err
+	}
+	metadata, body, err := splitFrontmatter(content)
+	if err != nil {
+		return Spec{}, fmt.Errorf("%s: %w", path, err)
+	}
+
+	prose := parseProse(body)
+	spec := Spec{Title: prose.Title, Metadata: metadata}
+	for _, section := range prose.Sections {
+		if section.Title == "Purpose" {
+			spec.Purpose = section.Body
+		}
+	}
+	blocks, err := parsers.ParseRequirementsContent(body)
+	if err != nil {
+		return Spec{}, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	spec.Requirements = requirements(blocks)
+
+	return spec, nil
+}
+
+// readChange reads a change directory. The ID is left to the caller.
+func readChange(dir string) (Change, error) {
+	var change Change
+	content, err := fileio.ReadString(filepath.Join(dir, "proposal.md"))
+	if err != nil {
+		return change, err
+	}
+	metadata, body, err := splitFrontmatter(content)
+	if err != nil {
+		return change, fmt.Errorf("proposal.md: %w", err)
+	}
+	change.Metadata = metadata
+	change.Proposal = parseProse(body)
+
+	design, err := fileio.ReadString(filepath.Join(dir, "design.md"))
+	switch {
+	case err == nil:
+		prose := parseProse(design)
+		change.Design = &prose
+	case !errors.Is(err, fs.ErrNotExist):
+		return change, err
+	}
+
+	if change.Deltas, err = readDeltas(filepath.Join(dir, "specs")); err != nil {
+		return change, err
+	}
+	if change.Tasks, change.Accepted, err = readTasks(dir); err != nil || !change.Accepted {
+		return change, err
+	}
+	change.TasksHeader, change.TasksNewline, err = readTasksFrame(filepath.Join(dir, "tasks.jsonc"))
+	if change.TasksHeader == parsers.TasksJSONHeader {
+		change.TasksHeader = ""
+	}
+
+	return change, err
+}
+
+// readDeltas reads the delta specs under specsDir, in path order.
+func readDeltas(specsDir string) ([]Delta, error) {
+	var deltas []Delta
+	err := fileio.WalkDir(specsDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() || entry.Name() != "spec.md" {
+			return err
+		}
+		plan, err := parsers.ParseDeltaSpec(path)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		rel, err := filepath.Rel(specsDir, filepath.Dir(path))
+		if err != nil {
+			return err
+		}
+		content, err := fileio.ReadString(path)
+		if err != nil {
+			return err
+		}
+		delta := Delta{
+			Spec:     filepath.ToSlash(rel),
+			Added:    requirements(plan.Added),
+			Modified: requirements(plan.Modified),
+			Removed:  plan.Removed,
+			Order:    deltaOrder(content),
+		}
+		for _, op := range plan.Renamed {
+			delta.Renamed = append(delta.Renamed, Rename(op))
+		}
+		deltas = append(deltas, delta)
+
+		return nil
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+
+	return deltas, err
+}
+
+// readTasks reads the tasks of a change from tasks.jsonc, with the tasks
+// of child files in place of their parent, or else from tasks.md.
+// accepted reports whether tasks.jsonc was read.
+func readTasks(dir string) (tasks []Task, accepted bool, err error) {
+	path := filepath.Join(dir, "tasks.jsonc")
+	file, err := parsers.ReadTasksJson(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		parsed, err := parsers.ParseTasksMarkdown(filepath.Join(dir, "tasks.md"))
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, false, nil
+		}
+
+		return convertTasks(parsed), false, err
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	for _, task := range file.Tasks {
+		if ref, ok := strings.CutPrefix(task.Children, "$ref:"); ok {
+			child, err := parsers.ReadTasksJson(filepath.Join(dir, filepath.FromSlash(ref)))
+			if err == nil {
+				tasks = append(tasks, convertTasks(child.Tasks)...)
+
+				continue
+			}
+			if !errors.Is(err, fs.ErrNotExist) {
+				return nil, true, err
+			}
+		}
+		tasks = append(tasks, convertTasks([]parsers.Task{task})...)
+	}
+
+	return tasks, true, nil
+}
+
+// readTasksFrame returns the text of a tasks.jsonc file before the line
+// that opens its JSON object, and whether the file ends with a newline.
+func readTasksFrame(path string) (header string, newline bool, err error) {
+	content, err := fileio.ReadString(path)
+	if err != nil {
+		return "", false, err
+	}
+	offset := 0
+	for line := range strings.SplitAfterSeq(content, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "{") {
+			break
+		}
+		offset += len(line)
+	}
+
+	return content[:offset], strings.HasSuffix(content, "\n"), nil
+}
+
+// deltaOrder returns the delta sections of a delta spec in file order, or
+// nil when they are in the order ADDED, MODIFIED, REMOVED, RENAMED.
+func deltaOrder(content string) []markdown.DeltaType {
+	var order []markdown.DeltaType
+	for line := range strings.SplitSeq(content, "\n") {
+		name, ok := markdown.MatchH2DeltaSection(strings.TrimSpace(line))
+		if deltaType := markdown.DeltaType(name); ok && !slices.Contains(order, deltaType) {
+			order = append(order, deltaType)
+		}
+	}
+	if slices.IsSortedFunc(order, func(a, b markdown.DeltaType) int {
+		return slices.Index(deltaTypes, a) - slices.Index(deltaTypes, b)
+	}) {
+		return nil
+	}
+
+	return order
+}
+
+// convertTasks converts parsed tasks, dropping child file references.
+func convertTasks(parsed []parsers.Task) []Task {
+	tasks := make([]Task, 0, len(parsed))
+	for _, task := range parsed {
+		tasks = append(tasks, Task{
+			ID:          task.ID,
+			Section:     task.Section,
+			Description: task.Description,
+			Status:      task.Status,
+			Covers:      task.Covers,
+			Estimate:    task.Estimate,
+			Assignee:    task.Assignee,
+		})
+	}
+
+	return tasks
+}
+
+// requirements converts parsed requirement blocks.
+func requirements(blocks []parsers.RequirementBlock) []Requirement {
+	reqs := make([]Requirement, 0, len(blocks))
+	for _, block := range blocks {
+		_, body, _ := strings.Cut(block.Raw, "\n")
+		req := Requirement{Name: block.Name}
+
+		var description []string
+		for line := range strings.SplitSeq(body, "\n") {
+			if _, ok := markdown.MatchScenarioHeader(strings.TrimSpace(line)); ok {
+				break
+			}
+			description = append(description, line)
+		}
+		req.Description = strings.TrimSpace(strings.Join(description, "\n"))
+
+		for _, block := range parsers.ParseScenarioBlocks(body) {
+			scenario := Scenario{Name: block.Name}
+			for _, step := range block.Steps {
+				text := step.Text
+				if len(step.Wrapped) > 0 {
+					first := strings.TrimSuffix(text, " "+strings.Join(step.Wrapped, " "))
+					text = strings.Join(append([]string{first}, step.Wrapped...), "\n")
+				}
+				scenario.Steps = append(scenario.Steps, Step{Keyword: step.Keyword, Text: text})
+			}
+			if block.Examples != nil && len(block.Examples.Header) > 0 {
+				scenario.Examples = &Examples{
+					Header: block.Examples.Header,
+					Rows:   block.Examples.Rows,
+				}
+			}
+			req.Scenarios = append(req.Scenarios, scenario)
+		}
+		reqs = append(reqs, req)
+	}
+
+	return reqs
+}
+
+// splitFrontmatter returns the YAML frontmatter of content as a map, and
+// the content after it.
+func splitFrontmatter(content string) (map[string]any, string, error) {
+	lines := strings.SplitAfter(content, "\n")
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != "---" {
+		return nil, content, nil
+	}
+	end := slices.IndexFunc(lines[1:], func(line string) bool {
+		return strings.TrimSpace(line) == "---"
+	})
+	if end < 0 {
+		return nil, content, errors.New("frontmatter not closed: missing closing '---'")
+	}
+	end++
+
+	var metadata map[string]any
+	if err := yaml.Unmarshal([]byte(strings.Join(lines[1:end], "")), &metadata); err != nil {
+		return nil, content, fmt.Errorf("invalid YAML in frontmatter: %w", err)
+	}
+
+	return metadata, strings.Join(lines[end+1:], ""), nil
+}
+
+// parseProse splits markdown into its # title and ## sections. Headings
+// inside code fences are body text.
+func parseProse(content string) Prose {
+	var prose Prose
+	var current *Section
+	var body []string
+	inFence, inSections := false, false
+	flush := func() {
+		if current != nil {
+			current.Body = strings.TrimSpace(strings.Join(body, "\n"))
+			if current.Title != "" || current.Body != "" {
+				prose.Sections = append(prose.Sections, *current)
+			}
+		}
+		body = nil
+	}
+
+	for line := range strings.SplitSeq(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+		}
+		if !inFence {
+			if title, ok := strings.CutPrefix(trimmed, "# "); ok && prose.Title == "" && !inSections {
+				prose.Title = strings.TrimSpace(title)
+
+				continue
+			}
+			if title, ok := markdown.MatchH2SectionHeader(trimmed); ok {
+				flush()
+				current = &Section{Title: strings.TrimSpace(title)}
+				inSections = true
+
+				continue
+			}
+		}
+		if current == nil {
+			current = &Section{}
+		}
+		body = append(body, line)
+	}
+	flush()
+
+	return prose
+}
@@ -0,0 +1,390 @@
+package yamldoc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/connerohnesorge/spectr/internal/domain"
+	"github.com/connerohnesorge/spectr/internal/markdown"
+	"github.com/connerohnesorge/spectr/internal/parsers"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+	"github.com/connerohnesorge/spectr/internal/txn"
+)
+
+// filePerm is the permission of imported files.
+const filePerm = 0o644
+
+// ImportOptions control Import.
+type ImportOptions struct {
+	// Force replaces existing files
+	Force bool
+}
+
+// ImportResult lists the files Import wrote.
+type ImportResult struct {
+	// Written holds paths relative to the project root, in write order
+	Written []string `json:"written"`
+}
+
+// file is a file to import, with a path relative to the project root.
+type file struct {
+	path    string
+	content string
+}
+
+// Import writes the specs and changes of doc into the project at
+// projectRoot as one transaction. Existing files are only replaced with
+// opts.Force; otherwise nothing is written. source names the document in
+// errors.
+func Import(
+	ctx context.Context,
+	projectRoot, source string,
+	doc *Document,
+	opts ImportOptions,
+) (*ImportResult, error) {
+	files, err := render(source, doc)
+	if err != nil {
+		return nil, err
+	}
+
+	if !opts.Force {
+		var existing []string
+		for _, f := range files {
+			if _, err := os.Stat(filepath.Join(projectRoot, f.path)); err == nil {
+				existing = append(existing, f.path)
+			}
+		}
+		if len(existing) > 0 {
+			return nil, &specterrs.ImportConflictError{Paths: existing}
+		}
+	}
+
+	tx := txn.New()
+	result := &ImportResult{}
+	for _, f := range files {
+		tx.WriteFile(filepath.Join(projectRoot, f.path), []byte(f.content), filePerm)
+		result.Written = append(result.Written, filepath.ToSlash(f.path))
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// render returns the files of doc.
+func render(source string, doc *Document) ([]file, error) {
+	var files []file
+	seen := make(map[string]bool)
+	add := func(rel, content string) error {
+		if seen[rel] {
+			return &specterrs.InvalidDocumentError{
+				Path:   source,
+				Reason: fmt.Sprintf("%s is written twice", filepath.ToSlash(rel)),
+			}
+		}
+		seen[rel] = true
+		files = append(files, file{path: rel, content: content})
+
+		return nil
+	}
+
+	for _, spec := range doc.Specs {
+		dir, err := itemDir(source, "spec", spec.ID)
+		if err != nil {
+			return nil, err
+		}
+		content, err := specMarkdown(spec)
+		if err != nil {
+			return nil, err
+		}
+		if err := add(filepath.Join("spectr", "specs", dir, "spec.md"), content); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, change := range doc.Changes {
+		dir, err := itemDir(source, "change", change.ID)
+		if err != nil {
+			return nil, err
+		}
+		changeFiles, err := changeMarkdown(source, change)
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range changeFiles {
+			if err := add(filepath.Join("spectr", "changes", dir, f.path), f.content); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return files, nil
+}
+
+// itemDir checks the ID of a spec, change or delta and returns it as a
+// relative directory.
+func itemDir(source, what, id string) (string, error) {
+	clean := path.Clean(strings.TrimSpace(id))
+	if id == "" || clean == "." || path.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, "../") {
+		return "", &specterrs.InvalidDocumentError{
+			Path:   source,
+			Reason: fmt.Sprintf("%s ID %q is not a relative path", what, id),
+		}
+	}
+
+	return filepath.FromSlash(clean), nil
+}
+
+// specMarkdown renders a spec.md file.
+func specMarkdown(spec Spec) (string, error) {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# %s\n", spec.Title)
+	if spec.Purpose != "" {
+		fmt.Fprintf(&sb, "\n## Purpose\n\n%s\n", spec.Purpose)
+	}
+	sb.WriteString("\n## Requirements\n")
+	for _, req := range spec.Requirements {
+		sb.WriteString("\n")
+		writeRequirement(&sb, req)
+	}
+
+	return withFrontmatter(spec.Metadata, sb.String())
+}
+
+// changeMarkdown renders the files of a change, with paths relative to the
+// change directory.
+func changeMarkdown(source string, change Change) ([]file, error) {
+	proposal, err := withFrontmatter(change.Metadata, proseMarkdown(change.Proposal))
+	if err != nil {
+		return nil, err
+	}
+	files := []file{{path: "proposal.md", content: proposal}}
+	if change.Design != nil {
+		files = append(files, file{path: "design.md", content: proseMarkdown(*change.Design)})
+	}
+
+	for _, delta := range change.Deltas {
+		dir, err := itemDir(source, "delta spec", delta.Spec)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file{
+			path:    filepath.Join("specs", dir, "spec.md"),
+			content: deltaMarkdown(delta),
+		})
+	}
+
+	if len(change.Tasks) == 0 {
+		return files, nil
+	}
+	files = append(files, file{path: "tasks.md", content: tasksMarkdown(change.Tasks)})
+	if change.Accepted {
+		content, err := tasksJSONC(change)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file{path: "tasks.jsonc", content: content})
+	}
+
+	return files, nil
+}
+
+// withFrontmatter prepends metadata as YAML frontmatter when there is any.
+func withFrontmatter(metadata map[string]any, body string) (string, error) {
+	if len(metadata) == 0 {
+		return body, nil
+	}
+
+	return domain.RenderFrontmatter(metadata, body)
+}
+
+// proseMarkdown renders a prose document.
+func proseMarkdown(prose Prose) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# %s\n", prose.Title)
+	for _, section := range prose.Sections {
+		if section.Title != "" {
+			fmt.Fprintf(&sb, "\n## %s\n", section.Title)
+		}
+		if section.Body != "" {
+			fmt.Fprintf(&sb, "\n%s\n", section.Body)
+		}
+	}
+
+	return sb.String()
+}
+
+// deltaMarkdown renders a delta spec.md file, with its sections in the
+// delta's Order.
+func deltaMarkdown(delta Delta) string {
+	var sb strings.Builder
+	writeSection := func(op markdown.DeltaType, reqs []Requirement) {
+		if len(reqs) == 0 {
+			return
+		}
+		if sb.Len() > 0 {
+			sb.WriteString("\n")
+		}
+		fmt.Fprintf(&sb, "## %s\n", markdown.DeltaSectionTitle(op))
+		for _, req := range reqs {
+			sb.WriteString("\n")
+			writeRequirement(&sb, req)
+		}
+	}
+	writeRenamed := func() {
+		if len(delta.Renamed) == 0 {
+			return
+		}
+		if sb.Len() > 0 {
+			sb.WriteString("\n")
+		}
+		fmt.Fprintf(&sb, "## %s\n\n", markdown.DeltaSectionTitle(markdown.DeltaRenamed))
+		for _, rename := range delta.Renamed {
+			fmt.Fprintf(&sb, "- FROM: `### Requirement: %s`\n", rename.From)
+			fmt.Fprintf(&sb, "- TO: `### Requirement: %s`\n", rename.To)
+		}
+	}
+
+	removed := make([]Requirement, 0, len(delta.Removed))
+	for _, name := range delta.Removed {
+		removed = append(removed, Requirement{Name: name})
+	}
+	order := slices.Clone(delta.Order)
+	for _, op := range deltaTypes {
+		if !slices.Contains(order, op) {
+			order = append(order, op)
+		}
+	}
+	for _, op := range order {
+		switch op {
+		case markdown.DeltaAdded:
+			writeSection(op, delta.Added)
+		case markdown.DeltaModified:
+			writeSection(op, delta.Modified)
+		case markdown.DeltaRemoved:
+			writeSection(op, removed)
+		case markdown.DeltaRenamed:
+			writeRenamed()
+		}
+	}
+
+	return sb.String()
+}
+
+// writeRequirement renders a requirement with its scenarios.
+func writeRequirement(sb *strings.Builder, req Requirement) {
+	fmt.Fprintf(sb, "### Requirement: %s\n", req.Name)
+	if req.Description != "" {
+		fmt.Fprintf(sb, "%s\n", req.Description)
+	}
+	for _, scenario := range req.Scenarios {
+		fmt.Fprintf(sb, "\n#### Scenario: %s\n", scenario.Name)
+		for _, step := range scenario.Steps {
+			first, wrapped, _ := strings.Cut(step.Text, "\n")
+			fmt.Fprintf(sb, "- **%s** %s\n", step.Keyword, first)
+			for line := range strings.SplitSeq(wrapped, "\n") {
+				if line != "" {
+					fmt.Fprintf(sb, "  %s\n", line)
+				}
+			}
+		}
+		if scenario.Examples == nil {
+			continue
+		}
+		sb.WriteString("\n**Examples:**\n\n")
+		writeTableRow(sb, scenario.Examples.Header)
+		separator := make([]string, len(scenario.Examples.Header))
+		for i := range separator {
+			separator[i] = "---"
+		}
+		writeTableRow(sb, separator)
+		for _, row := range scenario.Examples.Rows {
+			writeTableRow(sb, row)
+		}
+	}
+}
+
+// writeTableRow writes a pipe table row, escaping pipes in cells.
+func writeTableRow(sb *strings.Builder, cells []string) {
+	sb.WriteString("|")
+	for _, cell := range cells {
+		fmt.Fprintf(sb, " %s |", strings.ReplaceAll(cell, "|", "\\|"))
+	}
+	sb.WriteString("\n")
+}
+
+// tasksMarkdown renders tasks.md, one numbered section per task section.
+// In-progress tasks are unchecked, as tasks.md has no such state.
+func tasksMarkdown(tasks []Task) string {
+	var sb strings.Builder
+	section, number := "", 0
+	for i, task := range tasks {
+		if i == 0 || task.Section != section {
+			section = task.Section
+			number++
+			if i > 0 {
+				sb.WriteString("\n")
+			}
+			title := section
+			if title == "" {
+				title = "Tasks"
+			}
+			fmt.Fprintf(&sb, "## %d. %s\n\n", number, title)
+		}
+		check := " "
+		if task.Status == parsers.TaskStatusCompleted {
+			check = "x"
+		}
+		fmt.Fprintf(&sb, "- [%s] %s %s", check, task.ID, task.Description)
+		if task.Estimate != "" {
+			fmt.Fprintf(&sb, " (estimate: %s)", task.Estimate)
+		}
+		if task.Assignee != "" {
+			fmt.Fprintf(&sb, " (assignee: %s)", task.Assignee)
+		}
+		if len(task.Covers) > 0 {
+			fmt.Fprintf(&sb, " (covers: %s)", strings.Join(task.Covers, ", "))
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// tasksJSONC renders a version 1 tasks.jsonc file behind the change's
+// tasks header.
+func tasksJSONC(change Change) (string, error) {
+	file := parsers.TasksFile{Version: 1}
+	for _, task := range change.Tasks {
+		file.Tasks = append(file.Tasks, parsers.Task{
+			ID:          task.ID,
+			Section:     task.Section,
+			Description: task.Description,
+			Status:      task.Status,
+			Covers:      task.Covers,
+			Estimate:    task.Estimate,
+			Assignee:    task.Assignee,
+		})
+	}
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal tasks to JSON: %w", err)
+	}
+	header := change.TasksHeader
+	if header == "" {
+		header = parsers.TasksJSONHeader
+	}
+
+	if change.TasksNewline {
+		data = append(data, '\n')
+	}
+
+	return header + string(data), nil
+}
@@ -0,0 +1,347 @@
+package yamldoc
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/connerohnesorge/spectr/internal/specterrs"
+)
+
+const testSpec = `---
+owner: auth-team
+---
+# Auth
+
+## Purpose
+
+Log users in and out.
+
+## Requirements
+
+### Requirement: Login
+The system SHALL log users in.
+
+#### Scenario: Login as <role>
+- **GIVEN** a <role> account
+- **WHEN** the user logs in
+- **THEN** they see the <page> page
+
+**Examples:**
+
+| role  | page      |
+| ----- | --------- |
+| admin | dashboard |
+| guest | home      |
+
+### Requirement: Logout
+The system SHALL log users out.
+
+#### Scenario: Logout
+- **WHEN** the user logs out
+- **THEN** the session ends
+`
+
+const testProposal = `# Change: Add sessions
+
+## Why
+
+Users stay logged in.
+
+## What Changes
+
+- Add session handling
+`
+
+const testDelta = `## ADDED Requirements
+
+### Requirement: Session
+The system SHALL keep sessions.
+
+#### Scenario: Resume
+- **WHEN** the user returns
+- **THEN** the session resumes
+
+## MODIFIED Requirements
+
+### Requirement: Logout
+The system SHALL log users out and end their session.
+
+#### Scenario: Logout
+- **WHEN** the user logs out
+- **THEN** the session ends
+
+## REMOVED Requirements
+
+### Requirement: Remember me
+
+## RENAMED Requirements
+
+- FROM: ` + "`### Requirement: Login`" + `
+- TO: ` + "`### Requirement: Sign in`" + `
+`
+
+const testTasks = `## 1. Sessions
+
+- [x] 1.1 Add session store (estimate: 4h)
+- [ ] 1.2 Expire sessions (assignee: alice)
+`
+
+// writeProject writes files, keyed by path relative to root.
+func writeProject(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for rel, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func testProject(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	writeProject(t, root, map[string]string{
+		"spectr/specs/auth/spec.md":                         testSpec,
+		"spectr/changes/add-sessions/proposal.md":           testProposal,
+		"spectr/changes/add-sessions/specs/auth/spec.md":    testDelta,
+		"spectr/changes/add-sessions/tasks.md":              testTasks,
+		"spectr/changes/archive/2026-01-01-old/proposal.md": "# Change: Old\n",
+	})
+
+	return root
+}
+
+func TestBuild(t *testing.T) {
+	doc, err := Build(testProject(t), Options{Changes: true})
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+
+	if len(doc.Specs) != 1 || len(doc.Changes) != 1 {
+		t.Fatalf("got %d specs and %d changes, want 1 and 1", len(doc.Specs), len(doc.Changes))
+	}
+
+	spec := doc.Specs[0]
+	if spec.ID != "auth" || spec.Title != "Auth" || spec.Purpose != "Log users in and out." {
+		t.Errorf("unexpected spec %q %q %q", spec.ID, spec.Title, spec.Purpose)
+	}
+	if spec.Metadata["owner"] != "auth-team" {
+		t.Errorf("Metadata = %v", spec.Metadata)
+	}
+	if len(spec.Requirements) != 2 {
+		t.Fatalf("got %d requirements, want 2", len(spec.Requirements))
+	}
+	login := spec.Requirements[0]
+	if login.Description != "The system SHALL log users in." || len(login.Scenarios) != 1 {
+		t.Errorf("unexpected requirement %+v", login)
+	}
+	wantExamples := &Examples{
+		Header: []string{"role", "page"},
+		Rows:   [][]string{{"admin", "dashboard"}, {"guest", "home"}},
+	}
+	if !reflect.DeepEqual(login.Scenarios[0].Examples, wantExamples) {
+		t.Errorf("Examples = %+v", login.Scenarios[0].Examples)
+	}
+
+	change := doc.Changes[0]
+	if change.ID != "add-sessions" || change.Accepted || change.Design != nil {
+		t.Errorf("unexpected change %q accepted=%v", change.ID, change.Accepted)
+	}
+	if len(change.Deltas) != 1 {
+		t.Fatalf("got %d deltas, want 1", len(change.Deltas))
+	}
+	delta := change.Deltas[0]
+	if delta.Spec != "auth" || len(delta.Added) != 1 || len(delta.Modified) != 1 {
+		t.Errorf("unexpected delta %+v", delta)
+	}
+	if !reflect.DeepEqual(delta.Removed, []string{"Remember me"}) {
+		t.Errorf("Removed = %q", delta.Removed)
+	}
+	if !reflect.DeepEqual(delta.Renamed, []Rename{{From: "Login", To: "Sign in"}}) {
+		t.Errorf("Renamed = %+v", delta.Renamed)
+	}
+	if len(change.Tasks) != 2 || change.Tasks[0].Estimate != "4h" ||
+		change.Tasks[1].Assignee != "alice" {
+		t.Errorf("Tasks = %+v", change.Tasks)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	doc, err := Build(testProject(t), Options{Changes: true})
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	data, err := Encode(doc)
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	decoded, err := Decode("project.yaml", data)
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+
+	target := t.TempDir()
+	result, err := Import(context.Background(), target, "project.yaml", decoded, ImportOptions{})
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	wantWritten := []string{
+		"spectr/specs/auth/spec.md",
+		"spectr/changes/add-sessions/proposal.md",
+		"spectr/changes/add-sessions/specs/auth/spec.md",
+		"spectr/changes/add-sessions/tasks.md",
+	}
+	if !reflect.DeepEqual(result.Written, wantWritten) {
+		t.Errorf("Written = %q\nwant %q", result.Written, wantWritten)
+	}
+
+	again, err := Build(target, Options{Changes: true})
+	if err != nil {
+		t.Fatalf("Build of imported project: %v", err)
+	}
+	if !reflect.DeepEqual(again, doc) {
+		t.Errorf("round trip changed the document\ngot  %+v\nwant %+v", again, doc)
+	}
+}
+
+func TestRoundTrip_KeepsLayout(t *testing.T) {
+	delta := `## MODIFIED Requirements
+
+### Requirement: Logout
+The system SHALL log users out.
+
+#### Scenario: Logout
+- **WHEN** the user logs out
+- **THEN** the session ends
+  and the cookie is cleared
+
+## ADDED Requirements
+
+### Requirement: Session
+The system SHALL keep sessions.
+`
+	tasksJSON := `// Tasks of add-sessions
+
+{
+  "version": 1,
+  "tasks": [
+    {
+      "id": "1.1",
+      "section": "Sessions",
+      "description": "Add session store",
+      "status": "in_progress"
+    }
+  ]
+}
+`
+	root := t.TempDir()
+	writeProject(t, root, map[string]string{
+		"spectr/changes/add-sessions/proposal.md":        testProposal,
+		"spectr/changes/add-sessions/specs/auth/spec.md": delta,
+		"spectr/changes/add-sessions/tasks.jsonc":        tasksJSON,
+	})
+
+	doc, err := Build(root, Options{Changes: true})
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	data, err := Encode(doc)
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	decoded, err := Decode("project.yaml", data)
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	target := t.TempDir()
+	if _, err := Import(context.Background(), target, "project.yaml", decoded, ImportOptions{}); err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+
+	for rel, want := range map[string]string{
+		"spectr/changes/add-sessions/proposal.md":        testProposal,
+		"spectr/changes/add-sessions/specs/auth/spec.md": delta,
+		"spectr/changes/add-sessions/tasks.jsonc":        tasksJSON,
+	} {
+		got, err := os.ReadFile(filepath.Join(target, filepath.FromSlash(rel)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != want {
+			t.Errorf("%s = %q\nwant %q", rel, got, want)
+		}
+	}
+}
+
+func TestImport_Conflict(t *testing.T) {
+	root := testProject(t)
+	doc, err := Build(root, Options{})
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	doc.Specs[0].Purpose = "Replaced."
+
+	_, err = Import(context.Background(), root, "project.yaml", doc, ImportOptions{})
+	var conflict *specterrs.ImportConflictError
+	if !errors.As(err, &conflict) {
+		t.Fatalf("expected ImportConflictError, got %v", err)
+	}
+	if !reflect.DeepEqual(conflict.Paths, []string{"spectr/specs/auth/spec.md"}) {
+		t.Errorf("Paths = %q", conflict.Paths)
+	}
+
+	if _, err := Import(context.Background(), root, "project.yaml", doc, ImportOptions{Force: true}); err != nil {
+		t.Fatalf("Import with Force: %v", err)
+	}
+	replaced, err := Build(root, Options{})
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	if replaced.Specs[0].Purpose != "Replaced." {
+		t.Errorf("Purpose = %q, want the imported one", replaced.Specs[0].Purpose)
+	}
+}
+
+func TestImport_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  Document
+	}{
+		{"empty ID", Document{Specs: []Spec{{Title: "X"}}}},
+		{"escaping ID", Document{Specs: []Spec{{ID: "../x", Title: "X"}}}},
+		{"absolute delta", Document{Changes: []Change{{
+			ID:     "c",
+			Deltas: []Delta{{Spec: "/etc"}},
+		}}}},
+		{"duplicate spec", Document{Specs: []Spec{{ID: "a"}, {ID: "a/"}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := t.TempDir()
+			_, err := Import(context.Background(), target, "project.yaml", &tt.doc, ImportOptions{})
+			var invalid *specterrs.InvalidDocumentError
+			if !errors.As(err, &invalid) {
+				t.Fatalf("expected InvalidDocumentError, got %v", err)
+			}
+			if entries, _ := os.ReadDir(target); len(entries) != 0 {
+				t.Errorf("invalid import wrote %d entries", len(entries))
+			}
+		})
+	}
+}
+
+func TestDecode_Version(t *testing.T) {
+	_, err := Decode("project.yaml", []byte("version: 99\n"))
+	var version *specterrs.UnsupportedDocumentVersionError
+	if !errors.As(err, &version) || version.Version != 99 {
+		t.Fatalf("expected UnsupportedDocumentVersionError, got %v", err)
+	}
+}
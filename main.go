@@ -4,10 +4,12 @@ Copyright © 2025 Conner Ohnesorge
 package main
 
 import (
+	"errors"
 	"os"
 
 	"github.com/alecthomas/kong"
 	"github.com/connerohnesorge/spectr/cmd"
+	"github.com/connerohnesorge/spectr/internal/specterrs"
 	kongcompletion "github.com/jotaen/kong-completion"
 )
 
@@ -44,10 +46,23 @@ func main() {
 			"item",
 			cmd.PredictItems(),
 		),
+		kongcompletion.WithPredictor(
+			"taskID",
+			cmd.PredictTaskIDs(),
+		),
 	)
 
 	ctx, err := app.Parse(os.Args[1:])
+	if err != nil {
+		cli.Cleanup()
+	}
+	// Usage errors exit 1 (see the Exit Codes table in the README)
+	var parseErr *kong.ParseError
+	if errors.As(err, &parseErr) {
+		err = &specterrs.UsageError{Err: err}
+	}
 	app.FatalIfErrorf(err)
 	err = ctx.Run()
+	cli.Cleanup()
 	app.FatalIfErrorf(err)
 }